@@ -18,6 +18,12 @@ import (
 
 const batchSize = 100
 
+// backfillDocument is a parsed document with its tenant's time zone.
+type backfillDocument struct {
+	domain.Document
+	TimeZone string `db:"time_zone"`
+}
+
 func main() {
 	if err := run(); err != nil {
 		log.Fatal(err)
@@ -43,13 +49,15 @@ func run() error {
 	total := 0
 
 	for {
-		var docs []domain.Document
+		var docs []backfillDocument
 		err := db.SelectContext(ctx, &docs,
-			`SELECT id, tenant_id, collection_id, structured_data,
-				parsing_status, review_status, validation_status, reconciliation_status
-			 FROM documents
-			 WHERE parsing_status = 'completed' AND structured_data IS NOT NULL
-			 ORDER BY created_at
+			`SELECT d.id, d.tenant_id, d.collection_id, d.structured_data,
+				d.parsing_status, d.review_status, d.validation_status, d.reconciliation_status,
+				t.time_zone
+			 FROM documents d
+			 JOIN tenants t ON t.id = d.tenant_id
+			 WHERE d.parsing_status = 'completed' AND d.structured_data IS NOT NULL
+			 ORDER BY d.created_at
 			 LIMIT $1 OFFSET $2`, batchSize, offset)
 		if err != nil {
 			return fmt.Errorf("querying documents at offset %d: %w", offset, err)
@@ -101,8 +109,9 @@ func run() error {
 			}
 
 			// Parse invoice date and due date
-			summary.InvoiceDate = parseInvoiceDate(inv.Invoice.InvoiceDate)
-			summary.DueDate = parseInvoiceDate(inv.Invoice.DueDate)
+			loc := domain.LoadTimeZone(doc.TimeZone)
+			summary.InvoiceDate = parseInvoiceDate(inv.Invoice.InvoiceDate, loc)
+			summary.DueDate = parseInvoiceDate(inv.Invoice.DueDate, loc)
 
 			// Collect distinct HSN codes
 			hsnSet := make(map[string]struct{})
@@ -135,8 +144,9 @@ func run() error {
 	return nil
 }
 
-// parseInvoiceDate attempts multiple date formats from LLM output.
-func parseInvoiceDate(s string) *time.Time {
+// parseInvoiceDate attempts multiple date formats from LLM output. Timestamps
+// are dated in the tenant's time zone, as in the document service.
+func parseInvoiceDate(s string, loc *time.Location) *time.Time {
 	if s == "" {
 		return nil
 	}
@@ -154,5 +164,19 @@ func parseInvoiceDate(s string) *time.Time {
 			return &t
 		}
 	}
+	timestamps := []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"02/01/2006 15:04:05",
+		"02-01-2006 15:04:05",
+	}
+	for _, f := range timestamps {
+		if t, err := time.ParseInLocation(f, s, loc); err == nil {
+			y, m, d := t.In(loc).Date()
+			day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+			return &day
+		}
+	}
 	return nil
 }
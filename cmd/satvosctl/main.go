@@ -0,0 +1,168 @@
+// Command satvosctl runs routine administration tasks against a SATVOS server's
+// API, authenticated with an API key (POST /api/v1/users/me/api-keys as an admin).
+// It prints the response data as JSON and exits non-zero on an API error.
+// The server and key come from -url and -api-key, or SATVOS_URL and SATVOS_API_KEY.
+//
+// Usage:
+//
+//	satvosctl [-url <url>] [-api-key <key>] <command>
+//
+// Commands:
+//
+//	tenant create -name <name> -slug <slug>
+//	tenant suspend <tenant-id>
+//	user role set <user-id> <role>
+//	document requeue <document-id>
+//	export run <tenant-id>
+//	quota set <user-id> <monthly-limit>     (0 is unlimited)
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+const usage = `usage: satvosctl [-url <url>] [-api-key <key>] <command>
+
+commands:
+  tenant create -name <name> -slug <slug>
+  tenant suspend <tenant-id>
+  user role set <user-id> <role>
+  document requeue <document-id>
+  export run <tenant-id>
+  quota set <user-id> <monthly-limit>     (0 is unlimited)
+`
+
+// errUsage reports a malformed command line; usage is printed instead of the error.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "satvosctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("satvosctl", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	baseURL := fs.String("url", envOr("SATVOS_URL", "http://localhost:8080"), "server base URL")
+	apiKey := fs.String("api-key", os.Getenv("SATVOS_API_KEY"), "API key")
+	timeout := fs.Duration("timeout", 30*time.Second, "request timeout")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if *apiKey == "" {
+		return errors.New("an API key is required: set -api-key or SATVOS_API_KEY")
+	}
+
+	c := &client{baseURL: *baseURL, apiKey: *apiKey, http: &http.Client{Timeout: *timeout}}
+	ctx := context.Background()
+	data, err := dispatch(ctx, c, fs.Args())
+	if err != nil {
+		return err
+	}
+	return printJSON(out, data)
+}
+
+// dispatch runs one command and returns the response data.
+func dispatch(ctx context.Context, c *client, args []string) ([]byte, error) {
+	if len(args) < 2 {
+		return nil, errUsage
+	}
+	switch args[0] + " " + args[1] {
+	case "tenant create":
+		fs := flag.NewFlagSet("tenant create", flag.ContinueOnError)
+		name := fs.String("name", "", "tenant name")
+		slug := fs.String("slug", "", "tenant slug, used to log in")
+		if err := fs.Parse(args[2:]); err != nil || *name == "" || *slug == "" || fs.NArg() != 0 {
+			return nil, errUsage
+		}
+		return c.do(ctx, http.MethodPost, "/api/v1/admin/tenants", map[string]string{"name": *name, "slug": *slug})
+
+	case "tenant suspend":
+		id, err := idArg(args[2:])
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, http.MethodPut, "/api/v1/admin/tenants/"+id, map[string]bool{"is_active": false})
+
+	case "user role":
+		// user role set <user-id> <role>
+		if len(args) != 5 || args[2] != "set" {
+			return nil, errUsage
+		}
+		id, err := idArg(args[3:4])
+		if err != nil {
+			return nil, err
+		}
+		role := domain.UserRole(args[4])
+		if !domain.ValidUserRoles[role] {
+			return nil, fmt.Errorf("unknown role %q", args[4])
+		}
+		return c.do(ctx, http.MethodPut, "/api/v1/users/"+id, map[string]domain.UserRole{"role": role})
+
+	case "document requeue":
+		id, err := idArg(args[2:])
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, http.MethodPost, "/api/v1/documents/"+id+"/retry", nil)
+
+	case "export run":
+		id, err := idArg(args[2:])
+		if err != nil {
+			return nil, err
+		}
+		return c.do(ctx, http.MethodPost, "/api/v1/admin/tenants/"+id+"/analytics-export/run", nil)
+
+	case "quota set":
+		if len(args) != 4 {
+			return nil, errUsage
+		}
+		id, err := idArg(args[2:3])
+		if err != nil {
+			return nil, err
+		}
+		limit, err := strconv.Atoi(args[3])
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("monthly limit must be a whole number, 0 for unlimited: %q", args[3])
+		}
+		return c.do(ctx, http.MethodPut, "/api/v1/users/"+id, map[string]int{"monthly_document_limit": limit})
+	}
+	return nil, errUsage
+}
+
+// idArg returns the only argument, which must be a UUID.
+func idArg(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errUsage
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return "", fmt.Errorf("invalid ID %q", args[0])
+	}
+	return id.String(), nil
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -15,20 +16,33 @@ import (
 	"satvos/internal/domain"
 	"satvos/internal/email/noop"
 	"satvos/internal/email/ses"
+	"satvos/internal/faults"
 	"satvos/internal/handler"
+	"satvos/internal/httpclient"
+	"satvos/internal/middleware"
+	"satvos/internal/notify"
+	"satvos/internal/pagerender/pdftoppm"
 	"satvos/internal/parser"
 	claudeparser "satvos/internal/parser/claude"
+	fakeparser "satvos/internal/parser/fake"
 	geminiparser "satvos/internal/parser/gemini"
 	openaiparser "satvos/internal/parser/openai"
+	tesseractparser "satvos/internal/parser/tesseract"
+	textractparser "satvos/internal/parser/textract"
 	"satvos/internal/port"
 	"satvos/internal/repository/postgres"
 	"satvos/internal/router"
 	"satvos/internal/service"
+	"satvos/internal/shard"
 	s3storage "satvos/internal/storage/s3"
 	"satvos/internal/validator"
 	"satvos/internal/validator/invoice"
 
+	"satvos/internal/alert"
 	googleauth "satvos/internal/auth/google"
+	"satvos/internal/cloud"
+	"satvos/internal/cloud/dropbox"
+	"satvos/internal/cloud/gdrive"
 
 	_ "satvos/docs" // swagger docs
 )
@@ -76,40 +90,181 @@ func run() error {
 	}
 	defer func() { _ = db.Close() }()
 
+	// Shard pools; no repository is routed through them yet, so only readiness uses them
+	if _, ok := cfg.DB.Shards[shard.PrimaryName(cfg.DB.Cluster)]; ok {
+		return fmt.Errorf("SATVOS_DB_SHARDS must not name the primary cluster %q", shard.PrimaryName(cfg.DB.Cluster))
+	}
+	shardDBs, closeShards, err := postgres.OpenShards(cfg.DB.Shards, cfg.DB.ShardMaxOpen, cfg.DB.ShardMaxIdle)
+	if err != nil {
+		return fmt.Errorf("failed to open database shards: %w", err)
+	}
+	defer closeShards()
+
+	// Fault injection wraps storage, the parsers and the document repository; never in production
+	var faultInjector *faults.Injector
+	if cfg.Faults.Enabled {
+		if cfg.Server.Environment == "production" {
+			log.Println("WARNING: SATVOS_FAULTS_ENABLED is ignored in production")
+		} else {
+			faultInjector = faults.NewInjector()
+			log.Println("Fault injection enabled: scenarios can be set via /api/v1/admin/faults")
+		}
+	}
+
 	// Initialize repositories
 	tenantRepo := postgres.NewTenantRepo(db)
 	userRepo := postgres.NewUserRepo(db)
+	membershipRepo := postgres.NewTenantMembershipRepo(db)
 	fileRepo := postgres.NewFileMetaRepo(db)
 	collectionRepo := postgres.NewCollectionRepo(db)
 	collectionPermRepo := postgres.NewCollectionPermissionRepo(db)
 	collectionFileRepo := postgres.NewCollectionFileRepo(db)
+	delegationRepo := postgres.NewReviewDelegationRepo(db)
+	rejectionReasonRepo := postgres.NewRejectionReasonRepo(db)
+	computedFieldRepo := postgres.NewComputedFieldRepo(db)
 
 	// Initialize storage
 	s3Client, err := s3storage.NewS3Client(&cfg.S3)
 	if err != nil {
 		return fmt.Errorf("failed to initialize S3 client: %w", err)
 	}
+	if cfg.S3.StrictCompliance {
+		if err := s3storage.CheckBucketCompliance(context.Background(), &cfg.S3); err != nil {
+			return fmt.Errorf("S3 strict compliance check failed: %w", err)
+		}
+		log.Println("S3 strict compliance: every bucket has default encryption and versioning")
+	}
+	if faultInjector != nil {
+		s3Client = faults.NewStorage(s3Client, faultInjector)
+	}
+	// Tenant objects are written with the tenant's server-side encryption
+	s3Client = service.NewTenantEncryptingStorage(s3Client, tenantRepo)
 
 	// Initialize document repositories
 	docRepo := postgres.NewDocumentRepo(db)
+	if faultInjector != nil {
+		docRepo = faults.NewDocumentRepo(docRepo, faultInjector)
+	}
 	documentTagRepo := postgres.NewDocumentTagRepo(db)
 	auditRepo := postgres.NewDocumentAuditRepo(db)
 	summaryRepo := postgres.NewDocumentSummaryRepo(db)
+	overrideRepo := postgres.NewDocumentFieldOverrideRepo(db)
+	flagRepo := postgres.NewFeatureFlagRepo(db)
+	importJobRepo := postgres.NewImportJobRepo(db)
+	cloudConnRepo := postgres.NewCloudConnectionRepo(db)
+	cloudSyncRepo := postgres.NewCloudSyncRepo(db)
+	feedRepo := postgres.NewFeedIngestionRepo(db)
 	validationRuleRepo := postgres.NewDocumentValidationRuleRepo(db)
 	statsRepo := postgres.NewStatsRepo(db)
+	analyticsExportRepo := postgres.NewAnalyticsExportRepo(db)
+
+	// Dashboard counters are materialized; writes outside the document service's events mark their bucket here
+	statsRefresher := service.NewStatsRefresher(statsRepo, tenantRepo, service.StatsRefreshConfig{
+		Interval:         time.Duration(cfg.Stats.RefreshIntervalSecs) * time.Second,
+		ReconcileHourUTC: cfg.Stats.ReconcileHourUTC,
+	})
+	docRepo = service.NewStatsTrackingDocumentRepo(docRepo, statsRefresher)
+
+	// Every validation result and approval feeds the long-term rule failure metrics
+	ruleOutcomeRepo := postgres.NewValidationRuleOutcomeRepo(db)
+	docRepo = service.NewRuleOutcomeTrackingDocumentRepo(docRepo, ruleOutcomeRepo)
+
+	// Approvals are pushed to no-code REST hooks (Zapier, Make) by a document.reviewed listener
+	integrationsHTTP, err := httpclient.New(cfg.Integrations.HTTP)
+	if err != nil {
+		return fmt.Errorf("failed to create integrations http client: %w", err)
+	}
+	integrationsHTTP.Timeout = 10 * time.Second
+	integrationSvc := service.NewIntegrationService(postgres.NewIntegrationRepo(db), userRepo, collectionPermRepo,
+		collectionRepo, integrationsHTTP, cfg.Integrations.HTTP.AllowedHosts)
+
+	// Cloud OAuth tokens and chat webhook URLs are sealed at rest
+	tokenKey := cfg.CloudImport.TokenKey
+	if tokenKey == "" {
+		tokenKey = cfg.JWT.Secret
+	}
+	tokenSealer, err := cloud.NewTokenSealer(tokenKey)
+	if err != nil {
+		return fmt.Errorf("failed to initialize cloud token encryption: %w", err)
+	}
+
+	// Parse failures, assignments, review SLA breaches, escalations and weekly summaries go to Slack / Teams
+	notificationsHTTP, err := httpclient.New(cfg.Notifications.HTTP)
+	if err != nil {
+		return fmt.Errorf("failed to create notifications http client: %w", err)
+	}
+	notificationsHTTP.Timeout = 10 * time.Second
+	notificationSvc := service.NewNotificationService(postgres.NewNotificationChannelRepo(db), collectionRepo,
+		collectionPermRepo, userRepo, tokenSealer, notificationsHTTP)
+	docRepo = service.NewChannelNotifyingDocumentRepo(docRepo, notificationSvc)
+
+	// A share of each collection's approvals is sampled for blind second-opinion QA
+	qaReviewRepo := postgres.NewQAReviewRepo(db)
+	docRepo = service.NewQASamplingDocumentRepo(docRepo, qaReviewRepo, collectionRepo)
+
+	bulkTagJobRepo := postgres.NewBulkTagJobRepo(db)
+	bulkDeleteRepo := postgres.NewBulkDeleteRepo(db)
+	validationRunRepo := postgres.NewValidationRunRepo(db)
+	starRepo := postgres.NewStarRepo(db)
 	hsnRepo := postgres.NewHSNRepo(db)
 	duplicateFinder := postgres.NewDuplicateFinderRepo(db)
-
-	// Register parser providers
+	parseTimingRepo := postgres.NewParseTimingRepo(db)
+	confidenceObservationRepo := postgres.NewConfidenceObservationRepo(db)
+	authzDenialRepo := postgres.NewAuthzDenialRepo(db)
+	collectionEventRepo := postgres.NewCollectionEventRepo(db)
+	parseBudgetRepo := postgres.NewParseBudgetRepo(db)
+	exportArtifactRepo := postgres.NewExportArtifactRepo(db)
+	notificationRouteRepo := postgres.NewNotificationRouteRepo(db)
+	corsOriginRepo := postgres.NewTenantCORSOriginRepo(db)
+	inAppNotificationRepo := postgres.NewInAppNotificationRepo(db)
+	accountSecurityRepo := postgres.NewAccountSecurityRepo(db)
+
+	// Register parser providers; Anthropic and OpenAI report rate limit headroom for queue pacing
+	rateLimits := parser.NewRateLimitTracker()
 	parser.RegisterProvider("claude", func(provCfg *config.ParserProviderConfig) (port.DocumentParser, error) {
-		return claudeparser.NewParser(provCfg), nil
+		p, err := claudeparser.NewParser(provCfg)
+		if err != nil {
+			return nil, err
+		}
+		p.TrackRateLimits(rateLimits)
+		return p, nil
 	})
 	parser.RegisterProvider("gemini", func(provCfg *config.ParserProviderConfig) (port.DocumentParser, error) {
-		return geminiparser.NewParser(provCfg), nil
+		p, err := geminiparser.NewParser(provCfg)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
 	})
 	parser.RegisterProvider("openai", func(provCfg *config.ParserProviderConfig) (port.DocumentParser, error) {
-		return openaiparser.NewParser(provCfg), nil
+		p, err := openaiparser.NewParser(provCfg)
+		if err != nil {
+			return nil, err
+		}
+		p.TrackRateLimits(rateLimits)
+		return p, nil
+	})
+	parser.RegisterProvider("local", func(provCfg *config.ParserProviderConfig) (port.DocumentParser, error) {
+		p, err := openaiparser.NewLocalParser(provCfg)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	})
+	// Textract authenticates with the AWS credential chain, in the storage region unless configured
+	parser.RegisterProvider("textract", func(provCfg *config.ParserProviderConfig) (port.DocumentParser, error) {
+		p, err := textractparser.NewParser(provCfg, cfg.S3.Region)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
 	})
+	// The fake provider answers from the file hash without network calls; never in production
+	if cfg.Server.Environment != "production" {
+		parser.RegisterProvider("fake", func(_ *config.ParserProviderConfig) (port.DocumentParser, error) {
+			return fakeparser.NewParser(cfg.Parser.Fake), nil
+		})
+	}
 
 	// Initialize primary parser
 	primaryCfg := cfg.Parser.PrimaryConfig()
@@ -141,18 +296,51 @@ func run() error {
 		}
 	}
 
+	// Faults are injected per provider so the fallback chain sees each one fail
+	if faultInjector != nil {
+		primaryParser = faults.NewParser(primaryParser, faultInjector)
+		if secondaryParser != nil {
+			secondaryParser = faults.NewParser(secondaryParser, faultInjector)
+		}
+		if tertiaryParser != nil {
+			tertiaryParser = faults.NewParser(tertiaryParser, faultInjector)
+		}
+	}
+
+	// Offline OCR, tried once every provider has failed or is rate limited
+	var ocrParser port.DocumentParser
+	if cfg.Parser.OCR.Enabled {
+		ocrParser = tesseractparser.NewParser(cfg.Parser.OCR, pdftoppm.NewRenderer(cfg.PageImage.RendererPath))
+		log.Printf("Tesseract OCR fallback parser enabled (languages=%s)", cfg.Parser.OCR.Languages)
+	}
+
 	// Wrap single-parse path in FallbackParser if extra parsers are available
-	documentParser := buildFallbackParser(primaryParser, primaryCfg.Provider, secondaryParser, secondaryCfg, tertiaryParser, tertiaryCfg)
+	documentParser := buildFallbackParser(primaryParser, primaryCfg.Provider, secondaryParser, secondaryCfg, tertiaryParser, tertiaryCfg, ocrParser)
 
 	// Initialize optional merge parser for dual-parse mode
 	var mergeDocParser port.DocumentParser
 	if secondaryParser != nil {
-		primarySide := buildFallbackParser(primaryParser, primaryCfg.Provider, tertiaryParser, tertiaryCfg, nil, nil)
-		secondarySide := buildFallbackParser(secondaryParser, secondaryCfg.Provider, tertiaryParser, tertiaryCfg, nil, nil)
+		primarySide := buildFallbackParser(primaryParser, primaryCfg.Provider, tertiaryParser, tertiaryCfg, nil, nil, ocrParser)
+		secondarySide := buildFallbackParser(secondaryParser, secondaryCfg.Provider, tertiaryParser, tertiaryCfg, nil, nil, ocrParser)
 		mergeDocParser = parser.NewMergeParser(primarySide, secondarySide)
 		log.Printf("Multi-parser mode enabled: primary=%s, secondary=%s", primaryCfg.Provider, secondaryCfg.Provider)
 	}
 
+	// The free tier may be restricted to one (the cheapest) configured provider
+	var freeParser port.DocumentParser
+	if provider := cfg.ParseBudget.FreeProvider; provider != "" {
+		switch {
+		case primaryCfg.Provider == provider:
+			freeParser = primaryParser
+		case secondaryParser != nil && secondaryCfg.Provider == provider:
+			freeParser = secondaryParser
+		case tertiaryParser != nil && tertiaryCfg.Provider == provider:
+			freeParser = tertiaryParser
+		default:
+			log.Printf("WARNING: SATVOS_PARSE_BUDGET_FREE_PROVIDER %q is not a configured parser; free tier uses the default parsers", provider)
+		}
+	}
+
 	// Initialize validation engine
 	registry := validator.NewRegistry()
 	for _, v := range invoice.AllBuiltinValidators() {
@@ -169,28 +357,157 @@ func run() error {
 	for _, v := range invoice.HSNValidators(hsnLookup) {
 		registry.Register(v)
 	}
+	hsnCodes, err := hsnRepo.ListCodes(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to load HSN hierarchy: %w", err)
+	}
+	hsnSvc := service.NewHSNService(hsnCodes, hsnLookup)
 
 	// Register duplicate invoice validator
 	registry.Register(invoice.DuplicateInvoiceValidator(duplicateFinder))
 
+	// Register related party validator (sellers the tenant registered as its own or group companies)
+	relatedPartyRepo := postgres.NewRelatedPartyRepo(db)
+	registry.Register(invoice.RelatedPartyValidator(relatedPartyRepo))
+
 	validationEngine := validator.NewEngine(registry, validationRuleRepo, docRepo)
 
 	// Initialize services
-	authSvc := service.NewAuthService(userRepo, tenantRepo, cfg.JWT)
-	fileSvc := service.NewFileService(fileRepo, s3Client, &cfg.S3)
-	tenantSvc := service.NewTenantService(tenantRepo)
-	userSvc := service.NewUserService(userRepo)
-	collectionSvc := service.NewCollectionService(collectionRepo, collectionPermRepo, collectionFileRepo, fileSvc, userRepo)
-	statsSvc := service.NewStatsService(statsRepo)
+	// API keys are accepted wherever an access token is, for scripts and satvosctl
+	apiKeySvc := service.NewAPIKeyService(postgres.NewAPIKeyRepo(db), userRepo)
+	authSvc := service.NewAPIKeyAuthService(service.NewAuthService(userRepo, tenantRepo, cfg.JWT), apiKeySvc)
+	residency := service.NewStorageResidency(tenantRepo, &cfg.S3)
+	parseBudget := service.NewParseBudget(tenantRepo, parseBudgetRepo, cfg.FreeTier.TenantSlug, cfg.ParseBudget, freeParser)
+	fileSvc := service.NewFileService(fileRepo, s3Client, &cfg.S3, residency)
+	storageLayoutSvc := service.NewStorageLayoutService(fileRepo, s3Client, &cfg.S3)
+	tenantSvc := service.NewTenantService(tenantRepo, fileRepo, residency, storageLayoutSvc)
+	membershipSvc := service.NewTenantMembershipService(membershipRepo, userRepo, tenantRepo, authSvc)
+	clientSvc := service.NewClientTenantService(tenantRepo, membershipRepo, userRepo, statsRepo)
+	delegationSvc := service.NewReviewDelegationService(delegationRepo, userRepo)
+	collectionSvc := service.NewCollectionService(collectionRepo, collectionPermRepo, collectionFileRepo, fileSvc, userRepo, collectionEventRepo)
+	rollupCacheTTL := time.Duration(cfg.Stats.CacheTTLSecs) * time.Second
+	statsSvc := service.NewCachingStatsService(
+		service.NewStatsService(statsRepo, parseTimingRepo, confidenceObservationRepo, ruleOutcomeRepo), rollupCacheTTL)
 	reportRepo := postgres.NewReportRepo(db)
-	reportSvc := service.NewReportService(reportRepo)
+	reportSvc := service.NewCachingReportService(service.NewReportService(reportRepo), rollupCacheTTL)
+	flagSvc := service.NewFeatureFlagService(flagRepo, 30*time.Second)
+	rejectionReasonSvc := service.NewRejectionReasonService(rejectionReasonRepo)
 
-	var documentSvc service.DocumentService
-	if mergeDocParser != nil {
-		documentSvc = service.NewDocumentServiceWithMerge(docRepo, fileRepo, userRepo, collectionPermRepo, documentTagRepo, documentParser, mergeDocParser, s3Client, validationEngine, auditRepo, summaryRepo)
-	} else {
-		documentSvc = service.NewDocumentService(docRepo, fileRepo, userRepo, collectionPermRepo, documentTagRepo, documentParser, s3Client, validationEngine, auditRepo, summaryRepo)
+	// Initialize email sender
+	var emailSender port.EmailSender
+	switch cfg.Email.Provider {
+	case "ses":
+		var sesHTTP *http.Client
+		if !cfg.Email.HTTP.IsZero() {
+			if sesHTTP, err = httpclient.New(cfg.Email.HTTP); err != nil {
+				return fmt.Errorf("failed to create SES http client: %w", err)
+			}
+		}
+		emailSender, err = ses.NewSESSender(cfg.Email.Region, cfg.Email.FromAddress, cfg.Email.FromName, cfg.Email.FrontendURL, cfg.Email.AccessKey, cfg.Email.SecretKey, sesHTTP)
+		if err != nil {
+			return fmt.Errorf("failed to initialize SES email sender: %w", err)
+		}
+		log.Println("Email sender: AWS SES")
+	default:
+		emailSender = noop.NewNoopSender(cfg.Email.FrontendURL)
+		log.Println("Email sender: noop (verification URLs logged to stdout)")
+	}
+	// Addresses SES reported as bouncing or complaining are never emailed again
+	emailSuppressionRepo := postgres.NewEmailSuppressionRepo(db)
+	emailSender = service.NewSuppressingEmailSender(emailSender, emailSuppressionRepo)
+
+	// User notifications are routed per kind and tenant; email and the in-app
+	// inbox are always available, Slack and the webhook when configured
+	notifierChannels := map[domain.NotifierChannel]port.Notifier{
+		domain.NotifierChannelEmail: notify.NewEmailNotifier(emailSender),
+		domain.NotifierChannelInApp: notify.NewInAppNotifier(inAppNotificationRepo),
+	}
+	if cfg.Notifications.SlackWebhookURL != "" {
+		notifierChannels[domain.NotifierChannelSlack] = notify.NewSlackNotifier(cfg.Notifications.SlackWebhookURL, notificationsHTTP)
 	}
+	if cfg.Notifications.WebhookURL != "" {
+		notifierChannels[domain.NotifierChannelWebhook] = notify.NewWebhookNotifier(cfg.Notifications.WebhookURL, notificationsHTTP)
+	}
+	defaultRoutes, err := notify.ParseRoutes(cfg.Notifications.Routes)
+	if err != nil {
+		return fmt.Errorf("invalid SATVOS_NOTIFICATIONS_ROUTES: %w", err)
+	}
+	notifier := notify.NewRouter(notifierChannels, defaultRoutes, notificationRouteRepo)
+
+	// Quota increments warn the user and tenant admins at 80% and 95% of the limit
+	quotaUserRepo := service.NewQuotaWarningUserRepo(userRepo, notifier)
+	quotaSvc := service.NewQuotaService(userRepo)
+
+	parseJobTimeout := time.Duration(cfg.Parser.JobTimeoutSecs) * time.Second
+	// A nil mergeDocParser leaves dual parse mode unavailable
+	documentSvc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:        docRepo,
+		FileRepo:       fileRepo,
+		UserRepo:       quotaUserRepo,
+		PermRepo:       collectionPermRepo,
+		TagRepo:        documentTagRepo,
+		Parser:         documentParser,
+		MergeParser:    mergeDocParser,
+		Storage:        s3Client,
+		Validator:      validationEngine,
+		AuditRepo:      auditRepo,
+		SummaryRepo:    summaryRepo,
+		OverrideRepo:   overrideRepo,
+		Flags:          flagSvc,
+		TimingRepo:     parseTimingRepo,
+		CollectionRepo: collectionRepo,
+		DelegationRepo: delegationRepo,
+		ReasonRepo:     rejectionReasonRepo,
+		Observations:   confidenceObservationRepo,
+		Residency:      residency,
+		Budget:         parseBudget,
+		JobTimeout:     parseJobTimeout,
+	})
+	// Invoices from registered related parties are tagged after the default auto-tags
+	relatedPartySvc := service.NewRelatedPartyService(relatedPartyRepo, documentTagRepo)
+	documentSvc.Events().Subscribe("related_party_tag", relatedPartySvc.TagDocument, service.DocumentEventParsed, service.DocumentEventEdited)
+	// Stats buckets, channel notifications and REST hooks follow the document service's changes
+	documentSvc.Events().Subscribe("stats", statsRefresher.MarkDocumentDirty, service.DocumentEventCreated,
+		service.DocumentEventParseStatusChanged, service.DocumentEventParsed, service.DocumentEventParseFailed,
+		service.DocumentEventEdited, service.DocumentEventReviewed, service.DocumentEventVersioned)
+	documentSvc.Events().Subscribe("channel_notifications", service.NotifyParseFailedListener(notificationSvc), service.DocumentEventParseFailed)
+	documentSvc.Events().Subscribe("rest_hooks", service.NotifyApprovedListener(integrationSvc), service.DocumentEventReviewed)
+	bulkTagSvc := service.NewBulkTagService(bulkTagJobRepo, documentTagRepo, auditRepo, collectionSvc)
+	bulkDeleteSvc := service.NewBulkDeleteService(bulkDeleteRepo, docRepo, fileSvc, auditRepo, collectionSvc)
+	ruleSimulationSvc := service.NewRuleSimulationService(docRepo, validationEngine, collectionSvc)
+	validationRunSvc := service.NewValidationRunService(validationRunRepo, docRepo, validationEngine, auditRepo, summaryRepo, collectionSvc)
+	documentStreamSvc := service.NewDocumentStreamService(postgres.NewDocumentChangeRepo(db), collectionSvc)
+	starSvc := service.NewStarService(starRepo, docRepo, collectionRepo, collectionSvc)
+	qaReviewSvc := service.NewQAReviewService(qaReviewRepo, collectionSvc, userRepo, auditRepo)
+	docLockRepo := postgres.NewDocumentLockRepo(db)
+	docLockSvc := service.NewDocumentLockService(docLockRepo, docRepo, collectionSvc, userRepo)
+	duplicateSvc := service.NewDuplicateService(documentSvc, duplicateFinder, collectionRepo)
+	exportAuditSvc := service.NewExportAuditService(exportArtifactRepo, collectionRepo, collectionSvc)
+	demoSvc := service.NewDemoDataService(userRepo, collectionRepo, collectionPermRepo, collectionFileRepo, fileSvc, documentSvc)
+	importSvc := service.NewImportService(importJobRepo, fileSvc, collectionSvc, documentSvc, s3Client, &cfg.S3, residency)
+
+	// Initialize cloud storage import (each provider enabled only when its credentials are set)
+	var cloudProviders []port.CloudDriveProvider
+	if cfg.CloudImport.GoogleClientID != "" {
+		cloudProviders = append(cloudProviders, gdrive.NewProvider(cfg.CloudImport.GoogleClientID, cfg.CloudImport.GoogleClientSecret, cfg.CloudImport.RedirectURL))
+		log.Println("Cloud import enabled: Google Drive")
+	}
+	if cfg.CloudImport.DropboxAppKey != "" {
+		cloudProviders = append(cloudProviders, dropbox.NewProvider(cfg.CloudImport.DropboxAppKey, cfg.CloudImport.DropboxAppSecret, cfg.CloudImport.RedirectURL))
+		log.Println("Cloud import enabled: Dropbox")
+	}
+	// Documents can be pulled from public https URLs; the client refuses internal addresses
+	urlImportHTTP, err := httpclient.NewPublic(cfg.URLImport.HTTP)
+	if err != nil {
+		return fmt.Errorf("failed to create url import http client: %w", err)
+	}
+	urlImportHTTP.Timeout = time.Duration(cfg.URLImport.TimeoutSecs) * time.Second
+	urlImportSvc := service.NewURLImportService(urlImportHTTP, cfg.URLImport, fileSvc, collectionSvc, documentSvc)
+	previewSvc := service.NewParsePreviewService(documentParser, validationEngine, quotaUserRepo, parseBudget, cfg.ParsePreview)
+	schemaSvc := service.NewSchemaService(registry)
+	computedFieldSvc := service.NewComputedFieldService(computedFieldRepo, schemaSvc)
+	pageImageSvc := service.NewPageImageService(fileRepo, s3Client, pdftoppm.NewRenderer(cfg.PageImage.RendererPath), residency, cfg.PageImage)
+	cloudSvc := service.NewCloudImportService(cloudProviders, cloudConnRepo, cloudSyncRepo, userRepo, fileSvc, collectionSvc, documentSvc, tokenSealer, cfg.JWT, &cfg.S3)
 
 	// Auto-create free tier tenant if it doesn't exist
 	if _, ftErr := tenantRepo.GetBySlug(context.Background(), cfg.FreeTier.TenantSlug); ftErr != nil {
@@ -209,22 +526,36 @@ func run() error {
 		log.Printf("Free tier tenant '%s' ready", cfg.FreeTier.TenantSlug)
 	}
 
-	// Initialize email sender
-	var emailSender port.EmailSender
-	switch cfg.Email.Provider {
-	case "ses":
-		emailSender, err = ses.NewSESSender(cfg.Email.Region, cfg.Email.FromAddress, cfg.Email.FromName, cfg.Email.FrontendURL, cfg.Email.AccessKey, cfg.Email.SecretKey)
-		if err != nil {
-			return fmt.Errorf("failed to initialize SES email sender: %w", err)
-		}
-		log.Println("Email sender: AWS SES")
-	default:
-		emailSender = noop.NewNoopSender(cfg.Email.FrontendURL)
-		log.Println("Email sender: noop (verification URLs logged to stdout)")
+	registrationSvc := service.NewRegistrationService(tenantRepo, userRepo, collectionRepo, collectionPermRepo, authSvc, notifier, cfg.JWT, cfg.FreeTier)
+	passwordResetSvc := service.NewPasswordResetService(tenantRepo, userRepo, accountSecurityRepo, notifier, cfg.JWT, cfg.AccountSecurity)
+	userSvc := service.NewUserService(userRepo, passwordResetSvc)
+	userImportSvc := service.NewUserImportService(tenantRepo, userRepo, collectionRepo, collectionPermRepo, passwordResetSvc)
+	feedSvc := service.NewBatchFeedService(tenantRepo, collectionRepo, userRepo, feedRepo, flagSvc,
+		fileSvc, collectionSvc, documentSvc, s3Client, notifier, &cfg.S3, cfg.BatchFeed.ReportHour)
+	notificationRouteSvc := service.NewNotificationRouteService(tenantRepo, notificationRouteRepo, notifier)
+	tenantCORSSvc := service.NewTenantCORSService(tenantRepo, corsOriginRepo)
+	snsHTTP, err := httpclient.New(cfg.Email.HTTP)
+	if err != nil {
+		return fmt.Errorf("failed to create SNS http client: %w", err)
 	}
-
-	registrationSvc := service.NewRegistrationService(tenantRepo, userRepo, collectionRepo, collectionPermRepo, authSvc, emailSender, cfg.JWT, cfg.FreeTier)
-	passwordResetSvc := service.NewPasswordResetService(tenantRepo, userRepo, emailSender, cfg.JWT)
+	snsHTTP.Timeout = 10 * time.Second
+	emailFeedbackSvc := service.NewEmailFeedbackService(ses.NewSNSVerifier(snsHTTP), emailSuppressionRepo, userRepo, notifier, cfg.Email.SNSTopicARN)
+	inboxSvc := service.NewInboxService(inAppNotificationRepo)
+	analyticsExportSvc := service.NewAnalyticsExportService(analyticsExportRepo, s3Client, &cfg.S3)
+
+	// Tenant moves need this cluster's name so moved tenants can be told apart
+	moveTargets := cfg.TenantMove.Targets
+	if cfg.DB.Cluster == "" && len(moveTargets) > 0 {
+		log.Println("WARN: SATVOS_TENANT_MOVE_TARGETS ignored: SATVOS_DB_CLUSTER is not set")
+		moveTargets = nil
+	}
+	moveTargetStores, closeMoveTargets, err := postgres.OpenTenantDataStores(moveTargets)
+	if err != nil {
+		return fmt.Errorf("failed to open tenant move targets: %w", err)
+	}
+	defer closeMoveTargets()
+	tenantMoveSvc := service.NewTenantMoveService(postgres.NewTenantMoveRepo(db), tenantRepo,
+		postgres.NewTenantDataStore(db), moveTargetStores, s3Client, &cfg.S3, cfg.DB.Cluster, router.TenantStatusCacheTTL)
 
 	// Initialize social auth (optional — disabled if no client ID configured)
 	var socialAuthSvc service.SocialAuthService
@@ -241,28 +572,219 @@ func run() error {
 
 	// Start parse queue worker
 	queueCfg := service.ParseQueueConfig{
-		PollInterval: time.Duration(cfg.Queue.PollIntervalSecs) * time.Second,
-		MaxRetries:   cfg.Queue.MaxRetries,
-		Concurrency:  cfg.Queue.Concurrency,
+		PollInterval:            time.Duration(cfg.Queue.PollIntervalSecs) * time.Second,
+		MaxRetries:              cfg.Queue.MaxRetries,
+		Concurrency:             cfg.Queue.Concurrency,
+		JobTimeout:              parseJobTimeout,
+		RateLimits:              rateLimits,
+		Provider:                primaryCfg.Provider,
+		RateLimitReservePercent: cfg.Queue.RateLimitReservePercent,
 	}
 	queueWorker := service.NewParseQueueWorker(docRepo, documentSvc, queueCfg)
 	queueCtx, queueStop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer queueStop()
-	go queueWorker.Start(queueCtx)
+	if cfg.Queue.LeaderElection {
+		// One replica polls at a time; the others take over when its session ends
+		queueElection := service.NewLeaderElection("parse-queue", postgres.NewAdvisoryLock(db, "satvos.parse_queue"),
+			time.Duration(cfg.Queue.LeaderRetrySecs)*time.Second, queueWorker.Start)
+		go queueElection.Start(queueCtx)
+	} else {
+		go queueWorker.Start(queueCtx)
+	}
+
+	// Start cloud folder sync poller
+	if len(cloudProviders) > 0 {
+		cloudWorker := service.NewCloudSyncWorker(cloudSvc, time.Duration(cfg.CloudImport.PollIntervalMins)*time.Minute)
+		go cloudWorker.Start(queueCtx)
+	}
+
+	// Start parse SLA monitor (only when an alert destination is configured)
+	var alertSenders []port.AlertSender
+	if len(cfg.ParseSLA.AlertEmails) > 0 {
+		alertSenders = append(alertSenders, alert.NewEmailSender(emailSender, cfg.ParseSLA.AlertEmails))
+	}
+	if cfg.ParseSLA.AlertWebhookURL != "" {
+		alertSenders = append(alertSenders, alert.NewWebhookSender(cfg.ParseSLA.AlertWebhookURL))
+	}
+	if len(alertSenders) > 0 {
+		slaMonitor := service.NewParseSLAMonitor(parseTimingRepo, alert.Multi(alertSenders...), service.ParseSLAConfig{
+			CheckInterval: time.Duration(cfg.ParseSLA.CheckIntervalSecs) * time.Second,
+			Window:        time.Duration(cfg.ParseSLA.WindowMins) * time.Minute,
+			MinSamples:    cfg.ParseSLA.MinSamples,
+			MaxP95Parse:   time.Duration(cfg.ParseSLA.P95ParseSecs) * time.Second,
+			MaxQueueAge:   time.Duration(cfg.ParseSLA.MaxQueueAgeSecs) * time.Second,
+			Cooldown:      time.Duration(cfg.ParseSLA.AlertCooldownMins) * time.Minute,
+		})
+		go slaMonitor.Start(queueCtx)
+	}
+
+	// Start materialized stats refresher
+	go statsRefresher.Start(queueCtx)
+
+	// Start batch feed drop-folder scanner (tenants opt in via the batch_feed flag)
+	feedWorker := service.NewBatchFeedWorker(feedSvc, time.Duration(cfg.BatchFeed.PollIntervalSecs)*time.Second)
+	go feedWorker.Start(queueCtx)
+
+	// Start scheduled Parquet exports to tenant analytics buckets
+	if cfg.AnalyticsExport.PollIntervalSecs > 0 {
+		exportWorker := service.NewAnalyticsExportWorker(analyticsExportSvc, time.Duration(cfg.AnalyticsExport.PollIntervalSecs)*time.Second)
+		go exportWorker.Start(queueCtx)
+	}
+
+	// Start review SLA and weekly summary notifications
+	if cfg.Notifications.PollIntervalSecs > 0 {
+		notificationWorker := service.NewNotificationWorker(notificationSvc, time.Duration(cfg.Notifications.PollIntervalSecs)*time.Second)
+		go notificationWorker.Start(queueCtx)
+	}
+
+	// Start escalation of reviews that waited past their collection's policy
+	escalationSvc := service.NewReviewEscalationService(postgres.NewReviewEscalationRepo(db), docRepo, collectionRepo,
+		collectionPermRepo, userRepo, auditRepo, notificationSvc)
+	if cfg.Escalation.PollIntervalSecs > 0 {
+		escalationWorker := service.NewReviewEscalationWorker(escalationSvc, time.Duration(cfg.Escalation.PollIntervalSecs)*time.Second)
+		go escalationWorker.Start(queueCtx)
+	}
+
+	// Start weekly nudges about uploaded files that never became documents
+	orphanFileSvc := service.NewOrphanFileService(postgres.NewOrphanFileRepo(db), tenantRepo, userRepo,
+		collectionSvc, documentSvc, flagSvc, notifier, cfg.OrphanFiles.NudgeAfterDays)
+	if cfg.OrphanFiles.PollIntervalSecs > 0 {
+		orphanWorker := service.NewOrphanFileWorker(orphanFileSvc, time.Duration(cfg.OrphanFiles.PollIntervalSecs)*time.Second)
+		go orphanWorker.Start(queueCtx)
+	}
+
+	// Start verification reminders for unverified free-tier users
+	if cfg.FreeTier.VerificationReminderAfterHours > 0 {
+		go service.NewVerificationReminderWorker(registrationSvc).Start(queueCtx)
+	}
 
 	// Initialize handlers
 	authH := handler.NewAuthHandler(authSvc, registrationSvc, passwordResetSvc, socialAuthSvc)
 	fileH := handler.NewFileHandler(fileSvc, collectionSvc)
 	tenantH := handler.NewTenantHandler(tenantSvc)
-	userH := handler.NewUserHandler(userSvc)
-	healthH := handler.NewHealthHandler(db)
-	collectionH := handler.NewCollectionHandler(collectionSvc, documentSvc)
-	documentH := handler.NewDocumentHandler(documentSvc, auditRepo)
+	userH := handler.NewUserHandler(userSvc, delegationSvc, passwordResetSvc)
+	healthH := handler.NewHealthHandler(db, shardDBs)
+	collectionH := handler.NewCollectionHandler(collectionSvc, documentSvc, exportAuditSvc, computedFieldSvc)
+	documentH := handler.NewDocumentHandler(documentSvc, auditRepo, computedFieldSvc, quotaSvc)
 	statsH := handler.NewStatsHandler(statsSvc)
+	demoH := handler.NewDemoDataHandler(demoSvc)
 	reportH := handler.NewReportHandler(reportSvc)
+	hsnH := handler.NewHSNHandler(hsnSvc)
+	flagH := handler.NewFeatureFlagHandler(flagSvc)
+	rejectionReasonH := handler.NewRejectionReasonHandler(rejectionReasonSvc)
+	importH := handler.NewImportHandler(importSvc)
+	bulkTagH := handler.NewBulkTagHandler(bulkTagSvc)
+	bulkDeleteH := handler.NewBulkDeleteHandler(bulkDeleteSvc)
+	starH := handler.NewStarHandler(starSvc)
+	cloudH := handler.NewCloudImportHandler(cloudSvc)
+	feedH := handler.NewBatchFeedHandler(feedSvc)
+	analyticsH := handler.NewAnalyticsExportHandler(analyticsExportSvc)
+	integrationH := handler.NewIntegrationHandler(integrationSvc)
+	notificationH := handler.NewNotificationHandler(notificationSvc)
+	escalationH := handler.NewReviewEscalationHandler(escalationSvc, documentSvc)
+	membershipH := handler.NewTenantMembershipHandler(membershipSvc)
+	clientH := handler.NewClientTenantHandler(clientSvc)
+	urlImportH := handler.NewURLImportHandler(urlImportSvc, quotaSvc)
+	previewH := handler.NewParsePreviewHandler(previewSvc)
+	schemaH := handler.NewSchemaHandler(schemaSvc)
+	computedFieldH := handler.NewComputedFieldHandler(computedFieldSvc)
+	duplicateH := handler.NewDuplicateHandler(duplicateSvc)
+	capabilityH := handler.NewCapabilityHandler(service.NewCapabilityService(router.RouteMatrix(), userRepo, collectionPermRepo, collectionRepo, flagSvc))
+	pageImageH := handler.NewPageImageHandler(pageImageSvc, collectionSvc)
+	preflightH := handler.NewFilePreflightHandler(service.NewFilePreflightService(fileRepo, s3Client, residency))
+	apiKeyH := handler.NewAPIKeyHandler(apiKeySvc)
+	parseQueueH := handler.NewParseQueueHandler(queueWorker)
+	relatedPartyH := handler.NewRelatedPartyHandler(relatedPartySvc)
+	documentStreamH := handler.NewDocumentStreamHandler(documentStreamSvc)
+	userImportH := handler.NewUserImportHandler(userImportSvc)
+	orphanFileH := handler.NewOrphanFileHandler(orphanFileSvc)
+	validationRunH := handler.NewValidationRunHandler(validationRunSvc)
+	ruleSimulationH := handler.NewRuleSimulationHandler(ruleSimulationSvc)
+	tenantMoveH := handler.NewTenantMoveHandler(tenantMoveSvc)
+	notificationRouteH := handler.NewNotificationRouteHandler(notificationRouteSvc)
+	tenantCORSH := handler.NewTenantCORSHandler(tenantCORSSvc)
+	emailFeedbackH := handler.NewEmailFeedbackHandler(emailFeedbackSvc)
+	inboxH := handler.NewInboxHandler(inboxSvc)
+	qaReviewH := handler.NewQAReviewHandler(qaReviewSvc)
+	docLockH := handler.NewDocumentLockHandler(docLockSvc)
+
+	maintenance := middleware.NewMaintenanceMode(cfg.Maintenance.Enabled, cfg.Maintenance.RetryAfterSecs)
+	if cfg.Maintenance.Enabled {
+		log.Println("Maintenance mode enabled: write requests will be rejected")
+	}
+
+	bodyLimits := middleware.BodyLimits{
+		Auth:   cfg.Limits.AuthBodyKB * 1024,
+		JSON:   cfg.Limits.JSONBodyKB * 1024,
+		Upload: cfg.Limits.UploadBodyMB * 1024 * 1024,
+	}
 
 	// Setup router
-	r := router.Setup(authSvc, authH, fileH, tenantH, userH, healthH, collectionH, documentH, statsH, reportH, cfg.CORS.AllowedOrigins, userRepo)
+	r := router.Setup(&router.Deps{
+		Handlers: router.Handlers{
+			Auth:              authH,
+			File:              fileH,
+			Tenant:            tenantH,
+			User:              userH,
+			Health:            healthH,
+			Collection:        collectionH,
+			Document:          documentH,
+			Stats:             statsH,
+			Report:            reportH,
+			FeatureFlag:       flagH,
+			Import:            importH,
+			CloudImport:       cloudH,
+			BatchFeed:         feedH,
+			HSN:               hsnH,
+			BulkTag:           bulkTagH,
+			Star:              starH,
+			DemoData:          demoH,
+			AnalyticsExport:   analyticsH,
+			Integration:       integrationH,
+			Notification:      notificationH,
+			RejectionReason:   rejectionReasonH,
+			ReviewEscalation:  escalationH,
+			TenantMembership:  membershipH,
+			ClientTenant:      clientH,
+			URLImport:         urlImportH,
+			ParsePreview:      previewH,
+			Schema:            schemaH,
+			PageImage:         pageImageH,
+			ValidationRun:     validationRunH,
+			RuleSimulation:    ruleSimulationH,
+			TenantMove:        tenantMoveH,
+			NotificationRoute: notificationRouteH,
+			Inbox:             inboxH,
+			QAReview:          qaReviewH,
+			DocumentLock:      docLockH,
+			BulkDelete:        bulkDeleteH,
+			TenantCORS:        tenantCORSH,
+			EmailFeedback:     emailFeedbackH,
+			ComputedField:     computedFieldH,
+			Duplicate:         duplicateH,
+			Capability:        capabilityH,
+			FilePreflight:     preflightH,
+			APIKey:            apiKeyH,
+			ParseQueue:        parseQueueH,
+			RelatedParty:      relatedPartyH,
+			DocumentStream:    documentStreamH,
+			UserImport:        userImportH,
+			OrphanFile:        orphanFileH,
+		},
+		AuthSvc:              authSvc,
+		CORSOrigins:          cfg.CORS.AllowedOrigins,
+		CORSOriginRepo:       corsOriginRepo,
+		UserRepo:             userRepo,
+		TenantRepo:           tenantRepo,
+		DocLockRepo:          docLockRepo,
+		DBCluster:            cfg.DB.Cluster,
+		AuthzDenialRepo:      authzDenialRepo,
+		AuditDenials:         cfg.AuthzAudit.Enabled,
+		Maintenance:          maintenance,
+		FaultInjector:        faultInjector,
+		BodyLimits:           bodyLimits,
+		PreviewRatePerMinute: cfg.ParsePreview.RequestsPerMinute,
+	})
 
 	log.Printf("Server starting on %s", cfg.Server.Port)
 	if err := r.Run(cfg.Server.Port); err != nil {
@@ -272,9 +794,10 @@ func run() error {
 	return nil
 }
 
-// buildFallbackParser wraps a primary parser with optional fallback parsers.
+// buildFallbackParser wraps a primary parser with optional fallback parsers and
+// the optional OCR parser as the last resort.
 // If no fallbacks are available, returns the primary parser directly.
-func buildFallbackParser(p1 port.DocumentParser, p1Name string, p2 port.DocumentParser, p2Cfg *config.ParserProviderConfig, p3 port.DocumentParser, p3Cfg *config.ParserProviderConfig) port.DocumentParser {
+func buildFallbackParser(p1 port.DocumentParser, p1Name string, p2 port.DocumentParser, p2Cfg *config.ParserProviderConfig, p3 port.DocumentParser, p3Cfg *config.ParserProviderConfig, ocr port.DocumentParser) port.DocumentParser {
 	parsers := []port.DocumentParser{p1}
 	names := []string{p1Name}
 
@@ -287,6 +810,9 @@ func buildFallbackParser(p1 port.DocumentParser, p1Name string, p2 port.Document
 		names = append(names, p3Cfg.Provider)
 	}
 
+	if ocr != nil {
+		return parser.NewFallbackParser(parsers, names).WithLastResort(ocr, "tesseract")
+	}
 	if len(parsers) == 1 {
 		return p1
 	}
@@ -0,0 +1,99 @@
+// Command storagelayout moves existing collection files to the
+// tenants/{tenant}/collections/{collection}/ S3 key layout and syncs each
+// tenant's lifecycle rule from its storage_ia_after_days setting.
+// Objects are copied, file_metadata is repointed, and only then is the old
+// object deleted, so the job is safe to stop and re-run at any point.
+// Usage: go run ./cmd/storagelayout [-tenant <uuid>]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/repository/postgres"
+	"satvos/internal/service"
+	s3storage "satvos/internal/storage/s3"
+)
+
+const batchSize = 100
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	tenantFlag := flag.String("tenant", "", "only process this tenant ID")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	db, err := postgres.NewDB(&cfg.DB)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	s3Client, err := s3storage.NewS3Client(&cfg.S3)
+	if err != nil {
+		return fmt.Errorf("creating s3 client: %w", err)
+	}
+
+	tenantRepo := postgres.NewTenantRepo(db)
+	layout := service.NewStorageLayoutService(postgres.NewFileMetaRepo(db), s3Client, &cfg.S3)
+	ctx := context.Background()
+
+	if *tenantFlag != "" {
+		tenantID, err := uuid.Parse(*tenantFlag)
+		if err != nil {
+			return fmt.Errorf("invalid -tenant: %w", err)
+		}
+		tenant, err := tenantRepo.GetByID(ctx, tenantID)
+		if err != nil {
+			return fmt.Errorf("loading tenant %s: %w", tenantID, err)
+		}
+		return process(ctx, layout, tenant)
+	}
+
+	for offset := 0; ; offset += batchSize {
+		tenants, _, err := tenantRepo.List(ctx, offset, batchSize)
+		if err != nil {
+			return fmt.Errorf("listing tenants at offset %d: %w", offset, err)
+		}
+		for i := range tenants {
+			if err := process(ctx, layout, &tenants[i]); err != nil {
+				log.Printf("WARN: tenant %s: %v", tenants[i].ID, err)
+			}
+		}
+		if len(tenants) < batchSize {
+			return nil
+		}
+	}
+}
+
+func process(ctx context.Context, layout service.StorageLayoutService, tenant *domain.Tenant) error {
+	if err := layout.ApplyLifecycle(ctx, tenant); err != nil {
+		return fmt.Errorf("syncing lifecycle rule: %w", err)
+	}
+
+	result, err := layout.RelocateTenant(ctx, tenant.ID)
+	if err != nil {
+		return fmt.Errorf("relocating objects: %w", err)
+	}
+	log.Printf("Tenant %s (%s): %d relocated, %d failed, %d old objects left behind",
+		tenant.ID, tenant.Slug, result.Relocated, result.Failed, len(result.Orphans))
+	for _, key := range result.Orphans {
+		log.Printf("  orphan: %s", key)
+	}
+	return nil
+}
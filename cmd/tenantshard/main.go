@@ -0,0 +1,102 @@
+// Command tenantshard lists database shards and shows a tenant's shard, read
+// from tenants.db_cluster.
+// Usage:
+//
+//	go run ./cmd/tenantshard                               # shards and their moved tenants
+//	go run ./cmd/tenantshard -tenant <uuid>                # a tenant's shard
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/config"
+	"satvos/internal/port"
+	"satvos/internal/repository/postgres"
+	"satvos/internal/shard"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	tenantFlag := flag.String("tenant", "", "ID of the tenant to show")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	db, err := postgres.NewDB(&cfg.DB)
+	if err != nil {
+		return fmt.Errorf("connecting to database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	primary := shard.PrimaryName(cfg.DB.Cluster)
+	if _, ok := cfg.DB.Shards[primary]; ok {
+		return fmt.Errorf("SATVOS_DB_SHARDS must not name the primary cluster %q", primary)
+	}
+	pools, closeShards, err := postgres.OpenShards(cfg.DB.Shards, 2, 1)
+	if err != nil {
+		return err
+	}
+	defer closeShards()
+
+	names := make([]string, 0, len(pools))
+	targets := map[string]*sqlx.DB{primary: db}
+	for name, pool := range pools {
+		names = append(names, name)
+		targets[name] = pool
+	}
+
+	placements := postgres.NewTenantShardRepo(db)
+	resolver := shard.NewResolver(primary, names, placements, 0)
+	dbs, err := shard.NewRouter(resolver, targets)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if *tenantFlag == "" {
+		return listShards(ctx, dbs, placements, primary)
+	}
+	tenantID, err := uuid.Parse(*tenantFlag)
+	if err != nil {
+		return fmt.Errorf("invalid -tenant: %w", err)
+	}
+	name, err := resolver.Shard(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	log.Printf("Tenant %s is on shard %q", tenantID, name)
+	return nil
+}
+
+func listShards(ctx context.Context, dbs *shard.Router[*sqlx.DB], placements port.TenantShardRepository, primary string) error {
+	counts, err := placements.CountByShard(ctx)
+	if err != nil {
+		return err
+	}
+	return dbs.Each(func(name string, db *sqlx.DB) error {
+		status := "ok"
+		if err := db.PingContext(ctx); err != nil {
+			status = "unreachable"
+		}
+		tenants := fmt.Sprintf("%d moved tenants", counts[name])
+		if name == primary {
+			tenants = fmt.Sprintf("%d moved tenants, plus those never moved", counts[name])
+		}
+		log.Printf("  %-20s %-12s %s", name, status, tenants)
+		return nil
+	})
+}
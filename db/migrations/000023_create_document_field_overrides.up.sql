@@ -0,0 +1,13 @@
+CREATE TABLE document_field_overrides (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id   UUID NOT NULL,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    field_path  VARCHAR(255) NOT NULL,
+    value       JSONB NOT NULL,
+    created_by  UUID NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (document_id, field_path)
+);
+
+CREATE INDEX idx_field_overrides_tenant ON document_field_overrides (tenant_id);
@@ -0,0 +1 @@
+CREATE INDEX idx_audit_log_tenant_action ON document_audit_log (tenant_id, action, created_at DESC);
@@ -0,0 +1,9 @@
+CREATE TABLE tenant_feature_flags (
+    tenant_id  UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    flag       VARCHAR(100) NOT NULL,
+    enabled    BOOLEAN NOT NULL,
+    updated_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, flag)
+);
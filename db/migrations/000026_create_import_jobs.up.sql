@@ -0,0 +1,22 @@
+CREATE TABLE import_jobs (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    collection_id  UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    created_by     UUID NOT NULL,
+    source         VARCHAR(20) NOT NULL,
+    source_ref     TEXT NOT NULL,
+    document_type  VARCHAR(100) NOT NULL,
+    parse_mode     VARCHAR(20) NOT NULL DEFAULT 'single',
+    status         VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total_entries  INT NOT NULL DEFAULT 0,
+    imported_count INT NOT NULL DEFAULT 0,
+    skipped_count  INT NOT NULL DEFAULT 0,
+    failed_count   INT NOT NULL DEFAULT 0,
+    entries        JSONB NOT NULL DEFAULT '[]',
+    error          TEXT,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_import_jobs_collection ON import_jobs (tenant_id, collection_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS cloud_sync_files;
+DROP TABLE IF EXISTS cloud_syncs;
+DROP TABLE IF EXISTS cloud_connections;
@@ -0,0 +1,53 @@
+CREATE TABLE cloud_connections (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider      VARCHAR(20) NOT NULL,
+    account_id    VARCHAR(255) NOT NULL,
+    access_token  TEXT NOT NULL,
+    refresh_token TEXT NOT NULL,
+    token_expiry  TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, user_id, provider, account_id)
+);
+
+CREATE TABLE cloud_syncs (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    collection_id  UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    connection_id  UUID NOT NULL REFERENCES cloud_connections(id) ON DELETE CASCADE,
+    provider       VARCHAR(20) NOT NULL,
+    folder_id      TEXT NOT NULL,
+    folder_name    TEXT NOT NULL DEFAULT '',
+    document_type  VARCHAR(100) NOT NULL,
+    parse_mode     VARCHAR(20) NOT NULL DEFAULT 'single',
+    poll_enabled   BOOLEAN NOT NULL DEFAULT FALSE,
+    last_synced_at TIMESTAMPTZ,
+    last_error     TEXT,
+    created_by     UUID NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (collection_id, connection_id, folder_id)
+);
+
+CREATE INDEX idx_cloud_syncs_collection ON cloud_syncs (tenant_id, collection_id);
+CREATE INDEX idx_cloud_syncs_poll ON cloud_syncs (last_synced_at) WHERE poll_enabled;
+
+CREATE TABLE cloud_sync_files (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    sync_id       UUID NOT NULL REFERENCES cloud_syncs(id) ON DELETE CASCADE,
+    collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    external_id   TEXT NOT NULL,
+    name          TEXT NOT NULL,
+    content_hash  VARCHAR(64) NOT NULL DEFAULT '',
+    status        VARCHAR(20) NOT NULL,
+    reason        TEXT NOT NULL DEFAULT '',
+    file_id       UUID,
+    document_id   UUID,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (sync_id, external_id)
+);
+
+CREATE INDEX idx_cloud_sync_files_hash ON cloud_sync_files (collection_id, content_hash) WHERE status = 'imported';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS feed_report_runs;
+DROP TABLE IF EXISTS feed_ingestions;
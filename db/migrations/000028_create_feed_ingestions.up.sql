@@ -0,0 +1,26 @@
+CREATE TABLE feed_ingestions (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    object_key    TEXT NOT NULL,
+    folder        TEXT NOT NULL DEFAULT '',
+    file_name     TEXT NOT NULL,
+    collection_id UUID REFERENCES collections(id) ON DELETE SET NULL,
+    status        VARCHAR(20) NOT NULL DEFAULT 'processing',
+    reason        TEXT NOT NULL DEFAULT '',
+    file_id       UUID,
+    document_id   UUID,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at  TIMESTAMPTZ
+);
+
+-- At most one in-flight claim per dropped object, across all worker instances
+CREATE UNIQUE INDEX idx_feed_ingestions_claim ON feed_ingestions (tenant_id, object_key) WHERE status = 'processing';
+CREATE INDEX idx_feed_ingestions_tenant_created ON feed_ingestions (tenant_id, created_at DESC);
+
+-- One nightly report per tenant per day, across all worker instances
+CREATE TABLE feed_report_runs (
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    report_date DATE NOT NULL,
+    sent_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, report_date)
+);
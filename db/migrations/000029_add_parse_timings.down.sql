@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS parse_timings;
+DROP INDEX IF EXISTS idx_documents_waiting_queued_at;
+ALTER TABLE documents DROP COLUMN IF EXISTS queued_at;
@@ -0,0 +1,24 @@
+-- When the document last became eligible to parse; queue wait is measured from here
+ALTER TABLE documents ADD COLUMN queued_at TIMESTAMPTZ;
+UPDATE documents SET queued_at = COALESCE(retry_after, created_at);
+ALTER TABLE documents ALTER COLUMN queued_at SET NOT NULL;
+ALTER TABLE documents ALTER COLUMN queued_at SET DEFAULT NOW();
+
+CREATE INDEX idx_documents_waiting_queued_at ON documents (queued_at)
+    WHERE parsing_status IN ('pending', 'queued');
+
+-- One row per parse attempt
+CREATE TABLE parse_timings (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id    UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id  UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    parser_model VARCHAR(100) NOT NULL DEFAULT '',
+    parse_mode   VARCHAR(20) NOT NULL DEFAULT 'single',
+    outcome      VARCHAR(20) NOT NULL,
+    queue_ms     BIGINT NOT NULL DEFAULT 0,
+    parse_ms     BIGINT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_parse_timings_tenant_created ON parse_timings (tenant_id, created_at DESC);
+CREATE INDEX idx_parse_timings_created ON parse_timings (created_at DESC);
@@ -0,0 +1,2 @@
+ALTER TABLE parse_timings DROP COLUMN IF EXISTS failure_category;
+ALTER TABLE documents DROP COLUMN IF EXISTS parse_failure_category;
@@ -0,0 +1,11 @@
+-- Why the latest parse attempt did not complete: timeout, rate_limited, unavailable, error
+ALTER TABLE documents ADD COLUMN parse_failure_category VARCHAR(20) NOT NULL DEFAULT '';
+UPDATE documents SET parse_failure_category = CASE
+    WHEN parsing_error ILIKE '%deadline exceeded%' OR parsing_error ILIKE '%timeout%' THEN 'timeout'
+    ELSE 'error'
+END
+WHERE parsing_status = 'failed';
+UPDATE documents SET parse_failure_category = 'rate_limited'
+WHERE parsing_status = 'queued' AND parsing_error LIKE 'rate limited%';
+
+ALTER TABLE parse_timings ADD COLUMN failure_category VARCHAR(20) NOT NULL DEFAULT '';
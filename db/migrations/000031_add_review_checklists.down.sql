@@ -0,0 +1,2 @@
+ALTER TABLE documents DROP COLUMN IF EXISTS review_checklist;
+ALTER TABLE collections DROP COLUMN IF EXISTS review_checklist;
@@ -0,0 +1,5 @@
+-- Yes/no items reviewers must answer before approving documents in the collection
+ALTER TABLE collections ADD COLUMN review_checklist JSONB NOT NULL DEFAULT '[]';
+
+-- Checklist answers recorded with the latest review decision
+ALTER TABLE documents ADD COLUMN review_checklist JSONB NOT NULL DEFAULT '[]';
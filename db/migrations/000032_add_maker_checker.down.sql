@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_documents_awaiting_checker;
+ALTER TABLE documents DROP COLUMN IF EXISTS maker_approved_at;
+ALTER TABLE documents DROP COLUMN IF EXISTS maker_approved_by;
+ALTER TABLE collections DROP COLUMN IF EXISTS checker_threshold;
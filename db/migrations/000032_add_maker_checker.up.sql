@@ -0,0 +1,10 @@
+-- Invoice total at or above which an approval needs a second (checker) confirmation.
+-- NULL = single-stage review.
+ALTER TABLE collections ADD COLUMN checker_threshold NUMERIC(15,2);
+
+-- First-stage (maker) approval, kept after the checker's decision for the record
+ALTER TABLE documents ADD COLUMN maker_approved_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE documents ADD COLUMN maker_approved_at TIMESTAMPTZ;
+
+CREATE INDEX idx_documents_awaiting_checker ON documents (tenant_id, maker_approved_at)
+    WHERE review_status = 'awaiting_checker';
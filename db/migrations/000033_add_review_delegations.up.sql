@@ -0,0 +1,16 @@
+-- Out-of-office review delegation: one setting per user, active for an inclusive date range
+CREATE TABLE review_delegations (
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    delegate_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_on   DATE NOT NULL,
+    ends_on     DATE NOT NULL,
+    share_queue BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, user_id),
+    CHECK (user_id <> delegate_id),
+    CHECK (ends_on >= starts_on)
+);
+
+CREATE INDEX idx_review_delegations_delegate ON review_delegations(tenant_id, delegate_id);
@@ -0,0 +1,20 @@
+CREATE TABLE bulk_tag_jobs (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    created_by     UUID NOT NULL,
+    action         VARCHAR(10) NOT NULL,
+    tag_key        VARCHAR(100) NOT NULL,
+    tag_value      VARCHAR(500) NOT NULL,
+    filter         JSONB NOT NULL DEFAULT '{}',
+    status         VARCHAR(20) NOT NULL DEFAULT 'pending',
+    matched_count  INT NOT NULL DEFAULT 0,
+    updated_count  INT NOT NULL DEFAULT 0,
+    skipped_count  INT NOT NULL DEFAULT 0,
+    failed_count   INT NOT NULL DEFAULT 0,
+    error          TEXT,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_bulk_tag_jobs_tenant ON bulk_tag_jobs (tenant_id, created_at DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS collection_stars;
+DROP TABLE IF EXISTS document_stars;
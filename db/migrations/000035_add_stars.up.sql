@@ -0,0 +1,19 @@
+-- Per-user stars: a private working set of documents and collections
+CREATE TABLE document_stars (
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, document_id)
+);
+
+CREATE TABLE collection_stars (
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    collection_id UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, collection_id)
+);
+
+CREATE INDEX idx_document_stars_user ON document_stars (tenant_id, user_id, created_at DESC);
+CREATE INDEX idx_collection_stars_user ON collection_stars (tenant_id, user_id, created_at DESC);
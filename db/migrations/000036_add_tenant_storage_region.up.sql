@@ -0,0 +1,3 @@
+-- Data residency: tenants with a storage region keep every object in that region's bucket.
+-- NULL means the deployment's default S3 region.
+ALTER TABLE tenants ADD COLUMN storage_region VARCHAR(32);
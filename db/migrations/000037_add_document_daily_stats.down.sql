@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_documents_collection_created;
+DROP TABLE IF EXISTS document_daily_stats;
@@ -0,0 +1,59 @@
+-- Materialized dashboard counters: one row per tenant, collection and UTC creation day.
+-- Buckets are recomputed when their documents change and rebuilt nightly.
+CREATE TABLE document_daily_stats (
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    collection_id           UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    day                     DATE NOT NULL,
+    total_documents         INT NOT NULL DEFAULT 0,
+    parsing_completed       INT NOT NULL DEFAULT 0,
+    parsing_failed          INT NOT NULL DEFAULT 0,
+    parsing_processing      INT NOT NULL DEFAULT 0,
+    parsing_pending         INT NOT NULL DEFAULT 0,
+    parsing_queued          INT NOT NULL DEFAULT 0,
+    validation_valid        INT NOT NULL DEFAULT 0,
+    validation_warning      INT NOT NULL DEFAULT 0,
+    validation_invalid      INT NOT NULL DEFAULT 0,
+    reconciliation_valid    INT NOT NULL DEFAULT 0,
+    reconciliation_warning  INT NOT NULL DEFAULT 0,
+    reconciliation_invalid  INT NOT NULL DEFAULT 0,
+    review_pending          INT NOT NULL DEFAULT 0,
+    review_approved         INT NOT NULL DEFAULT 0,
+    review_rejected         INT NOT NULL DEFAULT 0,
+    review_awaiting_checker INT NOT NULL DEFAULT 0,
+    refreshed_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, collection_id, day)
+);
+
+CREATE INDEX idx_document_daily_stats_collection ON document_daily_stats (collection_id);
+
+-- Bounds the per-bucket recount to one collection-day of documents
+CREATE INDEX idx_documents_collection_created ON documents (collection_id, created_at);
+
+INSERT INTO document_daily_stats (
+    tenant_id, collection_id, day,
+    total_documents,
+    parsing_completed, parsing_failed, parsing_processing, parsing_pending, parsing_queued,
+    validation_valid, validation_warning, validation_invalid,
+    reconciliation_valid, reconciliation_warning, reconciliation_invalid,
+    review_pending, review_approved, review_rejected, review_awaiting_checker
+)
+SELECT
+    tenant_id, collection_id, (created_at AT TIME ZONE 'UTC')::date,
+    COUNT(*),
+    COUNT(CASE WHEN parsing_status = 'completed' THEN 1 END),
+    COUNT(CASE WHEN parsing_status = 'failed' THEN 1 END),
+    COUNT(CASE WHEN parsing_status = 'processing' THEN 1 END),
+    COUNT(CASE WHEN parsing_status = 'pending' THEN 1 END),
+    COUNT(CASE WHEN parsing_status = 'queued' THEN 1 END),
+    COUNT(CASE WHEN validation_status = 'valid' THEN 1 END),
+    COUNT(CASE WHEN validation_status = 'warning' THEN 1 END),
+    COUNT(CASE WHEN validation_status = 'invalid' THEN 1 END),
+    COUNT(CASE WHEN reconciliation_status = 'valid' THEN 1 END),
+    COUNT(CASE WHEN reconciliation_status = 'warning' THEN 1 END),
+    COUNT(CASE WHEN reconciliation_status = 'invalid' THEN 1 END),
+    COUNT(CASE WHEN review_status = 'pending' THEN 1 END),
+    COUNT(CASE WHEN review_status = 'approved' THEN 1 END),
+    COUNT(CASE WHEN review_status = 'rejected' THEN 1 END),
+    COUNT(CASE WHEN review_status = 'awaiting_checker' THEN 1 END)
+FROM documents
+GROUP BY tenant_id, collection_id, (created_at AT TIME ZONE 'UTC')::date;
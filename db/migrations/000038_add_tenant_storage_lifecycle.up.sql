@@ -0,0 +1,4 @@
+-- Per-tenant S3 lifecycle: objects under tenants/{id}/ move to STANDARD_IA after this many days.
+-- NULL disables the transition. S3 rejects STANDARD_IA transitions earlier than 30 days.
+ALTER TABLE tenants ADD COLUMN storage_ia_after_days INTEGER
+    CHECK (storage_ia_after_days IS NULL OR storage_ia_after_days >= 30);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_verification_reminder;
+ALTER TABLE users DROP COLUMN IF EXISTS verification_reminded_at;
+ALTER TABLE users DROP COLUMN IF EXISTS verification_sent_at;
@@ -0,0 +1,13 @@
+-- When the last verification email went out (rate-limits resends) and when the
+-- one automatic reminder was sent.
+ALTER TABLE users ADD COLUMN verification_sent_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN verification_reminded_at TIMESTAMPTZ;
+
+-- Every existing unverified user got a verification email at registration.
+UPDATE users SET verification_sent_at = created_at WHERE email_verified = false;
+-- Accounts abandoned more than a week ago are not reminded when this ships.
+UPDATE users SET verification_reminded_at = NOW()
+    WHERE email_verified = false AND created_at < NOW() - INTERVAL '7 days';
+
+CREATE INDEX idx_users_verification_reminder ON users (created_at)
+    WHERE email_verified = false AND verification_reminded_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_collections_demo;
+ALTER TABLE collections DROP COLUMN IF EXISTS is_demo;
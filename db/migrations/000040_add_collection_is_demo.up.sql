@@ -0,0 +1,4 @@
+-- Demo collections are seeded by the admin demo-data endpoint and removed by its cleanup.
+ALTER TABLE collections ADD COLUMN is_demo BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_collections_demo ON collections (tenant_id) WHERE is_demo;
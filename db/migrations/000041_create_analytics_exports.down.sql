@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_documents_tenant_updated;
+DROP TABLE IF EXISTS analytics_exports;
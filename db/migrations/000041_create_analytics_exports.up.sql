@@ -0,0 +1,20 @@
+CREATE TABLE analytics_exports (
+    tenant_id          UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    s3_bucket          VARCHAR(63) NOT NULL,
+    s3_prefix          TEXT NOT NULL DEFAULT '',
+    interval_hours     INTEGER NOT NULL DEFAULT 24 CHECK (interval_hours BETWEEN 1 AND 168),
+    enabled            BOOLEAN NOT NULL DEFAULT TRUE,
+    -- Documents updated at or before this time have been exported.
+    exported_through   TIMESTAMPTZ,
+    next_run_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_run_at        TIMESTAMPTZ,
+    last_run_documents INTEGER NOT NULL DEFAULT 0,
+    last_error         TEXT NOT NULL DEFAULT '',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_analytics_exports_due ON analytics_exports (next_run_at) WHERE enabled;
+
+-- Incremental exports page through a tenant's documents by last update.
+CREATE INDEX idx_documents_tenant_updated ON documents (tenant_id, updated_at, id);
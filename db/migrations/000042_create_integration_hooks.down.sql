@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_documents_tenant_approved;
+DROP TABLE IF EXISTS integration_hooks;
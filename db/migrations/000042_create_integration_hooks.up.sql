@@ -0,0 +1,17 @@
+CREATE TABLE integration_hooks (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id        UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event          VARCHAR(50) NOT NULL,
+    target_url     TEXT NOT NULL,
+    -- NULL subscribes to every collection the user can view.
+    collection_id  UUID REFERENCES collections(id) ON DELETE CASCADE,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_integration_hooks_tenant_event ON integration_hooks (tenant_id, event);
+CREATE INDEX idx_integration_hooks_user ON integration_hooks (tenant_id, user_id, created_at);
+
+-- Polling triggers page through approved documents by approval time.
+CREATE INDEX idx_documents_tenant_approved ON documents (tenant_id, reviewed_at, id)
+    WHERE review_status = 'approved';
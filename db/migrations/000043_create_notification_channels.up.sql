@@ -0,0 +1,24 @@
+CREATE TABLE notification_channels (
+    id                  UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id           UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    collection_id       UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    provider            VARCHAR(20) NOT NULL CHECK (provider IN ('slack', 'teams')),
+    -- Incoming webhook URL, AES-GCM sealed; the URL itself grants posting rights.
+    webhook_url         TEXT NOT NULL,
+    webhook_host        VARCHAR(255) NOT NULL,
+    events              TEXT[] NOT NULL DEFAULT '{}',
+    -- Per-event text/template overrides: {"parse_failed": "..."}.
+    templates           JSONB NOT NULL DEFAULT '{}',
+    review_sla_hours    INTEGER NOT NULL DEFAULT 48 CHECK (review_sla_hours BETWEEN 1 AND 720),
+    enabled             BOOLEAN NOT NULL DEFAULT TRUE,
+    -- Documents parsed at or before this time have been checked against the review SLA.
+    sla_checked_through TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    next_summary_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_delivered_at   TIMESTAMPTZ,
+    last_error          TEXT NOT NULL DEFAULT '',
+    created_by          UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_channels_collection ON notification_channels (tenant_id, collection_id);
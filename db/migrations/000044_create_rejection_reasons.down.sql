@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_documents_rejected;
+ALTER TABLE documents DROP COLUMN IF EXISTS rejection_reason;
+DROP TABLE IF EXISTS rejection_reasons;
@@ -0,0 +1,19 @@
+-- Tenant overrides of the built-in rejection-reason taxonomy (domain.DefaultRejectionReasons)
+-- plus tenant-specific reasons. Rows are never deleted so reports keep their labels;
+-- a retired reason has active = false.
+CREATE TABLE rejection_reasons (
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    code        VARCHAR(64) NOT NULL,
+    label       VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    active      BOOLEAN NOT NULL DEFAULT true,
+    sort_order  INT NOT NULL DEFAULT 0,
+    updated_by  UUID,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, code)
+);
+
+ALTER TABLE documents ADD COLUMN rejection_reason VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_documents_rejected ON documents (tenant_id, reviewed_at) WHERE review_status = 'rejected';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_documents_escalated;
+DROP INDEX IF EXISTS idx_documents_escalation_due;
+ALTER TABLE documents DROP COLUMN IF EXISTS escalated_at;
+ALTER TABLE collections DROP COLUMN IF EXISTS escalation_action, DROP COLUMN IF EXISTS escalate_after_days;
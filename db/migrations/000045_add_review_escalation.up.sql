@@ -0,0 +1,14 @@
+-- Per-collection escalation of stale reviews. A document still pending review
+-- escalate_after_days after parsing is escalated once: flagged, or also reassigned
+-- to the collection owner. escalated_at is cleared when the document is reviewed.
+ALTER TABLE collections
+    ADD COLUMN escalate_after_days INT CHECK (escalate_after_days BETWEEN 1 AND 365),
+    ADD COLUMN escalation_action VARCHAR(20) NOT NULL DEFAULT 'flag'
+        CHECK (escalation_action IN ('flag', 'reassign'));
+
+ALTER TABLE documents ADD COLUMN escalated_at TIMESTAMPTZ;
+
+CREATE INDEX idx_documents_escalation_due ON documents (parsed_at)
+    WHERE review_status = 'pending' AND parsing_status = 'completed' AND escalated_at IS NULL;
+CREATE INDEX idx_documents_escalated ON documents (tenant_id, escalated_at)
+    WHERE escalated_at IS NOT NULL;
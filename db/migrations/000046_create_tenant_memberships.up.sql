@@ -0,0 +1,15 @@
+-- Guest access to tenants other than a user's home tenant (users.tenant_id), with
+-- a per-tenant role. A user signs in once with their home credentials and switches
+-- between tenants, e.g. a CA serving several client tenants.
+CREATE TABLE tenant_memberships (
+    tenant_id  UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role       VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'manager', 'member', 'viewer')),
+    is_active  BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+CREATE INDEX idx_tenant_memberships_user ON tenant_memberships (user_id);
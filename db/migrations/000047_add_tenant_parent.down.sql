@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tenants_parent;
+ALTER TABLE tenants DROP CONSTRAINT IF EXISTS chk_tenants_parent_not_self;
+ALTER TABLE tenants DROP COLUMN IF EXISTS parent_tenant_id;
@@ -0,0 +1,10 @@
+-- Client sub-tenants: a firm tenant (e.g. a CA practice) creates and administers
+-- tenants for its clients. Only one level deep; a firm can't be deleted while it
+-- still has clients. Client data stays in the client tenant; firm staff reach it
+-- through tenant_memberships.
+ALTER TABLE tenants
+    ADD COLUMN parent_tenant_id UUID REFERENCES tenants(id) ON DELETE RESTRICT,
+    ADD CONSTRAINT chk_tenants_parent_not_self CHECK (parent_tenant_id <> id);
+
+CREATE INDEX idx_tenants_parent ON tenants (parent_tenant_id, created_at DESC)
+    WHERE parent_tenant_id IS NOT NULL;
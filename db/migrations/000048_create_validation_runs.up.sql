@@ -0,0 +1,18 @@
+CREATE TABLE validation_runs (
+    id               UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id        UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    collection_id    UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    created_by       UUID NOT NULL,
+    status           VARCHAR(20) NOT NULL DEFAULT 'pending',
+    total_documents  INT NOT NULL DEFAULT 0,
+    processed_count  INT NOT NULL DEFAULT 0,
+    changed_count    INT NOT NULL DEFAULT 0,
+    failed_count     INT NOT NULL DEFAULT 0,
+    changes          JSONB NOT NULL DEFAULT '[]',
+    error            TEXT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at     TIMESTAMPTZ
+);
+
+CREATE INDEX idx_validation_runs_collection ON validation_runs (tenant_id, collection_id, created_at DESC);
@@ -0,0 +1,14 @@
+CREATE TABLE confidence_observations (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id    UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    parser_model   VARCHAR(100) NOT NULL DEFAULT '',
+    document_type  VARCHAR(50) NOT NULL,
+    field_path     VARCHAR(255) NOT NULL,
+    confidence     DOUBLE PRECISION NOT NULL,
+    corrected      BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (document_id, field_path)
+);
+
+CREATE INDEX idx_confidence_observations_tenant ON confidence_observations (tenant_id, created_at);
@@ -0,0 +1,16 @@
+CREATE TABLE authz_denials (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL,
+    role        VARCHAR(20) NOT NULL DEFAULT '',
+    method      VARCHAR(10) NOT NULL,
+    path        TEXT NOT NULL,
+    route       TEXT NOT NULL DEFAULT '',
+    code        VARCHAR(64) NOT NULL DEFAULT '',
+    reason      TEXT NOT NULL DEFAULT '',
+    request_id  VARCHAR(64) NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_authz_denials_tenant ON authz_denials (tenant_id, created_at DESC);
+CREATE INDEX idx_authz_denials_user ON authz_denials (tenant_id, user_id, created_at DESC);
@@ -0,0 +1,23 @@
+ALTER TABLE documents ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+CREATE TABLE document_versions (
+    id                 UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id          UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id        UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    version            INT NOT NULL,
+    file_id            UUID REFERENCES file_metadata(id) ON DELETE SET NULL,
+    parser_model       VARCHAR(100) NOT NULL DEFAULT '',
+    structured_data    JSONB NOT NULL DEFAULT '{}',
+    confidence_scores  JSONB NOT NULL DEFAULT '{}',
+    field_provenance   JSONB NOT NULL DEFAULT '{}',
+    parsing_status     VARCHAR(20) NOT NULL,
+    parsed_at          TIMESTAMPTZ,
+    review_status      VARCHAR(20) NOT NULL,
+    reviewed_by        UUID,
+    reviewed_at        TIMESTAMPTZ,
+    validation_status  VARCHAR(20) NOT NULL,
+    validation_results JSONB NOT NULL DEFAULT '[]',
+    replaced_by        UUID NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (document_id, version)
+);
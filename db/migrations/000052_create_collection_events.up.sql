@@ -0,0 +1,12 @@
+CREATE TABLE collection_events (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    collection_id  UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    user_id        UUID,
+    action         VARCHAR(50) NOT NULL,
+    target_user_id UUID,
+    changes        JSONB NOT NULL DEFAULT '{}',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_collection_events_collection ON collection_events (collection_id, created_at DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tenant_moves;
+ALTER TABLE tenants DROP COLUMN IF EXISTS db_cluster;
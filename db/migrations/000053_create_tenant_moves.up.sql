@@ -0,0 +1,25 @@
+-- Routing flag: the database cluster that serves the tenant. A server refuses
+-- tenants whose db_cluster is set and differs from its SATVOS_DB_CLUSTER; ''
+-- means the tenant has never moved and is served wherever its row lives.
+ALTER TABLE tenants ADD COLUMN db_cluster VARCHAR(63) NOT NULL DEFAULT '';
+
+-- Moves of a tenant's rows and files to another database cluster (and optionally
+-- another storage region). tables holds the per-table row counts and checksums.
+CREATE TABLE tenant_moves (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    source_cluster VARCHAR(63) NOT NULL,
+    target_cluster VARCHAR(63) NOT NULL,
+    target_region  VARCHAR(32),
+    status         VARCHAR(20) NOT NULL DEFAULT 'pending',
+    tables         JSONB NOT NULL DEFAULT '[]',
+    objects_total  INT NOT NULL DEFAULT 0,
+    objects_copied INT NOT NULL DEFAULT 0,
+    error          TEXT,
+    requested_by   UUID,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_tenant_moves_tenant ON tenant_moves (tenant_id, created_at DESC);
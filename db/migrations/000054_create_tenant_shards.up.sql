@@ -0,0 +1,12 @@
+-- Control table for horizontal sharding: the Postgres cluster (shard) holding a
+-- tenant group's data. A group is a tenant and its client tenants, which always
+-- share a shard, so only the group's root tenant has a row. Tenants without one
+-- live on the primary cluster, where this table is kept.
+CREATE TABLE tenant_shards (
+    tenant_id  UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    shard      VARCHAR(63) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_tenant_shards_shard ON tenant_shards (shard);
@@ -0,0 +1,10 @@
+-- Parses scheduled per UTC day against the tier's daily budget. Standard tenants
+-- are counted as a whole (user_id is the nil UUID); the free tier is one shared
+-- tenant, so it is counted per user.
+CREATE TABLE parse_budget_usage (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id   UUID NOT NULL,
+    day       DATE NOT NULL,
+    calls     INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, user_id, day)
+);
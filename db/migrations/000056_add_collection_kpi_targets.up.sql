@@ -0,0 +1,3 @@
+-- Per-collection KPI targets, e.g. 100% of documents reviewed by a date; progress
+-- against them is computed on read from the collection's documents.
+ALTER TABLE collections ADD COLUMN kpi_targets JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS export_downloads;
+DROP TABLE IF EXISTS export_artifacts;
@@ -0,0 +1,29 @@
+-- Every distinct file a collection export produced, identified by its SHA-256,
+-- so a firm can prove which data snapshot it filed. Regenerating byte-identical
+-- output adds a download to the existing artifact instead of a new row.
+CREATE TABLE export_artifacts (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id       UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    collection_id   UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    kind            VARCHAR(50) NOT NULL,
+    format          VARCHAR(10) NOT NULL,
+    filters         JSONB NOT NULL DEFAULT '{}',
+    row_count       INTEGER NOT NULL,
+    size_bytes      BIGINT NOT NULL,
+    checksum_sha256 CHAR(64) NOT NULL,
+    generated_by    UUID NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (collection_id, kind, format, checksum_sha256)
+);
+
+CREATE INDEX idx_export_artifacts_collection ON export_artifacts (collection_id, created_at DESC);
+
+CREATE TABLE export_downloads (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    artifact_id UUID NOT NULL REFERENCES export_artifacts(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_export_downloads_artifact ON export_downloads (artifact_id, created_at DESC);
@@ -0,0 +1,22 @@
+-- Per-tenant overrides of the channels each notification kind is delivered on;
+-- kinds without a row use the server's SATVOS_NOTIFICATIONS_ROUTES defaults.
+CREATE TABLE notification_routes (
+    tenant_id  UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind       VARCHAR(50) NOT NULL,
+    channels   TEXT[] NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, kind)
+);
+
+CREATE TABLE in_app_notifications (
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id  UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind       VARCHAR(50) NOT NULL,
+    subject    TEXT NOT NULL,
+    body       TEXT NOT NULL DEFAULT '',
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_in_app_notifications_user ON in_app_notifications (user_id, created_at DESC);
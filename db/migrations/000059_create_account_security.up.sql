@@ -0,0 +1,29 @@
+-- Account security audit trail: password resets and email changes per user.
+CREATE TABLE account_security_events (
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id  UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event      VARCHAR(50) NOT NULL,
+    actor_id   UUID,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    detail     TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_account_security_events_user ON account_security_events (user_id, created_at DESC);
+
+-- Email changes the old address can undo until undo_expires_at.
+CREATE TABLE email_changes (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id       UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_email       VARCHAR(255) NOT NULL,
+    new_email       VARCHAR(255) NOT NULL,
+    changed_by      UUID NOT NULL,
+    undo_expires_at TIMESTAMPTZ NOT NULL,
+    undone_at       TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_email_changes_user ON email_changes (user_id, created_at DESC);
@@ -0,0 +1,19 @@
+-- Latest result of each validation rule on each document, for long-term rule
+-- failure metrics. Rows outlive their document and rule so history survives
+-- deletions; only removing the tenant clears them.
+CREATE TABLE validation_rule_outcomes (
+    tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id    UUID NOT NULL,
+    rule_id        UUID NOT NULL,
+    document_type  VARCHAR(50) NOT NULL,
+    field_path     VARCHAR(255) NOT NULL DEFAULT '',
+    failing        BOOLEAN NOT NULL,
+    failed         BOOLEAN NOT NULL,
+    corrected      BOOLEAN NOT NULL DEFAULT FALSE,
+    overridden     BOOLEAN NOT NULL DEFAULT FALSE,
+    first_seen_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (document_id, rule_id)
+);
+
+CREATE INDEX idx_validation_rule_outcomes_tenant ON validation_rule_outcomes (tenant_id, first_seen_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS qa_reviews;
+ALTER TABLE collections DROP COLUMN IF EXISTS qa_sample_percent;
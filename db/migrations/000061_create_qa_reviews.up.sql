@@ -0,0 +1,25 @@
+-- Second-opinion QA sampling. qa_sample_percent of a collection's approvals are
+-- sampled into qa_reviews for a blind second review by someone other than the
+-- approving reviewer (or maker); disagreements score the original reviewer.
+ALTER TABLE collections
+    ADD COLUMN qa_sample_percent INT CHECK (qa_sample_percent BETWEEN 1 AND 100);
+
+CREATE TABLE qa_reviews (
+    id                 UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id          UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id        UUID NOT NULL UNIQUE REFERENCES documents(id) ON DELETE CASCADE,
+    collection_id      UUID NOT NULL,
+    reviewer_id        UUID NOT NULL,
+    maker_id           UUID,
+    status             VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed')),
+    second_reviewer_id UUID,
+    decision           VARCHAR(20) CHECK (decision IN ('approved', 'rejected')),
+    disputed_fields    JSONB NOT NULL DEFAULT '[]',
+    disagreed          BOOLEAN,
+    notes              TEXT NOT NULL DEFAULT '',
+    sampled_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at       TIMESTAMPTZ
+);
+
+CREATE INDEX idx_qa_reviews_pending ON qa_reviews (tenant_id, sampled_at) WHERE status = 'pending';
+CREATE INDEX idx_qa_reviews_reviewer ON qa_reviews (tenant_id, reviewer_id, sampled_at);
@@ -0,0 +1,9 @@
+-- Soft review locks: one row per document, held by the user reviewing it until
+-- expires_at. Holders extend it with heartbeats; an expired row is free to take.
+CREATE TABLE document_locks (
+    document_id UUID PRIMARY KEY REFERENCES documents(id) ON DELETE CASCADE,
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    acquired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ NOT NULL
+);
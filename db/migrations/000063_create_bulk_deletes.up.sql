@@ -0,0 +1,15 @@
+-- One row per bulk delete run: who deleted what, and the filter that selected it.
+CREATE TABLE bulk_deletes (
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id      UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    created_by     UUID NOT NULL,
+    filter         JSONB NOT NULL DEFAULT '{}',
+    delete_files   BOOLEAN NOT NULL DEFAULT FALSE,
+    matched_count  INT NOT NULL DEFAULT 0,
+    deleted_count  INT NOT NULL DEFAULT 0,
+    failed_count   INT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_bulk_deletes_tenant ON bulk_deletes (tenant_id, created_at DESC);
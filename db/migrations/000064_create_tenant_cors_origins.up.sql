@@ -0,0 +1,10 @@
+-- Frontend origins a tenant serves its users from (white-labeled domains), allowed
+-- by CORS in addition to the server's SATVOS_CORS_ALLOWED_ORIGINS.
+CREATE TABLE tenant_cors_origins (
+    tenant_id  UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    origin     VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, origin)
+);
+
+CREATE INDEX idx_tenant_cors_origins_origin ON tenant_cors_origins (origin);
@@ -0,0 +1,2 @@
+ALTER TABLE tenants DROP COLUMN IF EXISTS storage_kms_key_arn;
+ALTER TABLE tenants DROP COLUMN IF EXISTS storage_sse;
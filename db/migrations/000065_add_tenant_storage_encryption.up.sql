@@ -0,0 +1,6 @@
+-- Per-tenant S3 server-side encryption for objects under tenants/{id}/. NULL uses
+-- the bucket's default encryption; sse-kms encrypts with the tenant's own key.
+ALTER TABLE tenants ADD COLUMN storage_sse VARCHAR(10)
+    CHECK (storage_sse IS NULL OR storage_sse IN ('sse-s3', 'sse-kms'));
+ALTER TABLE tenants ADD COLUMN storage_kms_key_arn TEXT
+    CHECK ((storage_sse = 'sse-kms') = (storage_kms_key_arn IS NOT NULL));
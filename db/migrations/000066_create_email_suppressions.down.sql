@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_lower_email;
+ALTER TABLE users DROP COLUMN IF EXISTS email_undeliverable_at;
+ALTER TABLE users DROP COLUMN IF EXISTS email_undeliverable;
+DROP TABLE IF EXISTS email_suppressions;
@@ -0,0 +1,17 @@
+-- Addresses SES reported as permanently bouncing or complaining, reported over
+-- SNS. Nothing is sent to them until an admin clears the suppression.
+CREATE TABLE email_suppressions (
+    email       VARCHAR(255) PRIMARY KEY,
+    reason      VARCHAR(20) NOT NULL CHECK (reason IN ('bounce', 'complaint')),
+    detail      TEXT NOT NULL DEFAULT '',
+    feedback_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The suppression of the user's current address, if any, shown on the user record.
+ALTER TABLE users ADD COLUMN email_undeliverable VARCHAR(20)
+    CHECK (email_undeliverable IS NULL OR email_undeliverable IN ('bounce', 'complaint'));
+ALTER TABLE users ADD COLUMN email_undeliverable_at TIMESTAMPTZ;
+
+CREATE INDEX idx_users_lower_email ON users (LOWER(email));
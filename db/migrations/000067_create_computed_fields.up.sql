@@ -0,0 +1,13 @@
+-- Tenant-defined computed fields: expressions over a document's structured data,
+-- evaluated on read and returned under "extensions" when ?extensions=true.
+CREATE TABLE computed_fields (
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name          VARCHAR(64) NOT NULL,
+    document_type VARCHAR(50) NOT NULL DEFAULT 'invoice',
+    expression    TEXT NOT NULL,
+    description   TEXT NOT NULL DEFAULT '',
+    updated_by    UUID,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, name)
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_parse_timings_document;
+ALTER TABLE parse_timings
+    DROP COLUMN IF EXISTS attempt,
+    DROP COLUMN IF EXISTS secondary_parser_model,
+    DROP COLUMN IF EXISTS error_message,
+    DROP COLUMN IF EXISTS input_tokens,
+    DROP COLUMN IF EXISTS output_tokens;
@@ -0,0 +1,9 @@
+-- Per-attempt detail for debugging providers and costing dual parse
+ALTER TABLE parse_timings
+    ADD COLUMN attempt                INT NOT NULL DEFAULT 0,
+    ADD COLUMN secondary_parser_model VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN error_message          TEXT NOT NULL DEFAULT '',
+    ADD COLUMN input_tokens           INT NOT NULL DEFAULT 0,
+    ADD COLUMN output_tokens          INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_parse_timings_document ON parse_timings (document_id, created_at);
@@ -0,0 +1,2 @@
+-- Highest usage warning (80 or 95 percent) sent in the user's current quota period
+ALTER TABLE users ADD COLUMN quota_warning_level SMALLINT NOT NULL DEFAULT 0;
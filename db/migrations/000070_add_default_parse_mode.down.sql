@@ -0,0 +1,2 @@
+ALTER TABLE collections DROP COLUMN IF EXISTS default_parse_mode;
+ALTER TABLE tenants DROP COLUMN IF EXISTS default_parse_mode;
@@ -0,0 +1,6 @@
+-- Parse mode used when a document is created without one: the collection's,
+-- else the tenant's, else single. NULL means no default at that level.
+ALTER TABLE tenants ADD COLUMN default_parse_mode VARCHAR(20)
+    CHECK (default_parse_mode IS NULL OR default_parse_mode IN ('single', 'dual'));
+ALTER TABLE collections ADD COLUMN default_parse_mode VARCHAR(20)
+    CHECK (default_parse_mode IS NULL OR default_parse_mode IN ('single', 'dual'));
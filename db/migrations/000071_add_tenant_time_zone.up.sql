@@ -0,0 +1,4 @@
+-- IANA time zone the tenant's dates are read in (invoice timestamps, report
+-- date ranges, KPI due dates, daily report times). GST periods follow IST, so
+-- Indian tenants set Asia/Kolkata.
+ALTER TABLE tenants ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
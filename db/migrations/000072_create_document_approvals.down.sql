@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS document_approvals;
+DROP FUNCTION IF EXISTS reject_document_approval_update();
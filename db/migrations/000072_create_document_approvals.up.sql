@@ -0,0 +1,34 @@
+-- Each final approval of a document, frozen as it was approved. Later edits,
+-- re-parses and file replacements only change the documents row.
+CREATE TABLE document_approvals (
+    id                 UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id          UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id        UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    version            INT NOT NULL,
+    parser_model       VARCHAR(100) NOT NULL DEFAULT '',
+    structured_data    JSONB NOT NULL DEFAULT '{}',
+    confidence_scores  JSONB NOT NULL DEFAULT '{}',
+    field_provenance   JSONB NOT NULL DEFAULT '{}',
+    validation_status  VARCHAR(20) NOT NULL,
+    validation_results JSONB NOT NULL DEFAULT '[]',
+    review_checklist   JSONB NOT NULL DEFAULT '[]',
+    reviewer_notes     TEXT NOT NULL DEFAULT '',
+    approved_by        UUID NOT NULL,
+    approved_at        TIMESTAMPTZ NOT NULL,
+    maker_approved_by  UUID,
+    maker_approved_at  TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_document_approvals_document ON document_approvals (tenant_id, document_id, approved_at DESC);
+
+-- Rows are append-only; they go only when their document or tenant is deleted
+CREATE FUNCTION reject_document_approval_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'document_approvals rows are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER document_approvals_immutable
+    BEFORE UPDATE ON document_approvals
+    FOR EACH ROW EXECUTE FUNCTION reject_document_approval_update();
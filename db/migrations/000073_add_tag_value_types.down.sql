@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_document_tags_prefix;
+DROP INDEX IF EXISTS idx_document_tags_date;
+DROP INDEX IF EXISTS idx_document_tags_number;
+ALTER TABLE document_tags
+    DROP COLUMN IF EXISTS date_value,
+    DROP COLUMN IF EXISTS number_value,
+    DROP COLUMN IF EXISTS value_type;
@@ -0,0 +1,29 @@
+-- Typed tags: value stays the text as entered; number_value or date_value
+-- holds the parsed value of number and date tags for range search.
+ALTER TABLE document_tags
+    ADD COLUMN value_type   VARCHAR(10) NOT NULL DEFAULT 'string' CHECK (value_type IN ('string', 'number', 'date')),
+    ADD COLUMN number_value NUMERIC,
+    ADD COLUMN date_value   DATE;
+
+-- Existing auto-tags that are numbers or ISO dates become typed
+UPDATE document_tags
+   SET value_type = 'number', number_value = value::numeric
+ WHERE source = 'auto' AND key = 'total_amount' AND value ~ '^-?[0-9]+(\.[0-9]+)?$';
+
+CREATE FUNCTION pg_temp.try_date(s TEXT) RETURNS DATE AS $$
+BEGIN
+    RETURN s::date;
+EXCEPTION WHEN OTHERS THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE document_tags
+   SET value_type = 'date', date_value = pg_temp.try_date(value)
+ WHERE source = 'auto' AND key = 'invoice_date' AND value ~ '^[0-9]{4}-[0-9]{2}-[0-9]{2}$'
+   AND pg_temp.try_date(value) IS NOT NULL;
+
+CREATE INDEX idx_document_tags_number ON document_tags (tenant_id, key, number_value) WHERE number_value IS NOT NULL;
+CREATE INDEX idx_document_tags_date ON document_tags (tenant_id, key, date_value) WHERE date_value IS NOT NULL;
+-- Serves prefix search (LIKE 'abc%') whatever the database collation
+CREATE INDEX idx_document_tags_prefix ON document_tags (tenant_id, key, value varchar_pattern_ops);
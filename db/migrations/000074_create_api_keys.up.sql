@@ -0,0 +1,14 @@
+-- Long-lived credentials for scripts and satvosctl. Only a SHA-256 hash of the
+-- key is stored; the key is shown once, when it is created.
+CREATE TABLE api_keys (
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id    UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(100) NOT NULL,
+    key_prefix   VARCHAR(20) NOT NULL,
+    key_hash     CHAR(64) NOT NULL UNIQUE,
+    last_used_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_keys_user ON api_keys (tenant_id, user_id, created_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_documents_collection_name;
+
+ALTER TABLE documents DROP COLUMN IF EXISTS original_name;
+
+ALTER TABLE collections DROP COLUMN IF EXISTS naming_template;
@@ -0,0 +1,7 @@
+-- Naming templates: a collection's template renames its documents after their
+-- first parse; original_name keeps the name the document had before.
+ALTER TABLE collections ADD COLUMN naming_template VARCHAR(255);
+
+ALTER TABLE documents ADD COLUMN original_name VARCHAR(255);
+
+CREATE INDEX idx_documents_collection_name ON documents (collection_id, name);
@@ -0,0 +1,12 @@
+-- GSTINs a tenant registered as its own (other state registrations) or its
+-- group companies'. Invoices whose seller is one of them are flagged.
+CREATE TABLE related_parties (
+    tenant_id    UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    gstin        CHAR(15) NOT NULL,
+    name         VARCHAR(255) NOT NULL,
+    relationship VARCHAR(10) NOT NULL CHECK (relationship IN ('own', 'group')),
+    updated_by   UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, gstin)
+);
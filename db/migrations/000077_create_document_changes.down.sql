@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS documents_record_change ON documents;
+DROP FUNCTION IF EXISTS record_document_change();
+DROP TABLE IF EXISTS document_changes;
@@ -0,0 +1,37 @@
+-- The latest change of every document, for GET /documents/stream. A deleted
+-- document keeps its row as a tombstone. A trigger maintains it so every write
+-- counts, whichever code path makes it, and times it with the database clock.
+-- No foreign keys: tombstones outlive their document and collection.
+CREATE TABLE document_changes (
+    document_id   UUID PRIMARY KEY,
+    tenant_id     UUID NOT NULL,
+    collection_id UUID NOT NULL,
+    changed_at    TIMESTAMPTZ NOT NULL,
+    deleted       BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX idx_document_changes_tenant ON document_changes (tenant_id, changed_at, document_id);
+
+INSERT INTO document_changes (document_id, tenant_id, collection_id, changed_at)
+SELECT id, tenant_id, collection_id, updated_at FROM documents;
+
+CREATE FUNCTION record_document_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO document_changes (document_id, tenant_id, collection_id, changed_at, deleted)
+        VALUES (OLD.id, OLD.tenant_id, OLD.collection_id, clock_timestamp(), TRUE)
+        ON CONFLICT (document_id) DO UPDATE
+        SET changed_at = EXCLUDED.changed_at, deleted = TRUE;
+        RETURN OLD;
+    END IF;
+    INSERT INTO document_changes (document_id, tenant_id, collection_id, changed_at)
+    VALUES (NEW.id, NEW.tenant_id, NEW.collection_id, clock_timestamp())
+    ON CONFLICT (document_id) DO UPDATE
+    SET collection_id = EXCLUDED.collection_id, changed_at = EXCLUDED.changed_at, deleted = FALSE;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER documents_record_change
+    AFTER INSERT OR UPDATE OR DELETE ON documents
+    FOR EACH ROW EXECUTE FUNCTION record_document_change();
@@ -0,0 +1,3 @@
+-- ISO 639-1 code of the language a document was detected to be in when it was
+-- parsed ('' until then, and for documents parsed before detection).
+ALTER TABLE documents ADD COLUMN language VARCHAR(10) NOT NULL DEFAULT '';
@@ -0,0 +1,17 @@
+-- Daily snapshots of the aggregates behind each collection's data quality score,
+-- recorded by the nightly stats rebuild so the score can be followed over time.
+CREATE TABLE collection_quality_snapshots (
+    tenant_id          UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    collection_id      UUID NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    day                DATE NOT NULL,
+    total_documents    INT NOT NULL DEFAULT 0,
+    parsed_documents   INT NOT NULL DEFAULT 0,
+    confidence_sum     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    validation_valid   INT NOT NULL DEFAULT 0,
+    validation_warning INT NOT NULL DEFAULT 0,
+    review_approved    INT NOT NULL DEFAULT 0,
+    recorded_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, collection_id, day)
+);
+
+CREATE INDEX idx_collection_quality_snapshots_collection ON collection_quality_snapshots (collection_id, day);
@@ -0,0 +1 @@
+ALTER TABLE integration_hooks DROP COLUMN IF EXISTS payload_template;
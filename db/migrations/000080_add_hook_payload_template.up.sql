@@ -0,0 +1,3 @@
+-- Go text/template rendering the body a hook posts over the flat document;
+-- NULL posts the flat document itself.
+ALTER TABLE integration_hooks ADD COLUMN payload_template TEXT;
@@ -0,0 +1 @@
+ALTER TABLE collections DROP COLUMN IF EXISTS file_download_perm;
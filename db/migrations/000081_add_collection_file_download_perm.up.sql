@@ -0,0 +1,4 @@
+-- Original file access: the collection permission needed to download the
+-- original files of a collection's documents; NULL lets anyone who can view
+-- the collection download them.
+ALTER TABLE collections ADD COLUMN file_download_perm VARCHAR(20);
@@ -0,0 +1,2 @@
+ALTER TABLE document_versions DROP COLUMN IF EXISTS reason;
+ALTER TABLE document_versions DROP COLUMN IF EXISTS document_type;
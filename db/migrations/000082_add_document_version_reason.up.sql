@@ -0,0 +1,9 @@
+-- Reclassification: a version also records the document type it was parsed as
+-- and why it was superseded (a new file or a new document type).
+ALTER TABLE document_versions ADD COLUMN document_type VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE document_versions ADD COLUMN reason VARCHAR(20) NOT NULL DEFAULT 'file_replaced';
+
+-- Before this migration a version only ever changed the file, so the type is
+-- still the document's own
+UPDATE document_versions v SET document_type = d.document_type
+  FROM documents d WHERE d.id = v.document_id;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_document_versions_file;
+DROP TABLE IF EXISTS collection_orphan_nudges;
@@ -0,0 +1,11 @@
+-- The last reminder sent to a collection's owner about files uploaded to it that
+-- never became documents, so each collection is nudged at most once a week.
+CREATE TABLE collection_orphan_nudges (
+    collection_id UUID PRIMARY KEY REFERENCES collections(id) ON DELETE CASCADE,
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    file_count    INT NOT NULL,
+    nudged_at     TIMESTAMPTZ NOT NULL
+);
+
+-- Orphan detection skips files a document has since been moved off
+CREATE INDEX idx_document_versions_file ON document_versions (file_id) WHERE file_id IS NOT NULL;
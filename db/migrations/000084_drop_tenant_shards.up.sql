@@ -0,0 +1,3 @@
+-- Shard placement is read from tenants.db_cluster, the routing flag that servers
+-- already enforce and cmd/tenantmove sets, so the separate control table goes.
+DROP TABLE IF EXISTS tenant_shards;
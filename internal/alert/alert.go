@@ -0,0 +1,84 @@
+// Package alert delivers operational alerts by webhook and email.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"satvos/internal/port"
+)
+
+type webhookSender struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSender creates an AlertSender that POSTs each alert as JSON to url.
+func NewWebhookSender(url string) port.AlertSender {
+	return &webhookSender{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *webhookSender) SendAlert(ctx context.Context, alert *port.Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("encoding alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling alert webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type emailSender struct {
+	sender     port.EmailSender
+	recipients []string
+}
+
+// NewEmailSender creates an AlertSender that emails each alert to recipients.
+func NewEmailSender(sender port.EmailSender, recipients []string) port.AlertSender {
+	return &emailSender{sender: sender, recipients: recipients}
+}
+
+func (e *emailSender) SendAlert(ctx context.Context, alert *port.Alert) error {
+	var errs []error
+	for _, to := range e.recipients {
+		if err := e.sender.SendAlertEmail(ctx, to, alert.Subject, alert.Message); err != nil {
+			errs = append(errs, fmt.Errorf("emailing %s: %w", to, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type multiSender []port.AlertSender
+
+// Multi fans each alert out to every sender, returning the combined errors.
+func Multi(senders ...port.AlertSender) port.AlertSender {
+	return multiSender(senders)
+}
+
+func (m multiSender) SendAlert(ctx context.Context, alert *port.Alert) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.SendAlert(ctx, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,199 @@
+// Package apierror is the catalogue of API error codes and the writer that
+// renders them, either in the standard response envelope or as RFC 7807 problem
+// details.
+package apierror
+
+import (
+	"net/http"
+	"sort"
+)
+
+// CatalogueBasePath is where the catalogue is served; each code's docs URL and
+// problem type is CatalogueBasePath + "/" + code.
+const CatalogueBasePath = "/api/v1/errors"
+
+// Entry describes one error code. Codes, statuses and retryable flags are stable:
+// clients branch on them, so change them only with a deprecation.
+type Entry struct {
+	Code   string `json:"code"`
+	Status int    `json:"status"`
+	// Title is the type-level summary; individual responses may carry a more
+	// specific message.
+	Title string `json:"title"`
+	// Retryable reports whether the same request may succeed later without changes.
+	Retryable bool   `json:"retryable"`
+	DocsURL   string `json:"docs_url"`
+}
+
+// entries lists every code the API returns. Keep it in sync with ERROR_CODES.md;
+// TestCatalogue_MatchesErrorCodesDoc fails otherwise.
+var entries = []Entry{
+	{Code: "ASSIGNEE_CANNOT_REVIEW", Status: http.StatusBadRequest, Title: "assignee does not have review permission on this collection"},
+	{Code: "BULK_DELETE_UNCONFIRMED", Status: http.StatusConflict, Title: "confirmation token does not match the documents the filter selects; run a new dry run"},
+	{Code: "CHECKER_NOT_ALLOWED", Status: http.StatusForbidden, Title: "confirming an approval requires manager role or collection owner permission"},
+	{Code: "CHECKER_SAME_AS_MAKER", Status: http.StatusForbidden, Title: "the checker must be a different user from the maker"},
+	{Code: "CLOUD_AUTH_FAILED", Status: http.StatusBadGateway, Title: "cloud storage provider rejected the authorization; reconnect the account"},
+	{Code: "CLOUD_PROVIDER_NOT_CONFIGURED", Status: http.StatusNotFound, Title: "cloud storage provider is not configured"},
+	{Code: "COLLECTION_NOT_FOUND", Status: http.StatusNotFound, Title: "collection not found"},
+	{Code: "COLLECTION_PERMISSION_DENIED", Status: http.StatusForbidden, Title: "insufficient collection permission"},
+	{Code: "DATA_RESIDENCY_VIOLATION", Status: http.StatusConflict, Title: "the tenant's storage region is unavailable or does not hold this object"},
+	{Code: "DELEGATION_NOT_FOUND", Status: http.StatusNotFound, Title: "no review delegation is set"},
+	{Code: "DEMO_DATA_EXISTS", Status: http.StatusConflict, Title: "the tenant already has demo data; remove it before seeding again"},
+	{Code: "DOCUMENT_ALREADY_EXISTS", Status: http.StatusConflict, Title: "document already exists for this file"},
+	{Code: "DOCUMENT_LOCKED", Status: http.StatusLocked, Title: "document is being reviewed by another user; try again when their lock expires", Retryable: true},
+	{Code: "DOCUMENT_MODIFIED", Status: http.StatusConflict, Title: "document was changed since it was read; reload it and retry"},
+	{Code: "DOCUMENT_NOT_FOUND", Status: http.StatusNotFound, Title: "document not found"},
+	{Code: "DOCUMENT_NOT_PARSED", Status: http.StatusBadRequest, Title: "document has not been parsed yet"},
+	{Code: "DOCUMENT_PARSE_IN_PROGRESS", Status: http.StatusConflict, Title: "document is still being parsed"},
+	{Code: "DUPLICATE_CLOUD_SYNC", Status: http.StatusConflict, Title: "folder is already synced into this collection"},
+	{Code: "DUPLICATE_COLLECTION_FILE", Status: http.StatusConflict, Title: "file already exists in collection"},
+	{Code: "DUPLICATE_EMAIL", Status: http.StatusConflict, Title: "email already exists for this tenant"},
+	{Code: "DUPLICATE_SLUG", Status: http.StatusConflict, Title: "tenant slug already exists"},
+	{Code: "EMAIL_NOT_VERIFIED", Status: http.StatusForbidden, Title: "please verify your email before performing this action"},
+	{Code: "EMAIL_SUPPRESSION_SHARED", Status: http.StatusConflict, Title: "users of other tenants also have this email address, so its suppression can only be cleared by support"},
+	{Code: "EMAIL_UNDELIVERABLE", Status: http.StatusConflict, Title: "this email address bounced or reported a complaint, so email to it is suppressed; an admin can clear it once the mailbox is fixed"},
+	{Code: "FAULT_INJECTION_DISABLED", Status: http.StatusNotFound, Title: "fault injection is not enabled on this instance"},
+	{Code: "FEATURE_DISABLED", Status: http.StatusForbidden, Title: "feature is not enabled for this tenant"},
+	{Code: "FILE_CONTENT_MISMATCH", Status: http.StatusBadRequest, Title: "file content does not match its declared type"},
+	{Code: "FILE_DOWNLOAD_RESTRICTED", Status: http.StatusForbidden, Title: "downloading the original file needs a higher permission on a collection holding it"},
+	{Code: "FILE_READ_ERROR", Status: http.StatusBadRequest, Title: "failed to read uploaded file"},
+	{Code: "FILE_TOO_LARGE", Status: http.StatusRequestEntityTooLarge, Title: "file exceeds maximum allowed size"},
+	{Code: "FORBIDDEN", Status: http.StatusForbidden, Title: "forbidden"},
+	{Code: "INSUFFICIENT_ROLE", Status: http.StatusForbidden, Title: "insufficient role for this action"},
+	{Code: "INTERNAL_ERROR", Status: http.StatusInternalServerError, Title: "an internal error occurred", Retryable: true},
+	{Code: "INVALID_AMOUNT_SEARCH", Status: http.StatusBadRequest, Title: "value must be a positive amount and tolerance a non-negative amount or a percentage up to 100%"},
+	{Code: "INVALID_ARCHIVE", Status: http.StatusBadRequest, Title: "file is not a valid ZIP archive"},
+	{Code: "INVALID_BULK_DELETE", Status: http.StatusBadRequest, Title: "invalid bulk delete request; the filter needs at least one criterion and may match at most 1000 documents, and a real run needs the confirmation_token from a dry run"},
+	{Code: "INVALID_BULK_TAG", Status: http.StatusBadRequest, Title: "invalid bulk tag request; action must be add or remove, key 1-100 characters, and the filter needs at least one criterion"},
+	{Code: "INVALID_CLIENT_TENANT", Status: http.StatusBadRequest, Title: "client tenants can't have clients of their own"},
+	{Code: "INVALID_COMPUTED_FIELD", Status: http.StatusBadRequest, Title: "invalid computed field; check the name, document type and expression"},
+	{Code: "INVALID_CORS_ORIGINS", Status: http.StatusBadRequest, Title: "origins must be at most 20 http(s) origins of the form scheme://host[:port]"},
+	{Code: "INVALID_CREDENTIALS", Status: http.StatusUnauthorized, Title: "invalid credentials"},
+	{Code: "INVALID_CURSOR", Status: http.StatusBadRequest, Title: "cursor is invalid; use next_cursor from an earlier response"},
+	{Code: "INVALID_DELEGATION", Status: http.StatusBadRequest, Title: "invalid review delegation; the delegate must be another active reviewer and the range must end today or later (max 180 days)"},
+	{Code: "INVALID_DEMO_OWNER", Status: http.StatusBadRequest, Title: "demo data owner must be an active user of the tenant"},
+	{Code: "INVALID_DOCUMENT_LIMIT", Status: http.StatusBadRequest, Title: "monthly_document_limit must be 0 (unlimited) or more"},
+	{Code: "INVALID_DOCUMENT_TYPE", Status: http.StatusBadRequest, Title: "document_type must be 1 to 50 characters and differ from the current type"},
+	{Code: "INVALID_DPI", Status: http.StatusBadRequest, Title: "dpi is outside the allowed range"},
+	{Code: "INVALID_ESCALATION_POLICY", Status: http.StatusBadRequest, Title: "after_days must be between 1 and 365 or null, and action flag or reassign"},
+	{Code: "INVALID_EXPORT_DESTINATION", Status: http.StatusBadRequest, Title: "destination must be s3://bucket/prefix; deployment buckets only under tenants/{tenant_id}/"},
+	{Code: "INVALID_EXPORT_INTERVAL", Status: http.StatusBadRequest, Title: "interval_hours must be between 1 and 168"},
+	{Code: "INVALID_HOOK_EVENT", Status: http.StatusBadRequest, Title: "unsupported hook event; supported: document.approved"},
+	{Code: "INVALID_HOOK_TARGET", Status: http.StatusBadRequest, Title: "target_url must be an https URL with a public host name"},
+	{Code: "INVALID_HOOK_TEMPLATE", Status: http.StatusBadRequest, Title: "payload_template must be a Go template of at most 8000 characters that renders valid JSON"},
+	{Code: "INVALID_ID", Status: http.StatusBadRequest, Title: "invalid ID"},
+	{Code: "INVALID_IMPORT_PREFIX", Status: http.StatusBadRequest, Title: "prefix must be a relative path inside the tenant inbox"},
+	{Code: "INVALID_JSON_PATCH", Status: http.StatusBadRequest, Title: "invalid JSON patch; use RFC 6902 operations add, remove, replace, move, copy or test with JSON Pointer paths"},
+	{Code: "INVALID_KPI_TARGETS", Status: http.StatusBadRequest, Title: "metric must be parsed, reviewed or approved, target_pct between 0 and 100, due_date YYYY-MM-DD, at most 10 targets"},
+	{Code: "INVALID_LINE_ITEM_PATCH", Status: http.StatusBadRequest, Title: "invalid line item patch; ops must be add, update or delete, update and delete need the index of a stored line item, and fields must be line item fields"},
+	{Code: "INVALID_LOCK_TTL", Status: http.StatusBadRequest, Title: "ttl_seconds must be between 15 and 600"},
+	{Code: "INVALID_MEMBERSHIP", Status: http.StatusBadRequest, Title: "invalid tenant membership"},
+	{Code: "INVALID_MOVE_TARGET", Status: http.StatusBadRequest, Title: "target_cluster is not a configured move target of this cluster"},
+	{Code: "INVALID_NAMING_TEMPLATE", Status: http.StatusBadRequest, Title: "naming_template must use known {field} placeholders and at most 200 characters"},
+	{Code: "INVALID_NEIGHBOR_CONTEXT", Status: http.StatusBadRequest, Title: "context must be review-queue or collection"},
+	{Code: "INVALID_NOTIFICATION_CHANNEL", Status: http.StatusBadRequest, Title: "invalid notification channel; check provider, webhook_url, events and review_sla_hours"},
+	{Code: "INVALID_NOTIFICATION_ROUTES", Status: http.StatusBadRequest, Title: "routes must map known kinds to distinct configured channels; email_verification and password_reset must include email"},
+	{Code: "INVALID_NOTIFICATION_TEMPLATE", Status: http.StatusBadRequest, Title: "a message template is not a valid template for its event"},
+	{Code: "INVALID_OAUTH_STATE", Status: http.StatusBadRequest, Title: "invalid or expired OAuth state"},
+	{Code: "INVALID_PAGE", Status: http.StatusBadRequest, Title: "page must be a positive integer"},
+	{Code: "INVALID_PARSE_MODE", Status: http.StatusBadRequest, Title: "parse_mode must be single or dual"},
+	{Code: "INVALID_PERMISSION", Status: http.StatusBadRequest, Title: "invalid collection permission; allowed: owner, editor, viewer"},
+	{Code: "INVALID_PROPOSED_RULE", Status: http.StatusBadRequest, Title: "invalid proposed rule; give a known builtin_rule_key, or rule_type required_field or regex with rule_config.field_path naming a string field (and a valid pattern for regex), and severity error or warning"},
+	{Code: "INVALID_QA_SAMPLING", Status: http.StatusBadRequest, Title: "sample_percent must be between 1 and 100 or null"},
+	{Code: "INVALID_QA_VERDICT", Status: http.StatusBadRequest, Title: "decision must be approved or rejected, with at most 50 disputed field paths and 2000 characters of notes"},
+	{Code: "INVALID_REJECTION_REASON", Status: http.StatusBadRequest, Title: "invalid rejection reason; use an active code from GET /rejection-reasons"},
+	{Code: "INVALID_RELATED_PARTY", Status: http.StatusBadRequest, Title: "related party needs a 15-character GSTIN, a name of at most 255 characters and relationship own or group"},
+	{Code: "INVALID_REQUEST", Status: http.StatusBadRequest, Title: "invalid request"},
+	{Code: "INVALID_RESET_TOKEN", Status: http.StatusUnauthorized, Title: "password reset token is invalid or has already been used"},
+	{Code: "INVALID_REVIEW_CHECKLIST", Status: http.StatusBadRequest, Title: "invalid review checklist; items need a unique id and a label (max 25)"},
+	{Code: "INVALID_SNS_MESSAGE", Status: http.StatusBadRequest, Title: "the request is not a correctly signed SNS message from the configured topic"},
+	{Code: "INVALID_SOCIAL_TOKEN", Status: http.StatusUnauthorized, Title: "social authentication token is invalid or expired"},
+	{Code: "INVALID_SOURCE_URL", Status: http.StatusBadRequest, Title: "url must be a public https URL on an allowed host"},
+	{Code: "INVALID_STORAGE_ENCRYPTION", Status: http.StatusBadRequest, Title: "storage_sse must be sse-s3 or sse-kms, and storage_kms_key_arn a KMS key or alias ARN given only with sse-kms"},
+	{Code: "INVALID_STORAGE_LIFECYCLE", Status: http.StatusBadRequest, Title: "storage_ia_after_days must be 0 or at least 30"},
+	{Code: "INVALID_STORAGE_REGION", Status: http.StatusBadRequest, Title: "storage region is not configured on this deployment"},
+	{Code: "INVALID_STRUCTURED_DATA", Status: http.StatusBadRequest, Title: "structured data does not match expected format"},
+	{Code: "INVALID_TAG_SEARCH", Status: http.StatusBadRequest, Title: "search needs key and exactly one of value, prefix or a min/max range of numbers or YYYY-MM-DD dates"},
+	{Code: "INVALID_TAG_VALUE", Status: http.StatusBadRequest, Title: "tag type must be string, number or date, and number and date tags need a number or YYYY-MM-DD value"},
+	{Code: "INVALID_TIME_ZONE", Status: http.StatusBadRequest, Title: "time_zone must be an IANA time zone such as Asia/Kolkata"},
+	{Code: "INVALID_UNDO_TOKEN", Status: http.StatusUnauthorized, Title: "email change undo link is invalid, expired or already used"},
+	{Code: "INVALID_USER_IMPORT", Status: http.StatusBadRequest, Title: "the CSV needs a header row with name, email and role columns, and 1 to 500 rows"},
+	{Code: "JSON_PATCH_CONFLICT", Status: http.StatusConflict, Title: "JSON patch does not apply to the current structured data; reload the document and retry"},
+	{Code: "MAINTENANCE", Status: http.StatusServiceUnavailable, Title: "service is in maintenance mode; writes are temporarily disabled", Retryable: true},
+	{Code: "MISSING_FILE", Status: http.StatusBadRequest, Title: "file field is required"},
+	{Code: "MISSING_FILES", Status: http.StatusBadRequest, Title: "at least one file is required in 'files' field"},
+	{Code: "NOTIFICATION_DELIVERY_FAILED", Status: http.StatusBadGateway, Title: "the chat service did not accept the message", Retryable: true},
+	{Code: "NOT_FOUND", Status: http.StatusNotFound, Title: "resource not found"},
+	{Code: "PAGE_NOT_FOUND", Status: http.StatusNotFound, Title: "the file has no such page"},
+	{Code: "PAGE_RENDER_UNAVAILABLE", Status: http.StatusServiceUnavailable, Title: "page images are not available on this server"},
+	{Code: "PARSER_UNAVAILABLE", Status: http.StatusServiceUnavailable, Title: "the document parser is busy; try again shortly", Retryable: true},
+	{Code: "PARSE_BUDGET_EXHAUSTED", Status: http.StatusPaymentRequired, Title: "daily parse budget exhausted; upgrade your plan for more parses, or try again after midnight UTC"},
+	{Code: "PARSE_FAILED", Status: http.StatusUnprocessableEntity, Title: "the document could not be parsed"},
+	{Code: "PASSWORD_LOGIN_NOT_ALLOWED", Status: http.StatusBadRequest, Title: "this account uses social login; use your social provider to sign in"},
+	{Code: "PAYLOAD_TOO_LARGE", Status: http.StatusRequestEntityTooLarge, Title: "request body exceeds the maximum allowed size"},
+	{Code: "PDF_ENCRYPTED", Status: http.StatusUnprocessableEntity, Title: "PDF is encrypted or password-protected; upload an unlocked copy"},
+	{Code: "PDF_NO_PAGES", Status: http.StatusUnprocessableEntity, Title: "PDF has no pages"},
+	{Code: "QA_OWN_REVIEW", Status: http.StatusForbidden, Title: "the second reviewer must not have approved the document"},
+	{Code: "QA_REVIEW_COMPLETED", Status: http.StatusConflict, Title: "QA review already has a verdict"},
+	{Code: "QA_REVIEW_NOT_FOUND", Status: http.StatusNotFound, Title: "QA review not found"},
+	{Code: "QUOTA_EXCEEDED", Status: http.StatusTooManyRequests, Title: "monthly document quota exceeded; upgrade for more"},
+	{Code: "RATE_LIMITED", Status: http.StatusTooManyRequests, Title: "too many requests; try again later", Retryable: true},
+	{Code: "REJECTION_REASON_REQUIRED", Status: http.StatusBadRequest, Title: "rejecting a document requires a reason_code"},
+	{Code: "REVIEW_CHECKLIST_INCOMPLETE", Status: http.StatusBadRequest, Title: "every review checklist item must be checked before approving"},
+	{Code: "SELF_PERMISSION_REMOVAL", Status: http.StatusBadRequest, Title: "cannot remove your own permission"},
+	{Code: "SOURCE_FETCH_FAILED", Status: http.StatusBadGateway, Title: "could not download the file from the url", Retryable: true},
+	{Code: "STORAGE_REGION_LOCKED", Status: http.StatusConflict, Title: "storage region cannot change once the tenant has files"},
+	{Code: "STORAGE_UNAVAILABLE", Status: http.StatusServiceUnavailable, Title: "object storage is temporarily unavailable; try again shortly", Retryable: true},
+	{Code: "TENANT_ACCESS_DENIED", Status: http.StatusForbidden, Title: "no access to this tenant"},
+	{Code: "TENANT_HAS_CLIENTS", Status: http.StatusConflict, Title: "tenant still has client tenants; delete them first"},
+	{Code: "TENANT_INACTIVE", Status: http.StatusForbidden, Title: "tenant is inactive"},
+	{Code: "TENANT_MOVED", Status: http.StatusMisdirectedRequest, Title: "tenant has moved to another database cluster"},
+	{Code: "TENANT_MOVE_IN_PROGRESS", Status: http.StatusConflict, Title: "a move of this tenant is already in progress"},
+	{Code: "TENANT_NOT_MOVABLE", Status: http.StatusConflict, Title: "tenant has a parent tenant, client tenants or guest memberships and cannot be moved"},
+	{Code: "UNAUTHORIZED", Status: http.StatusUnauthorized, Title: "unauthorized"},
+	{Code: "UNKNOWN_FEATURE_FLAG", Status: http.StatusBadRequest, Title: "unknown feature flag"},
+	{Code: "UNSUPPORTED_FILE_TYPE", Status: http.StatusBadRequest, Title: "unsupported file type; allowed: pdf, jpg, png, tiff"},
+	{Code: "UPLOAD_FAILED", Status: http.StatusInternalServerError, Title: "file upload to storage failed", Retryable: true},
+	{Code: "USER_INACTIVE", Status: http.StatusForbidden, Title: "user is inactive"},
+	{Code: "VALIDATION_ERROR", Status: http.StatusBadRequest, Title: "request validation failed"},
+	{Code: "VALIDATION_RULE_NOT_FOUND", Status: http.StatusNotFound, Title: "validation rule not found"},
+	{Code: "VALIDATION_RUN_IN_PROGRESS", Status: http.StatusConflict, Title: "a validation run is already in progress for this collection", Retryable: true},
+	{Code: "VERIFICATION_RESEND_TOO_SOON", Status: http.StatusTooManyRequests, Title: "a verification email was sent recently; try again later", Retryable: true},
+}
+
+var byCode = func() map[string]Entry {
+	m := make(map[string]Entry, len(entries))
+	for i := range entries {
+		entries[i].DocsURL = DocsURL(entries[i].Code)
+		m[entries[i].Code] = entries[i]
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Code < entries[j].Code })
+	return m
+}()
+
+// DocsURL returns the catalogue URL of a code.
+func DocsURL(code string) string {
+	return CatalogueBasePath + "/" + code
+}
+
+// Catalogue returns every entry, sorted by code.
+func Catalogue() []Entry {
+	out := make([]Entry, len(entries))
+	copy(out, entries)
+	return out
+}
+
+// Lookup returns the entry for code.
+func Lookup(code string) (Entry, bool) {
+	e, ok := byCode[code]
+	return e, ok
+}
+
+// retryable reports the catalogue's retryable flag. Codes missing from the
+// catalogue fall back to the status: 429 and 503 are worth retrying.
+func retryable(code string, status int) bool {
+	if e, ok := byCode[code]; ok {
+		return e.Retryable
+	}
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
@@ -0,0 +1,119 @@
+package apierror
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the RFC 7807 media type. Clients that list it in Accept
+// get problem details instead of the standard envelope.
+const ProblemContentType = "application/problem+json"
+
+// Context keys under which Respond stores the code and message it sent, for
+// middleware that inspects the outcome after the handler chain returns.
+const (
+	ContextKeyCode    = "error_code"
+	ContextKeyMessage = "error_message"
+)
+
+// FieldError describes one invalid field of a request body. Field is the JSON
+// path of the field (e.g. "items[0].id"); Code is one of required,
+// invalid_uuid, invalid_type, invalid_format, out_of_range or invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Body is the error object of the standard response envelope.
+type Body struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	Retryable bool         `json:"retryable"`
+	DocsURL   string       `json:"docs_url"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// Problem is an RFC 7807 problem details object. Code, Retryable, RequestID and
+// Errors are extension members.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	Retryable bool         `json:"retryable"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+type envelope struct {
+	Success bool  `json:"success"`
+	Error   *Body `json:"error"`
+}
+
+// Respond writes an error response for code in the format the client accepts.
+func Respond(c *gin.Context, status int, code, msg string) {
+	RespondFields(c, status, code, msg, nil)
+}
+
+// RespondFields is Respond with per-field details, rendered as the errors array
+// of the envelope or of the problem details.
+func RespondFields(c *gin.Context, status int, code, msg string, fields []FieldError) {
+	c.Set(ContextKeyCode, code)
+	c.Set(ContextKeyMessage, msg)
+	if !wantsProblem(c.GetHeader("Accept")) {
+		c.JSON(status, envelope{Error: &Body{
+			Code: code, Message: msg, Retryable: retryable(code, status), DocsURL: DocsURL(code), Errors: fields,
+		}})
+		return
+	}
+
+	title := http.StatusText(status)
+	if e, ok := byCode[code]; ok {
+		title = e.Title
+	}
+	requestID, _ := c.Get("request_id")
+	requestIDStr, _ := requestID.(string)
+	c.Render(status, problemRender{Problem{
+		Type: DocsURL(code), Title: title, Status: status, Detail: msg,
+		Instance: c.Request.URL.Path, Code: code, Retryable: retryable(code, status), RequestID: requestIDStr,
+		Errors: fields,
+	}})
+}
+
+// Abort is Respond for middleware: it also stops the handler chain.
+func Abort(c *gin.Context, status int, code, msg string) {
+	c.Abort()
+	Respond(c, status, code, msg)
+}
+
+// wantsProblem reports whether the Accept header lists application/problem+json.
+func wantsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mediaType == ProblemContentType {
+			return true
+		}
+	}
+	return false
+}
+
+// problemRender writes a Problem as JSON with the problem+json content type,
+// which gin's JSON renderer would replace with application/json.
+type problemRender struct {
+	problem Problem
+}
+
+func (r problemRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return json.NewEncoder(w).Encode(r.problem)
+}
+
+func (r problemRender) WriteContentType(w http.ResponseWriter) {
+	w.Header()["Content-Type"] = []string{ProblemContentType}
+}
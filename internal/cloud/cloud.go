@@ -0,0 +1,149 @@
+// Package cloud holds helpers shared by the cloud storage import providers.
+package cloud
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// tokenResponse is the RFC 6749 token endpoint response shared by Google and Dropbox.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	AccountID    string `json:"account_id"`
+}
+
+// ExchangeToken posts form to an OAuth token endpoint. Provider rejections
+// (4xx) are reported as domain.ErrCloudAuthFailed.
+func ExchangeToken(ctx context.Context, client *http.Client, tokenURL string, form url.Values) (*port.CloudToken, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling token endpoint: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return nil, domain.ErrCloudAuthFailed
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var tr tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, fmt.Errorf("decoding token response: %w", err)
+	}
+	if tr.AccessToken == "" {
+		return nil, domain.ErrCloudAuthFailed
+	}
+	return &port.CloudToken{
+		AccessToken:  tr.AccessToken,
+		RefreshToken: tr.RefreshToken,
+		Expiry:       time.Now().UTC().Add(time.Duration(tr.ExpiresIn) * time.Second),
+		AccountID:    tr.AccountID,
+	}, nil
+}
+
+// CheckStatus converts a provider API response status into an error. 401 means
+// the grant was revoked or the token is stale.
+func CheckStatus(resp *http.Response, op string) error {
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return domain.ErrCloudAuthFailed
+	case resp.StatusCode == http.StatusNotFound:
+		return domain.ErrNotFound
+	case resp.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// ReadLimited reads r fully, failing with domain.ErrFileTooLarge past maxBytes.
+func ReadLimited(r io.Reader, maxBytes int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, domain.ErrFileTooLarge
+	}
+	return data, nil
+}
+
+// TokenSealer encrypts OAuth tokens at rest with AES-256-GCM.
+type TokenSealer struct {
+	aead cipher.AEAD
+}
+
+// NewTokenSealer derives an AES-256 key from secret.
+func NewTokenSealer(secret string) (*TokenSealer, error) {
+	if secret == "" {
+		return nil, errors.New("token encryption key is empty")
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenSealer{aead: aead}, nil
+}
+
+// Seal encrypts plaintext and returns it base64-encoded with the nonce prepended.
+func (s *TokenSealer) Seal(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+	sealed := s.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open reverses Seal.
+func (s *TokenSealer) Open(sealed string) (string, error) {
+	if sealed == "" {
+		return "", nil
+	}
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", fmt.Errorf("decoding sealed token: %w", err)
+	}
+	n := s.aead.NonceSize()
+	if len(data) < n {
+		return "", errors.New("sealed token is too short")
+	}
+	plain, err := s.aead.Open(nil, data[:n], data[n:], nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypting token: %w", err)
+	}
+	return string(plain), nil
+}
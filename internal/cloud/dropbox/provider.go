@@ -0,0 +1,181 @@
+// Package dropbox implements port.CloudDriveProvider for Dropbox.
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"satvos/internal/cloud"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// Endpoints holds the Dropbox URLs the provider calls. Overridable for tests.
+type Endpoints struct {
+	AuthURL    string
+	TokenURL   string
+	APIURL     string // RPC base, e.g. https://api.dropboxapi.com/2
+	ContentURL string // Content base, e.g. https://content.dropboxapi.com/2
+}
+
+// DefaultEndpoints are the production Dropbox endpoints.
+var DefaultEndpoints = Endpoints{
+	AuthURL:    "https://www.dropbox.com/oauth2/authorize",
+	TokenURL:   "https://api.dropboxapi.com/oauth2/token",
+	APIURL:     "https://api.dropboxapi.com/2",
+	ContentURL: "https://content.dropboxapi.com/2",
+}
+
+// Provider is a read-only Dropbox client.
+type Provider struct {
+	appKey      string
+	appSecret   string
+	redirectURL string
+	endpoints   Endpoints
+	httpClient  *http.Client
+}
+
+// NewProvider creates a Dropbox provider using the production endpoints.
+func NewProvider(appKey, appSecret, redirectURL string) *Provider {
+	return NewProviderWithEndpoints(appKey, appSecret, redirectURL, DefaultEndpoints)
+}
+
+// NewProviderWithEndpoints creates a Dropbox provider with custom endpoints.
+func NewProviderWithEndpoints(appKey, appSecret, redirectURL string, endpoints Endpoints) *Provider {
+	return &Provider{
+		appKey:      appKey,
+		appSecret:   appSecret,
+		redirectURL: redirectURL,
+		endpoints:   endpoints,
+		httpClient:  &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (p *Provider) Provider() domain.CloudProvider {
+	return domain.CloudProviderDropbox
+}
+
+func (p *Provider) AuthCodeURL(state string) string {
+	q := url.Values{
+		"client_id":         {p.appKey},
+		"redirect_uri":      {p.redirectURL},
+		"response_type":     {"code"},
+		"token_access_type": {"offline"},
+		"state":             {state},
+	}
+	return p.endpoints.AuthURL + "?" + q.Encode()
+}
+
+func (p *Provider) Exchange(ctx context.Context, code string) (*port.CloudToken, error) {
+	return cloud.ExchangeToken(ctx, p.httpClient, p.endpoints.TokenURL, url.Values{
+		"code":          {code},
+		"client_id":     {p.appKey},
+		"client_secret": {p.appSecret},
+		"redirect_uri":  {p.redirectURL},
+		"grant_type":    {"authorization_code"},
+	})
+}
+
+func (p *Provider) Refresh(ctx context.Context, refreshToken string) (*port.CloudToken, error) {
+	return cloud.ExchangeToken(ctx, p.httpClient, p.endpoints.TokenURL, url.Values{
+		"refresh_token": {refreshToken},
+		"client_id":     {p.appKey},
+		"client_secret": {p.appSecret},
+		"grant_type":    {"refresh_token"},
+	})
+}
+
+type listFolderResult struct {
+	Entries []struct {
+		Tag  string `json:".tag"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Size int64  `json:"size"`
+	} `json:"entries"`
+	Cursor  string `json:"cursor"`
+	HasMore bool   `json:"has_more"`
+}
+
+func (p *Provider) ListFolder(ctx context.Context, accessToken, folderID string) ([]port.CloudFile, error) {
+	// Dropbox addresses the root as "" and accepts "id:..." identifiers as paths.
+	var page listFolderResult
+	if err := p.rpc(ctx, accessToken, "/files/list_folder", map[string]interface{}{"path": folderID}, &page); err != nil {
+		return nil, fmt.Errorf("dropbox.ListFolder: %w", err)
+	}
+
+	var files []port.CloudFile
+	for {
+		for _, e := range page.Entries {
+			if e.Tag != "file" && e.Tag != "folder" {
+				continue
+			}
+			files = append(files, port.CloudFile{
+				ID:       e.ID,
+				Name:     e.Name,
+				Size:     e.Size,
+				IsFolder: e.Tag == "folder",
+			})
+		}
+		if !page.HasMore {
+			return files, nil
+		}
+		cursor := page.Cursor
+		page = listFolderResult{}
+		if err := p.rpc(ctx, accessToken, "/files/list_folder/continue", map[string]string{"cursor": cursor}, &page); err != nil {
+			return nil, fmt.Errorf("dropbox.ListFolder: %w", err)
+		}
+	}
+}
+
+func (p *Provider) Download(ctx context.Context, accessToken, fileID string, maxBytes int64) ([]byte, error) {
+	arg, err := json.Marshal(map[string]string{"path": fileID})
+	if err != nil {
+		return nil, fmt.Errorf("dropbox.Download: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoints.ContentURL+"/files/download", http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("dropbox.Download: creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Dropbox-API-Arg", string(arg))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("dropbox.Download: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := cloud.CheckStatus(resp, "dropbox.Download"); err != nil {
+		return nil, err
+	}
+	return cloud.ReadLimited(resp.Body, maxBytes)
+}
+
+func (p *Provider) rpc(ctx context.Context, accessToken, endpoint string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoints.APIURL+endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := cloud.CheckStatus(resp, endpoint); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,199 @@
+// Package gdrive implements port.CloudDriveProvider for Google Drive.
+package gdrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"satvos/internal/cloud"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	folderMimeType = "application/vnd.google-apps.folder"
+	scopes         = "https://www.googleapis.com/auth/drive.readonly https://www.googleapis.com/auth/userinfo.email"
+)
+
+// Endpoints holds the Google URLs the provider calls. Overridable for tests.
+type Endpoints struct {
+	AuthURL  string
+	TokenURL string
+	APIURL   string // Drive v3 base, e.g. https://www.googleapis.com/drive/v3
+}
+
+// DefaultEndpoints are the production Google endpoints.
+var DefaultEndpoints = Endpoints{
+	AuthURL:  "https://accounts.google.com/o/oauth2/v2/auth",
+	TokenURL: "https://oauth2.googleapis.com/token",
+	APIURL:   "https://www.googleapis.com/drive/v3",
+}
+
+// Provider is a read-only Google Drive client.
+type Provider struct {
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	endpoints    Endpoints
+	httpClient   *http.Client
+}
+
+// NewProvider creates a Google Drive provider using the production endpoints.
+func NewProvider(clientID, clientSecret, redirectURL string) *Provider {
+	return NewProviderWithEndpoints(clientID, clientSecret, redirectURL, DefaultEndpoints)
+}
+
+// NewProviderWithEndpoints creates a Google Drive provider with custom endpoints.
+func NewProviderWithEndpoints(clientID, clientSecret, redirectURL string, endpoints Endpoints) *Provider {
+	return &Provider{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		endpoints:    endpoints,
+		httpClient:   &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (p *Provider) Provider() domain.CloudProvider {
+	return domain.CloudProviderGoogleDrive
+}
+
+func (p *Provider) AuthCodeURL(state string) string {
+	q := url.Values{
+		"client_id":     {p.clientID},
+		"redirect_uri":  {p.redirectURL},
+		"response_type": {"code"},
+		"scope":         {scopes},
+		"access_type":   {"offline"},
+		"prompt":        {"consent"},
+		"state":         {state},
+	}
+	return p.endpoints.AuthURL + "?" + q.Encode()
+}
+
+func (p *Provider) Exchange(ctx context.Context, code string) (*port.CloudToken, error) {
+	tok, err := cloud.ExchangeToken(ctx, p.httpClient, p.endpoints.TokenURL, url.Values{
+		"code":          {code},
+		"client_id":     {p.clientID},
+		"client_secret": {p.clientSecret},
+		"redirect_uri":  {p.redirectURL},
+		"grant_type":    {"authorization_code"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Google's token response carries no account identifier; ask Drive who we are.
+	var about struct {
+		User struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"user"`
+	}
+	if err := p.getJSON(ctx, tok.AccessToken, p.endpoints.APIURL+"/about?fields=user(emailAddress)", &about); err != nil {
+		return nil, fmt.Errorf("gdrive.Exchange: fetching account: %w", err)
+	}
+	tok.AccountID = about.User.EmailAddress
+	return tok, nil
+}
+
+func (p *Provider) Refresh(ctx context.Context, refreshToken string) (*port.CloudToken, error) {
+	return cloud.ExchangeToken(ctx, p.httpClient, p.endpoints.TokenURL, url.Values{
+		"refresh_token": {refreshToken},
+		"client_id":     {p.clientID},
+		"client_secret": {p.clientSecret},
+		"grant_type":    {"refresh_token"},
+	})
+}
+
+type fileList struct {
+	NextPageToken string `json:"nextPageToken"`
+	Files         []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		MimeType string `json:"mimeType"`
+		Size     string `json:"size"`
+	} `json:"files"`
+}
+
+func (p *Provider) ListFolder(ctx context.Context, accessToken, folderID string) ([]port.CloudFile, error) {
+	if folderID == "" {
+		folderID = "root"
+	}
+	query := fmt.Sprintf("'%s' in parents and trashed = false", strings.ReplaceAll(folderID, "'", `\'`))
+
+	var files []port.CloudFile
+	pageToken := ""
+	for {
+		q := url.Values{
+			"q":        {query},
+			"fields":   {"nextPageToken,files(id,name,mimeType,size)"},
+			"pageSize": {"1000"},
+		}
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+
+		var page fileList
+		if err := p.getJSON(ctx, accessToken, p.endpoints.APIURL+"/files?"+q.Encode(), &page); err != nil {
+			return nil, fmt.Errorf("gdrive.ListFolder: %w", err)
+		}
+		for _, f := range page.Files {
+			var size int64
+			_, _ = fmt.Sscan(f.Size, &size)
+			files = append(files, port.CloudFile{
+				ID:       f.ID,
+				Name:     f.Name,
+				Size:     size,
+				IsFolder: f.MimeType == folderMimeType,
+			})
+		}
+		if page.NextPageToken == "" {
+			return files, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (p *Provider) Download(ctx context.Context, accessToken, fileID string, maxBytes int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		p.endpoints.APIURL+"/files/"+url.PathEscape(fileID)+"?alt=media", http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("gdrive.Download: creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gdrive.Download: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := cloud.CheckStatus(resp, "gdrive.Download"); err != nil {
+		return nil, err
+	}
+	return cloud.ReadLimited(resp.Body, maxBytes)
+}
+
+func (p *Provider) getJSON(ctx context.Context, accessToken, rawURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := cloud.CheckStatus(resp, "drive api"); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -20,8 +20,175 @@ type Config struct {
 	CORS       CORSConfig
 	Queue      QueueConfig
 	FreeTier   FreeTierConfig
+	AccountSecurity AccountSecurityConfig
 	Email      EmailConfig
-	GoogleAuth GoogleAuthConfig
+	GoogleAuth  GoogleAuthConfig
+	Maintenance MaintenanceConfig
+	Limits      LimitsConfig
+	CloudImport CloudImportConfig
+	BatchFeed   BatchFeedConfig
+	AnalyticsExport AnalyticsExportConfig
+	Integrations    IntegrationsConfig
+	Notifications   NotificationsConfig
+	Escalation      EscalationConfig
+	OrphanFiles     OrphanFilesConfig
+	URLImport       URLImportConfig
+	ParsePreview    ParsePreviewConfig
+	ParseBudget     ParseBudgetConfig
+	PageImage       PageImageConfig
+	ParseSLA    ParseSLAConfig
+	Stats       StatsConfig
+	AuthzAudit  AuthzAuditConfig
+	TenantMove  TenantMoveConfig
+	Faults      FaultsConfig
+}
+
+// FaultsConfig gates the fault injection wrappers around storage, the parsers
+// and the document repository, toggled over /admin/faults. Ignored in production.
+type FaultsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// TenantMoveConfig lists the database clusters tenants can be moved to, keyed by
+// cluster name. Each target's DSN is configured here only, never over the API.
+type TenantMoveConfig struct {
+	Targets map[string]string `mapstructure:"targets"`
+}
+
+// AuthzAuditConfig controls recording of authorization denials (403 responses)
+// for GET /audit/denials.
+type AuthzAuditConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// StatsConfig holds settings for the materialized dashboard counters.
+// ReconcileHourUTC is the hour after which the nightly full rebuild runs.
+type StatsConfig struct {
+	RefreshIntervalSecs int `mapstructure:"refresh_interval_secs"`
+	ReconcileHourUTC    int `mapstructure:"reconcile_hour_utc"`
+	// CacheTTLSecs is how long stats and report results are served from memory;
+	// 0 only coalesces concurrent identical queries.
+	CacheTTLSecs int `mapstructure:"cache_ttl_secs"`
+}
+
+// ParseSLAConfig holds parse latency alerting thresholds. Alerts are sent to
+// AlertEmails and/or AlertWebhookURL; monitoring is off when neither is set.
+type ParseSLAConfig struct {
+	CheckIntervalSecs int      `mapstructure:"check_interval_secs"`
+	WindowMins        int      `mapstructure:"window_mins"`
+	MinSamples        int      `mapstructure:"min_samples"`
+	P95ParseSecs      int      `mapstructure:"p95_parse_secs"`
+	MaxQueueAgeSecs   int      `mapstructure:"max_queue_age_secs"`
+	AlertCooldownMins int      `mapstructure:"alert_cooldown_mins"`
+	AlertEmails       []string `mapstructure:"alert_emails"`
+	AlertWebhookURL   string   `mapstructure:"alert_webhook_url"`
+}
+
+// BatchFeedConfig holds settings for the S3 drop-folder batch feed worker.
+// ReportHour is the hour, in each tenant's time zone, after which the
+// previous 24h report is emailed.
+type BatchFeedConfig struct {
+	PollIntervalSecs int `mapstructure:"poll_interval_secs"`
+	ReportHour       int `mapstructure:"report_hour"`
+}
+
+// AnalyticsExportConfig holds settings for the scheduled Parquet export worker.
+// A PollIntervalSecs of 0 disables the worker.
+type AnalyticsExportConfig struct {
+	PollIntervalSecs int `mapstructure:"poll_interval_secs"`
+}
+
+// IntegrationsConfig holds settings for REST hook deliveries to no-code tools.
+// HTTP.AllowedHosts also limits the target URLs users may subscribe.
+type IntegrationsConfig struct {
+	HTTP HTTPClientConfig `mapstructure:"http"`
+}
+
+// NotificationsConfig holds settings for Slack / Teams channel notifications.
+// PollIntervalSecs paces the review SLA and weekly summary checks; 0 disables them.
+// SlackWebhookURL and WebhookURL enable those channels for user notifications, and
+// Routes maps a notification kind to its default channels joined with "+".
+type NotificationsConfig struct {
+	PollIntervalSecs int               `mapstructure:"poll_interval_secs"`
+	HTTP             HTTPClientConfig  `mapstructure:"http"`
+	SlackWebhookURL  string            `mapstructure:"slack_webhook_url"`
+	WebhookURL       string            `mapstructure:"webhook_url"`
+	Routes           map[string]string `mapstructure:"routes"`
+}
+
+// EscalationConfig holds settings for stale-review escalation. PollIntervalSecs
+// paces the check for overdue reviews; 0 disables escalation.
+type EscalationConfig struct {
+	PollIntervalSecs int `mapstructure:"poll_interval_secs"`
+}
+
+// OrphanFilesConfig holds settings for the weekly nudge about files uploaded to
+// a collection but never made documents, sent to tenants with the
+// orphan_file_nudges flag. PollIntervalSecs paces the check; 0 disables it.
+// NudgeAfterDays is how old a file must be before it counts.
+type OrphanFilesConfig struct {
+	PollIntervalSecs int `mapstructure:"poll_interval_secs"`
+	NudgeAfterDays   int `mapstructure:"nudge_after_days"`
+}
+
+// URLImportConfig holds settings for POST /documents/from-url. Source URLs must be
+// public https URLs; HTTP.AllowedHosts further limits them when set.
+type URLImportConfig struct {
+	MaxSizeMB   int64            `mapstructure:"max_size_mb"`
+	TimeoutSecs int              `mapstructure:"timeout_secs"`
+	HTTP        HTTPClientConfig `mapstructure:"http"`
+}
+
+// ParsePreviewConfig holds settings for POST /parse/preview, which parses and
+// validates a file without storing anything.
+type ParsePreviewConfig struct {
+	MaxSizeMB         int64 `mapstructure:"max_size_mb"`
+	RequestsPerMinute int   `mapstructure:"requests_per_minute"` // per user; 0 disables the limit
+}
+
+// ParseBudgetConfig caps the parses tenants can schedule per UTC day, by tier.
+// The free tier (the FreeTier tenant) is counted per user, other tenants as a
+// whole; 0 means unlimited.
+type ParseBudgetConfig struct {
+	DailyCalls     int `mapstructure:"daily_calls"`
+	FreeDailyCalls int `mapstructure:"free_daily_calls"`
+	// FreeProvider restricts the free tier to the configured parser of that
+	// provider (e.g. "gemini"), with no fallback; "" uses the normal chain.
+	FreeProvider string `mapstructure:"free_provider"`
+}
+
+// PageImageConfig holds settings for GET /files/:id/pages/:n/image. PDF pages are
+// rendered by poppler's pdftoppm, which must be installed on the server.
+type PageImageConfig struct {
+	RendererPath string `mapstructure:"renderer_path"`
+	DefaultDPI   int    `mapstructure:"default_dpi"`
+	MaxDPI       int    `mapstructure:"max_dpi"`
+	TimeoutSecs  int    `mapstructure:"timeout_secs"`
+}
+
+// CloudImportConfig holds Google Drive / Dropbox import settings. A provider is
+// enabled only when its client credentials are set.
+type CloudImportConfig struct {
+	GoogleClientID     string `mapstructure:"google_client_id"`
+	GoogleClientSecret string `mapstructure:"google_client_secret"`
+	DropboxAppKey      string `mapstructure:"dropbox_app_key"`
+	DropboxAppSecret   string `mapstructure:"dropbox_app_secret"`
+	RedirectURL        string `mapstructure:"redirect_url"`
+	TokenKey           string `mapstructure:"token_key"`
+	PollIntervalMins   int    `mapstructure:"poll_interval_mins"`
+}
+
+// LimitsConfig holds maximum request body sizes per route group.
+type LimitsConfig struct {
+	AuthBodyKB   int64 `mapstructure:"auth_body_kb"`
+	JSONBodyKB   int64 `mapstructure:"json_body_kb"`
+	UploadBodyMB int64 `mapstructure:"upload_body_mb"`
+}
+
+// MaintenanceConfig holds the initial maintenance-mode state.
+type MaintenanceConfig struct {
+	Enabled        bool `mapstructure:"enabled"`
+	RetryAfterSecs int  `mapstructure:"retry_after_secs"`
 }
 
 // GoogleAuthConfig holds Google OAuth settings.
@@ -36,14 +203,34 @@ type EmailConfig struct {
 	FromAddress string `mapstructure:"from_address"`
 	FromName    string `mapstructure:"from_name"`
 	FrontendURL string `mapstructure:"frontend_url"`
-	AccessKey   string `mapstructure:"access_key"`
-	SecretKey   string `mapstructure:"secret_key"`
+	AccessKey   string           `mapstructure:"access_key"`
+	SecretKey   string           `mapstructure:"secret_key"`
+	HTTP        HTTPClientConfig `mapstructure:"http"`
+	// SNSTopicARN is the SNS topic SES publishes bounces and complaints to;
+	// POST /webhooks/ses rejects messages from any other topic.
+	SNSTopicARN string `mapstructure:"sns_topic_arn"`
 }
 
 // FreeTierConfig holds free tier settings.
 type FreeTierConfig struct {
 	TenantSlug   string `mapstructure:"tenant_slug"`
 	MonthlyLimit int    `mapstructure:"monthly_limit"`
+	// VerificationResendCooldownSecs is the minimum gap between verification emails to one user.
+	VerificationResendCooldownSecs int `mapstructure:"verification_resend_cooldown_secs"`
+	// VerificationReminderAfterHours sends one reminder to users still unverified this long
+	// after registering; 0 disables reminders.
+	VerificationReminderAfterHours int `mapstructure:"verification_reminder_after_hours"`
+}
+
+// AccountSecurityConfig holds password reset and email change settings.
+// BindResetTokens ties a reset link to the IP address and browser that asked
+// for it; EmailChangeUndoHours is how long the old address can undo a change.
+// InvitationTTLHours is how long an imported user's invitation link works.
+type AccountSecurityConfig struct {
+	ResetTokenTTLMins    int  `mapstructure:"reset_token_ttl_mins"`
+	BindResetTokens      bool `mapstructure:"bind_reset_tokens"`
+	EmailChangeUndoHours int  `mapstructure:"email_change_undo_hours"`
+	InvitationTTLHours   int  `mapstructure:"invitation_ttl_hours"`
 }
 
 // QueueConfig holds parse queue worker settings.
@@ -51,6 +238,13 @@ type QueueConfig struct {
 	PollIntervalSecs int `mapstructure:"poll_interval_secs"`
 	MaxRetries       int `mapstructure:"max_retries"`
 	Concurrency      int `mapstructure:"concurrency"`
+	// LeaderElection runs the worker on one instance at a time, the others
+	// standing by; without it every instance polls and claims its own documents.
+	LeaderElection  bool `mapstructure:"leader_election"`
+	LeaderRetrySecs int  `mapstructure:"leader_retry_secs"`
+	// RateLimitReservePercent of the primary parser's rate limits is kept spare
+	// by slowing dispatch; 0 turns pacing off.
+	RateLimitReservePercent int `mapstructure:"rate_limit_reserve_percent"`
 }
 
 // CORSConfig holds CORS settings.
@@ -64,7 +258,39 @@ type ParserProviderConfig struct {
 	APIKey       string `mapstructure:"api_key"`
 	DefaultModel string `mapstructure:"default_model"`
 	MaxRetries   int    `mapstructure:"max_retries"`
-	TimeoutSecs  int    `mapstructure:"timeout_secs"`
+	TimeoutSecs  int    `mapstructure:"timeout_secs"` // base per-call timeout
+
+	// BaseURL points the "local" provider at a self-hosted OpenAI-compatible server
+	// (e.g. http://vllm:8000/v1), or "textract" at a non-default endpoint. Ignored
+	// by other hosted providers.
+	BaseURL string `mapstructure:"base_url"`
+
+	// Region is the AWS region of the "textract" provider; empty uses the S3
+	// region. Textract authenticates with the default AWS credential chain.
+	Region string `mapstructure:"region"`
+
+	// Per-call timeout scales with document size, capped at MaxTimeoutSecs
+	TimeoutPerPageSecs int `mapstructure:"timeout_per_page_secs"`
+	TimeoutPerMBSecs   int `mapstructure:"timeout_per_mb_secs"`
+	MaxTimeoutSecs     int `mapstructure:"max_timeout_secs"`
+
+	HTTP HTTPClientConfig `mapstructure:"http"`
+}
+
+// HTTPClientConfig holds outbound HTTP settings for one external dependency.
+// Zero values keep Go's defaults, including proxy settings from the environment.
+type HTTPClientConfig struct {
+	ProxyURL                  string   `mapstructure:"proxy_url"`
+	CABundle                  string   `mapstructure:"ca_bundle"`     // PEM file added to the system roots
+	AllowedHosts              []string `mapstructure:"allowed_hosts"` // exact host or "*.suffix"; empty allows any
+	ConnectTimeoutSecs        int      `mapstructure:"connect_timeout_secs"`
+	ResponseHeaderTimeoutSecs int      `mapstructure:"response_header_timeout_secs"`
+}
+
+// IsZero reports whether no outbound HTTP setting is configured.
+func (c *HTTPClientConfig) IsZero() bool {
+	return c.ProxyURL == "" && c.CABundle == "" && len(c.AllowedHosts) == 0 &&
+		c.ConnectTimeoutSecs == 0 && c.ResponseHeaderTimeoutSecs == 0
 }
 
 // ParserConfig holds LLM document parser settings with multi-provider support.
@@ -76,10 +302,48 @@ type ParserConfig struct {
 	MaxRetries   int    `mapstructure:"max_retries"`
 	TimeoutSecs  int    `mapstructure:"timeout_secs"`
 
+	TimeoutPerPageSecs int `mapstructure:"timeout_per_page_secs"`
+	TimeoutPerMBSecs   int `mapstructure:"timeout_per_mb_secs"`
+	MaxTimeoutSecs     int `mapstructure:"max_timeout_secs"`
+
+	// JobTimeoutSecs bounds one whole parse of a document (download, every provider
+	// in the fallback chain, saving results).
+	JobTimeoutSecs int `mapstructure:"job_timeout_secs"`
+
 	// Multi-provider fields
 	Primary   ParserProviderConfig `mapstructure:"primary"`
 	Secondary ParserProviderConfig `mapstructure:"secondary"`
 	Tertiary  ParserProviderConfig `mapstructure:"tertiary"`
+
+	// Fake tunes the "fake" provider used in development and integration tests
+	Fake FakeParserConfig `mapstructure:"fake"`
+
+	// OCR is the offline Tesseract parser tried after every configured provider
+	OCR OCRParserConfig `mapstructure:"ocr"`
+}
+
+// FakeParserConfig holds failure injection settings for the "fake" parser
+// provider. Files are picked by hash, so a file always behaves the same way:
+// FailPercent of files fail permanently, the next RateLimitPercent are rate
+// limited (with RetryAfterSecs) on their first parse and succeed on retry.
+type FakeParserConfig struct {
+	FailPercent      int `mapstructure:"fail_percent"`
+	RateLimitPercent int `mapstructure:"rate_limit_percent"`
+	RetryAfterSecs   int `mapstructure:"retry_after_secs"`
+}
+
+// OCRParserConfig holds settings for the last-resort Tesseract parser, which
+// keeps documents parsing while every cloud provider is down or rate limited.
+// PDF pages are rendered with the page image renderer (pdftoppm) first.
+// Every field it extracts is given Confidence, so its results go to review.
+type OCRParserConfig struct {
+	Enabled       bool    `mapstructure:"enabled"`
+	TesseractPath string  `mapstructure:"tesseract_path"`
+	Languages     string  `mapstructure:"languages"`
+	DPI           int     `mapstructure:"dpi"`
+	MaxPages      int     `mapstructure:"max_pages"`
+	TimeoutSecs   int     `mapstructure:"timeout_secs"`
+	Confidence    float64 `mapstructure:"confidence"`
 }
 
 // PrimaryConfig returns the primary parser provider config, falling back to legacy flat fields.
@@ -93,6 +357,15 @@ func (p *ParserConfig) PrimaryConfig() *ParserProviderConfig {
 		DefaultModel: p.DefaultModel,
 		MaxRetries:   p.MaxRetries,
 		TimeoutSecs:  p.TimeoutSecs,
+
+		TimeoutPerPageSecs: p.TimeoutPerPageSecs,
+		TimeoutPerMBSecs:   p.TimeoutPerMBSecs,
+		MaxTimeoutSecs:     p.MaxTimeoutSecs,
+
+		// Legacy configs take base URL, region and outbound HTTP settings from the primary block
+		BaseURL: p.Primary.BaseURL,
+		Region:  p.Primary.Region,
+		HTTP:    p.Primary.HTTP,
 	}
 }
 
@@ -130,6 +403,15 @@ type DBConfig struct {
 	SSLMode  string `mapstructure:"sslmode"`
 	MaxOpen  int    `mapstructure:"max_open"`
 	MaxIdle  int    `mapstructure:"max_idle"`
+	// Cluster names this database cluster. The server refuses tenants whose
+	// routing flag (tenants.db_cluster) names a different cluster.
+	Cluster string `mapstructure:"cluster"`
+	// Shards are further clusters holding tenants' data, keyed by shard name;
+	// a tenant's shard is its tenants.db_cluster. Each gets its own pool of
+	// ShardMaxOpen/ShardMaxIdle connections.
+	Shards       map[string]string `mapstructure:"shards"`
+	ShardMaxOpen int               `mapstructure:"shard_max_open"`
+	ShardMaxIdle int               `mapstructure:"shard_max_idle"`
 }
 
 // DSN returns the PostgreSQL connection string.
@@ -148,15 +430,44 @@ type JWTConfig struct {
 	Issuer             string        `mapstructure:"issuer"`
 }
 
-// S3Config holds AWS S3 settings.
+// S3Config holds AWS S3 settings. Bucket in Region is the default store;
+// RegionBuckets maps each additional data residency region to its bucket.
 type S3Config struct {
-	Region        string `mapstructure:"region"`
-	Bucket        string `mapstructure:"bucket"`
-	Endpoint      string `mapstructure:"endpoint"`
-	AccessKey     string `mapstructure:"access_key"`
-	SecretKey     string `mapstructure:"secret_key"`
-	MaxFileSizeMB int64  `mapstructure:"max_file_size_mb"`
-	PresignExpiry int64  `mapstructure:"presign_expiry"`
+	Region        string            `mapstructure:"region"`
+	Bucket        string            `mapstructure:"bucket"`
+	Endpoint      string            `mapstructure:"endpoint"`
+	AccessKey     string            `mapstructure:"access_key"`
+	SecretKey     string            `mapstructure:"secret_key"`
+	MaxFileSizeMB int64             `mapstructure:"max_file_size_mb"`
+	PresignExpiry int64             `mapstructure:"presign_expiry"`
+	RegionBuckets map[string]string `mapstructure:"region_buckets"`
+	HTTP          HTTPClientConfig  `mapstructure:"http"`
+	// StrictCompliance refuses to start unless every bucket has default
+	// encryption and versioning enabled.
+	StrictCompliance bool `mapstructure:"strict_compliance"`
+}
+
+// BucketForRegion returns the bucket that stores data for region. An empty
+// region means the default bucket.
+func (c *S3Config) BucketForRegion(region string) (string, bool) {
+	if region == "" || region == c.Region {
+		return c.Bucket, true
+	}
+	bucket, ok := c.RegionBuckets[region]
+	return bucket, ok
+}
+
+// RegionForBucket returns the region a configured bucket lives in.
+func (c *S3Config) RegionForBucket(bucket string) (string, bool) {
+	if bucket == c.Bucket {
+		return c.Region, true
+	}
+	for region, b := range c.RegionBuckets {
+		if b == bucket {
+			return region, true
+		}
+	}
+	return "", false
 }
 
 // LogConfig holds logging settings.
@@ -187,6 +498,10 @@ func Load() (*Config, error) {
 	v.SetDefault("db.sslmode", "disable")
 	v.SetDefault("db.max_open", 25)
 	v.SetDefault("db.max_idle", 10)
+	v.SetDefault("db.cluster", "")
+	v.SetDefault("db.shards", "")
+	v.SetDefault("db.shard_max_open", 10)
+	v.SetDefault("db.shard_max_idle", 2)
 
 	// JWT defaults
 	v.SetDefault("jwt.secret", "change-me-in-production")
@@ -200,6 +515,8 @@ func Load() (*Config, error) {
 	v.SetDefault("s3.endpoint", "")
 	v.SetDefault("s3.max_file_size_mb", 50)
 	v.SetDefault("s3.presign_expiry", 3600)
+	v.SetDefault("s3.region_buckets", "")
+	v.SetDefault("s3.strict_compliance", false)
 
 	// Log defaults
 	v.SetDefault("log.level", "debug")
@@ -212,6 +529,9 @@ func Load() (*Config, error) {
 	v.SetDefault("queue.poll_interval_secs", 10)
 	v.SetDefault("queue.max_retries", 5)
 	v.SetDefault("queue.concurrency", 5)
+	v.SetDefault("queue.leader_election", false)
+	v.SetDefault("queue.leader_retry_secs", 15)
+	v.SetDefault("queue.rate_limit_reserve_percent", 10)
 
 	// Email defaults
 	v.SetDefault("email.provider", "noop")
@@ -221,37 +541,130 @@ func Load() (*Config, error) {
 	v.SetDefault("email.frontend_url", "http://localhost:3000")
 	v.SetDefault("email.access_key", "")
 	v.SetDefault("email.secret_key", "")
+	v.SetDefault("email.sns_topic_arn", "")
 
 	// Google Auth defaults
 	v.SetDefault("google_auth.client_id", "")
 
+	// Maintenance defaults
+	v.SetDefault("maintenance.enabled", false)
+	v.SetDefault("maintenance.retry_after_secs", 300)
+
+	// Fault injection defaults
+	v.SetDefault("faults.enabled", false)
+
+	// Request body limits
+	v.SetDefault("limits.auth_body_kb", 64)
+	v.SetDefault("limits.json_body_kb", 2048)
+	v.SetDefault("limits.upload_body_mb", 100)
+
+	// Cloud import defaults
+	v.SetDefault("cloud_import.google_client_id", "")
+	v.SetDefault("cloud_import.google_client_secret", "")
+	v.SetDefault("cloud_import.dropbox_app_key", "")
+	v.SetDefault("cloud_import.dropbox_app_secret", "")
+	v.SetDefault("cloud_import.redirect_url", "http://localhost:3000/integrations/callback")
+	v.SetDefault("cloud_import.token_key", "")
+	v.SetDefault("cloud_import.poll_interval_mins", 15)
+	v.SetDefault("batch_feed.poll_interval_secs", 300)
+	v.SetDefault("batch_feed.report_hour", 18)
+	v.SetDefault("analytics_export.poll_interval_secs", 300)
+	v.SetDefault("notifications.poll_interval_secs", 300)
+	v.SetDefault("notifications.slack_webhook_url", "")
+	v.SetDefault("notifications.webhook_url", "")
+	v.SetDefault("notifications.routes", "")
+	v.SetDefault("escalation.poll_interval_secs", 900)
+	v.SetDefault("orphan_files.poll_interval_secs", 3600)
+	v.SetDefault("orphan_files.nudge_after_days", 7)
+	v.SetDefault("url_import.max_size_mb", 20)
+	v.SetDefault("url_import.timeout_secs", 30)
+	v.SetDefault("parse_preview.max_size_mb", 10)
+	v.SetDefault("parse_preview.requests_per_minute", 10)
+	v.SetDefault("parse_budget.daily_calls", 0)
+	v.SetDefault("parse_budget.free_daily_calls", 20)
+	v.SetDefault("parse_budget.free_provider", "")
+	v.SetDefault("page_image.renderer_path", "pdftoppm")
+	v.SetDefault("page_image.default_dpi", 150)
+	v.SetDefault("page_image.max_dpi", 300)
+	v.SetDefault("page_image.timeout_secs", 30)
+	v.SetDefault("stats.refresh_interval_secs", 5)
+	v.SetDefault("stats.reconcile_hour_utc", 2)
+	v.SetDefault("stats.cache_ttl_secs", 10)
+	v.SetDefault("authz_audit.enabled", false)
+	v.SetDefault("tenant_move.targets", "")
+	v.SetDefault("parse_sla.check_interval_secs", 60)
+	v.SetDefault("parse_sla.window_mins", 15)
+	v.SetDefault("parse_sla.min_samples", 5)
+	v.SetDefault("parse_sla.p95_parse_secs", 120)
+	v.SetDefault("parse_sla.max_queue_age_secs", 900)
+	v.SetDefault("parse_sla.alert_cooldown_mins", 60)
+	v.SetDefault("parse_sla.alert_emails", "")
+	v.SetDefault("parse_sla.alert_webhook_url", "")
+
 	// Free tier defaults
 	v.SetDefault("free_tier.tenant_slug", "satvos")
 	v.SetDefault("free_tier.monthly_limit", 5)
+	v.SetDefault("free_tier.verification_resend_cooldown_secs", 60)
+	v.SetDefault("free_tier.verification_reminder_after_hours", 24)
+
+	// Account security defaults
+	v.SetDefault("account_security.reset_token_ttl_mins", 15)
+	v.SetDefault("account_security.bind_reset_tokens", true)
+	v.SetDefault("account_security.email_change_undo_hours", 72)
+	v.SetDefault("account_security.invitation_ttl_hours", 168)
 
 	// Parser defaults (legacy flat)
 	v.SetDefault("parser.provider", "claude")
 	v.SetDefault("parser.api_key", "")
 	v.SetDefault("parser.default_model", "claude-sonnet-4-20250514")
 	v.SetDefault("parser.max_retries", 2)
-	v.SetDefault("parser.timeout_secs", 120)
+	v.SetDefault("parser.timeout_secs", 60)
+	v.SetDefault("parser.timeout_per_page_secs", 10)
+	v.SetDefault("parser.timeout_per_mb_secs", 5)
+	v.SetDefault("parser.max_timeout_secs", 600)
+	v.SetDefault("parser.job_timeout_secs", 1800)
 
 	// Parser primary/secondary defaults
 	v.SetDefault("parser.primary.provider", "")
 	v.SetDefault("parser.primary.api_key", "")
 	v.SetDefault("parser.primary.default_model", "")
 	v.SetDefault("parser.primary.max_retries", 2)
-	v.SetDefault("parser.primary.timeout_secs", 120)
+	v.SetDefault("parser.primary.timeout_secs", 60)
+	v.SetDefault("parser.primary.timeout_per_page_secs", 10)
+	v.SetDefault("parser.primary.timeout_per_mb_secs", 5)
+	v.SetDefault("parser.primary.max_timeout_secs", 600)
+	v.SetDefault("parser.primary.base_url", "")
+	v.SetDefault("parser.primary.region", "")
 	v.SetDefault("parser.secondary.provider", "")
 	v.SetDefault("parser.secondary.api_key", "")
 	v.SetDefault("parser.secondary.default_model", "")
 	v.SetDefault("parser.secondary.max_retries", 2)
-	v.SetDefault("parser.secondary.timeout_secs", 120)
+	v.SetDefault("parser.secondary.timeout_secs", 60)
+	v.SetDefault("parser.secondary.timeout_per_page_secs", 10)
+	v.SetDefault("parser.secondary.timeout_per_mb_secs", 5)
+	v.SetDefault("parser.secondary.max_timeout_secs", 600)
+	v.SetDefault("parser.secondary.base_url", "")
+	v.SetDefault("parser.secondary.region", "")
 	v.SetDefault("parser.tertiary.provider", "")
 	v.SetDefault("parser.tertiary.api_key", "")
 	v.SetDefault("parser.tertiary.default_model", "")
 	v.SetDefault("parser.tertiary.max_retries", 2)
-	v.SetDefault("parser.tertiary.timeout_secs", 120)
+	v.SetDefault("parser.tertiary.timeout_secs", 60)
+	v.SetDefault("parser.tertiary.timeout_per_page_secs", 10)
+	v.SetDefault("parser.tertiary.timeout_per_mb_secs", 5)
+	v.SetDefault("parser.tertiary.max_timeout_secs", 600)
+	v.SetDefault("parser.tertiary.base_url", "")
+	v.SetDefault("parser.tertiary.region", "")
+	v.SetDefault("parser.fake.fail_percent", 0)
+	v.SetDefault("parser.fake.rate_limit_percent", 0)
+	v.SetDefault("parser.fake.retry_after_secs", 1)
+	v.SetDefault("parser.ocr.enabled", false)
+	v.SetDefault("parser.ocr.tesseract_path", "tesseract")
+	v.SetDefault("parser.ocr.languages", "eng")
+	v.SetDefault("parser.ocr.dpi", 300)
+	v.SetDefault("parser.ocr.max_pages", 5)
+	v.SetDefault("parser.ocr.timeout_secs", 120)
+	v.SetDefault("parser.ocr.confidence", 0.3)
 
 	// Bind environment variables explicitly for nested keys
 	envBindings := map[string]string{
@@ -267,6 +680,10 @@ func Load() (*Config, error) {
 		"db.sslmode":           "SATVOS_DB_SSLMODE",
 		"db.max_open":          "SATVOS_DB_MAX_OPEN",
 		"db.max_idle":          "SATVOS_DB_MAX_IDLE",
+		"db.cluster":           "SATVOS_DB_CLUSTER",
+		"db.shards":            "SATVOS_DB_SHARDS",
+		"db.shard_max_open":    "SATVOS_DB_SHARD_MAX_OPEN",
+		"db.shard_max_idle":    "SATVOS_DB_SHARD_MAX_IDLE",
 		"jwt.secret":           "SATVOS_JWT_SECRET",
 		"jwt.access_expiry":    "SATVOS_JWT_ACCESS_EXPIRY",
 		"jwt.refresh_expiry":   "SATVOS_JWT_REFRESH_EXPIRY",
@@ -278,32 +695,66 @@ func Load() (*Config, error) {
 		"s3.secret_key":        "SATVOS_S3_SECRET_KEY",
 		"s3.max_file_size_mb":  "SATVOS_S3_MAX_FILE_SIZE_MB",
 		"s3.presign_expiry":    "SATVOS_S3_PRESIGN_EXPIRY",
+		"s3.region_buckets":    "SATVOS_S3_REGION_BUCKETS",
+		"s3.strict_compliance": "SATVOS_S3_STRICT_COMPLIANCE",
 		"log.level":            "SATVOS_LOG_LEVEL",
 		"log.format":           "SATVOS_LOG_FORMAT",
 		"cors.allowed_origins":           "SATVOS_CORS_ALLOWED_ORIGINS",
 		"queue.poll_interval_secs":       "SATVOS_QUEUE_POLL_INTERVAL_SECS",
 		"queue.max_retries":              "SATVOS_QUEUE_MAX_RETRIES",
 		"queue.concurrency":              "SATVOS_QUEUE_CONCURRENCY",
+		"queue.leader_election":          "SATVOS_QUEUE_LEADER_ELECTION",
+		"queue.leader_retry_secs":        "SATVOS_QUEUE_LEADER_RETRY_SECS",
+		"queue.rate_limit_reserve_percent": "SATVOS_QUEUE_RATE_LIMIT_RESERVE_PERCENT",
 		"parser.provider":                "SATVOS_PARSER_PROVIDER",
 		"parser.api_key":                 "SATVOS_PARSER_API_KEY",
 		"parser.default_model":           "SATVOS_PARSER_DEFAULT_MODEL",
 		"parser.max_retries":             "SATVOS_PARSER_MAX_RETRIES",
 		"parser.timeout_secs":            "SATVOS_PARSER_TIMEOUT_SECS",
+		"parser.timeout_per_page_secs": "SATVOS_PARSER_TIMEOUT_PER_PAGE_SECS",
+		"parser.timeout_per_mb_secs": "SATVOS_PARSER_TIMEOUT_PER_MB_SECS",
+		"parser.max_timeout_secs": "SATVOS_PARSER_MAX_TIMEOUT_SECS",
+		"parser.job_timeout_secs": "SATVOS_PARSER_JOB_TIMEOUT_SECS",
 		"parser.primary.provider":        "SATVOS_PARSER_PRIMARY_PROVIDER",
 		"parser.primary.api_key":         "SATVOS_PARSER_PRIMARY_API_KEY",
 		"parser.primary.default_model":   "SATVOS_PARSER_PRIMARY_DEFAULT_MODEL",
 		"parser.primary.max_retries":     "SATVOS_PARSER_PRIMARY_MAX_RETRIES",
 		"parser.primary.timeout_secs":    "SATVOS_PARSER_PRIMARY_TIMEOUT_SECS",
+		"parser.primary.timeout_per_page_secs": "SATVOS_PARSER_PRIMARY_TIMEOUT_PER_PAGE_SECS",
+		"parser.primary.timeout_per_mb_secs": "SATVOS_PARSER_PRIMARY_TIMEOUT_PER_MB_SECS",
+		"parser.primary.max_timeout_secs": "SATVOS_PARSER_PRIMARY_MAX_TIMEOUT_SECS",
+		"parser.primary.base_url":         "SATVOS_PARSER_PRIMARY_BASE_URL",
+		"parser.primary.region":           "SATVOS_PARSER_PRIMARY_REGION",
 		"parser.secondary.provider":      "SATVOS_PARSER_SECONDARY_PROVIDER",
 		"parser.secondary.api_key":       "SATVOS_PARSER_SECONDARY_API_KEY",
 		"parser.secondary.default_model": "SATVOS_PARSER_SECONDARY_DEFAULT_MODEL",
 		"parser.secondary.max_retries":   "SATVOS_PARSER_SECONDARY_MAX_RETRIES",
 		"parser.secondary.timeout_secs":  "SATVOS_PARSER_SECONDARY_TIMEOUT_SECS",
+		"parser.secondary.timeout_per_page_secs": "SATVOS_PARSER_SECONDARY_TIMEOUT_PER_PAGE_SECS",
+		"parser.secondary.timeout_per_mb_secs": "SATVOS_PARSER_SECONDARY_TIMEOUT_PER_MB_SECS",
+		"parser.secondary.max_timeout_secs": "SATVOS_PARSER_SECONDARY_MAX_TIMEOUT_SECS",
+		"parser.secondary.base_url":         "SATVOS_PARSER_SECONDARY_BASE_URL",
+		"parser.secondary.region":           "SATVOS_PARSER_SECONDARY_REGION",
 		"parser.tertiary.provider":       "SATVOS_PARSER_TERTIARY_PROVIDER",
 		"parser.tertiary.api_key":        "SATVOS_PARSER_TERTIARY_API_KEY",
 		"parser.tertiary.default_model":  "SATVOS_PARSER_TERTIARY_DEFAULT_MODEL",
 		"parser.tertiary.max_retries":    "SATVOS_PARSER_TERTIARY_MAX_RETRIES",
 		"parser.tertiary.timeout_secs":   "SATVOS_PARSER_TERTIARY_TIMEOUT_SECS",
+		"parser.tertiary.timeout_per_page_secs": "SATVOS_PARSER_TERTIARY_TIMEOUT_PER_PAGE_SECS",
+		"parser.tertiary.timeout_per_mb_secs": "SATVOS_PARSER_TERTIARY_TIMEOUT_PER_MB_SECS",
+		"parser.tertiary.max_timeout_secs": "SATVOS_PARSER_TERTIARY_MAX_TIMEOUT_SECS",
+		"parser.tertiary.base_url":         "SATVOS_PARSER_TERTIARY_BASE_URL",
+		"parser.tertiary.region":           "SATVOS_PARSER_TERTIARY_REGION",
+		"parser.fake.fail_percent":         "SATVOS_PARSER_FAKE_FAIL_PERCENT",
+		"parser.fake.rate_limit_percent":   "SATVOS_PARSER_FAKE_RATE_LIMIT_PERCENT",
+		"parser.fake.retry_after_secs":     "SATVOS_PARSER_FAKE_RETRY_AFTER_SECS",
+		"parser.ocr.enabled":               "SATVOS_PARSER_OCR_ENABLED",
+		"parser.ocr.tesseract_path":        "SATVOS_PARSER_OCR_TESSERACT_PATH",
+		"parser.ocr.languages":             "SATVOS_PARSER_OCR_LANGUAGES",
+		"parser.ocr.dpi":                   "SATVOS_PARSER_OCR_DPI",
+		"parser.ocr.max_pages":             "SATVOS_PARSER_OCR_MAX_PAGES",
+		"parser.ocr.timeout_secs":          "SATVOS_PARSER_OCR_TIMEOUT_SECS",
+		"parser.ocr.confidence":            "SATVOS_PARSER_OCR_CONFIDENCE",
 		"email.provider":                 "SATVOS_EMAIL_PROVIDER",
 		"email.region":                   "SATVOS_EMAIL_REGION",
 		"email.from_address":             "SATVOS_EMAIL_FROM_ADDRESS",
@@ -311,9 +762,68 @@ func Load() (*Config, error) {
 		"email.frontend_url":             "SATVOS_EMAIL_FRONTEND_URL",
 		"email.access_key":               "SATVOS_EMAIL_ACCESS_KEY",
 		"email.secret_key":               "SATVOS_EMAIL_SECRET_KEY",
+		"email.sns_topic_arn":            "SATVOS_EMAIL_SNS_TOPIC_ARN",
 		"free_tier.tenant_slug":          "SATVOS_FREE_TIER_TENANT_SLUG",
 		"free_tier.monthly_limit":        "SATVOS_FREE_TIER_MONTHLY_LIMIT",
+		"free_tier.verification_resend_cooldown_secs": "SATVOS_FREE_TIER_VERIFICATION_RESEND_COOLDOWN_SECS",
+		"free_tier.verification_reminder_after_hours": "SATVOS_FREE_TIER_VERIFICATION_REMINDER_AFTER_HOURS",
+		"account_security.reset_token_ttl_mins":       "SATVOS_ACCOUNT_SECURITY_RESET_TOKEN_TTL_MINS",
+		"account_security.bind_reset_tokens":          "SATVOS_ACCOUNT_SECURITY_BIND_RESET_TOKENS",
+		"account_security.email_change_undo_hours":    "SATVOS_ACCOUNT_SECURITY_EMAIL_CHANGE_UNDO_HOURS",
+		"account_security.invitation_ttl_hours":       "SATVOS_ACCOUNT_SECURITY_INVITATION_TTL_HOURS",
 		"google_auth.client_id":          "SATVOS_GOOGLE_AUTH_CLIENT_ID",
+		"maintenance.enabled":            "SATVOS_MAINTENANCE_ENABLED",
+		"maintenance.retry_after_secs":   "SATVOS_MAINTENANCE_RETRY_AFTER_SECS",
+		"faults.enabled":                 "SATVOS_FAULTS_ENABLED",
+		"limits.auth_body_kb":            "SATVOS_LIMITS_AUTH_BODY_KB",
+		"limits.json_body_kb":            "SATVOS_LIMITS_JSON_BODY_KB",
+		"limits.upload_body_mb":          "SATVOS_LIMITS_UPLOAD_BODY_MB",
+		"cloud_import.google_client_id":     "SATVOS_CLOUD_IMPORT_GOOGLE_CLIENT_ID",
+		"cloud_import.google_client_secret": "SATVOS_CLOUD_IMPORT_GOOGLE_CLIENT_SECRET",
+		"cloud_import.dropbox_app_key":      "SATVOS_CLOUD_IMPORT_DROPBOX_APP_KEY",
+		"cloud_import.dropbox_app_secret":   "SATVOS_CLOUD_IMPORT_DROPBOX_APP_SECRET",
+		"cloud_import.redirect_url":         "SATVOS_CLOUD_IMPORT_REDIRECT_URL",
+		"cloud_import.token_key":            "SATVOS_CLOUD_IMPORT_TOKEN_KEY",
+		"cloud_import.poll_interval_mins":   "SATVOS_CLOUD_IMPORT_POLL_INTERVAL_MINS",
+		"batch_feed.poll_interval_secs":     "SATVOS_BATCH_FEED_POLL_INTERVAL_SECS",
+		"batch_feed.report_hour":            "SATVOS_BATCH_FEED_REPORT_HOUR",
+		"analytics_export.poll_interval_secs": "SATVOS_ANALYTICS_EXPORT_POLL_INTERVAL_SECS",
+		"notifications.poll_interval_secs":    "SATVOS_NOTIFICATIONS_POLL_INTERVAL_SECS",
+		"notifications.slack_webhook_url":     "SATVOS_NOTIFICATIONS_SLACK_WEBHOOK_URL",
+		"notifications.webhook_url":           "SATVOS_NOTIFICATIONS_WEBHOOK_URL",
+		"notifications.routes":                "SATVOS_NOTIFICATIONS_ROUTES",
+		"escalation.poll_interval_secs":       "SATVOS_ESCALATION_POLL_INTERVAL_SECS",
+		"orphan_files.poll_interval_secs":     "SATVOS_ORPHAN_FILES_POLL_INTERVAL_SECS",
+		"orphan_files.nudge_after_days":       "SATVOS_ORPHAN_FILES_NUDGE_AFTER_DAYS",
+		"url_import.max_size_mb":              "SATVOS_URL_IMPORT_MAX_SIZE_MB",
+		"url_import.timeout_secs":             "SATVOS_URL_IMPORT_TIMEOUT_SECS",
+		"parse_preview.max_size_mb":           "SATVOS_PARSE_PREVIEW_MAX_SIZE_MB",
+		"parse_preview.requests_per_minute":   "SATVOS_PARSE_PREVIEW_REQUESTS_PER_MINUTE",
+		"parse_budget.daily_calls":            "SATVOS_PARSE_BUDGET_DAILY_CALLS",
+		"parse_budget.free_daily_calls":       "SATVOS_PARSE_BUDGET_FREE_DAILY_CALLS",
+		"parse_budget.free_provider":          "SATVOS_PARSE_BUDGET_FREE_PROVIDER",
+		"page_image.renderer_path":            "SATVOS_PAGE_IMAGE_RENDERER_PATH",
+		"page_image.default_dpi":              "SATVOS_PAGE_IMAGE_DEFAULT_DPI",
+		"page_image.max_dpi":                  "SATVOS_PAGE_IMAGE_MAX_DPI",
+		"page_image.timeout_secs":             "SATVOS_PAGE_IMAGE_TIMEOUT_SECS",
+		"stats.refresh_interval_secs":       "SATVOS_STATS_REFRESH_INTERVAL_SECS",
+		"stats.reconcile_hour_utc":          "SATVOS_STATS_RECONCILE_HOUR_UTC",
+		"stats.cache_ttl_secs":              "SATVOS_STATS_CACHE_TTL_SECS",
+		"authz_audit.enabled":               "SATVOS_AUTHZ_AUDIT_ENABLED",
+		"tenant_move.targets":               "SATVOS_TENANT_MOVE_TARGETS",
+		"parse_sla.check_interval_secs":     "SATVOS_PARSE_SLA_CHECK_INTERVAL_SECS",
+		"parse_sla.window_mins":             "SATVOS_PARSE_SLA_WINDOW_MINS",
+		"parse_sla.min_samples":             "SATVOS_PARSE_SLA_MIN_SAMPLES",
+		"parse_sla.p95_parse_secs":          "SATVOS_PARSE_SLA_P95_PARSE_SECS",
+		"parse_sla.max_queue_age_secs":      "SATVOS_PARSE_SLA_MAX_QUEUE_AGE_SECS",
+		"parse_sla.alert_cooldown_mins":     "SATVOS_PARSE_SLA_ALERT_COOLDOWN_MINS",
+		"parse_sla.alert_emails":            "SATVOS_PARSE_SLA_ALERT_EMAILS",
+		"parse_sla.alert_webhook_url":       "SATVOS_PARSE_SLA_ALERT_WEBHOOK_URL",
+	}
+	for prefix, envPrefix := range httpClientPrefixes {
+		for _, field := range httpClientFields {
+			envBindings[prefix+".http."+field] = envPrefix + "_HTTP_" + strings.ToUpper(field)
+		}
 	}
 	for key, env := range envBindings {
 		_ = v.BindEnv(key, env)
@@ -334,14 +844,18 @@ func Load() (*Config, error) {
 		Environment:  v.GetString("server.environment"),
 	}
 	cfg.DB = DBConfig{
-		Host:     v.GetString("db.host"),
-		Port:     v.GetInt("db.port"),
-		User:     v.GetString("db.user"),
-		Password: v.GetString("db.password"),
-		Name:     v.GetString("db.name"),
-		SSLMode:  v.GetString("db.sslmode"),
-		MaxOpen:  v.GetInt("db.max_open"),
-		MaxIdle:  v.GetInt("db.max_idle"),
+		Host:         v.GetString("db.host"),
+		Port:         v.GetInt("db.port"),
+		User:         v.GetString("db.user"),
+		Password:     v.GetString("db.password"),
+		Name:         v.GetString("db.name"),
+		SSLMode:      v.GetString("db.sslmode"),
+		MaxOpen:      v.GetInt("db.max_open"),
+		MaxIdle:      v.GetInt("db.max_idle"),
+		Cluster:      v.GetString("db.cluster"),
+		Shards:       parsePairs(v.GetString("db.shards")),
+		ShardMaxOpen: v.GetInt("db.shard_max_open"),
+		ShardMaxIdle: v.GetInt("db.shard_max_idle"),
 	}
 	cfg.JWT = JWTConfig{
 		Secret:             v.GetString("jwt.secret"),
@@ -357,6 +871,10 @@ func Load() (*Config, error) {
 		SecretKey:     v.GetString("s3.secret_key"),
 		MaxFileSizeMB: v.GetInt64("s3.max_file_size_mb"),
 		PresignExpiry: v.GetInt64("s3.presign_expiry"),
+		RegionBuckets: parsePairs(v.GetString("s3.region_buckets")),
+		HTTP:          loadHTTPClientConfig(v, "s3"),
+
+		StrictCompliance: v.GetBool("s3.strict_compliance"),
 	}
 	cfg.Log = LogConfig{
 		Level:  v.GetString("log.level"),
@@ -380,12 +898,26 @@ func Load() (*Config, error) {
 		DefaultModel: v.GetString("parser.default_model"),
 		MaxRetries:   v.GetInt("parser.max_retries"),
 		TimeoutSecs:  v.GetInt("parser.timeout_secs"),
+
+		TimeoutPerPageSecs: v.GetInt("parser.timeout_per_page_secs"),
+		TimeoutPerMBSecs:   v.GetInt("parser.timeout_per_mb_secs"),
+		MaxTimeoutSecs:     v.GetInt("parser.max_timeout_secs"),
+		JobTimeoutSecs:     v.GetInt("parser.job_timeout_secs"),
+
 		Primary: ParserProviderConfig{
 			Provider:     v.GetString("parser.primary.provider"),
 			APIKey:       v.GetString("parser.primary.api_key"),
 			DefaultModel: v.GetString("parser.primary.default_model"),
 			MaxRetries:   v.GetInt("parser.primary.max_retries"),
 			TimeoutSecs:  v.GetInt("parser.primary.timeout_secs"),
+
+			TimeoutPerPageSecs: v.GetInt("parser.primary.timeout_per_page_secs"),
+			TimeoutPerMBSecs:   v.GetInt("parser.primary.timeout_per_mb_secs"),
+			MaxTimeoutSecs:     v.GetInt("parser.primary.max_timeout_secs"),
+
+			BaseURL: v.GetString("parser.primary.base_url"),
+			Region:  v.GetString("parser.primary.region"),
+			HTTP:    loadHTTPClientConfig(v, "parser.primary"),
 		},
 		Secondary: ParserProviderConfig{
 			Provider:     v.GetString("parser.secondary.provider"),
@@ -393,6 +925,14 @@ func Load() (*Config, error) {
 			DefaultModel: v.GetString("parser.secondary.default_model"),
 			MaxRetries:   v.GetInt("parser.secondary.max_retries"),
 			TimeoutSecs:  v.GetInt("parser.secondary.timeout_secs"),
+
+			TimeoutPerPageSecs: v.GetInt("parser.secondary.timeout_per_page_secs"),
+			TimeoutPerMBSecs:   v.GetInt("parser.secondary.timeout_per_mb_secs"),
+			MaxTimeoutSecs:     v.GetInt("parser.secondary.max_timeout_secs"),
+
+			BaseURL: v.GetString("parser.secondary.base_url"),
+			Region:  v.GetString("parser.secondary.region"),
+			HTTP:    loadHTTPClientConfig(v, "parser.secondary"),
 		},
 		Tertiary: ParserProviderConfig{
 			Provider:     v.GetString("parser.tertiary.provider"),
@@ -400,18 +940,52 @@ func Load() (*Config, error) {
 			DefaultModel: v.GetString("parser.tertiary.default_model"),
 			MaxRetries:   v.GetInt("parser.tertiary.max_retries"),
 			TimeoutSecs:  v.GetInt("parser.tertiary.timeout_secs"),
+
+			TimeoutPerPageSecs: v.GetInt("parser.tertiary.timeout_per_page_secs"),
+			TimeoutPerMBSecs:   v.GetInt("parser.tertiary.timeout_per_mb_secs"),
+			MaxTimeoutSecs:     v.GetInt("parser.tertiary.max_timeout_secs"),
+
+			BaseURL: v.GetString("parser.tertiary.base_url"),
+			Region:  v.GetString("parser.tertiary.region"),
+			HTTP:    loadHTTPClientConfig(v, "parser.tertiary"),
+		},
+		Fake: FakeParserConfig{
+			FailPercent:      v.GetInt("parser.fake.fail_percent"),
+			RateLimitPercent: v.GetInt("parser.fake.rate_limit_percent"),
+			RetryAfterSecs:   v.GetInt("parser.fake.retry_after_secs"),
+		},
+		OCR: OCRParserConfig{
+			Enabled:       v.GetBool("parser.ocr.enabled"),
+			TesseractPath: v.GetString("parser.ocr.tesseract_path"),
+			Languages:     v.GetString("parser.ocr.languages"),
+			DPI:           v.GetInt("parser.ocr.dpi"),
+			MaxPages:      v.GetInt("parser.ocr.max_pages"),
+			TimeoutSecs:   v.GetInt("parser.ocr.timeout_secs"),
+			Confidence:    v.GetFloat64("parser.ocr.confidence"),
 		},
 	}
 
 	cfg.Queue = QueueConfig{
-		PollIntervalSecs: v.GetInt("queue.poll_interval_secs"),
-		MaxRetries:       v.GetInt("queue.max_retries"),
-		Concurrency:      v.GetInt("queue.concurrency"),
+		PollIntervalSecs:        v.GetInt("queue.poll_interval_secs"),
+		MaxRetries:              v.GetInt("queue.max_retries"),
+		Concurrency:             v.GetInt("queue.concurrency"),
+		LeaderElection:          v.GetBool("queue.leader_election"),
+		LeaderRetrySecs:         v.GetInt("queue.leader_retry_secs"),
+		RateLimitReservePercent: v.GetInt("queue.rate_limit_reserve_percent"),
 	}
 
 	cfg.FreeTier = FreeTierConfig{
-		TenantSlug:   v.GetString("free_tier.tenant_slug"),
-		MonthlyLimit: v.GetInt("free_tier.monthly_limit"),
+		TenantSlug:                     v.GetString("free_tier.tenant_slug"),
+		MonthlyLimit:                   v.GetInt("free_tier.monthly_limit"),
+		VerificationResendCooldownSecs: v.GetInt("free_tier.verification_resend_cooldown_secs"),
+		VerificationReminderAfterHours: v.GetInt("free_tier.verification_reminder_after_hours"),
+	}
+
+	cfg.AccountSecurity = AccountSecurityConfig{
+		ResetTokenTTLMins:    v.GetInt("account_security.reset_token_ttl_mins"),
+		BindResetTokens:      v.GetBool("account_security.bind_reset_tokens"),
+		EmailChangeUndoHours: v.GetInt("account_security.email_change_undo_hours"),
+		InvitationTTLHours:   v.GetInt("account_security.invitation_ttl_hours"),
 	}
 
 	cfg.Email = EmailConfig{
@@ -422,11 +996,158 @@ func Load() (*Config, error) {
 		FrontendURL: v.GetString("email.frontend_url"),
 		AccessKey:   v.GetString("email.access_key"),
 		SecretKey:   v.GetString("email.secret_key"),
+		HTTP:        loadHTTPClientConfig(v, "email"),
+		SNSTopicARN: v.GetString("email.sns_topic_arn"),
 	}
 
 	cfg.GoogleAuth = GoogleAuthConfig{
 		ClientID: v.GetString("google_auth.client_id"),
 	}
 
+	cfg.Maintenance = MaintenanceConfig{
+		Enabled:        v.GetBool("maintenance.enabled"),
+		RetryAfterSecs: v.GetInt("maintenance.retry_after_secs"),
+	}
+
+	cfg.Faults = FaultsConfig{
+		Enabled: v.GetBool("faults.enabled"),
+	}
+
+	cfg.Limits = LimitsConfig{
+		AuthBodyKB:   v.GetInt64("limits.auth_body_kb"),
+		JSONBodyKB:   v.GetInt64("limits.json_body_kb"),
+		UploadBodyMB: v.GetInt64("limits.upload_body_mb"),
+	}
+
+	cfg.CloudImport = CloudImportConfig{
+		GoogleClientID:     v.GetString("cloud_import.google_client_id"),
+		GoogleClientSecret: v.GetString("cloud_import.google_client_secret"),
+		DropboxAppKey:      v.GetString("cloud_import.dropbox_app_key"),
+		DropboxAppSecret:   v.GetString("cloud_import.dropbox_app_secret"),
+		RedirectURL:        v.GetString("cloud_import.redirect_url"),
+		TokenKey:           v.GetString("cloud_import.token_key"),
+		PollIntervalMins:   v.GetInt("cloud_import.poll_interval_mins"),
+	}
+	cfg.BatchFeed = BatchFeedConfig{
+		PollIntervalSecs: v.GetInt("batch_feed.poll_interval_secs"),
+		ReportHour:       v.GetInt("batch_feed.report_hour"),
+	}
+	cfg.AnalyticsExport = AnalyticsExportConfig{
+		PollIntervalSecs: v.GetInt("analytics_export.poll_interval_secs"),
+	}
+	cfg.Integrations = IntegrationsConfig{HTTP: loadHTTPClientConfig(v, "integrations")}
+	cfg.Notifications = NotificationsConfig{
+		PollIntervalSecs: v.GetInt("notifications.poll_interval_secs"),
+		HTTP:             loadHTTPClientConfig(v, "notifications"),
+		SlackWebhookURL:  v.GetString("notifications.slack_webhook_url"),
+		WebhookURL:       v.GetString("notifications.webhook_url"),
+		Routes:           parsePairs(v.GetString("notifications.routes")),
+	}
+	cfg.Escalation = EscalationConfig{
+		PollIntervalSecs: v.GetInt("escalation.poll_interval_secs"),
+	}
+	cfg.OrphanFiles = OrphanFilesConfig{
+		PollIntervalSecs: v.GetInt("orphan_files.poll_interval_secs"),
+		NudgeAfterDays:   v.GetInt("orphan_files.nudge_after_days"),
+	}
+	cfg.URLImport = URLImportConfig{
+		MaxSizeMB:   v.GetInt64("url_import.max_size_mb"),
+		TimeoutSecs: v.GetInt("url_import.timeout_secs"),
+		HTTP:        loadHTTPClientConfig(v, "url_import"),
+	}
+	cfg.ParsePreview = ParsePreviewConfig{
+		MaxSizeMB:         v.GetInt64("parse_preview.max_size_mb"),
+		RequestsPerMinute: v.GetInt("parse_preview.requests_per_minute"),
+	}
+	cfg.ParseBudget = ParseBudgetConfig{
+		DailyCalls:     v.GetInt("parse_budget.daily_calls"),
+		FreeDailyCalls: v.GetInt("parse_budget.free_daily_calls"),
+		FreeProvider:   v.GetString("parse_budget.free_provider"),
+	}
+	cfg.PageImage = PageImageConfig{
+		RendererPath: v.GetString("page_image.renderer_path"),
+		DefaultDPI:   v.GetInt("page_image.default_dpi"),
+		MaxDPI:       v.GetInt("page_image.max_dpi"),
+		TimeoutSecs:  v.GetInt("page_image.timeout_secs"),
+	}
+
+	cfg.Stats = StatsConfig{
+		RefreshIntervalSecs: v.GetInt("stats.refresh_interval_secs"),
+		ReconcileHourUTC:    v.GetInt("stats.reconcile_hour_utc"),
+		CacheTTLSecs:        v.GetInt("stats.cache_ttl_secs"),
+	}
+
+	cfg.AuthzAudit = AuthzAuditConfig{
+		Enabled: v.GetBool("authz_audit.enabled"),
+	}
+	cfg.TenantMove = TenantMoveConfig{
+		Targets: parsePairs(v.GetString("tenant_move.targets")),
+	}
+	var alertEmails []string
+	for _, e := range strings.Split(v.GetString("parse_sla.alert_emails"), ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			alertEmails = append(alertEmails, e)
+		}
+	}
+	cfg.ParseSLA = ParseSLAConfig{
+		CheckIntervalSecs: v.GetInt("parse_sla.check_interval_secs"),
+		WindowMins:        v.GetInt("parse_sla.window_mins"),
+		MinSamples:        v.GetInt("parse_sla.min_samples"),
+		P95ParseSecs:      v.GetInt("parse_sla.p95_parse_secs"),
+		MaxQueueAgeSecs:   v.GetInt("parse_sla.max_queue_age_secs"),
+		AlertCooldownMins: v.GetInt("parse_sla.alert_cooldown_mins"),
+		AlertEmails:       alertEmails,
+		AlertWebhookURL:   v.GetString("parse_sla.alert_webhook_url"),
+	}
+
 	return cfg, nil
 }
+
+// parsePairs parses "name=value,name=value" pairs, e.g. region buckets
+// "ap-south-1=satvos-in,eu-west-1=satvos-eu". Values are split at the first "=",
+// so they may contain "=" themselves (DSN query strings). Malformed pairs are ignored.
+func parsePairs(raw string) map[string]string {
+	pairs := map[string]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if ok && name != "" && value != "" {
+			pairs[name] = value
+		}
+	}
+	return pairs
+}
+
+// httpClientPrefixes maps config sections with an outbound HTTP client to their
+// env var prefix; each gets <PREFIX>_HTTP_PROXY_URL, _CA_BUNDLE, and so on.
+var httpClientPrefixes = map[string]string{
+	"parser.primary":   "SATVOS_PARSER_PRIMARY",
+	"parser.secondary": "SATVOS_PARSER_SECONDARY",
+	"parser.tertiary":  "SATVOS_PARSER_TERTIARY",
+	"s3":               "SATVOS_S3",
+	"email":            "SATVOS_EMAIL",
+	"integrations":     "SATVOS_INTEGRATIONS",
+	"notifications":    "SATVOS_NOTIFICATIONS",
+	"url_import":       "SATVOS_URL_IMPORT",
+}
+
+var httpClientFields = []string{
+	"proxy_url", "ca_bundle", "allowed_hosts", "connect_timeout_secs", "response_header_timeout_secs",
+}
+
+// loadHTTPClientConfig reads <prefix>.http.*; allowed_hosts is comma-separated.
+func loadHTTPClientConfig(v *viper.Viper, prefix string) HTTPClientConfig {
+	var hosts []string
+	for _, h := range strings.Split(v.GetString(prefix+".http.allowed_hosts"), ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	return HTTPClientConfig{
+		ProxyURL:                  v.GetString(prefix + ".http.proxy_url"),
+		CABundle:                  v.GetString(prefix + ".http.ca_bundle"),
+		AllowedHosts:              hosts,
+		ConnectTimeoutSecs:        v.GetInt(prefix + ".http.connect_timeout_secs"),
+		ResponseHeaderTimeoutSecs: v.GetInt(prefix + ".http.response_header_timeout_secs"),
+	}
+}
@@ -0,0 +1,194 @@
+package csvexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"satvos/internal/domain"
+)
+
+// Fix-list export formats.
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// failureColumns defines the header row of the validation failures export.
+var failureColumns = []string{
+	"Document Name",
+	"Invoice Number",
+	"Document ID",
+	"Review Status",
+	"Rule",
+	"Rule Type",
+	"Severity",
+	"Field",
+	"Expected",
+	"Actual",
+	"Message",
+	"Reconciliation Critical",
+}
+
+// FailureWriter writes a collection's failing validation results as a fix list.
+type FailureWriter interface {
+	WriteHeader() error
+	WriteFailures(failures []domain.ValidationFailure) error
+	// Flush pushes buffered rows to the underlying writer. XLSX rows can only be
+	// written as a whole workbook, so for XLSX this is a no-op until Close.
+	Flush() error
+	// Close finishes the file. It must be called once after the last row.
+	Close() error
+}
+
+// NewFailureWriter returns a FailureWriter for format ("csv" or "xlsx") writing to w.
+func NewFailureWriter(w io.Writer, format string) (FailureWriter, error) {
+	switch format {
+	case FormatCSV:
+		return &csvFailureWriter{out: w, csv: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return newXLSXFailureWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// FailureContentType returns the Content-Type for an export format.
+func FailureContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// BuildFailuresFilename returns the download filename for a collection's fix list.
+// Format: {sanitized_collection_name}_validation_failures_{YYYY-MM-DD}.{format}
+func BuildFailuresFilename(collectionName, format string) string {
+	sanitized := SanitizeFilename(collectionName)
+	date := time.Now().Format("2006-01-02")
+	return fmt.Sprintf("%s_validation_failures_%s.%s", sanitized, date, format)
+}
+
+func failureToRow(f *domain.ValidationFailure) []string {
+	return []string{
+		f.DocumentName,
+		f.InvoiceNumber,
+		f.DocumentID.String(),
+		string(f.ReviewStatus),
+		f.RuleName,
+		f.RuleType,
+		string(f.Severity),
+		f.FieldPath,
+		f.ExpectedValue,
+		f.ActualValue,
+		f.Message,
+		formatBool(f.ReconciliationCritical),
+	}
+}
+
+type csvFailureWriter struct {
+	out io.Writer
+	csv *csv.Writer
+}
+
+func (w *csvFailureWriter) WriteHeader() error {
+	// UTF-8 BOM for Excel compatibility, as in the document export
+	if _, err := w.out.Write(BOM); err != nil {
+		return err
+	}
+	return w.csv.Write(failureColumns)
+}
+
+func (w *csvFailureWriter) WriteFailures(failures []domain.ValidationFailure) error {
+	for i := range failures {
+		if err := w.csv.Write(failureToRow(&failures[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *csvFailureWriter) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func (w *csvFailureWriter) Close() error {
+	return w.Flush()
+}
+
+const failuresSheet = "Validation Failures"
+
+type xlsxFailureWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXFailureWriter(w io.Writer) (*xlsxFailureWriter, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", failuresSheet); err != nil {
+		return nil, err
+	}
+	sw, err := f.NewStreamWriter(failuresSheet)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxFailureWriter{out: w, file: f, stream: sw, row: 1}, nil
+}
+
+func (w *xlsxFailureWriter) WriteHeader() error {
+	bold, err := w.file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	// Column widths must be set before the first row is streamed.
+	if err := w.stream.SetColWidth(1, len(failureColumns), 20); err != nil {
+		return err
+	}
+	if err := w.stream.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+	return w.setRow(failureColumns, excelize.RowOpts{StyleID: bold})
+}
+
+func (w *xlsxFailureWriter) WriteFailures(failures []domain.ValidationFailure) error {
+	for i := range failures {
+		if err := w.setRow(failureToRow(&failures[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *xlsxFailureWriter) setRow(values []string, opts ...excelize.RowOpts) error {
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	row := make([]interface{}, len(values))
+	for i, v := range values {
+		row[i] = v
+	}
+	if err := w.stream.SetRow(cell, row, opts...); err != nil {
+		return err
+	}
+	w.row++
+	return nil
+}
+
+func (w *xlsxFailureWriter) Flush() error {
+	return nil
+}
+
+func (w *xlsxFailureWriter) Close() error {
+	defer w.file.Close()
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+	return w.file.Write(w.out)
+}
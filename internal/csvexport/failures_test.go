@@ -0,0 +1,46 @@
+package csvexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+)
+
+func TestFailureWriter_CSVRows(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewFailureWriter(&buf, FormatCSV)
+	require.NoError(t, err)
+
+	docID := uuid.New()
+	require.NoError(t, w.WriteHeader())
+	require.NoError(t, w.WriteFailures([]domain.ValidationFailure{{
+		DocumentID: docID, DocumentName: "Invoice 1", RuleName: "Format: Seller GSTIN",
+		Severity: domain.ValidationSeverityWarning, FieldPath: "seller.gstin",
+		ExpectedValue: "valid GSTIN", ActualValue: "29ABC", ReconciliationCritical: true,
+	}}))
+	require.NoError(t, w.Close())
+
+	records, err := csv.NewReader(bytes.NewReader(buf.Bytes()[len(BOM):])).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Len(t, records[0], len(failureColumns))
+	assert.Equal(t, docID.String(), records[1][2])
+	assert.Equal(t, "warning", records[1][6])
+	assert.Equal(t, "Yes", records[1][11])
+}
+
+func TestNewFailureWriter_UnknownFormat(t *testing.T) {
+	_, err := NewFailureWriter(&bytes.Buffer{}, "pdf")
+	assert.Error(t, err)
+}
+
+func TestBuildFailuresFilename(t *testing.T) {
+	name := BuildFailuresFilename("Q3 / Invoices", FormatXLSX)
+	assert.Regexp(t, `^Q3_Invoices_validation_failures_\d{4}-\d{2}-\d{2}\.xlsx$`, name)
+}
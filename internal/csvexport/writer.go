@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"satvos/internal/domain"
+	"satvos/internal/expr"
 	"satvos/internal/validator/invoice"
 )
 
@@ -56,7 +57,8 @@ var columns = []string{
 
 // Writer wraps csv.Writer for exporting documents as CSV.
 type Writer struct {
-	csv *csv.Writer
+	csv        *csv.Writer
+	extensions []string
 }
 
 // NewWriter creates a Writer that writes CSV to w.
@@ -64,15 +66,24 @@ func NewWriter(w io.Writer) *Writer {
 	return &Writer{csv: csv.NewWriter(w)}
 }
 
-// WriteHeader writes the 33-column header row.
+// SetExtensionColumns appends a column per computed field name, filled from
+// each document's Extensions. Call it before WriteHeader.
+func (w *Writer) SetExtensionColumns(names []string) {
+	w.extensions = names
+}
+
+// WriteHeader writes the 33-column header row, followed by any extension columns.
 func (w *Writer) WriteHeader() error {
-	return w.csv.Write(columns)
+	return w.csv.Write(append(append([]string{}, columns...), w.extensions...))
 }
 
 // WriteDocuments converts a batch of documents to CSV rows and writes them.
 func (w *Writer) WriteDocuments(docs []domain.Document) error {
 	for i := range docs {
 		row := documentToRow(&docs[i])
+		for _, name := range w.extensions {
+			row = append(row, expr.Format(docs[i].Extensions[name]))
+		}
 		if err := w.csv.Write(row); err != nil {
 			return err
 		}
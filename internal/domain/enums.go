@@ -4,16 +4,18 @@ package domain
 type FileType string
 
 const (
-	FileTypePDF FileType = "pdf"
-	FileTypeJPG FileType = "jpg"
-	FileTypePNG FileType = "png"
+	FileTypePDF  FileType = "pdf"
+	FileTypeJPG  FileType = "jpg"
+	FileTypePNG  FileType = "png"
+	FileTypeTIFF FileType = "tiff"
 )
 
 // AllowedFileTypes maps FileType to its MIME content type.
 var AllowedFileTypes = map[FileType]string{
-	FileTypePDF: "application/pdf",
-	FileTypeJPG: "image/jpeg",
-	FileTypePNG: "image/png",
+	FileTypePDF:  "application/pdf",
+	FileTypeJPG:  "image/jpeg",
+	FileTypePNG:  "image/png",
+	FileTypeTIFF: "image/tiff",
 }
 
 // AllowedContentTypes maps MIME content types back to FileType.
@@ -21,6 +23,7 @@ var AllowedContentTypes = map[string]FileType{
 	"application/pdf": FileTypePDF,
 	"image/jpeg":      FileTypeJPG,
 	"image/png":       FileTypePNG,
+	"image/tiff":      FileTypeTIFF,
 }
 
 // AllowedExtensions maps file extensions (without dot) to FileType.
@@ -29,6 +32,8 @@ var AllowedExtensions = map[string]FileType{
 	"jpg":  FileTypeJPG,
 	"jpeg": FileTypeJPG,
 	"png":  FileTypePNG,
+	"tif":  FileTypeTIFF,
+	"tiff": FileTypeTIFF,
 }
 
 // UserRole defines the role hierarchy within a tenant.
@@ -127,6 +132,17 @@ const (
 	ParsingStatusQueued     ParsingStatus = "queued"
 )
 
+// ParseFailureCategory classifies why the latest parse attempt did not complete.
+// It is empty while a document has not failed or after a successful parse.
+type ParseFailureCategory string
+
+const (
+	ParseFailureTimeout     ParseFailureCategory = "timeout"      // parser call or parse job exceeded its deadline
+	ParseFailureRateLimited ParseFailureCategory = "rate_limited" // provider returned 429
+	ParseFailureUnavailable ParseFailureCategory = "unavailable"  // storage or provider outage (network, 5xx)
+	ParseFailureError       ParseFailureCategory = "error"        // anything else; not retried
+)
+
 // ReviewStatus represents the human review state of a document.
 type ReviewStatus string
 
@@ -134,6 +150,9 @@ const (
 	ReviewStatusPending  ReviewStatus = "pending"
 	ReviewStatusApproved ReviewStatus = "approved"
 	ReviewStatusRejected ReviewStatus = "rejected"
+	// ReviewStatusAwaitingChecker means a maker approved the document and a
+	// checker must confirm before the approval is final.
+	ReviewStatusAwaitingChecker ReviewStatus = "awaiting_checker"
 )
 
 // ValidationRuleType defines the kind of validation to perform.
@@ -206,23 +225,54 @@ const (
 	AuthProviderGoogle AuthProvider = "google"
 )
 
+// Collection activity feed entry types that are not document audit actions.
+const (
+	ActivityFileUploaded             = "file.uploaded"
+	CollectionEventPermissionSet     = "collection.permission_set"
+	CollectionEventPermissionRemoved = "collection.permission_removed"
+)
+
+// CollectionActivityDocumentActions are the document audit actions shown in a
+// collection's activity feed.
+var CollectionActivityDocumentActions = []AuditAction{
+	AuditDocumentCreated,
+	AuditDocumentParseCompleted,
+	AuditDocumentParseFailed,
+	AuditDocumentReview,
+	AuditDocumentFileReplaced,
+	AuditDocumentReclassified,
+}
+
 // AuditAction identifies the type of document mutation recorded in the audit log.
 type AuditAction string
 
 const (
-	AuditDocumentCreated          AuditAction = "document.created"
-	AuditDocumentParseCompleted   AuditAction = "document.parse_completed"
-	AuditDocumentParseFailed      AuditAction = "document.parse_failed"
-	AuditDocumentParseQueued      AuditAction = "document.parse_queued"
-	AuditDocumentRetry            AuditAction = "document.retry"
-	AuditDocumentReview           AuditAction = "document.review"
-	AuditDocumentEditStructured   AuditAction = "document.edit_structured_data"
-	AuditDocumentValidate              AuditAction = "document.validate"
-	AuditDocumentValidationCompleted   AuditAction = "document.validation_completed"
-	AuditDocumentTagsAdded        AuditAction = "document.tags_added"
-	AuditDocumentTagDeleted       AuditAction = "document.tag_deleted"
-	AuditDocumentDeleted          AuditAction = "document.deleted"
-	AuditDocumentAssigned         AuditAction = "document.assigned"
+	AuditDocumentCreated             AuditAction = "document.created"
+	AuditDocumentParseCompleted      AuditAction = "document.parse_completed"
+	AuditDocumentParseFailed         AuditAction = "document.parse_failed"
+	AuditDocumentParseQueued         AuditAction = "document.parse_queued"
+	AuditDocumentRetry               AuditAction = "document.retry"
+	AuditDocumentFileReplaced        AuditAction = "document.file_replaced"
+	AuditDocumentReclassified        AuditAction = "document.reclassified"
+	AuditDocumentReview              AuditAction = "document.review"
+	AuditDocumentEditStructured      AuditAction = "document.edit_structured_data"
+	AuditDocumentValidate            AuditAction = "document.validate"
+	AuditDocumentValidationCompleted AuditAction = "document.validation_completed"
+	AuditDocumentTagsAdded           AuditAction = "document.tags_added"
+	AuditDocumentTagDeleted          AuditAction = "document.tag_deleted"
+	AuditDocumentDeleted             AuditAction = "document.deleted"
+	AuditDocumentAssigned            AuditAction = "document.assigned"
+	AuditDocumentOverridesCleared    AuditAction = "document.overrides_cleared"
+	AuditDocumentEscalated           AuditAction = "document.escalated"
+	AuditDocumentQAReviewed          AuditAction = "document.qa_reviewed"
+)
+
+// DocumentVersionReason records why a document version was superseded.
+type DocumentVersionReason string
+
+const (
+	DocumentVersionFileReplaced DocumentVersionReason = "file_replaced"
+	DocumentVersionReclassified DocumentVersionReason = "reclassified"
 )
 
 // FileStatus represents the lifecycle of an uploaded file.
@@ -234,3 +284,336 @@ const (
 	FileStatusFailed   FileStatus = "failed"
 	FileStatusDeleted  FileStatus = "deleted"
 )
+
+// FeatureFlag identifies a capability that can be toggled per tenant.
+type FeatureFlag string
+
+const (
+	FlagDualParse         FeatureFlag = "dual_parse"
+	FlagAutoApproval      FeatureFlag = "auto_approval"
+	FlagAnomalyDetection  FeatureFlag = "anomaly_detection"
+	FlagWhatsAppIngestion FeatureFlag = "whatsapp_ingestion"
+	FlagBatchFeed         FeatureFlag = "batch_feed"
+	// FlagOrphanFileNudges emails collection owners a weekly reminder about
+	// files uploaded to their collections that never became documents.
+	FlagOrphanFileNudges FeatureFlag = "orphan_file_nudges"
+)
+
+// FeatureFlagDefaults lists every known flag with its value for tenants that have no
+// explicit setting. Capabilities that already shipped default to enabled.
+var FeatureFlagDefaults = map[FeatureFlag]bool{
+	FlagDualParse:         true,
+	FlagAutoApproval:      false,
+	FlagAnomalyDetection:  false,
+	FlagWhatsAppIngestion: false,
+	FlagBatchFeed:         false,
+	FlagOrphanFileNudges:  false,
+}
+
+// ImportSource identifies where a bulk import reads its files from.
+type ImportSource string
+
+const (
+	ImportSourceZip      ImportSource = "zip"
+	ImportSourceS3Prefix ImportSource = "s3_prefix"
+)
+
+// ImportStatus represents the lifecycle of a bulk import job.
+type ImportStatus string
+
+const (
+	ImportStatusPending    ImportStatus = "pending"
+	ImportStatusProcessing ImportStatus = "processing"
+	ImportStatusCompleted  ImportStatus = "completed"
+	ImportStatusFailed     ImportStatus = "failed"
+)
+
+// ValidationRunStatus represents the lifecycle of a collection validation re-run.
+type ValidationRunStatus string
+
+const (
+	ValidationRunStatusPending    ValidationRunStatus = "pending"
+	ValidationRunStatusProcessing ValidationRunStatus = "processing"
+	ValidationRunStatusCompleted  ValidationRunStatus = "completed"
+	ValidationRunStatusFailed     ValidationRunStatus = "failed"
+)
+
+// TenantMoveStatus represents the lifecycle of a tenant move between database clusters.
+type TenantMoveStatus string
+
+const (
+	TenantMoveStatusPending   TenantMoveStatus = "pending"
+	TenantMoveStatusCopying   TenantMoveStatus = "copying"
+	TenantMoveStatusCompleted TenantMoveStatus = "completed"
+	TenantMoveStatusFailed    TenantMoveStatus = "failed"
+)
+
+// ParseTier is the parse budget tier of a tenant. The free tier is the shared
+// free-tier tenant; every other tenant is standard.
+type ParseTier string
+
+const (
+	ParseTierFree     ParseTier = "free"
+	ParseTierStandard ParseTier = "standard"
+)
+
+// KPIMetric is what a collection KPI target measures.
+type KPIMetric string
+
+const (
+	// KPIMetricParsed counts documents whose parsing completed.
+	KPIMetricParsed KPIMetric = "parsed"
+	// KPIMetricReviewed counts documents approved or rejected.
+	KPIMetricReviewed KPIMetric = "reviewed"
+	// KPIMetricApproved counts approved documents.
+	KPIMetricApproved KPIMetric = "approved"
+)
+
+// KPITargetStatus says where a collection is against a KPI target.
+type KPITargetStatus string
+
+const (
+	KPITargetMet     KPITargetStatus = "met"
+	KPITargetOnTrack KPITargetStatus = "on_track"
+	// KPITargetAtRisk means the current velocity won't reach the target by its due date.
+	KPITargetAtRisk KPITargetStatus = "at_risk"
+	// KPITargetMissed means the due date passed without reaching the target.
+	KPITargetMissed KPITargetStatus = "missed"
+)
+
+// LineItemOp is one row-level change in a line item patch.
+type LineItemOp string
+
+const (
+	LineItemOpAdd    LineItemOp = "add"
+	LineItemOpUpdate LineItemOp = "update"
+	LineItemOpDelete LineItemOp = "delete"
+)
+
+// BulkTagAction is what a bulk tag job does to each matching document.
+type BulkTagAction string
+
+const (
+	BulkTagAdd    BulkTagAction = "add"
+	BulkTagRemove BulkTagAction = "remove"
+)
+
+// TagValueType is how a tag's value is compared in searches.
+type TagValueType string
+
+const (
+	TagValueString TagValueType = "string"
+	// TagValueNumber tags hold a decimal number, such as "12500.50".
+	TagValueNumber TagValueType = "number"
+	// TagValueDate tags hold a YYYY-MM-DD date.
+	TagValueDate TagValueType = "date"
+)
+
+// BulkTagStatus represents the lifecycle of a bulk tag job.
+type BulkTagStatus string
+
+const (
+	BulkTagStatusPending    BulkTagStatus = "pending"
+	BulkTagStatusProcessing BulkTagStatus = "processing"
+	BulkTagStatusCompleted  BulkTagStatus = "completed"
+	BulkTagStatusFailed     BulkTagStatus = "failed"
+)
+
+// ImportEntryStatus is the outcome for a single file within a bulk import.
+type ImportEntryStatus string
+
+const (
+	ImportEntryImported ImportEntryStatus = "imported"
+	ImportEntrySkipped  ImportEntryStatus = "skipped"
+	ImportEntryFailed   ImportEntryStatus = "failed"
+)
+
+// CloudProvider identifies a connected cloud storage service.
+type CloudProvider string
+
+const (
+	CloudProviderGoogleDrive CloudProvider = "google_drive"
+	CloudProviderDropbox     CloudProvider = "dropbox"
+)
+
+// CloudSyncFileStatus is the outcome for a single file seen by a cloud folder sync.
+type CloudSyncFileStatus string
+
+const (
+	CloudSyncFileImported  CloudSyncFileStatus = "imported"
+	CloudSyncFileDuplicate CloudSyncFileStatus = "duplicate"
+	CloudSyncFileSkipped   CloudSyncFileStatus = "skipped"
+	CloudSyncFileFailed    CloudSyncFileStatus = "failed"
+)
+
+// FeedIngestionStatus tracks a file picked up from a tenant's batch feed drop folder.
+type FeedIngestionStatus string
+
+const (
+	FeedIngestionProcessing FeedIngestionStatus = "processing"
+	FeedIngestionImported   FeedIngestionStatus = "imported"
+	FeedIngestionSkipped    FeedIngestionStatus = "skipped"
+	FeedIngestionFailed     FeedIngestionStatus = "failed"
+)
+
+// NeighborContext is the list a document's previous/next neighbors are taken from.
+type NeighborContext string
+
+const (
+	NeighborContextReviewQueue NeighborContext = "review-queue"
+	NeighborContextCollection  NeighborContext = "collection"
+)
+
+// NotificationProvider is the chat service a notification channel posts to.
+type NotificationProvider string
+
+const (
+	NotificationProviderSlack NotificationProvider = "slack"
+	NotificationProviderTeams NotificationProvider = "teams"
+)
+
+// NotificationEvent is a collection event that can be posted to a chat channel.
+type NotificationEvent string
+
+const (
+	NotificationEventParseFailed       NotificationEvent = "parse_failed"
+	NotificationEventDocumentAssigned  NotificationEvent = "document_assigned"
+	NotificationEventReviewSLABreached NotificationEvent = "review_sla_breached"
+	NotificationEventWeeklySummary     NotificationEvent = "weekly_summary"
+	NotificationEventReviewEscalated   NotificationEvent = "review_escalated"
+)
+
+// NotificationEvents lists every event a channel can subscribe to.
+var NotificationEvents = []NotificationEvent{
+	NotificationEventParseFailed,
+	NotificationEventDocumentAssigned,
+	NotificationEventReviewSLABreached,
+	NotificationEventWeeklySummary,
+	NotificationEventReviewEscalated,
+}
+
+// EscalationAction is what happens to a document whose review has waited past
+// its collection's escalation deadline.
+type EscalationAction string
+
+const (
+	// EscalationActionFlag only marks the document escalated.
+	EscalationActionFlag EscalationAction = "flag"
+	// EscalationActionReassign also assigns the document to the collection owner.
+	EscalationActionReassign EscalationAction = "reassign"
+)
+
+// QAReviewStatus is the lifecycle of a second-opinion QA sample.
+type QAReviewStatus string
+
+const (
+	// QAReviewStatusPending samples wait in the QA queue for a second reviewer.
+	QAReviewStatusPending QAReviewStatus = "pending"
+	// QAReviewStatusCompleted samples carry the second reviewer's verdict.
+	QAReviewStatusCompleted QAReviewStatus = "completed"
+)
+
+// ExportKind is which collection export produced an export artifact.
+type ExportKind string
+
+const (
+	// ExportKindDocumentsCSV is the per-document GST reconciliation CSV.
+	ExportKindDocumentsCSV ExportKind = "documents_csv"
+	// ExportKindValidationFailures is the validation failure fix list, as CSV or XLSX.
+	ExportKindValidationFailures ExportKind = "validation_failures"
+)
+
+// NotificationKind is a message sent to a user by the platform, routed to
+// delivery channels per kind and tenant.
+type NotificationKind string
+
+const (
+	NotificationKindEmailVerification NotificationKind = "email_verification"
+	NotificationKindPasswordReset     NotificationKind = "password_reset"
+	NotificationKindIngestionReport   NotificationKind = "ingestion_report"
+	// NotificationKindEmailChanged goes to the old address with a link to undo the change.
+	NotificationKindEmailChanged NotificationKind = "email_changed"
+	// NotificationKindEmailUndeliverable tells tenant admins that a user's address
+	// bounced or complained and no longer receives email.
+	NotificationKindEmailUndeliverable NotificationKind = "email_undeliverable"
+	// NotificationKindQuotaWarning tells a user and their tenant admins that the
+	// user's monthly document quota is nearly used up.
+	NotificationKindQuotaWarning NotificationKind = "quota_warning"
+	// NotificationKindInvitation invites a user created by an admin to set a password.
+	NotificationKindInvitation NotificationKind = "invitation"
+	// NotificationKindOrphanFiles reminds a collection owner of files uploaded
+	// to the collection that never became documents.
+	NotificationKindOrphanFiles NotificationKind = "orphan_files"
+)
+
+// NotificationKinds lists every kind a tenant can route.
+var NotificationKinds = []NotificationKind{
+	NotificationKindEmailVerification,
+	NotificationKindPasswordReset,
+	NotificationKindIngestionReport,
+	NotificationKindEmailChanged,
+	NotificationKindEmailUndeliverable,
+	NotificationKindQuotaWarning,
+	NotificationKindInvitation,
+	NotificationKindOrphanFiles,
+}
+
+// CarriesToken reports whether the kind's message holds a single-use link, which
+// only the email channel delivers.
+func (k NotificationKind) CarriesToken() bool {
+	return k == NotificationKindEmailVerification || k == NotificationKindPasswordReset ||
+		k == NotificationKindEmailChanged || k == NotificationKindInvitation
+}
+
+// NotifierChannel is a way of delivering a NotificationKind.
+type NotifierChannel string
+
+const (
+	NotifierChannelEmail   NotifierChannel = "email"
+	NotifierChannelSlack   NotifierChannel = "slack"
+	NotifierChannelWebhook NotifierChannel = "webhook"
+	NotifierChannelInApp   NotifierChannel = "in_app"
+)
+
+// SecurityEventType is an entry in a user's account security audit trail.
+type SecurityEventType string
+
+const (
+	SecurityEventPasswordResetRequested SecurityEventType = "password_reset_requested"
+	SecurityEventPasswordResetCompleted SecurityEventType = "password_reset_completed"
+	// SecurityEventPasswordResetRejected is a valid reset link used from another
+	// IP address or browser than the one that asked for it.
+	SecurityEventPasswordResetRejected SecurityEventType = "password_reset_rejected"
+	SecurityEventEmailChanged          SecurityEventType = "email_changed"
+	SecurityEventEmailChangeUndone     SecurityEventType = "email_change_undone"
+	SecurityEventInvited               SecurityEventType = "invited"
+	SecurityEventInvitationAccepted    SecurityEventType = "invitation_accepted"
+)
+
+// StorageEncryption is the S3 server-side encryption applied to a tenant's objects.
+type StorageEncryption string
+
+const (
+	// StorageEncryptionS3 encrypts with S3-managed keys (AES256).
+	StorageEncryptionS3 StorageEncryption = "sse-s3"
+	// StorageEncryptionKMS encrypts with the tenant's AWS KMS key.
+	StorageEncryptionKMS StorageEncryption = "sse-kms"
+)
+
+// EmailSuppressionReason is why SES reported an address as undeliverable.
+type EmailSuppressionReason string
+
+const (
+	// EmailSuppressionBounce is a permanent (hard) bounce.
+	EmailSuppressionBounce EmailSuppressionReason = "bounce"
+	// EmailSuppressionComplaint is the recipient marking a message as spam.
+	EmailSuppressionComplaint EmailSuppressionReason = "complaint"
+)
+
+// UserImportRowStatus is the outcome of one row of a bulk user import.
+type UserImportRowStatus string
+
+const (
+	UserImportRowCreated UserImportRowStatus = "created"
+	UserImportRowFailed  UserImportRowStatus = "failed"
+)
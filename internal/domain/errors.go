@@ -22,6 +22,7 @@ var (
 	ErrDocumentNotFound        = errors.New("document not found")
 	ErrDocumentAlreadyExists   = errors.New("document already exists for this file")
 	ErrDocumentNotParsed       = errors.New("document has not been parsed yet")
+	ErrDocumentParseInProgress = errors.New("document is still being parsed")
 	ErrValidationRuleNotFound  = errors.New("validation rule not found")
 	ErrInsufficientRole        = errors.New("insufficient role for this action")
 	ErrInvalidStructuredData   = errors.New("invalid structured data format")
@@ -31,4 +32,95 @@ var (
 	ErrSocialAuthTokenInvalid      = errors.New("social auth token is invalid or expired")
 	ErrPasswordLoginNotAllowed     = errors.New("this account uses social login; password login is not available")
 	ErrAssigneeCannotReview        = errors.New("assignee does not have review permission on this collection")
+	ErrUnknownFeatureFlag          = errors.New("unknown feature flag")
+	ErrFeatureDisabled             = errors.New("feature is not enabled for this tenant")
+	ErrFileContentMismatch         = errors.New("file content does not match its declared type")
+	ErrPDFEncrypted                = errors.New("PDF is encrypted or password-protected")
+	ErrPDFNoPages                  = errors.New("PDF has no pages")
+	ErrInvalidArchive              = errors.New("file is not a valid ZIP archive")
+	ErrInvalidImportPrefix         = errors.New("import prefix must be a relative path inside the tenant inbox")
+	ErrCloudProviderNotConfigured  = errors.New("cloud storage provider is not configured")
+	ErrInvalidOAuthState           = errors.New("invalid or expired OAuth state")
+	ErrCloudAuthFailed             = errors.New("cloud storage provider rejected the authorization")
+	ErrDuplicateCloudSync          = errors.New("folder is already synced into this collection")
+	ErrStorageUnavailable          = errors.New("object storage is temporarily unavailable")
+	ErrInvalidReviewChecklist      = errors.New("invalid review checklist")
+	ErrReviewChecklistIncomplete   = errors.New("review checklist must be completed before approving")
+	ErrCheckerNotAllowed           = errors.New("confirming an approval requires manager role or collection owner permission")
+	ErrCheckerSameAsMaker          = errors.New("the checker must be a different user from the maker")
+	ErrDelegationNotFound          = errors.New("review delegation not found")
+	ErrInvalidDelegation           = errors.New("invalid review delegation")
+	ErrInvalidBulkTag              = errors.New("invalid bulk tag request")
+	ErrInvalidNeighborContext      = errors.New("invalid neighbor context")
+	ErrInvalidStorageRegion        = errors.New("storage region is not configured")
+	ErrStorageRegionLocked         = errors.New("storage region cannot change once the tenant has files")
+	ErrDataResidencyViolation      = errors.New("object is outside the tenant's storage region")
+	ErrInvalidStorageLifecycle     = errors.New("storage_ia_after_days must be 0 or at least 30")
+	ErrInvalidStorageEncryption    = errors.New("invalid storage encryption")
+	ErrVerificationResendTooSoon   = errors.New("a verification email was sent recently")
+	ErrDemoDataExists              = errors.New("the tenant already has demo data")
+	ErrInvalidDemoOwner            = errors.New("demo data owner must be an active user of the tenant")
+	ErrInvalidExportDestination    = errors.New("analytics export destination must be s3://bucket/prefix")
+	ErrInvalidExportInterval       = errors.New("analytics export interval_hours must be between 1 and 168")
+	ErrInvalidHookEvent            = errors.New("unsupported integration hook event")
+	ErrInvalidHookTarget           = errors.New("integration hook target_url must be a public https URL")
+	ErrInvalidHookTemplate         = errors.New("integration hook payload_template must render valid JSON")
+	ErrInvalidCursor               = errors.New("invalid cursor")
+	ErrInvalidNotificationChannel  = errors.New("invalid notification channel")
+	ErrInvalidNotificationTemplate = errors.New("invalid notification message template")
+	ErrNotificationDeliveryFailed  = errors.New("notification delivery failed")
+	ErrRejectionReasonRequired     = errors.New("a rejection reason is required")
+	ErrInvalidRejectionReason      = errors.New("invalid rejection reason")
+	ErrInvalidEscalationPolicy     = errors.New("invalid escalation policy")
+	ErrInvalidKPITargets           = errors.New("invalid KPI targets")
+	ErrTenantAccessDenied          = errors.New("no access to this tenant")
+	ErrInvalidMembership           = errors.New("invalid tenant membership")
+	ErrInvalidClientTenant         = errors.New("client tenants can't have clients of their own")
+	ErrTenantHasClients            = errors.New("tenant still has client tenants")
+	ErrInvalidSourceURL            = errors.New("source url must be a public https URL")
+	ErrSourceFetchFailed           = errors.New("could not download the file from the source url")
+	ErrParseFailed                 = errors.New("the document could not be parsed")
+	ErrParserUnavailable           = errors.New("the document parser is temporarily unavailable")
+	ErrPageNotFound                = errors.New("the file has no such page")
+	ErrInvalidDPI                  = errors.New("dpi is outside the allowed range")
+	ErrPageRenderUnavailable       = errors.New("page rendering is not available")
+	ErrValidationRunInProgress     = errors.New("a validation run is already in progress for this collection")
+	ErrInvalidProposedRule         = errors.New("invalid proposed validation rule")
+	ErrTenantMoved                 = errors.New("tenant has moved to another database cluster")
+	ErrTenantMoveInProgress        = errors.New("a move of this tenant is already in progress")
+	ErrInvalidMoveTarget           = errors.New("target cluster is not a configured move target")
+	ErrTenantNotMovable            = errors.New("tenant has cross-tenant links and cannot be moved")
+	ErrShardUnavailable            = errors.New("the tenant's shard is not configured on this server")
+	ErrParseBudgetExhausted        = errors.New("daily parse budget exhausted")
+	ErrInvalidLineItemPatch        = errors.New("invalid line item patch")
+	ErrDocumentModified            = errors.New("document was modified since it was read")
+	ErrInvalidJSONPatch            = errors.New("invalid JSON patch")
+	ErrJSONPatchConflict           = errors.New("JSON patch does not apply to the current structured data")
+	ErrInvalidNotificationRoutes   = errors.New("invalid notification routes")
+	ErrEmailChangeUndoInvalid      = errors.New("email change undo link is invalid, expired or already used")
+	ErrInvalidQASampling           = errors.New("invalid QA sampling rate")
+	ErrInvalidParseMode            = errors.New("invalid parse mode")
+	ErrQAReviewNotFound            = errors.New("QA review not found")
+	ErrQAReviewCompleted           = errors.New("QA review already has a verdict")
+	ErrQAOwnReview                 = errors.New("the second reviewer must not have approved the document")
+	ErrInvalidQAVerdict            = errors.New("invalid QA verdict")
+	ErrDocumentLocked              = errors.New("document is locked by another reviewer")
+	ErrInvalidLockTTL              = errors.New("invalid document lock TTL")
+	ErrInvalidBulkDelete           = errors.New("invalid bulk delete request")
+	ErrBulkDeleteUnconfirmed       = errors.New("confirmation token does not match the documents the filter selects")
+	ErrInvalidCORSOrigins          = errors.New("invalid CORS origins")
+	ErrEmailUndeliverable          = errors.New("email address is suppressed after a bounce or complaint")
+	ErrEmailSuppressionShared      = errors.New("suppressed email address is shared with users of other tenants")
+	ErrInvalidSNSMessage           = errors.New("invalid SNS message")
+	ErrInvalidComputedField        = errors.New("invalid computed field")
+	ErrInvalidAmountSearch         = errors.New("invalid amount search")
+	ErrInvalidTimeZone             = errors.New("invalid time zone")
+	ErrInvalidTagValue             = errors.New("tag value does not match its type")
+	ErrInvalidTagSearch            = errors.New("invalid tag search")
+	ErrInvalidDocumentLimit        = errors.New("invalid monthly document limit")
+	ErrInvalidNamingTemplate       = errors.New("invalid naming template")
+	ErrInvalidRelatedParty         = errors.New("invalid related party")
+	ErrFileDownloadRestricted      = errors.New("original file download restricted")
+	ErrInvalidDocumentType         = errors.New("invalid document type")
+	ErrInvalidUserImport           = errors.New("invalid user import")
 )
@@ -2,6 +2,10 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,12 +14,51 @@ import (
 
 // Tenant represents an isolated organizational tenant.
 type Tenant struct {
-	ID        uuid.UUID `db:"id" json:"id"`
-	Name      string    `db:"name" json:"name"`
-	Slug      string    `db:"slug" json:"slug"`
-	IsActive  bool      `db:"is_active" json:"is_active"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	ID       uuid.UUID `db:"id" json:"id"`
+	Name     string    `db:"name" json:"name"`
+	Slug     string    `db:"slug" json:"slug"`
+	IsActive bool      `db:"is_active" json:"is_active"`
+	// StorageRegion pins the tenant's files to one S3 region; nil means the default region.
+	StorageRegion *string `db:"storage_region" json:"storage_region"`
+	// StorageIAAfterDays moves the tenant's objects to infrequent-access storage
+	// after that many days; nil keeps them in standard storage.
+	StorageIAAfterDays *int `db:"storage_ia_after_days" json:"storage_ia_after_days"`
+	// StorageSSE is the server-side encryption of the tenant's uploads; nil uses
+	// the bucket's default. StorageKMSKeyARN is set exactly when it is sse-kms.
+	StorageSSE       *StorageEncryption `db:"storage_sse" json:"storage_sse"`
+	StorageKMSKeyARN *string            `db:"storage_kms_key_arn" json:"storage_kms_key_arn"`
+	// DefaultParseMode applies to documents created without a parse mode in
+	// collections that have none of their own; nil means single.
+	DefaultParseMode *ParseMode `db:"default_parse_mode" json:"default_parse_mode"`
+	// TimeZone is the IANA zone the tenant's dates are read in: invoice
+	// timestamps, report date ranges, KPI due dates and daily report times.
+	TimeZone string `db:"time_zone" json:"time_zone"`
+	// ParentTenantID is the firm tenant that administers this client tenant; nil
+	// for top-level tenants.
+	ParentTenantID *uuid.UUID `db:"parent_tenant_id" json:"parent_tenant_id"`
+	// DBCluster is the database cluster serving the tenant, set when it is moved;
+	// empty for tenants that never moved.
+	DBCluster string `db:"db_cluster" json:"db_cluster"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt          time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// Location returns the tenant's time zone.
+func (t *Tenant) Location() *time.Location {
+	return LoadTimeZone(t.TimeZone)
+}
+
+// LoadTimeZone returns the named IANA time zone, or UTC when name is empty or
+// unknown.
+func LoadTimeZone(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
 
 // User represents an authenticated user belonging to a tenant.
@@ -30,13 +73,47 @@ type User struct {
 	MonthlyDocumentLimit    int        `db:"monthly_document_limit" json:"monthly_document_limit"`
 	DocumentsUsedThisPeriod int        `db:"documents_used_this_period" json:"documents_used_this_period"`
 	CurrentPeriodStart      time.Time  `db:"current_period_start" json:"current_period_start"`
+	// QuotaWarningLevel is the highest usage warning (percent) sent this period.
+	QuotaWarningLevel int `db:"quota_warning_level" json:"-"`
 	EmailVerified           bool       `db:"email_verified" json:"email_verified"`
 	EmailVerifiedAt         *time.Time `db:"email_verified_at" json:"email_verified_at,omitempty"`
+	VerificationSentAt      *time.Time `db:"verification_sent_at" json:"verification_sent_at,omitempty"`
+	VerificationRemindedAt  *time.Time `db:"verification_reminded_at" json:"verification_reminded_at,omitempty"`
 	PasswordResetTokenID    *string      `db:"password_reset_token_id" json:"-"`
 	AuthProvider            AuthProvider `db:"auth_provider" json:"auth_provider"`
 	ProviderUserID          *string      `db:"provider_user_id" json:"-"`
+	// EmailUndeliverable is set while the user's address is suppressed after a
+	// bounce or complaint; no email reaches the user until it is cleared.
+	EmailUndeliverable   *EmailSuppressionReason `db:"email_undeliverable" json:"email_undeliverable,omitempty"`
+	EmailUndeliverableAt *time.Time              `db:"email_undeliverable_at" json:"email_undeliverable_at,omitempty"`
 	CreatedAt               time.Time    `db:"created_at" json:"created_at"`
 	UpdatedAt               time.Time    `db:"updated_at" json:"updated_at"`
+	// HomeTenantID is set when the user was loaded as a guest of TenantID through a
+	// TenantMembership; TenantID and Role are then the guest tenant and role.
+	HomeTenantID *uuid.UUID `db:"-" json:"home_tenant_id,omitempty"`
+}
+
+// QuotaUsage is a user's monthly document quota in the current period.
+type QuotaUsage struct {
+	Limit       int       `json:"limit"`
+	Used        int       `json:"used"`
+	Remaining   int       `json:"remaining"`
+	PeriodStart time.Time `json:"period_start"`
+	ResetsAt    time.Time `json:"resets_at"`
+}
+
+// APIKey is a long-lived credential that acts as its user, with the user's
+// current role. Only a hash of the key is stored.
+type APIKey struct {
+	ID       uuid.UUID `db:"id" json:"id"`
+	TenantID uuid.UUID `db:"tenant_id" json:"tenant_id"`
+	UserID   uuid.UUID `db:"user_id" json:"user_id"`
+	Name     string    `db:"name" json:"name"`
+	// KeyPrefix is the start of the key, to tell keys apart.
+	KeyPrefix  string     `db:"key_prefix" json:"key_prefix"`
+	KeyHash    string     `db:"key_hash" json:"-"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
 }
 
 // Collection represents a grouping of files within a tenant.
@@ -47,8 +124,68 @@ type Collection struct {
 	Description   string    `db:"description" json:"description"`
 	CreatedBy     uuid.UUID `db:"created_by" json:"created_by"`
 	DocumentCount int       `db:"document_count" json:"document_count"`
-	CreatedAt     time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt     time.Time `db:"updated_at" json:"updated_at"`
+	// ReviewChecklist is a JSON array of ReviewChecklistItem.
+	ReviewChecklist json.RawMessage `db:"review_checklist" json:"review_checklist" swaggertype:"array,object"`
+	// CheckerThreshold is the invoice total at or above which an approval needs
+	// checker confirmation; nil means single-stage review.
+	CheckerThreshold *float64 `db:"checker_threshold" json:"checker_threshold"`
+	// EscalateAfterDays is how long a document may wait for review before it is
+	// escalated; nil turns escalation off.
+	EscalateAfterDays *int             `db:"escalate_after_days" json:"escalate_after_days"`
+	EscalationAction  EscalationAction `db:"escalation_action" json:"escalation_action"`
+	// KPITargets is a JSON array of KPITarget.
+	KPITargets json.RawMessage `db:"kpi_targets" json:"kpi_targets" swaggertype:"array,object"`
+	// QASamplePercent is the share of approvals sampled for a blind second
+	// review; nil turns QA sampling off.
+	QASamplePercent *int `db:"qa_sample_percent" json:"qa_sample_percent"`
+	// DefaultParseMode applies to documents created in the collection without a
+	// parse mode; nil falls back to the tenant's default.
+	DefaultParseMode *ParseMode `db:"default_parse_mode" json:"default_parse_mode"`
+	// NamingTemplate renames documents after their first parse, e.g.
+	// "{seller_name}-{invoice_number}"; nil keeps the uploaded file name.
+	NamingTemplate *string `db:"naming_template" json:"naming_template"`
+	// FileDownloadPerm is the collection permission needed to download the
+	// original files of the collection's documents, separately from viewing
+	// their parsed data; nil lets every viewer download them.
+	FileDownloadPerm *CollectionPermission `db:"file_download_perm" json:"file_download_perm"`
+	// IsDemo marks sample data seeded for demos; it is removed by the demo cleanup.
+	IsDemo    bool      `db:"is_demo" json:"is_demo"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// ReviewChecklistItem is a yes/no question reviewers must answer before approving
+// a document in the collection.
+type ReviewChecklistItem struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// KPITarget is a collection goal: TargetPct percent of its documents reaching
+// Metric by the end of DueDate (YYYY-MM-DD, UTC).
+type KPITarget struct {
+	Metric    KPIMetric `json:"metric"`
+	TargetPct float64   `json:"target_pct"`
+	DueDate   string    `json:"due_date"`
+}
+
+// CollectionProgressCounts counts a collection's documents by how far they have
+// got, in total and over the recent window used to measure velocity.
+type CollectionProgressCounts struct {
+	Total          int `db:"total"`
+	Parsed         int `db:"parsed"`
+	Reviewed       int `db:"reviewed"`
+	Approved       int `db:"approved"`
+	ParsedRecent   int `db:"parsed_recent"`
+	ReviewedRecent int `db:"reviewed_recent"`
+	ApprovedRecent int `db:"approved_recent"`
+}
+
+// ReviewChecklistAnswer records a reviewer's answer to one checklist item.
+type ReviewChecklistAnswer struct {
+	ID      string `json:"id"`
+	Label   string `json:"label"`
+	Checked bool   `json:"checked"`
 }
 
 // CollectionPermissionEntry represents a user's permission on a collection.
@@ -78,18 +215,31 @@ type Document struct {
 	CollectionID     uuid.UUID          `db:"collection_id" json:"collection_id"`
 	FileID           uuid.UUID          `db:"file_id" json:"file_id"`
 	Name             string             `db:"name" json:"name"`
+	// OriginalName is the name before the collection's naming template renamed
+	// the document; nil if it was never renamed.
+	OriginalName     *string            `db:"original_name" json:"original_name,omitempty"`
 	DocumentType     string             `db:"document_type" json:"document_type"`
 	ParserModel      string             `db:"parser_model" json:"parser_model"`
 	ParserPrompt     string             `db:"parser_prompt" json:"parser_prompt"`
+	// Language is the ISO 639-1 code of the document's language, set when it is
+	// parsed: "en", an Indian language such as "hi", or "" when unknown.
+	Language         string             `db:"language" json:"language,omitempty"`
 	StructuredData   json.RawMessage    `db:"structured_data" json:"structured_data" swaggertype:"object"`
 	ConfidenceScores json.RawMessage    `db:"confidence_scores" json:"confidence_scores" swaggertype:"object"`
 	ParsingStatus    ParsingStatus      `db:"parsing_status" json:"parsing_status"`
 	ParsingError     string             `db:"parsing_error" json:"parsing_error"`
+	ParseFailureCategory ParseFailureCategory `db:"parse_failure_category" json:"parse_failure_category,omitempty"`
 	ParsedAt         *time.Time         `db:"parsed_at" json:"parsed_at"`
 	ReviewStatus     ReviewStatus       `db:"review_status" json:"review_status"`
 	ReviewedBy       *uuid.UUID         `db:"reviewed_by" json:"reviewed_by"`
 	ReviewedAt       *time.Time         `db:"reviewed_at" json:"reviewed_at"`
 	ReviewerNotes    string             `db:"reviewer_notes" json:"reviewer_notes"`
+	// RejectionReason is the reason code of a rejection; empty otherwise.
+	RejectionReason  string             `db:"rejection_reason" json:"rejection_reason,omitempty"`
+	// ReviewChecklist is a JSON array of ReviewChecklistAnswer from the latest review.
+	ReviewChecklist  json.RawMessage    `db:"review_checklist" json:"review_checklist" swaggertype:"array,object"`
+	MakerApprovedBy  *uuid.UUID         `db:"maker_approved_by" json:"maker_approved_by,omitempty"`
+	MakerApprovedAt  *time.Time         `db:"maker_approved_at" json:"maker_approved_at,omitempty"`
 	ValidationStatus      ValidationStatus     `db:"validation_status" json:"validation_status"`
 	ValidationResults     json.RawMessage      `db:"validation_results" json:"validation_results" swaggertype:"object"`
 	ReconciliationStatus  ReconciliationStatus `db:"reconciliation_status" json:"reconciliation_status"`
@@ -98,23 +248,160 @@ type Document struct {
 	SecondaryParserModel  string               `db:"secondary_parser_model" json:"secondary_parser_model"`
 	ParseAttempts         int                  `db:"parse_attempts" json:"parse_attempts"`
 	RetryAfter            *time.Time           `db:"retry_after" json:"retry_after,omitempty"`
+	QueuedAt              time.Time            `db:"queued_at" json:"queued_at"`
 	AssignedTo            *uuid.UUID           `db:"assigned_to" json:"assigned_to"`
 	AssignedAt            *time.Time           `db:"assigned_at" json:"assigned_at,omitempty"`
 	AssignedBy            *uuid.UUID           `db:"assigned_by" json:"assigned_by"`
+	// EscalatedAt is when a stale pending review was escalated; cleared on review.
+	EscalatedAt           *time.Time           `db:"escalated_at" json:"escalated_at,omitempty"`
+	// Version starts at 1 and goes up each time the document's file is replaced.
+	Version               int                  `db:"version" json:"version"`
 	CreatedBy             uuid.UUID            `db:"created_by" json:"created_by"`
 	CreatedAt             time.Time            `db:"created_at" json:"created_at"`
 	UpdatedAt             time.Time            `db:"updated_at" json:"updated_at"`
+
+	// Filled in on list responses by DocumentService.EnrichDocuments; not stored.
+	Tags                  []DocumentTag        `db:"-" json:"tags,omitempty"`
+	AssigneeName          *string              `db:"-" json:"assignee_name,omitempty"`
+	// Extensions holds the tenant's computed fields by name when requested with
+	// ?extensions=true; a field that fails to evaluate is null.
+	Extensions            map[string]interface{} `db:"-" json:"extensions,omitempty"`
+}
+
+// DocumentVersion is a superseded state of a document, saved when its file is
+// replaced or it is reclassified: the old file and document type and the parse,
+// review and validation results it had.
+// FileID is nil once the old file has been deleted.
+type DocumentVersion struct {
+	ID                uuid.UUID        `db:"id" json:"id"`
+	TenantID          uuid.UUID        `db:"tenant_id" json:"tenant_id"`
+	DocumentID        uuid.UUID        `db:"document_id" json:"document_id"`
+	Version           int              `db:"version" json:"version"`
+	FileID            *uuid.UUID       `db:"file_id" json:"file_id"`
+	DocumentType      string           `db:"document_type" json:"document_type"`
+	ParserModel       string           `db:"parser_model" json:"parser_model"`
+	StructuredData    json.RawMessage  `db:"structured_data" json:"structured_data" swaggertype:"object"`
+	ConfidenceScores  json.RawMessage  `db:"confidence_scores" json:"confidence_scores" swaggertype:"object"`
+	FieldProvenance   json.RawMessage  `db:"field_provenance" json:"field_provenance" swaggertype:"object"`
+	ParsingStatus     ParsingStatus    `db:"parsing_status" json:"parsing_status"`
+	ParsedAt          *time.Time       `db:"parsed_at" json:"parsed_at"`
+	ReviewStatus      ReviewStatus     `db:"review_status" json:"review_status"`
+	ReviewedBy        *uuid.UUID       `db:"reviewed_by" json:"reviewed_by"`
+	ReviewedAt        *time.Time       `db:"reviewed_at" json:"reviewed_at"`
+	ValidationStatus  ValidationStatus `db:"validation_status" json:"validation_status"`
+	ValidationResults json.RawMessage  `db:"validation_results" json:"validation_results" swaggertype:"object"`
+	// Reason is what superseded the version, ReplacedBy the user who did it
+	// and CreatedAt when.
+	Reason     DocumentVersionReason `db:"reason" json:"reason"`
+	ReplacedBy uuid.UUID             `db:"replaced_by" json:"replaced_by"`
+	CreatedAt  time.Time             `db:"created_at" json:"created_at"`
+}
+
+// DocumentApproval is a document as it was at a final approval: its parsed
+// data, confidence and validation results. It is written with the approval and
+// never changed, so later edits and re-parses can't alter what was approved.
+type DocumentApproval struct {
+	ID                uuid.UUID        `db:"id" json:"id"`
+	TenantID          uuid.UUID        `db:"tenant_id" json:"tenant_id"`
+	DocumentID        uuid.UUID        `db:"document_id" json:"document_id"`
+	Version           int              `db:"version" json:"version"`
+	ParserModel       string           `db:"parser_model" json:"parser_model"`
+	StructuredData    json.RawMessage  `db:"structured_data" json:"structured_data" swaggertype:"object"`
+	ConfidenceScores  json.RawMessage  `db:"confidence_scores" json:"confidence_scores" swaggertype:"object"`
+	FieldProvenance   json.RawMessage  `db:"field_provenance" json:"field_provenance" swaggertype:"object"`
+	ValidationStatus  ValidationStatus `db:"validation_status" json:"validation_status"`
+	ValidationResults json.RawMessage  `db:"validation_results" json:"validation_results" swaggertype:"object"`
+	ReviewChecklist   json.RawMessage  `db:"review_checklist" json:"review_checklist" swaggertype:"array,object"`
+	ReviewerNotes     string           `db:"reviewer_notes" json:"reviewer_notes"`
+	// ApprovedBy is the final approver: the checker when a maker approved first.
+	ApprovedBy      uuid.UUID  `db:"approved_by" json:"approved_by"`
+	ApprovedAt      time.Time  `db:"approved_at" json:"approved_at"`
+	MakerApprovedBy *uuid.UUID `db:"maker_approved_by" json:"maker_approved_by"`
+	MakerApprovedAt *time.Time `db:"maker_approved_at" json:"maker_approved_at"`
+	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
+}
+
+// DocumentChange is the latest change of a document, kept by a database trigger
+// for the change stream. A deleted document keeps its row as a tombstone.
+type DocumentChange struct {
+	DocumentID   uuid.UUID `db:"document_id"`
+	TenantID     uuid.UUID `db:"tenant_id"`
+	CollectionID uuid.UUID `db:"collection_id"`
+	ChangedAt    time.Time `db:"changed_at"`
+	Deleted      bool      `db:"deleted"`
 }
 
-// DocumentTag represents a searchable tag on a document.
+// DocumentTag represents a searchable tag on a document. Number and date tags
+// also keep their parsed value, set by SetValueType, for range search.
 type DocumentTag struct {
-	ID         uuid.UUID `db:"id" json:"id"`
-	DocumentID uuid.UUID `db:"document_id" json:"document_id"`
-	TenantID   uuid.UUID `db:"tenant_id" json:"tenant_id"`
-	Key        string    `db:"key" json:"key"`
-	Value      string    `db:"value" json:"value"`
-	Source     string    `db:"source" json:"source"`
-	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	ID          uuid.UUID    `db:"id" json:"id"`
+	DocumentID  uuid.UUID    `db:"document_id" json:"document_id"`
+	TenantID    uuid.UUID    `db:"tenant_id" json:"tenant_id"`
+	Key         string       `db:"key" json:"key"`
+	Value       string       `db:"value" json:"value"`
+	ValueType   TagValueType `db:"value_type" json:"value_type"`
+	NumberValue *float64     `db:"number_value" json:"-"`
+	DateValue   *time.Time   `db:"date_value" json:"-"`
+	Source      string       `db:"source" json:"source"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+}
+
+// SetValueType types the tag, parsing its value. An empty type is string. It
+// returns ErrInvalidTagValue for an unknown type or a value that isn't a number
+// or date as the type requires.
+func (t *DocumentTag) SetValueType(vt TagValueType) error {
+	t.NumberValue, t.DateValue = nil, nil
+	switch vt {
+	case "", TagValueString:
+		t.ValueType = TagValueString
+	case TagValueNumber:
+		n, err := ParseTagNumber(t.Value)
+		if err != nil {
+			return err
+		}
+		t.ValueType, t.NumberValue = vt, &n
+	case TagValueDate:
+		d, err := ParseTagDate(t.Value)
+		if err != nil {
+			return err
+		}
+		t.ValueType, t.DateValue = vt, &d
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidTagValue, vt)
+	}
+	return nil
+}
+
+// ParseTagNumber parses the value of a number tag.
+func ParseTagNumber(s string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return 0, fmt.Errorf("%w: %q is not a number", ErrInvalidTagValue, s)
+	}
+	return n, nil
+}
+
+// ParseTagDate parses the value of a date tag.
+func ParseTagDate(s string) (time.Time, error) {
+	d, err := time.Parse("2006-01-02", strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q is not a YYYY-MM-DD date", ErrInvalidTagValue, s)
+	}
+	return d, nil
+}
+
+// TagQuery selects documents by one tag key. Exactly one of Value (exact match),
+// Prefix or a range is set; a range has at least one bound of its Type.
+type TagQuery struct {
+	Key    string
+	Value  string
+	Prefix string
+	// Type is number or date for a range, bounds inclusive.
+	Type      TagValueType
+	MinNumber *float64
+	MaxNumber *float64
+	MinDate   *time.Time
+	MaxDate   *time.Time
 }
 
 // DocumentValidationRule represents a configurable validation rule for documents.
@@ -155,9 +442,280 @@ type Stats struct {
 	ReconciliationWarning int `db:"reconciliation_warning" json:"reconciliation_warning"`
 	ReconciliationInvalid int `db:"reconciliation_invalid" json:"reconciliation_invalid"`
 
-	ReviewPending  int `db:"review_pending" json:"review_pending"`
-	ReviewApproved int `db:"review_approved" json:"review_approved"`
-	ReviewRejected int `db:"review_rejected" json:"review_rejected"`
+	ReviewPending         int `db:"review_pending" json:"review_pending"`
+	ReviewApproved        int `db:"review_approved" json:"review_approved"`
+	ReviewRejected        int `db:"review_rejected" json:"review_rejected"`
+	ReviewAwaitingChecker int `db:"review_awaiting_checker" json:"review_awaiting_checker"`
+}
+
+// StatsDiscrepancy is a collection whose materialized document count disagreed
+// with the documents table when the tenant's stats were recounted.
+type StatsDiscrepancy struct {
+	CollectionID uuid.UUID `db:"collection_id" json:"collection_id"`
+	Materialized int       `db:"materialized" json:"materialized"`
+	Actual       int       `db:"actual" json:"actual"`
+}
+
+// StatsRecount reports the outcome of rebuilding a tenant's materialized stats.
+type StatsRecount struct {
+	TenantID      uuid.UUID          `json:"tenant_id"`
+	Discrepancies []StatsDiscrepancy `json:"discrepancies"`
+	RecountedAt   time.Time          `json:"recounted_at"`
+}
+
+// CollectionQualityCounts are the aggregates a collection's data quality score is
+// computed from, either live or as recorded on Day. ConfidenceSum adds up the mean
+// field confidence of each parsed document.
+type CollectionQualityCounts struct {
+	CollectionID      uuid.UUID `db:"collection_id"`
+	CollectionName    string    `db:"collection_name"`
+	Day               time.Time `db:"day"`
+	TotalDocuments    int       `db:"total_documents"`
+	ParsedDocuments   int       `db:"parsed_documents"`
+	ConfidenceSum     float64   `db:"confidence_sum"`
+	ValidationValid   int       `db:"validation_valid"`
+	ValidationWarning int       `db:"validation_warning"`
+	ReviewApproved    int       `db:"review_approved"`
+}
+
+// CollectionQuality is a collection's data quality score: one number from 0 to
+// 100 combining parse confidence, validation pass rate and review coverage, each
+// a share of all the collection's documents. Score is nil for an empty collection.
+type CollectionQuality struct {
+	CollectionID       uuid.UUID  `json:"collection_id"`
+	CollectionName     string     `json:"collection_name,omitempty"`
+	Day                *time.Time `json:"day,omitempty"`
+	Score              *float64   `json:"score"`
+	TotalDocuments     int        `json:"total_documents"`
+	ParsedDocuments    int        `json:"parsed_documents"`
+	Confidence         float64    `json:"confidence"`
+	ValidationPassRate float64    `json:"validation_pass_rate"`
+	ReviewCoverage     float64    `json:"review_coverage"`
+}
+
+// CollectionQualityFilter narrows the live quality scores to one collection and
+// to documents whose invoice date falls within From and To.
+type CollectionQualityFilter struct {
+	CollectionID *uuid.UUID
+	From         *time.Time
+	To           *time.Time
+}
+
+// ParseTiming records one parse attempt: the provider, queue wait, parser
+// duration, outcome and token usage. QueueMs runs from when the document became
+// eligible to parse (created, retried, or its rate-limit backoff elapsed) until
+// the attempt started.
+type ParseTiming struct {
+	ID          uuid.UUID     `db:"id" json:"id"`
+	TenantID    uuid.UUID     `db:"tenant_id" json:"tenant_id"`
+	DocumentID  uuid.UUID     `db:"document_id" json:"document_id"`
+	Attempt     int           `db:"attempt" json:"attempt"`
+	ParserModel string        `db:"parser_model" json:"parser_model"`
+	// SecondaryParserModel is the second model of a dual parse.
+	SecondaryParserModel string        `db:"secondary_parser_model" json:"secondary_parser_model,omitempty"`
+	ParseMode            ParseMode     `db:"parse_mode" json:"parse_mode"`
+	Outcome              ParsingStatus `db:"outcome" json:"outcome"`
+	QueueMs              int64         `db:"queue_ms" json:"queue_ms"`
+	ParseMs              int64         `db:"parse_ms" json:"parse_ms"`
+	// FailureCategory and ErrorMessage are set when the attempt did not complete.
+	FailureCategory ParseFailureCategory `db:"failure_category" json:"failure_category,omitempty"`
+	ErrorMessage    string               `db:"error_message" json:"error_message,omitempty"`
+	// Tokens reported by the provider, summed over both models of a dual parse.
+	// Zero when the attempt failed or the provider reports no usage.
+	InputTokens  int       `db:"input_tokens" json:"input_tokens"`
+	OutputTokens int       `db:"output_tokens" json:"output_tokens"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
+
+// ParseLatencyStats holds queue-wait and parse-duration percentiles (milliseconds)
+// for one parser model over a time window.
+type ParseLatencyStats struct {
+	ParserModel string  `db:"parser_model" json:"parser_model"`
+	Attempts    int     `db:"attempts" json:"attempts"`
+	Failed      int     `db:"failed" json:"failed"`
+	TimedOut    int     `db:"timed_out" json:"timed_out"`
+	ParseP50Ms  float64 `db:"parse_p50_ms" json:"parse_p50_ms"`
+	ParseP95Ms  float64 `db:"parse_p95_ms" json:"parse_p95_ms"`
+	ParseP99Ms  float64 `db:"parse_p99_ms" json:"parse_p99_ms"`
+	QueueP50Ms  float64 `db:"queue_p50_ms" json:"queue_p50_ms"`
+	QueueP95Ms  float64 `db:"queue_p95_ms" json:"queue_p95_ms"`
+	QueueP99Ms  float64 `db:"queue_p99_ms" json:"queue_p99_ms"`
+}
+
+// ConfidenceObservation pairs the confidence a parser gave one field with whether a
+// reviewer corrected that field. Observations are recorded when a reviewer edits
+// parser output or approves a document, and feed the calibration report.
+type ConfidenceObservation struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	TenantID     uuid.UUID `db:"tenant_id" json:"tenant_id"`
+	DocumentID   uuid.UUID `db:"document_id" json:"document_id"`
+	ParserModel  string    `db:"parser_model" json:"parser_model"`
+	DocumentType string    `db:"document_type" json:"document_type"`
+	FieldPath    string    `db:"field_path" json:"field_path"`
+	Confidence   float64   `db:"confidence" json:"confidence"`
+	Corrected    bool      `db:"corrected" json:"corrected"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
+
+// CalibrationBucketStats counts observations for one parser model whose confidence
+// falls in bucket Bucket (0 = [0.0, 0.1), ..., 9 = [0.9, 1.0]).
+type CalibrationBucketStats struct {
+	ParserModel    string  `db:"parser_model"`
+	Bucket         int     `db:"bucket"`
+	Fields         int     `db:"fields"`
+	Corrected      int     `db:"corrected"`
+	MeanConfidence float64 `db:"mean_confidence"`
+}
+
+// CalibrationBucket is one point of a reliability curve: of the fields a parser
+// scored within [MinConfidence, MaxConfidence), the share reviewers left unchanged.
+// Accuracy is nil when the bucket has no observations.
+type CalibrationBucket struct {
+	MinConfidence  float64  `json:"min_confidence"`
+	MaxConfidence  float64  `json:"max_confidence"`
+	Fields         int      `json:"fields"`
+	Corrected      int      `json:"corrected"`
+	MeanConfidence float64  `json:"mean_confidence"`
+	Accuracy       *float64 `json:"accuracy"`
+}
+
+// ConfidenceCalibration is the reliability curve of one parser model.
+type ConfidenceCalibration struct {
+	ParserModel string              `json:"parser_model"`
+	Fields      int                 `json:"fields"`
+	Corrected   int                 `json:"corrected"`
+	Accuracy    float64             `json:"accuracy"`
+	Buckets     []CalibrationBucket `json:"buckets"`
+}
+
+// DocumentLock is a soft lock on a document held by the user reviewing it.
+// It lapses at ExpiresAt unless the holder renews it; HolderName is filled in
+// on reads for the "being reviewed by" indicator.
+type DocumentLock struct {
+	DocumentID uuid.UUID `db:"document_id" json:"document_id"`
+	TenantID   uuid.UUID `db:"tenant_id" json:"tenant_id"`
+	UserID     uuid.UUID `db:"user_id" json:"user_id"`
+	HolderName string    `db:"-" json:"holder_name"`
+	AcquiredAt time.Time `db:"acquired_at" json:"acquired_at"`
+	ExpiresAt  time.Time `db:"expires_at" json:"expires_at"`
+}
+
+// QAReview is a second-opinion sample of an approved document. ReviewerID made
+// the final approval (MakerID the first, under maker-checker); neither may give
+// the second opinion. Decision, DisputedFields and Notes are the second
+// reviewer's; Disagreed is set on completion when they rejected the document or
+// disputed any field.
+type QAReview struct {
+	ID               uuid.UUID       `db:"id" json:"id"`
+	TenantID         uuid.UUID       `db:"tenant_id" json:"tenant_id"`
+	DocumentID       uuid.UUID       `db:"document_id" json:"document_id"`
+	CollectionID     uuid.UUID       `db:"collection_id" json:"collection_id"`
+	ReviewerID       uuid.UUID       `db:"reviewer_id" json:"reviewer_id"`
+	MakerID          *uuid.UUID      `db:"maker_id" json:"maker_id"`
+	Status           QAReviewStatus  `db:"status" json:"status"`
+	SecondReviewerID *uuid.UUID      `db:"second_reviewer_id" json:"second_reviewer_id"`
+	Decision         *ReviewStatus   `db:"decision" json:"decision"`
+	DisputedFields   json.RawMessage `db:"disputed_fields" json:"disputed_fields" swaggertype:"array,string"`
+	Disagreed        *bool           `db:"disagreed" json:"disagreed"`
+	Notes            string          `db:"notes" json:"notes"`
+	SampledAt        time.Time       `db:"sampled_at" json:"sampled_at"`
+	CompletedAt      *time.Time      `db:"completed_at" json:"completed_at"`
+}
+
+// QASample is the blind view of a pending QA review given to second reviewers:
+// the document as approved, without who approved it or their notes.
+type QASample struct {
+	ID             uuid.UUID       `db:"id" json:"id"`
+	DocumentID     uuid.UUID       `db:"document_id" json:"document_id"`
+	CollectionID   uuid.UUID       `db:"collection_id" json:"collection_id"`
+	FileID         uuid.UUID       `db:"file_id" json:"file_id"`
+	DocumentName   string          `db:"document_name" json:"document_name"`
+	DocumentType   string          `db:"document_type" json:"document_type"`
+	StructuredData json.RawMessage `db:"structured_data" json:"structured_data" swaggertype:"object"`
+	SampledAt      time.Time       `db:"sampled_at" json:"sampled_at"`
+}
+
+// QAReviewerScore is one reviewer's QA record over a window: how many of their
+// approvals were sampled, how many got a second opinion, and how many of those
+// the second reviewer disagreed with. AgreementRate is nil until a sample of
+// theirs has been completed.
+type QAReviewerScore struct {
+	ReviewerID    uuid.UUID `db:"reviewer_id" json:"reviewer_id"`
+	ReviewerName  string    `db:"-" json:"reviewer_name"`
+	Sampled       int       `db:"sampled" json:"sampled"`
+	Completed     int       `db:"completed" json:"completed"`
+	Disagreements int       `db:"disagreements" json:"disagreements"`
+	AgreementRate *float64  `db:"-" json:"agreement_rate"`
+}
+
+// ValidationRuleOutcome is the latest result of one validation rule on one
+// document. Failing is the current result; the repository derives the sticky
+// failed, corrected and overridden flags from successive results.
+type ValidationRuleOutcome struct {
+	TenantID     uuid.UUID `db:"tenant_id" json:"tenant_id"`
+	DocumentID   uuid.UUID `db:"document_id" json:"document_id"`
+	RuleID       uuid.UUID `db:"rule_id" json:"rule_id"`
+	DocumentType string    `db:"document_type" json:"document_type"`
+	FieldPath    string    `db:"field_path" json:"field_path"`
+	Failing      bool      `db:"failing" json:"failing"`
+}
+
+// RuleFailureStats counts, for one validation rule, the documents it was evaluated
+// on, how many it failed, how many of those failures reviewers approved anyway
+// (Overrides) and how many were later cleared by an edit or re-parse (Corrections).
+// RuleName and Severity are empty once the rule has been deleted.
+type RuleFailureStats struct {
+	RuleID         uuid.UUID          `db:"rule_id" json:"rule_id"`
+	RuleName       string             `db:"rule_name" json:"rule_name"`
+	BuiltinRuleKey *string            `db:"builtin_rule_key" json:"builtin_rule_key"`
+	Severity       ValidationSeverity `db:"severity" json:"severity"`
+	FieldPath      string             `db:"field_path" json:"field_path"`
+	Evaluations    int                `db:"evaluations" json:"evaluations"`
+	Failures       int                `db:"failures" json:"failures"`
+	Overrides      int                `db:"overrides" json:"overrides"`
+	Corrections    int                `db:"corrections" json:"corrections"`
+}
+
+// RuleNoise is one row of the noisy rules report. FailureRate is failures over
+// evaluations; OverrideRate and CorrectionRate are shares of failures. A high
+// NoiseScore (FailureRate × OverrideRate) marks a rule reviewers routinely
+// ignore, a candidate for downgrading to a warning; a high CorrectionRate points
+// at a parser field that needs prompt work.
+type RuleNoise struct {
+	RuleFailureStats
+	FailureRate    float64 `json:"failure_rate"`
+	OverrideRate   float64 `json:"override_rate"`
+	CorrectionRate float64 `json:"correction_rate"`
+	NoiseScore     float64 `json:"noise_score"`
+}
+
+// TimelineParseAttempt is the TimelineEvent type for one parse attempt; every other
+// event type is the AuditAction of the audit entry it came from.
+const TimelineParseAttempt = "parse_attempt"
+
+// TimelineEvent is one step in a document's processing history.
+type TimelineEvent struct {
+	At              time.Time       `json:"at"`
+	Type            string          `json:"type"`
+	UserID          *uuid.UUID      `json:"user_id,omitempty"`
+	DurationMs      *int64          `json:"duration_ms,omitempty"` // parse attempts only
+	SincePreviousMs int64           `json:"since_previous_ms"`     // gap from the previous event (or creation)
+	Details         json.RawMessage `json:"details,omitempty" swaggertype:"object"`
+}
+
+// DocumentTimeline is a chronological view of a document's processing assembled
+// from the audit log and per-attempt parse timings.
+type DocumentTimeline struct {
+	DocumentID           uuid.UUID            `json:"document_id"`
+	ParsingStatus        ParsingStatus        `json:"parsing_status"`
+	ParseFailureCategory ParseFailureCategory `json:"parse_failure_category,omitempty"`
+	ReviewStatus         ReviewStatus         `json:"review_status"`
+	CreatedAt            time.Time            `json:"created_at"`
+	ElapsedMs            int64                `json:"elapsed_ms"` // creation to the latest event
+	ParseAttempts        int                  `json:"parse_attempts"`
+	TotalQueueMs         int64                `json:"total_queue_ms"`
+	TotalParseMs         int64                `json:"total_parse_ms"`
+	Events               []TimelineEvent      `json:"events"`
 }
 
 // DocumentSummary is a denormalized view of a parsed document for reporting.
@@ -264,6 +822,30 @@ type PartyLedgerRow struct {
 	ReviewStatus      ReviewStatus     `db:"review_status" json:"review_status"`
 }
 
+// AmountTolerance is how far a document's total may be from a searched amount:
+// an absolute amount, or a percentage of the searched amount when Percent is set.
+type AmountTolerance struct {
+	Value   float64
+	Percent bool
+}
+
+// AmountMatchRow is a document whose grand total matches a searched amount,
+// used to match bank payments and payment advices against invoices.
+type AmountMatchRow struct {
+	DocumentID    uuid.UUID    `db:"document_id" json:"document_id"`
+	DocumentName  string       `db:"document_name" json:"document_name"`
+	CollectionID  uuid.UUID    `db:"collection_id" json:"collection_id"`
+	InvoiceNumber string       `db:"invoice_number" json:"invoice_number"`
+	InvoiceDate   *time.Time   `db:"invoice_date" json:"invoice_date"`
+	SellerName    string       `db:"seller_name" json:"seller_name"`
+	SellerGSTIN   string       `db:"seller_gstin" json:"seller_gstin"`
+	BuyerName     string       `db:"buyer_name" json:"buyer_name"`
+	BuyerGSTIN    string       `db:"buyer_gstin" json:"buyer_gstin"`
+	TotalAmount   float64      `db:"total_amount" json:"total_amount"`
+	Difference    float64      `db:"difference" json:"difference"` // total_amount minus the searched amount
+	ReviewStatus  ReviewStatus `db:"review_status" json:"review_status"`
+}
+
 // FinancialSummaryRow is one row in the financial summary report (one per time period).
 type FinancialSummaryRow struct {
 	Period        string    `json:"period"`
@@ -295,6 +877,27 @@ type TaxSummaryRow struct {
 	TotalTax          float64   `db:"total_tax" json:"total_tax"`
 }
 
+// HSNCode is one distinct HSN/SAC code from the master list with every GST rate
+// currently in force for it.
+type HSNCode struct {
+	Code        string          `db:"code"`
+	Description string          `db:"description"`
+	ParentCode  *string         `db:"parent_code"`
+	GSTRates    pq.Float64Array `db:"gst_rates"`
+}
+
+// HSNNode is a node in the HSN hierarchy: chapter (2 digits) → heading (4) →
+// subheading (6) → tariff item (8). Chapters are not in the master list, so they
+// have no description or rates.
+type HSNNode struct {
+	Code        string     `json:"code"`
+	Description string     `json:"description"`
+	Level       string     `json:"level"`
+	GSTRates    []float64  `json:"gst_rates"`
+	HasChildren bool       `json:"has_children"`
+	Children    []*HSNNode `json:"children,omitempty"`
+}
+
 // HSNSummaryRow is one row in the HSN summary report.
 type HSNSummaryRow struct {
 	HSNCode       string  `json:"hsn_code"`
@@ -341,6 +944,430 @@ type DocumentAuditEntry struct {
 	CreatedAt  time.Time        `db:"created_at" json:"created_at"`
 }
 
+// TenantFeatureFlag is the effective value of a feature flag for a tenant.
+// Overridden is false when the value comes from FeatureFlagDefaults.
+type TenantFeatureFlag struct {
+	TenantID   uuid.UUID   `db:"tenant_id" json:"tenant_id"`
+	Flag       FeatureFlag `db:"flag" json:"flag"`
+	Enabled    bool        `db:"enabled" json:"enabled"`
+	Overridden bool        `db:"-" json:"overridden"`
+	UpdatedBy  *uuid.UUID  `db:"updated_by" json:"updated_by,omitempty"`
+	CreatedAt  time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time   `db:"updated_at" json:"updated_at"`
+}
+
+// ReviewDelegation routes a user's review work to a delegate while they are away.
+// StartsOn and EndsOn are dates; the delegation is active on both. ShareQueue also
+// shows the user's existing review queue to the delegate while it is active.
+type ReviewDelegation struct {
+	TenantID   uuid.UUID `db:"tenant_id" json:"tenant_id"`
+	UserID     uuid.UUID `db:"user_id" json:"user_id"`
+	DelegateID uuid.UUID `db:"delegate_id" json:"delegate_id"`
+	StartsOn   time.Time `db:"starts_on" json:"starts_on"`
+	EndsOn     time.Time `db:"ends_on" json:"ends_on"`
+	ShareQueue bool      `db:"share_queue" json:"share_queue"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// ActiveOn reports whether the delegation covers the calendar date of t (UTC).
+func (d *ReviewDelegation) ActiveOn(t time.Time) bool {
+	day := t.UTC().Format("2006-01-02")
+	return day >= d.StartsOn.Format("2006-01-02") && day <= d.EndsOn.Format("2006-01-02")
+}
+
+// RouteRule declares who may call an API route. Roles is the set of tenant roles the
+// router lets through; CollectionPerm documents the collection-level permission the
+// service additionally enforces ("" when the route is not collection-scoped).
+type RouteRule struct {
+	Method         string               `json:"method"`
+	Path           string               `json:"path"`
+	Roles          []UserRole           `json:"roles"`
+	CollectionPerm CollectionPermission `json:"collection_perm,omitempty"`
+	// VerifiedEmail marks routes that free-tier users may only call once their
+	// email is verified.
+	VerifiedEmail bool `json:"verified_email,omitempty"`
+}
+
+// Operation is an API route the current user may call. CollectionPerm is the
+// collection permission it additionally needs on the target collection.
+type Operation struct {
+	Method         string               `json:"method"`
+	Path           string               `json:"path"`
+	CollectionPerm CollectionPermission `json:"collection_perm,omitempty"`
+}
+
+// Capabilities is what the current user may do in their tenant, so clients can
+// hide actions instead of re-implementing the authorization matrix.
+// CollectionPerm is the permission the role gives on every collection;
+// Collections lists explicit grants above it.
+type Capabilities struct {
+	Role           UserRole                           `json:"role"`
+	EmailVerified  bool                               `json:"email_verified"`
+	Operations     []Operation                        `json:"operations"`
+	CollectionPerm CollectionPermission               `json:"collection_perm"`
+	Collections    map[uuid.UUID]CollectionPermission `json:"collections"`
+	// FileDownloadDenied lists the collections whose documents' original files
+	// the user may not download, though they may view the parsed data.
+	FileDownloadDenied []uuid.UUID          `json:"file_download_denied"`
+	Features           map[FeatureFlag]bool `json:"features"`
+}
+
+// AuditFilters holds filter parameters for tenant-wide audit log queries.
+// Nil/empty fields are not applied.
+type AuditFilters struct {
+	Actions      []string
+	UserID       *uuid.UUID
+	DocumentID   *uuid.UUID
+	CollectionID *uuid.UUID
+	From         *time.Time
+	To           *time.Time
+	Offset       int
+	Limit        int
+}
+
+// ValidationFailure is one failing validation result of a document, with the
+// rule it came from, as listed in a collection's fix-list export.
+type ValidationFailure struct {
+	DocumentID             uuid.UUID          `db:"document_id" json:"document_id"`
+	DocumentName           string             `db:"document_name" json:"document_name"`
+	InvoiceNumber          string             `db:"invoice_number" json:"invoice_number"`
+	ReviewStatus           ReviewStatus       `db:"review_status" json:"review_status"`
+	RuleName               string             `db:"rule_name" json:"rule_name"`
+	RuleType               string             `db:"rule_type" json:"rule_type"`
+	Severity               ValidationSeverity `db:"severity" json:"severity"`
+	FieldPath              string             `db:"field_path" json:"field_path"`
+	ExpectedValue          string             `db:"expected_value" json:"expected_value"`
+	ActualValue            string             `db:"actual_value" json:"actual_value"`
+	Message                string             `db:"message" json:"message"`
+	ReconciliationCritical bool               `db:"reconciliation_critical" json:"reconciliation_critical"`
+}
+
+// CollectionEvent records a change to a collection itself, such as a permission
+// being granted or revoked. Document events stay in the document audit log.
+type CollectionEvent struct {
+	ID           uuid.UUID       `db:"id" json:"id"`
+	TenantID     uuid.UUID       `db:"tenant_id" json:"tenant_id"`
+	CollectionID uuid.UUID       `db:"collection_id" json:"collection_id"`
+	UserID       *uuid.UUID      `db:"user_id" json:"user_id,omitempty"`
+	Action       string          `db:"action" json:"action"`
+	TargetUserID *uuid.UUID      `db:"target_user_id" json:"target_user_id,omitempty"`
+	Changes      json.RawMessage `db:"changes" json:"changes" swaggertype:"object"`
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"`
+}
+
+// CollectionActivityEvent is one entry of a collection's activity feed: a file
+// upload, a document audit event or a collection event.
+type CollectionActivityEvent struct {
+	Type         string          `db:"type" json:"type"`
+	UserID       *uuid.UUID      `db:"user_id" json:"user_id,omitempty"`
+	UserName     string          `db:"user_name" json:"user_name,omitempty"`
+	DocumentID   *uuid.UUID      `db:"document_id" json:"document_id,omitempty"`
+	DocumentName string          `db:"document_name" json:"document_name,omitempty"`
+	FileID       *uuid.UUID      `db:"file_id" json:"file_id,omitempty"`
+	TargetUserID *uuid.UUID      `db:"target_user_id" json:"target_user_id,omitempty"`
+	Details      json.RawMessage `db:"details" json:"details" swaggertype:"object"`
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"`
+}
+
+// ExportArtifact is one distinct file produced by a collection export,
+// identified by its SHA-256 checksum. GeneratedBy and Filters come from the
+// first export that produced it; every export, that one included, is an
+// ExportDownload.
+type ExportArtifact struct {
+	ID               uuid.UUID       `db:"id" json:"id"`
+	TenantID         uuid.UUID       `db:"tenant_id" json:"tenant_id"`
+	CollectionID     uuid.UUID       `db:"collection_id" json:"collection_id"`
+	Kind             ExportKind      `db:"kind" json:"kind"`
+	Format           string          `db:"format" json:"format"`
+	Filters          json.RawMessage `db:"filters" json:"filters" swaggertype:"object"`
+	RowCount         int             `db:"row_count" json:"row_count"`
+	SizeBytes        int64           `db:"size_bytes" json:"size_bytes"`
+	ChecksumSHA256   string          `db:"checksum_sha256" json:"checksum_sha256"`
+	GeneratedBy      uuid.UUID       `db:"generated_by" json:"generated_by"`
+	CreatedAt        time.Time       `db:"created_at" json:"created_at"`
+	DownloadCount    int             `db:"download_count" json:"download_count"`
+	LastDownloadedAt *time.Time      `db:"last_downloaded_at" json:"last_downloaded_at,omitempty"`
+}
+
+// ExportDownload records one user receiving an export artifact.
+type ExportDownload struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	TenantID   uuid.UUID `db:"tenant_id" json:"tenant_id"`
+	ArtifactID uuid.UUID `db:"artifact_id" json:"artifact_id"`
+	UserID     uuid.UUID `db:"user_id" json:"user_id"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// AuthzDenial records a request rejected with 403: who made it, what they tried
+// to reach and the error code and message they got back.
+type AuthzDenial struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	TenantID  uuid.UUID `db:"tenant_id" json:"tenant_id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Role      string    `db:"role" json:"role"`
+	Method    string    `db:"method" json:"method"`
+	Path      string    `db:"path" json:"path"`
+	Route     string    `db:"route" json:"route"`
+	Code      string    `db:"code" json:"code"`
+	Reason    string    `db:"reason" json:"reason"`
+	RequestID string    `db:"request_id" json:"request_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// AuthzDenialFilters holds filter parameters for listing authorization denials.
+// Nil/empty fields are not applied.
+type AuthzDenialFilters struct {
+	UserID *uuid.UUID
+	Code   string
+	From   *time.Time
+	To     *time.Time
+	Offset int
+	Limit  int
+}
+
+// DocumentFieldOverride is a reviewer correction to a single structured-data field.
+// Overrides are re-applied on top of parser output after every re-parse.
+type DocumentFieldOverride struct {
+	ID         uuid.UUID       `db:"id" json:"id"`
+	TenantID   uuid.UUID       `db:"tenant_id" json:"tenant_id"`
+	DocumentID uuid.UUID       `db:"document_id" json:"document_id"`
+	FieldPath  string          `db:"field_path" json:"field_path"`
+	Value      json.RawMessage `db:"value" json:"value" swaggertype:"object"`
+	CreatedBy  uuid.UUID       `db:"created_by" json:"created_by"`
+	CreatedAt  time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time       `db:"updated_at" json:"updated_at"`
+}
+
+// ImportJob tracks a bulk import (ZIP archive or S3 prefix) into a collection.
+// Entries is the per-file report, a JSON array of ImportEntry.
+type ImportJob struct {
+	ID            uuid.UUID       `db:"id" json:"id"`
+	TenantID      uuid.UUID       `db:"tenant_id" json:"tenant_id"`
+	CollectionID  uuid.UUID       `db:"collection_id" json:"collection_id"`
+	CreatedBy     uuid.UUID       `db:"created_by" json:"created_by"`
+	Source        ImportSource    `db:"source" json:"source"`
+	SourceRef     string          `db:"source_ref" json:"source_ref"`
+	DocumentType  string          `db:"document_type" json:"document_type"`
+	ParseMode     ParseMode       `db:"parse_mode" json:"parse_mode"`
+	Status        ImportStatus    `db:"status" json:"status"`
+	TotalEntries  int             `db:"total_entries" json:"total_entries"`
+	ImportedCount int             `db:"imported_count" json:"imported_count"`
+	SkippedCount  int             `db:"skipped_count" json:"skipped_count"`
+	FailedCount   int             `db:"failed_count" json:"failed_count"`
+	Entries       json.RawMessage `db:"entries" json:"entries" swaggertype:"array,object"`
+	Error         *string         `db:"error" json:"error,omitempty"`
+	CreatedAt     time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at" json:"updated_at"`
+	CompletedAt   *time.Time      `db:"completed_at" json:"completed_at,omitempty"`
+}
+
+// ValidationRun re-runs validation across every parsed document in a collection,
+// e.g. after its rules changed. Changes lists the documents whose validation or
+// reconciliation status changed, a JSON array of ValidationRunChange.
+type ValidationRun struct {
+	ID             uuid.UUID           `db:"id" json:"id"`
+	TenantID       uuid.UUID           `db:"tenant_id" json:"tenant_id"`
+	CollectionID   uuid.UUID           `db:"collection_id" json:"collection_id"`
+	CreatedBy      uuid.UUID           `db:"created_by" json:"created_by"`
+	Status         ValidationRunStatus `db:"status" json:"status"`
+	TotalDocuments int                 `db:"total_documents" json:"total_documents"`
+	ProcessedCount int                 `db:"processed_count" json:"processed_count"`
+	ChangedCount   int                 `db:"changed_count" json:"changed_count"`
+	FailedCount    int                 `db:"failed_count" json:"failed_count"`
+	Changes        json.RawMessage     `db:"changes" json:"changes" swaggertype:"array,object"`
+	Error          *string             `db:"error" json:"error,omitempty"`
+	CreatedAt      time.Time           `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time           `db:"updated_at" json:"updated_at"`
+	CompletedAt    *time.Time          `db:"completed_at" json:"completed_at,omitempty"`
+}
+
+// ValidationRunChange is a document whose statuses a ValidationRun changed.
+type ValidationRunChange struct {
+	DocumentID                   uuid.UUID            `json:"document_id"`
+	DocumentName                 string               `json:"document_name"`
+	PreviousValidationStatus     ValidationStatus     `json:"previous_validation_status"`
+	ValidationStatus             ValidationStatus     `json:"validation_status"`
+	PreviousReconciliationStatus ReconciliationStatus `json:"previous_reconciliation_status"`
+	ReconciliationStatus         ReconciliationStatus `json:"reconciliation_status"`
+}
+
+// ValidationRunTarget is a parsed document a ValidationRun re-validates, with its
+// statuses before the run.
+type ValidationRunTarget struct {
+	DocumentID           uuid.UUID            `db:"id"`
+	Name                 string               `db:"name"`
+	ValidationStatus     ValidationStatus     `db:"validation_status"`
+	ReconciliationStatus ReconciliationStatus `db:"reconciliation_status"`
+}
+
+// BulkTagFilter selects the documents a bulk tag job or a bulk delete applies
+// to. Empty fields don't filter; From and To bound the invoice date (YYYY-MM-DD, inclusive).
+type BulkTagFilter struct {
+	CollectionID *uuid.UUID   `json:"collection_id,omitempty"`
+	SellerGSTIN  string       `json:"seller_gstin,omitempty"`
+	BuyerGSTIN   string       `json:"buyer_gstin,omitempty"`
+	From         string       `json:"from,omitempty"`
+	To           string       `json:"to,omitempty"`
+	ReviewStatus ReviewStatus `json:"review_status,omitempty"`
+	TagKey       string       `json:"tag_key,omitempty"`
+	TagValue     string       `json:"tag_value,omitempty"`
+}
+
+// IsEmpty reports whether the filter would match every document in the tenant.
+func (f *BulkTagFilter) IsEmpty() bool {
+	return *f == BulkTagFilter{}
+}
+
+// BulkTagJob adds or removes one tag across every document matching a filter.
+// Skipped counts documents the creator can't edit or that needed no change.
+type BulkTagJob struct {
+	ID           uuid.UUID       `db:"id" json:"id"`
+	TenantID     uuid.UUID       `db:"tenant_id" json:"tenant_id"`
+	CreatedBy    uuid.UUID       `db:"created_by" json:"created_by"`
+	Action       BulkTagAction   `db:"action" json:"action"`
+	TagKey       string          `db:"tag_key" json:"tag_key"`
+	TagValue     string          `db:"tag_value" json:"tag_value"`
+	Filter       json.RawMessage `db:"filter" json:"filter" swaggertype:"object"`
+	Status       BulkTagStatus   `db:"status" json:"status"`
+	MatchedCount int             `db:"matched_count" json:"matched_count"`
+	UpdatedCount int             `db:"updated_count" json:"updated_count"`
+	SkippedCount int             `db:"skipped_count" json:"skipped_count"`
+	FailedCount  int             `db:"failed_count" json:"failed_count"`
+	Error        *string         `db:"error" json:"error,omitempty"`
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time       `db:"updated_at" json:"updated_at"`
+	CompletedAt  *time.Time      `db:"completed_at" json:"completed_at,omitempty"`
+}
+
+// BulkTagTarget is a document matched by a bulk tag job.
+type BulkTagTarget struct {
+	DocumentID   uuid.UUID `db:"document_id"`
+	CollectionID uuid.UUID `db:"collection_id"`
+}
+
+// BulkDeleteTarget is a document matched by a bulk delete.
+type BulkDeleteTarget struct {
+	DocumentID   uuid.UUID `db:"document_id" json:"document_id"`
+	CollectionID uuid.UUID `db:"collection_id" json:"collection_id"`
+	FileID       uuid.UUID `db:"file_id" json:"file_id"`
+	Name         string    `db:"name" json:"name"`
+}
+
+// BulkDeletePreview is the result of a bulk delete dry run. Passing
+// ConfirmationToken back deletes exactly Documents; the token stops matching
+// once the filter selects anything else. Skipped counts matches in collections
+// the caller doesn't own, which a real run leaves alone.
+type BulkDeletePreview struct {
+	Documents         []BulkDeleteTarget `json:"documents"`
+	MatchedCount      int                `json:"matched_count"`
+	SkippedCount      int                `json:"skipped_count"`
+	DeleteFiles       bool               `json:"delete_files"`
+	ConfirmationToken string             `json:"confirmation_token"`
+}
+
+// BulkDelete is the audit record of one confirmed bulk delete: the filter it
+// ran with and what it removed.
+type BulkDelete struct {
+	ID           uuid.UUID       `db:"id" json:"id"`
+	TenantID     uuid.UUID       `db:"tenant_id" json:"tenant_id"`
+	CreatedBy    uuid.UUID       `db:"created_by" json:"created_by"`
+	Filter       json.RawMessage `db:"filter" json:"filter" swaggertype:"object"`
+	DeleteFiles  bool            `db:"delete_files" json:"delete_files"`
+	MatchedCount int             `db:"matched_count" json:"matched_count"`
+	DeletedCount int             `db:"deleted_count" json:"deleted_count"`
+	FailedCount  int             `db:"failed_count" json:"failed_count"`
+	CreatedAt    time.Time       `db:"created_at" json:"created_at"`
+	CompletedAt  *time.Time      `db:"completed_at" json:"completed_at,omitempty"`
+}
+
+// DocumentNeighbors holds the documents before and after one document in a list,
+// in that list's sort order. Either side is nil at the ends of the list.
+type DocumentNeighbors struct {
+	PreviousID *uuid.UUID `json:"previous_id"`
+	NextID     *uuid.UUID `json:"next_id"`
+}
+
+// ImportEntry is the outcome for one file in an ImportJob.
+type ImportEntry struct {
+	Name       string            `json:"name"`
+	Status     ImportEntryStatus `json:"status"`
+	Reason     string            `json:"reason,omitempty"`
+	FileID     *uuid.UUID        `json:"file_id,omitempty"`
+	DocumentID *uuid.UUID        `json:"document_id,omitempty"`
+}
+
+// CloudConnection is a user's OAuth grant to a cloud storage provider. Tokens are
+// stored encrypted and never serialized.
+type CloudConnection struct {
+	ID           uuid.UUID     `db:"id" json:"id"`
+	TenantID     uuid.UUID     `db:"tenant_id" json:"tenant_id"`
+	UserID       uuid.UUID     `db:"user_id" json:"user_id"`
+	Provider     CloudProvider `db:"provider" json:"provider"`
+	AccountID    string        `db:"account_id" json:"account_id"`
+	AccessToken  string        `db:"access_token" json:"-"`
+	RefreshToken string        `db:"refresh_token" json:"-"`
+	TokenExpiry  time.Time     `db:"token_expiry" json:"-"`
+	CreatedAt    time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time     `db:"updated_at" json:"updated_at"`
+}
+
+// CloudSync links a folder in a connected cloud account to a collection. PDFs in
+// the folder are imported once on creation and, when polling is enabled, again
+// whenever new files appear.
+type CloudSync struct {
+	ID           uuid.UUID     `db:"id" json:"id"`
+	TenantID     uuid.UUID     `db:"tenant_id" json:"tenant_id"`
+	CollectionID uuid.UUID     `db:"collection_id" json:"collection_id"`
+	ConnectionID uuid.UUID     `db:"connection_id" json:"connection_id"`
+	Provider     CloudProvider `db:"provider" json:"provider"`
+	FolderID     string        `db:"folder_id" json:"folder_id"`
+	FolderName   string        `db:"folder_name" json:"folder_name"`
+	DocumentType string        `db:"document_type" json:"document_type"`
+	ParseMode    ParseMode     `db:"parse_mode" json:"parse_mode"`
+	PollEnabled  bool          `db:"poll_enabled" json:"poll_enabled"`
+	LastSyncedAt *time.Time    `db:"last_synced_at" json:"last_synced_at,omitempty"`
+	LastError    *string       `db:"last_error" json:"last_error,omitempty"`
+	CreatedBy    uuid.UUID     `db:"created_by" json:"created_by"`
+	CreatedAt    time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time     `db:"updated_at" json:"updated_at"`
+}
+
+// CloudSyncFile records the import outcome for one file seen by a CloudSync.
+// A file is considered once per sync; ContentHash dedupes across syncs.
+type CloudSyncFile struct {
+	ID           uuid.UUID           `db:"id" json:"id"`
+	TenantID     uuid.UUID           `db:"tenant_id" json:"tenant_id"`
+	SyncID       uuid.UUID           `db:"sync_id" json:"sync_id"`
+	CollectionID uuid.UUID           `db:"collection_id" json:"collection_id"`
+	ExternalID   string              `db:"external_id" json:"external_id"`
+	Name         string              `db:"name" json:"name"`
+	ContentHash  string              `db:"content_hash" json:"content_hash"`
+	Status       CloudSyncFileStatus `db:"status" json:"status"`
+	Reason       string              `db:"reason" json:"reason,omitempty"`
+	FileID       *uuid.UUID          `db:"file_id" json:"file_id,omitempty"`
+	DocumentID   *uuid.UUID          `db:"document_id" json:"document_id,omitempty"`
+	CreatedAt    time.Time           `db:"created_at" json:"created_at"`
+}
+
+// FeedIngestion records one file picked up from a tenant's batch feed drop folder
+// (tenants/{tenant_id}/drop/{collection}/...). Folder is the collection folder the
+// file was dropped into; CollectionID is nil when it matched no collection.
+type FeedIngestion struct {
+	ID           uuid.UUID           `db:"id" json:"id"`
+	TenantID     uuid.UUID           `db:"tenant_id" json:"tenant_id"`
+	ObjectKey    string              `db:"object_key" json:"object_key"`
+	Folder       string              `db:"folder" json:"folder"`
+	FileName     string              `db:"file_name" json:"file_name"`
+	CollectionID *uuid.UUID          `db:"collection_id" json:"collection_id,omitempty"`
+	Status       FeedIngestionStatus `db:"status" json:"status"`
+	Reason       string              `db:"reason" json:"reason,omitempty"`
+	FileID       *uuid.UUID          `db:"file_id" json:"file_id,omitempty"`
+	DocumentID   *uuid.UUID          `db:"document_id" json:"document_id,omitempty"`
+	CreatedAt    time.Time           `db:"created_at" json:"created_at"`
+	CompletedAt  *time.Time          `db:"completed_at" json:"completed_at,omitempty"`
+}
+
 // FileMeta stores metadata about an uploaded file.
 type FileMeta struct {
 	ID           uuid.UUID  `db:"id" json:"id"`
@@ -357,3 +1384,402 @@ type FileMeta struct {
 	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
 }
+
+// FilePreflight is a quick quality check of a stored file, so the UI can warn
+// about unreadable scans before the user spends a parse on them.
+type FilePreflight struct {
+	FileID   uuid.UUID `json:"file_id"`
+	FileType FileType  `json:"file_type"`
+	FileSize int64     `json:"file_size"`
+	// SignatureValid is false when the content doesn't match the file type
+	SignatureValid bool `json:"signature_valid"`
+	PageCount      int  `json:"page_count"` // 0 when unknown (pages in compressed object streams)
+	Encrypted      bool `json:"encrypted"`
+	HasTextLayer   bool `json:"has_text_layer"`
+	// EstimatedDPI is the scan resolution, 0 when the file has no scanned image
+	// or it can't be measured
+	EstimatedDPI int `json:"estimated_dpi"`
+	// Sharpness is the variance of the Laplacian of the scanned image; lower is
+	// blurrier. Nil when no image could be decoded.
+	Sharpness  *float64 `json:"sharpness"`
+	Language   string   `json:"language"`   // ISO 639-1 code from the text layer, or "unknown"
+	Difficulty string   `json:"difficulty"` // easy, moderate, hard or unparseable
+	ParseReady bool     `json:"parse_ready"`
+	Warnings   []string `json:"warnings"`
+}
+
+// FileRelocation is a stored file whose object key predates the collection
+// layout, paired with the collection it should be filed under.
+type FileRelocation struct {
+	FileID       uuid.UUID `db:"file_id"`
+	CollectionID uuid.UUID `db:"collection_id"`
+	S3Bucket     string    `db:"s3_bucket"`
+	S3Key        string    `db:"s3_key"`
+}
+
+// StorageRelocation reports one run of the object relocation job for a tenant.
+type StorageRelocation struct {
+	TenantID  uuid.UUID `json:"tenant_id"`
+	Relocated int       `json:"relocated"`
+	Failed    int       `json:"failed"`
+	// Orphans are old object keys left behind because deleting them failed.
+	Orphans []string `json:"orphans"`
+}
+
+// DemoData reports the sample data seeded into a tenant.
+type DemoData struct {
+	TenantID     uuid.UUID `json:"tenant_id"`
+	CollectionID uuid.UUID `json:"collection_id"`
+	Documents    int       `json:"documents"`
+}
+
+// DemoCleanup reports what removing a tenant's demo data deleted.
+type DemoCleanup struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	Collections int       `json:"collections"`
+	Documents   int       `json:"documents"`
+	Files       int       `json:"files"`
+}
+
+// AnalyticsExport is a tenant's scheduled Parquet export of its fact tables to S3.
+type AnalyticsExport struct {
+	TenantID      uuid.UUID `db:"tenant_id" json:"tenant_id"`
+	S3Bucket      string    `db:"s3_bucket" json:"s3_bucket"`
+	S3Prefix      string    `db:"s3_prefix" json:"s3_prefix"`
+	IntervalHours int       `db:"interval_hours" json:"interval_hours"`
+	Enabled       bool      `db:"enabled" json:"enabled"`
+	// ExportedThrough is the watermark: documents updated at or before it have
+	// been written. Nil until the first successful run, which exports everything.
+	ExportedThrough  *time.Time `db:"exported_through" json:"exported_through"`
+	NextRunAt        time.Time  `db:"next_run_at" json:"next_run_at"`
+	LastRunAt        *time.Time `db:"last_run_at" json:"last_run_at"`
+	LastRunDocuments int        `db:"last_run_documents" json:"last_run_documents"`
+	LastError        string     `db:"last_error" json:"last_error"`
+	CreatedAt        time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// ValidationFact is one stored validation result of a document, joined with its rule.
+type ValidationFact struct {
+	DocumentID             uuid.UUID  `db:"document_id"`
+	TenantID               uuid.UUID  `db:"tenant_id"`
+	RuleID                 uuid.UUID  `db:"rule_id"`
+	RuleName               string     `db:"rule_name"`
+	BuiltinRuleKey         *string    `db:"builtin_rule_key"`
+	Severity               string     `db:"severity"`
+	FieldPath              string     `db:"field_path"`
+	Passed                 bool       `db:"passed"`
+	ExpectedValue          string     `db:"expected_value"`
+	ActualValue            string     `db:"actual_value"`
+	Message                string     `db:"message"`
+	ReconciliationCritical bool       `db:"reconciliation_critical"`
+	ValidatedAt            *time.Time `db:"validated_at"`
+}
+
+// IntegrationEvent names an event that REST hook subscribers can receive.
+type IntegrationEvent string
+
+// IntegrationEventDocumentApproved fires when a document reaches final approval.
+const IntegrationEventDocumentApproved IntegrationEvent = "document.approved"
+
+// IntegrationHook is a REST hook subscription (Zapier, Make) owned by a user.
+// Deliveries only include documents the user can still view. PayloadTemplate,
+// when set, renders the delivered body instead of the flat document.
+type IntegrationHook struct {
+	ID              uuid.UUID        `db:"id" json:"id"`
+	TenantID        uuid.UUID        `db:"tenant_id" json:"tenant_id"`
+	UserID          uuid.UUID        `db:"user_id" json:"user_id"`
+	Event           IntegrationEvent `db:"event" json:"event"`
+	TargetURL       string           `db:"target_url" json:"target_url"`
+	CollectionID    *uuid.UUID       `db:"collection_id" json:"collection_id"`
+	PayloadTemplate *string          `db:"payload_template" json:"payload_template"`
+	CreatedAt       time.Time        `db:"created_at" json:"created_at"`
+}
+
+// NotificationChannel posts a collection's events to a Slack or Microsoft Teams
+// incoming webhook. The webhook URL is a secret: it is stored sealed and only
+// its host is returned by the API.
+type NotificationChannel struct {
+	ID           uuid.UUID            `db:"id" json:"id"`
+	TenantID     uuid.UUID            `db:"tenant_id" json:"tenant_id"`
+	CollectionID uuid.UUID            `db:"collection_id" json:"collection_id"`
+	Provider     NotificationProvider `db:"provider" json:"provider"`
+	WebhookURL   string               `db:"webhook_url" json:"-"`
+	WebhookHost  string               `db:"webhook_host" json:"webhook_host"`
+	Events       pq.StringArray       `db:"events" json:"events" swaggertype:"array,string"`
+	// Templates maps an event name to a text/template that replaces its default message.
+	Templates      json.RawMessage `db:"templates" json:"templates" swaggertype:"object"`
+	ReviewSLAHours int             `db:"review_sla_hours" json:"review_sla_hours"`
+	Enabled        bool            `db:"enabled" json:"enabled"`
+	// SLACheckedThrough: documents parsed at or before this time have been checked against the review SLA.
+	SLACheckedThrough time.Time  `db:"sla_checked_through" json:"-"`
+	NextSummaryAt     time.Time  `db:"next_summary_at" json:"next_summary_at"`
+	LastDeliveredAt   *time.Time `db:"last_delivered_at" json:"last_delivered_at,omitempty"`
+	LastError         string     `db:"last_error" json:"last_error,omitempty"`
+	CreatedBy         uuid.UUID  `db:"created_by" json:"created_by"`
+	CreatedAt         time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt         time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// NotificationRoute overrides, for one tenant, the channels a notification kind
+// is delivered on.
+type NotificationRoute struct {
+	TenantID  uuid.UUID        `db:"tenant_id" json:"-"`
+	Kind      NotificationKind `db:"kind" json:"kind"`
+	Channels  pq.StringArray   `db:"channels" json:"channels" swaggertype:"array,string"`
+	UpdatedAt time.Time        `db:"updated_at" json:"updated_at"`
+}
+
+// InAppNotification is a notification delivered to a user's in-app inbox.
+type InAppNotification struct {
+	ID        uuid.UUID        `db:"id" json:"id"`
+	TenantID  uuid.UUID        `db:"tenant_id" json:"tenant_id"`
+	UserID    uuid.UUID        `db:"user_id" json:"user_id"`
+	Kind      NotificationKind `db:"kind" json:"kind"`
+	Subject   string           `db:"subject" json:"subject"`
+	Body      string           `db:"body" json:"body"`
+	ReadAt    *time.Time       `db:"read_at" json:"read_at,omitempty"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+}
+
+// SecurityEvent is one entry in a user's account security audit trail. ActorID
+// is who acted (nil for unauthenticated requests such as a password reset).
+type SecurityEvent struct {
+	ID        uuid.UUID         `db:"id" json:"id"`
+	TenantID  uuid.UUID         `db:"tenant_id" json:"tenant_id"`
+	UserID    uuid.UUID         `db:"user_id" json:"user_id"`
+	Event     SecurityEventType `db:"event" json:"event"`
+	ActorID   *uuid.UUID        `db:"actor_id" json:"actor_id,omitempty"`
+	IPAddress string            `db:"ip_address" json:"ip_address"`
+	UserAgent string            `db:"user_agent" json:"user_agent"`
+	Detail    string            `db:"detail" json:"detail"`
+	CreatedAt time.Time         `db:"created_at" json:"created_at"`
+}
+
+// EmailChange records a change of a user's email address, which the old
+// address can undo until UndoExpiresAt.
+type EmailChange struct {
+	ID            uuid.UUID  `db:"id" json:"id"`
+	TenantID      uuid.UUID  `db:"tenant_id" json:"tenant_id"`
+	UserID        uuid.UUID  `db:"user_id" json:"user_id"`
+	OldEmail      string     `db:"old_email" json:"old_email"`
+	NewEmail      string     `db:"new_email" json:"new_email"`
+	ChangedBy     uuid.UUID  `db:"changed_by" json:"changed_by"`
+	UndoExpiresAt time.Time  `db:"undo_expires_at" json:"undo_expires_at"`
+	UndoneAt      *time.Time `db:"undone_at" json:"undone_at,omitempty"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+}
+
+// HasEvent reports whether the channel is subscribed to event.
+func (c *NotificationChannel) HasEvent(event NotificationEvent) bool {
+	for _, e := range c.Events {
+		if e == string(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// CollectionActivity counts a collection's document activity over a period.
+type CollectionActivity struct {
+	Uploaded      int `db:"uploaded" json:"uploaded"`
+	Approved      int `db:"approved" json:"approved"`
+	Rejected      int `db:"rejected" json:"rejected"`
+	ParseFailed   int `db:"parse_failed" json:"parse_failed"`
+	PendingReview int `db:"pending_review" json:"pending_review"`
+}
+
+// RejectionReason is one entry of a tenant's rejection-reason taxonomy: a built-in
+// default (Overridden is false), a tenant's relabeling of one, or a tenant-specific
+// reason. Retired reasons stay listed with Active false.
+type RejectionReason struct {
+	TenantID    uuid.UUID  `db:"tenant_id" json:"-"`
+	Code        string     `db:"code" json:"code"`
+	Label       string     `db:"label" json:"label"`
+	Description string     `db:"description" json:"description,omitempty"`
+	Active      bool       `db:"active" json:"active"`
+	SortOrder   int        `db:"sort_order" json:"sort_order"`
+	Overridden  bool       `db:"-" json:"overridden"`
+	UpdatedBy   *uuid.UUID `db:"updated_by" json:"updated_by,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"-"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at,omitempty"`
+}
+
+// ComputedField is a tenant-defined field computed from the structured data of
+// documents of one type by an expression (see package expr).
+type ComputedField struct {
+	TenantID     uuid.UUID  `db:"tenant_id" json:"-"`
+	Name         string     `db:"name" json:"name"`
+	DocumentType string     `db:"document_type" json:"document_type"`
+	Expression   string     `db:"expression" json:"expression"`
+	Description  string     `db:"description" json:"description,omitempty"`
+	UpdatedBy    *uuid.UUID `db:"updated_by" json:"updated_by,omitempty"`
+	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// DefaultRejectionReasons is the taxonomy of a tenant that has not customized it.
+var DefaultRejectionReasons = []RejectionReason{
+	{Code: "wrong_amount", Label: "Wrong amount", Description: "Totals, tax or line amounts do not match the invoice", SortOrder: 10},
+	{Code: "illegible", Label: "Illegible", Description: "The scan is too poor to read reliably", SortOrder: 20},
+	{Code: "not_our_invoice", Label: "Not our invoice", Description: "Addressed to another buyer or GSTIN", SortOrder: 30},
+	{Code: "duplicate", Label: "Duplicate", Description: "The invoice was already submitted", SortOrder: 40},
+	{Code: "wrong_party_details", Label: "Wrong party details", Description: "Seller or buyer name, GSTIN or address extracted incorrectly", SortOrder: 50},
+	{Code: "missing_fields", Label: "Missing fields", Description: "Required fields such as invoice number or date were not extracted", SortOrder: 60},
+	{Code: "other", Label: "Other", SortOrder: 1000},
+}
+
+// RejectionReasonStat counts current rejections with one reason code for documents
+// parsed by one model. An empty ReasonCode counts rejections recorded before reason
+// codes were required.
+type RejectionReasonStat struct {
+	ReasonCode    string  `db:"reason_code" json:"reason_code"`
+	Label         string  `db:"-" json:"label"`
+	ParserModel   string  `db:"parser_model" json:"parser_model"`
+	RejectedCount int     `db:"rejected_count" json:"rejected_count"`
+	SharePct      float64 `db:"share_pct" json:"share_pct"`
+}
+
+// RelatedPartyRelationship is how a registered GSTIN relates to the tenant.
+type RelatedPartyRelationship string
+
+const (
+	// RelatedPartyOwn is another GST registration of the tenant itself, such as a
+	// branch in another state; supplies between them are between distinct persons.
+	RelatedPartyOwn RelatedPartyRelationship = "own"
+	// RelatedPartyGroup is a group company.
+	RelatedPartyGroup RelatedPartyRelationship = "group"
+)
+
+// RelatedParty is a GSTIN the tenant registered as its own or a group company's.
+// Invoices from it are flagged, since related-party supplies are treated
+// separately in GST returns and audits.
+type RelatedParty struct {
+	TenantID     uuid.UUID                `db:"tenant_id" json:"-"`
+	GSTIN        string                   `db:"gstin" json:"gstin"`
+	Name         string                   `db:"name" json:"name"`
+	Relationship RelatedPartyRelationship `db:"relationship" json:"relationship"`
+	UpdatedBy    *uuid.UUID               `db:"updated_by" json:"updated_by,omitempty"`
+	CreatedAt    time.Time                `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time                `db:"updated_at" json:"updated_at"`
+}
+
+// TenantMembership gives a user access to a tenant other than their home tenant,
+// with a role there. The home tenant (users.tenant_id) is implicit and is only
+// listed, with IsHome set, when a user's tenants are listed.
+type TenantMembership struct {
+	TenantID  uuid.UUID  `db:"tenant_id" json:"tenant_id"`
+	UserID    uuid.UUID  `db:"user_id" json:"user_id"`
+	Role      UserRole   `db:"role" json:"role"`
+	IsActive  bool       `db:"is_active" json:"is_active"`
+	IsHome    bool       `db:"is_home" json:"is_home"`
+	CreatedBy *uuid.UUID `db:"created_by" json:"created_by,omitempty"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at" json:"updated_at"`
+
+	// Filled in by list queries; not stored.
+	TenantName string `db:"tenant_name" json:"tenant_name,omitempty"`
+	TenantSlug string `db:"tenant_slug" json:"tenant_slug,omitempty"`
+	Email      string `db:"email" json:"email,omitempty"`
+	FullName   string `db:"full_name" json:"full_name,omitempty"`
+}
+
+// ClientStats is one client tenant's row of a firm's consolidated dashboard.
+type ClientStats struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	Name     string    `json:"name"`
+	Slug     string    `json:"slug"`
+	IsActive bool      `json:"is_active"`
+	Stats    Stats     `json:"stats"`
+}
+
+// ClientDashboard aggregates a firm tenant's clients. Totals sum the clients'
+// stats; the firm's own documents are not included.
+type ClientDashboard struct {
+	Totals  Stats         `json:"totals"`
+	Clients []ClientStats `json:"clients"`
+}
+
+// TenantMove copies a tenant's rows to another database cluster, and its files
+// to another storage region when TargetRegion is set, then points the tenant's
+// routing flag (Tenant.DBCluster) at the target. Tables is a JSON array of
+// TenantMoveTable.
+type TenantMove struct {
+	ID            uuid.UUID        `db:"id" json:"id"`
+	TenantID      uuid.UUID        `db:"tenant_id" json:"tenant_id"`
+	SourceCluster string           `db:"source_cluster" json:"source_cluster"`
+	TargetCluster string           `db:"target_cluster" json:"target_cluster"`
+	TargetRegion  *string          `db:"target_region" json:"target_region,omitempty"`
+	Status        TenantMoveStatus `db:"status" json:"status"`
+	Tables        json.RawMessage  `db:"tables" json:"tables" swaggertype:"array,object"`
+	ObjectsTotal  int              `db:"objects_total" json:"objects_total"`
+	ObjectsCopied int              `db:"objects_copied" json:"objects_copied"`
+	Error         *string          `db:"error" json:"error,omitempty"`
+	RequestedBy   *uuid.UUID       `db:"requested_by" json:"requested_by,omitempty"`
+	CreatedAt     time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time        `db:"updated_at" json:"updated_at"`
+	CompletedAt   *time.Time       `db:"completed_at" json:"completed_at,omitempty"`
+}
+
+// TenantMoveTable is the row count and checksum of one table's tenant rows on
+// the source, and what the target held after the import.
+type TenantMoveTable struct {
+	Table          string `json:"table"`
+	Rows           int64  `json:"rows"`
+	Checksum       string `json:"checksum"`
+	TargetRows     int64  `json:"target_rows"`
+	TargetChecksum string `json:"target_checksum,omitempty"`
+}
+
+// EmailSuppression is an address SES reported as undeliverable. Email is stored
+// lowercased; FeedbackID is SES's ID of the last bounce or complaint.
+type EmailSuppression struct {
+	Email      string                 `db:"email" json:"email"`
+	Reason     EmailSuppressionReason `db:"reason" json:"reason"`
+	Detail     string                 `db:"detail" json:"detail"`
+	FeedbackID string                 `db:"feedback_id" json:"feedback_id"`
+	CreatedAt  time.Time              `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time              `db:"updated_at" json:"updated_at"`
+}
+
+// UserImportRow is the outcome of one data row of a user import CSV. Row is the
+// CSV line number (the header is line 1). Invited is false for a created user
+// whose invitation email failed; they can still use forgot-password.
+type UserImportRow struct {
+	Row     int                 `json:"row"`
+	Email   string              `json:"email"`
+	Status  UserImportRowStatus `json:"status"`
+	UserID  *uuid.UUID          `json:"user_id,omitempty"`
+	Invited bool                `json:"invited"`
+	Error   string              `json:"error,omitempty"`
+}
+
+// UserImportReport is the per-row result of a bulk user import.
+type UserImportReport struct {
+	Total   int             `json:"total"`
+	Created int             `json:"created"`
+	Failed  int             `json:"failed"`
+	Rows    []UserImportRow `json:"rows"`
+}
+
+// OrphanFileSummary counts a collection's orphan files: files uploaded to it
+// that never became a document, uploaded before the report's cutoff.
+type OrphanFileSummary struct {
+	CollectionID     uuid.UUID `db:"collection_id" json:"collection_id"`
+	CollectionName   string    `db:"collection_name" json:"collection_name"`
+	CreatedBy        uuid.UUID `db:"created_by" json:"created_by"`
+	FileCount        int       `db:"file_count" json:"file_count"`
+	OldestUploadedAt time.Time `db:"oldest_uploaded_at" json:"oldest_uploaded_at"`
+}
+
+// OrphanDocumentRun is the result of creating documents for a collection's
+// orphan files. Entries has one ImportEntry per matched file, named by its
+// original file name. Remaining counts the orphans past the run's cap, left
+// for another run.
+type OrphanDocumentRun struct {
+	Matched   int           `json:"matched"`
+	Created   int           `json:"created"`
+	Failed    int           `json:"failed"`
+	Remaining int           `json:"remaining"`
+	Entries   []ImportEntry `json:"entries"`
+}
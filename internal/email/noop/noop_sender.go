@@ -29,3 +29,27 @@ func (s *noopSender) SendPasswordResetEmail(_ context.Context, toEmail, toName,
 	log.Printf("[NOOP EMAIL] Password reset for %s (%s): %s", toName, toEmail, resetURL)
 	return nil
 }
+
+func (s *noopSender) SendIngestionReport(_ context.Context, toEmail, toName string, report *port.IngestionReport) error {
+	log.Printf("[NOOP EMAIL] Ingestion report for %s (%s): %s %s — %d imported, %d skipped, %d failed",
+		toName, toEmail, report.TenantName, report.Date.Format("2006-01-02"),
+		report.Imported, report.Skipped, report.Failed)
+	return nil
+}
+
+func (s *noopSender) SendEmailChangeNotice(_ context.Context, toEmail, toName, newEmail, undoToken string) error {
+	undoURL := fmt.Sprintf("%s/undo-email-change?token=%s", s.frontendURL, url.QueryEscape(undoToken))
+	log.Printf("[NOOP EMAIL] Email change notice for %s (%s), now %s: %s", toName, toEmail, newEmail, undoURL)
+	return nil
+}
+
+func (s *noopSender) SendInvitationEmail(_ context.Context, toEmail, toName, tenantName, inviteToken string) error {
+	inviteURL := fmt.Sprintf("%s/accept-invitation?token=%s", s.frontendURL, url.QueryEscape(inviteToken))
+	log.Printf("[NOOP EMAIL] Invitation to %s for %s (%s): %s", tenantName, toName, toEmail, inviteURL)
+	return nil
+}
+
+func (s *noopSender) SendAlertEmail(_ context.Context, toEmail, subject, body string) error {
+	log.Printf("[NOOP EMAIL] Alert for %s: %s — %s", toEmail, subject, body)
+	return nil
+}
@@ -3,7 +3,10 @@ package ses
 import (
 	"context"
 	"fmt"
+	"html"
+	"net/http"
 	"net/url"
+	"strings"
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
@@ -23,9 +26,13 @@ type sesSender struct {
 // NewSESSender creates a new SES-backed EmailSender.
 // If accessKey and secretKey are provided, they are used as static credentials.
 // Otherwise, the default AWS credential chain is used (env vars, instance profile, etc.).
-func NewSESSender(region, fromAddress, fromName, frontendURL, accessKey, secretKey string) (port.EmailSender, error) {
+// A nil httpClient uses the SDK's default client.
+func NewSESSender(region, fromAddress, fromName, frontendURL, accessKey, secretKey string, httpClient *http.Client) (port.EmailSender, error) {
 	var opts []func(*awsconfig.LoadOptions) error
 	opts = append(opts, awsconfig.WithRegion(region))
+	if httpClient != nil {
+		opts = append(opts, awsconfig.WithHTTPClient(httpClient))
+	}
 
 	if accessKey != "" && secretKey != "" {
 		opts = append(opts, awsconfig.WithCredentialsProvider(
@@ -81,7 +88,96 @@ func (s *sesSender) SendPasswordResetEmail(ctx context.Context, toEmail, toName,
 
 	subject := "Reset your SATVOS password"
 	htmlBody := buildPasswordResetHTML(toName, resetURL)
-	textBody := fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. Visit the link below to set a new password:\n%s\n\nThis link expires in 1 hour. If you didn't request this, you can safely ignore this email.\n\nSATVOS Team", toName, resetURL)
+	textBody := fmt.Sprintf("Hi %s,\n\nWe received a request to reset your password. Visit the link below to set a new password:\n%s\n\nThis link works once, from the browser you requested it in, and expires shortly. If you didn't request this, you can safely ignore this email.\n\nSATVOS Team", toName, resetURL)
+
+	from := fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress)
+
+	_, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: &from,
+		Destination: &types.Destination{
+			ToAddresses: []string{toEmail},
+		},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: &subject},
+				Body: &types.Body{
+					Html: &types.Content{Data: &htmlBody},
+					Text: &types.Content{Data: &textBody},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("SES SendEmail: %w", err)
+	}
+	return nil
+}
+
+func (s *sesSender) SendIngestionReport(ctx context.Context, toEmail, toName string, report *port.IngestionReport) error {
+	subject := fmt.Sprintf("SATVOS batch feed report for %s: %d imported, %d failed",
+		report.Date.Format("2006-01-02"), report.Imported, report.Failed)
+	htmlBody := buildIngestionReportHTML(toName, report)
+	textBody := buildIngestionReportText(toName, report)
+
+	from := fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress)
+
+	_, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: &from,
+		Destination: &types.Destination{
+			ToAddresses: []string{toEmail},
+		},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: &subject},
+				Body: &types.Body{
+					Html: &types.Content{Data: &htmlBody},
+					Text: &types.Content{Data: &textBody},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("SES SendEmail: %w", err)
+	}
+	return nil
+}
+
+func (s *sesSender) SendEmailChangeNotice(ctx context.Context, toEmail, toName, newEmail, undoToken string) error {
+	undoURL := fmt.Sprintf("%s/undo-email-change?token=%s", s.frontendURL, url.QueryEscape(undoToken))
+
+	subject := "Your SATVOS email address was changed"
+	htmlBody := buildEmailChangeHTML(toName, newEmail, undoURL)
+	textBody := fmt.Sprintf("Hi %s,\n\nThe email address of your SATVOS account was changed to %s. If you made this change, no action is needed.\n\nIf you didn't, visit the link below to change it back to this address:\n%s\n\nSATVOS Team", toName, newEmail, undoURL)
+
+	from := fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress)
+
+	_, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: &from,
+		Destination: &types.Destination{
+			ToAddresses: []string{toEmail},
+		},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: &subject},
+				Body: &types.Body{
+					Html: &types.Content{Data: &htmlBody},
+					Text: &types.Content{Data: &textBody},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("SES SendEmail: %w", err)
+	}
+	return nil
+}
+
+func (s *sesSender) SendInvitationEmail(ctx context.Context, toEmail, toName, tenantName, inviteToken string) error {
+	inviteURL := fmt.Sprintf("%s/accept-invitation?token=%s", s.frontendURL, url.QueryEscape(inviteToken))
+
+	subject := fmt.Sprintf("You're invited to %s on SATVOS", tenantName)
+	htmlBody := buildInvitationHTML(toName, tenantName, inviteURL)
+	textBody := fmt.Sprintf("Hi %s,\n\nAn account was created for you in %s on SATVOS. Visit the link below to set your password and sign in:\n%s\n\nThis link works once and expires in a few days. If it has expired, use Forgot password on the sign-in page.\n\nSATVOS Team", toName, tenantName, inviteURL)
 
 	from := fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress)
 
@@ -106,6 +202,29 @@ func (s *sesSender) SendPasswordResetEmail(ctx context.Context, toEmail, toName,
 	return nil
 }
 
+func (s *sesSender) SendAlertEmail(ctx context.Context, toEmail, subject, body string) error {
+	from := fmt.Sprintf("%s <%s>", s.fromName, s.fromAddress)
+
+	_, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: &from,
+		Destination: &types.Destination{
+			ToAddresses: []string{toEmail},
+		},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject: &types.Content{Data: &subject},
+				Body: &types.Body{
+					Text: &types.Content{Data: &body},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("SES SendEmail: %w", err)
+	}
+	return nil
+}
+
 func buildVerificationHTML(name, verifyURL string) string {
 	return fmt.Sprintf(`<!DOCTYPE html>
 <html>
@@ -139,9 +258,88 @@ func buildPasswordResetHTML(name, resetURL string) string {
   </p>
   <p>Or copy and paste this link into your browser:</p>
   <p style="word-break: break-all; color: #666;">%s</p>
-  <p style="color: #999; font-size: 12px;">This link expires in 1 hour. If you didn't request a password reset, you can safely ignore this email.</p>
+  <p style="color: #999; font-size: 12px;">This link works once, from the browser you requested it in, and expires shortly. If you didn't request a password reset, you can safely ignore this email.</p>
   <hr style="border: none; border-top: 1px solid #eee; margin: 20px 0;">
   <p style="color: #999; font-size: 12px;">SATVOS - Invoice Processing Platform</p>
 </body>
 </html>`, name, resetURL, resetURL)
 }
+
+func buildEmailChangeHTML(name, newEmail, undoURL string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"></head>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto; padding: 20px;">
+  <h2 style="color: #333;">Your email address was changed</h2>
+  <p>Hi %s,</p>
+  <p>The email address of your SATVOS account was changed to <strong>%s</strong>. If you made this change, no action is needed.</p>
+  <p>If you didn't, change it back to this address:</p>
+  <p style="text-align: center; margin: 30px 0;">
+    <a href="%s" style="background-color: #DC2626; color: white; padding: 12px 24px; text-decoration: none; border-radius: 6px; display: inline-block;">Undo Email Change</a>
+  </p>
+  <p>Or copy and paste this link into your browser:</p>
+  <p style="word-break: break-all; color: #666;">%s</p>
+  <hr style="border: none; border-top: 1px solid #eee; margin: 20px 0;">
+  <p style="color: #999; font-size: 12px;">SATVOS - Invoice Processing Platform</p>
+</body>
+</html>`, name, newEmail, undoURL, undoURL)
+}
+
+func buildInvitationHTML(name, tenantName, inviteURL string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"></head>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto; padding: 20px;">
+  <h2 style="color: #333;">You're invited to SATVOS</h2>
+  <p>Hi %s,</p>
+  <p>An account was created for you in <strong>%s</strong> on SATVOS. Click the button below to set your password and sign in:</p>
+  <p style="text-align: center; margin: 30px 0;">
+    <a href="%s" style="background-color: #4F46E5; color: white; padding: 12px 24px; text-decoration: none; border-radius: 6px; display: inline-block;">Accept Invitation</a>
+  </p>
+  <p>Or copy and paste this link into your browser:</p>
+  <p style="word-break: break-all; color: #666;">%s</p>
+  <p style="color: #999; font-size: 12px;">This link works once and expires in a few days. If it has expired, use Forgot password on the sign-in page.</p>
+  <hr style="border: none; border-top: 1px solid #eee; margin: 20px 0;">
+  <p style="color: #999; font-size: 12px;">SATVOS - Invoice Processing Platform</p>
+</body>
+</html>`, html.EscapeString(name), html.EscapeString(tenantName), inviteURL, inviteURL)
+}
+
+func buildIngestionReportHTML(name string, report *port.IngestionReport) string {
+	var rows strings.Builder
+	for _, e := range report.Entries {
+		fmt.Fprintf(&rows, `<tr><td style="padding: 4px 8px; border-bottom: 1px solid #eee;">%s</td><td style="padding: 4px 8px; border-bottom: 1px solid #eee;">%s</td><td style="padding: 4px 8px; border-bottom: 1px solid #eee; color: #666;">%s</td></tr>`,
+			html.EscapeString(e.Path), html.EscapeString(e.Status), html.EscapeString(e.Reason))
+	}
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"></head>
+<body style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto; padding: 20px;">
+  <h2 style="color: #333;">Batch feed report for %s</h2>
+  <p>Hi %s,</p>
+  <p>Files picked up from the %s drop folder: <strong>%d imported</strong>, %d skipped, <strong>%d failed</strong>.</p>
+  <table style="width: 100%%; border-collapse: collapse; font-size: 13px;">
+    <tr><th style="text-align: left; padding: 4px 8px;">File</th><th style="text-align: left; padding: 4px 8px;">Status</th><th style="text-align: left; padding: 4px 8px;">Reason</th></tr>
+    %s
+  </table>
+  <hr style="border: none; border-top: 1px solid #eee; margin: 20px 0;">
+  <p style="color: #999; font-size: 12px;">SATVOS - Invoice Processing Platform</p>
+</body>
+</html>`, report.Date.Format("2006-01-02"), html.EscapeString(name), html.EscapeString(report.TenantName),
+		report.Imported, report.Skipped, report.Failed, rows.String())
+}
+
+func buildIngestionReportText(name string, report *port.IngestionReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Hi %s,\n\nBatch feed report for %s (%s): %d imported, %d skipped, %d failed.\n\n",
+		name, report.TenantName, report.Date.Format("2006-01-02"), report.Imported, report.Skipped, report.Failed)
+	for _, e := range report.Entries {
+		fmt.Fprintf(&b, "%s\t%s", e.Status, e.Path)
+		if e.Reason != "" {
+			fmt.Fprintf(&b, "\t%s", e.Reason)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\nSATVOS Team")
+	return b.String()
+}
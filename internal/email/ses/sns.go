@@ -0,0 +1,202 @@
+package ses
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec // SNS SignatureVersion 1 is SHA1withRSA
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"satvos/internal/port"
+)
+
+// snsHostPattern matches the hosts SNS signs certificates and subscription
+// links from; anything else is refused before it is fetched.
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// maxCertBytes bounds a downloaded signing certificate.
+const maxCertBytes = 64 << 10
+
+type snsEnvelope struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicARN         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL"`
+}
+
+type snsVerifier struct {
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewSNSVerifier creates an SNSVerifier. Signing certificates are fetched from
+// SNS hosts only and cached by URL. A nil httpClient uses http.DefaultClient.
+func NewSNSVerifier(httpClient *http.Client) port.SNSVerifier {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &snsVerifier{httpClient: httpClient, certs: map[string]*x509.Certificate{}}
+}
+
+func (v *snsVerifier) Verify(ctx context.Context, body []byte) (*port.SNSMessage, error) {
+	var env snsEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("parsing SNS message: %w", err)
+	}
+
+	var hash crypto.Hash
+	switch env.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return nil, fmt.Errorf("unsupported SNS signature version %q", env.SignatureVersion)
+	}
+	sig, err := base64.StdEncoding.DecodeString(env.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decoding SNS signature: %w", err)
+	}
+	cert, err := v.cert(ctx, env.SigningCertURL)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("SNS signing certificate has no RSA key")
+	}
+	if err := rsa.VerifyPKCS1v15(pub, hash, digest(hash, stringToSign(&env)), sig); err != nil {
+		return nil, fmt.Errorf("SNS signature does not verify: %w", err)
+	}
+
+	return &port.SNSMessage{
+		Type:         env.Type,
+		MessageID:    env.MessageID,
+		TopicARN:     env.TopicARN,
+		Message:      env.Message,
+		SubscribeURL: env.SubscribeURL,
+	}, nil
+}
+
+func (v *snsVerifier) ConfirmSubscription(ctx context.Context, msg *port.SNSMessage) error {
+	if err := checkSNSURL(msg.SubscribeURL); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, msg.SubscribeURL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("building SNS subscription confirmation: %w", err)
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("confirming SNS subscription: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("confirming SNS subscription: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (v *snsVerifier) cert(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if err := checkSNSURL(certURL); err != nil {
+		return nil, err
+	}
+	v.mu.Lock()
+	cert, ok := v.certs[certURL]
+	v.mu.Unlock()
+	if ok {
+		return cert, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("building SNS certificate request: %w", err)
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching SNS signing certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching SNS signing certificate: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCertBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading SNS signing certificate: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("SNS signing certificate is not PEM")
+	}
+	if cert, err = x509.ParseCertificate(block.Bytes); err != nil {
+		return nil, fmt.Errorf("parsing SNS signing certificate: %w", err)
+	}
+
+	v.mu.Lock()
+	v.certs[certURL] = cert
+	v.mu.Unlock()
+	return cert, nil
+}
+
+// checkSNSURL accepts only https URLs on an SNS host.
+func checkSNSURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || !snsHostPattern.MatchString(u.Hostname()) {
+		return fmt.Errorf("URL %q is not an SNS https URL", raw)
+	}
+	return nil
+}
+
+// stringToSign builds the canonical form SNS signs: name/value lines of the
+// type's signed fields in alphabetical order, skipping an absent Subject.
+func stringToSign(env *snsEnvelope) string {
+	fields := [][2]string{{"Message", env.Message}, {"MessageId", env.MessageID}}
+	if env.Type == "Notification" {
+		if env.Subject != "" {
+			fields = append(fields, [2]string{"Subject", env.Subject})
+		}
+	} else {
+		fields = append(fields, [2]string{"SubscribeURL", env.SubscribeURL})
+	}
+	fields = append(fields, [2]string{"Timestamp", env.Timestamp})
+	if env.Type != "Notification" {
+		fields = append(fields, [2]string{"Token", env.Token})
+	}
+	fields = append(fields, [2]string{"TopicArn", env.TopicARN}, [2]string{"Type", env.Type})
+
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteString(f[0] + "\n" + f[1] + "\n")
+	}
+	return b.String()
+}
+
+func digest(hash crypto.Hash, s string) []byte {
+	if hash == crypto.SHA1 {
+		sum := sha1.Sum([]byte(s)) //nolint:gosec // SNS SignatureVersion 1 is SHA1withRSA
+		return sum[:]
+	}
+	sum := sha256.Sum256([]byte(s))
+	return sum[:]
+}
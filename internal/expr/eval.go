@@ -0,0 +1,329 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type node interface {
+	eval(data map[string]interface{}) (interface{}, error)
+}
+
+type literalNode struct{ v interface{} }
+
+func (n *literalNode) eval(map[string]interface{}) (interface{}, error) { return n.v, nil }
+
+type fieldNode struct{ segments []string }
+
+func (n *fieldNode) eval(data map[string]interface{}) (interface{}, error) {
+	return lookup(data, n.segments), nil
+}
+
+type unaryNode struct {
+	op string
+	x  node
+}
+
+func (n *unaryNode) eval(data map[string]interface{}) (interface{}, error) {
+	v, err := n.x.eval(data)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		return !truthy(v), nil
+	}
+	if v == nil {
+		return nil, nil
+	}
+	f, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot negate %s", ErrEval, typeName(v))
+	}
+	return -f, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n *binaryNode) eval(data map[string]interface{}) (interface{}, error) {
+	l, err := n.left.eval(data)
+	if err != nil {
+		return nil, err
+	}
+	// && and || short-circuit
+	switch n.op {
+	case "&&":
+		if !truthy(l) {
+			return false, nil
+		}
+		r, err := n.right.eval(data)
+		return truthy(r), err
+	case "||":
+		if truthy(l) {
+			return true, nil
+		}
+		r, err := n.right.eval(data)
+		return truthy(r), err
+	}
+
+	r, err := n.right.eval(data)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return l == r, nil
+	case "!=":
+		return l != r, nil
+	}
+	if l == nil || r == nil {
+		return nil, nil
+	}
+
+	if ls, ok := l.(string); ok {
+		rs, ok := r.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot apply %s to string and %s", ErrEval, n.op, typeName(r))
+		}
+		switch n.op {
+		case "+":
+			return ls + rs, nil
+		case "<":
+			return ls < rs, nil
+		case "<=":
+			return ls <= rs, nil
+		case ">":
+			return ls > rs, nil
+		case ">=":
+			return ls >= rs, nil
+		}
+		return nil, fmt.Errorf("%w: cannot apply %s to strings", ErrEval, n.op)
+	}
+
+	lf, lok := l.(float64)
+	rf, rok := r.(float64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("%w: cannot apply %s to %s and %s", ErrEval, n.op, typeName(l), typeName(r))
+	}
+	switch n.op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "/":
+		if rf == 0 {
+			return nil, fmt.Errorf("%w: division by zero", ErrEval)
+		}
+		return lf / rf, nil
+	case "<":
+		return lf < rf, nil
+	case "<=":
+		return lf <= rf, nil
+	case ">":
+		return lf > rf, nil
+	default: // >=
+		return lf >= rf, nil
+	}
+}
+
+// function is a built-in taking a fixed range of arguments; maxArgs -1 means
+// any number. Arguments are passed unevaluated so if can skip a branch.
+type function struct {
+	minArgs, maxArgs int
+	call             func(data map[string]interface{}, args []node) (interface{}, error)
+}
+
+var functions = map[string]function{
+	"if": {3, 3, func(data map[string]interface{}, args []node) (interface{}, error) {
+		c, err := args[0].eval(data)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(c) {
+			return args[1].eval(data)
+		}
+		return args[2].eval(data)
+	}},
+	"coalesce": {1, -1, func(data map[string]interface{}, args []node) (interface{}, error) {
+		for _, a := range args {
+			v, err := a.eval(data)
+			if err != nil || v != nil {
+				return v, err
+			}
+		}
+		return nil, nil
+	}},
+	"concat": {1, -1, func(data map[string]interface{}, args []node) (interface{}, error) {
+		var b strings.Builder
+		for _, a := range args {
+			v, err := a.eval(data)
+			if err != nil {
+				return nil, err
+			}
+			b.WriteString(Format(v))
+		}
+		return b.String(), nil
+	}},
+	"round": {1, 2, numeric(func(x []float64) float64 {
+		scale := 1.0
+		if len(x) == 2 {
+			scale = math.Pow(10, math.Trunc(x[1]))
+		}
+		return math.Round(x[0]*scale) / scale
+	})},
+	"abs":   {1, 1, numeric(func(x []float64) float64 { return math.Abs(x[0]) })},
+	"upper": {1, 1, text(strings.ToUpper)},
+	"lower": {1, 1, text(strings.ToLower)},
+	"trim":  {1, 1, text(strings.TrimSpace)},
+}
+
+// numeric adapts a function of numbers; any null argument makes the result null.
+func numeric(f func([]float64) float64) func(map[string]interface{}, []node) (interface{}, error) {
+	return func(data map[string]interface{}, args []node) (interface{}, error) {
+		xs := make([]float64, len(args))
+		for i, a := range args {
+			v, err := a.eval(data)
+			if err != nil || v == nil {
+				return nil, err
+			}
+			x, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%w: expected a number, got %s", ErrEval, typeName(v))
+			}
+			xs[i] = x
+		}
+		return f(xs), nil
+	}
+}
+
+// text adapts a function of one string; a null argument makes the result null.
+func text(f func(string) string) func(map[string]interface{}, []node) (interface{}, error) {
+	return func(data map[string]interface{}, args []node) (interface{}, error) {
+		v, err := args[0].eval(data)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a string, got %s", ErrEval, typeName(v))
+		}
+		return f(s), nil
+	}
+}
+
+type callNode struct {
+	fn   function
+	args []node
+}
+
+func (n *callNode) eval(data map[string]interface{}) (interface{}, error) {
+	return n.fn.call(data, n.args)
+}
+
+// aggregates are the functions over an array path. Null elements are skipped;
+// avg, min and max of no values are null.
+var aggregates = map[string]struct{}{"sum": {}, "count": {}, "avg": {}, "min": {}, "max": {}}
+
+type aggregateNode struct {
+	fn    string
+	parts [][]string
+}
+
+func (n *aggregateNode) eval(data map[string]interface{}) (interface{}, error) {
+	var nums []float64
+	count := 0
+	for _, v := range collect(data, n.parts) {
+		if v == nil {
+			continue
+		}
+		count++
+		if n.fn == "count" {
+			continue
+		}
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s expects numbers, got %s", ErrEval, n.fn, typeName(v))
+		}
+		nums = append(nums, f)
+	}
+
+	switch n.fn {
+	case "count":
+		return float64(count), nil
+	case "sum":
+		total := 0.0
+		for _, f := range nums {
+			total += f
+		}
+		return total, nil
+	}
+	if len(nums) == 0 {
+		return nil, nil
+	}
+	out := nums[0]
+	for _, f := range nums[1:] {
+		switch n.fn {
+		case "avg":
+			out += f
+		case "min":
+			out = math.Min(out, f)
+		case "max":
+			out = math.Max(out, f)
+		}
+	}
+	if n.fn == "avg" {
+		out /= float64(len(nums))
+	}
+	return out, nil
+}
+
+// truthy is false for null, false, 0 and "".
+func truthy(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return x
+	case float64:
+		return x != 0
+	case string:
+		return x != ""
+	default:
+		return true
+	}
+}
+
+// Format renders a value as text: numbers without trailing zeros, null as "".
+func Format(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	case string:
+		return x
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
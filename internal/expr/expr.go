@@ -0,0 +1,140 @@
+// Package expr compiles and evaluates the small expression language of tenant
+// computed fields: arithmetic, comparisons and a few functions over the fields of
+// a document's structured data.
+//
+// Field paths use validation field path notation ("totals.grand_total");
+// "[]" paths ("line_items[].quantity") name one value per array element and may
+// only be passed to the aggregate functions sum, count, avg, min and max.
+// Values are numbers, strings, booleans or null. Arithmetic on null is null, so
+// missing fields don't fail the whole expression.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MaxLength bounds the source of an expression.
+const MaxLength = 1000
+
+// maxDepth bounds the nesting of an expression.
+const maxDepth = 32
+
+var (
+	// ErrSyntax means the expression is not valid: bad syntax, an unknown
+	// field or function, or a wrong number of arguments.
+	ErrSyntax = errors.New("invalid expression")
+	// ErrEval means the expression failed on a particular document, for
+	// example dividing by zero or adding a string to a number.
+	ErrEval = errors.New("expression evaluation failed")
+)
+
+// Expr is a compiled expression. It is safe for concurrent use.
+type Expr struct {
+	root node
+}
+
+// Compile parses src. fields maps the field paths the expression may use to
+// their JSON type; only scalar types (string, number, integer, boolean) can be
+// referenced.
+func Compile(src string, fields map[string]string) (*Expr, error) {
+	if len(src) > MaxLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrSyntax, MaxLength)
+	}
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks, fields: fields}
+	root, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, t.text, t.pos)
+	}
+	return &Expr{root: root}, nil
+}
+
+// Eval evaluates the expression against data, a document's structured data
+// decoded from JSON. The result is a float64, string, bool or nil.
+func (e *Expr) Eval(data map[string]interface{}) (interface{}, error) {
+	v, err := e.root.eval(data)
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := v.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return nil, fmt.Errorf("%w: result is not a finite number", ErrEval)
+	}
+	return v, nil
+}
+
+// lookup walks a dotted path through decoded JSON, returning nil when any
+// step is missing.
+func lookup(v interface{}, segments []string) interface{} {
+	for _, s := range segments {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[s]
+	}
+	return scalar(v)
+}
+
+// scalar returns v if it is a value the language works with, else nil.
+func scalar(v interface{}) interface{} {
+	switch v.(type) {
+	case float64, string, bool:
+		return v
+	default:
+		return nil
+	}
+}
+
+// collect returns the values of an array path: the array at prefix, then the
+// rest of the path within each element.
+func collect(data map[string]interface{}, parts [][]string) []interface{} {
+	var out []interface{}
+	var walk func(v interface{}, i int)
+	walk = func(v interface{}, i int) {
+		if i == len(parts)-1 {
+			out = append(out, lookup(v, parts[i]))
+			return
+		}
+		cur := v
+		for _, s := range parts[i] {
+			cm, ok := cur.(map[string]interface{})
+			if !ok {
+				return
+			}
+			cur = cm[s]
+		}
+		items, ok := cur.([]interface{})
+		if !ok {
+			return
+		}
+		for _, item := range items {
+			walk(item, i+1)
+		}
+	}
+	walk(data, 0)
+	return out
+}
+
+// splitPath splits "line_items[].hsn_sac_code" into the dotted segments before,
+// between and after each "[]".
+func splitPath(path string) [][]string {
+	var parts [][]string
+	for _, p := range strings.Split(path, "[]") {
+		p = strings.TrimPrefix(p, ".")
+		if p == "" {
+			parts = append(parts, nil)
+			continue
+		}
+		parts = append(parts, strings.Split(p, "."))
+	}
+	return parts
+}
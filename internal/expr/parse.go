@@ -0,0 +1,270 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+	num  float64
+	pos  int
+}
+
+// operators lists the operator tokens, two-character ones first.
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "+", "-", "*", "/", "<", ">", "!", "(", ")", ","}
+
+// precedence of the binary operators; higher binds tighter.
+var precedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6,
+}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			n, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: bad number %q at position %d", ErrSyntax, src[start:i], start)
+			}
+			toks = append(toks, token{kind: tokNumber, text: src[start:i], num: n, pos: start})
+		case c == '"' || c == '\'':
+			start := i
+			var b strings.Builder
+			i++
+			for i < len(src) && src[i] != c {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+				}
+				b.WriteByte(src[i])
+				i++
+			}
+			if i >= len(src) {
+				return nil, fmt.Errorf("%w: unterminated string at position %d", ErrSyntax, start)
+			}
+			i++
+			toks = append(toks, token{kind: tokString, text: b.String(), pos: start})
+		case c == '_' || unicode.IsLetter(rune(c)):
+			// An identifier or field path: letters, digits, underscores, dots and "[]"
+			start := i
+			for i < len(src) {
+				r := src[i]
+				if r == '_' || r == '.' || r >= '0' && r <= '9' || unicode.IsLetter(rune(r)) {
+					i++
+				} else if r == '[' && i+1 < len(src) && src[i+1] == ']' {
+					i += 2
+				} else {
+					break
+				}
+			}
+			toks = append(toks, token{kind: tokIdent, text: src[start:i], pos: start})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(src[i:], op) {
+					toks = append(toks, token{kind: tokOp, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, c, i)
+			}
+		}
+	}
+	return append(toks, token{kind: tokEOF, text: "end of expression", pos: len(src)}), nil
+}
+
+type parser struct {
+	toks   []token
+	i      int
+	fields map[string]string
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) expectOp(op string) error {
+	if t := p.next(); t.kind != tokOp || t.text != op {
+		return fmt.Errorf("%w: expected %q at position %d, got %q", ErrSyntax, op, t.pos, t.text)
+	}
+	return nil
+}
+
+// parseExpr parses a binary expression by precedence climbing.
+func (p *parser) parseExpr(depth int) (node, error) {
+	return p.parseBinary(1, depth)
+}
+
+func (p *parser) parseBinary(minPrec, depth int) (node, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: nested more than %d levels", ErrSyntax, maxDepth)
+	}
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		prec, ok := precedence[t.text]
+		if t.kind != tokOp || !ok || prec < minPrec {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(prec+1, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: t.text, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary(depth int) (node, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: nested more than %d levels", ErrSyntax, maxDepth)
+	}
+	if t := p.peek(); t.kind == tokOp && (t.text == "-" || t.text == "!") {
+		p.next()
+		x, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: t.text, x: x}, nil
+	}
+	return p.parsePrimary(depth)
+}
+
+func (p *parser) parsePrimary(depth int) (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &literalNode{v: t.num}, nil
+	case tokString:
+		return &literalNode{v: t.text}, nil
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{v: true}, nil
+		case "false":
+			return &literalNode{v: false}, nil
+		case "null":
+			return &literalNode{v: nil}, nil
+		}
+		if n := p.peek(); n.kind == tokOp && n.text == "(" {
+			return p.parseCall(t, depth)
+		}
+		if strings.Contains(t.text, "[]") {
+			return nil, fmt.Errorf("%w: %s has one value per element; use it in sum, count, avg, min or max", ErrSyntax, t.text)
+		}
+		if err := p.checkField(t.text); err != nil {
+			return nil, err
+		}
+		return &fieldNode{segments: strings.Split(t.text, ".")}, nil
+	case tokOp:
+		if t.text == "(" {
+			x, err := p.parseExpr(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, t.text, t.pos)
+}
+
+func (p *parser) parseCall(name token, depth int) (node, error) {
+	p.next() // (
+	if _, ok := aggregates[name.text]; ok {
+		arg := p.next()
+		if arg.kind != tokIdent || !strings.Contains(arg.text, "[]") {
+			return nil, fmt.Errorf("%w: %s takes one array field path such as line_items[].total, at position %d", ErrSyntax, name.text, arg.pos)
+		}
+		if err := p.checkField(arg.text); err != nil {
+			return nil, err
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+		return &aggregateNode{fn: name.text, parts: splitPath(arg.text)}, nil
+	}
+
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown function %s at position %d", ErrSyntax, name.text, name.pos)
+	}
+	var args []node
+	if t := p.peek(); t.kind == tokOp && t.text == ")" {
+		p.next()
+	} else {
+		for {
+			arg, err := p.parseExpr(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			t := p.next()
+			if t.kind == tokOp && t.text == ")" {
+				break
+			}
+			if t.kind != tokOp || t.text != "," {
+				return nil, fmt.Errorf("%w: expected \",\" or \")\" at position %d, got %q", ErrSyntax, t.pos, t.text)
+			}
+		}
+	}
+	if len(args) < fn.minArgs || fn.maxArgs >= 0 && len(args) > fn.maxArgs {
+		return nil, fmt.Errorf("%w: wrong number of arguments to %s at position %d", ErrSyntax, name.text, name.pos)
+	}
+	return &callNode{fn: fn, args: args}, nil
+}
+
+// checkField accepts paths to scalar fields only.
+func (p *parser) checkField(path string) error {
+	typ, ok := p.fields[path]
+	if !ok {
+		return fmt.Errorf("%w: unknown field %s", ErrSyntax, path)
+	}
+	switch typ {
+	case "string", "number", "integer", "boolean":
+		return nil
+	default:
+		return fmt.Errorf("%w: %s is an %s, not a single value", ErrSyntax, path, typ)
+	}
+}
@@ -0,0 +1,181 @@
+// Package faults injects latency, errors and partial failures into the storage,
+// parser and document repository ports, so the parse queue and retry logic can be
+// exercised against simulated brownouts in staging. The wrappers are only wired
+// when fault injection is enabled in config, and never in production.
+package faults
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Target names a port that faults can be injected into.
+type Target string
+
+// Injectable targets.
+const (
+	TargetStorage    Target = "storage"
+	TargetParser     Target = "parser"
+	TargetRepository Target = "repository"
+)
+
+// Targets lists every injectable target.
+var Targets = []Target{TargetStorage, TargetParser, TargetRepository}
+
+// MaxLatency caps the delay a scenario may add to each call.
+const MaxLatency = 5 * time.Minute
+
+// ErrInjected is wrapped by every error the wrappers fabricate.
+var ErrInjected = errors.New("injected fault")
+
+// ErrInvalidScenario is returned by Set for an unknown target or out-of-range settings.
+var ErrInvalidScenario = errors.New("invalid fault scenario")
+
+// Scenario describes the faults injected into one target. Each matching call is
+// delayed by LatencyMS, then fails outright with probability ErrorPercent, or
+// goes through to the real port and still reports an error with probability
+// PartialPercent (a write that landed but whose acknowledgement was lost, or a
+// truncated read).
+type Scenario struct {
+	Target Target `json:"target"`
+	// Operations limits the scenario to these method names (e.g. "Download");
+	// empty means every operation of the target.
+	Operations     []string `json:"operations,omitempty"`
+	LatencyMS      int      `json:"latency_ms"`
+	ErrorPercent   int      `json:"error_percent"`
+	PartialPercent int      `json:"partial_percent"`
+}
+
+func (s Scenario) validate() error {
+	known := false
+	for _, t := range Targets {
+		if s.Target == t {
+			known = true
+		}
+	}
+	switch {
+	case !known:
+		return fmt.Errorf("%w: unknown target %q", ErrInvalidScenario, s.Target)
+	case s.LatencyMS < 0 || time.Duration(s.LatencyMS)*time.Millisecond > MaxLatency:
+		return fmt.Errorf("%w: latency_ms must be between 0 and %d", ErrInvalidScenario, MaxLatency.Milliseconds())
+	case s.ErrorPercent < 0 || s.PartialPercent < 0 || s.ErrorPercent+s.PartialPercent > 100:
+		return fmt.Errorf("%w: error_percent and partial_percent must be non-negative and sum to at most 100", ErrInvalidScenario)
+	}
+	return nil
+}
+
+func (s Scenario) covers(op string) bool {
+	if len(s.Operations) == 0 {
+		return true
+	}
+	for _, o := range s.Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// outcome is what the injector decided for one call.
+type outcome int
+
+const (
+	pass    outcome = iota // call the real port normally
+	fail                   // return an error without calling the real port
+	partial                // call the real port, then return an error anyway
+)
+
+// Injector holds the active scenarios, one per target. It is process-wide and
+// per instance: set the same scenario on every replica to cover a whole fleet.
+type Injector struct {
+	mu        sync.RWMutex
+	scenarios map[Target]Scenario
+	roll      func() int // uniform in [0, 100)
+}
+
+// NewInjector creates an Injector with no active scenarios.
+func NewInjector() *Injector {
+	return &Injector{
+		scenarios: make(map[Target]Scenario),
+		roll:      func() int { return rand.IntN(100) },
+	}
+}
+
+// NewInjectorWithRoll creates an Injector whose dice are roll, for tests that
+// need deterministic outcomes. roll must return values in [0, 100).
+func NewInjectorWithRoll(roll func() int) *Injector {
+	i := NewInjector()
+	i.roll = roll
+	return i
+}
+
+// Set activates s, replacing any scenario already set for its target.
+func (i *Injector) Set(s Scenario) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.scenarios[s.Target] = s
+	return nil
+}
+
+// Clear removes the scenario for target, if any.
+func (i *Injector) Clear(target Target) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.scenarios, target)
+}
+
+// Scenarios returns the active scenarios ordered by target.
+func (i *Injector) Scenarios() []Scenario {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	out := make([]Scenario, 0, len(i.scenarios))
+	for _, s := range i.scenarios {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].Target < out[b].Target })
+	return out
+}
+
+// inject applies the scenario for target to one call of op: it sleeps for the
+// configured latency (returning early with the context's error if ctx ends) and
+// rolls for a failure.
+func (i *Injector) inject(ctx context.Context, target Target, op string) (outcome, error) {
+	i.mu.RLock()
+	s, ok := i.scenarios[target]
+	i.mu.RUnlock()
+	if !ok || !s.covers(op) {
+		return pass, nil
+	}
+
+	if s.LatencyMS > 0 {
+		t := time.NewTimer(time.Duration(s.LatencyMS) * time.Millisecond)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return pass, ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	r := i.roll()
+	switch {
+	case r < s.ErrorPercent:
+		return fail, nil
+	case r < s.ErrorPercent+s.PartialPercent:
+		return partial, nil
+	}
+	return pass, nil
+}
+
+// injectedError describes a fabricated failure of op on target.
+func injectedError(target Target, op, detail string) error {
+	return fmt.Errorf("%w: %s %s: %s", ErrInjected, target, op, detail)
+}
@@ -0,0 +1,43 @@
+package faults
+
+import (
+	"context"
+
+	"satvos/internal/parser"
+	"satvos/internal/port"
+)
+
+// providerName labels the errors the parser wrapper fabricates.
+const providerName = "fault-injection"
+
+// documentParser wraps a port.DocumentParser with the TargetParser scenario.
+// Failures are TransientErrors, so the fallback chain and the parse queue retry
+// them as they would a provider outage; a partial failure runs the real parse
+// and discards its result, like a response lost after the provider billed it.
+type documentParser struct {
+	next     port.DocumentParser
+	injector *Injector
+}
+
+// NewParser wraps next so calls are subject to the injector's parser scenario.
+func NewParser(next port.DocumentParser, injector *Injector) port.DocumentParser {
+	return &documentParser{next: next, injector: injector}
+}
+
+func (p *documentParser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseOutput, error) {
+	o, err := p.injector.inject(ctx, TargetParser, "Parse")
+	if err != nil {
+		return nil, parser.NewTransientError(providerName, err)
+	}
+	if o == fail {
+		return nil, parser.NewTransientError(providerName, injectedError(TargetParser, "Parse", "503 service unavailable"))
+	}
+	out, err := p.next.Parse(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if o == partial {
+		return nil, parser.NewTransientError(providerName, injectedError(TargetParser, "Parse", "connection reset while reading response"))
+	}
+	return out, nil
+}
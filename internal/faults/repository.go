@@ -0,0 +1,84 @@
+package faults
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// documentRepo wraps a port.DocumentRepository with the TargetRepository
+// scenario on the calls the parse pipeline makes; every other method passes
+// straight through. A partial failure commits the write (or the claim) and then
+// reports an error, as when the connection drops before the result comes back.
+type documentRepo struct {
+	port.DocumentRepository
+	injector *Injector
+}
+
+// NewDocumentRepo wraps next so parse-pipeline calls are subject to the
+// injector's repository scenario.
+func NewDocumentRepo(next port.DocumentRepository, injector *Injector) port.DocumentRepository {
+	return &documentRepo{DocumentRepository: next, injector: injector}
+}
+
+func (r *documentRepo) do(ctx context.Context, op string, call func() error) error {
+	o, err := r.injector.inject(ctx, TargetRepository, op)
+	if err != nil {
+		return err
+	}
+	if o == fail {
+		return injectedError(TargetRepository, op, "connection refused")
+	}
+	if err := call(); err != nil {
+		return err
+	}
+	if o == partial {
+		return injectedError(TargetRepository, op, "connection reset after commit")
+	}
+	return nil
+}
+
+func (r *documentRepo) ClaimQueued(ctx context.Context, limit int) ([]domain.Document, error) {
+	var docs []domain.Document
+	err := r.do(ctx, "ClaimQueued", func() error {
+		var err error
+		docs, err = r.DocumentRepository.ClaimQueued(ctx, limit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (r *documentRepo) GetByID(ctx context.Context, tenantID, docID uuid.UUID) (*domain.Document, error) {
+	var doc *domain.Document
+	err := r.do(ctx, "GetByID", func() error {
+		var err error
+		doc, err = r.DocumentRepository.GetByID(ctx, tenantID, docID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (r *documentRepo) Create(ctx context.Context, doc *domain.Document) error {
+	return r.do(ctx, "Create", func() error { return r.DocumentRepository.Create(ctx, doc) })
+}
+
+func (r *documentRepo) UpdateStructuredData(ctx context.Context, doc *domain.Document) error {
+	return r.do(ctx, "UpdateStructuredData", func() error { return r.DocumentRepository.UpdateStructuredData(ctx, doc) })
+}
+
+func (r *documentRepo) UpdateValidationResults(ctx context.Context, doc *domain.Document) error {
+	return r.do(ctx, "UpdateValidationResults", func() error { return r.DocumentRepository.UpdateValidationResults(ctx, doc) })
+}
+
+func (r *documentRepo) UpdateReviewStatus(ctx context.Context, doc *domain.Document) error {
+	return r.do(ctx, "UpdateReviewStatus", func() error { return r.DocumentRepository.UpdateReviewStatus(ctx, doc) })
+}
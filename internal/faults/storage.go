@@ -0,0 +1,165 @@
+package faults
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"satvos/internal/port"
+)
+
+// storage wraps a port.ObjectStorage with the TargetStorage scenario. Failed calls
+// look like an S3 503 SlowDown; partial reads return the first half of the object
+// with io.ErrUnexpectedEOF, and partial writes land before reporting an error.
+type storage struct {
+	next     port.ObjectStorage
+	injector *Injector
+}
+
+// NewStorage wraps next so calls are subject to the injector's storage scenario.
+func NewStorage(next port.ObjectStorage, injector *Injector) port.ObjectStorage {
+	return &storage{next: next, injector: injector}
+}
+
+func slowDown(op string) error {
+	return injectedError(TargetStorage, op, "503 SlowDown: please reduce your request rate")
+}
+
+func lostAck(op string) error {
+	return injectedError(TargetStorage, op, "connection reset after request was sent")
+}
+
+func truncated(op string) error {
+	return fmt.Errorf("%w: %w", injectedError(TargetStorage, op, "response body truncated"), io.ErrUnexpectedEOF)
+}
+
+// write runs a mutating call under the storage scenario.
+func (s *storage) write(ctx context.Context, op string, call func() error) error {
+	o, err := s.injector.inject(ctx, TargetStorage, op)
+	if err != nil {
+		return err
+	}
+	if o == fail {
+		return slowDown(op)
+	}
+	if err := call(); err != nil {
+		return err
+	}
+	if o == partial {
+		return lostAck(op)
+	}
+	return nil
+}
+
+func (s *storage) Upload(ctx context.Context, input port.UploadInput) (*port.UploadOutput, error) {
+	var out *port.UploadOutput
+	err := s.write(ctx, "Upload", func() error {
+		var err error
+		out, err = s.next.Upload(ctx, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *storage) Download(ctx context.Context, bucket, key string) ([]byte, error) {
+	o, err := s.injector.inject(ctx, TargetStorage, "Download")
+	if err != nil {
+		return nil, err
+	}
+	if o == fail {
+		return nil, slowDown("Download")
+	}
+	data, err := s.next.Download(ctx, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	if o == partial {
+		return data[:len(data)/2], truncated("Download")
+	}
+	return data, nil
+}
+
+func (s *storage) Open(ctx context.Context, bucket, key string) (*port.ObjectReader, error) {
+	o, err := s.injector.inject(ctx, TargetStorage, "Open")
+	if err != nil {
+		return nil, err
+	}
+	if o == fail {
+		return nil, slowDown("Open")
+	}
+	obj, err := s.next.Open(ctx, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	if o == partial {
+		obj.Body = &truncatedBody{ReadCloser: obj.Body, remaining: obj.Size / 2}
+	}
+	return obj, nil
+}
+
+func (s *storage) Delete(ctx context.Context, bucket, key string) error {
+	return s.write(ctx, "Delete", func() error { return s.next.Delete(ctx, bucket, key) })
+}
+
+func (s *storage) GetPresignedURL(ctx context.Context, bucket, key string, expirySeconds int64) (string, error) {
+	o, err := s.injector.inject(ctx, TargetStorage, "GetPresignedURL")
+	if err != nil {
+		return "", err
+	}
+	if o != pass {
+		return "", slowDown("GetPresignedURL")
+	}
+	return s.next.GetPresignedURL(ctx, bucket, key, expirySeconds)
+}
+
+func (s *storage) List(ctx context.Context, bucket, prefix string) ([]port.ObjectInfo, error) {
+	o, err := s.injector.inject(ctx, TargetStorage, "List")
+	if err != nil {
+		return nil, err
+	}
+	if o == fail {
+		return nil, slowDown("List")
+	}
+	objects, err := s.next.List(ctx, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if o == partial {
+		return objects[:len(objects)/2], truncated("List")
+	}
+	return objects, nil
+}
+
+func (s *storage) Copy(ctx context.Context, bucket, srcKey, dstKey string, enc *port.Encryption) error {
+	return s.write(ctx, "Copy", func() error { return s.next.Copy(ctx, bucket, srcKey, dstKey, enc) })
+}
+
+func (s *storage) PutLifecycleRule(ctx context.Context, bucket string, rule port.LifecycleRule) error {
+	return s.write(ctx, "PutLifecycleRule", func() error { return s.next.PutLifecycleRule(ctx, bucket, rule) })
+}
+
+func (s *storage) DeleteLifecycleRule(ctx context.Context, bucket, ruleID string) error {
+	return s.write(ctx, "DeleteLifecycleRule", func() error { return s.next.DeleteLifecycleRule(ctx, bucket, ruleID) })
+}
+
+// truncatedBody yields the first remaining bytes of the object, then fails the
+// way a dropped connection would.
+type truncatedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		return 0, truncated("Open")
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
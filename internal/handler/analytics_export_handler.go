@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// AnalyticsExportHandler handles tenant analytics export endpoints.
+type AnalyticsExportHandler struct {
+	exportService service.AnalyticsExportService
+}
+
+// NewAnalyticsExportHandler creates a new AnalyticsExportHandler.
+func NewAnalyticsExportHandler(exportService service.AnalyticsExportService) *AnalyticsExportHandler {
+	return &AnalyticsExportHandler{exportService: exportService}
+}
+
+// Get handles GET /api/v1/admin/tenants/:id/analytics-export
+// @Summary Get analytics export
+// @Description Get the tenant's scheduled Parquet export and the outcome of its last run (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Success 200 {object} Response{data=domain.AnalyticsExport} "Analytics export"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "No analytics export configured"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/analytics-export [get]
+func (h *AnalyticsExportHandler) Get(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	export, err := h.exportService.Get(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, export)
+}
+
+// Configure handles PUT /api/v1/admin/tenants/:id/analytics-export
+// @Summary Configure analytics export
+// @Description Create or replace the tenant's scheduled export of documents, line items and validation results as Parquet files under an S3 prefix. A new or changed destination receives the full history on the next run; later runs write only documents changed since the previous one (admin only)
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param body body service.ConfigureAnalyticsExportInput true "Export destination and schedule"
+// @Success 200 {object} Response{data=domain.AnalyticsExport} "Analytics export configured"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID, destination or interval"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/analytics-export [put]
+func (h *AnalyticsExportHandler) Configure(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	var input service.ConfigureAnalyticsExportInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	export, err := h.exportService.Configure(c.Request.Context(), tenantID, &input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, export)
+}
+
+// Delete handles DELETE /api/v1/admin/tenants/:id/analytics-export
+// @Summary Remove analytics export
+// @Description Stop exporting the tenant's data. Files already written are left in place (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Analytics export removed"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "No analytics export configured"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/analytics-export [delete]
+func (h *AnalyticsExportHandler) Delete(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	if err := h.exportService.Delete(c.Request.Context(), tenantID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "analytics export removed"})
+}
+
+// Run handles POST /api/v1/admin/tenants/:id/analytics-export/run
+// @Summary Run analytics export now
+// @Description Make the tenant's export due immediately; the export worker picks it up on its next tick (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Success 202 {object} Response{data=domain.AnalyticsExport} "Export scheduled"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "No analytics export configured"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/analytics-export/run [post]
+func (h *AnalyticsExportHandler) Run(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	export, err := h.exportService.RunNow(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{Success: true, Data: export})
+}
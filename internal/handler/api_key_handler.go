@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// APIKeyHandler handles the caller's API keys.
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler.
+func NewAPIKeyHandler(apiKeyService service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// Create handles POST /api/v1/users/me/api-keys
+// @Summary Create an API key
+// @Description Create a long-lived API key for scripts and satvosctl (admin only). Send it as "Authorization: Bearer <key>"; it acts as the caller with their current role until deleted or the caller is deactivated. The key is only returned here.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body service.CreateAPIKeyInput true "Key name"
+// @Success 201 {object} Response{data=service.CreatedAPIKey} "API key created"
+// @Failure 400 {object} ErrorResponseBody "Validation error"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden"
+// @Security BearerAuth
+// @Router /users/me/api-keys [post]
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var input service.CreateAPIKeyInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	key, err := h.apiKeyService.Create(c.Request.Context(), tenantID, userID, input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondCreated(c, key)
+}
+
+// List handles GET /api/v1/users/me/api-keys
+// @Summary List my API keys
+// @Description List the caller's API keys, oldest first, without their secrets (admin only)
+// @Tags users
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.APIKey} "API keys"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden"
+// @Security BearerAuth
+// @Router /users/me/api-keys [get]
+func (h *APIKeyHandler) List(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.apiKeyService.List(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, keys)
+}
+
+// Delete handles DELETE /api/v1/users/me/api-keys/:keyId
+// @Summary Delete an API key
+// @Description Revoke one of the caller's API keys (admin only). Requests made with it fail from then on.
+// @Tags users
+// @Produce json
+// @Param keyId path string true "API key ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "API key deleted"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden"
+// @Failure 404 {object} ErrorResponseBody "API key not found"
+// @Security BearerAuth
+// @Router /users/me/api-keys/{keyId} [delete]
+func (h *APIKeyHandler) Delete(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	keyID, err := uuid.Parse(c.Param("keyId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid API key ID")
+		return
+	}
+
+	if err := h.apiKeyService.Delete(c.Request.Context(), tenantID, userID, keyID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "API key deleted"})
+}
@@ -25,8 +25,7 @@ func NewAuthHandler(authService service.AuthService, registrationService service
 // Login handles POST /api/v1/auth/login
 func (h *AuthHandler) Login(c *gin.Context) {
 	var input service.LoginInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
 
@@ -42,8 +41,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // RefreshToken handles POST /api/v1/auth/refresh
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var input service.RefreshInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
 
@@ -64,8 +62,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	var input service.RegisterInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
 
@@ -107,10 +104,10 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	}
 
 	var input service.ForgotPasswordInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
+	input.Meta = requestMeta(c)
 
 	if err := h.passwordResetService.ForgotPassword(c.Request.Context(), input); err != nil {
 		// Never leak information — always return 200
@@ -128,10 +125,10 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	}
 
 	var input service.ResetPasswordInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
+	input.Meta = requestMeta(c)
 
 	if err := h.passwordResetService.ResetPassword(c.Request.Context(), input); err != nil {
 		HandleError(c, err)
@@ -141,6 +138,33 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	RespondOK(c, gin.H{"message": "password has been reset successfully"})
 }
 
+// UndoEmailChange handles POST /api/v1/auth/undo-email-change
+func (h *AuthHandler) UndoEmailChange(c *gin.Context) {
+	if h.passwordResetService == nil {
+		RespondError(c, http.StatusNotFound, "NOT_FOUND", "password reset is not enabled")
+		return
+	}
+
+	var input service.UndoEmailChangeInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+	input.Meta = requestMeta(c)
+
+	if err := h.passwordResetService.UndoEmailChange(c.Request.Context(), input); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "email address has been restored"})
+}
+
+// requestMeta identifies the client of a request for token binding and the
+// account security audit trail.
+func requestMeta(c *gin.Context) service.RequestMeta {
+	return service.RequestMeta{ClientIP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
+
 // SocialLogin handles POST /api/v1/auth/social-login
 func (h *AuthHandler) SocialLogin(c *gin.Context) {
 	if h.socialAuthService == nil {
@@ -149,8 +173,7 @@ func (h *AuthHandler) SocialLogin(c *gin.Context) {
 	}
 
 	var input service.SocialLoginInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
 
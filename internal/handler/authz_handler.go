@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/middleware"
+	"satvos/internal/port"
+)
+
+// AuthzHandler exposes the effective route authorization matrix and the log of
+// requests it (or a permission check further down) refused.
+type AuthzHandler struct {
+	rules   []domain.RouteRule
+	denials port.AuthzDenialRepository
+}
+
+// NewAuthzHandler creates a new AuthzHandler.
+func NewAuthzHandler(rules []domain.RouteRule, denials port.AuthzDenialRepository) *AuthzHandler {
+	return &AuthzHandler{rules: rules, denials: denials}
+}
+
+// Matrix handles GET /api/v1/admin/authz-matrix
+// @Summary Route authorization matrix
+// @Description Dump the effective route → allowed roles / collection permission matrix for security review (admin only)
+// @Tags admin
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.RouteRule} "Route authorization matrix"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient role"
+// @Security BearerAuth
+// @Router /admin/authz-matrix [get]
+func (h *AuthzHandler) Matrix(c *gin.Context) {
+	RespondOK(c, h.rules)
+}
+
+// Denials handles GET /api/v1/audit/denials
+// @Summary List authorization denials
+// @Description Requests in the tenant that were refused with 403, newest first: who made them, the route and the error code and message returned. Recorded only while SATVOS_AUTHZ_AUDIT_ENABLED is on (admin only)
+// @Tags admin
+// @Produce json
+// @Param user_id query string false "Filter by the denied user's ID"
+// @Param code query string false "Filter by error code, e.g. FORBIDDEN"
+// @Param from query string false "Start time (YYYY-MM-DD or RFC3339, inclusive)"
+// @Param to query string false "End time (YYYY-MM-DD or RFC3339, inclusive)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.AuthzDenial,meta=PagMeta} "Denials"
+// @Failure 400 {object} ErrorResponseBody "Invalid filter"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient role"
+// @Security BearerAuth
+// @Router /audit/denials [get]
+func (h *AuthzHandler) Denials(c *gin.Context) {
+	tenantID, err := middleware.GetTenantID(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing tenant context")
+		return
+	}
+
+	filters, err := parseDenialFilters(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	denials, total, err := h.denials.ListByTenant(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, denials, PagMeta{Total: total, Offset: filters.Offset, Limit: filters.Limit})
+}
+
+// parseDenialFilters extracts denial search filters from query params.
+func parseDenialFilters(c *gin.Context) (*domain.AuthzDenialFilters, error) {
+	filters := &domain.AuthzDenialFilters{Code: c.Query("code")}
+	filters.Offset, filters.Limit = parsePagination(c)
+
+	if v := c.Query("user_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'user_id': must be a valid UUID")
+		}
+		filters.UserID = &id
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		t, err := parseAuditTime(fromStr, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'from': must be YYYY-MM-DD or RFC3339")
+		}
+		filters.From = &t
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		t, err := parseAuditTime(toStr, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'to': must be YYYY-MM-DD or RFC3339")
+		}
+		filters.To = &t
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// BatchFeedHandler handles batch feed (drop folder) ingestion endpoints.
+type BatchFeedHandler struct {
+	feedService service.BatchFeedService
+}
+
+// NewBatchFeedHandler creates a new BatchFeedHandler.
+func NewBatchFeedHandler(feedService service.BatchFeedService) *BatchFeedHandler {
+	return &BatchFeedHandler{feedService: feedService}
+}
+
+// ListIngestions handles GET /api/v1/feeds/ingestions
+// @Summary List batch feed ingestions
+// @Description List files picked up from the tenant's drop folder (tenants/{tenant_id}/drop/{collection}/...), newest first, with their outcome (admin only)
+// @Tags feeds
+// @Produce json
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.FeedIngestion,meta=PagMeta} "Ingestions"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /feeds/ingestions [get]
+func (h *BatchFeedHandler) ListIngestions(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	recs, total, err := h.feedService.ListIngestions(c.Request.Context(), tenantID, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, recs, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"satvos/internal/apierror"
+)
+
+var uuidType = reflect.TypeOf(uuid.UUID{})
+
+// Report validation errors under the JSON field names clients send rather than
+// the Go struct field names.
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return f.Name
+			}
+			return name
+		})
+	}
+}
+
+// bindJSON decodes and validates the JSON request body into req. On failure it
+// sends a 400 with code, a message summarising the problems and one errors
+// entry per invalid field, and returns false.
+func bindJSON(c *gin.Context, req interface{}, code string) bool {
+	return bindBody(c, req, code, false)
+}
+
+// bindOptionalJSON is bindJSON for endpoints where the body may be omitted.
+func bindOptionalJSON(c *gin.Context, req interface{}, code string) bool {
+	return bindBody(c, req, code, true)
+}
+
+func bindBody(c *gin.Context, req interface{}, code string, optional bool) bool {
+	err := c.ShouldBindBodyWith(req, binding.JSON)
+	if err == nil || (optional && errors.Is(err, io.EOF)) {
+		return true
+	}
+	if isBodyTooLarge(err) {
+		respondBodyTooLarge(c)
+		return false
+	}
+
+	body, _ := c.Get(gin.BodyBytesKey)
+	bodyBytes, _ := body.([]byte)
+	fields := fieldErrors(err, req, bodyBytes)
+	RespondFieldErrors(c, http.StatusBadRequest, code, summarizeBindError(err, fields), fields...)
+	return false
+}
+
+// fieldErrors converts a binding error into per-field details. Errors that
+// cannot be pinned to a field (malformed JSON, empty body) yield none.
+func fieldErrors(err error, req interface{}, body []byte) []apierror.FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]apierror.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, validationFieldError(fe))
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		if typeErr.Type == uuidType {
+			return []apierror.FieldError{invalidUUID(typeErr.Field)}
+		}
+		return []apierror.FieldError{{
+			Field:   typeErr.Field,
+			Code:    "invalid_type",
+			Message: fmt.Sprintf("%s must be %s", typeErr.Field, jsonTypeName(typeErr.Type)),
+		}}
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+
+	// encoding/json returns UnmarshalText errors (a malformed UUID string)
+	// without the field they came from, so find it in the raw body.
+	return uuidFieldErrors(req, body)
+}
+
+func validationFieldError(fe validator.FieldError) apierror.FieldError {
+	field := fe.Namespace()
+	if _, rest, ok := strings.Cut(field, "."); ok {
+		field = rest
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return apierror.FieldError{Field: field, Code: "required", Message: field + " is required"}
+	case "uuid", "uuid4":
+		return invalidUUID(field)
+	case "email":
+		return apierror.FieldError{Field: field, Code: "invalid_format", Message: field + " must be a valid email address"}
+	case "url", "http_url":
+		return apierror.FieldError{Field: field, Code: "invalid_format", Message: field + " must be a valid URL"}
+	case "oneof":
+		return apierror.FieldError{Field: field, Code: "invalid_value", Message: fmt.Sprintf("%s must be one of: %s", field, fe.Param())}
+	case "min", "max", "gt", "gte", "lt", "lte", "len":
+		return apierror.FieldError{Field: field, Code: "out_of_range", Message: field + " " + rangeMessage(fe)}
+	default:
+		return apierror.FieldError{Field: field, Code: "invalid", Message: field + " is invalid"}
+	}
+}
+
+// rangeMessage describes a violated size bound in terms of the field's kind:
+// characters for strings, items for lists, the value itself for numbers.
+func rangeMessage(fe validator.FieldError) string {
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fe.Tag() {
+	case "min", "gte":
+		return "must be at least " + fe.Param() + unit
+	case "max", "lte":
+		return "must be at most " + fe.Param() + unit
+	case "gt":
+		return "must be greater than " + fe.Param() + unit
+	case "lt":
+		return "must be less than " + fe.Param() + unit
+	default:
+		return "must be exactly " + fe.Param() + unit
+	}
+}
+
+// uuidFieldErrors reports every top-level UUID field of req whose value in body
+// is present but not a valid UUID string.
+func uuidFieldErrors(req interface{}, body []byte) []apierror.FieldError {
+	var raw map[string]json.RawMessage
+	if json.Unmarshal(body, &raw) != nil {
+		return nil
+	}
+	t := reflect.TypeOf(req)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []apierror.FieldError
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type != uuidType && f.Type != reflect.PointerTo(uuidType) {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		value, ok := raw[name]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			if _, err := uuid.Parse(s); err == nil {
+				continue
+			}
+		}
+		fields = append(fields, invalidUUID(name))
+	}
+	return fields
+}
+
+func invalidUUID(field string) apierror.FieldError {
+	return apierror.FieldError{Field: field, Code: "invalid_uuid", Message: field + " must be a valid UUID"}
+}
+
+// jsonTypeName names the JSON type a Go type decodes from.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+func summarizeBindError(err error, fields []apierror.FieldError) string {
+	if len(fields) > 0 {
+		messages := make([]string, len(fields))
+		for i, f := range fields {
+			messages[i] = f.Message
+		}
+		return strings.Join(messages, "; ")
+	}
+	if errors.Is(err, io.EOF) {
+		return "request body is required"
+	}
+	return "request body is not valid JSON"
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// BulkDeleteHandler handles bulk delete endpoints.
+type BulkDeleteHandler struct {
+	bulkDeleteService service.BulkDeleteService
+}
+
+// NewBulkDeleteHandler creates a new BulkDeleteHandler.
+func NewBulkDeleteHandler(bulkDeleteService service.BulkDeleteService) *BulkDeleteHandler {
+	return &BulkDeleteHandler{bulkDeleteService: bulkDeleteService}
+}
+
+// Run handles POST /api/v1/documents/bulk-delete
+// @Summary Delete every document matching a filter
+// @Description Delete the documents matching the filter (collection, seller/buyer GSTIN, invoice date range, review status, existing tag) in collections where the caller has owner permission; matches elsewhere are skipped. Runs as a dry run unless dry_run is false: the dry run lists what would be deleted and returns a confirmation_token, and the real run must pass that token back. The token stops matching once the filter selects different documents. With delete_files the source files are removed from storage too. At most 1000 documents per run; every run is recorded with its filter.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param request body BulkDeleteRequest true "Bulk delete"
+// @Success 200 {object} Response{data=domain.BulkDeletePreview} "Dry run: documents that would be deleted"
+// @Success 201 {object} Response{data=domain.BulkDelete} "Bulk delete record"
+// @Failure 400 {object} ErrorResponseBody "Invalid filter, too many matches, or missing confirmation token"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 409 {object} ErrorResponseBody "Confirmation token doesn't match the current matches"
+// @Security BearerAuth
+// @Router /documents/bulk-delete [post]
+func (h *BulkDeleteHandler) Run(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Filter            domain.BulkTagFilter `json:"filter"`
+		DryRun            *bool                `json:"dry_run"`
+		DeleteFiles       bool                 `json:"delete_files"`
+		ConfirmationToken string               `json:"confirmation_token"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	input := &service.BulkDeleteInput{
+		TenantID:          tenantID,
+		UserID:            userID,
+		Role:              role,
+		Filter:            req.Filter,
+		DeleteFiles:       req.DeleteFiles,
+		ConfirmationToken: req.ConfirmationToken,
+	}
+	if req.DryRun == nil || *req.DryRun {
+		preview, err := h.bulkDeleteService.Preview(c.Request.Context(), input)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+		RespondOK(c, preview)
+		return
+	}
+
+	rec, err := h.bulkDeleteService.Delete(c.Request.Context(), input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	RespondCreated(c, rec)
+}
+
+// List handles GET /api/v1/documents/bulk-delete
+// @Summary List bulk deletes
+// @Description List the caller's bulk deletes with the filter each ran with, newest first. Admins see every bulk delete in the tenant.
+// @Tags documents
+// @Produce json
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.BulkDelete,meta=PagMeta} "Bulk deletes"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /documents/bulk-delete [get]
+func (h *BulkDeleteHandler) List(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	recs, total, err := h.bulkDeleteService.ListRuns(c.Request.Context(), tenantID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, recs, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// BulkTagHandler handles bulk tag endpoints.
+type BulkTagHandler struct {
+	bulkTagService service.BulkTagService
+}
+
+// NewBulkTagHandler creates a new BulkTagHandler.
+func NewBulkTagHandler(bulkTagService service.BulkTagService) *BulkTagHandler {
+	return &BulkTagHandler{bulkTagService: bulkTagService}
+}
+
+// Start handles POST /api/v1/documents/bulk-tags
+// @Summary Add or remove a tag across matching documents
+// @Description Start a job that adds or removes one tag on every document matching the filter (collection, seller/buyer GSTIN, invoice date range, review status, existing tag). Documents in collections where the caller lacks editor permission are skipped. Runs asynchronously; poll the returned job for progress. At most 10000 documents per job.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param request body BulkTagRequest true "Bulk tag operation"
+// @Success 202 {object} Response{data=domain.BulkTagJob} "Bulk tag job accepted"
+// @Failure 400 {object} ErrorResponseBody "Invalid request"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Security BearerAuth
+// @Router /documents/bulk-tags [post]
+func (h *BulkTagHandler) Start(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Action domain.BulkTagAction `json:"action" binding:"required"`
+		Key    string               `json:"key" binding:"required"`
+		Value  string               `json:"value"`
+		Filter domain.BulkTagFilter `json:"filter"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	job, err := h.bulkTagService.Start(c.Request.Context(), &service.BulkTagInput{
+		TenantID: tenantID,
+		UserID:   userID,
+		Role:     role,
+		Action:   req.Action,
+		Key:      req.Key,
+		Value:    req.Value,
+		Filter:   req.Filter,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{Success: true, Data: job})
+}
+
+// List handles GET /api/v1/documents/bulk-tags
+// @Summary List bulk tag jobs
+// @Description List the caller's bulk tag jobs, newest first. Admins see every job in the tenant.
+// @Tags documents
+// @Produce json
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.BulkTagJob,meta=PagMeta} "Bulk tag jobs"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /documents/bulk-tags [get]
+func (h *BulkTagHandler) List(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	jobs, total, err := h.bulkTagService.ListJobs(c.Request.Context(), tenantID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, jobs, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// Get handles GET /api/v1/documents/bulk-tags/:jobId
+// @Summary Get a bulk tag job
+// @Description Get a bulk tag job's status and counters
+// @Tags documents
+// @Produce json
+// @Param jobId path string true "Bulk tag job ID (UUID)"
+// @Success 200 {object} Response{data=domain.BulkTagJob} "Bulk tag job"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Job not found"
+// @Security BearerAuth
+// @Router /documents/bulk-tags/{jobId} [get]
+func (h *BulkTagHandler) Get(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("jobId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid bulk tag job ID")
+		return
+	}
+
+	job, err := h.bulkTagService.GetJob(c.Request.Context(), tenantID, jobID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, job)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// CapabilityHandler tells clients what the current user may do.
+type CapabilityHandler struct {
+	capabilityService service.CapabilityService
+}
+
+// NewCapabilityHandler creates a new CapabilityHandler.
+func NewCapabilityHandler(capabilityService service.CapabilityService) *CapabilityHandler {
+	return &CapabilityHandler{capabilityService: capabilityService}
+}
+
+// Get handles GET /api/v1/me/capabilities
+// @Summary Get my capabilities
+// @Description What the current user may do: the API operations their role allows (leaving out upload routes until a free user verifies their email), each with the collection permission it also needs; the permission the role gives on every collection plus explicit grants above it; and the tenant's feature flags. Lets clients hide buttons without duplicating the authorization matrix
+// @Tags users
+// @Produce json
+// @Success 200 {object} Response{data=domain.Capabilities} "Capabilities"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /me/capabilities [get]
+func (h *CapabilityHandler) Get(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	caps, err := h.capabilityService.Get(c.Request.Context(), tenantID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, caps)
+}
@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// ClientTenantHandler handles a firm tenant's client sub-tenants and the staff
+// assigned to them.
+type ClientTenantHandler struct {
+	clientService service.ClientTenantService
+}
+
+// NewClientTenantHandler creates a new ClientTenantHandler.
+func NewClientTenantHandler(clientService service.ClientTenantService) *ClientTenantHandler {
+	return &ClientTenantHandler{clientService: clientService}
+}
+
+// Create handles POST /api/v1/clients
+// @Summary Create a client tenant
+// @Description Create a client sub-tenant of the caller's tenant (admin only). The client inherits the firm's storage region and the caller becomes its admin. Client tenants can't have clients.
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param request body service.CreateClientInput true "Client details"
+// @Success 201 {object} Response{data=domain.Tenant} "Client created"
+// @Failure 400 {object} ErrorResponseBody "Validation error, or the caller's tenant is itself a client"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 409 {object} ErrorResponseBody "Slug already exists"
+// @Security BearerAuth
+// @Router /clients [post]
+func (h *ClientTenantHandler) Create(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var input service.CreateClientInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	client, err := h.clientService.Create(c.Request.Context(), tenantID, &input, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondCreated(c, client)
+}
+
+// List handles GET /api/v1/clients
+// @Summary List client tenants
+// @Description The caller's tenant's clients, newest first (admin only).
+// @Tags clients
+// @Produce json
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.Tenant,meta=PagMeta} "Clients"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /clients [get]
+func (h *ClientTenantHandler) List(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	clients, total, err := h.clientService.List(c.Request.Context(), tenantID, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, clients, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// Dashboard handles GET /api/v1/clients/dashboard
+// @Summary Consolidated client dashboard
+// @Description Document stats of each client and their totals (admin only). The firm's own documents are not included.
+// @Tags clients
+// @Produce json
+// @Success 200 {object} Response{data=domain.ClientDashboard} "Dashboard"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /clients/dashboard [get]
+func (h *ClientTenantHandler) Dashboard(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	dashboard, err := h.clientService.Dashboard(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, dashboard)
+}
+
+// Get handles GET /api/v1/clients/:id
+// @Summary Get a client tenant
+// @Tags clients
+// @Produce json
+// @Param id path string true "Client tenant ID (UUID)"
+// @Success 200 {object} Response{data=domain.Tenant} "Client"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Not a client of this tenant"
+// @Security BearerAuth
+// @Router /clients/{id} [get]
+func (h *ClientTenantHandler) Get(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid client ID")
+		return
+	}
+
+	client, err := h.clientService.Get(c.Request.Context(), tenantID, clientID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, client)
+}
+
+// Update handles PUT /api/v1/clients/:id
+// @Summary Update a client tenant
+// @Description Rename a client or suspend it with is_active=false (admin only). A suspended client's users and staff are locked out.
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param id path string true "Client tenant ID (UUID)"
+// @Param request body service.UpdateClientInput true "Fields to update"
+// @Success 200 {object} Response{data=domain.Tenant} "Client updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or empty name"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Not a client of this tenant"
+// @Security BearerAuth
+// @Router /clients/{id} [put]
+func (h *ClientTenantHandler) Update(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid client ID")
+		return
+	}
+
+	var input service.UpdateClientInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	client, err := h.clientService.Update(c.Request.Context(), tenantID, clientID, &input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, client)
+}
+
+// ListStaff handles GET /api/v1/clients/:id/staff
+// @Summary List a client's staff
+// @Description Users from outside the client with access to it, with their role there (admin only).
+// @Tags clients
+// @Produce json
+// @Param id path string true "Client tenant ID (UUID)"
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.TenantMembership,meta=PagMeta} "Staff"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Not a client of this tenant"
+// @Security BearerAuth
+// @Router /clients/{id}/staff [get]
+func (h *ClientTenantHandler) ListStaff(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid client ID")
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	staff, total, err := h.clientService.ListStaff(c.Request.Context(), tenantID, clientID, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, staff, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// AssignStaff handles PUT /api/v1/clients/:id/staff
+// @Summary Assign staff to a client
+// @Description Give a user of the firm a role in the client, or change it (admin only). Set is_active=false to suspend their access.
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param id path string true "Client tenant ID (UUID)"
+// @Param request body service.AssignStaffInput true "User and role"
+// @Success 200 {object} Response{data=domain.TenantMembership} "Staff assigned"
+// @Failure 400 {object} ErrorResponseBody "Invalid role, or the user isn't a user of the firm"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Not a client of this tenant"
+// @Security BearerAuth
+// @Router /clients/{id}/staff [put]
+func (h *ClientTenantHandler) AssignStaff(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid client ID")
+		return
+	}
+
+	var input service.AssignStaffInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	membership, err := h.clientService.AssignStaff(c.Request.Context(), tenantID, clientID, &input, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, membership)
+}
+
+// RemoveStaff handles DELETE /api/v1/clients/:id/staff/:userId
+// @Summary Remove staff from a client
+// @Description Remove a user's access to the client (admin only).
+// @Tags clients
+// @Produce json
+// @Param id path string true "Client tenant ID (UUID)"
+// @Param userId path string true "User ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Staff removed"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Client or membership not found"
+// @Security BearerAuth
+// @Router /clients/{id}/staff/{userId} [delete]
+func (h *ClientTenantHandler) RemoveStaff(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	clientID, staffID, ok := parseClientStaffIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.clientService.RemoveStaff(c.Request.Context(), tenantID, clientID, staffID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "staff removed"})
+}
+
+// MoveStaff handles POST /api/v1/clients/:id/staff/:userId/move
+// @Summary Move staff to another client
+// @Description Give the user access to another client of the firm and remove it from this one (admin only). The role defaults to their role in this client.
+// @Tags clients
+// @Accept json
+// @Produce json
+// @Param id path string true "Client tenant ID (UUID) the user leaves"
+// @Param userId path string true "User ID (UUID)"
+// @Param request body service.MoveStaffInput true "Target client and optional role"
+// @Success 200 {object} Response{data=domain.TenantMembership} "Membership in the new client"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or role, or same client"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Client or membership not found"
+// @Security BearerAuth
+// @Router /clients/{id}/staff/{userId}/move [post]
+func (h *ClientTenantHandler) MoveStaff(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	clientID, staffID, ok := parseClientStaffIDs(c)
+	if !ok {
+		return
+	}
+
+	var input service.MoveStaffInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	membership, err := h.clientService.MoveStaff(c.Request.Context(), tenantID, clientID, staffID, &input, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, membership)
+}
+
+func parseClientStaffIDs(c *gin.Context) (clientID, userID uuid.UUID, ok bool) {
+	clientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid client ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	userID, err = uuid.Parse(c.Param("userId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid user ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return clientID, userID, true
+}
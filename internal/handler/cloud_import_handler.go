@@ -0,0 +1,453 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// CloudImportHandler handles Google Drive / Dropbox integration endpoints.
+type CloudImportHandler struct {
+	cloudService service.CloudImportService
+}
+
+// NewCloudImportHandler creates a new CloudImportHandler.
+func NewCloudImportHandler(cloudService service.CloudImportService) *CloudImportHandler {
+	return &CloudImportHandler{cloudService: cloudService}
+}
+
+// parseSyncParams extracts the collection and sync IDs from the path.
+// Returns false if invalid (error response already written).
+func parseSyncParams(c *gin.Context) (collectionID, syncID uuid.UUID, ok bool) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	syncID, err = uuid.Parse(c.Param("syncId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid sync ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return collectionID, syncID, true
+}
+
+// Authorize handles GET /api/v1/integrations/:provider/authorize
+// @Summary Start connecting a cloud storage account
+// @Description Returns the provider consent URL. After consent the provider redirects to the frontend with code and state, which the frontend posts to the connect endpoint.
+// @Tags integrations
+// @Produce json
+// @Param provider path string true "Provider" Enums(google_drive, dropbox)
+// @Success 200 {object} Response{data=CloudAuthorizeResponse} "Consent URL"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Provider not configured"
+// @Security BearerAuth
+// @Router /integrations/{provider}/authorize [get]
+func (h *CloudImportHandler) Authorize(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	url, err := h.cloudService.AuthorizeURL(c.Request.Context(), tenantID, userID, domain.CloudProvider(c.Param("provider")))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, CloudAuthorizeResponse{URL: url})
+}
+
+// Connect handles POST /api/v1/integrations/:provider/connect
+// @Summary Complete connecting a cloud storage account
+// @Description Exchanges the OAuth code for tokens and stores the connection. Reconnecting the same account replaces its tokens.
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider" Enums(google_drive, dropbox)
+// @Param request body CloudConnectRequest true "OAuth code and state"
+// @Success 201 {object} Response{data=domain.CloudConnection} "Connection created"
+// @Failure 400 {object} ErrorResponseBody "Invalid request or state"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Provider not configured"
+// @Failure 502 {object} ErrorResponseBody "Provider rejected the code"
+// @Security BearerAuth
+// @Router /integrations/{provider}/connect [post]
+func (h *CloudImportHandler) Connect(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var req CloudConnectRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	conn, err := h.cloudService.Connect(c.Request.Context(), tenantID, userID,
+		domain.CloudProvider(c.Param("provider")), req.Code, req.State)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondCreated(c, conn)
+}
+
+// ListConnections handles GET /api/v1/integrations/connections
+// @Summary List cloud storage connections
+// @Description List the current user's connected cloud storage accounts
+// @Tags integrations
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.CloudConnection} "Connections"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /integrations/connections [get]
+func (h *CloudImportHandler) ListConnections(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	conns, err := h.cloudService.ListConnections(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, conns)
+}
+
+// DeleteConnection handles DELETE /api/v1/integrations/connections/:id
+// @Summary Disconnect a cloud storage account
+// @Description Delete a connection and every folder sync that uses it. Already imported documents are kept.
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Connection ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Connection deleted"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Connection not found"
+// @Security BearerAuth
+// @Router /integrations/connections/{id} [delete]
+func (h *CloudImportHandler) DeleteConnection(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid connection ID")
+		return
+	}
+
+	if err := h.cloudService.DeleteConnection(c.Request.Context(), tenantID, userID, connectionID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "connection deleted"})
+}
+
+// ListFolder handles GET /api/v1/integrations/connections/:id/folders
+// @Summary Browse a connected cloud account
+// @Description List the files and folders directly inside a folder, for picking the folder to sync
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Connection ID (UUID)"
+// @Param folder_id query string false "Folder ID (omit for the root)"
+// @Success 200 {object} Response{data=[]port.CloudFile} "Folder contents"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Connection or folder not found"
+// @Failure 502 {object} ErrorResponseBody "Provider rejected the stored authorization"
+// @Security BearerAuth
+// @Router /integrations/connections/{id}/folders [get]
+func (h *CloudImportHandler) ListFolder(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid connection ID")
+		return
+	}
+
+	files, err := h.cloudService.ListFolder(c.Request.Context(), tenantID, userID, connectionID, c.Query("folder_id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, files)
+}
+
+// CreateSync handles POST /api/v1/collections/:id/cloud-syncs
+// @Summary Sync a cloud folder into a collection
+// @Description Link a folder of one of the user's cloud connections to the collection. The initial import runs asynchronously; with poll_enabled, new files are imported periodically. Files are deduplicated by content hash within the collection.
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body CreateCloudSyncRequest true "Folder and document options"
+// @Success 202 {object} Response{data=domain.CloudSync} "Sync created, initial import started"
+// @Failure 400 {object} ErrorResponseBody "Invalid request"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Connection not found"
+// @Failure 409 {object} ErrorResponseBody "Folder already synced into this collection"
+// @Security BearerAuth
+// @Router /collections/{id}/cloud-syncs [post]
+func (h *CloudImportHandler) CreateSync(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req CreateCloudSyncRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+	if !parseImportOptions(c, req.DocumentType, &req.ParseMode) {
+		return
+	}
+
+	cs, err := h.cloudService.CreateSync(c.Request.Context(), &service.CreateCloudSyncInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		ConnectionID: req.ConnectionID,
+		FolderID:     req.FolderID,
+		FolderName:   req.FolderName,
+		DocumentType: req.DocumentType,
+		ParseMode:    req.ParseMode,
+		PollEnabled:  req.PollEnabled,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{Success: true, Data: cs})
+}
+
+// ListSyncs handles GET /api/v1/collections/:id/cloud-syncs
+// @Summary List cloud folder syncs
+// @Description List the cloud folders synced into a collection
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 200 {object} Response{data=[]domain.CloudSync} "Syncs"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Security BearerAuth
+// @Router /collections/{id}/cloud-syncs [get]
+func (h *CloudImportHandler) ListSyncs(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	syncs, err := h.cloudService.ListSyncs(c.Request.Context(), tenantID, collectionID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, syncs)
+}
+
+// GetSync handles GET /api/v1/collections/:id/cloud-syncs/:syncId
+// @Summary Get a cloud folder sync
+// @Description Get a sync's settings, last run time and last error
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param syncId path string true "Sync ID (UUID)"
+// @Success 200 {object} Response{data=domain.CloudSync} "Sync"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Sync not found"
+// @Security BearerAuth
+// @Router /collections/{id}/cloud-syncs/{syncId} [get]
+func (h *CloudImportHandler) GetSync(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+	collectionID, syncID, ok := parseSyncParams(c)
+	if !ok {
+		return
+	}
+
+	cs, err := h.cloudService.GetSync(c.Request.Context(), tenantID, collectionID, syncID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, cs)
+}
+
+// UpdateSync handles PUT /api/v1/collections/:id/cloud-syncs/:syncId
+// @Summary Toggle polling on a cloud folder sync
+// @Description Enable or disable periodic import of new files
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param syncId path string true "Sync ID (UUID)"
+// @Param request body UpdateCloudSyncRequest true "Polling setting"
+// @Success 200 {object} Response{data=domain.CloudSync} "Sync updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid request"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Sync not found"
+// @Security BearerAuth
+// @Router /collections/{id}/cloud-syncs/{syncId} [put]
+func (h *CloudImportHandler) UpdateSync(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+	collectionID, syncID, ok := parseSyncParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateCloudSyncRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	cs, err := h.cloudService.SetPolling(c.Request.Context(), tenantID, collectionID, syncID, userID, role, *req.PollEnabled)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, cs)
+}
+
+// DeleteSync handles DELETE /api/v1/collections/:id/cloud-syncs/:syncId
+// @Summary Delete a cloud folder sync
+// @Description Stop syncing a folder. Already imported documents are kept.
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param syncId path string true "Sync ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Sync deleted"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Sync not found"
+// @Security BearerAuth
+// @Router /collections/{id}/cloud-syncs/{syncId} [delete]
+func (h *CloudImportHandler) DeleteSync(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+	collectionID, syncID, ok := parseSyncParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.cloudService.DeleteSync(c.Request.Context(), tenantID, collectionID, syncID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "sync deleted"})
+}
+
+// RunSync handles POST /api/v1/collections/:id/cloud-syncs/:syncId/run
+// @Summary Run a cloud folder sync now
+// @Description Import new files from the folder immediately, in the background
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param syncId path string true "Sync ID (UUID)"
+// @Success 202 {object} Response{data=MessageResponse} "Sync started"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Sync not found"
+// @Security BearerAuth
+// @Router /collections/{id}/cloud-syncs/{syncId}/run [post]
+func (h *CloudImportHandler) RunSync(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+	collectionID, syncID, ok := parseSyncParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.cloudService.TriggerSync(c.Request.Context(), tenantID, collectionID, syncID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{Success: true, Data: gin.H{"message": "sync started"}})
+}
+
+// ListSyncFiles handles GET /api/v1/collections/:id/cloud-syncs/:syncId/files
+// @Summary List per-file sync results
+// @Description List the import outcome (imported, duplicate, skipped, failed) for every file the sync has seen, newest first
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param syncId path string true "Sync ID (UUID)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.CloudSyncFile,meta=PagMeta} "Sync files"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Sync not found"
+// @Security BearerAuth
+// @Router /collections/{id}/cloud-syncs/{syncId}/files [get]
+func (h *CloudImportHandler) ListSyncFiles(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+	collectionID, syncID, ok := parseSyncParams(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	files, total, err := h.cloudService.ListSyncFiles(c.Request.Context(), tenantID, collectionID, syncID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, files, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
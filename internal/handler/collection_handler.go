@@ -1,15 +1,22 @@
 package handler
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
+	"io"
 	"log"
 	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"satvos/internal/apierror"
 	"satvos/internal/csvexport"
 	"satvos/internal/domain"
 	"satvos/internal/service"
@@ -19,11 +26,25 @@ import (
 type CollectionHandler struct {
 	collectionService service.CollectionService
 	documentService   service.DocumentService
+	exportService     service.ExportAuditService
+	computedFields    service.ComputedFieldService
 }
 
-// NewCollectionHandler creates a new CollectionHandler.
-func NewCollectionHandler(collectionService service.CollectionService, documentService service.DocumentService) *CollectionHandler {
-	return &CollectionHandler{collectionService: collectionService, documentService: documentService}
+// NewCollectionHandler creates a new CollectionHandler. exportService may be nil,
+// in which case exports are not recorded; computedFields may be nil, in which
+// case CSV exports ignore ?extensions=true.
+func NewCollectionHandler(
+	collectionService service.CollectionService,
+	documentService service.DocumentService,
+	exportService service.ExportAuditService,
+	computedFields service.ComputedFieldService,
+) *CollectionHandler {
+	return &CollectionHandler{
+		collectionService: collectionService,
+		documentService:   documentService,
+		exportService:     exportService,
+		computedFields:    computedFields,
+	}
 }
 
 // Create handles POST /api/v1/collections
@@ -49,8 +70,7 @@ func (h *CollectionHandler) Create(c *gin.Context) {
 		Name        string `json:"name" binding:"required"`
 		Description string `json:"description"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "name is required")
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
 		return
 	}
 
@@ -202,8 +222,7 @@ func (h *CollectionHandler) Update(c *gin.Context) {
 		Name        string `json:"name" binding:"required"`
 		Description string `json:"description"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "name is required")
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
 		return
 	}
 
@@ -223,6 +242,435 @@ func (h *CollectionHandler) Update(c *gin.Context) {
 	RespondOK(c, collection)
 }
 
+// SetReviewChecklist handles PUT /api/v1/collections/:id/review-checklist
+// @Summary Set the collection's review checklist
+// @Description Replace the yes/no items reviewers must check before approving documents in this collection (requires owner permission). Items without an id get one derived from the label. An empty list removes the checklist.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body SetReviewChecklistRequest true "Checklist items"
+// @Success 200 {object} Response{data=domain.Collection} "Checklist updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid checklist"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/review-checklist [put]
+func (h *CollectionHandler) SetReviewChecklist(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req struct {
+		Items []domain.ReviewChecklistItem `json:"items"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	collection, err := h.collectionService.SetReviewChecklist(c.Request.Context(), &service.SetReviewChecklistInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		Items:        req.Items,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, collection)
+}
+
+// SetApprovalPolicy handles PUT /api/v1/collections/:id/approval-policy
+// @Summary Set the collection's maker-checker threshold
+// @Description Set the invoice total at or above which an approval needs confirmation by a manager or collection owner other than the approver (requires owner permission). A null threshold turns maker-checker off; 0 requires it for every approval.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body SetApprovalPolicyRequest true "Checker threshold"
+// @Success 200 {object} Response{data=domain.Collection} "Approval policy updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid threshold"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/approval-policy [put]
+func (h *CollectionHandler) SetApprovalPolicy(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req struct {
+		CheckerThreshold *float64 `json:"checker_threshold"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+	if req.CheckerThreshold != nil && *req.CheckerThreshold < 0 {
+		RespondFieldErrors(c, http.StatusBadRequest, "INVALID_REQUEST", "checker_threshold must be a non-negative amount or null",
+			apierror.FieldError{Field: "checker_threshold", Code: "out_of_range", Message: "checker_threshold must be at least 0"})
+		return
+	}
+
+	collection, err := h.collectionService.SetCheckerThreshold(c.Request.Context(), &service.SetCheckerThresholdInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		Threshold:    req.CheckerThreshold,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, collection)
+}
+
+// SetEscalationPolicy handles PUT /api/v1/collections/:id/escalation-policy
+// @Summary Set the collection's escalation policy
+// @Description Escalate documents still pending review after_days after parsing (owner only). "flag" marks them escalated; "reassign" also assigns them to the collection owner. Escalations are audited, posted to review_escalated notification channels and listed at GET /documents/escalations. Send after_days null to turn escalation off.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body SetEscalationPolicyRequest true "Escalation policy"
+// @Success 200 {object} Response{data=domain.Collection} "Escalation policy updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid policy"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/escalation-policy [put]
+func (h *CollectionHandler) SetEscalationPolicy(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req struct {
+		AfterDays *int                    `json:"after_days"`
+		Action    domain.EscalationAction `json:"action"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	collection, err := h.collectionService.SetEscalationPolicy(c.Request.Context(), &service.SetEscalationPolicyInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		AfterDays:    req.AfterDays,
+		Action:       req.Action,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, collection)
+}
+
+// SetKPITargets handles PUT /api/v1/collections/:id/kpi-targets
+// @Summary Set the collection's KPI targets
+// @Description Replace the collection's KPI targets (owner only), e.g. 100% of documents reviewed by a date. metric is parsed, reviewed (approved or rejected) or approved; target_pct defaults to 100; due_date is YYYY-MM-DD and the target holds until the end of that day in the tenant's time zone. Send an empty list to remove the targets. Progress is at GET /collections/{id}/progress
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body SetKPITargetsRequest true "KPI targets"
+// @Success 200 {object} Response{data=domain.Collection} "KPI targets updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid targets"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/kpi-targets [put]
+func (h *CollectionHandler) SetKPITargets(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req struct {
+		Targets []domain.KPITarget `json:"targets" binding:"required"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	collection, err := h.collectionService.SetKPITargets(c.Request.Context(), &service.SetKPITargetsInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		Targets:      req.Targets,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, collection)
+}
+
+// SetQASampling handles PUT /api/v1/collections/:id/qa-sampling
+// @Summary Set the collection's QA sampling rate
+// @Description Sample sample_percent of the collection's approvals for a blind second review (owner only). Samples are listed at GET /qa-reviews/queue for anyone but the approving reviewer (and maker); disagreements feed GET /qa-reviews/scores. Send sample_percent null to turn QA sampling off.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body SetQASamplingRequest true "QA sampling rate"
+// @Success 200 {object} Response{data=domain.Collection} "QA sampling updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid sampling rate"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/qa-sampling [put]
+func (h *CollectionHandler) SetQASampling(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req struct {
+		SamplePercent *int `json:"sample_percent"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	collection, err := h.collectionService.SetQASampling(c.Request.Context(), &service.SetQASamplingInput{
+		TenantID:      tenantID,
+		CollectionID:  collectionID,
+		UserID:        userID,
+		Role:          role,
+		SamplePercent: req.SamplePercent,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, collection)
+}
+
+// SetDefaultParseMode handles PUT /api/v1/collections/:id/parse-mode
+// @Summary Set the collection's default parse mode
+// @Description Parse mode for documents created in the collection without one (owner only). A parse_mode in the create request wins, then the collection's default, then the tenant's default_parse_mode, then single. Send parse_mode "" to fall back to the tenant's default. A dual default is parsed single while the tenant's dual_parse flag is off
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body SetDefaultParseModeRequest true "Default parse mode"
+// @Success 200 {object} Response{data=domain.Collection} "Default parse mode updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid parse mode"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/parse-mode [put]
+func (h *CollectionHandler) SetDefaultParseMode(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req struct {
+		ParseMode domain.ParseMode `json:"parse_mode"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	collection, err := h.collectionService.SetDefaultParseMode(c.Request.Context(), &service.SetDefaultParseModeInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		ParseMode:    req.ParseMode,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, collection)
+}
+
+// SetNamingTemplate handles PUT /api/v1/collections/:id/naming-template
+// @Summary Set the collection's naming template
+// @Description Template that renames documents after their first successful parse (owner only), e.g. "{seller_name}-{invoice_number}-{invoice_date}". Placeholders: seller_name, seller_gstin, buyer_name, buyer_gstin, invoice_number, invoice_date, due_date, invoice_type, place_of_supply, currency, total_amount, document_type. The file extension is kept, a name already used in the collection gets " (2)", " (3)", ..., and the previous name is kept as original_name. A document missing a placeholder's field keeps its name. Send "" to stop renaming; documents already renamed keep their names
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body SetNamingTemplateRequest true "Naming template"
+// @Success 200 {object} Response{data=domain.Collection} "Naming template updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid naming template"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/naming-template [put]
+func (h *CollectionHandler) SetNamingTemplate(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req SetNamingTemplateRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	collection, err := h.collectionService.SetNamingTemplate(c.Request.Context(), &service.SetNamingTemplateInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		Template:     req.NamingTemplate,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, collection)
+}
+
+// SetFileDownloadPerm handles PUT /api/v1/collections/:id/file-download
+// @Summary Restrict downloads of the collection's original files
+// @Description Collection permission needed to download the original files of the collection's documents (owner only), separately from viewing their parsed data, e.g. "owner" so that consultants with viewer access can check figures without taking the invoice PDFs. Applies to GET /files/:id (download_url), GET /files/:id/download and GET /files/:id/pages/:n/image; a file in several collections needs the permission of each. Send "" to let every viewer download them
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body SetFileDownloadPermRequest true "Download permission"
+// @Success 200 {object} Response{data=domain.Collection} "Download permission updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid permission"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/file-download [put]
+func (h *CollectionHandler) SetFileDownloadPerm(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req SetFileDownloadPermRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	collection, err := h.collectionService.SetFileDownloadPerm(c.Request.Context(), &service.SetFileDownloadPermInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		Permission:   domain.CollectionPermission(req.FileDownloadPerm),
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, collection)
+}
+
+// GetProgress handles GET /api/v1/collections/:id/progress
+// @Summary Collection progress against KPI targets
+// @Description Percent of the collection's documents parsed, reviewed and approved, and for each KPI target the current percentage, documents remaining, velocity (per day over the last 7 days), projected completion at that velocity and a status: met, on_track, at_risk (won't make the due date at the current velocity) or missed. at_risk is true when any target is at risk or missed. Requires viewer permission
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 200 {object} Response{data=service.CollectionProgress} "Collection progress"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/progress [get]
+func (h *CollectionHandler) GetProgress(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	progress, err := h.collectionService.GetProgress(c.Request.Context(), tenantID, collectionID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, progress)
+}
+
 // Delete handles DELETE /api/v1/collections/:id
 // @Summary Delete a collection
 // @Description Delete a collection (requires owner permission or admin role). Files are preserved.
@@ -286,6 +734,10 @@ func (h *CollectionHandler) BatchUploadFiles(c *gin.Context) {
 
 	form, err := c.MultipartForm()
 	if err != nil {
+		if isBodyTooLarge(err) {
+			respondBodyTooLarge(c)
+			return
+		}
 		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "multipart form is required")
 		return
 	}
@@ -410,8 +862,7 @@ func (h *CollectionHandler) SetPermission(c *gin.Context) {
 		UserID     uuid.UUID                   `json:"user_id" binding:"required"`
 		Permission domain.CollectionPermission `json:"permission" binding:"required"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "user_id and permission are required")
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
 		return
 	}
 
@@ -468,6 +919,43 @@ func (h *CollectionHandler) ListPermissions(c *gin.Context) {
 	RespondPaginated(c, perms, PagMeta{Total: total, Offset: offset, Limit: limit})
 }
 
+// ListActivity handles GET /api/v1/collections/:id/activity
+// @Summary Collection activity feed
+// @Description Recent events in a collection, newest first: file uploads (file.uploaded), document creation, parse completed/failed, reviews, file replacements and permission changes (collection.permission_set, collection.permission_removed). Requires viewer permission
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.CollectionActivityEvent,meta=PagMeta} "Activity feed"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Security BearerAuth
+// @Router /collections/{id}/activity [get]
+func (h *CollectionHandler) ListActivity(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	activity, total, err := h.collectionService.ListActivity(c.Request.Context(), tenantID, collectionID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, activity, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
 // RemovePermission handles DELETE /api/v1/collections/:id/permissions/:userId
 // @Summary Remove user permission from a collection
 // @Description Remove a user's permission from a collection (requires owner permission, cannot remove self)
@@ -510,10 +998,11 @@ func (h *CollectionHandler) RemovePermission(c *gin.Context) {
 
 // ExportCSV handles GET /api/v1/collections/:id/export/csv
 // @Summary Export collection documents as CSV
-// @Description Download all documents in a collection as a CSV file for GST reconciliation
+// @Description Download all documents in a collection as a CSV file for GST reconciliation. With extensions=true, a column per tenant computed field follows the standard columns. A completed download is recorded as an export artifact with its SHA-256 checksum
 // @Tags collections
 // @Produce text/csv
 // @Param id path string true "Collection ID (UUID)"
+// @Param extensions query bool false "Append a column per tenant computed field"
 // @Success 200 {file} file "CSV file"
 // @Failure 400 {object} ErrorResponseBody "Invalid ID"
 // @Failure 401 {object} ErrorResponseBody "Unauthorized"
@@ -540,18 +1029,35 @@ func (h *CollectionHandler) ExportCSV(c *gin.Context) {
 		return
 	}
 
+	// Computed field columns are fixed before streaming starts
+	var extensions []string
+	withExtensions := h.computedFields != nil && c.Query("extensions") == "true"
+	if withExtensions {
+		fields, err := h.computedFields.List(c.Request.Context(), tenantID)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+		for i := range fields {
+			extensions = append(extensions, fields[i].Name)
+		}
+	}
+
 	// Set response headers before streaming
 	filename := csvexport.BuildFilename(collection.Name)
 	c.Header("Content-Type", "text/csv; charset=utf-8")
 	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%q"`, filename))
 
+	rec := newExportRecorder(c.Writer)
+
 	// Write UTF-8 BOM for Excel compatibility
-	if _, err := c.Writer.Write(csvexport.BOM); err != nil {
+	if _, err := rec.Write(csvexport.BOM); err != nil {
 		log.Printf("ERROR: csv export BOM write failed: %v", err)
 		return
 	}
 
-	w := csvexport.NewWriter(c.Writer)
+	w := csvexport.NewWriter(rec)
+	w.SetExtensionColumns(extensions)
 	if err := w.WriteHeader(); err != nil {
 		log.Printf("ERROR: csv export header write failed: %v", err)
 		return
@@ -568,11 +1074,31 @@ func (h *CollectionHandler) ExportCSV(c *gin.Context) {
 			log.Printf("ERROR: csv export document fetch failed at offset %d: %v", offset, err)
 			return
 		}
+		if withExtensions {
+			if err := h.computedFields.Resolve(c.Request.Context(), tenantID, docs); err != nil {
+				log.Printf("ERROR: csv export computed fields failed at offset %d: %v", offset, err)
+				return
+			}
+		}
 
 		if err := w.WriteDocuments(docs); err != nil {
 			log.Printf("ERROR: csv export write failed at offset %d: %v", offset, err)
 			return
 		}
+		rec.rows += len(docs)
+
+		// Push each batch to the client as a chunk so memory stays at one batch. A
+		// stalled or vanished client then stops the export instead of buffering it.
+		w.Flush()
+		if err := w.Error(); err != nil {
+			log.Printf("ERROR: csv export flush failed at offset %d: %v", offset, err)
+			return
+		}
+		c.Writer.Flush()
+		if err := c.Request.Context().Err(); err != nil {
+			log.Printf("csv export aborted at offset %d: %v", offset, err)
+			return
+		}
 
 		offset += batchSize
 		if offset >= total {
@@ -583,6 +1109,233 @@ func (h *CollectionHandler) ExportCSV(c *gin.Context) {
 	w.Flush()
 	if err := w.Error(); err != nil {
 		log.Printf("ERROR: csv export flush failed: %v", err)
+		return
+	}
+	h.recordExport(c, rec, tenantID, collectionID, userID, domain.ExportKindDocumentsCSV, csvexport.FormatCSV)
+}
+
+// ExportValidationFailures handles GET /api/v1/collections/:id/export/validation-failures
+// @Summary Export validation failures
+// @Description Download every failing validation result across the collection's documents (document, rule, severity, expected vs actual) as a fix list. Rows are grouped by document, errors before warnings. A completed download is recorded as an export artifact with its SHA-256 checksum
+// @Tags collections
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path string true "Collection ID (UUID)"
+// @Param format query string false "csv or xlsx" default(csv)
+// @Success 200 {file} file "CSV or XLSX file"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or format"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/export/validation-failures [get]
+func (h *CollectionHandler) ExportValidationFailures(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	format := c.DefaultQuery("format", csvexport.FormatCSV)
+	if format != csvexport.FormatCSV && format != csvexport.FormatXLSX {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "format must be csv or xlsx")
+		return
+	}
+
+	// Permission check + get collection name
+	collection, err := h.collectionService.GetByID(c.Request.Context(), tenantID, collectionID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	rec := newExportRecorder(c.Writer)
+	w, err := csvexport.NewFailureWriter(rec, format)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	filename := csvexport.BuildFailuresFilename(collection.Name, format)
+	c.Header("Content-Type", csvexport.FailureContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := w.WriteHeader(); err != nil {
+		log.Printf("ERROR: validation failures export header write failed: %v", err)
+		return
+	}
+
+	const batchSize = 500
+	for offset := 0; ; offset += batchSize {
+		failures, err := h.documentService.ListValidationFailures(
+			c.Request.Context(), tenantID, collectionID, userID, role, offset, batchSize,
+		)
+		if err != nil {
+			log.Printf("ERROR: validation failures export fetch failed at offset %d: %v", offset, err)
+			return
+		}
+		if err := w.WriteFailures(failures); err != nil {
+			log.Printf("ERROR: validation failures export write failed at offset %d: %v", offset, err)
+			return
+		}
+		rec.rows += len(failures)
+		// CSV goes out a batch at a time; XLSX is assembled and written on Close.
+		if err := w.Flush(); err != nil {
+			log.Printf("ERROR: validation failures export flush failed at offset %d: %v", offset, err)
+			return
+		}
+		c.Writer.Flush()
+		if err := c.Request.Context().Err(); err != nil {
+			log.Printf("validation failures export aborted at offset %d: %v", offset, err)
+			return
+		}
+		if len(failures) < batchSize {
+			break
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		log.Printf("ERROR: validation failures export close failed: %v", err)
+		return
+	}
+	h.recordExport(c, rec, tenantID, collectionID, userID, domain.ExportKindValidationFailures, format)
+}
+
+// ListExports handles GET /api/v1/collections/:id/exports
+// @Summary List export artifacts
+// @Description List the distinct files the collection's exports produced, newest first, with who first generated each, its filters, row count, SHA-256 checksum and download count. Pass checksum to find the export a filed file came from
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param checksum query string false "SHA-256 of an exported file (hex)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.ExportArtifact,meta=PagMeta} "Export artifacts"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/exports [get]
+func (h *CollectionHandler) ListExports(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	artifacts, total, err := h.exportService.ListByCollection(c.Request.Context(), tenantID, collectionID, userID, role,
+		strings.ToLower(c.Query("checksum")), offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, artifacts, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// ListExportDownloads handles GET /api/v1/collections/:id/exports/:exportId/downloads
+// @Summary List export downloads
+// @Description List who downloaded an export artifact and when, newest first
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param exportId path string true "Export artifact ID (UUID)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.ExportDownload,meta=PagMeta} "Downloads"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection or export not found"
+// @Security BearerAuth
+// @Router /collections/{id}/exports/{exportId}/downloads [get]
+func (h *CollectionHandler) ListExportDownloads(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+	artifactID, err := uuid.Parse(c.Param("exportId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid export ID")
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	downloads, total, err := h.exportService.ListDownloads(c.Request.Context(), tenantID, collectionID, artifactID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, downloads, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// exportRecorder passes an export through to the client while hashing and
+// counting its bytes, so the finished file can be recorded as an artifact.
+type exportRecorder struct {
+	w    io.Writer
+	hash hash.Hash
+	size int64
+	rows int
+}
+
+func newExportRecorder(w io.Writer) *exportRecorder {
+	return &exportRecorder{w: w, hash: sha256.New()}
+}
+
+func (r *exportRecorder) Write(p []byte) (int, error) {
+	n, err := r.w.Write(p)
+	r.hash.Write(p[:n])
+	r.size += int64(n)
+	return n, err
+}
+
+// recordExport records a completed export. The file has already been sent, so
+// a failure is only logged; the request's query parameters other than format
+// are kept as the export's filters.
+func (h *CollectionHandler) recordExport(c *gin.Context, rec *exportRecorder, tenantID, collectionID, userID uuid.UUID, kind domain.ExportKind, format string) {
+	if h.exportService == nil {
+		return
+	}
+	filters := map[string]string{}
+	for key, values := range c.Request.URL.Query() {
+		if key != "format" && len(values) > 0 {
+			filters[key] = values[0]
+		}
+	}
+	_, err := h.exportService.Record(context.WithoutCancel(c.Request.Context()), &service.RecordExportInput{
+		TenantID:       tenantID,
+		CollectionID:   collectionID,
+		UserID:         userID,
+		Kind:           kind,
+		Format:         format,
+		Filters:        filters,
+		RowCount:       rec.rows,
+		SizeBytes:      rec.size,
+		ChecksumSHA256: hex.EncodeToString(rec.hash.Sum(nil)),
+	})
+	if err != nil {
+		log.Printf("ERROR: recording %s export of collection %s failed: %v", kind, collectionID, err)
 	}
 }
 
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// ComputedFieldHandler handles the tenant's computed fields.
+type ComputedFieldHandler struct {
+	computedFieldService service.ComputedFieldService
+}
+
+// NewComputedFieldHandler creates a new ComputedFieldHandler.
+func NewComputedFieldHandler(computedFieldService service.ComputedFieldService) *ComputedFieldHandler {
+	return &ComputedFieldHandler{computedFieldService: computedFieldService}
+}
+
+// List handles GET /api/v1/computed-fields
+// @Summary List computed fields
+// @Description The tenant's computed fields by name. Documents include their values under extensions when requested with ?extensions=true
+// @Tags documents
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.ComputedField} "Computed fields"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /computed-fields [get]
+func (h *ComputedFieldHandler) List(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	fields, err := h.computedFieldService.List(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, fields)
+}
+
+// Set handles PUT /api/v1/computed-fields/:name
+// @Summary Set a computed field
+// @Description Add or replace a computed field: an expression over the document's structured data (see GET /schemas/{documentType} for the field paths), evaluated server-side on read. Supports arithmetic, comparisons, && || !, if, coalesce, concat, round, abs, upper, lower, trim, and sum/count/avg/min/max over array paths such as line_items[].total (admin only)
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param name path string true "Field name (lowercase letters, digits, underscores; starts with a letter)"
+// @Param request body service.SetComputedFieldInput true "Field definition"
+// @Success 200 {object} Response{data=domain.ComputedField} "Computed field saved"
+// @Failure 400 {object} ErrorResponseBody "Invalid name, document type or expression"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /computed-fields/{name} [put]
+func (h *ComputedFieldHandler) Set(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var input service.SetComputedFieldInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	field, err := h.computedFieldService.Set(c.Request.Context(), tenantID, c.Param("name"), &input, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, field)
+}
+
+// Delete handles DELETE /api/v1/computed-fields/:name
+// @Summary Delete a computed field
+// @Description Remove a computed field; documents no longer include it under extensions (admin only)
+// @Tags documents
+// @Produce json
+// @Param name path string true "Field name"
+// @Success 200 {object} Response{data=MessageResponse} "Computed field deleted"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Computed field not found"
+// @Security BearerAuth
+// @Router /computed-fields/{name} [delete]
+func (h *ComputedFieldHandler) Delete(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.computedFieldService.Delete(c.Request.Context(), tenantID, c.Param("name")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "computed field deleted"})
+}
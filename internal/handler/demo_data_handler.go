@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// DemoDataHandler handles demo data endpoints.
+type DemoDataHandler struct {
+	demoService service.DemoDataService
+}
+
+// NewDemoDataHandler creates a new DemoDataHandler.
+func NewDemoDataHandler(demoService service.DemoDataService) *DemoDataHandler {
+	return &DemoDataHandler{demoService: demoService}
+}
+
+// seedDemoDataRequest is the optional body of POST /admin/tenants/:id/demo-data.
+type seedDemoDataRequest struct {
+	// OwnerID is the tenant user who owns the demo collection; defaults to the caller.
+	OwnerID *uuid.UUID `json:"owner_id"`
+}
+
+// Seed handles POST /api/v1/admin/tenants/:id/demo-data
+// @Summary Seed demo data
+// @Description Create a demo collection with sample parsed invoices, validation results and review states. No parser or LLM is called. The collection is owned by owner_id, or by the caller when omitted; the owner must be an active user of the tenant (admin only)
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param body body seedDemoDataRequest false "Demo collection owner"
+// @Success 201 {object} Response{data=domain.DemoData} "Seeded demo data"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or owner"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 409 {object} ErrorResponseBody "Tenant already has demo data"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/demo-data [post]
+func (h *DemoDataHandler) Seed(c *gin.Context) {
+	_, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	var req seedDemoDataRequest
+	if !bindOptionalJSON(c, &req, "VALIDATION_ERROR") {
+		return
+	}
+	ownerID := userID
+	if req.OwnerID != nil {
+		ownerID = *req.OwnerID
+	}
+
+	result, err := h.demoService.Seed(c.Request.Context(), tenantID, ownerID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondCreated(c, result)
+}
+
+// Cleanup handles DELETE /api/v1/admin/tenants/:id/demo-data
+// @Summary Remove demo data
+// @Description Delete the tenant's demo collections together with their documents and stored files (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Success 200 {object} Response{data=domain.DemoCleanup} "Removed demo data"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/demo-data [delete]
+func (h *DemoDataHandler) Cleanup(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	result, err := h.demoService.Cleanup(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, result)
+}
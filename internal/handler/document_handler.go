@@ -2,26 +2,52 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"satvos/internal/domain"
+	"satvos/internal/jsonpatch"
 	"satvos/internal/middleware"
 	"satvos/internal/port"
 	"satvos/internal/service"
 )
 
+// jsonPatchContentType selects RFC 6902 JSON Patch bodies on structured data edits.
+const jsonPatchContentType = "application/json-patch+json"
+
 // DocumentHandler handles document parsing endpoints.
 type DocumentHandler struct {
 	documentService service.DocumentService
 	auditRepo       port.DocumentAuditRepository
+	computedFields  service.ComputedFieldService
+	quota           service.QuotaService
 }
 
-// NewDocumentHandler creates a new DocumentHandler.
-func NewDocumentHandler(documentService service.DocumentService, auditRepo port.DocumentAuditRepository) *DocumentHandler {
-	return &DocumentHandler{documentService: documentService, auditRepo: auditRepo}
+// NewDocumentHandler creates a new DocumentHandler. computedFields may be nil,
+// in which case ?extensions=true is ignored, and quota may be nil, in which case
+// Create sends no quota headers.
+func NewDocumentHandler(documentService service.DocumentService, auditRepo port.DocumentAuditRepository, computedFields service.ComputedFieldService, quota service.QuotaService) *DocumentHandler {
+	return &DocumentHandler{documentService: documentService, auditRepo: auditRepo, computedFields: computedFields, quota: quota}
+}
+
+// resolveExtensions fills in the tenant's computed fields when the request asks
+// for them with ?extensions=true. It responds with the error and returns false
+// if they can't be loaded.
+func (h *DocumentHandler) resolveExtensions(c *gin.Context, tenantID uuid.UUID, docs []domain.Document) bool {
+	if h.computedFields == nil || c.Query("extensions") != "true" {
+		return true
+	}
+	if err := h.computedFields.Resolve(c.Request.Context(), tenantID, docs); err != nil {
+		HandleError(c, err)
+		return false
+	}
+	return true
 }
 
 // Create handles POST /api/v1/documents
@@ -34,6 +60,7 @@ func NewDocumentHandler(documentService service.DocumentService, auditRepo port.
 // @Success 201 {object} Response{data=domain.Document} "Document created, parsing started"
 // @Failure 400 {object} ErrorResponseBody "Invalid request"
 // @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 402 {object} ErrorResponseBody "Daily parse budget exhausted"
 // @Failure 403 {object} ErrorResponseBody "Insufficient permission"
 // @Failure 404 {object} ErrorResponseBody "File or collection not found"
 // @Failure 409 {object} ErrorResponseBody "Document already exists for this file"
@@ -53,16 +80,12 @@ func (h *DocumentHandler) Create(c *gin.Context) {
 		Name         string            `json:"name"`
 		Tags         map[string]string `json:"tags"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "file_id, collection_id, and document_type are required")
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
 		return
 	}
 
-	// Default to single parse mode
-	if req.ParseMode == "" {
-		req.ParseMode = domain.ParseModeSingle
-	}
-	if !domain.ValidParseModes[req.ParseMode] {
+	// An empty parse mode uses the collection's or tenant's default
+	if req.ParseMode != "" && !domain.ValidParseModes[req.ParseMode] {
 		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "parse_mode must be 'single' or 'dual'")
 		return
 	}
@@ -79,10 +102,14 @@ func (h *DocumentHandler) Create(c *gin.Context) {
 		Role:         role,
 	})
 	if err != nil {
+		if errors.Is(err, domain.ErrQuotaExceeded) {
+			setQuotaHeaders(c, h.quota, tenantID, userID)
+		}
 		HandleError(c, err)
 		return
 	}
 
+	setQuotaHeaders(c, h.quota, tenantID, userID)
 	RespondCreated(c, doc)
 }
 
@@ -92,6 +119,7 @@ func (h *DocumentHandler) Create(c *gin.Context) {
 // @Tags documents
 // @Produce json
 // @Param id path string true "Document ID (UUID)"
+// @Param extensions query bool false "Include the tenant's computed fields under extensions"
 // @Success 200 {object} Response{data=domain.Document} "Document details"
 // @Failure 400 {object} ErrorResponseBody "Invalid ID"
 // @Failure 401 {object} ErrorResponseBody "Unauthorized"
@@ -116,6 +144,11 @@ func (h *DocumentHandler) GetByID(c *gin.Context) {
 		HandleError(c, err)
 		return
 	}
+	docs := []domain.Document{*doc}
+	if !h.resolveExtensions(c, tenantID, docs) {
+		return
+	}
+	doc.Extensions = docs[0].Extensions
 
 	RespondOK(c, doc)
 }
@@ -129,6 +162,7 @@ func (h *DocumentHandler) GetByID(c *gin.Context) {
 // @Param limit query int false "Limit for pagination (max 100)" default(20)
 // @Param collection_id query string false "Filter by collection ID"
 // @Param assigned_to query string false "Filter by assigned user ID"
+// @Param extensions query bool false "Include the tenant's computed fields under extensions"
 // @Success 200 {object} Response{data=[]domain.Document,meta=PagMeta} "List of documents"
 // @Failure 400 {object} ErrorResponseBody "Invalid collection_id or assigned_to"
 // @Failure 401 {object} ErrorResponseBody "Unauthorized"
@@ -164,6 +198,13 @@ func (h *DocumentHandler) List(c *gin.Context) {
 			HandleError(c, err)
 			return
 		}
+		if err := h.documentService.EnrichDocuments(c.Request.Context(), tenantID, docs); err != nil {
+			HandleError(c, err)
+			return
+		}
+		if !h.resolveExtensions(c, tenantID, docs) {
+			return
+		}
 		RespondPaginated(c, docs, PagMeta{Total: total, Offset: offset, Limit: limit})
 		return
 	}
@@ -173,6 +214,13 @@ func (h *DocumentHandler) List(c *gin.Context) {
 		HandleError(c, err)
 		return
 	}
+	if err := h.documentService.EnrichDocuments(c.Request.Context(), tenantID, docs); err != nil {
+		HandleError(c, err)
+		return
+	}
+	if !h.resolveExtensions(c, tenantID, docs) {
+		return
+	}
 
 	RespondPaginated(c, docs, PagMeta{Total: total, Offset: offset, Limit: limit})
 }
@@ -186,6 +234,7 @@ func (h *DocumentHandler) List(c *gin.Context) {
 // @Success 200 {object} Response{data=domain.Document} "Parsing re-triggered"
 // @Failure 400 {object} ErrorResponseBody "Invalid ID"
 // @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 402 {object} ErrorResponseBody "Daily parse budget exhausted"
 // @Failure 403 {object} ErrorResponseBody "Insufficient permission"
 // @Failure 404 {object} ErrorResponseBody "Document not found"
 // @Security BearerAuth
@@ -211,16 +260,192 @@ func (h *DocumentHandler) Retry(c *gin.Context) {
 	RespondOK(c, doc)
 }
 
+// ReplaceFileRequest is the body of POST /documents/:id/replace-file.
+type ReplaceFileRequest struct {
+	FileID uuid.UUID `json:"file_id" binding:"required"`
+}
+
+// ReplaceFile handles POST /api/v1/documents/:id/replace-file
+// @Summary Replace a document's file
+// @Description Point the document at a newly uploaded file (e.g. a better scan of the same invoice). The previous file and its parsed data, review and validation are kept as a version, and the document is re-parsed. Upload the new file with POST /files/upload first. Counts against the upload quota.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Param request body ReplaceFileRequest true "Replacement file"
+// @Success 200 {object} Response{data=domain.Document} "File replaced, re-parse started"
+// @Failure 400 {object} ErrorResponseBody "Invalid request"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 402 {object} ErrorResponseBody "Daily parse budget exhausted"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission or quota exceeded"
+// @Failure 404 {object} ErrorResponseBody "Document or file not found"
+// @Failure 409 {object} ErrorResponseBody "Same file, file already used by another document, or parse in progress"
+// @Security BearerAuth
+// @Router /documents/{id}/replace-file [post]
+func (h *DocumentHandler) ReplaceFile(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	var req ReplaceFileRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	doc, err := h.documentService.ReplaceFile(c.Request.Context(), &service.ReplaceFileInput{
+		TenantID:   tenantID,
+		DocumentID: docID,
+		FileID:     req.FileID,
+		UserID:     userID,
+		Role:       role,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, doc)
+}
+
+// ReclassifyDocumentRequest is the body of POST /documents/:id/reclassify.
+type ReclassifyDocumentRequest struct {
+	DocumentType string `json:"document_type" binding:"required"`
+}
+
+// Reclassify handles POST /api/v1/documents/:id/reclassify
+// @Summary Reclassify a document
+// @Description Change the type of a document the parser misidentified (e.g. a credit note parsed as an invoice). The current parsed data, review and validation are kept as a version, and the document is re-parsed with the new type's prompt and validated against that type's rules. Spends a parse from the daily budget but not the upload quota.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Param request body ReclassifyDocumentRequest true "New document type"
+// @Success 200 {object} Response{data=domain.Document} "Document reclassified, re-parse started"
+// @Failure 400 {object} ErrorResponseBody "Invalid request or document type"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 402 {object} ErrorResponseBody "Daily parse budget exhausted"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document or file not found"
+// @Failure 409 {object} ErrorResponseBody "Parse in progress"
+// @Security BearerAuth
+// @Router /documents/{id}/reclassify [post]
+func (h *DocumentHandler) Reclassify(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	var req ReclassifyDocumentRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	doc, err := h.documentService.Reclassify(c.Request.Context(), &service.ReclassifyInput{
+		TenantID:     tenantID,
+		DocumentID:   docID,
+		DocumentType: req.DocumentType,
+		UserID:       userID,
+		Role:         role,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, doc)
+}
+
+// ListVersions handles GET /api/v1/documents/:id/versions
+// @Summary List document versions
+// @Description Superseded versions of a document, newest first. Each keeps the file it was parsed from (downloadable via /files/{id}/download) and its parsed data, review and validation outcome.
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response{data=[]domain.DocumentVersion} "Versions"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/versions [get]
+func (h *DocumentHandler) ListVersions(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	versions, err := h.documentService.ListVersions(c.Request.Context(), tenantID, docID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, versions)
+}
+
+// ListApprovals handles GET /api/v1/documents/:id/approvals
+// @Summary List document approvals
+// @Description Snapshots of the document taken at each final approval, newest first: the structured data, confidence scores and validation results that were approved. Snapshots are immutable, so later edits, re-parses and file replacements don't change them.
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response{data=[]domain.DocumentApproval} "Approvals"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/approvals [get]
+func (h *DocumentHandler) ListApprovals(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	approvals, err := h.documentService.ListApprovals(c.Request.Context(), tenantID, docID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, approvals)
+}
+
 // UpdateReview handles PUT /api/v1/documents/:id/review
 // @Summary Review a document
-// @Description Approve or reject a parsed document
+// @Description Approve or reject a parsed document. If the collection has a review checklist, approval requires every item answered true in checklist (keyed by item id). A rejection requires reason_code, an active code from GET /rejection-reasons
 // @Tags documents
 // @Accept json
 // @Produce json
 // @Param id path string true "Document ID (UUID)"
 // @Param request body ReviewDocumentRequest true "Review decision"
 // @Success 200 {object} Response{data=domain.Document} "Document reviewed"
-// @Failure 400 {object} ErrorResponseBody "Invalid request or document not parsed"
+// @Failure 400 {object} ErrorResponseBody "Invalid request, document not parsed, checklist incomplete, or missing/invalid rejection reason"
 // @Failure 401 {object} ErrorResponseBody "Unauthorized"
 // @Failure 403 {object} ErrorResponseBody "Insufficient permission"
 // @Failure 404 {object} ErrorResponseBody "Document not found"
@@ -239,11 +464,12 @@ func (h *DocumentHandler) UpdateReview(c *gin.Context) {
 	}
 
 	var req struct {
-		Status domain.ReviewStatus `json:"status" binding:"required"`
-		Notes  string              `json:"notes"`
+		Status     domain.ReviewStatus `json:"status" binding:"required"`
+		Notes      string              `json:"notes"`
+		Checklist  map[string]bool     `json:"checklist"`
+		ReasonCode string              `json:"reason_code"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "status is required (approved or rejected)")
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
 		return
 	}
 
@@ -259,6 +485,8 @@ func (h *DocumentHandler) UpdateReview(c *gin.Context) {
 		Role:       role,
 		Status:     req.Status,
 		Notes:      req.Notes,
+		Checklist:  req.Checklist,
+		ReasonCode: req.ReasonCode,
 	})
 	if err != nil {
 		HandleError(c, err)
@@ -298,8 +526,7 @@ func (h *DocumentHandler) AssignDocument(c *gin.Context) {
 	var req struct {
 		AssigneeID *string `json:"assignee_id"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "request body is required")
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
 		return
 	}
 
@@ -352,23 +579,116 @@ func (h *DocumentHandler) ReviewQueue(c *gin.Context) {
 		HandleError(c, err)
 		return
 	}
+	if err := h.documentService.EnrichDocuments(c.Request.Context(), tenantID, docs); err != nil {
+		HandleError(c, err)
+		return
+	}
 
 	RespondPaginated(c, docs, PagMeta{Total: total, Offset: offset, Limit: limit})
 }
 
+// CheckerQueue handles GET /api/v1/documents/checker-queue
+// @Summary Get checker queue
+// @Description List documents awaiting checker confirmation that the current user may confirm: approved by someone else, in any collection for managers and admins, in owned collections for others. Oldest maker approval first.
+// @Tags documents
+// @Produce json
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.Document,meta=PagMeta} "Checker queue"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient role"
+// @Security BearerAuth
+// @Router /documents/checker-queue [get]
+func (h *DocumentHandler) CheckerQueue(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	docs, total, err := h.documentService.ListCheckerQueue(c.Request.Context(), tenantID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if err := h.documentService.EnrichDocuments(c.Request.Context(), tenantID, docs); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, docs, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// Neighbors handles GET /api/v1/documents/:id/neighbors
+// @Summary Get previous and next documents
+// @Description Return the IDs of the documents before and after this one in the review queue or in its collection listing, with the same filters and sort order as those lists. Works for a document that has just left the list, e.g. after approval. Either ID is null at the ends of the list.
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Param context query string true "List to navigate" Enums(review-queue, collection)
+// @Param assigned_to query string false "Filter the collection listing by assignee (UUID)"
+// @Success 200 {object} Response{data=domain.DocumentNeighbors} "Neighboring documents"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or context"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/neighbors [get]
+func (h *DocumentHandler) Neighbors(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	var assignedTo *uuid.UUID
+	if assignedToStr := c.Query("assigned_to"); assignedToStr != "" {
+		parsed, err := uuid.Parse(assignedToStr)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid assigned_to")
+			return
+		}
+		assignedTo = &parsed
+	}
+
+	neighbors, err := h.documentService.GetNeighbors(c.Request.Context(), &service.NeighborsInput{
+		TenantID:   tenantID,
+		DocumentID: docID,
+		UserID:     userID,
+		Role:       role,
+		Context:    domain.NeighborContext(c.Query("context")),
+		AssignedTo: assignedTo,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, neighbors)
+}
+
 // EditStructuredData handles PUT /api/v1/documents/:id and PUT /api/v1/documents/:id/structured-data
 // @Summary Edit structured data
-// @Description Manually edit the parsed structured data of a document, re-run validation and auto-tag extraction
+// @Description Manually edit the parsed structured data of a document, re-run validation and auto-tag extraction. With Content-Type application/json-patch+json the body is an RFC 6902 JSON Patch (an array of operations) applied to the stored structured data instead
 // @Tags documents
 // @Accept json
+// @Accept json-patch+json
 // @Produce json
 // @Param id path string true "Document ID (UUID)"
-// @Param request body EditStructuredDataRequest true "Structured data (GSTInvoice)"
+// @Param if_updated_at query string false "JSON Patch only: the document's updated_at when it was read; the patch fails with 409 if it changed since"
+// @Param request body EditStructuredDataRequest true "Structured data (GSTInvoice), or a JSON Patch array"
 // @Success 200 {object} Response{data=domain.Document} "Document updated with new structured data"
-// @Failure 400 {object} ErrorResponseBody "Invalid request, document not parsed, or invalid structured data"
+// @Failure 400 {object} ErrorResponseBody "Invalid request or JSON patch, document not parsed, or invalid structured data"
 // @Failure 401 {object} ErrorResponseBody "Unauthorized"
 // @Failure 403 {object} ErrorResponseBody "Insufficient permission"
 // @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Failure 409 {object} ErrorResponseBody "JSON patch does not apply to the current structured data, or the document was modified"
 // @Security BearerAuth
 // @Router /documents/{id} [put]
 // @Router /documents/{id}/structured-data [put]
@@ -384,20 +704,92 @@ func (h *DocumentHandler) EditStructuredData(c *gin.Context) {
 		return
 	}
 
+	input := &service.EditStructuredDataInput{
+		TenantID:   tenantID,
+		DocumentID: docID,
+		UserID:     userID,
+		Role:       role,
+	}
+	if c.ContentType() == jsonPatchContentType {
+		var patch []jsonpatch.Operation
+		if !bindJSON(c, &patch, "INVALID_JSON_PATCH") {
+			return
+		}
+		if len(patch) == 0 {
+			RespondError(c, http.StatusBadRequest, "INVALID_JSON_PATCH", "JSON patch must contain at least one operation")
+			return
+		}
+		input.Patch = patch
+		if raw := c.Query("if_updated_at"); raw != "" {
+			ifUpdatedAt, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid 'if_updated_at': must be RFC3339")
+				return
+			}
+			input.IfUpdatedAt = &ifUpdatedAt
+		}
+	} else {
+		var req struct {
+			StructuredData json.RawMessage `json:"structured_data" binding:"required"`
+		}
+		if !bindJSON(c, &req, "INVALID_REQUEST") {
+			return
+		}
+		input.StructuredData = req.StructuredData
+	}
+
+	doc, err := h.documentService.EditStructuredData(c.Request.Context(), input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, doc)
+}
+
+// PatchLineItems handles PATCH /api/v1/documents/:id/line-items
+// @Summary Patch line items
+// @Description Add, update or delete individual line items. Indexes refer to the line items as stored before the patch; added items are appended. Amounts a changed item leaves out (taxable amount, tax amounts, total) are derived from its quantity, price and rates, the invoice totals are recomputed, and the result is saved and validated like an edit of the whole structured data. Pass if_updated_at (the document's updated_at when it was read) to reject the patch if the document changed since (requires editor+ permission)
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Param request body PatchLineItemsRequest true "Line item operations"
+// @Success 200 {object} Response{data=domain.Document} "Document updated with the patched line items"
+// @Failure 400 {object} ErrorResponseBody "Invalid request or patch, document not parsed, or invalid structured data"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Failure 409 {object} ErrorResponseBody "Document modified since if_updated_at"
+// @Security BearerAuth
+// @Router /documents/{id}/line-items [patch]
+func (h *DocumentHandler) PatchLineItems(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
 	var req struct {
-		StructuredData json.RawMessage `json:"structured_data" binding:"required"`
+		IfUpdatedAt *time.Time                  `json:"if_updated_at"`
+		Operations  []service.LineItemOperation `json:"operations" binding:"required"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "structured_data is required")
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
 		return
 	}
 
-	doc, err := h.documentService.EditStructuredData(c.Request.Context(), &service.EditStructuredDataInput{
-		TenantID:       tenantID,
-		DocumentID:     docID,
-		UserID:         userID,
-		Role:           role,
-		StructuredData: req.StructuredData,
+	doc, err := h.documentService.PatchLineItems(c.Request.Context(), &service.PatchLineItemsInput{
+		TenantID:    tenantID,
+		DocumentID:  docID,
+		UserID:      userID,
+		Role:        role,
+		IfUpdatedAt: req.IfUpdatedAt,
+		Operations:  req.Operations,
 	})
 	if err != nil {
 		HandleError(c, err)
@@ -508,7 +900,7 @@ func (h *DocumentHandler) ListTags(c *gin.Context) {
 
 // AddTags handles POST /api/v1/documents/:id/tags
 // @Summary Add tags to a document
-// @Description Add user tags to a document (requires editor+ permission)
+// @Description Add user tags to a document (requires editor+ permission). types optionally makes a tag a number or YYYY-MM-DD date, so it can be searched by range
 // @Tags documents
 // @Accept json
 // @Produce json
@@ -533,15 +925,12 @@ func (h *DocumentHandler) AddTags(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		Tags map[string]string `json:"tags" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "tags map is required")
+	var req AddTagsRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
 		return
 	}
 
-	tags, err := h.documentService.AddTags(c.Request.Context(), tenantID, docID, userID, role, req.Tags)
+	tags, err := h.documentService.AddTags(c.Request.Context(), tenantID, docID, userID, role, req.Tags, req.Types)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -590,17 +979,191 @@ func (h *DocumentHandler) DeleteTag(c *gin.Context) {
 	RespondOK(c, gin.H{"message": "tag deleted"})
 }
 
+// ListOverrides handles GET /api/v1/documents/:id/overrides
+// @Summary List field overrides
+// @Description List per-field manual overrides that are re-applied after every re-parse
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response{data=[]domain.DocumentFieldOverride} "List of overrides"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/overrides [get]
+func (h *DocumentHandler) ListOverrides(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	overrides, err := h.documentService.ListOverrides(c.Request.Context(), tenantID, docID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, overrides)
+}
+
+// Timeline handles GET /api/v1/documents/:id/timeline
+// @Summary Get document processing timeline
+// @Description Chronological view of a document's processing: audit events (created, queued, parse completed/failed, validations, review, edits) merged with one parse_attempt event per attempt carrying its duration and queue wait. Each event includes the gap since the previous one
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response{data=domain.DocumentTimeline} "Document timeline"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/timeline [get]
+func (h *DocumentHandler) Timeline(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	timeline, err := h.documentService.GetTimeline(c.Request.Context(), tenantID, docID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, timeline)
+}
+
+// Attempts handles GET /api/v1/documents/:id/attempts
+// @Summary List parse attempts
+// @Description Every parse attempt of the document, oldest first: attempt number, parser model (and secondary model in dual mode), queue wait, parse duration, outcome (completed, queued for retry or failed), failure category and error, and the input/output tokens the provider reported
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response{data=[]domain.ParseTiming} "Parse attempts"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/attempts [get]
+func (h *DocumentHandler) Attempts(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	attempts, err := h.documentService.ListParseAttempts(c.Request.Context(), tenantID, docID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, attempts)
+}
+
+// RecomputeTotals handles POST /api/v1/documents/:id/recompute
+// @Summary Recompute invoice totals
+// @Description Recalculates subtotal, taxable amount, CGST/SGST/IGST totals, round-off and grand total from the document's line items and returns them with the deltas versus the stored totals. Nothing is saved: to fix the totals, save the recomputed totals with PUT /documents/{id}/structured-data
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response{data=service.TotalsRecomputation} "Stored and recomputed totals with deltas"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID, document not parsed, or invalid structured data"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/recompute [post]
+func (h *DocumentHandler) RecomputeTotals(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	result, err := h.documentService.RecomputeTotals(c.Request.Context(), tenantID, docID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, result)
+}
+
+// ClearOverrides handles DELETE /api/v1/documents/:id/overrides
+// @Summary Clear field overrides
+// @Description Clear one override (via field_path) or all overrides on a document. Cleared fields revert to parser output on the next re-parse (requires editor+ permission)
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Param field_path query string false "Field path to clear (e.g. invoice.invoice_date); omit to clear all"
+// @Success 200 {object} Response{data=MessageResponse} "Overrides cleared"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document or override not found"
+// @Security BearerAuth
+// @Router /documents/{id}/overrides [delete]
+func (h *DocumentHandler) ClearOverrides(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	if err := h.documentService.ClearOverrides(c.Request.Context(), tenantID, docID, userID, role, c.Query("field_path")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "overrides cleared"})
+}
+
 // SearchByTag handles GET /api/v1/documents/search/tags
 // @Summary Search documents by tag
-// @Description Search for documents with a specific tag key-value pair
+// @Description Search for documents by one tag key: an exact value, a value prefix, or an inclusive min/max range over number or date tags. Give exactly one of value, prefix or min/max
 // @Tags documents
 // @Produce json
 // @Param key query string true "Tag key"
-// @Param value query string true "Tag value"
+// @Param value query string false "Exact tag value"
+// @Param prefix query string false "Tag value prefix"
+// @Param min query string false "Lower bound (number or YYYY-MM-DD)"
+// @Param max query string false "Upper bound (number or YYYY-MM-DD)"
+// @Param type query string false "Range type, inferred from the bounds if omitted" Enums(number, date)
 // @Param offset query int false "Offset for pagination" default(0)
 // @Param limit query int false "Limit for pagination (max 100)" default(20)
 // @Success 200 {object} Response{data=[]domain.Document,meta=PagMeta} "Matching documents"
-// @Failure 400 {object} ErrorResponseBody "Missing key or value"
+// @Failure 400 {object} ErrorResponseBody "Missing key, no or several match modes, or invalid bounds"
 // @Failure 401 {object} ErrorResponseBody "Unauthorized"
 // @Security BearerAuth
 // @Router /documents/search/tags [get]
@@ -611,20 +1174,25 @@ func (h *DocumentHandler) SearchByTag(c *gin.Context) {
 		return
 	}
 
-	key := c.Query("key")
-	value := c.Query("value")
-	if key == "" || value == "" {
-		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "key and value query parameters are required")
-		return
+	input := &service.TagSearchInput{
+		Key:    c.Query("key"),
+		Value:  c.Query("value"),
+		Prefix: c.Query("prefix"),
+		Min:    c.Query("min"),
+		Max:    c.Query("max"),
+		Type:   domain.TagValueType(c.Query("type")),
 	}
-
 	offset, limit := parsePagination(c)
 
-	docs, total, err := h.documentService.SearchByTag(c.Request.Context(), tenantID, key, value, offset, limit)
+	docs, total, err := h.documentService.SearchByTag(c.Request.Context(), tenantID, input, offset, limit)
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
+	if err := h.documentService.EnrichDocuments(c.Request.Context(), tenantID, docs); err != nil {
+		HandleError(c, err)
+		return
+	}
 
 	RespondPaginated(c, docs, PagMeta{Total: total, Offset: offset, Limit: limit})
 }
@@ -686,3 +1254,105 @@ func (h *DocumentHandler) ListAudit(c *gin.Context) {
 
 	RespondPaginated(c, entries, PagMeta{Total: total, Offset: offset, Limit: limit})
 }
+
+// SearchAudit handles GET /api/v1/audit
+// @Summary Search tenant audit log
+// @Description Query the audit log across all documents in the tenant. Filters combine with AND; action accepts a comma-separated list
+// @Tags documents
+// @Produce json
+// @Param action query string false "Audit action(s), e.g. document.review,document.edit_structured_data"
+// @Param user_id query string false "Filter by acting user ID"
+// @Param document_id query string false "Filter by document ID"
+// @Param collection_id query string false "Filter by collection ID (excludes deleted documents)"
+// @Param from query string false "Start time (YYYY-MM-DD or RFC3339, inclusive)"
+// @Param to query string false "End time (YYYY-MM-DD or RFC3339, inclusive)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.DocumentAuditEntry,meta=PagMeta} "Audit entries"
+// @Failure 400 {object} ErrorResponseBody "Invalid filter"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient role"
+// @Security BearerAuth
+// @Router /audit [get]
+func (h *DocumentHandler) SearchAudit(c *gin.Context) {
+	tenantID, err := middleware.GetTenantID(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing tenant context")
+		return
+	}
+
+	filters, err := parseAuditFilters(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	entries, total, err := h.auditRepo.ListByTenant(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, entries, PagMeta{Total: total, Offset: filters.Offset, Limit: filters.Limit})
+}
+
+// parseAuditFilters extracts audit search filters from query params.
+func parseAuditFilters(c *gin.Context) (*domain.AuditFilters, error) {
+	filters := &domain.AuditFilters{}
+	filters.Offset, filters.Limit = parsePagination(c)
+
+	if actionStr := c.Query("action"); actionStr != "" {
+		for _, action := range strings.Split(actionStr, ",") {
+			if action = strings.TrimSpace(action); action != "" {
+				filters.Actions = append(filters.Actions, action)
+			}
+		}
+	}
+
+	for param, dest := range map[string]**uuid.UUID{
+		"user_id":       &filters.UserID,
+		"document_id":   &filters.DocumentID,
+		"collection_id": &filters.CollectionID,
+	} {
+		if v := c.Query(param); v != "" {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid '%s': must be a valid UUID", param)
+			}
+			*dest = &id
+		}
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		t, err := parseAuditTime(fromStr, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'from': must be YYYY-MM-DD or RFC3339")
+		}
+		filters.From = &t
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		t, err := parseAuditTime(toStr, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'to': must be YYYY-MM-DD or RFC3339")
+		}
+		filters.To = &t
+	}
+
+	return filters, nil
+}
+
+// parseAuditTime accepts RFC3339 timestamps or plain dates. A plain date used as an
+// upper bound covers the whole day.
+func parseAuditTime(s string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
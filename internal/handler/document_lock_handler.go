@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// DocumentLockHandler serves the soft review locks on documents.
+type DocumentLockHandler struct {
+	lockService service.DocumentLockService
+}
+
+// NewDocumentLockHandler creates a new DocumentLockHandler.
+func NewDocumentLockHandler(lockService service.DocumentLockService) *DocumentLockHandler {
+	return &DocumentLockHandler{lockService: lockService}
+}
+
+// Acquire handles PUT /api/v1/documents/:id/lock
+// @Summary Acquire or renew a review lock
+// @Description Take the review lock on a document while it is open for review, or renew it (heartbeat) before it expires. While the lock is held, edits and reviews by other users are rejected with 423 DOCUMENT_LOCKED. Requires editor permission.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Param request body AcquireDocumentLockRequest false "Lock TTL"
+// @Success 200 {object} Response{data=domain.DocumentLock} "Lock held until expires_at"
+// @Failure 400 {object} ErrorResponseBody "Invalid TTL"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Failure 423 {object} ErrorResponseBody "Locked by another reviewer"
+// @Security BearerAuth
+// @Router /documents/{id}/lock [put]
+func (h *DocumentLockHandler) Acquire(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	var req AcquireDocumentLockRequest
+	if !bindOptionalJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	lock, err := h.lockService.Acquire(c.Request.Context(), tenantID, docID, userID, role, time.Duration(req.TTLSeconds)*time.Second)
+	if errors.Is(err, domain.ErrDocumentLocked) && lock != nil {
+		respondLocked(c, lock)
+		return
+	}
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, lock)
+}
+
+// Get handles GET /api/v1/documents/:id/lock
+// @Summary Get a document's review lock
+// @Description The document's active review lock with the holder's name, for a "being reviewed by" indicator; data is null when the document is free. Requires viewer permission.
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response{data=domain.DocumentLock} "Active lock, or null"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/lock [get]
+func (h *DocumentLockHandler) Get(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	lock, err := h.lockService.Get(c.Request.Context(), tenantID, docID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, lock)
+}
+
+// Release handles DELETE /api/v1/documents/:id/lock
+// @Summary Release a review lock
+// @Description Release the caller's review lock when they close the document. Managers, admins and collection owners may also break another user's lock. Releasing a free document is a no-op.
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Lock released"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Failure 423 {object} ErrorResponseBody "Locked by another reviewer"
+// @Security BearerAuth
+// @Router /documents/{id}/lock [delete]
+func (h *DocumentLockHandler) Release(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	if err := h.lockService.Release(c.Request.Context(), tenantID, docID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "document lock released"})
+}
+
+// respondLocked sends the 423 for a lock held by someone else, naming them and
+// when it lapses.
+func respondLocked(c *gin.Context, lock *domain.DocumentLock) {
+	holder := lock.HolderName
+	if holder == "" {
+		holder = "another user"
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(lock.ExpiresAt).Seconds()))))
+	RespondError(c, http.StatusLocked, "DOCUMENT_LOCKED",
+		fmt.Sprintf("document is being reviewed by %s until %s", holder, lock.ExpiresAt.UTC().Format(time.RFC3339)))
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// documentStreamFlushEvery is how many lines are buffered between flushes.
+const documentStreamFlushEvery = 100
+
+// DocumentStreamHandler handles the document change stream.
+type DocumentStreamHandler struct {
+	streamService service.DocumentStreamService
+}
+
+// NewDocumentStreamHandler creates a new DocumentStreamHandler.
+func NewDocumentStreamHandler(streamService service.DocumentStreamService) *DocumentStreamHandler {
+	return &DocumentStreamHandler{streamService: streamService}
+}
+
+// Stream handles GET /api/v1/documents/stream
+// @Summary Stream changed documents
+// @Description Newline-delimited JSON (application/x-ndjson) of the documents the caller can view that changed since a cursor, oldest change first: a "document" line with the full document, or a "tombstone" line for a deleted one. The last line has type "end" and the cursor to pass as since next time; has_more means the limit was reached. A response without an end line was cut short; resume from the cursor of its last line. Changes from the last few seconds are held back until they settle.
+// @Tags documents
+// @Produce json
+// @Param since query string false "cursor from an earlier response; omit to stream every document"
+// @Param collection_id query string false "Only documents in this collection"
+// @Param limit query int false "Maximum changes (max 10000)" default(1000)
+// @Success 200 {array} service.DocumentStreamRecord "One JSON record per line"
+// @Failure 400 {object} ErrorResponseBody "Invalid cursor or collection ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "No access to the collection"
+// @Security BearerAuth
+// @Router /documents/stream [get]
+func (h *DocumentStreamHandler) Stream(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	input := service.StreamDocumentsInput{TenantID: tenantID, UserID: userID, Role: role, Since: c.Query("since")}
+	if raw := c.Query("collection_id"); raw != "" {
+		collectionID, err := uuid.Parse(raw)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+			return
+		}
+		input.CollectionID = &collectionID
+	}
+	input.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	// Headers go out with the first line, so errors before it are still JSON errors
+	enc := json.NewEncoder(c.Writer)
+	lines := 0
+	err := h.streamService.Stream(c.Request.Context(), &input, func(rec *service.DocumentStreamRecord) error {
+		if lines == 0 {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Header("Cache-Control", "no-store")
+			c.Status(http.StatusOK)
+		}
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+		lines++
+		if lines%documentStreamFlushEvery == 0 || rec.Type == service.DocumentStreamEnd {
+			c.Writer.Flush()
+		}
+		return c.Request.Context().Err()
+	})
+	if err != nil {
+		if lines == 0 {
+			HandleError(c, err)
+			return
+		}
+		log.Printf("document stream for tenant %s aborted after %d lines: %v", tenantID, lines, err)
+	}
+}
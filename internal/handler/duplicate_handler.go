@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// DuplicateHandler serves fuzzy duplicate search for documents.
+type DuplicateHandler struct {
+	duplicateService service.DuplicateService
+}
+
+// NewDuplicateHandler creates a new DuplicateHandler.
+func NewDuplicateHandler(duplicateService service.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{duplicateService: duplicateService}
+}
+
+// List handles GET /api/v1/documents/:id/duplicates
+// @Summary Find possible duplicates
+// @Description Rank the other invoices of the document's seller (same seller GSTIN) by similarity: 40% invoice number (ignoring case, separators, leading zeros and changed prefixes, otherwise by edit distance), 40% total amount (same within 1, zero at 5% apart) and 20% invoice date (same day, zero at 30 days apart). Catches resubmissions under a new invoice number, which the exact-match duplicate rule misses. Viewers only see candidates from collections they have access to
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Param min_score query number false "Minimum score from 0 to 1" default(0.5)
+// @Param limit query int false "Maximum candidates (max 50)" default(10)
+// @Success 200 {object} Response{data=[]service.ScoredDuplicate} "Candidates, best first"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID, min_score or limit, or document not parsed"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/duplicates [get]
+func (h *DuplicateHandler) List(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	var q service.DuplicateQuery
+	if s := c.Query("min_score"); s != "" {
+		q.MinScore, err = strconv.ParseFloat(s, 64)
+		if err != nil || q.MinScore < 0 || q.MinScore > 1 {
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "min_score must be a number from 0 to 1")
+			return
+		}
+	}
+	if s := c.Query("limit"); s != "" {
+		q.Limit, err = strconv.Atoi(s)
+		if err != nil || q.Limit < 1 {
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "limit must be a positive integer")
+			return
+		}
+	}
+
+	duplicates, err := h.duplicateService.FindForDocument(c.Request.Context(), tenantID, docID, userID, role, q)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, duplicates)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/middleware"
+	"satvos/internal/service"
+)
+
+// EmailFeedbackHandler handles SES bounce and complaint feedback.
+type EmailFeedbackHandler struct {
+	feedbackService service.EmailFeedbackService
+}
+
+// NewEmailFeedbackHandler creates a new EmailFeedbackHandler.
+func NewEmailFeedbackHandler(feedbackService service.EmailFeedbackService) *EmailFeedbackHandler {
+	return &EmailFeedbackHandler{feedbackService: feedbackService}
+}
+
+// HandleSNS handles POST /api/v1/webhooks/ses
+// @Summary Receive SES feedback
+// @Description Amazon SNS endpoint for SES bounce and complaint notifications. The message must be signed by SNS and come from the topic in SATVOS_EMAIL_SNS_TOPIC_ARN; subscription confirmations are confirmed automatically. Permanent bounces and complaints suppress the address, mark its users email_undeliverable, and alert their tenant admins. No authentication: the SNS signature is the credential
+// @Tags webhooks
+// @Accept plain
+// @Produce json
+// @Success 200 {object} Response{data=MessageResponse} "Message processed"
+// @Failure 400 {object} ErrorResponseBody "Not a signed SNS message from the configured topic"
+// @Router /webhooks/ses [post]
+func (h *EmailFeedbackHandler) HandleSNS(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if isBodyTooLarge(err) {
+			respondBodyTooLarge(c)
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "failed to read request body")
+		return
+	}
+
+	if err := h.feedbackService.HandleSNS(c.Request.Context(), body); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "message processed"})
+}
+
+// ClearSuppression handles DELETE /api/v1/users/:id/email-suppression
+// @Summary Clear a user's email suppression
+// @Description Lift the suppression SES feedback put on the user's email address, once the mailbox is fixed. Clears the address for the tenant's accounts; refused when users of other tenants also have it (admin only)
+// @Tags users
+// @Produce json
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Suppression cleared"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "User not found or address not suppressed"
+// @Failure 409 {object} ErrorResponseBody "Address also belongs to users of other tenants"
+// @Security BearerAuth
+// @Router /users/{id}/email-suppression [delete]
+func (h *EmailFeedbackHandler) ClearSuppression(c *gin.Context) {
+	tenantID, err := middleware.GetTenantID(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing tenant context")
+		return
+	}
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid user ID")
+		return
+	}
+
+	if err := h.feedbackService.ClearSuppression(c.Request.Context(), tenantID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "email suppression cleared"})
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/apierror"
+)
+
+// ErrorCatalogueHandler serves the catalogue of API error codes.
+type ErrorCatalogueHandler struct{}
+
+// NewErrorCatalogueHandler creates a new ErrorCatalogueHandler.
+func NewErrorCatalogueHandler() *ErrorCatalogueHandler {
+	return &ErrorCatalogueHandler{}
+}
+
+// List handles GET /api/v1/errors
+// @Summary List error codes
+// @Description List every error code the API returns, with its HTTP status, title, whether retrying the same request can succeed, and its docs URL. Public; use it to branch on codes instead of messages
+// @Tags errors
+// @Produce json
+// @Success 200 {object} Response{data=[]apierror.Entry} "Error catalogue"
+// @Router /errors [get]
+func (h *ErrorCatalogueHandler) List(c *gin.Context) {
+	RespondOK(c, apierror.Catalogue())
+}
+
+// Get handles GET /api/v1/errors/:code
+// @Summary Get an error code
+// @Description Describe one error code. Every error response's docs_url (problem+json "type") points here
+// @Tags errors
+// @Produce json
+// @Param code path string true "Error code, e.g. RATE_LIMITED"
+// @Success 200 {object} Response{data=apierror.Entry} "Error code"
+// @Failure 404 {object} ErrorResponseBody "Unknown code"
+// @Router /errors/{code} [get]
+func (h *ErrorCatalogueHandler) Get(c *gin.Context) {
+	entry, ok := apierror.Lookup(strings.ToUpper(c.Param("code")))
+	if !ok {
+		RespondError(c, http.StatusNotFound, "NOT_FOUND", "unknown error code")
+		return
+	}
+	RespondOK(c, entry)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/faults"
+)
+
+// FaultInjectionHandler toggles fault injection scenarios on this instance.
+type FaultInjectionHandler struct {
+	injector *faults.Injector
+}
+
+// NewFaultInjectionHandler creates a new FaultInjectionHandler. A nil injector
+// means fault injection is disabled and every endpoint answers 404.
+func NewFaultInjectionHandler(injector *faults.Injector) *FaultInjectionHandler {
+	return &FaultInjectionHandler{injector: injector}
+}
+
+func (h *FaultInjectionHandler) enabled(c *gin.Context) bool {
+	if h.injector == nil {
+		RespondError(c, http.StatusNotFound, "FAULT_INJECTION_DISABLED", "fault injection is not enabled on this instance")
+		return false
+	}
+	return true
+}
+
+// List handles GET /api/v1/admin/faults
+// @Summary List fault injection scenarios
+// @Description List the fault injection scenarios active on this instance (admin only; staging/development builds with SATVOS_FAULTS_ENABLED)
+// @Tags admin
+// @Produce json
+// @Success 200 {object} Response{data=[]faults.Scenario} "Active scenarios"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Fault injection is not enabled"
+// @Security BearerAuth
+// @Router /admin/faults [get]
+func (h *FaultInjectionHandler) List(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+	RespondOK(c, h.injector.Scenarios())
+}
+
+// Set handles PUT /api/v1/admin/faults/:target
+// @Summary Set a fault injection scenario
+// @Description Inject latency, errors and partial failures into storage, the parsers or the document repository on this instance, replacing the target's current scenario (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param target path string true "Port to inject faults into" Enums(storage, parser, repository)
+// @Param request body SetFaultScenarioRequest true "Scenario"
+// @Success 200 {object} Response{data=faults.Scenario} "Scenario active"
+// @Failure 400 {object} ErrorResponseBody "Invalid body or unknown target"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Fault injection is not enabled"
+// @Security BearerAuth
+// @Router /admin/faults/{target} [put]
+func (h *FaultInjectionHandler) Set(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	var req SetFaultScenarioRequest
+	if !bindJSON(c, &req, "VALIDATION_ERROR") {
+		return
+	}
+
+	scenario := faults.Scenario{
+		Target:         faults.Target(c.Param("target")),
+		Operations:     req.Operations,
+		LatencyMS:      req.LatencyMS,
+		ErrorPercent:   req.ErrorPercent,
+		PartialPercent: req.PartialPercent,
+	}
+	if err := h.injector.Set(scenario); err != nil {
+		if errors.Is(err, faults.ErrInvalidScenario) {
+			RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+			return
+		}
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, scenario)
+}
+
+// Clear handles DELETE /api/v1/admin/faults/:target
+// @Summary Clear a fault injection scenario
+// @Description Stop injecting faults into the target on this instance (admin only)
+// @Tags admin
+// @Produce json
+// @Param target path string true "Port to stop injecting faults into" Enums(storage, parser, repository)
+// @Success 200 {object} Response{data=MessageResponse} "Scenario cleared"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Fault injection is not enabled"
+// @Security BearerAuth
+// @Router /admin/faults/{target} [delete]
+func (h *FaultInjectionHandler) Clear(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	h.injector.Clear(faults.Target(c.Param("target")))
+	RespondOK(c, gin.H{"message": "fault scenario cleared"})
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/middleware"
+	"satvos/internal/service"
+)
+
+// FeatureFlagHandler handles per-tenant feature flag endpoints.
+type FeatureFlagHandler struct {
+	flagService service.FeatureFlagService
+}
+
+// NewFeatureFlagHandler creates a new FeatureFlagHandler.
+func NewFeatureFlagHandler(flagService service.FeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{flagService: flagService}
+}
+
+// List handles GET /api/v1/admin/tenants/:id/flags
+// @Summary List tenant feature flags
+// @Description List the effective value of every feature flag for a tenant; overridden=false means the default applies (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Success 200 {object} Response{data=[]domain.TenantFeatureFlag} "Feature flags"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/flags [get]
+func (h *FeatureFlagHandler) List(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	flags, err := h.flagService.List(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, flags)
+}
+
+// Set handles PUT /api/v1/admin/tenants/:id/flags/:flag
+// @Summary Set a tenant feature flag
+// @Description Explicitly enable or disable a feature flag for a tenant (admin only)
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param flag path string true "Feature flag name" Enums(dual_parse, auto_approval, anomaly_detection, whatsapp_ingestion, batch_feed, orphan_file_nudges)
+// @Param request body SetFeatureFlagRequest true "Flag value"
+// @Success 200 {object} Response{data=domain.TenantFeatureFlag} "Feature flag updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID, body, or unknown flag"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/flags/{flag} [put]
+func (h *FeatureFlagHandler) Set(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	userID, err := middleware.GetUserID(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing user context")
+		return
+	}
+
+	var req SetFeatureFlagRequest
+	if !bindJSON(c, &req, "VALIDATION_ERROR") {
+		return
+	}
+
+	flag, err := h.flagService.Set(c.Request.Context(), tenantID, domain.FeatureFlag(c.Param("flag")), *req.Enabled, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, flag)
+}
+
+// Reset handles DELETE /api/v1/admin/tenants/:id/flags/:flag
+// @Summary Reset a tenant feature flag
+// @Description Remove the tenant's explicit setting so the flag falls back to its default (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param flag path string true "Feature flag name"
+// @Success 200 {object} Response{data=MessageResponse} "Feature flag reset"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or unknown flag"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/flags/{flag} [delete]
+func (h *FeatureFlagHandler) Reset(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	if err := h.flagService.Reset(c.Request.Context(), tenantID, domain.FeatureFlag(c.Param("flag"))); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "feature flag reset to default"})
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"log"
+	"mime"
 	"net/http"
 	"strconv"
 
@@ -53,16 +55,33 @@ func (h *FileHandler) Upload(c *gin.Context) {
 
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
+		if isBodyTooLarge(err) {
+			respondBodyTooLarge(c)
+			return
+		}
 		RespondError(c, http.StatusBadRequest, "MISSING_FILE", "file field is required")
 		return
 	}
 	defer func() { _ = file.Close() }()
 
+	// Parse collection_id up front so the object is stored under the collection's prefix
+	var collectionID *uuid.UUID
+	var warning string
+	if collectionIDStr := c.PostForm("collection_id"); collectionIDStr != "" {
+		id, parseErr := uuid.Parse(collectionIDStr)
+		if parseErr != nil {
+			warning = "invalid collection_id format; file uploaded but not added to collection"
+		} else {
+			collectionID = &id
+		}
+	}
+
 	input := service.FileUploadInput{
-		TenantID:   tenantID,
-		UploadedBy: userID,
-		File:       file,
-		Header:     header,
+		TenantID:     tenantID,
+		UploadedBy:   userID,
+		File:         file,
+		Header:       header,
+		CollectionID: collectionID,
 	}
 
 	meta, err := h.fileService.Upload(c.Request.Context(), input)
@@ -74,18 +93,12 @@ func (h *FileHandler) Upload(c *gin.Context) {
 	role := domain.UserRole(middleware.GetRole(c))
 
 	// Optional: add file to a collection if collection_id is provided
-	var warning string
-	if collectionIDStr := c.PostForm("collection_id"); collectionIDStr != "" {
-		collectionID, parseErr := uuid.Parse(collectionIDStr)
-		if parseErr != nil {
-			warning = "invalid collection_id format; file uploaded but not added to collection"
-		} else {
-			addErr := h.collectionService.AddFileToCollection(c.Request.Context(), tenantID, collectionID, meta.ID, userID, role)
-			if addErr != nil {
-				log.Printf("fileHandler.Upload: failed to add file %s to collection %s: %v",
-					meta.ID, collectionID, addErr)
-				warning = "file uploaded but failed to add to collection: " + addErr.Error()
-			}
+	if collectionID != nil {
+		addErr := h.collectionService.AddFileToCollection(c.Request.Context(), tenantID, *collectionID, meta.ID, userID, role)
+		if addErr != nil {
+			log.Printf("fileHandler.Upload: failed to add file %s to collection %s: %v",
+				meta.ID, *collectionID, addErr)
+			warning = "file uploaded but failed to add to collection: " + addErr.Error()
 		}
 	}
 
@@ -153,7 +166,7 @@ func (h *FileHandler) List(c *gin.Context) {
 
 // GetByID handles GET /api/v1/files/:id
 // @Summary Get file by ID
-// @Description Get file metadata and a presigned download URL
+// @Description Get file metadata and a presigned download URL. download_url is left out, and download_restricted is true, when a collection holding the file requires a higher permission to download its original (PUT /collections/:id/file-download)
 // @Tags files
 // @Produce json
 // @Param id path string true "File ID (UUID)"
@@ -194,6 +207,20 @@ func (h *FileHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	// The metadata stays visible when a collection holding the file restricts downloads
+	err = h.collectionService.CheckFileDownload(c.Request.Context(), tenantID, fileID, userID, role)
+	if errors.Is(err, domain.ErrFileDownloadRestricted) {
+		RespondOK(c, gin.H{
+			"file":                meta,
+			"download_restricted": true,
+		})
+		return
+	}
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
 	downloadURL, err := h.fileService.GetDownloadURL(c.Request.Context(), tenantID, fileID)
 	if err != nil {
 		HandleError(c, err)
@@ -201,8 +228,75 @@ func (h *FileHandler) GetByID(c *gin.Context) {
 	}
 
 	RespondOK(c, gin.H{
-		"file":         meta,
-		"download_url": downloadURL,
+		"file":                meta,
+		"download_url":        downloadURL,
+		"download_restricted": false,
+	})
+}
+
+// Download handles GET /api/v1/files/:id/download
+// @Summary Download file content
+// @Description Stream the stored file through the API, for clients that cannot reach S3 presigned URLs
+// @Tags files
+// @Produce octet-stream
+// @Param id path string true "File ID (UUID)"
+// @Success 200 {file} file "File content"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "A collection holding the file restricts downloads"
+// @Failure 404 {object} ErrorResponseBody "File not found"
+// @Failure 409 {object} ErrorResponseBody "File stored outside the tenant's region"
+// @Failure 503 {object} ErrorResponseBody "Storage unavailable"
+// @Security BearerAuth
+// @Router /files/{id}/download [get]
+func (h *FileHandler) Download(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	fileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid file ID")
+		return
+	}
+
+	meta, err := h.fileService.GetByID(c.Request.Context(), tenantID, fileID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	// Free users can only see their own files
+	if role == domain.RoleFree && meta.UploadedBy != userID {
+		RespondError(c, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		return
+	}
+	if err := h.collectionService.CheckFileDownload(c.Request.Context(), tenantID, fileID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	meta, obj, err := h.fileService.OpenContent(c.Request.Context(), tenantID, fileID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	defer func() { _ = obj.Body.Close() }()
+
+	contentType := meta.ContentType
+	if contentType == "" {
+		contentType = obj.ContentType
+	}
+	size := obj.Size
+	if size <= 0 {
+		size = -1 // unknown: fall back to chunked encoding
+	}
+
+	// Copy straight from S3 to the client: memory stays at one buffer regardless of
+	// file size, and a slow client slows the S3 read rather than piling data up here.
+	c.DataFromReader(http.StatusOK, size, contentType, obj.Body, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": meta.OriginalName}),
 	})
 }
 
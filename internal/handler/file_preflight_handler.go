@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// FilePreflightHandler serves pre-parse quality reports for files.
+type FilePreflightHandler struct {
+	preflightService service.FilePreflightService
+}
+
+// NewFilePreflightHandler creates a new FilePreflightHandler.
+func NewFilePreflightHandler(preflightService service.FilePreflightService) *FilePreflightHandler {
+	return &FilePreflightHandler{preflightService: preflightService}
+}
+
+// Preflight handles POST /api/v1/files/:id/preflight
+// @Summary Check a file before parsing
+// @Description Inspect a stored file so the UI can warn about it before a parse credit is spent: whether the content matches the file type, page count, encryption, whether a PDF has a text layer, the scan's estimated DPI (for photos, assuming an A4 page) and sharpness (variance of the Laplacian; below 100 is blurry), the language of the text layer, and the predicted parse difficulty (easy, moderate, hard or unparseable) with the warnings behind it. No parser is called and no malware scan is run
+// @Tags files
+// @Produce json
+// @Param id path string true "File ID (UUID)"
+// @Success 200 {object} Response{data=domain.FilePreflight}
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "File not found"
+// @Security BearerAuth
+// @Router /files/{id}/preflight [post]
+func (h *FilePreflightHandler) Preflight(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	fileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid file ID")
+		return
+	}
+
+	report, err := h.preflightService.Preflight(c.Request.Context(), &service.FilePreflightInput{
+		TenantID: tenantID,
+		UserID:   userID,
+		Role:     role,
+		FileID:   fileID,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, report)
+}
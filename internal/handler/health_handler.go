@@ -1,20 +1,28 @@
 package handler
 
 import (
+	"context"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 )
 
+// shardPingTimeout bounds each shard's ping, so one hung shard can't stall the probe.
+const shardPingTimeout = 2 * time.Second
+
 // HealthHandler handles health check endpoints.
 type HealthHandler struct {
-	db *sqlx.DB
+	db     *sqlx.DB
+	shards map[string]*sqlx.DB
 }
 
-// NewHealthHandler creates a new HealthHandler.
-func NewHealthHandler(db *sqlx.DB) *HealthHandler {
-	return &HealthHandler{db: db}
+// NewHealthHandler creates a new HealthHandler. shards are the pools of the
+// clusters configured in SATVOS_DB_SHARDS, keyed by name; it may be nil.
+func NewHealthHandler(db *sqlx.DB, shards map[string]*sqlx.DB) *HealthHandler {
+	return &HealthHandler{db: db, shards: shards}
 }
 
 // Liveness handles GET /healthz
@@ -30,7 +38,7 @@ func (h *HealthHandler) Liveness(c *gin.Context) {
 
 // Readiness handles GET /readyz
 // @Summary Readiness probe
-// @Description Check if the service is ready to accept traffic (checks database connection)
+// @Description Check if the service is ready to accept traffic (checks the primary database connection). With shards configured, each shard is pinged too and reported under shards; an unreachable shard makes the status "degraded" but keeps the service ready, as tenants on other shards can still be served
 // @Tags health
 // @Produce json
 // @Success 200 {object} HealthResponse "Service is ready"
@@ -41,5 +49,32 @@ func (h *HealthHandler) Readiness(c *gin.Context) {
 		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "error": "database not reachable"})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	if len(h.shards) == 0 {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		return
+	}
+
+	status := "ok"
+	shards := make(map[string]string, len(h.shards))
+	for _, name := range sortedShardNames(h.shards) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), shardPingTimeout)
+		err := h.shards[name].PingContext(ctx)
+		cancel()
+		if err != nil {
+			status = "degraded"
+			shards[name] = "unreachable"
+			continue
+		}
+		shards[name] = "ok"
+	}
+	c.JSON(http.StatusOK, gin.H{"status": status, "shards": shards})
+}
+
+func sortedShardNames(shards map[string]*sqlx.DB) []string {
+	names := make([]string, 0, len(shards))
+	for name := range shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
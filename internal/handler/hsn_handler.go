@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// hsnCodeRe matches a chapter, heading, subheading, or tariff item code.
+var hsnCodeRe = regexp.MustCompile(`^(\d{2}|\d{4}|\d{6}|\d{8})$`)
+
+// HSNHandler handles HSN/SAC master list browse endpoints.
+type HSNHandler struct {
+	hsnService service.HSNService
+}
+
+// NewHSNHandler creates a new HSNHandler.
+func NewHSNHandler(hsnService service.HSNService) *HSNHandler {
+	return &HSNHandler{hsnService: hsnService}
+}
+
+// Tree handles GET /api/v1/hsn/tree
+// @Summary Browse the HSN hierarchy
+// @Description Chapters (2 digits) with descendants expanded to the requested depth: 1 = chapters, 2 = + headings (4 digits), 3 = + subheadings (6), 4 = + tariff items (8). Chapters have no description or rates
+// @Tags hsn
+// @Produce json
+// @Param depth query int false "Levels to expand (1-4)" default(2)
+// @Success 200 {object} Response{data=[]domain.HSNNode} "HSN tree"
+// @Failure 400 {object} ErrorResponseBody "Invalid depth"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /hsn/tree [get]
+func (h *HSNHandler) Tree(c *gin.Context) {
+	depth := 2
+	if v := c.Query("depth"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d < 1 || d > 4 {
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "depth must be between 1 and 4")
+			return
+		}
+		depth = d
+	}
+
+	RespondOK(c, h.hsnService.Tree(c.Request.Context(), depth))
+}
+
+// Children handles GET /api/v1/hsn/:code/children
+// @Summary List HSN child codes
+// @Description Direct children of a chapter, heading, or subheading, for drill-down pickers. Tariff items without a subheading in the master list are listed under their heading
+// @Tags hsn
+// @Produce json
+// @Param code path string true "HSN code (2, 4, 6, or 8 digits)"
+// @Success 200 {object} Response{data=[]domain.HSNNode} "Child codes"
+// @Failure 400 {object} ErrorResponseBody "Invalid code"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Code not found"
+// @Security BearerAuth
+// @Router /hsn/{code}/children [get]
+func (h *HSNHandler) Children(c *gin.Context) {
+	code := c.Param("code")
+	if !hsnCodeRe.MatchString(code) {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "code must be 2, 4, 6, or 8 digits")
+		return
+	}
+
+	children, err := h.hsnService.Children(c.Request.Context(), code)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, children)
+}
+
+// ProposeRates handles POST /api/v1/hsn/rates
+// @Summary Propose GST rates from HSN codes
+// @Description For an invoice being edited, sets each line item's CGST/SGST/IGST rates from the HSN master as of the invoice date and recomputes line and invoice totals. Same-state supplies get CGST+SGST, others IGST. Nothing is saved: apply the returned line_items and totals to structured_data before saving. Lines with several rates in force (status ambiguous) keep their rates; pick one of the listed rates
+// @Tags hsn
+// @Accept json
+// @Produce json
+// @Param request body EditStructuredDataRequest true "Structured data (GSTInvoice) as edited"
+// @Success 200 {object} Response{data=service.HSNRateProposal} "Line items and totals with proposed rates"
+// @Failure 400 {object} ErrorResponseBody "Invalid request or structured data"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /hsn/rates [post]
+func (h *HSNHandler) ProposeRates(c *gin.Context) {
+	var req struct {
+		StructuredData json.RawMessage `json:"structured_data" binding:"required"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	proposal, err := h.hsnService.ProposeRates(c.Request.Context(), req.StructuredData)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, proposal)
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// ImportHandler handles bulk import endpoints.
+type ImportHandler struct {
+	importService service.ImportService
+}
+
+// NewImportHandler creates a new ImportHandler.
+func NewImportHandler(importService service.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// parseImportOptions validates the document_type/parse_mode shared by both import sources.
+// Returns false if invalid (error response already written).
+func parseImportOptions(c *gin.Context, documentType string, parseMode *domain.ParseMode) bool {
+	if documentType == "" {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "document_type is required")
+		return false
+	}
+	if *parseMode == "" {
+		*parseMode = domain.ParseModeSingle
+	}
+	if !domain.ValidParseModes[*parseMode] {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "parse_mode must be 'single' or 'dual'")
+		return false
+	}
+	return true
+}
+
+// ImportZip handles POST /api/v1/collections/:id/imports
+// @Summary Import a ZIP archive into a collection
+// @Description Upload a ZIP of invoices. The server unpacks it asynchronously, creating a file and document for every supported entry; unsupported entries are skipped and listed in the job report. Poll the returned job for progress.
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param file formData file true "ZIP archive"
+// @Param document_type formData string true "Document type for every imported document" example(invoice)
+// @Param parse_mode formData string false "Parse mode" Enums(single, dual)
+// @Success 202 {object} Response{data=domain.ImportJob} "Import job accepted"
+// @Failure 400 {object} ErrorResponseBody "Invalid request or archive"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 413 {object} ErrorResponseBody "Archive too large"
+// @Security BearerAuth
+// @Router /collections/{id}/imports [post]
+func (h *ImportHandler) ImportZip(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		if isBodyTooLarge(err) {
+			respondBodyTooLarge(c)
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "MISSING_FILE", "file field is required")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	parseMode := domain.ParseMode(c.PostForm("parse_mode"))
+	documentType := c.PostForm("document_type")
+	if !parseImportOptions(c, documentType, &parseMode) {
+		return
+	}
+
+	job, err := h.importService.ImportZip(c.Request.Context(), &service.ZipImportInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		DocumentType: documentType,
+		ParseMode:    parseMode,
+		File:         file,
+		Header:       header,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{Success: true, Data: job})
+}
+
+// ImportS3Prefix handles POST /api/v1/collections/:id/imports/s3
+// @Summary Import files from the tenant S3 inbox
+// @Description Import every object under a prefix of the tenant inbox (tenants/{tenant_id}/inbox/) asynchronously. Source objects are left in place.
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body S3ImportRequest true "Inbox prefix and document options"
+// @Success 202 {object} Response{data=domain.ImportJob} "Import job accepted"
+// @Failure 400 {object} ErrorResponseBody "Invalid request or prefix"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Security BearerAuth
+// @Router /collections/{id}/imports/s3 [post]
+func (h *ImportHandler) ImportS3Prefix(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req S3ImportRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+	if !parseImportOptions(c, req.DocumentType, &req.ParseMode) {
+		return
+	}
+
+	job, err := h.importService.ImportS3Prefix(c.Request.Context(), &service.S3ImportInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         role,
+		DocumentType: req.DocumentType,
+		ParseMode:    req.ParseMode,
+		Prefix:       req.Prefix,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{Success: true, Data: job})
+}
+
+// List handles GET /api/v1/collections/:id/imports
+// @Summary List import jobs
+// @Description List bulk import jobs for a collection, newest first
+// @Tags imports
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.ImportJob,meta=PagMeta} "Import jobs"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Security BearerAuth
+// @Router /collections/{id}/imports [get]
+func (h *ImportHandler) List(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	jobs, total, err := h.importService.ListJobs(c.Request.Context(), tenantID, collectionID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, jobs, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// Get handles GET /api/v1/collections/:id/imports/:importId
+// @Summary Get an import job
+// @Description Get an import job's status, counters and per-file report
+// @Tags imports
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param importId path string true "Import job ID (UUID)"
+// @Success 200 {object} Response{data=domain.ImportJob} "Import job"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Import job not found"
+// @Security BearerAuth
+// @Router /collections/{id}/imports/{importId} [get]
+func (h *ImportHandler) Get(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+	jobID, err := uuid.Parse(c.Param("importId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid import ID")
+		return
+	}
+
+	job, err := h.importService.GetJob(c.Request.Context(), tenantID, collectionID, jobID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, job)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// InboxHandler handles the current user's in-app notification endpoints.
+type InboxHandler struct {
+	inboxService service.InboxService
+}
+
+// NewInboxHandler creates a new InboxHandler.
+func NewInboxHandler(inboxService service.InboxService) *InboxHandler {
+	return &InboxHandler{inboxService: inboxService}
+}
+
+// List handles GET /api/v1/users/me/notifications
+// @Summary List my notifications
+// @Description List the current user's in-app notifications, newest first
+// @Tags users
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.InAppNotification,meta=PagMeta} "Notifications"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /users/me/notifications [get]
+func (h *InboxHandler) List(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+	unreadOnly := c.Query("unread") == "true"
+
+	notifications, total, err := h.inboxService.List(c.Request.Context(), tenantID, userID, unreadOnly, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, notifications, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// MarkRead handles POST /api/v1/users/me/notifications/:id/read
+// @Summary Mark a notification read
+// @Description Mark one of the current user's in-app notifications read; marking it again is a no-op
+// @Tags users
+// @Produce json
+// @Param id path string true "Notification ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Notification marked read"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Notification not found"
+// @Security BearerAuth
+// @Router /users/me/notifications/{id}/read [post]
+func (h *InboxHandler) MarkRead(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid notification ID")
+		return
+	}
+
+	if err := h.inboxService.MarkRead(c.Request.Context(), tenantID, userID, notificationID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "notification marked read"})
+}
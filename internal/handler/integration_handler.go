@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// IntegrationHandler handles the endpoints used by no-code integrations (Zapier, Make).
+type IntegrationHandler struct {
+	integrationService service.IntegrationService
+}
+
+// NewIntegrationHandler creates a new IntegrationHandler.
+func NewIntegrationHandler(integrationService service.IntegrationService) *IntegrationHandler {
+	return &IntegrationHandler{integrationService: integrationService}
+}
+
+// ListApproved handles GET /api/v1/integrations/documents/approved
+// @Summary Poll approved documents
+// @Description Approved documents the caller can view, as flat invoice JSON. Without a cursor, returns the most recently approved documents, newest first (for polling triggers that dedupe by id). With the next_cursor of an earlier response, returns documents approved after it, oldest first.
+// @Tags integrations
+// @Produce json
+// @Param cursor query string false "next_cursor from an earlier response"
+// @Param collection_id query string false "Only documents in this collection"
+// @Param limit query int false "Page size (max 100)" default(50)
+// @Success 200 {object} Response{data=service.ApprovedDocumentsPage} "Approved documents"
+// @Failure 400 {object} ErrorResponseBody "Invalid cursor or collection ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "No access to the collection"
+// @Security BearerAuth
+// @Router /integrations/documents/approved [get]
+func (h *IntegrationHandler) ListApproved(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	input := service.ListApprovedInput{TenantID: tenantID, UserID: userID, Role: role, Cursor: c.Query("cursor")}
+	if raw := c.Query("collection_id"); raw != "" {
+		collectionID, err := uuid.Parse(raw)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+			return
+		}
+		input.CollectionID = &collectionID
+	}
+	input.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	page, err := h.integrationService.ListApproved(c.Request.Context(), &input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, page)
+}
+
+// Subscribe handles POST /api/v1/integrations/hooks
+// @Summary Subscribe a REST hook
+// @Description Register a target URL that receives each newly approved document as flat invoice JSON (event document.approved), or as the JSON its optional payload_template (Go text/template over the flat document) renders. Deliveries only include documents the caller can view. A target that answers 410 Gone is unsubscribed.
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param body body service.SubscribeHookInput true "Hook subscription"
+// @Success 201 {object} Response{data=domain.IntegrationHook} "Hook created"
+// @Failure 400 {object} ErrorResponseBody "Invalid event, target URL, payload template or collection"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "No access to the collection"
+// @Security BearerAuth
+// @Router /integrations/hooks [post]
+func (h *IntegrationHandler) Subscribe(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var input service.SubscribeHookInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+	input.TenantID, input.UserID, input.Role = tenantID, userID, role
+
+	hook, err := h.integrationService.Subscribe(c.Request.Context(), &input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondCreated(c, hook)
+}
+
+// ListHooks handles GET /api/v1/integrations/hooks
+// @Summary List REST hooks
+// @Description List the caller's REST hook subscriptions, oldest first.
+// @Tags integrations
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.IntegrationHook} "Hooks"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /integrations/hooks [get]
+func (h *IntegrationHandler) ListHooks(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	hooks, err := h.integrationService.ListHooks(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, hooks)
+}
+
+// SetHookTemplate handles PUT /api/v1/integrations/hooks/:id/template
+// @Summary Set a REST hook's payload template
+// @Description Replace the Go text/template that renders the hook's delivered body over the flat document; null or "" delivers the flat document again. The template must render JSON for a sample document. Users can change their own hooks; admins can change any hook in the tenant.
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Hook ID (UUID)"
+// @Param body body service.SetHookTemplateInput true "Payload template"
+// @Success 200 {object} Response{data=domain.IntegrationHook} "Hook updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or payload template"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Hook not found"
+// @Security BearerAuth
+// @Router /integrations/hooks/{id}/template [put]
+func (h *IntegrationHandler) SetHookTemplate(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	hookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid hook ID")
+		return
+	}
+
+	var input service.SetHookTemplateInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	hook, err := h.integrationService.SetHookTemplate(c.Request.Context(), tenantID, hookID, userID, role, &input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, hook)
+}
+
+// Unsubscribe handles DELETE /api/v1/integrations/hooks/:id
+// @Summary Unsubscribe a REST hook
+// @Description Delete a REST hook subscription. Users can delete their own hooks; admins can delete any hook in the tenant.
+// @Tags integrations
+// @Produce json
+// @Param id path string true "Hook ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Hook deleted"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Hook not found"
+// @Security BearerAuth
+// @Router /integrations/hooks/{id} [delete]
+func (h *IntegrationHandler) Unsubscribe(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	hookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid hook ID")
+		return
+	}
+
+	if err := h.integrationService.Unsubscribe(c.Request.Context(), tenantID, hookID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "hook deleted"})
+}
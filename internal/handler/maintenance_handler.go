@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/middleware"
+)
+
+// MaintenanceHandler exposes the maintenance-mode switch.
+type MaintenanceHandler struct {
+	mode *middleware.MaintenanceMode
+}
+
+// NewMaintenanceHandler creates a new MaintenanceHandler.
+func NewMaintenanceHandler(mode *middleware.MaintenanceMode) *MaintenanceHandler {
+	return &MaintenanceHandler{mode: mode}
+}
+
+// MaintenanceStatus is the maintenance-mode state returned by the admin API.
+type MaintenanceStatus struct {
+	Enabled        bool `json:"enabled"`
+	RetryAfterSecs int  `json:"retry_after_secs"`
+}
+
+// Get handles GET /api/v1/admin/maintenance
+// @Summary Get maintenance mode
+// @Description Report whether maintenance mode is enabled on this instance (admin only)
+// @Tags admin
+// @Produce json
+// @Success 200 {object} Response{data=MaintenanceStatus} "Maintenance status"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/maintenance [get]
+func (h *MaintenanceHandler) Get(c *gin.Context) {
+	RespondOK(c, MaintenanceStatus{Enabled: h.mode.Enabled(), RetryAfterSecs: h.mode.RetryAfterSecs()})
+}
+
+// Set handles PUT /api/v1/admin/maintenance
+// @Summary Toggle maintenance mode
+// @Description Enable or disable maintenance mode on this instance. While enabled, writes return 503 with Retry-After; reads keep working (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body SetMaintenanceRequest true "Maintenance state"
+// @Success 200 {object} Response{data=MaintenanceStatus} "Maintenance status"
+// @Failure 400 {object} ErrorResponseBody "Invalid request"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/maintenance [put]
+func (h *MaintenanceHandler) Set(c *gin.Context) {
+	var req SetMaintenanceRequest
+	if !bindJSON(c, &req, "VALIDATION_ERROR") {
+		return
+	}
+
+	h.mode.Set(*req.Enabled)
+	RespondOK(c, MaintenanceStatus{Enabled: h.mode.Enabled(), RetryAfterSecs: h.mode.RetryAfterSecs()})
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// NotificationHandler handles a collection's Slack / Microsoft Teams notification channels.
+type NotificationHandler struct {
+	notificationService service.NotificationService
+}
+
+// NewNotificationHandler creates a new NotificationHandler.
+func NewNotificationHandler(notificationService service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// TestNotificationRequest is the optional body of a test delivery.
+type TestNotificationRequest struct {
+	// Event previews that event's template with sample data; empty sends a plain test message.
+	Event domain.NotificationEvent `json:"event"`
+}
+
+func parseChannelParams(c *gin.Context) (collectionID, channelID uuid.UUID, ok bool) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	channelID, err = uuid.Parse(c.Param("channelId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid channel ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return collectionID, channelID, true
+}
+
+// List handles GET /api/v1/collections/:id/notification-channels
+// @Summary List notification channels
+// @Description List the collection's Slack / Teams channels. Webhook URLs are never returned, only their host.
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 200 {object} Response{data=[]domain.NotificationChannel} "Channels"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/notification-channels [get]
+func (h *NotificationHandler) List(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	channels, err := h.notificationService.ListChannels(c.Request.Context(), tenantID, collectionID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, channels)
+}
+
+// Create handles POST /api/v1/collections/:id/notification-channels
+// @Summary Add a notification channel
+// @Description Post the collection's events (parse_failed, document_assigned, review_sla_breached, review_escalated, weekly_summary) to a Slack or Teams incoming webhook. Templates optionally override each event's message (Go text/template).
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body service.NotificationChannelInput true "Channel settings"
+// @Success 201 {object} Response{data=domain.NotificationChannel} "Channel created"
+// @Failure 400 {object} ErrorResponseBody "Invalid channel or template"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/notification-channels [post]
+func (h *NotificationHandler) Create(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var input service.NotificationChannelInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	ch, err := h.notificationService.CreateChannel(c.Request.Context(), tenantID, collectionID, userID, role, &input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondCreated(c, ch)
+}
+
+// Update handles PUT /api/v1/collections/:id/notification-channels/:channelId
+// @Summary Replace a notification channel's settings
+// @Description Replace provider, events, templates, SLA and enabled flag. Omit webhook_url to keep the current URL.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param channelId path string true "Channel ID (UUID)"
+// @Param request body service.NotificationChannelInput true "Channel settings"
+// @Success 200 {object} Response{data=domain.NotificationChannel} "Channel updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid channel or template"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Channel not found"
+// @Security BearerAuth
+// @Router /collections/{id}/notification-channels/{channelId} [put]
+func (h *NotificationHandler) Update(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+	collectionID, channelID, ok := parseChannelParams(c)
+	if !ok {
+		return
+	}
+
+	var input service.NotificationChannelInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	ch, err := h.notificationService.UpdateChannel(c.Request.Context(), tenantID, collectionID, channelID, userID, role, &input)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, ch)
+}
+
+// Delete handles DELETE /api/v1/collections/:id/notification-channels/:channelId
+// @Summary Delete a notification channel
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param channelId path string true "Channel ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Channel deleted"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Channel not found"
+// @Security BearerAuth
+// @Router /collections/{id}/notification-channels/{channelId} [delete]
+func (h *NotificationHandler) Delete(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+	collectionID, channelID, ok := parseChannelParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notificationService.DeleteChannel(c.Request.Context(), tenantID, collectionID, channelID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "notification channel deleted"})
+}
+
+// Test handles POST /api/v1/collections/:id/notification-channels/:channelId/test
+// @Summary Send a test notification
+// @Description Post a message to the channel right away. With an event, that event's template is rendered with sample data.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param channelId path string true "Channel ID (UUID)"
+// @Param request body TestNotificationRequest false "Event to preview"
+// @Success 200 {object} Response{data=MessageResponse} "Test message delivered"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or event"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Channel not found"
+// @Failure 502 {object} ErrorResponseBody "Slack / Teams rejected the message"
+// @Security BearerAuth
+// @Router /collections/{id}/notification-channels/{channelId}/test [post]
+func (h *NotificationHandler) Test(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+	collectionID, channelID, ok := parseChannelParams(c)
+	if !ok {
+		return
+	}
+
+	var req TestNotificationRequest
+	if !bindOptionalJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	if err := h.notificationService.TestChannel(c.Request.Context(), tenantID, collectionID, channelID, userID, role, req.Event); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "test notification delivered"})
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// NotificationRouteHandler handles per-tenant user notification routing endpoints.
+type NotificationRouteHandler struct {
+	routeService service.NotificationRouteService
+}
+
+// NewNotificationRouteHandler creates a new NotificationRouteHandler.
+func NewNotificationRouteHandler(routeService service.NotificationRouteService) *NotificationRouteHandler {
+	return &NotificationRouteHandler{routeService: routeService}
+}
+
+// Get handles GET /api/v1/admin/tenants/:id/notification-routes
+// @Summary Get notification routes
+// @Description List the channels each user notification kind is delivered on for the tenant, and whether the tenant overrides the server default (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Success 200 {object} Response{data=[]service.NotificationKindRoute} "Notification routes"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Tenant not found"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/notification-routes [get]
+func (h *NotificationRouteHandler) Get(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	routes, err := h.routeService.Get(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, routes)
+}
+
+// Set handles PUT /api/v1/admin/tenants/:id/notification-routes
+// @Summary Set notification routes
+// @Description Replace the tenant's notification routing. routes maps a kind (email_verification, password_reset, ingestion_report) to its channels (email, slack, webhook, in_app); kinds left out use the server default. Email verification and password reset must include email, the only channel given their links. Slack and webhook must be configured on the server (admin only)
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param request body SetNotificationRoutesRequest true "Notification routes"
+// @Success 200 {object} Response{data=[]service.NotificationKindRoute} "Notification routes updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or routes"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Tenant not found"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/notification-routes [put]
+func (h *NotificationRouteHandler) Set(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	var req struct {
+		Routes map[domain.NotificationKind][]domain.NotifierChannel `json:"routes" binding:"required"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	routes, err := h.routeService.Set(c.Request.Context(), tenantID, req.Routes)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, routes)
+}
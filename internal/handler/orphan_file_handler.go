@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+const (
+	// defaultOrphanFileDays is how old an upload must be to count as orphaned
+	// when the request doesn't say.
+	defaultOrphanFileDays = 7
+	maxOrphanFileDays     = 365
+)
+
+// OrphanFileHandler handles endpoints for files uploaded but never made documents.
+type OrphanFileHandler struct {
+	orphanService service.OrphanFileService
+}
+
+// NewOrphanFileHandler creates a new OrphanFileHandler.
+func NewOrphanFileHandler(orphanService service.OrphanFileService) *OrphanFileHandler {
+	return &OrphanFileHandler{orphanService: orphanService}
+}
+
+// validOrphanFileDays reports whether days is an accepted age cutoff, writing
+// the error response when it isn't.
+func validOrphanFileDays(c *gin.Context, days int) bool {
+	if days < 0 || days > maxOrphanFileDays {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "days must be between 0 and 365")
+		return false
+	}
+	return true
+}
+
+// parseOrphanFileDays reads the days query parameter. Returns false if invalid
+// (error response already written).
+func parseOrphanFileDays(c *gin.Context) (int, bool) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(defaultOrphanFileDays)))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "days must be between 0 and 365")
+		return 0, false
+	}
+	return days, validOrphanFileDays(c, days)
+}
+
+// Summary handles GET /api/v1/reports/orphan-files
+// @Summary Orphan files by collection
+// @Description Count, per collection, the files uploaded more than days days ago that never became a document: no document was created for them, nor later moved off them. Collections are listed with the most orphans first, with each collection's creator and oldest orphan upload (manager or admin)
+// @Tags reports
+// @Produce json
+// @Param days query int false "Minimum age of an upload in days (0-365)" default(7)
+// @Success 200 {object} Response{data=[]domain.OrphanFileSummary} "Orphan files per collection"
+// @Failure 400 {object} ErrorResponseBody "Invalid days"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - manager or admin only"
+// @Security BearerAuth
+// @Router /reports/orphan-files [get]
+func (h *OrphanFileHandler) Summary(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	days, ok := parseOrphanFileDays(c)
+	if !ok {
+		return
+	}
+
+	summaries, err := h.orphanService.Summary(c.Request.Context(), tenantID, days)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, summaries)
+}
+
+// List handles GET /api/v1/collections/:id/orphan-files
+// @Summary List a collection's orphan files
+// @Description List the collection's files uploaded more than days days ago that never became a document, oldest first. Requires viewer permission
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param days query int false "Minimum age of an upload in days (0-365)" default(7)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.FileMeta,meta=PagMeta} "Orphan files"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or days"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Security BearerAuth
+// @Router /collections/{id}/orphan-files [get]
+func (h *OrphanFileHandler) List(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	days, ok := parseOrphanFileDays(c)
+	if !ok {
+		return
+	}
+	offset, limit := parsePagination(c)
+
+	files, total, err := h.orphanService.List(c.Request.Context(), tenantID, collectionID, userID, role, days, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, files, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// CreateDocuments handles POST /api/v1/collections/:id/orphan-files/documents
+// @Summary Create documents for a collection's orphan files
+// @Description Create and parse a document for each of the collection's files uploaded more than days days ago (default 7) that never became a document, up to 500 per call; remaining counts the rest, left for the next call. Each file's outcome is reported as in an import job. Once the caller's quota or the tenant's parse budget runs out, the remaining files are reported failed. Requires editor permission
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param request body OrphanDocumentsRequest true "Document options and minimum upload age"
+// @Success 200 {object} Response{data=domain.OrphanDocumentRun} "Per-file results"
+// @Failure 400 {object} ErrorResponseBody "Invalid request"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/orphan-files/documents [post]
+func (h *OrphanFileHandler) CreateDocuments(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	var req OrphanDocumentsRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+	if !parseImportOptions(c, req.DocumentType, &req.ParseMode) {
+		return
+	}
+	days := defaultOrphanFileDays
+	if req.Days != nil {
+		days = *req.Days
+	}
+	if !validOrphanFileDays(c, days) {
+		return
+	}
+
+	run, err := h.orphanService.CreateDocuments(c.Request.Context(), &service.OrphanDocumentsInput{
+		TenantID:      tenantID,
+		CollectionID:  collectionID,
+		UserID:        userID,
+		Role:          role,
+		DocumentType:  req.DocumentType,
+		ParseMode:     req.ParseMode,
+		OlderThanDays: days,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, run)
+}
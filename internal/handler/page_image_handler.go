@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// PageImageHandler serves rendered page images of files.
+type PageImageHandler struct {
+	pageImageService  service.PageImageService
+	collectionService service.CollectionService
+}
+
+// NewPageImageHandler creates a new PageImageHandler.
+func NewPageImageHandler(pageImageService service.PageImageService, collectionService service.CollectionService) *PageImageHandler {
+	return &PageImageHandler{pageImageService: pageImageService, collectionService: collectionService}
+}
+
+// GetPageImage handles GET /api/v1/files/:id/pages/:n/image
+// @Summary Get a page of a file as an image
+// @Description Render one page of a file as PNG so the review UI can show the page a field came from. PDF pages are rendered at the requested DPI and cached; JPG and PNG files have a single page, returned at their own resolution. A page image is a copy of the original, so a collection restricting downloads of original files restricts it too.
+// @Tags files
+// @Produce image/png
+// @Param id path string true "File ID (UUID)"
+// @Param n path int true "Page number, starting at 1"
+// @Param dpi query int false "Resolution for PDF pages (default 150, 36 to the configured maximum)"
+// @Success 200 {file} binary "PNG image"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID, page or DPI, or a TIFF file"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "A collection holding the file restricts downloads"
+// @Failure 404 {object} ErrorResponseBody "File or page not found"
+// @Failure 503 {object} ErrorResponseBody "Page rendering not available"
+// @Security BearerAuth
+// @Router /files/{id}/pages/{n}/image [get]
+func (h *PageImageHandler) GetPageImage(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	fileID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid file ID")
+		return
+	}
+	page, err := strconv.Atoi(c.Param("n"))
+	if err != nil || page < 1 {
+		RespondError(c, http.StatusBadRequest, "INVALID_PAGE", "page must be a positive integer")
+		return
+	}
+	dpi := 0
+	if raw := c.Query("dpi"); raw != "" {
+		if dpi, err = strconv.Atoi(raw); err != nil {
+			RespondError(c, http.StatusBadRequest, "INVALID_DPI", "dpi must be an integer")
+			return
+		}
+	}
+
+	if err := h.collectionService.CheckFileDownload(c.Request.Context(), tenantID, fileID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	img, err := h.pageImageService.GetPageImage(c.Request.Context(), &service.PageImageInput{
+		TenantID: tenantID,
+		UserID:   userID,
+		Role:     role,
+		FileID:   fileID,
+		Page:     page,
+		DPI:      dpi,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	// A stored file never changes, so neither does its page image
+	c.Header("Cache-Control", "private, max-age=86400")
+	c.Data(http.StatusOK, "image/png", img)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// defaultPreviewDocumentType is used when the form omits document_type.
+const defaultPreviewDocumentType = "invoice"
+
+// ParsePreviewHandler handles dry-run parsing.
+type ParsePreviewHandler struct {
+	previewService service.ParsePreviewService
+}
+
+// NewParsePreviewHandler creates a new ParsePreviewHandler.
+func NewParsePreviewHandler(previewService service.ParsePreviewService) *ParsePreviewHandler {
+	return &ParsePreviewHandler{previewService: previewService}
+}
+
+// Preview handles POST /api/v1/parse/preview
+// @Summary Parse a file without storing it
+// @Description Parse and validate a file synchronously and return the extracted data. No file or document is created. Counts against the monthly document quota and is rate-limited per user.
+// @Tags parse
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File to parse (PDF, JPG, PNG or TIFF)"
+// @Param document_type formData string false "Document type (default invoice)"
+// @Success 200 {object} Response{data=service.ParsePreviewResult} "Extracted data, confidence scores and validation results"
+// @Failure 400 {object} ErrorResponseBody "Missing file or unsupported type"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 413 {object} ErrorResponseBody "File too large"
+// @Failure 422 {object} ErrorResponseBody "Document could not be parsed"
+// @Failure 402 {object} ErrorResponseBody "Daily parse budget exhausted"
+// @Failure 429 {object} ErrorResponseBody "Rate limited or quota exceeded"
+// @Failure 503 {object} ErrorResponseBody "Parser temporarily unavailable"
+// @Security BearerAuth
+// @Router /parse/preview [post]
+func (h *ParsePreviewHandler) Preview(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		if isBodyTooLarge(err) {
+			respondBodyTooLarge(c)
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "MISSING_FILE", "file field is required")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		if isBodyTooLarge(err) {
+			respondBodyTooLarge(c)
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "could not read file")
+		return
+	}
+
+	documentType := c.PostForm("document_type")
+	if documentType == "" {
+		documentType = defaultPreviewDocumentType
+	}
+
+	result, err := h.previewService.Preview(c.Request.Context(), &service.ParsePreviewInput{
+		TenantID:     tenantID,
+		UserID:       userID,
+		FileName:     header.Filename,
+		Content:      content,
+		DocumentType: documentType,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, result)
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// ParseQueueHandler exposes the parse queue worker's state.
+type ParseQueueHandler struct {
+	queue service.ParseQueueMonitor
+}
+
+// NewParseQueueHandler creates a new ParseQueueHandler.
+func NewParseQueueHandler(queue service.ParseQueueMonitor) *ParseQueueHandler {
+	return &ParseQueueHandler{queue: queue}
+}
+
+// Status handles GET /api/v1/admin/parse-queue
+// @Summary Get parse queue status
+// @Description Report this instance's parse queue worker: concurrency, parses in flight, whether dispatch is being slowed, and each parser provider's rate limit headroom from its last response (admin only). Only the instance running the worker has headroom for queued parses.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} Response{data=service.ParseQueueStatus} "Parse queue status"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/parse-queue [get]
+func (h *ParseQueueHandler) Status(c *gin.Context) {
+	RespondOK(c, h.queue.Status())
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// maxQAScoreWindowDays caps the QA score lookback (two years).
+const maxQAScoreWindowDays = 730
+
+// QAReviewHandler serves the second-opinion QA queue, verdicts and reviewer scores.
+type QAReviewHandler struct {
+	qaService service.QAReviewService
+}
+
+// NewQAReviewHandler creates a new QAReviewHandler.
+func NewQAReviewHandler(qaService service.QAReviewService) *QAReviewHandler {
+	return &QAReviewHandler{qaService: qaService}
+}
+
+// Queue handles GET /api/v1/qa-reviews/queue
+// @Summary List the QA queue
+// @Description List approvals sampled for a blind second review that the caller may give: never their own approvals (or ones they made under maker-checker). Managers and admins see every collection, others the collections they own. The samples show the document as approved, but not who approved it or their notes. Oldest sample first.
+// @Tags qa-reviews
+// @Produce json
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.QASample,meta=PagMeta} "Pending QA samples"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /qa-reviews/queue [get]
+func (h *QAReviewHandler) Queue(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	samples, total, err := h.qaService.ListQueue(c.Request.Context(), tenantID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, samples, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// SubmitVerdict handles POST /api/v1/qa-reviews/:id/verdict
+// @Summary Submit a QA verdict
+// @Description Give the second opinion on a QA sample: the decision the caller would have made and the field paths they would have changed. A rejection or any disputed field counts as a disagreement against the approving reviewer. Requires editor permission on the collection; the approving reviewer and maker can't give it.
+// @Tags qa-reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "QA review ID (UUID)"
+// @Param request body SubmitQAVerdictRequest true "Verdict"
+// @Success 200 {object} Response{data=domain.QAReview} "QA review completed"
+// @Failure 400 {object} ErrorResponseBody "Invalid verdict"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission or own approval"
+// @Failure 404 {object} ErrorResponseBody "QA review not found"
+// @Failure 409 {object} ErrorResponseBody "QA review already has a verdict"
+// @Security BearerAuth
+// @Router /qa-reviews/{id}/verdict [post]
+func (h *QAReviewHandler) SubmitVerdict(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid QA review ID")
+		return
+	}
+
+	var req SubmitQAVerdictRequest
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	review, err := h.qaService.SubmitVerdict(c.Request.Context(), &service.SubmitQAVerdictInput{
+		TenantID:       tenantID,
+		ReviewID:       reviewID,
+		UserID:         userID,
+		Role:           role,
+		Decision:       req.Decision,
+		DisputedFields: req.DisputedFields,
+		Notes:          req.Notes,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, review)
+}
+
+// Scores handles GET /api/v1/qa-reviews/scores
+// @Summary Reviewer QA scores
+// @Description Per approving reviewer, over the window: approvals sampled, samples with a second opinion, disagreements, and agreement_rate (agreed / completed; null until a sample is completed). Manager or admin only.
+// @Tags qa-reviews
+// @Produce json
+// @Param window_days query int false "Lookback window in days (1-730)" default(90)
+// @Success 200 {object} Response{data=[]domain.QAReviewerScore} "Reviewer scores"
+// @Failure 400 {object} ErrorResponseBody "Invalid window"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - manager or admin only"
+// @Security BearerAuth
+// @Router /qa-reviews/scores [get]
+func (h *QAReviewHandler) Scores(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("window_days", "90"))
+	if err != nil || days < 1 || days > maxQAScoreWindowDays {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "window_days must be between 1 and 730")
+		return
+	}
+
+	scores, err := h.qaService.Scores(c.Request.Context(), tenantID, time.Duration(days)*24*time.Hour)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, scores)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// RejectionReasonHandler handles the tenant's rejection-reason taxonomy and its report.
+type RejectionReasonHandler struct {
+	reasonService service.RejectionReasonService
+}
+
+// NewRejectionReasonHandler creates a new RejectionReasonHandler.
+func NewRejectionReasonHandler(reasonService service.RejectionReasonService) *RejectionReasonHandler {
+	return &RejectionReasonHandler{reasonService: reasonService}
+}
+
+// List handles GET /api/v1/rejection-reasons
+// @Summary List rejection reasons
+// @Description The tenant's rejection-reason taxonomy in display order: the built-in reasons (overridden=false unless relabeled) and tenant-specific ones. Rejecting a document requires one of the active codes.
+// @Tags documents
+// @Produce json
+// @Param include_inactive query bool false "Include retired reasons"
+// @Success 200 {object} Response{data=[]domain.RejectionReason} "Rejection reasons"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /rejection-reasons [get]
+func (h *RejectionReasonHandler) List(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	reasons, err := h.reasonService.List(c.Request.Context(), tenantID, c.Query("include_inactive") == "true")
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, reasons)
+}
+
+// Set handles PUT /api/v1/rejection-reasons/:code
+// @Summary Set a rejection reason
+// @Description Relabel or retire a built-in reason, or add a tenant-specific one (admin only). Reasons can't be deleted, so reports keep their labels; set active=false to retire one.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param code path string true "Reason code (lowercase letters, digits, underscores)"
+// @Param request body service.SetRejectionReasonInput true "Reason settings"
+// @Success 200 {object} Response{data=domain.RejectionReason} "Rejection reason saved"
+// @Failure 400 {object} ErrorResponseBody "Invalid code, label or description"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /rejection-reasons/{code} [put]
+func (h *RejectionReasonHandler) Set(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var input service.SetRejectionReasonInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	reason, err := h.reasonService.Set(c.Request.Context(), tenantID, c.Param("code"), &input, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, reason)
+}
+
+// Stats handles GET /api/v1/reports/rejection-reasons
+// @Summary      Rejection reasons report
+// @Description  Currently rejected documents counted by reason code and parser model, most frequent first. Dates filter on the review date. reason_code "" counts rejections recorded before reason codes were required.
+// @Tags         reports
+// @Produce      json
+// @Param        from query string false "Reviewed on or after (YYYY-MM-DD)"
+// @Param        to query string false "Reviewed on or before (YYYY-MM-DD)"
+// @Param        collection_id query string false "Collection UUID"
+// @Success      200 {object} APIResponse{data=[]domain.RejectionReasonStat}
+// @Failure      400 {object} APIResponse
+// @Failure      401 {object} APIResponse
+// @Failure      500 {object} APIResponse
+// @Security     BearerAuth
+// @Router       /reports/rejection-reasons [get]
+func (h *RejectionReasonHandler) Stats(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	filters, err := parseReportFilters(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	stats, err := h.reasonService.Stats(c.Request.Context(), tenantID, filters)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, stats)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// RelatedPartyHandler handles the tenant's related-party GSTINs.
+type RelatedPartyHandler struct {
+	relatedPartyService service.RelatedPartyService
+}
+
+// NewRelatedPartyHandler creates a new RelatedPartyHandler.
+func NewRelatedPartyHandler(relatedPartyService service.RelatedPartyService) *RelatedPartyHandler {
+	return &RelatedPartyHandler{relatedPartyService: relatedPartyService}
+}
+
+// List handles GET /api/v1/related-parties
+// @Summary List related parties
+// @Description The GSTINs the tenant registered as its own (relationship own) or its group companies' (group), by GSTIN. Invoices from them fail the logic.seller.related_party warning and get a related_party tag.
+// @Tags documents
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.RelatedParty} "Related parties"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /related-parties [get]
+func (h *RelatedPartyHandler) List(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	parties, err := h.relatedPartyService.List(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, parties)
+}
+
+// Set handles PUT /api/v1/related-parties/:gstin
+// @Summary Register a related party
+// @Description Register or update a GSTIN of the tenant's own or a group company (admin only). Documents parsed or edited from then on are flagged; revalidate older ones with a validation run.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param gstin path string true "15-character GSTIN"
+// @Param request body service.SetRelatedPartyInput true "Name and relationship"
+// @Success 200 {object} Response{data=domain.RelatedParty} "Related party saved"
+// @Failure 400 {object} ErrorResponseBody "Invalid GSTIN, name or relationship"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /related-parties/{gstin} [put]
+func (h *RelatedPartyHandler) Set(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var input service.SetRelatedPartyInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	party, err := h.relatedPartyService.Set(c.Request.Context(), tenantID, c.Param("gstin"), &input, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, party)
+}
+
+// Delete handles DELETE /api/v1/related-parties/:gstin
+// @Summary Remove a related party
+// @Description Stop flagging invoices from the GSTIN (admin only)
+// @Tags documents
+// @Produce json
+// @Param gstin path string true "15-character GSTIN"
+// @Success 200 {object} Response{data=MessageResponse} "Related party removed"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "GSTIN is not a related party"
+// @Security BearerAuth
+// @Router /related-parties/{gstin} [delete]
+func (h *RelatedPartyHandler) Delete(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.relatedPartyService.Delete(c.Request.Context(), tenantID, c.Param("gstin")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "related party removed"})
+}
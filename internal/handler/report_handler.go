@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -225,6 +226,92 @@ func (h *ReportHandler) PartyLedger(c *gin.Context) {
 	RespondPaginated(c, rows, PagMeta{Total: total, Offset: filters.Offset, Limit: filters.Limit})
 }
 
+// SearchByAmount handles GET /api/v1/documents/search/amount
+// @Summary      Search documents by amount
+// @Description  Finds documents whose grand total matches an amount within a tolerance, closest first, for matching bank payments and payment advices against invoices. tolerance is an absolute amount ("50") or a percentage of value ("1%", sent as 1%25); it defaults to an exact match. party matches the seller or buyer GSTIN exactly, or either name by substring
+// @Tags         documents
+// @Produce      json
+// @Param        value query number true "Amount to match"
+// @Param        tolerance query string false "Absolute amount or percentage of value, e.g. 50 or 1%" default(0)
+// @Param        party query string false "Seller or buyer GSTIN, or part of either name"
+// @Param        from query string false "Invoice date from (YYYY-MM-DD)"
+// @Param        to query string false "Invoice date to (YYYY-MM-DD)"
+// @Param        collection_id query string false "Collection UUID"
+// @Param        offset query int false "Pagination offset" default(0)
+// @Param        limit query int false "Pagination limit" default(20)
+// @Success      200 {object} APIResponse{data=[]domain.AmountMatchRow,meta=PagMeta}
+// @Failure      400 {object} APIResponse
+// @Failure      401 {object} APIResponse
+// @Failure      500 {object} APIResponse
+// @Security     BearerAuth
+// @Router       /documents/search/amount [get]
+func (h *ReportHandler) SearchByAmount(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	amount, err := strconv.ParseFloat(c.Query("value"), 64)
+	if err != nil {
+		HandleError(c, domain.ErrInvalidAmountSearch)
+		return
+	}
+	tolerance, err := parseAmountTolerance(rawQueryValue(c, "tolerance"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	filters, err := parseReportFilters(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	rows, total, err := h.reportService.SearchByAmount(c.Request.Context(), tenantID, amount, tolerance, c.Query("party"), filters)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, rows, PagMeta{Total: total, Offset: filters.Offset, Limit: filters.Limit})
+}
+
+// parseAmountTolerance parses "50" as an absolute tolerance and "1%" as a
+// percentage of the searched amount. Empty means an exact match.
+func parseAmountTolerance(s string) (domain.AmountTolerance, error) {
+	var t domain.AmountTolerance
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return t, nil
+	}
+	if strings.HasSuffix(s, "%") {
+		t.Percent = true
+		s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return t, domain.ErrInvalidAmountSearch
+	}
+	t.Value = v
+	return t, nil
+}
+
+// rawQueryValue returns a query parameter, falling back to the raw query when
+// it isn't validly escaped: a bare "tolerance=1%" fails URL decoding, and
+// net/url drops such parameters silently.
+func rawQueryValue(c *gin.Context, key string) string {
+	if v, ok := c.GetQuery(key); ok {
+		return v
+	}
+	for _, pair := range strings.Split(c.Request.URL.RawQuery, "&") {
+		if k, v, found := strings.Cut(pair, "="); found && k == key {
+			return v
+		}
+	}
+	return ""
+}
+
 // FinancialSummary handles GET /api/v1/reports/financial-summary
 // @Summary      Financial summary report
 // @Description  Time-series financial summary with totals per period
@@ -4,12 +4,15 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"satvos/internal/apierror"
 	"satvos/internal/domain"
 	"satvos/internal/middleware"
+	"satvos/internal/service"
 )
 
 // APIResponse is the standard envelope for all API responses.
@@ -20,10 +23,14 @@ type APIResponse struct {
 	Meta    *PagMeta    `json:"meta,omitempty"`
 }
 
-// APIError holds error details in the response.
+// APIError holds error details in the response. Retryable and DocsURL come from
+// the error catalogue (apierror.Catalogue); Errors lists the invalid fields of a rejected request body, when known.
 type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code      string                `json:"code"`
+	Message   string                `json:"message"`
+	Retryable bool                  `json:"retryable"`
+	DocsURL   string                `json:"docs_url"`
+	Errors    []apierror.FieldError `json:"errors,omitempty"`
 }
 
 // PagMeta holds pagination metadata.
@@ -48,12 +55,15 @@ func RespondPaginated(c *gin.Context, data interface{}, meta PagMeta) {
 	c.JSON(http.StatusOK, APIResponse{Success: true, Data: data, Meta: &meta})
 }
 
-// RespondError sends an error response with the given status code.
+// RespondError sends an error response with the given status code: the standard
+// envelope, or RFC 7807 problem details when the client accepts them.
 func RespondError(c *gin.Context, status int, code, msg string) {
-	c.JSON(status, APIResponse{
-		Success: false,
-		Error:   &APIError{Code: code, Message: msg},
-	})
+	apierror.Respond(c, status, code, msg)
+}
+
+// RespondFieldErrors is RespondError with per-field details in the errors array.
+func RespondFieldErrors(c *gin.Context, status int, code, msg string, fields ...apierror.FieldError) {
+	apierror.RespondFields(c, status, code, msg, fields)
 }
 
 // MapDomainError translates domain errors to HTTP status codes and error codes.
@@ -72,47 +82,229 @@ func MapDomainError(err error) (status int, code, msg string) {
 	case errors.Is(err, domain.ErrUserInactive):
 		return http.StatusForbidden, "USER_INACTIVE", "user is inactive"
 	case errors.Is(err, domain.ErrUnsupportedFileType):
-		return http.StatusBadRequest, "UNSUPPORTED_FILE_TYPE", "unsupported file type; allowed: pdf, jpg, png"
+		return http.StatusBadRequest, "UNSUPPORTED_FILE_TYPE", "unsupported file type; allowed: pdf, jpg, png, tiff"
 	case errors.Is(err, domain.ErrFileTooLarge):
 		return http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "file exceeds maximum allowed size"
+	case errors.Is(err, domain.ErrFileContentMismatch):
+		return http.StatusBadRequest, "FILE_CONTENT_MISMATCH", "file content does not match its declared type"
+	case errors.Is(err, domain.ErrPDFEncrypted):
+		return http.StatusUnprocessableEntity, "PDF_ENCRYPTED", "PDF is encrypted or password-protected; upload an unlocked copy"
+	case errors.Is(err, domain.ErrPDFNoPages):
+		return http.StatusUnprocessableEntity, "PDF_NO_PAGES", "PDF has no pages"
+	case errors.Is(err, domain.ErrInvalidArchive):
+		return http.StatusBadRequest, "INVALID_ARCHIVE", "file is not a valid ZIP archive"
+	case errors.Is(err, domain.ErrInvalidImportPrefix):
+		return http.StatusBadRequest, "INVALID_IMPORT_PREFIX", "prefix must be a relative path inside the tenant inbox"
+	case errors.Is(err, domain.ErrCloudProviderNotConfigured):
+		return http.StatusNotFound, "CLOUD_PROVIDER_NOT_CONFIGURED", "cloud storage provider is not configured"
+	case errors.Is(err, domain.ErrInvalidOAuthState):
+		return http.StatusBadRequest, "INVALID_OAUTH_STATE", "invalid or expired OAuth state"
+	case errors.Is(err, domain.ErrCloudAuthFailed):
+		return http.StatusBadGateway, "CLOUD_AUTH_FAILED", "cloud storage provider rejected the authorization; reconnect the account"
+	case errors.Is(err, domain.ErrDuplicateCloudSync):
+		return http.StatusConflict, "DUPLICATE_CLOUD_SYNC", "folder is already synced into this collection"
 	case errors.Is(err, domain.ErrDuplicateEmail):
 		return http.StatusConflict, "DUPLICATE_EMAIL", "email already exists for this tenant"
 	case errors.Is(err, domain.ErrDuplicateTenantSlug):
 		return http.StatusConflict, "DUPLICATE_SLUG", "tenant slug already exists"
+	case errors.Is(err, domain.ErrStorageUnavailable):
+		return http.StatusServiceUnavailable, "STORAGE_UNAVAILABLE", "object storage is temporarily unavailable; try again shortly"
 	case errors.Is(err, domain.ErrUploadFailed):
 		return http.StatusInternalServerError, "UPLOAD_FAILED", "file upload to storage failed"
 	case errors.Is(err, domain.ErrCollectionNotFound):
 		return http.StatusNotFound, "COLLECTION_NOT_FOUND", "collection not found"
 	case errors.Is(err, domain.ErrCollectionPermDenied):
 		return http.StatusForbidden, "COLLECTION_PERMISSION_DENIED", "insufficient collection permission"
+	case errors.Is(err, domain.ErrFileDownloadRestricted):
+		return http.StatusForbidden, "FILE_DOWNLOAD_RESTRICTED", "downloading the original file needs a higher permission on a collection holding it"
 	case errors.Is(err, domain.ErrDuplicateCollectionFile):
 		return http.StatusConflict, "DUPLICATE_COLLECTION_FILE", "file already exists in collection"
 	case errors.Is(err, domain.ErrSelfPermissionRemoval):
 		return http.StatusBadRequest, "SELF_PERMISSION_REMOVAL", "cannot remove your own permission"
 	case errors.Is(err, domain.ErrInvalidPermission):
 		return http.StatusBadRequest, "INVALID_PERMISSION", "invalid collection permission; allowed: owner, editor, viewer"
+	case errors.Is(err, domain.ErrInvalidReviewChecklist):
+		return http.StatusBadRequest, "INVALID_REVIEW_CHECKLIST", "invalid review checklist; items need a unique id and a label (max 25)"
 	case errors.Is(err, domain.ErrDocumentNotFound):
 		return http.StatusNotFound, "DOCUMENT_NOT_FOUND", "document not found"
 	case errors.Is(err, domain.ErrDocumentAlreadyExists):
 		return http.StatusConflict, "DOCUMENT_ALREADY_EXISTS", "document already exists for this file"
 	case errors.Is(err, domain.ErrDocumentNotParsed):
 		return http.StatusBadRequest, "DOCUMENT_NOT_PARSED", "document has not been parsed yet"
+	case errors.Is(err, domain.ErrDocumentParseInProgress):
+		return http.StatusConflict, "DOCUMENT_PARSE_IN_PROGRESS", "document is still being parsed"
+	case errors.Is(err, domain.ErrReviewChecklistIncomplete):
+		return http.StatusBadRequest, "REVIEW_CHECKLIST_INCOMPLETE", "every review checklist item must be checked before approving"
+	case errors.Is(err, domain.ErrRejectionReasonRequired):
+		return http.StatusBadRequest, "REJECTION_REASON_REQUIRED", "rejecting a document requires a reason_code"
+	case errors.Is(err, domain.ErrInvalidRejectionReason):
+		return http.StatusBadRequest, "INVALID_REJECTION_REASON", "invalid rejection reason; use an active code from GET /rejection-reasons"
+	case errors.Is(err, domain.ErrTenantAccessDenied):
+		return http.StatusForbidden, "TENANT_ACCESS_DENIED", "no access to this tenant"
+	case errors.Is(err, domain.ErrInvalidMembership):
+		return http.StatusBadRequest, "INVALID_MEMBERSHIP", "invalid tenant membership"
+	case errors.Is(err, domain.ErrInvalidClientTenant):
+		return http.StatusBadRequest, "INVALID_CLIENT_TENANT", "client tenants can't have clients of their own"
+	case errors.Is(err, domain.ErrInvalidSourceURL):
+		return http.StatusBadRequest, "INVALID_SOURCE_URL", "url must be a public https URL on an allowed host"
+	case errors.Is(err, domain.ErrSourceFetchFailed):
+		return http.StatusBadGateway, "SOURCE_FETCH_FAILED", "could not download the file from the url"
+	case errors.Is(err, domain.ErrParseFailed):
+		return http.StatusUnprocessableEntity, "PARSE_FAILED", "the document could not be parsed"
+	case errors.Is(err, domain.ErrParserUnavailable):
+		return http.StatusServiceUnavailable, "PARSER_UNAVAILABLE", "the document parser is busy; try again shortly"
+	case errors.Is(err, domain.ErrPageNotFound):
+		return http.StatusNotFound, "PAGE_NOT_FOUND", "the file has no such page"
+	case errors.Is(err, domain.ErrInvalidDPI):
+		return http.StatusBadRequest, "INVALID_DPI", "dpi is outside the allowed range"
+	case errors.Is(err, domain.ErrPageRenderUnavailable):
+		return http.StatusServiceUnavailable, "PAGE_RENDER_UNAVAILABLE", "page images are not available on this server"
+	case errors.Is(err, domain.ErrValidationRunInProgress):
+		return http.StatusConflict, "VALIDATION_RUN_IN_PROGRESS", "a validation run is already in progress for this collection"
+	case errors.Is(err, domain.ErrInvalidProposedRule):
+		return http.StatusBadRequest, "INVALID_PROPOSED_RULE", "invalid proposed rule; give a known builtin_rule_key, or rule_type required_field or regex with rule_config.field_path naming a string field (and a valid pattern for regex), and severity error or warning"
+	case errors.Is(err, domain.ErrTenantMoved):
+		return http.StatusMisdirectedRequest, "TENANT_MOVED", "tenant has moved to another database cluster"
+	case errors.Is(err, domain.ErrTenantMoveInProgress):
+		return http.StatusConflict, "TENANT_MOVE_IN_PROGRESS", "a move of this tenant is already in progress"
+	case errors.Is(err, domain.ErrInvalidMoveTarget):
+		return http.StatusBadRequest, "INVALID_MOVE_TARGET", "target_cluster is not a configured move target of this cluster"
+	case errors.Is(err, domain.ErrTenantNotMovable):
+		return http.StatusConflict, "TENANT_NOT_MOVABLE", "tenant has a parent tenant, client tenants or guest memberships and cannot be moved"
+	case errors.Is(err, domain.ErrTenantHasClients):
+		return http.StatusConflict, "TENANT_HAS_CLIENTS", "tenant still has client tenants; delete them first"
+	case errors.Is(err, domain.ErrInvalidNotificationRoutes):
+		return http.StatusBadRequest, "INVALID_NOTIFICATION_ROUTES", "routes must map known kinds to distinct configured channels; email_verification and password_reset must include email"
+	case errors.Is(err, domain.ErrInvalidKPITargets):
+		return http.StatusBadRequest, "INVALID_KPI_TARGETS", "metric must be parsed, reviewed or approved, target_pct between 0 and 100, due_date YYYY-MM-DD, at most 10 targets"
+	case errors.Is(err, domain.ErrDocumentLocked):
+		return http.StatusLocked, "DOCUMENT_LOCKED", "document is being reviewed by another user; try again when their lock expires"
+	case errors.Is(err, domain.ErrInvalidLockTTL):
+		return http.StatusBadRequest, "INVALID_LOCK_TTL", "ttl_seconds must be between 15 and 600"
+	case errors.Is(err, domain.ErrInvalidQASampling):
+		return http.StatusBadRequest, "INVALID_QA_SAMPLING", "sample_percent must be between 1 and 100 or null"
+	case errors.Is(err, domain.ErrInvalidParseMode):
+		return http.StatusBadRequest, "INVALID_PARSE_MODE", "parse_mode must be single or dual"
+	case errors.Is(err, domain.ErrInvalidAmountSearch):
+		return http.StatusBadRequest, "INVALID_AMOUNT_SEARCH", "value must be a positive amount and tolerance a non-negative amount or a percentage up to 100%"
+	case errors.Is(err, domain.ErrInvalidTagValue):
+		return http.StatusBadRequest, "INVALID_TAG_VALUE", "tag type must be string, number or date, and number and date tags need a number or YYYY-MM-DD value"
+	case errors.Is(err, domain.ErrInvalidTagSearch):
+		return http.StatusBadRequest, "INVALID_TAG_SEARCH", "search needs key and exactly one of value, prefix or a min/max range of numbers or YYYY-MM-DD dates"
+	case errors.Is(err, domain.ErrInvalidDocumentLimit):
+		return http.StatusBadRequest, "INVALID_DOCUMENT_LIMIT", "monthly_document_limit must be 0 (unlimited) or more"
+	case errors.Is(err, domain.ErrInvalidNamingTemplate):
+		return http.StatusBadRequest, "INVALID_NAMING_TEMPLATE", "naming_template must use known {field} placeholders and at most 200 characters"
+	case errors.Is(err, domain.ErrInvalidDocumentType):
+		return http.StatusBadRequest, "INVALID_DOCUMENT_TYPE", "document_type must be 1 to 50 characters and differ from the current type"
+	case errors.Is(err, domain.ErrInvalidUserImport):
+		return http.StatusBadRequest, "INVALID_USER_IMPORT", "the CSV needs a header row with name, email and role columns, and 1 to 500 rows"
+	case errors.Is(err, domain.ErrInvalidRelatedParty):
+		return http.StatusBadRequest, "INVALID_RELATED_PARTY", "related party needs a 15-character GSTIN, a name of at most 255 characters and relationship own or group"
+	case errors.Is(err, domain.ErrInvalidTimeZone):
+		return http.StatusBadRequest, "INVALID_TIME_ZONE", "time_zone must be an IANA time zone such as Asia/Kolkata"
+	case errors.Is(err, domain.ErrQAReviewNotFound):
+		return http.StatusNotFound, "QA_REVIEW_NOT_FOUND", "QA review not found"
+	case errors.Is(err, domain.ErrQAReviewCompleted):
+		return http.StatusConflict, "QA_REVIEW_COMPLETED", "QA review already has a verdict"
+	case errors.Is(err, domain.ErrQAOwnReview):
+		return http.StatusForbidden, "QA_OWN_REVIEW", "the second reviewer must not have approved the document"
+	case errors.Is(err, domain.ErrInvalidQAVerdict):
+		return http.StatusBadRequest, "INVALID_QA_VERDICT", "decision must be approved or rejected, with at most 50 disputed field paths and 2000 characters of notes"
+	case errors.Is(err, domain.ErrInvalidEscalationPolicy):
+		return http.StatusBadRequest, "INVALID_ESCALATION_POLICY", "after_days must be between 1 and 365 or null, and action flag or reassign"
+	case errors.Is(err, domain.ErrCheckerNotAllowed):
+		return http.StatusForbidden, "CHECKER_NOT_ALLOWED", "confirming an approval requires manager role or collection owner permission"
+	case errors.Is(err, domain.ErrCheckerSameAsMaker):
+		return http.StatusForbidden, "CHECKER_SAME_AS_MAKER", "the checker must be a different user from the maker"
 	case errors.Is(err, domain.ErrInsufficientRole):
 		return http.StatusForbidden, "INSUFFICIENT_ROLE", "insufficient role for this action"
+	case errors.Is(err, domain.ErrInvalidLineItemPatch):
+		return http.StatusBadRequest, "INVALID_LINE_ITEM_PATCH", "invalid line item patch; ops must be add, update or delete, update and delete need the index of a stored line item, and fields must be line item fields"
+	case errors.Is(err, domain.ErrDocumentModified):
+		return http.StatusConflict, "DOCUMENT_MODIFIED", "document was changed since it was read; reload it and retry"
+	case errors.Is(err, domain.ErrInvalidJSONPatch):
+		return http.StatusBadRequest, "INVALID_JSON_PATCH", "invalid JSON patch; use RFC 6902 operations add, remove, replace, move, copy or test with JSON Pointer paths"
+	case errors.Is(err, domain.ErrJSONPatchConflict):
+		return http.StatusConflict, "JSON_PATCH_CONFLICT", "JSON patch does not apply to the current structured data; reload the document and retry"
 	case errors.Is(err, domain.ErrInvalidStructuredData):
 		return http.StatusBadRequest, "INVALID_STRUCTURED_DATA", "structured data does not match expected format"
 	case errors.Is(err, domain.ErrQuotaExceeded):
 		return http.StatusTooManyRequests, "QUOTA_EXCEEDED", "monthly document quota exceeded; upgrade for more"
+	case errors.Is(err, domain.ErrParseBudgetExhausted):
+		return http.StatusPaymentRequired, "PARSE_BUDGET_EXHAUSTED", "daily parse budget exhausted; upgrade your plan for more parses, or try again after midnight UTC"
 	case errors.Is(err, domain.ErrEmailNotVerified):
 		return http.StatusForbidden, "EMAIL_NOT_VERIFIED", "please verify your email before performing this action"
+	case errors.Is(err, domain.ErrEmailChangeUndoInvalid):
+		return http.StatusUnauthorized, "INVALID_UNDO_TOKEN", "email change undo link is invalid, expired or already used"
 	case errors.Is(err, domain.ErrPasswordResetTokenInvalid):
 		return http.StatusUnauthorized, "INVALID_RESET_TOKEN", "password reset token is invalid or has already been used"
 	case errors.Is(err, domain.ErrSocialAuthTokenInvalid):
 		return http.StatusUnauthorized, "INVALID_SOCIAL_TOKEN", "social authentication token is invalid or expired"
 	case errors.Is(err, domain.ErrPasswordLoginNotAllowed):
 		return http.StatusBadRequest, "PASSWORD_LOGIN_NOT_ALLOWED", "this account uses social login; use your social provider to sign in"
+	case errors.Is(err, domain.ErrInvalidBulkTag):
+		return http.StatusBadRequest, "INVALID_BULK_TAG", "invalid bulk tag request; action must be add or remove, key 1-100 characters, and the filter needs at least one criterion"
+	case errors.Is(err, domain.ErrInvalidBulkDelete):
+		return http.StatusBadRequest, "INVALID_BULK_DELETE", "invalid bulk delete request; the filter needs at least one criterion and may match at most 1000 documents, and a real run needs the confirmation_token from a dry run"
+	case errors.Is(err, domain.ErrBulkDeleteUnconfirmed):
+		return http.StatusConflict, "BULK_DELETE_UNCONFIRMED", "confirmation token does not match the documents the filter selects; run a new dry run"
+	case errors.Is(err, domain.ErrInvalidCORSOrigins):
+		return http.StatusBadRequest, "INVALID_CORS_ORIGINS", "origins must be at most 20 http(s) origins of the form scheme://host[:port]"
+	case errors.Is(err, domain.ErrEmailUndeliverable):
+		return http.StatusConflict, "EMAIL_UNDELIVERABLE", "this email address bounced or reported a complaint, so email to it is suppressed; an admin can clear it once the mailbox is fixed"
+	case errors.Is(err, domain.ErrEmailSuppressionShared):
+		return http.StatusConflict, "EMAIL_SUPPRESSION_SHARED", "users of other tenants also have this email address, so its suppression can only be cleared by support"
+	case errors.Is(err, domain.ErrInvalidSNSMessage):
+		return http.StatusBadRequest, "INVALID_SNS_MESSAGE", "the request is not a correctly signed SNS message from the configured topic"
+	case errors.Is(err, domain.ErrInvalidComputedField):
+		return http.StatusBadRequest, "INVALID_COMPUTED_FIELD", "invalid computed field; check the name, document type and expression"
+	case errors.Is(err, domain.ErrInvalidNeighborContext):
+		return http.StatusBadRequest, "INVALID_NEIGHBOR_CONTEXT", "context must be review-queue or collection"
+	case errors.Is(err, domain.ErrInvalidStorageRegion):
+		return http.StatusBadRequest, "INVALID_STORAGE_REGION", "storage region is not configured on this deployment"
+	case errors.Is(err, domain.ErrVerificationResendTooSoon):
+		return http.StatusTooManyRequests, "VERIFICATION_RESEND_TOO_SOON", "a verification email was sent recently; try again later"
+	case errors.Is(err, domain.ErrDemoDataExists):
+		return http.StatusConflict, "DEMO_DATA_EXISTS", "the tenant already has demo data; remove it before seeding again"
+	case errors.Is(err, domain.ErrInvalidDemoOwner):
+		return http.StatusBadRequest, "INVALID_DEMO_OWNER", "demo data owner must be an active user of the tenant"
+	case errors.Is(err, domain.ErrInvalidExportDestination):
+		return http.StatusBadRequest, "INVALID_EXPORT_DESTINATION", "destination must be s3://bucket/prefix; deployment buckets only under tenants/{tenant_id}/"
+	case errors.Is(err, domain.ErrInvalidExportInterval):
+		return http.StatusBadRequest, "INVALID_EXPORT_INTERVAL", "interval_hours must be between 1 and 168"
+	case errors.Is(err, domain.ErrInvalidHookEvent):
+		return http.StatusBadRequest, "INVALID_HOOK_EVENT", "unsupported hook event; supported: document.approved"
+	case errors.Is(err, domain.ErrInvalidHookTarget):
+		return http.StatusBadRequest, "INVALID_HOOK_TARGET", "target_url must be an https URL with a public host name"
+	case errors.Is(err, domain.ErrInvalidHookTemplate):
+		return http.StatusBadRequest, "INVALID_HOOK_TEMPLATE", "payload_template must be a Go template of at most 8000 characters that renders valid JSON"
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return http.StatusBadRequest, "INVALID_CURSOR", "cursor is invalid; use next_cursor from an earlier response"
+	case errors.Is(err, domain.ErrInvalidNotificationChannel):
+		return http.StatusBadRequest, "INVALID_NOTIFICATION_CHANNEL", "invalid notification channel; check provider, webhook_url, events and review_sla_hours"
+	case errors.Is(err, domain.ErrInvalidNotificationTemplate):
+		return http.StatusBadRequest, "INVALID_NOTIFICATION_TEMPLATE", "a message template is not a valid template for its event"
+	case errors.Is(err, domain.ErrNotificationDeliveryFailed):
+		return http.StatusBadGateway, "NOTIFICATION_DELIVERY_FAILED", "the chat service did not accept the message"
+	case errors.Is(err, domain.ErrInvalidStorageLifecycle):
+		return http.StatusBadRequest, "INVALID_STORAGE_LIFECYCLE", "storage_ia_after_days must be 0 or at least 30"
+	case errors.Is(err, domain.ErrInvalidStorageEncryption):
+		return http.StatusBadRequest, "INVALID_STORAGE_ENCRYPTION", "storage_sse must be sse-s3 or sse-kms, and storage_kms_key_arn a KMS key or alias ARN given only with sse-kms"
+	case errors.Is(err, domain.ErrStorageRegionLocked):
+		return http.StatusConflict, "STORAGE_REGION_LOCKED", "storage region cannot change once the tenant has files"
+	case errors.Is(err, domain.ErrDataResidencyViolation):
+		return http.StatusConflict, "DATA_RESIDENCY_VIOLATION", "the tenant's storage region is unavailable or does not hold this object"
+	case errors.Is(err, domain.ErrDelegationNotFound):
+		return http.StatusNotFound, "DELEGATION_NOT_FOUND", "no review delegation is set"
+	case errors.Is(err, domain.ErrInvalidDelegation):
+		return http.StatusBadRequest, "INVALID_DELEGATION", "invalid review delegation; the delegate must be another active reviewer and the range must end today or later (max 180 days)"
 	case errors.Is(err, domain.ErrAssigneeCannotReview):
 		return http.StatusBadRequest, "ASSIGNEE_CANNOT_REVIEW", "assignee does not have review permission on this collection"
+	case errors.Is(err, domain.ErrUnknownFeatureFlag):
+		return http.StatusBadRequest, "UNKNOWN_FEATURE_FLAG", "unknown feature flag"
+	case errors.Is(err, domain.ErrFeatureDisabled):
+		return http.StatusForbidden, "FEATURE_DISABLED", "this feature is not enabled for your organization"
 	default:
 		return http.StatusInternalServerError, "INTERNAL_ERROR", "an internal error occurred"
 	}
@@ -136,6 +328,25 @@ func extractAuthContext(c *gin.Context) (tenantID, userID uuid.UUID, role domain
 	return tenantID, userID, role, true
 }
 
+// setQuotaHeaders adds X-Quota-Limit and X-Quota-Remaining for a user with a
+// monthly document quota, so clients can warn before uploads are rejected. It
+// does nothing when quota is nil, the user is unlimited or the lookup fails.
+func setQuotaHeaders(c *gin.Context, quota service.QuotaService, tenantID, userID uuid.UUID) {
+	if quota == nil {
+		return
+	}
+	usage, err := quota.Usage(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		log.Printf("setQuotaHeaders: quota of user %s: %v", userID, err)
+		return
+	}
+	if usage == nil {
+		return
+	}
+	c.Header("X-Quota-Limit", strconv.Itoa(usage.Limit))
+	c.Header("X-Quota-Remaining", strconv.Itoa(usage.Remaining))
+}
+
 // HandleError maps a domain error and sends the appropriate error response.
 func HandleError(c *gin.Context, err error) {
 	status, code, msg := MapDomainError(err)
@@ -145,3 +356,14 @@ func HandleError(c *gin.Context, err error) {
 	}
 	RespondError(c, status, code, msg)
 }
+
+// isBodyTooLarge reports whether err came from reading a body that middleware.BodyLimit cut off.
+func isBodyTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
+
+// respondBodyTooLarge sends the 413 response used for bodies over the route's limit.
+func respondBodyTooLarge(c *gin.Context) {
+	RespondError(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "request body exceeds the maximum allowed size")
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// ReviewEscalationHandler lists documents escalated for waiting too long for review.
+type ReviewEscalationHandler struct {
+	escalationService service.ReviewEscalationService
+	documentService   service.DocumentService
+}
+
+// NewReviewEscalationHandler creates a new ReviewEscalationHandler. documentService
+// fills in tags and assignee names.
+func NewReviewEscalationHandler(escalationService service.ReviewEscalationService, documentService service.DocumentService) *ReviewEscalationHandler {
+	return &ReviewEscalationHandler{escalationService: escalationService, documentService: documentService}
+}
+
+// List handles GET /api/v1/documents/escalations
+// @Summary List escalated reviews
+// @Description List documents escalated by their collection's escalation policy that still await review: in any collection for managers and admins, in owned collections for others. Oldest escalation first.
+// @Tags documents
+// @Produce json
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.Document,meta=PagMeta} "Escalated documents"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /documents/escalations [get]
+func (h *ReviewEscalationHandler) List(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	docs, total, err := h.escalationService.ListEscalated(c.Request.Context(), tenantID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	if err := h.documentService.EnrichDocuments(c.Request.Context(), tenantID, docs); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, docs, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+	"satvos/internal/validator"
+)
+
+// RuleSimulationHandler handles validation rule what-if simulations.
+type RuleSimulationHandler struct {
+	simulationService service.RuleSimulationService
+}
+
+// NewRuleSimulationHandler creates a new RuleSimulationHandler.
+func NewRuleSimulationHandler(simulationService service.RuleSimulationService) *RuleSimulationHandler {
+	return &RuleSimulationHandler{simulationService: simulationService}
+}
+
+// Simulate handles POST /api/v1/validation-rules/simulate
+// @Summary Simulate a validation rule
+// @Description Evaluate a proposed rule against the tenant's most recent parsed documents (up to 1000), optionally in one collection, and report how many would fail with sample documents. Read-only: no rule or result is stored.
+// @Tags validation
+// @Accept json
+// @Produce json
+// @Param request body SimulateRuleRequest true "Proposed rule and scope"
+// @Success 200 {object} Response{data=service.RuleSimulationResult} "Simulation result"
+// @Failure 400 {object} ErrorResponseBody "Invalid request or rule"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Admin only"
+// @Failure 404 {object} ErrorResponseBody "Collection or document type not found"
+// @Security BearerAuth
+// @Router /validation-rules/simulate [post]
+func (h *RuleSimulationHandler) Simulate(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		DocumentType string                 `json:"document_type" binding:"required"`
+		CollectionID *uuid.UUID             `json:"collection_id"`
+		Rule         validator.ProposedRule `json:"rule"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	result, err := h.simulationService.Simulate(c.Request.Context(), &service.RuleSimulationInput{
+		TenantID:     tenantID,
+		UserID:       userID,
+		Role:         role,
+		DocumentType: req.DocumentType,
+		CollectionID: req.CollectionID,
+		Rule:         req.Rule,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, result)
+}
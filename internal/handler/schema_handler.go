@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/service"
+)
+
+// SchemaHandler handles document schema introspection.
+type SchemaHandler struct {
+	schemaService service.SchemaService
+}
+
+// NewSchemaHandler creates a new SchemaHandler.
+func NewSchemaHandler(schemaService service.SchemaService) *SchemaHandler {
+	return &SchemaHandler{schemaService: schemaService}
+}
+
+// Get handles GET /api/v1/schemas/:documentType
+// @Summary Get a document type's schema
+// @Description JSON Schema of the structured data for a document type, a flat field list with descriptions, and the reconciliation-critical rules with the field paths they report on
+// @Tags schemas
+// @Produce json
+// @Param documentType path string true "Document type (e.g. invoice)"
+// @Success 200 {object} Response{data=validator.DocumentSchema} "Document schema"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "Unknown document type"
+// @Security BearerAuth
+// @Router /schemas/{documentType} [get]
+func (h *SchemaHandler) Get(c *gin.Context) {
+	schema, err := h.schemaService.Get(c.Param("documentType"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	RespondOK(c, schema)
+}
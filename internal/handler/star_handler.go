@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// StarHandler handles per-user star endpoints on documents and collections.
+type StarHandler struct {
+	starService service.StarService
+}
+
+// NewStarHandler creates a new StarHandler.
+func NewStarHandler(starService service.StarService) *StarHandler {
+	return &StarHandler{starService: starService}
+}
+
+// StarDocument handles PUT /api/v1/documents/:id/star
+// @Summary Star a document
+// @Description Add a document to the caller's starred list. Stars are private to the caller. Starring an already starred document is a no-op.
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response "Document starred"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Document not found"
+// @Security BearerAuth
+// @Router /documents/{id}/star [put]
+func (h *StarHandler) StarDocument(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	if err := h.starService.StarDocument(c.Request.Context(), tenantID, docID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "document starred"})
+}
+
+// UnstarDocument handles DELETE /api/v1/documents/:id/star
+// @Summary Unstar a document
+// @Description Remove a document from the caller's starred list. Unstarring a document that is not starred is a no-op.
+// @Tags documents
+// @Produce json
+// @Param id path string true "Document ID (UUID)"
+// @Success 200 {object} Response "Document unstarred"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /documents/{id}/star [delete]
+func (h *StarHandler) UnstarDocument(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	docID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid document ID")
+		return
+	}
+
+	if err := h.starService.UnstarDocument(c.Request.Context(), tenantID, docID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "document unstarred"})
+}
+
+// ListDocuments handles GET /api/v1/documents/starred
+// @Summary List starred documents
+// @Description List the caller's starred documents, most recently starred first. Documents the caller can no longer view are omitted.
+// @Tags documents
+// @Produce json
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.Document,meta=PagMeta} "Starred documents"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /documents/starred [get]
+func (h *StarHandler) ListDocuments(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	docs, total, err := h.starService.ListDocuments(c.Request.Context(), tenantID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, docs, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// StarCollection handles PUT /api/v1/collections/:id/star
+// @Summary Star a collection
+// @Description Add a collection to the caller's starred list. Stars are private to the caller. Starring an already starred collection is a no-op.
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 200 {object} Response "Collection starred"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /collections/{id}/star [put]
+func (h *StarHandler) StarCollection(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	if err := h.starService.StarCollection(c.Request.Context(), tenantID, collectionID, userID, role); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "collection starred"})
+}
+
+// UnstarCollection handles DELETE /api/v1/collections/:id/star
+// @Summary Unstar a collection
+// @Description Remove a collection from the caller's starred list. Unstarring a collection that is not starred is a no-op.
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 200 {object} Response "Collection unstarred"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /collections/{id}/star [delete]
+func (h *StarHandler) UnstarCollection(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	if err := h.starService.UnstarCollection(c.Request.Context(), tenantID, collectionID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "collection unstarred"})
+}
+
+// ListCollections handles GET /api/v1/collections/starred
+// @Summary List starred collections
+// @Description List the caller's starred collections, most recently starred first. Collections the caller can no longer view are omitted.
+// @Tags collections
+// @Produce json
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.Collection,meta=PagMeta} "Starred collections"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /collections/starred [get]
+func (h *StarHandler) ListCollections(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	collections, total, err := h.starService.ListCollections(c.Request.Context(), tenantID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, collections, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
@@ -1,11 +1,29 @@
 package handler
 
 import (
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
+	"satvos/internal/domain"
 	"satvos/internal/service"
 )
 
+// maxLatencyWindowHours caps the parse latency lookback (30 days).
+const maxLatencyWindowHours = 720
+
+// maxCalibrationWindowDays caps the confidence calibration lookback (one year).
+const maxCalibrationWindowDays = 365
+
+// maxRuleNoiseWindowDays caps the rule failure metrics lookback (two years).
+const maxRuleNoiseWindowDays = 730
+
+// maxQualityHistoryWindowDays caps the quality score history lookback (two years).
+const maxQualityHistoryWindowDays = 730
+
 // StatsHandler handles stats endpoints.
 type StatsHandler struct {
 	statsService service.StatsService
@@ -39,3 +57,246 @@ func (h *StatsHandler) GetStats(c *gin.Context) {
 
 	RespondOK(c, stats)
 }
+
+// GetParseLatency handles GET /api/v1/stats/parse-latency
+// @Summary Get parse latency percentiles
+// @Description Get p50/p95/p99 queue wait and parse duration (milliseconds) per parser model for the tenant's parse attempts in the trailing window. Parse percentiles count completed attempts only (manager+)
+// @Tags stats
+// @Produce json
+// @Param window_hours query int false "Lookback window in hours (1-720)" default(24)
+// @Success 200 {object} Response{data=[]domain.ParseLatencyStats} "Latency percentiles"
+// @Failure 400 {object} ErrorResponseBody "Invalid window"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - manager or above"
+// @Security BearerAuth
+// @Router /stats/parse-latency [get]
+func (h *StatsHandler) GetParseLatency(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	hours, err := strconv.Atoi(c.DefaultQuery("window_hours", "24"))
+	if err != nil || hours < 1 || hours > maxLatencyWindowHours {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "window_hours must be between 1 and 720")
+		return
+	}
+
+	stats, err := h.statsService.GetParseLatency(c.Request.Context(), tenantID, time.Duration(hours)*time.Hour)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, stats)
+}
+
+// GetConfidenceCalibration handles GET /api/v1/stats/confidence-calibration
+// @Summary Get parser confidence calibration
+// @Description Get a reliability curve per parser model: for each tenth of predicted field confidence, how many fields reviewers checked and the share they left uncorrected. Fields are observed when a reviewer edits parser output or approves a document (manager+)
+// @Tags stats
+// @Produce json
+// @Param window_days query int false "Lookback window in days (1-365)" default(90)
+// @Param document_type query string false "Only observations of this document type"
+// @Success 200 {object} Response{data=[]domain.ConfidenceCalibration} "Calibration per parser model"
+// @Failure 400 {object} ErrorResponseBody "Invalid window"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - manager or above"
+// @Security BearerAuth
+// @Router /stats/confidence-calibration [get]
+func (h *StatsHandler) GetConfidenceCalibration(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("window_days", "90"))
+	if err != nil || days < 1 || days > maxCalibrationWindowDays {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "window_days must be between 1 and 365")
+		return
+	}
+
+	report, err := h.statsService.GetConfidenceCalibration(c.Request.Context(), tenantID, c.Query("document_type"), time.Duration(days)*24*time.Hour)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, report)
+}
+
+// GetRuleNoise handles GET /api/v1/stats/rule-noise
+// @Summary Get noisy validation rules
+// @Description Rank the validation rules that failed on documents first validated in the window. For each rule: the documents it ran on, failures, overrides (documents approved while it was failing) and corrections (failures later cleared by an edit or re-parse). sort=noise ranks by failure rate × override rate (candidates for downgrading to warnings); sort=corrections points at parser fields that need prompt work (admin only)
+// @Tags stats
+// @Produce json
+// @Param window_days query int false "Lookback window in days (1-730)" default(90)
+// @Param document_type query string false "Only documents of this type"
+// @Param sort query string false "Ranking" Enums(noise, failures, corrections) default(noise)
+// @Param limit query int false "Maximum number of rules (1-100)" default(20)
+// @Success 200 {object} Response{data=[]domain.RuleNoise} "Rules, noisiest first"
+// @Failure 400 {object} ErrorResponseBody "Invalid window, sort or limit"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /stats/rule-noise [get]
+func (h *StatsHandler) GetRuleNoise(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("window_days", "90"))
+	if err != nil || days < 1 || days > maxRuleNoiseWindowDays {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "window_days must be between 1 and 730")
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "limit must be between 1 and 100")
+		return
+	}
+	sortBy := service.RuleNoiseSort(c.DefaultQuery("sort", string(service.RuleNoiseByScore)))
+	switch sortBy {
+	case service.RuleNoiseByScore, service.RuleNoiseByFailures, service.RuleNoiseByCorrections:
+	default:
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "sort must be noise, failures or corrections")
+		return
+	}
+
+	report, err := h.statsService.GetRuleNoise(c.Request.Context(), tenantID, c.Query("document_type"), time.Duration(days)*24*time.Hour, sortBy, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, report)
+}
+
+// GetCollectionQuality handles GET /api/v1/stats/quality
+// @Summary Get collection data quality scores
+// @Description Get a 0-100 data quality score per collection: 30% mean parse confidence, 40% validation pass rate (warnings count half) and 30% review coverage (approved documents), each over all of the collection's documents. With from/to, only documents whose invoice date falls in the period count, so a filing period can be judged ready. score is null for a collection without documents (manager+)
+// @Tags stats
+// @Produce json
+// @Param collection_id query string false "Only this collection"
+// @Param from query string false "Invoice date from (YYYY-MM-DD)"
+// @Param to query string false "Invoice date to (YYYY-MM-DD)"
+// @Success 200 {object} Response{data=[]domain.CollectionQuality} "Quality score per collection, by name"
+// @Failure 400 {object} ErrorResponseBody "Invalid collection ID or dates"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - manager or above"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /stats/quality [get]
+func (h *StatsHandler) GetCollectionQuality(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var filter domain.CollectionQualityFilter
+	if cidStr := c.Query("collection_id"); cidStr != "" {
+		cid, err := uuid.Parse(cidStr)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+			return
+		}
+		filter.CollectionID = &cid
+	}
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "from must be a date (YYYY-MM-DD)")
+			return
+		}
+		filter.From = &t
+	}
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "to must be a date (YYYY-MM-DD)")
+			return
+		}
+		filter.To = &t
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "to must not be before from")
+		return
+	}
+
+	report, err := h.statsService.GetCollectionQuality(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, report)
+}
+
+// GetCollectionQualityHistory handles GET /api/v1/stats/quality/history
+// @Summary Get a collection's data quality score history
+// @Description Get a collection's daily data quality scores, recorded by the nightly stats rebuild, oldest first and ending with today's live score. Days before the first snapshot are absent (manager+)
+// @Tags stats
+// @Produce json
+// @Param collection_id query string true "Collection ID"
+// @Param window_days query int false "Lookback window in days (1-730)" default(90)
+// @Success 200 {object} Response{data=[]domain.CollectionQuality} "Daily quality scores"
+// @Failure 400 {object} ErrorResponseBody "Invalid collection ID or window"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - manager or above"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Security BearerAuth
+// @Router /stats/quality/history [get]
+func (h *StatsHandler) GetCollectionQualityHistory(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Query("collection_id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "collection_id must be a collection ID")
+		return
+	}
+	days, err := strconv.Atoi(c.DefaultQuery("window_days", "90"))
+	if err != nil || days < 1 || days > maxQualityHistoryWindowDays {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "window_days must be between 1 and 730")
+		return
+	}
+
+	history, err := h.statsService.GetCollectionQualityHistory(c.Request.Context(), tenantID, collectionID, time.Duration(days)*24*time.Hour)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, history)
+}
+
+// Recount handles POST /api/v1/admin/tenants/:id/stats/recount
+// @Summary Recount tenant statistics
+// @Description Rebuild the tenant's materialized document counters from the documents table and list the collections whose count had drifted (admin only)
+// @Tags stats
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Success 200 {object} Response{data=domain.StatsRecount} "Recount result"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/stats/recount [post]
+func (h *StatsHandler) Recount(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	result, err := h.statsService.Recount(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, result)
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/google/uuid"
 
 	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/internal/validator"
 )
 
 // Swagger type definitions for API documentation.
@@ -55,8 +57,104 @@ type CreateDocumentRequest struct {
 
 // ReviewDocumentRequest represents the review document request body.
 type ReviewDocumentRequest struct {
-	Status string `json:"status" binding:"required" example:"approved"`
-	Notes  string `json:"notes" example:"Verified against source PDF. All data correct."`
+	Status    string          `json:"status" binding:"required" example:"approved"`
+	Notes     string          `json:"notes" example:"Verified against source PDF. All data correct."`
+	Checklist map[string]bool `json:"checklist" example:"po_attached:true,gstin_verified:true"`
+	// ReasonCode is required when rejecting; see GET /rejection-reasons.
+	ReasonCode string `json:"reason_code" example:"wrong_amount"`
+}
+
+// SetReviewChecklistRequest represents the set review checklist request body.
+type SetReviewChecklistRequest struct {
+	Items []domain.ReviewChecklistItem `json:"items"`
+}
+
+// SetApprovalPolicyRequest represents the set approval policy request body.
+type SetApprovalPolicyRequest struct {
+	CheckerThreshold *float64 `json:"checker_threshold" example:"100000"`
+}
+
+// SetEscalationPolicyRequest represents the set escalation policy request body.
+type SetEscalationPolicyRequest struct {
+	AfterDays *int                    `json:"after_days" example:"3"`
+	Action    domain.EscalationAction `json:"action" example:"reassign"`
+}
+
+// SetKPITargetsRequest represents the set KPI targets request body.
+type SetKPITargetsRequest struct {
+	Targets []domain.KPITarget `json:"targets" binding:"required"`
+}
+
+// SetQASamplingRequest represents the set QA sampling request body.
+type SetQASamplingRequest struct {
+	SamplePercent *int `json:"sample_percent" example:"5"`
+}
+
+// SetDefaultParseModeRequest represents the set default parse mode request body.
+type SetDefaultParseModeRequest struct {
+	ParseMode string `json:"parse_mode" example:"dual" enums:"single,dual"`
+}
+
+// SetNamingTemplateRequest represents the set naming template request body.
+type SetNamingTemplateRequest struct {
+	NamingTemplate string `json:"naming_template" example:"{seller_name}-{invoice_number}-{invoice_date}"`
+}
+
+// SetFileDownloadPermRequest represents the set file download permission request body.
+type SetFileDownloadPermRequest struct {
+	FileDownloadPerm string `json:"file_download_perm" example:"owner" enums:",viewer,editor,owner"`
+}
+
+// SubmitQAVerdictRequest represents a second reviewer's verdict on a QA sample.
+type SubmitQAVerdictRequest struct {
+	Decision       domain.ReviewStatus `json:"decision" binding:"required" example:"approved"`
+	DisputedFields []string            `json:"disputed_fields" example:"seller.gstin"`
+	Notes          string              `json:"notes" example:"GSTIN has a transposed digit"`
+}
+
+// AcquireDocumentLockRequest represents the optional acquire document lock request body.
+type AcquireDocumentLockRequest struct {
+	// TTLSeconds is how long the lock lasts without a renewal, 15-600 (default 120).
+	TTLSeconds int `json:"ttl_seconds" example:"120"`
+}
+
+// SetNotificationRoutesRequest represents the set notification routes request body.
+type SetNotificationRoutesRequest struct {
+	Routes map[string][]string `json:"routes" binding:"required"`
+}
+
+// SetTenantCORSOriginsRequest represents the set tenant CORS origins request body.
+type SetTenantCORSOriginsRequest struct {
+	Origins []string `json:"origins" binding:"required" example:"https://invoices.acme.example"`
+}
+
+// TenantCORSOriginsResponse lists the frontend origins CORS allows for a tenant.
+type TenantCORSOriginsResponse struct {
+	Origins []string `json:"origins" example:"https://invoices.acme.example"`
+}
+
+// SetDelegationRequest represents the set out-of-office delegation request body.
+type SetDelegationRequest struct {
+	DelegateID string `json:"delegate_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	StartsOn   string `json:"starts_on" example:"2026-03-01"`
+	EndsOn     string `json:"ends_on" example:"2026-03-14"`
+	ShareQueue bool   `json:"share_queue" example:"true"`
+}
+
+// BulkTagRequest represents the bulk tag request body.
+type BulkTagRequest struct {
+	Action string               `json:"action" example:"add" enums:"add,remove"`
+	Key    string               `json:"key" example:"audit"`
+	Value  string               `json:"value" example:"sample"`
+	Filter domain.BulkTagFilter `json:"filter"`
+}
+
+// BulkDeleteRequest represents the bulk delete request body.
+type BulkDeleteRequest struct {
+	Filter            domain.BulkTagFilter `json:"filter"`
+	DryRun            *bool                `json:"dry_run" example:"true"`
+	DeleteFiles       bool                 `json:"delete_files" example:"false"`
+	ConfirmationToken string               `json:"confirmation_token" example:"3f9a0c1e5b7d2f4a6c8e0b1d3f5a7c9e"`
 }
 
 // EditStructuredDataRequest represents the edit structured data request body.
@@ -64,9 +162,70 @@ type EditStructuredDataRequest struct {
 	StructuredData GSTInvoice `json:"structured_data" binding:"required"`
 }
 
-// AddTagsRequest represents the add tags request body.
+// PatchLineItemsRequest represents the line item patch request body.
+type PatchLineItemsRequest struct {
+	IfUpdatedAt *time.Time                  `json:"if_updated_at" example:"2026-10-15T09:30:00.123456Z"`
+	Operations  []service.LineItemOperation `json:"operations" binding:"required"`
+}
+
+// SetFeatureFlagRequest represents the set feature flag request body.
+type SetFeatureFlagRequest struct {
+	Enabled *bool `json:"enabled" binding:"required" example:"true"`
+}
+
+// SetMaintenanceRequest represents the toggle maintenance mode request body.
+type SetMaintenanceRequest struct {
+	Enabled *bool `json:"enabled" binding:"required" example:"true"`
+}
+
+// SetFaultScenarioRequest represents the set fault injection scenario request body.
+type SetFaultScenarioRequest struct {
+	Operations     []string `json:"operations" example:"Download,Upload"`
+	LatencyMS      int      `json:"latency_ms" binding:"min=0" example:"2000"`
+	ErrorPercent   int      `json:"error_percent" binding:"min=0,max=100" example:"30"`
+	PartialPercent int      `json:"partial_percent" binding:"min=0,max=100" example:"10"`
+}
+
+// S3ImportRequest represents the import-from-inbox request body.
+type S3ImportRequest struct {
+	Prefix       string           `json:"prefix" example:"2024-10/"`
+	DocumentType string           `json:"document_type" binding:"required" example:"invoice"`
+	ParseMode    domain.ParseMode `json:"parse_mode" example:"single"`
+}
+
+// OrphanDocumentsRequest represents the create-documents-for-orphan-files request body.
+type OrphanDocumentsRequest struct {
+	DocumentType string           `json:"document_type" binding:"required" example:"invoice"`
+	ParseMode    domain.ParseMode `json:"parse_mode" example:"single"`
+	Days         *int             `json:"days" example:"7"`
+}
+
+// CloudConnectRequest represents the OAuth redirect parameters forwarded by the frontend.
+type CloudConnectRequest struct {
+	Code  string `json:"code" binding:"required" example:"4/0AX4XfWh..."`
+	State string `json:"state" binding:"required"`
+}
+
+// CreateCloudSyncRequest represents the request body for linking a cloud folder to a collection.
+type CreateCloudSyncRequest struct {
+	ConnectionID uuid.UUID        `json:"connection_id" binding:"required"`
+	FolderID     string           `json:"folder_id" binding:"required" example:"1A2b3C4d5E6f"`
+	FolderName   string           `json:"folder_name" example:"Invoices 2024"`
+	DocumentType string           `json:"document_type" binding:"required" example:"invoice"`
+	ParseMode    domain.ParseMode `json:"parse_mode" example:"single"`
+	PollEnabled  bool             `json:"poll_enabled" example:"true"`
+}
+
+// UpdateCloudSyncRequest represents the request body for toggling polling on a cloud sync.
+type UpdateCloudSyncRequest struct {
+	PollEnabled *bool `json:"poll_enabled" binding:"required" example:"false"`
+}
+
+// AddTagsRequest represents the add tags request body. Types maps a tag key to
+// number or date; other tags are strings.
 type AddTagsRequest struct {
-	Tags map[string]string `json:"tags" binding:"required" example:"department:Engineering,cost_center:CC-1234"`
+	Tags  map[string]string              `json:"tags" binding:"required" example:"department:Engineering,cost_center:CC-1234"`
+	Types map[string]domain.TagValueType `json:"types" example:"po_amount:number"`
 }
 
 // CreateUserRequest represents the create user request body.
@@ -83,19 +242,29 @@ type UpdateUserRequest struct {
 	FullName *string          `json:"full_name" example:"Jane Smith"`
 	Role     *domain.UserRole `json:"role" example:"manager"`
 	IsActive *bool            `json:"is_active" example:"true"`
+	// MonthlyDocumentLimit sets the user's monthly document quota; 0 is unlimited.
+	MonthlyDocumentLimit *int `json:"monthly_document_limit" example:"500"`
 }
 
 // CreateTenantRequest represents the create tenant request body.
 type CreateTenantRequest struct {
-	Name string `json:"name" binding:"required" example:"Acme Corporation"`
-	Slug string `json:"slug" binding:"required" example:"acme"`
+	Name               string `json:"name" binding:"required" example:"Acme Corporation"`
+	Slug               string `json:"slug" binding:"required" example:"acme"`
+	StorageIAAfterDays int    `json:"storage_ia_after_days" example:"90"`
+	StorageSSE         string `json:"storage_sse" example:"sse-kms" enums:"sse-s3,sse-kms"`
+	StorageKMSKeyARN   string `json:"storage_kms_key_arn" example:"arn:aws:kms:ap-south-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"`
 }
 
 // UpdateTenantRequest represents the update tenant request body.
 type UpdateTenantRequest struct {
-	Name     *string `json:"name" example:"Acme Industries"`
-	Slug     *string `json:"slug" example:"acme-ind"`
-	IsActive *bool   `json:"is_active" example:"false"`
+	Name               *string `json:"name" example:"Acme Industries"`
+	Slug               *string `json:"slug" example:"acme-ind"`
+	IsActive           *bool   `json:"is_active" example:"false"`
+	StorageIAAfterDays *int    `json:"storage_ia_after_days" example:"90"`
+	StorageSSE         *string `json:"storage_sse" example:"sse-kms" enums:"sse-s3,sse-kms"`
+	StorageKMSKeyARN   *string `json:"storage_kms_key_arn" example:"arn:aws:kms:ap-south-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"`
+	DefaultParseMode   *string `json:"default_parse_mode" example:"dual" enums:"single,dual"`
+	TimeZone           *string `json:"time_zone" example:"Asia/Kolkata"`
 }
 
 // --- Response Types ---
@@ -111,6 +280,8 @@ type TokenResponse struct {
 type HealthResponse struct {
 	Status string `json:"status" example:"ok"`
 	Error  string `json:"error,omitempty" example:"database not reachable"`
+	// Shards reports "ok" or "unreachable" per configured shard (readiness only)
+	Shards map[string]string `json:"shards,omitempty"`
 }
 
 // MessageResponse represents a simple message response.
@@ -118,10 +289,18 @@ type MessageResponse struct {
 	Message string `json:"message" example:"operation completed successfully"`
 }
 
+// CloudAuthorizeResponse holds the provider consent URL to redirect the user to.
+type CloudAuthorizeResponse struct {
+	URL string `json:"url" example:"https://accounts.google.com/o/oauth2/v2/auth?..."`
+}
+
 // FileWithDownloadURL represents a file with its download URL.
 type FileWithDownloadURL struct {
 	File        domain.FileMeta `json:"file"`
-	DownloadURL string          `json:"download_url" example:"https://s3.amazonaws.com/satvos-uploads/...?X-Amz-Signature=..."`
+	DownloadURL string          `json:"download_url,omitempty" example:"https://s3.amazonaws.com/satvos-uploads/...?X-Amz-Signature=..."`
+	// DownloadRestricted is true when a collection holding the file keeps its
+	// original from this user; download_url is then left out.
+	DownloadRestricted bool `json:"download_restricted" example:"false"`
 }
 
 // FileUploadWithWarning represents a file upload response with optional warning.
@@ -270,3 +449,20 @@ type Payment struct {
 	IFSCCode      string `json:"ifsc_code" example:"HDFC0001234"`
 	PaymentTerms  string `json:"payment_terms" example:"Net 30"`
 }
+
+// CreateDocumentFromURLRequest represents the create-document-from-URL request body.
+type CreateDocumentFromURLRequest struct {
+	URL          string            `json:"url" binding:"required" example:"https://bucket.s3.amazonaws.com/invoices/inv-001.pdf?X-Amz-Signature=..."`
+	CollectionID uuid.UUID         `json:"collection_id" binding:"required" example:"660e8400-e29b-41d4-a716-446655440001"`
+	DocumentType string            `json:"document_type" binding:"required" example:"invoice"`
+	ParseMode    domain.ParseMode  `json:"parse_mode" example:"single"`
+	Name         string            `json:"name" example:"Acme Corp Invoice Q4-2024"`
+	Tags         map[string]string `json:"tags" example:"vendor:Acme Corp"`
+}
+
+// SimulateRuleRequest represents the validation rule simulation request body.
+type SimulateRuleRequest struct {
+	DocumentType string                 `json:"document_type" binding:"required" example:"invoice"`
+	CollectionID *uuid.UUID             `json:"collection_id" example:"660e8400-e29b-41d4-a716-446655440001"`
+	Rule         validator.ProposedRule `json:"rule"`
+}
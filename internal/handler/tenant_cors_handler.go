@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// TenantCORSHandler handles per-tenant allowed frontend origin endpoints.
+type TenantCORSHandler struct {
+	corsService service.TenantCORSService
+}
+
+// NewTenantCORSHandler creates a new TenantCORSHandler.
+func NewTenantCORSHandler(corsService service.TenantCORSService) *TenantCORSHandler {
+	return &TenantCORSHandler{corsService: corsService}
+}
+
+// Get handles GET /api/v1/admin/tenants/:id/cors-origins
+// @Summary Get tenant CORS origins
+// @Description List the frontend origins CORS allows for the tenant on top of the server-wide origins (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Success 200 {object} Response{data=TenantCORSOriginsResponse} "Tenant CORS origins"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Tenant not found"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/cors-origins [get]
+func (h *TenantCORSHandler) Get(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	origins, err := h.corsService.Get(c.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"origins": origins})
+}
+
+// Set handles PUT /api/v1/admin/tenants/:id/cors-origins
+// @Summary Set tenant CORS origins
+// @Description Replace the frontend origins (e.g. white-labeled domains) CORS allows for the tenant. Each origin is scheme://host[:port] over http or https; at most 20. Requests with the tenant's token are allowed from these origins; requests without a token (preflights, login) are allowed from any tenant's. Changes reach every instance within 30 seconds (admin only)
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param request body SetTenantCORSOriginsRequest true "Allowed origins"
+// @Success 200 {object} Response{data=TenantCORSOriginsResponse} "Tenant CORS origins updated"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or origins"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Tenant not found"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/cors-origins [put]
+func (h *TenantCORSHandler) Set(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	var req struct {
+		Origins []string `json:"origins" binding:"required"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	origins, err := h.corsService.Set(c.Request.Context(), tenantID, req.Origins)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"origins": origins})
+}
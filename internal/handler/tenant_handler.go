@@ -36,8 +36,7 @@ func NewTenantHandler(tenantService service.TenantService) *TenantHandler {
 // @Router /admin/tenants [post]
 func (h *TenantHandler) Create(c *gin.Context) {
 	var input service.CreateTenantInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
 
@@ -133,8 +132,7 @@ func (h *TenantHandler) Update(c *gin.Context) {
 	}
 
 	var input service.UpdateTenantInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
 
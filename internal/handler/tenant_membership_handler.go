@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// TenantMembershipHandler handles switching between a user's tenants and a
+// tenant's guests from other tenants.
+type TenantMembershipHandler struct {
+	membershipService service.TenantMembershipService
+}
+
+// NewTenantMembershipHandler creates a new TenantMembershipHandler.
+func NewTenantMembershipHandler(membershipService service.TenantMembershipService) *TenantMembershipHandler {
+	return &TenantMembershipHandler{membershipService: membershipService}
+}
+
+// SwitchTenantRequest is the body of a tenant switch.
+type SwitchTenantRequest struct {
+	TenantID string `json:"tenant_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// ListTenants handles GET /api/v1/auth/tenants
+// @Summary List the caller's tenants
+// @Description The tenants the caller can switch to: their home tenant (is_home=true) first, then tenants they are an active guest of, with the role in each.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.TenantMembership} "Tenants"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /auth/tenants [get]
+func (h *TenantMembershipHandler) ListTenants(c *gin.Context) {
+	_, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	tenants, err := h.membershipService.ListTenants(c.Request.Context(), userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, tenants)
+}
+
+// SwitchTenant handles POST /api/v1/auth/switch-tenant
+// @Summary Switch tenant
+// @Description Issue a new token pair scoped to one of the caller's tenants, with their role there. Guest tokens carry home_tenant_id and stop working when the membership is removed or suspended.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body SwitchTenantRequest true "Target tenant"
+// @Success 200 {object} Response{data=service.TokenPair} "Tokens for the tenant"
+// @Failure 400 {object} ErrorResponseBody "Invalid tenant ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "No access to the tenant, or tenant inactive"
+// @Security BearerAuth
+// @Router /auth/switch-tenant [post]
+func (h *TenantMembershipHandler) SwitchTenant(c *gin.Context) {
+	_, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var req SwitchTenantRequest
+	if !bindJSON(c, &req, "VALIDATION_ERROR") {
+		return
+	}
+	tenantID, err := uuid.Parse(req.TenantID)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	tokenPair, err := h.membershipService.SwitchTenant(c.Request.Context(), userID, tenantID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, tokenPair)
+}
+
+// ListGuests handles GET /api/v1/memberships
+// @Summary List tenant guests
+// @Description Users of other tenants with access to this tenant, newest first (admin only).
+// @Tags users
+// @Produce json
+// @Param offset query int false "Pagination offset" default(0)
+// @Param limit query int false "Pagination limit" default(20)
+// @Success 200 {object} Response{data=[]domain.TenantMembership,meta=PagMeta} "Guests"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /memberships [get]
+func (h *TenantMembershipHandler) ListGuests(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	guests, total, err := h.membershipService.ListGuests(c.Request.Context(), tenantID, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, guests, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// Grant handles PUT /api/v1/memberships
+// @Summary Grant or update a guest membership
+// @Description Give a user of another tenant, identified by email and home tenant slug, a role in this tenant, or change it (admin only). Set is_active=false to suspend access.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body service.GrantMembershipInput true "Guest and role"
+// @Success 200 {object} Response{data=domain.TenantMembership} "Membership saved"
+// @Failure 400 {object} ErrorResponseBody "Invalid role, or the user already belongs to this tenant"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Tenant or user not found"
+// @Security BearerAuth
+// @Router /memberships [put]
+func (h *TenantMembershipHandler) Grant(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var input service.GrantMembershipInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	membership, err := h.membershipService.Grant(c.Request.Context(), tenantID, &input, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, membership)
+}
+
+// Revoke handles DELETE /api/v1/memberships/:userId
+// @Summary Remove a guest
+// @Description Remove a guest's access to this tenant (admin only). Their tokens for the tenant stop working.
+// @Tags users
+// @Produce json
+// @Param userId path string true "Guest user ID (UUID)"
+// @Success 200 {object} Response{data=MessageResponse} "Membership removed"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Membership not found"
+// @Security BearerAuth
+// @Router /memberships/{userId} [delete]
+func (h *TenantMembershipHandler) Revoke(c *gin.Context) {
+	tenantID, _, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	guestID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid user ID")
+		return
+	}
+
+	if err := h.membershipService.Revoke(c.Request.Context(), tenantID, guestID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "membership removed"})
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// TenantMoveHandler handles moving tenants between database clusters.
+type TenantMoveHandler struct {
+	moveService service.TenantMoveService
+}
+
+// NewTenantMoveHandler creates a new TenantMoveHandler.
+func NewTenantMoveHandler(moveService service.TenantMoveService) *TenantMoveHandler {
+	return &TenantMoveHandler{moveService: moveService}
+}
+
+// Start handles POST /api/v1/admin/tenants/:id/moves
+// @Summary Move a tenant to another database cluster
+// @Description Copy the tenant's rows, keeping their IDs, to a cluster configured in SATVOS_TENANT_MOVE_TARGETS, and with storage_region its files to that region's bucket. The tenant stops being served by this cluster (421 TENANT_MOVED) as soon as the move starts; rows are verified by per-table counts and checksums before the target commits, and a failed move restores the tenant here. Poll the returned move for progress (admin only)
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param body body service.StartTenantMoveInput true "Target cluster and optional storage region"
+// @Success 202 {object} Response{data=domain.TenantMove} "Tenant move accepted"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID, target cluster or storage region"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Tenant not found"
+// @Failure 409 {object} ErrorResponseBody "A move is already in progress, or the tenant has cross-tenant links"
+// @Failure 421 {object} ErrorResponseBody "Tenant is served by another cluster"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/moves [post]
+func (h *TenantMoveHandler) Start(c *gin.Context) {
+	_, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	var input service.StartTenantMoveInput
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
+		return
+	}
+
+	move, err := h.moveService.Start(c.Request.Context(), tenantID, &input, &userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{Success: true, Data: move})
+}
+
+// List handles GET /api/v1/admin/tenants/:id/moves
+// @Summary List tenant moves
+// @Description List the moves of a tenant started from this cluster, newest first (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.TenantMove,meta=PagMeta} "Tenant moves"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/moves [get]
+func (h *TenantMoveHandler) List(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	moves, total, err := h.moveService.List(c.Request.Context(), tenantID, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, moves, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// Get handles GET /api/v1/admin/tenants/:id/moves/:moveId
+// @Summary Get a tenant move
+// @Description Get a tenant move's status, object progress, and per-table row counts and checksums on source and target (admin only)
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param moveId path string true "Tenant move ID (UUID)"
+// @Success 200 {object} Response{data=domain.TenantMove} "Tenant move"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Tenant move not found"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/moves/{moveId} [get]
+func (h *TenantMoveHandler) Get(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+	moveID, err := uuid.Parse(c.Param("moveId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant move ID")
+		return
+	}
+
+	move, err := h.moveService.Get(c.Request.Context(), tenantID, moveID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, move)
+}
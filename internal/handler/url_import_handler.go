@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// URLImportHandler handles creating documents from files hosted at a URL.
+type URLImportHandler struct {
+	urlImportService service.URLImportService
+	quota            service.QuotaService
+}
+
+// NewURLImportHandler creates a new URLImportHandler. quota may be nil, in which
+// case no quota headers are sent.
+func NewURLImportHandler(urlImportService service.URLImportService, quota service.QuotaService) *URLImportHandler {
+	return &URLImportHandler{urlImportService: urlImportService, quota: quota}
+}
+
+// CreateFromURL handles POST /api/v1/documents/from-url
+// @Summary Create a document from a URL
+// @Description Download a PDF or image from a public https URL (e.g. a presigned S3 link), store it in the collection and trigger AI parsing
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param request body CreateDocumentFromURLRequest true "Source URL and document details"
+// @Success 201 {object} Response{data=domain.Document} "Document created, parsing started"
+// @Failure 400 {object} ErrorResponseBody "Invalid request, URL not allowed or unsupported file"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Failure 502 {object} ErrorResponseBody "Source could not be downloaded"
+// @Security BearerAuth
+// @Router /documents/from-url [post]
+func (h *URLImportHandler) CreateFromURL(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		URL          string            `json:"url" binding:"required"`
+		CollectionID uuid.UUID         `json:"collection_id" binding:"required"`
+		DocumentType string            `json:"document_type" binding:"required"`
+		ParseMode    domain.ParseMode  `json:"parse_mode"`
+		Name         string            `json:"name"`
+		Tags         map[string]string `json:"tags"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+
+	if req.ParseMode != "" && !domain.ValidParseModes[req.ParseMode] {
+		RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "parse_mode must be 'single' or 'dual'")
+		return
+	}
+
+	doc, err := h.urlImportService.CreateFromURL(c.Request.Context(), &service.CreateFromURLInput{
+		TenantID:     tenantID,
+		CollectionID: req.CollectionID,
+		URL:          req.URL,
+		DocumentType: req.DocumentType,
+		ParseMode:    req.ParseMode,
+		Name:         req.Name,
+		Tags:         req.Tags,
+		CreatedBy:    userID,
+		Role:         role,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrQuotaExceeded) {
+			setQuotaHeaders(c, h.quota, tenantID, userID)
+		}
+		HandleError(c, err)
+		return
+	}
+
+	setQuotaHeaders(c, h.quota, tenantID, userID)
+	RespondCreated(c, doc)
+}
@@ -3,22 +3,35 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"satvos/internal/apierror"
+	"satvos/internal/domain"
 	"satvos/internal/middleware"
 	"satvos/internal/service"
 )
 
 // UserHandler handles user management endpoints.
 type UserHandler struct {
-	userService service.UserService
+	userService          service.UserService
+	delegationService    service.ReviewDelegationService
+	passwordResetService service.PasswordResetService
 }
 
 // NewUserHandler creates a new UserHandler.
-func NewUserHandler(userService service.UserService) *UserHandler {
-	return &UserHandler{userService: userService}
+func NewUserHandler(
+	userService service.UserService,
+	delegationService service.ReviewDelegationService,
+	passwordResetService service.PasswordResetService,
+) *UserHandler {
+	return &UserHandler{
+		userService:          userService,
+		delegationService:    delegationService,
+		passwordResetService: passwordResetService,
+	}
 }
 
 // Create handles POST /api/v1/users
@@ -43,8 +56,7 @@ func (h *UserHandler) Create(c *gin.Context) {
 	}
 
 	var input service.CreateUserInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
 
@@ -59,9 +71,10 @@ func (h *UserHandler) Create(c *gin.Context) {
 
 // List handles GET /api/v1/users
 // @Summary List users
-// @Description List all users in the tenant (admin only)
+// @Description List all users in the tenant (admin only). With email_verified=false, lists only active users who have not verified their email
 // @Tags users
 // @Produce json
+// @Param email_verified query bool false "Set to false to list only unverified users"
 // @Param offset query int false "Offset for pagination" default(0)
 // @Param limit query int false "Limit for pagination (max 100)" default(20)
 // @Success 200 {object} Response{data=[]domain.User,meta=PagMeta} "List of users"
@@ -85,7 +98,13 @@ func (h *UserHandler) List(c *gin.Context) {
 		offset = 0
 	}
 
-	users, total, err := h.userService.List(c.Request.Context(), tenantID, offset, limit)
+	var users []domain.User
+	var total int
+	if c.Query("email_verified") == "false" {
+		users, total, err = h.userService.ListUnverified(c.Request.Context(), tenantID, offset, limit)
+	} else {
+		users, total, err = h.userService.List(c.Request.Context(), tenantID, offset, limit)
+	}
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -139,7 +158,7 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 
 // Update handles PUT /api/v1/users/:id
 // @Summary Update a user
-// @Description Update user details (self can update name/email, admin can update role/active status)
+// @Description Update user details (self can update name/email, admin can update role/active status and the monthly document quota, where 0 is unlimited)
 // @Tags users
 // @Accept json
 // @Produce json
@@ -174,8 +193,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 	}
 
 	var input service.UpdateUserInput
-	if err := c.ShouldBindJSON(&input); err != nil {
-		RespondError(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	if !bindJSON(c, &input, "VALIDATION_ERROR") {
 		return
 	}
 
@@ -184,6 +202,12 @@ func (h *UserHandler) Update(c *gin.Context) {
 		RespondError(c, http.StatusForbidden, "FORBIDDEN", "only admins can change user roles")
 		return
 	}
+	if currentRole != "admin" && input.MonthlyDocumentLimit != nil {
+		RespondError(c, http.StatusForbidden, "FORBIDDEN", "only admins can change document quotas")
+		return
+	}
+	input.ActorID = currentUserID
+	input.Meta = requestMeta(c)
 
 	user, err := h.userService.Update(c.Request.Context(), tenantID, userID, input)
 	if err != nil {
@@ -227,3 +251,152 @@ func (h *UserHandler) Delete(c *gin.Context) {
 
 	RespondOK(c, gin.H{"message": "user deleted"})
 }
+
+// ListSecurityEvents handles GET /api/v1/users/:id/security-events
+// @Summary List a user's security events
+// @Description List the user's account security audit trail (password reset requests, completions and rejections, email changes and undos), newest first. Self or admin
+// @Tags users
+// @Produce json
+// @Param id path string true "User ID (UUID)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.SecurityEvent,meta=PagMeta} "Security events"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden"
+// @Security BearerAuth
+// @Router /users/{id}/security-events [get]
+func (h *UserHandler) ListSecurityEvents(c *gin.Context) {
+	tenantID, err := middleware.GetTenantID(c)
+	if err != nil {
+		RespondError(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing tenant context")
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid user ID")
+		return
+	}
+
+	// Allow self-access or admin access
+	currentUserID, _ := middleware.GetUserID(c)
+	if currentUserID != userID && middleware.GetRole(c) != "admin" {
+		RespondError(c, http.StatusForbidden, "FORBIDDEN", "insufficient permissions")
+		return
+	}
+
+	offset, limit := parsePagination(c)
+	events, total, err := h.passwordResetService.ListSecurityEvents(c.Request.Context(), tenantID, userID, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, events, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// GetDelegation handles GET /api/v1/users/me/delegation
+// @Summary Get my out-of-office delegation
+// @Description Get the current user's review delegation, whether or not it is active yet
+// @Tags users
+// @Produce json
+// @Success 200 {object} Response{data=domain.ReviewDelegation} "Delegation"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "No delegation set"
+// @Security BearerAuth
+// @Router /users/me/delegation [get]
+func (h *UserHandler) GetDelegation(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.delegationService.Get(c.Request.Context(), tenantID, userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, d)
+}
+
+// SetDelegation handles PUT /api/v1/users/me/delegation
+// @Summary Set my out-of-office delegation
+// @Description Designate a delegate for an inclusive date range. While it is active, new review assignments to you go to the delegate, and with share_queue the delegate also sees your review queue. Replaces any existing delegation.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body SetDelegationRequest true "Delegation"
+// @Success 200 {object} Response{data=domain.ReviewDelegation} "Delegation set"
+// @Failure 400 {object} ErrorResponseBody "Invalid delegation"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Security BearerAuth
+// @Router /users/me/delegation [put]
+func (h *UserHandler) SetDelegation(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		DelegateID uuid.UUID `json:"delegate_id" binding:"required"`
+		StartsOn   string    `json:"starts_on" binding:"required"`
+		EndsOn     string    `json:"ends_on" binding:"required"`
+		ShareQueue bool      `json:"share_queue"`
+	}
+	if !bindJSON(c, &req, "INVALID_REQUEST") {
+		return
+	}
+	startsOn, err := time.Parse("2006-01-02", req.StartsOn)
+	if err != nil {
+		RespondFieldErrors(c, http.StatusBadRequest, "INVALID_REQUEST", "starts_on must be YYYY-MM-DD",
+			apierror.FieldError{Field: "starts_on", Code: "invalid_format", Message: "starts_on must be YYYY-MM-DD"})
+		return
+	}
+	endsOn, err := time.Parse("2006-01-02", req.EndsOn)
+	if err != nil {
+		RespondFieldErrors(c, http.StatusBadRequest, "INVALID_REQUEST", "ends_on must be YYYY-MM-DD",
+			apierror.FieldError{Field: "ends_on", Code: "invalid_format", Message: "ends_on must be YYYY-MM-DD"})
+		return
+	}
+
+	d, err := h.delegationService.Set(c.Request.Context(), &service.SetDelegationInput{
+		TenantID:   tenantID,
+		UserID:     userID,
+		DelegateID: req.DelegateID,
+		StartsOn:   startsOn,
+		EndsOn:     endsOn,
+		ShareQueue: req.ShareQueue,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, d)
+}
+
+// ClearDelegation handles DELETE /api/v1/users/me/delegation
+// @Summary Clear my out-of-office delegation
+// @Description Remove the current user's review delegation. Documents already routed to the delegate stay assigned to them.
+// @Tags users
+// @Produce json
+// @Success 200 {object} Response{data=MessageResponse} "Delegation cleared"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 404 {object} ErrorResponseBody "No delegation set"
+// @Security BearerAuth
+// @Router /users/me/delegation [delete]
+func (h *UserHandler) ClearDelegation(c *gin.Context) {
+	tenantID, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.delegationService.Clear(c.Request.Context(), tenantID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, gin.H{"message": "delegation cleared"})
+}
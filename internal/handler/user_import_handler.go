@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// UserImportHandler handles bulk user import endpoints.
+type UserImportHandler struct {
+	importService service.UserImportService
+}
+
+// NewUserImportHandler creates a new UserImportHandler.
+func NewUserImportHandler(importService service.UserImportService) *UserImportHandler {
+	return &UserImportHandler{importService: importService}
+}
+
+// Import handles POST /api/v1/admin/tenants/:id/users/import
+// @Summary Import users from CSV
+// @Description Create the tenant's users from a CSV with a header row of name, email, role and (optional) collections columns, at most 500 rows. collections lists collection names or IDs separated by ";", each with an optional :owner, :editor or :viewer suffix (viewer by default). Each user is emailed an invitation to set a password. Bad rows are skipped and reported; the report lists every row's outcome (admin only)
+// @Tags users
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Tenant ID (UUID)"
+// @Param file formData file true "CSV of users"
+// @Success 200 {object} Response{data=domain.UserImportReport} "Per-row import report"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID or unreadable CSV"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Forbidden - admin only"
+// @Failure 404 {object} ErrorResponseBody "Tenant not found"
+// @Failure 413 {object} ErrorResponseBody "File too large"
+// @Security BearerAuth
+// @Router /admin/tenants/{id}/users/import [post]
+func (h *UserImportHandler) Import(c *gin.Context) {
+	_, userID, _, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid tenant ID")
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		if isBodyTooLarge(err) {
+			respondBodyTooLarge(c)
+			return
+		}
+		RespondError(c, http.StatusBadRequest, "MISSING_FILE", "file field is required")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	report, err := h.importService.Import(c.Request.Context(), &service.UserImportInput{
+		TenantID: tenantID,
+		ActorID:  userID,
+		CSV:      file,
+		Meta:     requestMeta(c),
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, report)
+}
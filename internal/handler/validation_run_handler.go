@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/service"
+)
+
+// ValidationRunHandler handles collection-wide validation re-runs.
+type ValidationRunHandler struct {
+	runService service.ValidationRunService
+}
+
+// NewValidationRunHandler creates a new ValidationRunHandler.
+func NewValidationRunHandler(runService service.ValidationRunService) *ValidationRunHandler {
+	return &ValidationRunHandler{runService: runService}
+}
+
+// Start handles POST /api/v1/collections/:id/validate
+// @Summary Re-run validation across a collection
+// @Description Re-validate every parsed document in the collection asynchronously, e.g. after rules changed. Poll the returned run for progress; when it completes, changes lists the documents whose validation or reconciliation status changed. Only one run per collection may be in progress.
+// @Tags validation
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 202 {object} Response{data=domain.ValidationRun} "Validation run accepted"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Collection not found"
+// @Failure 409 {object} ErrorResponseBody "A run is already in progress"
+// @Security BearerAuth
+// @Router /collections/{id}/validate [post]
+func (h *ValidationRunHandler) Start(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	run, err := h.runService.Start(c.Request.Context(), tenantID, collectionID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, APIResponse{Success: true, Data: run})
+}
+
+// List handles GET /api/v1/collections/:id/validation-runs
+// @Summary List validation runs
+// @Description List a collection's validation runs, newest first
+// @Tags validation
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param limit query int false "Limit for pagination (max 100)" default(20)
+// @Success 200 {object} Response{data=[]domain.ValidationRun,meta=PagMeta} "Validation runs"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Security BearerAuth
+// @Router /collections/{id}/validation-runs [get]
+func (h *ValidationRunHandler) List(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+
+	offset, limit := parsePagination(c)
+
+	runs, total, err := h.runService.ListRuns(c.Request.Context(), tenantID, collectionID, userID, role, offset, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondPaginated(c, runs, PagMeta{Total: total, Offset: offset, Limit: limit})
+}
+
+// Get handles GET /api/v1/collections/:id/validation-runs/:runId
+// @Summary Get a validation run
+// @Description Get a validation run's status, progress counters and the documents whose status changed
+// @Tags validation
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param runId path string true "Validation run ID (UUID)"
+// @Success 200 {object} Response{data=domain.ValidationRun} "Validation run"
+// @Failure 400 {object} ErrorResponseBody "Invalid ID"
+// @Failure 401 {object} ErrorResponseBody "Unauthorized"
+// @Failure 403 {object} ErrorResponseBody "Insufficient permission"
+// @Failure 404 {object} ErrorResponseBody "Validation run not found"
+// @Security BearerAuth
+// @Router /collections/{id}/validation-runs/{runId} [get]
+func (h *ValidationRunHandler) Get(c *gin.Context) {
+	tenantID, userID, role, ok := extractAuthContext(c)
+	if !ok {
+		return
+	}
+
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid collection ID")
+		return
+	}
+	runID, err := uuid.Parse(c.Param("runId"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, "INVALID_ID", "invalid validation run ID")
+		return
+	}
+
+	run, err := h.runService.GetRun(c.Request.Context(), tenantID, collectionID, runID, userID, role)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	RespondOK(c, run)
+}
@@ -0,0 +1,167 @@
+// Package httpclient builds outbound HTTP clients from per-dependency config:
+// explicit proxy, extra CA roots, connect/header timeouts and a host allowlist.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"satvos/internal/config"
+)
+
+// ErrEgressDenied is returned for requests to a host outside the allowlist.
+var ErrEgressDenied = errors.New("outbound request to host not in allowlist")
+
+// ErrNonPublicAddress is returned when a public-only client would connect to a
+// loopback, private, link-local or otherwise non-public address.
+var ErrNonPublicAddress = errors.New("outbound request to non-public address")
+
+// nonPublicNets are special-purpose ranges not covered by the net.IP predicates.
+var nonPublicNets = []*net.IPNet{
+	mustCIDR("0.0.0.0/8"),
+	mustCIDR("100.64.0.0/10"), // carrier-grade NAT
+	mustCIDR("192.0.0.0/24"),
+	mustCIDR("198.18.0.0/15"), // benchmarking
+	mustCIDR("64:ff9b::/96"),  // NAT64 can reach IPv4 internals
+}
+
+// New creates an HTTP client for cfg. A zero cfg behaves like http.DefaultClient,
+// including proxy settings from the environment; a ProxyURL overrides them.
+func New(cfg config.HTTPClientConfig) (*http.Client, error) {
+	return newClient(cfg, false)
+}
+
+// NewPublic creates a client for fetching user-supplied URLs. Without a ProxyURL
+// it connects directly, ignoring proxy settings from the environment, and refuses
+// non-public addresses after DNS resolution, so a host name can't be pointed at
+// internal services. With a ProxyURL the proxy is trusted to enforce egress.
+func NewPublic(cfg config.HTTPClientConfig) (*http.Client, error) {
+	return newClient(cfg, true)
+}
+
+// IsPublicIP reports whether ip is a globally routable unicast address.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func newClient(cfg config.HTTPClientConfig, publicOnly bool) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if publicOnly {
+		transport.Proxy = nil
+	}
+
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if cfg.CABundle != "" {
+		pem, err := os.ReadFile(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA bundle: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", cfg.CABundle)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	}
+
+	if cfg.ConnectTimeoutSecs > 0 || (publicOnly && cfg.ProxyURL == "") {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		if cfg.ConnectTimeoutSecs > 0 {
+			dialer.Timeout = time.Duration(cfg.ConnectTimeoutSecs) * time.Second
+			transport.TLSHandshakeTimeout = dialer.Timeout
+		}
+		if publicOnly && cfg.ProxyURL == "" {
+			dialer.Control = denyNonPublic
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	if cfg.ResponseHeaderTimeoutSecs > 0 {
+		transport.ResponseHeaderTimeout = time.Duration(cfg.ResponseHeaderTimeoutSecs) * time.Second
+	}
+
+	var rt http.RoundTripper = transport
+	if len(cfg.AllowedHosts) > 0 {
+		rt = &allowlistTransport{next: transport, hosts: cfg.AllowedHosts}
+	}
+	return &http.Client{Transport: rt}, nil
+}
+
+// HostAllowed reports whether host matches an allowlist entry: an exact host name
+// or "*.example.com" for any subdomain of example.com.
+func HostAllowed(host string, allowed []string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range allowed {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if suffix, ok := strings.CutPrefix(entry, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// allowlistTransport refuses requests whose target host is not allowlisted.
+// The check is on the destination, not the proxy, so it holds behind a proxy too.
+type allowlistTransport struct {
+	next  http.RoundTripper
+	hosts []string
+}
+
+func (t *allowlistTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !HostAllowed(req.URL.Hostname(), t.hosts) {
+		return nil, fmt.Errorf("%w: %s", ErrEgressDenied, req.URL.Hostname())
+	}
+	return t.next.RoundTrip(req)
+}
+
+// denyNonPublic runs after DNS resolution, for every address dialed.
+func denyNonPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+	}
+	return nil
+}
+
+func mustCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
@@ -0,0 +1,325 @@
+// Package jsonpatch applies RFC 6902 JSON Patch documents to JSON values.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalid means the patch itself is malformed: an unknown op, a missing
+	// value or from, or a path that isn't a JSON Pointer.
+	ErrInvalid = errors.New("invalid JSON patch")
+	// ErrConflict means the patch is well formed but doesn't apply to the
+	// document: a path doesn't exist or a test operation failed.
+	ErrConflict = errors.New("JSON patch does not apply")
+)
+
+// Operation is one RFC 6902 operation. Value is nil when the member is absent,
+// so an explicit null can be told apart from a missing value.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies ops to doc in order and returns the patched document. Nothing
+// is applied unless every operation succeeds.
+func Apply(doc json.RawMessage, ops []Operation) (json.RawMessage, error) {
+	root, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("decoding document: %w", err)
+	}
+	for i := range ops {
+		if root, err = applyOp(root, &ops[i]); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, ops[i].Op, ops[i].Path, err)
+		}
+	}
+	out, err := json.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("encoding document: %w", err)
+	}
+	return out, nil
+}
+
+func applyOp(root interface{}, op *Operation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: %s needs a value", ErrInvalid, op.Op)
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: value: %v", ErrInvalid, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(root, path, value)
+		case "replace":
+			if len(path) == 0 {
+				return value, nil
+			}
+			if root, _, err = remove(root, path); err != nil {
+				return nil, err
+			}
+			return add(root, path, value)
+		default:
+			current, err := get(root, path)
+			if err != nil {
+				return nil, err
+			}
+			if !equal(current, value) {
+				return nil, fmt.Errorf("%w: test failed", ErrConflict)
+			}
+			return root, nil
+		}
+	case "remove":
+		root, _, err = remove(root, path)
+		return root, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if op.Op == "move" {
+			if isProperPrefix(from, path) {
+				return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalid)
+			}
+			if root, value, err = remove(root, from); err != nil {
+				return nil, err
+			}
+		} else {
+			if value, err = get(root, from); err != nil {
+				return nil, err
+			}
+			// Copy so later operations on one location don't change the other
+			if value, err = deepCopy(value); err != nil {
+				return nil, err
+			}
+		}
+		return add(root, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalid, op.Op)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("%w: path %q must be empty or start with /", ErrInvalid, p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+func isProperPrefix(prefix, path []string) bool {
+	if len(prefix) >= len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex parses an array index token, which must be in [0, limit].
+func arrayIndex(token string, limit int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrConflict, token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > limit {
+		return 0, fmt.Errorf("%w: array index %q out of range", ErrConflict, token)
+	}
+	return i, nil
+}
+
+func get(node interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q not found", ErrConflict, token)
+			}
+			node = child
+		case []interface{}:
+			i, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%w: %q is not inside an object or array", ErrConflict, token)
+		}
+	}
+	return node, nil
+}
+
+// add sets the value at path, inserting into arrays, and returns the new node.
+func add(node interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token, rest := path[0], path[1:]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if len(rest) == 0 {
+			n[token] = value
+			return n, nil
+		}
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q not found", ErrConflict, token)
+		}
+		child, err := add(child, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+	case []interface{}:
+		if len(rest) == 0 {
+			i := len(n)
+			if token != "-" {
+				var err error
+				if i, err = arrayIndex(token, len(n)); err != nil {
+					return nil, err
+				}
+			}
+			n = append(n, nil)
+			copy(n[i+1:], n[i:])
+			n[i] = value
+			return n, nil
+		}
+		i, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		if n[i], err = add(n[i], rest, value); err != nil {
+			return nil, err
+		}
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%w: %q is not inside an object or array", ErrConflict, token)
+	}
+}
+
+// remove deletes the value at path and returns the new node and the removed value.
+func remove(node interface{}, path []string) (newNode, removed interface{}, err error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalid)
+	}
+	token, rest := path[0], path[1:]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %q not found", ErrConflict, token)
+		}
+		if len(rest) == 0 {
+			delete(n, token)
+			return n, child, nil
+		}
+		if n[token], removed, err = remove(child, rest); err != nil {
+			return nil, nil, err
+		}
+		return n, removed, nil
+	case []interface{}:
+		i, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rest) == 0 {
+			removed = n[i]
+			return append(n[:i], n[i+1:]...), removed, nil
+		}
+		if n[i], removed, err = remove(n[i], rest); err != nil {
+			return nil, nil, err
+		}
+		return n, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %q is not inside an object or array", ErrConflict, token)
+	}
+}
+
+// equal compares decoded JSON values, treating numbers by value (1 equals 1.0).
+func equal(a, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			w, ok := bv[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		af, aErr := av.Float64()
+		bf, bErr := bv.Float64()
+		if aErr != nil || bErr != nil {
+			return av == bv
+		}
+		return af == bf
+	default:
+		return a == b
+	}
+}
+
+func deepCopy(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decode(raw)
+}
+
+// decode unmarshals JSON keeping numbers as json.Number, so values the patch
+// doesn't touch are written back exactly as they were.
+func decode(raw []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
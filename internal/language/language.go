@@ -0,0 +1,117 @@
+// Package language tells the language of an invoice from the scripts of its
+// text. Each Indian language detected here has a Unicode script of its own, so
+// the script names the language; Devanagari, shared by Hindi and Marathi among
+// others, is reported as Hindi. Codes are ISO 639-1.
+package language
+
+import "unicode"
+
+// English is the language of invoices in Latin script.
+const English = "en"
+
+// minLetters is the least text a language is detected from.
+const minLetters = 3
+
+// Script is the Unicode block an Indian language is written in.
+type Script struct {
+	Language     string
+	LanguageName string
+	Name         string
+	First, Last  rune
+}
+
+// Scripts are the Indian scripts detected, in Unicode order.
+var Scripts = []Script{
+	{Language: "hi", LanguageName: "Hindi", Name: "Devanagari", First: 0x0900, Last: 0x097F},
+	{Language: "bn", LanguageName: "Bengali", Name: "Bengali", First: 0x0980, Last: 0x09FF},
+	{Language: "pa", LanguageName: "Punjabi", Name: "Gurmukhi", First: 0x0A00, Last: 0x0A7F},
+	{Language: "gu", LanguageName: "Gujarati", Name: "Gujarati", First: 0x0A80, Last: 0x0AFF},
+	{Language: "or", LanguageName: "Odia", Name: "Odia", First: 0x0B00, Last: 0x0B7F},
+	{Language: "ta", LanguageName: "Tamil", Name: "Tamil", First: 0x0B80, Last: 0x0BFF},
+	{Language: "te", LanguageName: "Telugu", Name: "Telugu", First: 0x0C00, Last: 0x0C7F},
+	{Language: "kn", LanguageName: "Kannada", Name: "Kannada", First: 0x0C80, Last: 0x0CFF},
+	{Language: "ml", LanguageName: "Malayalam", Name: "Malayalam", First: 0x0D00, Last: 0x0D7F},
+}
+
+// ScriptOf returns the Indian script r belongs to, or nil.
+func ScriptOf(r rune) *Script {
+	for i := range Scripts {
+		if r >= Scripts[i].First && r <= Scripts[i].Last {
+			return &Scripts[i]
+		}
+	}
+	return nil
+}
+
+// ForLanguage returns the script of an Indian language code, or nil for English
+// and unknown codes.
+func ForLanguage(code string) *Script {
+	for i := range Scripts {
+		if Scripts[i].Language == code {
+			return &Scripts[i]
+		}
+	}
+	return nil
+}
+
+// FirstIndicScript returns the script of the first Indian-script character in
+// s, or nil if s has none.
+func FirstIndicScript(s string) *Script {
+	for _, r := range s {
+		if sc := ScriptOf(r); sc != nil {
+			return sc
+		}
+	}
+	return nil
+}
+
+// DetectText returns the language of s: the Indian language whose script makes
+// up at least a tenth of its letters (bilingual invoices count as the Indian
+// language), English for other text in Latin script, or "" for too little text.
+func DetectText(s string) string {
+	var c counter
+	for _, r := range s {
+		c.add(r, 1)
+	}
+	return c.language()
+}
+
+// counter tallies letters by script.
+type counter struct {
+	latin int
+	indic map[string]int
+}
+
+func (c *counter) add(r rune, n int) {
+	if sc := ScriptOf(r); sc != nil {
+		// Vowel signs are marks, not letters, but are part of the script's text
+		if c.indic == nil {
+			c.indic = make(map[string]int)
+		}
+		c.indic[sc.Language] += n
+		return
+	}
+	if unicode.Is(unicode.Latin, r) {
+		c.latin += n
+	}
+}
+
+func (c *counter) language() string {
+	best, bestCount, total := "", 0, c.latin
+	for i := range Scripts {
+		n := c.indic[Scripts[i].Language]
+		total += n
+		if n > bestCount {
+			best, bestCount = Scripts[i].Language, n
+		}
+	}
+	switch {
+	case total < minLetters:
+		return ""
+	case bestCount > 0 && bestCount*10 >= total:
+		return best
+	case c.latin > 0:
+		return English
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package language
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strconv"
+	"unicode/utf16"
+)
+
+const (
+	// maxStreamBytes and maxInflatedBytes bound the work on hostile PDFs.
+	maxStreamBytes   = 1 << 20
+	maxInflatedBytes = 8 << 20
+	// maxRangeRunes caps the characters one bfrange entry counts for.
+	maxRangeRunes = 256
+	// dictLookback is how far before a stream its dictionary is looked for.
+	dictLookback = 2048
+)
+
+var (
+	streamKeyword    = []byte("stream")
+	endstreamKeyword = []byte("endstream")
+	objKeyword       = []byte(" obj")
+	bfcharRe         = regexp.MustCompile(`<([0-9A-Fa-f]+)>\s*<([0-9A-Fa-f]+)>`)
+	bfrangeRe        = regexp.MustCompile(`<([0-9A-Fa-f]+)>\s*<([0-9A-Fa-f]+)>\s*<([0-9A-Fa-f]+)>`)
+)
+
+// DetectPDF returns the language of a PDF's text layer, or "" for scanned PDFs
+// and others it can't tell. It reads the ToUnicode maps of the embedded fonts:
+// subset fonts only carry the glyphs the document uses, so the characters they
+// map to show its scripts without laying out any text.
+func DetectPDF(data []byte) string {
+	var c counter
+	inflated := 0
+	for pos := 0; pos < len(data); {
+		i := bytes.Index(data[pos:], streamKeyword)
+		if i < 0 {
+			break
+		}
+		start := pos + i
+		pos = start + len(streamKeyword)
+		if start >= 3 && string(data[start-3:start]) == "end" {
+			continue
+		}
+		// The stream data starts after the end of line that follows the keyword
+		body := pos
+		if body < len(data) && data[body] == '\r' {
+			body++
+		}
+		if body >= len(data) || data[body] != '\n' {
+			continue
+		}
+		body++
+		end := bytes.Index(data[body:], endstreamKeyword)
+		if end < 0 {
+			break
+		}
+		pos = body + end
+
+		dict := data[max(0, start-dictLookback):start]
+		if j := bytes.LastIndex(dict, objKeyword); j >= 0 {
+			dict = dict[j:]
+		}
+		content := data[body:pos]
+		switch {
+		case bytes.Contains(dict, []byte("/FlateDecode")):
+			if len(content) > maxStreamBytes || inflated >= maxInflatedBytes {
+				continue
+			}
+			content = inflate(content, min(maxStreamBytes, maxInflatedBytes-inflated))
+			inflated += len(content)
+		case bytes.Contains(dict, []byte("/Filter")):
+			continue
+		}
+		countCMap(&c, content)
+	}
+	return c.language()
+}
+
+func inflate(data []byte, limit int) []byte {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+	// A truncated or corrupt stream still yields what was read before the error
+	out, _ := io.ReadAll(io.LimitReader(r, int64(limit)))
+	return out
+}
+
+// countCMap adds the characters a ToUnicode CMap maps glyphs to.
+func countCMap(c *counter, cmap []byte) {
+	for _, section := range sections(cmap, "beginbfchar", "endbfchar") {
+		for _, m := range bfcharRe.FindAllSubmatch(section, -1) {
+			for _, r := range decodeUTF16Hex(m[2]) {
+				c.add(r, 1)
+			}
+		}
+	}
+	for _, section := range sections(cmap, "beginbfrange", "endbfrange") {
+		for _, m := range bfrangeRe.FindAllSubmatch(section, -1) {
+			lo, err1 := strconv.ParseUint(string(m[1]), 16, 32)
+			hi, err2 := strconv.ParseUint(string(m[2]), 16, 32)
+			dst := decodeUTF16Hex(m[3])
+			if err1 != nil || err2 != nil || hi < lo || len(dst) == 0 {
+				continue
+			}
+			c.add(dst[0], int(min(hi-lo+1, maxRangeRunes)))
+		}
+	}
+}
+
+// sections returns the parts of data between each begin and the following end.
+func sections(data []byte, begin, end string) [][]byte {
+	var out [][]byte
+	for {
+		i := bytes.Index(data, []byte(begin))
+		if i < 0 {
+			return out
+		}
+		data = data[i+len(begin):]
+		j := bytes.Index(data, []byte(end))
+		if j < 0 {
+			return append(out, data)
+		}
+		out = append(out, data[:j])
+		data = data[j+len(end):]
+	}
+}
+
+func decodeUTF16Hex(h []byte) []rune {
+	b := make([]byte, hex.DecodedLen(len(h)))
+	if _, err := hex.Decode(b, h); err != nil || len(b)%2 != 0 {
+		return nil
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return utf16.Decode(units)
+}
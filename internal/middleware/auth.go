@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"satvos/internal/apierror"
 	"satvos/internal/domain"
 	"satvos/internal/port"
 	"satvos/internal/service"
@@ -18,28 +19,32 @@ const (
 	ContextKeyEmail    = "email"
 	ContextKeyRole     = "role"
 	ContextKeyClaims   = "claims"
+	// ContextKeyHomeTenantID is set only for guests: the tenant the user belongs to
+	// when tenant_id is a tenant they switched to.
+	ContextKeyHomeTenantID = "home_tenant_id"
 )
 
-// AuthMiddleware returns Gin middleware that validates JWT tokens and injects
-// tenant and user context.
+// AuthMiddleware returns Gin middleware that validates JWT tokens, or API keys
+// when authService accepts them, and injects tenant and user context.
 func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
+	apiKeys, acceptsAPIKeys := authService.(service.APIKeyAuthenticator)
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   gin.H{"code": "UNAUTHORIZED", "message": "missing or invalid authorization header"},
-			})
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing or invalid authorization header")
 			return
 		}
 
 		token := strings.TrimPrefix(authHeader, "Bearer ")
-		claims, err := authService.ValidateToken(token)
+		var claims *service.Claims
+		var err error
+		if acceptsAPIKeys && strings.HasPrefix(token, service.APIKeyPrefix) {
+			claims, err = apiKeys.AuthenticateAPIKey(c.Request.Context(), token)
+		} else {
+			claims, err = authService.ValidateToken(token)
+		}
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   gin.H{"code": "UNAUTHORIZED", "message": "invalid or expired token"},
-			})
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "invalid or expired token")
 			return
 		}
 
@@ -48,6 +53,9 @@ func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 		c.Set(ContextKeyEmail, claims.Email)
 		c.Set(ContextKeyRole, string(claims.Role))
 		c.Set(ContextKeyClaims, claims)
+		if claims.HomeTenantID != nil {
+			c.Set(ContextKeyHomeTenantID, *claims.HomeTenantID)
+		}
 		c.Next()
 	}
 }
@@ -57,10 +65,7 @@ func RequireRole(roles ...domain.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		roleStr, exists := c.Get(ContextKeyRole)
 		if !exists {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"success": false,
-				"error":   gin.H{"code": "FORBIDDEN", "message": "role not found in context"},
-			})
+			apierror.Abort(c, http.StatusForbidden, "FORBIDDEN", "role not found in context")
 			return
 		}
 
@@ -72,10 +77,7 @@ func RequireRole(roles ...domain.UserRole) gin.HandlerFunc {
 			}
 		}
 
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-			"success": false,
-			"error":   gin.H{"code": "FORBIDDEN", "message": "insufficient permissions"},
-		})
+		apierror.Abort(c, http.StatusForbidden, "FORBIDDEN", "insufficient permissions")
 	}
 }
 
@@ -97,6 +99,16 @@ func GetUserID(c *gin.Context) (uuid.UUID, error) {
 	return val.(uuid.UUID), nil
 }
 
+// GetHomeTenantID returns the caller's home tenant when they act as a guest of
+// the token's tenant.
+func GetHomeTenantID(c *gin.Context) (uuid.UUID, bool) {
+	val, exists := c.Get(ContextKeyHomeTenantID)
+	if !exists {
+		return uuid.Nil, false
+	}
+	return val.(uuid.UUID), true
+}
+
 // GetRole extracts the user role string from the Gin context.
 func GetRole(c *gin.Context) string {
 	val, exists := c.Get(ContextKeyRole)
@@ -118,35 +130,23 @@ func RequireEmailVerified(userRepo port.UserRepository) gin.HandlerFunc {
 
 		tenantID, err := GetTenantID(c)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   gin.H{"code": "UNAUTHORIZED", "message": "missing tenant context"},
-			})
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing tenant context")
 			return
 		}
 		userID, err := GetUserID(c)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   gin.H{"code": "UNAUTHORIZED", "message": "missing user context"},
-			})
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing user context")
 			return
 		}
 
 		user, err := userRepo.GetByID(c.Request.Context(), tenantID, userID)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   gin.H{"code": "UNAUTHORIZED", "message": "user not found"},
-			})
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "user not found")
 			return
 		}
 
 		if !user.EmailVerified {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"success": false,
-				"error":   gin.H{"code": "EMAIL_NOT_VERIFIED", "message": "please verify your email before performing this action"},
-			})
+			apierror.Abort(c, http.StatusForbidden, "EMAIL_NOT_VERIFIED", "please verify your email before performing this action")
 			return
 		}
 
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/apierror"
+	"satvos/internal/domain"
+)
+
+// EnforceRouteMatrix returns middleware that authorizes requests against a declarative
+// route matrix, keyed by HTTP method and route template (c.FullPath()). Routes missing
+// from the matrix are denied, so every new endpoint must be declared explicitly.
+func EnforceRouteMatrix(rules []domain.RouteRule) gin.HandlerFunc {
+	allowed := make(map[string]map[domain.UserRole]bool, len(rules))
+	for i := range rules {
+		roles := make(map[domain.UserRole]bool, len(rules[i].Roles))
+		for _, r := range rules[i].Roles {
+			roles[r] = true
+		}
+		allowed[rules[i].Method+" "+rules[i].Path] = roles
+	}
+
+	return func(c *gin.Context) {
+		roles, declared := allowed[c.Request.Method+" "+c.FullPath()]
+		if !declared {
+			apierror.Abort(c, http.StatusForbidden, "FORBIDDEN", "route not authorized")
+			return
+		}
+
+		roleStr, _ := c.Get(ContextKeyRole)
+		role, _ := roleStr.(string)
+		if !roles[domain.UserRole(role)] {
+			apierror.Abort(c, http.StatusForbidden, "FORBIDDEN", "insufficient permissions")
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/apierror"
+)
+
+// BodyLimits holds the maximum request body size, in bytes, for each route group.
+// Zero disables the limit for that group.
+type BodyLimits struct {
+	Auth   int64 // public /auth endpoints
+	JSON   int64 // every other API route
+	Upload int64 // multipart upload routes
+}
+
+// BodyLimit returns middleware that caps the request body at defaultMax bytes, or
+// at routeMax[c.FullPath()] for listed route templates. A single middleware picks
+// the limit per route because nested http.MaxBytesReader wrappers can only tighten,
+// never widen, the limit of an enclosing group.
+//
+// Requests that declare an oversized Content-Length are rejected immediately with
+// 413; chunked bodies are cut off at the limit and surface as a read error in the
+// handler (see isBodyTooLarge in the handler package).
+func BodyLimit(defaultMax int64, routeMax map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := defaultMax
+		if l, ok := routeMax[c.FullPath()]; ok {
+			limit = l
+		}
+		if limit <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			apierror.Abort(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "request body exceeds the maximum allowed size")
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
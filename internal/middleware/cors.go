@@ -1,29 +1,122 @@
 package middleware
 
 import (
+	"log"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/port"
+	"satvos/internal/service"
 )
 
 // CORS returns a middleware that handles Cross-Origin Resource Sharing (CORS).
 // It allows requests from the specified origins and handles preflight OPTIONS requests.
 func CORS(allowedOrigins []string) gin.HandlerFunc {
+	return TenantCORS(allowedOrigins, nil, nil, 0)
+}
+
+// corsOriginCacheMaxEntries bounds TenantCORS's origin cache, whose keys come
+// from the client; when it is full of live entries it is emptied rather than
+// grown.
+const corsOriginCacheMaxEntries = 1000
+
+type originTenants struct {
+	tenantIDs []uuid.UUID
+	expires   time.Time
+}
+
+// TenantCORS is CORS that also allows the origins tenants registered for their
+// own (white-labeled) frontends. A request carrying a valid bearer token or API
+// key is allowed from its tenant's origins only. Any other request gets only the
+// server-wide allowedOrigins, except a preflight, which carries no credentials
+// and returns no data: it is allowed from any tenant's origins so the real
+// request can be sent and checked. Origin lookups are
+// cached for cacheTTL, but only for origins some tenant registered; database
+// errors allow only the server-wide origins.
+// A nil origins repository disables tenant origins.
+func TenantCORS(allowedOrigins []string, authService service.AuthService, origins port.TenantCORSOriginRepository, cacheTTL time.Duration) gin.HandlerFunc {
 	originSet := make(map[string]bool, len(allowedOrigins))
 	for _, o := range allowedOrigins {
 		originSet[o] = true
 	}
 
+	var mu sync.RWMutex
+	cache := make(map[string]originTenants)
+	tenantsFor := func(c *gin.Context, origin string) []uuid.UUID {
+		mu.RLock()
+		entry, ok := cache[origin]
+		mu.RUnlock()
+		if ok && time.Now().Before(entry.expires) {
+			return entry.tenantIDs
+		}
+		tenantIDs, err := origins.TenantsByOrigin(c.Request.Context(), origin)
+		if err != nil {
+			log.Printf("TenantCORS: origin lookup failed for %s: %v", origin, err)
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		now := time.Now()
+		if ok {
+			delete(cache, origin)
+		}
+		if len(tenantIDs) == 0 || cacheTTL <= 0 {
+			return tenantIDs
+		}
+		if len(cache) >= corsOriginCacheMaxEntries {
+			for o, e := range cache {
+				if now.After(e.expires) {
+					delete(cache, o)
+				}
+			}
+			if len(cache) >= corsOriginCacheMaxEntries {
+				clear(cache)
+			}
+		}
+		cache[origin] = originTenants{tenantIDs: tenantIDs, expires: now.Add(cacheTTL)}
+		return tenantIDs
+	}
+
+	allowed := func(c *gin.Context, origin string) bool {
+		if originSet[origin] {
+			return true
+		}
+		if origin == "" || origins == nil {
+			return false
+		}
+		tenantID, ok := requestTenant(c, authService)
+		if !ok && c.Request.Method != http.MethodOptions {
+			return false
+		}
+		tenantIDs := tenantsFor(c, origin)
+		if !ok {
+			return len(tenantIDs) > 0
+		}
+		for _, id := range tenantIDs {
+			if id == tenantID {
+				return true
+			}
+		}
+		return false
+	}
+
 	return func(c *gin.Context) {
 		origin := c.GetHeader("Origin")
 
-		if originSet[origin] {
+		c.Header("Vary", "Origin")
+		if allowed(c, origin) {
 			c.Header("Access-Control-Allow-Origin", origin)
 		}
 
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
 		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept, Origin, X-Requested-With")
 		c.Header("Access-Control-Allow-Credentials", "true")
+		c.Header("Access-Control-Expose-Headers", "X-Quota-Limit, X-Quota-Remaining")
 		c.Header("Access-Control-Max-Age", "86400")
 
 		// Handle preflight request
@@ -35,3 +128,24 @@ func CORS(allowedOrigins []string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// requestTenant returns the tenant of the request's bearer token or API key, as
+// AuthMiddleware would authenticate it. An invalid credential counts as none.
+func requestTenant(c *gin.Context, authService service.AuthService) (uuid.UUID, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authService == nil || !strings.HasPrefix(authHeader, "Bearer ") {
+		return uuid.Nil, false
+	}
+	token := strings.TrimPrefix(authHeader, "Bearer ")
+	var claims *service.Claims
+	var err error
+	if apiKeys, ok := authService.(service.APIKeyAuthenticator); ok && strings.HasPrefix(token, service.APIKeyPrefix) {
+		claims, err = apiKeys.AuthenticateAPIKey(c.Request.Context(), token)
+	} else {
+		claims, err = authService.ValidateToken(token)
+	}
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return claims.TenantID, true
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/apierror"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// AuditDenials returns middleware that records every authenticated request
+// answered with 403, whether the route matrix, a tenant check or a service
+// permission check refused it. It must run after AuthMiddleware. A nil repo
+// disables recording. Write failures are logged and never change the response.
+func AuditDenials(repo port.AuthzDenialRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if repo == nil || c.Writer.Status() != http.StatusForbidden {
+			return
+		}
+		tenantID, err := GetTenantID(c)
+		if err != nil {
+			return
+		}
+		userID, err := GetUserID(c)
+		if err != nil {
+			return
+		}
+
+		code, _ := c.Get(apierror.ContextKeyCode)
+		reason, _ := c.Get(apierror.ContextKeyMessage)
+		requestID, _ := c.Get("request_id")
+		denial := &domain.AuthzDenial{
+			TenantID: tenantID,
+			UserID:   userID,
+			Role:     GetRole(c),
+			Method:   c.Request.Method,
+			Path:     c.Request.URL.Path,
+			Route:    c.FullPath(),
+		}
+		denial.Code, _ = code.(string)
+		denial.Reason, _ = reason.(string)
+		denial.RequestID, _ = requestID.(string)
+
+		if err := repo.Create(context.WithoutCancel(c.Request.Context()), denial); err != nil {
+			log.Printf("AuditDenials: failed to record denial for %s on %s %s: %v", userID, denial.Method, denial.Path, err)
+		}
+	}
+}
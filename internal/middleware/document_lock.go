@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/apierror"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// RequireDocumentUnlocked returns middleware that rejects edits to the :id
+// document with 423 + Retry-After while another user holds its review lock.
+// The lock is soft: if it can't be read the request goes through.
+func RequireDocumentUnlocked(locks port.DocumentLockRepository, userRepo port.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		documentID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			c.Next()
+			return
+		}
+		tenantID, err := GetTenantID(c)
+		if err != nil {
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing tenant context")
+			return
+		}
+		userID, err := GetUserID(c)
+		if err != nil {
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing user context")
+			return
+		}
+
+		lock, err := locks.GetActive(c.Request.Context(), tenantID, documentID)
+		if err != nil {
+			log.Printf("RequireDocumentUnlocked: reading lock of %s: %v", documentID, err)
+			c.Next()
+			return
+		}
+		if lock == nil || lock.UserID == userID {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(lock.ExpiresAt).Seconds()))))
+		apierror.Abort(c, http.StatusLocked, "DOCUMENT_LOCKED", lockedMessage(c, userRepo, lock))
+	}
+}
+
+// lockedMessage names the lock holder when they can be looked up.
+func lockedMessage(c *gin.Context, userRepo port.UserRepository, lock *domain.DocumentLock) string {
+	holder := "another user"
+	if user, err := userRepo.GetByID(c.Request.Context(), lock.TenantID, lock.UserID); err == nil {
+		holder = user.FullName
+	}
+	return fmt.Sprintf("document is being reviewed by %s until %s", holder, lock.ExpiresAt.UTC().Format(time.RFC3339))
+}
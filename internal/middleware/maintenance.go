@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+
+	"satvos/internal/apierror"
+)
+
+// MaintenanceMode is a process-wide switch. While enabled, Maintenance middleware
+// rejects write requests; reads keep working. The switch is per instance — flip it
+// on every replica (or via SATVOS_MAINTENANCE_ENABLED at deploy time).
+type MaintenanceMode struct {
+	enabled        atomic.Bool
+	retryAfterSecs int
+}
+
+// NewMaintenanceMode creates a MaintenanceMode with the given initial state.
+func NewMaintenanceMode(enabled bool, retryAfterSecs int) *MaintenanceMode {
+	m := &MaintenanceMode{retryAfterSecs: retryAfterSecs}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled reports whether maintenance mode is on.
+func (m *MaintenanceMode) Enabled() bool { return m.enabled.Load() }
+
+// Set turns maintenance mode on or off.
+func (m *MaintenanceMode) Set(enabled bool) { m.enabled.Store(enabled) }
+
+// RetryAfterSecs is the Retry-After value sent with rejected writes.
+func (m *MaintenanceMode) RetryAfterSecs() int { return m.retryAfterSecs }
+
+// Maintenance returns middleware that rejects writes with 503 + Retry-After while
+// maintenance mode is enabled. Safe methods (GET, HEAD, OPTIONS) always pass, as do
+// the exempt route templates (matched against c.FullPath()).
+func Maintenance(m *MaintenanceMode, exemptPaths ...string) gin.HandlerFunc {
+	exempt := make(map[string]bool, len(exemptPaths))
+	for _, p := range exemptPaths {
+		exempt[p] = true
+	}
+
+	return func(c *gin.Context) {
+		if !m.Enabled() || exempt[c.FullPath()] {
+			c.Next()
+			return
+		}
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(m.retryAfterSecs))
+		apierror.Abort(c, http.StatusServiceUnavailable, "MAINTENANCE", "service is in maintenance mode; writes are temporarily disabled")
+	}
+}
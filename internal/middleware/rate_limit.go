@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/apierror"
+)
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimitPerUser returns middleware that allows each user at most limit requests
+// per window, counted in fixed windows starting at the user's first request.
+// Excess requests get 429 RATE_LIMITED with a Retry-After header. State is kept in
+// memory, so each server instance counts separately. It relies on AuthMiddleware
+// having set the user_id; a limit of 0 or less disables it.
+func RateLimitPerUser(limit int, window time.Duration) gin.HandlerFunc {
+	var (
+		mu      sync.Mutex
+		windows = make(map[uuid.UUID]*rateWindow)
+	)
+
+	return func(c *gin.Context) {
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+		userID, err := GetUserID(c)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+		mu.Lock()
+		w, ok := windows[userID]
+		if !ok || now.Sub(w.start) >= window {
+			// Drop expired windows while the map is locked anyway
+			for id, other := range windows {
+				if now.Sub(other.start) >= window {
+					delete(windows, id)
+				}
+			}
+			w = &rateWindow{start: now}
+			windows[userID] = w
+		}
+		w.count++
+		allowed := w.count <= limit
+		retryAfter := w.start.Add(window).Sub(now)
+		mu.Unlock()
+
+		if !allowed {
+			secs := int(retryAfter.Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(secs))
+			apierror.Abort(c, http.StatusTooManyRequests, "RATE_LIMITED", "too many requests; try again later")
+			return
+		}
+		c.Next()
+	}
+}
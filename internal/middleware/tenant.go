@@ -1,9 +1,18 @@
 package middleware
 
 import (
+	"errors"
+	"log"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"satvos/internal/apierror"
+	"satvos/internal/domain"
+	"satvos/internal/port"
 )
 
 // TenantGuard returns middleware that ensures tenant context is present.
@@ -12,10 +21,113 @@ func TenantGuard() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		_, exists := c.Get(ContextKeyTenantID)
 		if !exists {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   gin.H{"code": "UNAUTHORIZED", "message": "tenant context required"},
-			})
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "tenant context required")
+			return
+		}
+		c.Next()
+	}
+}
+
+type tenantStatus struct {
+	active    bool
+	moved     bool
+	checkedAt time.Time
+}
+
+// RequireActiveTenant returns middleware that rejects requests from suspended tenants
+// (IsActive=false) with 403 TENANT_INACTIVE, so tokens issued before a suspension stop
+// working, and requests for tenants whose routing flag names a database cluster other
+// than cluster with 421 TENANT_MOVED. Lookups are cached per tenant for cacheTTL; a
+// deleted tenant is treated as inactive, while database errors let the request through.
+// It relies on AuthMiddleware having already set the tenant_id.
+func RequireActiveTenant(tenantRepo port.TenantRepository, cluster string, cacheTTL time.Duration) gin.HandlerFunc {
+	var mu sync.RWMutex
+	cache := make(map[uuid.UUID]tenantStatus)
+
+	return func(c *gin.Context) {
+		tenantID, err := GetTenantID(c)
+		if err != nil {
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "tenant context required")
+			return
+		}
+
+		mu.RLock()
+		status, ok := cache[tenantID]
+		mu.RUnlock()
+		if !ok || time.Since(status.checkedAt) >= cacheTTL {
+			tenant, lookupErr := tenantRepo.GetByID(c.Request.Context(), tenantID)
+			if lookupErr != nil && !errors.Is(lookupErr, domain.ErrNotFound) {
+				// Transient lookup failure: don't lock tenants out, don't cache
+				log.Printf("RequireActiveTenant: tenant lookup failed for %s: %v", tenantID, lookupErr)
+				c.Next()
+				return
+			}
+			status = tenantStatus{active: lookupErr == nil && tenant.IsActive, checkedAt: time.Now()}
+			if lookupErr == nil {
+				status.moved = tenant.DBCluster != "" && tenant.DBCluster != cluster
+			}
+			mu.Lock()
+			cache[tenantID] = status
+			mu.Unlock()
+		}
+
+		if status.moved {
+			apierror.Abort(c, http.StatusMisdirectedRequest, "TENANT_MOVED", "tenant has moved to another database cluster")
+			return
+		}
+		if !status.active {
+			apierror.Abort(c, http.StatusForbidden, "TENANT_INACTIVE", "tenant is inactive")
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireActiveMembership returns middleware that rejects guest tokens with 403
+// TENANT_ACCESS_DENIED once the user's membership of the token's tenant is removed
+// or suspended. Home-tenant tokens pass without a lookup. Results are cached per
+// tenant and user for cacheTTL; database errors let the request through. It relies
+// on AuthMiddleware having already set the tenant, user and home tenant.
+func RequireActiveMembership(userRepo port.UserRepository, cacheTTL time.Duration) gin.HandlerFunc {
+	type key struct{ tenantID, userID uuid.UUID }
+	var mu sync.RWMutex
+	cache := make(map[key]tenantStatus)
+
+	return func(c *gin.Context) {
+		if _, guest := GetHomeTenantID(c); !guest {
+			c.Next()
+			return
+		}
+		tenantID, err := GetTenantID(c)
+		if err != nil {
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "tenant context required")
+			return
+		}
+		userID, err := GetUserID(c)
+		if err != nil {
+			apierror.Abort(c, http.StatusUnauthorized, "UNAUTHORIZED", "missing user context")
+			return
+		}
+
+		k := key{tenantID: tenantID, userID: userID}
+		mu.RLock()
+		status, ok := cache[k]
+		mu.RUnlock()
+		if !ok || time.Since(status.checkedAt) >= cacheTTL {
+			_, lookupErr := userRepo.GetByID(c.Request.Context(), tenantID, userID)
+			if lookupErr != nil && !errors.Is(lookupErr, domain.ErrNotFound) {
+				log.Printf("RequireActiveMembership: membership lookup failed for %s in %s: %v", userID, tenantID, lookupErr)
+				c.Next()
+				return
+			}
+			status = tenantStatus{active: lookupErr == nil, checkedAt: time.Now()}
+			mu.Lock()
+			cache[k] = status
+			mu.Unlock()
+		}
+
+		if !status.active {
+			apierror.Abort(c, http.StatusForbidden, "TENANT_ACCESS_DENIED", "no access to this tenant")
 			return
 		}
 		c.Next()
@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type emailNotifier struct {
+	sender port.EmailSender
+}
+
+// NewEmailNotifier creates a Notifier that emails each recipient, using the
+// sender's template for the notification kind and a plain-text email otherwise.
+func NewEmailNotifier(sender port.EmailSender) port.Notifier {
+	return &emailNotifier{sender: sender}
+}
+
+func (e *emailNotifier) Notify(ctx context.Context, n *port.Notification) error {
+	var errs []error
+	for _, to := range n.Recipients {
+		if err := e.send(ctx, n, to); err != nil {
+			errs = append(errs, fmt.Errorf("emailing %s: %w", to.Email, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e *emailNotifier) send(ctx context.Context, n *port.Notification, to port.NotificationRecipient) error {
+	switch {
+	case n.Kind == domain.NotificationKindEmailVerification:
+		return e.sender.SendVerificationEmail(ctx, to.Email, to.Name, n.Token)
+	case n.Kind == domain.NotificationKindPasswordReset:
+		return e.sender.SendPasswordResetEmail(ctx, to.Email, to.Name, n.Token)
+	case n.Kind == domain.NotificationKindEmailChanged:
+		return e.sender.SendEmailChangeNotice(ctx, to.Email, to.Name, n.NewEmail, n.Token)
+	case n.Kind == domain.NotificationKindInvitation:
+		return e.sender.SendInvitationEmail(ctx, to.Email, to.Name, n.TenantName, n.Token)
+	case n.Kind == domain.NotificationKindIngestionReport && n.IngestionReport != nil:
+		return e.sender.SendIngestionReport(ctx, to.Email, to.Name, n.IngestionReport)
+	default:
+		return e.sender.SendAlertEmail(ctx, to.Email, n.Subject, n.Body)
+	}
+}
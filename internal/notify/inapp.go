@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type inAppNotifier struct {
+	repo port.InAppNotificationRepository
+}
+
+// NewInAppNotifier creates a Notifier that stores each notification in the
+// inbox of every recipient with a user ID.
+func NewInAppNotifier(repo port.InAppNotificationRepository) port.Notifier {
+	return &inAppNotifier{repo: repo}
+}
+
+func (a *inAppNotifier) Notify(ctx context.Context, n *port.Notification) error {
+	var errs []error
+	for _, to := range n.Recipients {
+		if to.UserID == uuid.Nil {
+			continue
+		}
+		err := a.repo.Create(ctx, &domain.InAppNotification{
+			TenantID: n.TenantID,
+			UserID:   to.UserID,
+			Kind:     n.Kind,
+			Subject:  n.Subject,
+			Body:     n.Body,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("storing for user %s: %w", to.UserID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
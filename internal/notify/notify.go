@@ -0,0 +1,149 @@
+// Package notify delivers user notifications by email, Slack, webhook and the
+// in-app inbox, routing each notification kind to channels per tenant.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// Router is a port.Notifier that delivers each notification on the channels
+// routed for its kind: the tenant's override when it has one, else the server
+// default, else email.
+type Router struct {
+	channels map[domain.NotifierChannel]port.Notifier
+	defaults map[domain.NotificationKind][]domain.NotifierChannel
+	routes   port.NotificationRouteRepository
+}
+
+// NewRouter creates a Router over the configured channels. routes may be nil,
+// in which case every tenant uses the defaults.
+func NewRouter(
+	channels map[domain.NotifierChannel]port.Notifier,
+	defaults map[domain.NotificationKind][]domain.NotifierChannel,
+	routes port.NotificationRouteRepository,
+) *Router {
+	return &Router{channels: channels, defaults: defaults, routes: routes}
+}
+
+// Notify delivers n on each of its channels, returning the combined errors. A
+// routed channel that isn't configured on this server is skipped.
+func (r *Router) Notify(ctx context.Context, n *port.Notification) error {
+	var errs []error
+	for _, ch := range r.channelsFor(ctx, n.TenantID, n.Kind) {
+		notifier, ok := r.channels[ch]
+		if !ok {
+			log.Printf("notify.Router: %s notification for tenant %s routed to unconfigured channel %s", n.Kind, n.TenantID, ch)
+			continue
+		}
+		msg := *n
+		if ch != domain.NotifierChannelEmail {
+			msg.Token = ""
+		}
+		if err := notifier.Notify(ctx, &msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ch, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Available reports whether ch is configured on this server.
+func (r *Router) Available(ch domain.NotifierChannel) bool {
+	_, ok := r.channels[ch]
+	return ok
+}
+
+// builtinDefaults are the channels of kinds that go beyond email when the
+// server configures no route for them.
+var builtinDefaults = map[domain.NotificationKind][]domain.NotifierChannel{
+	domain.NotificationKindQuotaWarning: {domain.NotifierChannelEmail, domain.NotifierChannelInApp},
+}
+
+// Default returns the server's channels for kind.
+func (r *Router) Default(kind domain.NotificationKind) []domain.NotifierChannel {
+	if channels, ok := r.defaults[kind]; ok {
+		return channels
+	}
+	if channels, ok := builtinDefaults[kind]; ok {
+		return channels
+	}
+	return []domain.NotifierChannel{domain.NotifierChannelEmail}
+}
+
+func (r *Router) channelsFor(ctx context.Context, tenantID uuid.UUID, kind domain.NotificationKind) []domain.NotifierChannel {
+	if r.routes == nil || tenantID == uuid.Nil {
+		return r.Default(kind)
+	}
+	routes, err := r.routes.ListByTenant(ctx, tenantID)
+	if err != nil {
+		log.Printf("notify.Router: loading routes of tenant %s failed, using defaults: %v", tenantID, err)
+		return r.Default(kind)
+	}
+	for i := range routes {
+		if routes[i].Kind == kind {
+			channels := make([]domain.NotifierChannel, len(routes[i].Channels))
+			for j, ch := range routes[i].Channels {
+				channels[j] = domain.NotifierChannel(ch)
+			}
+			return channels
+		}
+	}
+	return r.Default(kind)
+}
+
+// ParseRoutes reads default routes given as kind to channels joined with "+",
+// e.g. {"ingestion_report": "email+slack"}. Kinds that carry a token must
+// include email, or their links could never be delivered.
+func ParseRoutes(raw map[string]string) (map[domain.NotificationKind][]domain.NotifierChannel, error) {
+	routes := make(map[domain.NotificationKind][]domain.NotifierChannel, len(raw))
+	for kind, value := range raw {
+		var channels []domain.NotifierChannel
+		for _, ch := range strings.Split(value, "+") {
+			channels = append(channels, domain.NotifierChannel(strings.TrimSpace(ch)))
+		}
+		if err := ValidateRoute(domain.NotificationKind(kind), channels); err != nil {
+			return nil, err
+		}
+		routes[domain.NotificationKind(kind)] = channels
+	}
+	return routes, nil
+}
+
+// ValidateRoute checks that kind is known and channels is a non-empty list of
+// known, distinct channels that includes email when kind carries a token.
+func ValidateRoute(kind domain.NotificationKind, channels []domain.NotifierChannel) error {
+	known := false
+	for _, k := range domain.NotificationKinds {
+		known = known || k == kind
+	}
+	if !known {
+		return fmt.Errorf("%w: unknown notification kind %q", domain.ErrInvalidNotificationRoutes, kind)
+	}
+	if len(channels) == 0 {
+		return fmt.Errorf("%w: %s needs at least one channel", domain.ErrInvalidNotificationRoutes, kind)
+	}
+	seen := map[domain.NotifierChannel]bool{}
+	for _, ch := range channels {
+		switch ch {
+		case domain.NotifierChannelEmail, domain.NotifierChannelSlack, domain.NotifierChannelWebhook, domain.NotifierChannelInApp:
+		default:
+			return fmt.Errorf("%w: unknown channel %q", domain.ErrInvalidNotificationRoutes, ch)
+		}
+		if seen[ch] {
+			return fmt.Errorf("%w: %s lists %s twice", domain.ErrInvalidNotificationRoutes, kind, ch)
+		}
+		seen[ch] = true
+	}
+	if kind.CarriesToken() && !seen[domain.NotifierChannelEmail] {
+		return fmt.Errorf("%w: %s must include email", domain.ErrInvalidNotificationRoutes, kind)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type slackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlackNotifier creates a Notifier that posts each notification once to a
+// Slack incoming webhook.
+func NewSlackNotifier(url string, client *http.Client) port.Notifier {
+	return &slackNotifier{url: url, client: client}
+}
+
+func (s *slackNotifier) Notify(ctx context.Context, n *port.Notification) error {
+	text := "*" + n.Subject + "*"
+	if n.Body != "" {
+		text += "\n" + n.Body
+	}
+	return postJSON(ctx, s.client, s.url, map[string]string{"text": text})
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a Notifier that POSTs each notification once as
+// JSON to url.
+func NewWebhookNotifier(url string, client *http.Client) port.Notifier {
+	return &webhookNotifier{url: url, client: client}
+}
+
+// webhookPayload is the JSON body of a webhook notification.
+type webhookPayload struct {
+	Kind       domain.NotificationKind `json:"kind"`
+	TenantID   uuid.UUID               `json:"tenant_id"`
+	Recipients []webhookRecipient      `json:"recipients"`
+	Subject    string                  `json:"subject"`
+	Body       string                  `json:"body"`
+	SentAt     time.Time               `json:"sent_at"`
+}
+
+type webhookRecipient struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	Name   string    `json:"name"`
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, n *port.Notification) error {
+	payload := webhookPayload{
+		Kind:       n.Kind,
+		TenantID:   n.TenantID,
+		Recipients: make([]webhookRecipient, len(n.Recipients)),
+		Subject:    n.Subject,
+		Body:       n.Body,
+		SentAt:     time.Now().UTC(),
+	}
+	for i, r := range n.Recipients {
+		payload.Recipients[i] = webhookRecipient{UserID: r.UserID, Email: r.Email, Name: r.Name}
+	}
+	return postJSON(ctx, w.client, w.url, payload)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling notification webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
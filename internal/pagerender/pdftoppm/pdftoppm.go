@@ -0,0 +1,64 @@
+package pdftoppm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type renderer struct {
+	binary string
+}
+
+// NewRenderer creates a PageRenderer that runs poppler's pdftoppm. binary is the
+// executable name or path; it is looked up on every render, so a missing binary
+// surfaces as domain.ErrPageRenderUnavailable instead of failing startup.
+func NewRenderer(binary string) port.PageRenderer {
+	return &renderer{binary: binary}
+}
+
+func (r *renderer) RenderPage(ctx context.Context, pdf []byte, page, dpi int) ([]byte, error) {
+	path, err := exec.LookPath(r.binary)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrPageRenderUnavailable, err)
+	}
+
+	n := strconv.Itoa(page)
+	// -singlefile writes just the page, with no page-number suffix; "-" sends it to stdout
+	cmd := exec.CommandContext(ctx, path, "-png", "-r", strconv.Itoa(dpi), "-f", n, "-l", n, "-singlefile", "-", "-")
+	cmd.Stdin = bytes.NewReader(pdf)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if isPageRangeError(stderr.String()) {
+			return nil, domain.ErrPageNotFound
+		}
+		return nil, fmt.Errorf("pdftoppm: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() == 0 {
+		// Older poppler releases exit 0 without output for a page past the end
+		if isPageRangeError(stderr.String()) {
+			return nil, domain.ErrPageNotFound
+		}
+		return nil, errors.New("pdftoppm: no output")
+	}
+	return stdout.Bytes(), nil
+}
+
+// isPageRangeError reports whether pdftoppm rejected the requested page as out of
+// range ("Wrong page range given: the first page (5) can not be after the last page (3).").
+func isPageRangeError(stderr string) bool {
+	return strings.Contains(stderr, "Wrong page range")
+}
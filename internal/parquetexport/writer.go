@@ -0,0 +1,285 @@
+// Package parquetexport writes SATVOS documents as normalized Parquet fact
+// tables for loading into analytics warehouses.
+package parquetexport
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+// Table names, also used as the S3 folder of each table.
+const (
+	TableDocuments   = "documents"
+	TableLineItems   = "line_items"
+	TableValidations = "validations"
+)
+
+// DocumentRow is one row of the documents table. Invoice columns are null or
+// empty when the document has not been parsed.
+type DocumentRow struct {
+	DocumentID           string     `parquet:"document_id"`
+	TenantID             string     `parquet:"tenant_id"`
+	CollectionID         string     `parquet:"collection_id"`
+	FileID               string     `parquet:"file_id"`
+	Name                 string     `parquet:"name"`
+	DocumentType         string     `parquet:"document_type"`
+	ParsingStatus        string     `parquet:"parsing_status"`
+	ReviewStatus         string     `parquet:"review_status"`
+	ValidationStatus     string     `parquet:"validation_status"`
+	ReconciliationStatus string     `parquet:"reconciliation_status"`
+	ParserModel          string     `parquet:"parser_model"`
+	InvoiceNumber        string     `parquet:"invoice_number"`
+	InvoiceDate          string     `parquet:"invoice_date"`
+	DueDate              string     `parquet:"due_date"`
+	InvoiceType          string     `parquet:"invoice_type"`
+	Currency             string     `parquet:"currency"`
+	PlaceOfSupply        string     `parquet:"place_of_supply"`
+	ReverseCharge        *bool      `parquet:"reverse_charge,optional"`
+	IRN                  string     `parquet:"irn"`
+	SellerName           string     `parquet:"seller_name"`
+	SellerGSTIN          string     `parquet:"seller_gstin"`
+	SellerStateCode      string     `parquet:"seller_state_code"`
+	BuyerName            string     `parquet:"buyer_name"`
+	BuyerGSTIN           string     `parquet:"buyer_gstin"`
+	BuyerStateCode       string     `parquet:"buyer_state_code"`
+	Subtotal             *float64   `parquet:"subtotal,optional"`
+	TaxableAmount        *float64   `parquet:"taxable_amount,optional"`
+	CGST                 *float64   `parquet:"cgst,optional"`
+	SGST                 *float64   `parquet:"sgst,optional"`
+	IGST                 *float64   `parquet:"igst,optional"`
+	Cess                 *float64   `parquet:"cess,optional"`
+	RoundOff             *float64   `parquet:"round_off,optional"`
+	Total                *float64   `parquet:"total,optional"`
+	LineItemCount        *int64     `parquet:"line_item_count,optional"`
+	ParsedAt             *time.Time `parquet:"parsed_at,optional,timestamp(millisecond)"`
+	ReviewedAt           *time.Time `parquet:"reviewed_at,optional,timestamp(millisecond)"`
+	CreatedAt            time.Time  `parquet:"created_at,timestamp(millisecond)"`
+	UpdatedAt            time.Time  `parquet:"updated_at,timestamp(millisecond)"`
+	ExportedAt           time.Time  `parquet:"exported_at,timestamp(millisecond)"`
+}
+
+// LineItemRow is one row of the line_items table.
+type LineItemRow struct {
+	DocumentID    string    `parquet:"document_id"`
+	TenantID      string    `parquet:"tenant_id"`
+	LineNumber    int64     `parquet:"line_number"`
+	Description   string    `parquet:"description"`
+	HSNSACCode    string    `parquet:"hsn_sac_code"`
+	Quantity      float64   `parquet:"quantity"`
+	Unit          string    `parquet:"unit"`
+	UnitPrice     float64   `parquet:"unit_price"`
+	Discount      float64   `parquet:"discount"`
+	TaxableAmount float64   `parquet:"taxable_amount"`
+	CGSTRate      float64   `parquet:"cgst_rate"`
+	CGSTAmount    float64   `parquet:"cgst_amount"`
+	SGSTRate      float64   `parquet:"sgst_rate"`
+	SGSTAmount    float64   `parquet:"sgst_amount"`
+	IGSTRate      float64   `parquet:"igst_rate"`
+	IGSTAmount    float64   `parquet:"igst_amount"`
+	Total         float64   `parquet:"total"`
+	ExportedAt    time.Time `parquet:"exported_at,timestamp(millisecond)"`
+}
+
+// ValidationRow is one row of the validations table: one rule result of a document.
+type ValidationRow struct {
+	DocumentID             string     `parquet:"document_id"`
+	TenantID               string     `parquet:"tenant_id"`
+	RuleID                 string     `parquet:"rule_id"`
+	RuleName               string     `parquet:"rule_name"`
+	RuleKey                string     `parquet:"rule_key"`
+	Severity               string     `parquet:"severity"`
+	FieldPath              string     `parquet:"field_path"`
+	Passed                 bool       `parquet:"passed"`
+	ExpectedValue          string     `parquet:"expected_value"`
+	ActualValue            string     `parquet:"actual_value"`
+	Message                string     `parquet:"message"`
+	ReconciliationCritical bool       `parquet:"reconciliation_critical"`
+	ValidatedAt            *time.Time `parquet:"validated_at,optional,timestamp(millisecond)"`
+	ExportedAt             time.Time  `parquet:"exported_at,timestamp(millisecond)"`
+}
+
+// Tables writes the three fact tables to their own Parquet streams. Every row
+// carries exportedAt, so a warehouse can keep the latest row per document.
+type Tables struct {
+	documents   *parquet.GenericWriter[DocumentRow]
+	lineItems   *parquet.GenericWriter[LineItemRow]
+	validations *parquet.GenericWriter[ValidationRow]
+	exportedAt  time.Time
+
+	// Row counts written so far, per table.
+	Documents   int
+	LineItems   int
+	Validations int
+}
+
+// NewTables creates Tables writing Snappy-compressed Parquet to the given streams.
+func NewTables(documents, lineItems, validations io.Writer, exportedAt time.Time) *Tables {
+	compression := parquet.Compression(&parquet.Snappy)
+	return &Tables{
+		documents:   parquet.NewGenericWriter[DocumentRow](documents, compression),
+		lineItems:   parquet.NewGenericWriter[LineItemRow](lineItems, compression),
+		validations: parquet.NewGenericWriter[ValidationRow](validations, compression),
+		exportedAt:  exportedAt.UTC(),
+	}
+}
+
+// WriteDocuments writes a batch of documents and their line items.
+func (t *Tables) WriteDocuments(docs []domain.Document) error {
+	docRows := make([]DocumentRow, 0, len(docs))
+	var itemRows []LineItemRow
+	for i := range docs {
+		row, inv := t.documentRow(&docs[i])
+		docRows = append(docRows, row)
+		if inv != nil {
+			itemRows = append(itemRows, t.lineItemRows(&docs[i], inv)...)
+		}
+	}
+
+	if _, err := t.documents.Write(docRows); err != nil {
+		return err
+	}
+	t.Documents += len(docRows)
+	if len(itemRows) > 0 {
+		if _, err := t.lineItems.Write(itemRows); err != nil {
+			return err
+		}
+		t.LineItems += len(itemRows)
+	}
+	return nil
+}
+
+// WriteValidations writes a batch of validation results.
+func (t *Tables) WriteValidations(facts []domain.ValidationFact) error {
+	if len(facts) == 0 {
+		return nil
+	}
+	rows := make([]ValidationRow, len(facts))
+	for i := range facts {
+		f := &facts[i]
+		rows[i] = ValidationRow{
+			DocumentID:             f.DocumentID.String(),
+			TenantID:               f.TenantID.String(),
+			RuleID:                 f.RuleID.String(),
+			RuleName:               f.RuleName,
+			Severity:               f.Severity,
+			FieldPath:              f.FieldPath,
+			Passed:                 f.Passed,
+			ExpectedValue:          f.ExpectedValue,
+			ActualValue:            f.ActualValue,
+			Message:                f.Message,
+			ReconciliationCritical: f.ReconciliationCritical,
+			ValidatedAt:            f.ValidatedAt,
+			ExportedAt:             t.exportedAt,
+		}
+		if f.BuiltinRuleKey != nil {
+			rows[i].RuleKey = *f.BuiltinRuleKey
+		}
+	}
+	if _, err := t.validations.Write(rows); err != nil {
+		return err
+	}
+	t.Validations += len(rows)
+	return nil
+}
+
+// Close flushes all three tables and writes their footers.
+func (t *Tables) Close() error {
+	if err := t.documents.Close(); err != nil {
+		return err
+	}
+	if err := t.lineItems.Close(); err != nil {
+		return err
+	}
+	return t.validations.Close()
+}
+
+// documentRow converts a document to its row. The parsed invoice is returned
+// when the document completed parsing and its structured data is valid.
+func (t *Tables) documentRow(doc *domain.Document) (DocumentRow, *invoice.GSTInvoice) {
+	row := DocumentRow{
+		DocumentID:           doc.ID.String(),
+		TenantID:             doc.TenantID.String(),
+		CollectionID:         doc.CollectionID.String(),
+		FileID:               doc.FileID.String(),
+		Name:                 doc.Name,
+		DocumentType:         doc.DocumentType,
+		ParsingStatus:        string(doc.ParsingStatus),
+		ReviewStatus:         string(doc.ReviewStatus),
+		ValidationStatus:     string(doc.ValidationStatus),
+		ReconciliationStatus: string(doc.ReconciliationStatus),
+		ParserModel:          doc.ParserModel,
+		ParsedAt:             doc.ParsedAt,
+		ReviewedAt:           doc.ReviewedAt,
+		CreatedAt:            doc.CreatedAt,
+		UpdatedAt:            doc.UpdatedAt,
+		ExportedAt:           t.exportedAt,
+	}
+
+	if doc.ParsingStatus != domain.ParsingStatusCompleted || len(doc.StructuredData) == 0 {
+		return row, nil
+	}
+	var inv invoice.GSTInvoice
+	if err := json.Unmarshal(doc.StructuredData, &inv); err != nil {
+		return row, nil
+	}
+
+	row.InvoiceNumber = inv.Invoice.InvoiceNumber
+	row.InvoiceDate = inv.Invoice.InvoiceDate
+	row.DueDate = inv.Invoice.DueDate
+	row.InvoiceType = inv.Invoice.InvoiceType
+	row.Currency = inv.Invoice.Currency
+	row.PlaceOfSupply = inv.Invoice.PlaceOfSupply
+	row.ReverseCharge = &inv.Invoice.ReverseCharge
+	row.IRN = inv.Invoice.IRN
+	row.SellerName = inv.Seller.Name
+	row.SellerGSTIN = inv.Seller.GSTIN
+	row.SellerStateCode = inv.Seller.StateCode
+	row.BuyerName = inv.Buyer.Name
+	row.BuyerGSTIN = inv.Buyer.GSTIN
+	row.BuyerStateCode = inv.Buyer.StateCode
+	row.Subtotal = &inv.Totals.Subtotal
+	row.TaxableAmount = &inv.Totals.TaxableAmount
+	row.CGST = &inv.Totals.CGST
+	row.SGST = &inv.Totals.SGST
+	row.IGST = &inv.Totals.IGST
+	row.Cess = &inv.Totals.Cess
+	row.RoundOff = &inv.Totals.RoundOff
+	row.Total = &inv.Totals.Total
+	count := int64(len(inv.LineItems))
+	row.LineItemCount = &count
+	return row, &inv
+}
+
+func (t *Tables) lineItemRows(doc *domain.Document, inv *invoice.GSTInvoice) []LineItemRow {
+	rows := make([]LineItemRow, len(inv.LineItems))
+	for i := range inv.LineItems {
+		li := &inv.LineItems[i]
+		rows[i] = LineItemRow{
+			DocumentID:    doc.ID.String(),
+			TenantID:      doc.TenantID.String(),
+			LineNumber:    int64(i + 1),
+			Description:   li.Description,
+			HSNSACCode:    li.HSNSACCode,
+			Quantity:      li.Quantity,
+			Unit:          li.Unit,
+			UnitPrice:     li.UnitPrice,
+			Discount:      li.Discount,
+			TaxableAmount: li.TaxableAmount,
+			CGSTRate:      li.CGSTRate,
+			CGSTAmount:    li.CGSTAmount,
+			SGSTRate:      li.SGSTRate,
+			SGSTAmount:    li.SGSTAmount,
+			IGSTRate:      li.IGSTRate,
+			IGSTAmount:    li.IGSTAmount,
+			Total:         li.Total,
+			ExportedAt:    t.exportedAt,
+		}
+	}
+	return rows
+}
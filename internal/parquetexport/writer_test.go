@@ -0,0 +1,100 @@
+package parquetexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+func readRows[T any](t *testing.T, buf *bytes.Buffer) []T {
+	t.Helper()
+	rows, err := parquet.Read[T](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return rows
+}
+
+func TestTables_WritesNormalizedRows(t *testing.T) {
+	inv := invoice.GSTInvoice{
+		Invoice: invoice.InvoiceHeader{InvoiceNumber: "INV-001", InvoiceDate: "2025-01-15", Currency: "INR"},
+		Seller:  invoice.Party{Name: "Seller Corp", GSTIN: "29ABCDE1234F1Z5", StateCode: "29"},
+		Buyer:   invoice.Party{Name: "Buyer Inc", GSTIN: "07FGHIJ5678K2Z3", StateCode: "07"},
+		LineItems: []invoice.LineItem{
+			{Description: "Widget", HSNSACCode: "8471", Quantity: 2, UnitPrice: 500, TaxableAmount: 1000, IGSTRate: 18, IGSTAmount: 180, Total: 1180},
+			{Description: "Cable", HSNSACCode: "8544", Quantity: 1, UnitPrice: 100, TaxableAmount: 100, IGSTRate: 18, IGSTAmount: 18, Total: 118},
+		},
+		Totals: invoice.Totals{TaxableAmount: 1100, IGST: 198, Total: 1298},
+	}
+	data, _ := json.Marshal(inv)
+	tenantID := uuid.New()
+	parsed := domain.Document{
+		ID: uuid.New(), TenantID: tenantID, Name: "INV-001", ParsingStatus: domain.ParsingStatusCompleted,
+		ReviewStatus: domain.ReviewStatusApproved, StructuredData: data,
+	}
+	pending := domain.Document{ID: uuid.New(), TenantID: tenantID, ParsingStatus: domain.ParsingStatusPending}
+	key := "math.totals.grand_total"
+	exportedAt := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	var docs, items, validations bytes.Buffer
+	tables := NewTables(&docs, &items, &validations, exportedAt)
+	require.NoError(t, tables.WriteDocuments([]domain.Document{parsed, pending}))
+	require.NoError(t, tables.WriteValidations([]domain.ValidationFact{
+		{DocumentID: parsed.ID, TenantID: tenantID, RuleID: uuid.New(), BuiltinRuleKey: &key, Severity: "error", Passed: true},
+	}))
+	require.NoError(t, tables.Close())
+
+	assert.Equal(t, 2, tables.Documents)
+	assert.Equal(t, 2, tables.LineItems)
+	assert.Equal(t, 1, tables.Validations)
+
+	docRows := readRows[DocumentRow](t, &docs)
+	require.Len(t, docRows, 2)
+	assert.Equal(t, "INV-001", docRows[0].InvoiceNumber)
+	assert.Equal(t, "approved", docRows[0].ReviewStatus)
+	require.NotNil(t, docRows[0].Total)
+	assert.InDelta(t, 1298, *docRows[0].Total, 0.001)
+	require.NotNil(t, docRows[0].LineItemCount)
+	assert.Equal(t, int64(2), *docRows[0].LineItemCount)
+	assert.True(t, exportedAt.Equal(docRows[0].ExportedAt))
+	// An unparsed document keeps its metadata and leaves invoice columns null.
+	assert.Equal(t, pending.ID.String(), docRows[1].DocumentID)
+	assert.Nil(t, docRows[1].Total)
+	assert.Empty(t, docRows[1].InvoiceNumber)
+
+	itemRows := readRows[LineItemRow](t, &items)
+	require.Len(t, itemRows, 2)
+	assert.Equal(t, int64(2), itemRows[1].LineNumber)
+	assert.Equal(t, "8544", itemRows[1].HSNSACCode)
+	assert.Equal(t, parsed.ID.String(), itemRows[1].DocumentID)
+
+	validationRows := readRows[ValidationRow](t, &validations)
+	require.Len(t, validationRows, 1)
+	assert.Equal(t, key, validationRows[0].RuleKey)
+	assert.Nil(t, validationRows[0].ValidatedAt)
+}
+
+func TestTables_InvalidStructuredDataWritesMetadataOnly(t *testing.T) {
+	doc := domain.Document{
+		ID: uuid.New(), TenantID: uuid.New(), ParsingStatus: domain.ParsingStatusCompleted,
+		StructuredData: json.RawMessage(`{not json`),
+	}
+
+	var docs, items, validations bytes.Buffer
+	tables := NewTables(&docs, &items, &validations, time.Now())
+	require.NoError(t, tables.WriteDocuments([]domain.Document{doc}))
+	require.NoError(t, tables.Close())
+
+	assert.Equal(t, 1, tables.Documents)
+	assert.Equal(t, 0, tables.LineItems)
+	rows := readRows[DocumentRow](t, &docs)
+	require.Len(t, rows, 1)
+	assert.Nil(t, rows[0].LineItemCount)
+}
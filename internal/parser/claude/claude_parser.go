@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"satvos/internal/config"
+	"satvos/internal/httpclient"
 	"satvos/internal/parser"
 	"satvos/internal/port"
 )
@@ -26,42 +27,50 @@ type Parser struct {
 	model    string
 	endpoint string
 	client   *http.Client
+	timeouts parser.TimeoutPolicy
+	limits   *parser.RateLimitTracker
 }
 
 // NewParser creates a Claude-based document parser from a provider config.
-func NewParser(cfg *config.ParserProviderConfig) *Parser {
-	return newParser(cfg, apiURL)
+func NewParser(cfg *config.ParserProviderConfig) (*Parser, error) {
+	client, err := httpclient.New(cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("claude http client: %w", err)
+	}
+	return newParser(cfg, apiURL, client), nil
 }
 
 // NewParserFromLegacy creates a Claude-based document parser from a legacy ParserConfig.
-func NewParserFromLegacy(cfg *config.ParserConfig) *Parser {
-	return newParser(cfg.PrimaryConfig(), apiURL)
+func NewParserFromLegacy(cfg *config.ParserConfig) (*Parser, error) {
+	return NewParser(cfg.PrimaryConfig())
 }
 
 // NewParserWithEndpoint creates a parser pointing at a custom API endpoint (for testing).
 func NewParserWithEndpoint(cfg *config.ParserProviderConfig, endpoint string) *Parser {
-	return newParser(cfg, endpoint)
+	return newParser(cfg, endpoint, &http.Client{})
 }
 
-func newParser(cfg *config.ParserProviderConfig, endpoint string) *Parser {
+func newParser(cfg *config.ParserProviderConfig, endpoint string, client *http.Client) *Parser {
 	model := cfg.DefaultModel
 	if model == "" {
 		model = "claude-sonnet-4-20250514"
 	}
-	timeout := time.Duration(cfg.TimeoutSecs) * time.Second
-	if timeout == 0 {
-		timeout = 120 * time.Second
-	}
 	return &Parser{
 		apiKey:   cfg.APIKey,
 		model:    model,
 		endpoint: endpoint,
-		client:   &http.Client{Timeout: timeout},
+		client:   client,
+		timeouts: parser.NewTimeoutPolicy(cfg),
 	}
 }
 
+// TrackRateLimits records the rate limit headers of every response in t.
+func (p *Parser) TrackRateLimits(t *parser.RateLimitTracker) {
+	p.limits = t
+}
+
 func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseOutput, error) {
-	prompt := buildPrompt(input.DocumentType)
+	prompt := buildPrompt(input.DocumentType, input.Language)
 
 	contentBlocks, err := buildContentBlocks(input, prompt)
 	if err != nil {
@@ -84,7 +93,11 @@ func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseO
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(bodyBytes))
+	timeout := p.timeouts.For(input)
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(callCtx, http.MethodPost, p.endpoint, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -94,13 +107,14 @@ func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseO
 
 	resp, err := p.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("calling anthropic API: %w", err)
+		return nil, parser.CallError("claude", callCtx, timeout, fmt.Errorf("calling anthropic API: %w", err))
 	}
 	defer func() { _ = resp.Body.Close() }()
+	p.limits.Observe(parser.AnthropicHeadroom("claude", resp.Header, time.Now()))
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+		return nil, parser.CallError("claude", callCtx, timeout, fmt.Errorf("reading response: %w", err))
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -109,6 +123,9 @@ func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseO
 			retryAfter := parser.ParseRetryAfterHeader(resp.Header.Get("Retry-After"))
 			return nil, parser.NewRateLimitError("claude", baseErr, retryAfter)
 		}
+		if parser.IsTransientStatus(resp.StatusCode) {
+			return nil, parser.NewTransientError("claude", baseErr)
+		}
 		return nil, baseErr
 	}
 
@@ -150,8 +167,8 @@ func buildContentBlocks(input port.ParseInput, prompt string) ([]map[string]inte
 	return blocks, nil
 }
 
-func buildPrompt(documentType string) string {
-	return parser.BuildGSTInvoicePrompt(documentType)
+func buildPrompt(documentType, language string) string {
+	return parser.BuildGSTInvoicePromptForLanguage(documentType, language)
 }
 
 // apiResponse models the Anthropic Messages API response.
@@ -161,6 +178,10 @@ type apiResponse struct {
 		Text string `json:"text"`
 	} `json:"content"`
 	StopReason string `json:"stop_reason"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
 }
 
 func parseResponse(body []byte, model, prompt string) (*port.ParseOutput, error) {
@@ -193,6 +214,8 @@ func parseResponse(body []byte, model, prompt string) (*port.ParseOutput, error)
 		ConfidenceScores: parsed.ConfidenceScores,
 		ModelUsed:        model,
 		PromptUsed:       prompt,
+		InputTokens:      resp.Usage.InputTokens,
+		OutputTokens:     resp.Usage.OutputTokens,
 	}, nil
 }
 
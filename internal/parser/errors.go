@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -45,3 +47,59 @@ func ParseRetryAfterHeader(val string) int {
 	}
 	return secs
 }
+
+// TransientError indicates a parser provider call failed in a way that is expected
+// to clear on its own: a network error, a timeout, or an HTTP 5xx/529 response.
+type TransientError struct {
+	Err      error
+	Provider string
+}
+
+func (e *TransientError) Error() string {
+	return fmt.Sprintf("%s temporarily unavailable: %v", e.Provider, e.Err)
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// NewTransientError creates a TransientError.
+func NewTransientError(provider string, err error) *TransientError {
+	return &TransientError{Err: err, Provider: provider}
+}
+
+// IsTransientStatus reports whether an HTTP status from a provider should be retried
+// later: any 5xx, including Anthropic's 529 overloaded.
+func IsTransientStatus(status int) bool {
+	return status >= 500 && status <= 599
+}
+
+// TimeoutError indicates a parser provider call did not finish within its deadline.
+type TimeoutError struct {
+	Err      error
+	Timeout  time.Duration
+	Provider string
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s: %v", e.Provider, e.Timeout, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// NewTimeoutError creates a TimeoutError.
+func NewTimeoutError(provider string, timeout time.Duration, err error) *TimeoutError {
+	return &TimeoutError{Err: err, Timeout: timeout, Provider: provider}
+}
+
+// CallError classifies an error from sending a provider request or reading its
+// response: a TimeoutError when callCtx's deadline has passed, otherwise a
+// TransientError (connection refused, reset, DNS failure).
+func CallError(provider string, callCtx context.Context, timeout time.Duration, err error) error {
+	if errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return NewTimeoutError(provider, timeout, err)
+	}
+	return NewTransientError(provider, err)
+}
@@ -0,0 +1,231 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"satvos/internal/config"
+	"satvos/internal/parser"
+	"satvos/internal/port"
+	"satvos/internal/validator/invoice"
+)
+
+// Model is the ModelUsed reported for every fake parse.
+const Model = "fake-parser"
+
+// Markers that force a behaviour for a file regardless of the configured
+// percentages, so tests can pick the outcome per upload.
+var (
+	FailMarker      = []byte("FAKE_PARSER_FAIL")
+	RateLimitMarker = []byte("FAKE_PARSER_RATE_LIMIT")
+)
+
+// Parser implements port.DocumentParser without calling any API. The invoice it
+// returns is derived from the SHA-256 of the file, so the same bytes always
+// parse to the same data, and the data is internally consistent (GSTINs match
+// their states and PANs, line and invoice totals add up). Development and
+// integration tests only.
+type Parser struct {
+	cfg config.FakeParserConfig
+
+	mu          sync.Mutex
+	rateLimited map[string]bool // hashes already rate limited once
+}
+
+// NewParser creates a fake parser with the given failure injection settings.
+func NewParser(cfg config.FakeParserConfig) *Parser {
+	return &Parser{cfg: cfg, rateLimited: make(map[string]bool)}
+}
+
+// Parse returns the invoice derived from the file hash. Files picked for
+// failure (by marker or FailPercent) always fail with a permanent error; files
+// picked for rate limiting fail with a RateLimitError on their first parse in
+// this process and succeed afterwards.
+func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(input.FileBytes)
+	hash := hex.EncodeToString(sum[:])
+	bucket := int(binary.BigEndian.Uint16(sum[30:32]) % 100)
+
+	if bytes.Contains(input.FileBytes, FailMarker) || bucket < p.cfg.FailPercent {
+		return nil, fmt.Errorf("fake parser: injected failure for file %s", hash[:12])
+	}
+	if bytes.Contains(input.FileBytes, RateLimitMarker) || bucket < p.cfg.FailPercent+p.cfg.RateLimitPercent {
+		p.mu.Lock()
+		first := !p.rateLimited[hash]
+		p.rateLimited[hash] = true
+		p.mu.Unlock()
+		if first {
+			return nil, parser.NewRateLimitError("fake", errors.New("injected rate limit"), p.cfg.RetryAfterSecs)
+		}
+	}
+
+	data, scores := buildInvoice(sum)
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling fake invoice: %w", err)
+	}
+	scoresJSON, err := json.Marshal(scores)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling fake confidence scores: %w", err)
+	}
+
+	return &port.ParseOutput{
+		StructuredData:   dataJSON,
+		ConfidenceScores: scoresJSON,
+		ModelUsed:        Model,
+		PromptUsed:       parser.BuildGSTInvoicePromptForLanguage(input.DocumentType, input.Language),
+	}, nil
+}
+
+type fakeParty struct {
+	name, address, gstin, state, stateCode string
+}
+
+var sellers = []fakeParty{
+	{"Acme Technologies Pvt Ltd", "123 Tech Park, Bengaluru", "29AABCA1234A1Z5", "Karnataka", "29"},
+	{"Sahyadri Traders LLP", "45 MG Road, Pune", "27AAFFS5678B1Z2", "Maharashtra", "27"},
+	{"Yamuna Supplies Pvt Ltd", "9 Connaught Place, New Delhi", "07AABCY9012C1Z8", "Delhi", "07"},
+}
+
+var buyers = []fakeParty{
+	{"Globex Retail Pvt Ltd", "77 Residency Road, Bengaluru", "29AABCG3456D1Z1", "Karnataka", "29"},
+	{"Initech Services Ltd", "12 Anna Salai, Chennai", "33AABCI7890E1Z4", "Tamil Nadu", "33"},
+}
+
+var items = []struct {
+	description, hsn, unit string
+	rate                   float64
+}{
+	{"Software Development Services", "998314", "hours", 18},
+	{"IT Consulting Services", "998313", "hours", 18},
+	{"Office Chairs", "940130", "pcs", 18},
+}
+
+// buildInvoice derives an invoice and its confidence scores from a file hash.
+func buildInvoice(sum [sha256.Size]byte) (*invoice.GSTInvoice, *invoice.ConfidenceScores) {
+	hash := hex.EncodeToString(sum[:])
+	seller := sellers[int(sum[0])%len(sellers)]
+	buyer := buyers[int(sum[1])%len(buyers)]
+	intrastate := seller.stateCode == buyer.stateCode
+
+	date := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(sum[2]))
+
+	inv := &invoice.GSTInvoice{
+		Invoice: invoice.InvoiceHeader{
+			InvoiceNumber: "FAKE-" + hash[:8],
+			InvoiceDate:   date.Format("2006-01-02"),
+			DueDate:       date.AddDate(0, 0, 30).Format("2006-01-02"),
+			InvoiceType:   "tax_invoice",
+			Currency:      "INR",
+			PlaceOfSupply: buyer.state,
+		},
+		Seller: party(seller),
+		Buyer:  party(buyer),
+		Payment: invoice.Payment{
+			BankName:      "State Bank of India",
+			AccountNumber: fmt.Sprintf("%012d", binary.BigEndian.Uint32(sum[4:8])),
+			IFSCCode:      "SBIN0001234",
+			PaymentTerms:  "Net 30",
+		},
+	}
+
+	// An e-invoice: the IRN is the hash the validators expect
+	fy, _ := invoice.DeriveFinancialYear(inv.Invoice.InvoiceDate)
+	inv.Invoice.IRN = invoice.ComputeIRNHash(seller.gstin, inv.Invoice.InvoiceNumber, fy)
+	inv.Invoice.AcknowledgementNumber = fmt.Sprintf("1120%011d", binary.BigEndian.Uint32(sum[24:28]))
+	inv.Invoice.AcknowledgementDate = inv.Invoice.InvoiceDate
+
+	lines := 1 + int(sum[3])%3
+	for i := 0; i < lines; i++ {
+		item := items[(int(sum[8+i])+i)%len(items)]
+		qty := float64(1 + int(sum[12+i])%20)
+		price := float64(100 * (1 + int(sum[16+i])%50))
+		taxable := qty * price
+		li := invoice.LineItem{
+			Description:   item.description,
+			HSNSACCode:    item.hsn,
+			Quantity:      qty,
+			Unit:          item.unit,
+			UnitPrice:     price,
+			TaxableAmount: taxable,
+		}
+		if intrastate {
+			li.CGSTRate, li.SGSTRate = item.rate/2, item.rate/2
+			li.CGSTAmount = round2(taxable * li.CGSTRate / 100)
+			li.SGSTAmount = li.CGSTAmount
+		} else {
+			li.IGSTRate = item.rate
+			li.IGSTAmount = round2(taxable * item.rate / 100)
+		}
+		li.Total = round2(taxable + li.CGSTAmount + li.SGSTAmount + li.IGSTAmount)
+		inv.LineItems = append(inv.LineItems, li)
+
+		inv.Totals.Subtotal += taxable
+		inv.Totals.TaxableAmount += taxable
+		inv.Totals.CGST += li.CGSTAmount
+		inv.Totals.SGST += li.SGSTAmount
+		inv.Totals.IGST += li.IGSTAmount
+		inv.Totals.Total += li.Total
+	}
+	inv.Totals.CGST = round2(inv.Totals.CGST)
+	inv.Totals.SGST = round2(inv.Totals.SGST)
+	inv.Totals.IGST = round2(inv.Totals.IGST)
+	inv.Totals.Total = round2(inv.Totals.Total)
+
+	return inv, confidence(sum, lines)
+}
+
+func party(p fakeParty) invoice.Party {
+	return invoice.Party{
+		Name:      p.name,
+		Address:   p.address,
+		GSTIN:     p.gstin,
+		PAN:       p.gstin[2:12],
+		State:     p.state,
+		StateCode: p.stateCode,
+	}
+}
+
+// confidence scores every field between 0.85 and 0.99, varying with the hash.
+func confidence(sum [sha256.Size]byte, lines int) *invoice.ConfidenceScores {
+	c := 0.85 + float64(sum[20]%15)/100
+	partyConf := invoice.PartyConfidence{Name: c, Address: c, GSTIN: c, PAN: c, State: c, StateCode: c}
+	scores := &invoice.ConfidenceScores{
+		Invoice: invoice.InvoiceConfidence{
+			InvoiceNumber: c, InvoiceDate: c, DueDate: c, InvoiceType: c, Currency: c,
+			PlaceOfSupply: c, ReverseCharge: c, IRN: c, AcknowledgementNumber: c, AcknowledgementDate: c,
+		},
+		Seller: partyConf,
+		Buyer:  partyConf,
+		Totals: invoice.TotalsConfidence{
+			Subtotal: c, TotalDiscount: c, TaxableAmount: c, CGST: c, SGST: c, IGST: c,
+			Cess: c, RoundOff: c, Total: c,
+		},
+		Payment: invoice.PaymentConfidence{BankName: c, AccountNumber: c, IFSCCode: c, PaymentTerms: c},
+	}
+	for i := 0; i < lines; i++ {
+		scores.LineItems = append(scores.LineItems, invoice.LineItemConfidence{
+			Description: c, HSNSACCode: c, Quantity: c, Unit: c, UnitPrice: c, Discount: c,
+			TaxableAmount: c, CGSTRate: c, CGSTAmount: c, SGSTRate: c, SGSTAmount: c,
+			IGSTRate: c, IGSTAmount: c, Total: c,
+		})
+	}
+	return scores
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
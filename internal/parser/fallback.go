@@ -35,6 +35,9 @@ type FallbackParser struct {
 	parsers  []port.DocumentParser
 	circuits []*circuitState
 	names    []string
+
+	lastResort     port.DocumentParser
+	lastResortName string
 }
 
 // NewFallbackParser creates a FallbackParser from an ordered list of parsers and their names.
@@ -50,6 +53,15 @@ func NewFallbackParser(parsers []port.DocumentParser, names []string) *FallbackP
 	}
 }
 
+// WithLastResort adds a parser tried only after every other parser failed or
+// was skipped, such as the offline OCR parser. Its own failure is not reported:
+// the error (and so whether the document is retried) stays the other parsers'.
+func (f *FallbackParser) WithLastResort(p port.DocumentParser, name string) *FallbackParser {
+	f.lastResort = p
+	f.lastResortName = name
+	return f
+}
+
 func (f *FallbackParser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseOutput, error) {
 	now := time.Now()
 	var lastErr error
@@ -85,6 +97,15 @@ func (f *FallbackParser) Parse(ctx context.Context, input port.ParseInput) (*por
 		}
 	}
 
+	if f.lastResort != nil {
+		out, err := f.lastResort.Parse(ctx, input)
+		if err == nil {
+			log.Printf("parser.FallbackParser: parsed with last resort %s", f.lastResortName)
+			return out, nil
+		}
+		log.Printf("parser.FallbackParser: last resort %s failed: %v", f.lastResortName, err)
+	}
+
 	if lastErr == nil {
 		// All parsers were skipped due to open circuits
 		retryAfter := time.Until(earliestReset)
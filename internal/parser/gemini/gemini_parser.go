@@ -8,9 +8,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	"satvos/internal/config"
+	"satvos/internal/httpclient"
 	"satvos/internal/parser"
 	"satvos/internal/port"
 )
@@ -25,27 +25,28 @@ type Parser struct {
 	model    string
 	endpoint string
 	client   *http.Client
+	timeouts parser.TimeoutPolicy
 }
 
 // NewParser creates a Gemini-based document parser.
-func NewParser(cfg *config.ParserProviderConfig) *Parser {
-	return newParser(cfg, "")
+func NewParser(cfg *config.ParserProviderConfig) (*Parser, error) {
+	client, err := httpclient.New(cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("gemini http client: %w", err)
+	}
+	return newParser(cfg, "", client), nil
 }
 
 // NewParserWithEndpoint creates a parser pointing at a custom API endpoint (for testing).
 func NewParserWithEndpoint(cfg *config.ParserProviderConfig, endpoint string) *Parser {
-	return newParser(cfg, endpoint)
+	return newParser(cfg, endpoint, &http.Client{})
 }
 
-func newParser(cfg *config.ParserProviderConfig, endpoint string) *Parser {
+func newParser(cfg *config.ParserProviderConfig, endpoint string, client *http.Client) *Parser {
 	model := cfg.DefaultModel
 	if model == "" {
 		model = "gemini-2.0-flash"
 	}
-	timeout := time.Duration(cfg.TimeoutSecs) * time.Second
-	if timeout == 0 {
-		timeout = 120 * time.Second
-	}
 	if endpoint == "" {
 		endpoint = fmt.Sprintf("%s/%s:generateContent", apiBaseURL, model)
 	}
@@ -53,12 +54,13 @@ func newParser(cfg *config.ParserProviderConfig, endpoint string) *Parser {
 		apiKey:   cfg.APIKey,
 		model:    model,
 		endpoint: endpoint,
-		client:   &http.Client{Timeout: timeout},
+		client:   client,
+		timeouts: parser.NewTimeoutPolicy(cfg),
 	}
 }
 
 func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseOutput, error) {
-	prompt := parser.BuildGSTInvoicePrompt(input.DocumentType)
+	prompt := parser.BuildGSTInvoicePromptForLanguage(input.DocumentType, input.Language)
 
 	mimeType, err := toGeminiMimeType(input.ContentType)
 	if err != nil {
@@ -95,7 +97,11 @@ func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseO
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(bodyBytes))
+	timeout := p.timeouts.For(input)
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(callCtx, http.MethodPost, p.endpoint, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -104,13 +110,13 @@ func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseO
 
 	resp, err := p.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("calling gemini API: %w", err)
+		return nil, parser.CallError("gemini", callCtx, timeout, fmt.Errorf("calling gemini API: %w", err))
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+		return nil, parser.CallError("gemini", callCtx, timeout, fmt.Errorf("reading response: %w", err))
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -119,6 +125,9 @@ func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseO
 			retryAfter := parser.ParseRetryAfterHeader(resp.Header.Get("Retry-After"))
 			return nil, parser.NewRateLimitError("gemini", baseErr, retryAfter)
 		}
+		if parser.IsTransientStatus(resp.StatusCode) {
+			return nil, parser.NewTransientError("gemini", baseErr)
+		}
 		return nil, baseErr
 	}
 
@@ -148,6 +157,10 @@ type geminiResponse struct {
 		} `json:"content"`
 		FinishReason string `json:"finishReason"`
 	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
 }
 
 func parseResponse(body []byte, model, prompt string) (*port.ParseOutput, error) {
@@ -183,6 +196,8 @@ func parseResponse(body []byte, model, prompt string) (*port.ParseOutput, error)
 		ConfidenceScores: parsed.ConfidenceScores,
 		ModelUsed:        model,
 		PromptUsed:       prompt,
+		InputTokens:      resp.UsageMetadata.PromptTokenCount,
+		OutputTokens:     resp.UsageMetadata.CandidatesTokenCount,
 	}, nil
 }
 
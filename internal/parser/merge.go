@@ -57,7 +57,7 @@ func (m *MergeParser) Parse(ctx context.Context, input port.ParseInput) (*port.P
 
 	// Both failed
 	if pResult.err != nil && sResult.err != nil {
-		return nil, fmt.Errorf("both parsers failed: primary: %v; secondary: %v", pResult.err, sResult.err)
+		return nil, fmt.Errorf("both parsers failed: primary: %w; secondary: %w", pResult.err, sResult.err)
 	}
 
 	// Only secondary succeeded
@@ -75,8 +75,14 @@ func (m *MergeParser) Parse(ctx context.Context, input port.ParseInput) (*port.P
 		return pResult.output, nil
 	}
 
-	// Both succeeded — merge
-	return mergeOutputs(pResult.output, sResult.output)
+	// Both succeeded — merge; both calls are billed
+	merged, err := mergeOutputs(pResult.output, sResult.output)
+	if err != nil {
+		return nil, err
+	}
+	merged.InputTokens = pResult.output.InputTokens + sResult.output.InputTokens
+	merged.OutputTokens = pResult.output.OutputTokens + sResult.output.OutputTokens
+	return merged, nil
 }
 
 func mergeOutputs(primary, secondary *port.ParseOutput) (*port.ParseOutput, error) {
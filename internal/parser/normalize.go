@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The helpers below tidy up values read by the OCR-based parsers (Textract,
+// Tesseract) into the forms the LLM parsers are prompted to return.
+
+// NormalizeCode upper-cases an identifier and drops the spaces OCR leaves in it.
+func NormalizeCode(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
+
+var amountRe = regexp.MustCompile(`-?[0-9][0-9,]*(?:\.[0-9]+)?`)
+
+// ParseAmount reads the first number in s, ignoring currency symbols and digit
+// grouping ("Rs. 1,18,000.00"). Parenthesized amounts are negative.
+func ParseAmount(s string) (float64, bool) {
+	m := amountRe.FindString(s)
+	if m == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(m, ",", ""), 64)
+	if err != nil {
+		return 0, false
+	}
+	if strings.HasPrefix(strings.TrimSpace(s), "(") && strings.HasSuffix(strings.TrimSpace(s), ")") {
+		n = -n
+	}
+	return n, true
+}
+
+// LastAmount reads the last number in s, which on a "CGST @ 9%   9,000.00" line
+// is the amount rather than the rate.
+func LastAmount(s string) (float64, bool) {
+	all := amountRe.FindAllString(s, -1)
+	if len(all) == 0 {
+		return 0, false
+	}
+	return ParseAmount(all[len(all)-1])
+}
+
+// dateLayouts are the layouts OCR'd date text is read with. Numeric dates are
+// day first, as on Indian invoices.
+var dateLayouts = []string{
+	"02-01-2006", "02/01/2006", "02.01.2006", "2006-01-02",
+	"02-01-06", "02/01/06", "2-1-2006", "2/1/2006",
+	"02-Jan-2006", "02-Jan-06", "02 Jan 2006", "2 Jan 2006", "02 January 2006", "2 January 2006",
+	"Jan 2, 2006", "January 2, 2006", "02-Jan-2006 15:04",
+}
+
+// NormalizeDate rewrites a date as DD-MM-YYYY, the format the LLM parsers are
+// asked for. Dates it can't read are returned unchanged.
+func NormalizeDate(s string) string {
+	cleaned := strings.Join(strings.Fields(s), " ")
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, cleaned); err == nil {
+			return t.Format("02-01-2006")
+		}
+	}
+	return s
+}
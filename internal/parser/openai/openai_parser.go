@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"satvos/internal/config"
+	"satvos/internal/httpclient"
 	"satvos/internal/parser"
 	"satvos/internal/port"
 )
@@ -20,42 +22,76 @@ const (
 )
 
 // Parser implements port.DocumentParser using the OpenAI Chat Completions API.
+// The same wire format serves self-hosted OpenAI-compatible servers (vLLM, Ollama)
+// through NewLocalParser.
 type Parser struct {
-	apiKey   string
-	model    string
-	endpoint string
-	client   *http.Client
+	provider  string
+	apiKey    string
+	model     string
+	endpoint  string
+	client    *http.Client
+	timeouts  parser.TimeoutPolicy
+	maxTokens string // request field carrying the output token limit
+	limits    *parser.RateLimitTracker
 }
 
 // NewParser creates an OpenAI-based document parser from a provider config.
-func NewParser(cfg *config.ParserProviderConfig) *Parser {
-	return newParser(cfg, apiURL)
+func NewParser(cfg *config.ParserProviderConfig) (*Parser, error) {
+	client, err := httpclient.New(cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("openai http client: %w", err)
+	}
+	return newParser(cfg, apiURL, client), nil
 }
 
 // NewParserWithEndpoint creates a parser pointing at a custom API endpoint (for testing).
 func NewParserWithEndpoint(cfg *config.ParserProviderConfig, endpoint string) *Parser {
-	return newParser(cfg, endpoint)
+	return newParser(cfg, endpoint, &http.Client{})
+}
+
+// NewLocalParser creates a parser for a self-hosted OpenAI-compatible server such as
+// vLLM or Ollama. BaseURL (e.g. http://vllm:8000/v1) and DefaultModel are required;
+// the API key is optional since most on-prem servers run without one.
+func NewLocalParser(cfg *config.ParserProviderConfig) (*Parser, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("local parser: base_url is required")
+	}
+	if cfg.DefaultModel == "" {
+		return nil, fmt.Errorf("local parser: default_model is required")
+	}
+	client, err := httpclient.New(cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("local http client: %w", err)
+	}
+	p := newParser(cfg, strings.TrimRight(cfg.BaseURL, "/")+"/chat/completions", client)
+	p.provider = "local"
+	p.maxTokens = "max_tokens"
+	return p, nil
 }
 
-func newParser(cfg *config.ParserProviderConfig, endpoint string) *Parser {
+func newParser(cfg *config.ParserProviderConfig, endpoint string, client *http.Client) *Parser {
 	model := cfg.DefaultModel
 	if model == "" {
 		model = "gpt-4o"
 	}
-	timeout := time.Duration(cfg.TimeoutSecs) * time.Second
-	if timeout == 0 {
-		timeout = 120 * time.Second
-	}
 	return &Parser{
-		apiKey:   cfg.APIKey,
-		model:    model,
-		endpoint: endpoint,
-		client:   &http.Client{Timeout: timeout},
+		provider:  "openai",
+		apiKey:    cfg.APIKey,
+		model:     model,
+		endpoint:  endpoint,
+		client:    client,
+		timeouts:  parser.NewTimeoutPolicy(cfg),
+		maxTokens: "max_completion_tokens",
 	}
 }
 
+// TrackRateLimits records the rate limit headers of every response in t.
+func (p *Parser) TrackRateLimits(t *parser.RateLimitTracker) {
+	p.limits = t
+}
+
 func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseOutput, error) {
-	prompt := parser.BuildGSTInvoicePrompt(input.DocumentType)
+	prompt := parser.BuildGSTInvoicePromptForLanguage(input.DocumentType, input.Language)
 
 	contentBlocks, err := buildContentBlocks(input, prompt)
 	if err != nil {
@@ -63,8 +99,8 @@ func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseO
 	}
 
 	reqBody := map[string]interface{}{
-		"model":      p.model,
-		"max_completion_tokens": 16384,
+		"model":     p.model,
+		p.maxTokens: 16384,
 		"messages": []map[string]interface{}{
 			{
 				"role":    "user",
@@ -81,29 +117,39 @@ func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseO
 		return nil, fmt.Errorf("marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(bodyBytes))
+	timeout := p.timeouts.For(input)
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(callCtx, http.MethodPost, p.endpoint, bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
 
 	resp, err := p.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("calling openai API: %w", err)
+		return nil, parser.CallError(p.provider, callCtx, timeout, fmt.Errorf("calling %s API: %w", p.provider, err))
 	}
 	defer func() { _ = resp.Body.Close() }()
+	p.limits.Observe(parser.OpenAIHeadroom(p.provider, resp.Header, time.Now()))
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+		return nil, parser.CallError(p.provider, callCtx, timeout, fmt.Errorf("reading response: %w", err))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		baseErr := fmt.Errorf("openai API error (status %d): %s", resp.StatusCode, string(respBody))
+		baseErr := fmt.Errorf("%s API error (status %d): %s", p.provider, resp.StatusCode, string(respBody))
 		if resp.StatusCode == http.StatusTooManyRequests {
 			retryAfter := parser.ParseRetryAfterHeader(resp.Header.Get("Retry-After"))
-			return nil, parser.NewRateLimitError("openai", baseErr, retryAfter)
+			return nil, parser.NewRateLimitError(p.provider, baseErr, retryAfter)
+		}
+		if parser.IsTransientStatus(resp.StatusCode) {
+			return nil, parser.NewTransientError(p.provider, baseErr)
 		}
 		return nil, baseErr
 	}
@@ -153,6 +199,10 @@ type apiResponse struct {
 		} `json:"message"`
 		FinishReason string `json:"finish_reason"`
 	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
 }
 
 func parseResponse(body []byte, model, prompt string) (*port.ParseOutput, error) {
@@ -184,6 +234,8 @@ func parseResponse(body []byte, model, prompt string) (*port.ParseOutput, error)
 		ConfidenceScores: parsed.ConfidenceScores,
 		ModelUsed:        model,
 		PromptUsed:       prompt,
+		InputTokens:      resp.Usage.PromptTokens,
+		OutputTokens:     resp.Usage.CompletionTokens,
 	}, nil
 }
 
@@ -1,5 +1,7 @@
 package parser
 
+import "satvos/internal/language"
+
 // BuildGSTInvoicePrompt returns the extraction prompt for GST invoice documents.
 func BuildGSTInvoicePrompt(documentType string) string {
 	return `You are a document data extraction assistant. Analyze the provided ` + documentType + ` document and extract ALL data into the following JSON structure.
@@ -72,3 +74,23 @@ The "confidence_scores" object should mirror the "data" structure but with float
 
 If a field is not present in the document, use empty string for text, 0 for numbers, and false for booleans.`
 }
+
+// BuildGSTInvoicePromptForLanguage returns the extraction prompt for a document
+// detected to be in language (an ISO 639-1 code). Invoices in an Indian language
+// get instructions to return English and Latin-script values; English and
+// unknown languages get the plain prompt.
+func BuildGSTInvoicePromptForLanguage(documentType, lang string) string {
+	prompt := BuildGSTInvoicePrompt(documentType)
+	sc := language.ForLanguage(lang)
+	if sc == nil {
+		return prompt
+	}
+	return prompt + `
+
+LANGUAGE:
+- The document is written partly or wholly in ` + sc.LanguageName + ` (` + sc.Name + ` script).
+- Read ` + sc.LanguageName + ` labels (for invoice, date, GSTIN, total, tax and so on) to locate fields, but never return a label as a value.
+- Return every text value in English and Latin script: translate descriptions of goods and services, and transliterate names, addresses and places. Do not leave ` + sc.Name + ` text in the JSON.
+- Write numbers, dates, invoice numbers and codes with the digits 0-9, converting ` + sc.Name + ` digits.
+- GSTIN, PAN, IFSC, HSN/SAC codes and IRN are Latin letters and digits, usually printed in Latin script even on ` + sc.LanguageName + ` invoices; copy them exactly.`
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitHeadroom is what a provider's last response said about its rate
+// limits. A limit of 0 means the provider did not report it.
+type RateLimitHeadroom struct {
+	Provider          string    `json:"provider"`
+	RequestsLimit     int       `json:"requests_limit"`
+	RequestsRemaining int       `json:"requests_remaining"`
+	RequestsReset     time.Time `json:"requests_reset"`
+	TokensLimit       int       `json:"tokens_limit"`
+	TokensRemaining   int       `json:"tokens_remaining"`
+	TokensReset       time.Time `json:"tokens_reset"`
+	ObservedAt        time.Time `json:"observed_at"`
+}
+
+// RateLimitTracker keeps each provider's latest rate limit headers so the parse
+// queue can slow down before the provider starts returning 429s. It is per
+// process: it sees the calls this instance made.
+type RateLimitTracker struct {
+	mu        sync.RWMutex
+	providers map[string]RateLimitHeadroom
+}
+
+// NewRateLimitTracker creates an empty tracker.
+func NewRateLimitTracker() *RateLimitTracker {
+	return &RateLimitTracker{providers: make(map[string]RateLimitHeadroom)}
+}
+
+// Observe records h for its provider. Responses without rate limit headers
+// (h with no limits) are ignored so they do not erase what is known.
+func (t *RateLimitTracker) Observe(h RateLimitHeadroom) {
+	if t == nil || (h.RequestsLimit == 0 && h.TokensLimit == 0) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.providers[h.Provider] = h
+}
+
+// Headroom returns every provider's latest headroom, by provider name.
+func (t *RateLimitTracker) Headroom() []RateLimitHeadroom {
+	if t == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make([]RateLimitHeadroom, 0, len(t.providers))
+	for _, h := range t.providers {
+		out = append(out, h)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Provider < out[j].Provider })
+	return out
+}
+
+// Allow returns how many of want new calls to provider fit while keeping
+// reservePercent of each limit in reserve, until the limit resets. It returns
+// want when nothing current is known, and 0 while tokens are within the reserve.
+func (t *RateLimitTracker) Allow(provider string, want, reservePercent int) int {
+	if t == nil || reservePercent <= 0 {
+		return want
+	}
+	t.mu.RLock()
+	h, ok := t.providers[provider]
+	t.mu.RUnlock()
+	if !ok {
+		return want
+	}
+	now := time.Now()
+	if h.TokensLimit > 0 && now.Before(h.TokensReset) && h.TokensRemaining <= reserve(h.TokensLimit, reservePercent) {
+		return 0
+	}
+	if h.RequestsLimit > 0 && now.Before(h.RequestsReset) {
+		if spare := h.RequestsRemaining - reserve(h.RequestsLimit, reservePercent); spare < want {
+			return max(spare, 0)
+		}
+	}
+	return want
+}
+
+func reserve(limit, percent int) int {
+	return (limit*percent + 99) / 100
+}
+
+// AnthropicHeadroom reads Anthropic's anthropic-ratelimit-* headers, whose
+// resets are RFC 3339 times.
+func AnthropicHeadroom(provider string, header http.Header, now time.Time) RateLimitHeadroom {
+	return RateLimitHeadroom{
+		Provider:          provider,
+		RequestsLimit:     headerInt(header, "anthropic-ratelimit-requests-limit"),
+		RequestsRemaining: headerInt(header, "anthropic-ratelimit-requests-remaining"),
+		RequestsReset:     headerTime(header, "anthropic-ratelimit-requests-reset"),
+		TokensLimit:       headerInt(header, "anthropic-ratelimit-tokens-limit"),
+		TokensRemaining:   headerInt(header, "anthropic-ratelimit-tokens-remaining"),
+		TokensReset:       headerTime(header, "anthropic-ratelimit-tokens-reset"),
+		ObservedAt:        now,
+	}
+}
+
+// OpenAIHeadroom reads OpenAI's x-ratelimit-* headers, whose resets are
+// durations from now such as "1s" or "6m0s".
+func OpenAIHeadroom(provider string, header http.Header, now time.Time) RateLimitHeadroom {
+	return RateLimitHeadroom{
+		Provider:          provider,
+		RequestsLimit:     headerInt(header, "x-ratelimit-limit-requests"),
+		RequestsRemaining: headerInt(header, "x-ratelimit-remaining-requests"),
+		RequestsReset:     headerAfter(header, "x-ratelimit-reset-requests", now),
+		TokensLimit:       headerInt(header, "x-ratelimit-limit-tokens"),
+		TokensRemaining:   headerInt(header, "x-ratelimit-remaining-tokens"),
+		TokensReset:       headerAfter(header, "x-ratelimit-reset-tokens", now),
+		ObservedAt:        now,
+	}
+}
+
+func headerInt(header http.Header, key string) int {
+	n, err := strconv.Atoi(header.Get(key))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func headerTime(header http.Header, key string) time.Time {
+	t, err := time.Parse(time.RFC3339, header.Get(key))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func headerAfter(header http.Header, key string, now time.Time) time.Time {
+	d, err := time.ParseDuration(header.Get(key))
+	if err != nil {
+		return time.Time{}
+	}
+	return now.Add(d)
+}
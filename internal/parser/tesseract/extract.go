@@ -0,0 +1,154 @@
+package tesseract
+
+import (
+	"regexp"
+	"strings"
+
+	"satvos/internal/parser"
+	"satvos/internal/validator/invoice"
+)
+
+var (
+	gstinRe         = regexp.MustCompile(`\b[0-9]{2}[A-Z]{5}[0-9]{4}[A-Z][1-9A-Z]Z[0-9A-Z]\b`)
+	ifscRe          = regexp.MustCompile(`\b[A-Z]{4}0[A-Z0-9]{6}\b`)
+	invoiceNumberRe = regexp.MustCompile(`(?i)\b(?:invoice|inv|bill)\s*(?:(?:number|num|no)\b\.?|#)\s*[:#\-]?\s*([A-Z0-9][A-Z0-9/\-]*)`)
+	dateRe          = regexp.MustCompile(`\b([0-9]{1,2}[./-][0-9]{1,2}[./-](?:[0-9]{4}|[0-9]{2})|[0-9]{1,2}[ -][A-Za-z]{3,9}[ -][0-9]{4})\b`)
+	placeOfSupplyRe = regexp.MustCompile(`(?i)place\s+of\s+supply\s*[:\-]?\s*(.+)`)
+	percentRe       = regexp.MustCompile(`[0-9.]+\s*%`)
+)
+
+// amountLabels maps words on a line to the total the line's amount is. The
+// first match wins, so "Total CGST" is the CGST and "Total Taxable Value" the
+// taxable amount.
+var amountLabels = []struct{ word, key string }{
+	{"cgst", "cgst"},
+	{"sgst", "sgst"},
+	{"utgst", "sgst"},
+	{"igst", "igst"},
+	{"cess", "cess"},
+	{"round", "round_off"},
+	{"taxable", "taxable_amount"},
+	{"sub total", "subtotal"},
+	{"subtotal", "subtotal"},
+	{"total", "total"},
+}
+
+// extractInvoice picks GST invoice fields out of OCR text, each with
+// confidence conf. ok is false when neither an invoice number nor a total was
+// found, since then the text is unlikely to be an invoice at all.
+func extractInvoice(text string, conf float64) (*invoice.GSTInvoice, *invoice.ConfidenceScores, bool) {
+	inv := &invoice.GSTInvoice{LineItems: []invoice.LineItem{}}
+	scores := &invoice.ConfidenceScores{LineItems: []invoice.LineItemConfidence{}}
+	upper := strings.ToUpper(text)
+
+	if m := invoiceNumberRe.FindStringSubmatch(text); m != nil {
+		inv.Invoice.InvoiceNumber, scores.Invoice.InvoiceNumber = m[1], conf
+	}
+	if date := invoiceDate(text); date != "" {
+		inv.Invoice.InvoiceDate, scores.Invoice.InvoiceDate = parser.NormalizeDate(date), conf
+	}
+	if m := placeOfSupplyRe.FindStringSubmatch(text); m != nil {
+		inv.Invoice.PlaceOfSupply, scores.Invoice.PlaceOfSupply = strings.TrimSpace(m[1]), conf
+	}
+	if strings.Contains(text, "₹") || strings.Contains(upper, "INR") || strings.Contains(upper, "RS.") {
+		inv.Invoice.Currency, scores.Invoice.Currency = "INR", conf
+	}
+
+	// The seller's GSTIN usually comes first, in the letterhead, then the buyer's
+	var gstins []string
+	for _, g := range gstinRe.FindAllString(upper, -1) {
+		if len(gstins) == 0 || gstins[0] != g {
+			gstins = append(gstins, g)
+		}
+		if len(gstins) == 2 {
+			break
+		}
+	}
+	parties := []struct {
+		party *invoice.Party
+		conf  *invoice.PartyConfidence
+	}{{&inv.Seller, &scores.Seller}, {&inv.Buyer, &scores.Buyer}}
+	for i, g := range gstins {
+		party, pc := parties[i].party, parties[i].conf
+		party.GSTIN, pc.GSTIN = g, conf
+		if st, ok := invoice.LookupState(g[:2]); ok {
+			party.StateCode, party.State = st.Code, st.Name
+			pc.StateCode, pc.State = conf, conf
+		}
+	}
+
+	if m := ifscRe.FindString(upper); m != "" {
+		inv.Payment.IFSCCode, scores.Payment.IFSCCode = m, conf
+	}
+
+	amounts := labelledAmounts(text)
+	set := func(key string, dst, c *float64) {
+		if v, ok := amounts[key]; ok {
+			*dst, *c = v, conf
+		}
+	}
+	set("subtotal", &inv.Totals.Subtotal, &scores.Totals.Subtotal)
+	set("taxable_amount", &inv.Totals.TaxableAmount, &scores.Totals.TaxableAmount)
+	set("cgst", &inv.Totals.CGST, &scores.Totals.CGST)
+	set("sgst", &inv.Totals.SGST, &scores.Totals.SGST)
+	set("igst", &inv.Totals.IGST, &scores.Totals.IGST)
+	set("cess", &inv.Totals.Cess, &scores.Totals.Cess)
+	set("round_off", &inv.Totals.RoundOff, &scores.Totals.RoundOff)
+	set("total", &inv.Totals.Total, &scores.Totals.Total)
+	// Invoices print one of the two; on a GST invoice they are the same pre-tax amount
+	if _, ok := amounts["taxable_amount"]; !ok {
+		inv.Totals.TaxableAmount, scores.Totals.TaxableAmount = inv.Totals.Subtotal, scores.Totals.Subtotal
+	}
+	if _, ok := amounts["subtotal"]; !ok {
+		inv.Totals.Subtotal, scores.Totals.Subtotal = inv.Totals.TaxableAmount, scores.Totals.TaxableAmount
+	}
+
+	return inv, scores, inv.Invoice.InvoiceNumber != "" || inv.Totals.Total != 0
+}
+
+// invoiceDate returns the first date on a line mentioning a date (other than
+// the due date), or else the first date in the text.
+func invoiceDate(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "date") && !strings.Contains(lower, "due") {
+			if m := dateRe.FindString(line); m != "" {
+				return m
+			}
+		}
+	}
+	return dateRe.FindString(text)
+}
+
+// labelledAmounts reads the amount on each line whose label names a total.
+// The first line wins for each tax; the last wins for the total, which puts
+// the grand total ahead of an earlier "Total Qty" or page total.
+func labelledAmounts(text string) map[string]float64 {
+	amounts := map[string]float64{}
+	for _, line := range strings.Split(text, "\n") {
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "words") {
+			continue
+		}
+		key := ""
+		for _, l := range amountLabels {
+			if strings.Contains(lower, l.word) {
+				key = l.key
+				break
+			}
+		}
+		if key == "" {
+			continue
+		}
+		// Drop tax rates so "CGST @ 9%" doesn't read as 9
+		v, ok := parser.LastAmount(percentRe.ReplaceAllString(line, ""))
+		if !ok {
+			continue
+		}
+		if _, seen := amounts[key]; seen && key != "total" {
+			continue
+		}
+		amounts[key] = v
+	}
+	return amounts
+}
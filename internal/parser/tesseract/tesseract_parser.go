@@ -0,0 +1,152 @@
+// Package tesseract parses invoices offline: Tesseract OCR reads the text and
+// heuristics pick out the GST invoice fields. It is the last resort of the
+// fallback chain, for when every cloud provider is down or rate limited.
+package tesseract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// Model is reported as ParseOutput.ModelUsed.
+const Model = "tesseract-ocr"
+
+// Recognizer turns an image into text.
+type Recognizer interface {
+	Recognize(ctx context.Context, image []byte) (string, error)
+}
+
+// Parser implements port.DocumentParser with OCR and field heuristics. Every
+// field it finds gets the configured (low) confidence, so reviewers check
+// them all; line items are left for the reviewer to enter.
+type Parser struct {
+	ocr      Recognizer
+	renderer port.PageRenderer
+	cfg      config.OCRParserConfig
+}
+
+// NewParser creates a parser running the tesseract binary, with PDF pages
+// rendered to images by renderer.
+func NewParser(cfg config.OCRParserConfig, renderer port.PageRenderer) *Parser {
+	return NewParserWithRecognizer(cfg, &cliRecognizer{binary: cfg.TesseractPath, languages: cfg.Languages}, renderer)
+}
+
+// NewParserWithRecognizer creates a parser reading text with ocr (for testing).
+func NewParserWithRecognizer(cfg config.OCRParserConfig, ocr Recognizer, renderer port.PageRenderer) *Parser {
+	return &Parser{ocr: ocr, renderer: renderer, cfg: cfg}
+}
+
+func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseOutput, error) {
+	switch input.ContentType {
+	case "application/pdf", "image/jpeg", "image/png", "image/tiff":
+	default:
+		return nil, fmt.Errorf("unsupported content type for tesseract: %s", input.ContentType)
+	}
+	if input.ContentType == "application/pdf" && p.cfg.MaxPages > 0 && input.PageCount > p.cfg.MaxPages {
+		return nil, fmt.Errorf("tesseract reads documents up to %d pages; document has %d", p.cfg.MaxPages, input.PageCount)
+	}
+
+	if p.cfg.TimeoutSecs > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(p.cfg.TimeoutSecs)*time.Second)
+		defer cancel()
+	}
+
+	text, err := p.readText(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("tesseract read no text from the document")
+	}
+
+	inv, scores, ok := extractInvoice(text, p.cfg.Confidence)
+	if !ok {
+		return nil, errors.New("tesseract found neither an invoice number nor a total in the document")
+	}
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling tesseract invoice: %w", err)
+	}
+	scoresJSON, err := json.Marshal(scores)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling tesseract confidence scores: %w", err)
+	}
+	return &port.ParseOutput{
+		StructuredData:   data,
+		ConfidenceScores: scoresJSON,
+		ModelUsed:        Model,
+	}, nil
+}
+
+// readText OCRs an image as is, and a PDF page by page.
+func (p *Parser) readText(ctx context.Context, input port.ParseInput) (string, error) {
+	if input.ContentType != "application/pdf" {
+		return p.ocr.Recognize(ctx, input.FileBytes)
+	}
+
+	pages := input.PageCount
+	if pages <= 0 {
+		// Unknown page count: read until the renderer runs out of pages
+		pages = max(p.cfg.MaxPages, 1)
+	}
+	var texts []string
+	for page := 1; page <= pages; page++ {
+		img, err := p.renderer.RenderPage(ctx, input.FileBytes, page, p.cfg.DPI)
+		if errors.Is(err, domain.ErrPageNotFound) && page > 1 {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("rendering page %d for tesseract: %w", page, err)
+		}
+		text, err := p.ocr.Recognize(ctx, img)
+		if err != nil {
+			return "", err
+		}
+		texts = append(texts, text)
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// cliRecognizer runs the tesseract command line tool.
+type cliRecognizer struct {
+	binary    string
+	languages string
+}
+
+func (r *cliRecognizer) Recognize(ctx context.Context, image []byte) (string, error) {
+	path, err := exec.LookPath(r.binary)
+	if err != nil {
+		return "", fmt.Errorf("tesseract is not installed: %w", err)
+	}
+
+	// --psm 4 reads the page as one column of lines of varying size, which
+	// keeps a label and its amount on one line
+	args := []string{"stdin", "stdout", "--psm", "4"}
+	if r.languages != "" {
+		args = append(args, "-l", r.languages)
+	}
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = bytes.NewReader(image)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", fmt.Errorf("tesseract: %w", ctxErr)
+		}
+		return "", fmt.Errorf("tesseract: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
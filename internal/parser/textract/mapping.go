@@ -0,0 +1,320 @@
+package textract
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/textract/types"
+
+	"satvos/internal/parser"
+	"satvos/internal/validator/invoice"
+)
+
+// field is a Textract expense field's text with its confidence (0-1): the
+// lower of Textract's confidence in the field type and in the value it read.
+type field struct {
+	text       string
+	confidence float64
+}
+
+func fieldOf(f *types.ExpenseField) (field, bool) {
+	if f.ValueDetection == nil || f.ValueDetection.Text == nil {
+		return field{}, false
+	}
+	text := strings.TrimSpace(*f.ValueDetection.Text)
+	if text == "" {
+		return field{}, false
+	}
+	conf := float64(aws32(f.ValueDetection.Confidence))
+	if f.Type != nil && f.Type.Confidence != nil {
+		conf = min(conf, float64(*f.Type.Confidence))
+	}
+	return field{text: text, confidence: conf / 100}, true
+}
+
+func aws32(v *float32) float32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func typeOf(f *types.ExpenseField) string {
+	if f.Type == nil || f.Type.Text == nil {
+		return ""
+	}
+	return *f.Type.Text
+}
+
+func labelOf(f *types.ExpenseField) string {
+	if f.LabelDetection == nil || f.LabelDetection.Text == nil {
+		return ""
+	}
+	return strings.ToLower(*f.LabelDetection.Text)
+}
+
+// fields keeps the most confident value found for each target.
+type fields map[string]field
+
+func (fs fields) put(key string, f field) {
+	if cur, ok := fs[key]; !ok || f.confidence > cur.confidence {
+		fs[key] = f
+	}
+}
+
+// summaryTargets maps Textract's standard summary field types to invoice paths.
+var summaryTargets = map[string]string{
+	"INVOICE_RECEIPT_ID":   "invoice.invoice_number",
+	"INVOICE_RECEIPT_DATE": "invoice.invoice_date",
+	"DUE_DATE":             "invoice.due_date",
+	"VENDOR_NAME":          "seller.name",
+	"VENDOR_ADDRESS":       "seller.address",
+	"VENDOR_GST_NUMBER":    "seller.gstin",
+	"VENDOR_PAN_NUMBER":    "seller.pan",
+	"RECEIVER_NAME":        "buyer.name",
+	"RECEIVER_ADDRESS":     "buyer.address",
+	"RECEIVER_GST_NUMBER":  "buyer.gstin",
+	"RECEIVER_PAN_NUMBER":  "buyer.pan",
+	"SUBTOTAL":             "totals.subtotal",
+	"DISCOUNT":             "totals.total_discount",
+	"TOTAL":                "totals.total",
+	"PAYMENT_TERMS":        "payment.payment_terms",
+	"ACCOUNT_NUMBER":       "payment.account_number",
+}
+
+// labelTargets maps words in the labels of TAX and OTHER fields, which
+// Textract has no type for, to invoice paths. The first match wins.
+var labelTargets = []struct{ word, path string }{
+	{"cgst", "totals.cgst"},
+	{"sgst", "totals.sgst"},
+	{"utgst", "totals.sgst"},
+	{"igst", "totals.igst"},
+	{"cess", "totals.cess"},
+	{"round", "totals.round_off"},
+	{"words", "totals.amount_in_words"},
+	{"place of supply", "invoice.place_of_supply"},
+	{"irn", "invoice.irn"},
+	{"ifsc", "payment.ifsc_code"},
+	{"a/c", "payment.account_number"},
+	{"account", "payment.account_number"},
+	{"bank", "payment.bank_name"},
+}
+
+// lineLabelTargets does the same for line item columns.
+var lineLabelTargets = []struct{ word, path string }{
+	{"hsn", "hsn_sac_code"},
+	{"sac", "hsn_sac_code"},
+	{"taxable", "taxable_amount"},
+	{"discount", "discount"},
+	{"uom", "unit"},
+	{"unit", "unit"},
+}
+
+var gstinRe = regexp.MustCompile(`^[0-9]{2}[A-Z]{5}[0-9]{4}[A-Z][1-9A-Z]Z[0-9A-Z]$`)
+
+// mapExpenseDocument maps an AnalyzeExpense result onto the GST invoice schema.
+// Fields Textract did not find are left empty with zero confidence.
+func mapExpenseDocument(doc *types.ExpenseDocument) (*invoice.GSTInvoice, *invoice.ConfidenceScores) {
+	fs := fields{}
+	var currency field
+	for i := range doc.SummaryFields {
+		f := &doc.SummaryFields[i]
+		v, ok := fieldOf(f)
+		if !ok {
+			continue
+		}
+		typ := typeOf(f)
+		switch path, known := summaryTargets[typ]; {
+		case known:
+			fs.put(path, v)
+		case typ == "AMOUNT_DUE":
+			fs.put("amount_due", v)
+		case typ == "TAX_PAYER_ID":
+			fs.put("tax_payer_id", v)
+		case typ == "TAX" || typ == "OTHER":
+			if path := matchLabel(labelOf(f), labelTargets); path != "" {
+				fs.put(path, v)
+			}
+		}
+		if typ == "TOTAL" && f.Currency != nil && f.Currency.Code != nil {
+			currency = field{text: strings.ToUpper(*f.Currency.Code), confidence: float64(aws32(f.Currency.Confidence)) / 100}
+		}
+	}
+	// Fall back to the amount due and the vendor's tax ID when the usual fields are missing
+	if _, ok := fs["totals.total"]; !ok {
+		if v, ok := fs["amount_due"]; ok {
+			fs["totals.total"] = v
+		}
+	}
+	if _, ok := fs["seller.gstin"]; !ok {
+		if v, ok := fs["tax_payer_id"]; ok && gstinRe.MatchString(parser.NormalizeCode(v.text)) {
+			fs["seller.gstin"] = v
+		}
+	}
+
+	inv := &invoice.GSTInvoice{LineItems: []invoice.LineItem{}}
+	scores := &invoice.ConfidenceScores{LineItems: []invoice.LineItemConfidence{}}
+
+	text := func(path string, dst *string, conf *float64) {
+		if v, ok := fs[path]; ok {
+			*dst, *conf = v.text, v.confidence
+		}
+	}
+	date := func(path string, dst *string, conf *float64) {
+		if v, ok := fs[path]; ok {
+			*dst, *conf = parser.NormalizeDate(v.text), v.confidence
+		}
+	}
+	code := func(path string, dst *string, conf *float64) {
+		if v, ok := fs[path]; ok {
+			*dst, *conf = parser.NormalizeCode(v.text), v.confidence
+		}
+	}
+	amount := func(path string, dst, conf *float64) {
+		if v, ok := fs[path]; ok {
+			if n, ok := parser.ParseAmount(v.text); ok {
+				*dst, *conf = n, v.confidence
+			}
+		}
+	}
+
+	text("invoice.invoice_number", &inv.Invoice.InvoiceNumber, &scores.Invoice.InvoiceNumber)
+	date("invoice.invoice_date", &inv.Invoice.InvoiceDate, &scores.Invoice.InvoiceDate)
+	date("invoice.due_date", &inv.Invoice.DueDate, &scores.Invoice.DueDate)
+	text("invoice.place_of_supply", &inv.Invoice.PlaceOfSupply, &scores.Invoice.PlaceOfSupply)
+	code("invoice.irn", &inv.Invoice.IRN, &scores.Invoice.IRN)
+	if currency.text != "" {
+		inv.Invoice.Currency, scores.Invoice.Currency = currency.text, currency.confidence
+	}
+
+	for _, side := range []struct {
+		prefix string
+		party  *invoice.Party
+		conf   *invoice.PartyConfidence
+	}{
+		{"seller", &inv.Seller, &scores.Seller},
+		{"buyer", &inv.Buyer, &scores.Buyer},
+	} {
+		text(side.prefix+".name", &side.party.Name, &side.conf.Name)
+		text(side.prefix+".address", &side.party.Address, &side.conf.Address)
+		code(side.prefix+".gstin", &side.party.GSTIN, &side.conf.GSTIN)
+		code(side.prefix+".pan", &side.party.PAN, &side.conf.PAN)
+		// A GSTIN starts with its holder's state code
+		if gstinRe.MatchString(side.party.GSTIN) {
+			if st, ok := invoice.LookupState(side.party.GSTIN[:2]); ok {
+				side.party.StateCode, side.party.State = st.Code, st.Name
+				side.conf.StateCode, side.conf.State = side.conf.GSTIN, side.conf.GSTIN
+			}
+		}
+	}
+
+	amount("totals.subtotal", &inv.Totals.Subtotal, &scores.Totals.Subtotal)
+	amount("totals.total_discount", &inv.Totals.TotalDiscount, &scores.Totals.TotalDiscount)
+	amount("totals.cgst", &inv.Totals.CGST, &scores.Totals.CGST)
+	amount("totals.sgst", &inv.Totals.SGST, &scores.Totals.SGST)
+	amount("totals.igst", &inv.Totals.IGST, &scores.Totals.IGST)
+	amount("totals.cess", &inv.Totals.Cess, &scores.Totals.Cess)
+	amount("totals.round_off", &inv.Totals.RoundOff, &scores.Totals.RoundOff)
+	amount("totals.total", &inv.Totals.Total, &scores.Totals.Total)
+	if v, ok := fs["totals.amount_in_words"]; ok {
+		inv.Totals.AmountInWords = v.text
+	}
+	// Textract's subtotal is the pre-tax amount, which is the taxable value on a GST invoice
+	inv.Totals.TaxableAmount, scores.Totals.TaxableAmount = inv.Totals.Subtotal, scores.Totals.Subtotal
+
+	text("payment.payment_terms", &inv.Payment.PaymentTerms, &scores.Payment.PaymentTerms)
+	code("payment.account_number", &inv.Payment.AccountNumber, &scores.Payment.AccountNumber)
+	code("payment.ifsc_code", &inv.Payment.IFSCCode, &scores.Payment.IFSCCode)
+	text("payment.bank_name", &inv.Payment.BankName, &scores.Payment.BankName)
+
+	for g := range doc.LineItemGroups {
+		for l := range doc.LineItemGroups[g].LineItems {
+			item, conf, ok := mapLineItem(&doc.LineItemGroups[g].LineItems[l])
+			if ok {
+				inv.LineItems = append(inv.LineItems, item)
+				scores.LineItems = append(scores.LineItems, conf)
+			}
+		}
+	}
+	return inv, scores
+}
+
+// mapLineItem maps one row of a line item table. Rows without a description or
+// amount, such as headers Textract kept, are dropped.
+func mapLineItem(row *types.LineItemFields) (invoice.LineItem, invoice.LineItemConfidence, bool) {
+	fs := fields{}
+	for i := range row.LineItemExpenseFields {
+		f := &row.LineItemExpenseFields[i]
+		v, ok := fieldOf(f)
+		if !ok {
+			continue
+		}
+		switch typeOf(f) {
+		case "ITEM":
+			fs.put("description", v)
+		case "QUANTITY":
+			fs.put("quantity", v)
+		case "UNIT_PRICE":
+			fs.put("unit_price", v)
+		case "PRICE":
+			fs.put("total", v)
+		case "PRODUCT_CODE":
+			fs.put("product_code", v)
+		case "OTHER":
+			if path := matchLabel(labelOf(f), lineLabelTargets); path != "" {
+				fs.put(path, v)
+			}
+		}
+	}
+
+	var item invoice.LineItem
+	var conf invoice.LineItemConfidence
+	if v, ok := fs["description"]; ok {
+		item.Description, conf.Description = v.text, v.confidence
+	}
+	amount := func(key string, dst, c *float64) {
+		if v, ok := fs[key]; ok {
+			if n, ok := parser.ParseAmount(v.text); ok {
+				*dst, *c = n, v.confidence
+			}
+		}
+	}
+	amount("quantity", &item.Quantity, &conf.Quantity)
+	amount("unit_price", &item.UnitPrice, &conf.UnitPrice)
+	amount("discount", &item.Discount, &conf.Discount)
+	amount("total", &item.Total, &conf.Total)
+	amount("taxable_amount", &item.TaxableAmount, &conf.TaxableAmount)
+	if _, ok := fs["taxable_amount"]; !ok {
+		// Without a taxable value column the row amount is the pre-tax value
+		item.TaxableAmount, conf.TaxableAmount = item.Total, conf.Total
+	}
+	if v, ok := fs["unit"]; ok {
+		item.Unit, conf.Unit = v.text, v.confidence
+	}
+	hsn, ok := fs["hsn_sac_code"]
+	if !ok {
+		// Indian invoices often put the HSN/SAC code in the product code column
+		if v, found := fs["product_code"]; found && hsnRe.MatchString(v.text) {
+			hsn, ok = v, true
+		}
+	}
+	if ok {
+		item.HSNSACCode, conf.HSNSACCode = parser.NormalizeCode(hsn.text), hsn.confidence
+	}
+
+	return item, conf, item.Description != "" || item.Total != 0
+}
+
+var hsnRe = regexp.MustCompile(`^[0-9]{4,8}$`)
+
+func matchLabel(label string, targets []struct{ word, path string }) string {
+	if label == "" {
+		return ""
+	}
+	for _, t := range targets {
+		if strings.Contains(label, t.word) {
+			return t.path
+		}
+	}
+	return ""
+}
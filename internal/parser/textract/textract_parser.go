@@ -0,0 +1,155 @@
+// Package textract parses invoices with Amazon Textract's AnalyzeExpense API, a
+// non-LLM path that maps Textract's expense fields onto the GST invoice schema.
+package textract
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/textract"
+	"github.com/aws/aws-sdk-go-v2/service/textract/types"
+	"github.com/aws/smithy-go"
+
+	"satvos/internal/config"
+	"satvos/internal/httpclient"
+	"satvos/internal/language"
+	"satvos/internal/parser"
+	"satvos/internal/port"
+)
+
+const (
+	// Model is reported as ParseOutput.ModelUsed.
+	Model = "textract-analyze-expense"
+	// maxDocumentBytes is the size limit of synchronous AnalyzeExpense.
+	maxDocumentBytes = 10 << 20
+)
+
+// ExpenseAnalyzer is the part of the Textract client the parser calls.
+type ExpenseAnalyzer interface {
+	AnalyzeExpense(ctx context.Context, params *textract.AnalyzeExpenseInput, optFns ...func(*textract.Options)) (*textract.AnalyzeExpenseOutput, error)
+}
+
+// Parser implements port.DocumentParser with synchronous AnalyzeExpense, which
+// reads single-page PDFs and images. Multi-page documents, documents in Indian
+// scripts (which Textract does not read) and unsupported formats fail at once,
+// so a fallback chain moves on to the next parser.
+type Parser struct {
+	client   ExpenseAnalyzer
+	timeouts parser.TimeoutPolicy
+}
+
+// NewParser creates a Textract parser in cfg.Region (defaultRegion when empty),
+// authenticating with the default AWS credential chain.
+func NewParser(cfg *config.ParserProviderConfig, defaultRegion string) (*Parser, error) {
+	region := cfg.Region
+	if region == "" {
+		region = defaultRegion
+	}
+	if region == "" {
+		return nil, fmt.Errorf("textract parser: region is required")
+	}
+
+	opts := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(region)}
+	if !cfg.HTTP.IsZero() {
+		httpClient, err := httpclient.New(cfg.HTTP)
+		if err != nil {
+			return nil, fmt.Errorf("textract http client: %w", err)
+		}
+		opts = append(opts, awsconfig.WithHTTPClient(httpClient))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading aws config for textract: %w", err)
+	}
+
+	client := textract.NewFromConfig(awsCfg, func(o *textract.Options) {
+		if cfg.BaseURL != "" {
+			o.BaseEndpoint = aws.String(cfg.BaseURL)
+		}
+		// Retries are left to the parse queue and the fallback chain, as for the LLM providers
+		o.RetryMaxAttempts = 1
+	})
+	return NewParserWithClient(cfg, client), nil
+}
+
+// NewParserWithClient creates a parser calling client (for testing).
+func NewParserWithClient(cfg *config.ParserProviderConfig, client ExpenseAnalyzer) *Parser {
+	return &Parser{client: client, timeouts: parser.NewTimeoutPolicy(cfg)}
+}
+
+func (p *Parser) Parse(ctx context.Context, input port.ParseInput) (*port.ParseOutput, error) {
+	switch input.ContentType {
+	case "application/pdf", "image/jpeg", "image/png":
+	default:
+		return nil, fmt.Errorf("unsupported content type for textract: %s", input.ContentType)
+	}
+	if input.PageCount > 1 {
+		return nil, fmt.Errorf("textract reads single-page documents synchronously; document has %d pages", input.PageCount)
+	}
+	if len(input.FileBytes) > maxDocumentBytes {
+		return nil, fmt.Errorf("textract reads documents up to %d MB synchronously", maxDocumentBytes>>20)
+	}
+	if sc := language.ForLanguage(input.Language); sc != nil {
+		return nil, fmt.Errorf("textract does not read %s script", sc.Name)
+	}
+
+	timeout := p.timeouts.For(input)
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	out, err := p.client.AnalyzeExpense(callCtx, &textract.AnalyzeExpenseInput{
+		Document: &types.Document{Bytes: input.FileBytes},
+	})
+	if err != nil {
+		return nil, classifyError(callCtx, timeout, err)
+	}
+	if len(out.ExpenseDocuments) == 0 {
+		return nil, fmt.Errorf("textract found no invoice in the document")
+	}
+
+	// A file holding several invoices is parsed as its first one
+	inv, scores := mapExpenseDocument(&out.ExpenseDocuments[0])
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling textract invoice: %w", err)
+	}
+	scoresJSON, err := json.Marshal(scores)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling textract confidence scores: %w", err)
+	}
+	return &port.ParseOutput{
+		StructuredData:   data,
+		ConfidenceScores: scoresJSON,
+		ModelUsed:        Model,
+	}, nil
+}
+
+// classifyError maps Textract errors onto the parser error types the queue and
+// fallback chain act on.
+func classifyError(callCtx context.Context, timeout time.Duration, err error) error {
+	var throttled *types.ThrottlingException
+	var throughput *types.ProvisionedThroughputExceededException
+	var limit *types.LimitExceededException
+	var internal *types.InternalServerError
+	switch {
+	case errors.As(err, &throttled), errors.As(err, &throughput), errors.As(err, &limit):
+		return parser.NewRateLimitError("textract", err, 0)
+	case errors.As(err, &internal):
+		return parser.NewTransientError("textract", err)
+	}
+	var respErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &respErr) && parser.IsTransientStatus(respErr.HTTPStatusCode()) {
+		return parser.NewTransientError("textract", err)
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		// Bad, unsupported or oversized documents and access errors won't clear on retry
+		return fmt.Errorf("textract API error: %w", err)
+	}
+	return parser.CallError("textract", callCtx, timeout, fmt.Errorf("calling textract: %w", err))
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"time"
+
+	"satvos/internal/config"
+	"satvos/internal/port"
+)
+
+// Defaults used when a provider config leaves a timeout setting at zero.
+const (
+	defaultBaseTimeout = 60 * time.Second
+	defaultMaxTimeout  = 10 * time.Minute
+)
+
+// TimeoutPolicy computes the deadline for a single provider call from the size of
+// the document: a base allowance plus a per-page and a per-megabyte allowance,
+// capped at Max.
+type TimeoutPolicy struct {
+	Base    time.Duration
+	PerPage time.Duration
+	PerMB   time.Duration
+	Max     time.Duration
+}
+
+// NewTimeoutPolicy builds a TimeoutPolicy from a provider config.
+func NewTimeoutPolicy(cfg *config.ParserProviderConfig) TimeoutPolicy {
+	p := TimeoutPolicy{
+		Base:    time.Duration(cfg.TimeoutSecs) * time.Second,
+		PerPage: time.Duration(cfg.TimeoutPerPageSecs) * time.Second,
+		PerMB:   time.Duration(cfg.TimeoutPerMBSecs) * time.Second,
+		Max:     time.Duration(cfg.MaxTimeoutSecs) * time.Second,
+	}
+	if p.Base <= 0 {
+		p.Base = defaultBaseTimeout
+	}
+	if p.Max <= 0 {
+		p.Max = defaultMaxTimeout
+	}
+	if p.Max < p.Base {
+		p.Max = p.Base
+	}
+	return p
+}
+
+// For returns the deadline for parsing input. Inputs without a page count are
+// treated as a single page.
+func (p TimeoutPolicy) For(input port.ParseInput) time.Duration {
+	pages := input.PageCount
+	if pages < 1 {
+		pages = 1
+	}
+	mb := float64(len(input.FileBytes)) / (1 << 20)
+	d := p.Base + time.Duration(pages)*p.PerPage + time.Duration(mb*float64(p.PerMB))
+	if d > p.Max {
+		d = p.Max
+	}
+	return d
+}
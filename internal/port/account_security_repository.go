@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// AccountSecurityRepository defines the contract for the account security audit
+// trail and undoable email changes.
+type AccountSecurityRepository interface {
+	RecordEvent(ctx context.Context, event *domain.SecurityEvent) error
+	// ListEvents lists the user's security events newest first.
+	ListEvents(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.SecurityEvent, int, error)
+	// CreateEmailChange records a change of address and clears the user's pending
+	// password reset, whose link went to the old address.
+	CreateEmailChange(ctx context.Context, change *domain.EmailChange) error
+	GetEmailChange(ctx context.Context, tenantID, changeID uuid.UUID) (*domain.EmailChange, error)
+	// UndoEmailChange restores the old address, clears any pending password reset
+	// and marks the change undone. ErrEmailChangeUndoInvalid when the change was
+	// already undone or the user's address has changed again since.
+	UndoEmailChange(ctx context.Context, change *domain.EmailChange) error
+}
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// Alert is an operational alert raised by a background monitor.
+type Alert struct {
+	// Key identifies the condition (e.g. "parse_p95:gemini-2.0-flash") so repeats
+	// of the same breach can be suppressed.
+	Key      string    `json:"key"`
+	Subject  string    `json:"subject"`
+	Message  string    `json:"message"`
+	RaisedAt time.Time `json:"raised_at"`
+}
+
+// AlertSender delivers operational alerts to operators.
+type AlertSender interface {
+	SendAlert(ctx context.Context, alert *Alert) error
+}
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// AnalyticsExportRepository defines the contract for scheduled analytics export
+// settings and the document reads an export run needs.
+type AnalyticsExportRepository interface {
+	Get(ctx context.Context, tenantID uuid.UUID) (*domain.AnalyticsExport, error)
+	// Upsert stores the export settings, watermark and next run time.
+	Upsert(ctx context.Context, export *domain.AnalyticsExport) error
+	Delete(ctx context.Context, tenantID uuid.UUID) error
+	// ClaimDue atomically claims enabled exports due at now across all tenants,
+	// pushing next_run_at out by lease so other instances skip them.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.AnalyticsExport, error)
+	// CompleteRun persists the outcome of a run (watermark, next run, last_*).
+	// It is a no-op if the destination was changed while the run was in flight.
+	CompleteRun(ctx context.Context, export *domain.AnalyticsExport) error
+
+	// ListDocumentsUpdated returns the tenant's documents with
+	// (updated_at, id) > (afterAt, afterID) and updated_at <= through, oldest first.
+	ListDocumentsUpdated(ctx context.Context, tenantID uuid.UUID, afterAt time.Time, afterID uuid.UUID, through time.Time, limit int) ([]domain.Document, error)
+	// ListValidationFacts flattens the stored validation results of the given documents.
+	ListValidationFacts(ctx context.Context, tenantID uuid.UUID, documentIDs []uuid.UUID) ([]domain.ValidationFact, error)
+}
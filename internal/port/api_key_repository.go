@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// APIKeyRepository defines the contract for API key persistence.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	// ListByUser lists the user's keys, oldest first.
+	ListByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.APIKey, error)
+	// Delete removes one of the user's keys; ErrNotFound if they have no such key.
+	Delete(ctx context.Context, tenantID, userID, keyID uuid.UUID) error
+	// GetByHash finds a key by the SHA-256 hash of its secret, across tenants.
+	GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	// TouchLastUsed records that the key was just used.
+	TouchLastUsed(ctx context.Context, keyID uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// AuthzDenialRepository defines the contract for authorization denial audit persistence.
+type AuthzDenialRepository interface {
+	Create(ctx context.Context, denial *domain.AuthzDenial) error
+	ListByTenant(ctx context.Context, tenantID uuid.UUID, filters *domain.AuthzDenialFilters) ([]domain.AuthzDenial, int, error)
+}
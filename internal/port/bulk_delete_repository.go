@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// BulkDeleteRepository defines the contract for bulk delete records and matching.
+type BulkDeleteRepository interface {
+	Create(ctx context.Context, rec *domain.BulkDelete) error
+	// Finish persists the counters and completed_at of a finished run.
+	Finish(ctx context.Context, rec *domain.BulkDelete) error
+	// ListByTenant lists runs newest first; a non-nil createdBy restricts them to that user's.
+	ListByTenant(ctx context.Context, tenantID uuid.UUID, createdBy *uuid.UUID, offset, limit int) ([]domain.BulkDelete, int, error)
+	// MatchDocuments returns up to limit documents matching filter, oldest first.
+	MatchDocuments(ctx context.Context, tenantID uuid.UUID, filter *domain.BulkTagFilter, limit int) ([]domain.BulkDeleteTarget, error)
+}
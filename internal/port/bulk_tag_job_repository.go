@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// BulkTagJobRepository defines the contract for bulk tag job persistence.
+type BulkTagJobRepository interface {
+	Create(ctx context.Context, job *domain.BulkTagJob) error
+	GetByID(ctx context.Context, tenantID, jobID uuid.UUID) (*domain.BulkTagJob, error)
+	// ListByTenant lists jobs newest first; a non-nil createdBy restricts them to that user's.
+	ListByTenant(ctx context.Context, tenantID uuid.UUID, createdBy *uuid.UUID, offset, limit int) ([]domain.BulkTagJob, int, error)
+	// UpdateProgress persists status, counters, error and completed_at.
+	UpdateProgress(ctx context.Context, job *domain.BulkTagJob) error
+	// MatchDocuments returns up to limit documents matching filter, oldest first.
+	MatchDocuments(ctx context.Context, tenantID uuid.UUID, filter *domain.BulkTagFilter, limit int) ([]domain.BulkTagTarget, error)
+}
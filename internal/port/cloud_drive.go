@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"satvos/internal/domain"
+)
+
+// CloudToken is an OAuth token pair issued by a cloud storage provider.
+type CloudToken struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time
+	AccountID    string // Provider account identifier (email for Drive, account_id for Dropbox)
+}
+
+// CloudFile is an entry in a cloud storage folder.
+type CloudFile struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	IsFolder bool   `json:"is_folder"`
+}
+
+// CloudDriveProvider abstracts an OAuth-connected cloud storage service.
+type CloudDriveProvider interface {
+	Provider() domain.CloudProvider
+	// AuthCodeURL returns the consent page URL; state is echoed back to the redirect.
+	AuthCodeURL(state string) string
+	Exchange(ctx context.Context, code string) (*CloudToken, error)
+	// Refresh returns a new access token. RefreshToken may be empty if unchanged.
+	Refresh(ctx context.Context, refreshToken string) (*CloudToken, error)
+	// ListFolder lists the direct children of folderID ("" for the root).
+	ListFolder(ctx context.Context, accessToken, folderID string) ([]CloudFile, error)
+	// Download returns a file's content, failing with domain.ErrFileTooLarge past maxBytes.
+	Download(ctx context.Context, accessToken, fileID string, maxBytes int64) ([]byte, error)
+}
@@ -0,0 +1,43 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// CloudConnectionRepository defines the contract for cloud OAuth connection persistence.
+type CloudConnectionRepository interface {
+	// Upsert creates the connection or, if the user already connected the same
+	// provider account, replaces its tokens and returns the existing ID.
+	Upsert(ctx context.Context, conn *domain.CloudConnection) error
+	GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.CloudConnection, error)
+	ListByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.CloudConnection, error)
+	UpdateTokens(ctx context.Context, conn *domain.CloudConnection) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+}
+
+// CloudSyncRepository defines the contract for cloud folder sync persistence.
+type CloudSyncRepository interface {
+	Create(ctx context.Context, sync *domain.CloudSync) error
+	GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.CloudSync, error)
+	ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID) ([]domain.CloudSync, error)
+	// ClaimDueForPoll atomically claims polling syncs across all tenants last synced
+	// before cutoff, bumping last_synced_at so other instances skip them.
+	ClaimDueForPoll(ctx context.Context, cutoff time.Time, limit int) ([]domain.CloudSync, error)
+	// Update persists poll_enabled, last_synced_at and last_error.
+	Update(ctx context.Context, sync *domain.CloudSync) error
+	Delete(ctx context.Context, tenantID, id uuid.UUID) error
+
+	// RecordFile inserts a file outcome, replacing any earlier outcome for the same external ID.
+	RecordFile(ctx context.Context, file *domain.CloudSyncFile) error
+	ListFiles(ctx context.Context, tenantID, syncID uuid.UUID, offset, limit int) ([]domain.CloudSyncFile, int, error)
+	// SeenExternalIDs returns the external IDs already settled for a sync. Failed
+	// files are excluded so the next run retries them.
+	SeenExternalIDs(ctx context.Context, syncID uuid.UUID) (map[string]bool, error)
+	// HashImported reports whether content with this hash was already imported into the collection.
+	HashImported(ctx context.Context, collectionID uuid.UUID, contentHash string) (bool, error)
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// CollectionEventRepository defines the contract for collection event persistence
+// and the collection activity feed built from it.
+type CollectionEventRepository interface {
+	Create(ctx context.Context, event *domain.CollectionEvent) error
+	// ListActivity merges file uploads, document audit events and collection
+	// events for one collection, newest first.
+	ListActivity(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.CollectionActivityEvent, int, error)
+}
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -15,7 +16,32 @@ type CollectionRepository interface {
 	ListByUser(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.Collection, int, error)
 	ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.Collection, int, error)
 	Update(ctx context.Context, collection *domain.Collection) error
+	UpdateReviewChecklist(ctx context.Context, collection *domain.Collection) error
+	UpdateCheckerThreshold(ctx context.Context, collection *domain.Collection) error
+	UpdateEscalationPolicy(ctx context.Context, collection *domain.Collection) error
+	UpdateKPITargets(ctx context.Context, collection *domain.Collection) error
+	UpdateQASampling(ctx context.Context, collection *domain.Collection) error
+	UpdateDefaultParseMode(ctx context.Context, collection *domain.Collection) error
+	UpdateNamingTemplate(ctx context.Context, collection *domain.Collection) error
+	UpdateFileDownloadPerm(ctx context.Context, collection *domain.Collection) error
+	// FileDownloadPerms returns, for each collection holding the file (directly
+	// or through a document) that restricts downloads, the permission it requires.
+	FileDownloadPerms(ctx context.Context, tenantID, fileID uuid.UUID) (map[uuid.UUID]domain.CollectionPermission, error)
+	// ListFileDownloadPerms returns the permission required by each of the
+	// tenant's collections that restricts downloads.
+	ListFileDownloadPerms(ctx context.Context, tenantID uuid.UUID) (map[uuid.UUID]domain.CollectionPermission, error)
+	// DefaultParseMode resolves the parse mode for documents created in the
+	// collection without one: the collection's default, else the tenant's, else "".
+	DefaultParseMode(ctx context.Context, tenantID, collectionID uuid.UUID) (domain.ParseMode, error)
+	// TimeZone resolves the IANA time zone dates in the collection are read
+	// in: its tenant's.
+	TimeZone(ctx context.Context, tenantID, collectionID uuid.UUID) (string, error)
+	// ProgressCounts counts the collection's documents by progress, with the
+	// recent counts covering documents that got there at or after since.
+	ProgressCounts(ctx context.Context, tenantID, collectionID uuid.UUID, since time.Time) (*domain.CollectionProgressCounts, error)
 	Delete(ctx context.Context, tenantID, collectionID uuid.UUID) error
+	// ListDemo returns the tenant's demo collections.
+	ListDemo(ctx context.Context, tenantID uuid.UUID) ([]domain.Collection, error)
 }
 
 // CollectionPermissionRepository defines the contract for collection permission persistence.
@@ -23,6 +49,8 @@ type CollectionPermissionRepository interface {
 	Upsert(ctx context.Context, perm *domain.CollectionPermissionEntry) error
 	GetByCollectionAndUser(ctx context.Context, collectionID, userID uuid.UUID) (*domain.CollectionPermissionEntry, error)
 	GetByUserForCollections(ctx context.Context, userID uuid.UUID, collectionIDs []uuid.UUID) (map[uuid.UUID]domain.CollectionPermission, error)
+	// ListByUser returns every explicit grant the user holds in the tenant, by collection.
+	ListByUser(ctx context.Context, tenantID, userID uuid.UUID) (map[uuid.UUID]domain.CollectionPermission, error)
 	ListByCollection(ctx context.Context, collectionID uuid.UUID, offset, limit int) ([]domain.CollectionPermissionEntry, int, error)
 	Delete(ctx context.Context, collectionID, userID uuid.UUID) error
 }
@@ -33,3 +61,17 @@ type CollectionFileRepository interface {
 	Remove(ctx context.Context, collectionID, fileID uuid.UUID) error
 	ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.FileMeta, int, error)
 }
+
+// OrphanFileRepository finds orphan files: files in a collection that are
+// uploaded but were never made a document, not even one later moved to
+// another file, uploaded before olderThan.
+type OrphanFileRepository interface {
+	// Summarize counts the tenant's orphan files per collection, most first.
+	Summarize(ctx context.Context, tenantID uuid.UUID, olderThan time.Time) ([]domain.OrphanFileSummary, error)
+	// ListByCollection lists the collection's orphan files, oldest first.
+	ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, olderThan time.Time, offset, limit int) ([]domain.FileMeta, int, error)
+	// ClaimNudge records a nudge about fileCount orphan files for the
+	// collection, unless one was already sent after notSince. It returns false
+	// when the collection was nudged too recently.
+	ClaimNudge(ctx context.Context, tenantID, collectionID uuid.UUID, fileCount int, notSince time.Time) (bool, error)
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ComputedFieldRepository defines the contract for tenant computed-field persistence.
+type ComputedFieldRepository interface {
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.ComputedField, error)
+	Upsert(ctx context.Context, field *domain.ComputedField) error
+	// Delete returns domain.ErrNotFound if the tenant has no field by that name.
+	Delete(ctx context.Context, tenantID uuid.UUID, name string) error
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ConfidenceObservationRepository stores per-field parser confidence alongside
+// reviewer corrections and aggregates them into calibration buckets.
+type ConfidenceObservationRepository interface {
+	// Record upserts observations keyed by (document_id, field_path). An existing
+	// observation keeps its confidence and parser model; Corrected only ever turns
+	// true, so a later approval cannot undo an earlier correction.
+	Record(ctx context.Context, observations []domain.ConfidenceObservation) error
+	// MarkCorrected flags existing observations at, or nested under, the given
+	// field paths.
+	MarkCorrected(ctx context.Context, tenantID, documentID uuid.UUID, fieldPaths []string) error
+	// BucketsByModel groups the tenant's observations since the given time by parser
+	// model and tenth of confidence. An empty documentType matches every type.
+	BucketsByModel(ctx context.Context, tenantID uuid.UUID, documentType string, since time.Time) ([]domain.CalibrationBucketStats, error)
+}
@@ -12,4 +12,5 @@ import (
 type DocumentAuditRepository interface {
 	Create(ctx context.Context, entry *domain.DocumentAuditEntry) error
 	ListByDocument(ctx context.Context, tenantID, documentID uuid.UUID, offset, limit int) ([]domain.DocumentAuditEntry, int, error)
+	ListByTenant(ctx context.Context, tenantID uuid.UUID, filters *domain.AuditFilters) ([]domain.DocumentAuditEntry, int, error)
 }
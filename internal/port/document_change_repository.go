@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// DocumentChangeRepository reads the document change log behind the change stream.
+type DocumentChangeRepository interface {
+	// ListSince returns up to limit changes after (afterAt, afterID), oldest
+	// first, leaving out changes newer than settle so that a write committed late
+	// is not skipped by a cursor already past it. With explicitOnly, only
+	// collections the user has a permission on are included.
+	ListSince(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID,
+		afterAt time.Time, afterID uuid.UUID, settle time.Duration, limit int) ([]domain.DocumentChange, error)
+	// ListDocuments loads the tenant's documents with ids, keyed by ID. Documents
+	// deleted in the meantime are missing.
+	ListDocuments(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]domain.Document, error)
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// DocumentFieldOverrideRepository defines the contract for per-field manual override persistence.
+type DocumentFieldOverrideRepository interface {
+	Upsert(ctx context.Context, override *domain.DocumentFieldOverride) error
+	ListByDocument(ctx context.Context, tenantID, documentID uuid.UUID) ([]domain.DocumentFieldOverride, error)
+	DeleteByPath(ctx context.Context, tenantID, documentID uuid.UUID, fieldPath string) error
+	DeleteByDocument(ctx context.Context, tenantID, documentID uuid.UUID) error
+}
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// DocumentLockRepository defines the contract for soft document review locks.
+type DocumentLockRepository interface {
+	// Acquire takes the lock for lock.UserID until lock.ExpiresAt, or renews it
+	// if they already hold it. It returns the lock now in force: another user's
+	// if they hold an unexpired lock.
+	Acquire(ctx context.Context, lock *domain.DocumentLock) (*domain.DocumentLock, error)
+	// GetActive returns the document's unexpired lock, or nil if there is none.
+	GetActive(ctx context.Context, tenantID, documentID uuid.UUID) (*domain.DocumentLock, error)
+	// Release drops the document's lock. A nil userID releases whoever holds it.
+	Release(ctx context.Context, tenantID, documentID uuid.UUID, userID *uuid.UUID) error
+}
@@ -10,6 +10,8 @@ type ParseInput struct {
 	FileBytes    []byte
 	ContentType  string
 	DocumentType string
+	PageCount    int    // best-effort page count; 0 when unknown
+	Language     string // ISO 639-1 code detected before parsing; "" when unknown
 }
 
 // ParseOutput contains the structured result from an LLM parser.
@@ -20,6 +22,8 @@ type ParseOutput struct {
 	PromptUsed       string
 	FieldProvenance  map[string]string // which model provided each field (populated in dual parse mode)
 	SecondaryModel   string            // secondary model used (for audit trail in dual parse mode)
+	InputTokens      int               // tokens reported by the provider (summed over both models in dual parse mode)
+	OutputTokens     int
 }
 
 // DocumentParser abstracts LLM-based document parsing.
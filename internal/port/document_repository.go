@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -14,25 +15,65 @@ type DocumentRepository interface {
 	GetByID(ctx context.Context, tenantID, docID uuid.UUID) (*domain.Document, error)
 	GetByFileID(ctx context.Context, tenantID, fileID uuid.UUID) (*domain.Document, error)
 	ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, assignedTo *uuid.UUID, offset, limit int) ([]domain.Document, int, error)
+	// ListValidationFailures returns the failing validation results of a collection's
+	// documents, ordered by document and then errors before warnings.
+	ListValidationFailures(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.ValidationFailure, error)
 	ListByTenant(ctx context.Context, tenantID uuid.UUID, assignedTo *uuid.UUID, offset, limit int) ([]domain.Document, int, error)
 	ListByUserCollections(ctx context.Context, tenantID, userID uuid.UUID, assignedTo *uuid.UUID, offset, limit int) ([]domain.Document, int, error)
 	UpdateStructuredData(ctx context.Context, doc *domain.Document) error
+	// UpdateStructuredDataIfUnmodified is UpdateStructuredData for a document
+	// whose updated_at is still lastUpdatedAt; otherwise it changes nothing and
+	// returns domain.ErrDocumentModified.
+	UpdateStructuredDataIfUnmodified(ctx context.Context, doc *domain.Document, lastUpdatedAt time.Time) error
+	// UpdateReviewStatus saves the review decision. An approval also snapshots the
+	// document into document_approvals in the same transaction.
 	UpdateReviewStatus(ctx context.Context, doc *domain.Document) error
 	UpdateAssignment(ctx context.Context, doc *domain.Document) error
 	UpdateValidationResults(ctx context.Context, doc *domain.Document) error
-	ListReviewQueue(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.Document, int, error)
+	// ListReviewQueue lists parsed, pending documents assigned to any of assignees.
+	ListReviewQueue(ctx context.Context, tenantID uuid.UUID, assignees []uuid.UUID, offset, limit int) ([]domain.Document, int, error)
+	// ReviewQueueNeighbors returns the documents around the position (assignedAt, docID)
+	// in the review queue of assignees. The position need not still be in the queue.
+	ReviewQueueNeighbors(ctx context.Context, tenantID uuid.UUID, assignees []uuid.UUID, assignedAt time.Time, docID uuid.UUID) (*domain.DocumentNeighbors, error)
+	// CollectionNeighbors returns the documents around the position (createdAt, docID)
+	// in a collection listing, optionally filtered by assignee.
+	CollectionNeighbors(ctx context.Context, tenantID, collectionID uuid.UUID, assignedTo *uuid.UUID, createdAt time.Time, docID uuid.UUID) (*domain.DocumentNeighbors, error)
+	// ListCheckerQueue lists documents awaiting checker confirmation that userID did
+	// not make; ownedOnly restricts them to collections where userID is an explicit owner.
+	ListCheckerQueue(ctx context.Context, tenantID, userID uuid.UUID, ownedOnly bool, offset, limit int) ([]domain.Document, int, error)
 	ClaimQueued(ctx context.Context, limit int) ([]domain.Document, error)
 	Delete(ctx context.Context, tenantID, docID uuid.UUID) error
+	// SaveVersion saves prev as a version and writes doc, with its version bumped
+	// and its new file or document type, in one transaction. It fails with
+	// ErrDocumentNotFound if the document is no longer at prev.Version.
+	SaveVersion(ctx context.Context, doc *domain.Document, prev *domain.DocumentVersion) error
+	// ListVersions returns the superseded versions of a document, newest first.
+	ListVersions(ctx context.Context, tenantID, docID uuid.UUID) ([]domain.DocumentVersion, error)
+	// ListApprovals returns the approval snapshots of a document, newest first.
+	ListApprovals(ctx context.Context, tenantID, docID uuid.UUID) ([]domain.DocumentApproval, error)
+	// UpdateName saves the document's Name and OriginalName.
+	UpdateName(ctx context.Context, doc *domain.Document) error
+	// ListNamesWithPrefix returns the names in a collection that start with prefix,
+	// leaving out the document excludeID.
+	ListNamesWithPrefix(ctx context.Context, tenantID, collectionID uuid.UUID, prefix string, excludeID uuid.UUID) ([]string, error)
 }
 
 // DocumentTagRepository defines the contract for document tag persistence.
 type DocumentTagRepository interface {
 	CreateBatch(ctx context.Context, tags []domain.DocumentTag) error
 	ListByDocument(ctx context.Context, documentID uuid.UUID) ([]domain.DocumentTag, error)
-	SearchByTag(ctx context.Context, tenantID uuid.UUID, key, value string, offset, limit int) ([]domain.Document, int, error)
+	// ListByDocuments loads the tags of many documents in one query, keyed by document ID.
+	ListByDocuments(ctx context.Context, tenantID uuid.UUID, documentIDs []uuid.UUID) (map[uuid.UUID][]domain.DocumentTag, error)
+	// SearchByTag returns the documents with a tag matching q, newest first.
+	SearchByTag(ctx context.Context, tenantID uuid.UUID, q *domain.TagQuery, offset, limit int) ([]domain.Document, int, error)
 	DeleteByID(ctx context.Context, documentID, tagID uuid.UUID) error
 	DeleteByDocument(ctx context.Context, documentID uuid.UUID) error
 	DeleteByDocumentAndSource(ctx context.Context, documentID uuid.UUID, source string) error
+	// AddIfMissing inserts tag unless the document already has a tag with the same
+	// key and value, and reports whether it was inserted.
+	AddIfMissing(ctx context.Context, tag *domain.DocumentTag) (bool, error)
+	// DeleteByKeyValue removes the document's tags with key and value and returns how many were removed.
+	DeleteByKeyValue(ctx context.Context, documentID uuid.UUID, key, value string) (int, error)
 }
 
 // DocumentValidationRuleRepository defines the contract for validation rule persistence.
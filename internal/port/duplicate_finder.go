@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"satvos/internal/domain"
 )
 
 // DuplicateMatch holds enough information about a matching document for an actionable warning message.
@@ -13,8 +15,26 @@ type DuplicateMatch struct {
 	CreatedAt    time.Time `db:"created_at"`
 }
 
+// DuplicateCandidate is a parsed invoice from the same seller as the document
+// being checked, with the fields its similarity is scored on.
+type DuplicateCandidate struct {
+	DocumentID    uuid.UUID           `db:"document_id"`
+	DocumentName  string              `db:"name"`
+	CollectionID  uuid.UUID           `db:"collection_id"`
+	InvoiceNumber string              `db:"invoice_number"`
+	InvoiceDate   *time.Time          `db:"invoice_date"`
+	TotalAmount   float64             `db:"total_amount"`
+	ReviewStatus  domain.ReviewStatus `db:"review_status"`
+	CreatedAt     time.Time           `db:"created_at"`
+}
+
 // DuplicateInvoiceFinder checks for other documents with the same seller GSTIN + invoice number.
 type DuplicateInvoiceFinder interface {
 	FindDuplicates(ctx context.Context, tenantID, excludeDocID uuid.UUID,
 		sellerGSTIN, invoiceNumber string) ([]DuplicateMatch, error)
+	// FindCandidates returns up to limit of the seller's other parsed invoices,
+	// newest first, for fuzzy duplicate scoring. Viewer and free roles only see
+	// collections they have a permission on.
+	FindCandidates(ctx context.Context, tenantID, excludeDocID uuid.UUID, sellerGSTIN string,
+		userID uuid.UUID, role domain.UserRole, limit int) ([]DuplicateCandidate, error)
 }
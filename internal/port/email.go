@@ -1,9 +1,72 @@
 package port
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
 
 // EmailSender defines the contract for sending emails.
 type EmailSender interface {
 	SendVerificationEmail(ctx context.Context, toEmail, toName, verificationToken string) error
 	SendPasswordResetEmail(ctx context.Context, toEmail, toName, resetToken string) error
+	SendIngestionReport(ctx context.Context, toEmail, toName string, report *IngestionReport) error
+	// SendEmailChangeNotice tells the old address that the account now uses
+	// newEmail, with a link to undo the change.
+	SendEmailChangeNotice(ctx context.Context, toEmail, toName, newEmail, undoToken string) error
+	// SendInvitationEmail invites a user an admin created to set a password.
+	SendInvitationEmail(ctx context.Context, toEmail, toName, tenantName, inviteToken string) error
+	// SendAlertEmail sends a plain-text operational alert.
+	SendAlertEmail(ctx context.Context, toEmail, subject, body string) error
+}
+
+// IngestionReport summarizes one day of batch feed ingestion for a tenant.
+type IngestionReport struct {
+	TenantName string
+	Date       time.Time
+	Imported   int
+	Skipped    int
+	Failed     int
+	Entries    []IngestionReportEntry
+}
+
+// IngestionReportEntry is one file listed in an IngestionReport.
+type IngestionReportEntry struct {
+	Path   string
+	Status string
+	Reason string
+}
+
+// EmailSuppressionRepository defines the contract for addresses that must not be
+// emailed. Addresses are matched case-insensitively, across all tenants.
+type EmailSuppressionRepository interface {
+	// Suppress records s and marks every user with the address undeliverable. It
+	// returns the users whose state changed, so repeat reports don't re-alert.
+	Suppress(ctx context.Context, s *domain.EmailSuppression) ([]domain.User, error)
+	// Get returns the suppression of email, or domain.ErrNotFound.
+	Get(ctx context.Context, email string) (*domain.EmailSuppression, error)
+	// Delete lifts the suppression of email and clears the state of its users in
+	// tenantID. It returns domain.ErrEmailSuppressionShared, leaving the
+	// suppression in place, when users of other tenants also have the address.
+	Delete(ctx context.Context, tenantID uuid.UUID, email string) error
+}
+
+// SNSMessage is an Amazon SNS HTTP delivery whose signature has been verified.
+type SNSMessage struct {
+	Type         string
+	MessageID    string
+	TopicARN     string
+	Message      string
+	SubscribeURL string
+}
+
+// SNSVerifier authenticates Amazon SNS HTTP deliveries.
+type SNSVerifier interface {
+	// Verify parses body and checks its signature with the topic's signing certificate.
+	Verify(ctx context.Context, body []byte) (*SNSMessage, error)
+	// ConfirmSubscription visits the SubscribeURL of a verified SubscriptionConfirmation.
+	ConfirmSubscription(ctx context.Context, msg *SNSMessage) error
 }
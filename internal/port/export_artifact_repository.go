@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ExportArtifactRepository defines the contract for export artifact and
+// download persistence.
+type ExportArtifactRepository interface {
+	// Record stores artifact unless the collection already has one of the same
+	// kind, format and checksum, then adds a download by userID to whichever is
+	// stored. artifact is updated to the stored row.
+	Record(ctx context.Context, artifact *domain.ExportArtifact, userID uuid.UUID) error
+	GetByID(ctx context.Context, tenantID, artifactID uuid.UUID) (*domain.ExportArtifact, error)
+	// ListByCollection lists the collection's artifacts newest first; a non-empty
+	// checksum keeps only the artifact with that SHA-256.
+	ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, checksum string, offset, limit int) ([]domain.ExportArtifact, int, error)
+	// ListDownloads lists an artifact's downloads newest first.
+	ListDownloads(ctx context.Context, tenantID, artifactID uuid.UUID, offset, limit int) ([]domain.ExportDownload, int, error)
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// FeatureFlagRepository defines the contract for per-tenant feature flag persistence.
+// Only explicit tenant settings are stored; defaults live in domain.FeatureFlagDefaults.
+type FeatureFlagRepository interface {
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantFeatureFlag, error)
+	Upsert(ctx context.Context, flag *domain.TenantFeatureFlag) error
+	Delete(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) error
+}
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// FeedIngestionRepository defines the contract for batch feed ingestion records.
+type FeedIngestionRepository interface {
+	// Claim inserts a processing record for the object. Returns false if another
+	// worker already holds a claim on the same object.
+	Claim(ctx context.Context, rec *domain.FeedIngestion) (bool, error)
+	// Complete persists the outcome (status, reason, collection, file, document).
+	Complete(ctx context.Context, rec *domain.FeedIngestion) error
+	// FailStale marks processing claims created before cutoff as failed so the
+	// objects are retried, returning how many were released.
+	FailStale(ctx context.Context, cutoff time.Time) (int, error)
+	ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.FeedIngestion, int, error)
+	// ListCompletedBetween returns completed records in [from, to), oldest first.
+	ListCompletedBetween(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]domain.FeedIngestion, error)
+	// ClaimReport records that the report for date is being sent. Returns false if
+	// it was already claimed.
+	ClaimReport(ctx context.Context, tenantID uuid.UUID, date time.Time) (bool, error)
+}
@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// Flags evaluates per-tenant feature flags. Implementations must never fail the
+// caller: on lookup errors they fall back to the flag's default.
+type Flags interface {
+	IsEnabled(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) bool
+}
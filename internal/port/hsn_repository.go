@@ -1,16 +1,27 @@
 package port
 
-import "context"
+import (
+	"context"
+	"time"
 
-// HSNEntry represents a single HSN code entry with its GST rate.
+	"satvos/internal/domain"
+)
+
+// HSNEntry represents a single HSN code entry with its GST rate and the period it applies to.
 type HSNEntry struct {
-	Code          string  `db:"code"`
-	Description   string  `db:"description"`
-	GSTRate       float64 `db:"gst_rate"`
-	ConditionDesc string  `db:"condition_desc"`
+	Code          string     `db:"code"`
+	Description   string     `db:"description"`
+	GSTRate       float64    `db:"gst_rate"`
+	ConditionDesc string     `db:"condition_desc"`
+	EffectiveFrom time.Time  `db:"effective_from"`
+	EffectiveTo   *time.Time `db:"effective_to"`
 }
 
 // HSNRepository defines the contract for HSN code data access.
 type HSNRepository interface {
+	// LoadAll returns every rate entry, including superseded ones, so rates can be
+	// checked as of an invoice date.
 	LoadAll(ctx context.Context) ([]HSNEntry, error)
+	// ListCodes returns each distinct code currently in force with all of its rates.
+	ListCodes(ctx context.Context) ([]domain.HSNCode, error)
 }
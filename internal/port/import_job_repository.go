@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ImportJobRepository defines the contract for bulk import job persistence.
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ImportJob) error
+	GetByID(ctx context.Context, tenantID, jobID uuid.UUID) (*domain.ImportJob, error)
+	ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.ImportJob, int, error)
+	// UpdateProgress persists status, counters, entries, error and completed_at.
+	UpdateProgress(ctx context.Context, job *domain.ImportJob) error
+}
@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// IntegrationRepository defines the contract for no-code integration hooks and
+// the approved-document feed that polling triggers read.
+type IntegrationRepository interface {
+	CreateHook(ctx context.Context, hook *domain.IntegrationHook) error
+	GetHook(ctx context.Context, tenantID, hookID uuid.UUID) (*domain.IntegrationHook, error)
+	// UpdateHookTemplate sets the hook's payload template; nil clears it.
+	UpdateHookTemplate(ctx context.Context, tenantID, hookID uuid.UUID, payloadTemplate *string) error
+	DeleteHook(ctx context.Context, tenantID, hookID uuid.UUID) error
+	// ListHooksByUser lists the user's hooks, oldest first.
+	ListHooksByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.IntegrationHook, error)
+	// ListHooksForEvent lists every hook of the tenant subscribed to event.
+	ListHooksForEvent(ctx context.Context, tenantID uuid.UUID, event domain.IntegrationEvent) ([]domain.IntegrationHook, error)
+
+	// ListApprovedSince returns approved documents with (reviewed_at, id) > (afterAt, afterID),
+	// oldest approval first. explicitOnly restricts them to collections the user has an
+	// explicit permission on; collectionID, when set, to one collection.
+	ListApprovedSince(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID, afterAt time.Time, afterID uuid.UUID, limit int) ([]domain.Document, error)
+	// ListRecentlyApproved returns the most recently approved documents, newest first,
+	// with the same filters as ListApprovedSince.
+	ListRecentlyApproved(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID, limit int) ([]domain.Document, error)
+}
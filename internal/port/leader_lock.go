@@ -0,0 +1,17 @@
+package port
+
+import "context"
+
+// LeaderLock is a lock held by at most one instance at a time, used to elect the
+// single instance that runs a worker. It belongs to its holder's database
+// session, so a leader that crashes or loses its connection releases it.
+type LeaderLock interface {
+	// TryAcquire takes the lock if it is free and reports whether this instance
+	// now holds it. It does not wait.
+	TryAcquire(ctx context.Context) (bool, error)
+	// Check returns an error once the lock is no longer held, such as after the
+	// session holding it dropped.
+	Check(ctx context.Context) error
+	// Release gives the lock up. It is a no-op when the lock is not held.
+	Release(ctx context.Context) error
+}
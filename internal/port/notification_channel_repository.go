@@ -0,0 +1,40 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// NotificationChannelRepository defines the contract for per-collection Slack /
+// Teams channels and the document reads their scheduled messages need.
+type NotificationChannelRepository interface {
+	Create(ctx context.Context, channel *domain.NotificationChannel) error
+	GetByID(ctx context.Context, tenantID, channelID uuid.UUID) (*domain.NotificationChannel, error)
+	ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID) ([]domain.NotificationChannel, error)
+	// Update stores the channel settings; delivery state and schedule are left alone.
+	Update(ctx context.Context, channel *domain.NotificationChannel) error
+	Delete(ctx context.Context, tenantID, channelID uuid.UUID) error
+	// ListForEvent lists the collection's enabled channels subscribed to event.
+	ListForEvent(ctx context.Context, tenantID, collectionID uuid.UUID, event domain.NotificationEvent) ([]domain.NotificationChannel, error)
+	// ListScheduled lists enabled channels across all tenants subscribed to the
+	// review SLA or weekly summary events.
+	ListScheduled(ctx context.Context) ([]domain.NotificationChannel, error)
+	// AdvanceSLACheck moves sla_checked_through from from to to. It reports false
+	// when another instance already moved it, so each window is checked once.
+	AdvanceSLACheck(ctx context.Context, channelID uuid.UUID, from, to time.Time) (bool, error)
+	// AdvanceSummary moves next_summary_at from from to to, like AdvanceSLACheck.
+	AdvanceSummary(ctx context.Context, channelID uuid.UUID, from, to time.Time) (bool, error)
+	// RecordDelivery stores the outcome of the latest post; an empty lastError means success.
+	RecordDelivery(ctx context.Context, channelID uuid.UUID, at time.Time, lastError string) error
+
+	// ListOverdueReviews returns the collection's parsed documents still awaiting
+	// review whose parsed_at is in (after, through], oldest first.
+	ListOverdueReviews(ctx context.Context, tenantID, collectionID uuid.UUID, after, through time.Time, limit int) ([]domain.Document, error)
+	// CollectionActivity counts uploads, review decisions and parse failures in
+	// [from, to), plus the documents awaiting review now.
+	CollectionActivity(ctx context.Context, tenantID, collectionID uuid.UUID, from, to time.Time) (*domain.CollectionActivity, error)
+}
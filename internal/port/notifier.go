@@ -0,0 +1,58 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// NotificationRecipient is a user a notification is addressed to.
+type NotificationRecipient struct {
+	UserID uuid.UUID
+	Email  string
+	Name   string
+}
+
+// Notification is a message to one or more users of a tenant. Email and the
+// in-app inbox deliver it to each recipient; Slack and the webhook post it once.
+// The email channel renders it with the template for its Kind, the others send
+// Subject and Body. Token is the single-use link secret of kinds that carry one;
+// those kinds have exactly one recipient and only email is given the token, so
+// it can't leak into a shared Slack channel, a webhook or a stored inbox.
+type Notification struct {
+	Kind       domain.NotificationKind
+	TenantID   uuid.UUID
+	Recipients []NotificationRecipient
+	Subject    string
+	Body       string
+	Token      string
+	// IngestionReport is set for NotificationKindIngestionReport.
+	IngestionReport *IngestionReport
+	// NewEmail is set for NotificationKindEmailChanged.
+	NewEmail string
+	// TenantName is set for NotificationKindInvitation.
+	TenantName string
+}
+
+// Notifier delivers notifications over one channel, or routes them to several.
+type Notifier interface {
+	Notify(ctx context.Context, n *Notification) error
+}
+
+// NotificationRouteRepository defines the contract for per-tenant notification routing.
+type NotificationRouteRepository interface {
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.NotificationRoute, error)
+	// Replace swaps the tenant's routes for routes; an empty slice removes them all.
+	Replace(ctx context.Context, tenantID uuid.UUID, routes []domain.NotificationRoute) error
+}
+
+// InAppNotificationRepository defines the contract for users' in-app inboxes.
+type InAppNotificationRepository interface {
+	Create(ctx context.Context, n *domain.InAppNotification) error
+	// ListByUser lists the user's notifications newest first, optionally only unread ones.
+	ListByUser(ctx context.Context, tenantID, userID uuid.UUID, unreadOnly bool, offset, limit int) ([]domain.InAppNotification, int, error)
+	// MarkRead marks one of the user's notifications read; ErrNotFound when it isn't theirs.
+	MarkRead(ctx context.Context, tenantID, userID, notificationID uuid.UUID) error
+}
@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// PageRenderer rasterizes a single page of a PDF.
+type PageRenderer interface {
+	// RenderPage returns page (1-based) of pdf as a PNG at dpi. It returns
+	// domain.ErrPageNotFound when the document has fewer pages.
+	RenderPage(ctx context.Context, pdf []byte, page, dpi int) ([]byte, error)
+}
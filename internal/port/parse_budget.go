@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParseBudgetRepository defines the contract for daily parse budget counters.
+type ParseBudgetRepository interface {
+	// Reserve counts one parse for (tenantID, userID) on day if fewer than limit
+	// were counted, and returns domain.ErrParseBudgetExhausted otherwise. userID
+	// is uuid.Nil for budgets kept per tenant.
+	Reserve(ctx context.Context, tenantID, userID uuid.UUID, day time.Time, limit int) error
+}
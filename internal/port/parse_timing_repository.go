@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ParseTimingRepository records per-attempt parse timings and aggregates them for
+// SLA reporting and alerting.
+type ParseTimingRepository interface {
+	Record(ctx context.Context, timing *domain.ParseTiming) error
+	// ListByDocument returns a document's attempts, oldest first.
+	ListByDocument(ctx context.Context, tenantID, documentID uuid.UUID) ([]domain.ParseTiming, error)
+	// LatencyByModel returns percentiles per parser model for attempts since the
+	// given time. A nil tenantID aggregates across all tenants.
+	LatencyByModel(ctx context.Context, tenantID *uuid.UUID, since time.Time) ([]domain.ParseLatencyStats, error)
+	// OldestWaiting returns how long the longest-waiting pending or queued document
+	// has been eligible to parse, or zero if none are waiting.
+	OldestWaiting(ctx context.Context) (time.Duration, error)
+}
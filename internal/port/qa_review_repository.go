@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// QAReviewRepository defines the contract for second-opinion QA sample persistence.
+type QAReviewRepository interface {
+	// Create samples a document. It reports false, without error, when the
+	// document was already sampled.
+	Create(ctx context.Context, review *domain.QAReview) (bool, error)
+	GetByID(ctx context.Context, tenantID, reviewID uuid.UUID) (*domain.QAReview, error)
+	// ListPending lists pending samples userID may give a second opinion on,
+	// oldest first: never one they approved (or made). ownedOnly restricts it to
+	// collections userID owns.
+	ListPending(ctx context.Context, tenantID, userID uuid.UUID, ownedOnly bool, offset, limit int) ([]domain.QASample, int, error)
+	// Complete records the verdict on a pending sample; ErrQAReviewCompleted if
+	// it already has one.
+	Complete(ctx context.Context, review *domain.QAReview) error
+	// ScoresByReviewer aggregates the samples taken at or after since per
+	// approving reviewer.
+	ScoresByReviewer(ctx context.Context, tenantID uuid.UUID, since time.Time) ([]domain.QAReviewerScore, error)
+}
@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// RejectionReasonRepository defines the contract for per-tenant rejection-reason
+// persistence. Only tenant settings are stored; defaults live in
+// domain.DefaultRejectionReasons.
+type RejectionReasonRepository interface {
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.RejectionReason, error)
+	Upsert(ctx context.Context, reason *domain.RejectionReason) error
+	// Stats counts the tenant's currently rejected documents by reason code and
+	// parser model. From/To filter on the review date; viewer and free roles only
+	// see collections they have a permission on.
+	Stats(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.RejectionReasonStat, error)
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// RelatedPartyFinder looks up a GSTIN among a tenant's related parties.
+type RelatedPartyFinder interface {
+	// FindRelatedParty returns domain.ErrNotFound if gstin is not registered.
+	FindRelatedParty(ctx context.Context, tenantID uuid.UUID, gstin string) (*domain.RelatedParty, error)
+}
+
+// RelatedPartyRepository defines the contract for the GSTINs a tenant registered
+// as its own or its group companies'.
+type RelatedPartyRepository interface {
+	RelatedPartyFinder
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.RelatedParty, error)
+	Upsert(ctx context.Context, party *domain.RelatedParty) error
+	// Delete returns domain.ErrNotFound if gstin is not registered.
+	Delete(ctx context.Context, tenantID uuid.UUID, gstin string) error
+}
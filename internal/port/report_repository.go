@@ -13,6 +13,10 @@ type ReportRepository interface {
 	SellerSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.SellerSummaryRow, int, error)
 	BuyerSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.BuyerSummaryRow, int, error)
 	PartyLedger(ctx context.Context, tenantID uuid.UUID, gstin string, filters *domain.ReportFilters) ([]domain.PartyLedgerRow, int, error)
+	// AmountMatches lists documents whose total is between minAmount and
+	// maxAmount, closest to target first. A non-empty party matches either
+	// side's GSTIN exactly or name by substring.
+	AmountMatches(ctx context.Context, tenantID uuid.UUID, target, minAmount, maxAmount float64, party string, filters *domain.ReportFilters) ([]domain.AmountMatchRow, int, error)
 	FinancialSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.FinancialSummaryRow, error)
 	TaxSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.TaxSummaryRow, error)
 	HSNSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.HSNSummaryRow, int, error)
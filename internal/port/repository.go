@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -15,6 +16,8 @@ type TenantRepository interface {
 	GetBySlug(ctx context.Context, slug string) (*domain.Tenant, error)
 	List(ctx context.Context, offset, limit int) ([]domain.Tenant, int, error)
 	Update(ctx context.Context, tenant *domain.Tenant) error
+	// ListClients returns the client tenants of a firm tenant, newest first.
+	ListClients(ctx context.Context, parentID uuid.UUID, offset, limit int) ([]domain.Tenant, int, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -23,12 +26,25 @@ type TenantRepository interface {
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, tenantID, userID uuid.UUID) (*domain.User, error)
+	// GetByIDs loads many users in one query; unknown IDs are skipped.
+	GetByIDs(ctx context.Context, tenantID uuid.UUID, userIDs []uuid.UUID) ([]domain.User, error)
 	GetByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*domain.User, error)
 	ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.User, int, error)
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, tenantID, userID uuid.UUID) error
 	CheckAndIncrementQuota(ctx context.Context, tenantID, userID uuid.UUID) error
+	// ClaimQuotaWarning raises the user's quota warning level for the current
+	// period to level. It reports false if that level was already reached.
+	ClaimQuotaWarning(ctx context.Context, userID uuid.UUID, level int) (bool, error)
 	SetEmailVerified(ctx context.Context, tenantID, userID uuid.UUID) error
+	// ListUnverified returns active users who have not verified their email, newest first.
+	ListUnverified(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.User, int, error)
+	// ClaimVerificationSend records a verification email for an unverified user unless
+	// one was already sent after notSentSince. It reports whether the send may go ahead.
+	ClaimVerificationSend(ctx context.Context, tenantID, userID uuid.UUID, notSentSince time.Time) (bool, error)
+	// ClaimVerificationReminders marks up to limit unverified users, across all tenants,
+	// registered before registeredBefore and never reminded, and returns them.
+	ClaimVerificationReminders(ctx context.Context, registeredBefore time.Time, limit int) ([]domain.User, error)
 	SetPasswordResetToken(ctx context.Context, tenantID, userID uuid.UUID, tokenID string) error
 	ResetPassword(ctx context.Context, tenantID, userID uuid.UUID, passwordHash, expectedTokenID string) error
 	GetByProviderID(ctx context.Context, tenantID uuid.UUID, provider domain.AuthProvider, providerUserID string) (*domain.User, error)
@@ -44,4 +60,9 @@ type FileMetaRepository interface {
 	ListByUploader(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.FileMeta, int, error)
 	UpdateStatus(ctx context.Context, tenantID, fileID uuid.UUID, status domain.FileStatus) error
 	Delete(ctx context.Context, tenantID, fileID uuid.UUID) error
+	// ListRelocations returns up to limit uploaded files, ordered by ID after afterID,
+	// that belong to a collection but are not stored under that collection's prefix.
+	ListRelocations(ctx context.Context, tenantID, afterID uuid.UUID, limit int) ([]domain.FileRelocation, error)
+	// UpdateS3Key repoints a file at newKey if it still points at oldKey; otherwise ErrNotFound.
+	UpdateS3Key(ctx context.Context, tenantID, fileID uuid.UUID, oldKey, newKey string) error
 }
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ReviewDelegationRepository defines the contract for out-of-office delegation persistence.
+type ReviewDelegationRepository interface {
+	// Upsert creates or replaces the user's delegation.
+	Upsert(ctx context.Context, d *domain.ReviewDelegation) error
+	GetByUser(ctx context.Context, tenantID, userID uuid.UUID) (*domain.ReviewDelegation, error)
+	Delete(ctx context.Context, tenantID, userID uuid.UUID) error
+	// ListActiveForDelegate returns the delegations to delegateID active on the date of at.
+	ListActiveForDelegate(ctx context.Context, tenantID, delegateID uuid.UUID, at time.Time) ([]domain.ReviewDelegation, error)
+}
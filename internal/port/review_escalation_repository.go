@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ReviewEscalationRepository finds and lists documents whose review has waited
+// past their collection's escalation deadline.
+type ReviewEscalationRepository interface {
+	// ClaimDue marks up to limit pending documents escalated at now, across all
+	// tenants, when they were parsed at least escalate_after_days before now.
+	// Each document is claimed once until its review clears escalated_at.
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]domain.Document, error)
+	// ListEscalated lists the tenant's escalated documents still awaiting review,
+	// oldest escalation first. ownedOnly restricts it to collections userID owns.
+	ListEscalated(ctx context.Context, tenantID, userID uuid.UUID, ownedOnly bool, offset, limit int) ([]domain.Document, int, error)
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// StarRepository defines the contract for per-user document and collection stars.
+// Starring is idempotent; unstarring something not starred is a no-op.
+type StarRepository interface {
+	StarDocument(ctx context.Context, tenantID, userID, documentID uuid.UUID) error
+	UnstarDocument(ctx context.Context, tenantID, userID, documentID uuid.UUID) error
+	StarCollection(ctx context.Context, tenantID, userID, collectionID uuid.UUID) error
+	UnstarCollection(ctx context.Context, tenantID, userID, collectionID uuid.UUID) error
+	// ListStarredDocuments lists the user's starred documents, most recently starred
+	// first; explicitOnly restricts them to collections the user has an explicit permission on.
+	ListStarredDocuments(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, offset, limit int) ([]domain.Document, int, error)
+	ListStarredCollections(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, offset, limit int) ([]domain.Collection, int, error)
+}
@@ -2,14 +2,30 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
 	"satvos/internal/domain"
 )
 
-// StatsRepository provides aggregate statistics queries.
+// StatsRepository provides aggregate statistics queries. Reads come from the
+// document_daily_stats materialization, kept current by RefreshDay and ReconcileTenant.
 type StatsRepository interface {
 	GetTenantStats(ctx context.Context, tenantID uuid.UUID) (*domain.Stats, error)
 	GetUserStats(ctx context.Context, tenantID, userID uuid.UUID) (*domain.Stats, error)
+	// RefreshDay recounts the documents of one collection created on day (UTC)
+	// and stores the result, removing the bucket when it has no documents left.
+	RefreshDay(ctx context.Context, tenantID, collectionID uuid.UUID, day time.Time) error
+	// ReconcileTenant rebuilds every bucket of the tenant from the documents table in
+	// one transaction and returns the collections whose document count had drifted.
+	ReconcileTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.StatsDiscrepancy, error)
+	// CollectionQuality computes the quality score aggregates of each of the tenant's
+	// collections from the documents table, ordered by collection name.
+	CollectionQuality(ctx context.Context, tenantID uuid.UUID, filter domain.CollectionQualityFilter) ([]domain.CollectionQualityCounts, error)
+	// SnapshotQuality records the quality score aggregates of every collection of
+	// the tenant for day, replacing an earlier snapshot of the same day.
+	SnapshotQuality(ctx context.Context, tenantID uuid.UUID, day time.Time) error
+	// QualityHistory returns a collection's snapshots from since on, oldest first.
+	QualityHistory(ctx context.Context, tenantID, collectionID uuid.UUID, since time.Time) ([]domain.CollectionQualityCounts, error)
 }
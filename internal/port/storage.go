@@ -3,15 +3,26 @@ package port
 import (
 	"context"
 	"io"
+
+	"satvos/internal/domain"
 )
 
+// Encryption is the server-side encryption to write an object with. KMSKeyARN
+// is set only for SSE-KMS.
+type Encryption struct {
+	Algorithm domain.StorageEncryption
+	KMSKeyARN string
+}
+
 // UploadInput encapsulates the parameters needed to upload an object.
+// A nil Encryption leaves the object to the bucket's default encryption.
 type UploadInput struct {
 	Bucket      string
 	Key         string
 	Body        io.Reader
 	ContentType string
 	Size        int64
+	Encryption  *Encryption
 }
 
 // UploadOutput contains the result of a successful upload.
@@ -20,10 +31,42 @@ type UploadOutput struct {
 	ETag     string
 }
 
+// ObjectInfo describes a stored object returned by List.
+type ObjectInfo struct {
+	Key  string
+	Size int64
+}
+
+// ObjectReader is an open object body. The caller must close Body.
+type ObjectReader struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+}
+
+// LifecycleRule transitions every object under Prefix to infrequent-access
+// storage TransitionDays after it was written.
+type LifecycleRule struct {
+	ID             string
+	Prefix         string
+	TransitionDays int
+}
+
 // ObjectStorage abstracts cloud object storage operations.
 type ObjectStorage interface {
 	Upload(ctx context.Context, input UploadInput) (*UploadOutput, error)
 	Download(ctx context.Context, bucket, key string) ([]byte, error)
+	// Open streams an object instead of buffering it, for large downloads.
+	Open(ctx context.Context, bucket, key string) (*ObjectReader, error)
 	Delete(ctx context.Context, bucket, key string) error
 	GetPresignedURL(ctx context.Context, bucket, key string, expirySeconds int64) (string, error)
+	// List returns every object whose key starts with prefix.
+	List(ctx context.Context, bucket, prefix string) ([]ObjectInfo, error)
+	// Copy duplicates an object within a bucket; the source is left in place.
+	// The copy is written with enc, or the bucket's default encryption if nil.
+	Copy(ctx context.Context, bucket, srcKey, dstKey string, enc *Encryption) error
+	// PutLifecycleRule adds or replaces the bucket rule with rule.ID, keeping all other rules.
+	PutLifecycleRule(ctx context.Context, bucket string, rule LifecycleRule) error
+	// DeleteLifecycleRule removes the bucket rule with ruleID; a missing rule is not an error.
+	DeleteLifecycleRule(ctx context.Context, bucket, ruleID string) error
 }
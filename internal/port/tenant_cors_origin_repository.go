@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// TenantCORSOriginRepository defines the contract for tenants' allowed frontend origins.
+type TenantCORSOriginRepository interface {
+	// ListByTenant returns the tenant's origins in alphabetical order.
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]string, error)
+	// Replace swaps the tenant's origins for origins; an empty slice removes them all.
+	Replace(ctx context.Context, tenantID uuid.UUID, origins []string) error
+	// TenantsByOrigin returns the tenants that allow origin.
+	TenantsByOrigin(ctx context.Context, origin string) ([]uuid.UUID, error)
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// TenantMembershipRepository defines the contract for guest access of users to
+// tenants other than their home tenant.
+type TenantMembershipRepository interface {
+	Upsert(ctx context.Context, membership *domain.TenantMembership) error
+	Get(ctx context.Context, tenantID, userID uuid.UUID) (*domain.TenantMembership, error)
+	// ListByUser returns the user's home tenant followed by their active
+	// memberships, skipping inactive tenants, with tenant names and slugs.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.TenantMembership, error)
+	// ListByTenant returns the tenant's guests with their email and name.
+	ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMembership, int, error)
+	Delete(ctx context.Context, tenantID, userID uuid.UUID) error
+}
@@ -0,0 +1,63 @@
+package port
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// TenantMoveRepository defines the contract for tenant move persistence.
+type TenantMoveRepository interface {
+	// Create inserts the move unless another move of the same tenant is still
+	// pending or copying, in which case it returns domain.ErrTenantMoveInProgress.
+	Create(ctx context.Context, move *domain.TenantMove) error
+	GetByID(ctx context.Context, tenantID, moveID uuid.UUID) (*domain.TenantMove, error)
+	ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMove, int, error)
+	// UpdateProgress persists status, tables, object counters, error and completed_at.
+	UpdateProgress(ctx context.Context, move *domain.TenantMove) error
+}
+
+// StoredObject is an S3 object referenced by a tenant's file metadata.
+type StoredObject struct {
+	Bucket string
+	Key    string
+}
+
+// TenantImport describes one import of a tenant's rows into a TenantDataStore.
+type TenantImport struct {
+	TenantID uuid.UUID
+	// Source is read table by table, in the order of Tables.
+	Source TenantDataStore
+	Tables []string
+	// Verify receives the row counts and checksums of the imported rows before
+	// the import commits; an error rolls the whole import back.
+	Verify func(imported []domain.TenantMoveTable) error
+	// StorageRegion, when set, replaces the tenant's storage region, and Buckets
+	// repoints file metadata from each source bucket to its target bucket.
+	StorageRegion *string
+	Buckets       map[string]string
+}
+
+// TenantDataStore reads and writes all of one tenant's rows in a database
+// cluster, for moving tenants between clusters. Rows keep their IDs.
+type TenantDataStore interface {
+	// SchemaVersion returns the applied migration version; a dirty schema is an error.
+	SchemaVersion(ctx context.Context) (uint, error)
+	// CheckMovable returns domain.ErrTenantNotMovable when the tenant has a parent
+	// tenant, client tenants, or guest memberships in either direction.
+	CheckMovable(ctx context.Context, tenantID uuid.UUID) error
+	// SetCluster sets the tenant's routing flag (tenants.db_cluster).
+	SetCluster(ctx context.Context, tenantID uuid.UUID, cluster string) error
+	// Snapshot returns the row count and checksum of the tenant's rows in every
+	// tenant-scoped table, parents before the tables referencing them.
+	Snapshot(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantMoveTable, error)
+	// ExportTable streams the tenant's rows of table to fn as JSON arrays of rows.
+	ExportTable(ctx context.Context, tenantID uuid.UUID, table string, fn func(rows json.RawMessage) error) error
+	// ListObjects returns the S3 objects behind the tenant's files.
+	ListObjects(ctx context.Context, tenantID uuid.UUID) ([]StoredObject, error)
+	// Import replaces the tenant's rows with the source's in one transaction.
+	Import(ctx context.Context, in *TenantImport) error
+}
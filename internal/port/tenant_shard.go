@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// TenantShardRepository reads tenant placement from tenants.db_cluster on the
+// primary cluster, the same routing flag RequireActiveTenant enforces.
+type TenantShardRepository interface {
+	// Resolve returns the tenant's db_cluster. "" means the tenant was never
+	// moved and lives on the primary cluster.
+	Resolve(ctx context.Context, tenantID uuid.UUID) (string, error)
+	// CountByShard returns the number of tenants placed on each cluster.
+	CountByShard(ctx context.Context) (map[string]int, error)
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ValidationRuleOutcomeRepository keeps the result history of every validation
+// rule on every document and aggregates it into per-rule failure metrics.
+type ValidationRuleOutcomeRepository interface {
+	// Record upserts the latest result of each rule, keyed by (document_id, rule_id).
+	// Failed only ever turns true; a rule that was failing and now passes is marked
+	// corrected.
+	Record(ctx context.Context, outcomes []domain.ValidationRuleOutcome) error
+	// MarkOverridden flags the rules currently failing on a document as overridden,
+	// i.e. the document was approved despite them.
+	MarkOverridden(ctx context.Context, tenantID, documentID uuid.UUID) error
+	// StatsByRule aggregates, per rule, the tenant's outcomes first seen since the
+	// given time. An empty documentType matches every type.
+	StatsByRule(ctx context.Context, tenantID uuid.UUID, documentType string, since time.Time) ([]domain.RuleFailureStats, error)
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ValidationRunRepository defines the contract for collection validation run persistence.
+type ValidationRunRepository interface {
+	// Create inserts the run unless another run of the same collection is still
+	// pending or processing, in which case it returns domain.ErrValidationRunInProgress.
+	Create(ctx context.Context, run *domain.ValidationRun) error
+	GetByID(ctx context.Context, tenantID, runID uuid.UUID) (*domain.ValidationRun, error)
+	ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.ValidationRun, int, error)
+	// UpdateProgress persists status, counters, changes, error and completed_at.
+	UpdateProgress(ctx context.Context, run *domain.ValidationRun) error
+	// ListTargets returns up to limit parsed documents of the collection, oldest first.
+	ListTargets(ctx context.Context, tenantID, collectionID uuid.UUID, limit int) ([]domain.ValidationRunTarget, error)
+}
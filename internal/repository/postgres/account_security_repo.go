@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type accountSecurityRepo struct {
+	db *sqlx.DB
+}
+
+// NewAccountSecurityRepo creates a new PostgreSQL-backed AccountSecurityRepository.
+func NewAccountSecurityRepo(db *sqlx.DB) port.AccountSecurityRepository {
+	return &accountSecurityRepo{db: db}
+}
+
+func (r *accountSecurityRepo) RecordEvent(ctx context.Context, event *domain.SecurityEvent) error {
+	event.ID = uuid.New()
+	event.CreatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO account_security_events (id, tenant_id, user_id, event, actor_id, ip_address, user_agent, detail, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		event.ID, event.TenantID, event.UserID, event.Event, event.ActorID,
+		event.IPAddress, event.UserAgent, event.Detail, event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("accountSecurityRepo.RecordEvent: %w", err)
+	}
+	return nil
+}
+
+func (r *accountSecurityRepo) ListEvents(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.SecurityEvent, int, error) {
+	var total int
+	if err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM account_security_events WHERE tenant_id = $1 AND user_id = $2",
+		tenantID, userID); err != nil {
+		return nil, 0, fmt.Errorf("accountSecurityRepo.ListEvents count: %w", err)
+	}
+
+	var events []domain.SecurityEvent
+	if err := r.db.SelectContext(ctx, &events,
+		`SELECT * FROM account_security_events WHERE tenant_id = $1 AND user_id = $2
+		 ORDER BY created_at DESC, id DESC OFFSET $3 LIMIT $4`,
+		tenantID, userID, offset, limit); err != nil {
+		return nil, 0, fmt.Errorf("accountSecurityRepo.ListEvents: %w", err)
+	}
+	return events, total, nil
+}
+
+func (r *accountSecurityRepo) CreateEmailChange(ctx context.Context, change *domain.EmailChange) error {
+	change.ID = uuid.New()
+	change.CreatedAt = time.Now().UTC()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("accountSecurityRepo.CreateEmailChange begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO email_changes (id, tenant_id, user_id, old_email, new_email, changed_by, undo_expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		change.ID, change.TenantID, change.UserID, change.OldEmail, change.NewEmail,
+		change.ChangedBy, change.UndoExpiresAt, change.CreatedAt); err != nil {
+		return fmt.Errorf("accountSecurityRepo.CreateEmailChange insert: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE users SET password_reset_token_id = NULL WHERE id = $1 AND tenant_id = $2",
+		change.UserID, change.TenantID); err != nil {
+		return fmt.Errorf("accountSecurityRepo.CreateEmailChange clear reset: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("accountSecurityRepo.CreateEmailChange commit: %w", err)
+	}
+	return nil
+}
+
+func (r *accountSecurityRepo) GetEmailChange(ctx context.Context, tenantID, changeID uuid.UUID) (*domain.EmailChange, error) {
+	var change domain.EmailChange
+	err := r.db.GetContext(ctx, &change,
+		"SELECT * FROM email_changes WHERE id = $1 AND tenant_id = $2", changeID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("accountSecurityRepo.GetEmailChange: %w", err)
+	}
+	return &change, nil
+}
+
+func (r *accountSecurityRepo) UndoEmailChange(ctx context.Context, change *domain.EmailChange) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("accountSecurityRepo.UndoEmailChange begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	result, err := tx.ExecContext(ctx,
+		"UPDATE email_changes SET undone_at = $1 WHERE id = $2 AND tenant_id = $3 AND undone_at IS NULL",
+		now, change.ID, change.TenantID)
+	if err != nil {
+		return fmt.Errorf("accountSecurityRepo.UndoEmailChange mark: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return domain.ErrEmailChangeUndoInvalid
+	}
+
+	// Only while the address is still the one this change set
+	result, err = tx.ExecContext(ctx,
+		`UPDATE users SET email = $1, password_reset_token_id = NULL, updated_at = $2
+		 WHERE id = $3 AND tenant_id = $4 AND email = $5`,
+		change.OldEmail, now, change.UserID, change.TenantID, change.NewEmail)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return domain.ErrDuplicateEmail
+		}
+		return fmt.Errorf("accountSecurityRepo.UndoEmailChange restore: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return domain.ErrEmailChangeUndoInvalid
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("accountSecurityRepo.UndoEmailChange commit: %w", err)
+	}
+	change.UndoneAt = &now
+	return nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/port"
+)
+
+// errLockNotHeld is returned by Check when the lock was never taken or was released.
+var errLockNotHeld = errors.New("advisory lock not held")
+
+// advisoryLock is a session-level Postgres advisory lock. It keeps one pooled
+// connection for as long as it holds the lock, since the lock lives and dies with
+// that session. It does not work through a transaction-pooling proxy.
+type advisoryLock struct {
+	db   *sqlx.DB
+	name string
+
+	mu   sync.Mutex
+	conn *sqlx.Conn
+}
+
+// NewAdvisoryLock creates a LeaderLock on the Postgres advisory lock keyed by
+// hashtext(name).
+func NewAdvisoryLock(db *sqlx.DB, name string) port.LeaderLock {
+	return &advisoryLock{db: db, name: name}
+}
+
+func (l *advisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		return true, nil
+	}
+	conn, err := l.db.Connx(ctx)
+	if err != nil {
+		return false, fmt.Errorf("advisoryLock.TryAcquire connect: %w", err)
+	}
+	var acquired bool
+	if err := conn.GetContext(ctx, &acquired, "SELECT pg_try_advisory_lock(hashtext($1))", l.name); err != nil {
+		// Whether the lock was taken is unknown, so the session must not go back to the pool
+		discardConn(conn)
+		return false, fmt.Errorf("advisoryLock.TryAcquire: %w", err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+func (l *advisoryLock) Check(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return errLockNotHeld
+	}
+	if _, err := l.conn.ExecContext(ctx, "SELECT 1"); err != nil {
+		discardConn(l.conn)
+		l.conn = nil
+		return fmt.Errorf("advisoryLock.Check: %w", err)
+	}
+	return nil
+}
+
+func (l *advisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	conn := l.conn
+	l.conn = nil
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1))", l.name); err != nil {
+		discardConn(conn)
+		return fmt.Errorf("advisoryLock.Release: %w", err)
+	}
+	return conn.Close()
+}
+
+// discardConn closes a connection's session instead of returning it to the pool,
+// which also drops any advisory lock it holds.
+func discardConn(conn *sqlx.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
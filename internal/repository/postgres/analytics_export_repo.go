@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type analyticsExportRepo struct {
+	db *sqlx.DB
+}
+
+// NewAnalyticsExportRepo creates a new PostgreSQL-backed AnalyticsExportRepository.
+func NewAnalyticsExportRepo(db *sqlx.DB) port.AnalyticsExportRepository {
+	return &analyticsExportRepo{db: db}
+}
+
+func (r *analyticsExportRepo) Get(ctx context.Context, tenantID uuid.UUID) (*domain.AnalyticsExport, error) {
+	var export domain.AnalyticsExport
+	err := r.db.GetContext(ctx, &export, "SELECT * FROM analytics_exports WHERE tenant_id = $1", tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("analyticsExportRepo.Get: %w", err)
+	}
+	return &export, nil
+}
+
+func (r *analyticsExportRepo) Upsert(ctx context.Context, e *domain.AnalyticsExport) error {
+	err := r.db.QueryRowxContext(ctx,
+		`INSERT INTO analytics_exports
+			(tenant_id, s3_bucket, s3_prefix, interval_hours, enabled, exported_through, next_run_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (tenant_id) DO UPDATE
+		 SET s3_bucket = EXCLUDED.s3_bucket,
+		     s3_prefix = EXCLUDED.s3_prefix,
+		     interval_hours = EXCLUDED.interval_hours,
+		     enabled = EXCLUDED.enabled,
+		     exported_through = EXCLUDED.exported_through,
+		     next_run_at = EXCLUDED.next_run_at,
+		     updated_at = NOW()
+		 RETURNING *`,
+		e.TenantID, e.S3Bucket, e.S3Prefix, e.IntervalHours, e.Enabled, e.ExportedThrough, e.NextRunAt,
+	).StructScan(e)
+	if err != nil {
+		return fmt.Errorf("analyticsExportRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *analyticsExportRepo) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM analytics_exports WHERE tenant_id = $1", tenantID)
+	if err != nil {
+		return fmt.Errorf("analyticsExportRepo.Delete: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *analyticsExportRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.AnalyticsExport, error) {
+	var exports []domain.AnalyticsExport
+	err := r.db.SelectContext(ctx, &exports,
+		`UPDATE analytics_exports SET next_run_at = $2
+		 WHERE tenant_id IN (
+			SELECT tenant_id FROM analytics_exports
+			WHERE enabled AND next_run_at <= $1
+			ORDER BY next_run_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING *`, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("analyticsExportRepo.ClaimDue: %w", err)
+	}
+	return exports, nil
+}
+
+func (r *analyticsExportRepo) CompleteRun(ctx context.Context, e *domain.AnalyticsExport) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE analytics_exports
+		 SET exported_through = $1, next_run_at = $2, last_run_at = $3,
+		     last_run_documents = $4, last_error = $5, updated_at = NOW()
+		 WHERE tenant_id = $6 AND s3_bucket = $7 AND s3_prefix = $8`,
+		e.ExportedThrough, e.NextRunAt, e.LastRunAt, e.LastRunDocuments, e.LastError,
+		e.TenantID, e.S3Bucket, e.S3Prefix)
+	if err != nil {
+		return fmt.Errorf("analyticsExportRepo.CompleteRun: %w", err)
+	}
+	return nil
+}
+
+func (r *analyticsExportRepo) ListDocumentsUpdated(ctx context.Context, tenantID uuid.UUID, afterAt time.Time, afterID uuid.UUID, through time.Time, limit int) ([]domain.Document, error) {
+	var docs []domain.Document
+	err := r.db.SelectContext(ctx, &docs,
+		`SELECT * FROM documents
+		 WHERE tenant_id = $1 AND (updated_at, id) > ($2, $3) AND updated_at <= $4
+		 ORDER BY updated_at, id
+		 LIMIT $5`, tenantID, afterAt, afterID, through, limit)
+	if err != nil {
+		return nil, fmt.Errorf("analyticsExportRepo.ListDocumentsUpdated: %w", err)
+	}
+	return docs, nil
+}
+
+func (r *analyticsExportRepo) ListValidationFacts(ctx context.Context, tenantID uuid.UUID, documentIDs []uuid.UUID) ([]domain.ValidationFact, error) {
+	if len(documentIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(
+		`SELECT d.id AS document_id, d.tenant_id,
+			(v->>'rule_id')::uuid AS rule_id,
+			COALESCE(r.rule_name, '') AS rule_name,
+			r.builtin_rule_key,
+			COALESCE(r.severity, '') AS severity,
+			COALESCE(v->>'field_path', '') AS field_path,
+			COALESCE((v->>'passed')::boolean, false) AS passed,
+			COALESCE(v->>'expected_value', '') AS expected_value,
+			COALESCE(v->>'actual_value', '') AS actual_value,
+			COALESCE(v->>'message', '') AS message,
+			COALESCE((v->>'reconciliation_critical')::boolean, false) AS reconciliation_critical,
+			(v->>'validated_at')::timestamptz AS validated_at
+		 FROM documents d
+		 CROSS JOIN LATERAL jsonb_array_elements(
+			CASE WHEN jsonb_typeof(d.validation_results) = 'array' THEN d.validation_results ELSE '[]'::jsonb END) v
+		 LEFT JOIN document_validation_rules r ON r.id = (v->>'rule_id')::uuid
+		 WHERE d.tenant_id = ? AND d.id IN (?)
+		 ORDER BY d.id`, tenantID, documentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("analyticsExportRepo.ListValidationFacts: building query: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	var facts []domain.ValidationFact
+	if err := r.db.SelectContext(ctx, &facts, query, args...); err != nil {
+		return nil, fmt.Errorf("analyticsExportRepo.ListValidationFacts: %w", err)
+	}
+	return facts, nil
+}
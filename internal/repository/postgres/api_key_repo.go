@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type apiKeyRepo struct {
+	db *sqlx.DB
+}
+
+// NewAPIKeyRepo creates a new PostgreSQL-backed APIKeyRepository.
+func NewAPIKeyRepo(db *sqlx.DB) port.APIKeyRepository {
+	return &apiKeyRepo{db: db}
+}
+
+func (r *apiKeyRepo) Create(ctx context.Context, key *domain.APIKey) error {
+	key.CreatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO api_keys (id, tenant_id, user_id, name, key_prefix, key_hash, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		key.ID, key.TenantID, key.UserID, key.Name, key.KeyPrefix, key.KeyHash, key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("apiKeyRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepo) ListByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.APIKey, error) {
+	keys := []domain.APIKey{}
+	err := r.db.SelectContext(ctx, &keys,
+		"SELECT * FROM api_keys WHERE tenant_id = $1 AND user_id = $2 ORDER BY created_at",
+		tenantID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyRepo.ListByUser: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepo) Delete(ctx context.Context, tenantID, userID, keyID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM api_keys WHERE tenant_id = $1 AND user_id = $2 AND id = $3",
+		tenantID, userID, keyID)
+	if err != nil {
+		return fmt.Errorf("apiKeyRepo.Delete: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("apiKeyRepo.Delete: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepo) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.db.GetContext(ctx, &key, "SELECT * FROM api_keys WHERE key_hash = $1", keyHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("apiKeyRepo.GetByHash: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepo) TouchLastUsed(ctx context.Context, keyID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE api_keys SET last_used_at = NOW() WHERE id = $1", keyID)
+	if err != nil {
+		return fmt.Errorf("apiKeyRepo.TouchLastUsed: %w", err)
+	}
+	return nil
+}
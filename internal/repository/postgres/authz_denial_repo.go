@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type authzDenialRepo struct {
+	db *sqlx.DB
+}
+
+// NewAuthzDenialRepo creates a new PostgreSQL-backed AuthzDenialRepository.
+func NewAuthzDenialRepo(db *sqlx.DB) port.AuthzDenialRepository {
+	return &authzDenialRepo{db: db}
+}
+
+func (r *authzDenialRepo) Create(ctx context.Context, denial *domain.AuthzDenial) error {
+	if denial.ID == uuid.Nil {
+		denial.ID = uuid.New()
+	}
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO authz_denials (id, tenant_id, user_id, role, method, path, route, code, reason, request_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		denial.ID, denial.TenantID, denial.UserID, denial.Role, denial.Method, denial.Path,
+		denial.Route, denial.Code, denial.Reason, denial.RequestID)
+	if err != nil {
+		return fmt.Errorf("authzDenialRepo.Create: %w", err)
+	}
+	return nil
+}
+
+// buildDenialWhereClause constructs a dynamic WHERE clause for denial queries.
+func buildDenialWhereClause(tenantID uuid.UUID, filters *domain.AuthzDenialFilters) (clause string, args []interface{}) {
+	args = []interface{}{tenantID}
+	clause = "WHERE tenant_id = $1"
+	argN := 2
+
+	if filters.UserID != nil {
+		clause += fmt.Sprintf(" AND user_id = $%d", argN)
+		args = append(args, *filters.UserID)
+		argN++
+	}
+	if filters.Code != "" {
+		clause += fmt.Sprintf(" AND code = $%d", argN)
+		args = append(args, filters.Code)
+		argN++
+	}
+	if filters.From != nil {
+		clause += fmt.Sprintf(" AND created_at >= $%d", argN)
+		args = append(args, *filters.From)
+		argN++
+	}
+	if filters.To != nil {
+		clause += fmt.Sprintf(" AND created_at <= $%d", argN)
+		args = append(args, *filters.To)
+		argN++ //nolint:ineffassign // argN kept incremented for consistency
+	}
+
+	return clause, args
+}
+
+func (r *authzDenialRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, filters *domain.AuthzDenialFilters) ([]domain.AuthzDenial, int, error) {
+	whereClause, args := buildDenialWhereClause(tenantID, filters)
+
+	var total int
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM authz_denials "+whereClause, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("authzDenialRepo.ListByTenant count: %w", err)
+	}
+
+	n := len(args)
+	query := fmt.Sprintf(`SELECT * FROM authz_denials %s
+		 ORDER BY created_at DESC
+		 LIMIT $%d OFFSET $%d`, whereClause, n+1, n+2)
+	args = append(args, filters.Limit, filters.Offset)
+
+	var denials []domain.AuthzDenial
+	if err := r.db.SelectContext(ctx, &denials, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("authzDenialRepo.ListByTenant: %w", err)
+	}
+	return denials, total, nil
+}
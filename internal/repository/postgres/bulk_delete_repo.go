@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type bulkDeleteRepo struct {
+	db *sqlx.DB
+}
+
+// NewBulkDeleteRepo creates a new PostgreSQL-backed BulkDeleteRepository.
+func NewBulkDeleteRepo(db *sqlx.DB) port.BulkDeleteRepository {
+	return &bulkDeleteRepo{db: db}
+}
+
+func (r *bulkDeleteRepo) Create(ctx context.Context, rec *domain.BulkDelete) error {
+	rec.CreatedAt = time.Now().UTC()
+	if rec.Filter == nil {
+		rec.Filter = []byte("{}")
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO bulk_deletes (id, tenant_id, created_by, filter, delete_files, matched_count, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		rec.ID, rec.TenantID, rec.CreatedBy, rec.Filter, rec.DeleteFiles, rec.MatchedCount, rec.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("bulkDeleteRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *bulkDeleteRepo) Finish(ctx context.Context, rec *domain.BulkDelete) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE bulk_deletes SET deleted_count = $1, failed_count = $2, completed_at = $3
+		 WHERE id = $4 AND tenant_id = $5`,
+		rec.DeletedCount, rec.FailedCount, rec.CompletedAt, rec.ID, rec.TenantID)
+	if err != nil {
+		return fmt.Errorf("bulkDeleteRepo.Finish: %w", err)
+	}
+	return nil
+}
+
+func (r *bulkDeleteRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, createdBy *uuid.UUID, offset, limit int) ([]domain.BulkDelete, int, error) {
+	where := "WHERE tenant_id = $1 AND ($2::uuid IS NULL OR created_by = $2)"
+
+	var total int
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM bulk_deletes "+where, tenantID, createdBy)
+	if err != nil {
+		return nil, 0, fmt.Errorf("bulkDeleteRepo.ListByTenant count: %w", err)
+	}
+
+	var recs []domain.BulkDelete
+	err = r.db.SelectContext(ctx, &recs,
+		"SELECT * FROM bulk_deletes "+where+" ORDER BY created_at DESC LIMIT $3 OFFSET $4",
+		tenantID, createdBy, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("bulkDeleteRepo.ListByTenant: %w", err)
+	}
+	return recs, total, nil
+}
+
+func (r *bulkDeleteRepo) MatchDocuments(ctx context.Context, tenantID uuid.UUID, filter *domain.BulkTagFilter, limit int) ([]domain.BulkDeleteTarget, error) {
+	where, args := bulkFilterWhere(tenantID, filter)
+	args = append(args, limit)
+
+	query := fmt.Sprintf(
+		`SELECT d.id AS document_id, d.collection_id, d.file_id, d.name FROM documents d
+		 LEFT JOIN document_summaries s ON s.document_id = d.id
+		 WHERE %s
+		 ORDER BY d.created_at, d.id LIMIT $%d`,
+		where, len(args))
+
+	targets := []domain.BulkDeleteTarget{}
+	if err := r.db.SelectContext(ctx, &targets, query, args...); err != nil {
+		return nil, fmt.Errorf("bulkDeleteRepo.MatchDocuments: %w", err)
+	}
+	return targets, nil
+}
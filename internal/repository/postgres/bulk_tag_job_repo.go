@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type bulkTagJobRepo struct {
+	db *sqlx.DB
+}
+
+// NewBulkTagJobRepo creates a new PostgreSQL-backed BulkTagJobRepository.
+func NewBulkTagJobRepo(db *sqlx.DB) port.BulkTagJobRepository {
+	return &bulkTagJobRepo{db: db}
+}
+
+func (r *bulkTagJobRepo) Create(ctx context.Context, job *domain.BulkTagJob) error {
+	now := time.Now().UTC()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	if job.Filter == nil {
+		job.Filter = []byte("{}")
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO bulk_tag_jobs
+			(id, tenant_id, created_by, action, tag_key, tag_value, filter, status, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		job.ID, job.TenantID, job.CreatedBy, job.Action, job.TagKey, job.TagValue, job.Filter,
+		job.Status, job.CreatedAt, job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("bulkTagJobRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *bulkTagJobRepo) GetByID(ctx context.Context, tenantID, jobID uuid.UUID) (*domain.BulkTagJob, error) {
+	var job domain.BulkTagJob
+	err := r.db.GetContext(ctx, &job,
+		"SELECT * FROM bulk_tag_jobs WHERE id = $1 AND tenant_id = $2", jobID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("bulkTagJobRepo.GetByID: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *bulkTagJobRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, createdBy *uuid.UUID, offset, limit int) ([]domain.BulkTagJob, int, error) {
+	where := "WHERE tenant_id = $1 AND ($2::uuid IS NULL OR created_by = $2)"
+
+	var total int
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM bulk_tag_jobs "+where, tenantID, createdBy)
+	if err != nil {
+		return nil, 0, fmt.Errorf("bulkTagJobRepo.ListByTenant count: %w", err)
+	}
+
+	var jobs []domain.BulkTagJob
+	err = r.db.SelectContext(ctx, &jobs,
+		"SELECT * FROM bulk_tag_jobs "+where+" ORDER BY created_at DESC LIMIT $3 OFFSET $4",
+		tenantID, createdBy, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("bulkTagJobRepo.ListByTenant: %w", err)
+	}
+	return jobs, total, nil
+}
+
+func (r *bulkTagJobRepo) UpdateProgress(ctx context.Context, job *domain.BulkTagJob) error {
+	job.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE bulk_tag_jobs SET
+			status = $1, matched_count = $2, updated_count = $3, skipped_count = $4,
+			failed_count = $5, error = $6, completed_at = $7, updated_at = $8
+		 WHERE id = $9 AND tenant_id = $10`,
+		job.Status, job.MatchedCount, job.UpdatedCount, job.SkippedCount, job.FailedCount,
+		job.Error, job.CompletedAt, job.UpdatedAt, job.ID, job.TenantID)
+	if err != nil {
+		return fmt.Errorf("bulkTagJobRepo.UpdateProgress: %w", err)
+	}
+	return nil
+}
+
+func (r *bulkTagJobRepo) MatchDocuments(ctx context.Context, tenantID uuid.UUID, filter *domain.BulkTagFilter, limit int) ([]domain.BulkTagTarget, error) {
+	where, args := bulkFilterWhere(tenantID, filter)
+	args = append(args, limit)
+
+	query := fmt.Sprintf(
+		`SELECT d.id AS document_id, d.collection_id FROM documents d
+		 LEFT JOIN document_summaries s ON s.document_id = d.id
+		 WHERE %s
+		 ORDER BY d.created_at, d.id LIMIT $%d`,
+		where, len(args))
+
+	var targets []domain.BulkTagTarget
+	if err := r.db.SelectContext(ctx, &targets, query, args...); err != nil {
+		return nil, fmt.Errorf("bulkTagJobRepo.MatchDocuments: %w", err)
+	}
+	return targets, nil
+}
+
+// bulkFilterWhere builds the WHERE clause selecting filter's documents, aliased
+// d, joined to their summaries as s. Bulk deletes match with the same filter.
+func bulkFilterWhere(tenantID uuid.UUID, filter *domain.BulkTagFilter) (string, []interface{}) {
+	conds := []string{"d.tenant_id = $1"}
+	args := []interface{}{tenantID}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.CollectionID != nil {
+		add("d.collection_id = $%d", *filter.CollectionID)
+	}
+	if filter.SellerGSTIN != "" {
+		add("s.seller_gstin = $%d", filter.SellerGSTIN)
+	}
+	if filter.BuyerGSTIN != "" {
+		add("s.buyer_gstin = $%d", filter.BuyerGSTIN)
+	}
+	if filter.From != "" {
+		add("s.invoice_date >= $%d::date", filter.From)
+	}
+	if filter.To != "" {
+		add("s.invoice_date <= $%d::date", filter.To)
+	}
+	if filter.ReviewStatus != "" {
+		add("d.review_status = $%d", filter.ReviewStatus)
+	}
+	if filter.TagKey != "" {
+		args = append(args, filter.TagKey)
+		cond := fmt.Sprintf("EXISTS (SELECT 1 FROM document_tags t WHERE t.document_id = d.id AND t.key = $%d", len(args))
+		if filter.TagValue != "" {
+			args = append(args, filter.TagValue)
+			cond += fmt.Sprintf(" AND t.value = $%d", len(args))
+		}
+		conds = append(conds, cond+")")
+	}
+	return strings.Join(conds, " AND "), args
+}
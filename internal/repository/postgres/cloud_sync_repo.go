@@ -0,0 +1,269 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type cloudConnectionRepo struct {
+	db *sqlx.DB
+}
+
+// NewCloudConnectionRepo creates a new PostgreSQL-backed CloudConnectionRepository.
+func NewCloudConnectionRepo(db *sqlx.DB) port.CloudConnectionRepository {
+	return &cloudConnectionRepo{db: db}
+}
+
+func (r *cloudConnectionRepo) Upsert(ctx context.Context, conn *domain.CloudConnection) error {
+	if conn.ID == uuid.Nil {
+		conn.ID = uuid.New()
+	}
+	// Providers only issue a refresh token on first consent; keep the stored one when absent.
+	err := r.db.QueryRowxContext(ctx,
+		`INSERT INTO cloud_connections
+			(id, tenant_id, user_id, provider, account_id, access_token, refresh_token, token_expiry)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (tenant_id, user_id, provider, account_id) DO UPDATE
+		 SET access_token = EXCLUDED.access_token,
+		     refresh_token = COALESCE(NULLIF(EXCLUDED.refresh_token, ''), cloud_connections.refresh_token),
+		     token_expiry = EXCLUDED.token_expiry,
+		     updated_at = NOW()
+		 RETURNING id, created_at, updated_at`,
+		conn.ID, conn.TenantID, conn.UserID, conn.Provider, conn.AccountID,
+		conn.AccessToken, conn.RefreshToken, conn.TokenExpiry).
+		Scan(&conn.ID, &conn.CreatedAt, &conn.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("cloudConnectionRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *cloudConnectionRepo) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.CloudConnection, error) {
+	var conn domain.CloudConnection
+	err := r.db.GetContext(ctx, &conn,
+		"SELECT * FROM cloud_connections WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("cloudConnectionRepo.GetByID: %w", err)
+	}
+	return &conn, nil
+}
+
+func (r *cloudConnectionRepo) ListByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.CloudConnection, error) {
+	var conns []domain.CloudConnection
+	err := r.db.SelectContext(ctx, &conns,
+		`SELECT * FROM cloud_connections WHERE tenant_id = $1 AND user_id = $2
+		 ORDER BY created_at`, tenantID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("cloudConnectionRepo.ListByUser: %w", err)
+	}
+	return conns, nil
+}
+
+func (r *cloudConnectionRepo) UpdateTokens(ctx context.Context, conn *domain.CloudConnection) error {
+	conn.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE cloud_connections SET access_token = $1, refresh_token = $2, token_expiry = $3, updated_at = $4
+		 WHERE id = $5 AND tenant_id = $6`,
+		conn.AccessToken, conn.RefreshToken, conn.TokenExpiry, conn.UpdatedAt, conn.ID, conn.TenantID)
+	if err != nil {
+		return fmt.Errorf("cloudConnectionRepo.UpdateTokens: %w", err)
+	}
+	return nil
+}
+
+func (r *cloudConnectionRepo) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM cloud_connections WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return fmt.Errorf("cloudConnectionRepo.Delete: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+type cloudSyncRepo struct {
+	db *sqlx.DB
+}
+
+// NewCloudSyncRepo creates a new PostgreSQL-backed CloudSyncRepository.
+func NewCloudSyncRepo(db *sqlx.DB) port.CloudSyncRepository {
+	return &cloudSyncRepo{db: db}
+}
+
+func (r *cloudSyncRepo) Create(ctx context.Context, sync *domain.CloudSync) error {
+	if sync.ID == uuid.Nil {
+		sync.ID = uuid.New()
+	}
+	now := time.Now().UTC()
+	sync.CreatedAt = now
+	sync.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO cloud_syncs
+			(id, tenant_id, collection_id, connection_id, provider, folder_id, folder_name,
+			 document_type, parse_mode, poll_enabled, created_by, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		sync.ID, sync.TenantID, sync.CollectionID, sync.ConnectionID, sync.Provider, sync.FolderID,
+		sync.FolderName, sync.DocumentType, sync.ParseMode, sync.PollEnabled, sync.CreatedBy,
+		sync.CreatedAt, sync.UpdatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return domain.ErrDuplicateCloudSync
+		}
+		return fmt.Errorf("cloudSyncRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *cloudSyncRepo) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.CloudSync, error) {
+	var sync domain.CloudSync
+	err := r.db.GetContext(ctx, &sync,
+		"SELECT * FROM cloud_syncs WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("cloudSyncRepo.GetByID: %w", err)
+	}
+	return &sync, nil
+}
+
+func (r *cloudSyncRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID) ([]domain.CloudSync, error) {
+	var syncs []domain.CloudSync
+	err := r.db.SelectContext(ctx, &syncs,
+		`SELECT * FROM cloud_syncs WHERE tenant_id = $1 AND collection_id = $2
+		 ORDER BY created_at DESC`, tenantID, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("cloudSyncRepo.ListByCollection: %w", err)
+	}
+	return syncs, nil
+}
+
+func (r *cloudSyncRepo) ClaimDueForPoll(ctx context.Context, cutoff time.Time, limit int) ([]domain.CloudSync, error) {
+	var syncs []domain.CloudSync
+	err := r.db.SelectContext(ctx, &syncs,
+		`UPDATE cloud_syncs SET last_synced_at = NOW()
+		 WHERE id IN (
+			SELECT id FROM cloud_syncs
+			WHERE poll_enabled AND (last_synced_at IS NULL OR last_synced_at < $1)
+			ORDER BY last_synced_at NULLS FIRST
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING *`, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("cloudSyncRepo.ClaimDueForPoll: %w", err)
+	}
+	return syncs, nil
+}
+
+func (r *cloudSyncRepo) Update(ctx context.Context, sync *domain.CloudSync) error {
+	sync.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE cloud_syncs SET poll_enabled = $1, last_synced_at = $2, last_error = $3, updated_at = $4
+		 WHERE id = $5 AND tenant_id = $6`,
+		sync.PollEnabled, sync.LastSyncedAt, sync.LastError, sync.UpdatedAt, sync.ID, sync.TenantID)
+	if err != nil {
+		return fmt.Errorf("cloudSyncRepo.Update: %w", err)
+	}
+	return nil
+}
+
+func (r *cloudSyncRepo) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM cloud_syncs WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return fmt.Errorf("cloudSyncRepo.Delete: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *cloudSyncRepo) RecordFile(ctx context.Context, file *domain.CloudSyncFile) error {
+	if file.ID == uuid.Nil {
+		file.ID = uuid.New()
+	}
+	file.CreatedAt = time.Now().UTC()
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO cloud_sync_files
+			(id, tenant_id, sync_id, collection_id, external_id, name, content_hash,
+			 status, reason, file_id, document_id, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		 ON CONFLICT (sync_id, external_id) DO UPDATE
+		 SET name = EXCLUDED.name, content_hash = EXCLUDED.content_hash, status = EXCLUDED.status,
+		     reason = EXCLUDED.reason, file_id = EXCLUDED.file_id, document_id = EXCLUDED.document_id,
+		     created_at = EXCLUDED.created_at`,
+		file.ID, file.TenantID, file.SyncID, file.CollectionID, file.ExternalID, file.Name,
+		file.ContentHash, file.Status, file.Reason, file.FileID, file.DocumentID, file.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("cloudSyncRepo.RecordFile: %w", err)
+	}
+	return nil
+}
+
+func (r *cloudSyncRepo) ListFiles(ctx context.Context, tenantID, syncID uuid.UUID, offset, limit int) ([]domain.CloudSyncFile, int, error) {
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM cloud_sync_files WHERE tenant_id = $1 AND sync_id = $2",
+		tenantID, syncID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cloudSyncRepo.ListFiles count: %w", err)
+	}
+
+	var files []domain.CloudSyncFile
+	err = r.db.SelectContext(ctx, &files,
+		`SELECT * FROM cloud_sync_files
+		 WHERE tenant_id = $1 AND sync_id = $2
+		 ORDER BY created_at DESC LIMIT $3 OFFSET $4`,
+		tenantID, syncID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cloudSyncRepo.ListFiles: %w", err)
+	}
+	return files, total, nil
+}
+
+func (r *cloudSyncRepo) SeenExternalIDs(ctx context.Context, syncID uuid.UUID) (map[string]bool, error) {
+	var ids []string
+	err := r.db.SelectContext(ctx, &ids,
+		"SELECT external_id FROM cloud_sync_files WHERE sync_id = $1 AND status <> 'failed'", syncID)
+	if err != nil {
+		return nil, fmt.Errorf("cloudSyncRepo.SeenExternalIDs: %w", err)
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	return seen, nil
+}
+
+func (r *cloudSyncRepo) HashImported(ctx context.Context, collectionID uuid.UUID, contentHash string) (bool, error) {
+	var exists bool
+	err := r.db.GetContext(ctx, &exists,
+		`SELECT EXISTS (SELECT 1 FROM cloud_sync_files
+		 WHERE collection_id = $1 AND content_hash = $2 AND status = 'imported')`,
+		collectionID, contentHash)
+	if err != nil {
+		return false, fmt.Errorf("cloudSyncRepo.HashImported: %w", err)
+	}
+	return exists, nil
+}
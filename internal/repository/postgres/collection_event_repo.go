@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type collectionEventRepo struct {
+	db *sqlx.DB
+}
+
+// NewCollectionEventRepo creates a new PostgreSQL-backed CollectionEventRepository.
+func NewCollectionEventRepo(db *sqlx.DB) port.CollectionEventRepository {
+	return &collectionEventRepo{db: db}
+}
+
+func (r *collectionEventRepo) Create(ctx context.Context, event *domain.CollectionEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if len(event.Changes) == 0 {
+		event.Changes = json.RawMessage("{}")
+	}
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO collection_events (id, tenant_id, collection_id, user_id, action, target_user_id, changes)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		event.ID, event.TenantID, event.CollectionID, event.UserID, event.Action, event.TargetUserID, event.Changes)
+	if err != nil {
+		return fmt.Errorf("collectionEventRepo.Create: %w", err)
+	}
+	return nil
+}
+
+// collectionActivitySQL unions the three sources of a collection's activity.
+// $1 tenant, $2 collection, $3 document audit actions to include.
+const collectionActivitySQL = `
+	SELECT $4::text AS type, cf.added_by AS user_id, NULL::uuid AS document_id, '' AS document_name,
+	       cf.file_id AS file_id, NULL::uuid AS target_user_id,
+	       jsonb_build_object('file_name', fm.original_name) AS details, cf.added_at AS created_at
+	  FROM collection_files cf
+	  JOIN file_metadata fm ON fm.id = cf.file_id
+	 WHERE cf.tenant_id = $1 AND cf.collection_id = $2
+	UNION ALL
+	SELECT a.action, a.user_id, a.document_id, d.name, NULL::uuid, NULL::uuid, a.changes, a.created_at
+	  FROM document_audit_log a
+	  JOIN documents d ON d.id = a.document_id AND d.tenant_id = a.tenant_id
+	 WHERE a.tenant_id = $1 AND d.collection_id = $2 AND a.action = ANY($3)
+	UNION ALL
+	SELECT e.action, e.user_id, NULL::uuid, '', NULL::uuid, e.target_user_id, e.changes, e.created_at
+	  FROM collection_events e
+	 WHERE e.tenant_id = $1 AND e.collection_id = $2`
+
+func (r *collectionEventRepo) ListActivity(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.CollectionActivityEvent, int, error) {
+	actions := make([]string, len(domain.CollectionActivityDocumentActions))
+	for i, a := range domain.CollectionActivityDocumentActions {
+		actions[i] = string(a)
+	}
+	args := []interface{}{tenantID, collectionID, pq.Array(actions), domain.ActivityFileUploaded}
+
+	var total int
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM ("+collectionActivitySQL+") activity", args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("collectionEventRepo.ListActivity count: %w", err)
+	}
+
+	query := `SELECT activity.*, COALESCE(u.full_name, '') AS user_name
+		 FROM (` + collectionActivitySQL + `) activity
+		 LEFT JOIN users u ON u.id = activity.user_id
+		 ORDER BY activity.created_at DESC
+		 LIMIT $5 OFFSET $6`
+	args = append(args, limit, offset)
+
+	var activity []domain.CollectionActivityEvent
+	if err := r.db.SelectContext(ctx, &activity, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("collectionEventRepo.ListActivity: %w", err)
+	}
+	return activity, total, nil
+}
@@ -86,6 +86,25 @@ func (r *collectionPermissionRepo) GetByUserForCollections(ctx context.Context,
 	return result, nil
 }
 
+func (r *collectionPermissionRepo) ListByUser(ctx context.Context, tenantID, userID uuid.UUID) (map[uuid.UUID]domain.CollectionPermission, error) {
+	var rows []struct {
+		CollectionID uuid.UUID                   `db:"collection_id"`
+		Permission   domain.CollectionPermission `db:"permission"`
+	}
+	err := r.db.SelectContext(ctx, &rows,
+		"SELECT collection_id, permission FROM collection_permissions WHERE tenant_id = $1 AND user_id = $2",
+		tenantID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("collectionPermissionRepo.ListByUser: %w", err)
+	}
+
+	result := make(map[uuid.UUID]domain.CollectionPermission, len(rows))
+	for i := range rows {
+		result[rows[i].CollectionID] = rows[i].Permission
+	}
+	return result, nil
+}
+
 func (r *collectionPermissionRepo) ListByCollection(ctx context.Context, collectionID uuid.UUID, offset, limit int) ([]domain.CollectionPermissionEntry, int, error) {
 	var total int
 	err := r.db.GetContext(ctx, &total,
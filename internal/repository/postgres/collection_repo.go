@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -28,11 +29,23 @@ func (r *collectionRepo) Create(ctx context.Context, c *domain.Collection) error
 	c.CreatedAt = now
 	c.UpdatedAt = now
 
-	query := `INSERT INTO collections (id, tenant_id, name, description, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	if c.ReviewChecklist == nil {
+		c.ReviewChecklist = json.RawMessage("[]")
+	}
+	if c.EscalationAction == "" {
+		c.EscalationAction = domain.EscalationActionFlag
+	}
+	if c.KPITargets == nil {
+		c.KPITargets = json.RawMessage("[]")
+	}
+
+	query := `INSERT INTO collections (id, tenant_id, name, description, review_checklist, is_demo,
+		escalate_after_days, escalation_action, kpi_targets, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 
 	_, err := r.db.ExecContext(ctx, query,
-		c.ID, c.TenantID, c.Name, c.Description, c.CreatedBy, c.CreatedAt, c.UpdatedAt)
+		c.ID, c.TenantID, c.Name, c.Description, c.ReviewChecklist, c.IsDemo,
+		c.EscalateAfterDays, c.EscalationAction, c.KPITargets, c.CreatedBy, c.CreatedAt, c.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("collectionRepo.Create: %w", err)
 	}
@@ -114,6 +127,220 @@ func (r *collectionRepo) Update(ctx context.Context, c *domain.Collection) error
 	return nil
 }
 
+func (r *collectionRepo) UpdateReviewChecklist(ctx context.Context, c *domain.Collection) error {
+	c.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE collections SET review_checklist = $1, updated_at = $2
+		 WHERE id = $3 AND tenant_id = $4`,
+		c.ReviewChecklist, c.UpdatedAt, c.ID, c.TenantID)
+	if err != nil {
+		return fmt.Errorf("collectionRepo.UpdateReviewChecklist: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) UpdateEscalationPolicy(ctx context.Context, c *domain.Collection) error {
+	c.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE collections SET escalate_after_days = $1, escalation_action = $2, updated_at = $3
+		 WHERE id = $4 AND tenant_id = $5`,
+		c.EscalateAfterDays, c.EscalationAction, c.UpdatedAt, c.ID, c.TenantID)
+	if err != nil {
+		return fmt.Errorf("collectionRepo.UpdateEscalationPolicy: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) UpdateKPITargets(ctx context.Context, c *domain.Collection) error {
+	c.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE collections SET kpi_targets = $1, updated_at = $2
+		 WHERE id = $3 AND tenant_id = $4`,
+		c.KPITargets, c.UpdatedAt, c.ID, c.TenantID)
+	if err != nil {
+		return fmt.Errorf("collectionRepo.UpdateKPITargets: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) UpdateQASampling(ctx context.Context, c *domain.Collection) error {
+	c.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE collections SET qa_sample_percent = $1, updated_at = $2
+		 WHERE id = $3 AND tenant_id = $4`,
+		c.QASamplePercent, c.UpdatedAt, c.ID, c.TenantID)
+	if err != nil {
+		return fmt.Errorf("collectionRepo.UpdateQASampling: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) UpdateDefaultParseMode(ctx context.Context, c *domain.Collection) error {
+	c.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE collections SET default_parse_mode = $1, updated_at = $2
+		 WHERE id = $3 AND tenant_id = $4`,
+		c.DefaultParseMode, c.UpdatedAt, c.ID, c.TenantID)
+	if err != nil {
+		return fmt.Errorf("collectionRepo.UpdateDefaultParseMode: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) UpdateNamingTemplate(ctx context.Context, c *domain.Collection) error {
+	c.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE collections SET naming_template = $1, updated_at = $2
+		 WHERE id = $3 AND tenant_id = $4`,
+		c.NamingTemplate, c.UpdatedAt, c.ID, c.TenantID)
+	if err != nil {
+		return fmt.Errorf("collectionRepo.UpdateNamingTemplate: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) UpdateFileDownloadPerm(ctx context.Context, c *domain.Collection) error {
+	c.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE collections SET file_download_perm = $1, updated_at = $2
+		 WHERE id = $3 AND tenant_id = $4`,
+		c.FileDownloadPerm, c.UpdatedAt, c.ID, c.TenantID)
+	if err != nil {
+		return fmt.Errorf("collectionRepo.UpdateFileDownloadPerm: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) FileDownloadPerms(ctx context.Context, tenantID, fileID uuid.UUID) (map[uuid.UUID]domain.CollectionPermission, error) {
+	perms, err := r.selectFileDownloadPerms(ctx,
+		`SELECT c.id, c.file_download_perm FROM collections c
+		 WHERE c.tenant_id = $1 AND c.file_download_perm IS NOT NULL
+		   AND (EXISTS (SELECT 1 FROM collection_files cf WHERE cf.collection_id = c.id AND cf.file_id = $2)
+		     OR EXISTS (SELECT 1 FROM documents d WHERE d.collection_id = c.id AND d.file_id = $2))`,
+		tenantID, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("collectionRepo.FileDownloadPerms: %w", err)
+	}
+	return perms, nil
+}
+
+func (r *collectionRepo) ListFileDownloadPerms(ctx context.Context, tenantID uuid.UUID) (map[uuid.UUID]domain.CollectionPermission, error) {
+	perms, err := r.selectFileDownloadPerms(ctx,
+		`SELECT id, file_download_perm FROM collections
+		 WHERE tenant_id = $1 AND file_download_perm IS NOT NULL`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("collectionRepo.ListFileDownloadPerms: %w", err)
+	}
+	return perms, nil
+}
+
+// selectFileDownloadPerms runs a query returning (id, file_download_perm) rows.
+func (r *collectionRepo) selectFileDownloadPerms(ctx context.Context, query string, args ...interface{}) (map[uuid.UUID]domain.CollectionPermission, error) {
+	var rows []struct {
+		CollectionID uuid.UUID                   `db:"id"`
+		Permission   domain.CollectionPermission `db:"file_download_perm"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	perms := make(map[uuid.UUID]domain.CollectionPermission, len(rows))
+	for _, row := range rows {
+		perms[row.CollectionID] = row.Permission
+	}
+	return perms, nil
+}
+
+func (r *collectionRepo) DefaultParseMode(ctx context.Context, tenantID, collectionID uuid.UUID) (domain.ParseMode, error) {
+	var mode domain.ParseMode
+	err := r.db.GetContext(ctx, &mode,
+		`SELECT COALESCE(c.default_parse_mode, t.default_parse_mode, '')
+		 FROM collections c JOIN tenants t ON t.id = c.tenant_id
+		 WHERE c.id = $1 AND c.tenant_id = $2`, collectionID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrCollectionNotFound
+		}
+		return "", fmt.Errorf("collectionRepo.DefaultParseMode: %w", err)
+	}
+	return mode, nil
+}
+
+func (r *collectionRepo) TimeZone(ctx context.Context, tenantID, collectionID uuid.UUID) (string, error) {
+	var tz string
+	err := r.db.GetContext(ctx, &tz,
+		`SELECT t.time_zone FROM collections c JOIN tenants t ON t.id = c.tenant_id
+		 WHERE c.id = $1 AND c.tenant_id = $2`, collectionID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrCollectionNotFound
+		}
+		return "", fmt.Errorf("collectionRepo.TimeZone: %w", err)
+	}
+	return tz, nil
+}
+
+func (r *collectionRepo) ProgressCounts(ctx context.Context, tenantID, collectionID uuid.UUID, since time.Time) (*domain.CollectionProgressCounts, error) {
+	var counts domain.CollectionProgressCounts
+	err := r.db.GetContext(ctx, &counts,
+		`SELECT COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE parsing_status = 'completed') AS parsed,
+			COUNT(*) FILTER (WHERE review_status IN ('approved', 'rejected')) AS reviewed,
+			COUNT(*) FILTER (WHERE review_status = 'approved') AS approved,
+			COUNT(*) FILTER (WHERE parsing_status = 'completed' AND parsed_at >= $3) AS parsed_recent,
+			COUNT(*) FILTER (WHERE review_status IN ('approved', 'rejected') AND reviewed_at >= $3) AS reviewed_recent,
+			COUNT(*) FILTER (WHERE review_status = 'approved' AND reviewed_at >= $3) AS approved_recent
+		 FROM documents WHERE tenant_id = $1 AND collection_id = $2`,
+		tenantID, collectionID, since)
+	if err != nil {
+		return nil, fmt.Errorf("collectionRepo.ProgressCounts: %w", err)
+	}
+	return &counts, nil
+}
+
+func (r *collectionRepo) UpdateCheckerThreshold(ctx context.Context, c *domain.Collection) error {
+	c.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE collections SET checker_threshold = $1, updated_at = $2
+		 WHERE id = $3 AND tenant_id = $4`,
+		c.CheckerThreshold, c.UpdatedAt, c.ID, c.TenantID)
+	if err != nil {
+		return fmt.Errorf("collectionRepo.UpdateCheckerThreshold: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrCollectionNotFound
+	}
+	return nil
+}
+
 func (r *collectionRepo) Delete(ctx context.Context, tenantID, collectionID uuid.UUID) error {
 	result, err := r.db.ExecContext(ctx,
 		"DELETE FROM collections WHERE id = $1 AND tenant_id = $2",
@@ -127,3 +354,15 @@ func (r *collectionRepo) Delete(ctx context.Context, tenantID, collectionID uuid
 	}
 	return nil
 }
+
+func (r *collectionRepo) ListDemo(ctx context.Context, tenantID uuid.UUID) ([]domain.Collection, error) {
+	var collections []domain.Collection
+	err := r.db.SelectContext(ctx, &collections,
+		`SELECT c.*, (SELECT COUNT(*) FROM documents d WHERE d.collection_id = c.id) AS document_count
+		 FROM collections c WHERE c.tenant_id = $1 AND c.is_demo
+		 ORDER BY c.created_at`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("collectionRepo.ListDemo: %w", err)
+	}
+	return collections, nil
+}
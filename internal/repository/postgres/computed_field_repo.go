@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type computedFieldRepo struct {
+	db *sqlx.DB
+}
+
+// NewComputedFieldRepo creates a new PostgreSQL-backed ComputedFieldRepository.
+func NewComputedFieldRepo(db *sqlx.DB) port.ComputedFieldRepository {
+	return &computedFieldRepo{db: db}
+}
+
+func (r *computedFieldRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.ComputedField, error) {
+	fields := []domain.ComputedField{}
+	err := r.db.SelectContext(ctx, &fields,
+		`SELECT * FROM computed_fields WHERE tenant_id = $1 ORDER BY name`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("computedFieldRepo.ListByTenant: %w", err)
+	}
+	return fields, nil
+}
+
+func (r *computedFieldRepo) Upsert(ctx context.Context, field *domain.ComputedField) error {
+	err := r.db.QueryRowxContext(ctx,
+		`INSERT INTO computed_fields (tenant_id, name, document_type, expression, description, updated_by)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (tenant_id, name) DO UPDATE
+		 SET document_type = EXCLUDED.document_type, expression = EXCLUDED.expression,
+		     description = EXCLUDED.description, updated_by = EXCLUDED.updated_by, updated_at = NOW()
+		 RETURNING created_at, updated_at`,
+		field.TenantID, field.Name, field.DocumentType, field.Expression, field.Description, field.UpdatedBy).
+		Scan(&field.CreatedAt, &field.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("computedFieldRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *computedFieldRepo) Delete(ctx context.Context, tenantID uuid.UUID, name string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM computed_fields WHERE tenant_id = $1 AND name = $2`, tenantID, name)
+	if err != nil {
+		return fmt.Errorf("computedFieldRepo.Delete: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
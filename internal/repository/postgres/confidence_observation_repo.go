@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type confidenceObservationRepo struct {
+	db *sqlx.DB
+}
+
+// NewConfidenceObservationRepo creates a new PostgreSQL-backed ConfidenceObservationRepository.
+func NewConfidenceObservationRepo(db *sqlx.DB) port.ConfidenceObservationRepository {
+	return &confidenceObservationRepo{db: db}
+}
+
+func (r *confidenceObservationRepo) Record(ctx context.Context, observations []domain.ConfidenceObservation) error {
+	if len(observations) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("confidenceObservationRepo.Record begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	for i := range observations {
+		o := &observations[i]
+		if o.ID == uuid.Nil {
+			o.ID = uuid.New()
+		}
+		o.CreatedAt = now
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO confidence_observations (id, tenant_id, document_id, parser_model, document_type, field_path, confidence, corrected, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			 ON CONFLICT (document_id, field_path)
+			 DO UPDATE SET corrected = confidence_observations.corrected OR EXCLUDED.corrected`,
+			o.ID, o.TenantID, o.DocumentID, o.ParserModel, o.DocumentType, o.FieldPath, o.Confidence, o.Corrected, o.CreatedAt); err != nil {
+			return fmt.Errorf("confidenceObservationRepo.Record %s: %w", o.FieldPath, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("confidenceObservationRepo.Record commit: %w", err)
+	}
+	return nil
+}
+
+func (r *confidenceObservationRepo) MarkCorrected(ctx context.Context, tenantID, documentID uuid.UUID, fieldPaths []string) error {
+	if len(fieldPaths) == 0 {
+		return nil
+	}
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE confidence_observations SET corrected = TRUE
+		 WHERE tenant_id = $1 AND document_id = $2
+		   AND EXISTS (SELECT 1 FROM unnest($3::text[]) AS p(path)
+		               WHERE field_path = p.path
+		                  OR starts_with(field_path, p.path || '.')
+		                  OR starts_with(field_path, p.path || '['))`,
+		tenantID, documentID, pq.Array(fieldPaths))
+	if err != nil {
+		return fmt.Errorf("confidenceObservationRepo.MarkCorrected: %w", err)
+	}
+	return nil
+}
+
+// A confidence of exactly 1.0 falls in the top bucket rather than an eleventh one.
+// Observations recorded before a parser model was known are grouped under "unknown".
+const bucketsByModelQuery = `SELECT
+	COALESCE(NULLIF(parser_model, ''), 'unknown') AS parser_model,
+	LEAST(FLOOR(confidence * 10), 9)::int AS bucket,
+	COUNT(*) AS fields,
+	COUNT(CASE WHEN corrected THEN 1 END) AS corrected,
+	AVG(confidence) AS mean_confidence
+FROM confidence_observations
+WHERE tenant_id = $1 AND created_at >= $2 AND ($3 = '' OR document_type = $3)
+GROUP BY 1, 2
+ORDER BY 1, 2`
+
+func (r *confidenceObservationRepo) BucketsByModel(ctx context.Context, tenantID uuid.UUID, documentType string, since time.Time) ([]domain.CalibrationBucketStats, error) {
+	stats := []domain.CalibrationBucketStats{}
+	if err := r.db.SelectContext(ctx, &stats, bucketsByModelQuery, tenantID, since, documentType); err != nil {
+		return nil, fmt.Errorf("confidenceObservationRepo.BucketsByModel: %w", err)
+	}
+	return stats, nil
+}
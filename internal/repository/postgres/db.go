@@ -19,3 +19,36 @@ func NewDB(cfg *config.DBConfig) (*sqlx.DB, error) {
 	db.SetMaxIdleConns(cfg.MaxIdle)
 	return db, nil
 }
+
+// OpenDSN creates a connection pool for another database cluster, such as a
+// tenant move target. It connects lazily, so an unreachable cluster does not
+// stop the server from starting.
+func OpenDSN(dsn string, maxOpen, maxIdle int) (*sqlx.DB, error) {
+	db, err := sqlx.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening postgres: %w", err)
+	}
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	return db, nil
+}
+
+// OpenShards opens a connection pool of maxOpen/maxIdle connections for each
+// shard DSN, keyed by shard name. closeAll releases them.
+func OpenShards(dsns map[string]string, maxOpen, maxIdle int) (pools map[string]*sqlx.DB, closeAll func(), err error) {
+	pools = make(map[string]*sqlx.DB, len(dsns))
+	closeAll = func() {
+		for _, db := range pools {
+			_ = db.Close()
+		}
+	}
+	for shard, dsn := range dsns {
+		db, err := OpenDSN(dsn, maxOpen, maxIdle)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("shard %s: %w", shard, err)
+		}
+		pools[shard] = db
+	}
+	return pools, closeAll, nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -52,3 +53,72 @@ func (r *documentAuditRepo) ListByDocument(ctx context.Context, tenantID, docume
 	}
 	return entries, total, nil
 }
+
+// buildAuditWhereClause constructs a dynamic WHERE clause for tenant-wide audit queries.
+// The collection filter goes through the documents table, so entries for deleted
+// documents are excluded when it is set.
+func buildAuditWhereClause(tenantID uuid.UUID, filters *domain.AuditFilters) (clause string, args []interface{}) {
+	args = []interface{}{tenantID}
+	clause = "WHERE a.tenant_id = $1"
+	argN := 2
+
+	if len(filters.Actions) > 0 {
+		placeholders := make([]string, len(filters.Actions))
+		for i, action := range filters.Actions {
+			placeholders[i] = fmt.Sprintf("$%d", argN)
+			args = append(args, action)
+			argN++
+		}
+		clause += " AND a.action IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+	if filters.UserID != nil {
+		clause += fmt.Sprintf(" AND a.user_id = $%d", argN)
+		args = append(args, *filters.UserID)
+		argN++
+	}
+	if filters.DocumentID != nil {
+		clause += fmt.Sprintf(" AND a.document_id = $%d", argN)
+		args = append(args, *filters.DocumentID)
+		argN++
+	}
+	if filters.CollectionID != nil {
+		clause += fmt.Sprintf(" AND a.document_id IN (SELECT id FROM documents WHERE tenant_id = $1 AND collection_id = $%d)", argN)
+		args = append(args, *filters.CollectionID)
+		argN++
+	}
+	if filters.From != nil {
+		clause += fmt.Sprintf(" AND a.created_at >= $%d", argN)
+		args = append(args, *filters.From)
+		argN++
+	}
+	if filters.To != nil {
+		clause += fmt.Sprintf(" AND a.created_at <= $%d", argN)
+		args = append(args, *filters.To)
+		argN++ //nolint:ineffassign // argN kept incremented for consistency
+	}
+
+	return clause, args
+}
+
+func (r *documentAuditRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, filters *domain.AuditFilters) ([]domain.DocumentAuditEntry, int, error) {
+	whereClause, args := buildAuditWhereClause(tenantID, filters)
+
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM document_audit_log a "+whereClause, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("documentAuditRepo.ListByTenant count: %w", err)
+	}
+
+	n := len(args)
+	query := fmt.Sprintf(`SELECT a.* FROM document_audit_log a %s
+		 ORDER BY a.created_at DESC
+		 LIMIT $%d OFFSET $%d`, whereClause, n+1, n+2)
+	args = append(args, filters.Limit, filters.Offset)
+
+	var entries []domain.DocumentAuditEntry
+	if err := r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("documentAuditRepo.ListByTenant: %w", err)
+	}
+	return entries, total, nil
+}
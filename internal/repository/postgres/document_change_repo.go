@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type documentChangeRepo struct {
+	db *sqlx.DB
+}
+
+// NewDocumentChangeRepo creates a new PostgreSQL-backed DocumentChangeRepository.
+func NewDocumentChangeRepo(db *sqlx.DB) port.DocumentChangeRepository {
+	return &documentChangeRepo{db: db}
+}
+
+func (r *documentChangeRepo) ListSince(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID,
+	afterAt time.Time, afterID uuid.UUID, settle time.Duration, limit int) ([]domain.DocumentChange, error) {
+	where := "FROM document_changes c WHERE c.tenant_id = $1"
+	args := []interface{}{tenantID}
+	if explicitOnly {
+		// explicitPermCond reads the user from $2
+		where += fmt.Sprintf(explicitPermCond, "c.collection_id")
+		args = append(args, userID)
+	}
+	if collectionID != nil {
+		args = append(args, *collectionID)
+		where += fmt.Sprintf(" AND c.collection_id = $%d", len(args))
+	}
+	args = append(args, afterAt, afterID)
+	where += fmt.Sprintf(" AND (c.changed_at, c.document_id) > ($%d, $%d)", len(args)-1, len(args))
+	args = append(args, settle.Seconds())
+	where += fmt.Sprintf(" AND c.changed_at < clock_timestamp() - make_interval(secs => $%d)", len(args))
+	args = append(args, limit)
+
+	changes := []domain.DocumentChange{}
+	err := r.db.SelectContext(ctx, &changes,
+		"SELECT c.* "+where+fmt.Sprintf(" ORDER BY c.changed_at, c.document_id LIMIT $%d", len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("documentChangeRepo.ListSince: %w", err)
+	}
+	return changes, nil
+}
+
+func (r *documentChangeRepo) ListDocuments(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]domain.Document, error) {
+	result := make(map[uuid.UUID]domain.Document, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM documents WHERE tenant_id = ? AND id IN (?)", tenantID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("documentChangeRepo.ListDocuments: building query: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	var docs []domain.Document
+	if err := r.db.SelectContext(ctx, &docs, query, args...); err != nil {
+		return nil, fmt.Errorf("documentChangeRepo.ListDocuments: %w", err)
+	}
+	for i := range docs {
+		result[docs[i].ID] = docs[i]
+	}
+	return result, nil
+}
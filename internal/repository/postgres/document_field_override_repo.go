@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type documentFieldOverrideRepo struct {
+	db *sqlx.DB
+}
+
+// NewDocumentFieldOverrideRepo creates a new PostgreSQL-backed DocumentFieldOverrideRepository.
+func NewDocumentFieldOverrideRepo(db *sqlx.DB) port.DocumentFieldOverrideRepository {
+	return &documentFieldOverrideRepo{db: db}
+}
+
+func (r *documentFieldOverrideRepo) Upsert(ctx context.Context, override *domain.DocumentFieldOverride) error {
+	now := time.Now().UTC()
+	override.UpdatedAt = now
+	err := r.db.GetContext(ctx, override,
+		`INSERT INTO document_field_overrides (id, tenant_id, document_id, field_path, value, created_by, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		 ON CONFLICT (document_id, field_path) DO UPDATE SET
+			value = EXCLUDED.value,
+			created_by = EXCLUDED.created_by,
+			updated_at = EXCLUDED.updated_at
+		 RETURNING *`,
+		override.ID, override.TenantID, override.DocumentID, override.FieldPath, override.Value, override.CreatedBy, now)
+	if err != nil {
+		return fmt.Errorf("documentFieldOverrideRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *documentFieldOverrideRepo) ListByDocument(ctx context.Context, tenantID, documentID uuid.UUID) ([]domain.DocumentFieldOverride, error) {
+	var overrides []domain.DocumentFieldOverride
+	err := r.db.SelectContext(ctx, &overrides,
+		`SELECT * FROM document_field_overrides
+		 WHERE tenant_id = $1 AND document_id = $2
+		 ORDER BY field_path`,
+		tenantID, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("documentFieldOverrideRepo.ListByDocument: %w", err)
+	}
+	return overrides, nil
+}
+
+func (r *documentFieldOverrideRepo) DeleteByPath(ctx context.Context, tenantID, documentID uuid.UUID, fieldPath string) error {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM document_field_overrides WHERE tenant_id = $1 AND document_id = $2 AND field_path = $3",
+		tenantID, documentID, fieldPath)
+	if err != nil {
+		return fmt.Errorf("documentFieldOverrideRepo.DeleteByPath: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *documentFieldOverrideRepo) DeleteByDocument(ctx context.Context, tenantID, documentID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		"DELETE FROM document_field_overrides WHERE tenant_id = $1 AND document_id = $2",
+		tenantID, documentID)
+	if err != nil {
+		return fmt.Errorf("documentFieldOverrideRepo.DeleteByDocument: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type documentLockRepo struct {
+	db *sqlx.DB
+}
+
+// NewDocumentLockRepo creates a new PostgreSQL-backed DocumentLockRepository.
+func NewDocumentLockRepo(db *sqlx.DB) port.DocumentLockRepository {
+	return &documentLockRepo{db: db}
+}
+
+// The upsert only overwrites a row the caller holds or that has expired; a
+// renewal keeps the original acquired_at.
+func (r *documentLockRepo) Acquire(ctx context.Context, lock *domain.DocumentLock) (*domain.DocumentLock, error) {
+	var held domain.DocumentLock
+	err := r.db.GetContext(ctx, &held,
+		`INSERT INTO document_locks (document_id, tenant_id, user_id, acquired_at, expires_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (document_id) DO UPDATE SET
+		     user_id = EXCLUDED.user_id,
+		     acquired_at = CASE WHEN document_locks.user_id = EXCLUDED.user_id AND document_locks.expires_at > EXCLUDED.acquired_at
+		                        THEN document_locks.acquired_at ELSE EXCLUDED.acquired_at END,
+		     expires_at = EXCLUDED.expires_at
+		 WHERE document_locks.user_id = EXCLUDED.user_id OR document_locks.expires_at <= EXCLUDED.acquired_at
+		 RETURNING *`,
+		lock.DocumentID, lock.TenantID, lock.UserID, lock.AcquiredAt, lock.ExpiresAt)
+	if err == nil {
+		return &held, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("documentLockRepo.Acquire: %w", err)
+	}
+
+	// Someone else holds it
+	err = r.db.GetContext(ctx, &held,
+		"SELECT * FROM document_locks WHERE document_id = $1 AND tenant_id = $2", lock.DocumentID, lock.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("documentLockRepo.Acquire holder: %w", err)
+	}
+	return &held, nil
+}
+
+func (r *documentLockRepo) GetActive(ctx context.Context, tenantID, documentID uuid.UUID) (*domain.DocumentLock, error) {
+	var lock domain.DocumentLock
+	err := r.db.GetContext(ctx, &lock,
+		`SELECT * FROM document_locks
+		 WHERE document_id = $1 AND tenant_id = $2 AND expires_at > NOW()`,
+		documentID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("documentLockRepo.GetActive: %w", err)
+	}
+	return &lock, nil
+}
+
+func (r *documentLockRepo) Release(ctx context.Context, tenantID, documentID uuid.UUID, userID *uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM document_locks
+		 WHERE document_id = $1 AND tenant_id = $2 AND ($3::uuid IS NULL OR user_id = $3)`,
+		documentID, tenantID, userID)
+	if err != nil {
+		return fmt.Errorf("documentLockRepo.Release: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -28,6 +29,7 @@ func (r *documentRepo) Create(ctx context.Context, doc *domain.Document) error {
 	now := time.Now().UTC()
 	doc.CreatedAt = now
 	doc.UpdatedAt = now
+	doc.QueuedAt = now
 
 	query := `INSERT INTO documents (
 		id, tenant_id, collection_id, file_id, name, document_type,
@@ -37,7 +39,7 @@ func (r *documentRepo) Create(ctx context.Context, doc *domain.Document) error {
 		validation_status, validation_results, reconciliation_status,
 		parse_mode, field_provenance,
 		secondary_parser_model, parse_attempts, retry_after,
-		created_by, created_at, updated_at
+		created_by, created_at, updated_at, queued_at
 	) VALUES (
 		$1, $2, $3, $4, $5, $6,
 		$7, $8, $9, $10,
@@ -46,7 +48,7 @@ func (r *documentRepo) Create(ctx context.Context, doc *domain.Document) error {
 		$18, $19, $20,
 		$21, $22,
 		$23, $24, $25,
-		$26, $27, $28
+		$26, $27, $28, $29
 	)`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -57,7 +59,7 @@ func (r *documentRepo) Create(ctx context.Context, doc *domain.Document) error {
 		doc.ValidationStatus, doc.ValidationResults, doc.ReconciliationStatus,
 		doc.ParseMode, doc.FieldProvenance,
 		doc.SecondaryParserModel, doc.ParseAttempts, doc.RetryAfter,
-		doc.CreatedBy, doc.CreatedAt, doc.UpdatedAt)
+		doc.CreatedBy, doc.CreatedAt, doc.UpdatedAt, doc.QueuedAt)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") && strings.Contains(err.Error(), "file_id") {
 			return domain.ErrDocumentAlreadyExists
@@ -109,7 +111,7 @@ func (r *documentRepo) ListByCollection(ctx context.Context, tenantID, collectio
 		return nil, 0, fmt.Errorf("documentRepo.ListByCollection count: %w", err)
 	}
 
-	selectQuery += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	selectQuery += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
 	args = append(args, limit, offset)
 
 	var docs []domain.Document
@@ -176,7 +178,42 @@ func (r *documentRepo) ListByUserCollections(ctx context.Context, tenantID, user
 }
 
 func (r *documentRepo) UpdateStructuredData(ctx context.Context, doc *domain.Document) error {
-	doc.UpdatedAt = time.Now().UTC()
+	rows, err := r.updateStructuredData(ctx, doc, "")
+	if err != nil {
+		return fmt.Errorf("documentRepo.UpdateStructuredData: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrDocumentNotFound
+	}
+	return nil
+}
+
+func (r *documentRepo) UpdateStructuredDataIfUnmodified(ctx context.Context, doc *domain.Document, lastUpdatedAt time.Time) error {
+	rows, err := r.updateStructuredData(ctx, doc, " AND updated_at = $18", lastUpdatedAt)
+	if err != nil {
+		return fmt.Errorf("documentRepo.UpdateStructuredDataIfUnmodified: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrDocumentModified
+	}
+	return nil
+}
+
+// updateStructuredData saves doc's parse results where its id and tenant match
+// and cond, whose placeholders start at $18, holds. It returns the rows updated.
+func (r *documentRepo) updateStructuredData(ctx context.Context, doc *domain.Document, cond string, condArgs ...interface{}) (int64, error) {
+	updatedAt := time.Now().UTC()
+	args := []interface{}{
+		doc.StructuredData, doc.ConfidenceScores,
+		doc.ParsingStatus, doc.ParsingError, doc.ParsedAt,
+		doc.ParserModel, doc.ParserPrompt,
+		doc.FieldProvenance,
+		doc.SecondaryParserModel, doc.ParseAttempts,
+		doc.RetryAfter, doc.QueuedAt,
+		doc.ParseFailureCategory, doc.Language,
+		updatedAt,
+		doc.ID, doc.TenantID,
+	}
 	result, err := r.db.ExecContext(ctx,
 		`UPDATE documents SET
 			structured_data = $1, confidence_scores = $2,
@@ -184,36 +221,41 @@ func (r *documentRepo) UpdateStructuredData(ctx context.Context, doc *domain.Doc
 			parser_model = $6, parser_prompt = $7,
 			field_provenance = $8,
 			secondary_parser_model = $9, parse_attempts = $10,
-			retry_after = $11,
-			updated_at = $12
-		 WHERE id = $13 AND tenant_id = $14`,
-		doc.StructuredData, doc.ConfidenceScores,
-		doc.ParsingStatus, doc.ParsingError, doc.ParsedAt,
-		doc.ParserModel, doc.ParserPrompt,
-		doc.FieldProvenance,
-		doc.SecondaryParserModel, doc.ParseAttempts,
-		doc.RetryAfter,
-		doc.UpdatedAt,
-		doc.ID, doc.TenantID)
+			retry_after = $11, queued_at = $12,
+			parse_failure_category = $13, language = $14,
+			updated_at = $15
+		 WHERE id = $16 AND tenant_id = $17`+cond,
+		append(args, condArgs...)...)
 	if err != nil {
-		return fmt.Errorf("documentRepo.UpdateStructuredData: %w", err)
+		return 0, err
 	}
 	rows, _ := result.RowsAffected()
-	if rows == 0 {
-		return domain.ErrDocumentNotFound
+	if rows > 0 {
+		doc.UpdatedAt = updatedAt
 	}
-	return nil
+	return rows, nil
 }
 
 func (r *documentRepo) UpdateReviewStatus(ctx context.Context, doc *domain.Document) error {
 	doc.UpdatedAt = time.Now().UTC()
-	result, err := r.db.ExecContext(ctx,
+	if len(doc.ReviewChecklist) == 0 {
+		doc.ReviewChecklist = json.RawMessage("[]")
+	}
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("documentRepo.UpdateReviewStatus begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx,
 		`UPDATE documents SET
 			review_status = $1, reviewed_by = $2, reviewed_at = $3,
-			reviewer_notes = $4, updated_at = $5
-		 WHERE id = $6 AND tenant_id = $7`,
+			reviewer_notes = $4, review_checklist = $5, rejection_reason = $6,
+			maker_approved_by = $7, maker_approved_at = $8, escalated_at = $9, updated_at = $10
+		 WHERE id = $11 AND tenant_id = $12`,
 		doc.ReviewStatus, doc.ReviewedBy, doc.ReviewedAt,
-		doc.ReviewerNotes, doc.UpdatedAt,
+		doc.ReviewerNotes, doc.ReviewChecklist, doc.RejectionReason,
+		doc.MakerApprovedBy, doc.MakerApprovedAt, doc.EscalatedAt, doc.UpdatedAt,
 		doc.ID, doc.TenantID)
 	if err != nil {
 		return fmt.Errorf("documentRepo.UpdateReviewStatus: %w", err)
@@ -222,6 +264,31 @@ func (r *documentRepo) UpdateReviewStatus(ctx context.Context, doc *domain.Docum
 	if rows == 0 {
 		return domain.ErrDocumentNotFound
 	}
+
+	// The snapshot is read from the row just updated, which the update keeps
+	// locked, so it is exactly what was approved.
+	if doc.ReviewStatus == domain.ReviewStatusApproved {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO document_approvals (
+				tenant_id, document_id, version, parser_model,
+				structured_data, confidence_scores, field_provenance,
+				validation_status, validation_results, review_checklist, reviewer_notes,
+				approved_by, approved_at, maker_approved_by, maker_approved_at
+			)
+			SELECT tenant_id, id, version, parser_model,
+				structured_data, confidence_scores, field_provenance,
+				validation_status, validation_results, review_checklist, reviewer_notes,
+				reviewed_by, reviewed_at, maker_approved_by, maker_approved_at
+			FROM documents WHERE id = $1 AND tenant_id = $2`,
+			doc.ID, doc.TenantID)
+		if err != nil {
+			return fmt.Errorf("documentRepo.UpdateReviewStatus snapshot: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("documentRepo.UpdateReviewStatus commit: %w", err)
+	}
 	return nil
 }
 
@@ -243,26 +310,156 @@ func (r *documentRepo) UpdateAssignment(ctx context.Context, doc *domain.Documen
 	return nil
 }
 
-func (r *documentRepo) ListReviewQueue(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.Document, int, error) {
-	baseWhere := "WHERE tenant_id = $1 AND assigned_to = $2 AND parsing_status = 'completed' AND review_status = 'pending'"
+func (r *documentRepo) UpdateName(ctx context.Context, doc *domain.Document) error {
+	doc.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE documents SET name = $1, original_name = $2, updated_at = $3
+		 WHERE id = $4 AND tenant_id = $5`,
+		doc.Name, doc.OriginalName, doc.UpdatedAt, doc.ID, doc.TenantID)
+	if err != nil {
+		return fmt.Errorf("documentRepo.UpdateName: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrDocumentNotFound
+	}
+	return nil
+}
+
+func (r *documentRepo) ListNamesWithPrefix(ctx context.Context, tenantID, collectionID uuid.UUID, prefix string, excludeID uuid.UUID) ([]string, error) {
+	var names []string
+	err := r.db.SelectContext(ctx, &names,
+		`SELECT name FROM documents
+		 WHERE tenant_id = $1 AND collection_id = $2 AND id <> $3 AND name LIKE $4`,
+		tenantID, collectionID, excludeID, likePrefix(prefix))
+	if err != nil {
+		return nil, fmt.Errorf("documentRepo.ListNamesWithPrefix: %w", err)
+	}
+	return names, nil
+}
+
+func (r *documentRepo) ListReviewQueue(ctx context.Context, tenantID uuid.UUID, assignees []uuid.UUID, offset, limit int) ([]domain.Document, int, error) {
+	if len(assignees) == 0 {
+		return []domain.Document{}, 0, nil
+	}
+	baseWhere, args, err := sqlx.In(
+		"WHERE tenant_id = ? AND assigned_to IN (?) AND parsing_status = 'completed' AND review_status = 'pending'",
+		tenantID, assignees)
+	if err != nil {
+		return nil, 0, fmt.Errorf("documentRepo.ListReviewQueue: building query: %w", err)
+	}
 
 	var total int
-	err := r.db.GetContext(ctx, &total,
-		"SELECT COUNT(*) FROM documents "+baseWhere, tenantID, userID)
+	err = r.db.GetContext(ctx, &total,
+		r.db.Rebind("SELECT COUNT(*) FROM documents "+baseWhere), args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("documentRepo.ListReviewQueue count: %w", err)
 	}
 
 	var docs []domain.Document
 	err = r.db.SelectContext(ctx, &docs,
-		"SELECT * FROM documents "+baseWhere+" ORDER BY assigned_at ASC LIMIT $3 OFFSET $4",
-		tenantID, userID, limit, offset)
+		r.db.Rebind("SELECT * FROM documents "+baseWhere+" ORDER BY assigned_at ASC, id ASC LIMIT ? OFFSET ?"),
+		append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("documentRepo.ListReviewQueue: %w", err)
 	}
 	return docs, total, nil
 }
 
+func (r *documentRepo) ReviewQueueNeighbors(ctx context.Context, tenantID uuid.UUID, assignees []uuid.UUID, assignedAt time.Time, docID uuid.UUID) (*domain.DocumentNeighbors, error) {
+	neighbors := &domain.DocumentNeighbors{}
+	if len(assignees) == 0 {
+		return neighbors, nil
+	}
+	baseWhere, args, err := sqlx.In(
+		"WHERE tenant_id = ? AND assigned_to IN (?) AND parsing_status = 'completed' AND review_status = 'pending'",
+		tenantID, assignees)
+	if err != nil {
+		return nil, fmt.Errorf("documentRepo.ReviewQueueNeighbors: building query: %w", err)
+	}
+
+	// The queue is sorted by assigned_at ascending.
+	if neighbors.PreviousID, err = r.adjacentID(ctx, baseWhere, args, "assigned_at", assignedAt, docID, false); err != nil {
+		return nil, fmt.Errorf("documentRepo.ReviewQueueNeighbors previous: %w", err)
+	}
+	if neighbors.NextID, err = r.adjacentID(ctx, baseWhere, args, "assigned_at", assignedAt, docID, true); err != nil {
+		return nil, fmt.Errorf("documentRepo.ReviewQueueNeighbors next: %w", err)
+	}
+	return neighbors, nil
+}
+
+func (r *documentRepo) CollectionNeighbors(ctx context.Context, tenantID, collectionID uuid.UUID, assignedTo *uuid.UUID, createdAt time.Time, docID uuid.UUID) (*domain.DocumentNeighbors, error) {
+	baseWhere := "WHERE tenant_id = ? AND collection_id = ?"
+	args := []interface{}{tenantID, collectionID}
+	if assignedTo != nil {
+		baseWhere += " AND assigned_to = ?"
+		args = append(args, *assignedTo)
+	}
+
+	// Collection listings are sorted by created_at descending, so the previous
+	// document is the one created just after this one.
+	var err error
+	neighbors := &domain.DocumentNeighbors{}
+	if neighbors.PreviousID, err = r.adjacentID(ctx, baseWhere, args, "created_at", createdAt, docID, true); err != nil {
+		return nil, fmt.Errorf("documentRepo.CollectionNeighbors previous: %w", err)
+	}
+	if neighbors.NextID, err = r.adjacentID(ctx, baseWhere, args, "created_at", createdAt, docID, false); err != nil {
+		return nil, fmt.Errorf("documentRepo.CollectionNeighbors next: %w", err)
+	}
+	return neighbors, nil
+}
+
+// adjacentID returns the ID of the first document matching baseWhere whose
+// (keyCol, id) sorts immediately after (after=true) or before the given position,
+// or nil when there is none. baseWhere uses ? placeholders.
+func (r *documentRepo) adjacentID(ctx context.Context, baseWhere string, args []interface{}, keyCol string, key time.Time, docID uuid.UUID, after bool) (*uuid.UUID, error) {
+	cmp, order := "<", "DESC"
+	if after {
+		cmp, order = ">", "ASC"
+	}
+	query := fmt.Sprintf("SELECT id FROM documents %s AND (%s, id) %s (?, ?) ORDER BY %s %s, id %s LIMIT 1",
+		baseWhere, keyCol, cmp, keyCol, order, order)
+
+	queryArgs := make([]interface{}, 0, len(args)+2)
+	queryArgs = append(queryArgs, args...)
+	queryArgs = append(queryArgs, key, docID)
+
+	var id uuid.UUID
+	if err := r.db.GetContext(ctx, &id, r.db.Rebind(query), queryArgs...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (r *documentRepo) ListCheckerQueue(ctx context.Context, tenantID, userID uuid.UUID, ownedOnly bool, offset, limit int) ([]domain.Document, int, error) {
+	// The maker can never confirm their own approval, so their documents are excluded.
+	baseWhere := `WHERE d.tenant_id = $1 AND d.review_status = 'awaiting_checker'
+		AND d.maker_approved_by IS DISTINCT FROM $2`
+	if ownedOnly {
+		baseWhere += ` AND EXISTS (SELECT 1 FROM collection_permissions cp
+			WHERE cp.collection_id = d.collection_id AND cp.user_id = $2 AND cp.permission = 'owner')`
+	}
+
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM documents d "+baseWhere, tenantID, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("documentRepo.ListCheckerQueue count: %w", err)
+	}
+
+	var docs []domain.Document
+	err = r.db.SelectContext(ctx, &docs,
+		"SELECT d.* FROM documents d "+baseWhere+" ORDER BY d.maker_approved_at ASC LIMIT $3 OFFSET $4",
+		tenantID, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("documentRepo.ListCheckerQueue: %w", err)
+	}
+	return docs, total, nil
+}
+
 func (r *documentRepo) UpdateValidationResults(ctx context.Context, doc *domain.Document) error {
 	doc.UpdatedAt = time.Now().UTC()
 	result, err := r.db.ExecContext(ctx,
@@ -312,3 +509,140 @@ func (r *documentRepo) Delete(ctx context.Context, tenantID, docID uuid.UUID) er
 	}
 	return nil
 }
+
+func (r *documentRepo) SaveVersion(ctx context.Context, doc *domain.Document, prev *domain.DocumentVersion) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("documentRepo.SaveVersion begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if prev.ID == uuid.Nil {
+		prev.ID = uuid.New()
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO document_versions (
+			id, tenant_id, document_id, version, file_id, document_type, parser_model,
+			structured_data, confidence_scores, field_provenance,
+			parsing_status, parsed_at, review_status, reviewed_by, reviewed_at,
+			validation_status, validation_results, reason, replaced_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)`,
+		prev.ID, prev.TenantID, prev.DocumentID, prev.Version, prev.FileID, prev.DocumentType, prev.ParserModel,
+		prev.StructuredData, prev.ConfidenceScores, prev.FieldProvenance,
+		prev.ParsingStatus, prev.ParsedAt, prev.ReviewStatus, prev.ReviewedBy, prev.ReviewedAt,
+		prev.ValidationStatus, prev.ValidationResults, prev.Reason, prev.ReplacedBy)
+	if err != nil {
+		// Another replacement or reclassification already saved this version and started a new parse
+		if strings.Contains(err.Error(), "duplicate key") {
+			return domain.ErrDocumentParseInProgress
+		}
+		return fmt.Errorf("documentRepo.SaveVersion version: %w", err)
+	}
+
+	now := time.Now().UTC()
+	doc.UpdatedAt = now
+	doc.QueuedAt = now
+	if len(doc.ReviewChecklist) == 0 {
+		doc.ReviewChecklist = json.RawMessage("[]")
+	}
+	// The version guard makes a concurrent replacement of the same document fail
+	// instead of overwriting this one.
+	result, err := tx.ExecContext(ctx,
+		`UPDATE documents SET
+			file_id = $1, version = $2,
+			structured_data = $3, confidence_scores = $4, field_provenance = $5,
+			parser_model = $6, parser_prompt = $7, secondary_parser_model = $8,
+			parsing_status = $9, parsing_error = $10, parse_failure_category = $11,
+			parsed_at = $12, parse_attempts = $13, retry_after = $14, queued_at = $15,
+			review_status = $16, reviewed_by = $17, reviewed_at = $18, reviewer_notes = $19,
+			review_checklist = $20, rejection_reason = $21,
+			maker_approved_by = $22, maker_approved_at = $23, escalated_at = $24,
+			validation_status = $25, validation_results = $26, reconciliation_status = $27,
+			assigned_to = $28, assigned_at = $29, assigned_by = $30,
+			document_type = $31, updated_at = $32
+		 WHERE id = $33 AND tenant_id = $34 AND version = $35`,
+		doc.FileID, doc.Version,
+		doc.StructuredData, doc.ConfidenceScores, doc.FieldProvenance,
+		doc.ParserModel, doc.ParserPrompt, doc.SecondaryParserModel,
+		doc.ParsingStatus, doc.ParsingError, doc.ParseFailureCategory,
+		doc.ParsedAt, doc.ParseAttempts, doc.RetryAfter, doc.QueuedAt,
+		doc.ReviewStatus, doc.ReviewedBy, doc.ReviewedAt, doc.ReviewerNotes,
+		doc.ReviewChecklist, doc.RejectionReason,
+		doc.MakerApprovedBy, doc.MakerApprovedAt, doc.EscalatedAt,
+		doc.ValidationStatus, doc.ValidationResults, doc.ReconciliationStatus,
+		doc.AssignedTo, doc.AssignedAt, doc.AssignedBy,
+		doc.DocumentType, doc.UpdatedAt,
+		doc.ID, doc.TenantID, prev.Version)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") && strings.Contains(err.Error(), "file_id") {
+			return domain.ErrDocumentAlreadyExists
+		}
+		return fmt.Errorf("documentRepo.SaveVersion: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrDocumentNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("documentRepo.SaveVersion commit: %w", err)
+	}
+	return nil
+}
+
+func (r *documentRepo) ListVersions(ctx context.Context, tenantID, docID uuid.UUID) ([]domain.DocumentVersion, error) {
+	var versions []domain.DocumentVersion
+	err := r.db.SelectContext(ctx, &versions,
+		`SELECT * FROM document_versions
+		 WHERE tenant_id = $1 AND document_id = $2
+		 ORDER BY version DESC`,
+		tenantID, docID)
+	if err != nil {
+		return nil, fmt.Errorf("documentRepo.ListVersions: %w", err)
+	}
+	return versions, nil
+}
+
+func (r *documentRepo) ListApprovals(ctx context.Context, tenantID, docID uuid.UUID) ([]domain.DocumentApproval, error) {
+	var approvals []domain.DocumentApproval
+	err := r.db.SelectContext(ctx, &approvals,
+		`SELECT * FROM document_approvals
+		 WHERE tenant_id = $1 AND document_id = $2
+		 ORDER BY approved_at DESC, created_at DESC`,
+		tenantID, docID)
+	if err != nil {
+		return nil, fmt.Errorf("documentRepo.ListApprovals: %w", err)
+	}
+	return approvals, nil
+}
+
+func (r *documentRepo) ListValidationFailures(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.ValidationFailure, error) {
+	// Results whose rule has since been deleted count as warnings, as in GET /documents/:id/validation.
+	var failures []domain.ValidationFailure
+	err := r.db.SelectContext(ctx, &failures,
+		`SELECT d.id AS document_id, d.name AS document_name,
+		        COALESCE(d.structured_data->'invoice'->>'invoice_number', '') AS invoice_number,
+		        d.review_status,
+		        COALESCE(vr.rule_name, '') AS rule_name, COALESCE(vr.rule_type, '') AS rule_type,
+		        COALESCE(vr.severity, 'warning') AS severity,
+		        COALESCE(res.entry->>'field_path', '') AS field_path,
+		        COALESCE(res.entry->>'expected_value', '') AS expected_value,
+		        COALESCE(res.entry->>'actual_value', '') AS actual_value,
+		        COALESCE(res.entry->>'message', '') AS message,
+		        COALESCE((res.entry->>'reconciliation_critical')::boolean, false) AS reconciliation_critical
+		 FROM documents d
+		 CROSS JOIN LATERAL jsonb_array_elements(
+		     CASE WHEN jsonb_typeof(d.validation_results) = 'array' THEN d.validation_results ELSE '[]'::jsonb END
+		 ) WITH ORDINALITY AS res(entry, n)
+		 LEFT JOIN document_validation_rules vr
+		        ON vr.tenant_id = d.tenant_id AND vr.id::text = res.entry->>'rule_id'
+		 WHERE d.tenant_id = $1 AND d.collection_id = $2
+		   AND (res.entry->>'passed')::boolean IS FALSE
+		 ORDER BY d.created_at, d.id, CASE WHEN vr.severity = 'error' THEN 0 ELSE 1 END, res.n
+		 LIMIT $3 OFFSET $4`,
+		tenantID, collectionID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("documentRepo.ListValidationFailures: %w", err)
+	}
+	return failures, nil
+}
@@ -29,22 +29,23 @@ func (r *documentTagRepo) CreateBatch(ctx context.Context, tags []domain.Documen
 
 	now := time.Now().UTC()
 	valueStrings := make([]string, 0, len(tags))
-	valueArgs := make([]interface{}, 0, len(tags)*6)
+	valueArgs := make([]interface{}, 0, len(tags)*9)
 
 	for i, tag := range tags {
 		tag.CreatedAt = now
-		base := i * 6
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)",
-			base+1, base+2, base+3, base+4, base+5, base+6))
+		base := i * 9
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			base+1, base+2, base+3, base+4, base+5, base+6, base+7, base+8, base+9))
 		source := tag.Source
 		if source == "" {
 			source = "user"
 		}
-		valueArgs = append(valueArgs, tag.ID, tag.DocumentID, tag.TenantID, tag.Key, tag.Value, source)
+		valueArgs = append(valueArgs, tag.ID, tag.DocumentID, tag.TenantID, tag.Key, tag.Value, source,
+			tagValueType(tag.ValueType), tag.NumberValue, tag.DateValue)
 	}
 
 	query := fmt.Sprintf(
-		`INSERT INTO document_tags (id, document_id, tenant_id, key, value, source) VALUES %s`,
+		`INSERT INTO document_tags (id, document_id, tenant_id, key, value, source, value_type, number_value, date_value) VALUES %s`,
 		strings.Join(valueStrings, ", "))
 
 	_, err := r.db.ExecContext(ctx, query, valueArgs...)
@@ -65,30 +66,111 @@ func (r *documentTagRepo) ListByDocument(ctx context.Context, documentID uuid.UU
 	return tags, nil
 }
 
-func (r *documentTagRepo) SearchByTag(ctx context.Context, tenantID uuid.UUID, key, value string, offset, limit int) ([]domain.Document, int, error) {
+func (r *documentTagRepo) ListByDocuments(ctx context.Context, tenantID uuid.UUID, documentIDs []uuid.UUID) (map[uuid.UUID][]domain.DocumentTag, error) {
+	result := make(map[uuid.UUID][]domain.DocumentTag)
+	if len(documentIDs) == 0 {
+		return result, nil
+	}
+
+	query, args, err := sqlx.In(
+		"SELECT * FROM document_tags WHERE tenant_id = ? AND document_id IN (?) ORDER BY key, value",
+		tenantID, documentIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("documentTagRepo.ListByDocuments: building query: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	var tags []domain.DocumentTag
+	if err := r.db.SelectContext(ctx, &tags, query, args...); err != nil {
+		return nil, fmt.Errorf("documentTagRepo.ListByDocuments: %w", err)
+	}
+	for i := range tags {
+		result[tags[i].DocumentID] = append(result[tags[i].DocumentID], tags[i])
+	}
+	return result, nil
+}
+
+func (r *documentTagRepo) SearchByTag(ctx context.Context, tenantID uuid.UUID, q *domain.TagQuery, offset, limit int) ([]domain.Document, int, error) {
+	where, args := tagQueryWhere(tenantID, q)
+
 	var total int
 	err := r.db.GetContext(ctx, &total,
 		`SELECT COUNT(DISTINCT d.id) FROM documents d
 		 INNER JOIN document_tags dt ON dt.document_id = d.id
-		 WHERE dt.tenant_id = $1 AND dt.key = $2 AND dt.value = $3`,
-		tenantID, key, value)
+		 WHERE `+where,
+		args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("documentTagRepo.SearchByTag count: %w", err)
 	}
 
 	var docs []domain.Document
+	n := len(args)
 	err = r.db.SelectContext(ctx, &docs,
-		`SELECT DISTINCT d.* FROM documents d
+		fmt.Sprintf(`SELECT DISTINCT d.* FROM documents d
 		 INNER JOIN document_tags dt ON dt.document_id = d.id
-		 WHERE dt.tenant_id = $1 AND dt.key = $2 AND dt.value = $3
-		 ORDER BY d.created_at DESC LIMIT $4 OFFSET $5`,
-		tenantID, key, value, limit, offset)
+		 WHERE %s
+		 ORDER BY d.created_at DESC LIMIT $%d OFFSET $%d`, where, n+1, n+2),
+		append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("documentTagRepo.SearchByTag: %w", err)
 	}
 	return docs, total, nil
 }
 
+// tagQueryWhere builds the tag condition of SearchByTag. Exact and prefix
+// matches use the text value; ranges the typed value, so only tags of the
+// range's type match.
+func tagQueryWhere(tenantID uuid.UUID, q *domain.TagQuery) (string, []interface{}) {
+	args := []interface{}{tenantID, q.Key}
+	where := "dt.tenant_id = $1 AND dt.key = $2"
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where += fmt.Sprintf(" AND "+cond, len(args))
+	}
+	switch {
+	case q.Prefix != "":
+		add(`dt.value LIKE $%d`, likePrefix(q.Prefix))
+	case q.Type == domain.TagValueNumber:
+		where += " AND dt.number_value IS NOT NULL"
+		if q.MinNumber != nil {
+			add("dt.number_value >= $%d", *q.MinNumber)
+		}
+		if q.MaxNumber != nil {
+			add("dt.number_value <= $%d", *q.MaxNumber)
+		}
+	case q.Type == domain.TagValueDate:
+		where += " AND dt.date_value IS NOT NULL"
+		if q.MinDate != nil {
+			add("dt.date_value >= $%d", q.MinDate.Format("2006-01-02"))
+		}
+		if q.MaxDate != nil {
+			add("dt.date_value <= $%d", q.MaxDate.Format("2006-01-02"))
+		}
+	default:
+		add("dt.value = $%d", q.Value)
+	}
+	return where, args
+}
+
+// likePrefix escapes LIKE wildcards in prefix and matches anything after it.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix) + "%"
+}
+
+// likeContains escapes LIKE wildcards in s and matches it anywhere.
+func likeContains(s string) string {
+	return "%" + strings.TrimSuffix(likePrefix(s), "%") + "%"
+}
+
+// tagValueType stores untyped tags as strings.
+func tagValueType(t domain.TagValueType) domain.TagValueType {
+	if t == "" {
+		return domain.TagValueString
+	}
+	return t
+}
+
 func (r *documentTagRepo) DeleteByID(ctx context.Context, documentID, tagID uuid.UUID) error {
 	result, err := r.db.ExecContext(ctx,
 		"DELETE FROM document_tags WHERE id = $1 AND document_id = $2", tagID, documentID)
@@ -119,3 +201,31 @@ func (r *documentTagRepo) DeleteByDocumentAndSource(ctx context.Context, documen
 	}
 	return nil
 }
+
+func (r *documentTagRepo) AddIfMissing(ctx context.Context, tag *domain.DocumentTag) (bool, error) {
+	source := tag.Source
+	if source == "" {
+		source = "user"
+	}
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO document_tags (id, document_id, tenant_id, key, value, source, value_type, number_value, date_value)
+		 SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9
+		 WHERE NOT EXISTS (SELECT 1 FROM document_tags WHERE document_id = $2 AND key = $4 AND value = $5)`,
+		tag.ID, tag.DocumentID, tag.TenantID, tag.Key, tag.Value, source,
+		tagValueType(tag.ValueType), tag.NumberValue, tag.DateValue)
+	if err != nil {
+		return false, fmt.Errorf("documentTagRepo.AddIfMissing: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+func (r *documentTagRepo) DeleteByKeyValue(ctx context.Context, documentID uuid.UUID, key, value string) (int, error) {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM document_tags WHERE document_id = $1 AND key = $2 AND value = $3", documentID, key, value)
+	if err != nil {
+		return 0, fmt.Errorf("documentTagRepo.DeleteByKeyValue: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
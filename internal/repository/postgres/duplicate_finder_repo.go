@@ -2,10 +2,12 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 
+	"satvos/internal/domain"
 	"satvos/internal/port"
 )
 
@@ -43,3 +45,35 @@ func (r *duplicateFinderRepo) FindDuplicates(
 	}
 	return matches, nil
 }
+
+func (r *duplicateFinderRepo) FindCandidates(
+	ctx context.Context,
+	tenantID, excludeDocID uuid.UUID,
+	sellerGSTIN string,
+	userID uuid.UUID,
+	role domain.UserRole,
+	limit int,
+) ([]port.DuplicateCandidate, error) {
+	query := `
+		SELECT ds.document_id, d.name, ds.collection_id, COALESCE(ds.invoice_number, '') AS invoice_number,
+		       ds.invoice_date, COALESCE(ds.total_amount, 0) AS total_amount, d.review_status, d.created_at
+		FROM document_summaries ds
+		JOIN documents d ON d.id = ds.document_id
+		WHERE ds.tenant_id = $1
+		  AND ds.document_id != $2
+		  AND ds.seller_gstin = $3
+		  AND d.parsing_status = 'completed'`
+	args := []interface{}{tenantID, excludeDocID, sellerGSTIN}
+	if role != domain.RoleAdmin && role != domain.RoleManager && role != domain.RoleMember {
+		args = append(args, userID)
+		query += fmt.Sprintf(" AND ds.collection_id IN (SELECT collection_id FROM collection_permissions WHERE user_id = $%d)", len(args))
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY d.created_at DESC LIMIT $%d", len(args))
+
+	candidates := []port.DuplicateCandidate{}
+	if err := r.db.SelectContext(ctx, &candidates, query, args...); err != nil {
+		return nil, fmt.Errorf("duplicateFinderRepo.FindCandidates: %w", err)
+	}
+	return candidates, nil
+}
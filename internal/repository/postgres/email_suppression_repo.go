@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type emailSuppressionRepo struct {
+	db *sqlx.DB
+}
+
+// NewEmailSuppressionRepo creates a new PostgreSQL-backed EmailSuppressionRepository.
+func NewEmailSuppressionRepo(db *sqlx.DB) port.EmailSuppressionRepository {
+	return &emailSuppressionRepo{db: db}
+}
+
+func (r *emailSuppressionRepo) Suppress(ctx context.Context, s *domain.EmailSuppression) ([]domain.User, error) {
+	s.Email = strings.ToLower(s.Email)
+	now := time.Now().UTC()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("emailSuppressionRepo.Suppress begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.QueryRowxContext(ctx,
+		`INSERT INTO email_suppressions (email, reason, detail, feedback_id, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $5)
+		 ON CONFLICT (email) DO UPDATE SET reason = EXCLUDED.reason, detail = EXCLUDED.detail,
+		     feedback_id = EXCLUDED.feedback_id, updated_at = EXCLUDED.updated_at
+		 RETURNING created_at, updated_at`,
+		s.Email, s.Reason, s.Detail, s.FeedbackID, now).Scan(&s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("emailSuppressionRepo.Suppress upsert: %w", err)
+	}
+
+	users := []domain.User{}
+	if err := sqlx.SelectContext(ctx, tx, &users,
+		`UPDATE users SET email_undeliverable = $2, email_undeliverable_at = $3
+		 WHERE LOWER(email) = $1 AND email_undeliverable IS DISTINCT FROM $2
+		 RETURNING *`,
+		s.Email, s.Reason, now); err != nil {
+		return nil, fmt.Errorf("emailSuppressionRepo.Suppress users: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("emailSuppressionRepo.Suppress commit: %w", err)
+	}
+	return users, nil
+}
+
+func (r *emailSuppressionRepo) Get(ctx context.Context, email string) (*domain.EmailSuppression, error) {
+	var s domain.EmailSuppression
+	err := r.db.GetContext(ctx, &s, "SELECT * FROM email_suppressions WHERE email = $1", strings.ToLower(email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("emailSuppressionRepo.Get: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *emailSuppressionRepo) Delete(ctx context.Context, tenantID uuid.UUID, email string) error {
+	email = strings.ToLower(email)
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("emailSuppressionRepo.Delete begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// The suppression covers the address everywhere, so one tenant's admin may
+	// only lift it when no other tenant's user would start receiving email.
+	var shared bool
+	if err := tx.GetContext(ctx, &shared,
+		"SELECT EXISTS (SELECT 1 FROM users WHERE LOWER(email) = $1 AND tenant_id <> $2)",
+		email, tenantID); err != nil {
+		return fmt.Errorf("emailSuppressionRepo.Delete shared: %w", err)
+	}
+	if shared {
+		return domain.ErrEmailSuppressionShared
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM email_suppressions WHERE email = $1", email)
+	if err != nil {
+		return fmt.Errorf("emailSuppressionRepo.Delete: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return domain.ErrNotFound
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE users SET email_undeliverable = NULL, email_undeliverable_at = NULL
+		 WHERE LOWER(email) = $1 AND tenant_id = $2 AND email_undeliverable IS NOT NULL`, email, tenantID); err != nil {
+		return fmt.Errorf("emailSuppressionRepo.Delete users: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("emailSuppressionRepo.Delete commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// exportArtifactSelect reads artifacts with their download count and latest download.
+const exportArtifactSelect = `
+	SELECT a.*,
+	       (SELECT COUNT(*) FROM export_downloads d WHERE d.artifact_id = a.id) AS download_count,
+	       (SELECT MAX(d.created_at) FROM export_downloads d WHERE d.artifact_id = a.id) AS last_downloaded_at
+	  FROM export_artifacts a`
+
+type exportArtifactRepo struct {
+	db *sqlx.DB
+}
+
+// NewExportArtifactRepo creates a new PostgreSQL-backed ExportArtifactRepository.
+func NewExportArtifactRepo(db *sqlx.DB) port.ExportArtifactRepository {
+	return &exportArtifactRepo{db: db}
+}
+
+func (r *exportArtifactRepo) Record(ctx context.Context, artifact *domain.ExportArtifact, userID uuid.UUID) error {
+	if artifact.ID == uuid.Nil {
+		artifact.ID = uuid.New()
+	}
+	if len(artifact.Filters) == 0 {
+		artifact.Filters = json.RawMessage("{}")
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("exportArtifactRepo.Record begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// The no-op update makes RETURNING yield the existing row on conflict
+	var artifactID uuid.UUID
+	err = tx.GetContext(ctx, &artifactID,
+		`INSERT INTO export_artifacts (id, tenant_id, collection_id, kind, format, filters,
+		                               row_count, size_bytes, checksum_sha256, generated_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 ON CONFLICT (collection_id, kind, format, checksum_sha256)
+		 DO UPDATE SET checksum_sha256 = EXCLUDED.checksum_sha256
+		 RETURNING id`,
+		artifact.ID, artifact.TenantID, artifact.CollectionID, artifact.Kind, artifact.Format, artifact.Filters,
+		artifact.RowCount, artifact.SizeBytes, artifact.ChecksumSHA256, artifact.GeneratedBy)
+	if err != nil {
+		return fmt.Errorf("exportArtifactRepo.Record artifact: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO export_downloads (id, tenant_id, artifact_id, user_id) VALUES ($1, $2, $3, $4)`,
+		uuid.New(), artifact.TenantID, artifactID, userID); err != nil {
+		return fmt.Errorf("exportArtifactRepo.Record download: %w", err)
+	}
+
+	if err := tx.GetContext(ctx, artifact, exportArtifactSelect+" WHERE a.id = $1", artifactID); err != nil {
+		return fmt.Errorf("exportArtifactRepo.Record reload: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("exportArtifactRepo.Record commit: %w", err)
+	}
+	return nil
+}
+
+func (r *exportArtifactRepo) GetByID(ctx context.Context, tenantID, artifactID uuid.UUID) (*domain.ExportArtifact, error) {
+	var artifact domain.ExportArtifact
+	err := r.db.GetContext(ctx, &artifact, exportArtifactSelect+" WHERE a.id = $1 AND a.tenant_id = $2", artifactID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("exportArtifactRepo.GetByID: %w", err)
+	}
+	return &artifact, nil
+}
+
+func (r *exportArtifactRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, checksum string, offset, limit int) ([]domain.ExportArtifact, int, error) {
+	where := " WHERE a.tenant_id = $1 AND a.collection_id = $2 AND ($3 = '' OR a.checksum_sha256 = $3)"
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM export_artifacts a"+where, tenantID, collectionID, checksum); err != nil {
+		return nil, 0, fmt.Errorf("exportArtifactRepo.ListByCollection count: %w", err)
+	}
+
+	artifacts := []domain.ExportArtifact{}
+	err := r.db.SelectContext(ctx, &artifacts,
+		exportArtifactSelect+where+" ORDER BY a.created_at DESC, a.id LIMIT $4 OFFSET $5",
+		tenantID, collectionID, checksum, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("exportArtifactRepo.ListByCollection: %w", err)
+	}
+	return artifacts, total, nil
+}
+
+func (r *exportArtifactRepo) ListDownloads(ctx context.Context, tenantID, artifactID uuid.UUID, offset, limit int) ([]domain.ExportDownload, int, error) {
+	var total int
+	if err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM export_downloads WHERE tenant_id = $1 AND artifact_id = $2", tenantID, artifactID); err != nil {
+		return nil, 0, fmt.Errorf("exportArtifactRepo.ListDownloads count: %w", err)
+	}
+
+	downloads := []domain.ExportDownload{}
+	err := r.db.SelectContext(ctx, &downloads,
+		`SELECT * FROM export_downloads WHERE tenant_id = $1 AND artifact_id = $2
+		 ORDER BY created_at DESC, id LIMIT $3 OFFSET $4`,
+		tenantID, artifactID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("exportArtifactRepo.ListDownloads: %w", err)
+	}
+	return downloads, total, nil
+}
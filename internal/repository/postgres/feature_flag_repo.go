@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type featureFlagRepo struct {
+	db *sqlx.DB
+}
+
+// NewFeatureFlagRepo creates a new PostgreSQL-backed FeatureFlagRepository.
+func NewFeatureFlagRepo(db *sqlx.DB) port.FeatureFlagRepository {
+	return &featureFlagRepo{db: db}
+}
+
+func (r *featureFlagRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantFeatureFlag, error) {
+	var flags []domain.TenantFeatureFlag
+	err := r.db.SelectContext(ctx, &flags,
+		`SELECT * FROM tenant_feature_flags WHERE tenant_id = $1 ORDER BY flag`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("featureFlagRepo.ListByTenant: %w", err)
+	}
+	return flags, nil
+}
+
+func (r *featureFlagRepo) Upsert(ctx context.Context, flag *domain.TenantFeatureFlag) error {
+	err := r.db.QueryRowxContext(ctx,
+		`INSERT INTO tenant_feature_flags (tenant_id, flag, enabled, updated_by)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (tenant_id, flag) DO UPDATE
+		 SET enabled = EXCLUDED.enabled, updated_by = EXCLUDED.updated_by, updated_at = NOW()
+		 RETURNING created_at, updated_at`,
+		flag.TenantID, flag.Flag, flag.Enabled, flag.UpdatedBy).
+		Scan(&flag.CreatedAt, &flag.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("featureFlagRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *featureFlagRepo) Delete(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) error {
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM tenant_feature_flags WHERE tenant_id = $1 AND flag = $2`, tenantID, flag)
+	if err != nil {
+		return fmt.Errorf("featureFlagRepo.Delete: %w", err)
+	}
+	return nil
+}
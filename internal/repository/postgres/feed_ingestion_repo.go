@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type feedIngestionRepo struct {
+	db *sqlx.DB
+}
+
+// NewFeedIngestionRepo creates a new PostgreSQL-backed FeedIngestionRepository.
+func NewFeedIngestionRepo(db *sqlx.DB) port.FeedIngestionRepository {
+	return &feedIngestionRepo{db: db}
+}
+
+func (r *feedIngestionRepo) Claim(ctx context.Context, rec *domain.FeedIngestion) (bool, error) {
+	if rec.ID == uuid.Nil {
+		rec.ID = uuid.New()
+	}
+	rec.Status = domain.FeedIngestionProcessing
+	rec.CreatedAt = time.Now().UTC()
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO feed_ingestions (id, tenant_id, object_key, folder, file_name, status, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (tenant_id, object_key) WHERE status = 'processing' DO NOTHING`,
+		rec.ID, rec.TenantID, rec.ObjectKey, rec.Folder, rec.FileName, rec.Status, rec.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("feedIngestionRepo.Claim: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows == 1, nil
+}
+
+func (r *feedIngestionRepo) Complete(ctx context.Context, rec *domain.FeedIngestion) error {
+	now := time.Now().UTC()
+	rec.CompletedAt = &now
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE feed_ingestions SET status = $1, reason = $2, collection_id = $3, file_id = $4,
+			document_id = $5, completed_at = $6
+		 WHERE id = $7 AND tenant_id = $8`,
+		rec.Status, rec.Reason, rec.CollectionID, rec.FileID, rec.DocumentID, rec.CompletedAt,
+		rec.ID, rec.TenantID)
+	if err != nil {
+		return fmt.Errorf("feedIngestionRepo.Complete: %w", err)
+	}
+	return nil
+}
+
+func (r *feedIngestionRepo) FailStale(ctx context.Context, cutoff time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE feed_ingestions SET status = 'failed', reason = 'worker stopped before finishing; will retry',
+			completed_at = NOW()
+		 WHERE status = 'processing' AND created_at < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("feedIngestionRepo.FailStale: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
+
+func (r *feedIngestionRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.FeedIngestion, int, error) {
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM feed_ingestions WHERE tenant_id = $1", tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("feedIngestionRepo.ListByTenant count: %w", err)
+	}
+
+	var recs []domain.FeedIngestion
+	err = r.db.SelectContext(ctx, &recs,
+		`SELECT * FROM feed_ingestions WHERE tenant_id = $1
+		 ORDER BY created_at DESC LIMIT $2 OFFSET $3`,
+		tenantID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("feedIngestionRepo.ListByTenant: %w", err)
+	}
+	return recs, total, nil
+}
+
+func (r *feedIngestionRepo) ListCompletedBetween(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]domain.FeedIngestion, error) {
+	var recs []domain.FeedIngestion
+	err := r.db.SelectContext(ctx, &recs,
+		`SELECT * FROM feed_ingestions
+		 WHERE tenant_id = $1 AND status <> 'processing' AND completed_at >= $2 AND completed_at < $3
+		 ORDER BY completed_at`,
+		tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("feedIngestionRepo.ListCompletedBetween: %w", err)
+	}
+	return recs, nil
+}
+
+func (r *feedIngestionRepo) ClaimReport(ctx context.Context, tenantID uuid.UUID, date time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO feed_report_runs (tenant_id, report_date) VALUES ($1, $2)
+		 ON CONFLICT DO NOTHING`,
+		tenantID, date.Format("2006-01-02"))
+	if err != nil {
+		return false, fmt.Errorf("feedIngestionRepo.ClaimReport: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows == 1, nil
+}
@@ -115,3 +115,38 @@ func (r *fileMetaRepo) UpdateStatus(ctx context.Context, tenantID, fileID uuid.U
 func (r *fileMetaRepo) Delete(ctx context.Context, tenantID, fileID uuid.UUID) error {
 	return r.UpdateStatus(ctx, tenantID, fileID, domain.FileStatusDeleted)
 }
+
+func (r *fileMetaRepo) ListRelocations(ctx context.Context, tenantID, afterID uuid.UUID, limit int) ([]domain.FileRelocation, error) {
+	// A file in several collections is filed under the one it joined first.
+	var files []domain.FileRelocation
+	err := r.db.SelectContext(ctx, &files,
+		`SELECT f.id AS file_id, cf.collection_id, f.s3_bucket, f.s3_key
+		 FROM file_metadata f
+		 JOIN LATERAL (
+		     SELECT collection_id FROM collection_files
+		     WHERE file_id = f.id AND tenant_id = f.tenant_id
+		     ORDER BY added_at, collection_id LIMIT 1
+		 ) cf ON true
+		 WHERE f.tenant_id = $1 AND f.status = $2 AND f.id > $3
+		   AND f.s3_key NOT LIKE 'tenants/' || f.tenant_id || '/collections/' || cf.collection_id || '/%'
+		 ORDER BY f.id LIMIT $4`,
+		tenantID, domain.FileStatusUploaded, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fileMetaRepo.ListRelocations: %w", err)
+	}
+	return files, nil
+}
+
+func (r *fileMetaRepo) UpdateS3Key(ctx context.Context, tenantID, fileID uuid.UUID, oldKey, newKey string) error {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE file_metadata SET s3_key = $1, updated_at = $2 WHERE id = $3 AND tenant_id = $4 AND s3_key = $5",
+		newKey, time.Now().UTC(), fileID, tenantID, oldKey)
+	if err != nil {
+		return fmt.Errorf("fileMetaRepo.UpdateS3Key: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -2,9 +2,11 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/jmoiron/sqlx"
 
+	"satvos/internal/domain"
 	"satvos/internal/port"
 )
 
@@ -20,12 +22,26 @@ func NewHSNRepo(db *sqlx.DB) port.HSNRepository {
 func (r *hsnRepo) LoadAll(ctx context.Context) ([]port.HSNEntry, error) {
 	var entries []port.HSNEntry
 	err := r.db.SelectContext(ctx, &entries,
-		`SELECT code, description, gst_rate, condition_desc
+		`SELECT code, description, gst_rate, condition_desc, effective_from, effective_to
 		 FROM hsn_codes
-		 WHERE effective_to IS NULL OR effective_to >= CURRENT_DATE
-		 ORDER BY code, gst_rate`)
+		 ORDER BY code, effective_from, gst_rate`)
 	if err != nil {
 		return nil, err
 	}
 	return entries, nil
 }
+
+func (r *hsnRepo) ListCodes(ctx context.Context) ([]domain.HSNCode, error) {
+	var codes []domain.HSNCode
+	err := r.db.SelectContext(ctx, &codes,
+		`SELECT code, MIN(description) AS description, MIN(parent_code) AS parent_code,
+		        array_agg(DISTINCT gst_rate ORDER BY gst_rate) AS gst_rates
+		 FROM hsn_codes
+		 WHERE effective_to IS NULL OR effective_to >= CURRENT_DATE
+		 GROUP BY code
+		 ORDER BY code`)
+	if err != nil {
+		return nil, fmt.Errorf("hsnRepo.ListCodes: %w", err)
+	}
+	return codes, nil
+}
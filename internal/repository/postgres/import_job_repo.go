@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type importJobRepo struct {
+	db *sqlx.DB
+}
+
+// NewImportJobRepo creates a new PostgreSQL-backed ImportJobRepository.
+func NewImportJobRepo(db *sqlx.DB) port.ImportJobRepository {
+	return &importJobRepo{db: db}
+}
+
+func (r *importJobRepo) Create(ctx context.Context, job *domain.ImportJob) error {
+	now := time.Now().UTC()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	if job.Entries == nil {
+		job.Entries = []byte("[]")
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO import_jobs
+			(id, tenant_id, collection_id, created_by, source, source_ref, document_type,
+			 parse_mode, status, entries, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		job.ID, job.TenantID, job.CollectionID, job.CreatedBy, job.Source, job.SourceRef,
+		job.DocumentType, job.ParseMode, job.Status, job.Entries, job.CreatedAt, job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("importJobRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *importJobRepo) GetByID(ctx context.Context, tenantID, jobID uuid.UUID) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	err := r.db.GetContext(ctx, &job,
+		"SELECT * FROM import_jobs WHERE id = $1 AND tenant_id = $2", jobID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("importJobRepo.GetByID: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *importJobRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.ImportJob, int, error) {
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM import_jobs WHERE tenant_id = $1 AND collection_id = $2",
+		tenantID, collectionID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("importJobRepo.ListByCollection count: %w", err)
+	}
+
+	var jobs []domain.ImportJob
+	err = r.db.SelectContext(ctx, &jobs,
+		`SELECT * FROM import_jobs
+		 WHERE tenant_id = $1 AND collection_id = $2
+		 ORDER BY created_at DESC LIMIT $3 OFFSET $4`,
+		tenantID, collectionID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("importJobRepo.ListByCollection: %w", err)
+	}
+	return jobs, total, nil
+}
+
+func (r *importJobRepo) UpdateProgress(ctx context.Context, job *domain.ImportJob) error {
+	job.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE import_jobs SET
+			status = $1, total_entries = $2, imported_count = $3, skipped_count = $4,
+			failed_count = $5, entries = $6, error = $7, completed_at = $8, updated_at = $9
+		 WHERE id = $10 AND tenant_id = $11`,
+		job.Status, job.TotalEntries, job.ImportedCount, job.SkippedCount, job.FailedCount,
+		job.Entries, job.Error, job.CompletedAt, job.UpdatedAt, job.ID, job.TenantID)
+	if err != nil {
+		return fmt.Errorf("importJobRepo.UpdateProgress: %w", err)
+	}
+	return nil
+}
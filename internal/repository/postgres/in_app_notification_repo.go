@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type inAppNotificationRepo struct {
+	db *sqlx.DB
+}
+
+// NewInAppNotificationRepo creates a new PostgreSQL-backed InAppNotificationRepository.
+func NewInAppNotificationRepo(db *sqlx.DB) port.InAppNotificationRepository {
+	return &inAppNotificationRepo{db: db}
+}
+
+func (r *inAppNotificationRepo) Create(ctx context.Context, n *domain.InAppNotification) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	err := r.db.GetContext(ctx, &n.CreatedAt,
+		`INSERT INTO in_app_notifications (id, tenant_id, user_id, kind, subject, body)
+		 VALUES ($1, $2, $3, $4, $5, $6) RETURNING created_at`,
+		n.ID, n.TenantID, n.UserID, n.Kind, n.Subject, n.Body)
+	if err != nil {
+		return fmt.Errorf("inAppNotificationRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *inAppNotificationRepo) ListByUser(ctx context.Context, tenantID, userID uuid.UUID, unreadOnly bool, offset, limit int) ([]domain.InAppNotification, int, error) {
+	where := " FROM in_app_notifications WHERE tenant_id = $1 AND user_id = $2 AND (NOT $3 OR read_at IS NULL)"
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*)"+where, tenantID, userID, unreadOnly); err != nil {
+		return nil, 0, fmt.Errorf("inAppNotificationRepo.ListByUser count: %w", err)
+	}
+
+	notifications := []domain.InAppNotification{}
+	if err := r.db.SelectContext(ctx, &notifications,
+		"SELECT *"+where+" ORDER BY created_at DESC, id LIMIT $4 OFFSET $5",
+		tenantID, userID, unreadOnly, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("inAppNotificationRepo.ListByUser: %w", err)
+	}
+	return notifications, total, nil
+}
+
+func (r *inAppNotificationRepo) MarkRead(ctx context.Context, tenantID, userID, notificationID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE in_app_notifications SET read_at = COALESCE(read_at, NOW())
+		 WHERE id = $1 AND tenant_id = $2 AND user_id = $3`,
+		notificationID, tenantID, userID)
+	if err != nil {
+		return fmt.Errorf("inAppNotificationRepo.MarkRead: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type integrationRepo struct {
+	db *sqlx.DB
+}
+
+// NewIntegrationRepo creates a new PostgreSQL-backed IntegrationRepository.
+func NewIntegrationRepo(db *sqlx.DB) port.IntegrationRepository {
+	return &integrationRepo{db: db}
+}
+
+func (r *integrationRepo) CreateHook(ctx context.Context, hook *domain.IntegrationHook) error {
+	hook.CreatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO integration_hooks (id, tenant_id, user_id, event, target_url, collection_id, payload_template, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		hook.ID, hook.TenantID, hook.UserID, hook.Event, hook.TargetURL, hook.CollectionID, hook.PayloadTemplate, hook.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("integrationRepo.CreateHook: %w", err)
+	}
+	return nil
+}
+
+func (r *integrationRepo) GetHook(ctx context.Context, tenantID, hookID uuid.UUID) (*domain.IntegrationHook, error) {
+	var hook domain.IntegrationHook
+	err := r.db.GetContext(ctx, &hook,
+		"SELECT * FROM integration_hooks WHERE tenant_id = $1 AND id = $2", tenantID, hookID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("integrationRepo.GetHook: %w", err)
+	}
+	return &hook, nil
+}
+
+func (r *integrationRepo) UpdateHookTemplate(ctx context.Context, tenantID, hookID uuid.UUID, payloadTemplate *string) error {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE integration_hooks SET payload_template = $3 WHERE tenant_id = $1 AND id = $2",
+		tenantID, hookID, payloadTemplate)
+	if err != nil {
+		return fmt.Errorf("integrationRepo.UpdateHookTemplate: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *integrationRepo) DeleteHook(ctx context.Context, tenantID, hookID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM integration_hooks WHERE tenant_id = $1 AND id = $2", tenantID, hookID)
+	if err != nil {
+		return fmt.Errorf("integrationRepo.DeleteHook: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *integrationRepo) ListHooksByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.IntegrationHook, error) {
+	hooks := []domain.IntegrationHook{}
+	err := r.db.SelectContext(ctx, &hooks,
+		"SELECT * FROM integration_hooks WHERE tenant_id = $1 AND user_id = $2 ORDER BY created_at",
+		tenantID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("integrationRepo.ListHooksByUser: %w", err)
+	}
+	return hooks, nil
+}
+
+func (r *integrationRepo) ListHooksForEvent(ctx context.Context, tenantID uuid.UUID, event domain.IntegrationEvent) ([]domain.IntegrationHook, error) {
+	var hooks []domain.IntegrationHook
+	err := r.db.SelectContext(ctx, &hooks,
+		"SELECT * FROM integration_hooks WHERE tenant_id = $1 AND event = $2", tenantID, event)
+	if err != nil {
+		return nil, fmt.Errorf("integrationRepo.ListHooksForEvent: %w", err)
+	}
+	return hooks, nil
+}
+
+func (r *integrationRepo) ListApprovedSince(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID, afterAt time.Time, afterID uuid.UUID, limit int) ([]domain.Document, error) {
+	where, args := approvedWhere(tenantID, userID, explicitOnly, collectionID)
+	where += fmt.Sprintf(" AND (d.reviewed_at, d.id) > ($%d, $%d)", len(args)+1, len(args)+2)
+	args = append(args, afterAt, afterID, limit)
+
+	docs := []domain.Document{}
+	err := r.db.SelectContext(ctx, &docs,
+		"SELECT d.* "+where+fmt.Sprintf(" ORDER BY d.reviewed_at, d.id LIMIT $%d", len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("integrationRepo.ListApprovedSince: %w", err)
+	}
+	return docs, nil
+}
+
+func (r *integrationRepo) ListRecentlyApproved(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID, limit int) ([]domain.Document, error) {
+	where, args := approvedWhere(tenantID, userID, explicitOnly, collectionID)
+	args = append(args, limit)
+
+	docs := []domain.Document{}
+	err := r.db.SelectContext(ctx, &docs,
+		"SELECT d.* "+where+fmt.Sprintf(" ORDER BY d.reviewed_at DESC, d.id DESC LIMIT $%d", len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("integrationRepo.ListRecentlyApproved: %w", err)
+	}
+	return docs, nil
+}
+
+// approvedWhere builds the FROM/WHERE clause shared by the approved-document feeds.
+func approvedWhere(tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID) (string, []interface{}) {
+	where := "FROM documents d WHERE d.tenant_id = $1 AND d.review_status = 'approved' AND d.reviewed_at IS NOT NULL"
+	args := []interface{}{tenantID}
+	if explicitOnly {
+		// explicitPermCond reads the user from $2
+		where += fmt.Sprintf(explicitPermCond, "d.collection_id")
+		args = append(args, userID)
+	}
+	if collectionID != nil {
+		where += fmt.Sprintf(" AND d.collection_id = $%d", len(args)+1)
+		args = append(args, *collectionID)
+	}
+	return where, args
+}
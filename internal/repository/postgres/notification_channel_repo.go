@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type notificationChannelRepo struct {
+	db *sqlx.DB
+}
+
+// NewNotificationChannelRepo creates a new PostgreSQL-backed NotificationChannelRepository.
+func NewNotificationChannelRepo(db *sqlx.DB) port.NotificationChannelRepository {
+	return &notificationChannelRepo{db: db}
+}
+
+func (r *notificationChannelRepo) Create(ctx context.Context, ch *domain.NotificationChannel) error {
+	if ch.ID == uuid.Nil {
+		ch.ID = uuid.New()
+	}
+	now := time.Now().UTC()
+	ch.CreatedAt = now
+	ch.UpdatedAt = now
+	if ch.Templates == nil {
+		ch.Templates = json.RawMessage("{}")
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO notification_channels
+			(id, tenant_id, collection_id, provider, webhook_url, webhook_host, events, templates,
+			 review_sla_hours, enabled, sla_checked_through, next_summary_at, created_by, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`,
+		ch.ID, ch.TenantID, ch.CollectionID, ch.Provider, ch.WebhookURL, ch.WebhookHost, ch.Events, ch.Templates,
+		ch.ReviewSLAHours, ch.Enabled, ch.SLACheckedThrough, ch.NextSummaryAt, ch.CreatedBy, ch.CreatedAt, ch.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("notificationChannelRepo.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationChannelRepo) GetByID(ctx context.Context, tenantID, channelID uuid.UUID) (*domain.NotificationChannel, error) {
+	var ch domain.NotificationChannel
+	err := r.db.GetContext(ctx, &ch,
+		"SELECT * FROM notification_channels WHERE tenant_id = $1 AND id = $2", tenantID, channelID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("notificationChannelRepo.GetByID: %w", err)
+	}
+	return &ch, nil
+}
+
+func (r *notificationChannelRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID) ([]domain.NotificationChannel, error) {
+	channels := []domain.NotificationChannel{}
+	err := r.db.SelectContext(ctx, &channels,
+		"SELECT * FROM notification_channels WHERE tenant_id = $1 AND collection_id = $2 ORDER BY created_at",
+		tenantID, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("notificationChannelRepo.ListByCollection: %w", err)
+	}
+	return channels, nil
+}
+
+func (r *notificationChannelRepo) Update(ctx context.Context, ch *domain.NotificationChannel) error {
+	ch.UpdatedAt = time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE notification_channels
+		 SET provider = $1, webhook_url = $2, webhook_host = $3, events = $4, templates = $5,
+		     review_sla_hours = $6, enabled = $7, updated_at = $8
+		 WHERE id = $9 AND tenant_id = $10`,
+		ch.Provider, ch.WebhookURL, ch.WebhookHost, ch.Events, ch.Templates,
+		ch.ReviewSLAHours, ch.Enabled, ch.UpdatedAt, ch.ID, ch.TenantID)
+	if err != nil {
+		return fmt.Errorf("notificationChannelRepo.Update: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *notificationChannelRepo) Delete(ctx context.Context, tenantID, channelID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		"DELETE FROM notification_channels WHERE tenant_id = $1 AND id = $2", tenantID, channelID)
+	if err != nil {
+		return fmt.Errorf("notificationChannelRepo.Delete: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *notificationChannelRepo) ListForEvent(ctx context.Context, tenantID, collectionID uuid.UUID, event domain.NotificationEvent) ([]domain.NotificationChannel, error) {
+	var channels []domain.NotificationChannel
+	err := r.db.SelectContext(ctx, &channels,
+		`SELECT * FROM notification_channels
+		 WHERE tenant_id = $1 AND collection_id = $2 AND enabled AND $3 = ANY(events)`,
+		tenantID, collectionID, string(event))
+	if err != nil {
+		return nil, fmt.Errorf("notificationChannelRepo.ListForEvent: %w", err)
+	}
+	return channels, nil
+}
+
+func (r *notificationChannelRepo) ListScheduled(ctx context.Context) ([]domain.NotificationChannel, error) {
+	var channels []domain.NotificationChannel
+	err := r.db.SelectContext(ctx, &channels,
+		`SELECT * FROM notification_channels
+		 WHERE enabled AND events && ARRAY[$1, $2]::text[]`,
+		string(domain.NotificationEventReviewSLABreached), string(domain.NotificationEventWeeklySummary))
+	if err != nil {
+		return nil, fmt.Errorf("notificationChannelRepo.ListScheduled: %w", err)
+	}
+	return channels, nil
+}
+
+func (r *notificationChannelRepo) AdvanceSLACheck(ctx context.Context, channelID uuid.UUID, from, to time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE notification_channels SET sla_checked_through = $1 WHERE id = $2 AND sla_checked_through = $3",
+		to, channelID, from)
+	if err != nil {
+		return false, fmt.Errorf("notificationChannelRepo.AdvanceSLACheck: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+func (r *notificationChannelRepo) AdvanceSummary(ctx context.Context, channelID uuid.UUID, from, to time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE notification_channels SET next_summary_at = $1 WHERE id = $2 AND next_summary_at = $3",
+		to, channelID, from)
+	if err != nil {
+		return false, fmt.Errorf("notificationChannelRepo.AdvanceSummary: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+func (r *notificationChannelRepo) RecordDelivery(ctx context.Context, channelID uuid.UUID, at time.Time, lastError string) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE notification_channels SET last_delivered_at = $1, last_error = $2 WHERE id = $3",
+		at, lastError, channelID)
+	if err != nil {
+		return fmt.Errorf("notificationChannelRepo.RecordDelivery: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationChannelRepo) ListOverdueReviews(ctx context.Context, tenantID, collectionID uuid.UUID, after, through time.Time, limit int) ([]domain.Document, error) {
+	docs := []domain.Document{}
+	err := r.db.SelectContext(ctx, &docs,
+		`SELECT * FROM documents
+		 WHERE tenant_id = $1 AND collection_id = $2
+		   AND parsing_status = 'completed' AND review_status IN ('pending', 'awaiting_checker')
+		   AND parsed_at > $3 AND parsed_at <= $4
+		 ORDER BY parsed_at, id LIMIT $5`,
+		tenantID, collectionID, after, through, limit)
+	if err != nil {
+		return nil, fmt.Errorf("notificationChannelRepo.ListOverdueReviews: %w", err)
+	}
+	return docs, nil
+}
+
+func (r *notificationChannelRepo) CollectionActivity(ctx context.Context, tenantID, collectionID uuid.UUID, from, to time.Time) (*domain.CollectionActivity, error) {
+	var activity domain.CollectionActivity
+	err := r.db.GetContext(ctx, &activity,
+		`SELECT
+			COUNT(*) FILTER (WHERE created_at >= $3 AND created_at < $4) AS uploaded,
+			COUNT(*) FILTER (WHERE review_status = 'approved' AND reviewed_at >= $3 AND reviewed_at < $4) AS approved,
+			COUNT(*) FILTER (WHERE review_status = 'rejected' AND reviewed_at >= $3 AND reviewed_at < $4) AS rejected,
+			COUNT(*) FILTER (WHERE parsing_status = 'failed' AND updated_at >= $3 AND updated_at < $4) AS parse_failed,
+			COUNT(*) FILTER (WHERE parsing_status = 'completed' AND review_status IN ('pending', 'awaiting_checker')) AS pending_review
+		 FROM documents
+		 WHERE tenant_id = $1 AND collection_id = $2`,
+		tenantID, collectionID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("notificationChannelRepo.CollectionActivity: %w", err)
+	}
+	return &activity, nil
+}
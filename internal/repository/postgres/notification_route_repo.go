@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type notificationRouteRepo struct {
+	db *sqlx.DB
+}
+
+// NewNotificationRouteRepo creates a new PostgreSQL-backed NotificationRouteRepository.
+func NewNotificationRouteRepo(db *sqlx.DB) port.NotificationRouteRepository {
+	return &notificationRouteRepo{db: db}
+}
+
+func (r *notificationRouteRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.NotificationRoute, error) {
+	var routes []domain.NotificationRoute
+	if err := r.db.SelectContext(ctx, &routes,
+		"SELECT * FROM notification_routes WHERE tenant_id = $1 ORDER BY kind", tenantID); err != nil {
+		return nil, fmt.Errorf("notificationRouteRepo.ListByTenant: %w", err)
+	}
+	return routes, nil
+}
+
+func (r *notificationRouteRepo) Replace(ctx context.Context, tenantID uuid.UUID, routes []domain.NotificationRoute) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("notificationRouteRepo.Replace begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM notification_routes WHERE tenant_id = $1", tenantID); err != nil {
+		return fmt.Errorf("notificationRouteRepo.Replace delete: %w", err)
+	}
+	for i := range routes {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO notification_routes (tenant_id, kind, channels) VALUES ($1, $2, $3)",
+			tenantID, routes[i].Kind, routes[i].Channels); err != nil {
+			return fmt.Errorf("notificationRouteRepo.Replace insert %s: %w", routes[i].Kind, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("notificationRouteRepo.Replace commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// orphanFileWhere matches the orphan files of collection_files cf joined to
+// file_metadata fm. $1 is the tenant ID and $2 the upload cutoff.
+const orphanFileWhere = `WHERE cf.tenant_id = $1 AND fm.status = 'uploaded' AND fm.created_at < $2
+	  AND NOT EXISTS (SELECT 1 FROM documents d WHERE d.file_id = fm.id)
+	  AND NOT EXISTS (SELECT 1 FROM document_versions v WHERE v.file_id = fm.id)`
+
+type orphanFileRepo struct {
+	db *sqlx.DB
+}
+
+// NewOrphanFileRepo creates a new PostgreSQL-backed OrphanFileRepository.
+func NewOrphanFileRepo(db *sqlx.DB) port.OrphanFileRepository {
+	return &orphanFileRepo{db: db}
+}
+
+func (r *orphanFileRepo) Summarize(ctx context.Context, tenantID uuid.UUID, olderThan time.Time) ([]domain.OrphanFileSummary, error) {
+	summaries := []domain.OrphanFileSummary{}
+	err := r.db.SelectContext(ctx, &summaries,
+		`SELECT c.id AS collection_id, c.name AS collection_name, c.created_by,
+		        COUNT(*) AS file_count, MIN(fm.created_at) AS oldest_uploaded_at
+		 FROM collection_files cf
+		 INNER JOIN file_metadata fm ON fm.id = cf.file_id
+		 INNER JOIN collections c ON c.id = cf.collection_id
+		 `+orphanFileWhere+`
+		 GROUP BY c.id, c.name, c.created_by
+		 ORDER BY file_count DESC, c.name`,
+		tenantID, olderThan)
+	if err != nil {
+		return nil, fmt.Errorf("orphanFileRepo.Summarize: %w", err)
+	}
+	return summaries, nil
+}
+
+func (r *orphanFileRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, olderThan time.Time, offset, limit int) ([]domain.FileMeta, int, error) {
+	from := `FROM collection_files cf
+		 INNER JOIN file_metadata fm ON fm.id = cf.file_id
+		 ` + orphanFileWhere + ` AND cf.collection_id = $3`
+
+	var total int
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) "+from, tenantID, olderThan, collectionID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("orphanFileRepo.ListByCollection count: %w", err)
+	}
+
+	var files []domain.FileMeta
+	err = r.db.SelectContext(ctx, &files,
+		"SELECT fm.* "+from+" ORDER BY fm.created_at ASC, fm.id LIMIT $4 OFFSET $5",
+		tenantID, olderThan, collectionID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("orphanFileRepo.ListByCollection: %w", err)
+	}
+	return files, total, nil
+}
+
+func (r *orphanFileRepo) ClaimNudge(ctx context.Context, tenantID, collectionID uuid.UUID, fileCount int, notSince time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO collection_orphan_nudges (collection_id, tenant_id, file_count, nudged_at)
+		 VALUES ($1, $2, $3, NOW())
+		 ON CONFLICT (collection_id) DO UPDATE
+		 SET file_count = EXCLUDED.file_count, nudged_at = EXCLUDED.nudged_at
+		 WHERE collection_orphan_nudges.nudged_at <= $4`,
+		collectionID, tenantID, fileCount, notSince)
+	if err != nil {
+		return false, fmt.Errorf("orphanFileRepo.ClaimNudge: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type parseBudgetRepo struct {
+	db *sqlx.DB
+}
+
+// NewParseBudgetRepo creates a new PostgreSQL-backed ParseBudgetRepository.
+func NewParseBudgetRepo(db *sqlx.DB) port.ParseBudgetRepository {
+	return &parseBudgetRepo{db: db}
+}
+
+func (r *parseBudgetRepo) Reserve(ctx context.Context, tenantID, userID uuid.UUID, day time.Time, limit int) error {
+	// Single atomic upsert: the first parse of the day inserts, later ones
+	// increment only while under the limit
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO parse_budget_usage (tenant_id, user_id, day, calls)
+		 SELECT $1, $2, $3, 1 WHERE $4 > 0
+		 ON CONFLICT (tenant_id, user_id, day)
+		 DO UPDATE SET calls = parse_budget_usage.calls + 1
+		 WHERE parse_budget_usage.calls < $4`,
+		tenantID, userID, day.Format("2006-01-02"), limit)
+	if err != nil {
+		return fmt.Errorf("parseBudgetRepo.Reserve: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrParseBudgetExhausted
+	}
+	return nil
+}
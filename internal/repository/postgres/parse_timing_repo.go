@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type parseTimingRepo struct {
+	db *sqlx.DB
+}
+
+// NewParseTimingRepo creates a new PostgreSQL-backed ParseTimingRepository.
+func NewParseTimingRepo(db *sqlx.DB) port.ParseTimingRepository {
+	return &parseTimingRepo{db: db}
+}
+
+func (r *parseTimingRepo) Record(ctx context.Context, timing *domain.ParseTiming) error {
+	if timing.ID == uuid.Nil {
+		timing.ID = uuid.New()
+	}
+	timing.CreatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO parse_timings (id, tenant_id, document_id, attempt, parser_model, secondary_parser_model, parse_mode,
+			outcome, queue_ms, parse_ms, failure_category, error_message, input_tokens, output_tokens, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`,
+		timing.ID, timing.TenantID, timing.DocumentID, timing.Attempt, timing.ParserModel, timing.SecondaryParserModel,
+		timing.ParseMode, timing.Outcome, timing.QueueMs, timing.ParseMs, timing.FailureCategory, timing.ErrorMessage,
+		timing.InputTokens, timing.OutputTokens, timing.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("parseTimingRepo.Record: %w", err)
+	}
+	return nil
+}
+
+func (r *parseTimingRepo) ListByDocument(ctx context.Context, tenantID, documentID uuid.UUID) ([]domain.ParseTiming, error) {
+	timings := []domain.ParseTiming{}
+	err := r.db.SelectContext(ctx, &timings,
+		`SELECT * FROM parse_timings
+		 WHERE tenant_id = $1 AND document_id = $2
+		 ORDER BY created_at`,
+		tenantID, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("parseTimingRepo.ListByDocument: %w", err)
+	}
+	return timings, nil
+}
+
+// Parse-duration percentiles only count completed attempts, so fast failures
+// (bad file, auth error) don't mask a slow provider. Attempts that failed before a
+// model answered are grouped under "unknown".
+const latencyByModelQuery = `SELECT
+	COALESCE(NULLIF(parser_model, ''), 'unknown') AS parser_model,
+	COUNT(*) AS attempts,
+	COUNT(CASE WHEN outcome = 'failed' THEN 1 END) AS failed,
+	COUNT(CASE WHEN failure_category = 'timeout' THEN 1 END) AS timed_out,
+	COALESCE(percentile_cont(0.50) WITHIN GROUP (ORDER BY parse_ms) FILTER (WHERE outcome = 'completed'), 0) AS parse_p50_ms,
+	COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY parse_ms) FILTER (WHERE outcome = 'completed'), 0) AS parse_p95_ms,
+	COALESCE(percentile_cont(0.99) WITHIN GROUP (ORDER BY parse_ms) FILTER (WHERE outcome = 'completed'), 0) AS parse_p99_ms,
+	percentile_cont(0.50) WITHIN GROUP (ORDER BY queue_ms) AS queue_p50_ms,
+	percentile_cont(0.95) WITHIN GROUP (ORDER BY queue_ms) AS queue_p95_ms,
+	percentile_cont(0.99) WITHIN GROUP (ORDER BY queue_ms) AS queue_p99_ms
+FROM parse_timings
+WHERE created_at >= $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+GROUP BY 1
+ORDER BY 1`
+
+func (r *parseTimingRepo) LatencyByModel(ctx context.Context, tenantID *uuid.UUID, since time.Time) ([]domain.ParseLatencyStats, error) {
+	stats := []domain.ParseLatencyStats{}
+	if err := r.db.SelectContext(ctx, &stats, latencyByModelQuery, since, tenantID); err != nil {
+		return nil, fmt.Errorf("parseTimingRepo.LatencyByModel: %w", err)
+	}
+	return stats, nil
+}
+
+func (r *parseTimingRepo) OldestWaiting(ctx context.Context) (time.Duration, error) {
+	var secs float64
+	err := r.db.GetContext(ctx, &secs,
+		`SELECT COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(queued_at)), 0)
+		 FROM documents
+		 WHERE parsing_status IN ('pending', 'queued') AND queued_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("parseTimingRepo.OldestWaiting: %w", err)
+	}
+	return time.Duration(secs * float64(time.Second)), nil
+}
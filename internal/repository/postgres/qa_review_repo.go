@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type qaReviewRepo struct {
+	db *sqlx.DB
+}
+
+// NewQAReviewRepo creates a new PostgreSQL-backed QAReviewRepository.
+func NewQAReviewRepo(db *sqlx.DB) port.QAReviewRepository {
+	return &qaReviewRepo{db: db}
+}
+
+func (r *qaReviewRepo) Create(ctx context.Context, q *domain.QAReview) (bool, error) {
+	q.SampledAt = time.Now().UTC()
+	q.Status = domain.QAReviewStatusPending
+	if q.DisputedFields == nil {
+		q.DisputedFields = json.RawMessage("[]")
+	}
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO qa_reviews (id, tenant_id, document_id, collection_id, reviewer_id, maker_id, status, disputed_fields, sampled_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 ON CONFLICT (document_id) DO NOTHING`,
+		q.ID, q.TenantID, q.DocumentID, q.CollectionID, q.ReviewerID, q.MakerID, q.Status, q.DisputedFields, q.SampledAt)
+	if err != nil {
+		return false, fmt.Errorf("qaReviewRepo.Create: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+func (r *qaReviewRepo) GetByID(ctx context.Context, tenantID, reviewID uuid.UUID) (*domain.QAReview, error) {
+	var q domain.QAReview
+	err := r.db.GetContext(ctx, &q,
+		"SELECT * FROM qa_reviews WHERE id = $1 AND tenant_id = $2", reviewID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrQAReviewNotFound
+		}
+		return nil, fmt.Errorf("qaReviewRepo.GetByID: %w", err)
+	}
+	return &q, nil
+}
+
+func (r *qaReviewRepo) ListPending(ctx context.Context, tenantID, userID uuid.UUID, ownedOnly bool, offset, limit int) ([]domain.QASample, int, error) {
+	baseWhere := `WHERE q.tenant_id = $1 AND q.status = 'pending'
+		AND q.reviewer_id <> $2 AND q.maker_id IS DISTINCT FROM $2`
+	if ownedOnly {
+		baseWhere += ` AND EXISTS (SELECT 1 FROM collection_permissions cp
+			WHERE cp.collection_id = q.collection_id AND cp.user_id = $2 AND cp.permission = 'owner')`
+	}
+
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM qa_reviews q "+baseWhere, tenantID, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("qaReviewRepo.ListPending count: %w", err)
+	}
+
+	samples := []domain.QASample{}
+	err = r.db.SelectContext(ctx, &samples,
+		`SELECT q.id, q.document_id, q.collection_id, d.file_id, d.name AS document_name,
+		        d.document_type, d.structured_data, q.sampled_at
+		 FROM qa_reviews q JOIN documents d ON d.id = q.document_id `+baseWhere+
+			" ORDER BY q.sampled_at ASC, q.id LIMIT $3 OFFSET $4",
+		tenantID, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("qaReviewRepo.ListPending: %w", err)
+	}
+	return samples, total, nil
+}
+
+func (r *qaReviewRepo) Complete(ctx context.Context, q *domain.QAReview) error {
+	now := time.Now().UTC()
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE qa_reviews SET status = 'completed', second_reviewer_id = $1, decision = $2,
+		     disputed_fields = $3, disagreed = $4, notes = $5, completed_at = $6
+		 WHERE id = $7 AND tenant_id = $8 AND status = 'pending'`,
+		q.SecondReviewerID, q.Decision, q.DisputedFields, q.Disagreed, q.Notes, now, q.ID, q.TenantID)
+	if err != nil {
+		return fmt.Errorf("qaReviewRepo.Complete: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrQAReviewCompleted
+	}
+	q.Status = domain.QAReviewStatusCompleted
+	q.CompletedAt = &now
+	return nil
+}
+
+func (r *qaReviewRepo) ScoresByReviewer(ctx context.Context, tenantID uuid.UUID, since time.Time) ([]domain.QAReviewerScore, error) {
+	scores := []domain.QAReviewerScore{}
+	err := r.db.SelectContext(ctx, &scores,
+		`SELECT reviewer_id,
+		        COUNT(*) AS sampled,
+		        COUNT(CASE WHEN status = 'completed' THEN 1 END) AS completed,
+		        COUNT(CASE WHEN disagreed THEN 1 END) AS disagreements
+		 FROM qa_reviews
+		 WHERE tenant_id = $1 AND sampled_at >= $2
+		 GROUP BY reviewer_id
+		 ORDER BY reviewer_id`,
+		tenantID, since)
+	if err != nil {
+		return nil, fmt.Errorf("qaReviewRepo.ScoresByReviewer: %w", err)
+	}
+	return scores, nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type rejectionReasonRepo struct {
+	db *sqlx.DB
+}
+
+// NewRejectionReasonRepo creates a new PostgreSQL-backed RejectionReasonRepository.
+func NewRejectionReasonRepo(db *sqlx.DB) port.RejectionReasonRepository {
+	return &rejectionReasonRepo{db: db}
+}
+
+func (r *rejectionReasonRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.RejectionReason, error) {
+	var reasons []domain.RejectionReason
+	err := r.db.SelectContext(ctx, &reasons,
+		`SELECT * FROM rejection_reasons WHERE tenant_id = $1 ORDER BY code`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("rejectionReasonRepo.ListByTenant: %w", err)
+	}
+	return reasons, nil
+}
+
+func (r *rejectionReasonRepo) Upsert(ctx context.Context, reason *domain.RejectionReason) error {
+	err := r.db.QueryRowxContext(ctx,
+		`INSERT INTO rejection_reasons (tenant_id, code, label, description, active, sort_order, updated_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (tenant_id, code) DO UPDATE
+		 SET label = EXCLUDED.label, description = EXCLUDED.description, active = EXCLUDED.active,
+		     sort_order = EXCLUDED.sort_order, updated_by = EXCLUDED.updated_by, updated_at = NOW()
+		 RETURNING created_at, updated_at`,
+		reason.TenantID, reason.Code, reason.Label, reason.Description, reason.Active, reason.SortOrder, reason.UpdatedBy).
+		Scan(&reason.CreatedAt, &reason.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("rejectionReasonRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *rejectionReasonRepo) Stats(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.RejectionReasonStat, error) {
+	where := "WHERE d.tenant_id = $1 AND d.review_status = 'rejected'"
+	args := []interface{}{tenantID}
+	// From and To are dates; their days start at midnight in the tenant's time zone
+	const tenantZone = "(SELECT time_zone FROM tenants WHERE id = $1)"
+	if filters.From != nil {
+		args = append(args, filters.From.Format("2006-01-02"))
+		where += fmt.Sprintf(" AND d.reviewed_at >= ($%d::date)::timestamp AT TIME ZONE %s", len(args), tenantZone)
+	}
+	if filters.To != nil {
+		// Include the whole day
+		args = append(args, filters.To.Format("2006-01-02"))
+		where += fmt.Sprintf(" AND d.reviewed_at < ($%d::date + 1)::timestamp AT TIME ZONE %s", len(args), tenantZone)
+	}
+	if filters.CollectionID != nil {
+		args = append(args, *filters.CollectionID)
+		where += fmt.Sprintf(" AND d.collection_id = $%d", len(args))
+	}
+	// Viewer and free roles need collection permission scoping
+	if filters.UserRole != domain.RoleAdmin && filters.UserRole != domain.RoleManager && filters.UserRole != domain.RoleMember {
+		args = append(args, filters.UserID)
+		where += fmt.Sprintf(" AND d.collection_id IN (SELECT collection_id FROM collection_permissions WHERE user_id = $%d)", len(args))
+	}
+
+	query := fmt.Sprintf(`SELECT
+		d.rejection_reason AS reason_code,
+		d.parser_model,
+		COUNT(*) AS rejected_count,
+		ROUND(COUNT(*)::numeric / SUM(COUNT(*)) OVER () * 100, 1) AS share_pct
+	FROM documents d
+	%s
+	GROUP BY d.rejection_reason, d.parser_model
+	ORDER BY rejected_count DESC, reason_code, d.parser_model`, where)
+
+	stats := []domain.RejectionReasonStat{}
+	if err := r.db.SelectContext(ctx, &stats, query, args...); err != nil {
+		return nil, fmt.Errorf("rejectionReasonRepo.Stats: %w", err)
+	}
+	return stats, nil
+}
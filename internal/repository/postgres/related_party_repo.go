@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type relatedPartyRepo struct {
+	db *sqlx.DB
+}
+
+// NewRelatedPartyRepo creates a new PostgreSQL-backed RelatedPartyRepository.
+func NewRelatedPartyRepo(db *sqlx.DB) port.RelatedPartyRepository {
+	return &relatedPartyRepo{db: db}
+}
+
+func (r *relatedPartyRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.RelatedParty, error) {
+	parties := []domain.RelatedParty{}
+	err := r.db.SelectContext(ctx, &parties,
+		`SELECT * FROM related_parties WHERE tenant_id = $1 ORDER BY gstin`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("relatedPartyRepo.ListByTenant: %w", err)
+	}
+	return parties, nil
+}
+
+func (r *relatedPartyRepo) FindRelatedParty(ctx context.Context, tenantID uuid.UUID, gstin string) (*domain.RelatedParty, error) {
+	var party domain.RelatedParty
+	err := r.db.GetContext(ctx, &party,
+		`SELECT * FROM related_parties WHERE tenant_id = $1 AND gstin = $2`, tenantID, gstin)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("relatedPartyRepo.FindRelatedParty: %w", err)
+	}
+	return &party, nil
+}
+
+func (r *relatedPartyRepo) Upsert(ctx context.Context, party *domain.RelatedParty) error {
+	err := r.db.QueryRowxContext(ctx,
+		`INSERT INTO related_parties (tenant_id, gstin, name, relationship, updated_by)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (tenant_id, gstin) DO UPDATE
+		 SET name = EXCLUDED.name, relationship = EXCLUDED.relationship,
+		     updated_by = EXCLUDED.updated_by, updated_at = NOW()
+		 RETURNING created_at, updated_at`,
+		party.TenantID, party.GSTIN, party.Name, party.Relationship, party.UpdatedBy).
+		Scan(&party.CreatedAt, &party.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("relatedPartyRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *relatedPartyRepo) Delete(ctx context.Context, tenantID uuid.UUID, gstin string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM related_parties WHERE tenant_id = $1 AND gstin = $2`, tenantID, gstin)
+	if err != nil {
+		return fmt.Errorf("relatedPartyRepo.Delete: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("relatedPartyRepo.Delete: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -239,6 +239,62 @@ func (r *reportRepo) PartyLedger(ctx context.Context, tenantID uuid.UUID, gstin
 	return rows, total, nil
 }
 
+func (r *reportRepo) AmountMatches(ctx context.Context, tenantID uuid.UUID, target, minAmount, maxAmount float64, party string, filters *domain.ReportFilters) ([]domain.AmountMatchRow, int, error) {
+	whereClause, args := buildWhereClause(tenantID, filters)
+
+	argN := len(args) + 1
+	amountFilter := fmt.Sprintf(" AND ds.total_amount BETWEEN $%d AND $%d", argN, argN+1)
+	args = append(args, minAmount, maxAmount)
+	argN += 2
+	if party != "" {
+		amountFilter += fmt.Sprintf(` AND (ds.seller_gstin = $%d OR ds.buyer_gstin = $%d
+		OR ds.seller_name ILIKE $%d OR ds.buyer_name ILIKE $%d)`, argN, argN, argN+1, argN+1)
+		args = append(args, party, likeContains(party))
+		argN += 2
+	}
+	countArgs := args
+	// The target only orders the results, so the count query leaves it out
+	targetParam := fmt.Sprintf("$%d::numeric", argN)
+	dataArgs := append(args[:len(args):len(args)], target)
+
+	dataQuery := fmt.Sprintf(`SELECT
+		ds.document_id,
+		d.name AS document_name,
+		ds.collection_id,
+		COALESCE(ds.invoice_number, '') AS invoice_number,
+		ds.invoice_date,
+		COALESCE(ds.seller_name, '') AS seller_name,
+		COALESCE(ds.seller_gstin, '') AS seller_gstin,
+		COALESCE(ds.buyer_name, '') AS buyer_name,
+		COALESCE(ds.buyer_gstin, '') AS buyer_gstin,
+		ds.total_amount,
+		ds.total_amount - %s AS difference,
+		d.review_status
+	FROM document_summaries ds
+	JOIN documents d ON d.id = ds.document_id
+	%s
+	%s
+	ORDER BY ABS(ds.total_amount - %s) ASC, ds.invoice_date DESC NULLS LAST, ds.document_id
+	OFFSET %d LIMIT %d`, targetParam, whereClause, amountFilter, targetParam, filters.Offset, filters.Limit)
+
+	rows := []domain.AmountMatchRow{}
+	if err := sqlx.SelectContext(ctx, r.db, &rows, dataQuery, dataArgs...); err != nil {
+		return nil, 0, fmt.Errorf("reportRepo.AmountMatches data: %w", err)
+	}
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*)
+	FROM document_summaries ds
+	%s
+	%s`, whereClause, amountFilter)
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, countQuery, countArgs...); err != nil {
+		return nil, 0, fmt.Errorf("reportRepo.AmountMatches count: %w", err)
+	}
+
+	return rows, total, nil
+}
+
 // financialSummaryDBRow is an intermediate struct for scanning time-series results.
 type financialSummaryDBRow struct {
 	PeriodStart   time.Time `db:"period_start"`
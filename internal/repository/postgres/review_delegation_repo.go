@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type reviewDelegationRepo struct {
+	db *sqlx.DB
+}
+
+// NewReviewDelegationRepo creates a new PostgreSQL-backed ReviewDelegationRepository.
+func NewReviewDelegationRepo(db *sqlx.DB) port.ReviewDelegationRepository {
+	return &reviewDelegationRepo{db: db}
+}
+
+func (r *reviewDelegationRepo) Upsert(ctx context.Context, d *domain.ReviewDelegation) error {
+	err := r.db.QueryRowxContext(ctx,
+		`INSERT INTO review_delegations (tenant_id, user_id, delegate_id, starts_on, ends_on, share_queue)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (tenant_id, user_id) DO UPDATE
+		 SET delegate_id = EXCLUDED.delegate_id, starts_on = EXCLUDED.starts_on, ends_on = EXCLUDED.ends_on,
+		     share_queue = EXCLUDED.share_queue, updated_at = NOW()
+		 RETURNING created_at, updated_at`,
+		d.TenantID, d.UserID, d.DelegateID, d.StartsOn, d.EndsOn, d.ShareQueue).
+		Scan(&d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("reviewDelegationRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *reviewDelegationRepo) GetByUser(ctx context.Context, tenantID, userID uuid.UUID) (*domain.ReviewDelegation, error) {
+	var d domain.ReviewDelegation
+	err := r.db.GetContext(ctx, &d,
+		`SELECT * FROM review_delegations WHERE tenant_id = $1 AND user_id = $2`, tenantID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrDelegationNotFound
+		}
+		return nil, fmt.Errorf("reviewDelegationRepo.GetByUser: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *reviewDelegationRepo) Delete(ctx context.Context, tenantID, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM review_delegations WHERE tenant_id = $1 AND user_id = $2`, tenantID, userID)
+	if err != nil {
+		return fmt.Errorf("reviewDelegationRepo.Delete: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrDelegationNotFound
+	}
+	return nil
+}
+
+func (r *reviewDelegationRepo) ListActiveForDelegate(ctx context.Context, tenantID, delegateID uuid.UUID, at time.Time) ([]domain.ReviewDelegation, error) {
+	var ds []domain.ReviewDelegation
+	err := r.db.SelectContext(ctx, &ds,
+		`SELECT * FROM review_delegations
+		 WHERE tenant_id = $1 AND delegate_id = $2 AND $3::date BETWEEN starts_on AND ends_on
+		 ORDER BY user_id`,
+		tenantID, delegateID, at.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("reviewDelegationRepo.ListActiveForDelegate: %w", err)
+	}
+	return ds, nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type reviewEscalationRepo struct {
+	db *sqlx.DB
+}
+
+// NewReviewEscalationRepo creates a new PostgreSQL-backed ReviewEscalationRepository.
+func NewReviewEscalationRepo(db *sqlx.DB) port.ReviewEscalationRepository {
+	return &reviewEscalationRepo{db: db}
+}
+
+func (r *reviewEscalationRepo) ClaimDue(ctx context.Context, now time.Time, limit int) ([]domain.Document, error) {
+	var docs []domain.Document
+	err := r.db.SelectContext(ctx, &docs,
+		`UPDATE documents
+		 SET escalated_at = $1, updated_at = $1
+		 WHERE id IN (
+		     SELECT d.id FROM documents d
+		     JOIN collections c ON c.id = d.collection_id
+		     WHERE c.escalate_after_days IS NOT NULL
+		       AND d.parsing_status = 'completed' AND d.review_status = 'pending'
+		       AND d.escalated_at IS NULL
+		       AND d.parsed_at <= $1 - make_interval(days => c.escalate_after_days)
+		     ORDER BY d.parsed_at ASC
+		     LIMIT $2
+		     FOR UPDATE OF d SKIP LOCKED
+		 )
+		 RETURNING *`,
+		now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("reviewEscalationRepo.ClaimDue: %w", err)
+	}
+	return docs, nil
+}
+
+func (r *reviewEscalationRepo) ListEscalated(ctx context.Context, tenantID, userID uuid.UUID, ownedOnly bool, offset, limit int) ([]domain.Document, int, error) {
+	baseWhere := `WHERE d.tenant_id = $1 AND d.escalated_at IS NOT NULL AND d.review_status = 'pending'`
+	args := []interface{}{tenantID}
+	if ownedOnly {
+		args = append(args, userID)
+		baseWhere += ` AND EXISTS (SELECT 1 FROM collection_permissions cp
+			WHERE cp.collection_id = d.collection_id AND cp.user_id = $2 AND cp.permission = 'owner')`
+	}
+
+	var total int
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM documents d "+baseWhere, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reviewEscalationRepo.ListEscalated count: %w", err)
+	}
+
+	var docs []domain.Document
+	n := len(args)
+	err = r.db.SelectContext(ctx, &docs,
+		"SELECT d.* FROM documents d "+baseWhere+
+			fmt.Sprintf(" ORDER BY d.escalated_at ASC, d.id LIMIT $%d OFFSET $%d", n+1, n+2),
+		append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reviewEscalationRepo.ListEscalated: %w", err)
+	}
+	return docs, total, nil
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type starRepo struct {
+	db *sqlx.DB
+}
+
+// NewStarRepo creates a new PostgreSQL-backed StarRepository.
+func NewStarRepo(db *sqlx.DB) port.StarRepository {
+	return &starRepo{db: db}
+}
+
+func (r *starRepo) StarDocument(ctx context.Context, tenantID, userID, documentID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO document_stars (tenant_id, user_id, document_id) VALUES ($1, $2, $3)
+		 ON CONFLICT (user_id, document_id) DO NOTHING`,
+		tenantID, userID, documentID)
+	if err != nil {
+		return fmt.Errorf("starRepo.StarDocument: %w", err)
+	}
+	return nil
+}
+
+func (r *starRepo) UnstarDocument(ctx context.Context, tenantID, userID, documentID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		"DELETE FROM document_stars WHERE tenant_id = $1 AND user_id = $2 AND document_id = $3",
+		tenantID, userID, documentID)
+	if err != nil {
+		return fmt.Errorf("starRepo.UnstarDocument: %w", err)
+	}
+	return nil
+}
+
+func (r *starRepo) StarCollection(ctx context.Context, tenantID, userID, collectionID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO collection_stars (tenant_id, user_id, collection_id) VALUES ($1, $2, $3)
+		 ON CONFLICT (user_id, collection_id) DO NOTHING`,
+		tenantID, userID, collectionID)
+	if err != nil {
+		return fmt.Errorf("starRepo.StarCollection: %w", err)
+	}
+	return nil
+}
+
+func (r *starRepo) UnstarCollection(ctx context.Context, tenantID, userID, collectionID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		"DELETE FROM collection_stars WHERE tenant_id = $1 AND user_id = $2 AND collection_id = $3",
+		tenantID, userID, collectionID)
+	if err != nil {
+		return fmt.Errorf("starRepo.UnstarCollection: %w", err)
+	}
+	return nil
+}
+
+// explicitPermCond restricts rows to collections where $2 holds an explicit permission.
+const explicitPermCond = ` AND EXISTS (SELECT 1 FROM collection_permissions cp
+	WHERE cp.collection_id = %s AND cp.user_id = $2)`
+
+func (r *starRepo) ListStarredDocuments(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, offset, limit int) ([]domain.Document, int, error) {
+	baseWhere := "FROM document_stars st JOIN documents d ON d.id = st.document_id WHERE st.tenant_id = $1 AND st.user_id = $2"
+	if explicitOnly {
+		baseWhere += fmt.Sprintf(explicitPermCond, "d.collection_id")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) "+baseWhere, tenantID, userID); err != nil {
+		return nil, 0, fmt.Errorf("starRepo.ListStarredDocuments count: %w", err)
+	}
+
+	var docs []domain.Document
+	err := r.db.SelectContext(ctx, &docs,
+		"SELECT d.* "+baseWhere+" ORDER BY st.created_at DESC LIMIT $3 OFFSET $4",
+		tenantID, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("starRepo.ListStarredDocuments: %w", err)
+	}
+	return docs, total, nil
+}
+
+func (r *starRepo) ListStarredCollections(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, offset, limit int) ([]domain.Collection, int, error) {
+	baseWhere := "FROM collection_stars st JOIN collections c ON c.id = st.collection_id WHERE st.tenant_id = $1 AND st.user_id = $2"
+	if explicitOnly {
+		baseWhere += fmt.Sprintf(explicitPermCond, "c.id")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) "+baseWhere, tenantID, userID); err != nil {
+		return nil, 0, fmt.Errorf("starRepo.ListStarredCollections count: %w", err)
+	}
+
+	var collections []domain.Collection
+	err := r.db.SelectContext(ctx, &collections,
+		"SELECT c.* "+baseWhere+" ORDER BY st.created_at DESC LIMIT $3 OFFSET $4",
+		tenantID, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("starRepo.ListStarredCollections: %w", err)
+	}
+	return collections, total, nil
+}
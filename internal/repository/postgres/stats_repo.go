@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -20,8 +21,8 @@ func NewStatsRepo(db *sqlx.DB) port.StatsRepository {
 	return &statsRepo{db: db}
 }
 
-const tenantDocStatsQuery = `SELECT
-	COUNT(*) AS total_documents,
+// docStatsAggregates computes the Stats counters over a set of documents.
+const docStatsAggregates = `COUNT(*) AS total_documents,
 	COUNT(CASE WHEN parsing_status = 'completed' THEN 1 END) AS parsing_completed,
 	COUNT(CASE WHEN parsing_status = 'failed' THEN 1 END) AS parsing_failed,
 	COUNT(CASE WHEN parsing_status = 'processing' THEN 1 END) AS parsing_processing,
@@ -35,32 +36,37 @@ const tenantDocStatsQuery = `SELECT
 	COUNT(CASE WHEN reconciliation_status = 'invalid' THEN 1 END) AS reconciliation_invalid,
 	COUNT(CASE WHEN review_status = 'pending' THEN 1 END) AS review_pending,
 	COUNT(CASE WHEN review_status = 'approved' THEN 1 END) AS review_approved,
-	COUNT(CASE WHEN review_status = 'rejected' THEN 1 END) AS review_rejected
-FROM documents WHERE tenant_id = $1`
-
-const userDocStatsQuery = `SELECT
-	COUNT(*) AS total_documents,
-	COUNT(CASE WHEN d.parsing_status = 'completed' THEN 1 END) AS parsing_completed,
-	COUNT(CASE WHEN d.parsing_status = 'failed' THEN 1 END) AS parsing_failed,
-	COUNT(CASE WHEN d.parsing_status = 'processing' THEN 1 END) AS parsing_processing,
-	COUNT(CASE WHEN d.parsing_status = 'pending' THEN 1 END) AS parsing_pending,
-	COUNT(CASE WHEN d.parsing_status = 'queued' THEN 1 END) AS parsing_queued,
-	COUNT(CASE WHEN d.validation_status = 'valid' THEN 1 END) AS validation_valid,
-	COUNT(CASE WHEN d.validation_status = 'warning' THEN 1 END) AS validation_warning,
-	COUNT(CASE WHEN d.validation_status = 'invalid' THEN 1 END) AS validation_invalid,
-	COUNT(CASE WHEN d.reconciliation_status = 'valid' THEN 1 END) AS reconciliation_valid,
-	COUNT(CASE WHEN d.reconciliation_status = 'warning' THEN 1 END) AS reconciliation_warning,
-	COUNT(CASE WHEN d.reconciliation_status = 'invalid' THEN 1 END) AS reconciliation_invalid,
-	COUNT(CASE WHEN d.review_status = 'pending' THEN 1 END) AS review_pending,
-	COUNT(CASE WHEN d.review_status = 'approved' THEN 1 END) AS review_approved,
-	COUNT(CASE WHEN d.review_status = 'rejected' THEN 1 END) AS review_rejected
-FROM documents d
-INNER JOIN collection_permissions cp ON cp.collection_id = d.collection_id
-WHERE d.tenant_id = $1 AND cp.user_id = $2`
+	COUNT(CASE WHEN review_status = 'rejected' THEN 1 END) AS review_rejected,
+	COUNT(CASE WHEN review_status = 'awaiting_checker' THEN 1 END) AS review_awaiting_checker`
+
+// dailyStatsSums adds up materialized buckets into the Stats counters.
+const dailyStatsSums = `COALESCE(SUM(total_documents), 0) AS total_documents,
+	COALESCE(SUM(parsing_completed), 0) AS parsing_completed,
+	COALESCE(SUM(parsing_failed), 0) AS parsing_failed,
+	COALESCE(SUM(parsing_processing), 0) AS parsing_processing,
+	COALESCE(SUM(parsing_pending), 0) AS parsing_pending,
+	COALESCE(SUM(parsing_queued), 0) AS parsing_queued,
+	COALESCE(SUM(validation_valid), 0) AS validation_valid,
+	COALESCE(SUM(validation_warning), 0) AS validation_warning,
+	COALESCE(SUM(validation_invalid), 0) AS validation_invalid,
+	COALESCE(SUM(reconciliation_valid), 0) AS reconciliation_valid,
+	COALESCE(SUM(reconciliation_warning), 0) AS reconciliation_warning,
+	COALESCE(SUM(reconciliation_invalid), 0) AS reconciliation_invalid,
+	COALESCE(SUM(review_pending), 0) AS review_pending,
+	COALESCE(SUM(review_approved), 0) AS review_approved,
+	COALESCE(SUM(review_rejected), 0) AS review_rejected,
+	COALESCE(SUM(review_awaiting_checker), 0) AS review_awaiting_checker`
+
+const dailyStatsCounterColumns = `total_documents,
+	parsing_completed, parsing_failed, parsing_processing, parsing_pending, parsing_queued,
+	validation_valid, validation_warning, validation_invalid,
+	reconciliation_valid, reconciliation_warning, reconciliation_invalid,
+	review_pending, review_approved, review_rejected, review_awaiting_checker`
 
 func (r *statsRepo) GetTenantStats(ctx context.Context, tenantID uuid.UUID) (*domain.Stats, error) {
 	var stats domain.Stats
-	if err := r.db.GetContext(ctx, &stats, tenantDocStatsQuery, tenantID); err != nil {
+	if err := r.db.GetContext(ctx, &stats,
+		"SELECT "+dailyStatsSums+" FROM document_daily_stats WHERE tenant_id = $1", tenantID); err != nil {
 		return nil, fmt.Errorf("statsRepo.GetTenantStats docs: %w", err)
 	}
 
@@ -76,7 +82,11 @@ func (r *statsRepo) GetTenantStats(ctx context.Context, tenantID uuid.UUID) (*do
 
 func (r *statsRepo) GetUserStats(ctx context.Context, tenantID, userID uuid.UUID) (*domain.Stats, error) {
 	var stats domain.Stats
-	if err := r.db.GetContext(ctx, &stats, userDocStatsQuery, tenantID, userID); err != nil {
+	if err := r.db.GetContext(ctx, &stats,
+		`SELECT `+dailyStatsSums+` FROM document_daily_stats
+		 WHERE tenant_id = $1
+		   AND collection_id IN (SELECT collection_id FROM collection_permissions WHERE user_id = $2)`,
+		tenantID, userID); err != nil {
 		return nil, fmt.Errorf("statsRepo.GetUserStats docs: %w", err)
 	}
 
@@ -91,3 +101,188 @@ func (r *statsRepo) GetUserStats(ctx context.Context, tenantID, userID uuid.UUID
 
 	return &stats, nil
 }
+
+func (r *statsRepo) RefreshDay(ctx context.Context, tenantID, collectionID uuid.UUID, day time.Time) error {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	var s domain.Stats
+	if err := r.db.GetContext(ctx, &s,
+		`SELECT `+docStatsAggregates+` FROM documents
+		 WHERE tenant_id = $1 AND collection_id = $2 AND created_at >= $3 AND created_at < $4`,
+		tenantID, collectionID, start, end); err != nil {
+		return fmt.Errorf("statsRepo.RefreshDay count: %w", err)
+	}
+
+	if s.TotalDocuments == 0 {
+		if _, err := r.db.ExecContext(ctx,
+			"DELETE FROM document_daily_stats WHERE tenant_id = $1 AND collection_id = $2 AND day = $3",
+			tenantID, collectionID, start); err != nil {
+			return fmt.Errorf("statsRepo.RefreshDay delete: %w", err)
+		}
+		return nil
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO document_daily_stats (tenant_id, collection_id, day, `+dailyStatsCounterColumns+`, refreshed_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NOW())
+		 ON CONFLICT (tenant_id, collection_id, day) DO UPDATE SET
+		     total_documents = EXCLUDED.total_documents,
+		     parsing_completed = EXCLUDED.parsing_completed,
+		     parsing_failed = EXCLUDED.parsing_failed,
+		     parsing_processing = EXCLUDED.parsing_processing,
+		     parsing_pending = EXCLUDED.parsing_pending,
+		     parsing_queued = EXCLUDED.parsing_queued,
+		     validation_valid = EXCLUDED.validation_valid,
+		     validation_warning = EXCLUDED.validation_warning,
+		     validation_invalid = EXCLUDED.validation_invalid,
+		     reconciliation_valid = EXCLUDED.reconciliation_valid,
+		     reconciliation_warning = EXCLUDED.reconciliation_warning,
+		     reconciliation_invalid = EXCLUDED.reconciliation_invalid,
+		     review_pending = EXCLUDED.review_pending,
+		     review_approved = EXCLUDED.review_approved,
+		     review_rejected = EXCLUDED.review_rejected,
+		     review_awaiting_checker = EXCLUDED.review_awaiting_checker,
+		     refreshed_at = NOW()`,
+		tenantID, collectionID, start,
+		s.TotalDocuments,
+		s.ParsingCompleted, s.ParsingFailed, s.ParsingProcessing, s.ParsingPending, s.ParsingQueued,
+		s.ValidationValid, s.ValidationWarning, s.ValidationInvalid,
+		s.ReconciliationValid, s.ReconciliationWarning, s.ReconciliationInvalid,
+		s.ReviewPending, s.ReviewApproved, s.ReviewRejected, s.ReviewAwaitingChecker)
+	if err != nil {
+		return fmt.Errorf("statsRepo.RefreshDay upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *statsRepo) ReconcileTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.StatsDiscrepancy, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("statsRepo.ReconcileTenant begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Compare before rebuilding; the transaction keeps the comparison and the rebuild consistent
+	discrepancies := []domain.StatsDiscrepancy{}
+	if err := tx.SelectContext(ctx, &discrepancies,
+		`WITH mat AS (
+		     SELECT collection_id, SUM(total_documents) AS n FROM document_daily_stats
+		     WHERE tenant_id = $1 GROUP BY collection_id
+		 ), act AS (
+		     SELECT collection_id, COUNT(*) AS n FROM documents
+		     WHERE tenant_id = $1 GROUP BY collection_id
+		 )
+		 SELECT COALESCE(a.collection_id, m.collection_id) AS collection_id,
+		        COALESCE(m.n, 0) AS materialized, COALESCE(a.n, 0) AS actual
+		 FROM act a FULL OUTER JOIN mat m ON m.collection_id = a.collection_id
+		 WHERE COALESCE(m.n, 0) <> COALESCE(a.n, 0)
+		 ORDER BY 1`,
+		tenantID); err != nil {
+		return nil, fmt.Errorf("statsRepo.ReconcileTenant compare: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM document_daily_stats WHERE tenant_id = $1", tenantID); err != nil {
+		return nil, fmt.Errorf("statsRepo.ReconcileTenant delete: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO document_daily_stats (tenant_id, collection_id, day, `+dailyStatsCounterColumns+`)
+		 SELECT tenant_id, collection_id, (created_at AT TIME ZONE 'UTC')::date, `+docStatsAggregates+`
+		 FROM documents WHERE tenant_id = $1
+		 GROUP BY tenant_id, collection_id, (created_at AT TIME ZONE 'UTC')::date`,
+		tenantID); err != nil {
+		return nil, fmt.Errorf("statsRepo.ReconcileTenant rebuild: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("statsRepo.ReconcileTenant commit: %w", err)
+	}
+	return discrepancies, nil
+}
+
+// qualityAggregates computes the CollectionQualityCounts of collections c joined
+// to their documents d. A document's confidence is the mean of every number in its
+// confidence_scores.
+const qualityAggregates = `COUNT(d.id) AS total_documents,
+	COUNT(CASE WHEN d.parsing_status = 'completed' THEN 1 END) AS parsed_documents,
+	COALESCE(SUM(CASE WHEN d.parsing_status = 'completed' THEN (
+	    SELECT AVG((v #>> '{}')::float8)
+	    FROM jsonb_path_query(d.confidence_scores, 'strict $.** ? (@.type() == "number")') v
+	) END), 0) AS confidence_sum,
+	COUNT(CASE WHEN d.validation_status = 'valid' THEN 1 END) AS validation_valid,
+	COUNT(CASE WHEN d.validation_status = 'warning' THEN 1 END) AS validation_warning,
+	COUNT(CASE WHEN d.review_status = 'approved' THEN 1 END) AS review_approved`
+
+const qualityCounterColumns = `total_documents, parsed_documents, confidence_sum,
+	validation_valid, validation_warning, review_approved`
+
+func (r *statsRepo) CollectionQuality(ctx context.Context, tenantID uuid.UUID, filter domain.CollectionQualityFilter) ([]domain.CollectionQualityCounts, error) {
+	args := []interface{}{tenantID}
+	docJoin := "d.collection_id = c.id AND d.tenant_id = c.tenant_id"
+	// A period only counts documents whose invoice date is known to fall in it
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		docJoin += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM document_summaries s
+		     WHERE s.document_id = d.id AND s.invoice_date >= $%d)`, len(args))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		docJoin += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM document_summaries s
+		     WHERE s.document_id = d.id AND s.invoice_date <= $%d)`, len(args))
+	}
+	where := "c.tenant_id = $1"
+	if filter.CollectionID != nil {
+		args = append(args, *filter.CollectionID)
+		where += fmt.Sprintf(" AND c.id = $%d", len(args))
+	}
+
+	counts := []domain.CollectionQualityCounts{}
+	if err := r.db.SelectContext(ctx, &counts,
+		`SELECT c.id AS collection_id, c.name AS collection_name, `+qualityAggregates+`
+		 FROM collections c
+		 LEFT JOIN documents d ON `+docJoin+`
+		 WHERE `+where+`
+		 GROUP BY c.id, c.name
+		 ORDER BY c.name, c.id`,
+		args...); err != nil {
+		return nil, fmt.Errorf("statsRepo.CollectionQuality: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *statsRepo) SnapshotQuality(ctx context.Context, tenantID uuid.UUID, day time.Time) error {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO collection_quality_snapshots (tenant_id, collection_id, day, `+qualityCounterColumns+`)
+		 SELECT c.tenant_id, c.id, $2, `+qualityAggregates+`
+		 FROM collections c
+		 LEFT JOIN documents d ON d.collection_id = c.id AND d.tenant_id = c.tenant_id
+		 WHERE c.tenant_id = $1
+		 GROUP BY c.tenant_id, c.id
+		 ON CONFLICT (tenant_id, collection_id, day) DO UPDATE SET
+		     total_documents = EXCLUDED.total_documents,
+		     parsed_documents = EXCLUDED.parsed_documents,
+		     confidence_sum = EXCLUDED.confidence_sum,
+		     validation_valid = EXCLUDED.validation_valid,
+		     validation_warning = EXCLUDED.validation_warning,
+		     review_approved = EXCLUDED.review_approved,
+		     recorded_at = NOW()`,
+		tenantID, day)
+	if err != nil {
+		return fmt.Errorf("statsRepo.SnapshotQuality: %w", err)
+	}
+	return nil
+}
+
+func (r *statsRepo) QualityHistory(ctx context.Context, tenantID, collectionID uuid.UUID, since time.Time) ([]domain.CollectionQualityCounts, error) {
+	counts := []domain.CollectionQualityCounts{}
+	if err := r.db.SelectContext(ctx, &counts,
+		`SELECT collection_id, day, `+qualityCounterColumns+`
+		 FROM collection_quality_snapshots
+		 WHERE tenant_id = $1 AND collection_id = $2 AND day >= $3
+		 ORDER BY day`,
+		tenantID, collectionID, since); err != nil {
+		return nil, fmt.Errorf("statsRepo.QualityHistory: %w", err)
+	}
+	return counts, nil
+}
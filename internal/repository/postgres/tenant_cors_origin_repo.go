@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/port"
+)
+
+type tenantCORSOriginRepo struct {
+	db *sqlx.DB
+}
+
+// NewTenantCORSOriginRepo creates a new PostgreSQL-backed TenantCORSOriginRepository.
+func NewTenantCORSOriginRepo(db *sqlx.DB) port.TenantCORSOriginRepository {
+	return &tenantCORSOriginRepo{db: db}
+}
+
+func (r *tenantCORSOriginRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]string, error) {
+	origins := []string{}
+	if err := r.db.SelectContext(ctx, &origins,
+		"SELECT origin FROM tenant_cors_origins WHERE tenant_id = $1 ORDER BY origin", tenantID); err != nil {
+		return nil, fmt.Errorf("tenantCORSOriginRepo.ListByTenant: %w", err)
+	}
+	return origins, nil
+}
+
+func (r *tenantCORSOriginRepo) Replace(ctx context.Context, tenantID uuid.UUID, origins []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("tenantCORSOriginRepo.Replace begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tenant_cors_origins WHERE tenant_id = $1", tenantID); err != nil {
+		return fmt.Errorf("tenantCORSOriginRepo.Replace delete: %w", err)
+	}
+	for _, origin := range origins {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO tenant_cors_origins (tenant_id, origin) VALUES ($1, $2)",
+			tenantID, origin); err != nil {
+			return fmt.Errorf("tenantCORSOriginRepo.Replace insert %s: %w", origin, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("tenantCORSOriginRepo.Replace commit: %w", err)
+	}
+	return nil
+}
+
+func (r *tenantCORSOriginRepo) TenantsByOrigin(ctx context.Context, origin string) ([]uuid.UUID, error) {
+	var tenantIDs []uuid.UUID
+	if err := r.db.SelectContext(ctx, &tenantIDs,
+		"SELECT tenant_id FROM tenant_cors_origins WHERE origin = $1", origin); err != nil {
+		return nil, fmt.Errorf("tenantCORSOriginRepo.TenantsByOrigin: %w", err)
+	}
+	return tenantIDs, nil
+}
@@ -0,0 +1,359 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	// exportBatchRows is how many rows ExportTable hands over per JSON array.
+	exportBatchRows = 500
+	// tenantsTable is keyed by id; every other tenant-scoped table by tenant_id.
+	tenantsTable = "tenants"
+	// moveTargetMaxConns sizes the pool of each move target; moves run one table at a time.
+	moveTargetMaxConns = 4
+)
+
+// tablesNotMoved are tenant-scoped tables that stay on the source cluster.
+var tablesNotMoved = map[string]bool{
+	// Move history belongs to the cluster that ran the move
+	"tenant_moves": true,
+}
+
+type tenantDataStore struct {
+	db *sqlx.DB
+}
+
+// NewTenantDataStore creates a PostgreSQL-backed TenantDataStore for one cluster.
+func NewTenantDataStore(db *sqlx.DB) port.TenantDataStore {
+	return &tenantDataStore{db: db}
+}
+
+// OpenTenantDataStores opens a TenantDataStore for each move target DSN, keyed by
+// cluster name. closeAll releases their connection pools.
+func OpenTenantDataStores(targets map[string]string) (stores map[string]port.TenantDataStore, closeAll func(), err error) {
+	stores = make(map[string]port.TenantDataStore, len(targets))
+	var dbs []*sqlx.DB
+	closeAll = func() {
+		for _, db := range dbs {
+			_ = db.Close()
+		}
+	}
+	for cluster, dsn := range targets {
+		db, err := OpenDSN(dsn, moveTargetMaxConns, 1)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("move target %s: %w", cluster, err)
+		}
+		dbs = append(dbs, db)
+		stores[cluster] = NewTenantDataStore(db)
+	}
+	return stores, closeAll, nil
+}
+
+func (s *tenantDataStore) SchemaVersion(ctx context.Context) (uint, error) {
+	var row struct {
+		Version uint `db:"version"`
+		Dirty   bool `db:"dirty"`
+	}
+	if err := s.db.GetContext(ctx, &row, "SELECT version, dirty FROM schema_migrations LIMIT 1"); err != nil {
+		return 0, fmt.Errorf("tenantDataStore.SchemaVersion: %w", err)
+	}
+	if row.Dirty {
+		return 0, fmt.Errorf("tenantDataStore.SchemaVersion: migration %d is dirty", row.Version)
+	}
+	return row.Version, nil
+}
+
+func (s *tenantDataStore) CheckMovable(ctx context.Context, tenantID uuid.UUID) error {
+	var linked bool
+	err := s.db.GetContext(ctx, &linked,
+		`SELECT t.parent_tenant_id IS NOT NULL
+			OR EXISTS (SELECT 1 FROM tenants c WHERE c.parent_tenant_id = t.id)
+			OR EXISTS (
+				SELECT 1 FROM tenant_memberships m
+				WHERE m.tenant_id = t.id
+				   OR m.user_id IN (SELECT u.id FROM users u WHERE u.tenant_id = t.id)
+			)
+		 FROM tenants t WHERE t.id = $1`, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("tenantDataStore.CheckMovable: %w", err)
+	}
+	if linked {
+		return domain.ErrTenantNotMovable
+	}
+	return nil
+}
+
+func (s *tenantDataStore) SetCluster(ctx context.Context, tenantID uuid.UUID, cluster string) error {
+	res, err := s.db.ExecContext(ctx,
+		"UPDATE tenants SET db_cluster = $1, updated_at = NOW() WHERE id = $2", cluster, tenantID)
+	if err != nil {
+		return fmt.Errorf("tenantDataStore.SetCluster: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("tenantDataStore.SetCluster rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (s *tenantDataStore) Snapshot(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantMoveTable, error) {
+	tables, err := s.tables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true, Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return nil, fmt.Errorf("tenantDataStore.Snapshot begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	return checksumTables(ctx, tx, tenantID, tables)
+}
+
+func (s *tenantDataStore) ExportTable(ctx context.Context, tenantID uuid.UUID, table string, fn func(rows json.RawMessage) error) error {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT row_to_json(t)::text FROM %s t WHERE %s = $1", quoteTable(table), tenantColumn(table)), tenantID)
+	if err != nil {
+		return fmt.Errorf("tenantDataStore.ExportTable %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var batch bytes.Buffer
+	n := 0
+	flush := func() error {
+		if n == 0 {
+			return nil
+		}
+		batch.WriteByte(']')
+		err := fn(json.RawMessage(batch.Bytes()))
+		batch.Reset()
+		n = 0
+		return err
+	}
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return fmt.Errorf("tenantDataStore.ExportTable %s scan: %w", table, err)
+		}
+		if n == 0 {
+			batch.WriteByte('[')
+		} else {
+			batch.WriteByte(',')
+		}
+		batch.WriteString(row)
+		n++
+		if n == exportBatchRows {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("tenantDataStore.ExportTable %s rows: %w", table, err)
+	}
+	return flush()
+}
+
+func (s *tenantDataStore) ListObjects(ctx context.Context, tenantID uuid.UUID) ([]port.StoredObject, error) {
+	var objects []port.StoredObject
+	err := s.db.SelectContext(ctx, &objects,
+		`SELECT DISTINCT s3_bucket AS "bucket", s3_key AS "key" FROM file_metadata
+		 WHERE tenant_id = $1 ORDER BY s3_bucket, s3_key`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("tenantDataStore.ListObjects: %w", err)
+	}
+	return objects, nil
+}
+
+func (s *tenantDataStore) Import(ctx context.Context, in *port.TenantImport) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("tenantDataStore.Import begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// The source is authoritative: drop whatever an earlier move left here,
+	// children before the tables they reference
+	for i := len(in.Tables) - 1; i >= 0; i-- {
+		table := in.Tables[i]
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s = $1",
+			quoteTable(table), tenantColumn(table)), in.TenantID); err != nil {
+			return fmt.Errorf("tenantDataStore.Import clearing %s: %w", table, err)
+		}
+	}
+
+	for _, table := range in.Tables {
+		insert := fmt.Sprintf("INSERT INTO %[1]s SELECT * FROM json_populate_recordset(NULL::%[1]s, $1::json)", quoteTable(table))
+		err := in.Source.ExportTable(ctx, in.TenantID, table, func(rows json.RawMessage) error {
+			if _, err := tx.ExecContext(ctx, insert, string(rows)); err != nil {
+				return fmt.Errorf("inserting into %s: %w", table, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("tenantDataStore.Import: %w", err)
+		}
+	}
+
+	imported, err := checksumTables(ctx, tx, in.TenantID, in.Tables)
+	if err != nil {
+		return err
+	}
+	if in.Verify != nil {
+		if err := in.Verify(imported); err != nil {
+			return err
+		}
+	}
+
+	if in.StorageRegion != nil {
+		if _, err := tx.ExecContext(ctx, "UPDATE tenants SET storage_region = $1 WHERE id = $2",
+			*in.StorageRegion, in.TenantID); err != nil {
+			return fmt.Errorf("tenantDataStore.Import storage region: %w", err)
+		}
+	}
+	for from, to := range in.Buckets {
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE file_metadata SET s3_bucket = $1 WHERE tenant_id = $2 AND s3_bucket = $3",
+			to, in.TenantID, from); err != nil {
+			return fmt.Errorf("tenantDataStore.Import repointing bucket %s: %w", from, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("tenantDataStore.Import commit: %w", err)
+	}
+	return nil
+}
+
+// tables returns every tenant-scoped table, each after the tables it references.
+func (s *tenantDataStore) tables(ctx context.Context) ([]string, error) {
+	var names []string
+	err := s.db.SelectContext(ctx, &names,
+		`SELECT c.table_name FROM information_schema.columns c
+		 JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		 WHERE c.table_schema = current_schema() AND c.column_name = 'tenant_id' AND t.table_type = 'BASE TABLE'`)
+	if err != nil {
+		return nil, fmt.Errorf("tenantDataStore.tables: %w", err)
+	}
+
+	var edges []struct {
+		Child  string `db:"child"`
+		Parent string `db:"parent"`
+	}
+	err = s.db.SelectContext(ctx, &edges,
+		`SELECT c.relname AS child, p.relname AS parent FROM pg_constraint con
+		 JOIN pg_class c ON c.oid = con.conrelid
+		 JOIN pg_class p ON p.oid = con.confrelid
+		 WHERE con.contype = 'f' AND con.connamespace = current_schema()::regnamespace`)
+	if err != nil {
+		return nil, fmt.Errorf("tenantDataStore.tables foreign keys: %w", err)
+	}
+
+	scoped := map[string]bool{tenantsTable: true}
+	for _, name := range names {
+		if !tablesNotMoved[name] {
+			scoped[name] = true
+		}
+	}
+	parents := make(map[string][]string)
+	for _, e := range edges {
+		// Self-references (tenants.parent_tenant_id) are excluded by CheckMovable
+		if e.Child != e.Parent && scoped[e.Child] && scoped[e.Parent] {
+			parents[e.Child] = append(parents[e.Child], e.Parent)
+		}
+	}
+	return sortByDependency(scoped, parents)
+}
+
+// sortByDependency orders tables so each comes after its parents, alphabetically
+// among tables that are ready at the same time.
+func sortByDependency(tables map[string]bool, parents map[string][]string) ([]string, error) {
+	placed := make(map[string]bool, len(tables))
+	ordered := make([]string, 0, len(tables))
+	for len(ordered) < len(tables) {
+		var ready []string
+		for table := range tables {
+			if placed[table] {
+				continue
+			}
+			ok := true
+			for _, p := range parents[table] {
+				if !placed[p] {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				ready = append(ready, table)
+			}
+		}
+		if len(ready) == 0 {
+			return nil, fmt.Errorf("tenantDataStore.tables: foreign key cycle among %d tables", len(tables)-len(ordered))
+		}
+		sort.Strings(ready)
+		for _, table := range ready {
+			placed[table] = true
+		}
+		ordered = append(ordered, ready...)
+	}
+	return ordered, nil
+}
+
+// checksumTables counts the tenant's rows of each table and hashes them in an
+// order-independent way. Time zone and date style are pinned so the text form
+// of a row, and with it the hash, is the same on every cluster.
+func checksumTables(ctx context.Context, tx *sqlx.Tx, tenantID uuid.UUID, tables []string) ([]domain.TenantMoveTable, error) {
+	for _, set := range []string{"SET LOCAL TimeZone = 'UTC'", "SET LOCAL DateStyle = 'ISO, MDY'"} {
+		if _, err := tx.ExecContext(ctx, set); err != nil {
+			return nil, fmt.Errorf("checksumTables: %w", err)
+		}
+	}
+	result := make([]domain.TenantMoveTable, 0, len(tables))
+	for _, table := range tables {
+		var row struct {
+			Rows     int64  `db:"rows"`
+			Checksum string `db:"checksum"`
+		}
+		err := tx.GetContext(ctx, &row, fmt.Sprintf(
+			`SELECT COUNT(*) AS rows,
+				COALESCE(md5(string_agg(md5(t::text), '' ORDER BY md5(t::text))), '') AS checksum
+			 FROM %s t WHERE %s = $1`, quoteTable(table), tenantColumn(table)), tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("checksumTables %s: %w", table, err)
+		}
+		result = append(result, domain.TenantMoveTable{Table: table, Rows: row.Rows, Checksum: row.Checksum})
+	}
+	return result, nil
+}
+
+func quoteTable(table string) string {
+	return pgx.Identifier{table}.Sanitize()
+}
+
+func tenantColumn(table string) string {
+	if table == tenantsTable {
+		return "id"
+	}
+	return "tenant_id"
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type tenantMembershipRepo struct {
+	db *sqlx.DB
+}
+
+// NewTenantMembershipRepo creates a new PostgreSQL-backed TenantMembershipRepository.
+func NewTenantMembershipRepo(db *sqlx.DB) port.TenantMembershipRepository {
+	return &tenantMembershipRepo{db: db}
+}
+
+func (r *tenantMembershipRepo) Upsert(ctx context.Context, m *domain.TenantMembership) error {
+	now := time.Now().UTC()
+	m.UpdatedAt = now
+	err := r.db.QueryRowxContext(ctx,
+		`INSERT INTO tenant_memberships (tenant_id, user_id, role, is_active, created_by, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $6)
+		 ON CONFLICT (tenant_id, user_id) DO UPDATE SET
+		     role = EXCLUDED.role, is_active = EXCLUDED.is_active, updated_at = EXCLUDED.updated_at
+		 RETURNING created_by, created_at, updated_at`,
+		m.TenantID, m.UserID, m.Role, m.IsActive, m.CreatedBy, now,
+	).Scan(&m.CreatedBy, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("tenantMembershipRepo.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (r *tenantMembershipRepo) Get(ctx context.Context, tenantID, userID uuid.UUID) (*domain.TenantMembership, error) {
+	var m domain.TenantMembership
+	err := r.db.GetContext(ctx, &m,
+		`SELECT * FROM tenant_memberships WHERE tenant_id = $1 AND user_id = $2`, tenantID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("tenantMembershipRepo.Get: %w", err)
+	}
+	return &m, nil
+}
+
+func (r *tenantMembershipRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.TenantMembership, error) {
+	var memberships []domain.TenantMembership
+	err := r.db.SelectContext(ctx, &memberships,
+		`SELECT u.tenant_id, u.id AS user_id, u.role, u.is_active, true AS is_home,
+		        NULL::uuid AS created_by, u.created_at, u.updated_at, t.name AS tenant_name, t.slug AS tenant_slug
+		 FROM users u JOIN tenants t ON t.id = u.tenant_id
+		 WHERE u.id = $1
+		 UNION ALL
+		 SELECT * FROM (
+		     SELECT m.tenant_id, m.user_id, m.role, m.is_active, false AS is_home,
+		            m.created_by, m.created_at, m.updated_at, t.name AS tenant_name, t.slug AS tenant_slug
+		     FROM tenant_memberships m JOIN tenants t ON t.id = m.tenant_id
+		     WHERE m.user_id = $1 AND m.is_active AND t.is_active
+		     ORDER BY t.name
+		 ) guest`,
+		userID)
+	if err != nil {
+		return nil, fmt.Errorf("tenantMembershipRepo.ListByUser: %w", err)
+	}
+	return memberships, nil
+}
+
+func (r *tenantMembershipRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMembership, int, error) {
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		`SELECT COUNT(*) FROM tenant_memberships WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("tenantMembershipRepo.ListByTenant count: %w", err)
+	}
+
+	var memberships []domain.TenantMembership
+	err = r.db.SelectContext(ctx, &memberships,
+		`SELECT m.*, false AS is_home, u.email, u.full_name
+		 FROM tenant_memberships m JOIN users u ON u.id = m.user_id
+		 WHERE m.tenant_id = $1
+		 ORDER BY m.created_at DESC LIMIT $2 OFFSET $3`,
+		tenantID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("tenantMembershipRepo.ListByTenant: %w", err)
+	}
+	return memberships, total, nil
+}
+
+func (r *tenantMembershipRepo) Delete(ctx context.Context, tenantID, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM tenant_memberships WHERE tenant_id = $1 AND user_id = $2`, tenantID, userID)
+	if err != nil {
+		return fmt.Errorf("tenantMembershipRepo.Delete: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
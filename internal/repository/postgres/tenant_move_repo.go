@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type tenantMoveRepo struct {
+	db *sqlx.DB
+}
+
+// NewTenantMoveRepo creates a new PostgreSQL-backed TenantMoveRepository.
+func NewTenantMoveRepo(db *sqlx.DB) port.TenantMoveRepository {
+	return &tenantMoveRepo{db: db}
+}
+
+func (r *tenantMoveRepo) Create(ctx context.Context, move *domain.TenantMove) error {
+	now := time.Now().UTC()
+	move.CreatedAt = now
+	move.UpdatedAt = now
+	if move.Tables == nil {
+		move.Tables = []byte("[]")
+	}
+
+	// A move that has not saved progress for longer than its timeout died with
+	// the server that ran it and no longer blocks a new one
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO tenant_moves
+			(id, tenant_id, source_cluster, target_cluster, target_region, status, tables,
+			 requested_by, created_at, updated_at)
+		 SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		 WHERE NOT EXISTS (
+			SELECT 1 FROM tenant_moves
+			WHERE tenant_id = $2
+			  AND status IN ('pending', 'copying')
+			  AND updated_at > NOW() - INTERVAL '6 hours'
+		 )`,
+		move.ID, move.TenantID, move.SourceCluster, move.TargetCluster, move.TargetRegion, move.Status,
+		move.Tables, move.RequestedBy, move.CreatedAt, move.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("tenantMoveRepo.Create: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("tenantMoveRepo.Create rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrTenantMoveInProgress
+	}
+	return nil
+}
+
+func (r *tenantMoveRepo) GetByID(ctx context.Context, tenantID, moveID uuid.UUID) (*domain.TenantMove, error) {
+	var move domain.TenantMove
+	err := r.db.GetContext(ctx, &move,
+		"SELECT * FROM tenant_moves WHERE id = $1 AND tenant_id = $2", moveID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("tenantMoveRepo.GetByID: %w", err)
+	}
+	return &move, nil
+}
+
+func (r *tenantMoveRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMove, int, error) {
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM tenant_moves WHERE tenant_id = $1", tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("tenantMoveRepo.ListByTenant count: %w", err)
+	}
+
+	var moves []domain.TenantMove
+	err = r.db.SelectContext(ctx, &moves,
+		`SELECT * FROM tenant_moves WHERE tenant_id = $1
+		 ORDER BY created_at DESC LIMIT $2 OFFSET $3`,
+		tenantID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("tenantMoveRepo.ListByTenant: %w", err)
+	}
+	return moves, total, nil
+}
+
+func (r *tenantMoveRepo) UpdateProgress(ctx context.Context, move *domain.TenantMove) error {
+	move.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE tenant_moves SET
+			status = $1, tables = $2, objects_total = $3, objects_copied = $4,
+			error = $5, completed_at = $6, updated_at = $7
+		 WHERE id = $8 AND tenant_id = $9`,
+		move.Status, move.Tables, move.ObjectsTotal, move.ObjectsCopied,
+		move.Error, move.CompletedAt, move.UpdatedAt, move.ID, move.TenantID)
+	if err != nil {
+		return fmt.Errorf("tenantMoveRepo.UpdateProgress: %w", err)
+	}
+	return nil
+}
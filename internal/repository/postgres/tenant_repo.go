@@ -29,12 +29,18 @@ func (r *tenantRepo) Create(ctx context.Context, tenant *domain.Tenant) error {
 	now := time.Now().UTC()
 	tenant.CreatedAt = now
 	tenant.UpdatedAt = now
+	if tenant.TimeZone == "" {
+		tenant.TimeZone = "UTC"
+	}
 
-	query := `INSERT INTO tenants (id, name, slug, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+	query := `INSERT INTO tenants (id, name, slug, is_active, storage_region, storage_ia_after_days, storage_sse,
+		storage_kms_key_arn, default_parse_mode, time_zone, parent_tenant_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
 
 	_, err := r.db.ExecContext(ctx, query,
-		tenant.ID, tenant.Name, tenant.Slug, tenant.IsActive, tenant.CreatedAt, tenant.UpdatedAt)
+		tenant.ID, tenant.Name, tenant.Slug, tenant.IsActive, tenant.StorageRegion, tenant.StorageIAAfterDays,
+		tenant.StorageSSE, tenant.StorageKMSKeyARN, tenant.DefaultParseMode, tenant.TimeZone, tenant.ParentTenantID,
+		tenant.CreatedAt, tenant.UpdatedAt)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") && strings.Contains(err.Error(), "slug") {
 			return domain.ErrDuplicateTenantSlug
@@ -84,11 +90,31 @@ func (r *tenantRepo) List(ctx context.Context, offset, limit int) ([]domain.Tena
 	return tenants, total, nil
 }
 
+func (r *tenantRepo) ListClients(ctx context.Context, parentID uuid.UUID, offset, limit int) ([]domain.Tenant, int, error) {
+	var total int
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM tenants WHERE parent_tenant_id = $1", parentID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("tenantRepo.ListClients count: %w", err)
+	}
+
+	var tenants []domain.Tenant
+	err = r.db.SelectContext(ctx, &tenants,
+		"SELECT * FROM tenants WHERE parent_tenant_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3",
+		parentID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("tenantRepo.ListClients: %w", err)
+	}
+	return tenants, total, nil
+}
+
 func (r *tenantRepo) Update(ctx context.Context, tenant *domain.Tenant) error {
 	tenant.UpdatedAt = time.Now().UTC()
-	query := `UPDATE tenants SET name = $1, slug = $2, is_active = $3, updated_at = $4 WHERE id = $5`
+	query := `UPDATE tenants SET name = $1, slug = $2, is_active = $3, storage_region = $4,
+		storage_ia_after_days = $5, storage_sse = $6, storage_kms_key_arn = $7, default_parse_mode = $8,
+		time_zone = $9, updated_at = $10 WHERE id = $11`
 	result, err := r.db.ExecContext(ctx, query,
-		tenant.Name, tenant.Slug, tenant.IsActive, tenant.UpdatedAt, tenant.ID)
+		tenant.Name, tenant.Slug, tenant.IsActive, tenant.StorageRegion, tenant.StorageIAAfterDays,
+		tenant.StorageSSE, tenant.StorageKMSKeyARN, tenant.DefaultParseMode, tenant.TimeZone, tenant.UpdatedAt, tenant.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") && strings.Contains(err.Error(), "slug") {
 			return domain.ErrDuplicateTenantSlug
@@ -105,6 +131,9 @@ func (r *tenantRepo) Update(ctx context.Context, tenant *domain.Tenant) error {
 func (r *tenantRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	result, err := r.db.ExecContext(ctx, "DELETE FROM tenants WHERE id = $1", id)
 	if err != nil {
+		if strings.Contains(err.Error(), "foreign key") && strings.Contains(err.Error(), "parent_tenant_id") {
+			return domain.ErrTenantHasClients
+		}
 		return fmt.Errorf("tenantRepo.Delete: %w", err)
 	}
 	rows, _ := result.RowsAffected()
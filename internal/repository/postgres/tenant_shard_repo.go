@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type tenantShardRepo struct {
+	db *sqlx.DB
+}
+
+// NewTenantShardRepo creates a new PostgreSQL-backed TenantShardRepository. It
+// must be given the primary cluster, whose tenants rows carry the placement.
+func NewTenantShardRepo(db *sqlx.DB) port.TenantShardRepository {
+	return &tenantShardRepo{db: db}
+}
+
+func (r *tenantShardRepo) Resolve(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	var shard string
+	err := r.db.GetContext(ctx, &shard,
+		"SELECT db_cluster FROM tenants WHERE id = $1", tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrNotFound
+		}
+		return "", fmt.Errorf("tenantShardRepo.Resolve: %w", err)
+	}
+	return shard, nil
+}
+
+func (r *tenantShardRepo) CountByShard(ctx context.Context) (map[string]int, error) {
+	var rows []struct {
+		Shard   string `db:"shard"`
+		Tenants int    `db:"tenants"`
+	}
+	if err := r.db.SelectContext(ctx, &rows,
+		`SELECT db_cluster AS shard, COUNT(*) AS tenants FROM tenants
+		 WHERE db_cluster <> ''
+		 GROUP BY db_cluster`); err != nil {
+		return nil, fmt.Errorf("tenantShardRepo.CountByShard: %w", err)
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Shard] = row.Tenants
+	}
+	return counts, nil
+}
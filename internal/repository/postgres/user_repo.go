@@ -33,17 +33,22 @@ func (r *userRepo) Create(ctx context.Context, user *domain.User) error {
 		user.CurrentPeriodStart = now
 	}
 
+	// An address that is already suppressed is undeliverable from the start
 	query := `INSERT INTO users (id, tenant_id, email, password_hash, full_name, role, is_active,
 		monthly_document_limit, documents_used_this_period, current_period_start,
-		email_verified, email_verified_at, auth_provider, provider_user_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+		email_verified, email_verified_at, auth_provider, provider_user_id, created_at, updated_at,
+		email_undeliverable, email_undeliverable_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
+			(SELECT reason FROM email_suppressions WHERE email = LOWER($3)),
+			(SELECT updated_at FROM email_suppressions WHERE email = LOWER($3)))
+		RETURNING email_undeliverable, email_undeliverable_at`
 
-	_, err := r.db.ExecContext(ctx, query,
+	err := r.db.QueryRowxContext(ctx, query,
 		user.ID, user.TenantID, user.Email, user.PasswordHash, user.FullName,
 		user.Role, user.IsActive, user.MonthlyDocumentLimit, user.DocumentsUsedThisPeriod,
 		user.CurrentPeriodStart, user.EmailVerified, user.EmailVerifiedAt,
 		user.AuthProvider, user.ProviderUserID,
-		user.CreatedAt, user.UpdatedAt)
+		user.CreatedAt, user.UpdatedAt).Scan(&user.EmailUndeliverable, &user.EmailUndeliverableAt)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
 			return domain.ErrDuplicateEmail
@@ -53,19 +58,75 @@ func (r *userRepo) Create(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// guestUserRow is a user loaded through a tenant membership, with the role the
+// membership grants.
+type guestUserRow struct {
+	domain.User
+	MemberRole *domain.UserRole `db:"member_role"`
+}
+
+// user returns the row as seen from tenantID: a guest takes the membership's
+// tenant and role and keeps their home tenant in HomeTenantID.
+func (row *guestUserRow) user(tenantID uuid.UUID) domain.User {
+	u := row.User
+	if row.MemberRole != nil {
+		home := u.TenantID
+		u.HomeTenantID = &home
+		u.TenantID = tenantID
+		u.Role = *row.MemberRole
+	}
+	return u
+}
+
+// GetByID finds a user of the tenant: a home user, or a guest with an active
+// tenant membership.
 func (r *userRepo) GetByID(ctx context.Context, tenantID, userID uuid.UUID) (*domain.User, error) {
-	var user domain.User
-	err := r.db.GetContext(ctx, &user,
-		"SELECT * FROM users WHERE id = $1 AND tenant_id = $2", userID, tenantID)
+	var row guestUserRow
+	err := r.db.GetContext(ctx, &row,
+		`SELECT u.*, NULL::varchar AS member_role FROM users u WHERE u.id = $1 AND u.tenant_id = $2
+		 UNION ALL
+		 SELECT u.*, m.role AS member_role FROM users u
+		 JOIN tenant_memberships m ON m.user_id = u.id
+		 WHERE u.id = $1 AND m.tenant_id = $2 AND m.is_active
+		 LIMIT 1`, userID, tenantID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrNotFound
 		}
 		return nil, fmt.Errorf("userRepo.GetByID: %w", err)
 	}
+	user := row.user(tenantID)
 	return &user, nil
 }
 
+func (r *userRepo) GetByIDs(ctx context.Context, tenantID uuid.UUID, userIDs []uuid.UUID) ([]domain.User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(
+		`SELECT u.*, NULL::varchar AS member_role FROM users u WHERE u.tenant_id = ? AND u.id IN (?)
+		 UNION ALL
+		 SELECT u.*, m.role AS member_role FROM users u
+		 JOIN tenant_memberships m ON m.user_id = u.id
+		 WHERE m.tenant_id = ? AND m.is_active AND u.id IN (?)`,
+		tenantID, userIDs, tenantID, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("userRepo.GetByIDs: building query: %w", err)
+	}
+	query = r.db.Rebind(query)
+
+	var rows []guestUserRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("userRepo.GetByIDs: %w", err)
+	}
+	users := make([]domain.User, len(rows))
+	for i := range rows {
+		users[i] = rows[i].user(tenantID)
+	}
+	return users, nil
+}
+
 func (r *userRepo) GetByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*domain.User, error) {
 	var user domain.User
 	err := r.db.GetContext(ctx, &user,
@@ -99,20 +160,28 @@ func (r *userRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, offset,
 
 func (r *userRepo) Update(ctx context.Context, user *domain.User) error {
 	user.UpdatedAt = time.Now().UTC()
-	query := `UPDATE users SET email = $1, full_name = $2, role = $3, is_active = $4, updated_at = $5
-		WHERE id = $6 AND tenant_id = $7`
-	result, err := r.db.ExecContext(ctx, query,
-		user.Email, user.FullName, user.Role, user.IsActive, user.UpdatedAt, user.ID, user.TenantID)
+	// A new address takes that address's suppression state; SET sees the old email
+	query := `UPDATE users u SET email = $1, full_name = $2, role = $3, is_active = $4, updated_at = $5,
+		monthly_document_limit = $8,
+		email_undeliverable = CASE WHEN LOWER(u.email) = LOWER($1) THEN u.email_undeliverable
+			ELSE (SELECT s.reason FROM email_suppressions s WHERE s.email = LOWER($1)) END,
+		email_undeliverable_at = CASE WHEN LOWER(u.email) = LOWER($1) THEN u.email_undeliverable_at
+			ELSE (SELECT s.updated_at FROM email_suppressions s WHERE s.email = LOWER($1)) END
+		WHERE u.id = $6 AND u.tenant_id = $7
+		RETURNING u.email_undeliverable, u.email_undeliverable_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		user.Email, user.FullName, user.Role, user.IsActive, user.UpdatedAt, user.ID, user.TenantID,
+		user.MonthlyDocumentLimit).
+		Scan(&user.EmailUndeliverable, &user.EmailUndeliverableAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotFound
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
 			return domain.ErrDuplicateEmail
 		}
 		return fmt.Errorf("userRepo.Update: %w", err)
 	}
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
-		return domain.ErrNotFound
-	}
 	return nil
 }
 
@@ -131,9 +200,12 @@ func (r *userRepo) Delete(ctx context.Context, tenantID, userID uuid.UUID) error
 
 func (r *userRepo) CheckAndIncrementQuota(ctx context.Context, tenantID, userID uuid.UUID) error {
 	// 0 means unlimited — skip check entirely
+	// The quota belongs to the user, so guests of the tenant draw on their own.
 	var limit int
 	err := r.db.GetContext(ctx, &limit,
-		"SELECT monthly_document_limit FROM users WHERE id = $1 AND tenant_id = $2", userID, tenantID)
+		`SELECT monthly_document_limit FROM users u WHERE u.id = $1
+		 AND (u.tenant_id = $2 OR EXISTS (SELECT 1 FROM tenant_memberships m
+		     WHERE m.user_id = u.id AND m.tenant_id = $2 AND m.is_active))`, userID, tenantID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.ErrNotFound
@@ -158,13 +230,17 @@ func (r *userRepo) CheckAndIncrementQuota(ctx context.Context, tenantID, userID
 				WHEN documents_used_this_period < monthly_document_limit THEN documents_used_this_period + 1
 				ELSE documents_used_this_period
 			END,
+			quota_warning_level = CASE
+				WHEN NOW() - current_period_start > INTERVAL '30 days' THEN 0
+				ELSE quota_warning_level
+			END,
 			updated_at = NOW()
-		WHERE id = $1 AND tenant_id = $2
+		WHERE id = $1
 		  AND (
 				NOW() - current_period_start > INTERVAL '30 days'
 				OR documents_used_this_period < monthly_document_limit
 		  )`,
-		userID, tenantID)
+		userID)
 	if err != nil {
 		return fmt.Errorf("userRepo.CheckAndIncrementQuota: %w", err)
 	}
@@ -175,6 +251,17 @@ func (r *userRepo) CheckAndIncrementQuota(ctx context.Context, tenantID, userID
 	return nil
 }
 
+func (r *userRepo) ClaimQuotaWarning(ctx context.Context, userID uuid.UUID, level int) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE users SET quota_warning_level = $2 WHERE id = $1 AND quota_warning_level < $2`,
+		userID, level)
+	if err != nil {
+		return false, fmt.Errorf("userRepo.ClaimQuotaWarning: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
 func (r *userRepo) SetEmailVerified(ctx context.Context, tenantID, userID uuid.UUID) error {
 	result, err := r.db.ExecContext(ctx,
 		`UPDATE users SET email_verified = true, email_verified_at = NOW(), updated_at = NOW()
@@ -248,3 +335,55 @@ func (r *userRepo) LinkProvider(ctx context.Context, tenantID, userID uuid.UUID,
 	}
 	return nil
 }
+
+func (r *userRepo) ListUnverified(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.User, int, error) {
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM users WHERE tenant_id = $1 AND email_verified = false AND is_active = true", tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("userRepo.ListUnverified count: %w", err)
+	}
+
+	var users []domain.User
+	err = r.db.SelectContext(ctx, &users,
+		`SELECT * FROM users WHERE tenant_id = $1 AND email_verified = false AND is_active = true
+		 ORDER BY created_at DESC LIMIT $2 OFFSET $3`,
+		tenantID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("userRepo.ListUnverified: %w", err)
+	}
+	return users, total, nil
+}
+
+func (r *userRepo) ClaimVerificationSend(ctx context.Context, tenantID, userID uuid.UUID, notSentSince time.Time) (bool, error) {
+	// A single conditional UPDATE, so concurrent resends across instances can't both pass.
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE users SET verification_sent_at = NOW()
+		 WHERE id = $1 AND tenant_id = $2 AND email_verified = false
+		   AND (verification_sent_at IS NULL OR verification_sent_at <= $3)`,
+		userID, tenantID, notSentSince)
+	if err != nil {
+		return false, fmt.Errorf("userRepo.ClaimVerificationSend: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+func (r *userRepo) ClaimVerificationReminders(ctx context.Context, registeredBefore time.Time, limit int) ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.SelectContext(ctx, &users,
+		`UPDATE users SET verification_reminded_at = NOW(), verification_sent_at = NOW()
+		 WHERE id IN (
+		     SELECT id FROM users
+		     WHERE email_verified = false AND is_active = true
+		       AND verification_reminded_at IS NULL AND created_at < $1
+		     ORDER BY created_at LIMIT $2
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING *`,
+		registeredBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("userRepo.ClaimVerificationReminders: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type validationRuleOutcomeRepo struct {
+	db *sqlx.DB
+}
+
+// NewValidationRuleOutcomeRepo creates a new PostgreSQL-backed ValidationRuleOutcomeRepository.
+func NewValidationRuleOutcomeRepo(db *sqlx.DB) port.ValidationRuleOutcomeRepository {
+	return &validationRuleOutcomeRepo{db: db}
+}
+
+// On conflict every SET expression sees the previous row, so corrected compares
+// the old failing flag with the new result.
+func (r *validationRuleOutcomeRepo) Record(ctx context.Context, outcomes []domain.ValidationRuleOutcome) error {
+	if len(outcomes) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("validationRuleOutcomeRepo.Record begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	for i := range outcomes {
+		o := &outcomes[i]
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO validation_rule_outcomes (tenant_id, document_id, rule_id, document_type, field_path, failing, failed, first_seen_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $7)
+			 ON CONFLICT (document_id, rule_id)
+			 DO UPDATE SET failing = EXCLUDED.failing,
+			               failed = validation_rule_outcomes.failed OR EXCLUDED.failing,
+			               corrected = validation_rule_outcomes.corrected OR (validation_rule_outcomes.failing AND NOT EXCLUDED.failing),
+			               field_path = EXCLUDED.field_path,
+			               updated_at = EXCLUDED.updated_at`,
+			o.TenantID, o.DocumentID, o.RuleID, o.DocumentType, o.FieldPath, o.Failing, now); err != nil {
+			return fmt.Errorf("validationRuleOutcomeRepo.Record %s: %w", o.RuleID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("validationRuleOutcomeRepo.Record commit: %w", err)
+	}
+	return nil
+}
+
+func (r *validationRuleOutcomeRepo) MarkOverridden(ctx context.Context, tenantID, documentID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE validation_rule_outcomes SET overridden = TRUE, updated_at = NOW()
+		 WHERE tenant_id = $1 AND document_id = $2 AND failing AND NOT overridden`,
+		tenantID, documentID)
+	if err != nil {
+		return fmt.Errorf("validationRuleOutcomeRepo.MarkOverridden: %w", err)
+	}
+	return nil
+}
+
+// Rules deleted since their outcomes were recorded keep their counts under an
+// empty name and severity.
+const statsByRuleQuery = `SELECT
+	o.rule_id,
+	COALESCE(r.rule_name, '') AS rule_name,
+	r.builtin_rule_key,
+	COALESCE(r.severity, '') AS severity,
+	MAX(o.field_path) AS field_path,
+	COUNT(*) AS evaluations,
+	COUNT(CASE WHEN o.failed THEN 1 END) AS failures,
+	COUNT(CASE WHEN o.failed AND o.overridden THEN 1 END) AS overrides,
+	COUNT(CASE WHEN o.failed AND o.corrected THEN 1 END) AS corrections
+FROM validation_rule_outcomes o
+LEFT JOIN document_validation_rules r ON r.id = o.rule_id AND r.tenant_id = o.tenant_id
+WHERE o.tenant_id = $1 AND o.first_seen_at >= $2 AND ($3 = '' OR o.document_type = $3)
+GROUP BY o.rule_id, r.rule_name, r.builtin_rule_key, r.severity
+ORDER BY o.rule_id`
+
+func (r *validationRuleOutcomeRepo) StatsByRule(ctx context.Context, tenantID uuid.UUID, documentType string, since time.Time) ([]domain.RuleFailureStats, error) {
+	stats := []domain.RuleFailureStats{}
+	if err := r.db.SelectContext(ctx, &stats, statsByRuleQuery, tenantID, since, documentType); err != nil {
+		return nil, fmt.Errorf("validationRuleOutcomeRepo.StatsByRule: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+type validationRunRepo struct {
+	db *sqlx.DB
+}
+
+// NewValidationRunRepo creates a new PostgreSQL-backed ValidationRunRepository.
+func NewValidationRunRepo(db *sqlx.DB) port.ValidationRunRepository {
+	return &validationRunRepo{db: db}
+}
+
+func (r *validationRunRepo) Create(ctx context.Context, run *domain.ValidationRun) error {
+	now := time.Now().UTC()
+	run.CreatedAt = now
+	run.UpdatedAt = now
+	if run.Changes == nil {
+		run.Changes = []byte("[]")
+	}
+
+	// A run that has not saved progress for an hour outlived its timeout (e.g. the
+	// server restarted mid-run) and no longer blocks a new one
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO validation_runs
+			(id, tenant_id, collection_id, created_by, status, changes, created_at, updated_at)
+		 SELECT $1, $2, $3, $4, $5, $6, $7, $8
+		 WHERE NOT EXISTS (
+			SELECT 1 FROM validation_runs
+			WHERE tenant_id = $2 AND collection_id = $3
+			  AND status IN ('pending', 'processing')
+			  AND updated_at > NOW() - INTERVAL '1 hour'
+		 )`,
+		run.ID, run.TenantID, run.CollectionID, run.CreatedBy, run.Status, run.Changes,
+		run.CreatedAt, run.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("validationRunRepo.Create: %w", err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("validationRunRepo.Create rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrValidationRunInProgress
+	}
+	return nil
+}
+
+func (r *validationRunRepo) GetByID(ctx context.Context, tenantID, runID uuid.UUID) (*domain.ValidationRun, error) {
+	var run domain.ValidationRun
+	err := r.db.GetContext(ctx, &run,
+		"SELECT * FROM validation_runs WHERE id = $1 AND tenant_id = $2", runID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("validationRunRepo.GetByID: %w", err)
+	}
+	return &run, nil
+}
+
+func (r *validationRunRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.ValidationRun, int, error) {
+	var total int
+	err := r.db.GetContext(ctx, &total,
+		"SELECT COUNT(*) FROM validation_runs WHERE tenant_id = $1 AND collection_id = $2",
+		tenantID, collectionID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("validationRunRepo.ListByCollection count: %w", err)
+	}
+
+	var runs []domain.ValidationRun
+	err = r.db.SelectContext(ctx, &runs,
+		`SELECT * FROM validation_runs
+		 WHERE tenant_id = $1 AND collection_id = $2
+		 ORDER BY created_at DESC LIMIT $3 OFFSET $4`,
+		tenantID, collectionID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("validationRunRepo.ListByCollection: %w", err)
+	}
+	return runs, total, nil
+}
+
+func (r *validationRunRepo) UpdateProgress(ctx context.Context, run *domain.ValidationRun) error {
+	run.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE validation_runs SET
+			status = $1, total_documents = $2, processed_count = $3, changed_count = $4,
+			failed_count = $5, changes = $6, error = $7, completed_at = $8, updated_at = $9
+		 WHERE id = $10 AND tenant_id = $11`,
+		run.Status, run.TotalDocuments, run.ProcessedCount, run.ChangedCount, run.FailedCount,
+		run.Changes, run.Error, run.CompletedAt, run.UpdatedAt, run.ID, run.TenantID)
+	if err != nil {
+		return fmt.Errorf("validationRunRepo.UpdateProgress: %w", err)
+	}
+	return nil
+}
+
+func (r *validationRunRepo) ListTargets(ctx context.Context, tenantID, collectionID uuid.UUID, limit int) ([]domain.ValidationRunTarget, error) {
+	var targets []domain.ValidationRunTarget
+	err := r.db.SelectContext(ctx, &targets,
+		`SELECT id, name, validation_status, reconciliation_status FROM documents
+		 WHERE tenant_id = $1 AND collection_id = $2 AND parsing_status = $3
+		 ORDER BY created_at, id LIMIT $4`,
+		tenantID, collectionID, domain.ParsingStatusCompleted, limit)
+	if err != nil {
+		return nil, fmt.Errorf("validationRunRepo.ListTargets: %w", err)
+	}
+	return targets, nil
+}
@@ -0,0 +1,273 @@
+package router
+
+import (
+	"net/http"
+
+	"satvos/internal/domain"
+)
+
+const apiPrefix = "/api/v1"
+
+// anyRole allows every authenticated tenant role, including free-tier users.
+var anyRole = []domain.UserRole{domain.RoleAdmin, domain.RoleManager, domain.RoleMember, domain.RoleViewer, domain.RoleFree}
+
+// minRole returns every role at or above the given level. Free users sit below
+// viewer, so minRole(domain.RoleViewer) excludes them.
+func minRole(level domain.UserRole) []domain.UserRole {
+	roles := make([]domain.UserRole, 0, len(anyRole))
+	for _, r := range anyRole {
+		if domain.RoleLevel(r) >= domain.RoleLevel(level) {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+func rule(method, path string, roles []domain.UserRole, perm domain.CollectionPermission) domain.RouteRule {
+	return domain.RouteRule{Method: method, Path: apiPrefix + path, Roles: roles, CollectionPerm: perm}
+}
+
+// verified marks a rule whose route also runs middleware.RequireEmailVerified.
+func verified(r domain.RouteRule) domain.RouteRule {
+	r.VerifiedEmail = true
+	return r
+}
+
+// RouteMatrix returns the declarative authorization matrix for every authenticated
+// route. The router enforces the role column; services still enforce collection
+// permissions (documented in the CollectionPerm column) as a second layer.
+func RouteMatrix() []domain.RouteRule {
+	const (
+		owner  = domain.CollectionPermOwner
+		editor = domain.CollectionPermEditor
+		viewer = domain.CollectionPermViewer
+	)
+	uploaders := []domain.UserRole{domain.RoleAdmin, domain.RoleManager, domain.RoleMember, domain.RoleFree}
+
+	return []domain.RouteRule{
+		rule(http.MethodPost, "/auth/resend-verification", anyRole, ""),
+		rule(http.MethodGet, "/auth/tenants", anyRole, ""),
+		rule(http.MethodPost, "/auth/switch-tenant", anyRole, ""),
+
+		// Files
+		verified(rule(http.MethodPost, "/files/upload", uploaders, "")),
+		rule(http.MethodGet, "/files", anyRole, ""),
+		rule(http.MethodGet, "/files/:id", anyRole, ""),
+		rule(http.MethodGet, "/files/:id/download", anyRole, ""),
+		rule(http.MethodGet, "/files/:id/pages/:n/image", anyRole, ""),
+		rule(http.MethodPost, "/files/:id/preflight", anyRole, ""),
+		rule(http.MethodDelete, "/files/:id", minRole(domain.RoleAdmin), ""),
+
+		// Collections
+		rule(http.MethodPost, "/collections", minRole(domain.RoleMember), ""),
+		rule(http.MethodGet, "/collections", anyRole, ""),
+		rule(http.MethodGet, "/collections/starred", anyRole, ""),
+		rule(http.MethodGet, "/collections/:id", anyRole, viewer),
+		rule(http.MethodPut, "/collections/:id", anyRole, editor),
+		rule(http.MethodPut, "/collections/:id/review-checklist", anyRole, owner),
+		rule(http.MethodPut, "/collections/:id/approval-policy", anyRole, owner),
+		rule(http.MethodPut, "/collections/:id/escalation-policy", anyRole, owner),
+		rule(http.MethodPut, "/collections/:id/kpi-targets", anyRole, owner),
+		rule(http.MethodPut, "/collections/:id/qa-sampling", anyRole, owner),
+		rule(http.MethodPut, "/collections/:id/parse-mode", anyRole, owner),
+		rule(http.MethodPut, "/collections/:id/naming-template", anyRole, owner),
+		rule(http.MethodPut, "/collections/:id/file-download", anyRole, owner),
+		rule(http.MethodGet, "/collections/:id/progress", anyRole, viewer),
+		rule(http.MethodDelete, "/collections/:id", anyRole, owner),
+		rule(http.MethodPut, "/collections/:id/star", anyRole, viewer),
+		rule(http.MethodDelete, "/collections/:id/star", anyRole, ""),
+		rule(http.MethodPost, "/collections/:id/files", anyRole, editor),
+		rule(http.MethodDelete, "/collections/:id/files/:fileId", anyRole, editor),
+		rule(http.MethodPost, "/collections/:id/permissions", anyRole, owner),
+		rule(http.MethodGet, "/collections/:id/permissions", anyRole, owner),
+		rule(http.MethodDelete, "/collections/:id/permissions/:userId", anyRole, owner),
+		rule(http.MethodGet, "/collections/:id/activity", anyRole, viewer),
+		rule(http.MethodGet, "/collections/:id/export/csv", anyRole, viewer),
+		rule(http.MethodGet, "/collections/:id/export/validation-failures", anyRole, viewer),
+		rule(http.MethodGet, "/collections/:id/exports", anyRole, viewer),
+		rule(http.MethodGet, "/collections/:id/exports/:exportId/downloads", anyRole, viewer),
+		verified(rule(http.MethodPost, "/collections/:id/imports", anyRole, editor)),
+		verified(rule(http.MethodPost, "/collections/:id/imports/s3", anyRole, editor)),
+		rule(http.MethodGet, "/collections/:id/imports", anyRole, viewer),
+		rule(http.MethodGet, "/collections/:id/imports/:importId", anyRole, viewer),
+		rule(http.MethodGet, "/collections/:id/orphan-files", anyRole, viewer),
+		verified(rule(http.MethodPost, "/collections/:id/orphan-files/documents", anyRole, editor)),
+		rule(http.MethodPost, "/collections/:id/validate", anyRole, editor),
+		rule(http.MethodGet, "/collections/:id/validation-runs", anyRole, viewer),
+		rule(http.MethodGet, "/collections/:id/validation-runs/:runId", anyRole, viewer),
+		verified(rule(http.MethodPost, "/collections/:id/cloud-syncs", anyRole, editor)),
+		rule(http.MethodGet, "/collections/:id/cloud-syncs", anyRole, viewer),
+		rule(http.MethodGet, "/collections/:id/cloud-syncs/:syncId", anyRole, viewer),
+		rule(http.MethodPut, "/collections/:id/cloud-syncs/:syncId", anyRole, editor),
+		rule(http.MethodDelete, "/collections/:id/cloud-syncs/:syncId", anyRole, editor),
+		rule(http.MethodPost, "/collections/:id/cloud-syncs/:syncId/run", anyRole, editor),
+		rule(http.MethodGet, "/collections/:id/cloud-syncs/:syncId/files", anyRole, viewer),
+		rule(http.MethodGet, "/collections/:id/notification-channels", anyRole, owner),
+		rule(http.MethodPost, "/collections/:id/notification-channels", anyRole, owner),
+		rule(http.MethodPut, "/collections/:id/notification-channels/:channelId", anyRole, owner),
+		rule(http.MethodDelete, "/collections/:id/notification-channels/:channelId", anyRole, owner),
+		rule(http.MethodPost, "/collections/:id/notification-channels/:channelId/test", anyRole, owner),
+
+		// Cloud storage integrations (connections are per user)
+		rule(http.MethodGet, "/integrations/connections", anyRole, ""),
+		rule(http.MethodDelete, "/integrations/connections/:id", anyRole, ""),
+		rule(http.MethodGet, "/integrations/connections/:id/folders", anyRole, ""),
+		rule(http.MethodGet, "/integrations/:provider/authorize", anyRole, ""),
+		rule(http.MethodPost, "/integrations/:provider/connect", anyRole, ""),
+
+		// No-code integrations (Zapier, Make); hooks are per user
+		rule(http.MethodGet, "/integrations/documents/approved", anyRole, ""),
+		rule(http.MethodGet, "/integrations/hooks", anyRole, ""),
+		rule(http.MethodPost, "/integrations/hooks", anyRole, ""),
+		rule(http.MethodPut, "/integrations/hooks/:id/template", anyRole, ""),
+		rule(http.MethodDelete, "/integrations/hooks/:id", anyRole, ""),
+
+		// Documents
+		verified(rule(http.MethodPost, "/documents", anyRole, editor)),
+		verified(rule(http.MethodPost, "/documents/from-url", anyRole, editor)),
+		verified(rule(http.MethodPost, "/parse/preview", anyRole, "")),
+		rule(http.MethodGet, "/documents", anyRole, viewer),
+		rule(http.MethodGet, "/documents/search/tags", anyRole, ""),
+		rule(http.MethodGet, "/documents/search/amount", anyRole, ""),
+		rule(http.MethodGet, "/documents/stream", anyRole, ""),
+		rule(http.MethodPost, "/documents/bulk-tags", minRole(domain.RoleMember), editor),
+		rule(http.MethodGet, "/documents/bulk-tags", minRole(domain.RoleMember), ""),
+		rule(http.MethodGet, "/documents/bulk-tags/:jobId", minRole(domain.RoleMember), ""),
+		rule(http.MethodPost, "/documents/bulk-delete", minRole(domain.RoleMember), owner),
+		rule(http.MethodGet, "/documents/bulk-delete", minRole(domain.RoleMember), ""),
+		rule(http.MethodGet, "/documents/review-queue", anyRole, ""),
+		rule(http.MethodGet, "/documents/checker-queue", anyRole, ""),
+		rule(http.MethodGet, "/documents/escalations", anyRole, ""),
+		rule(http.MethodGet, "/documents/starred", anyRole, ""),
+		rule(http.MethodGet, "/documents/:id", anyRole, viewer),
+		rule(http.MethodPut, "/documents/:id", anyRole, editor),
+		rule(http.MethodPost, "/documents/:id/retry", anyRole, editor),
+		rule(http.MethodPost, "/documents/:id/replace-file", anyRole, editor),
+		rule(http.MethodPost, "/documents/:id/reclassify", anyRole, editor),
+		rule(http.MethodGet, "/documents/:id/versions", anyRole, viewer),
+		rule(http.MethodGet, "/documents/:id/approvals", anyRole, viewer),
+		rule(http.MethodGet, "/documents/:id/lock", anyRole, viewer),
+		rule(http.MethodPut, "/documents/:id/lock", anyRole, editor),
+		rule(http.MethodDelete, "/documents/:id/lock", anyRole, editor),
+		rule(http.MethodGet, "/documents/:id/duplicates", anyRole, viewer),
+		rule(http.MethodPut, "/documents/:id/review", anyRole, editor),
+		rule(http.MethodPut, "/documents/:id/assign", anyRole, editor),
+		rule(http.MethodPut, "/documents/:id/structured-data", anyRole, editor),
+		rule(http.MethodPost, "/documents/:id/validate", anyRole, editor),
+		rule(http.MethodGet, "/documents/:id/validation", anyRole, viewer),
+		rule(http.MethodPost, "/documents/:id/recompute", anyRole, viewer),
+		rule(http.MethodPatch, "/documents/:id/line-items", anyRole, editor),
+		rule(http.MethodGet, "/documents/:id/tags", anyRole, viewer),
+		rule(http.MethodGet, "/documents/:id/neighbors", anyRole, viewer),
+		rule(http.MethodPut, "/documents/:id/star", anyRole, viewer),
+		rule(http.MethodDelete, "/documents/:id/star", anyRole, ""),
+		rule(http.MethodPost, "/documents/:id/tags", anyRole, editor),
+		rule(http.MethodDelete, "/documents/:id/tags/:tagId", anyRole, editor),
+		rule(http.MethodGet, "/documents/:id/audit", anyRole, ""),
+		rule(http.MethodGet, "/documents/:id/timeline", anyRole, viewer),
+		rule(http.MethodGet, "/documents/:id/attempts", anyRole, viewer),
+		rule(http.MethodGet, "/documents/:id/overrides", anyRole, viewer),
+		rule(http.MethodDelete, "/documents/:id/overrides", anyRole, editor),
+		rule(http.MethodDelete, "/documents/:id", minRole(domain.RoleAdmin), ""),
+
+		// Second-opinion QA; verdicts check collection permission in the service
+		rule(http.MethodGet, "/qa-reviews/queue", anyRole, ""),
+		rule(http.MethodPost, "/qa-reviews/:id/verdict", anyRole, ""),
+		rule(http.MethodGet, "/qa-reviews/scores", minRole(domain.RoleManager), ""),
+
+		// Audit, stats, feeds, reports
+		rule(http.MethodGet, "/audit", minRole(domain.RoleManager), ""),
+		rule(http.MethodGet, "/audit/denials", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/stats", anyRole, ""),
+		rule(http.MethodGet, "/stats/parse-latency", minRole(domain.RoleManager), ""),
+		rule(http.MethodGet, "/stats/confidence-calibration", minRole(domain.RoleManager), ""),
+		rule(http.MethodGet, "/stats/rule-noise", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/stats/quality", minRole(domain.RoleManager), ""),
+		rule(http.MethodGet, "/stats/quality/history", minRole(domain.RoleManager), ""),
+		rule(http.MethodGet, "/feeds/ingestions", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/reports/sellers", anyRole, ""),
+		rule(http.MethodGet, "/reports/buyers", anyRole, ""),
+		rule(http.MethodGet, "/reports/party-ledger", anyRole, ""),
+		rule(http.MethodGet, "/reports/financial-summary", anyRole, ""),
+		rule(http.MethodGet, "/reports/tax-summary", anyRole, ""),
+		rule(http.MethodGet, "/reports/hsn-summary", anyRole, ""),
+		rule(http.MethodGet, "/reports/collections-overview", anyRole, ""),
+		rule(http.MethodGet, "/reports/rejection-reasons", anyRole, ""),
+		rule(http.MethodGet, "/reports/orphan-files", minRole(domain.RoleManager), ""),
+		rule(http.MethodGet, "/schemas/:documentType", anyRole, ""),
+		rule(http.MethodPost, "/validation-rules/simulate", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/rejection-reasons", anyRole, ""),
+		rule(http.MethodPut, "/rejection-reasons/:code", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/related-parties", anyRole, ""),
+		rule(http.MethodPut, "/related-parties/:gstin", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/related-parties/:gstin", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/computed-fields", anyRole, ""),
+		rule(http.MethodPut, "/computed-fields/:name", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/computed-fields/:name", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/hsn/tree", anyRole, ""),
+		rule(http.MethodGet, "/hsn/:code/children", anyRole, ""),
+		rule(http.MethodPost, "/hsn/rates", anyRole, ""),
+
+		// Users
+		rule(http.MethodGet, "/me/capabilities", anyRole, ""),
+		rule(http.MethodPost, "/users", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/users", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/users/me/delegation", minRole(domain.RoleMember), ""),
+		rule(http.MethodPut, "/users/me/delegation", minRole(domain.RoleMember), ""),
+		rule(http.MethodDelete, "/users/me/delegation", minRole(domain.RoleMember), ""),
+		rule(http.MethodPost, "/users/me/api-keys", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/users/me/api-keys", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/users/me/api-keys/:keyId", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/users/me/notifications", minRole(domain.RoleMember), ""),
+		rule(http.MethodPost, "/users/me/notifications/:id/read", minRole(domain.RoleMember), ""),
+		rule(http.MethodGet, "/users/:id", anyRole, ""),
+		rule(http.MethodPut, "/users/:id", anyRole, ""),
+		rule(http.MethodDelete, "/users/:id", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/users/:id/security-events", anyRole, ""),
+		rule(http.MethodDelete, "/users/:id/email-suppression", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/memberships", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/memberships", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/memberships/:userId", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPost, "/clients", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/clients", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/clients/dashboard", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/clients/:id", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/clients/:id", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/clients/:id/staff", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/clients/:id/staff", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/clients/:id/staff/:userId", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPost, "/clients/:id/staff/:userId/move", minRole(domain.RoleAdmin), ""),
+
+		// Admin
+		rule(http.MethodGet, "/admin/authz-matrix", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/maintenance", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/admin/maintenance", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/faults", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/admin/faults/:target", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/admin/faults/:target", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/parse-queue", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPost, "/admin/tenants", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/tenants", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/tenants/:id", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/admin/tenants/:id", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/admin/tenants/:id", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/tenants/:id/flags", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/admin/tenants/:id/flags/:flag", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/admin/tenants/:id/flags/:flag", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPost, "/admin/tenants/:id/stats/recount", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPost, "/admin/tenants/:id/demo-data", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/admin/tenants/:id/demo-data", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/tenants/:id/analytics-export", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/admin/tenants/:id/analytics-export", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodDelete, "/admin/tenants/:id/analytics-export", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPost, "/admin/tenants/:id/analytics-export/run", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPost, "/admin/tenants/:id/moves", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/tenants/:id/moves", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/tenants/:id/moves/:moveId", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/tenants/:id/notification-routes", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/admin/tenants/:id/notification-routes", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodGet, "/admin/tenants/:id/cors-origins", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPut, "/admin/tenants/:id/cors-origins", minRole(domain.RoleAdmin), ""),
+		rule(http.MethodPost, "/admin/tenants/:id/users/import", minRole(domain.RoleAdmin), ""),
+	}
+}
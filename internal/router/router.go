@@ -1,43 +1,117 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
-	"satvos/internal/domain"
+	"satvos/internal/faults"
 	"satvos/internal/handler"
 	"satvos/internal/middleware"
 	"satvos/internal/port"
 	"satvos/internal/service"
 )
 
+// TenantStatusCacheTTL bounds how long a suspended or moved tenant's existing tokens keep
+// working on this server.
+const TenantStatusCacheTTL = 30 * time.Second
+
+// TenantCORSCacheTTL bounds how long a change to a tenant's CORS origins takes to
+// reach this server.
+const TenantCORSCacheTTL = 30 * time.Second
+
+// Handlers are the HTTP handlers the router mounts, one per resource.
+type Handlers struct {
+	Auth              *handler.AuthHandler
+	File              *handler.FileHandler
+	Tenant            *handler.TenantHandler
+	User              *handler.UserHandler
+	Health            *handler.HealthHandler
+	Collection        *handler.CollectionHandler
+	Document          *handler.DocumentHandler
+	Stats             *handler.StatsHandler
+	Report            *handler.ReportHandler
+	FeatureFlag       *handler.FeatureFlagHandler
+	Import            *handler.ImportHandler
+	CloudImport       *handler.CloudImportHandler
+	BatchFeed         *handler.BatchFeedHandler
+	HSN               *handler.HSNHandler
+	BulkTag           *handler.BulkTagHandler
+	Star              *handler.StarHandler
+	DemoData          *handler.DemoDataHandler
+	AnalyticsExport   *handler.AnalyticsExportHandler
+	Integration       *handler.IntegrationHandler
+	Notification      *handler.NotificationHandler
+	RejectionReason   *handler.RejectionReasonHandler
+	ReviewEscalation  *handler.ReviewEscalationHandler
+	TenantMembership  *handler.TenantMembershipHandler
+	ClientTenant      *handler.ClientTenantHandler
+	URLImport         *handler.URLImportHandler
+	ParsePreview      *handler.ParsePreviewHandler
+	Schema            *handler.SchemaHandler
+	PageImage         *handler.PageImageHandler
+	ValidationRun     *handler.ValidationRunHandler
+	RuleSimulation    *handler.RuleSimulationHandler
+	TenantMove        *handler.TenantMoveHandler
+	NotificationRoute *handler.NotificationRouteHandler
+	Inbox             *handler.InboxHandler
+	QAReview          *handler.QAReviewHandler
+	DocumentLock      *handler.DocumentLockHandler
+	BulkDelete        *handler.BulkDeleteHandler
+	TenantCORS        *handler.TenantCORSHandler
+	EmailFeedback     *handler.EmailFeedbackHandler
+	ComputedField     *handler.ComputedFieldHandler
+	Duplicate         *handler.DuplicateHandler
+	Capability        *handler.CapabilityHandler
+	FilePreflight     *handler.FilePreflightHandler
+	APIKey            *handler.APIKeyHandler
+	ParseQueue        *handler.ParseQueueHandler
+	RelatedParty      *handler.RelatedPartyHandler
+	DocumentStream    *handler.DocumentStreamHandler
+	UserImport        *handler.UserImportHandler
+	OrphanFile        *handler.OrphanFileHandler
+}
+
+// Deps is everything Setup needs: the handlers and the dependencies of the
+// router's own middleware (CORS, authentication, tenant and membership checks,
+// review locks, denial auditing, maintenance mode, body and rate limits).
+type Deps struct {
+	Handlers Handlers
+
+	AuthSvc              service.AuthService
+	CORSOrigins          []string
+	CORSOriginRepo       port.TenantCORSOriginRepository
+	UserRepo             port.UserRepository
+	TenantRepo           port.TenantRepository
+	DocLockRepo          port.DocumentLockRepository
+	DBCluster            string
+	AuthzDenialRepo      port.AuthzDenialRepository
+	AuditDenials         bool
+	Maintenance          *middleware.MaintenanceMode
+	FaultInjector        *faults.Injector
+	BodyLimits           middleware.BodyLimits
+	PreviewRatePerMinute int
+}
+
 // Setup configures the Gin engine with all routes and middleware.
-func Setup(
-	authSvc service.AuthService,
-	authH *handler.AuthHandler,
-	fileH *handler.FileHandler,
-	tenantH *handler.TenantHandler,
-	userH *handler.UserHandler,
-	healthH *handler.HealthHandler,
-	collectionH *handler.CollectionHandler,
-	documentH *handler.DocumentHandler,
-	statsH *handler.StatsHandler,
-	reportH *handler.ReportHandler,
-	corsOrigins []string,
-	userRepo port.UserRepository,
-) *gin.Engine {
+func Setup(deps *Deps) *gin.Engine {
+	h := &deps.Handlers
 	r := gin.New()
 
 	// Global middleware
 	r.Use(middleware.Recovery())
 	r.Use(middleware.RequestID())
-	r.Use(middleware.CORS(corsOrigins))
+	r.Use(middleware.TenantCORS(deps.CORSOrigins, deps.AuthSvc, deps.CORSOriginRepo, TenantCORSCacheTTL))
 	r.Use(middleware.Logger())
+	r.Use(middleware.Maintenance(deps.Maintenance,
+		"/api/v1/auth/login", "/api/v1/auth/refresh", "/api/v1/auth/social-login",
+		"/api/v1/admin/maintenance"))
 
 	// Health checks
-	r.GET("/healthz", healthH.Liveness)
-	r.GET("/readyz", healthH.Readiness)
+	r.GET("/healthz", h.Health.Liveness)
+	r.GET("/readyz", h.Health.Readiness)
 
 	// Swagger docs
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler,
@@ -45,98 +119,327 @@ func Setup(
 	))
 
 	v1 := r.Group("/api/v1")
+	v1.Use(middleware.BodyLimit(deps.BodyLimits.JSON, map[string]int64{
+		apiPrefix + "/auth/login":              deps.BodyLimits.Auth,
+		apiPrefix + "/auth/refresh":            deps.BodyLimits.Auth,
+		apiPrefix + "/auth/register":           deps.BodyLimits.Auth,
+		apiPrefix + "/auth/forgot-password":    deps.BodyLimits.Auth,
+		apiPrefix + "/auth/reset-password":     deps.BodyLimits.Auth,
+		apiPrefix + "/auth/undo-email-change":  deps.BodyLimits.Auth,
+		apiPrefix + "/auth/social-login":       deps.BodyLimits.Auth,
+		apiPrefix + "/auth/switch-tenant":      deps.BodyLimits.Auth,
+		apiPrefix + "/files/upload":            deps.BodyLimits.Upload,
+		apiPrefix + "/collections/:id/files":   deps.BodyLimits.Upload,
+		apiPrefix + "/collections/:id/imports": deps.BodyLimits.Upload,
+		apiPrefix + "/parse/preview":           deps.BodyLimits.Upload,
+	}))
 
 	// Public auth routes
 	auth := v1.Group("/auth")
-	auth.POST("/login", authH.Login)
-	auth.POST("/refresh", authH.RefreshToken)
-	auth.POST("/register", authH.Register)
-	auth.GET("/verify-email", authH.VerifyEmail)
-	auth.POST("/forgot-password", authH.ForgotPassword)
-	auth.POST("/reset-password", authH.ResetPassword)
-	auth.POST("/social-login", authH.SocialLogin)
+	auth.POST("/login", h.Auth.Login)
+	auth.POST("/refresh", h.Auth.RefreshToken)
+	auth.POST("/register", h.Auth.Register)
+	auth.GET("/verify-email", h.Auth.VerifyEmail)
+	auth.POST("/forgot-password", h.Auth.ForgotPassword)
+	auth.POST("/reset-password", h.Auth.ResetPassword)
+	auth.POST("/undo-email-change", h.Auth.UndoEmailChange)
+	auth.POST("/social-login", h.Auth.SocialLogin)
+
+	// Public error catalogue; error responses link here
+	errorsH := handler.NewErrorCatalogueHandler()
+	v1.GET("/errors", errorsH.List)
+	v1.GET("/errors/:code", errorsH.Get)
+
+	// SES bounce and complaint feedback from SNS; the message signature authenticates it
+	v1.POST("/webhooks/ses", h.EmailFeedback.HandleSNS)
+
+	// Route authorization matrix, enforced after authentication on every protected route
+	matrix := RouteMatrix()
+	authzH := handler.NewAuthzHandler(matrix, deps.AuthzDenialRepo)
+	// 403s are recorded only when denial auditing is on; the list endpoint always works
+	var denialRecorder port.AuthzDenialRepository
+	if deps.AuditDenials {
+		denialRecorder = deps.AuthzDenialRepo
+	}
+	maintenanceH := handler.NewMaintenanceHandler(deps.Maintenance)
+	faultH := handler.NewFaultInjectionHandler(deps.FaultInjector)
 
 	// Protected routes - require valid JWT
 	protected := v1.Group("")
-	protected.Use(middleware.AuthMiddleware(authSvc))
+	protected.Use(middleware.AuthMiddleware(deps.AuthSvc))
+	protected.Use(middleware.AuditDenials(denialRecorder))
+	protected.Use(middleware.RequireActiveTenant(deps.TenantRepo, deps.DBCluster, TenantStatusCacheTTL))
+	protected.Use(middleware.RequireActiveMembership(deps.UserRepo, TenantStatusCacheTTL))
+	protected.Use(middleware.EnforceRouteMatrix(matrix))
 
 	// Resend verification (authenticated, no email verification required)
-	protected.POST("/auth/resend-verification", authH.ResendVerification)
+	protected.POST("/auth/resend-verification", h.Auth.ResendVerification)
+
+	// Tenant switching for users with access to several tenants
+	protected.GET("/auth/tenants", h.TenantMembership.ListTenants)
+	protected.POST("/auth/switch-tenant", h.TenantMembership.SwitchTenant)
 
 	// File routes
 	files := protected.Group("/files")
-	files.POST("/upload",
-		middleware.RequireRole(domain.RoleAdmin, domain.RoleManager, domain.RoleMember, domain.RoleFree),
-		middleware.RequireEmailVerified(userRepo),
-		fileH.Upload)
-	files.GET("", fileH.List)
-	files.GET("/:id", fileH.GetByID)
-	files.DELETE("/:id", middleware.RequireRole(domain.RoleAdmin), fileH.Delete)
+	files.POST("/upload", middleware.RequireEmailVerified(deps.UserRepo), h.File.Upload)
+	files.GET("", h.File.List)
+	files.GET("/:id", h.File.GetByID)
+	files.GET("/:id/download", h.File.Download)
+	files.GET("/:id/pages/:n/image", h.PageImage.GetPageImage)
+	files.POST("/:id/preflight", h.FilePreflight.Preflight)
+	files.DELETE("/:id", h.File.Delete)
 
 	// Collection routes
 	collections := protected.Group("/collections")
-	collections.POST("", middleware.RequireRole(domain.RoleAdmin, domain.RoleManager, domain.RoleMember), collectionH.Create)
-	collections.GET("", collectionH.List)
-	collections.GET("/:id", collectionH.GetByID)
-	collections.PUT("/:id", collectionH.Update)
-	collections.DELETE("/:id", collectionH.Delete)
-	collections.POST("/:id/files", collectionH.BatchUploadFiles)
-	collections.DELETE("/:id/files/:fileId", collectionH.RemoveFile)
-	collections.POST("/:id/permissions", collectionH.SetPermission)
-	collections.GET("/:id/permissions", collectionH.ListPermissions)
-	collections.DELETE("/:id/permissions/:userId", collectionH.RemovePermission)
-	collections.GET("/:id/export/csv", collectionH.ExportCSV)
-
-	// Document routes
+	collections.POST("", h.Collection.Create)
+	collections.GET("", h.Collection.List)
+	collections.GET("/starred", h.Star.ListCollections)
+	collections.GET("/:id", h.Collection.GetByID)
+	collections.PUT("/:id", h.Collection.Update)
+	collections.PUT("/:id/review-checklist", h.Collection.SetReviewChecklist)
+	collections.PUT("/:id/approval-policy", h.Collection.SetApprovalPolicy)
+	collections.PUT("/:id/escalation-policy", h.Collection.SetEscalationPolicy)
+	collections.PUT("/:id/kpi-targets", h.Collection.SetKPITargets)
+	collections.PUT("/:id/qa-sampling", h.Collection.SetQASampling)
+	collections.PUT("/:id/parse-mode", h.Collection.SetDefaultParseMode)
+	collections.PUT("/:id/naming-template", h.Collection.SetNamingTemplate)
+	collections.PUT("/:id/file-download", h.Collection.SetFileDownloadPerm)
+	collections.GET("/:id/progress", h.Collection.GetProgress)
+	collections.DELETE("/:id", h.Collection.Delete)
+	collections.PUT("/:id/star", h.Star.StarCollection)
+	collections.DELETE("/:id/star", h.Star.UnstarCollection)
+	collections.POST("/:id/files", h.Collection.BatchUploadFiles)
+	collections.DELETE("/:id/files/:fileId", h.Collection.RemoveFile)
+	collections.POST("/:id/permissions", h.Collection.SetPermission)
+	collections.GET("/:id/permissions", h.Collection.ListPermissions)
+	collections.DELETE("/:id/permissions/:userId", h.Collection.RemovePermission)
+	collections.GET("/:id/activity", h.Collection.ListActivity)
+	collections.GET("/:id/export/csv", h.Collection.ExportCSV)
+	collections.GET("/:id/export/validation-failures", h.Collection.ExportValidationFailures)
+	collections.GET("/:id/exports", h.Collection.ListExports)
+	collections.GET("/:id/exports/:exportId/downloads", h.Collection.ListExportDownloads)
+	collections.POST("/:id/imports", middleware.RequireEmailVerified(deps.UserRepo), h.Import.ImportZip)
+	collections.POST("/:id/imports/s3", middleware.RequireEmailVerified(deps.UserRepo), h.Import.ImportS3Prefix)
+	collections.GET("/:id/imports", h.Import.List)
+	collections.GET("/:id/imports/:importId", h.Import.Get)
+	collections.GET("/:id/orphan-files", h.OrphanFile.List)
+	collections.POST("/:id/orphan-files/documents", middleware.RequireEmailVerified(deps.UserRepo), h.OrphanFile.CreateDocuments)
+	collections.POST("/:id/validate", h.ValidationRun.Start)
+	collections.GET("/:id/validation-runs", h.ValidationRun.List)
+	collections.GET("/:id/validation-runs/:runId", h.ValidationRun.Get)
+	collections.POST("/:id/cloud-syncs", middleware.RequireEmailVerified(deps.UserRepo), h.CloudImport.CreateSync)
+	collections.GET("/:id/cloud-syncs", h.CloudImport.ListSyncs)
+	collections.GET("/:id/cloud-syncs/:syncId", h.CloudImport.GetSync)
+	collections.PUT("/:id/cloud-syncs/:syncId", h.CloudImport.UpdateSync)
+	collections.DELETE("/:id/cloud-syncs/:syncId", h.CloudImport.DeleteSync)
+	collections.POST("/:id/cloud-syncs/:syncId/run", h.CloudImport.RunSync)
+	collections.GET("/:id/cloud-syncs/:syncId/files", h.CloudImport.ListSyncFiles)
+	collections.GET("/:id/notification-channels", h.Notification.List)
+	collections.POST("/:id/notification-channels", h.Notification.Create)
+	collections.PUT("/:id/notification-channels/:channelId", h.Notification.Update)
+	collections.DELETE("/:id/notification-channels/:channelId", h.Notification.Delete)
+	collections.POST("/:id/notification-channels/:channelId/test", h.Notification.Test)
+
+	// Cloud storage integrations (Google Drive, Dropbox)
+	integrations := protected.Group("/integrations")
+	integrations.GET("/connections", h.CloudImport.ListConnections)
+	integrations.DELETE("/connections/:id", h.CloudImport.DeleteConnection)
+	integrations.GET("/connections/:id/folders", h.CloudImport.ListFolder)
+	integrations.GET("/:provider/authorize", h.CloudImport.Authorize)
+	integrations.POST("/:provider/connect", h.CloudImport.Connect)
+
+	// No-code integrations (Zapier, Make): polling feed and REST hooks
+	integrations.GET("/documents/approved", h.Integration.ListApproved)
+	integrations.GET("/hooks", h.Integration.ListHooks)
+	integrations.POST("/hooks", h.Integration.Subscribe)
+	integrations.PUT("/hooks/:id/template", h.Integration.SetHookTemplate)
+	integrations.DELETE("/hooks/:id", h.Integration.Unsubscribe)
+
+	// Dry-run parsing: nothing is stored, but each call costs an LLM parse
+	protected.POST("/parse/preview", middleware.RequireEmailVerified(deps.UserRepo),
+		middleware.RateLimitPerUser(deps.PreviewRatePerMinute, time.Minute), h.ParsePreview.Preview)
+
+	// Document routes; edits are refused while another user holds the review lock
+	unlocked := middleware.RequireDocumentUnlocked(deps.DocLockRepo, deps.UserRepo)
 	documents := protected.Group("/documents")
-	documents.POST("", middleware.RequireEmailVerified(userRepo), documentH.Create)
-	documents.GET("", documentH.List)
-	documents.GET("/search/tags", documentH.SearchByTag)
-	documents.GET("/review-queue", documentH.ReviewQueue)
-	documents.GET("/:id", documentH.GetByID)
-	documents.PUT("/:id", documentH.EditStructuredData)
-	documents.POST("/:id/retry", documentH.Retry)
-	documents.PUT("/:id/review", documentH.UpdateReview)
-	documents.PUT("/:id/assign", documentH.AssignDocument)
-	documents.PUT("/:id/structured-data", documentH.EditStructuredData)
-	documents.POST("/:id/validate", documentH.Validate)
-	documents.GET("/:id/validation", documentH.GetValidation)
-	documents.GET("/:id/tags", documentH.ListTags)
-	documents.POST("/:id/tags", documentH.AddTags)
-	documents.DELETE("/:id/tags/:tagId", documentH.DeleteTag)
-	documents.GET("/:id/audit", documentH.ListAudit)
-	documents.DELETE("/:id", middleware.RequireRole(domain.RoleAdmin), documentH.Delete)
+	documents.POST("", middleware.RequireEmailVerified(deps.UserRepo), h.Document.Create)
+	documents.POST("/from-url", middleware.RequireEmailVerified(deps.UserRepo), h.URLImport.CreateFromURL)
+	documents.GET("", h.Document.List)
+	documents.GET("/search/tags", h.Document.SearchByTag)
+	documents.GET("/search/amount", h.Report.SearchByAmount)
+	documents.GET("/stream", h.DocumentStream.Stream)
+	documents.POST("/bulk-tags", h.BulkTag.Start)
+	documents.GET("/bulk-tags", h.BulkTag.List)
+	documents.GET("/bulk-tags/:jobId", h.BulkTag.Get)
+	documents.POST("/bulk-delete", h.BulkDelete.Run)
+	documents.GET("/bulk-delete", h.BulkDelete.List)
+	documents.GET("/review-queue", h.Document.ReviewQueue)
+	documents.GET("/checker-queue", h.Document.CheckerQueue)
+	documents.GET("/escalations", h.ReviewEscalation.List)
+	documents.GET("/starred", h.Star.ListDocuments)
+	documents.GET("/:id", h.Document.GetByID)
+	documents.PUT("/:id", unlocked, h.Document.EditStructuredData)
+	documents.POST("/:id/retry", unlocked, h.Document.Retry)
+	documents.POST("/:id/replace-file", unlocked, h.Document.ReplaceFile)
+	documents.POST("/:id/reclassify", unlocked, h.Document.Reclassify)
+	documents.GET("/:id/versions", h.Document.ListVersions)
+	documents.GET("/:id/approvals", h.Document.ListApprovals)
+	documents.GET("/:id/lock", h.DocumentLock.Get)
+	documents.PUT("/:id/lock", h.DocumentLock.Acquire)
+	documents.DELETE("/:id/lock", h.DocumentLock.Release)
+	documents.GET("/:id/duplicates", h.Duplicate.List)
+	documents.PUT("/:id/review", unlocked, h.Document.UpdateReview)
+	documents.PUT("/:id/assign", unlocked, h.Document.AssignDocument)
+	documents.PUT("/:id/structured-data", unlocked, h.Document.EditStructuredData)
+	documents.POST("/:id/validate", unlocked, h.Document.Validate)
+	documents.GET("/:id/validation", h.Document.GetValidation)
+	documents.POST("/:id/recompute", unlocked, h.Document.RecomputeTotals)
+	documents.PATCH("/:id/line-items", unlocked, h.Document.PatchLineItems)
+	documents.GET("/:id/tags", h.Document.ListTags)
+	documents.GET("/:id/neighbors", h.Document.Neighbors)
+	documents.PUT("/:id/star", h.Star.StarDocument)
+	documents.DELETE("/:id/star", h.Star.UnstarDocument)
+	documents.POST("/:id/tags", unlocked, h.Document.AddTags)
+	documents.DELETE("/:id/tags/:tagId", unlocked, h.Document.DeleteTag)
+	documents.GET("/:id/audit", h.Document.ListAudit)
+	documents.GET("/:id/timeline", h.Document.Timeline)
+	documents.GET("/:id/attempts", h.Document.Attempts)
+	documents.GET("/:id/overrides", h.Document.ListOverrides)
+	documents.DELETE("/:id/overrides", unlocked, h.Document.ClearOverrides)
+	documents.DELETE("/:id", unlocked, h.Document.Delete)
+
+	// Second-opinion QA of sampled approvals
+	qaReviews := protected.Group("/qa-reviews")
+	qaReviews.GET("/queue", h.QAReview.Queue)
+	qaReviews.POST("/:id/verdict", h.QAReview.SubmitVerdict)
+	qaReviews.GET("/scores", h.QAReview.Scores)
+
+	// Tenant-wide audit log search
+	protected.GET("/audit", h.Document.SearchAudit)
+	protected.GET("/audit/denials", authzH.Denials)
 
 	// Stats
-	protected.GET("/stats", statsH.GetStats)
+	protected.GET("/stats", h.Stats.GetStats)
+	protected.GET("/stats/parse-latency", h.Stats.GetParseLatency)
+	protected.GET("/stats/confidence-calibration", h.Stats.GetConfidenceCalibration)
+	protected.GET("/stats/rule-noise", h.Stats.GetRuleNoise)
+	protected.GET("/stats/quality", h.Stats.GetCollectionQuality)
+	protected.GET("/stats/quality/history", h.Stats.GetCollectionQualityHistory)
+
+	// Batch feed ingestion log
+	protected.GET("/feeds/ingestions", h.BatchFeed.ListIngestions)
 
 	// Report routes
 	reports := protected.Group("/reports")
-	reports.GET("/sellers", reportH.Sellers)
-	reports.GET("/buyers", reportH.Buyers)
-	reports.GET("/party-ledger", reportH.PartyLedger)
-	reports.GET("/financial-summary", reportH.FinancialSummary)
-	reports.GET("/tax-summary", reportH.TaxSummary)
-	reports.GET("/hsn-summary", reportH.HSNSummary)
-	reports.GET("/collections-overview", reportH.CollectionsOverview)
+	reports.GET("/sellers", h.Report.Sellers)
+	reports.GET("/buyers", h.Report.Buyers)
+	reports.GET("/party-ledger", h.Report.PartyLedger)
+	reports.GET("/financial-summary", h.Report.FinancialSummary)
+	reports.GET("/tax-summary", h.Report.TaxSummary)
+	reports.GET("/hsn-summary", h.Report.HSNSummary)
+	reports.GET("/collections-overview", h.Report.CollectionsOverview)
+	reports.GET("/rejection-reasons", h.RejectionReason.Stats)
+	reports.GET("/orphan-files", h.OrphanFile.Summary)
+
+	// Structured-data schema per document type, for forms and integrators
+	protected.GET("/schemas/:documentType", h.Schema.Get)
+	protected.POST("/validation-rules/simulate", h.RuleSimulation.Simulate)
+
+	// Rejection-reason taxonomy (tenant-scoped)
+	protected.GET("/rejection-reasons", h.RejectionReason.List)
+	protected.PUT("/rejection-reasons/:code", h.RejectionReason.Set)
+
+	// Related-party GSTINs (tenant-scoped); invoices from them are flagged
+	protected.GET("/related-parties", h.RelatedParty.List)
+	protected.PUT("/related-parties/:gstin", h.RelatedParty.Set)
+	protected.DELETE("/related-parties/:gstin", h.RelatedParty.Delete)
+
+	// Computed fields (tenant-scoped), returned with ?extensions=true
+	protected.GET("/computed-fields", h.ComputedField.List)
+	protected.PUT("/computed-fields/:name", h.ComputedField.Set)
+	protected.DELETE("/computed-fields/:name", h.ComputedField.Delete)
+
+	// HSN master list browse
+	protected.GET("/hsn/tree", h.HSN.Tree)
+	protected.GET("/hsn/:code/children", h.HSN.Children)
+	protected.POST("/hsn/rates", h.HSN.ProposeRates)
+
+	// What the current user may do, for hiding actions client-side
+	protected.GET("/me/capabilities", h.Capability.Get)
 
 	// User management (tenant-scoped)
 	users := protected.Group("/users")
-	users.POST("", middleware.RequireRole(domain.RoleAdmin), userH.Create)
-	users.GET("", middleware.RequireRole(domain.RoleAdmin), userH.List)
-	users.GET("/:id", userH.GetByID)
-	users.PUT("/:id", userH.Update)
-	users.DELETE("/:id", middleware.RequireRole(domain.RoleAdmin), userH.Delete)
+	users.POST("", h.User.Create)
+	users.GET("", h.User.List)
+	users.GET("/me/delegation", h.User.GetDelegation)
+	users.PUT("/me/delegation", h.User.SetDelegation)
+	users.DELETE("/me/delegation", h.User.ClearDelegation)
+	users.POST("/me/api-keys", h.APIKey.Create)
+	users.GET("/me/api-keys", h.APIKey.List)
+	users.DELETE("/me/api-keys/:keyId", h.APIKey.Delete)
+	users.GET("/me/notifications", h.Inbox.List)
+	users.POST("/me/notifications/:id/read", h.Inbox.MarkRead)
+	users.GET("/:id", h.User.GetByID)
+	users.GET("/:id/security-events", h.User.ListSecurityEvents)
+	users.PUT("/:id", h.User.Update)
+	users.DELETE("/:id", h.User.Delete)
+	users.DELETE("/:id/email-suppression", h.EmailFeedback.ClearSuppression)
+
+	// Guests from other tenants
+	memberships := protected.Group("/memberships")
+	memberships.GET("", h.TenantMembership.ListGuests)
+	memberships.PUT("", h.TenantMembership.Grant)
+	memberships.DELETE("/:userId", h.TenantMembership.Revoke)
+
+	// Client sub-tenants of a firm tenant
+	clients := protected.Group("/clients")
+	clients.POST("", h.ClientTenant.Create)
+	clients.GET("", h.ClientTenant.List)
+	clients.GET("/dashboard", h.ClientTenant.Dashboard)
+	clients.GET("/:id", h.ClientTenant.Get)
+	clients.PUT("/:id", h.ClientTenant.Update)
+	clients.GET("/:id/staff", h.ClientTenant.ListStaff)
+	clients.PUT("/:id/staff", h.ClientTenant.AssignStaff)
+	clients.DELETE("/:id/staff/:userId", h.ClientTenant.RemoveStaff)
+	clients.POST("/:id/staff/:userId/move", h.ClientTenant.MoveStaff)
 
 	// Admin routes - tenant management
 	admin := v1.Group("/admin")
-	admin.Use(middleware.AuthMiddleware(authSvc))
-	admin.Use(middleware.RequireRole(domain.RoleAdmin))
-	admin.POST("/tenants", tenantH.Create)
-	admin.GET("/tenants", tenantH.List)
-	admin.GET("/tenants/:id", tenantH.GetByID)
-	admin.PUT("/tenants/:id", tenantH.Update)
-	admin.DELETE("/tenants/:id", tenantH.Delete)
+	admin.Use(middleware.AuthMiddleware(deps.AuthSvc))
+	admin.Use(middleware.AuditDenials(denialRecorder))
+	admin.Use(middleware.RequireActiveTenant(deps.TenantRepo, deps.DBCluster, TenantStatusCacheTTL))
+	admin.Use(middleware.RequireActiveMembership(deps.UserRepo, TenantStatusCacheTTL))
+	admin.Use(middleware.EnforceRouteMatrix(matrix))
+	admin.GET("/authz-matrix", authzH.Matrix)
+	admin.GET("/maintenance", maintenanceH.Get)
+	admin.PUT("/maintenance", maintenanceH.Set)
+	admin.GET("/faults", faultH.List)
+	admin.PUT("/faults/:target", faultH.Set)
+	admin.DELETE("/faults/:target", faultH.Clear)
+	admin.GET("/parse-queue", h.ParseQueue.Status)
+	admin.POST("/tenants", h.Tenant.Create)
+	admin.GET("/tenants", h.Tenant.List)
+	admin.GET("/tenants/:id", h.Tenant.GetByID)
+	admin.PUT("/tenants/:id", h.Tenant.Update)
+	admin.DELETE("/tenants/:id", h.Tenant.Delete)
+	admin.GET("/tenants/:id/flags", h.FeatureFlag.List)
+	admin.PUT("/tenants/:id/flags/:flag", h.FeatureFlag.Set)
+	admin.DELETE("/tenants/:id/flags/:flag", h.FeatureFlag.Reset)
+	admin.POST("/tenants/:id/stats/recount", h.Stats.Recount)
+	admin.POST("/tenants/:id/demo-data", h.DemoData.Seed)
+	admin.DELETE("/tenants/:id/demo-data", h.DemoData.Cleanup)
+	admin.GET("/tenants/:id/analytics-export", h.AnalyticsExport.Get)
+	admin.PUT("/tenants/:id/analytics-export", h.AnalyticsExport.Configure)
+	admin.DELETE("/tenants/:id/analytics-export", h.AnalyticsExport.Delete)
+	admin.POST("/tenants/:id/analytics-export/run", h.AnalyticsExport.Run)
+	admin.POST("/tenants/:id/moves", h.TenantMove.Start)
+	admin.GET("/tenants/:id/moves", h.TenantMove.List)
+	admin.GET("/tenants/:id/moves/:moveId", h.TenantMove.Get)
+	admin.GET("/tenants/:id/notification-routes", h.NotificationRoute.Get)
+	admin.PUT("/tenants/:id/notification-routes", h.NotificationRoute.Set)
+	admin.GET("/tenants/:id/cors-origins", h.TenantCORS.Get)
+	admin.PUT("/tenants/:id/cors-origins", h.TenantCORS.Set)
+	admin.POST("/tenants/:id/users/import", h.UserImport.Import)
 
 	return r
 }
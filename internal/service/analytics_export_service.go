@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/parquetexport"
+	"satvos/internal/port"
+)
+
+const (
+	analyticsExportBatchSize     = 1000
+	analyticsExportClaimLimit    = 5
+	analyticsExportDefaultHours  = 24
+	analyticsExportMaxHours      = 168
+	analyticsExportContentType   = "application/vnd.apache.parquet"
+	analyticsExportKeyTimeFormat = "20060102T150405Z"
+	// analyticsExportLease is how long a claimed run owns its export; a failed
+	// run is retried once it expires.
+	analyticsExportLease = time.Hour
+	// analyticsExportLag leaves the newest minutes for the next run, so documents
+	// stamped by an instance with a slightly slow clock are not skipped.
+	analyticsExportLag = 5 * time.Minute
+)
+
+var s3BucketNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// ConfigureAnalyticsExportInput is the DTO for setting a tenant's analytics export.
+type ConfigureAnalyticsExportInput struct {
+	// Destination is s3://bucket/prefix; tables are written under the prefix.
+	Destination string `json:"destination" binding:"required"`
+	// IntervalHours between runs, 1-168; 0 means daily.
+	IntervalHours int   `json:"interval_hours"`
+	Enabled       *bool `json:"enabled"` // defaults to true
+}
+
+// AnalyticsExportService manages scheduled Parquet exports of a tenant's
+// documents, line items and validation results.
+type AnalyticsExportService interface {
+	Get(ctx context.Context, tenantID uuid.UUID) (*domain.AnalyticsExport, error)
+	// Configure creates or replaces the tenant's export. Changing the destination
+	// resets the watermark, so the next run writes the full history there.
+	Configure(ctx context.Context, tenantID uuid.UUID, input *ConfigureAnalyticsExportInput) (*domain.AnalyticsExport, error)
+	Delete(ctx context.Context, tenantID uuid.UUID) error
+	// RunNow makes the export due immediately; the worker runs it on its next tick.
+	RunNow(ctx context.Context, tenantID uuid.UUID) (*domain.AnalyticsExport, error)
+	// RunDue claims and runs every due export across tenants.
+	RunDue(ctx context.Context) error
+}
+
+type analyticsExportService struct {
+	repo    port.AnalyticsExportRepository
+	storage port.ObjectStorage
+	cfg     *config.S3Config
+}
+
+// NewAnalyticsExportService creates a new AnalyticsExportService.
+func NewAnalyticsExportService(repo port.AnalyticsExportRepository, storage port.ObjectStorage, cfg *config.S3Config) AnalyticsExportService {
+	return &analyticsExportService{repo: repo, storage: storage, cfg: cfg}
+}
+
+func (s *analyticsExportService) Get(ctx context.Context, tenantID uuid.UUID) (*domain.AnalyticsExport, error) {
+	return s.repo.Get(ctx, tenantID)
+}
+
+func (s *analyticsExportService) Configure(ctx context.Context, tenantID uuid.UUID, input *ConfigureAnalyticsExportInput) (*domain.AnalyticsExport, error) {
+	bucket, prefix, err := s.parseDestination(tenantID, input.Destination)
+	if err != nil {
+		return nil, err
+	}
+	hours := input.IntervalHours
+	if hours == 0 {
+		hours = analyticsExportDefaultHours
+	}
+	if hours < 1 || hours > analyticsExportMaxHours {
+		return nil, domain.ErrInvalidExportInterval
+	}
+
+	export, err := s.repo.Get(ctx, tenantID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		export = &domain.AnalyticsExport{TenantID: tenantID, NextRunAt: time.Now().UTC()}
+	case err != nil:
+		return nil, err
+	case export.S3Bucket != bucket || export.S3Prefix != prefix:
+		export.ExportedThrough = nil
+		export.NextRunAt = time.Now().UTC()
+	}
+
+	export.S3Bucket = bucket
+	export.S3Prefix = prefix
+	export.IntervalHours = hours
+	export.Enabled = input.Enabled == nil || *input.Enabled
+	if err := s.repo.Upsert(ctx, export); err != nil {
+		return nil, err
+	}
+	log.Printf("analyticsExportService.Configure: tenant %s exports to s3://%s/%s every %dh (enabled=%t)",
+		tenantID, bucket, prefix, hours, export.Enabled)
+	return export, nil
+}
+
+func (s *analyticsExportService) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	return s.repo.Delete(ctx, tenantID)
+}
+
+func (s *analyticsExportService) RunNow(ctx context.Context, tenantID uuid.UUID) (*domain.AnalyticsExport, error) {
+	export, err := s.repo.Get(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	export.NextRunAt = time.Now().UTC()
+	if err := s.repo.Upsert(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// parseDestination splits s3://bucket/prefix. A deployment bucket is only
+// accepted under the tenant's own tenants/{id}/ prefix, so an export can never
+// overwrite another tenant's objects.
+func (s *analyticsExportService) parseDestination(tenantID uuid.UUID, destination string) (bucket, prefix string, err error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(destination), "s3://")
+	if !ok {
+		return "", "", domain.ErrInvalidExportDestination
+	}
+	bucket, prefix, _ = strings.Cut(rest, "/")
+	prefix = strings.Trim(prefix, "/")
+	if !s3BucketNameRe.MatchString(bucket) {
+		return "", "", domain.ErrInvalidExportDestination
+	}
+	if prefix != "" {
+		for _, seg := range strings.Split(prefix, "/") {
+			if seg == "" || seg == "." || seg == ".." {
+				return "", "", domain.ErrInvalidExportDestination
+			}
+		}
+	}
+	if _, ours := s.cfg.RegionForBucket(bucket); ours && !strings.HasPrefix(prefix+"/", "tenants/"+tenantID.String()+"/") {
+		return "", "", domain.ErrInvalidExportDestination
+	}
+	return bucket, prefix, nil
+}
+
+func (s *analyticsExportService) RunDue(ctx context.Context) error {
+	for {
+		now := time.Now().UTC()
+		exports, err := s.repo.ClaimDue(ctx, now, analyticsExportLease, analyticsExportClaimLimit)
+		if err != nil {
+			return err
+		}
+		for i := range exports {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.run(ctx, &exports[i], now)
+		}
+		if len(exports) < analyticsExportClaimLimit {
+			return nil
+		}
+	}
+}
+
+// run exports one tenant and records the outcome. The watermark only moves on
+// success; a failed run is retried when its lease expires.
+func (s *analyticsExportService) run(ctx context.Context, export *domain.AnalyticsExport, now time.Time) {
+	through := now.Add(-analyticsExportLag)
+	documents, err := s.export(ctx, export, through, now)
+
+	export.LastRunAt = &now
+	if err != nil {
+		log.Printf("analyticsExportService.run: tenant %s: %v", export.TenantID, err)
+		export.LastError = err.Error()
+		export.NextRunAt = now.Add(analyticsExportLease)
+	} else {
+		log.Printf("analyticsExportService.run: tenant %s: exported %d documents to s3://%s/%s",
+			export.TenantID, documents, export.S3Bucket, export.S3Prefix)
+		export.ExportedThrough = &through
+		export.LastError = ""
+		export.LastRunDocuments = documents
+		export.NextRunAt = now.Add(time.Duration(export.IntervalHours) * time.Hour)
+	}
+	if err := s.repo.CompleteRun(ctx, export); err != nil {
+		log.Printf("analyticsExportService.run: tenant %s: recording run: %v", export.TenantID, err)
+	}
+}
+
+// export writes documents updated after the watermark and up to through as one
+// Parquet file per table, and returns how many documents it wrote.
+func (s *analyticsExportService) export(ctx context.Context, export *domain.AnalyticsExport, through, exportedAt time.Time) (int, error) {
+	files := make(map[string]*os.File, 3)
+	for _, table := range []string{parquetexport.TableDocuments, parquetexport.TableLineItems, parquetexport.TableValidations} {
+		f, err := os.CreateTemp("", "satvos-export-*.parquet")
+		if err != nil {
+			return 0, fmt.Errorf("creating temp file: %w", err)
+		}
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}()
+		files[table] = f
+	}
+	tables := parquetexport.NewTables(files[parquetexport.TableDocuments], files[parquetexport.TableLineItems],
+		files[parquetexport.TableValidations], exportedAt)
+
+	// uuid.Max skips documents stamped exactly at the watermark, which the previous run wrote.
+	afterAt, afterID := time.Time{}, uuid.Nil
+	if export.ExportedThrough != nil {
+		afterAt, afterID = *export.ExportedThrough, uuid.Max
+	}
+	for {
+		docs, err := s.repo.ListDocumentsUpdated(ctx, export.TenantID, afterAt, afterID, through, analyticsExportBatchSize)
+		if err != nil {
+			return 0, err
+		}
+		if len(docs) == 0 {
+			break
+		}
+		if err := tables.WriteDocuments(docs); err != nil {
+			return 0, fmt.Errorf("writing documents: %w", err)
+		}
+		ids := make([]uuid.UUID, len(docs))
+		for i := range docs {
+			ids[i] = docs[i].ID
+		}
+		facts, err := s.repo.ListValidationFacts(ctx, export.TenantID, ids)
+		if err != nil {
+			return 0, err
+		}
+		if err := tables.WriteValidations(facts); err != nil {
+			return 0, fmt.Errorf("writing validations: %w", err)
+		}
+		last := &docs[len(docs)-1]
+		afterAt, afterID = last.UpdatedAt, last.ID
+		if len(docs) < analyticsExportBatchSize {
+			break
+		}
+	}
+	if err := tables.Close(); err != nil {
+		return 0, fmt.Errorf("finishing parquet files: %w", err)
+	}
+	if tables.Documents == 0 {
+		return 0, nil
+	}
+
+	for _, t := range []struct {
+		name string
+		rows int
+	}{
+		{parquetexport.TableDocuments, tables.Documents},
+		{parquetexport.TableLineItems, tables.LineItems},
+		{parquetexport.TableValidations, tables.Validations},
+	} {
+		if t.rows == 0 {
+			continue
+		}
+		if err := s.upload(ctx, export, analyticsExportKey(export.S3Prefix, t.name, through), files[t.name]); err != nil {
+			return 0, fmt.Errorf("uploading %s: %w", t.name, err)
+		}
+	}
+	return tables.Documents, nil
+}
+
+func (s *analyticsExportService) upload(ctx context.Context, export *domain.AnalyticsExport, key string, f *os.File) error {
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = s.storage.Upload(ctx, port.UploadInput{
+		Bucket:      export.S3Bucket,
+		Key:         key,
+		Body:        f,
+		ContentType: analyticsExportContentType,
+		Size:        size,
+	})
+	return err
+}
+
+// analyticsExportKey is {prefix}/{table}/export_date=YYYY-MM-DD/{through}.parquet,
+// a Hive-style partition that Snowflake, BigQuery and Athena load directly.
+func analyticsExportKey(prefix, table string, through time.Time) string {
+	return path.Join(prefix, table, "export_date="+through.Format("2006-01-02"),
+		through.Format(analyticsExportKeyTimeFormat)+".parquet")
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// AnalyticsExportWorker periodically runs the Parquet exports that are due.
+type AnalyticsExportWorker struct {
+	svc      AnalyticsExportService
+	interval time.Duration
+}
+
+// NewAnalyticsExportWorker creates a new AnalyticsExportWorker that checks for
+// due exports every interval.
+func NewAnalyticsExportWorker(svc AnalyticsExportService, interval time.Duration) *AnalyticsExportWorker {
+	return &AnalyticsExportWorker{svc: svc, interval: interval}
+}
+
+// Start runs the export loop until ctx is canceled.
+func (w *AnalyticsExportWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	log.Printf("analyticsExportWorker: started (interval=%s)", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("analyticsExportWorker: shutdown complete")
+			return
+		case <-ticker.C:
+			if err := w.svc.RunDue(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("analyticsExportWorker: RunDue error: %v", err)
+			}
+		}
+	}
+}
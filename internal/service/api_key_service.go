@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// APIKeyPrefix starts every API key, so a key sent as a bearer token is told
+// apart from a JWT.
+const APIKeyPrefix = "satvos_"
+
+const (
+	apiKeySecretBytes = 32
+	// apiKeyDisplayLen is how much of the key is kept to tell keys apart.
+	apiKeyDisplayLen = len(APIKeyPrefix) + 6
+	// apiKeyTouchInterval bounds how often a key's last_used_at is written.
+	apiKeyTouchInterval = time.Minute
+)
+
+// CreateAPIKeyInput is the DTO for creating an API key.
+type CreateAPIKeyInput struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// CreatedAPIKey is a new API key with its secret, which is never shown again.
+type CreatedAPIKey struct {
+	domain.APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyService manages users' API keys and authenticates requests made with them.
+type APIKeyService interface {
+	Create(ctx context.Context, tenantID, userID uuid.UUID, input CreateAPIKeyInput) (*CreatedAPIKey, error)
+	List(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.APIKey, error)
+	Delete(ctx context.Context, tenantID, userID, keyID uuid.UUID) error
+	// Authenticate returns the claims of the key's user, with the user's current
+	// role. Unknown keys are ErrUnauthorized; keys of deactivated users ErrUserInactive.
+	Authenticate(ctx context.Context, key string) (*Claims, error)
+}
+
+type apiKeyService struct {
+	repo     port.APIKeyRepository
+	userRepo port.UserRepository
+}
+
+// NewAPIKeyService creates a new APIKeyService.
+func NewAPIKeyService(repo port.APIKeyRepository, userRepo port.UserRepository) APIKeyService {
+	return &apiKeyService{repo: repo, userRepo: userRepo}
+}
+
+func (s *apiKeyService) Create(ctx context.Context, tenantID, userID uuid.UUID, input CreateAPIKeyInput) (*CreatedAPIKey, error) {
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generating api key: %w", err)
+	}
+	raw := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	key := &domain.APIKey{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		UserID:    userID,
+		Name:      strings.TrimSpace(input.Name),
+		KeyPrefix: raw[:apiKeyDisplayLen],
+		KeyHash:   hashAPIKey(raw),
+	}
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	log.Printf("apiKeyService.Create: user %s created API key %s (%s)", userID, key.ID, key.Name)
+	return &CreatedAPIKey{APIKey: *key, Key: raw}, nil
+}
+
+func (s *apiKeyService) List(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.APIKey, error) {
+	return s.repo.ListByUser(ctx, tenantID, userID)
+}
+
+func (s *apiKeyService) Delete(ctx context.Context, tenantID, userID, keyID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, tenantID, userID, keyID); err != nil {
+		return err
+	}
+	log.Printf("apiKeyService.Delete: user %s revoked API key %s", userID, keyID)
+	return nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, raw string) (*Claims, error) {
+	key, err := s.repo.GetByHash(ctx, hashAPIKey(raw))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+	user, err := s.userRepo.GetByID(ctx, key.TenantID, key.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	if key.LastUsedAt == nil || time.Since(*key.LastUsedAt) > apiKeyTouchInterval {
+		if err := s.repo.TouchLastUsed(ctx, key.ID); err != nil {
+			log.Printf("apiKeyService.Authenticate: recording use of key %s failed: %v", key.ID, err)
+		}
+	}
+	return &Claims{TenantID: key.TenantID, UserID: user.ID, Email: user.Email, Role: user.Role}, nil
+}
+
+// hashAPIKey is the stored form of a key. Keys are random, so an unsalted hash
+// is enough and lets a key be looked up by its hash.
+func hashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyAuthenticator is implemented by an AuthService that also accepts API keys.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*Claims, error)
+}
+
+// apiKeyAuthService adds API key authentication to an AuthService.
+type apiKeyAuthService struct {
+	AuthService
+	keys APIKeyService
+}
+
+// NewAPIKeyAuthService wraps auth so AuthMiddleware also accepts API keys as
+// bearer tokens.
+func NewAPIKeyAuthService(auth AuthService, keys APIKeyService) AuthService {
+	return &apiKeyAuthService{AuthService: auth, keys: keys}
+}
+
+func (s *apiKeyAuthService) AuthenticateAPIKey(ctx context.Context, key string) (*Claims, error) {
+	return s.keys.Authenticate(ctx, key)
+}
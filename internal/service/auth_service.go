@@ -22,6 +22,8 @@ type Claims struct {
 	UserID   uuid.UUID       `json:"user_id"`
 	Email    string          `json:"email"`
 	Role     domain.UserRole `json:"role"`
+	// HomeTenantID is set when TenantID is a tenant the user is a guest of.
+	HomeTenantID *uuid.UUID `json:"home_tenant_id,omitempty"`
 }
 
 // TokenPair holds access and refresh tokens.
@@ -118,6 +120,14 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*T
 		return nil, domain.ErrUserInactive
 	}
 
+	tenant, err := s.tenantRepo.GetByID(ctx, claims.TenantID)
+	if err != nil {
+		return nil, domain.ErrUnauthorized
+	}
+	if !tenant.IsActive {
+		return nil, domain.ErrTenantInactive
+	}
+
 	return s.generateTokenPair(user)
 }
 
@@ -143,10 +153,11 @@ func (s *authService) generateTokenPair(user *domain.User) (*TokenPair, error) {
 			ID:        uuid.New().String(),
 			Audience:  jwt.ClaimStrings{"access"},
 		},
-		TenantID: user.TenantID,
-		UserID:   user.ID,
-		Email:    user.Email,
-		Role:     user.Role,
+		TenantID:     user.TenantID,
+		UserID:       user.ID,
+		Email:        user.Email,
+		Role:         user.Role,
+		HomeTenantID: user.HomeTenantID,
 	}
 
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
@@ -164,10 +175,11 @@ func (s *authService) generateTokenPair(user *domain.User) (*TokenPair, error) {
 			ID:        uuid.New().String(),
 			Audience:  jwt.ClaimStrings{"refresh"},
 		},
-		TenantID: user.TenantID,
-		UserID:   user.ID,
-		Email:    user.Email,
-		Role:     user.Role,
+		TenantID:     user.TenantID,
+		UserID:       user.ID,
+		Email:        user.Email,
+		Role:         user.Role,
+		HomeTenantID: user.HomeTenantID,
 	}
 
 	refreshTokenObj := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
@@ -0,0 +1,457 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	// feedClaimTimeout is how long a processing claim may be held before it is
+	// treated as abandoned by a crashed worker and the object becomes eligible again.
+	feedClaimTimeout = 30 * time.Minute
+	// feedPageSize is the page size used when walking tenants, collections and users.
+	feedPageSize = 100
+)
+
+// BatchFeedService ingests files that enterprise systems drop into a tenant's feed
+// folder in object storage and emails a daily report of the outcome.
+//
+// Layout: tenants/{tenant_id}/drop/{collection}/.../{file}. The first folder below
+// drop/ selects the collection, by ID or by name (case-insensitive). Documents are
+// created as the collection's owner. Processed objects are moved to drop-processed/
+// or drop-failed/ under a dated folder so they are never picked up twice.
+type BatchFeedService interface {
+	// ScanAll processes every pending drop-folder object for tenants with the
+	// batch_feed flag enabled.
+	ScanAll(ctx context.Context) error
+	// SendDueReports emails the daily ingestion report for every enabled tenant
+	// whose report for the current day has not been sent and is due at now.
+	SendDueReports(ctx context.Context, now time.Time) error
+	ListIngestions(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.FeedIngestion, int, error)
+}
+
+type batchFeedService struct {
+	tenantRepo     port.TenantRepository
+	collectionRepo port.CollectionRepository
+	userRepo       port.UserRepository
+	feedRepo       port.FeedIngestionRepository
+	flags          port.Flags
+	ingester       *collectionIngester
+	storage        port.ObjectStorage
+	notifier       port.Notifier
+	s3Cfg          *config.S3Config
+	reportHour     int
+}
+
+// NewBatchFeedService creates a new BatchFeedService implementation.
+func NewBatchFeedService(
+	tenantRepo port.TenantRepository,
+	collectionRepo port.CollectionRepository,
+	userRepo port.UserRepository,
+	feedRepo port.FeedIngestionRepository,
+	flags port.Flags,
+	fileSvc FileService,
+	collectionSvc CollectionService,
+	docSvc DocumentService,
+	storage port.ObjectStorage,
+	notifier port.Notifier,
+	s3Cfg *config.S3Config,
+	reportHour int,
+) BatchFeedService {
+	return &batchFeedService{
+		tenantRepo:     tenantRepo,
+		collectionRepo: collectionRepo,
+		userRepo:       userRepo,
+		feedRepo:       feedRepo,
+		flags:          flags,
+		ingester:       &collectionIngester{fileSvc: fileSvc, collectionSvc: collectionSvc, docSvc: docSvc},
+		storage:        storage,
+		notifier:       notifier,
+		s3Cfg:          s3Cfg,
+		reportHour:     reportHour,
+	}
+}
+
+func tenantDropPrefix(tenantID uuid.UUID) string {
+	return fmt.Sprintf("tenants/%s/drop/", tenantID)
+}
+
+func (s *batchFeedService) ListIngestions(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.FeedIngestion, int, error) {
+	return s.feedRepo.ListByTenant(ctx, tenantID, offset, limit)
+}
+
+// enabledTenants returns the active tenants that have batch feed ingestion enabled.
+func (s *batchFeedService) enabledTenants(ctx context.Context) ([]domain.Tenant, error) {
+	var enabled []domain.Tenant
+	for offset := 0; ; offset += feedPageSize {
+		tenants, total, err := s.tenantRepo.List(ctx, offset, feedPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("listing tenants: %w", err)
+		}
+		for i := range tenants {
+			if tenants[i].IsActive && s.flags.IsEnabled(ctx, tenants[i].ID, domain.FlagBatchFeed) {
+				enabled = append(enabled, tenants[i])
+			}
+		}
+		if offset+feedPageSize >= total || len(tenants) == 0 {
+			return enabled, nil
+		}
+	}
+}
+
+func (s *batchFeedService) ScanAll(ctx context.Context) error {
+	if released, err := s.feedRepo.FailStale(ctx, time.Now().UTC().Add(-feedClaimTimeout)); err != nil {
+		log.Printf("batchFeedService.ScanAll: releasing stale claims failed: %v", err)
+	} else if released > 0 {
+		log.Printf("batchFeedService.ScanAll: released %d stale claims", released)
+	}
+
+	tenants, err := s.enabledTenants(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range tenants {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.scanTenant(ctx, &tenants[i]); err != nil {
+			log.Printf("batchFeedService.ScanAll: tenant %s: %v", tenants[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// feedScan caches per-tenant lookups for the duration of one scan.
+type feedScan struct {
+	tenantID    uuid.UUID
+	bucket      string
+	collections []domain.Collection
+	loaded      bool
+}
+
+func (s *batchFeedService) scanTenant(ctx context.Context, tenant *domain.Tenant) error {
+	tenantID := tenant.ID
+	// The drop folder lives in the tenant's residency bucket
+	region := ""
+	if tenant.StorageRegion != nil {
+		region = *tenant.StorageRegion
+	}
+	bucket, ok := s.s3Cfg.BucketForRegion(region)
+	if !ok {
+		return fmt.Errorf("%w: no bucket configured for region %s", domain.ErrDataResidencyViolation, region)
+	}
+
+	prefix := tenantDropPrefix(tenantID)
+	objects, err := s.storage.List(ctx, bucket, prefix)
+	if err != nil {
+		return fmt.Errorf("listing drop folder: %w", err)
+	}
+
+	scan := &feedScan{tenantID: tenantID, bucket: bucket}
+	processed := 0
+	for _, obj := range objects {
+		if strings.HasSuffix(obj.Key, "/") || strings.HasPrefix(path.Base(obj.Key), ".") {
+			continue
+		}
+		if processed >= maxImportEntries {
+			// Leave the rest for the next scan so one tenant can't starve the others
+			break
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		rel := strings.TrimPrefix(obj.Key, prefix)
+		folder := ""
+		if i := strings.Index(rel, "/"); i > 0 {
+			folder = rel[:i]
+		}
+		rec := &domain.FeedIngestion{
+			TenantID:  tenantID,
+			ObjectKey: obj.Key,
+			Folder:    folder,
+			FileName:  path.Base(rel),
+		}
+		claimed, err := s.feedRepo.Claim(ctx, rec)
+		if err != nil {
+			return fmt.Errorf("claiming %s: %w", obj.Key, err)
+		}
+		if !claimed {
+			continue
+		}
+		processed++
+
+		content := s.ingestObject(ctx, scan, rec, obj)
+		s.archiveObject(ctx, scan.bucket, rec, rel, content)
+		if err := s.feedRepo.Complete(ctx, rec); err != nil {
+			log.Printf("batchFeedService.scanTenant: completing %s failed: %v", rec.ID, err)
+		}
+	}
+	if processed > 0 {
+		log.Printf("batchFeedService.scanTenant: processed %d dropped files for tenant %s", processed, tenantID)
+	}
+	return nil
+}
+
+// ingestObject routes one dropped object to its collection and records the outcome on
+// rec. It returns the object content if it was read, so archiving need not re-read it.
+func (s *batchFeedService) ingestObject(ctx context.Context, scan *feedScan, rec *domain.FeedIngestion, obj port.ObjectInfo) []byte {
+	if rec.Folder == "" {
+		s.setOutcome(rec, domain.FeedIngestionFailed, "file must be placed inside a collection folder")
+		return nil
+	}
+	col, err := s.resolveCollection(ctx, scan, rec.Folder)
+	if err != nil {
+		s.setOutcome(rec, domain.FeedIngestionFailed, fmt.Sprintf("looking up collection: %v", err))
+		return nil
+	}
+	if col == nil {
+		s.setOutcome(rec, domain.FeedIngestionFailed, fmt.Sprintf("no collection matches folder %q", rec.Folder))
+		return nil
+	}
+	rec.CollectionID = &col.ID
+
+	owner, err := s.userRepo.GetByID(ctx, rec.TenantID, col.CreatedBy)
+	if err != nil || !owner.IsActive {
+		s.setOutcome(rec, domain.FeedIngestionFailed, "collection owner is not an active user")
+		return nil
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(rec.FileName), "."))
+	if _, allowed := domain.AllowedExtensions[ext]; !allowed {
+		s.setOutcome(rec, domain.FeedIngestionSkipped, domain.ErrUnsupportedFileType.Error())
+		return nil
+	}
+	if obj.Size > s.s3Cfg.MaxFileSizeMB*1024*1024 {
+		s.setOutcome(rec, domain.FeedIngestionSkipped, domain.ErrFileTooLarge.Error())
+		return nil
+	}
+
+	content, err := s.storage.Download(ctx, scan.bucket, rec.ObjectKey)
+	if err != nil {
+		s.setOutcome(rec, domain.FeedIngestionFailed, fmt.Sprintf("reading file: %v", err))
+		return nil
+	}
+
+	entry := s.ingester.ingest(ctx, &ingestTarget{
+		TenantID:     rec.TenantID,
+		CollectionID: col.ID,
+		UserID:       owner.ID,
+		Role:         owner.Role,
+		DocumentType: "invoice",
+		Tags:         map[string]string{"feed_ingestion_id": rec.ID.String()},
+	}, rec.ObjectKey, rec.FileName, content)
+
+	rec.FileID = entry.FileID
+	rec.DocumentID = entry.DocumentID
+	switch entry.Status {
+	case domain.ImportEntryImported:
+		s.setOutcome(rec, domain.FeedIngestionImported, "")
+	case domain.ImportEntrySkipped:
+		s.setOutcome(rec, domain.FeedIngestionSkipped, entry.Reason)
+	default:
+		s.setOutcome(rec, domain.FeedIngestionFailed, entry.Reason)
+	}
+	return content
+}
+
+func (s *batchFeedService) setOutcome(rec *domain.FeedIngestion, status domain.FeedIngestionStatus, reason string) {
+	rec.Status = status
+	rec.Reason = reason
+}
+
+// resolveCollection finds the collection a drop folder refers to, by ID or by name.
+// Returns nil when nothing matches.
+func (s *batchFeedService) resolveCollection(ctx context.Context, scan *feedScan, folder string) (*domain.Collection, error) {
+	if !scan.loaded {
+		for offset := 0; ; offset += feedPageSize {
+			page, total, err := s.collectionRepo.ListByTenant(ctx, scan.tenantID, offset, feedPageSize)
+			if err != nil {
+				return nil, err
+			}
+			scan.collections = append(scan.collections, page...)
+			if offset+feedPageSize >= total || len(page) == 0 {
+				break
+			}
+		}
+		scan.loaded = true
+	}
+
+	if id, err := uuid.Parse(folder); err == nil {
+		for i := range scan.collections {
+			if scan.collections[i].ID == id {
+				return &scan.collections[i], nil
+			}
+		}
+		return nil, nil
+	}
+	for i := range scan.collections {
+		if strings.EqualFold(scan.collections[i].Name, folder) {
+			return &scan.collections[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// archiveObject moves a processed object out of the drop folder so it isn't
+// picked up again. Failures are logged; the claim record still holds the outcome.
+func (s *batchFeedService) archiveObject(ctx context.Context, bucket string, rec *domain.FeedIngestion, rel string, content []byte) {
+	dest := "drop-processed"
+	if rec.Status != domain.FeedIngestionImported {
+		dest = "drop-failed"
+	}
+	archiveKey := fmt.Sprintf("tenants/%s/%s/%s/%s", rec.TenantID, dest, time.Now().UTC().Format("2006-01-02"), rel)
+
+	if content == nil {
+		var err error
+		if content, err = s.storage.Download(ctx, bucket, rec.ObjectKey); err != nil {
+			log.Printf("batchFeedService.archiveObject: reading %s failed: %v", rec.ObjectKey, err)
+			return
+		}
+	}
+	if _, err := s.storage.Upload(ctx, port.UploadInput{
+		Bucket:      bucket,
+		Key:         archiveKey,
+		Body:        bytes.NewReader(content),
+		ContentType: "application/octet-stream",
+		Size:        int64(len(content)),
+	}); err != nil {
+		log.Printf("batchFeedService.archiveObject: copying %s failed: %v", rec.ObjectKey, err)
+		return
+	}
+	if err := s.storage.Delete(ctx, bucket, rec.ObjectKey); err != nil {
+		log.Printf("batchFeedService.archiveObject: deleting %s failed: %v", rec.ObjectKey, err)
+	}
+}
+
+func (s *batchFeedService) SendDueReports(ctx context.Context, now time.Time) error {
+	tenants, err := s.enabledTenants(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range tenants {
+		// The report hour and the report's day are the tenant's local time
+		local := now.In(tenants[i].Location())
+		if local.Hour() < s.reportHour {
+			continue
+		}
+		reportDate := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+		windowEnd := time.Date(local.Year(), local.Month(), local.Day(), s.reportHour, 0, 0, 0, local.Location())
+		windowStart := windowEnd.AddDate(0, 0, -1)
+
+		claimed, err := s.feedRepo.ClaimReport(ctx, tenants[i].ID, reportDate)
+		if err != nil {
+			log.Printf("batchFeedService.SendDueReports: claiming report for tenant %s: %v", tenants[i].ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.sendReport(ctx, &tenants[i], reportDate, windowStart.UTC(), windowEnd.UTC()); err != nil {
+			log.Printf("batchFeedService.SendDueReports: tenant %s: %v", tenants[i].ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *batchFeedService) sendReport(ctx context.Context, tenant *domain.Tenant, date, from, to time.Time) error {
+	recs, err := s.feedRepo.ListCompletedBetween(ctx, tenant.ID, from, to)
+	if err != nil {
+		return fmt.Errorf("listing ingestions: %w", err)
+	}
+	if len(recs) == 0 {
+		return nil
+	}
+
+	report := &port.IngestionReport{TenantName: tenant.Name, Date: date}
+	prefix := tenantDropPrefix(tenant.ID)
+	for i := range recs {
+		switch recs[i].Status {
+		case domain.FeedIngestionImported:
+			report.Imported++
+		case domain.FeedIngestionSkipped:
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+		report.Entries = append(report.Entries, port.IngestionReportEntry{
+			Path:   strings.TrimPrefix(recs[i].ObjectKey, prefix),
+			Status: string(recs[i].Status),
+			Reason: recs[i].Reason,
+		})
+	}
+
+	admins, err := tenantAdmins(ctx, s.userRepo, tenant.ID)
+	if err != nil {
+		return fmt.Errorf("listing admins: %w", err)
+	}
+	if len(admins) == 0 {
+		return nil
+	}
+	n := &port.Notification{
+		Kind:            domain.NotificationKindIngestionReport,
+		TenantID:        tenant.ID,
+		Subject:         fmt.Sprintf("Batch feed report for %s, %s", tenant.Name, date.Format("2006-01-02")),
+		Body:            ingestionReportSummary(report),
+		IngestionReport: report,
+	}
+	for i := range admins {
+		n.Recipients = append(n.Recipients, port.NotificationRecipient{
+			UserID: admins[i].ID, Email: admins[i].Email, Name: admins[i].FullName,
+		})
+	}
+	if err := s.notifier.Notify(ctx, n); err != nil {
+		log.Printf("batchFeedService.sendReport: notifying admins of tenant %s failed: %v", tenant.ID, err)
+	}
+	return nil
+}
+
+// ingestionReportSummary is the plain-text form of a report for channels
+// without an email template, listing at most 20 failed files.
+func ingestionReportSummary(report *port.IngestionReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d imported, %d skipped, %d failed.", report.Imported, report.Skipped, report.Failed)
+	listed := 0
+	for _, e := range report.Entries {
+		if e.Status != string(domain.FeedIngestionFailed) {
+			continue
+		}
+		if listed == 20 {
+			fmt.Fprintf(&b, "\n...and %d more", report.Failed-listed)
+			break
+		}
+		fmt.Fprintf(&b, "\n- %s: %s", e.Path, e.Reason)
+		listed++
+	}
+	return b.String()
+}
+
+// tenantAdmins returns the tenant's active admins.
+func tenantAdmins(ctx context.Context, userRepo port.UserRepository, tenantID uuid.UUID) ([]domain.User, error) {
+	var admins []domain.User
+	for offset := 0; ; offset += feedPageSize {
+		users, total, err := userRepo.ListByTenant(ctx, tenantID, offset, feedPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range users {
+			if users[i].IsActive && users[i].Role == domain.RoleAdmin {
+				admins = append(admins, users[i])
+			}
+		}
+		if offset+feedPageSize >= total || len(users) == 0 {
+			return admins, nil
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// BatchFeedWorker periodically scans tenant drop folders and sends the daily
+// ingestion reports once they are due.
+type BatchFeedWorker struct {
+	svc      BatchFeedService
+	interval time.Duration
+}
+
+// NewBatchFeedWorker creates a new BatchFeedWorker that scans every interval.
+func NewBatchFeedWorker(svc BatchFeedService, interval time.Duration) *BatchFeedWorker {
+	return &BatchFeedWorker{svc: svc, interval: interval}
+}
+
+// Start runs the scan loop until ctx is canceled.
+func (w *BatchFeedWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	log.Printf("batchFeedWorker: started (interval=%s)", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("batchFeedWorker: shutdown complete")
+			return
+		case <-ticker.C:
+			if err := w.svc.ScanAll(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("batchFeedWorker: ScanAll error: %v", err)
+			}
+			if err := w.svc.SendDueReports(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				log.Printf("batchFeedWorker: SendDueReports error: %v", err)
+			}
+		}
+	}
+}
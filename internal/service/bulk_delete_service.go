@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// maxBulkDeleteDocuments caps how many documents one bulk delete may remove.
+// Deletes run inline, so the cap also bounds the request's duration.
+const maxBulkDeleteDocuments = 1000
+
+// BulkDeleteInput is the DTO for previewing or running a bulk delete.
+// DeleteFiles also removes each document's source file from storage.
+type BulkDeleteInput struct {
+	TenantID          uuid.UUID
+	UserID            uuid.UUID
+	Role              domain.UserRole
+	Filter            domain.BulkTagFilter
+	DeleteFiles       bool
+	ConfirmationToken string
+}
+
+// BulkDeleteService deletes every document matching a filter in the collections
+// the caller owns. A dry run (Preview) lists the matches and returns a
+// confirmation token; Delete only runs with the token of a preview whose matches
+// are unchanged.
+type BulkDeleteService interface {
+	Preview(ctx context.Context, input *BulkDeleteInput) (*domain.BulkDeletePreview, error)
+	Delete(ctx context.Context, input *BulkDeleteInput) (*domain.BulkDelete, error)
+	ListRuns(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.BulkDelete, int, error)
+}
+
+type bulkDeleteService struct {
+	repo          port.BulkDeleteRepository
+	docRepo       port.DocumentRepository
+	fileSvc       FileService
+	auditRepo     port.DocumentAuditRepository
+	collectionSvc CollectionService
+}
+
+// NewBulkDeleteService creates a new BulkDeleteService implementation.
+func NewBulkDeleteService(
+	repo port.BulkDeleteRepository,
+	docRepo port.DocumentRepository,
+	fileSvc FileService,
+	auditRepo port.DocumentAuditRepository,
+	collectionSvc CollectionService,
+) BulkDeleteService {
+	return &bulkDeleteService{repo: repo, docRepo: docRepo, fileSvc: fileSvc, auditRepo: auditRepo, collectionSvc: collectionSvc}
+}
+
+func validateBulkDeleteFilter(filter *domain.BulkTagFilter) error {
+	if filter.IsEmpty() {
+		return fmt.Errorf("%w: filter needs at least one criterion", domain.ErrInvalidBulkDelete)
+	}
+	for _, d := range []string{filter.From, filter.To} {
+		if _, err := time.Parse("2006-01-02", d); d != "" && err != nil {
+			return fmt.Errorf("%w: from and to must be YYYY-MM-DD", domain.ErrInvalidBulkDelete)
+		}
+	}
+	return nil
+}
+
+func (s *bulkDeleteService) Preview(ctx context.Context, input *BulkDeleteInput) (*domain.BulkDeletePreview, error) {
+	targets, skipped, err := s.match(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.BulkDeletePreview{
+		Documents:         targets,
+		MatchedCount:      len(targets),
+		SkippedCount:      skipped,
+		DeleteFiles:       input.DeleteFiles,
+		ConfirmationToken: confirmationToken(input, targets),
+	}, nil
+}
+
+func (s *bulkDeleteService) Delete(ctx context.Context, input *BulkDeleteInput) (*domain.BulkDelete, error) {
+	if input.ConfirmationToken == "" {
+		return nil, fmt.Errorf("%w: confirmation_token is required; run a dry run first", domain.ErrInvalidBulkDelete)
+	}
+	targets, _, err := s.match(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if confirmationToken(input, targets) != input.ConfirmationToken {
+		return nil, domain.ErrBulkDeleteUnconfirmed
+	}
+
+	filter, err := json.Marshal(input.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling bulk delete filter: %w", err)
+	}
+	rec := &domain.BulkDelete{
+		ID:           uuid.New(),
+		TenantID:     input.TenantID,
+		CreatedBy:    input.UserID,
+		Filter:       filter,
+		DeleteFiles:  input.DeleteFiles,
+		MatchedCount: len(targets),
+	}
+	if err := s.repo.Create(ctx, rec); err != nil {
+		return nil, err
+	}
+	log.Printf("bulkDeleteService.Delete: run %s deleting %d documents (files: %t) with filter %s (by user %s)",
+		rec.ID, rec.MatchedCount, rec.DeleteFiles, filter, rec.CreatedBy)
+
+	changes, _ := json.Marshal(map[string]interface{}{
+		"bulk_delete_id": rec.ID.String(), "filter": rec.Filter, "file_deleted": rec.DeleteFiles,
+	})
+	for i := range targets {
+		t := &targets[i]
+		if err := s.deleteOne(ctx, input, t); err != nil {
+			log.Printf("bulkDeleteService.Delete: run %s document %s: %v", rec.ID, t.DocumentID, err)
+			rec.FailedCount++
+			continue
+		}
+		rec.DeletedCount++
+		entry := &domain.DocumentAuditEntry{
+			ID:         uuid.New(),
+			TenantID:   input.TenantID,
+			DocumentID: t.DocumentID,
+			UserID:     &input.UserID,
+			Action:     string(domain.AuditDocumentDeleted),
+			Changes:    changes,
+		}
+		if err := s.auditRepo.Create(ctx, entry); err != nil {
+			log.Printf("bulkDeleteService.Delete: failed to write audit entry for %s: %v", t.DocumentID, err)
+		}
+	}
+
+	now := time.Now().UTC()
+	rec.CompletedAt = &now
+	if err := s.repo.Finish(ctx, rec); err != nil {
+		log.Printf("bulkDeleteService.Delete: failed to finish run %s: %v", rec.ID, err)
+	}
+	log.Printf("bulkDeleteService.Delete: run %s completed (%d deleted, %d failed)", rec.ID, rec.DeletedCount, rec.FailedCount)
+	return rec, nil
+}
+
+// deleteOne removes one document. Deleting its file cascades to the document.
+func (s *bulkDeleteService) deleteOne(ctx context.Context, input *BulkDeleteInput, t *domain.BulkDeleteTarget) error {
+	if input.DeleteFiles {
+		return s.fileSvc.Delete(ctx, input.TenantID, t.FileID)
+	}
+	return s.docRepo.Delete(ctx, input.TenantID, t.DocumentID)
+}
+
+// ListRuns lists the caller's bulk deletes, or every one in the tenant for admins.
+func (s *bulkDeleteService) ListRuns(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.BulkDelete, int, error) {
+	var createdBy *uuid.UUID
+	if role != domain.RoleAdmin {
+		createdBy = &userID
+	}
+	return s.repo.ListByTenant(ctx, tenantID, createdBy, offset, limit)
+}
+
+// match returns the filter's documents in collections the caller owns, and how
+// many it matched elsewhere. Naming a collection the caller doesn't own is an error.
+func (s *bulkDeleteService) match(ctx context.Context, input *BulkDeleteInput) ([]domain.BulkDeleteTarget, int, error) {
+	if err := validateBulkDeleteFilter(&input.Filter); err != nil {
+		return nil, 0, err
+	}
+	if input.Filter.CollectionID != nil {
+		eff := s.collectionSvc.EffectivePermission(ctx, *input.Filter.CollectionID, input.UserID, input.Role)
+		if eff != domain.CollectionPermOwner {
+			return nil, 0, domain.ErrCollectionPermDenied
+		}
+	}
+
+	matched, err := s.repo.MatchDocuments(ctx, input.TenantID, &input.Filter, maxBulkDeleteDocuments+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(matched) > maxBulkDeleteDocuments {
+		return nil, 0, fmt.Errorf("%w: filter matches more than %d documents; narrow it", domain.ErrInvalidBulkDelete, maxBulkDeleteDocuments)
+	}
+
+	var collectionIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for i := range matched {
+		if id := matched[i].CollectionID; !seen[id] {
+			seen[id] = true
+			collectionIDs = append(collectionIDs, id)
+		}
+	}
+	perms, err := s.collectionSvc.EffectivePermissions(ctx, collectionIDs, input.UserID, input.Role)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	targets := make([]domain.BulkDeleteTarget, 0, len(matched))
+	for i := range matched {
+		if perms[matched[i].CollectionID] == domain.CollectionPermOwner {
+			targets = append(targets, matched[i])
+		}
+	}
+	return targets, len(matched) - len(targets), nil
+}
+
+// confirmationToken fingerprints what a run would delete, and for whom. It
+// guards against deleting a set the caller never previewed; it is not a secret.
+func confirmationToken(input *BulkDeleteInput, targets []domain.BulkDeleteTarget) string {
+	h := sha256.New()
+	h.Write([]byte(input.TenantID.String()))
+	h.Write([]byte(input.UserID.String()))
+	h.Write([]byte(strconv.FormatBool(input.DeleteFiles)))
+	for i := range targets {
+		h.Write(targets[i].DocumentID[:])
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	// maxBulkTagDocuments caps how many documents one bulk tag job may touch.
+	maxBulkTagDocuments = 10000
+	// bulkTagTimeout bounds the background processing of one bulk tag job.
+	bulkTagTimeout = 30 * time.Minute
+	// bulkTagProgressEvery is how many documents are processed between progress saves.
+	bulkTagProgressEvery = 50
+)
+
+// BulkTagInput is the DTO for starting a bulk tag job.
+type BulkTagInput struct {
+	TenantID uuid.UUID
+	UserID   uuid.UUID
+	Role     domain.UserRole
+	Action   domain.BulkTagAction
+	Key      string
+	Value    string
+	Filter   domain.BulkTagFilter
+}
+
+// BulkTagService adds or removes a tag across every document matching a filter.
+// Jobs run asynchronously: Start returns a pending job whose counters fill in as it runs.
+type BulkTagService interface {
+	Start(ctx context.Context, input *BulkTagInput) (*domain.BulkTagJob, error)
+	GetJob(ctx context.Context, tenantID, jobID, userID uuid.UUID, role domain.UserRole) (*domain.BulkTagJob, error)
+	ListJobs(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.BulkTagJob, int, error)
+}
+
+type bulkTagService struct {
+	jobRepo       port.BulkTagJobRepository
+	tagRepo       port.DocumentTagRepository
+	auditRepo     port.DocumentAuditRepository
+	collectionSvc CollectionService
+}
+
+// NewBulkTagService creates a new BulkTagService implementation.
+func NewBulkTagService(
+	jobRepo port.BulkTagJobRepository,
+	tagRepo port.DocumentTagRepository,
+	auditRepo port.DocumentAuditRepository,
+	collectionSvc CollectionService,
+) BulkTagService {
+	return &bulkTagService{jobRepo: jobRepo, tagRepo: tagRepo, auditRepo: auditRepo, collectionSvc: collectionSvc}
+}
+
+func validateBulkTag(input *BulkTagInput) error {
+	input.Key = strings.TrimSpace(input.Key)
+	input.Value = strings.TrimSpace(input.Value)
+	switch {
+	case input.Action != domain.BulkTagAdd && input.Action != domain.BulkTagRemove:
+		return fmt.Errorf("%w: action must be 'add' or 'remove'", domain.ErrInvalidBulkTag)
+	case input.Key == "" || len(input.Key) > 100:
+		return fmt.Errorf("%w: key must be 1-100 characters", domain.ErrInvalidBulkTag)
+	case len(input.Value) > 500:
+		return fmt.Errorf("%w: value must be at most 500 characters", domain.ErrInvalidBulkTag)
+	case input.Filter.IsEmpty():
+		return fmt.Errorf("%w: filter needs at least one criterion", domain.ErrInvalidBulkTag)
+	}
+	for _, d := range []string{input.Filter.From, input.Filter.To} {
+		if _, err := time.Parse("2006-01-02", d); d != "" && err != nil {
+			return fmt.Errorf("%w: from and to must be YYYY-MM-DD", domain.ErrInvalidBulkTag)
+		}
+	}
+	return nil
+}
+
+func (s *bulkTagService) Start(ctx context.Context, input *BulkTagInput) (*domain.BulkTagJob, error) {
+	if err := validateBulkTag(input); err != nil {
+		return nil, err
+	}
+	if input.Filter.CollectionID != nil {
+		if err := s.requireEditor(ctx, *input.Filter.CollectionID, input.UserID, input.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	filter, err := json.Marshal(input.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling bulk tag filter: %w", err)
+	}
+	job := &domain.BulkTagJob{
+		ID:        uuid.New(),
+		TenantID:  input.TenantID,
+		CreatedBy: input.UserID,
+		Action:    input.Action,
+		TagKey:    input.Key,
+		TagValue:  input.Value,
+		Filter:    filter,
+		Status:    domain.BulkTagStatusPending,
+	}
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	log.Printf("bulkTagService.Start: job %s will %s tag %s=%s (by user %s)",
+		job.ID, job.Action, job.TagKey, job.TagValue, job.CreatedBy)
+	go s.runInBackground(job, input.Role, input.Filter)
+	return job, nil
+}
+
+// GetJob returns a job to its creator or to an admin.
+func (s *bulkTagService) GetJob(ctx context.Context, tenantID, jobID, userID uuid.UUID, role domain.UserRole) (*domain.BulkTagJob, error) {
+	job, err := s.jobRepo.GetByID(ctx, tenantID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if role != domain.RoleAdmin && job.CreatedBy != userID {
+		return nil, domain.ErrNotFound
+	}
+	return job, nil
+}
+
+// ListJobs lists the caller's jobs, or every job in the tenant for admins.
+func (s *bulkTagService) ListJobs(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.BulkTagJob, int, error) {
+	var createdBy *uuid.UUID
+	if role != domain.RoleAdmin {
+		createdBy = &userID
+	}
+	return s.jobRepo.ListByTenant(ctx, tenantID, createdBy, offset, limit)
+}
+
+func (s *bulkTagService) requireEditor(ctx context.Context, collectionID, userID uuid.UUID, role domain.UserRole) error {
+	eff := s.collectionSvc.EffectivePermission(ctx, collectionID, userID, role)
+	if domain.CollectionPermLevel(eff) < domain.CollectionPermLevel(domain.CollectionPermEditor) {
+		return domain.ErrCollectionPermDenied
+	}
+	return nil
+}
+
+func (s *bulkTagService) runInBackground(job *domain.BulkTagJob, role domain.UserRole, filter domain.BulkTagFilter) {
+	ctx, cancel := context.WithTimeout(context.Background(), bulkTagTimeout)
+	defer cancel()
+	s.run(ctx, job, role, &filter)
+}
+
+func (s *bulkTagService) run(ctx context.Context, job *domain.BulkTagJob, role domain.UserRole, filter *domain.BulkTagFilter) {
+	job.Status = domain.BulkTagStatusProcessing
+	s.saveProgress(ctx, job)
+
+	targets, err := s.jobRepo.MatchDocuments(ctx, job.TenantID, filter, maxBulkTagDocuments+1)
+	if err == nil && len(targets) > maxBulkTagDocuments {
+		err = fmt.Errorf("filter matches more than %d documents; narrow it and try again", maxBulkTagDocuments)
+	}
+	if err != nil {
+		log.Printf("bulkTagService.run: job %s failed: %v", job.ID, err)
+		msg := err.Error()
+		job.Error = &msg
+		s.finish(ctx, job, domain.BulkTagStatusFailed)
+		return
+	}
+
+	job.MatchedCount = len(targets)
+	canEdit := make(map[uuid.UUID]bool)
+	for i, target := range targets {
+		if ctx.Err() != nil {
+			msg := "bulk tag job timed out"
+			job.Error = &msg
+			s.finish(ctx, job, domain.BulkTagStatusFailed)
+			return
+		}
+
+		allowed, seen := canEdit[target.CollectionID]
+		if !seen {
+			allowed = s.requireEditor(ctx, target.CollectionID, job.CreatedBy, role) == nil
+			canEdit[target.CollectionID] = allowed
+		}
+		if !allowed {
+			job.SkippedCount++
+		} else {
+			changed, err := s.applyOne(ctx, job, target.DocumentID)
+			switch {
+			case err != nil:
+				log.Printf("bulkTagService.run: job %s document %s: %v", job.ID, target.DocumentID, err)
+				job.FailedCount++
+			case changed:
+				job.UpdatedCount++
+			default:
+				job.SkippedCount++
+			}
+		}
+
+		if (i+1)%bulkTagProgressEvery == 0 {
+			s.saveProgress(ctx, job)
+		}
+	}
+
+	s.finish(ctx, job, domain.BulkTagStatusCompleted)
+	log.Printf("bulkTagService.run: job %s completed (%d matched, %d updated, %d skipped, %d failed)",
+		job.ID, job.MatchedCount, job.UpdatedCount, job.SkippedCount, job.FailedCount)
+}
+
+// applyOne adds or removes the job's tag on one document and audits the change.
+// changed is false when the document already was in the desired state.
+func (s *bulkTagService) applyOne(ctx context.Context, job *domain.BulkTagJob, docID uuid.UUID) (changed bool, err error) {
+	var action domain.AuditAction
+	var changes json.RawMessage
+	if job.Action == domain.BulkTagAdd {
+		changed, err = s.tagRepo.AddIfMissing(ctx, &domain.DocumentTag{
+			ID:         uuid.New(),
+			DocumentID: docID,
+			TenantID:   job.TenantID,
+			Key:        job.TagKey,
+			Value:      job.TagValue,
+			Source:     "user",
+		})
+		action = domain.AuditDocumentTagsAdded
+		changes, _ = json.Marshal(map[string]interface{}{
+			job.TagKey: job.TagValue, "bulk_tag_job_id": job.ID.String(),
+		})
+	} else {
+		var removed int
+		removed, err = s.tagRepo.DeleteByKeyValue(ctx, docID, job.TagKey, job.TagValue)
+		changed = removed > 0
+		action = domain.AuditDocumentTagDeleted
+		changes, _ = json.Marshal(map[string]interface{}{
+			"key": job.TagKey, "value": job.TagValue, "bulk_tag_job_id": job.ID.String(),
+		})
+	}
+	if err != nil || !changed {
+		return changed, err
+	}
+
+	entry := &domain.DocumentAuditEntry{
+		ID:         uuid.New(),
+		TenantID:   job.TenantID,
+		DocumentID: docID,
+		UserID:     &job.CreatedBy,
+		Action:     string(action),
+		Changes:    changes,
+	}
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("bulkTagService.applyOne: failed to write audit entry for %s: %v", docID, err)
+	}
+	return true, nil
+}
+
+// saveProgress persists the job's current state. Errors are logged, not returned:
+// a lost progress update must not abort the job.
+func (s *bulkTagService) saveProgress(ctx context.Context, job *domain.BulkTagJob) {
+	if err := s.jobRepo.UpdateProgress(ctx, job); err != nil {
+		log.Printf("bulkTagService.saveProgress: failed to update job %s: %v", job.ID, err)
+	}
+}
+
+func (s *bulkTagService) finish(ctx context.Context, job *domain.BulkTagJob, status domain.BulkTagStatus) {
+	now := time.Now().UTC()
+	job.Status = status
+	job.CompletedAt = &now
+	s.saveProgress(ctx, job)
+}
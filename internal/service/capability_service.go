@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// CapabilityService tells a user what they may do, derived from the route
+// authorization matrix, their collection permissions, the collections'
+// download restrictions and the tenant's feature flags.
+type CapabilityService interface {
+	Get(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole) (*domain.Capabilities, error)
+}
+
+type capabilityService struct {
+	rules          []domain.RouteRule
+	userRepo       port.UserRepository
+	permRepo       port.CollectionPermissionRepository
+	collectionRepo port.CollectionRepository
+	flags          port.Flags
+}
+
+// NewCapabilityService creates a new CapabilityService over the router's
+// authorization matrix.
+func NewCapabilityService(
+	rules []domain.RouteRule,
+	userRepo port.UserRepository,
+	permRepo port.CollectionPermissionRepository,
+	collectionRepo port.CollectionRepository,
+	flags port.Flags,
+) CapabilityService {
+	return &capabilityService{rules: rules, userRepo: userRepo, permRepo: permRepo, collectionRepo: collectionRepo, flags: flags}
+}
+
+func (s *capabilityService) Get(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole) (*domain.Capabilities, error) {
+	user, err := s.userRepo.GetByID(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	caps := &domain.Capabilities{
+		Role:               role,
+		EmailVerified:      user.EmailVerified,
+		Operations:         []domain.Operation{},
+		CollectionPerm:     domain.ImplicitCollectionPerm(role),
+		Collections:        map[uuid.UUID]domain.CollectionPermission{},
+		FileDownloadDenied: []uuid.UUID{},
+		Features:           make(map[domain.FeatureFlag]bool, len(domain.FeatureFlagDefaults)),
+	}
+	// Only free users are held back until they verify their email
+	unverified := role == domain.RoleFree && !user.EmailVerified
+	for i := range s.rules {
+		r := &s.rules[i]
+		if !hasRole(r.Roles, role) || (r.VerifiedEmail && unverified) {
+			continue
+		}
+		caps.Operations = append(caps.Operations, domain.Operation{Method: r.Method, Path: r.Path, CollectionPerm: r.CollectionPerm})
+	}
+
+	// Admins own every collection, so their grants change nothing and no download is kept from them
+	if role != domain.RoleAdmin {
+		grants, err := s.permRepo.ListByUser(ctx, tenantID, userID)
+		if err != nil {
+			return nil, err
+		}
+		for id, perm := range grants {
+			if domain.CollectionPermLevel(perm) > domain.CollectionPermLevel(caps.CollectionPerm) {
+				caps.Collections[id] = perm
+			}
+		}
+
+		restricted, err := s.collectionRepo.ListFileDownloadPerms(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		for id, required := range restricted {
+			perm := caps.CollectionPerm
+			if granted, ok := caps.Collections[id]; ok {
+				perm = granted
+			}
+			if domain.CollectionPermLevel(perm) < domain.CollectionPermLevel(required) {
+				caps.FileDownloadDenied = append(caps.FileDownloadDenied, id)
+			}
+		}
+	}
+
+	for flag := range domain.FeatureFlagDefaults {
+		caps.Features[flag] = s.flags.IsEnabled(ctx, tenantID, flag)
+	}
+	return caps, nil
+}
+
+func hasRole(roles []domain.UserRole, role domain.UserRole) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// maxDashboardClients caps how many clients the consolidated dashboard reads.
+const maxDashboardClients = 500
+
+// CreateClientInput is the DTO for creating a client tenant under a firm.
+type CreateClientInput struct {
+	Name string `json:"name" binding:"required"`
+	Slug string `json:"slug" binding:"required"`
+}
+
+// UpdateClientInput is the DTO for renaming or suspending a client tenant.
+type UpdateClientInput struct {
+	Name     *string `json:"name"`
+	IsActive *bool   `json:"is_active"`
+}
+
+// AssignStaffInput gives a user of the firm a role in one of its clients.
+type AssignStaffInput struct {
+	UserID uuid.UUID       `json:"user_id" binding:"required"`
+	Role   domain.UserRole `json:"role" binding:"required"`
+	// IsActive defaults to true; false suspends the user's access to the client.
+	IsActive *bool `json:"is_active"`
+}
+
+// MoveStaffInput moves a firm user from one client to another.
+type MoveStaffInput struct {
+	ToClientID uuid.UUID `json:"to_client_id" binding:"required"`
+	// Role in the new client; defaults to the user's role in the old one.
+	Role domain.UserRole `json:"role"`
+}
+
+// ClientTenantService lets a firm tenant create and administer client tenants.
+// Clients are ordinary tenants with their own data; firm staff work in them
+// through tenant memberships, so one client never sees another's data.
+type ClientTenantService interface {
+	Create(ctx context.Context, firmID uuid.UUID, input *CreateClientInput, createdBy uuid.UUID) (*domain.Tenant, error)
+	List(ctx context.Context, firmID uuid.UUID, offset, limit int) ([]domain.Tenant, int, error)
+	// Get returns the client, or ErrNotFound if it isn't a client of the firm.
+	Get(ctx context.Context, firmID, clientID uuid.UUID) (*domain.Tenant, error)
+	Update(ctx context.Context, firmID, clientID uuid.UUID, input *UpdateClientInput) (*domain.Tenant, error)
+	// Dashboard returns each client's document stats and their totals.
+	Dashboard(ctx context.Context, firmID uuid.UUID) (*domain.ClientDashboard, error)
+	ListStaff(ctx context.Context, firmID, clientID uuid.UUID, offset, limit int) ([]domain.TenantMembership, int, error)
+	AssignStaff(ctx context.Context, firmID, clientID uuid.UUID, input *AssignStaffInput, assignedBy uuid.UUID) (*domain.TenantMembership, error)
+	RemoveStaff(ctx context.Context, firmID, clientID, userID uuid.UUID) error
+	// MoveStaff grants the user access to another client and removes it from this one.
+	MoveStaff(ctx context.Context, firmID, clientID, userID uuid.UUID, input *MoveStaffInput, movedBy uuid.UUID) (*domain.TenantMembership, error)
+}
+
+type clientTenantService struct {
+	tenantRepo     port.TenantRepository
+	membershipRepo port.TenantMembershipRepository
+	userRepo       port.UserRepository
+	statsRepo      port.StatsRepository
+}
+
+// NewClientTenantService creates a new ClientTenantService.
+func NewClientTenantService(
+	tenantRepo port.TenantRepository,
+	membershipRepo port.TenantMembershipRepository,
+	userRepo port.UserRepository,
+	statsRepo port.StatsRepository,
+) ClientTenantService {
+	return &clientTenantService{tenantRepo: tenantRepo, membershipRepo: membershipRepo, userRepo: userRepo, statsRepo: statsRepo}
+}
+
+func (s *clientTenantService) Create(ctx context.Context, firmID uuid.UUID, input *CreateClientInput, createdBy uuid.UUID) (*domain.Tenant, error) {
+	firm, err := s.tenantRepo.GetByID(ctx, firmID)
+	if err != nil {
+		return nil, err
+	}
+	if firm.ParentTenantID != nil {
+		return nil, domain.ErrInvalidClientTenant
+	}
+
+	// Clients inherit the firm's data residency.
+	client := &domain.Tenant{
+		Name:           strings.TrimSpace(input.Name),
+		Slug:           strings.TrimSpace(input.Slug),
+		IsActive:       true,
+		StorageRegion:  firm.StorageRegion,
+		ParentTenantID: &firm.ID,
+	}
+	if err := s.tenantRepo.Create(ctx, client); err != nil {
+		return nil, err
+	}
+
+	// The creator administers the new client; other staff are assigned explicitly.
+	if err := s.membershipRepo.Upsert(ctx, &domain.TenantMembership{
+		TenantID:  client.ID,
+		UserID:    createdBy,
+		Role:      domain.RoleAdmin,
+		IsActive:  true,
+		CreatedBy: &createdBy,
+	}); err != nil {
+		return nil, fmt.Errorf("granting creator access to client %s: %w", client.ID, err)
+	}
+
+	log.Printf("clientTenantService.Create: firm %s created client %s (%s) by user %s", firmID, client.ID, client.Slug, createdBy)
+	return client, nil
+}
+
+func (s *clientTenantService) List(ctx context.Context, firmID uuid.UUID, offset, limit int) ([]domain.Tenant, int, error) {
+	return s.tenantRepo.ListClients(ctx, firmID, offset, limit)
+}
+
+func (s *clientTenantService) Get(ctx context.Context, firmID, clientID uuid.UUID) (*domain.Tenant, error) {
+	client, err := s.tenantRepo.GetByID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client.ParentTenantID == nil || *client.ParentTenantID != firmID {
+		return nil, domain.ErrNotFound
+	}
+	return client, nil
+}
+
+func (s *clientTenantService) Update(ctx context.Context, firmID, clientID uuid.UUID, input *UpdateClientInput) (*domain.Tenant, error) {
+	client, err := s.Get(ctx, firmID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name must not be empty", domain.ErrInvalidClientTenant)
+		}
+		client.Name = name
+	}
+	if input.IsActive != nil {
+		client.IsActive = *input.IsActive
+	}
+	if err := s.tenantRepo.Update(ctx, client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (s *clientTenantService) Dashboard(ctx context.Context, firmID uuid.UUID) (*domain.ClientDashboard, error) {
+	dashboard := &domain.ClientDashboard{Clients: []domain.ClientStats{}}
+	for offset := 0; offset < maxDashboardClients; offset += 100 {
+		clients, total, err := s.tenantRepo.ListClients(ctx, firmID, offset, 100)
+		if err != nil {
+			return nil, err
+		}
+		for i := range clients {
+			stats, err := s.statsRepo.GetTenantStats(ctx, clients[i].ID)
+			if err != nil {
+				return nil, err
+			}
+			dashboard.Clients = append(dashboard.Clients, domain.ClientStats{
+				TenantID: clients[i].ID,
+				Name:     clients[i].Name,
+				Slug:     clients[i].Slug,
+				IsActive: clients[i].IsActive,
+				Stats:    *stats,
+			})
+			addStats(&dashboard.Totals, stats)
+		}
+		if offset+len(clients) >= total || len(clients) == 0 {
+			break
+		}
+	}
+	return dashboard, nil
+}
+
+func (s *clientTenantService) ListStaff(ctx context.Context, firmID, clientID uuid.UUID, offset, limit int) ([]domain.TenantMembership, int, error) {
+	if _, err := s.Get(ctx, firmID, clientID); err != nil {
+		return nil, 0, err
+	}
+	return s.membershipRepo.ListByTenant(ctx, clientID, offset, limit)
+}
+
+func (s *clientTenantService) AssignStaff(ctx context.Context, firmID, clientID uuid.UUID, input *AssignStaffInput, assignedBy uuid.UUID) (*domain.TenantMembership, error) {
+	if input.Role == domain.RoleFree || !domain.ValidUserRoles[input.Role] {
+		return nil, fmt.Errorf("%w: role must be admin, manager, member or viewer", domain.ErrInvalidMembership)
+	}
+	if _, err := s.Get(ctx, firmID, clientID); err != nil {
+		return nil, err
+	}
+
+	// Staff are the firm's own users; guests of the firm can't be passed on.
+	user, err := s.userRepo.GetByID(ctx, firmID, input.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: staff must be users of the firm", domain.ErrInvalidMembership)
+		}
+		return nil, err
+	}
+	if user.HomeTenantID != nil {
+		return nil, fmt.Errorf("%w: staff must be users of the firm", domain.ErrInvalidMembership)
+	}
+
+	membership := &domain.TenantMembership{
+		TenantID:  clientID,
+		UserID:    user.ID,
+		Role:      input.Role,
+		IsActive:  input.IsActive == nil || *input.IsActive,
+		CreatedBy: &assignedBy,
+	}
+	if err := s.membershipRepo.Upsert(ctx, membership); err != nil {
+		return nil, err
+	}
+	membership.Email = user.Email
+	membership.FullName = user.FullName
+
+	log.Printf("clientTenantService.AssignStaff: user %s assigned %s in client %s of firm %s (active=%t, by user %s)",
+		user.ID, input.Role, clientID, firmID, membership.IsActive, assignedBy)
+	return membership, nil
+}
+
+func (s *clientTenantService) RemoveStaff(ctx context.Context, firmID, clientID, userID uuid.UUID) error {
+	if _, err := s.Get(ctx, firmID, clientID); err != nil {
+		return err
+	}
+	if err := s.membershipRepo.Delete(ctx, clientID, userID); err != nil {
+		return err
+	}
+	log.Printf("clientTenantService.RemoveStaff: user %s removed from client %s of firm %s", userID, clientID, firmID)
+	return nil
+}
+
+func (s *clientTenantService) MoveStaff(ctx context.Context, firmID, clientID, userID uuid.UUID, input *MoveStaffInput, movedBy uuid.UUID) (*domain.TenantMembership, error) {
+	if input.ToClientID == clientID {
+		return nil, fmt.Errorf("%w: the user is already in this client", domain.ErrInvalidMembership)
+	}
+	if _, err := s.Get(ctx, firmID, clientID); err != nil {
+		return nil, err
+	}
+	current, err := s.membershipRepo.Get(ctx, clientID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	role := input.Role
+	if role == "" {
+		role = current.Role
+	}
+	membership, err := s.AssignStaff(ctx, firmID, input.ToClientID, &AssignStaffInput{UserID: userID, Role: role}, movedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.membershipRepo.Delete(ctx, clientID, userID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("clientTenantService.MoveStaff: user %s moved from client %s to %s (by user %s)", userID, clientID, input.ToClientID, movedBy)
+	return membership, nil
+}
+
+func addStats(total, s *domain.Stats) {
+	total.TotalDocuments += s.TotalDocuments
+	total.TotalCollections += s.TotalCollections
+	total.ParsingCompleted += s.ParsingCompleted
+	total.ParsingFailed += s.ParsingFailed
+	total.ParsingProcessing += s.ParsingProcessing
+	total.ParsingPending += s.ParsingPending
+	total.ParsingQueued += s.ParsingQueued
+	total.ValidationValid += s.ValidationValid
+	total.ValidationWarning += s.ValidationWarning
+	total.ValidationInvalid += s.ValidationInvalid
+	total.ReconciliationValid += s.ReconciliationValid
+	total.ReconciliationWarning += s.ReconciliationWarning
+	total.ReconciliationInvalid += s.ReconciliationInvalid
+	total.ReviewPending += s.ReviewPending
+	total.ReviewApproved += s.ReviewApproved
+	total.ReviewRejected += s.ReviewRejected
+	total.ReviewAwaitingChecker += s.ReviewAwaitingChecker
+}
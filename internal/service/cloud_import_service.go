@@ -0,0 +1,574 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"satvos/internal/cloud"
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	// cloudOAuthAudience scopes OAuth state tokens so they can't be replayed as access tokens.
+	cloudOAuthAudience = "cloud-oauth"
+	// cloudOAuthStateTTL bounds how long a user may take on the provider's consent page.
+	cloudOAuthStateTTL = 10 * time.Minute
+	// cloudTokenRefreshSkew refreshes access tokens this long before they expire.
+	cloudTokenRefreshSkew = time.Minute
+	// cloudSyncBatchSize caps the number of due syncs picked up per poll.
+	cloudSyncBatchSize = 20
+)
+
+// CreateCloudSyncInput is the DTO for linking a cloud folder to a collection.
+type CreateCloudSyncInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	ConnectionID uuid.UUID
+	FolderID     string
+	FolderName   string
+	DocumentType string
+	ParseMode    domain.ParseMode
+	PollEnabled  bool
+}
+
+// CloudImportService defines the Google Drive / Dropbox import contract. Folder
+// syncs run asynchronously; each file's outcome is recorded as a CloudSyncFile.
+type CloudImportService interface {
+	AuthorizeURL(ctx context.Context, tenantID, userID uuid.UUID, provider domain.CloudProvider) (string, error)
+	Connect(ctx context.Context, tenantID, userID uuid.UUID, provider domain.CloudProvider, code, state string) (*domain.CloudConnection, error)
+	ListConnections(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.CloudConnection, error)
+	DeleteConnection(ctx context.Context, tenantID, userID, connectionID uuid.UUID) error
+	ListFolder(ctx context.Context, tenantID, userID, connectionID uuid.UUID, folderID string) ([]port.CloudFile, error)
+
+	CreateSync(ctx context.Context, input *CreateCloudSyncInput) (*domain.CloudSync, error)
+	ListSyncs(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) ([]domain.CloudSync, error)
+	GetSync(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole) (*domain.CloudSync, error)
+	SetPolling(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole, enabled bool) (*domain.CloudSync, error)
+	DeleteSync(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole) error
+	TriggerSync(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole) error
+	ListSyncFiles(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.CloudSyncFile, int, error)
+
+	// PollDue runs every polling sync whose last run is older than interval.
+	PollDue(ctx context.Context, interval time.Duration) error
+}
+
+type cloudImportService struct {
+	providers     map[domain.CloudProvider]port.CloudDriveProvider
+	connRepo      port.CloudConnectionRepository
+	syncRepo      port.CloudSyncRepository
+	userRepo      port.UserRepository
+	collectionSvc CollectionService
+	ingester      *collectionIngester
+	sealer        *cloud.TokenSealer
+	jwtCfg        config.JWTConfig
+	maxFileBytes  int64
+
+	// running holds the IDs of syncs currently in progress so overlapping
+	// triggers (manual + poll) don't import the same folder twice.
+	running sync.Map
+}
+
+// NewCloudImportService creates a new CloudImportService. Only providers passed in
+// are available; the rest report domain.ErrCloudProviderNotConfigured.
+func NewCloudImportService(
+	providers []port.CloudDriveProvider,
+	connRepo port.CloudConnectionRepository,
+	syncRepo port.CloudSyncRepository,
+	userRepo port.UserRepository,
+	fileSvc FileService,
+	collectionSvc CollectionService,
+	docSvc DocumentService,
+	sealer *cloud.TokenSealer,
+	jwtCfg config.JWTConfig,
+	s3Cfg *config.S3Config,
+) CloudImportService {
+	byName := make(map[domain.CloudProvider]port.CloudDriveProvider, len(providers))
+	for _, p := range providers {
+		byName[p.Provider()] = p
+	}
+	return &cloudImportService{
+		providers:     byName,
+		connRepo:      connRepo,
+		syncRepo:      syncRepo,
+		userRepo:      userRepo,
+		collectionSvc: collectionSvc,
+		ingester:      &collectionIngester{fileSvc: fileSvc, collectionSvc: collectionSvc, docSvc: docSvc},
+		sealer:        sealer,
+		jwtCfg:        jwtCfg,
+		maxFileBytes:  s3Cfg.MaxFileSizeMB * 1024 * 1024,
+	}
+}
+
+func (s *cloudImportService) provider(name domain.CloudProvider) (port.CloudDriveProvider, error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return nil, domain.ErrCloudProviderNotConfigured
+	}
+	return p, nil
+}
+
+func (s *cloudImportService) requirePerm(ctx context.Context, collectionID, userID uuid.UUID, role domain.UserRole, minLevel domain.CollectionPermission) error {
+	eff := s.collectionSvc.EffectivePermission(ctx, collectionID, userID, role)
+	if domain.CollectionPermLevel(eff) < domain.CollectionPermLevel(minLevel) {
+		return domain.ErrCollectionPermDenied
+	}
+	return nil
+}
+
+func (s *cloudImportService) AuthorizeURL(ctx context.Context, tenantID, userID uuid.UUID, provider domain.CloudProvider) (string, error) {
+	p, err := s.provider(provider)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   string(provider),
+			Issuer:    s.jwtCfg.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(cloudOAuthStateTTL)),
+			ID:        uuid.New().String(),
+			Audience:  jwt.ClaimStrings{cloudOAuthAudience},
+		},
+		TenantID: tenantID,
+		UserID:   userID,
+	}
+	state, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.jwtCfg.Secret))
+	if err != nil {
+		return "", fmt.Errorf("signing oauth state: %w", err)
+	}
+	return p.AuthCodeURL(state), nil
+}
+
+// verifyState checks that state was issued by AuthorizeURL for this user and provider.
+func (s *cloudImportService) verifyState(state string, tenantID, userID uuid.UUID, provider domain.CloudProvider) error {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(state, claims, func(_ *jwt.Token) (interface{}, error) {
+		return []byte(s.jwtCfg.Secret), nil
+	}, jwt.WithAudience(cloudOAuthAudience), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return domain.ErrInvalidOAuthState
+	}
+	if claims.TenantID != tenantID || claims.UserID != userID || claims.Subject != string(provider) {
+		return domain.ErrInvalidOAuthState
+	}
+	return nil
+}
+
+func (s *cloudImportService) Connect(ctx context.Context, tenantID, userID uuid.UUID, provider domain.CloudProvider, code, state string) (*domain.CloudConnection, error) {
+	p, err := s.provider(provider)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyState(state, tenantID, userID, provider); err != nil {
+		return nil, err
+	}
+
+	tok, err := p.Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &domain.CloudConnection{
+		TenantID:    tenantID,
+		UserID:      userID,
+		Provider:    provider,
+		AccountID:   tok.AccountID,
+		TokenExpiry: tok.Expiry,
+	}
+	if err := s.sealTokens(conn, tok); err != nil {
+		return nil, err
+	}
+	if err := s.connRepo.Upsert(ctx, conn); err != nil {
+		return nil, fmt.Errorf("saving cloud connection: %w", err)
+	}
+
+	log.Printf("cloudImportService.Connect: user %s connected %s account %s (tenant %s)",
+		userID, provider, tok.AccountID, tenantID)
+	return conn, nil
+}
+
+func (s *cloudImportService) sealTokens(conn *domain.CloudConnection, tok *port.CloudToken) error {
+	access, err := s.sealer.Seal(tok.AccessToken)
+	if err != nil {
+		return fmt.Errorf("encrypting access token: %w", err)
+	}
+	refresh, err := s.sealer.Seal(tok.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("encrypting refresh token: %w", err)
+	}
+	conn.AccessToken = access
+	if refresh != "" {
+		conn.RefreshToken = refresh
+	}
+	conn.TokenExpiry = tok.Expiry
+	return nil
+}
+
+func (s *cloudImportService) ListConnections(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.CloudConnection, error) {
+	return s.connRepo.ListByUser(ctx, tenantID, userID)
+}
+
+// ownConnection loads a connection, hiding other users' connections as not found.
+func (s *cloudImportService) ownConnection(ctx context.Context, tenantID, userID, connectionID uuid.UUID) (*domain.CloudConnection, error) {
+	conn, err := s.connRepo.GetByID(ctx, tenantID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+	return conn, nil
+}
+
+func (s *cloudImportService) DeleteConnection(ctx context.Context, tenantID, userID, connectionID uuid.UUID) error {
+	if _, err := s.ownConnection(ctx, tenantID, userID, connectionID); err != nil {
+		return err
+	}
+	return s.connRepo.Delete(ctx, tenantID, connectionID)
+}
+
+// accessToken returns a usable access token for conn, refreshing it if it's about to expire.
+func (s *cloudImportService) accessToken(ctx context.Context, p port.CloudDriveProvider, conn *domain.CloudConnection) (string, error) {
+	if time.Now().Add(cloudTokenRefreshSkew).Before(conn.TokenExpiry) {
+		return s.sealer.Open(conn.AccessToken)
+	}
+
+	refresh, err := s.sealer.Open(conn.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+	if refresh == "" {
+		return "", domain.ErrCloudAuthFailed
+	}
+	tok, err := p.Refresh(ctx, refresh)
+	if err != nil {
+		return "", err
+	}
+	if err := s.sealTokens(conn, tok); err != nil {
+		return "", err
+	}
+	if err := s.connRepo.UpdateTokens(ctx, conn); err != nil {
+		return "", fmt.Errorf("saving refreshed token: %w", err)
+	}
+	return tok.AccessToken, nil
+}
+
+func (s *cloudImportService) ListFolder(ctx context.Context, tenantID, userID, connectionID uuid.UUID, folderID string) ([]port.CloudFile, error) {
+	conn, err := s.ownConnection(ctx, tenantID, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.provider(conn.Provider)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.accessToken(ctx, p, conn)
+	if err != nil {
+		return nil, err
+	}
+	return p.ListFolder(ctx, token, folderID)
+}
+
+func (s *cloudImportService) CreateSync(ctx context.Context, input *CreateCloudSyncInput) (*domain.CloudSync, error) {
+	if err := s.requirePerm(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+	conn, err := s.ownConnection(ctx, input.TenantID, input.UserID, input.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.provider(conn.Provider); err != nil {
+		return nil, err
+	}
+
+	parseMode := input.ParseMode
+	if parseMode == "" {
+		parseMode = domain.ParseModeSingle
+	}
+	cs := &domain.CloudSync{
+		ID:           uuid.New(),
+		TenantID:     input.TenantID,
+		CollectionID: input.CollectionID,
+		ConnectionID: conn.ID,
+		Provider:     conn.Provider,
+		FolderID:     input.FolderID,
+		FolderName:   input.FolderName,
+		DocumentType: input.DocumentType,
+		ParseMode:    parseMode,
+		PollEnabled:  input.PollEnabled,
+		CreatedBy:    input.UserID,
+	}
+	if err := s.syncRepo.Create(ctx, cs); err != nil {
+		return nil, err
+	}
+
+	log.Printf("cloudImportService.CreateSync: created sync %s (%s folder %s) for collection %s (tenant %s)",
+		cs.ID, cs.Provider, cs.FolderID, cs.CollectionID, cs.TenantID)
+
+	// Initial import runs in the background
+	result := *cs
+	s.startInBackground(cs)
+	return &result, nil
+}
+
+// collectionSync loads a sync and checks it belongs to the collection.
+func (s *cloudImportService) collectionSync(ctx context.Context, tenantID, collectionID, syncID uuid.UUID) (*domain.CloudSync, error) {
+	cs, err := s.syncRepo.GetByID(ctx, tenantID, syncID)
+	if err != nil {
+		return nil, err
+	}
+	if cs.CollectionID != collectionID {
+		return nil, domain.ErrNotFound
+	}
+	return cs, nil
+}
+
+func (s *cloudImportService) ListSyncs(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) ([]domain.CloudSync, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+	return s.syncRepo.ListByCollection(ctx, tenantID, collectionID)
+}
+
+func (s *cloudImportService) GetSync(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole) (*domain.CloudSync, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+	return s.collectionSync(ctx, tenantID, collectionID, syncID)
+}
+
+func (s *cloudImportService) SetPolling(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole, enabled bool) (*domain.CloudSync, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+	cs, err := s.collectionSync(ctx, tenantID, collectionID, syncID)
+	if err != nil {
+		return nil, err
+	}
+	cs.PollEnabled = enabled
+	if err := s.syncRepo.Update(ctx, cs); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+func (s *cloudImportService) DeleteSync(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole) error {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermEditor); err != nil {
+		return err
+	}
+	if _, err := s.collectionSync(ctx, tenantID, collectionID, syncID); err != nil {
+		return err
+	}
+	return s.syncRepo.Delete(ctx, tenantID, syncID)
+}
+
+func (s *cloudImportService) TriggerSync(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole) error {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermEditor); err != nil {
+		return err
+	}
+	cs, err := s.collectionSync(ctx, tenantID, collectionID, syncID)
+	if err != nil {
+		return err
+	}
+	s.startInBackground(cs)
+	return nil
+}
+
+func (s *cloudImportService) ListSyncFiles(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.CloudSyncFile, int, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, 0, err
+	}
+	if _, err := s.collectionSync(ctx, tenantID, collectionID, syncID); err != nil {
+		return nil, 0, err
+	}
+	return s.syncRepo.ListFiles(ctx, tenantID, syncID, offset, limit)
+}
+
+func (s *cloudImportService) PollDue(ctx context.Context, interval time.Duration) error {
+	due, err := s.syncRepo.ClaimDueForPoll(ctx, time.Now().UTC().Add(-interval), cloudSyncBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range due {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.runExclusive(ctx, &due[i])
+	}
+	return nil
+}
+
+func (s *cloudImportService) startInBackground(cs *domain.CloudSync) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+		defer cancel()
+		s.runExclusive(ctx, cs)
+	}()
+}
+
+// runExclusive runs a sync unless another run of the same sync is in progress.
+func (s *cloudImportService) runExclusive(ctx context.Context, cs *domain.CloudSync) {
+	if _, busy := s.running.LoadOrStore(cs.ID, struct{}{}); busy {
+		log.Printf("cloudImportService: sync %s already running, skipping", cs.ID)
+		return
+	}
+	defer s.running.Delete(cs.ID)
+
+	err := s.run(ctx, cs)
+
+	now := time.Now().UTC()
+	cs.LastSyncedAt = &now
+	cs.LastError = nil
+	if err != nil {
+		log.Printf("cloudImportService: sync %s failed: %v", cs.ID, err)
+		msg := err.Error()
+		if errors.Is(err, domain.ErrCloudAuthFailed) {
+			// The grant is gone; stop polling until the user reconnects and re-enables it
+			msg = "cloud account authorization failed; reconnect the account and re-enable polling"
+			cs.PollEnabled = false
+		}
+		cs.LastError = &msg
+	}
+	if err := s.syncRepo.Update(ctx, cs); err != nil {
+		log.Printf("cloudImportService: failed to save sync %s state: %v", cs.ID, err)
+	}
+}
+
+// run imports every file in the sync's folder that it hasn't settled yet.
+func (s *cloudImportService) run(ctx context.Context, cs *domain.CloudSync) error {
+	p, err := s.provider(cs.Provider)
+	if err != nil {
+		return err
+	}
+	conn, err := s.connRepo.GetByID(ctx, cs.TenantID, cs.ConnectionID)
+	if err != nil {
+		return fmt.Errorf("loading connection: %w", err)
+	}
+	// Polls act on behalf of the sync's creator with their current role
+	user, err := s.userRepo.GetByID(ctx, cs.TenantID, cs.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("loading sync owner: %w", err)
+	}
+	if !user.IsActive {
+		return fmt.Errorf("sync owner %s is deactivated", user.ID)
+	}
+
+	token, err := s.accessToken(ctx, p, conn)
+	if err != nil {
+		return err
+	}
+	files, err := p.ListFolder(ctx, token, cs.FolderID)
+	if err != nil {
+		return err
+	}
+	seen, err := s.syncRepo.SeenExternalIDs(ctx, cs.ID)
+	if err != nil {
+		return err
+	}
+
+	target := &ingestTarget{
+		TenantID:     cs.TenantID,
+		CollectionID: cs.CollectionID,
+		UserID:       user.ID,
+		Role:         user.Role,
+		DocumentType: cs.DocumentType,
+		ParseMode:    cs.ParseMode,
+		Tags:         map[string]string{"cloud_sync_id": cs.ID.String()},
+	}
+
+	processed, counts := 0, map[domain.CloudSyncFileStatus]int{}
+	for _, f := range files {
+		if f.IsFolder || seen[f.ID] {
+			continue
+		}
+		if processed == maxImportEntries {
+			log.Printf("cloudImportService: sync %s reached %d files; the rest will be picked up next run", cs.ID, maxImportEntries)
+			break
+		}
+		processed++
+
+		rec := s.syncOne(ctx, p, token, cs, target, f)
+		counts[rec.Status]++
+		if err := s.syncRepo.RecordFile(ctx, rec); err != nil {
+			log.Printf("cloudImportService: failed to record %s for sync %s: %v", f.ID, cs.ID, err)
+		}
+	}
+
+	log.Printf("cloudImportService: sync %s done (%d imported, %d duplicate, %d skipped, %d failed)",
+		cs.ID, counts[domain.CloudSyncFileImported], counts[domain.CloudSyncFileDuplicate],
+		counts[domain.CloudSyncFileSkipped], counts[domain.CloudSyncFileFailed])
+	return nil
+}
+
+// syncOne downloads one cloud file, dedupes it by content hash and ingests it.
+func (s *cloudImportService) syncOne(ctx context.Context, p port.CloudDriveProvider, token string,
+	cs *domain.CloudSync, target *ingestTarget, f port.CloudFile) *domain.CloudSyncFile {
+	rec := &domain.CloudSyncFile{
+		TenantID:     cs.TenantID,
+		SyncID:       cs.ID,
+		CollectionID: cs.CollectionID,
+		ExternalID:   f.ID,
+		Name:         f.Name,
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(f.Name), "."))
+	if _, allowed := domain.AllowedExtensions[ext]; !allowed {
+		rec.Status, rec.Reason = domain.CloudSyncFileSkipped, domain.ErrUnsupportedFileType.Error()
+		return rec
+	}
+	if f.Size > s.maxFileBytes {
+		rec.Status, rec.Reason = domain.CloudSyncFileSkipped, domain.ErrFileTooLarge.Error()
+		return rec
+	}
+
+	content, err := p.Download(ctx, token, f.ID, s.maxFileBytes)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileTooLarge) {
+			rec.Status, rec.Reason = domain.CloudSyncFileSkipped, err.Error()
+		} else {
+			rec.Status, rec.Reason = domain.CloudSyncFileFailed, fmt.Sprintf("downloading file: %v", err)
+		}
+		return rec
+	}
+
+	sum := sha256.Sum256(content)
+	rec.ContentHash = hex.EncodeToString(sum[:])
+	dup, err := s.syncRepo.HashImported(ctx, cs.CollectionID, rec.ContentHash)
+	if err != nil {
+		rec.Status, rec.Reason = domain.CloudSyncFileFailed, fmt.Sprintf("checking duplicates: %v", err)
+		return rec
+	}
+	if dup {
+		rec.Status, rec.Reason = domain.CloudSyncFileDuplicate, "identical file already imported into this collection"
+		return rec
+	}
+
+	entry := s.ingester.ingest(ctx, target, f.Name, path.Base(f.Name), content)
+	rec.FileID, rec.DocumentID, rec.Reason = entry.FileID, entry.DocumentID, entry.Reason
+	switch entry.Status {
+	case domain.ImportEntryImported:
+		rec.Status = domain.CloudSyncFileImported
+	case domain.ImportEntrySkipped:
+		rec.Status = domain.CloudSyncFileSkipped
+	default:
+		rec.Status = domain.CloudSyncFileFailed
+	}
+	return rec
+}
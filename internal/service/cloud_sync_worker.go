@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// CloudSyncWorker periodically re-runs cloud folder syncs that have polling enabled.
+type CloudSyncWorker struct {
+	svc      CloudImportService
+	interval time.Duration
+}
+
+// NewCloudSyncWorker creates a new CloudSyncWorker. Each polling sync runs at most
+// once per interval.
+func NewCloudSyncWorker(svc CloudImportService, interval time.Duration) *CloudSyncWorker {
+	return &CloudSyncWorker{svc: svc, interval: interval}
+}
+
+// Start runs the polling loop until ctx is canceled.
+func (w *CloudSyncWorker) Start(ctx context.Context) {
+	// Check for due syncs more often than the interval so a sync isn't delayed a full extra cycle
+	ticker := time.NewTicker(w.interval / 4)
+	defer ticker.Stop()
+
+	log.Printf("cloudSyncWorker: started (interval=%s)", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("cloudSyncWorker: shutdown complete")
+			return
+		case <-ticker.C:
+			if err := w.svc.PollDue(ctx, w.interval); err != nil && ctx.Err() == nil {
+				log.Printf("cloudSyncWorker: PollDue error: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// ingestTarget identifies where server-side ingested files land and how their
+// documents are parsed. It is shared by every bulk ingestion path.
+type ingestTarget struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	DocumentType string
+	ParseMode    domain.ParseMode
+	Tags         map[string]string
+}
+
+// collectionIngester stores a file, attaches it to a collection and creates its
+// document — the same three steps a client performs after a manual upload.
+type collectionIngester struct {
+	fileSvc       FileService
+	collectionSvc CollectionService
+	docSvc        DocumentService
+}
+
+// ingest runs the pipeline for one file and reports the outcome. Content rejections
+// from FileService are reported as skipped; every other error as failed.
+func (g *collectionIngester) ingest(ctx context.Context, t *ingestTarget, entryName, fileName string, content []byte) domain.ImportEntry {
+	entry := domain.ImportEntry{Name: entryName}
+
+	meta, err := g.fileSvc.Ingest(ctx, FileIngestInput{
+		TenantID:     t.TenantID,
+		UploadedBy:   t.UserID,
+		FileName:     fileName,
+		Content:      content,
+		CollectionID: &t.CollectionID,
+	})
+	if err != nil {
+		if isFileRejection(err) {
+			return skippedEntry(entry, err)
+		}
+		return failedEntry(entry, fmt.Sprintf("storing file: %v", err))
+	}
+	entry.FileID = &meta.ID
+
+	if err := g.collectionSvc.AddFileToCollection(ctx, t.TenantID, t.CollectionID, meta.ID, t.UserID, t.Role); err != nil {
+		return failedEntry(entry, fmt.Sprintf("adding file to collection: %v", err))
+	}
+
+	doc, err := g.docSvc.CreateAndParse(ctx, &CreateDocumentInput{
+		TenantID:     t.TenantID,
+		CollectionID: t.CollectionID,
+		FileID:       meta.ID,
+		DocumentType: t.DocumentType,
+		ParseMode:    t.ParseMode,
+		Tags:         t.Tags,
+		CreatedBy:    t.UserID,
+		Role:         t.Role,
+	})
+	if err != nil {
+		return failedEntry(entry, fmt.Sprintf("creating document: %v", err))
+	}
+	entry.DocumentID = &doc.ID
+	entry.Status = domain.ImportEntryImported
+	return entry
+}
+
+// isFileRejection reports whether err is a content validation failure from FileService.
+func isFileRejection(err error) bool {
+	return errors.Is(err, domain.ErrUnsupportedFileType) ||
+		errors.Is(err, domain.ErrFileTooLarge) ||
+		errors.Is(err, domain.ErrFileContentMismatch) ||
+		errors.Is(err, domain.ErrPDFEncrypted) ||
+		errors.Is(err, domain.ErrPDFNoPages)
+}
+
+func skippedEntry(entry domain.ImportEntry, reason error) domain.ImportEntry {
+	entry.Status = domain.ImportEntrySkipped
+	entry.Reason = reason.Error()
+	return entry
+}
+
+func failedEntry(entry domain.ImportEntry, reason string) domain.ImportEntry {
+	entry.Status = domain.ImportEntryFailed
+	entry.Reason = reason
+	return entry
+}
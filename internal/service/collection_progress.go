@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+const (
+	maxKPITargets = 10
+	// kpiVelocityWindowDays is how far back velocity is measured for projections.
+	kpiVelocityWindowDays = 7
+)
+
+// SetKPITargetsInput is the DTO for replacing a collection's KPI targets.
+type SetKPITargetsInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	Targets      []domain.KPITarget
+}
+
+// CollectionProgress is how far a collection's documents have got, with each
+// KPI target's status and projected completion.
+type CollectionProgress struct {
+	CollectionID    uuid.UUID           `json:"collection_id"`
+	AsOf            time.Time           `json:"as_of"`
+	TotalDocuments  int                 `json:"total_documents"`
+	Parsed          int                 `json:"parsed"`
+	Reviewed        int                 `json:"reviewed"`
+	Approved        int                 `json:"approved"`
+	PercentParsed   float64             `json:"percent_parsed"`
+	PercentReviewed float64             `json:"percent_reviewed"`
+	PercentApproved float64             `json:"percent_approved"`
+	Targets         []KPITargetProgress `json:"targets"`
+	// AtRisk is true when any target is at risk or missed.
+	AtRisk bool `json:"at_risk"`
+}
+
+// KPITargetProgress is the progress against one KPI target. VelocityPerDay is
+// measured over the last kpiVelocityWindowDays days; ProjectedCompletion is when
+// the target is reached at that velocity, nil when it is met or velocity is zero.
+type KPITargetProgress struct {
+	domain.KPITarget
+	CurrentPct          float64                `json:"current_pct"`
+	Remaining           int                    `json:"remaining"`
+	VelocityPerDay      float64                `json:"velocity_per_day"`
+	ProjectedCompletion *time.Time             `json:"projected_completion"`
+	Status              domain.KPITargetStatus `json:"status"`
+}
+
+// SetKPITargets replaces the collection's KPI targets. An empty list removes them.
+func (s *collectionService) SetKPITargets(ctx context.Context, input *SetKPITargetsInput) (*domain.Collection, error) {
+	targets, err := normalizeKPITargets(input.Targets)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.requirePermission(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermOwner); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, input.TenantID, input.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(targets)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling KPI targets: %w", err)
+	}
+	collection.KPITargets = raw
+	if err := s.collectionRepo.UpdateKPITargets(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	log.Printf("collectionService.SetKPITargets: collection %s now has %d KPI targets (by user %s)",
+		collection.ID, len(targets), input.UserID)
+	return collection, nil
+}
+
+// GetProgress reports the collection's parse and review progress and where it
+// stands against each of its KPI targets.
+func (s *collectionService) GetProgress(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) (*CollectionProgress, error) {
+	if err := s.requirePermission(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, tenantID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	counts, err := s.collectionRepo.ProgressCounts(ctx, tenantID, collectionID, now.AddDate(0, 0, -kpiVelocityWindowDays))
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &CollectionProgress{
+		CollectionID:    collectionID,
+		AsOf:            now,
+		TotalDocuments:  counts.Total,
+		Parsed:          counts.Parsed,
+		Reviewed:        counts.Reviewed,
+		Approved:        counts.Approved,
+		PercentParsed:   percentOf(counts.Parsed, counts.Total),
+		PercentReviewed: percentOf(counts.Reviewed, counts.Total),
+		PercentApproved: percentOf(counts.Approved, counts.Total),
+		Targets:         []KPITargetProgress{},
+	}
+	targets := decodeKPITargets(collection.KPITargets)
+	loc := time.UTC
+	if len(targets) > 0 {
+		loc = collectionLocation(ctx, s.collectionRepo, tenantID, collectionID)
+	}
+	for _, target := range targets {
+		tp := kpiTargetProgress(target, counts, now, loc)
+		if tp.Status == domain.KPITargetAtRisk || tp.Status == domain.KPITargetMissed {
+			progress.AtRisk = true
+		}
+		progress.Targets = append(progress.Targets, tp)
+	}
+	return progress, nil
+}
+
+// normalizeKPITargets defaults a zero target_pct to 100 and rejects unknown
+// metrics, percentages outside (0, 100], malformed due dates and duplicates.
+func normalizeKPITargets(targets []domain.KPITarget) ([]domain.KPITarget, error) {
+	if len(targets) > maxKPITargets {
+		return nil, fmt.Errorf("%w: at most %d targets", domain.ErrInvalidKPITargets, maxKPITargets)
+	}
+	out := make([]domain.KPITarget, 0, len(targets))
+	seen := make(map[domain.KPITarget]bool, len(targets))
+	for _, t := range targets {
+		switch t.Metric {
+		case domain.KPIMetricParsed, domain.KPIMetricReviewed, domain.KPIMetricApproved:
+		default:
+			return nil, fmt.Errorf("%w: metric must be parsed, reviewed or approved", domain.ErrInvalidKPITargets)
+		}
+		if t.TargetPct == 0 {
+			t.TargetPct = 100
+		}
+		if t.TargetPct < 0 || t.TargetPct > 100 {
+			return nil, fmt.Errorf("%w: target_pct must be between 0 and 100", domain.ErrInvalidKPITargets)
+		}
+		if _, err := time.Parse("2006-01-02", t.DueDate); err != nil {
+			return nil, fmt.Errorf("%w: due_date must be YYYY-MM-DD", domain.ErrInvalidKPITargets)
+		}
+		if seen[t] {
+			return nil, fmt.Errorf("%w: duplicate target", domain.ErrInvalidKPITargets)
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// decodeKPITargets reads a collection's stored KPI targets; a missing or
+// malformed value means none.
+func decodeKPITargets(raw json.RawMessage) []domain.KPITarget {
+	var targets []domain.KPITarget
+	if len(raw) == 0 || json.Unmarshal(raw, &targets) != nil {
+		return nil
+	}
+	return targets
+}
+
+func kpiTargetProgress(target domain.KPITarget, counts *domain.CollectionProgressCounts, now time.Time, loc *time.Location) KPITargetProgress {
+	var done, recent int
+	switch target.Metric {
+	case domain.KPIMetricParsed:
+		done, recent = counts.Parsed, counts.ParsedRecent
+	case domain.KPIMetricReviewed:
+		done, recent = counts.Reviewed, counts.ReviewedRecent
+	case domain.KPIMetricApproved:
+		done, recent = counts.Approved, counts.ApprovedRecent
+	}
+
+	// Small epsilon so 100% of 3 documents needs 3, not 4, after float rounding
+	needed := int(math.Ceil(target.TargetPct/100*float64(counts.Total) - 1e-9))
+	tp := KPITargetProgress{
+		KPITarget:      target,
+		CurrentPct:     percentOf(done, counts.Total),
+		Remaining:      max(needed-done, 0),
+		VelocityPerDay: math.Round(float64(recent)/kpiVelocityWindowDays*100) / 100,
+	}
+
+	// The target holds until the end of its due date in the tenant's time zone
+	due, _ := time.ParseInLocation("2006-01-02", target.DueDate, loc)
+	deadline := due.AddDate(0, 0, 1)
+	velocity := float64(recent) / kpiVelocityWindowDays
+
+	switch {
+	case tp.Remaining == 0:
+		tp.Status = domain.KPITargetMet
+	case !now.Before(deadline):
+		tp.Status = domain.KPITargetMissed
+	case velocity == 0:
+		tp.Status = domain.KPITargetAtRisk
+	default:
+		projected := now.Add(time.Duration(float64(tp.Remaining) / velocity * float64(24*time.Hour))).Truncate(time.Second)
+		tp.ProjectedCompletion = &projected
+		tp.Status = domain.KPITargetOnTrack
+		if projected.After(deadline) {
+			tp.Status = domain.KPITargetAtRisk
+		}
+	}
+	return tp
+}
+
+// percentOf returns part as a percentage of total, to two decimals; 0 when total is 0.
+func percentOf(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*10000) / 100
+}
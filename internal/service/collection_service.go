@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"mime/multipart"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -31,6 +33,77 @@ type UpdateCollectionInput struct {
 	Description  string
 }
 
+// SetReviewChecklistInput is the DTO for replacing a collection's review checklist.
+type SetReviewChecklistInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	Items        []domain.ReviewChecklistItem
+}
+
+// SetCheckerThresholdInput is the DTO for setting a collection's maker-checker threshold.
+type SetCheckerThresholdInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	Threshold    *float64
+}
+
+// SetEscalationPolicyInput is the DTO for setting a collection's stale-review escalation.
+type SetEscalationPolicyInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	// AfterDays is the number of days a document may wait for review; nil turns escalation off.
+	AfterDays *int
+	// Action defaults to flag.
+	Action domain.EscalationAction
+}
+
+// SetQASamplingInput is the DTO for setting a collection's QA sampling rate.
+type SetQASamplingInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	// SamplePercent is the share of approvals sampled, 1-100; nil turns QA sampling off.
+	SamplePercent *int
+}
+
+// SetDefaultParseModeInput is the DTO for setting a collection's default parse mode.
+type SetDefaultParseModeInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	// ParseMode is single or dual; "" clears it so the tenant's default applies.
+	ParseMode domain.ParseMode
+}
+
+// SetNamingTemplateInput is the DTO for setting a collection's naming template.
+type SetNamingTemplateInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	// Template such as "{seller_name}-{invoice_number}"; "" turns renaming off.
+	Template string
+}
+
+// SetFileDownloadPermInput is the DTO for restricting downloads of a
+// collection's original files.
+type SetFileDownloadPermInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	// Permission needed to download original files; "" lets every viewer download them.
+	Permission domain.CollectionPermission
+}
+
 // SetPermissionInput is the DTO for setting a collection permission.
 type SetPermissionInput struct {
 	TenantID     uuid.UUID
@@ -61,6 +134,18 @@ type CollectionService interface {
 	GetByID(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) (*domain.Collection, error)
 	List(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Collection, int, error)
 	Update(ctx context.Context, input *UpdateCollectionInput) (*domain.Collection, error)
+	SetReviewChecklist(ctx context.Context, input *SetReviewChecklistInput) (*domain.Collection, error)
+	SetCheckerThreshold(ctx context.Context, input *SetCheckerThresholdInput) (*domain.Collection, error)
+	SetEscalationPolicy(ctx context.Context, input *SetEscalationPolicyInput) (*domain.Collection, error)
+	SetKPITargets(ctx context.Context, input *SetKPITargetsInput) (*domain.Collection, error)
+	SetQASampling(ctx context.Context, input *SetQASamplingInput) (*domain.Collection, error)
+	SetDefaultParseMode(ctx context.Context, input *SetDefaultParseModeInput) (*domain.Collection, error)
+	SetNamingTemplate(ctx context.Context, input *SetNamingTemplateInput) (*domain.Collection, error)
+	SetFileDownloadPerm(ctx context.Context, input *SetFileDownloadPermInput) (*domain.Collection, error)
+	// CheckFileDownload returns ErrFileDownloadRestricted if a collection holding
+	// the file requires a higher permission to download it than the user has.
+	CheckFileDownload(ctx context.Context, tenantID, fileID, userID uuid.UUID, role domain.UserRole) error
+	GetProgress(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) (*CollectionProgress, error)
 	Delete(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) error
 	ListFiles(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.FileMeta, int, error)
 	BatchUploadFiles(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, files []BatchUploadFileInput) ([]BatchUploadResult, error)
@@ -71,6 +156,7 @@ type CollectionService interface {
 	RemovePermission(ctx context.Context, tenantID, collectionID, targetUserID, userID uuid.UUID, role domain.UserRole) error
 	EffectivePermission(ctx context.Context, collectionID, userID uuid.UUID, role domain.UserRole) domain.CollectionPermission
 	EffectivePermissions(ctx context.Context, collectionIDs []uuid.UUID, userID uuid.UUID, role domain.UserRole) (map[uuid.UUID]domain.CollectionPermission, error)
+	ListActivity(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.CollectionActivityEvent, int, error)
 }
 
 type collectionService struct {
@@ -79,6 +165,7 @@ type collectionService struct {
 	fileRepo       port.CollectionFileRepository
 	fileSvc        FileService
 	userRepo       port.UserRepository
+	eventRepo      port.CollectionEventRepository
 }
 
 // NewCollectionService creates a new CollectionService implementation.
@@ -88,6 +175,7 @@ func NewCollectionService(
 	fileRepo port.CollectionFileRepository,
 	fileSvc FileService,
 	userRepo port.UserRepository,
+	eventRepo port.CollectionEventRepository,
 ) CollectionService {
 	return &collectionService{
 		collectionRepo: collectionRepo,
@@ -95,6 +183,7 @@ func NewCollectionService(
 		fileRepo:       fileRepo,
 		fileSvc:        fileSvc,
 		userRepo:       userRepo,
+		eventRepo:      eventRepo,
 	}
 }
 
@@ -201,6 +290,266 @@ func (s *collectionService) Update(ctx context.Context, input *UpdateCollectionI
 	return collection, nil
 }
 
+// SetReviewChecklist replaces the yes/no items reviewers must answer before
+// approving documents in the collection. An empty list removes the checklist.
+func (s *collectionService) SetReviewChecklist(ctx context.Context, input *SetReviewChecklistInput) (*domain.Collection, error) {
+	if err := s.requirePermission(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermOwner); err != nil {
+		return nil, err
+	}
+
+	items, err := normalizeChecklistItems(input.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, input.TenantID, input.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling review checklist: %w", err)
+	}
+	collection.ReviewChecklist = raw
+
+	if err := s.collectionRepo.UpdateReviewChecklist(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	log.Printf("collectionService.SetReviewChecklist: collection %s now has %d checklist items (by user %s)",
+		collection.ID, len(items), input.UserID)
+	return collection, nil
+}
+
+// SetCheckerThreshold sets the invoice total at or above which an approval needs
+// a second, checker confirmation. A nil threshold turns maker-checker off.
+func (s *collectionService) SetCheckerThreshold(ctx context.Context, input *SetCheckerThresholdInput) (*domain.Collection, error) {
+	if err := s.requirePermission(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermOwner); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, input.TenantID, input.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.CheckerThreshold = input.Threshold
+	if err := s.collectionRepo.UpdateCheckerThreshold(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	log.Printf("collectionService.SetCheckerThreshold: collection %s checker threshold set to %v (by user %s)",
+		collection.ID, formatThreshold(input.Threshold), input.UserID)
+	return collection, nil
+}
+
+// SetEscalationPolicy sets how many days a document may wait for review before
+// it is escalated, and whether escalation also reassigns it to the collection owner.
+func (s *collectionService) SetEscalationPolicy(ctx context.Context, input *SetEscalationPolicyInput) (*domain.Collection, error) {
+	action := input.Action
+	if action == "" {
+		action = domain.EscalationActionFlag
+	}
+	if action != domain.EscalationActionFlag && action != domain.EscalationActionReassign {
+		return nil, fmt.Errorf("%w: action must be flag or reassign", domain.ErrInvalidEscalationPolicy)
+	}
+	if input.AfterDays != nil && (*input.AfterDays < 1 || *input.AfterDays > maxEscalateAfterDays) {
+		return nil, fmt.Errorf("%w: after_days must be between 1 and %d", domain.ErrInvalidEscalationPolicy, maxEscalateAfterDays)
+	}
+
+	if err := s.requirePermission(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermOwner); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, input.TenantID, input.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.EscalateAfterDays = input.AfterDays
+	collection.EscalationAction = action
+	if err := s.collectionRepo.UpdateEscalationPolicy(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	log.Printf("collectionService.SetEscalationPolicy: collection %s escalation set to %s (by user %s)",
+		collection.ID, formatEscalationPolicy(collection), input.UserID)
+	return collection, nil
+}
+
+// SetQASampling sets the share of the collection's approvals sampled for a blind
+// second review. A nil percent turns QA sampling off.
+func (s *collectionService) SetQASampling(ctx context.Context, input *SetQASamplingInput) (*domain.Collection, error) {
+	if input.SamplePercent != nil && (*input.SamplePercent < 1 || *input.SamplePercent > 100) {
+		return nil, fmt.Errorf("%w: sample_percent must be between 1 and 100", domain.ErrInvalidQASampling)
+	}
+
+	if err := s.requirePermission(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermOwner); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, input.TenantID, input.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.QASamplePercent = input.SamplePercent
+	if err := s.collectionRepo.UpdateQASampling(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	log.Printf("collectionService.SetQASampling: collection %s QA sampling set to %s (by user %s)",
+		collection.ID, formatQASampling(input.SamplePercent), input.UserID)
+	return collection, nil
+}
+
+// SetDefaultParseMode sets the parse mode for documents created in the
+// collection without one. An empty mode falls back to the tenant's default.
+func (s *collectionService) SetDefaultParseMode(ctx context.Context, input *SetDefaultParseModeInput) (*domain.Collection, error) {
+	mode, err := parseModeDefault(input.ParseMode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.requirePermission(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermOwner); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, input.TenantID, input.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.DefaultParseMode = mode
+	if err := s.collectionRepo.UpdateDefaultParseMode(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	log.Printf("collectionService.SetDefaultParseMode: collection %s default parse mode set to %q (by user %s)",
+		collection.ID, input.ParseMode, input.UserID)
+	return collection, nil
+}
+
+// SetNamingTemplate sets the template that renames the collection's documents
+// after their first parse. Documents already parsed keep their names.
+func (s *collectionService) SetNamingTemplate(ctx context.Context, input *SetNamingTemplateInput) (*domain.Collection, error) {
+	var template *string
+	if t := strings.TrimSpace(input.Template); t != "" {
+		if err := validateNamingTemplate(t); err != nil {
+			return nil, err
+		}
+		template = &t
+	}
+
+	if err := s.requirePermission(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermOwner); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, input.TenantID, input.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.NamingTemplate = template
+	if err := s.collectionRepo.UpdateNamingTemplate(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	log.Printf("collectionService.SetNamingTemplate: collection %s naming template set to %q (by user %s)",
+		collection.ID, input.Template, input.UserID)
+	return collection, nil
+}
+
+// SetFileDownloadPerm sets the permission needed to download the original
+// files of the collection's documents. Viewing their parsed data is unaffected.
+func (s *collectionService) SetFileDownloadPerm(ctx context.Context, input *SetFileDownloadPermInput) (*domain.Collection, error) {
+	var perm *domain.CollectionPermission
+	if input.Permission != "" {
+		if !domain.ValidCollectionPermissions[input.Permission] {
+			return nil, domain.ErrInvalidPermission
+		}
+		p := input.Permission
+		perm = &p
+	}
+
+	if err := s.requirePermission(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermOwner); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, input.TenantID, input.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.FileDownloadPerm = perm
+	if err := s.collectionRepo.UpdateFileDownloadPerm(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	log.Printf("collectionService.SetFileDownloadPerm: collection %s file download permission set to %q (by user %s)",
+		collection.ID, input.Permission, input.UserID)
+	return collection, nil
+}
+
+func (s *collectionService) CheckFileDownload(ctx context.Context, tenantID, fileID, userID uuid.UUID, role domain.UserRole) error {
+	required, err := s.collectionRepo.FileDownloadPerms(ctx, tenantID, fileID)
+	if err != nil {
+		return err
+	}
+	if len(required) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(required))
+	for id := range required {
+		ids = append(ids, id)
+	}
+	effective, err := s.EffectivePermissions(ctx, ids, userID, role)
+	if err != nil {
+		return err
+	}
+	// A file filed in several collections is only as open as the strictest of them
+	for id, perm := range required {
+		if domain.CollectionPermLevel(effective[id]) < domain.CollectionPermLevel(perm) {
+			return domain.ErrFileDownloadRestricted
+		}
+	}
+	return nil
+}
+
+// parseModeDefault validates a default parse mode; "" clears it.
+func parseModeDefault(mode domain.ParseMode) (*domain.ParseMode, error) {
+	if mode == "" {
+		return nil, nil
+	}
+	if !domain.ValidParseModes[mode] {
+		return nil, fmt.Errorf("%w: parse_mode must be single or dual", domain.ErrInvalidParseMode)
+	}
+	return &mode, nil
+}
+
+func formatQASampling(p *int) string {
+	if p == nil {
+		return "off"
+	}
+	return fmt.Sprintf("%d%%", *p)
+}
+
+func formatEscalationPolicy(c *domain.Collection) string {
+	if c.EscalateAfterDays == nil {
+		return "off"
+	}
+	return fmt.Sprintf("%s after %d days", c.EscalationAction, *c.EscalateAfterDays)
+}
+
+func formatThreshold(t *float64) string {
+	if t == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%.2f", *t)
+}
+
 func (s *collectionService) Delete(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) error {
 	if err := s.requirePermission(ctx, collectionID, userID, role, domain.CollectionPermOwner); err != nil {
 		return err
@@ -300,6 +649,11 @@ func (s *collectionService) SetPermission(ctx context.Context, input *SetPermiss
 	log.Printf("collectionService.SetPermission: setting %s permission for user %s on collection %s by user %s",
 		input.Permission, input.UserID, input.CollectionID, input.GrantedBy)
 
+	changes := map[string]interface{}{"permission": input.Permission}
+	if prev := s.previousPermission(ctx, input.CollectionID, input.UserID); prev != "" {
+		changes["previous_permission"] = prev
+	}
+
 	perm := &domain.CollectionPermissionEntry{
 		CollectionID: input.CollectionID,
 		TenantID:     input.TenantID,
@@ -307,7 +661,11 @@ func (s *collectionService) SetPermission(ctx context.Context, input *SetPermiss
 		Permission:   input.Permission,
 		GrantedBy:    input.GrantedBy,
 	}
-	return s.permRepo.Upsert(ctx, perm)
+	if err := s.permRepo.Upsert(ctx, perm); err != nil {
+		return err
+	}
+	s.recordEvent(ctx, input.TenantID, input.CollectionID, input.GrantedBy, domain.CollectionEventPermissionSet, input.UserID, changes)
+	return nil
 }
 
 func (s *collectionService) ListPermissions(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.CollectionPermissionEntry, int, error) {
@@ -326,7 +684,67 @@ func (s *collectionService) RemovePermission(ctx context.Context, tenantID, coll
 	}
 	log.Printf("collectionService.RemovePermission: removing permission for user %s on collection %s by user %s",
 		targetUserID, collectionID, userID)
-	return s.permRepo.Delete(ctx, collectionID, targetUserID)
+	changes := map[string]interface{}{}
+	if prev := s.previousPermission(ctx, collectionID, targetUserID); prev != "" {
+		changes["previous_permission"] = prev
+	}
+	if err := s.permRepo.Delete(ctx, collectionID, targetUserID); err != nil {
+		return err
+	}
+	s.recordEvent(ctx, tenantID, collectionID, userID, domain.CollectionEventPermissionRemoved, targetUserID, changes)
+	return nil
+}
+
+// ListActivity returns the collection's activity feed (uploads, parse outcomes,
+// reviews, file replacements and permission changes), newest first.
+func (s *collectionService) ListActivity(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.CollectionActivityEvent, int, error) {
+	if err := s.requirePermission(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, 0, err
+	}
+	if s.eventRepo == nil {
+		return []domain.CollectionActivityEvent{}, 0, nil
+	}
+	activity, total, err := s.eventRepo.ListActivity(ctx, tenantID, collectionID, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if activity == nil {
+		activity = []domain.CollectionActivityEvent{}
+	}
+	return activity, total, nil
+}
+
+// previousPermission returns a user's explicit grant before a permission change,
+// for the event record. It skips the lookup when events are not recorded.
+func (s *collectionService) previousPermission(ctx context.Context, collectionID, userID uuid.UUID) domain.CollectionPermission {
+	if s.eventRepo == nil {
+		return ""
+	}
+	existing, err := s.permRepo.GetByCollectionAndUser(ctx, collectionID, userID)
+	if err != nil {
+		return ""
+	}
+	return existing.Permission
+}
+
+// recordEvent writes a collection event. Like the document audit log, failures
+// are logged and never fail the operation.
+func (s *collectionService) recordEvent(ctx context.Context, tenantID, collectionID, userID uuid.UUID, action string, targetUserID uuid.UUID, changes map[string]interface{}) {
+	if s.eventRepo == nil {
+		return
+	}
+	changesJSON, _ := json.Marshal(changes)
+	event := &domain.CollectionEvent{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       &userID,
+		Action:       action,
+		TargetUserID: &targetUserID,
+		Changes:      changesJSON,
+	}
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		log.Printf("collectionService.recordEvent: failed to record %s on collection %s: %v", action, collectionID, err)
+	}
 }
 
 // EffectivePermission returns the effective collection permission for a user,
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/expr"
+	"satvos/internal/port"
+)
+
+const (
+	maxComputedFields           = 50
+	maxComputedFieldDescLen     = 500
+	defaultComputedFieldDocType = "invoice"
+)
+
+var computedFieldNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// SetComputedFieldInput is the DTO for adding or changing a computed field.
+type SetComputedFieldInput struct {
+	// Expression is evaluated against the document's structured data, e.g.
+	// "round(totals.total - totals.taxable_amount, 2)" or
+	// "sum(line_items[].quantity)". See API.md for the language.
+	Expression  string `json:"expression" binding:"required"`
+	Description string `json:"description"`
+	// DocumentType defaults to invoice.
+	DocumentType string `json:"document_type"`
+}
+
+// ComputedFieldService manages each tenant's computed fields and resolves them
+// for documents, so integrators read derived values from the API instead of
+// reimplementing them.
+type ComputedFieldService interface {
+	List(ctx context.Context, tenantID uuid.UUID) ([]domain.ComputedField, error)
+	// Set adds or replaces the field with the given name after compiling its
+	// expression against the document type's schema.
+	Set(ctx context.Context, tenantID uuid.UUID, name string, input *SetComputedFieldInput, updatedBy uuid.UUID) (*domain.ComputedField, error)
+	Delete(ctx context.Context, tenantID uuid.UUID, name string) error
+	// Resolve fills Extensions on each document with the tenant's fields for its
+	// document type. A field that fails on a document is null there.
+	Resolve(ctx context.Context, tenantID uuid.UUID, docs []domain.Document) error
+}
+
+type computedFieldService struct {
+	repo    port.ComputedFieldRepository
+	schemas SchemaService
+
+	mu         sync.Mutex
+	fieldTypes map[string]map[string]string
+}
+
+// NewComputedFieldService creates a new ComputedFieldService. Expressions may
+// reference the scalar fields schemas lists for the field's document type.
+func NewComputedFieldService(repo port.ComputedFieldRepository, schemas SchemaService) ComputedFieldService {
+	return &computedFieldService{repo: repo, schemas: schemas, fieldTypes: map[string]map[string]string{}}
+}
+
+func (s *computedFieldService) List(ctx context.Context, tenantID uuid.UUID) ([]domain.ComputedField, error) {
+	return s.repo.ListByTenant(ctx, tenantID)
+}
+
+func (s *computedFieldService) Set(ctx context.Context, tenantID uuid.UUID, name string, input *SetComputedFieldInput, updatedBy uuid.UUID) (*domain.ComputedField, error) {
+	if !computedFieldNameRe.MatchString(name) {
+		return nil, fmt.Errorf("%w: name %q must be lowercase letters, digits and underscores, starting with a letter", domain.ErrInvalidComputedField, name)
+	}
+	docType := strings.TrimSpace(input.DocumentType)
+	if docType == "" {
+		docType = defaultComputedFieldDocType
+	}
+	description := strings.TrimSpace(input.Description)
+	if len(description) > maxComputedFieldDescLen {
+		return nil, fmt.Errorf("%w: description must be at most %d characters", domain.ErrInvalidComputedField, maxComputedFieldDescLen)
+	}
+	expression := strings.TrimSpace(input.Expression)
+	if _, err := s.compile(docType, expression); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxComputedFields && !hasComputedField(existing, name) {
+		return nil, fmt.Errorf("%w: at most %d computed fields", domain.ErrInvalidComputedField, maxComputedFields)
+	}
+
+	field := &domain.ComputedField{
+		TenantID:     tenantID,
+		Name:         name,
+		DocumentType: docType,
+		Expression:   expression,
+		Description:  description,
+		UpdatedBy:    &updatedBy,
+	}
+	if err := s.repo.Upsert(ctx, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func hasComputedField(fields []domain.ComputedField, name string) bool {
+	for i := range fields {
+		if fields[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *computedFieldService) Delete(ctx context.Context, tenantID uuid.UUID, name string) error {
+	return s.repo.Delete(ctx, tenantID, name)
+}
+
+func (s *computedFieldService) Resolve(ctx context.Context, tenantID uuid.UUID, docs []domain.Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	fields, err := s.repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	type compiled struct {
+		field *domain.ComputedField
+		expr  *expr.Expr
+	}
+	var exprs []compiled
+	for i := range fields {
+		e, err := s.compile(fields[i].DocumentType, fields[i].Expression)
+		if err != nil {
+			// The schema changed under a stored field; it resolves to null.
+			log.Printf("computedFieldService: field %s of tenant %s no longer compiles: %v", fields[i].Name, tenantID, err)
+		}
+		exprs = append(exprs, compiled{field: &fields[i], expr: e})
+	}
+
+	for i := range docs {
+		doc := &docs[i]
+		var data map[string]interface{}
+		if len(doc.StructuredData) > 0 {
+			_ = json.Unmarshal(doc.StructuredData, &data)
+		}
+		doc.Extensions = map[string]interface{}{}
+		for _, c := range exprs {
+			if c.field.DocumentType != doc.DocumentType {
+				continue
+			}
+			var v interface{}
+			if c.expr != nil && data != nil {
+				v, _ = c.expr.Eval(data)
+			}
+			doc.Extensions[c.field.Name] = v
+		}
+	}
+	return nil
+}
+
+// compile compiles an expression against the scalar fields of a document
+// type's schema, which is built once per type.
+func (s *computedFieldService) compile(docType, expression string) (*expr.Expr, error) {
+	s.mu.Lock()
+	types, ok := s.fieldTypes[docType]
+	s.mu.Unlock()
+	if !ok {
+		schema, err := s.schemas.Get(docType)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: unknown document type %q", domain.ErrInvalidComputedField, docType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		types = make(map[string]string, len(schema.Fields))
+		for _, f := range schema.Fields {
+			types[f.Path] = f.Type
+		}
+		s.mu.Lock()
+		s.fieldTypes[docType] = types
+		s.mu.Unlock()
+	}
+
+	e, err := expr.Compile(expression, types)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidComputedField, err)
+	}
+	return e, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"satvos/internal/domain"
+)
+
+// recordConfidenceObservations stores, for every field the parser scored, whether
+// a reviewer changed it. changed holds the paths edited away from doc's current
+// data (nil on an unchanged approval). Once a manual edit has replaced the parser
+// output, the scores are no longer the parser's, so later edits only flag fields
+// already observed. Non-blocking: failures are logged.
+func (s *documentService) recordConfidenceObservations(ctx context.Context, doc *domain.Document, changed map[string]json.RawMessage) {
+	if s.observations == nil {
+		return
+	}
+
+	var provenance map[string]interface{}
+	_ = json.Unmarshal(doc.FieldProvenance, &provenance)
+	if provenance["source"] == "manual_edit" {
+		paths := make([]string, 0, len(changed))
+		for path := range changed {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		if err := s.observations.MarkCorrected(ctx, doc.TenantID, doc.ID, paths); err != nil {
+			log.Printf("documentService.recordConfidenceObservations: failed to mark corrections on %s: %v", doc.ID, err)
+		}
+		return
+	}
+
+	var scores interface{}
+	if err := json.Unmarshal(doc.ConfidenceScores, &scores); err != nil {
+		return
+	}
+	leaves := make(map[string]float64)
+	collectConfidenceLeaves("", scores, leaves)
+
+	observations := make([]domain.ConfidenceObservation, 0, len(leaves))
+	for path, confidence := range leaves {
+		// Overridden fields are pinned to 1.0; that score is the reviewer's, not the parser's
+		if provenance[path] == "manual_override" {
+			continue
+		}
+		observations = append(observations, domain.ConfidenceObservation{
+			TenantID:     doc.TenantID,
+			DocumentID:   doc.ID,
+			ParserModel:  doc.ParserModel,
+			DocumentType: doc.DocumentType,
+			FieldPath:    path,
+			Confidence:   confidence,
+			Corrected:    fieldChanged(path, changed),
+		})
+	}
+	sort.Slice(observations, func(i, j int) bool { return observations[i].FieldPath < observations[j].FieldPath })
+	if err := s.observations.Record(ctx, observations); err != nil {
+		log.Printf("documentService.recordConfidenceObservations: failed to record observations for %s: %v", doc.ID, err)
+	}
+}
+
+// collectConfidenceLeaves flattens confidence scores into field path -> score.
+func collectConfidenceLeaves(path string, node interface{}, out map[string]float64) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			collectConfidenceLeaves(joinFieldPath(path, key), child, out)
+		}
+	case []interface{}:
+		for i, child := range v {
+			collectConfidenceLeaves(fmt.Sprintf("%s[%d]", path, i), child, out)
+		}
+	case float64:
+		if path != "" && v >= 0 && v <= 1 {
+			out[path] = v
+		}
+	}
+}
+
+// fieldChanged reports whether path, or an object or array containing it, was edited.
+func fieldChanged(path string, changed map[string]json.RawMessage) bool {
+	for c := range changed {
+		if path == c || strings.HasPrefix(path, c+".") || strings.HasPrefix(path, c+"[") {
+			return true
+		}
+	}
+	return false
+}
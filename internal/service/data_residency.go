@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// StorageResidency maps tenants to the bucket of their storage region, so a
+// tenant pinned to e.g. ap-south-1 never has objects written or read elsewhere.
+type StorageResidency interface {
+	// Bucket returns the bucket holding the tenant's objects.
+	Bucket(ctx context.Context, tenantID uuid.UUID) (string, error)
+	// Check returns ErrDataResidencyViolation if bucket is not the tenant's bucket.
+	Check(ctx context.Context, tenantID uuid.UUID, bucket string) error
+	// ValidRegion reports whether region has a configured bucket. "" (default region) is always valid.
+	ValidRegion(region string) bool
+}
+
+type storageResidency struct {
+	tenantRepo port.TenantRepository
+	cfg        *config.S3Config
+}
+
+// NewStorageResidency creates a StorageResidency backed by the tenant's
+// storage_region and the S3 region bucket configuration.
+func NewStorageResidency(tenantRepo port.TenantRepository, cfg *config.S3Config) StorageResidency {
+	return &storageResidency{tenantRepo: tenantRepo, cfg: cfg}
+}
+
+func (r *storageResidency) Bucket(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	tenant, err := r.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", fmt.Errorf("loading tenant: %w", err)
+	}
+	region := ""
+	if tenant.StorageRegion != nil {
+		region = *tenant.StorageRegion
+	}
+	bucket, ok := r.cfg.BucketForRegion(region)
+	if !ok {
+		// Never fall back to the default bucket for a pinned tenant.
+		return "", fmt.Errorf("%w: no bucket configured for region %s", domain.ErrDataResidencyViolation, region)
+	}
+	return bucket, nil
+}
+
+func (r *storageResidency) Check(ctx context.Context, tenantID uuid.UUID, bucket string) error {
+	want, err := r.Bucket(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if bucket != want {
+		return fmt.Errorf("%w: bucket %s", domain.ErrDataResidencyViolation, bucket)
+	}
+	return nil
+}
+
+func (r *storageResidency) ValidRegion(region string) bool {
+	_, ok := r.cfg.BucketForRegion(region)
+	return ok
+}
+
+// residentBucket returns the tenant's bucket, or defaultBucket when residency isn't wired.
+func residentBucket(ctx context.Context, residency StorageResidency, tenantID uuid.UUID, defaultBucket string) (string, error) {
+	if residency == nil {
+		return defaultBucket, nil
+	}
+	return residency.Bucket(ctx, tenantID)
+}
+
+// checkResidency verifies bucket against the tenant's region; a nil residency allows everything.
+func checkResidency(ctx context.Context, residency StorageResidency, tenantID uuid.UUID, bucket string) error {
+	if residency == nil {
+		return nil
+	}
+	return residency.Check(ctx, tenantID, bucket)
+}
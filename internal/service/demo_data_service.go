@@ -0,0 +1,433 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/validator/invoice"
+)
+
+const (
+	demoCollectionName = "Demo Invoices"
+	demoParserModel    = "demo"
+	demoFileBatchSize  = 100
+)
+
+// DemoDataService seeds and removes sample data used to demo a tenant.
+type DemoDataService interface {
+	// Seed creates a demo collection owned by ownerID holding sample parsed
+	// invoices with validation results and a mix of review states. No parser is
+	// called. It fails with ErrDemoDataExists if the tenant already has demo data.
+	Seed(ctx context.Context, tenantID, ownerID uuid.UUID) (*domain.DemoData, error)
+	// Cleanup deletes every demo collection of the tenant with its documents and files.
+	Cleanup(ctx context.Context, tenantID uuid.UUID) (*domain.DemoCleanup, error)
+}
+
+type demoDataService struct {
+	userRepo           port.UserRepository
+	collectionRepo     port.CollectionRepository
+	permRepo           port.CollectionPermissionRepository
+	collectionFileRepo port.CollectionFileRepository
+	fileSvc            FileService
+	docSvc             DocumentService
+}
+
+// NewDemoDataService creates a new DemoDataService.
+func NewDemoDataService(
+	userRepo port.UserRepository,
+	collectionRepo port.CollectionRepository,
+	permRepo port.CollectionPermissionRepository,
+	collectionFileRepo port.CollectionFileRepository,
+	fileSvc FileService,
+	docSvc DocumentService,
+) DemoDataService {
+	return &demoDataService{
+		userRepo:           userRepo,
+		collectionRepo:     collectionRepo,
+		permRepo:           permRepo,
+		collectionFileRepo: collectionFileRepo,
+		fileSvc:            fileSvc,
+		docSvc:             docSvc,
+	}
+}
+
+func (s *demoDataService) Seed(ctx context.Context, tenantID, ownerID uuid.UUID) (*domain.DemoData, error) {
+	existing, err := s.collectionRepo.ListDemo(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, domain.ErrDemoDataExists
+	}
+
+	owner, err := s.userRepo.GetByID(ctx, tenantID, ownerID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidDemoOwner
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !owner.IsActive {
+		return nil, domain.ErrInvalidDemoOwner
+	}
+
+	collection := &domain.Collection{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		Name:        demoCollectionName,
+		Description: "Sample invoices for exploring SATVOS. Remove them with the demo data cleanup.",
+		IsDemo:      true,
+		CreatedBy:   owner.ID,
+	}
+	if err := s.collectionRepo.Create(ctx, collection); err != nil {
+		return nil, fmt.Errorf("creating demo collection: %w", err)
+	}
+	if err := s.permRepo.Upsert(ctx, &domain.CollectionPermissionEntry{
+		CollectionID: collection.ID,
+		TenantID:     tenantID,
+		UserID:       owner.ID,
+		Permission:   domain.CollectionPermOwner,
+		GrantedBy:    owner.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("assigning owner permission: %w", err)
+	}
+
+	log.Printf("demoDataService.Seed: seeding collection %s for tenant %s", collection.ID, tenantID)
+
+	// A failure part-way leaves a flagged collection behind; Cleanup removes it.
+	result := &domain.DemoData{TenantID: tenantID, CollectionID: collection.ID}
+	for i, sample := range demoInvoices(time.Now().UTC()) {
+		if err := s.seedDocument(ctx, collection, owner, &sample); err != nil {
+			return nil, fmt.Errorf("seeding demo invoice %d: %w", i+1, err)
+		}
+		result.Documents++
+	}
+	return result, nil
+}
+
+func (s *demoDataService) seedDocument(ctx context.Context, collection *domain.Collection, owner *domain.User, sample *demoInvoice) error {
+	file, err := s.fileSvc.Ingest(ctx, FileIngestInput{
+		TenantID:     collection.TenantID,
+		UploadedBy:   owner.ID,
+		FileName:     sample.inv.Invoice.InvoiceNumber + ".pdf",
+		Content:      demoInvoicePDF(&sample.inv),
+		CollectionID: &collection.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("storing file: %w", err)
+	}
+	if err := s.collectionFileRepo.Add(ctx, &domain.CollectionFile{
+		CollectionID: collection.ID,
+		FileID:       file.ID,
+		TenantID:     collection.TenantID,
+		AddedBy:      owner.ID,
+	}); err != nil {
+		return fmt.Errorf("adding file to collection: %w", err)
+	}
+
+	data, err := json.Marshal(sample.inv)
+	if err != nil {
+		return fmt.Errorf("encoding invoice: %w", err)
+	}
+	doc, err := s.docSvc.CreateParsed(ctx, &CreateParsedDocumentInput{
+		TenantID:       collection.TenantID,
+		CollectionID:   collection.ID,
+		FileID:         file.ID,
+		DocumentType:   "invoice",
+		Name:           sample.inv.Invoice.InvoiceNumber,
+		StructuredData: data,
+		ParserModel:    demoParserModel,
+		CreatedBy:      owner.ID,
+		Role:           owner.Role,
+	})
+	if err != nil {
+		return fmt.Errorf("creating document: %w", err)
+	}
+
+	if sample.review == domain.ReviewStatusPending {
+		return nil
+	}
+	_, err = s.docSvc.UpdateReview(ctx, &UpdateReviewInput{
+		TenantID:   collection.TenantID,
+		DocumentID: doc.ID,
+		ReviewerID: owner.ID,
+		Role:       owner.Role,
+		Status:     sample.review,
+		Notes:      sample.notes,
+		ReasonCode: sample.reason,
+	})
+	if err != nil {
+		return fmt.Errorf("reviewing document: %w", err)
+	}
+	return nil
+}
+
+func (s *demoDataService) Cleanup(ctx context.Context, tenantID uuid.UUID) (*domain.DemoCleanup, error) {
+	collections, err := s.collectionRepo.ListDemo(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.DemoCleanup{TenantID: tenantID}
+	for i := range collections {
+		c := &collections[i]
+		files, err := s.collectionFiles(ctx, tenantID, c.ID)
+		if err != nil {
+			return result, err
+		}
+		// Deleting the collection cascades to its documents and file links.
+		if err := s.collectionRepo.Delete(ctx, tenantID, c.ID); err != nil {
+			return result, fmt.Errorf("deleting demo collection %s: %w", c.ID, err)
+		}
+		result.Collections++
+		result.Documents += c.DocumentCount
+
+		for _, fileID := range files {
+			if err := s.fileSvc.Delete(ctx, tenantID, fileID); err != nil {
+				log.Printf("demoDataService.Cleanup: failed to delete file %s: %v", fileID, err)
+				continue
+			}
+			result.Files++
+		}
+	}
+
+	log.Printf("demoDataService.Cleanup: tenant %s: removed %d collections, %d documents, %d files",
+		tenantID, result.Collections, result.Documents, result.Files)
+	return result, nil
+}
+
+func (s *demoDataService) collectionFiles(ctx context.Context, tenantID, collectionID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for offset := 0; ; offset += demoFileBatchSize {
+		files, _, err := s.collectionFileRepo.ListByCollection(ctx, tenantID, collectionID, offset, demoFileBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("listing files of demo collection %s: %w", collectionID, err)
+		}
+		for i := range files {
+			ids = append(ids, files[i].ID)
+		}
+		if len(files) < demoFileBatchSize {
+			return ids, nil
+		}
+	}
+}
+
+// demoInvoice is one sample invoice and the review state it is left in.
+type demoInvoice struct {
+	inv    invoice.GSTInvoice
+	review domain.ReviewStatus
+	reason string // rejection reason code
+	notes  string
+}
+
+var (
+	demoSeller = invoice.Party{
+		Name: "Nimbus Office Supplies Pvt Ltd", Address: "14 Residency Road, Bengaluru",
+		GSTIN: "29AABCN1234F1Z5", PAN: "AABCN1234F", State: "Karnataka", StateCode: "29",
+	}
+	demoInterStateSeller = invoice.Party{
+		Name: "Sahyadri Electricals LLP", Address: "Plot 22, MIDC Bhosari, Pune",
+		GSTIN: "27AAGFS5678K1Z2", PAN: "AAGFS5678K", State: "Maharashtra", StateCode: "27",
+	}
+	demoServiceSeller = invoice.Party{
+		Name: "Brightline Consulting Pvt Ltd", Address: "3rd Floor, Koramangala, Bengaluru",
+		GSTIN: "29AADCB4321M1Z8", PAN: "AADCB4321M", State: "Karnataka", StateCode: "29",
+	}
+	demoBuyer = invoice.Party{
+		Name: "Acme Retail Pvt Ltd", Address: "88 MG Road, Bengaluru",
+		GSTIN: "29AAACA9876Q1Z3", PAN: "AAACA9876Q", State: "Karnataka", StateCode: "29",
+	}
+)
+
+// demoInvoices returns the sample invoices, dated in the weeks before now so
+// they show up on dashboards. Between them they cover intra- and inter-state
+// supplies, goods and services, e-invoiced approvals, a math error that was
+// rejected and a missing buyer GSTIN awaiting review.
+func demoInvoices(now time.Time) []demoInvoice {
+	intra, inter := false, true
+
+	withError := buildDemoInvoice(demoSeller, demoBuyer, "DEMO-INV-1004", now.AddDate(0, 0, -12),
+		demoLine("Ergonomic office chair", "9401", 6, "nos", 7800, 18, intra),
+		demoLine("Steel filing cabinet", "9403", 2, "nos", 11250, 18, intra))
+	withError.Totals.Total += 1000 // the printed total does not add up
+
+	noBuyerGSTIN := buildDemoInvoice(demoSeller, demoBuyer, "DEMO-INV-1005", now.AddDate(0, 0, -6),
+		demoLine("Whiteboard markers (box of 10)", "9608", 20, "box", 350, 18, intra))
+	noBuyerGSTIN.Buyer.GSTIN = ""
+	noBuyerGSTIN.Buyer.PAN = ""
+
+	return []demoInvoice{
+		{
+			inv: withDemoIRN(buildDemoInvoice(demoSeller, demoBuyer, "DEMO-INV-1001", now.AddDate(0, 0, -28),
+				demoLine("A4 copier paper, 75 GSM", "4802", 50, "ream", 280, 12, intra),
+				demoLine("Laser printer toner", "8443", 4, "nos", 3200, 18, intra)), "112410045678901"),
+			review: domain.ReviewStatusApproved,
+			notes:  "Matches purchase order PO-2231.",
+		},
+		{
+			inv: withDemoIRN(buildDemoInvoice(demoInterStateSeller, demoBuyer, "SE/24/0771", now.AddDate(0, 0, -21),
+				demoLine("LED panel light 36W", "9405", 40, "nos", 1150, 18, inter),
+				demoLine("Copper wire 2.5 sq mm (90 m)", "8544", 10, "coil", 2400, 18, inter)), "112410045679342"),
+			review: domain.ReviewStatusApproved,
+		},
+		{
+			inv: buildDemoInvoice(demoServiceSeller, demoBuyer, "BLC-2024-118", now.AddDate(0, 0, -15),
+				demoLine("Process consulting", "998311", 32, "hours", 3500, 18, intra)),
+			review: domain.ReviewStatusPending,
+		},
+		{
+			inv:    withError,
+			review: domain.ReviewStatusRejected,
+			reason: "wrong_amount",
+			notes:  "Invoice total does not match line items; asked the supplier for a corrected invoice.",
+		},
+		{
+			inv:    noBuyerGSTIN,
+			review: domain.ReviewStatusPending,
+		},
+		{
+			inv: buildDemoInvoice(demoInterStateSeller, demoBuyer, "SE/24/0802", now.AddDate(0, 0, -2),
+				demoLine("Electrical installation service", "995461", 1, "job", 18500, 18, inter)),
+			review: domain.ReviewStatusPending,
+		},
+	}
+}
+
+func demoLine(description, hsn string, qty float64, unit string, unitPrice, gstRate float64, interState bool) invoice.LineItem {
+	taxable := roundPaise(qty * unitPrice)
+	item := invoice.LineItem{
+		Description:   description,
+		HSNSACCode:    hsn,
+		Quantity:      qty,
+		Unit:          unit,
+		UnitPrice:     unitPrice,
+		TaxableAmount: taxable,
+	}
+	if interState {
+		item.IGSTRate = gstRate
+		item.IGSTAmount = roundPaise(taxable * gstRate / 100)
+	} else {
+		item.CGSTRate = gstRate / 2
+		item.CGSTAmount = roundPaise(taxable * gstRate / 200)
+		item.SGSTRate = gstRate / 2
+		item.SGSTAmount = item.CGSTAmount
+	}
+	item.Total = roundPaise(taxable + item.CGSTAmount + item.SGSTAmount + item.IGSTAmount)
+	return item
+}
+
+func buildDemoInvoice(seller, buyer invoice.Party, number string, date time.Time, items ...invoice.LineItem) invoice.GSTInvoice {
+	inv := invoice.GSTInvoice{
+		Invoice: invoice.InvoiceHeader{
+			InvoiceNumber: number,
+			InvoiceDate:   date.Format("2006-01-02"),
+			DueDate:       date.AddDate(0, 0, 30).Format("2006-01-02"),
+			InvoiceType:   "tax_invoice",
+			Currency:      "INR",
+			PlaceOfSupply: buyer.State,
+		},
+		Seller:    seller,
+		Buyer:     buyer,
+		LineItems: items,
+		Payment:   invoice.Payment{PaymentTerms: "Net 30"},
+	}
+	for i := range items {
+		inv.Totals.Subtotal += items[i].TaxableAmount
+		inv.Totals.CGST += items[i].CGSTAmount
+		inv.Totals.SGST += items[i].SGSTAmount
+		inv.Totals.IGST += items[i].IGSTAmount
+	}
+	inv.Totals.Subtotal = roundPaise(inv.Totals.Subtotal)
+	inv.Totals.TaxableAmount = inv.Totals.Subtotal
+	inv.Totals.CGST = roundPaise(inv.Totals.CGST)
+	inv.Totals.SGST = roundPaise(inv.Totals.SGST)
+	inv.Totals.IGST = roundPaise(inv.Totals.IGST)
+	inv.Totals.Total = roundPaise(inv.Totals.TaxableAmount + inv.Totals.CGST + inv.Totals.SGST + inv.Totals.IGST)
+	return inv
+}
+
+// withDemoIRN e-invoices the sample: it sets the IRN the validator expects for
+// the seller, number and financial year, and an acknowledgement on the invoice date.
+func withDemoIRN(inv invoice.GSTInvoice, ackNumber string) invoice.GSTInvoice {
+	fy, _ := invoice.DeriveFinancialYear(inv.Invoice.InvoiceDate)
+	inv.Invoice.IRN = invoice.ComputeIRNHash(inv.Seller.GSTIN, inv.Invoice.InvoiceNumber, fy)
+	inv.Invoice.AcknowledgementNumber = ackNumber
+	inv.Invoice.AcknowledgementDate = inv.Invoice.InvoiceDate
+	return inv
+}
+
+func roundPaise(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// demoInvoicePDF renders a one-page text PDF of the invoice so the seeded
+// documents have a file to preview and download.
+func demoInvoicePDF(inv *invoice.GSTInvoice) []byte {
+	lines := []string{
+		"TAX INVOICE",
+		inv.Seller.Name,
+		inv.Seller.Address,
+		"GSTIN: " + inv.Seller.GSTIN,
+		"",
+		"Invoice No: " + inv.Invoice.InvoiceNumber + "    Date: " + inv.Invoice.InvoiceDate,
+		"Bill To: " + inv.Buyer.Name + ", " + inv.Buyer.Address,
+		"Buyer GSTIN: " + inv.Buyer.GSTIN,
+		"",
+	}
+	for i := range inv.LineItems {
+		li := &inv.LineItems[i]
+		lines = append(lines, fmt.Sprintf("%d. %s (HSN/SAC %s)  %.0f %s x %.2f = %.2f",
+			i+1, li.Description, li.HSNSACCode, li.Quantity, li.Unit, li.UnitPrice, li.TaxableAmount))
+	}
+	lines = append(lines, "",
+		fmt.Sprintf("Taxable value: %.2f", inv.Totals.TaxableAmount),
+		fmt.Sprintf("CGST: %.2f  SGST: %.2f  IGST: %.2f", inv.Totals.CGST, inv.Totals.SGST, inv.Totals.IGST),
+		fmt.Sprintf("Invoice total: INR %.2f", inv.Totals.Total),
+		"",
+		"SAMPLE DOCUMENT - generated demo data")
+
+	var content strings.Builder
+	content.WriteString("BT /F1 11 Tf 14 TL 50 790 Td\n")
+	for _, l := range lines {
+		content.WriteString("(" + pdfEscape(l) + ") Tj T*\n")
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+func pdfEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(s)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// DocumentEventKind names something that happened to a document.
+type DocumentEventKind string
+
+const (
+	// DocumentEventCreated is a new document, before any parse.
+	DocumentEventCreated DocumentEventKind = "document.created"
+	// DocumentEventParsed is a document with fresh parser output, validated.
+	DocumentEventParsed DocumentEventKind = "document.parsed"
+	// DocumentEventEdited is a reviewer's structured data edit, validated.
+	DocumentEventEdited DocumentEventKind = "document.edited"
+	// DocumentEventReviewed is a review decision, including a maker approval.
+	DocumentEventReviewed DocumentEventKind = "document.reviewed"
+	// DocumentEventVersioned is a document whose parse, review and validation
+	// were kept as a version and reset for a new parse: its file was replaced or
+	// it was reclassified.
+	DocumentEventVersioned DocumentEventKind = "document.versioned"
+	// DocumentEventParseFailed is a parse that ran out of attempts.
+	DocumentEventParseFailed DocumentEventKind = "document.parse_failed"
+	// DocumentEventParseStatusChanged is a parsing status change with no parser
+	// output: a parse starting, queued for retry or reset by a user retry.
+	DocumentEventParseStatusChanged DocumentEventKind = "document.parse_status_changed"
+)
+
+// DocumentEvent is published after the change is saved. Document is the saved
+// state; ActorID is nil for system work such as background parsing.
+type DocumentEvent struct {
+	Kind     DocumentEventKind
+	Document *domain.Document
+	ActorID  *uuid.UUID
+}
+
+// DocumentEventListener reacts to a document event. It must not fail the change
+// that raised the event, so it logs its own errors.
+type DocumentEventListener func(ctx context.Context, e *DocumentEvent)
+
+type documentEventSubscription struct {
+	name     string
+	listener DocumentEventListener
+}
+
+// DocumentEvents dispatches document events to the listeners subscribed to
+// them, synchronously and in subscription order, so a listener sees the work of
+// the ones before it. Listeners cover everything that follows the document
+// service's parse, edit and review changes: auto-tags, the summary, stats
+// buckets, channel notifications and REST hooks. Writes made outside the
+// document service (queue claims, bulk deletes, validation runs, escalation
+// reassignments) still reach stats and channels through repository decorators.
+type DocumentEvents struct {
+	mu        sync.RWMutex
+	listeners map[DocumentEventKind][]documentEventSubscription
+}
+
+// NewDocumentEvents creates a dispatcher with no listeners.
+func NewDocumentEvents() *DocumentEvents {
+	return &DocumentEvents{listeners: make(map[DocumentEventKind][]documentEventSubscription)}
+}
+
+// Subscribe registers listener for kinds; name identifies it in logs.
+func (d *DocumentEvents) Subscribe(name string, listener DocumentEventListener, kinds ...DocumentEventKind) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, kind := range kinds {
+		d.listeners[kind] = append(d.listeners[kind], documentEventSubscription{name: name, listener: listener})
+	}
+}
+
+// Publish runs every listener of e.Kind. A listener that panics is logged and
+// the rest still run.
+func (d *DocumentEvents) Publish(ctx context.Context, e *DocumentEvent) {
+	d.mu.RLock()
+	subs := d.listeners[e.Kind]
+	d.mu.RUnlock()
+	for _, sub := range subs {
+		d.run(ctx, sub, e)
+	}
+}
+
+func (d *DocumentEvents) run(ctx context.Context, sub documentEventSubscription, e *DocumentEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("DocumentEvents: listener %s panicked on %s for document %s: %v", sub.name, e.Kind, e.Document.ID, r)
+		}
+	}()
+	sub.listener(ctx, e)
+}
+
+// subscribeDefaultListeners registers the document service's own read-model
+// updates.
+func (s *documentService) subscribeDefaultListeners() {
+	s.events.Subscribe("naming_template", func(ctx context.Context, e *DocumentEvent) {
+		s.applyNamingTemplate(ctx, e.Document)
+	}, DocumentEventParsed)
+	if s.tagRepo != nil {
+		s.events.Subscribe("auto_tags", func(ctx context.Context, e *DocumentEvent) {
+			s.extractAndSaveAutoTags(ctx, e.Document.ID, e.Document.TenantID, e.Document.StructuredData)
+		}, DocumentEventParsed, DocumentEventEdited)
+		s.events.Subscribe("auto_tags_reset", func(ctx context.Context, e *DocumentEvent) {
+			if err := s.tagRepo.DeleteByDocumentAndSource(ctx, e.Document.ID, "auto"); err != nil {
+				log.Printf("documentService: failed to delete auto-tags for %s: %v", e.Document.ID, err)
+			}
+		}, DocumentEventVersioned)
+	}
+	s.events.Subscribe("summary", func(ctx context.Context, e *DocumentEvent) {
+		s.upsertSummary(ctx, e.Document)
+	}, DocumentEventParsed, DocumentEventEdited)
+	s.events.Subscribe("summary_statuses", func(ctx context.Context, e *DocumentEvent) {
+		s.updateSummaryStatuses(ctx, e.Document)
+	}, DocumentEventReviewed, DocumentEventVersioned)
+}
+
+func (s *documentService) Events() *DocumentEvents {
+	return s.events
+}
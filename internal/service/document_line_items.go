@@ -0,0 +1,263 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+// maxLineItemOperations caps the operations in one line item patch.
+const maxLineItemOperations = 500
+
+// PatchLineItemsInput is the DTO for row-level line item edits.
+type PatchLineItemsInput struct {
+	TenantID   uuid.UUID
+	DocumentID uuid.UUID
+	UserID     uuid.UUID
+	Role       domain.UserRole
+	// IfUpdatedAt, when set, must match the document's updated_at, so an edit
+	// based on a stale read fails with ErrDocumentModified instead of landing.
+	IfUpdatedAt *time.Time
+	Operations  []LineItemOperation
+}
+
+// LineItemOperation adds, updates or deletes one line item. Index refers to the
+// line items as stored before the patch, so operations don't shift each other;
+// added items are appended in order. Fields uses the line item's JSON keys.
+type LineItemOperation struct {
+	Op     domain.LineItemOp          `json:"op"`
+	Index  *int                       `json:"index,omitempty"`
+	Fields map[string]json.RawMessage `json:"fields,omitempty"`
+}
+
+// patchedLineItem is a line item's JSON object with the keys the patch set on it.
+// given is nil for items the patch didn't touch.
+type patchedLineItem struct {
+	fields  map[string]json.RawMessage
+	given   map[string]bool
+	deleted bool
+}
+
+// PatchLineItems applies row-level line item changes to a parsed document,
+// derives the amounts they leave out, recomputes the invoice totals and saves
+// the result like EditStructuredData, so overrides, audit, review reset and
+// validation behave the same.
+func (s *documentService) PatchLineItems(ctx context.Context, input *PatchLineItemsInput) (*domain.Document, error) {
+	if len(input.Operations) == 0 || len(input.Operations) > maxLineItemOperations {
+		return nil, fmt.Errorf("%w: between 1 and %d operations are allowed", domain.ErrInvalidLineItemPatch, maxLineItemOperations)
+	}
+
+	doc, err := s.docRepo.GetByID(ctx, input.TenantID, input.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, input.UserID, input.Role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+	if doc.ParsingStatus != domain.ParsingStatusCompleted {
+		return nil, domain.ErrDocumentNotParsed
+	}
+	// Timestamps are stored to the microsecond
+	if input.IfUpdatedAt != nil && !doc.UpdatedAt.Truncate(time.Microsecond).Equal(input.IfUpdatedAt.Truncate(time.Microsecond)) {
+		return nil, domain.ErrDocumentModified
+	}
+
+	data, err := applyLineItemPatch(doc.StructuredData, input.Operations)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.EditStructuredData(ctx, &EditStructuredDataInput{
+		TenantID:       input.TenantID,
+		DocumentID:     input.DocumentID,
+		UserID:         input.UserID,
+		Role:           input.Role,
+		StructuredData: data,
+		// The patch was applied to this read of the document
+		IfUpdatedAt: &doc.UpdatedAt,
+	})
+}
+
+// applyLineItemPatch applies ops to the structured data's line items and
+// recomputes its totals. Keys it doesn't know about are kept.
+func applyLineItemPatch(structuredData json.RawMessage, ops []LineItemOperation) (json.RawMessage, error) {
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(structuredData, &data); err != nil || data == nil {
+		return nil, domain.ErrInvalidStructuredData
+	}
+	var stored []map[string]json.RawMessage
+	if raw, ok := data["line_items"]; ok {
+		if err := json.Unmarshal(raw, &stored); err != nil {
+			return nil, domain.ErrInvalidStructuredData
+		}
+	}
+
+	items := make([]*patchedLineItem, len(stored))
+	for i, fields := range stored {
+		if fields == nil {
+			fields = map[string]json.RawMessage{}
+		}
+		items[i] = &patchedLineItem{fields: fields}
+	}
+	var added []*patchedLineItem
+
+	for n, op := range ops {
+		if err := checkLineItemFields(op.Fields); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %v", domain.ErrInvalidLineItemPatch, n, err)
+		}
+		switch op.Op {
+		case domain.LineItemOpAdd:
+			if op.Index != nil {
+				return nil, fmt.Errorf("%w: operation %d: add takes no index", domain.ErrInvalidLineItemPatch, n)
+			}
+			item := &patchedLineItem{fields: map[string]json.RawMessage{}, given: map[string]bool{}}
+			item.set(op.Fields)
+			added = append(added, item)
+		case domain.LineItemOpUpdate, domain.LineItemOpDelete:
+			if op.Index == nil || *op.Index < 0 || *op.Index >= len(items) {
+				return nil, fmt.Errorf("%w: operation %d: index must name one of the %d stored line items", domain.ErrInvalidLineItemPatch, n, len(items))
+			}
+			item := items[*op.Index]
+			if item.deleted {
+				return nil, fmt.Errorf("%w: operation %d: line item %d is already deleted", domain.ErrInvalidLineItemPatch, n, *op.Index)
+			}
+			if op.Op == domain.LineItemOpDelete {
+				if len(op.Fields) > 0 {
+					return nil, fmt.Errorf("%w: operation %d: delete takes no fields", domain.ErrInvalidLineItemPatch, n)
+				}
+				item.deleted = true
+				continue
+			}
+			if len(op.Fields) == 0 {
+				return nil, fmt.Errorf("%w: operation %d: update needs fields", domain.ErrInvalidLineItemPatch, n)
+			}
+			if item.given == nil {
+				item.given = map[string]bool{}
+			}
+			item.set(op.Fields)
+		default:
+			return nil, fmt.Errorf("%w: operation %d: op must be add, update or delete", domain.ErrInvalidLineItemPatch, n)
+		}
+	}
+
+	lineItems := make([]map[string]json.RawMessage, 0, len(items)+len(added))
+	for _, item := range append(items, added...) {
+		if item.deleted {
+			continue
+		}
+		if item.given != nil {
+			if err := item.deriveAmounts(); err != nil {
+				return nil, err
+			}
+		}
+		lineItems = append(lineItems, item.fields)
+	}
+	raw, err := json.Marshal(lineItems)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling line items: %w", err)
+	}
+	data["line_items"] = raw
+
+	if err := recomputeStoredTotals(data); err != nil {
+		return nil, err
+	}
+	out, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling structured data: %w", err)
+	}
+	return out, nil
+}
+
+// checkLineItemFields rejects keys that aren't line item fields and values of the wrong type.
+func checkLineItemFields(fields map[string]json.RawMessage) error {
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var item invoice.LineItem
+	return dec.Decode(&item)
+}
+
+func (p *patchedLineItem) set(fields map[string]json.RawMessage) {
+	for k, v := range fields {
+		p.fields[k] = v
+		p.given[k] = true
+	}
+}
+
+// deriveAmounts recomputes the amounts of a changed line item that the patch
+// didn't set: the taxable amount when quantity, unit price or discount changed,
+// then the tax amounts and the line total.
+func (p *patchedLineItem) deriveAmounts() error {
+	raw, err := json.Marshal(p.fields)
+	if err != nil {
+		return fmt.Errorf("marshaling line item: %w", err)
+	}
+	var li invoice.LineItem
+	if err := json.Unmarshal(raw, &li); err != nil {
+		return domain.ErrInvalidStructuredData
+	}
+
+	// derive sets key to v unless the patch set it
+	derive := func(key string, field *float64, v float64) {
+		if p.given[key] {
+			return
+		}
+		*field = v
+		p.fields[key] = json.RawMessage(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	if p.given["quantity"] || p.given["unit_price"] || p.given["discount"] {
+		derive("taxable_amount", &li.TaxableAmount, roundPaise(li.Quantity*li.UnitPrice-li.Discount))
+	}
+	derive("cgst_amount", &li.CGSTAmount, roundPaise(li.TaxableAmount*li.CGSTRate/100))
+	derive("sgst_amount", &li.SGSTAmount, roundPaise(li.TaxableAmount*li.SGSTRate/100))
+	derive("igst_amount", &li.IGSTAmount, roundPaise(li.TaxableAmount*li.IGSTRate/100))
+	derive("total", &li.Total, roundPaise(li.TaxableAmount+li.CGSTAmount+li.SGSTAmount+li.IGSTAmount))
+	return nil
+}
+
+// recomputeStoredTotals replaces the totals in data with invoice.RecomputeTotals,
+// keeping any keys of the stored totals it doesn't know about.
+func recomputeStoredTotals(data map[string]json.RawMessage) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshaling structured data: %w", err)
+	}
+	var inv invoice.GSTInvoice
+	if err := json.Unmarshal(raw, &inv); err != nil {
+		return domain.ErrInvalidStructuredData
+	}
+	recomputed := invoice.RecomputeTotals(&inv)
+
+	totals := map[string]json.RawMessage{}
+	if stored, ok := data["totals"]; ok {
+		if err := json.Unmarshal(stored, &totals); err != nil || totals == nil {
+			totals = map[string]json.RawMessage{}
+		}
+	}
+	raw, err = json.Marshal(recomputed)
+	if err != nil {
+		return fmt.Errorf("marshaling totals: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return fmt.Errorf("unmarshaling totals: %w", err)
+	}
+	for k, v := range fields {
+		totals[k] = v
+	}
+	if data["totals"], err = json.Marshal(totals); err != nil {
+		return fmt.Errorf("marshaling totals: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	// DefaultDocumentLockTTL is how long a review lock lasts without a heartbeat.
+	DefaultDocumentLockTTL = 2 * time.Minute
+	minDocumentLockTTL     = 15 * time.Second
+	maxDocumentLockTTL     = 10 * time.Minute
+)
+
+// DocumentLockService manages the soft locks reviewers hold on documents they
+// have open, so others see who is reviewing and can't edit concurrently.
+type DocumentLockService interface {
+	// Acquire takes or renews the caller's lock for ttl (0 = the default). If
+	// another user holds an unexpired lock it returns their lock with
+	// ErrDocumentLocked.
+	Acquire(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole, ttl time.Duration) (*domain.DocumentLock, error)
+	// Get returns the document's active lock, or nil if it is free.
+	Get(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole) (*domain.DocumentLock, error)
+	// Release drops the lock. Only the holder may release it, except managers,
+	// admins and collection owners, who may break another user's lock.
+	Release(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole) error
+}
+
+type documentLockService struct {
+	repo          port.DocumentLockRepository
+	docRepo       port.DocumentRepository
+	collectionSvc CollectionService
+	userRepo      port.UserRepository
+}
+
+// NewDocumentLockService creates a new DocumentLockService.
+func NewDocumentLockService(
+	repo port.DocumentLockRepository,
+	docRepo port.DocumentRepository,
+	collectionSvc CollectionService,
+	userRepo port.UserRepository,
+) DocumentLockService {
+	return &documentLockService{
+		repo:          repo,
+		docRepo:       docRepo,
+		collectionSvc: collectionSvc,
+		userRepo:      userRepo,
+	}
+}
+
+func (s *documentLockService) Acquire(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole, ttl time.Duration) (*domain.DocumentLock, error) {
+	if ttl == 0 {
+		ttl = DefaultDocumentLockTTL
+	}
+	if ttl < minDocumentLockTTL || ttl > maxDocumentLockTTL {
+		return nil, fmt.Errorf("%w: ttl_seconds must be between %d and %d", domain.ErrInvalidLockTTL,
+			int(minDocumentLockTTL.Seconds()), int(maxDocumentLockTTL.Seconds()))
+	}
+	if _, err := s.authorize(ctx, tenantID, documentID, userID, role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	lock, err := s.repo.Acquire(ctx, &domain.DocumentLock{
+		DocumentID: documentID,
+		TenantID:   tenantID,
+		UserID:     userID,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.fillHolderName(ctx, lock)
+	if lock.UserID != userID {
+		return lock, domain.ErrDocumentLocked
+	}
+	return lock, nil
+}
+
+func (s *documentLockService) Get(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole) (*domain.DocumentLock, error) {
+	if _, err := s.authorize(ctx, tenantID, documentID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+	lock, err := s.repo.GetActive(ctx, tenantID, documentID)
+	if err != nil || lock == nil {
+		return nil, err
+	}
+	s.fillHolderName(ctx, lock)
+	return lock, nil
+}
+
+func (s *documentLockService) Release(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole) error {
+	perm, err := s.authorize(ctx, tenantID, documentID, userID, role, domain.CollectionPermEditor)
+	if err != nil {
+		return err
+	}
+	lock, err := s.repo.GetActive(ctx, tenantID, documentID)
+	if err != nil || lock == nil {
+		return err
+	}
+
+	var holder *uuid.UUID
+	if lock.UserID != userID {
+		if role != domain.RoleAdmin && role != domain.RoleManager && perm != domain.CollectionPermOwner {
+			return domain.ErrDocumentLocked
+		}
+		log.Printf("documentLockService.Release: user %s broke the lock of %s on document %s",
+			userID, lock.UserID, documentID)
+	} else {
+		holder = &userID
+	}
+	return s.repo.Release(ctx, tenantID, documentID, holder)
+}
+
+// authorize loads the document and checks the caller's permission on its
+// collection, returning that permission.
+func (s *documentLockService) authorize(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole, minLevel domain.CollectionPermission) (domain.CollectionPermission, error) {
+	doc, err := s.docRepo.GetByID(ctx, tenantID, documentID)
+	if err != nil {
+		return "", err
+	}
+	perm := s.collectionSvc.EffectivePermission(ctx, doc.CollectionID, userID, role)
+	if domain.CollectionPermLevel(perm) < domain.CollectionPermLevel(minLevel) {
+		return "", domain.ErrCollectionPermDenied
+	}
+	return perm, nil
+}
+
+func (s *documentLockService) fillHolderName(ctx context.Context, lock *domain.DocumentLock) {
+	user, err := s.userRepo.GetByID(ctx, lock.TenantID, lock.UserID)
+	if err != nil {
+		log.Printf("documentLockService: loading lock holder %s: %v", lock.UserID, err)
+		return
+	}
+	lock.HolderName = user.FullName
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+// maxNamingTemplateLen bounds a template so names stay within documents.name.
+const maxNamingTemplateLen = 200
+
+// maxDocumentNameLen is the size of documents.name.
+const maxDocumentNameLen = 255
+
+// NamingTemplateFields are the placeholders a naming template may use.
+var NamingTemplateFields = []string{
+	"seller_name", "seller_gstin", "buyer_name", "buyer_gstin",
+	"invoice_number", "invoice_date", "due_date", "invoice_type",
+	"place_of_supply", "currency", "total_amount", "document_type",
+}
+
+var namingPlaceholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// validateNamingTemplate checks that template uses at least one placeholder,
+// only known ones, and no stray braces.
+func validateNamingTemplate(template string) error {
+	if len(template) > maxNamingTemplateLen {
+		return fmt.Errorf("%w: longer than %d characters", domain.ErrInvalidNamingTemplate, maxNamingTemplateLen)
+	}
+	matches := namingPlaceholder.FindAllStringSubmatch(template, -1)
+	if len(matches) == 0 {
+		return fmt.Errorf("%w: no {field} placeholder", domain.ErrInvalidNamingTemplate)
+	}
+	for _, m := range matches {
+		if !slices.Contains(NamingTemplateFields, m[1]) {
+			return fmt.Errorf("%w: unknown placeholder {%s}", domain.ErrInvalidNamingTemplate, m[1])
+		}
+	}
+	if strings.ContainsAny(namingPlaceholder.ReplaceAllString(template, ""), "{}") {
+		return fmt.Errorf("%w: unbalanced braces", domain.ErrInvalidNamingTemplate)
+	}
+	return nil
+}
+
+// renderDocumentName fills template from the document's structured data. It
+// reports false when a placeholder has no value, so the document keeps its name
+// rather than getting a partial one.
+func renderDocumentName(template string, doc *domain.Document) (string, bool) {
+	var inv invoice.GSTInvoice
+	if len(doc.StructuredData) > 0 {
+		if err := json.Unmarshal(doc.StructuredData, &inv); err != nil {
+			return "", false
+		}
+	}
+	values := map[string]string{
+		"seller_name":     inv.Seller.Name,
+		"seller_gstin":    inv.Seller.GSTIN,
+		"buyer_name":      inv.Buyer.Name,
+		"buyer_gstin":     inv.Buyer.GSTIN,
+		"invoice_number":  inv.Invoice.InvoiceNumber,
+		"invoice_date":    datePart(inv.Invoice.InvoiceDate),
+		"due_date":        datePart(inv.Invoice.DueDate),
+		"invoice_type":    inv.Invoice.InvoiceType,
+		"place_of_supply": inv.Invoice.PlaceOfSupply,
+		"currency":        inv.Invoice.Currency,
+		"document_type":   doc.DocumentType,
+	}
+	if inv.Totals.Total != 0 {
+		values["total_amount"] = fmt.Sprintf("%.2f", inv.Totals.Total)
+	}
+
+	complete := true
+	name := namingPlaceholder.ReplaceAllStringFunc(template, func(m string) string {
+		v := sanitizeNamePart(values[m[1:len(m)-1]])
+		if v == "" {
+			complete = false
+		}
+		return v
+	})
+	name = strings.Join(strings.Fields(sanitizeNamePart(name)), " ")
+	if !complete || name == "" {
+		return "", false
+	}
+
+	return name, true
+}
+
+// fileExt returns the extension of an uploaded file name such as ".pdf", or ""
+// if the name has none.
+func fileExt(name string) string {
+	ext := path.Ext(name)
+	if len(ext) < 2 || len(ext) > 10 || strings.ContainsAny(ext, " ") {
+		return ""
+	}
+	return ext
+}
+
+// datePart keeps the YYYY-MM-DD of a date with a time of day.
+func datePart(s string) string {
+	if len(s) > 10 && s[4] == '-' && s[7] == '-' {
+		return s[:10]
+	}
+	return s
+}
+
+// sanitizeNamePart replaces characters that are unsafe in file names, so names
+// work as download and export file names.
+func sanitizeNamePart(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case strings.ContainsRune(`/\:*?"<>|`, r):
+			return '-'
+		case unicode.IsControl(r):
+			return ' '
+		}
+		return r
+	}, strings.TrimSpace(s))
+}
+
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// fitNameBase shortens base so base, a conflict suffix such as " (12)" and ext
+// fit documents.name.
+func fitNameBase(base, ext string) string {
+	if limit := maxDocumentNameLen - len(ext) - 6; len(base) > limit {
+		return strings.TrimSpace(truncateUTF8(base, limit))
+	}
+	return base
+}
+
+// uniqueDocumentName returns base+ext, or base with " (2)", " (3)", ... before
+// ext, whichever is not in taken.
+func uniqueDocumentName(base, ext string, taken []string) string {
+	if name := base + ext; !slices.Contains(taken, name) {
+		return name
+	}
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s (%d)%s", base, n, ext)
+		if !slices.Contains(taken, candidate) {
+			return candidate
+		}
+	}
+}
+
+// applyNamingTemplate renames a freshly parsed document with its collection's
+// naming template. Only a document that was never renamed is: a re-parse keeps
+// the name, and OriginalName stays the uploaded name.
+func (s *documentService) applyNamingTemplate(ctx context.Context, doc *domain.Document) {
+	if s.collectionRepo == nil || doc.OriginalName != nil {
+		return
+	}
+	collection, err := s.collectionRepo.GetByID(ctx, doc.TenantID, doc.CollectionID)
+	if err != nil {
+		log.Printf("documentService.applyNamingTemplate: loading collection %s failed: %v", doc.CollectionID, err)
+		return
+	}
+	if collection.NamingTemplate == nil {
+		return
+	}
+	base, ok := renderDocumentName(*collection.NamingTemplate, doc)
+	if !ok {
+		log.Printf("documentService.applyNamingTemplate: document %s is missing a field of %q, keeping its name",
+			doc.ID, *collection.NamingTemplate)
+		return
+	}
+
+	ext := fileExt(doc.Name)
+	base = fitNameBase(base, ext)
+	taken, err := s.docRepo.ListNamesWithPrefix(ctx, doc.TenantID, doc.CollectionID, base, doc.ID)
+	if err != nil {
+		log.Printf("documentService.applyNamingTemplate: listing names for document %s failed: %v", doc.ID, err)
+		return
+	}
+	name := uniqueDocumentName(base, ext, taken)
+	if name == doc.Name {
+		return
+	}
+
+	original := doc.Name
+	doc.Name, doc.OriginalName = name, &original
+	if err := s.docRepo.UpdateName(ctx, doc); err != nil {
+		log.Printf("documentService.applyNamingTemplate: renaming document %s failed: %v", doc.ID, err)
+		doc.Name, doc.OriginalName = original, nil
+		return
+	}
+	log.Printf("documentService.applyNamingTemplate: document %s renamed from %q to %q", doc.ID, original, name)
+}
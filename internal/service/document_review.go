@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+// Review stages recorded in the document.review audit entry. Single-stage
+// reviews record no stage.
+const (
+	reviewStageMaker   = "maker"
+	reviewStageChecker = "checker"
+)
+
+// reviewCollection loads the document's collection for its review settings.
+// It returns nil when the service was built without a collection repository.
+func (s *documentService) reviewCollection(ctx context.Context, doc *domain.Document) (*domain.Collection, error) {
+	if s.collectionRepo == nil {
+		return nil, nil
+	}
+	collection, err := s.collectionRepo.GetByID(ctx, doc.TenantID, doc.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading review settings: %w", err)
+	}
+	return collection, nil
+}
+
+// reviewStage decides which approval stage a review decision belongs to. Any
+// decision on a document awaiting a checker is the checker's and requires
+// checker authority. An approval of a document whose invoice total meets the
+// collection's checker threshold is a maker approval. Everything else is final.
+func (s *documentService) reviewStage(ctx context.Context, doc *domain.Document, collection *domain.Collection, input *UpdateReviewInput) (string, error) {
+	if doc.ReviewStatus == domain.ReviewStatusAwaitingChecker {
+		if err := s.requireChecker(ctx, doc, input); err != nil {
+			return "", err
+		}
+		return reviewStageChecker, nil
+	}
+	if input.Status == domain.ReviewStatusApproved && needsChecker(collection, doc) {
+		return reviewStageMaker, nil
+	}
+	return "", nil
+}
+
+// rejectionReason returns the reason code recorded with a review decision. A
+// rejection must name an active reason of the tenant's taxonomy; approvals carry
+// none. Without a reason repository the code is recorded unchecked.
+func (s *documentService) rejectionReason(ctx context.Context, input *UpdateReviewInput) (string, error) {
+	if input.Status != domain.ReviewStatusRejected {
+		return "", nil
+	}
+	if s.reasonRepo == nil {
+		return strings.TrimSpace(input.ReasonCode), nil
+	}
+	return resolveRejectionReason(ctx, s.reasonRepo, input.TenantID, input.ReasonCode)
+}
+
+// requireChecker allows managers, admins and collection owners to act as checker,
+// as long as they did not make the approval being confirmed.
+func (s *documentService) requireChecker(ctx context.Context, doc *domain.Document, input *UpdateReviewInput) error {
+	if doc.MakerApprovedBy != nil && *doc.MakerApprovedBy == input.ReviewerID {
+		return domain.ErrCheckerSameAsMaker
+	}
+	if input.Role == domain.RoleAdmin || input.Role == domain.RoleManager {
+		return nil
+	}
+	err := s.requireCollectionPerm(ctx, doc.CollectionID, input.ReviewerID, input.Role, domain.CollectionPermOwner)
+	if errors.Is(err, domain.ErrCollectionPermDenied) {
+		return domain.ErrCheckerNotAllowed
+	}
+	return err
+}
+
+// needsChecker reports whether approving doc requires checker confirmation. An
+// invoice whose total can't be read is treated as above the threshold.
+func needsChecker(collection *domain.Collection, doc *domain.Document) bool {
+	if collection == nil || collection.CheckerThreshold == nil {
+		return false
+	}
+	var inv invoice.GSTInvoice
+	if err := json.Unmarshal(doc.StructuredData, &inv); err != nil {
+		return true
+	}
+	return inv.Totals.Total >= *collection.CheckerThreshold
+}
+
+// checklistItemsOf returns the collection's review checklist, or none for a nil collection.
+func checklistItemsOf(collection *domain.Collection) []domain.ReviewChecklistItem {
+	if collection == nil {
+		return nil
+	}
+	return decodeChecklistItems(collection.ReviewChecklist)
+}
+
+// activeDelegation returns userID's delegation if it is active today, or nil.
+// Lookup failures are logged and treated as no delegation.
+func (s *documentService) activeDelegation(ctx context.Context, tenantID, userID uuid.UUID) *domain.ReviewDelegation {
+	if s.delegationRepo == nil {
+		return nil
+	}
+	d, err := s.delegationRepo.GetByUser(ctx, tenantID, userID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrDelegationNotFound) {
+			log.Printf("documentService.activeDelegation: lookup for %s failed: %v", userID, err)
+		}
+		return nil
+	}
+	if !d.ActiveOn(time.Now()) {
+		return nil
+	}
+	return d
+}
+
+// queueAssignees returns the users whose review queue userID sees: themselves,
+// plus anyone with an active delegation to them that shares the queue.
+func (s *documentService) queueAssignees(ctx context.Context, tenantID, userID uuid.UUID) ([]uuid.UUID, error) {
+	assignees := []uuid.UUID{userID}
+	if s.delegationRepo == nil {
+		return assignees, nil
+	}
+	ds, err := s.delegationRepo.ListActiveForDelegate(ctx, tenantID, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for i := range ds {
+		if ds[i].ShareQueue {
+			assignees = append(assignees, ds[i].UserID)
+		}
+	}
+	return assignees, nil
+}
+
+// reviewingOnBehalfOf returns the assignee a reviewer is standing in for: the
+// document's assignee, when the reviewer is that assignee's active delegate.
+func (s *documentService) reviewingOnBehalfOf(ctx context.Context, doc *domain.Document, reviewerID uuid.UUID) *uuid.UUID {
+	if doc.AssignedTo == nil || *doc.AssignedTo == reviewerID {
+		return nil
+	}
+	if d := s.activeDelegation(ctx, doc.TenantID, *doc.AssignedTo); d != nil && d.DelegateID == reviewerID {
+		return doc.AssignedTo
+	}
+	return nil
+}
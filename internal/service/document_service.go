@@ -6,11 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 
 	"satvos/internal/domain"
+	"satvos/internal/jsonpatch"
 	"satvos/internal/parser"
 	"satvos/internal/port"
 	"satvos/internal/validator"
@@ -19,6 +22,18 @@ import (
 
 const defaultMaxParseAttempts = 5
 
+// defaultParseJobTimeout bounds one whole parse of a document when no job timeout
+// is configured.
+const defaultParseJobTimeout = 30 * time.Minute
+
+// Transient failures (network errors, timeouts, 5xx) are retried through the queue
+// with exponential backoff: transientRetryBase after the first attempt, doubling per
+// attempt up to transientRetryMax.
+const (
+	transientRetryBase = 30 * time.Second
+	transientRetryMax  = 15 * time.Minute
+)
+
 // CreateDocumentInput is the DTO for creating a document and triggering parsing.
 type CreateDocumentInput struct {
 	TenantID     uuid.UUID
@@ -32,6 +47,20 @@ type CreateDocumentInput struct {
 	Role         domain.UserRole
 }
 
+// CreateParsedDocumentInput is the DTO for creating a document whose structured
+// data is already known, e.g. seeded demo invoices. No parser is called.
+type CreateParsedDocumentInput struct {
+	TenantID       uuid.UUID
+	CollectionID   uuid.UUID
+	FileID         uuid.UUID
+	DocumentType   string
+	Name           string
+	StructuredData json.RawMessage
+	ParserModel    string // recorded as the document's parser_model
+	CreatedBy      uuid.UUID
+	Role           domain.UserRole
+}
+
 // EditStructuredDataInput is the DTO for manually editing a document's structured data.
 type EditStructuredDataInput struct {
 	TenantID       uuid.UUID
@@ -39,6 +68,13 @@ type EditStructuredDataInput struct {
 	UserID         uuid.UUID
 	Role           domain.UserRole
 	StructuredData json.RawMessage
+	// Patch, when set, is applied to the stored structured data instead of
+	// replacing it with StructuredData, and only saved if the data is still
+	// the version it was applied to.
+	Patch []jsonpatch.Operation
+	// IfUpdatedAt, when set, must match the document's updated_at when the edit
+	// is saved, or the edit fails with ErrDocumentModified.
+	IfUpdatedAt *time.Time
 }
 
 // AssignDocumentInput is the DTO for assigning a document to a reviewer.
@@ -50,6 +86,28 @@ type AssignDocumentInput struct {
 	AssigneeID *uuid.UUID // nil = unassign
 }
 
+// NeighborsInput is the DTO for finding the documents around one document in a list.
+type NeighborsInput struct {
+	TenantID   uuid.UUID
+	DocumentID uuid.UUID
+	UserID     uuid.UUID
+	Role       domain.UserRole
+	Context    domain.NeighborContext
+	AssignedTo *uuid.UUID // collection context only
+}
+
+// TagSearchInput is the DTO for searching documents by tag. Exactly one of
+// Value, Prefix or a Min/Max range is given. Range bounds are numbers or
+// YYYY-MM-DD dates; Type picks which, and is inferred from the bounds if empty.
+type TagSearchInput struct {
+	Key    string
+	Value  string
+	Prefix string
+	Min    string
+	Max    string
+	Type   domain.TagValueType
+}
+
 // UpdateReviewInput is the DTO for updating a document's review status.
 type UpdateReviewInput struct {
 	TenantID   uuid.UUID
@@ -58,98 +116,193 @@ type UpdateReviewInput struct {
 	Role       domain.UserRole
 	Status     domain.ReviewStatus
 	Notes      string
+	Checklist  map[string]bool // answers keyed by checklist item ID
+	ReasonCode string          // rejection reason code; required when rejecting
 }
 
 // DocumentService defines the document management contract.
 type DocumentService interface {
 	CreateAndParse(ctx context.Context, input *CreateDocumentInput) (*domain.Document, error)
+	// CreateParsed stores a completed document from input.StructuredData, then
+	// tags, summarizes and validates it as if a parse had just finished. It does
+	// not count against the creator's quota.
+	CreateParsed(ctx context.Context, input *CreateParsedDocumentInput) (*domain.Document, error)
 	GetByID(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*domain.Document, error)
 	GetByFileID(ctx context.Context, tenantID, fileID, userID uuid.UUID, role domain.UserRole) (*domain.Document, error)
 	ListByCollection(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, assignedTo *uuid.UUID, offset, limit int) ([]domain.Document, int, error)
+	ListValidationFailures(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ValidationFailure, error)
 	ListByTenant(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, assignedTo *uuid.UUID, offset, limit int) ([]domain.Document, int, error)
 	AssignDocument(ctx context.Context, input *AssignDocumentInput) (*domain.Document, error)
 	ListReviewQueue(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.Document, int, error)
+	ListCheckerQueue(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Document, int, error)
+	GetNeighbors(ctx context.Context, input *NeighborsInput) (*domain.DocumentNeighbors, error)
 	UpdateReview(ctx context.Context, input *UpdateReviewInput) (*domain.Document, error)
 	EditStructuredData(ctx context.Context, input *EditStructuredDataInput) (*domain.Document, error)
 	RetryParse(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*domain.Document, error)
+	// ReplaceFile swaps in a new file, keeps the old one as a version and re-parses.
+	ReplaceFile(ctx context.Context, input *ReplaceFileInput) (*domain.Document, error)
+	// Reclassify changes the document type, keeps the old parse as a version and re-parses.
+	Reclassify(ctx context.Context, input *ReclassifyInput) (*domain.Document, error)
+	ListVersions(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentVersion, error)
+	// ListApprovals returns what the document looked like at each final approval.
+	ListApprovals(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentApproval, error)
 	ValidateDocument(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) error
 	GetValidation(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*validator.ValidationResponse, error)
+	// RecomputeTotals recalculates the invoice totals from the line items and
+	// returns them with the deltas versus the stored totals, without saving.
+	RecomputeTotals(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*TotalsRecomputation, error)
+	// PatchLineItems adds, updates or deletes individual line items and saves the
+	// structured data with recomputed totals.
+	PatchLineItems(ctx context.Context, input *PatchLineItemsInput) (*domain.Document, error)
 	Delete(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) error
 	ListTags(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentTag, error)
-	AddTags(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, tags map[string]string) ([]domain.DocumentTag, error)
+	// AddTags adds user tags. types gives a key's value type (string, number or
+	// date); keys without one are strings.
+	AddTags(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, tags map[string]string, types map[string]domain.TagValueType) ([]domain.DocumentTag, error)
 	DeleteTag(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, tagID uuid.UUID) error
-	SearchByTag(ctx context.Context, tenantID uuid.UUID, key, value string, offset, limit int) ([]domain.Document, int, error)
+	ListOverrides(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentFieldOverride, error)
+	ClearOverrides(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, fieldPath string) error
+	GetTimeline(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*domain.DocumentTimeline, error)
+	// ListParseAttempts returns every parse attempt of a document, oldest first.
+	ListParseAttempts(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.ParseTiming, error)
+	// SearchByTag finds documents by exact, prefix or range match on one tag key.
+	SearchByTag(ctx context.Context, tenantID uuid.UUID, input *TagSearchInput, offset, limit int) ([]domain.Document, int, error)
+	// EnrichDocuments fills Tags and AssigneeName on a page of documents with one
+	// tag query and one user query, however many documents there are.
+	EnrichDocuments(ctx context.Context, tenantID uuid.UUID, docs []domain.Document) error
 	ParseDocument(ctx context.Context, doc *domain.Document, maxAttempts int)
+	// Events is the dispatcher of document.parsed, document.edited and
+	// document.reviewed; subscribe to add post-processing.
+	Events() *DocumentEvents
 }
 
 type documentService struct {
-	docRepo     port.DocumentRepository
-	fileRepo    port.FileMetaRepository
-	userRepo    port.UserRepository
-	permRepo    port.CollectionPermissionRepository
-	tagRepo     port.DocumentTagRepository
-	auditRepo   port.DocumentAuditRepository
-	summaryRepo port.DocumentSummaryRepository
-	parser      port.DocumentParser
-	mergeParser port.DocumentParser // optional merge parser for dual mode
-	storage     port.ObjectStorage
-	validator   *validator.Engine
+	docRepo        port.DocumentRepository
+	fileRepo       port.FileMetaRepository
+	userRepo       port.UserRepository
+	permRepo       port.CollectionPermissionRepository
+	tagRepo        port.DocumentTagRepository
+	auditRepo      port.DocumentAuditRepository
+	summaryRepo    port.DocumentSummaryRepository
+	overrideRepo   port.DocumentFieldOverrideRepository
+	flags          port.Flags
+	timingRepo     port.ParseTimingRepository
+	collectionRepo port.CollectionRepository
+	delegationRepo port.ReviewDelegationRepository
+	reasonRepo     port.RejectionReasonRepository
+	observations   port.ConfidenceObservationRepository
+	residency      StorageResidency
+	budget         ParseBudget
+	parser         port.DocumentParser
+	mergeParser    port.DocumentParser // optional merge parser for dual mode
+	storage        port.ObjectStorage
+	validator      *validator.Engine
+	jobTimeout     time.Duration
+	events         *DocumentEvents
+}
+
+// DocumentServiceDeps are the collaborators of the document service. DocRepo,
+// FileRepo, UserRepo, PermRepo, Parser and Storage are required; the rest may
+// be left nil and the features that use them are skipped.
+type DocumentServiceDeps struct {
+	DocRepo  port.DocumentRepository
+	FileRepo port.FileMetaRepository
+	UserRepo port.UserRepository
+	PermRepo port.CollectionPermissionRepository
+	TagRepo  port.DocumentTagRepository
+	Parser   port.DocumentParser
+	// MergeParser enables dual parse mode; nil leaves only single mode.
+	MergeParser    port.DocumentParser
+	Storage        port.ObjectStorage
+	Validator      *validator.Engine
+	AuditRepo      port.DocumentAuditRepository
+	SummaryRepo    port.DocumentSummaryRepository
+	OverrideRepo   port.DocumentFieldOverrideRepository
+	Flags          port.Flags
+	TimingRepo     port.ParseTimingRepository
+	CollectionRepo port.CollectionRepository
+	DelegationRepo port.ReviewDelegationRepository
+	ReasonRepo     port.RejectionReasonRepository
+	Observations   port.ConfidenceObservationRepository
+	Residency      StorageResidency
+	Budget         ParseBudget
+	// JobTimeout bounds one background parse; zero uses defaultParseJobTimeout.
+	JobTimeout time.Duration
 }
 
 // NewDocumentService creates a new DocumentService implementation.
-func NewDocumentService(
-	docRepo port.DocumentRepository,
-	fileRepo port.FileMetaRepository,
-	userRepo port.UserRepository,
-	permRepo port.CollectionPermissionRepository,
-	tagRepo port.DocumentTagRepository,
-	docParser port.DocumentParser,
-	storage port.ObjectStorage,
-	validationEngine *validator.Engine,
-	auditRepo port.DocumentAuditRepository,
-	summaryRepo port.DocumentSummaryRepository,
-) DocumentService {
-	return &documentService{
-		docRepo:     docRepo,
-		fileRepo:    fileRepo,
-		userRepo:    userRepo,
-		permRepo:    permRepo,
-		tagRepo:     tagRepo,
-		auditRepo:   auditRepo,
-		summaryRepo: summaryRepo,
-		parser:      docParser,
-		storage:     storage,
-		validator:   validationEngine,
+func NewDocumentService(deps *DocumentServiceDeps) DocumentService {
+	s := &documentService{
+		docRepo:        deps.DocRepo,
+		fileRepo:       deps.FileRepo,
+		userRepo:       deps.UserRepo,
+		permRepo:       deps.PermRepo,
+		tagRepo:        deps.TagRepo,
+		auditRepo:      deps.AuditRepo,
+		summaryRepo:    deps.SummaryRepo,
+		overrideRepo:   deps.OverrideRepo,
+		flags:          deps.Flags,
+		timingRepo:     deps.TimingRepo,
+		collectionRepo: deps.CollectionRepo,
+		delegationRepo: deps.DelegationRepo,
+		reasonRepo:     deps.ReasonRepo,
+		observations:   deps.Observations,
+		residency:      deps.Residency,
+		budget:         deps.Budget,
+		parser:         deps.Parser,
+		mergeParser:    deps.MergeParser,
+		storage:        deps.Storage,
+		validator:      deps.Validator,
+		jobTimeout:     parseJobTimeout(deps.JobTimeout),
+		events:         NewDocumentEvents(),
+	}
+	s.subscribeDefaultListeners()
+	return s
+}
+
+// parseJobTimeout returns d, or defaultParseJobTimeout when d is not positive.
+func parseJobTimeout(d time.Duration) time.Duration {
+	if d <= 0 {
+		return defaultParseJobTimeout
+	}
+	return d
+}
+
+// featureEnabled evaluates a tenant feature flag, falling back to the flag's default
+// when no Flags implementation is configured.
+func (s *documentService) featureEnabled(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) bool {
+	if s.flags == nil {
+		return domain.FeatureFlagDefaults[flag]
 	}
+	return s.flags.IsEnabled(ctx, tenantID, flag)
 }
 
-// NewDocumentServiceWithMerge creates a DocumentService with dual-parse support.
-func NewDocumentServiceWithMerge(
-	docRepo port.DocumentRepository,
-	fileRepo port.FileMetaRepository,
-	userRepo port.UserRepository,
-	permRepo port.CollectionPermissionRepository,
-	tagRepo port.DocumentTagRepository,
-	docParser port.DocumentParser,
-	mergeDocParser port.DocumentParser,
-	storage port.ObjectStorage,
-	validationEngine *validator.Engine,
-	auditRepo port.DocumentAuditRepository,
-	summaryRepo port.DocumentSummaryRepository,
-) DocumentService {
-	return &documentService{
-		docRepo:     docRepo,
-		fileRepo:    fileRepo,
-		userRepo:    userRepo,
-		permRepo:    permRepo,
-		tagRepo:     tagRepo,
-		auditRepo:   auditRepo,
-		summaryRepo: summaryRepo,
-		parser:      docParser,
-		mergeParser: mergeDocParser,
-		storage:     storage,
-		validator:   validationEngine,
+// resolveParseMode picks the document's parse mode: the request's, else the
+// collection's default, else the tenant's, else single. Dual parse is gated per
+// tenant; asking for it explicitly fails, while a dual default parses single.
+func (s *documentService) resolveParseMode(ctx context.Context, input *CreateDocumentInput) (domain.ParseMode, error) {
+	if input.ParseMode != "" {
+		if input.ParseMode == domain.ParseModeDual && !s.featureEnabled(ctx, input.TenantID, domain.FlagDualParse) {
+			return "", domain.ErrFeatureDisabled
+		}
+		return input.ParseMode, nil
+	}
+
+	mode := domain.ParseModeSingle
+	if s.collectionRepo != nil {
+		def, err := s.collectionRepo.DefaultParseMode(ctx, input.TenantID, input.CollectionID)
+		if err != nil {
+			return "", err
+		}
+		if def != "" {
+			mode = def
+		}
 	}
+	if mode == domain.ParseModeDual && !s.featureEnabled(ctx, input.TenantID, domain.FlagDualParse) {
+		log.Printf("documentService.resolveParseMode: dual parse default for collection %s but dual_parse is off, using single", input.CollectionID)
+		mode = domain.ParseModeSingle
+	}
+	return mode, nil
 }
 
 // effectiveCollectionPerm computes the effective collection permission for a user.
@@ -261,9 +414,13 @@ func (s *documentService) upsertSummary(ctx context.Context, doc *domain.Documen
 		ReconciliationStatus: doc.ReconciliationStatus,
 	}
 
-	// Parse invoice date
-	summary.InvoiceDate = parseInvoiceDate(inv.Invoice.InvoiceDate)
-	summary.DueDate = parseInvoiceDate(inv.Invoice.DueDate)
+	// Only timestamps depend on the tenant's zone; plain dates skip the lookup
+	loc := time.UTC
+	if hasTimeOfDay(inv.Invoice.InvoiceDate) || hasTimeOfDay(inv.Invoice.DueDate) {
+		loc = collectionLocation(ctx, s.collectionRepo, doc.TenantID, doc.CollectionID)
+	}
+	summary.InvoiceDate = parseInvoiceDate(inv.Invoice.InvoiceDate, loc)
+	summary.DueDate = parseInvoiceDate(inv.Invoice.DueDate, loc)
 
 	// Collect distinct HSN codes
 	hsnSet := make(map[string]struct{})
@@ -298,30 +455,24 @@ func (s *documentService) updateSummaryStatuses(ctx context.Context, doc *domain
 	}
 }
 
-// parseInvoiceDate attempts multiple date formats from LLM output.
-func parseInvoiceDate(s string) *time.Time {
-	if s == "" {
-		return nil
-	}
-	formats := []string{"2006-01-02", "02/01/2006", "02-01-2006", "01/02/2006", "January 2, 2006", "Jan 2, 2006", "2 January 2006"}
-	for _, f := range formats {
-		if t, err := time.Parse(f, s); err == nil {
-			return &t
-		}
-	}
-	return nil
-}
-
 func (s *documentService) CreateAndParse(ctx context.Context, input *CreateDocumentInput) (*domain.Document, error) {
 	// Check editor+ permission on the collection
 	if err := s.requireCollectionPerm(ctx, input.CollectionID, input.CreatedBy, input.Role, domain.CollectionPermEditor); err != nil {
 		return nil, err
 	}
 
+	parseMode, err := s.resolveParseMode(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check and increment quota (no-op for unlimited users)
 	if err := s.userRepo.CheckAndIncrementQuota(ctx, input.TenantID, input.CreatedBy); err != nil {
 		return nil, err
 	}
+	if err := reserveParse(ctx, s.budget, input.TenantID, input.CreatedBy); err != nil {
+		return nil, err
+	}
 
 	// Verify the file exists
 	file, err := s.fileRepo.GetByID(ctx, input.TenantID, input.FileID)
@@ -329,10 +480,6 @@ func (s *documentService) CreateAndParse(ctx context.Context, input *CreateDocum
 		return nil, fmt.Errorf("looking up file: %w", err)
 	}
 
-	parseMode := input.ParseMode
-	if parseMode == "" {
-		parseMode = domain.ParseModeSingle
-	}
 	// Fall back to single if dual requested but no merge parser configured
 	if parseMode == domain.ParseModeDual && s.mergeParser == nil {
 		log.Printf("documentService.CreateAndParse: dual parse requested but no merge parser configured, falling back to single")
@@ -375,6 +522,7 @@ func (s *documentService) CreateAndParse(ctx context.Context, input *CreateDocum
 		"document_type": input.DocumentType, "parse_mode": string(parseMode),
 	})
 	s.audit(ctx, doc.TenantID, doc.ID, &input.CreatedBy, domain.AuditDocumentCreated, changesJSON)
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventCreated, Document: doc, ActorID: &input.CreatedBy})
 
 	// Save user-provided tags
 	if len(input.Tags) > 0 && s.tagRepo != nil {
@@ -403,15 +551,68 @@ func (s *documentService) CreateAndParse(ctx context.Context, input *CreateDocum
 	return &result, nil
 }
 
-func (s *documentService) selectParser(mode domain.ParseMode) port.DocumentParser {
-	if mode == domain.ParseModeDual && s.mergeParser != nil {
-		return s.mergeParser
+func (s *documentService) CreateParsed(ctx context.Context, input *CreateParsedDocumentInput) (*domain.Document, error) {
+	if err := s.requireCollectionPerm(ctx, input.CollectionID, input.CreatedBy, input.Role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	doc := &domain.Document{
+		ID:                   uuid.New(),
+		TenantID:             input.TenantID,
+		CollectionID:         input.CollectionID,
+		FileID:               input.FileID,
+		Name:                 input.Name,
+		DocumentType:         input.DocumentType,
+		ParserModel:          input.ParserModel,
+		StructuredData:       input.StructuredData,
+		ConfidenceScores:     json.RawMessage("{}"),
+		ParsingStatus:        domain.ParsingStatusCompleted,
+		ParsedAt:             &now,
+		ReviewStatus:         domain.ReviewStatusPending,
+		ValidationStatus:     domain.ValidationStatusPending,
+		ReconciliationStatus: domain.ReconciliationStatusPending,
+		ValidationResults:    json.RawMessage("[]"),
+		ParseMode:            domain.ParseModeSingle,
+		FieldProvenance:      json.RawMessage("{}"),
+		CreatedBy:            input.CreatedBy,
+	}
+	if err := s.docRepo.Create(ctx, doc); err != nil {
+		return nil, fmt.Errorf("creating document: %w", err)
+	}
+
+	changesJSON, _ := json.Marshal(map[string]interface{}{
+		"collection_id": input.CollectionID, "file_id": input.FileID,
+		"document_type": input.DocumentType, "parser_model": input.ParserModel,
+	})
+	s.audit(ctx, doc.TenantID, doc.ID, &input.CreatedBy, domain.AuditDocumentCreated, changesJSON)
+
+	if s.validator != nil {
+		if err := s.validator.ValidateDocument(ctx, doc.TenantID, doc.ID); err != nil {
+			log.Printf("documentService.CreateParsed: validation failed for %s: %v", doc.ID, err)
+		} else {
+			s.auditValidationCompleted(ctx, doc.TenantID, doc.ID, &input.CreatedBy, "create")
+			if validatedDoc, err := s.docRepo.GetByID(ctx, doc.TenantID, doc.ID); err == nil {
+				doc = validatedDoc
+			}
+		}
 	}
-	return s.parser
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventParsed, Document: doc, ActorID: &input.CreatedBy})
+	return doc, nil
+}
+
+// selectParser picks the parser for the document's parse mode, restricted to the
+// parser of the tenant's tier when it has one.
+func (s *documentService) selectParser(ctx context.Context, doc *domain.Document) port.DocumentParser {
+	active := s.parser
+	if doc.ParseMode == domain.ParseModeDual && s.mergeParser != nil {
+		active = s.mergeParser
+	}
+	return tierParser(ctx, s.budget, doc.TenantID, active)
 }
 
 func (s *documentService) parseInBackground(docID, tenantID uuid.UUID) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), s.jobTimeout)
 	defer cancel()
 
 	log.Printf("documentService.parseInBackground: starting parsing for document %s", docID)
@@ -428,42 +629,76 @@ func (s *documentService) parseInBackground(docID, tenantID uuid.UUID) {
 		log.Printf("documentService.parseInBackground: failed to set processing status for %s: %v", docID, err)
 		return
 	}
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventParseStatusChanged, Document: doc})
 
 	s.ParseDocument(ctx, doc, defaultMaxParseAttempts)
 }
 
 // ParseDocument performs the core parse logic: file lookup, S3 download, LLM parse,
-// error handling (with rate-limit queueing), result saving, auto-tags, and validation.
+// error handling (with rate-limit queueing), result saving, validation, and the
+// document.parsed event that updates auto-tags and the summary.
 // It is called by both parseInBackground and the queue worker.
 // The doc must already be in processing status with ParseAttempts incremented.
 func (s *documentService) ParseDocument(ctx context.Context, doc *domain.Document, maxAttempts int) {
+	timing := &domain.ParseTiming{TenantID: doc.TenantID, DocumentID: doc.ID, Attempt: doc.ParseAttempts, ParseMode: doc.ParseMode}
+	startedAt := time.Now()
+	if !doc.QueuedAt.IsZero() && startedAt.After(doc.QueuedAt) {
+		timing.QueueMs = startedAt.Sub(doc.QueuedAt).Milliseconds()
+	}
+	defer s.recordTiming(ctx, doc, timing)
+
 	// Look up file for S3 coordinates
 	file, err := s.fileRepo.GetByID(ctx, doc.TenantID, doc.FileID)
 	if err != nil {
-		s.failParsing(ctx, doc, fmt.Sprintf("downloading file: %v", err))
+		s.failParsing(ctx, doc, domain.ParseFailureError, fmt.Sprintf("downloading file: %v", err))
+		return
+	}
+
+	if err := checkResidency(ctx, s.residency, doc.TenantID, file.S3Bucket); err != nil {
+		s.failParsing(ctx, doc, domain.ParseFailureError, fmt.Sprintf("downloading file: %v", err))
 		return
 	}
 
 	// Download file bytes from S3
 	fileBytes, err := s.storage.Download(ctx, file.S3Bucket, file.S3Key)
 	if err != nil {
-		s.failParsing(ctx, doc, fmt.Sprintf("downloading file: %v", err))
+		s.handleParseError(ctx, doc, err, maxAttempts, "downloading file")
 		return
 	}
 
 	// Select parser based on document's parse mode
-	activeParser := s.selectParser(doc.ParseMode)
+	activeParser := s.selectParser(ctx, doc)
 
 	// Call parser
+	lang := detectDocumentLanguage(file.ContentType, fileBytes)
+	parseStart := time.Now()
 	output, err := activeParser.Parse(ctx, port.ParseInput{
 		FileBytes:    fileBytes,
 		ContentType:  file.ContentType,
 		DocumentType: doc.DocumentType,
+		PageCount:    countPages(file.ContentType, fileBytes),
+		Language:     lang,
 	})
+	timing.ParseMs = time.Since(parseStart).Milliseconds()
 	if err != nil {
-		s.handleParseError(ctx, doc, err, maxAttempts)
+		var rlErr *parser.RateLimitError
+		var trErr *parser.TransientError
+		var toErr *parser.TimeoutError
+		switch {
+		case errors.As(err, &rlErr):
+			timing.ParserModel = rlErr.Provider
+		case errors.As(err, &toErr):
+			timing.ParserModel = toErr.Provider
+		case errors.As(err, &trErr):
+			timing.ParserModel = trErr.Provider
+		}
+		s.handleParseError(ctx, doc, err, maxAttempts, "parsing document")
 		return
 	}
+	timing.ParserModel = output.ModelUsed
+	timing.SecondaryParserModel = output.SecondaryModel
+	timing.InputTokens = output.InputTokens
+	timing.OutputTokens = output.OutputTokens
 
 	// Update with results
 	now := time.Now().UTC()
@@ -472,14 +707,20 @@ func (s *documentService) ParseDocument(ctx context.Context, doc *domain.Documen
 	doc.ParserModel = output.ModelUsed
 	doc.SecondaryParserModel = output.SecondaryModel
 	doc.ParserPrompt = output.PromptUsed
+	doc.Language = parsedLanguage(lang, output.StructuredData)
 	doc.ParsingStatus = domain.ParsingStatusCompleted
 	doc.ParsingError = ""
+	doc.ParseFailureCategory = ""
 	doc.ParsedAt = &now
 	doc.RetryAfter = nil
 
+	// Re-apply reviewer overrides on top of the fresh parser output
+	provenance := output.FieldProvenance
+	overridesApplied := s.applyOverrides(ctx, doc, &provenance)
+
 	// Save field provenance if present
-	if len(output.FieldProvenance) > 0 {
-		if provenanceJSON, jsonErr := json.Marshal(output.FieldProvenance); jsonErr == nil {
+	if len(provenance) > 0 {
+		if provenanceJSON, jsonErr := json.Marshal(provenance); jsonErr == nil {
 			doc.FieldProvenance = provenanceJSON
 		}
 	}
@@ -491,66 +732,139 @@ func (s *documentService) ParseDocument(ctx context.Context, doc *domain.Documen
 
 	parseChanges, _ := json.Marshal(map[string]interface{}{
 		"parser_model": doc.ParserModel, "parse_mode": string(doc.ParseMode), "attempt": doc.ParseAttempts,
+		"overrides_applied": overridesApplied,
 	})
 	s.audit(ctx, doc.TenantID, doc.ID, nil, domain.AuditDocumentParseCompleted, parseChanges)
 
 	log.Printf("documentService.ParseDocument: document %s parsed successfully", doc.ID)
 
-	// Extract auto-tags from parsed data
-	if s.tagRepo != nil {
-		s.extractAndSaveAutoTags(ctx, doc.ID, doc.TenantID, doc.StructuredData)
-	}
-
-	// Upsert document summary for reporting
-	s.upsertSummary(ctx, doc)
-
 	// Run validation after successful parsing
+	parsed := doc
 	if s.validator != nil {
 		if err := s.validator.ValidateDocument(ctx, doc.TenantID, doc.ID); err != nil {
 			log.Printf("documentService.ParseDocument: validation failed for %s: %v", doc.ID, err)
 		} else {
 			s.auditValidationCompleted(ctx, doc.TenantID, doc.ID, nil, "parse")
-			// Update summary statuses after successful validation
 			if validatedDoc, fetchErr := s.docRepo.GetByID(ctx, doc.TenantID, doc.ID); fetchErr == nil {
-				s.updateSummaryStatuses(ctx, validatedDoc)
+				parsed = validatedDoc
 			}
 		}
 	}
+
+	// Auto-tags and the reporting summary follow the parsed data
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventParsed, Document: parsed})
 }
 
-// handleParseError checks if the error is a rate limit and queues the document for retry
-// if under the max attempts threshold. Otherwise, marks parsing as permanently failed.
-func (s *documentService) handleParseError(ctx context.Context, doc *domain.Document, parseErr error, maxAttempts int) {
-	var rlErr *parser.RateLimitError
-	if errors.As(parseErr, &rlErr) && doc.ParseAttempts < maxAttempts {
-		retryAt := time.Now().Add(rlErr.RetryAfter)
-		doc.ParsingStatus = domain.ParsingStatusQueued
-		doc.ParsingError = fmt.Sprintf("rate limited by %s, queued for retry", rlErr.Provider)
-		doc.RetryAfter = &retryAt
-		if err := s.docRepo.UpdateStructuredData(ctx, doc); err != nil {
-			log.Printf("documentService.handleParseError: failed to queue document %s: %v", doc.ID, err)
-		} else {
-			queueChanges, _ := json.Marshal(map[string]interface{}{
-				"retry_after": retryAt.Format(time.RFC3339), "attempt": doc.ParseAttempts,
-			})
-			s.audit(ctx, doc.TenantID, doc.ID, nil, domain.AuditDocumentParseQueued, queueChanges)
-			log.Printf("documentService.handleParseError: document %s queued for retry after %s", doc.ID, retryAt.Format(time.RFC3339))
+// recordTiming stores a finished parse attempt; the outcome, failure category and
+// error are the document's resulting parsing state. Errors are logged, not returned.
+func (s *documentService) recordTiming(ctx context.Context, doc *domain.Document, timing *domain.ParseTiming) {
+	if s.timingRepo == nil {
+		return
+	}
+	timing.Outcome = doc.ParsingStatus
+	if doc.ParsingStatus != domain.ParsingStatusCompleted {
+		timing.FailureCategory = doc.ParseFailureCategory
+		timing.ErrorMessage = doc.ParsingError
+	}
+	if err := s.timingRepo.Record(context.WithoutCancel(ctx), timing); err != nil {
+		log.Printf("documentService.recordTiming: failed to record timing for %s: %v", doc.ID, err)
+	}
+}
+
+// handleParseError queues the document for retry when the error is a rate limit, a
+// timeout, or a transient failure and the document is under the max attempts
+// threshold. Rate limits wait for the provider's Retry-After; the others back off
+// exponentially. Anything else marks parsing as permanently failed, prefixed with stage.
+func (s *documentService) handleParseError(ctx context.Context, doc *domain.Document, parseErr error, maxAttempts int, stage string) {
+	// The job deadline may be what failed the parse; the outcome must still be saved.
+	ctx = context.WithoutCancel(ctx)
+	category := parseFailureCategory(parseErr)
+	if doc.ParseAttempts < maxAttempts {
+		var rlErr *parser.RateLimitError
+		switch {
+		case errors.As(parseErr, &rlErr):
+			s.queueRetry(ctx, doc, category, rlErr.RetryAfter, fmt.Sprintf("rate limited by %s, queued for retry", rlErr.Provider))
+			return
+		case category == domain.ParseFailureTimeout:
+			s.queueRetry(ctx, doc, category, transientBackoff(doc.ParseAttempts),
+				fmt.Sprintf("%s: timed out, queued for retry: %v", stage, parseErr))
+			return
+		case category == domain.ParseFailureUnavailable:
+			s.queueRetry(ctx, doc, category, transientBackoff(doc.ParseAttempts),
+				fmt.Sprintf("%s: temporary failure, queued for retry: %v", stage, parseErr))
+			return
 		}
+	}
+	s.failParsing(ctx, doc, category, fmt.Sprintf("%s: %v", stage, parseErr))
+}
+
+// queueRetry puts the document back in the parse queue to be claimed after delay.
+func (s *documentService) queueRetry(ctx context.Context, doc *domain.Document, category domain.ParseFailureCategory, delay time.Duration, msg string) {
+	retryAt := time.Now().Add(delay)
+	doc.ParsingStatus = domain.ParsingStatusQueued
+	doc.ParsingError = msg
+	doc.ParseFailureCategory = category
+	doc.RetryAfter = &retryAt
+	doc.QueuedAt = retryAt
+	if err := s.docRepo.UpdateStructuredData(ctx, doc); err != nil {
+		log.Printf("documentService.queueRetry: failed to queue document %s: %v", doc.ID, err)
 		return
 	}
-	s.failParsing(ctx, doc, fmt.Sprintf("parsing document: %v", parseErr))
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventParseStatusChanged, Document: doc})
+	queueChanges, _ := json.Marshal(map[string]interface{}{
+		"retry_after": retryAt.Format(time.RFC3339), "attempt": doc.ParseAttempts, "reason": category,
+	})
+	s.audit(ctx, doc.TenantID, doc.ID, nil, domain.AuditDocumentParseQueued, queueChanges)
+	log.Printf("documentService.queueRetry: document %s queued for retry after %s (%s)", doc.ID, retryAt.Format(time.RFC3339), category)
+}
+
+// parseFailureCategory classifies a download or parse error. Timeouts are checked
+// before outages because a provider call that hits its deadline is reported as both.
+func parseFailureCategory(err error) domain.ParseFailureCategory {
+	var rlErr *parser.RateLimitError
+	var toErr *parser.TimeoutError
+	var trErr *parser.TransientError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &rlErr):
+		return domain.ParseFailureRateLimited
+	case errors.As(err, &toErr), errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return domain.ParseFailureTimeout
+	case errors.As(err, &trErr), errors.Is(err, domain.ErrStorageUnavailable):
+		return domain.ParseFailureUnavailable
+	default:
+		return domain.ParseFailureError
+	}
+}
+
+// transientBackoff returns the delay before retrying after the given attempt number.
+func transientBackoff(attempt int) time.Duration {
+	delay := transientRetryBase
+	for i := 1; i < attempt && delay < transientRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > transientRetryMax {
+		delay = transientRetryMax
+	}
+	return delay
 }
 
-func (s *documentService) failParsing(ctx context.Context, doc *domain.Document, errMsg string) {
-	log.Printf("documentService.failParsing: document %s failed: %s", doc.ID, errMsg)
+func (s *documentService) failParsing(ctx context.Context, doc *domain.Document, category domain.ParseFailureCategory, errMsg string) {
+	log.Printf("documentService.failParsing: document %s failed (%s): %s", doc.ID, category, errMsg)
 	doc.ParsingStatus = domain.ParsingStatusFailed
 	doc.ParsingError = errMsg
+	doc.ParseFailureCategory = category
 	doc.RetryAfter = nil
-	if err := s.docRepo.UpdateStructuredData(ctx, doc); err != nil {
-		log.Printf("documentService.failParsing: failed to update status for %s: %v", doc.ID, err)
+	saveErr := s.docRepo.UpdateStructuredData(ctx, doc)
+	if saveErr != nil {
+		log.Printf("documentService.failParsing: failed to update status for %s: %v", doc.ID, saveErr)
 	}
-	failChanges, _ := json.Marshal(map[string]interface{}{"error": errMsg, "attempt": doc.ParseAttempts})
+	failChanges, _ := json.Marshal(map[string]interface{}{"error": errMsg, "category": category, "attempt": doc.ParseAttempts})
 	s.audit(ctx, doc.TenantID, doc.ID, nil, domain.AuditDocumentParseFailed, failChanges)
+	if saveErr == nil {
+		s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventParseFailed, Document: doc})
+	}
 }
 
 func (s *documentService) GetByID(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*domain.Document, error) {
@@ -582,6 +896,15 @@ func (s *documentService) ListByCollection(ctx context.Context, tenantID, collec
 	return s.docRepo.ListByCollection(ctx, tenantID, collectionID, assignedTo, offset, limit)
 }
 
+// ListValidationFailures returns a page of the collection's failing validation
+// results, for the fix-list export.
+func (s *documentService) ListValidationFailures(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ValidationFailure, error) {
+	if err := s.requireCollectionPerm(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+	return s.docRepo.ListValidationFailures(ctx, tenantID, collectionID, offset, limit)
+}
+
 func (s *documentService) ListByTenant(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, assignedTo *uuid.UUID, offset, limit int) ([]domain.Document, int, error) {
 	// Admin, manager, and member see all documents
 	if role == domain.RoleAdmin || role == domain.RoleManager || role == domain.RoleMember {
@@ -606,25 +929,82 @@ func (s *documentService) UpdateReview(ctx context.Context, input *UpdateReviewI
 		return nil, domain.ErrDocumentNotParsed
 	}
 
+	collection, err := s.reviewCollection(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := s.reviewStage(ctx, doc, collection, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// A checker who submits no answers confirms the maker's completed checklist.
+	var checklist []domain.ReviewChecklistAnswer
+	if stage == reviewStageChecker && len(input.Checklist) == 0 {
+		_ = json.Unmarshal(doc.ReviewChecklist, &checklist)
+	} else {
+		checklist, err = resolveReviewChecklist(checklistItemsOf(collection), input.Checklist, input.Status)
+		if err != nil {
+			return nil, err
+		}
+		doc.ReviewChecklist, _ = json.Marshal(checklist)
+	}
+
+	reasonCode, err := s.rejectionReason(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	previousStatus := doc.ReviewStatus
 	now := time.Now().UTC()
 	doc.ReviewStatus = input.Status
+	if stage == reviewStageMaker {
+		doc.ReviewStatus = domain.ReviewStatusAwaitingChecker
+		doc.MakerApprovedBy = &input.ReviewerID
+		doc.MakerApprovedAt = &now
+	}
 	doc.ReviewedBy = &input.ReviewerID
 	doc.ReviewedAt = &now
 	doc.ReviewerNotes = input.Notes
+	doc.RejectionReason = reasonCode
+	doc.EscalatedAt = nil
 
 	if err := s.docRepo.UpdateReviewStatus(ctx, doc); err != nil {
 		return nil, fmt.Errorf("updating review status: %w", err)
 	}
 
-	reviewChanges, _ := json.Marshal(map[string]interface{}{"status": string(input.Status), "notes": input.Notes})
+	changes := map[string]interface{}{
+		"status": string(doc.ReviewStatus), "notes": input.Notes, "previous_status": string(previousStatus),
+		"checklist": checklist,
+	}
+	if reasonCode != "" {
+		changes["reason_code"] = reasonCode
+	}
+	if stage != "" {
+		changes["stage"] = stage
+	}
+	if onBehalfOf := s.reviewingOnBehalfOf(ctx, doc, input.ReviewerID); onBehalfOf != nil {
+		changes["on_behalf_of"] = onBehalfOf.String()
+	}
+	reviewChanges, _ := json.Marshal(changes)
 	s.audit(ctx, input.TenantID, input.DocumentID, &input.ReviewerID, domain.AuditDocumentReview, reviewChanges)
 
-	// Update summary statuses after review
-	s.updateSummaryStatuses(ctx, doc)
+	// An approval confirms every field the reviewer did not edit
+	if doc.ReviewStatus == domain.ReviewStatusApproved {
+		s.recordConfidenceObservations(ctx, doc, nil)
+	}
+
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventReviewed, Document: doc, ActorID: &input.ReviewerID})
 
 	return doc, nil
 }
 
+func (s *documentService) ListCheckerQueue(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Document, int, error) {
+	ownedOnly := role != domain.RoleAdmin && role != domain.RoleManager
+	return s.docRepo.ListCheckerQueue(ctx, tenantID, userID, ownedOnly, offset, limit)
+}
+
 func (s *documentService) AssignDocument(ctx context.Context, input *AssignDocumentInput) (*domain.Document, error) {
 	doc, err := s.docRepo.GetByID(ctx, input.TenantID, input.DocumentID)
 	if err != nil {
@@ -642,6 +1022,7 @@ func (s *documentService) AssignDocument(ctx context.Context, input *AssignDocum
 
 	previousAssignee := doc.AssignedTo
 
+	var delegatedFrom *uuid.UUID
 	if input.AssigneeID != nil {
 		// Verify assignee exists in tenant
 		assignee, err := s.userRepo.GetByID(ctx, input.TenantID, *input.AssigneeID)
@@ -654,6 +1035,18 @@ func (s *documentService) AssignDocument(ctx context.Context, input *AssignDocum
 			return nil, domain.ErrAssigneeCannotReview
 		}
 
+		// Route to the assignee's out-of-office delegate if they can review here
+		if d := s.activeDelegation(ctx, input.TenantID, *input.AssigneeID); d != nil {
+			if delegate, err := s.userRepo.GetByID(ctx, input.TenantID, d.DelegateID); err == nil &&
+				s.requireCollectionPerm(ctx, doc.CollectionID, d.DelegateID, delegate.Role, domain.CollectionPermEditor) == nil {
+				delegatedFrom = input.AssigneeID
+				input.AssigneeID = &d.DelegateID
+			} else {
+				log.Printf("documentService.AssignDocument: delegate %s cannot review document %s, keeping %s",
+					d.DelegateID, doc.ID, *input.AssigneeID)
+			}
+		}
+
 		now := time.Now().UTC()
 		doc.AssignedTo = input.AssigneeID
 		doc.AssignedAt = &now
@@ -672,9 +1065,13 @@ func (s *documentService) AssignDocument(ctx context.Context, input *AssignDocum
 	// Audit
 	var changes json.RawMessage
 	if input.AssigneeID != nil {
-		changes, _ = json.Marshal(map[string]interface{}{
+		assigned := map[string]interface{}{
 			"assigned_to": input.AssigneeID.String(), "assigned_by": input.CallerID.String(),
-		})
+		}
+		if delegatedFrom != nil {
+			assigned["delegated_from"] = delegatedFrom.String()
+		}
+		changes, _ = json.Marshal(assigned)
 	} else {
 		prev := ""
 		if previousAssignee != nil {
@@ -690,7 +1087,59 @@ func (s *documentService) AssignDocument(ctx context.Context, input *AssignDocum
 }
 
 func (s *documentService) ListReviewQueue(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.Document, int, error) {
-	return s.docRepo.ListReviewQueue(ctx, tenantID, userID, offset, limit)
+	assignees, err := s.queueAssignees(ctx, tenantID, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("loading delegated queues: %w", err)
+	}
+	return s.docRepo.ListReviewQueue(ctx, tenantID, assignees, offset, limit)
+}
+
+// GetNeighbors returns the previous and next documents around a document in the
+// review queue or its collection listing, using the same filters and sort order as
+// those lists. The document itself may have left the list (e.g. just approved).
+func (s *documentService) GetNeighbors(ctx context.Context, input *NeighborsInput) (*domain.DocumentNeighbors, error) {
+	doc, err := s.docRepo.GetByID(ctx, input.TenantID, input.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, input.UserID, input.Role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+
+	switch input.Context {
+	case domain.NeighborContextReviewQueue:
+		assignees, err := s.queueAssignees(ctx, input.TenantID, input.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("loading delegated queues: %w", err)
+		}
+		// An unassigned document sorts before the whole queue.
+		var assignedAt time.Time
+		if doc.AssignedAt != nil {
+			assignedAt = *doc.AssignedAt
+		}
+		return s.docRepo.ReviewQueueNeighbors(ctx, input.TenantID, assignees, assignedAt, doc.ID)
+	case domain.NeighborContextCollection:
+		return s.docRepo.CollectionNeighbors(ctx, input.TenantID, doc.CollectionID, input.AssignedTo, doc.CreatedAt, doc.ID)
+	default:
+		return nil, domain.ErrInvalidNeighborContext
+	}
+}
+
+// applyStructuredDataPatch applies a JSON Patch to the stored structured data.
+func applyStructuredDataPatch(stored json.RawMessage, patch []jsonpatch.Operation) (json.RawMessage, error) {
+	if len(stored) == 0 {
+		stored = json.RawMessage(`{}`)
+	}
+	patched, err := jsonpatch.Apply(stored, patch)
+	switch {
+	case errors.Is(err, jsonpatch.ErrInvalid):
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidJSONPatch, err)
+	case errors.Is(err, jsonpatch.ErrConflict):
+		return nil, fmt.Errorf("%w: %v", domain.ErrJSONPatchConflict, err)
+	case err != nil:
+		return nil, domain.ErrInvalidStructuredData
+	}
+	return patched, nil
 }
 
 func (s *documentService) EditStructuredData(ctx context.Context, input *EditStructuredDataInput) (*domain.Document, error) {
@@ -707,10 +1156,22 @@ func (s *documentService) EditStructuredData(ctx context.Context, input *EditStr
 	if doc.ParsingStatus != domain.ParsingStatusCompleted {
 		return nil, domain.ErrDocumentNotParsed
 	}
+	// Timestamps are stored to the microsecond
+	if input.IfUpdatedAt != nil && !doc.UpdatedAt.Truncate(time.Microsecond).Equal(input.IfUpdatedAt.Truncate(time.Microsecond)) {
+		return nil, domain.ErrDocumentModified
+	}
+	lastUpdatedAt := doc.UpdatedAt
+
+	structuredData := input.StructuredData
+	if input.Patch != nil {
+		if structuredData, err = applyStructuredDataPatch(doc.StructuredData, input.Patch); err != nil {
+			return nil, err
+		}
+	}
 
 	// Validate that the structured data can be unmarshalled into GSTInvoice
 	var inv invoice.GSTInvoice
-	if err := json.Unmarshal(input.StructuredData, &inv); err != nil {
+	if err := json.Unmarshal(structuredData, &inv); err != nil {
 		return nil, domain.ErrInvalidStructuredData
 	}
 
@@ -722,7 +1183,9 @@ func (s *documentService) EditStructuredData(ctx context.Context, input *EditStr
 	}
 
 	// Update document fields
-	doc.StructuredData = input.StructuredData
+	previous := *doc
+	previousData := doc.StructuredData
+	doc.StructuredData = structuredData
 	doc.ConfidenceScores = confidenceJSON
 	doc.FieldProvenance = json.RawMessage(`{"source":"manual_edit"}`)
 
@@ -731,28 +1194,53 @@ func (s *documentService) EditStructuredData(ctx context.Context, input *EditStr
 	doc.ValidationResults = json.RawMessage("[]")
 	doc.ReconciliationStatus = domain.ReconciliationStatusPending
 
-	// Persist structured data changes
-	if err := s.docRepo.UpdateStructuredData(ctx, doc); err != nil {
+	// Persist structured data changes. A conditional edit or a patch only lands
+	// if nobody saved the document since it was read, checked in the same
+	// statement.
+	if input.IfUpdatedAt != nil || input.Patch != nil {
+		err = s.docRepo.UpdateStructuredDataIfUnmodified(ctx, doc, lastUpdatedAt)
+	} else {
+		err = s.docRepo.UpdateStructuredData(ctx, doc)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("updating structured data: %w", err)
 	}
 
-	s.audit(ctx, input.TenantID, input.DocumentID, &input.UserID, domain.AuditDocumentEditStructured, json.RawMessage(`{"provenance":"manual_edit"}`))
+	// Record changed fields as overrides so they survive a re-parse
+	overridden, err := s.recordOverrides(ctx, &previous, structuredData, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Score the parser's confidence against what the reviewer changed
+	if changed, diffErr := diffFieldPaths(previousData, structuredData); diffErr == nil {
+		s.recordConfidenceObservations(ctx, &previous, changed)
+	}
+
+	changes := map[string]interface{}{
+		"provenance": "manual_edit", "overridden_fields": overridden,
+		"before": previousData, "after": structuredData,
+	}
+	if input.Patch != nil {
+		changes["patch"] = input.Patch
+	}
+	editChanges, _ := json.Marshal(changes)
+	s.audit(ctx, input.TenantID, input.DocumentID, &input.UserID, domain.AuditDocumentEditStructured, editChanges)
 
 	// Reset review status
 	doc.ReviewStatus = domain.ReviewStatusPending
 	doc.ReviewedBy = nil
 	doc.ReviewedAt = nil
 	doc.ReviewerNotes = ""
+	doc.RejectionReason = ""
+	doc.ReviewChecklist = nil
+	doc.MakerApprovedBy = nil
+	doc.MakerApprovedAt = nil
 
 	if err := s.docRepo.UpdateReviewStatus(ctx, doc); err != nil {
 		return nil, fmt.Errorf("resetting review status: %w", err)
 	}
 
-	// Re-extract auto-tags from the new structured data
-	if s.tagRepo != nil {
-		s.extractAndSaveAutoTags(ctx, doc.ID, doc.TenantID, doc.StructuredData)
-	}
-
 	// Run validation synchronously
 	if s.validator != nil {
 		if err := s.validator.ValidateDocument(ctx, input.TenantID, input.DocumentID); err != nil {
@@ -766,15 +1254,13 @@ func (s *documentService) EditStructuredData(ctx context.Context, input *EditStr
 		return nil, fmt.Errorf("re-fetching document after edit: %w", err)
 	}
 
-	// Upsert document summary for reporting
-	s.upsertSummary(ctx, updated)
-
 	if s.validator != nil {
 		s.auditValidationCompleted(ctx, input.TenantID, input.DocumentID, &input.UserID, "edit")
-		// Update summary statuses after validation
-		s.updateSummaryStatuses(ctx, updated)
 	}
 
+	// Auto-tags and the reporting summary follow the edited data
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventEdited, Document: updated, ActorID: &input.UserID})
+
 	return updated, nil
 }
 
@@ -867,6 +1353,9 @@ func (s *documentService) RetryParse(ctx context.Context, tenantID, docID, userI
 	if _, err := s.fileRepo.GetByID(ctx, tenantID, doc.FileID); err != nil {
 		return nil, fmt.Errorf("looking up file for retry: %w", err)
 	}
+	if err := reserveParse(ctx, s.budget, tenantID, userID); err != nil {
+		return nil, err
+	}
 
 	// Delete auto-tags before re-parsing
 	if s.tagRepo != nil {
@@ -878,6 +1367,8 @@ func (s *documentService) RetryParse(ctx context.Context, tenantID, docID, userI
 	// Reset to pending and clear assignment
 	doc.ParsingStatus = domain.ParsingStatusPending
 	doc.ParsingError = ""
+	doc.ParseFailureCategory = ""
+	doc.QueuedAt = time.Now().UTC()
 	doc.StructuredData = json.RawMessage("{}")
 	doc.ConfidenceScores = json.RawMessage("{}")
 	doc.AssignedTo = nil
@@ -888,6 +1379,7 @@ func (s *documentService) RetryParse(ctx context.Context, tenantID, docID, userI
 	}
 
 	s.audit(ctx, tenantID, docID, &userID, domain.AuditDocumentRetry, nil)
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventParseStatusChanged, Document: doc, ActorID: &userID})
 
 	log.Printf("documentService.RetryParse: retrying parsing for document %s", docID)
 
@@ -952,7 +1444,7 @@ func (s *documentService) ListTags(ctx context.Context, tenantID, docID, userID
 	return s.tagRepo.ListByDocument(ctx, docID)
 }
 
-func (s *documentService) AddTags(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, tagsMap map[string]string) ([]domain.DocumentTag, error) {
+func (s *documentService) AddTags(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, tagsMap map[string]string, types map[string]domain.TagValueType) ([]domain.DocumentTag, error) {
 	doc, err := s.docRepo.GetByID(ctx, tenantID, docID)
 	if err != nil {
 		return nil, err
@@ -963,14 +1455,18 @@ func (s *documentService) AddTags(ctx context.Context, tenantID, docID, userID u
 
 	tags := make([]domain.DocumentTag, 0, len(tagsMap))
 	for k, v := range tagsMap {
-		tags = append(tags, domain.DocumentTag{
+		tag := domain.DocumentTag{
 			ID:         uuid.New(),
 			DocumentID: docID,
 			TenantID:   tenantID,
 			Key:        k,
 			Value:      v,
 			Source:     "user",
-		})
+		}
+		if err := tag.SetValueType(types[k]); err != nil {
+			return nil, fmt.Errorf("tag %q: %w", k, err)
+		}
+		tags = append(tags, tag)
 	}
 
 	if err := s.tagRepo.CreateBatch(ctx, tags); err != nil {
@@ -999,8 +1495,258 @@ func (s *documentService) DeleteTag(ctx context.Context, tenantID, docID, userID
 	return nil
 }
 
-func (s *documentService) SearchByTag(ctx context.Context, tenantID uuid.UUID, key, value string, offset, limit int) ([]domain.Document, int, error) {
-	return s.tagRepo.SearchByTag(ctx, tenantID, key, value, offset, limit)
+// ListOverrides returns the per-field manual overrides recorded for a document.
+func (s *documentService) ListOverrides(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentFieldOverride, error) {
+	doc, err := s.docRepo.GetByID(ctx, tenantID, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+	if s.overrideRepo == nil {
+		return []domain.DocumentFieldOverride{}, nil
+	}
+	return s.overrideRepo.ListByDocument(ctx, tenantID, docID)
+}
+
+// ClearOverrides removes a single override (fieldPath non-empty) or all overrides on a document.
+// The current structured data is left as-is; the cleared fields revert to parser output on the next re-parse.
+func (s *documentService) ClearOverrides(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, fieldPath string) error {
+	doc, err := s.docRepo.GetByID(ctx, tenantID, docID)
+	if err != nil {
+		return err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, userID, role, domain.CollectionPermEditor); err != nil {
+		return err
+	}
+	if s.overrideRepo == nil {
+		return nil
+	}
+
+	if fieldPath != "" {
+		err = s.overrideRepo.DeleteByPath(ctx, tenantID, docID, fieldPath)
+	} else {
+		err = s.overrideRepo.DeleteByDocument(ctx, tenantID, docID)
+	}
+	if err != nil {
+		return err
+	}
+
+	changes, _ := json.Marshal(map[string]interface{}{"field_path": fieldPath, "all": fieldPath == ""})
+	s.audit(ctx, tenantID, docID, &userID, domain.AuditDocumentOverridesCleared, changes)
+	return nil
+}
+
+// recordOverrides diffs the current structured data against an edit and upserts an
+// override for every changed field. Returns the overridden field paths.
+func (s *documentService) recordOverrides(ctx context.Context, doc *domain.Document, edited json.RawMessage, userID uuid.UUID) ([]string, error) {
+	if s.overrideRepo == nil {
+		return []string{}, nil
+	}
+	changed, err := diffFieldPaths(doc.StructuredData, edited)
+	if err != nil {
+		return nil, domain.ErrInvalidStructuredData
+	}
+
+	paths := make([]string, 0, len(changed))
+	for path, value := range changed {
+		override := &domain.DocumentFieldOverride{
+			ID:         uuid.New(),
+			TenantID:   doc.TenantID,
+			DocumentID: doc.ID,
+			FieldPath:  path,
+			Value:      value,
+			CreatedBy:  userID,
+		}
+		if err := s.overrideRepo.Upsert(ctx, override); err != nil {
+			return nil, fmt.Errorf("saving field override %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// applyOverrides writes stored overrides into doc.StructuredData, marks them with
+// "manual_override" provenance, and pins their confidence to 1.0.
+// Non-blocking: failures are logged and the parser output is kept unchanged.
+func (s *documentService) applyOverrides(ctx context.Context, doc *domain.Document, provenance *map[string]string) int {
+	if s.overrideRepo == nil {
+		return 0
+	}
+	overrides, err := s.overrideRepo.ListByDocument(ctx, doc.TenantID, doc.ID)
+	if err != nil {
+		log.Printf("documentService.applyOverrides: failed to load overrides for %s: %v", doc.ID, err)
+		return 0
+	}
+	if len(overrides) == 0 {
+		return 0
+	}
+
+	var data, confidence map[string]interface{}
+	if err := json.Unmarshal(doc.StructuredData, &data); err != nil || data == nil {
+		log.Printf("documentService.applyOverrides: structured data for %s is not an object: %v", doc.ID, err)
+		return 0
+	}
+	if err := json.Unmarshal(doc.ConfidenceScores, &confidence); err != nil || confidence == nil {
+		confidence = make(map[string]interface{})
+	}
+	if *provenance == nil {
+		*provenance = make(map[string]string)
+	}
+
+	applied := 0
+	for i := range overrides {
+		o := &overrides[i]
+		var value interface{}
+		if err := json.Unmarshal(o.Value, &value); err != nil {
+			log.Printf("documentService.applyOverrides: bad override value %s on %s: %v", o.FieldPath, doc.ID, err)
+			continue
+		}
+		if err := setFieldPath(data, o.FieldPath, value); err != nil {
+			log.Printf("documentService.applyOverrides: skipping override %s on %s: %v", o.FieldPath, doc.ID, err)
+			continue
+		}
+		// Confidence scores only track scalar fields; a removed field drops its score
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+		case nil:
+			_ = setFieldPath(confidence, o.FieldPath, nil)
+		default:
+			_ = setFieldPath(confidence, o.FieldPath, 1.0)
+		}
+		(*provenance)[o.FieldPath] = "manual_override"
+		applied++
+	}
+
+	if dataJSON, err := json.Marshal(data); err == nil {
+		doc.StructuredData = dataJSON
+	}
+	if confJSON, err := json.Marshal(confidence); err == nil {
+		doc.ConfidenceScores = confJSON
+	}
+	return applied
+}
+
+func (s *documentService) SearchByTag(ctx context.Context, tenantID uuid.UUID, input *TagSearchInput, offset, limit int) ([]domain.Document, int, error) {
+	q, err := tagQuery(input)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.tagRepo.SearchByTag(ctx, tenantID, q, offset, limit)
+}
+
+// tagQuery validates a tag search and parses its range bounds.
+func tagQuery(input *TagSearchInput) (*domain.TagQuery, error) {
+	q := &domain.TagQuery{Key: input.Key, Value: input.Value, Prefix: input.Prefix}
+	isRange := input.Min != "" || input.Max != ""
+	modes := 0
+	for _, set := range []bool{input.Value != "", input.Prefix != "", isRange} {
+		if set {
+			modes++
+		}
+	}
+	if q.Key == "" || modes != 1 {
+		return nil, domain.ErrInvalidTagSearch
+	}
+	if !isRange {
+		return q, nil
+	}
+
+	q.Type = input.Type
+	if q.Type == "" {
+		// Bounds that all read as dates make a date range
+		isDate := func(s string) bool {
+			_, err := domain.ParseTagDate(s)
+			return s == "" || err == nil
+		}
+		q.Type = domain.TagValueNumber
+		if isDate(input.Min) && isDate(input.Max) {
+			q.Type = domain.TagValueDate
+		}
+	}
+	var err error
+	switch q.Type {
+	case domain.TagValueNumber:
+		if q.MinNumber, err = optionalBound(input.Min, domain.ParseTagNumber); err != nil {
+			return nil, domain.ErrInvalidTagSearch
+		}
+		if q.MaxNumber, err = optionalBound(input.Max, domain.ParseTagNumber); err != nil {
+			return nil, domain.ErrInvalidTagSearch
+		}
+		if q.MinNumber != nil && q.MaxNumber != nil && *q.MinNumber > *q.MaxNumber {
+			return nil, domain.ErrInvalidTagSearch
+		}
+	case domain.TagValueDate:
+		if q.MinDate, err = optionalBound(input.Min, domain.ParseTagDate); err != nil {
+			return nil, domain.ErrInvalidTagSearch
+		}
+		if q.MaxDate, err = optionalBound(input.Max, domain.ParseTagDate); err != nil {
+			return nil, domain.ErrInvalidTagSearch
+		}
+		if q.MinDate != nil && q.MaxDate != nil && q.MinDate.After(*q.MaxDate) {
+			return nil, domain.ErrInvalidTagSearch
+		}
+	default:
+		return nil, domain.ErrInvalidTagSearch
+	}
+	return q, nil
+}
+
+// optionalBound parses a range bound; an empty bound is open.
+func optionalBound[T any](s string, parse func(string) (T, error)) (*T, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (s *documentService) EnrichDocuments(ctx context.Context, tenantID uuid.UUID, docs []domain.Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	docIDs := make([]uuid.UUID, len(docs))
+	var assigneeIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for i := range docs {
+		docIDs[i] = docs[i].ID
+		if a := docs[i].AssignedTo; a != nil && !seen[*a] {
+			seen[*a] = true
+			assigneeIDs = append(assigneeIDs, *a)
+		}
+	}
+
+	tags, err := s.tagRepo.ListByDocuments(ctx, tenantID, docIDs)
+	if err != nil {
+		return fmt.Errorf("loading document tags: %w", err)
+	}
+
+	names := make(map[uuid.UUID]string, len(assigneeIDs))
+	if len(assigneeIDs) > 0 {
+		users, err := s.userRepo.GetByIDs(ctx, tenantID, assigneeIDs)
+		if err != nil {
+			return fmt.Errorf("loading assignees: %w", err)
+		}
+		for i := range users {
+			names[users[i].ID] = users[i].FullName
+		}
+	}
+
+	for i := range docs {
+		docs[i].Tags = tags[docs[i].ID]
+		if a := docs[i].AssignedTo; a != nil {
+			if name, ok := names[*a]; ok {
+				docs[i].AssigneeName = &name
+			}
+		}
+	}
+	return nil
 }
 
 func (s *documentService) extractAndSaveAutoTags(ctx context.Context, docID, tenantID uuid.UUID, structuredData json.RawMessage) {
@@ -1053,14 +1799,26 @@ func (s *documentService) extractAndSaveAutoTags(ctx context.Context, docID, ten
 
 	tags := make([]domain.DocumentTag, 0, len(tagMap))
 	for k, v := range tagMap {
-		tags = append(tags, domain.DocumentTag{
+		tag := domain.DocumentTag{
 			ID:         uuid.New(),
 			DocumentID: docID,
 			TenantID:   tenantID,
 			Key:        k,
 			Value:      v,
+			ValueType:  domain.TagValueString,
 			Source:     "auto",
-		})
+		}
+		// Amounts and dates are typed for range search. A timestamp's date depends
+		// on the tenant's time zone, so it stays a string tag.
+		switch k {
+		case "total_amount":
+			_ = tag.SetValueType(domain.TagValueNumber)
+		case "invoice_date":
+			if d := parseInvoiceDate(v, time.UTC); d != nil && !hasTimeOfDay(v) {
+				tag.ValueType, tag.DateValue = domain.TagValueDate, d
+			}
+		}
+		tags = append(tags, tag)
 	}
 
 	if err := s.tagRepo.CreateBatch(ctx, tags); err != nil {
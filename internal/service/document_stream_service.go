@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	documentStreamDefaultLimit = 1000
+	documentStreamMaxLimit     = 10000
+	documentStreamBatchSize    = 500
+	// documentStreamSettle holds back the newest changes, so a write that
+	// commits after a later one is not skipped by a cursor already past it.
+	documentStreamSettle = 5 * time.Second
+)
+
+// DocumentStreamRecord types.
+const (
+	DocumentStreamDocument  = "document"
+	DocumentStreamTombstone = "tombstone"
+	DocumentStreamEnd       = "end"
+)
+
+// DocumentStreamRecord is one line of the document change stream: a changed
+// document, the tombstone of a deleted one, or the end of the response.
+type DocumentStreamRecord struct {
+	Type string `json:"type"`
+	// Cursor resumes the stream after this record.
+	Cursor       string           `json:"cursor"`
+	ChangedAt    *time.Time       `json:"changed_at,omitempty"`
+	ID           *uuid.UUID       `json:"id,omitempty"`
+	CollectionID *uuid.UUID       `json:"collection_id,omitempty"`
+	Document     *domain.Document `json:"document,omitempty"`
+	// HasMore is set on the end record when the limit cut the response short.
+	HasMore bool `json:"has_more,omitempty"`
+}
+
+// StreamDocumentsInput is the query of the document change stream.
+type StreamDocumentsInput struct {
+	TenantID     uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	CollectionID *uuid.UUID
+	// Since is a cursor from an earlier response; empty streams every document.
+	Since string
+	Limit int
+}
+
+// DocumentStreamService streams the documents changed since a cursor, with
+// tombstones for deleted ones, for incremental sync into a customer's store.
+type DocumentStreamService interface {
+	// Stream calls emit for each change after input.Since, oldest first, then once
+	// with an end record. An invalid cursor or collection fails before any emit.
+	Stream(ctx context.Context, input *StreamDocumentsInput, emit func(*DocumentStreamRecord) error) error
+}
+
+type documentStreamService struct {
+	repo          port.DocumentChangeRepository
+	collectionSvc CollectionService
+}
+
+// NewDocumentStreamService creates a new DocumentStreamService.
+func NewDocumentStreamService(repo port.DocumentChangeRepository, collectionSvc CollectionService) DocumentStreamService {
+	return &documentStreamService{repo: repo, collectionSvc: collectionSvc}
+}
+
+func (s *documentStreamService) Stream(ctx context.Context, input *StreamDocumentsInput, emit func(*DocumentStreamRecord) error) error {
+	limit := input.Limit
+	if limit <= 0 || limit > documentStreamMaxLimit {
+		limit = documentStreamDefaultLimit
+	}
+	var afterAt time.Time
+	var afterID uuid.UUID
+	if input.Since != "" {
+		// Same (time, id) encoding as the approved-document feed
+		var err error
+		if afterAt, afterID, err = decodeApprovalCursor(input.Since); err != nil {
+			return err
+		}
+	}
+	if input.CollectionID != nil {
+		if _, err := s.collectionSvc.GetByID(ctx, input.TenantID, *input.CollectionID, input.UserID, input.Role); err != nil {
+			return err
+		}
+	}
+	explicitOnly := domain.ImplicitCollectionPerm(input.Role) == ""
+
+	cursor := input.Since
+	sent, hasMore := 0, false
+	for sent < limit {
+		batch := min(documentStreamBatchSize, limit-sent)
+		changes, err := s.repo.ListSince(ctx, input.TenantID, input.UserID, explicitOnly, input.CollectionID,
+			afterAt, afterID, documentStreamSettle, batch)
+		if err != nil {
+			return err
+		}
+		ids := make([]uuid.UUID, 0, len(changes))
+		for i := range changes {
+			if !changes[i].Deleted {
+				ids = append(ids, changes[i].DocumentID)
+			}
+		}
+		docs, err := s.repo.ListDocuments(ctx, input.TenantID, ids)
+		if err != nil {
+			return err
+		}
+
+		for i := range changes {
+			ch := &changes[i]
+			afterAt, afterID = ch.ChangedAt, ch.DocumentID
+			cursor = encodeApprovalCursor(afterAt, afterID)
+			rec := &DocumentStreamRecord{Cursor: cursor, ChangedAt: &ch.ChangedAt, ID: &ch.DocumentID, CollectionID: &ch.CollectionID}
+			if ch.Deleted {
+				rec.Type = DocumentStreamTombstone
+			} else {
+				doc, ok := docs[ch.DocumentID]
+				if !ok {
+					// Deleted since it was listed; its tombstone follows in a later response
+					continue
+				}
+				rec.Type, rec.Document = DocumentStreamDocument, &doc
+			}
+			if err := emit(rec); err != nil {
+				return err
+			}
+			sent++
+		}
+		if len(changes) < batch {
+			break
+		}
+		hasMore = sent >= limit
+	}
+
+	return emit(&DocumentStreamRecord{Type: DocumentStreamEnd, Cursor: cursor, HasMore: hasMore})
+}
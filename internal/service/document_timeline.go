@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// maxTimelineAuditEntries caps the audit entries loaded into one timeline.
+const maxTimelineAuditEntries = 1000
+
+// GetTimeline assembles a document's processing history: every audit entry plus one
+// event per parse attempt (placed at the start of the parser call, with its duration
+// and queue wait), in chronological order with the gap since the previous event.
+func (s *documentService) GetTimeline(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*domain.DocumentTimeline, error) {
+	doc, err := s.docRepo.GetByID(ctx, tenantID, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+
+	tl := &domain.DocumentTimeline{
+		DocumentID:           doc.ID,
+		ParsingStatus:        doc.ParsingStatus,
+		ParseFailureCategory: doc.ParseFailureCategory,
+		ReviewStatus:         doc.ReviewStatus,
+		CreatedAt:            doc.CreatedAt,
+		Events:               []domain.TimelineEvent{},
+	}
+
+	if s.auditRepo != nil {
+		entries, _, err := s.auditRepo.ListByDocument(ctx, tenantID, docID, 0, maxTimelineAuditEntries)
+		if err != nil {
+			return nil, err
+		}
+		for i := range entries {
+			e := &entries[i]
+			tl.Events = append(tl.Events, domain.TimelineEvent{
+				At:      e.CreatedAt,
+				Type:    e.Action,
+				UserID:  e.UserID,
+				Details: e.Changes,
+			})
+		}
+	}
+
+	if s.timingRepo != nil {
+		timings, err := s.timingRepo.ListByDocument(ctx, tenantID, docID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range timings {
+			t := &timings[i]
+			parseMs := t.ParseMs
+			details, _ := json.Marshal(map[string]interface{}{
+				"parser_model": t.ParserModel, "parse_mode": t.ParseMode, "outcome": t.Outcome,
+				"failure_category": t.FailureCategory, "queue_ms": t.QueueMs,
+			})
+			tl.Events = append(tl.Events, domain.TimelineEvent{
+				At:         t.CreatedAt.Add(-time.Duration(parseMs) * time.Millisecond),
+				Type:       domain.TimelineParseAttempt,
+				DurationMs: &parseMs,
+				Details:    details,
+			})
+			tl.ParseAttempts++
+			tl.TotalQueueMs += t.QueueMs
+			tl.TotalParseMs += t.ParseMs
+		}
+	}
+
+	sort.SliceStable(tl.Events, func(i, j int) bool { return tl.Events[i].At.Before(tl.Events[j].At) })
+	prev := doc.CreatedAt
+	for i := range tl.Events {
+		ev := &tl.Events[i]
+		if ev.At.After(prev) {
+			ev.SincePreviousMs = ev.At.Sub(prev).Milliseconds()
+			prev = ev.At
+		}
+	}
+	tl.ElapsedMs = prev.Sub(doc.CreatedAt).Milliseconds()
+	return tl, nil
+}
+
+// ListParseAttempts returns the document's parse attempts, oldest first, with the
+// provider, durations, outcome, error and token usage of each.
+func (s *documentService) ListParseAttempts(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.ParseTiming, error) {
+	doc, err := s.docRepo.GetByID(ctx, tenantID, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+	if s.timingRepo == nil {
+		return []domain.ParseTiming{}, nil
+	}
+	return s.timingRepo.ListByDocument(ctx, tenantID, docID)
+}
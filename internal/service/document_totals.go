@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+// TotalsRecomputation compares a document's stored invoice totals with the
+// totals recomputed from its line items.
+type TotalsRecomputation struct {
+	DocumentID uuid.UUID             `json:"document_id"`
+	Stored     invoice.Totals        `json:"stored"`
+	Recomputed invoice.Totals        `json:"recomputed"`
+	Deltas     []invoice.TotalsDelta `json:"deltas"`
+	Balanced   bool                  `json:"balanced"`
+}
+
+// RecomputeTotals recalculates a parsed document's totals from its line items
+// and reports how they differ from the stored ones. Nothing is saved; the
+// reviewer applies the recomputed totals with EditStructuredData.
+func (s *documentService) RecomputeTotals(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*TotalsRecomputation, error) {
+	doc, err := s.docRepo.GetByID(ctx, tenantID, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+	if doc.ParsingStatus != domain.ParsingStatusCompleted {
+		return nil, domain.ErrDocumentNotParsed
+	}
+
+	var inv invoice.GSTInvoice
+	if err := json.Unmarshal(doc.StructuredData, &inv); err != nil {
+		return nil, domain.ErrInvalidStructuredData
+	}
+	recomputed := invoice.RecomputeTotals(&inv)
+	deltas := invoice.DiffTotals(&inv.Totals, &recomputed)
+	return &TotalsRecomputation{
+		DocumentID: doc.ID,
+		Stored:     inv.Totals,
+		Recomputed: recomputed,
+		Deltas:     deltas,
+		Balanced:   len(deltas) == 0,
+	}, nil
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// maxDocumentTypeLen is the width of documents.document_type.
+const maxDocumentTypeLen = 50
+
+// ReplaceFileInput is the DTO for replacing the file behind a document.
+type ReplaceFileInput struct {
+	TenantID   uuid.UUID
+	DocumentID uuid.UUID
+	FileID     uuid.UUID
+	UserID     uuid.UUID
+	Role       domain.UserRole
+}
+
+// ReplaceFile points a document at a new upload of the same paper (a better scan,
+// say). The old file and everything derived from it are kept as a version, the
+// document goes back to pending parse and review, and it is parsed again. Field
+// overrides survive and are re-applied to the new parse. The replacement counts
+// against the caller's quota like a new document.
+func (s *documentService) ReplaceFile(ctx context.Context, input *ReplaceFileInput) (*domain.Document, error) {
+	doc, err := s.docRepo.GetByID(ctx, input.TenantID, input.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, input.UserID, input.Role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+	if doc.FileID == input.FileID {
+		return nil, domain.ErrDocumentAlreadyExists
+	}
+	// A parse still running would write its result over the reset document
+	switch doc.ParsingStatus {
+	case domain.ParsingStatusPending, domain.ParsingStatusProcessing, domain.ParsingStatusQueued:
+		return nil, domain.ErrDocumentParseInProgress
+	}
+
+	if _, err := s.fileRepo.GetByID(ctx, input.TenantID, input.FileID); err != nil {
+		return nil, fmt.Errorf("looking up replacement file: %w", err)
+	}
+	if err := s.userRepo.CheckAndIncrementQuota(ctx, input.TenantID, input.UserID); err != nil {
+		return nil, err
+	}
+	if err := reserveParse(ctx, s.budget, input.TenantID, input.UserID); err != nil {
+		return nil, err
+	}
+
+	oldFileID := doc.FileID
+	prev := versionSnapshot(doc, domain.DocumentVersionFileReplaced, input.UserID)
+
+	doc.FileID = input.FileID
+	doc.Version++
+	resetForNewFile(doc)
+	if err := s.docRepo.SaveVersion(ctx, doc, prev); err != nil {
+		return nil, err
+	}
+
+	changesJSON, _ := json.Marshal(map[string]interface{}{
+		"previous_file_id": oldFileID, "file_id": doc.FileID,
+		"previous_version": prev.Version, "version": doc.Version,
+	})
+	s.audit(ctx, doc.TenantID, doc.ID, &input.UserID, domain.AuditDocumentFileReplaced, changesJSON)
+
+	// Auto-tags and summary statuses follow the reset document
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventVersioned, Document: doc, ActorID: &input.UserID})
+
+	log.Printf("documentService.ReplaceFile: document %s now at version %d (file %s), re-parsing", doc.ID, doc.Version, doc.FileID)
+
+	// Copy before launching goroutine so the caller's value is independent of background work
+	result := *doc
+
+	go s.parseInBackground(doc.ID, doc.TenantID)
+
+	return &result, nil
+}
+
+// ReclassifyInput is the DTO for changing a document's type.
+type ReclassifyInput struct {
+	TenantID     uuid.UUID
+	DocumentID   uuid.UUID
+	DocumentType string
+	UserID       uuid.UUID
+	Role         domain.UserRole
+}
+
+// Reclassify changes the type of a document the parser misidentified (a credit
+// note read as an invoice, say). The current parse, review and validation are
+// kept as a version and the document is parsed again with the new type's prompt,
+// which also re-runs that type's validation rules. Unlike ReplaceFile it spends
+// only a parse, not a document of the quota.
+func (s *documentService) Reclassify(ctx context.Context, input *ReclassifyInput) (*domain.Document, error) {
+	docType := strings.TrimSpace(input.DocumentType)
+	if docType == "" || len(docType) > maxDocumentTypeLen {
+		return nil, domain.ErrInvalidDocumentType
+	}
+
+	doc, err := s.docRepo.GetByID(ctx, input.TenantID, input.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, input.UserID, input.Role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+	if doc.DocumentType == docType {
+		return nil, domain.ErrInvalidDocumentType
+	}
+	switch doc.ParsingStatus {
+	case domain.ParsingStatusPending, domain.ParsingStatusProcessing, domain.ParsingStatusQueued:
+		return nil, domain.ErrDocumentParseInProgress
+	}
+
+	if _, err := s.fileRepo.GetByID(ctx, input.TenantID, doc.FileID); err != nil {
+		return nil, fmt.Errorf("looking up file for reclassify: %w", err)
+	}
+	if err := reserveParse(ctx, s.budget, input.TenantID, input.UserID); err != nil {
+		return nil, err
+	}
+
+	prev := versionSnapshot(doc, domain.DocumentVersionReclassified, input.UserID)
+
+	doc.DocumentType = docType
+	doc.Version++
+	resetForNewFile(doc)
+	if err := s.docRepo.SaveVersion(ctx, doc, prev); err != nil {
+		return nil, err
+	}
+
+	changesJSON, _ := json.Marshal(map[string]interface{}{
+		"previous_document_type": prev.DocumentType, "document_type": doc.DocumentType,
+		"previous_version": prev.Version, "version": doc.Version,
+	})
+	s.audit(ctx, doc.TenantID, doc.ID, &input.UserID, domain.AuditDocumentReclassified, changesJSON)
+
+	// Auto-tags and summary statuses follow the reset document
+	s.events.Publish(ctx, &DocumentEvent{Kind: DocumentEventVersioned, Document: doc, ActorID: &input.UserID})
+
+	log.Printf("documentService.Reclassify: document %s reclassified %s -> %s at version %d, re-parsing",
+		doc.ID, prev.DocumentType, doc.DocumentType, doc.Version)
+
+	// Copy before launching goroutine so the caller's value is independent of background work
+	result := *doc
+
+	go s.parseInBackground(doc.ID, doc.TenantID)
+
+	return &result, nil
+}
+
+// ListVersions returns the superseded versions of a document, newest first.
+func (s *documentService) ListVersions(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentVersion, error) {
+	doc, err := s.docRepo.GetByID(ctx, tenantID, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.docRepo.ListVersions(ctx, tenantID, docID)
+	if err != nil {
+		return nil, err
+	}
+	if versions == nil {
+		versions = []domain.DocumentVersion{}
+	}
+	return versions, nil
+}
+
+// ListApprovals returns the approval snapshots of a document, newest first.
+func (s *documentService) ListApprovals(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentApproval, error) {
+	doc, err := s.docRepo.GetByID(ctx, tenantID, docID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireCollectionPerm(ctx, doc.CollectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.docRepo.ListApprovals(ctx, tenantID, docID)
+	if err != nil {
+		return nil, err
+	}
+	if approvals == nil {
+		approvals = []domain.DocumentApproval{}
+	}
+	return approvals, nil
+}
+
+// versionSnapshot captures doc as the version being superseded for reason.
+func versionSnapshot(doc *domain.Document, reason domain.DocumentVersionReason, userID uuid.UUID) *domain.DocumentVersion {
+	fileID := doc.FileID
+	return &domain.DocumentVersion{
+		ID:                uuid.New(),
+		TenantID:          doc.TenantID,
+		DocumentID:        doc.ID,
+		Version:           doc.Version,
+		FileID:            &fileID,
+		DocumentType:      doc.DocumentType,
+		ParserModel:       doc.ParserModel,
+		StructuredData:    nonEmptyJSON(doc.StructuredData, "{}"),
+		ConfidenceScores:  nonEmptyJSON(doc.ConfidenceScores, "{}"),
+		FieldProvenance:   nonEmptyJSON(doc.FieldProvenance, "{}"),
+		ParsingStatus:     doc.ParsingStatus,
+		ParsedAt:          doc.ParsedAt,
+		ReviewStatus:      doc.ReviewStatus,
+		ReviewedBy:        doc.ReviewedBy,
+		ReviewedAt:        doc.ReviewedAt,
+		ValidationStatus:  doc.ValidationStatus,
+		ValidationResults: nonEmptyJSON(doc.ValidationResults, "[]"),
+		Reason:            reason,
+		ReplacedBy:        userID,
+	}
+}
+
+// resetForNewFile clears everything derived from the previous file: parse output,
+// review decision, validation results and assignment. The document is left
+// pending, ready for parseInBackground.
+func resetForNewFile(doc *domain.Document) {
+	doc.StructuredData = json.RawMessage("{}")
+	doc.ConfidenceScores = json.RawMessage("{}")
+	doc.FieldProvenance = json.RawMessage("{}")
+	doc.ParserModel = ""
+	doc.ParserPrompt = ""
+	doc.SecondaryParserModel = ""
+	doc.ParsingStatus = domain.ParsingStatusPending
+	doc.ParsingError = ""
+	doc.ParseFailureCategory = ""
+	doc.ParsedAt = nil
+	doc.ParseAttempts = 0
+	doc.RetryAfter = nil
+
+	doc.ReviewStatus = domain.ReviewStatusPending
+	doc.ReviewedBy = nil
+	doc.ReviewedAt = nil
+	doc.ReviewerNotes = ""
+	doc.ReviewChecklist = json.RawMessage("[]")
+	doc.RejectionReason = ""
+	doc.MakerApprovedBy = nil
+	doc.MakerApprovedAt = nil
+	doc.EscalatedAt = nil
+
+	doc.ValidationStatus = domain.ValidationStatusPending
+	doc.ValidationResults = json.RawMessage("[]")
+	doc.ReconciliationStatus = domain.ReconciliationStatusPending
+
+	doc.AssignedTo = nil
+	doc.AssignedAt = nil
+	doc.AssignedBy = nil
+}
+
+// nonEmptyJSON returns raw, or fallback when raw is empty.
+func nonEmptyJSON(raw json.RawMessage, fallback string) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage(fallback)
+	}
+	return raw
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/validator/invoice"
+)
+
+const (
+	defaultDuplicateMinScore = 0.5
+	defaultDuplicateLimit    = 10
+	maxDuplicateLimit        = 50
+	// duplicateCandidatePool bounds how many of the seller's invoices are scored.
+	duplicateCandidatePool = 500
+	// Amounts further apart than duplicateAmountTolerance (relative) or dates
+	// further apart than duplicateDateWindowDays score zero on that signal.
+	duplicateAmountTolerance = 0.05
+	duplicateDateWindowDays  = 30
+	// Signal weights; they sum to 1.
+	duplicateNumberWeight = 0.4
+	duplicateAmountWeight = 0.4
+	duplicateDateWeight   = 0.2
+)
+
+// Reasons a candidate is reported as a possible duplicate.
+const (
+	DuplicateReasonSameInvoiceNumber    = "same_invoice_number"
+	DuplicateReasonSimilarInvoiceNumber = "similar_invoice_number"
+	DuplicateReasonSameAmount           = "same_amount"
+	DuplicateReasonSimilarAmount        = "similar_amount"
+	DuplicateReasonSameDate             = "same_date"
+	DuplicateReasonCloseDate            = "close_date"
+)
+
+// DuplicateSignals are the per-signal similarities, each from 0 to 1.
+type DuplicateSignals struct {
+	InvoiceNumber float64 `json:"invoice_number"`
+	Amount        float64 `json:"amount"`
+	Date          float64 `json:"date"`
+}
+
+// ScoredDuplicate is a possible duplicate of a document: another invoice from the
+// same seller, with its similarity score and the signals behind it.
+type ScoredDuplicate struct {
+	DocumentID    uuid.UUID           `json:"document_id"`
+	DocumentName  string              `json:"document_name"`
+	CollectionID  uuid.UUID           `json:"collection_id"`
+	InvoiceNumber string              `json:"invoice_number"`
+	InvoiceDate   *time.Time          `json:"invoice_date"`
+	TotalAmount   float64             `json:"total_amount"`
+	ReviewStatus  domain.ReviewStatus `json:"review_status"`
+	CreatedAt     time.Time           `json:"created_at"`
+	// Score is the weighted similarity: 40% invoice number, 40% amount, 20% date.
+	Score   float64          `json:"score"`
+	Signals DuplicateSignals `json:"signals"`
+	Reasons []string         `json:"reasons"`
+}
+
+// DuplicateQuery tunes a duplicate search. Zero values use the defaults
+// (min score 0.5, limit 10).
+type DuplicateQuery struct {
+	MinScore float64
+	Limit    int
+}
+
+// DuplicateService finds likely duplicates of a document, including
+// resubmissions under a new invoice number.
+type DuplicateService interface {
+	// FindForDocument ranks the other invoices of the document's seller by
+	// similarity, best first. The document must be parsed and have a seller GSTIN.
+	FindForDocument(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, q DuplicateQuery) ([]ScoredDuplicate, error)
+}
+
+type duplicateService struct {
+	docSvc         DocumentService
+	finder         port.DuplicateInvoiceFinder
+	collectionRepo port.CollectionRepository
+}
+
+// NewDuplicateService creates a new DuplicateService. collectionRepo resolves
+// the tenant's time zone for invoice dates with a time of day.
+func NewDuplicateService(docSvc DocumentService, finder port.DuplicateInvoiceFinder, collectionRepo port.CollectionRepository) DuplicateService {
+	return &duplicateService{docSvc: docSvc, finder: finder, collectionRepo: collectionRepo}
+}
+
+func (s *duplicateService) FindForDocument(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, q DuplicateQuery) ([]ScoredDuplicate, error) {
+	// GetByID checks the caller can view the document
+	doc, err := s.docSvc.GetByID(ctx, tenantID, docID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if doc.ParsingStatus != domain.ParsingStatusCompleted {
+		return nil, domain.ErrDocumentNotParsed
+	}
+	var inv invoice.GSTInvoice
+	if err := json.Unmarshal(doc.StructuredData, &inv); err != nil {
+		return nil, domain.ErrInvalidStructuredData
+	}
+	results := []ScoredDuplicate{}
+	gstin := strings.TrimSpace(inv.Seller.GSTIN)
+	if gstin == "" {
+		return results, nil
+	}
+
+	minScore := q.MinScore
+	if minScore <= 0 {
+		minScore = defaultDuplicateMinScore
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultDuplicateLimit
+	}
+	if limit > maxDuplicateLimit {
+		limit = maxDuplicateLimit
+	}
+
+	candidates, err := s.finder.FindCandidates(ctx, tenantID, docID, gstin, userID, role, duplicateCandidatePool)
+	if err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if hasTimeOfDay(inv.Invoice.InvoiceDate) {
+		loc = collectionLocation(ctx, s.collectionRepo, tenantID, doc.CollectionID)
+	}
+	invoiceDate := parseInvoiceDate(inv.Invoice.InvoiceDate, loc)
+	for i := range candidates {
+		c := &candidates[i]
+		signals := DuplicateSignals{
+			InvoiceNumber: invoiceNumberSimilarity(inv.Invoice.InvoiceNumber, c.InvoiceNumber),
+			Amount:        amountSimilarity(inv.Totals.Total, c.TotalAmount),
+			Date:          dateSimilarity(invoiceDate, c.InvoiceDate),
+		}
+		score := duplicateNumberWeight*signals.InvoiceNumber + duplicateAmountWeight*signals.Amount + duplicateDateWeight*signals.Date
+		score = math.Round(score*100) / 100
+		if score < minScore {
+			continue
+		}
+		results = append(results, ScoredDuplicate{
+			DocumentID:    c.DocumentID,
+			DocumentName:  c.DocumentName,
+			CollectionID:  c.CollectionID,
+			InvoiceNumber: c.InvoiceNumber,
+			InvoiceDate:   c.InvoiceDate,
+			TotalAmount:   c.TotalAmount,
+			ReviewStatus:  c.ReviewStatus,
+			CreatedAt:     c.CreatedAt,
+			Score:         score,
+			Signals:       signals,
+			Reasons:       duplicateReasons(signals),
+		})
+	}
+
+	// Candidates arrive newest first, so equal scores stay newest first
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func duplicateReasons(s DuplicateSignals) []string {
+	reasons := []string{}
+	switch {
+	case s.InvoiceNumber == 1:
+		reasons = append(reasons, DuplicateReasonSameInvoiceNumber)
+	case s.InvoiceNumber >= 0.7:
+		reasons = append(reasons, DuplicateReasonSimilarInvoiceNumber)
+	}
+	switch {
+	case s.Amount == 1:
+		reasons = append(reasons, DuplicateReasonSameAmount)
+	case s.Amount > 0:
+		reasons = append(reasons, DuplicateReasonSimilarAmount)
+	}
+	switch {
+	case s.Date == 1:
+		reasons = append(reasons, DuplicateReasonSameDate)
+	case s.Date > 0:
+		reasons = append(reasons, DuplicateReasonCloseDate)
+	}
+	return reasons
+}
+
+// invoiceNumberSimilarity compares invoice numbers ignoring case and separators:
+// 1 for the same number, 0.9 when the serial matches but the prefix or padding
+// differs ("INV/24/0012" vs "12"), else one minus the normalized edit distance.
+func invoiceNumberSimilarity(a, b string) float64 {
+	na, nb := normalizeInvoiceNumber(a), normalizeInvoiceNumber(b)
+	if na == "" || nb == "" {
+		return 0
+	}
+	if na == nb {
+		return 1
+	}
+	if da, db := trailingDigits(a), trailingDigits(b); da != "" && da == db {
+		return 0.9
+	}
+	ra, rb := []rune(na), []rune(nb)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	sim := 1 - float64(editDistance(ra, rb))/float64(longest)
+	return math.Round(sim*100) / 100
+}
+
+func normalizeInvoiceNumber(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// trailingDigits returns the last run of digits in s, ignoring anything after
+// it and leading zeros: the serial part of "INV/24/0012-A" is "12".
+func trailingDigits(s string) string {
+	end := strings.LastIndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' }) + 1
+	start := end
+	for start > 0 && s[start-1] >= '0' && s[start-1] <= '9' {
+		start--
+	}
+	return strings.TrimLeft(s[start:end], "0")
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// amountSimilarity is 1 for totals within a rupee of each other, falling to 0
+// at duplicateAmountTolerance apart. Zero totals carry no signal.
+func amountSimilarity(a, b float64) float64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	diff := math.Abs(a - b)
+	if diff <= 1 {
+		return 1
+	}
+	rel := diff / math.Max(math.Abs(a), math.Abs(b))
+	if rel >= duplicateAmountTolerance {
+		return 0
+	}
+	return math.Round((1-rel/duplicateAmountTolerance)*100) / 100
+}
+
+// dateSimilarity is 1 for the same invoice date, falling to 0 at
+// duplicateDateWindowDays apart. A missing date carries no signal.
+func dateSimilarity(a, b *time.Time) float64 {
+	if a == nil || b == nil {
+		return 0
+	}
+	days := math.Abs(a.Sub(*b).Hours()) / 24
+	if days >= duplicateDateWindowDays {
+		return 0
+	}
+	return math.Round((1-days/duplicateDateWindowDays)*100) / 100
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// EmailFeedbackService processes SES bounce and complaint notifications
+// delivered by SNS, and lets admins lift the resulting suppressions.
+type EmailFeedbackService interface {
+	// HandleSNS verifies an SNS delivery from the configured topic, confirms
+	// subscriptions, and suppresses the addresses of permanent bounces and
+	// complaints, alerting the admins of each affected user's tenant.
+	HandleSNS(ctx context.Context, body []byte) error
+	// ClearSuppression lifts the suppression of a user's address once the
+	// mailbox is fixed.
+	ClearSuppression(ctx context.Context, tenantID, userID uuid.UUID) error
+}
+
+type emailFeedbackService struct {
+	verifier     port.SNSVerifier
+	suppressions port.EmailSuppressionRepository
+	userRepo     port.UserRepository
+	notifier     port.Notifier
+	topicARN     string
+}
+
+// NewEmailFeedbackService creates a new EmailFeedbackService. With an empty
+// topicARN every SNS delivery is rejected.
+func NewEmailFeedbackService(
+	verifier port.SNSVerifier,
+	suppressions port.EmailSuppressionRepository,
+	userRepo port.UserRepository,
+	notifier port.Notifier,
+	topicARN string,
+) EmailFeedbackService {
+	return &emailFeedbackService{
+		verifier:     verifier,
+		suppressions: suppressions,
+		userRepo:     userRepo,
+		notifier:     notifier,
+		topicARN:     topicARN,
+	}
+}
+
+// sesNotification is the part of an SES bounce or complaint notification the
+// service reads. Notifications use notificationType; configuration set event
+// publishing uses eventType.
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           *struct {
+		BounceType        string `json:"bounceType"`
+		BounceSubType     string `json:"bounceSubType"`
+		FeedbackID        string `json:"feedbackId"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint *struct {
+		ComplaintFeedbackType string `json:"complaintFeedbackType"`
+		FeedbackID            string `json:"feedbackId"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+}
+
+func (s *emailFeedbackService) HandleSNS(ctx context.Context, body []byte) error {
+	if s.topicARN == "" {
+		return fmt.Errorf("%w: no SNS topic is configured", domain.ErrInvalidSNSMessage)
+	}
+	msg, err := s.verifier.Verify(ctx, body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidSNSMessage, err)
+	}
+	if msg.TopicARN != s.topicARN {
+		return fmt.Errorf("%w: unexpected topic %s", domain.ErrInvalidSNSMessage, msg.TopicARN)
+	}
+
+	switch msg.Type {
+	case "SubscriptionConfirmation":
+		if err := s.verifier.ConfirmSubscription(ctx, msg); err != nil {
+			return err
+		}
+		log.Printf("emailFeedbackService: confirmed SNS subscription to %s", msg.TopicARN)
+		return nil
+	case "Notification":
+	default:
+		return nil
+	}
+
+	var n sesNotification
+	if err := json.Unmarshal([]byte(msg.Message), &n); err != nil {
+		return fmt.Errorf("%w: message %s is not an SES notification", domain.ErrInvalidSNSMessage, msg.MessageID)
+	}
+	for _, sup := range suppressionsOf(&n) {
+		s.suppress(ctx, sup)
+	}
+	return nil
+}
+
+// suppressionsOf returns the addresses a notification makes undeliverable.
+// Transient and undetermined bounces are left to SES's own retries.
+func suppressionsOf(n *sesNotification) []*domain.EmailSuppression {
+	var out []*domain.EmailSuppression
+	kind := n.NotificationType
+	if kind == "" {
+		kind = n.EventType
+	}
+	switch {
+	case kind == "Bounce" && n.Bounce != nil && n.Bounce.BounceType == "Permanent":
+		for _, r := range n.Bounce.BouncedRecipients {
+			detail := n.Bounce.BounceSubType
+			if r.DiagnosticCode != "" {
+				detail += ": " + r.DiagnosticCode
+			}
+			out = append(out, &domain.EmailSuppression{
+				Email:      bareAddress(r.EmailAddress),
+				Reason:     domain.EmailSuppressionBounce,
+				Detail:     detail,
+				FeedbackID: n.Bounce.FeedbackID,
+			})
+		}
+	case kind == "Complaint" && n.Complaint != nil:
+		for _, r := range n.Complaint.ComplainedRecipients {
+			out = append(out, &domain.EmailSuppression{
+				Email:      bareAddress(r.EmailAddress),
+				Reason:     domain.EmailSuppressionComplaint,
+				Detail:     n.Complaint.ComplaintFeedbackType,
+				FeedbackID: n.Complaint.FeedbackID,
+			})
+		}
+	}
+	return out
+}
+
+// bareAddress strips a display name SES may include with a recipient.
+func bareAddress(raw string) string {
+	if addr, err := mail.ParseAddress(raw); err == nil {
+		return addr.Address
+	}
+	return strings.TrimSpace(raw)
+}
+
+// suppress records one suppression and alerts the admins of every tenant with a
+// user at the address. Failures are logged: SNS retries only on delivery errors,
+// and one bad recipient must not block the others.
+func (s *emailFeedbackService) suppress(ctx context.Context, sup *domain.EmailSuppression) {
+	users, err := s.suppressions.Suppress(ctx, sup)
+	if err != nil {
+		log.Printf("emailFeedbackService: failed to suppress %s: %v", sup.Email, err)
+		return
+	}
+	log.Printf("emailFeedbackService: suppressed %s after a %s (%d users)", sup.Email, sup.Reason, len(users))
+
+	for i := range users {
+		s.alertAdmins(ctx, &users[i], sup)
+	}
+}
+
+func (s *emailFeedbackService) alertAdmins(ctx context.Context, user *domain.User, sup *domain.EmailSuppression) {
+	admins, err := tenantAdmins(ctx, s.userRepo, user.TenantID)
+	if err != nil {
+		log.Printf("emailFeedbackService: listing admins of tenant %s failed: %v", user.TenantID, err)
+		return
+	}
+	if len(admins) == 0 {
+		return
+	}
+
+	what := "a permanent bounce"
+	if sup.Reason == domain.EmailSuppressionComplaint {
+		what = "a spam complaint"
+	}
+	n := &port.Notification{
+		Kind:     domain.NotificationKindEmailUndeliverable,
+		TenantID: user.TenantID,
+		Subject:  fmt.Sprintf("Email to %s is undeliverable", user.Email),
+		Body: fmt.Sprintf("Email to %s (%s) returned %s, so SATVOS no longer emails this address, including verification and password reset links. "+
+			"Fix or change the user's address, then clear the suppression on the user.", user.Email, user.FullName, what),
+	}
+	if sup.Detail != "" {
+		n.Body += "\n\nDetails: " + sup.Detail
+	}
+	for i := range admins {
+		n.Recipients = append(n.Recipients, port.NotificationRecipient{
+			UserID: admins[i].ID, Email: admins[i].Email, Name: admins[i].FullName,
+		})
+	}
+	if err := s.notifier.Notify(ctx, n); err != nil {
+		log.Printf("emailFeedbackService: alerting admins of tenant %s about %s failed: %v", user.TenantID, user.Email, err)
+	}
+}
+
+func (s *emailFeedbackService) ClearSuppression(ctx context.Context, tenantID, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.suppressions.Delete(ctx, tenantID, user.Email); err != nil {
+		return err
+	}
+	log.Printf("emailFeedbackService: cleared suppression of %s (user %s)", user.Email, user.ID)
+	return nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// suppressingEmailSender wraps an EmailSender and refuses to send to addresses
+// SES reported as bouncing or complaining, with domain.ErrEmailUndeliverable, so
+// callers such as resend-verification report the problem instead of losing the
+// email. A failed lookup sends anyway: a lost email is worse than a bounce.
+type suppressingEmailSender struct {
+	next         port.EmailSender
+	suppressions port.EmailSuppressionRepository
+}
+
+// NewSuppressingEmailSender wraps sender so suppressed addresses are never emailed.
+func NewSuppressingEmailSender(sender port.EmailSender, suppressions port.EmailSuppressionRepository) port.EmailSender {
+	return &suppressingEmailSender{next: sender, suppressions: suppressions}
+}
+
+func (s *suppressingEmailSender) check(ctx context.Context, toEmail string) error {
+	_, err := s.suppressions.Get(ctx, toEmail)
+	switch {
+	case err == nil:
+		return domain.ErrEmailUndeliverable
+	case errors.Is(err, domain.ErrNotFound):
+		return nil
+	default:
+		log.Printf("suppressingEmailSender: suppression lookup for %s failed, sending anyway: %v", toEmail, err)
+		return nil
+	}
+}
+
+func (s *suppressingEmailSender) SendVerificationEmail(ctx context.Context, toEmail, toName, verificationToken string) error {
+	if err := s.check(ctx, toEmail); err != nil {
+		return err
+	}
+	return s.next.SendVerificationEmail(ctx, toEmail, toName, verificationToken)
+}
+
+func (s *suppressingEmailSender) SendPasswordResetEmail(ctx context.Context, toEmail, toName, resetToken string) error {
+	if err := s.check(ctx, toEmail); err != nil {
+		return err
+	}
+	return s.next.SendPasswordResetEmail(ctx, toEmail, toName, resetToken)
+}
+
+func (s *suppressingEmailSender) SendIngestionReport(ctx context.Context, toEmail, toName string, report *port.IngestionReport) error {
+	if err := s.check(ctx, toEmail); err != nil {
+		return err
+	}
+	return s.next.SendIngestionReport(ctx, toEmail, toName, report)
+}
+
+func (s *suppressingEmailSender) SendEmailChangeNotice(ctx context.Context, toEmail, toName, newEmail, undoToken string) error {
+	if err := s.check(ctx, toEmail); err != nil {
+		return err
+	}
+	return s.next.SendEmailChangeNotice(ctx, toEmail, toName, newEmail, undoToken)
+}
+
+func (s *suppressingEmailSender) SendInvitationEmail(ctx context.Context, toEmail, toName, tenantName, inviteToken string) error {
+	if err := s.check(ctx, toEmail); err != nil {
+		return err
+	}
+	return s.next.SendInvitationEmail(ctx, toEmail, toName, tenantName, inviteToken)
+}
+
+func (s *suppressingEmailSender) SendAlertEmail(ctx context.Context, toEmail, subject, body string) error {
+	if err := s.check(ctx, toEmail); err != nil {
+		return err
+	}
+	return s.next.SendAlertEmail(ctx, toEmail, subject, body)
+}
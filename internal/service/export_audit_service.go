@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// RecordExportInput describes a collection export that finished streaming.
+type RecordExportInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Kind         domain.ExportKind
+	Format       string
+	// Filters are the options that shaped the export, stored as given.
+	Filters        map[string]string
+	RowCount       int
+	SizeBytes      int64
+	ChecksumSHA256 string
+}
+
+// ExportAuditService records collection exports as checksummed artifacts with
+// their download history, so a firm can show which data snapshot it filed.
+type ExportAuditService interface {
+	// Record stores a finished export. Output identical to an earlier export of the
+	// collection counts as another download of that artifact.
+	Record(ctx context.Context, input *RecordExportInput) (*domain.ExportArtifact, error)
+	ListByCollection(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, checksum string, offset, limit int) ([]domain.ExportArtifact, int, error)
+	ListDownloads(ctx context.Context, tenantID, collectionID, artifactID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ExportDownload, int, error)
+}
+
+type exportAuditService struct {
+	artifactRepo   port.ExportArtifactRepository
+	collectionRepo port.CollectionRepository
+	collectionSvc  CollectionService
+}
+
+// NewExportAuditService creates a new ExportAuditService implementation.
+func NewExportAuditService(
+	artifactRepo port.ExportArtifactRepository,
+	collectionRepo port.CollectionRepository,
+	collectionSvc CollectionService,
+) ExportAuditService {
+	return &exportAuditService{artifactRepo: artifactRepo, collectionRepo: collectionRepo, collectionSvc: collectionSvc}
+}
+
+func (s *exportAuditService) Record(ctx context.Context, input *RecordExportInput) (*domain.ExportArtifact, error) {
+	filters := input.Filters
+	if filters == nil {
+		filters = map[string]string{}
+	}
+	raw, err := json.Marshal(filters)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling export filters: %w", err)
+	}
+
+	artifact := &domain.ExportArtifact{
+		TenantID:       input.TenantID,
+		CollectionID:   input.CollectionID,
+		Kind:           input.Kind,
+		Format:         input.Format,
+		Filters:        raw,
+		RowCount:       input.RowCount,
+		SizeBytes:      input.SizeBytes,
+		ChecksumSHA256: input.ChecksumSHA256,
+		GeneratedBy:    input.UserID,
+	}
+	if err := s.artifactRepo.Record(ctx, artifact, input.UserID); err != nil {
+		return nil, err
+	}
+
+	log.Printf("exportAuditService.Record: %s %s export of collection %s by user %s is artifact %s (%d rows, sha256 %s)",
+		artifact.Kind, artifact.Format, artifact.CollectionID, input.UserID, artifact.ID, artifact.RowCount, artifact.ChecksumSHA256)
+	return artifact, nil
+}
+
+func (s *exportAuditService) ListByCollection(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, checksum string, offset, limit int) ([]domain.ExportArtifact, int, error) {
+	if err := s.requireViewer(ctx, tenantID, collectionID, userID, role); err != nil {
+		return nil, 0, err
+	}
+	return s.artifactRepo.ListByCollection(ctx, tenantID, collectionID, checksum, offset, limit)
+}
+
+func (s *exportAuditService) ListDownloads(ctx context.Context, tenantID, collectionID, artifactID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ExportDownload, int, error) {
+	if err := s.requireViewer(ctx, tenantID, collectionID, userID, role); err != nil {
+		return nil, 0, err
+	}
+	artifact, err := s.artifactRepo.GetByID(ctx, tenantID, artifactID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if artifact.CollectionID != collectionID {
+		return nil, 0, domain.ErrNotFound
+	}
+	return s.artifactRepo.ListDownloads(ctx, tenantID, artifactID, offset, limit)
+}
+
+func (s *exportAuditService) requireViewer(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) error {
+	if _, err := s.collectionRepo.GetByID(ctx, tenantID, collectionID); err != nil {
+		return err
+	}
+	eff := s.collectionSvc.EffectivePermission(ctx, collectionID, userID, role)
+	if domain.CollectionPermLevel(eff) < domain.CollectionPermLevel(domain.CollectionPermViewer) {
+		return domain.ErrCollectionPermDenied
+	}
+	return nil
+}
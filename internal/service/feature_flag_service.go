@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// FeatureFlagService manages per-tenant feature flags and evaluates them for other
+// services (it implements port.Flags).
+type FeatureFlagService interface {
+	port.Flags
+	List(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantFeatureFlag, error)
+	Set(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag, enabled bool, updatedBy uuid.UUID) (*domain.TenantFeatureFlag, error)
+	Reset(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) error
+}
+
+type flagCacheEntry struct {
+	values   map[domain.FeatureFlag]bool
+	loadedAt time.Time
+}
+
+type featureFlagService struct {
+	repo     port.FeatureFlagRepository
+	cacheTTL time.Duration
+
+	mu    sync.RWMutex
+	cache map[uuid.UUID]flagCacheEntry
+}
+
+// NewFeatureFlagService creates a new FeatureFlagService. Explicit tenant settings are
+// cached in memory for cacheTTL; writes through this service invalidate the cache
+// immediately, writes from other instances become visible after the TTL.
+func NewFeatureFlagService(repo port.FeatureFlagRepository, cacheTTL time.Duration) FeatureFlagService {
+	return &featureFlagService{
+		repo:     repo,
+		cacheTTL: cacheTTL,
+		cache:    make(map[uuid.UUID]flagCacheEntry),
+	}
+}
+
+func (s *featureFlagService) IsEnabled(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) bool {
+	values, err := s.tenantValues(ctx, tenantID)
+	if err != nil {
+		log.Printf("featureFlagService.IsEnabled: falling back to default for %s/%s: %v", tenantID, flag, err)
+		return domain.FeatureFlagDefaults[flag]
+	}
+	if enabled, ok := values[flag]; ok {
+		return enabled
+	}
+	return domain.FeatureFlagDefaults[flag]
+}
+
+func (s *featureFlagService) List(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantFeatureFlag, error) {
+	stored, err := s.repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	explicit := make(map[domain.FeatureFlag]domain.TenantFeatureFlag, len(stored))
+	for i := range stored {
+		explicit[stored[i].Flag] = stored[i]
+	}
+
+	flags := make([]domain.TenantFeatureFlag, 0, len(domain.FeatureFlagDefaults))
+	for flag, def := range domain.FeatureFlagDefaults {
+		if f, ok := explicit[flag]; ok {
+			f.Overridden = true
+			flags = append(flags, f)
+			continue
+		}
+		flags = append(flags, domain.TenantFeatureFlag{TenantID: tenantID, Flag: flag, Enabled: def})
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Flag < flags[j].Flag })
+	return flags, nil
+}
+
+func (s *featureFlagService) Set(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag, enabled bool, updatedBy uuid.UUID) (*domain.TenantFeatureFlag, error) {
+	if _, ok := domain.FeatureFlagDefaults[flag]; !ok {
+		return nil, domain.ErrUnknownFeatureFlag
+	}
+	f := &domain.TenantFeatureFlag{
+		TenantID:  tenantID,
+		Flag:      flag,
+		Enabled:   enabled,
+		UpdatedBy: &updatedBy,
+	}
+	if err := s.repo.Upsert(ctx, f); err != nil {
+		return nil, err
+	}
+	f.Overridden = true
+	s.invalidate(tenantID)
+	return f, nil
+}
+
+func (s *featureFlagService) Reset(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) error {
+	if _, ok := domain.FeatureFlagDefaults[flag]; !ok {
+		return domain.ErrUnknownFeatureFlag
+	}
+	if err := s.repo.Delete(ctx, tenantID, flag); err != nil {
+		return err
+	}
+	s.invalidate(tenantID)
+	return nil
+}
+
+// tenantValues returns the explicit flag settings for a tenant, from cache when fresh.
+func (s *featureFlagService) tenantValues(ctx context.Context, tenantID uuid.UUID) (map[domain.FeatureFlag]bool, error) {
+	s.mu.RLock()
+	entry, ok := s.cache[tenantID]
+	s.mu.RUnlock()
+	if ok && time.Since(entry.loadedAt) < s.cacheTTL {
+		return entry.values, nil
+	}
+
+	stored, err := s.repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[domain.FeatureFlag]bool, len(stored))
+	for i := range stored {
+		values[stored[i].Flag] = stored[i].Enabled
+	}
+
+	s.mu.Lock()
+	s.cache[tenantID] = flagCacheEntry{values: values, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return values, nil
+}
+
+func (s *featureFlagService) invalidate(tenantID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.cache, tenantID)
+	s.mu.Unlock()
+}
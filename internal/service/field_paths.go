@@ -0,0 +1,151 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Field paths use the same notation as validation results and field provenance:
+// dotted object keys with bracketed array indices, e.g. "seller.gstin" or
+// "line_items[2].hsn_sac_code".
+
+// diffFieldPaths returns the leaf paths whose values differ between before and after,
+// mapped to their new values. Arrays of equal length are compared element-wise;
+// arrays whose length changed are reported as a single path holding the whole array.
+// A key present in before but missing from after is reported as JSON null, so the
+// removal is recorded like any other change.
+func diffFieldPaths(before, after json.RawMessage) (map[string]json.RawMessage, error) {
+	var b, a interface{}
+	if len(before) > 0 {
+		if err := json.Unmarshal(before, &b); err != nil {
+			return nil, fmt.Errorf("unmarshaling previous data: %w", err)
+		}
+	}
+	if err := json.Unmarshal(after, &a); err != nil {
+		return nil, fmt.Errorf("unmarshaling new data: %w", err)
+	}
+	changed := make(map[string]json.RawMessage)
+	collectDiff("", b, a, changed)
+	return changed, nil
+}
+
+func collectDiff(path string, before, after interface{}, out map[string]json.RawMessage) {
+	switch av := after.(type) {
+	case map[string]interface{}:
+		bv, _ := before.(map[string]interface{})
+		for key, child := range av {
+			collectDiff(joinFieldPath(path, key), bv[key], child, out)
+		}
+		for key, child := range bv {
+			if _, kept := av[key]; !kept && child != nil {
+				out[joinFieldPath(path, key)] = json.RawMessage("null")
+			}
+		}
+		return
+	case []interface{}:
+		if bv, ok := before.([]interface{}); ok && len(bv) == len(av) && path != "" {
+			for i := range av {
+				collectDiff(fmt.Sprintf("%s[%d]", path, i), bv[i], av[i], out)
+			}
+			return
+		}
+	}
+	if path == "" || reflect.DeepEqual(before, after) {
+		return
+	}
+	raw, err := json.Marshal(after)
+	if err != nil {
+		return
+	}
+	out[path] = raw
+}
+
+func joinFieldPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// fieldPathSegment is one step of a parsed field path: an object key, optionally
+// followed by an array index.
+type fieldPathSegment struct {
+	key   string
+	index int // -1 when the segment has no array index
+}
+
+func parseFieldPath(path string) ([]fieldPathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty field path")
+	}
+	parts := strings.Split(path, ".")
+	segments := make([]fieldPathSegment, 0, len(parts))
+	for _, part := range parts {
+		seg := fieldPathSegment{key: part, index: -1}
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			if !strings.HasSuffix(part, "]") || open == 0 {
+				return nil, fmt.Errorf("invalid field path segment %q", part)
+			}
+			idx, err := strconv.Atoi(part[open+1 : len(part)-1])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid array index in %q", part)
+			}
+			seg.key = part[:open]
+			seg.index = idx
+		}
+		if seg.key == "" {
+			return nil, fmt.Errorf("invalid field path %q", path)
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+// setFieldPath writes value at path inside root, creating intermediate objects as
+// needed. Array elements are only addressable if they already exist. A nil value
+// on an object key removes the key, undoing a field the user deleted.
+func setFieldPath(root map[string]interface{}, path string, value interface{}) error {
+	segments, err := parseFieldPath(path)
+	if err != nil {
+		return err
+	}
+	current := root
+	for i, seg := range segments {
+		last := i == len(segments)-1
+		if seg.index < 0 {
+			if last {
+				if value == nil {
+					delete(current, seg.key)
+				} else {
+					current[seg.key] = value
+				}
+				return nil
+			}
+			next, ok := current[seg.key].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				current[seg.key] = next
+			}
+			current = next
+			continue
+		}
+
+		arr, ok := current[seg.key].([]interface{})
+		if !ok || seg.index >= len(arr) {
+			return fmt.Errorf("field path %q: index %d out of range", path, seg.index)
+		}
+		if last {
+			arr[seg.index] = value
+			return nil
+		}
+		next, ok := arr[seg.index].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field path %q: element %d is not an object", path, seg.index)
+		}
+		current = next
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+
+	"satvos/internal/domain"
+	"satvos/internal/language"
+	"satvos/internal/validator/invoice"
+)
+
+// Magic-byte signatures for the whitelisted upload types.
+var (
+	pdfMagic      = []byte("%PDF-")
+	jpegMagic     = []byte{0xFF, 0xD8, 0xFF}
+	pngMagic      = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}
+	tiffMagicLE   = []byte{'I', 'I', 0x2A, 0x00}
+	tiffMagicBE   = []byte{'M', 'M', 0x00, 0x2A}
+	pdfEncryptRe  = regexp.MustCompile(`/Encrypt\s*(\d+\s+\d+\s+R|<<)`)
+	pdfPageRe     = regexp.MustCompile(`/Type\s*/Page\b`)
+	pdfObjStmMark = []byte("/ObjStm")
+)
+
+// sniffFileType identifies a file from its leading bytes. http.DetectContentType
+// is not used because it does not recognise TIFF.
+func sniffFileType(head []byte) (domain.FileType, bool) {
+	switch {
+	case bytes.HasPrefix(head, pdfMagic):
+		return domain.FileTypePDF, true
+	case bytes.HasPrefix(head, jpegMagic):
+		return domain.FileTypeJPG, true
+	case bytes.HasPrefix(head, pngMagic):
+		return domain.FileTypePNG, true
+	case bytes.HasPrefix(head, tiffMagicLE), bytes.HasPrefix(head, tiffMagicBE):
+		return domain.FileTypeTIFF, true
+	default:
+		return "", false
+	}
+}
+
+// inspectPDF rejects PDFs that no parser can read: encrypted documents and
+// documents without a single page object. Page objects packed inside compressed
+// object streams cannot be seen without decompressing, so such files are given
+// the benefit of the doubt.
+func inspectPDF(data []byte) error {
+	if pdfEncryptRe.Match(data) {
+		return domain.ErrPDFEncrypted
+	}
+	if !pdfPageRe.Match(data) && !bytes.Contains(data, pdfObjStmMark) {
+		return domain.ErrPDFNoPages
+	}
+	return nil
+}
+
+// countPages estimates a document's page count for sizing parse timeouts: the number
+// of page objects in a PDF (0 when they are hidden in compressed object streams),
+// and 1 for images.
+func countPages(contentType string, data []byte) int {
+	if contentType != "application/pdf" {
+		return 1
+	}
+	return len(pdfPageRe.FindAllIndex(data, -1))
+}
+
+// detectDocumentLanguage tells a document's language before parsing from the fonts of a
+// PDF's text layer. Images and scanned PDFs return "" and get the default prompt.
+func detectDocumentLanguage(contentType string, data []byte) string {
+	if contentType != "application/pdf" {
+		return ""
+	}
+	return language.DetectPDF(data)
+}
+
+// parsedLanguage returns detected, or when nothing was detected before parsing,
+// the language of the text the parser extracted.
+func parsedLanguage(detected string, structuredData json.RawMessage) string {
+	if detected != "" || len(structuredData) == 0 {
+		return detected
+	}
+	var inv invoice.GSTInvoice
+	if err := json.Unmarshal(structuredData, &inv); err != nil {
+		return ""
+	}
+	return language.DetectText(invoice.InvoiceText(&inv))
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // register decoders for image.Decode
+	_ "image/png"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// Predicted parse difficulty of a file.
+const (
+	PreflightDifficultyEasy        = "easy"
+	PreflightDifficultyModerate    = "moderate"
+	PreflightDifficultyHard        = "hard"
+	PreflightDifficultyUnparseable = "unparseable"
+)
+
+// Warnings a preflight report can carry.
+const (
+	PreflightWarningSignatureMismatch = "signature_mismatch"
+	PreflightWarningEncrypted         = "encrypted"
+	PreflightWarningNoPages           = "no_pages"
+	PreflightWarningLowDPI            = "low_dpi"
+	PreflightWarningBlurry            = "blurry"
+	PreflightWarningManyPages         = "many_pages"
+)
+
+const (
+	// preflightMinDPI is the scan resolution below which OCR accuracy drops sharply.
+	preflightMinDPI = 150
+	// preflightBlurThreshold is the Laplacian variance below which a scan is blurry.
+	preflightBlurThreshold = 100
+	// preflightManyPages is the page count above which a parse gets harder.
+	preflightManyPages = 10
+	// preflightSampleSide bounds the image size the sharpness is measured at.
+	preflightSampleSide = 1200
+	// preflightTextBudget bounds the decompressed PDF content scanned for text.
+	preflightTextBudget = 1 << 20
+	// preflightPageInches is the short side of an A4 page, assumed for photos and
+	// scans that carry no physical size.
+	preflightPageInches = 8.27
+)
+
+var (
+	pdfImageRe    = regexp.MustCompile(`/Subtype\s*/Image\b`)
+	pdfWidthRe    = regexp.MustCompile(`/Width\s+(\d+)`)
+	pdfHeightRe   = regexp.MustCompile(`/Height\s+(\d+)`)
+	pdfMediaBoxRe = regexp.MustCompile(`/MediaBox\s*\[\s*(-?[\d.]+)\s+(-?[\d.]+)\s+(-?[\d.]+)\s+(-?[\d.]+)\s*\]`)
+	pdfStreamRe   = regexp.MustCompile(`\bstream\r?\n`)
+	pdfTextRe     = regexp.MustCompile(`\(((?:\\.|[^\\)])*)\)\s*(?:Tj|'|")|\[((?:[^\]])*)\]\s*TJ`)
+	pdfLiteralRe  = regexp.MustCompile(`\(((?:\\.|[^\\)])*)\)`)
+	pdfFontMark   = []byte("/Font")
+	pdfDCTMark    = []byte("/DCTDecode")
+	pdfEndObjMark = []byte("endobj")
+	pdfEndStream  = []byte("endstream")
+)
+
+// preflightScripts maps the Unicode scripts of the languages invoices are
+// issued in to their ISO 639-1 codes. Latin text is taken to be English.
+var preflightScripts = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Latin, "en"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Bengali, "bn"},
+	{unicode.Tamil, "ta"},
+	{unicode.Telugu, "te"},
+	{unicode.Kannada, "kn"},
+	{unicode.Gujarati, "gu"},
+	{unicode.Gurmukhi, "pa"},
+	{unicode.Malayalam, "ml"},
+}
+
+// FilePreflightInput identifies the file to check.
+type FilePreflightInput struct {
+	TenantID uuid.UUID
+	UserID   uuid.UUID
+	Role     domain.UserRole
+	FileID   uuid.UUID
+}
+
+// FilePreflightService checks whether a stored file is worth parsing. It
+// inspects the bytes only; no parser is called and no parse credit is used.
+// There is no malware scan.
+type FilePreflightService interface {
+	Preflight(ctx context.Context, input *FilePreflightInput) (*domain.FilePreflight, error)
+}
+
+type filePreflightService struct {
+	fileRepo  port.FileMetaRepository
+	storage   port.ObjectStorage
+	residency StorageResidency
+}
+
+// NewFilePreflightService creates a new FilePreflightService.
+func NewFilePreflightService(fileRepo port.FileMetaRepository, storage port.ObjectStorage, residency StorageResidency) FilePreflightService {
+	return &filePreflightService{fileRepo: fileRepo, storage: storage, residency: residency}
+}
+
+func (s *filePreflightService) Preflight(ctx context.Context, input *FilePreflightInput) (*domain.FilePreflight, error) {
+	meta, err := s.fileRepo.GetByID(ctx, input.TenantID, input.FileID)
+	if err != nil {
+		return nil, err
+	}
+	// Free users can only see their own files
+	if input.Role == domain.RoleFree && meta.UploadedBy != input.UserID {
+		return nil, domain.ErrNotFound
+	}
+	if err := checkResidency(ctx, s.residency, input.TenantID, meta.S3Bucket); err != nil {
+		return nil, err
+	}
+	data, err := s.storage.Download(ctx, meta.S3Bucket, meta.S3Key)
+	if err != nil {
+		return nil, fmt.Errorf("downloading file: %w", err)
+	}
+	return preflightReport(meta, data), nil
+}
+
+// preflightReport inspects a file's content.
+func preflightReport(meta *domain.FileMeta, data []byte) *domain.FilePreflight {
+	r := &domain.FilePreflight{
+		FileID:   meta.ID,
+		FileType: meta.FileType,
+		FileSize: int64(len(data)),
+		Language: "unknown",
+		Warnings: []string{},
+	}
+	sniffed, ok := sniffFileType(data)
+	r.SignatureValid = ok && sniffed == meta.FileType
+
+	var scan image.Image
+	var unreadable error
+	switch {
+	case !r.SignatureValid:
+	case meta.FileType == domain.FileTypePDF:
+		scan, unreadable = preflightPDF(r, data)
+	case meta.FileType == domain.FileTypeJPG, meta.FileType == domain.FileTypePNG:
+		r.PageCount = 1
+		if img, _, err := image.Decode(bytes.NewReader(data)); err == nil {
+			b := img.Bounds()
+			r.EstimatedDPI = int(math.Round(float64(min(b.Dx(), b.Dy())) / preflightPageInches))
+			scan = img
+		}
+	default:
+		// TIFF has no decoder in the standard library
+		r.PageCount = 1
+	}
+	if scan != nil {
+		sharpness := laplacianVariance(scan)
+		r.Sharpness = &sharpness
+	}
+
+	r.Difficulty = preflightDifficulty(r, unreadable)
+	r.ParseReady = r.Difficulty != PreflightDifficultyUnparseable
+	return r
+}
+
+// preflightPDF fills in the PDF-specific parts of the report and returns the
+// largest JPEG-encoded scan in the file, if any, or the inspectPDF error that
+// makes the file unreadable.
+func preflightPDF(r *domain.FilePreflight, data []byte) (image.Image, error) {
+	r.PageCount = countPages("application/pdf", data)
+	if err := inspectPDF(data); err != nil {
+		r.Encrypted = errors.Is(err, domain.ErrPDFEncrypted)
+		return nil, err
+	}
+	r.HasTextLayer = bytes.Contains(data, pdfFontMark)
+	if r.HasTextLayer {
+		r.Language = detectLanguage(pdfText(data))
+	}
+
+	pageW, pageH := pdfPageSizeInches(data)
+	var scanJPEG []byte
+	largest := 0
+	for _, loc := range pdfImageRe.FindAllIndex(data, -1) {
+		dict, stream := pdfObjectAt(data, loc[0])
+		w, h := atoiMatch(pdfWidthRe, dict), atoiMatch(pdfHeightRe, dict)
+		if w*h <= largest {
+			continue
+		}
+		largest = w * h
+		if pageW > 0 && pageH > 0 {
+			r.EstimatedDPI = int(math.Round(float64(max(w, h)) / math.Max(pageW, pageH)))
+		}
+		scanJPEG = nil
+		if bytes.Contains(dict, pdfDCTMark) {
+			scanJPEG = stream
+		}
+	}
+	if scanJPEG == nil {
+		return nil, nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(scanJPEG))
+	if err != nil {
+		return nil, nil
+	}
+	return img, nil
+}
+
+// preflightDifficulty predicts how hard the file is to parse and records the
+// warnings behind the prediction.
+func preflightDifficulty(r *domain.FilePreflight, unreadable error) string {
+	switch {
+	case !r.SignatureValid:
+		r.Warnings = append(r.Warnings, PreflightWarningSignatureMismatch)
+		return PreflightDifficultyUnparseable
+	case errors.Is(unreadable, domain.ErrPDFEncrypted):
+		r.Warnings = append(r.Warnings, PreflightWarningEncrypted)
+		return PreflightDifficultyUnparseable
+	case unreadable != nil:
+		r.Warnings = append(r.Warnings, PreflightWarningNoPages)
+		return PreflightDifficultyUnparseable
+	}
+
+	level := 1 // moderate: a scan of acceptable quality
+	if r.HasTextLayer {
+		level = 0
+	} else {
+		if r.EstimatedDPI > 0 && r.EstimatedDPI < preflightMinDPI {
+			r.Warnings = append(r.Warnings, PreflightWarningLowDPI)
+			level = 2
+		}
+		if r.Sharpness != nil && *r.Sharpness < preflightBlurThreshold {
+			r.Warnings = append(r.Warnings, PreflightWarningBlurry)
+			level = 2
+		}
+	}
+	if r.PageCount > preflightManyPages {
+		r.Warnings = append(r.Warnings, PreflightWarningManyPages)
+		level = min(level+1, 2)
+	}
+	return []string{PreflightDifficultyEasy, PreflightDifficultyModerate, PreflightDifficultyHard}[level]
+}
+
+// pdfObjectAt returns the dictionary of the object containing offset and its
+// stream, if it has one.
+func pdfObjectAt(data []byte, offset int) (dict, stream []byte) {
+	start := bytes.LastIndex(data[:offset], []byte(" obj"))
+	if start < 0 {
+		start = offset
+	}
+	end := len(data)
+	if i := bytes.Index(data[offset:], pdfEndObjMark); i >= 0 {
+		end = offset + i
+	}
+	obj := data[start:end]
+	loc := pdfStreamRe.FindIndex(obj)
+	if loc == nil {
+		return obj, nil
+	}
+	dict, stream = obj[:loc[0]], obj[loc[1]:]
+	if i := bytes.Index(stream, pdfEndStream); i >= 0 {
+		stream = stream[:i]
+	}
+	return dict, stream
+}
+
+// pdfPageSizeInches returns the first page's MediaBox in inches, or zeros.
+func pdfPageSizeInches(data []byte) (w, h float64) {
+	m := pdfMediaBoxRe.FindSubmatch(data)
+	if m == nil {
+		return 0, 0
+	}
+	var box [4]float64
+	for i := range box {
+		box[i], _ = strconv.ParseFloat(string(m[i+1]), 64)
+	}
+	return math.Abs(box[2]-box[0]) / 72, math.Abs(box[3]-box[1]) / 72
+}
+
+// pdfText returns the strings shown by the PDF's compressed content streams,
+// up to preflightTextBudget bytes of decompressed content.
+func pdfText(data []byte) string {
+	var text bytes.Buffer
+	budget := int64(preflightTextBudget)
+	for _, loc := range pdfStreamRe.FindAllIndex(data, -1) {
+		if budget <= 0 {
+			break
+		}
+		// Skip images; their data is no text
+		if start := bytes.LastIndex(data[:loc[0]], []byte(" obj")); start >= 0 && pdfImageRe.Match(data[start:loc[0]]) {
+			continue
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(data[loc[1]:]))
+		if err != nil {
+			continue
+		}
+		content, _ := io.ReadAll(io.LimitReader(zr, budget))
+		_ = zr.Close()
+		budget -= int64(len(content))
+		for _, m := range pdfTextRe.FindAllSubmatch(content, -1) {
+			if m[1] != nil {
+				text.Write(m[1])
+			}
+			for _, lit := range pdfLiteralRe.FindAllSubmatch(m[2], -1) {
+				text.Write(lit[1])
+			}
+			text.WriteByte(' ')
+		}
+	}
+	return text.String()
+}
+
+// detectLanguage names the dominant script of text, or "unknown" when there
+// is too little text to tell.
+func detectLanguage(text string) string {
+	counts := make([]int, len(preflightScripts))
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for i, s := range preflightScripts {
+			if unicode.Is(s.table, r) {
+				counts[i]++
+				break
+			}
+		}
+	}
+	if letters < 20 {
+		return "unknown"
+	}
+	best := 0
+	for i := range counts {
+		if counts[i] > counts[best] {
+			best = i
+		}
+	}
+	if counts[best]*2 < letters {
+		return "unknown"
+	}
+	return preflightScripts[best].lang
+}
+
+// laplacianVariance measures sharpness as the variance of the Laplacian of the
+// image in grayscale, sampled down to at most preflightSampleSide pixels a side.
+func laplacianVariance(img image.Image) float64 {
+	b := img.Bounds()
+	step := max(1, int(math.Ceil(float64(max(b.Dx(), b.Dy()))/preflightSampleSide)))
+	w, h := b.Dx()/step, b.Dy()/step
+	if w < 3 || h < 3 {
+		return 0
+	}
+	gray := make([]float64, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			g := color.GrayModel.Convert(img.At(b.Min.X+x*step, b.Min.Y+y*step)).(color.Gray)
+			gray[y*w+x] = float64(g.Y)
+		}
+	}
+	var sum, sumSq float64
+	n := 0
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			lap := gray[i-w] + gray[i+w] + gray[i-1] + gray[i+1] - 4*gray[i]
+			sum += lap
+			sumSq += lap * lap
+			n++
+		}
+	}
+	mean := sum / float64(n)
+	return math.Round((sumSq/float64(n)-mean*mean)*10) / 10
+}
+
+func atoiMatch(re *regexp.Regexp, b []byte) int {
+	m := re.FindSubmatch(b)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(string(m[1]))
+	return n
+}
@@ -1,12 +1,13 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"log"
 	"mime/multipart"
-	"net/http"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -23,22 +24,41 @@ type FileUploadInput struct {
 	UploadedBy uuid.UUID
 	File       multipart.File
 	Header     *multipart.FileHeader
+	// CollectionID, when known at upload time, files the object under the collection's prefix.
+	CollectionID *uuid.UUID
+}
+
+// FileIngestInput is the DTO for storing a file the server already holds in memory,
+// e.g. an entry unpacked from a ZIP import.
+type FileIngestInput struct {
+	TenantID   uuid.UUID
+	UploadedBy uuid.UUID
+	FileName   string
+	Content    []byte
+	// CollectionID, when known at upload time, files the object under the collection's prefix.
+	CollectionID *uuid.UUID
+	// ContentType, when it is a specific supported type, must agree with the content.
+	ContentType string
 }
 
 // FileService defines the file management contract.
 type FileService interface {
 	Upload(ctx context.Context, input FileUploadInput) (*domain.FileMeta, error)
+	Ingest(ctx context.Context, input FileIngestInput) (*domain.FileMeta, error)
 	GetByID(ctx context.Context, tenantID, fileID uuid.UUID) (*domain.FileMeta, error)
 	List(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.FileMeta, int, error)
 	ListByUploader(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.FileMeta, int, error)
 	GetDownloadURL(ctx context.Context, tenantID, fileID uuid.UUID) (string, error)
+	// OpenContent streams the stored file; the caller must close the returned body.
+	OpenContent(ctx context.Context, tenantID, fileID uuid.UUID) (*domain.FileMeta, *port.ObjectReader, error)
 	Delete(ctx context.Context, tenantID, fileID uuid.UUID) error
 }
 
 type fileService struct {
-	fileRepo port.FileMetaRepository
-	storage  port.ObjectStorage
-	cfg      *config.S3Config
+	fileRepo  port.FileMetaRepository
+	storage   port.ObjectStorage
+	cfg       *config.S3Config
+	residency StorageResidency
 }
 
 // NewFileService creates a new FileService implementation.
@@ -46,17 +66,31 @@ func NewFileService(
 	fileRepo port.FileMetaRepository,
 	storage port.ObjectStorage,
 	cfg *config.S3Config,
+	residency StorageResidency,
 ) FileService {
 	return &fileService{
-		fileRepo: fileRepo,
-		storage:  storage,
-		cfg:      cfg,
+		fileRepo:  fileRepo,
+		storage:   storage,
+		cfg:       cfg,
+		residency: residency,
 	}
 }
 
 func (s *fileService) Upload(ctx context.Context, input FileUploadInput) (*domain.FileMeta, error) {
+	return s.store(ctx, input.TenantID, input.UploadedBy, input.CollectionID, input.Header.Filename, input.Header.Size,
+		input.File, input.Header.Header.Get("Content-Type"))
+}
+
+func (s *fileService) Ingest(ctx context.Context, input FileIngestInput) (*domain.FileMeta, error) {
+	return s.store(ctx, input.TenantID, input.UploadedBy, input.CollectionID, input.FileName, int64(len(input.Content)),
+		bytes.NewReader(input.Content), input.ContentType)
+}
+
+// store validates a file and uploads it to object storage. declaredType is the
+// client-supplied Content-Type, if any.
+func (s *fileService) store(ctx context.Context, tenantID, uploadedBy uuid.UUID, collectionID *uuid.UUID, filename string, size int64, body io.ReadSeeker, declaredType string) (*domain.FileMeta, error) {
 	// Validate file extension
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(input.Header.Filename), "."))
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
 	fileType, ok := domain.AllowedExtensions[ext]
 	if !ok {
 		return nil, domain.ErrUnsupportedFileType
@@ -64,50 +98,76 @@ func (s *fileService) Upload(ctx context.Context, input FileUploadInput) (*domai
 
 	// Validate file size
 	maxBytes := s.cfg.MaxFileSizeMB * 1024 * 1024
-	if input.Header.Size > maxBytes {
+	if size > maxBytes {
 		return nil, domain.ErrFileTooLarge
 	}
 
 	// Read first 512 bytes for magic-byte content type detection
 	buf := make([]byte, 512)
-	n, err := input.File.Read(buf)
-	if err != nil && err != io.EOF {
+	n, err := io.ReadFull(body, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 		return nil, fmt.Errorf("reading file header: %w", err)
 	}
-	detectedType := http.DetectContentType(buf[:n])
-
-	// Validate detected content type
-	_, validContent := domain.AllowedContentTypes[detectedType]
-	if !validContent {
+	detectedType, ok := sniffFileType(buf[:n])
+	if !ok {
 		return nil, domain.ErrUnsupportedFileType
 	}
 
-	// Seek back to beginning for upload
-	if _, err := input.File.Seek(0, io.SeekStart); err != nil {
+	// The content must agree with the extension and, when the client sent a
+	// specific one, the declared Content-Type of the part
+	if detectedType != fileType {
+		return nil, domain.ErrFileContentMismatch
+	}
+	if declared, known := domain.AllowedContentTypes[declaredType]; known && declared != detectedType {
+		return nil, domain.ErrFileContentMismatch
+	}
+
+	// Seek back to beginning for inspection/upload
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
 		return nil, fmt.Errorf("seeking file: %w", err)
 	}
 
+	// Reject PDFs the parsers cannot read before they reach storage or an LLM
+	if fileType == domain.FileTypePDF {
+		data, err := io.ReadAll(io.LimitReader(body, maxBytes))
+		if err != nil {
+			return nil, fmt.Errorf("reading file: %w", err)
+		}
+		if err := inspectPDF(data); err != nil {
+			return nil, err
+		}
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("seeking file: %w", err)
+		}
+	}
+
+	bucket, err := residentBucket(ctx, s.residency, tenantID, s.cfg.Bucket)
+	if err != nil {
+		log.Printf("fileService.Upload: resolving storage bucket for tenant %s: %v", tenantID, err)
+		return nil, err
+	}
+
 	// Generate storage key and file metadata
 	fileID := uuid.New()
-	s3Key := fmt.Sprintf("tenants/%s/files/%s/%s", input.TenantID, fileID, input.Header.Filename)
+	s3Key := fileObjectKey(tenantID, collectionID, fileID, filename)
 	contentType := domain.AllowedFileTypes[fileType]
 
 	meta := &domain.FileMeta{
 		ID:           fileID,
-		TenantID:     input.TenantID,
-		UploadedBy:   input.UploadedBy,
+		TenantID:     tenantID,
+		UploadedBy:   uploadedBy,
 		FileName:     fileID.String() + "." + ext,
-		OriginalName: input.Header.Filename,
+		OriginalName: filename,
 		FileType:     fileType,
-		FileSize:     input.Header.Size,
-		S3Bucket:     s.cfg.Bucket,
+		FileSize:     size,
+		S3Bucket:     bucket,
 		S3Key:        s3Key,
 		ContentType:  contentType,
 		Status:       domain.FileStatusPending,
 	}
 
 	log.Printf("fileService.Upload: uploading file %s (%s, %d bytes) for tenant %s by user %s",
-		input.Header.Filename, contentType, input.Header.Size, input.TenantID, input.UploadedBy)
+		filename, contentType, size, tenantID, uploadedBy)
 
 	// Persist metadata with pending status
 	if err := s.fileRepo.Create(ctx, meta); err != nil {
@@ -117,11 +177,11 @@ func (s *fileService) Upload(ctx context.Context, input FileUploadInput) (*domai
 
 	// Upload to S3
 	_, err = s.storage.Upload(ctx, port.UploadInput{
-		Bucket:      s.cfg.Bucket,
+		Bucket:      bucket,
 		Key:         s3Key,
-		Body:        input.File,
+		Body:        body,
 		ContentType: contentType,
-		Size:        input.Header.Size,
+		Size:        size,
 	})
 	if err != nil {
 		log.Printf("fileService.Upload: S3 upload failed for file %s: %v", meta.ID, err)
@@ -156,9 +216,27 @@ func (s *fileService) GetDownloadURL(ctx context.Context, tenantID, fileID uuid.
 	if err != nil {
 		return "", err
 	}
+	if err := checkResidency(ctx, s.residency, tenantID, meta.S3Bucket); err != nil {
+		return "", err
+	}
 	return s.storage.GetPresignedURL(ctx, meta.S3Bucket, meta.S3Key, s.cfg.PresignExpiry)
 }
 
+func (s *fileService) OpenContent(ctx context.Context, tenantID, fileID uuid.UUID) (*domain.FileMeta, *port.ObjectReader, error) {
+	meta, err := s.fileRepo.GetByID(ctx, tenantID, fileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkResidency(ctx, s.residency, tenantID, meta.S3Bucket); err != nil {
+		return nil, nil, err
+	}
+	obj, err := s.storage.Open(ctx, meta.S3Bucket, meta.S3Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening file content: %w", err)
+	}
+	return meta, obj, nil
+}
+
 func (s *fileService) Delete(ctx context.Context, tenantID, fileID uuid.UUID) error {
 	log.Printf("fileService.Delete: deleting file %s for tenant %s", fileID, tenantID)
 
@@ -171,6 +249,24 @@ func (s *fileService) Delete(ctx context.Context, tenantID, fileID uuid.UUID) er
 		log.Printf("fileService.Delete: failed to delete from S3: %v", err)
 		return fmt.Errorf("deleting from storage: %w", err)
 	}
+	if meta.FileType == domain.FileTypePDF {
+		s.deletePageImages(ctx, meta)
+	}
 
 	return s.fileRepo.Delete(ctx, tenantID, fileID)
 }
+
+// deletePageImages removes the rendered pages cached beside a PDF. Failures are
+// only logged: leftover images are unreachable once the file is gone.
+func (s *fileService) deletePageImages(ctx context.Context, meta *domain.FileMeta) {
+	objects, err := s.storage.List(ctx, meta.S3Bucket, path.Dir(meta.S3Key)+"/pages/")
+	if err != nil {
+		log.Printf("fileService.Delete: listing page images of file %s: %v", meta.ID, err)
+		return
+	}
+	for _, obj := range objects {
+		if err := s.storage.Delete(ctx, meta.S3Bucket, obj.Key); err != nil {
+			log.Printf("fileService.Delete: deleting page image %s: %v", obj.Key, err)
+		}
+	}
+}
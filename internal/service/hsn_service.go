@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+// HSN hierarchy levels by code length.
+var hsnLevels = map[int]string{2: "chapter", 4: "heading", 6: "subheading", 8: "tariff_item"}
+
+// HSNService serves the HSN/SAC master list as a browsable hierarchy.
+type HSNService interface {
+	// Tree returns the chapters with their descendants expanded to depth levels
+	// (1 = chapters only).
+	Tree(ctx context.Context, depth int) []*domain.HSNNode
+	// Children returns the direct children of code, or ErrNotFound if the code is
+	// not in the hierarchy.
+	Children(ctx context.Context, code string) ([]*domain.HSNNode, error)
+	// ProposeRates fills in the GST rates of an invoice's line items from the HSN
+	// master and recomputes the amounts, without saving anything.
+	ProposeRates(ctx context.Context, structuredData json.RawMessage) (*HSNRateProposal, error)
+}
+
+// HSNRateProposal is an invoice's line items and totals with GST rates taken from
+// the HSN master and amounts recomputed, for a reviewer to apply while editing.
+type HSNRateProposal struct {
+	LineItems []invoice.LineItem     `json:"line_items"`
+	Totals    invoice.Totals         `json:"totals"`
+	Proposals []invoice.RateProposal `json:"proposals"`
+}
+
+type hsnService struct {
+	nodes    map[string]*domain.HSNNode
+	children map[string][]string // parent code → child codes, sorted
+	chapters []string
+	lookup   *invoice.HSNLookup
+}
+
+// NewHSNService builds the hierarchy from the master list. A code's parent is its
+// longest proper prefix that is itself a code (or the chapter), so an 8-digit item
+// without a 6-digit subheading in the list hangs directly off its heading. Rates
+// are proposed from lookup, which holds the full rate history.
+func NewHSNService(codes []domain.HSNCode, lookup *invoice.HSNLookup) HSNService {
+	s := &hsnService{
+		nodes:    make(map[string]*domain.HSNNode, len(codes)),
+		children: make(map[string][]string),
+		lookup:   lookup,
+	}
+	if s.lookup == nil {
+		s.lookup = invoice.NewHSNLookup(nil)
+	}
+	for i := range codes {
+		c := &codes[i]
+		level, ok := hsnLevels[len(c.Code)]
+		if !ok {
+			continue
+		}
+		s.nodes[c.Code] = &domain.HSNNode{
+			Code:        c.Code,
+			Description: c.Description,
+			Level:       level,
+			GSTRates:    []float64(c.GSTRates),
+		}
+	}
+	codeList := make([]string, 0, len(s.nodes))
+	for code := range s.nodes {
+		codeList = append(codeList, code)
+	}
+	for _, code := range codeList {
+		chapter := code[:2]
+		if _, ok := s.nodes[chapter]; !ok {
+			s.nodes[chapter] = &domain.HSNNode{Code: chapter, Level: hsnLevels[2], GSTRates: []float64{}}
+		}
+		if code == chapter {
+			continue
+		}
+		parent := chapter
+		for _, n := range []int{6, 4} {
+			if len(code) > n {
+				if _, ok := s.nodes[code[:n]]; ok {
+					parent = code[:n]
+					break
+				}
+			}
+		}
+		s.children[parent] = append(s.children[parent], code)
+	}
+	for code, node := range s.nodes {
+		if node.Level == hsnLevels[2] {
+			s.chapters = append(s.chapters, code)
+		}
+	}
+	sort.Strings(s.chapters)
+	for parent, kids := range s.children {
+		sort.Strings(kids)
+		s.nodes[parent].HasChildren = true
+	}
+	return s
+}
+
+func (s *hsnService) Tree(_ context.Context, depth int) []*domain.HSNNode {
+	out := make([]*domain.HSNNode, 0, len(s.chapters))
+	for _, code := range s.chapters {
+		out = append(out, s.expand(code, depth))
+	}
+	return out
+}
+
+func (s *hsnService) Children(_ context.Context, code string) ([]*domain.HSNNode, error) {
+	if _, ok := s.nodes[code]; !ok {
+		return nil, domain.ErrNotFound
+	}
+	kids := s.children[code]
+	out := make([]*domain.HSNNode, 0, len(kids))
+	for _, k := range kids {
+		out = append(out, s.expand(k, 1))
+	}
+	return out, nil
+}
+
+// expand returns a copy of the node with its descendants filled in to depth levels.
+func (s *hsnService) expand(code string, depth int) *domain.HSNNode {
+	node := *s.nodes[code]
+	node.Children = nil
+	if depth > 1 {
+		for _, k := range s.children[code] {
+			node.Children = append(node.Children, s.expand(k, depth-1))
+		}
+	}
+	return &node
+}
+
+func (s *hsnService) ProposeRates(_ context.Context, structuredData json.RawMessage) (*HSNRateProposal, error) {
+	var inv invoice.GSTInvoice
+	if err := json.Unmarshal(structuredData, &inv); err != nil {
+		return nil, domain.ErrInvalidStructuredData
+	}
+	proposals := invoice.ApplyHSNRates(&inv, s.lookup)
+	lineItems := inv.LineItems
+	if lineItems == nil {
+		lineItems = []invoice.LineItem{}
+	}
+	return &HSNRateProposal{LineItems: lineItems, Totals: inv.Totals, Proposals: proposals}, nil
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	// maxImportEntries caps the number of files a single import may contain.
+	maxImportEntries = 500
+	// importTimeout bounds the background processing of one import job.
+	importTimeout = 30 * time.Minute
+)
+
+// zipMagic is the local file header signature that starts every non-empty ZIP archive.
+var zipMagic = []byte{'P', 'K', 0x03, 0x04}
+
+// ZipImportInput is the DTO for importing a ZIP archive into a collection.
+type ZipImportInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	DocumentType string
+	ParseMode    domain.ParseMode
+	File         multipart.File
+	Header       *multipart.FileHeader
+}
+
+// S3ImportInput is the DTO for importing every object under a prefix of the tenant inbox.
+type S3ImportInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	DocumentType string
+	ParseMode    domain.ParseMode
+	Prefix       string
+}
+
+// ImportService defines the bulk import contract. Imports run asynchronously: the
+// Import* methods return a pending job whose per-file report fills in as it runs.
+type ImportService interface {
+	ImportZip(ctx context.Context, input *ZipImportInput) (*domain.ImportJob, error)
+	ImportS3Prefix(ctx context.Context, input *S3ImportInput) (*domain.ImportJob, error)
+	GetJob(ctx context.Context, tenantID, collectionID, jobID, userID uuid.UUID, role domain.UserRole) (*domain.ImportJob, error)
+	ListJobs(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ImportJob, int, error)
+}
+
+type importService struct {
+	jobRepo       port.ImportJobRepository
+	collectionSvc CollectionService
+	ingester      *collectionIngester
+	storage       port.ObjectStorage
+	cfg           *config.S3Config
+	residency     StorageResidency
+}
+
+// NewImportService creates a new ImportService implementation.
+func NewImportService(
+	jobRepo port.ImportJobRepository,
+	fileSvc FileService,
+	collectionSvc CollectionService,
+	docSvc DocumentService,
+	storage port.ObjectStorage,
+	cfg *config.S3Config,
+	residency StorageResidency,
+) ImportService {
+	return &importService{
+		jobRepo:       jobRepo,
+		collectionSvc: collectionSvc,
+		ingester:      &collectionIngester{fileSvc: fileSvc, collectionSvc: collectionSvc, docSvc: docSvc},
+		storage:       storage,
+		cfg:           cfg,
+		residency:     residency,
+	}
+}
+
+// importCandidate is one file found in an import source, read lazily.
+type importCandidate struct {
+	name string
+	size int64
+	read func(ctx context.Context) ([]byte, error)
+}
+
+// tenantInboxPrefix is the S3 prefix a tenant may import from.
+func tenantInboxPrefix(tenantID uuid.UUID) string {
+	return fmt.Sprintf("tenants/%s/inbox/", tenantID)
+}
+
+func (s *importService) requirePerm(ctx context.Context, collectionID, userID uuid.UUID, role domain.UserRole, minLevel domain.CollectionPermission) error {
+	eff := s.collectionSvc.EffectivePermission(ctx, collectionID, userID, role)
+	if domain.CollectionPermLevel(eff) < domain.CollectionPermLevel(minLevel) {
+		return domain.ErrCollectionPermDenied
+	}
+	return nil
+}
+
+func (s *importService) newJob(tenantID, collectionID, userID uuid.UUID, source domain.ImportSource, documentType string, parseMode domain.ParseMode) *domain.ImportJob {
+	if parseMode == "" {
+		parseMode = domain.ParseModeSingle
+	}
+	return &domain.ImportJob{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		CreatedBy:    userID,
+		Source:       source,
+		DocumentType: documentType,
+		ParseMode:    parseMode,
+		Status:       domain.ImportStatusPending,
+		Entries:      json.RawMessage("[]"),
+	}
+}
+
+func (s *importService) ImportZip(ctx context.Context, input *ZipImportInput) (*domain.ImportJob, error) {
+	if err := s.requirePerm(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(filepath.Ext(input.Header.Filename), ".zip") {
+		return nil, domain.ErrInvalidArchive
+	}
+	head := make([]byte, len(zipMagic))
+	if _, err := io.ReadFull(input.File, head); err != nil || !bytes.Equal(head, zipMagic) {
+		return nil, domain.ErrInvalidArchive
+	}
+	if _, err := input.File.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seeking archive: %w", err)
+	}
+
+	bucket, err := residentBucket(ctx, s.residency, input.TenantID, s.cfg.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	job := s.newJob(input.TenantID, input.CollectionID, input.UserID, domain.ImportSourceZip, input.DocumentType, input.ParseMode)
+	job.SourceRef = fmt.Sprintf("tenants/%s/imports/%s.zip", input.TenantID, job.ID)
+
+	// Stage the archive in object storage so the background run doesn't depend on the request
+	if _, err := s.storage.Upload(ctx, port.UploadInput{
+		Bucket:      bucket,
+		Key:         job.SourceRef,
+		Body:        input.File,
+		ContentType: "application/zip",
+		Size:        input.Header.Size,
+	}); err != nil {
+		log.Printf("importService.ImportZip: staging archive failed: %v", err)
+		return nil, domain.ErrUploadFailed
+	}
+
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		_ = s.storage.Delete(ctx, bucket, job.SourceRef)
+		return nil, fmt.Errorf("creating import job: %w", err)
+	}
+
+	log.Printf("importService.ImportZip: created import job %s for collection %s (tenant %s)",
+		job.ID, job.CollectionID, job.TenantID)
+
+	result := *job
+	go s.runInBackground(job, input.Role, s.zipCandidates)
+	return &result, nil
+}
+
+func (s *importService) ImportS3Prefix(ctx context.Context, input *S3ImportInput) (*domain.ImportJob, error) {
+	if err := s.requirePerm(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimPrefix(input.Prefix, "/")
+	for _, seg := range strings.Split(prefix, "/") {
+		if seg == ".." || seg == "." {
+			return nil, domain.ErrInvalidImportPrefix
+		}
+	}
+
+	job := s.newJob(input.TenantID, input.CollectionID, input.UserID, domain.ImportSourceS3Prefix, input.DocumentType, input.ParseMode)
+	job.SourceRef = tenantInboxPrefix(input.TenantID) + prefix
+
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("creating import job: %w", err)
+	}
+
+	log.Printf("importService.ImportS3Prefix: created import job %s for prefix %s (tenant %s)",
+		job.ID, job.SourceRef, job.TenantID)
+
+	result := *job
+	go s.runInBackground(job, input.Role, s.s3Candidates)
+	return &result, nil
+}
+
+func (s *importService) GetJob(ctx context.Context, tenantID, collectionID, jobID, userID uuid.UUID, role domain.UserRole) (*domain.ImportJob, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+	job, err := s.jobRepo.GetByID(ctx, tenantID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.CollectionID != collectionID {
+		return nil, domain.ErrNotFound
+	}
+	return job, nil
+}
+
+func (s *importService) ListJobs(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ImportJob, int, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, 0, err
+	}
+	return s.jobRepo.ListByCollection(ctx, tenantID, collectionID, offset, limit)
+}
+
+// zipCandidates downloads the staged archive and lists its entries.
+func (s *importService) zipCandidates(ctx context.Context, job *domain.ImportJob) ([]importCandidate, error) {
+	bucket, err := residentBucket(ctx, s.residency, job.TenantID, s.cfg.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.storage.Download(ctx, bucket, job.SourceRef)
+	if err != nil {
+		return nil, fmt.Errorf("downloading archive: %w", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, domain.ErrInvalidArchive
+	}
+
+	maxBytes := s.cfg.MaxFileSizeMB * 1024 * 1024
+	var candidates []importCandidate
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		f := f
+		candidates = append(candidates, importCandidate{
+			name: f.Name,
+			size: int64(f.UncompressedSize64),
+			read: func(_ context.Context) ([]byte, error) {
+				rc, err := f.Open()
+				if err != nil {
+					return nil, err
+				}
+				defer func() { _ = rc.Close() }()
+				// The declared size can lie; never inflate past the file size limit
+				content, err := io.ReadAll(io.LimitReader(rc, maxBytes+1))
+				if err != nil {
+					return nil, err
+				}
+				if int64(len(content)) > maxBytes {
+					return nil, domain.ErrFileTooLarge
+				}
+				return content, nil
+			},
+		})
+	}
+	return candidates, nil
+}
+
+// s3Candidates lists the objects under the job's inbox prefix.
+func (s *importService) s3Candidates(ctx context.Context, job *domain.ImportJob) ([]importCandidate, error) {
+	bucket, err := residentBucket(ctx, s.residency, job.TenantID, s.cfg.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := s.storage.List(ctx, bucket, job.SourceRef)
+	if err != nil {
+		return nil, fmt.Errorf("listing prefix: %w", err)
+	}
+
+	inbox := tenantInboxPrefix(job.TenantID)
+	var candidates []importCandidate
+	for _, obj := range objects {
+		if strings.HasSuffix(obj.Key, "/") {
+			continue
+		}
+		key := obj.Key
+		candidates = append(candidates, importCandidate{
+			name: strings.TrimPrefix(key, inbox),
+			size: obj.Size,
+			read: func(ctx context.Context) ([]byte, error) {
+				return s.storage.Download(ctx, bucket, key)
+			},
+		})
+	}
+	return candidates, nil
+}
+
+func (s *importService) runInBackground(job *domain.ImportJob, role domain.UserRole,
+	list func(ctx context.Context, job *domain.ImportJob) ([]importCandidate, error)) {
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+
+	s.run(ctx, job, role, list)
+
+	if job.Source == domain.ImportSourceZip {
+		bucket, err := residentBucket(ctx, s.residency, job.TenantID, s.cfg.Bucket)
+		if err != nil {
+			log.Printf("importService.run: resolving bucket for job %s: %v", job.ID, err)
+			return
+		}
+		if err := s.storage.Delete(ctx, bucket, job.SourceRef); err != nil {
+			log.Printf("importService.run: failed to delete staged archive for job %s: %v", job.ID, err)
+		}
+	}
+}
+
+func (s *importService) run(ctx context.Context, job *domain.ImportJob, role domain.UserRole,
+	list func(ctx context.Context, job *domain.ImportJob) ([]importCandidate, error)) {
+	log.Printf("importService.run: starting import job %s", job.ID)
+
+	job.Status = domain.ImportStatusProcessing
+	s.saveProgress(ctx, job, nil)
+
+	candidates, err := list(ctx, job)
+	if err == nil && len(candidates) > maxImportEntries {
+		err = fmt.Errorf("import contains %d files; at most %d are allowed per import", len(candidates), maxImportEntries)
+	}
+	if err != nil {
+		log.Printf("importService.run: import job %s failed: %v", job.ID, err)
+		msg := err.Error()
+		job.Error = &msg
+		s.finish(ctx, job, domain.ImportStatusFailed, nil)
+		return
+	}
+
+	job.TotalEntries = len(candidates)
+	entries := make([]domain.ImportEntry, 0, len(candidates))
+	for _, cand := range candidates {
+		entry, ok := s.importOne(ctx, job, role, cand)
+		if !ok {
+			job.TotalEntries--
+			continue
+		}
+		switch entry.Status {
+		case domain.ImportEntryImported:
+			job.ImportedCount++
+		case domain.ImportEntrySkipped:
+			job.SkippedCount++
+		default:
+			job.FailedCount++
+		}
+		entries = append(entries, entry)
+		s.saveProgress(ctx, job, entries)
+	}
+
+	s.finish(ctx, job, domain.ImportStatusCompleted, entries)
+	log.Printf("importService.run: import job %s completed (%d imported, %d skipped, %d failed)",
+		job.ID, job.ImportedCount, job.SkippedCount, job.FailedCount)
+}
+
+// importOne reads a single candidate and hands it to the ingester. ok is false for
+// entries that are silently ignored (OS metadata files).
+func (s *importService) importOne(ctx context.Context, job *domain.ImportJob, role domain.UserRole, cand importCandidate) (entry domain.ImportEntry, ok bool) {
+	base := path.Base(cand.name)
+	if strings.HasPrefix(base, ".") || strings.HasPrefix(cand.name, "__MACOSX/") {
+		return entry, false
+	}
+	entry.Name = cand.name
+
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(base), "."))
+	if _, allowed := domain.AllowedExtensions[ext]; !allowed {
+		return skippedEntry(entry, domain.ErrUnsupportedFileType), true
+	}
+	if cand.size > s.cfg.MaxFileSizeMB*1024*1024 {
+		return skippedEntry(entry, domain.ErrFileTooLarge), true
+	}
+
+	content, err := cand.read(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileTooLarge) {
+			return skippedEntry(entry, err), true
+		}
+		return failedEntry(entry, fmt.Sprintf("reading file: %v", err)), true
+	}
+
+	return s.ingester.ingest(ctx, &ingestTarget{
+		TenantID:     job.TenantID,
+		CollectionID: job.CollectionID,
+		UserID:       job.CreatedBy,
+		Role:         role,
+		DocumentType: job.DocumentType,
+		ParseMode:    job.ParseMode,
+		Tags:         map[string]string{"import_id": job.ID.String()},
+	}, cand.name, base, content), true
+}
+
+// saveProgress persists the job's current state. Errors are logged, not returned:
+// a lost progress update must not abort the import.
+func (s *importService) saveProgress(ctx context.Context, job *domain.ImportJob, entries []domain.ImportEntry) {
+	if entries != nil {
+		if data, err := json.Marshal(entries); err == nil {
+			job.Entries = data
+		}
+	}
+	if err := s.jobRepo.UpdateProgress(ctx, job); err != nil {
+		log.Printf("importService.saveProgress: failed to update job %s: %v", job.ID, err)
+	}
+}
+
+func (s *importService) finish(ctx context.Context, job *domain.ImportJob, status domain.ImportStatus, entries []domain.ImportEntry) {
+	now := time.Now().UTC()
+	job.Status = status
+	job.CompletedAt = &now
+	s.saveProgress(ctx, job, entries)
+}
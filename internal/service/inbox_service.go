@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// InboxService reads and acknowledges a user's in-app notifications.
+type InboxService interface {
+	List(ctx context.Context, tenantID, userID uuid.UUID, unreadOnly bool, offset, limit int) ([]domain.InAppNotification, int, error)
+	MarkRead(ctx context.Context, tenantID, userID, notificationID uuid.UUID) error
+}
+
+type inboxService struct {
+	repo port.InAppNotificationRepository
+}
+
+// NewInboxService creates a new InboxService.
+func NewInboxService(repo port.InAppNotificationRepository) InboxService {
+	return &inboxService{repo: repo}
+}
+
+func (s *inboxService) List(ctx context.Context, tenantID, userID uuid.UUID, unreadOnly bool, offset, limit int) ([]domain.InAppNotification, int, error) {
+	return s.repo.ListByUser(ctx, tenantID, userID, unreadOnly, offset, limit)
+}
+
+func (s *inboxService) MarkRead(ctx context.Context, tenantID, userID, notificationID uuid.UUID) error {
+	return s.repo.MarkRead(ctx, tenantID, userID, notificationID)
+}
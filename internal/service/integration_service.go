@@ -0,0 +1,533 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/httpclient"
+	"satvos/internal/port"
+	"satvos/internal/validator/invoice"
+)
+
+const (
+	integrationFeedDefaultLimit = 50
+	integrationFeedMaxLimit     = 100
+	integrationDeliveryTimeout  = 30 * time.Second
+)
+
+// FlatLineItem is one invoice line in FlatDocument.
+type FlatLineItem struct {
+	LineNumber    int     `json:"line_number"`
+	Description   string  `json:"description"`
+	HSNSACCode    string  `json:"hsn_sac_code"`
+	Quantity      float64 `json:"quantity"`
+	Unit          string  `json:"unit"`
+	UnitPrice     float64 `json:"unit_price"`
+	Discount      float64 `json:"discount"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	CGSTRate      float64 `json:"cgst_rate"`
+	CGSTAmount    float64 `json:"cgst_amount"`
+	SGSTRate      float64 `json:"sgst_rate"`
+	SGSTAmount    float64 `json:"sgst_amount"`
+	IGSTRate      float64 `json:"igst_rate"`
+	IGSTAmount    float64 `json:"igst_amount"`
+	Total         float64 `json:"total"`
+}
+
+// FlatDocument is a document with its invoice flattened to top-level scalar
+// fields, the shape no-code tools (Zapier, Make) map most easily. Invoice
+// fields are empty when the document has no valid structured data.
+type FlatDocument struct {
+	ID                   uuid.UUID      `json:"id"`
+	CollectionID         uuid.UUID      `json:"collection_id"`
+	Name                 string         `json:"name"`
+	ReviewStatus         string         `json:"review_status"`
+	ValidationStatus     string         `json:"validation_status"`
+	ReconciliationStatus string         `json:"reconciliation_status"`
+	ReviewedBy           *uuid.UUID     `json:"reviewed_by"`
+	ReviewedAt           *time.Time     `json:"reviewed_at"`
+	ReviewerNotes        string         `json:"reviewer_notes"`
+	InvoiceNumber        string         `json:"invoice_number"`
+	InvoiceDate          string         `json:"invoice_date"`
+	DueDate              string         `json:"due_date"`
+	InvoiceType          string         `json:"invoice_type"`
+	Currency             string         `json:"currency"`
+	PlaceOfSupply        string         `json:"place_of_supply"`
+	ReverseCharge        bool           `json:"reverse_charge"`
+	IRN                  string         `json:"irn"`
+	SellerName           string         `json:"seller_name"`
+	SellerGSTIN          string         `json:"seller_gstin"`
+	SellerPAN            string         `json:"seller_pan"`
+	SellerAddress        string         `json:"seller_address"`
+	SellerStateCode      string         `json:"seller_state_code"`
+	BuyerName            string         `json:"buyer_name"`
+	BuyerGSTIN           string         `json:"buyer_gstin"`
+	BuyerPAN             string         `json:"buyer_pan"`
+	BuyerAddress         string         `json:"buyer_address"`
+	BuyerStateCode       string         `json:"buyer_state_code"`
+	Subtotal             float64        `json:"subtotal"`
+	TotalDiscount        float64        `json:"total_discount"`
+	TaxableAmount        float64        `json:"taxable_amount"`
+	CGST                 float64        `json:"cgst"`
+	SGST                 float64        `json:"sgst"`
+	IGST                 float64        `json:"igst"`
+	Cess                 float64        `json:"cess"`
+	RoundOff             float64        `json:"round_off"`
+	Total                float64        `json:"total"`
+	AmountInWords        string         `json:"amount_in_words"`
+	PaymentTerms         string         `json:"payment_terms"`
+	LineItemCount        int            `json:"line_item_count"`
+	LineItems            []FlatLineItem `json:"line_items"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+}
+
+// FlattenDocument converts a document to its flat integration representation.
+func FlattenDocument(doc *domain.Document) FlatDocument {
+	flat := FlatDocument{
+		ID:                   doc.ID,
+		CollectionID:         doc.CollectionID,
+		Name:                 doc.Name,
+		ReviewStatus:         string(doc.ReviewStatus),
+		ValidationStatus:     string(doc.ValidationStatus),
+		ReconciliationStatus: string(doc.ReconciliationStatus),
+		ReviewedBy:           doc.ReviewedBy,
+		ReviewedAt:           doc.ReviewedAt,
+		ReviewerNotes:        doc.ReviewerNotes,
+		LineItems:            []FlatLineItem{},
+		CreatedAt:            doc.CreatedAt,
+		UpdatedAt:            doc.UpdatedAt,
+	}
+
+	var inv invoice.GSTInvoice
+	if len(doc.StructuredData) == 0 || json.Unmarshal(doc.StructuredData, &inv) != nil {
+		return flat
+	}
+	flat.InvoiceNumber = inv.Invoice.InvoiceNumber
+	flat.InvoiceDate = inv.Invoice.InvoiceDate
+	flat.DueDate = inv.Invoice.DueDate
+	flat.InvoiceType = inv.Invoice.InvoiceType
+	flat.Currency = inv.Invoice.Currency
+	flat.PlaceOfSupply = inv.Invoice.PlaceOfSupply
+	flat.ReverseCharge = inv.Invoice.ReverseCharge
+	flat.IRN = inv.Invoice.IRN
+	flat.SellerName = inv.Seller.Name
+	flat.SellerGSTIN = inv.Seller.GSTIN
+	flat.SellerPAN = inv.Seller.PAN
+	flat.SellerAddress = inv.Seller.Address
+	flat.SellerStateCode = inv.Seller.StateCode
+	flat.BuyerName = inv.Buyer.Name
+	flat.BuyerGSTIN = inv.Buyer.GSTIN
+	flat.BuyerPAN = inv.Buyer.PAN
+	flat.BuyerAddress = inv.Buyer.Address
+	flat.BuyerStateCode = inv.Buyer.StateCode
+	flat.Subtotal = inv.Totals.Subtotal
+	flat.TotalDiscount = inv.Totals.TotalDiscount
+	flat.TaxableAmount = inv.Totals.TaxableAmount
+	flat.CGST = inv.Totals.CGST
+	flat.SGST = inv.Totals.SGST
+	flat.IGST = inv.Totals.IGST
+	flat.Cess = inv.Totals.Cess
+	flat.RoundOff = inv.Totals.RoundOff
+	flat.Total = inv.Totals.Total
+	flat.AmountInWords = inv.Totals.AmountInWords
+	flat.PaymentTerms = inv.Payment.PaymentTerms
+	flat.LineItemCount = len(inv.LineItems)
+	for i := range inv.LineItems {
+		li := &inv.LineItems[i]
+		flat.LineItems = append(flat.LineItems, FlatLineItem{
+			LineNumber:    i + 1,
+			Description:   li.Description,
+			HSNSACCode:    li.HSNSACCode,
+			Quantity:      li.Quantity,
+			Unit:          li.Unit,
+			UnitPrice:     li.UnitPrice,
+			Discount:      li.Discount,
+			TaxableAmount: li.TaxableAmount,
+			CGSTRate:      li.CGSTRate,
+			CGSTAmount:    li.CGSTAmount,
+			SGSTRate:      li.SGSTRate,
+			SGSTAmount:    li.SGSTAmount,
+			IGSTRate:      li.IGSTRate,
+			IGSTAmount:    li.IGSTAmount,
+			Total:         li.Total,
+		})
+	}
+	return flat
+}
+
+// ListApprovedInput is the query of the approved-document polling feed.
+type ListApprovedInput struct {
+	TenantID     uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	CollectionID *uuid.UUID
+	// Cursor is a next_cursor from an earlier page; empty returns the most
+	// recently approved documents, newest first.
+	Cursor string
+	Limit  int
+}
+
+// ApprovedDocumentsPage is one page of the approved-document feed.
+type ApprovedDocumentsPage struct {
+	Items []FlatDocument `json:"items"`
+	// NextCursor returns the documents approved after this page.
+	NextCursor string `json:"next_cursor"`
+}
+
+// SubscribeHookInput is the DTO for creating a REST hook subscription.
+type SubscribeHookInput struct {
+	TenantID     uuid.UUID               `json:"-"`
+	UserID       uuid.UUID               `json:"-"`
+	Role         domain.UserRole         `json:"-"`
+	TargetURL    string                  `json:"target_url" binding:"required"`
+	Event        domain.IntegrationEvent `json:"event" binding:"required"`
+	CollectionID *uuid.UUID              `json:"collection_id"`
+	// PayloadTemplate optionally renders the delivered body (Go text/template
+	// over FlatDocument); it must produce JSON.
+	PayloadTemplate *string `json:"payload_template"`
+}
+
+// SetHookTemplateInput is the DTO for changing a hook's payload template.
+type SetHookTemplateInput struct {
+	// PayloadTemplate replaces the template; null or "" restores the flat document body.
+	PayloadTemplate *string `json:"payload_template"`
+}
+
+// IntegrationService provides the polling feed and REST hooks used by no-code
+// integrations.
+type IntegrationService interface {
+	// ListApproved returns approved documents the caller can view as flat JSON.
+	ListApproved(ctx context.Context, input *ListApprovedInput) (*ApprovedDocumentsPage, error)
+	Subscribe(ctx context.Context, input *SubscribeHookInput) (*domain.IntegrationHook, error)
+	ListHooks(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.IntegrationHook, error)
+	// SetHookTemplate changes a hook's payload template. Only its owner or a
+	// tenant admin may change it.
+	SetHookTemplate(ctx context.Context, tenantID, hookID, userID uuid.UUID, role domain.UserRole, input *SetHookTemplateInput) (*domain.IntegrationHook, error)
+	// Unsubscribe deletes a hook. Only its owner or a tenant admin may delete it.
+	Unsubscribe(ctx context.Context, tenantID, hookID, userID uuid.UUID, role domain.UserRole) error
+	// NotifyApproved delivers doc to the document.approved hooks in the background.
+	NotifyApproved(doc *domain.Document)
+	// DeliverApproved posts doc to every document.approved hook whose owner can view it.
+	DeliverApproved(ctx context.Context, doc *domain.Document) error
+}
+
+type integrationService struct {
+	repo           port.IntegrationRepository
+	userRepo       port.UserRepository
+	permRepo       port.CollectionPermissionRepository
+	collectionRepo port.CollectionRepository
+	client         *http.Client
+	allowedHosts   []string
+}
+
+// NewIntegrationService creates a new IntegrationService. Hooks are delivered
+// with client; allowedHosts, when set, also restricts the URLs users may subscribe.
+func NewIntegrationService(
+	repo port.IntegrationRepository,
+	userRepo port.UserRepository,
+	permRepo port.CollectionPermissionRepository,
+	collectionRepo port.CollectionRepository,
+	client *http.Client,
+	allowedHosts []string,
+) IntegrationService {
+	return &integrationService{
+		repo:           repo,
+		userRepo:       userRepo,
+		permRepo:       permRepo,
+		collectionRepo: collectionRepo,
+		client:         client,
+		allowedHosts:   allowedHosts,
+	}
+}
+
+func (s *integrationService) ListApproved(ctx context.Context, input *ListApprovedInput) (*ApprovedDocumentsPage, error) {
+	limit := input.Limit
+	if limit <= 0 || limit > integrationFeedMaxLimit {
+		limit = integrationFeedDefaultLimit
+	}
+	if input.CollectionID != nil {
+		if err := s.requireView(ctx, input.TenantID, *input.CollectionID, input.UserID, input.Role); err != nil {
+			return nil, err
+		}
+	}
+	explicitOnly := domain.ImplicitCollectionPerm(input.Role) == ""
+
+	var docs []domain.Document
+	var err error
+	if input.Cursor == "" {
+		docs, err = s.repo.ListRecentlyApproved(ctx, input.TenantID, input.UserID, explicitOnly, input.CollectionID, limit)
+	} else {
+		afterAt, afterID, cerr := decodeApprovalCursor(input.Cursor)
+		if cerr != nil {
+			return nil, cerr
+		}
+		docs, err = s.repo.ListApprovedSince(ctx, input.TenantID, input.UserID, explicitOnly, input.CollectionID, afterAt, afterID, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ApprovedDocumentsPage{Items: make([]FlatDocument, len(docs)), NextCursor: input.Cursor}
+	for i := range docs {
+		page.Items[i] = FlattenDocument(&docs[i])
+	}
+	if len(docs) > 0 {
+		// Newest-first pages continue from their first item, cursor pages from their last
+		latest := &docs[len(docs)-1]
+		if input.Cursor == "" {
+			latest = &docs[0]
+		}
+		page.NextCursor = encodeApprovalCursor(*latest.ReviewedAt, latest.ID)
+	}
+	return page, nil
+}
+
+// encodeApprovalCursor encodes a (reviewed_at, id) position as an opaque token.
+func encodeApprovalCursor(at time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(at.UTC().Format(time.RFC3339Nano) + "|" + id.String()))
+}
+
+func decodeApprovalCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, domain.ErrInvalidCursor
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, domain.ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return time.Time{}, uuid.Nil, domain.ErrInvalidCursor
+	}
+	docID, err := uuid.Parse(id)
+	if err != nil {
+		return time.Time{}, uuid.Nil, domain.ErrInvalidCursor
+	}
+	return t, docID, nil
+}
+
+func (s *integrationService) Subscribe(ctx context.Context, input *SubscribeHookInput) (*domain.IntegrationHook, error) {
+	if input.Event != domain.IntegrationEventDocumentApproved {
+		return nil, domain.ErrInvalidHookEvent
+	}
+	if err := s.validateTarget(input.TargetURL); err != nil {
+		return nil, err
+	}
+	payloadTemplate, err := normalizeHookTemplate(input.PayloadTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if input.CollectionID != nil {
+		if err := s.requireView(ctx, input.TenantID, *input.CollectionID, input.UserID, input.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	hook := &domain.IntegrationHook{
+		ID:              uuid.New(),
+		TenantID:        input.TenantID,
+		UserID:          input.UserID,
+		Event:           input.Event,
+		TargetURL:       input.TargetURL,
+		CollectionID:    input.CollectionID,
+		PayloadTemplate: payloadTemplate,
+	}
+	if err := s.repo.CreateHook(ctx, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// validateTarget accepts https URLs with a host name. IP literals and localhost
+// are rejected so hooks cannot be pointed at internal services.
+func (s *integrationService) validateTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme != "https" || u.User != nil {
+		return domain.ErrInvalidHookTarget
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" || net.ParseIP(host) != nil || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return domain.ErrInvalidHookTarget
+	}
+	if len(s.allowedHosts) > 0 && !httpclient.HostAllowed(host, s.allowedHosts) {
+		return domain.ErrInvalidHookTarget
+	}
+	return nil
+}
+
+func (s *integrationService) ListHooks(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.IntegrationHook, error) {
+	return s.repo.ListHooksByUser(ctx, tenantID, userID)
+}
+
+// normalizeHookTemplate validates a payload template; nil and "" mean none.
+func normalizeHookTemplate(text *string) (*string, error) {
+	if text == nil || *text == "" {
+		return nil, nil
+	}
+	if err := validateHookTemplate(*text); err != nil {
+		return nil, err
+	}
+	return text, nil
+}
+
+func (s *integrationService) SetHookTemplate(
+	ctx context.Context, tenantID, hookID, userID uuid.UUID, role domain.UserRole, input *SetHookTemplateInput,
+) (*domain.IntegrationHook, error) {
+	payloadTemplate, err := normalizeHookTemplate(input.PayloadTemplate)
+	if err != nil {
+		return nil, err
+	}
+	hook, err := s.ownedHook(ctx, tenantID, hookID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateHookTemplate(ctx, tenantID, hookID, payloadTemplate); err != nil {
+		return nil, err
+	}
+	hook.PayloadTemplate = payloadTemplate
+	return hook, nil
+}
+
+func (s *integrationService) Unsubscribe(ctx context.Context, tenantID, hookID, userID uuid.UUID, role domain.UserRole) error {
+	if _, err := s.ownedHook(ctx, tenantID, hookID, userID, role); err != nil {
+		return err
+	}
+	return s.repo.DeleteHook(ctx, tenantID, hookID)
+}
+
+// ownedHook loads a hook the user owns, or any hook of the tenant for admins.
+func (s *integrationService) ownedHook(ctx context.Context, tenantID, hookID, userID uuid.UUID, role domain.UserRole) (*domain.IntegrationHook, error) {
+	hook, err := s.repo.GetHook(ctx, tenantID, hookID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' hooks are reported as missing rather than forbidden
+	if hook.UserID != userID && role != domain.RoleAdmin {
+		return nil, domain.ErrNotFound
+	}
+	return hook, nil
+}
+
+// requireView checks that the collection is in the tenant and the user can view it.
+func (s *integrationService) requireView(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) error {
+	if _, err := s.collectionRepo.GetByID(ctx, tenantID, collectionID); err != nil {
+		return err
+	}
+	if !s.canView(ctx, collectionID, userID, role) {
+		return domain.ErrCollectionPermDenied
+	}
+	return nil
+}
+
+func (s *integrationService) canView(ctx context.Context, collectionID, userID uuid.UUID, role domain.UserRole) bool {
+	if domain.CollectionPermLevel(domain.ImplicitCollectionPerm(role)) >= domain.CollectionPermLevel(domain.CollectionPermViewer) {
+		return true
+	}
+	perm, err := s.permRepo.GetByCollectionAndUser(ctx, collectionID, userID)
+	return err == nil && domain.CollectionPermLevel(perm.Permission) >= domain.CollectionPermLevel(domain.CollectionPermViewer)
+}
+
+func (s *integrationService) NotifyApproved(doc *domain.Document) {
+	snapshot := *doc
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), integrationDeliveryTimeout)
+		defer cancel()
+		if err := s.DeliverApproved(ctx, &snapshot); err != nil {
+			log.Printf("integrationService.NotifyApproved: document %s: %v", snapshot.ID, err)
+		}
+	}()
+}
+
+func (s *integrationService) DeliverApproved(ctx context.Context, doc *domain.Document) error {
+	hooks, err := s.repo.ListHooksForEvent(ctx, doc.TenantID, domain.IntegrationEventDocumentApproved)
+	if err != nil {
+		return err
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+	flat := FlattenDocument(doc)
+	body, err := json.Marshal(flat)
+	if err != nil {
+		return fmt.Errorf("encoding document: %w", err)
+	}
+
+	var errs []error
+	for i := range hooks {
+		hook := &hooks[i]
+		if hook.CollectionID != nil && *hook.CollectionID != doc.CollectionID {
+			continue
+		}
+		user, err := s.userRepo.GetByID(ctx, doc.TenantID, hook.UserID)
+		if err != nil || !user.IsActive || !s.canView(ctx, doc.CollectionID, user.ID, user.Role) {
+			continue
+		}
+		payload := body
+		if hook.PayloadTemplate != nil {
+			// A template that fails on this document skips the hook: its target
+			// expects the templated shape, not the flat document
+			if payload, err = renderHookPayload(*hook.PayloadTemplate, &flat); err != nil {
+				errs = append(errs, fmt.Errorf("hook %s: rendering payload template: %w", hook.ID, err))
+				continue
+			}
+		}
+		if err := s.post(ctx, hook, payload); err != nil {
+			errs = append(errs, fmt.Errorf("hook %s: %w", hook.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *integrationService) post(ctx context.Context, hook *domain.IntegrationHook, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.TargetURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Satvos-Event", string(hook.Event))
+	req.Header.Set("X-Satvos-Hook-Id", hook.ID.String())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling target: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusGone:
+		// REST hook consumers (Zapier) answer 410 when the subscription is gone on their side
+		if err := s.repo.DeleteHook(ctx, hook.TenantID, hook.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("deleting hook after 410: %w", err)
+		}
+		log.Printf("integrationService.post: hook %s removed after 410 from target", hook.ID)
+		return nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("target returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NotifyApprovedListener returns a document.reviewed listener that delivers
+// approved documents to integration hooks.
+func NotifyApprovedListener(integrations IntegrationService) DocumentEventListener {
+	return func(_ context.Context, e *DocumentEvent) {
+		if e.Document.ReviewStatus == domain.ReviewStatusApproved {
+			integrations.NotifyApproved(e.Document)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+const (
+	// hookTemplateMaxLen bounds a hook's payload template.
+	hookTemplateMaxLen = 8000
+	// hookPayloadMaxLen bounds a rendered body, which templates ranging over
+	// line items could otherwise grow without limit.
+	hookPayloadMaxLen = 1 << 20
+)
+
+// hookTemplateFuncs are the functions payload templates may call besides the
+// text/template builtins. json encodes a value as JSON, so string fields come
+// out quoted and escaped: {"vendor": {{json .SellerName}}}.
+var hookTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// renderHookPayload renders a payload template over flat. The result must be
+// JSON, since hooks are delivered as application/json.
+func renderHookPayload(text string, flat *FlatDocument) ([]byte, error) {
+	tmpl, err := template.New("payload").Funcs(hookTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, flat); err != nil {
+		return nil, err
+	}
+	if buf.Len() > hookPayloadMaxLen {
+		return nil, fmt.Errorf("rendered payload is larger than %d bytes", hookPayloadMaxLen)
+	}
+	body := []byte(buf.String())
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("rendered payload is not valid JSON")
+	}
+	return body, nil
+}
+
+// validateHookTemplate checks a payload template by rendering it over a sample
+// document, so templates naming unknown fields are rejected when saved.
+func validateHookTemplate(text string) error {
+	if strings.TrimSpace(text) == "" || len(text) > hookTemplateMaxLen {
+		return fmt.Errorf("%w: empty or longer than %d characters", domain.ErrInvalidHookTemplate, hookTemplateMaxLen)
+	}
+	if _, err := renderHookPayload(text, sampleFlatDocument()); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidHookTemplate, err)
+	}
+	return nil
+}
+
+// sampleFlatDocument is the document payload templates are validated with. It
+// has one line item: a template indexing the first line passes validation but
+// fails at delivery on invoices without lines.
+func sampleFlatDocument() *FlatDocument {
+	reviewedBy := uuid.Nil
+	at := time.Date(2026, 1, 12, 10, 30, 0, 0, time.UTC)
+	return &FlatDocument{
+		Name:                 "sample-invoice.pdf",
+		ReviewStatus:         string(domain.ReviewStatusApproved),
+		ValidationStatus:     string(domain.ValidationStatusValid),
+		ReconciliationStatus: string(domain.ReconciliationStatusValid),
+		ReviewedBy:           &reviewedBy,
+		ReviewedAt:           &at,
+		InvoiceNumber:        "INV-0001",
+		InvoiceDate:          "2026-01-05",
+		DueDate:              "2026-02-04",
+		InvoiceType:          "tax_invoice",
+		Currency:             "INR",
+		PlaceOfSupply:        "Karnataka",
+		SellerName:           "Sample Supplier Pvt Ltd",
+		SellerGSTIN:          "29ABCDE1234F1Z5",
+		SellerPAN:            "ABCDE1234F",
+		SellerAddress:        "1 MG Road, Bengaluru",
+		SellerStateCode:      "29",
+		BuyerName:            "Sample Buyer Ltd",
+		BuyerGSTIN:           "29PQRSX5678K1Z2",
+		BuyerPAN:             "PQRSX5678K",
+		BuyerAddress:         "2 Residency Road, Bengaluru",
+		BuyerStateCode:       "29",
+		Subtotal:             1000,
+		TaxableAmount:        1000,
+		CGST:                 90,
+		SGST:                 90,
+		Total:                1180,
+		AmountInWords:        "One Thousand One Hundred Eighty Rupees Only",
+		PaymentTerms:         "Net 30",
+		LineItemCount:        1,
+		LineItems: []FlatLineItem{{
+			LineNumber: 1, Description: "Consulting services", HSNSACCode: "998311",
+			Quantity: 1, Unit: "NOS", UnitPrice: 1000, TaxableAmount: 1000,
+			CGSTRate: 9, CGSTAmount: 90, SGSTRate: 9, SGSTAmount: 90, Total: 1180,
+		}},
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+}
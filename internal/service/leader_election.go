@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"satvos/internal/port"
+)
+
+const (
+	// leaderReleaseTimeout bounds giving up the lock once work has stopped.
+	leaderReleaseTimeout = 5 * time.Second
+	// defaultLeaderInterval replaces an interval that is not positive.
+	defaultLeaderInterval = 15 * time.Second
+)
+
+// LeaderElection runs a worker on only one instance at a time. The other
+// instances stand by and take over once the leader's lock is released.
+type LeaderElection struct {
+	name     string
+	lock     port.LeaderLock
+	interval time.Duration
+	work     func(ctx context.Context)
+}
+
+// NewLeaderElection creates a LeaderElection that runs work while holding lock.
+// Standby instances try to take the lock every interval, and the leader checks
+// it still holds the lock as often, so failover takes at most about one interval
+// after the leader's database session ends. An interval that is not positive
+// is replaced by defaultLeaderInterval.
+func NewLeaderElection(name string, lock port.LeaderLock, interval time.Duration, work func(ctx context.Context)) *LeaderElection {
+	if interval <= 0 {
+		interval = defaultLeaderInterval
+	}
+	return &LeaderElection{name: name, lock: lock, interval: interval, work: work}
+}
+
+// Start runs the election until ctx is canceled. While this instance leads,
+// work runs with a context that is canceled when the lock is lost or ctx ends.
+// Start waits for work to return before standing by again or returning.
+func (e *LeaderElection) Start(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	log.Printf("leaderElection[%s]: standing by (interval=%s)", e.name, e.interval)
+
+	for {
+		acquired, err := e.lock.TryAcquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("leaderElection[%s]: TryAcquire error: %v", e.name, err)
+		}
+		if acquired {
+			e.lead(ctx, ticker)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("leaderElection[%s]: shutdown complete", e.name)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// lead runs work until it returns, ctx ends or the lock is lost, then releases the lock.
+func (e *LeaderElection) lead(ctx context.Context, ticker *time.Ticker) {
+	log.Printf("leaderElection[%s]: became leader", e.name)
+
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.work(workCtx)
+	}()
+
+	for stop := false; !stop; {
+		select {
+		case <-ctx.Done():
+			stop = true
+		case <-done:
+			stop = true
+		case <-ticker.C:
+			if err := e.lock.Check(ctx); err != nil {
+				if ctx.Err() == nil {
+					log.Printf("leaderElection[%s]: lost leadership: %v", e.name, err)
+				}
+				stop = true
+			}
+		}
+	}
+	cancel()
+	<-done
+
+	releaseCtx, cancelRelease := context.WithTimeout(context.WithoutCancel(ctx), leaderReleaseTimeout)
+	defer cancelRelease()
+	if err := e.lock.Release(releaseCtx); err != nil {
+		log.Printf("leaderElection[%s]: Release error: %v", e.name, err)
+	}
+	log.Printf("leaderElection[%s]: stepped down", e.name)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/notify"
+	"satvos/internal/port"
+)
+
+// NotificationKindRoute is where one notification kind is delivered for a tenant.
+type NotificationKindRoute struct {
+	Kind     domain.NotificationKind  `json:"kind"`
+	Channels []domain.NotifierChannel `json:"channels"`
+	// Overridden is true when the tenant routes the kind itself rather than
+	// using the server default.
+	Overridden bool `json:"overridden"`
+}
+
+// NotificationRouteService manages which channels each notification kind is
+// delivered on for a tenant.
+type NotificationRouteService interface {
+	// Get returns the effective route of every notification kind.
+	Get(ctx context.Context, tenantID uuid.UUID) ([]NotificationKindRoute, error)
+	// Set replaces the tenant's overrides; kinds left out use the server default.
+	Set(ctx context.Context, tenantID uuid.UUID, routes map[domain.NotificationKind][]domain.NotifierChannel) ([]NotificationKindRoute, error)
+}
+
+type notificationRouteService struct {
+	tenantRepo port.TenantRepository
+	routeRepo  port.NotificationRouteRepository
+	router     *notify.Router
+}
+
+// NewNotificationRouteService creates a new NotificationRouteService.
+func NewNotificationRouteService(
+	tenantRepo port.TenantRepository,
+	routeRepo port.NotificationRouteRepository,
+	router *notify.Router,
+) NotificationRouteService {
+	return &notificationRouteService{tenantRepo: tenantRepo, routeRepo: routeRepo, router: router}
+}
+
+func (s *notificationRouteService) Get(ctx context.Context, tenantID uuid.UUID) ([]NotificationKindRoute, error) {
+	if _, err := s.tenantRepo.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	overrides, err := s.routeRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	byKind := make(map[domain.NotificationKind][]domain.NotifierChannel, len(overrides))
+	for i := range overrides {
+		channels := make([]domain.NotifierChannel, len(overrides[i].Channels))
+		for j, ch := range overrides[i].Channels {
+			channels[j] = domain.NotifierChannel(ch)
+		}
+		byKind[overrides[i].Kind] = channels
+	}
+
+	routes := make([]NotificationKindRoute, 0, len(domain.NotificationKinds))
+	for _, kind := range domain.NotificationKinds {
+		route := NotificationKindRoute{Kind: kind, Channels: s.router.Default(kind)}
+		if channels, ok := byKind[kind]; ok {
+			route.Channels, route.Overridden = channels, true
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func (s *notificationRouteService) Set(ctx context.Context, tenantID uuid.UUID, routes map[domain.NotificationKind][]domain.NotifierChannel) ([]NotificationKindRoute, error) {
+	for kind, channels := range routes {
+		if err := notify.ValidateRoute(kind, channels); err != nil {
+			return nil, err
+		}
+		for _, ch := range channels {
+			if !s.router.Available(ch) {
+				return nil, fmt.Errorf("%w: channel %s is not configured on this server", domain.ErrInvalidNotificationRoutes, ch)
+			}
+		}
+	}
+
+	// Stored in kind order so the result doesn't depend on map iteration
+	stored := make([]domain.NotificationRoute, 0, len(routes))
+	for _, kind := range domain.NotificationKinds {
+		channels, ok := routes[kind]
+		if !ok {
+			continue
+		}
+		route := domain.NotificationRoute{TenantID: tenantID, Kind: kind}
+		for _, ch := range channels {
+			route.Channels = append(route.Channels, string(ch))
+		}
+		stored = append(stored, route)
+	}
+
+	if _, err := s.tenantRepo.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	if err := s.routeRepo.Replace(ctx, tenantID, stored); err != nil {
+		return nil, err
+	}
+
+	log.Printf("notificationRouteService.Set: tenant %s now overrides %d notification routes", tenantID, len(stored))
+	return s.Get(ctx, tenantID)
+}
@@ -0,0 +1,663 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/cloud"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	notificationDefaultSLAHours = 48
+	notificationMaxSLAHours     = 720
+	notificationTemplateMaxLen  = 2000
+	notificationMessageMaxLen   = 3000
+	// notificationOverdueLimit caps the documents listed in one SLA breach message;
+	// the rest are reported on the next check.
+	notificationOverdueLimit    = 20
+	notificationDeliveryTimeout = 30 * time.Second
+	notificationSummaryPeriod   = 7 * 24 * time.Hour
+	// notificationSummaryHour is when weekly summaries go out on Mondays, in
+	// the tenant's time zone.
+	notificationSummaryHour = 9
+)
+
+// defaultNotificationTemplates are the messages used when a channel has no
+// template of its own for an event.
+var defaultNotificationTemplates = map[domain.NotificationEvent]string{
+	domain.NotificationEventParseFailed:      `Parsing failed for "{{.Document.Name}}" in {{.Collection}}: {{.Document.Error}}`,
+	domain.NotificationEventDocumentAssigned: `"{{.Document.Name}}" in {{.Collection}} was assigned to {{.Document.Assignee}} for review.`,
+	domain.NotificationEventReviewSLABreached: `{{len .Documents}} document(s) in {{.Collection}} have waited more than {{.ReviewSLAHours}}h for review:` +
+		`{{range .Documents}}
+- {{.Name}} ({{.WaitingHours}}h){{end}}`,
+	domain.NotificationEventWeeklySummary: `Weekly summary for {{.Collection}} ({{.Summary.From}} to {{.Summary.To}}): ` +
+		`{{.Summary.Uploaded}} uploaded, {{.Summary.Approved}} approved, {{.Summary.Rejected}} rejected, ` +
+		`{{.Summary.ParseFailed}} failed to parse. {{.Summary.PendingReview}} awaiting review.`,
+	domain.NotificationEventReviewEscalated: `"{{.Document.Name}}" in {{.Collection}} has waited {{.Document.WaitingHours}}h for review and was escalated.` +
+		`{{if .Document.Assignee}} Assigned to {{.Document.Assignee}}.{{end}}`,
+}
+
+// NotificationChannelInput is the DTO for creating or replacing a notification channel.
+type NotificationChannelInput struct {
+	Provider domain.NotificationProvider `json:"provider" binding:"required"`
+	// WebhookURL is the incoming webhook URL; on update, empty keeps the current one.
+	WebhookURL string                     `json:"webhook_url"`
+	Events     []domain.NotificationEvent `json:"events" binding:"required"`
+	// Templates overrides the message of individual events (Go text/template).
+	Templates      map[domain.NotificationEvent]string `json:"templates"`
+	ReviewSLAHours int                                 `json:"review_sla_hours"` // 0 means 48
+	Enabled        *bool                               `json:"enabled"`          // defaults to true
+}
+
+// NotificationMessage is the data a channel's message templates render.
+type NotificationMessage struct {
+	Event          domain.NotificationEvent
+	Collection     string
+	Document       *NotificationDocument
+	Documents      []NotificationDocument
+	ReviewSLAHours int
+	Summary        *NotificationSummary
+}
+
+// NotificationDocument describes one document in a notification message.
+type NotificationDocument struct {
+	ID           string
+	Name         string
+	Error        string
+	Assignee     string
+	WaitingHours int
+}
+
+// NotificationSummary is a collection's activity over a weekly summary period.
+type NotificationSummary struct {
+	From string
+	To   string
+	domain.CollectionActivity
+}
+
+// NotificationService manages per-collection Slack / Microsoft Teams channels and
+// posts collection events to them.
+type NotificationService interface {
+	ListChannels(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) ([]domain.NotificationChannel, error)
+	CreateChannel(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, input *NotificationChannelInput) (*domain.NotificationChannel, error)
+	UpdateChannel(ctx context.Context, tenantID, collectionID, channelID, userID uuid.UUID, role domain.UserRole, input *NotificationChannelInput) (*domain.NotificationChannel, error)
+	DeleteChannel(ctx context.Context, tenantID, collectionID, channelID, userID uuid.UUID, role domain.UserRole) error
+	// TestChannel posts a sample message synchronously. event selects the template
+	// to preview with sample data; empty sends a plain test message.
+	TestChannel(ctx context.Context, tenantID, collectionID, channelID, userID uuid.UUID, role domain.UserRole, event domain.NotificationEvent) error
+	// NotifyDocument posts a document event to the collection's channels in the background.
+	NotifyDocument(event domain.NotificationEvent, doc *domain.Document)
+	// RunScheduled posts review SLA breaches and due weekly summaries for every channel.
+	RunScheduled(ctx context.Context) error
+}
+
+type notificationService struct {
+	repo           port.NotificationChannelRepository
+	collectionRepo port.CollectionRepository
+	permRepo       port.CollectionPermissionRepository
+	userRepo       port.UserRepository
+	sealer         *cloud.TokenSealer
+	client         *http.Client
+}
+
+// NewNotificationService creates a new NotificationService. Webhook URLs are
+// sealed with sealer at rest and posted to with client.
+func NewNotificationService(
+	repo port.NotificationChannelRepository,
+	collectionRepo port.CollectionRepository,
+	permRepo port.CollectionPermissionRepository,
+	userRepo port.UserRepository,
+	sealer *cloud.TokenSealer,
+	client *http.Client,
+) NotificationService {
+	return &notificationService{
+		repo:           repo,
+		collectionRepo: collectionRepo,
+		permRepo:       permRepo,
+		userRepo:       userRepo,
+		sealer:         sealer,
+		client:         client,
+	}
+}
+
+// requireOwner loads the collection and checks the user has owner permission on it.
+func (s *notificationService) requireOwner(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) (*domain.Collection, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, tenantID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	level := domain.CollectionPermLevel(domain.ImplicitCollectionPerm(role))
+	if level < domain.CollectionPermLevel(domain.CollectionPermOwner) {
+		if perm, err := s.permRepo.GetByCollectionAndUser(ctx, collectionID, userID); err == nil {
+			level = max(level, domain.CollectionPermLevel(perm.Permission))
+		}
+	}
+	if level < domain.CollectionPermLevel(domain.CollectionPermOwner) {
+		return nil, domain.ErrCollectionPermDenied
+	}
+	return collection, nil
+}
+
+// collectionChannel loads a channel and checks it belongs to the collection.
+func (s *notificationService) collectionChannel(ctx context.Context, tenantID, collectionID, channelID uuid.UUID) (*domain.NotificationChannel, error) {
+	ch, err := s.repo.GetByID(ctx, tenantID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if ch.CollectionID != collectionID {
+		return nil, domain.ErrNotFound
+	}
+	return ch, nil
+}
+
+func (s *notificationService) ListChannels(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) ([]domain.NotificationChannel, error) {
+	if _, err := s.requireOwner(ctx, tenantID, collectionID, userID, role); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByCollection(ctx, tenantID, collectionID)
+}
+
+func (s *notificationService) CreateChannel(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, input *NotificationChannelInput) (*domain.NotificationChannel, error) {
+	if _, err := s.requireOwner(ctx, tenantID, collectionID, userID, role); err != nil {
+		return nil, err
+	}
+	if input.WebhookURL == "" {
+		return nil, domain.ErrInvalidNotificationChannel
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	ch := &domain.NotificationChannel{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		CreatedBy:    userID,
+	}
+	if err := s.apply(ch, input); err != nil {
+		return nil, err
+	}
+	ch.NextSummaryAt = nextWeeklySummary(now, collectionLocation(ctx, s.collectionRepo, tenantID, collectionID))
+	// Only documents parsed from now on are checked against the SLA
+	ch.SLACheckedThrough = now.Add(-time.Duration(ch.ReviewSLAHours) * time.Hour)
+	if err := s.repo.Create(ctx, ch); err != nil {
+		return nil, err
+	}
+
+	log.Printf("notificationService.CreateChannel: created %s channel %s for collection %s (tenant %s)",
+		ch.Provider, ch.ID, collectionID, tenantID)
+	return ch, nil
+}
+
+func (s *notificationService) UpdateChannel(ctx context.Context, tenantID, collectionID, channelID, userID uuid.UUID, role domain.UserRole, input *NotificationChannelInput) (*domain.NotificationChannel, error) {
+	if _, err := s.requireOwner(ctx, tenantID, collectionID, userID, role); err != nil {
+		return nil, err
+	}
+	ch, err := s.collectionChannel(ctx, tenantID, collectionID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if input.WebhookURL == "" && input.Provider != ch.Provider {
+		// The stored URL was validated for the old provider
+		return nil, domain.ErrInvalidNotificationChannel
+	}
+	if err := s.apply(ch, input); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, ch); err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// apply validates input and copies it onto ch, sealing a new webhook URL.
+func (s *notificationService) apply(ch *domain.NotificationChannel, input *NotificationChannelInput) error {
+	if input.Provider != domain.NotificationProviderSlack && input.Provider != domain.NotificationProviderTeams {
+		return domain.ErrInvalidNotificationChannel
+	}
+	if input.WebhookURL != "" {
+		host, err := notificationWebhookHost(input.Provider, input.WebhookURL)
+		if err != nil {
+			return err
+		}
+		sealed, err := s.sealer.Seal(input.WebhookURL)
+		if err != nil {
+			return fmt.Errorf("sealing webhook URL: %w", err)
+		}
+		ch.WebhookURL = sealed
+		ch.WebhookHost = host
+	}
+
+	if len(input.Events) == 0 {
+		return domain.ErrInvalidNotificationChannel
+	}
+	events := make([]string, 0, len(input.Events))
+	for _, e := range input.Events {
+		if _, ok := defaultNotificationTemplates[e]; !ok {
+			return domain.ErrInvalidNotificationChannel
+		}
+		if !containsString(events, string(e)) {
+			events = append(events, string(e))
+		}
+	}
+
+	for event, text := range input.Templates {
+		if _, ok := defaultNotificationTemplates[event]; !ok {
+			return domain.ErrInvalidNotificationTemplate
+		}
+		if len(text) > notificationTemplateMaxLen {
+			return domain.ErrInvalidNotificationTemplate
+		}
+		if _, err := renderNotification(text, sampleNotificationMessage(event)); err != nil {
+			return domain.ErrInvalidNotificationTemplate
+		}
+	}
+	templates := input.Templates
+	if templates == nil {
+		templates = map[domain.NotificationEvent]string{}
+	}
+	templatesJSON, err := json.Marshal(templates)
+	if err != nil {
+		return fmt.Errorf("encoding templates: %w", err)
+	}
+
+	hours := input.ReviewSLAHours
+	if hours == 0 {
+		hours = notificationDefaultSLAHours
+	}
+	if hours < 1 || hours > notificationMaxSLAHours {
+		return domain.ErrInvalidNotificationChannel
+	}
+
+	ch.Provider = input.Provider
+	ch.Events = events
+	ch.Templates = templatesJSON
+	ch.ReviewSLAHours = hours
+	ch.Enabled = input.Enabled == nil || *input.Enabled
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// notificationWebhookHost checks that raw is an incoming webhook URL of the
+// provider and returns its host. Only the providers' own hosts are accepted,
+// so a channel cannot be used to make requests to arbitrary servers.
+func notificationWebhookHost(provider domain.NotificationProvider, raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return "", domain.ErrInvalidNotificationChannel
+	}
+	host := strings.ToLower(u.Hostname())
+	switch provider {
+	case domain.NotificationProviderSlack:
+		if host == "hooks.slack.com" {
+			return host, nil
+		}
+	case domain.NotificationProviderTeams:
+		// Office 365 connectors and Power Automate "Workflows" webhooks
+		if strings.HasSuffix(host, ".webhook.office.com") || strings.HasSuffix(host, ".logic.azure.com") {
+			return host, nil
+		}
+	}
+	return "", domain.ErrInvalidNotificationChannel
+}
+
+func (s *notificationService) DeleteChannel(ctx context.Context, tenantID, collectionID, channelID, userID uuid.UUID, role domain.UserRole) error {
+	if _, err := s.requireOwner(ctx, tenantID, collectionID, userID, role); err != nil {
+		return err
+	}
+	if _, err := s.collectionChannel(ctx, tenantID, collectionID, channelID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, tenantID, channelID)
+}
+
+func (s *notificationService) TestChannel(ctx context.Context, tenantID, collectionID, channelID, userID uuid.UUID, role domain.UserRole, event domain.NotificationEvent) error {
+	collection, err := s.requireOwner(ctx, tenantID, collectionID, userID, role)
+	if err != nil {
+		return err
+	}
+	ch, err := s.collectionChannel(ctx, tenantID, collectionID, channelID)
+	if err != nil {
+		return err
+	}
+
+	text := fmt.Sprintf("Test message from SATVOS for collection %s.", collection.Name)
+	if event != "" {
+		if _, ok := defaultNotificationTemplates[event]; !ok {
+			return domain.ErrInvalidNotificationChannel
+		}
+		msg := sampleNotificationMessage(event)
+		msg.Collection = collection.Name
+		if text, err = s.message(ch, event, msg); err != nil {
+			return err
+		}
+	}
+	return s.deliver(ctx, ch, text)
+}
+
+func (s *notificationService) NotifyDocument(event domain.NotificationEvent, doc *domain.Document) {
+	snapshot := *doc
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notificationDeliveryTimeout)
+		defer cancel()
+		if err := s.notifyDocument(ctx, event, &snapshot); err != nil {
+			log.Printf("notificationService.NotifyDocument: %s for document %s: %v", event, snapshot.ID, err)
+		}
+	}()
+}
+
+func (s *notificationService) notifyDocument(ctx context.Context, event domain.NotificationEvent, doc *domain.Document) error {
+	channels, err := s.repo.ListForEvent(ctx, doc.TenantID, doc.CollectionID, event)
+	if err != nil || len(channels) == 0 {
+		return err
+	}
+	collection, err := s.collectionRepo.GetByID(ctx, doc.TenantID, doc.CollectionID)
+	if err != nil {
+		return err
+	}
+
+	nd := &NotificationDocument{ID: doc.ID.String(), Name: doc.Name, Error: doc.ParsingError}
+	if doc.ParsedAt != nil {
+		nd.WaitingHours = int(time.Since(*doc.ParsedAt).Hours())
+	}
+	if doc.AssignedTo != nil {
+		nd.Assignee = doc.AssignedTo.String()
+		if user, err := s.userRepo.GetByID(ctx, doc.TenantID, *doc.AssignedTo); err == nil {
+			nd.Assignee = user.FullName
+		}
+	}
+	msg := &NotificationMessage{Event: event, Collection: collection.Name, Document: nd}
+
+	var errs []error
+	for i := range channels {
+		if err := s.send(ctx, &channels[i], event, msg); err != nil {
+			errs = append(errs, fmt.Errorf("channel %s: %w", channels[i].ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *notificationService) RunScheduled(ctx context.Context) error {
+	channels, err := s.repo.ListScheduled(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	for i := range channels {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		ch := &channels[i]
+		if ch.HasEvent(domain.NotificationEventReviewSLABreached) {
+			if err := s.checkReviewSLA(ctx, ch, now); err != nil {
+				log.Printf("notificationService.RunScheduled: SLA check for channel %s: %v", ch.ID, err)
+			}
+		}
+		if ch.HasEvent(domain.NotificationEventWeeklySummary) && !ch.NextSummaryAt.After(now) {
+			if err := s.sendWeeklySummary(ctx, ch, now); err != nil {
+				log.Printf("notificationService.RunScheduled: weekly summary for channel %s: %v", ch.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkReviewSLA reports documents that became overdue since the last check.
+// The window is claimed before posting, so each breach is reported at most once.
+func (s *notificationService) checkReviewSLA(ctx context.Context, ch *domain.NotificationChannel, now time.Time) error {
+	through := now.Add(-time.Duration(ch.ReviewSLAHours) * time.Hour)
+	if !through.After(ch.SLACheckedThrough) {
+		return nil
+	}
+	docs, err := s.repo.ListOverdueReviews(ctx, ch.TenantID, ch.CollectionID, ch.SLACheckedThrough, through, notificationOverdueLimit)
+	if err != nil {
+		return err
+	}
+	if len(docs) == notificationOverdueLimit {
+		// Leave the rest for the next check
+		through = *docs[len(docs)-1].ParsedAt
+	}
+	claimed, err := s.repo.AdvanceSLACheck(ctx, ch.ID, ch.SLACheckedThrough, through)
+	if err != nil || !claimed || len(docs) == 0 {
+		return err
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, ch.TenantID, ch.CollectionID)
+	if err != nil {
+		return err
+	}
+	msg := &NotificationMessage{
+		Event:          domain.NotificationEventReviewSLABreached,
+		Collection:     collection.Name,
+		ReviewSLAHours: ch.ReviewSLAHours,
+		Documents:      make([]NotificationDocument, len(docs)),
+	}
+	for i := range docs {
+		msg.Documents[i] = NotificationDocument{
+			ID:           docs[i].ID.String(),
+			Name:         docs[i].Name,
+			WaitingHours: int(now.Sub(*docs[i].ParsedAt).Hours()),
+		}
+	}
+	return s.send(ctx, ch, domain.NotificationEventReviewSLABreached, msg)
+}
+
+func (s *notificationService) sendWeeklySummary(ctx context.Context, ch *domain.NotificationChannel, now time.Time) error {
+	next := nextWeeklySummary(now, collectionLocation(ctx, s.collectionRepo, ch.TenantID, ch.CollectionID))
+	claimed, err := s.repo.AdvanceSummary(ctx, ch.ID, ch.NextSummaryAt, next)
+	if err != nil || !claimed {
+		return err
+	}
+
+	from := now.Add(-notificationSummaryPeriod)
+	activity, err := s.repo.CollectionActivity(ctx, ch.TenantID, ch.CollectionID, from, now)
+	if err != nil {
+		return err
+	}
+	collection, err := s.collectionRepo.GetByID(ctx, ch.TenantID, ch.CollectionID)
+	if err != nil {
+		return err
+	}
+	msg := &NotificationMessage{
+		Event:      domain.NotificationEventWeeklySummary,
+		Collection: collection.Name,
+		Summary: &NotificationSummary{
+			From:               from.Format("2006-01-02"),
+			To:                 now.Format("2006-01-02"),
+			CollectionActivity: *activity,
+		},
+	}
+	return s.send(ctx, ch, domain.NotificationEventWeeklySummary, msg)
+}
+
+// nextWeeklySummary returns the first Monday summary time in loc after t.
+func nextWeeklySummary(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	days := (int(time.Monday) - int(t.Weekday()) + 7) % 7
+	next := time.Date(t.Year(), t.Month(), t.Day()+days, notificationSummaryHour, 0, 0, 0, loc)
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next.UTC()
+}
+
+func (s *notificationService) send(ctx context.Context, ch *domain.NotificationChannel, event domain.NotificationEvent, msg *NotificationMessage) error {
+	text, err := s.message(ch, event, msg)
+	if err != nil {
+		return err
+	}
+	return s.deliver(ctx, ch, text)
+}
+
+// message renders the channel's template for event, or the default one.
+func (s *notificationService) message(ch *domain.NotificationChannel, event domain.NotificationEvent, msg *NotificationMessage) (string, error) {
+	text := defaultNotificationTemplates[event]
+	var custom map[domain.NotificationEvent]string
+	if len(ch.Templates) > 0 && json.Unmarshal(ch.Templates, &custom) == nil && custom[event] != "" {
+		text = custom[event]
+	}
+	return renderNotification(text, msg)
+}
+
+func renderNotification(text string, msg *NotificationMessage) (string, error) {
+	tmpl, err := template.New("notification").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, msg); err != nil {
+		return "", err
+	}
+	out := buf.String()
+	if len(out) > notificationMessageMaxLen {
+		out = strings.ToValidUTF8(out[:notificationMessageMaxLen], "") + "…"
+	}
+	return out, nil
+}
+
+// sampleNotificationMessage is the data templates are validated and previewed
+// with. Only the fields the event fills in are set, so a template that reads
+// another event's fields fails validation instead of at delivery.
+func sampleNotificationMessage(event domain.NotificationEvent) *NotificationMessage {
+	doc := NotificationDocument{ID: uuid.Nil.String(), Name: "sample-invoice.pdf"}
+	msg := &NotificationMessage{Event: event, Collection: "Sample collection"}
+	switch event {
+	case domain.NotificationEventParseFailed:
+		doc.Error = "parser returned no invoice data"
+		msg.Document = &doc
+	case domain.NotificationEventDocumentAssigned:
+		doc.Assignee = "Sample Reviewer"
+		msg.Document = &doc
+	case domain.NotificationEventReviewSLABreached:
+		doc.WaitingHours = notificationDefaultSLAHours + 2
+		msg.Documents = []NotificationDocument{doc}
+		msg.ReviewSLAHours = notificationDefaultSLAHours
+	case domain.NotificationEventReviewEscalated:
+		doc.Assignee = "Sample Owner"
+		doc.WaitingHours = 74
+		msg.Document = &doc
+	case domain.NotificationEventWeeklySummary:
+		msg.Summary = &NotificationSummary{
+			From: "2026-01-05",
+			To:   "2026-01-12",
+			CollectionActivity: domain.CollectionActivity{
+				Uploaded: 42, Approved: 30, Rejected: 3, ParseFailed: 2, PendingReview: 9,
+			},
+		}
+	}
+	return msg
+}
+
+// deliver posts text to the channel's webhook and records the outcome on the channel.
+func (s *notificationService) deliver(ctx context.Context, ch *domain.NotificationChannel, text string) error {
+	err := s.post(ctx, ch, text)
+	lastError := ""
+	if err != nil {
+		lastError = err.Error()
+	}
+	if recErr := s.repo.RecordDelivery(ctx, ch.ID, time.Now().UTC(), lastError); recErr != nil {
+		log.Printf("notificationService.deliver: recording delivery for channel %s: %v", ch.ID, recErr)
+	}
+	return err
+}
+
+func (s *notificationService) post(ctx context.Context, ch *domain.NotificationChannel, text string) error {
+	target, err := s.sealer.Open(ch.WebhookURL)
+	if err != nil {
+		return fmt.Errorf("opening webhook URL: %w", err)
+	}
+	body, err := notificationPayload(ch.Provider, text)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// The URL is a secret; don't let it reach logs or API responses
+		return fmt.Errorf("%w: posting to %s failed", domain.ErrNotificationDeliveryFailed, ch.WebhookHost)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %s returned status %d", domain.ErrNotificationDeliveryFailed, ch.WebhookHost, resp.StatusCode)
+	}
+	return nil
+}
+
+// notificationPayload wraps text in the provider's incoming webhook format.
+func notificationPayload(provider domain.NotificationProvider, text string) ([]byte, error) {
+	var payload interface{}
+	switch provider {
+	case domain.NotificationProviderTeams:
+		summary, _, _ := strings.Cut(text, "\n")
+		payload = map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  summary,
+			// Teams markdown needs a blank line to break a line
+			"text": strings.ReplaceAll(text, "\n", "\n\n"),
+		}
+	default:
+		payload = map[string]string{"text": text}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+	return body, nil
+}
+
+// NotifyParseFailedListener returns a document.parse_failed listener that posts
+// the failure to the collection's notification channels.
+func NotifyParseFailedListener(notifications NotificationService) DocumentEventListener {
+	return func(_ context.Context, e *DocumentEvent) {
+		notifications.NotifyDocument(domain.NotificationEventParseFailed, e.Document)
+	}
+}
+
+// channelNotifyingDocumentRepo wraps a DocumentRepository and posts assignments
+// to the collection's notification channels. Review escalations reassign
+// documents too, outside the document service's events.
+type channelNotifyingDocumentRepo struct {
+	port.DocumentRepository
+	notifications NotificationService
+}
+
+// NewChannelNotifyingDocumentRepo wraps repo so assignments reach Slack / Teams
+// channels.
+func NewChannelNotifyingDocumentRepo(repo port.DocumentRepository, notifications NotificationService) port.DocumentRepository {
+	return &channelNotifyingDocumentRepo{DocumentRepository: repo, notifications: notifications}
+}
+
+func (r *channelNotifyingDocumentRepo) UpdateAssignment(ctx context.Context, doc *domain.Document) error {
+	if err := r.DocumentRepository.UpdateAssignment(ctx, doc); err != nil {
+		return err
+	}
+	if doc.AssignedTo != nil {
+		r.notifications.NotifyDocument(domain.NotificationEventDocumentAssigned, doc)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// NotificationWorker periodically posts review SLA breaches and weekly summaries
+// to notification channels.
+type NotificationWorker struct {
+	svc      NotificationService
+	interval time.Duration
+}
+
+// NewNotificationWorker creates a new NotificationWorker that checks channels every interval.
+func NewNotificationWorker(svc NotificationService, interval time.Duration) *NotificationWorker {
+	return &NotificationWorker{svc: svc, interval: interval}
+}
+
+// Start runs the notification loop until ctx is canceled.
+func (w *NotificationWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	log.Printf("notificationWorker: started (interval=%s)", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("notificationWorker: shutdown complete")
+			return
+		case <-ticker.C:
+			if err := w.svc.RunScheduled(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("notificationWorker: RunScheduled error: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	// maxOrphanDocumentRun caps how many documents one CreateDocuments call
+	// creates. Documents are created inline, so the cap bounds the request.
+	maxOrphanDocumentRun = 500
+	// orphanNudgeEvery is the least time between two nudges about a collection.
+	orphanNudgeEvery = 7 * 24 * time.Hour
+)
+
+// OrphanDocumentsInput is the DTO for creating documents for a collection's
+// orphan files uploaded more than OlderThanDays days ago.
+type OrphanDocumentsInput struct {
+	TenantID      uuid.UUID
+	CollectionID  uuid.UUID
+	UserID        uuid.UUID
+	Role          domain.UserRole
+	DocumentType  string
+	ParseMode     domain.ParseMode
+	OlderThanDays int
+}
+
+// OrphanFileService finds files uploaded to collections that never became
+// documents, creates their documents on request and reminds collection owners
+// of them.
+type OrphanFileService interface {
+	// Summary counts the tenant's orphan files per collection.
+	Summary(ctx context.Context, tenantID uuid.UUID, olderThanDays int) ([]domain.OrphanFileSummary, error)
+	// List lists a collection's orphan files, oldest first.
+	List(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, olderThanDays, offset, limit int) ([]domain.FileMeta, int, error)
+	// CreateDocuments creates and parses a document for each of the
+	// collection's orphan files, up to maxOrphanDocumentRun per call.
+	CreateDocuments(ctx context.Context, input *OrphanDocumentsInput) (*domain.OrphanDocumentRun, error)
+	// RunNudges emails the creator of each collection with orphan files, in
+	// tenants with the orphan_file_nudges flag, at most once a week.
+	RunNudges(ctx context.Context) error
+}
+
+type orphanFileService struct {
+	orphanRepo    port.OrphanFileRepository
+	tenantRepo    port.TenantRepository
+	userRepo      port.UserRepository
+	collectionSvc CollectionService
+	docSvc        DocumentService
+	flags         port.Flags
+	notifier      port.Notifier
+	nudgeAfter    time.Duration
+}
+
+// NewOrphanFileService creates a new OrphanFileService implementation. Nudges
+// count files uploaded more than nudgeAfterDays days ago.
+func NewOrphanFileService(
+	orphanRepo port.OrphanFileRepository,
+	tenantRepo port.TenantRepository,
+	userRepo port.UserRepository,
+	collectionSvc CollectionService,
+	docSvc DocumentService,
+	flags port.Flags,
+	notifier port.Notifier,
+	nudgeAfterDays int,
+) OrphanFileService {
+	return &orphanFileService{
+		orphanRepo:    orphanRepo,
+		tenantRepo:    tenantRepo,
+		userRepo:      userRepo,
+		collectionSvc: collectionSvc,
+		docSvc:        docSvc,
+		flags:         flags,
+		notifier:      notifier,
+		nudgeAfter:    time.Duration(nudgeAfterDays) * 24 * time.Hour,
+	}
+}
+
+func (s *orphanFileService) requirePerm(ctx context.Context, collectionID, userID uuid.UUID, role domain.UserRole, minLevel domain.CollectionPermission) error {
+	eff := s.collectionSvc.EffectivePermission(ctx, collectionID, userID, role)
+	if domain.CollectionPermLevel(eff) < domain.CollectionPermLevel(minLevel) {
+		return domain.ErrCollectionPermDenied
+	}
+	return nil
+}
+
+// orphanCutoff is the upload time orphan files must predate.
+func orphanCutoff(olderThanDays int) time.Time {
+	return time.Now().UTC().AddDate(0, 0, -olderThanDays)
+}
+
+func (s *orphanFileService) Summary(ctx context.Context, tenantID uuid.UUID, olderThanDays int) ([]domain.OrphanFileSummary, error) {
+	return s.orphanRepo.Summarize(ctx, tenantID, orphanCutoff(olderThanDays))
+}
+
+func (s *orphanFileService) List(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, olderThanDays, offset, limit int) ([]domain.FileMeta, int, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, 0, err
+	}
+	return s.orphanRepo.ListByCollection(ctx, tenantID, collectionID, orphanCutoff(olderThanDays), offset, limit)
+}
+
+func (s *orphanFileService) CreateDocuments(ctx context.Context, input *OrphanDocumentsInput) (*domain.OrphanDocumentRun, error) {
+	if err := s.requirePerm(ctx, input.CollectionID, input.UserID, input.Role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+	if _, err := s.collectionSvc.GetByID(ctx, input.TenantID, input.CollectionID, input.UserID, input.Role); err != nil {
+		return nil, err
+	}
+
+	files, total, err := s.orphanRepo.ListByCollection(ctx, input.TenantID, input.CollectionID,
+		orphanCutoff(input.OlderThanDays), 0, maxOrphanDocumentRun)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &domain.OrphanDocumentRun{
+		Matched:   len(files),
+		Remaining: total - len(files),
+		Entries:   make([]domain.ImportEntry, 0, len(files)),
+	}
+	// Once the quota or parse budget runs out every later file would fail the same way
+	var exhausted error
+	for i := range files {
+		file := &files[i]
+		entry := domain.ImportEntry{Name: file.OriginalName, FileID: &file.ID}
+		if exhausted != nil {
+			run.Entries = append(run.Entries, failedEntry(entry, fmt.Sprintf("creating document: %v", exhausted)))
+			run.Failed++
+			continue
+		}
+		doc, err := s.docSvc.CreateAndParse(ctx, &CreateDocumentInput{
+			TenantID:     input.TenantID,
+			CollectionID: input.CollectionID,
+			FileID:       file.ID,
+			DocumentType: input.DocumentType,
+			ParseMode:    input.ParseMode,
+			CreatedBy:    input.UserID,
+			Role:         input.Role,
+		})
+		if err != nil {
+			if errors.Is(err, domain.ErrQuotaExceeded) || errors.Is(err, domain.ErrParseBudgetExhausted) {
+				exhausted = err
+			}
+			run.Entries = append(run.Entries, failedEntry(entry, fmt.Sprintf("creating document: %v", err)))
+			run.Failed++
+			continue
+		}
+		entry.DocumentID = &doc.ID
+		entry.Status = domain.ImportEntryImported
+		run.Entries = append(run.Entries, entry)
+		run.Created++
+	}
+
+	log.Printf("orphanFileService.CreateDocuments: created %d of %d documents in collection %s (tenant %s, by user %s)",
+		run.Created, run.Matched, input.CollectionID, input.TenantID, input.UserID)
+	return run, nil
+}
+
+// enabledTenants returns the active tenants that have orphan file nudges enabled.
+func (s *orphanFileService) enabledTenants(ctx context.Context) ([]domain.Tenant, error) {
+	var enabled []domain.Tenant
+	for offset := 0; ; offset += feedPageSize {
+		tenants, total, err := s.tenantRepo.List(ctx, offset, feedPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("listing tenants: %w", err)
+		}
+		for i := range tenants {
+			if tenants[i].IsActive && s.flags.IsEnabled(ctx, tenants[i].ID, domain.FlagOrphanFileNudges) {
+				enabled = append(enabled, tenants[i])
+			}
+		}
+		if offset+feedPageSize >= total || len(tenants) == 0 {
+			return enabled, nil
+		}
+	}
+}
+
+func (s *orphanFileService) RunNudges(ctx context.Context) error {
+	tenants, err := s.enabledTenants(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for i := range tenants {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.nudgeTenant(ctx, tenants[i].ID, now); err != nil {
+			log.Printf("orphanFileService.RunNudges: tenant %s: %v", tenants[i].ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *orphanFileService) nudgeTenant(ctx context.Context, tenantID uuid.UUID, now time.Time) error {
+	summaries, err := s.orphanRepo.Summarize(ctx, tenantID, now.Add(-s.nudgeAfter))
+	if err != nil {
+		return err
+	}
+	for i := range summaries {
+		sum := &summaries[i]
+		owner, err := s.userRepo.GetByID(ctx, tenantID, sum.CreatedBy)
+		if err != nil || !owner.IsActive {
+			continue
+		}
+		claimed, err := s.orphanRepo.ClaimNudge(ctx, tenantID, sum.CollectionID, sum.FileCount, now.Add(-orphanNudgeEvery))
+		if err != nil {
+			log.Printf("orphanFileService.RunNudges: claiming nudge for collection %s failed: %v", sum.CollectionID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		err = s.notifier.Notify(ctx, &port.Notification{
+			Kind:     domain.NotificationKindOrphanFiles,
+			TenantID: tenantID,
+			Recipients: []port.NotificationRecipient{
+				{UserID: owner.ID, Email: owner.Email, Name: owner.FullName},
+			},
+			Subject: fmt.Sprintf("%d files in %s were never processed", sum.FileCount, sum.CollectionName),
+			Body: fmt.Sprintf("%d files uploaded to the collection %s, the oldest on %s, have no document, so they "+
+				"were never parsed or reviewed. Create their documents from the collection's orphan files, or remove "+
+				"the files you don't need.",
+				sum.FileCount, sum.CollectionName, sum.OldestUploadedAt.Format("2 Jan 2006")),
+		})
+		if err != nil {
+			log.Printf("orphanFileService.RunNudges: notifying owner of collection %s failed: %v", sum.CollectionID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// OrphanFileWorker periodically reminds collection owners of files uploaded to
+// their collections that never became documents.
+type OrphanFileWorker struct {
+	svc      OrphanFileService
+	interval time.Duration
+}
+
+// NewOrphanFileWorker creates a new OrphanFileWorker that checks every interval.
+func NewOrphanFileWorker(svc OrphanFileService, interval time.Duration) *OrphanFileWorker {
+	return &OrphanFileWorker{svc: svc, interval: interval}
+}
+
+// Start runs the nudge loop until ctx is canceled.
+func (w *OrphanFileWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	log.Printf("orphanFileWorker: started (interval=%s)", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("orphanFileWorker: shutdown complete")
+			return
+		case <-ticker.C:
+			if err := w.svc.RunNudges(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("orphanFileWorker: RunNudges error: %v", err)
+			}
+		}
+	}
+}
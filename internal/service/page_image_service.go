@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// minPageImageDPI is the lowest DPI a page image may be requested at; below it
+// text is unreadable.
+const minPageImageDPI = 36
+
+// PageImageInput identifies one page of a file to render.
+type PageImageInput struct {
+	TenantID uuid.UUID
+	UserID   uuid.UUID
+	Role     domain.UserRole
+	FileID   uuid.UUID
+	Page     int // 1-based
+	DPI      int // 0 = the configured default
+}
+
+// PageImageService renders single pages of stored files as PNG images.
+type PageImageService interface {
+	// GetPageImage returns the page as a PNG. PDF pages are rendered at the
+	// requested DPI and cached next to the file in S3; images have a single page,
+	// returned at their own resolution.
+	GetPageImage(ctx context.Context, input *PageImageInput) ([]byte, error)
+}
+
+type pageImageService struct {
+	fileRepo  port.FileMetaRepository
+	storage   port.ObjectStorage
+	renderer  port.PageRenderer
+	residency StorageResidency
+	cfg       config.PageImageConfig
+}
+
+// NewPageImageService creates a new PageImageService.
+func NewPageImageService(
+	fileRepo port.FileMetaRepository,
+	storage port.ObjectStorage,
+	renderer port.PageRenderer,
+	residency StorageResidency,
+	cfg config.PageImageConfig,
+) PageImageService {
+	return &pageImageService{
+		fileRepo:  fileRepo,
+		storage:   storage,
+		renderer:  renderer,
+		residency: residency,
+		cfg:       cfg,
+	}
+}
+
+// pageImageKey is where a rendered page is cached: a pages/ folder beside the file's object.
+func pageImageKey(fileKey string, page, dpi int) string {
+	return fmt.Sprintf("%s/pages/%d@%d.png", path.Dir(fileKey), page, dpi)
+}
+
+func (s *pageImageService) GetPageImage(ctx context.Context, input *PageImageInput) ([]byte, error) {
+	if input.Page < 1 {
+		return nil, domain.ErrPageNotFound
+	}
+	dpi := input.DPI
+	if dpi == 0 {
+		dpi = s.cfg.DefaultDPI
+	}
+	if dpi < minPageImageDPI || dpi > s.cfg.MaxDPI {
+		return nil, domain.ErrInvalidDPI
+	}
+
+	meta, err := s.fileRepo.GetByID(ctx, input.TenantID, input.FileID)
+	if err != nil {
+		return nil, err
+	}
+	// Free users can only see their own files
+	if input.Role == domain.RoleFree && meta.UploadedBy != input.UserID {
+		return nil, domain.ErrNotFound
+	}
+	if err := checkResidency(ctx, s.residency, input.TenantID, meta.S3Bucket); err != nil {
+		return nil, err
+	}
+
+	switch meta.FileType {
+	case domain.FileTypePDF:
+		return s.renderPDFPage(ctx, meta, input.Page, dpi)
+	case domain.FileTypePNG, domain.FileTypeJPG:
+		if input.Page != 1 {
+			return nil, domain.ErrPageNotFound
+		}
+		return s.imageAsPNG(ctx, meta)
+	default:
+		return nil, domain.ErrUnsupportedFileType
+	}
+}
+
+func (s *pageImageService) renderPDFPage(ctx context.Context, meta *domain.FileMeta, page, dpi int) ([]byte, error) {
+	key := pageImageKey(meta.S3Key, page, dpi)
+	// Any download error counts as a cache miss; the page is simply rendered again
+	if cached, err := s.storage.Download(ctx, meta.S3Bucket, key); err == nil && len(cached) > 0 {
+		return cached, nil
+	}
+
+	pdf, err := s.storage.Download(ctx, meta.S3Bucket, meta.S3Key)
+	if err != nil {
+		return nil, fmt.Errorf("downloading file: %w", err)
+	}
+
+	renderCtx, cancel := context.WithTimeout(ctx, time.Duration(s.cfg.TimeoutSecs)*time.Second)
+	defer cancel()
+	img, err := s.renderer.RenderPage(renderCtx, pdf, page, dpi)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.storage.Upload(ctx, port.UploadInput{
+		Bucket:      meta.S3Bucket,
+		Key:         key,
+		Body:        bytes.NewReader(img),
+		ContentType: "image/png",
+		Size:        int64(len(img)),
+	}); err != nil {
+		// The image is still good; the next request renders it again
+		log.Printf("pageImageService.renderPDFPage: caching page %d of file %s: %v", page, meta.ID, err)
+	}
+	return img, nil
+}
+
+// imageAsPNG returns an image file as PNG, converting JPEGs.
+func (s *pageImageService) imageAsPNG(ctx context.Context, meta *domain.FileMeta) ([]byte, error) {
+	data, err := s.storage.Download(ctx, meta.S3Bucket, meta.S3Key)
+	if err != nil {
+		return nil, fmt.Errorf("downloading file: %w", err)
+	}
+	if meta.FileType == domain.FileTypePNG {
+		return data, nil
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding jpeg: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encoding png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
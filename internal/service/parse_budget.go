@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// ParseBudget enforces the daily parse budget of a tenant's tier when parses are
+// scheduled, and picks the parser a tier may use.
+type ParseBudget interface {
+	// Tier returns the tenant's parse tier.
+	Tier(ctx context.Context, tenantID uuid.UUID) (domain.ParseTier, error)
+	// Reserve counts one parse against today's budget, or returns
+	// ErrParseBudgetExhausted when it is used up.
+	Reserve(ctx context.Context, tenantID, userID uuid.UUID) error
+	// Parser returns the parser for the tenant's tier, or def when the tier is
+	// not restricted.
+	Parser(ctx context.Context, tenantID uuid.UUID, def port.DocumentParser) port.DocumentParser
+}
+
+type parseBudget struct {
+	tenantRepo port.TenantRepository
+	repo       port.ParseBudgetRepository
+	freeSlug   string
+	cfg        config.ParseBudgetConfig
+	freeParser port.DocumentParser
+	now        func() time.Time
+}
+
+// NewParseBudget creates a ParseBudget. The free tier is the tenant with slug
+// freeSlug; freeParser, when non-nil, is the only parser it may use.
+func NewParseBudget(
+	tenantRepo port.TenantRepository,
+	repo port.ParseBudgetRepository,
+	freeSlug string,
+	cfg config.ParseBudgetConfig,
+	freeParser port.DocumentParser,
+) ParseBudget {
+	return &parseBudget{
+		tenantRepo: tenantRepo,
+		repo:       repo,
+		freeSlug:   freeSlug,
+		cfg:        cfg,
+		freeParser: freeParser,
+		now:        time.Now,
+	}
+}
+
+func (b *parseBudget) Tier(ctx context.Context, tenantID uuid.UUID) (domain.ParseTier, error) {
+	if b.freeSlug == "" {
+		return domain.ParseTierStandard, nil
+	}
+	tenant, err := b.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return "", fmt.Errorf("loading tenant: %w", err)
+	}
+	if tenant.Slug == b.freeSlug {
+		return domain.ParseTierFree, nil
+	}
+	return domain.ParseTierStandard, nil
+}
+
+func (b *parseBudget) Reserve(ctx context.Context, tenantID, userID uuid.UUID) error {
+	tier, err := b.Tier(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	// Free tier users share one tenant, so they are counted one by one
+	limit, counted := b.cfg.DailyCalls, uuid.Nil
+	if tier == domain.ParseTierFree {
+		limit, counted = b.cfg.FreeDailyCalls, userID
+	}
+	if limit <= 0 {
+		return nil
+	}
+	day := b.now().UTC().Truncate(24 * time.Hour)
+	return b.repo.Reserve(ctx, tenantID, counted, day, limit)
+}
+
+func (b *parseBudget) Parser(ctx context.Context, tenantID uuid.UUID, def port.DocumentParser) port.DocumentParser {
+	if b.freeParser == nil {
+		return def
+	}
+	if tier, err := b.Tier(ctx, tenantID); err == nil && tier == domain.ParseTierFree {
+		return b.freeParser
+	}
+	return def
+}
+
+// reserveParse counts a parse against the tenant's budget; a nil budget is unlimited.
+func reserveParse(ctx context.Context, budget ParseBudget, tenantID, userID uuid.UUID) error {
+	if budget == nil {
+		return nil
+	}
+	return budget.Reserve(ctx, tenantID, userID)
+}
+
+// tierParser returns the parser for the tenant's tier, or def when budget isn't wired.
+func tierParser(ctx context.Context, budget ParseBudget, tenantID uuid.UUID, def port.DocumentParser) port.DocumentParser {
+	if budget == nil {
+		return def
+	}
+	return budget.Parser(ctx, tenantID, def)
+}
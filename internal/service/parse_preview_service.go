@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/parser"
+	"satvos/internal/port"
+	"satvos/internal/validator"
+)
+
+// parsePreviewTimeout bounds a preview parse, which runs inside the HTTP request.
+const parsePreviewTimeout = 2 * time.Minute
+
+// ParsePreviewInput is the DTO for a dry-run parse.
+type ParsePreviewInput struct {
+	TenantID     uuid.UUID
+	UserID       uuid.UUID
+	FileName     string
+	Content      []byte
+	DocumentType string
+}
+
+// ParsePreviewResult is what a dry-run parse returns in place of a document.
+type ParsePreviewResult struct {
+	DocumentType     string                        `json:"document_type"`
+	ParserModel      string                        `json:"parser_model"`
+	StructuredData   json.RawMessage               `json:"structured_data" swaggertype:"object"`
+	ConfidenceScores json.RawMessage               `json:"confidence_scores" swaggertype:"object"`
+	Validation       *validator.ValidationResponse `json:"validation"`
+}
+
+// ParsePreviewService parses and validates a file synchronously without storing
+// the file or creating a document.
+type ParsePreviewService interface {
+	Preview(ctx context.Context, input *ParsePreviewInput) (*ParsePreviewResult, error)
+}
+
+type parsePreviewService struct {
+	parser    port.DocumentParser
+	validator *validator.Engine
+	userRepo  port.UserRepository
+	budget    ParseBudget
+	maxBytes  int64
+}
+
+// NewParsePreviewService creates a new ParsePreviewService. validationEngine may be
+// nil, in which case previews carry no validation results.
+func NewParsePreviewService(
+	docParser port.DocumentParser,
+	validationEngine *validator.Engine,
+	userRepo port.UserRepository,
+	budget ParseBudget,
+	cfg config.ParsePreviewConfig,
+) ParsePreviewService {
+	return &parsePreviewService{
+		parser:    docParser,
+		validator: validationEngine,
+		userRepo:  userRepo,
+		budget:    budget,
+		maxBytes:  cfg.MaxSizeMB * 1024 * 1024,
+	}
+}
+
+func (s *parsePreviewService) Preview(ctx context.Context, input *ParsePreviewInput) (*ParsePreviewResult, error) {
+	// Same checks as an upload, so a preview never spends quota on a file the
+	// parser would reject
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(input.FileName), "."))
+	fileType, ok := domain.AllowedExtensions[ext]
+	if !ok {
+		return nil, domain.ErrUnsupportedFileType
+	}
+	if int64(len(input.Content)) > s.maxBytes {
+		return nil, domain.ErrFileTooLarge
+	}
+	detectedType, ok := sniffFileType(input.Content)
+	if !ok {
+		return nil, domain.ErrUnsupportedFileType
+	}
+	if detectedType != fileType {
+		return nil, domain.ErrFileContentMismatch
+	}
+	if fileType == domain.FileTypePDF {
+		if err := inspectPDF(input.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	// A preview costs an LLM call, so it counts against the monthly quota like a document
+	if err := s.userRepo.CheckAndIncrementQuota(ctx, input.TenantID, input.UserID); err != nil {
+		return nil, err
+	}
+	if err := reserveParse(ctx, s.budget, input.TenantID, input.UserID); err != nil {
+		return nil, err
+	}
+
+	contentType := domain.AllowedFileTypes[fileType]
+	parseCtx, cancel := context.WithTimeout(ctx, parsePreviewTimeout)
+	defer cancel()
+	output, err := tierParser(ctx, s.budget, input.TenantID, s.parser).Parse(parseCtx, port.ParseInput{
+		FileBytes:    input.Content,
+		ContentType:  contentType,
+		DocumentType: input.DocumentType,
+		PageCount:    countPages(contentType, input.Content),
+		Language:     detectDocumentLanguage(contentType, input.Content),
+	})
+	if err != nil {
+		log.Printf("parsePreviewService.Preview: parse failed for tenant %s: %v", input.TenantID, err)
+		return nil, previewParseError(err)
+	}
+
+	result := &ParsePreviewResult{
+		DocumentType:     input.DocumentType,
+		ParserModel:      output.ModelUsed,
+		StructuredData:   output.StructuredData,
+		ConfidenceScores: output.ConfidenceScores,
+	}
+	if s.validator != nil {
+		validation, err := s.validator.Preview(ctx, input.TenantID, input.UserID, input.DocumentType,
+			output.StructuredData, output.ConfidenceScores)
+		if err != nil {
+			// The extraction is still useful without validation results
+			log.Printf("parsePreviewService.Preview: validation failed for tenant %s: %v", input.TenantID, err)
+		} else {
+			result.Validation = validation
+		}
+	}
+	return result, nil
+}
+
+// previewParseError maps parser failures to errors a caller can act on: retry
+// later for provider throttling and timeouts, give up otherwise.
+func previewParseError(err error) error {
+	var rlErr *parser.RateLimitError
+	var trErr *parser.TransientError
+	var toErr *parser.TimeoutError
+	if errors.As(err, &rlErr) || errors.As(err, &trErr) || errors.As(err, &toErr) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %v", domain.ErrParserUnavailable, err)
+	}
+	return fmt.Errorf("%w: %v", domain.ErrParseFailed, err)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"satvos/internal/parser"
 	"satvos/internal/port"
 )
 
@@ -14,6 +16,29 @@ type ParseQueueConfig struct {
 	PollInterval time.Duration
 	MaxRetries   int
 	Concurrency  int
+	JobTimeout   time.Duration // bounds one document's parse; defaults to 30m
+	// RateLimits paces dispatch on Provider's rate limit headers, keeping
+	// RateLimitReservePercent of its limits spare until they reset. Nil or a
+	// reserve of 0 dispatches up to Concurrency regardless.
+	RateLimits              *parser.RateLimitTracker
+	Provider                string
+	RateLimitReservePercent int
+}
+
+// ParseQueueStatus is what the admin API reports about this instance's parse
+// queue worker.
+type ParseQueueStatus struct {
+	Concurrency             int                        `json:"concurrency"`
+	InFlight                int                        `json:"in_flight"`
+	Provider                string                     `json:"provider"`
+	RateLimitReservePercent int                        `json:"rate_limit_reserve_percent"`
+	Throttled               bool                       `json:"throttled"`
+	Headroom                []parser.RateLimitHeadroom `json:"headroom"`
+}
+
+// ParseQueueMonitor reports the parse queue worker's state.
+type ParseQueueMonitor interface {
+	Status() ParseQueueStatus
 }
 
 // ParseQueueWorker polls for queued documents and dispatches them for parsing.
@@ -22,10 +47,13 @@ type ParseQueueWorker struct {
 	docService DocumentService
 	cfg        ParseQueueConfig
 	wg         sync.WaitGroup
+	inFlight   atomic.Int32
+	throttled  atomic.Bool
 }
 
 // NewParseQueueWorker creates a new ParseQueueWorker.
 func NewParseQueueWorker(docRepo port.DocumentRepository, docService DocumentService, cfg ParseQueueConfig) *ParseQueueWorker {
+	cfg.JobTimeout = parseJobTimeout(cfg.JobTimeout)
 	return &ParseQueueWorker{
 		docRepo:    docRepo,
 		docService: docService,
@@ -52,7 +80,7 @@ func (w *ParseQueueWorker) Start(ctx context.Context) {
 			log.Printf("parseQueueWorker: shutdown complete")
 			return
 		case <-ticker.C:
-			available := w.cfg.Concurrency - len(sem)
+			available := w.pace(w.cfg.Concurrency - len(sem))
 			if available <= 0 {
 				continue
 			}
@@ -72,14 +100,15 @@ func (w *ParseQueueWorker) Start(ctx context.Context) {
 				doc.ParseAttempts++
 
 				sem <- struct{}{} // acquire
+				w.inFlight.Add(1)
 				w.wg.Add(1)
 				go func() {
 					defer w.wg.Done()
-					defer func() { <-sem }() // release
+					defer func() { <-sem; w.inFlight.Add(-1) }() // release
 
 					// Use a fresh context independent of the poll context
 					// so in-flight parses complete even during shutdown.
-					parseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+					parseCtx, cancel := context.WithTimeout(context.Background(), w.cfg.JobTimeout)
 					defer cancel()
 
 					log.Printf("parseQueueWorker: dispatching document %s (attempt %d)", doc.ID, doc.ParseAttempts)
@@ -89,3 +118,33 @@ func (w *ParseQueueWorker) Start(ctx context.Context) {
 		}
 	}
 }
+
+// pace caps free dispatch slots by the provider's rate limit headroom, so the
+// queue slows down before the provider starts returning 429s.
+func (w *ParseQueueWorker) pace(available int) int {
+	if available <= 0 {
+		return available
+	}
+	allowed := w.cfg.RateLimits.Allow(w.cfg.Provider, available, w.cfg.RateLimitReservePercent)
+	throttled := allowed < available
+	if throttled != w.throttled.Swap(throttled) {
+		if throttled {
+			log.Printf("parseQueueWorker: %s is near its rate limit, dispatching %d of %d", w.cfg.Provider, allowed, available)
+		} else {
+			log.Printf("parseQueueWorker: %s rate limit headroom recovered", w.cfg.Provider)
+		}
+	}
+	return allowed
+}
+
+// Status reports this worker's concurrency, in-flight parses and provider headroom.
+func (w *ParseQueueWorker) Status() ParseQueueStatus {
+	return ParseQueueStatus{
+		Concurrency:             w.cfg.Concurrency,
+		InFlight:                int(w.inFlight.Load()),
+		Provider:                w.cfg.Provider,
+		RateLimitReservePercent: w.cfg.RateLimitReservePercent,
+		Throttled:               w.throttled.Load(),
+		Headroom:                w.cfg.RateLimits.Headroom(),
+	}
+}
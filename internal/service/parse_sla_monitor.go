@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"satvos/internal/port"
+)
+
+// ParseSLAConfig holds the thresholds checked by ParseSLAMonitor.
+type ParseSLAConfig struct {
+	CheckInterval time.Duration
+	Window        time.Duration // lookback for p95 parse latency
+	MinSamples    int           // completed attempts a model needs before its p95 is judged
+	MaxP95Parse   time.Duration
+	MaxQueueAge   time.Duration
+	Cooldown      time.Duration // minimum gap between alerts for the same ongoing breach
+}
+
+// ParseSLAMonitor periodically checks parse latency across all tenants and alerts
+// when a parser model's p95 parse time or the oldest waiting document's queue age
+// exceeds its threshold.
+type ParseSLAMonitor struct {
+	timingRepo port.ParseTimingRepository
+	alerts     port.AlertSender
+	cfg        ParseSLAConfig
+	lastSent   map[string]time.Time
+}
+
+// NewParseSLAMonitor creates a new ParseSLAMonitor.
+func NewParseSLAMonitor(timingRepo port.ParseTimingRepository, alerts port.AlertSender, cfg ParseSLAConfig) *ParseSLAMonitor {
+	return &ParseSLAMonitor{
+		timingRepo: timingRepo,
+		alerts:     alerts,
+		cfg:        cfg,
+		lastSent:   make(map[string]time.Time),
+	}
+}
+
+// Start runs the check loop until ctx is canceled.
+func (m *ParseSLAMonitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	log.Printf("parseSLAMonitor: started (interval=%s, p95<=%s, queue age<=%s)",
+		m.cfg.CheckInterval, m.cfg.MaxP95Parse, m.cfg.MaxQueueAge)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("parseSLAMonitor: shutdown complete")
+			return
+		case <-ticker.C:
+			if err := m.Check(ctx, time.Now().UTC()); err != nil && ctx.Err() == nil {
+				log.Printf("parseSLAMonitor: Check error: %v", err)
+			}
+		}
+	}
+}
+
+// Check evaluates the thresholds once at now and sends an alert for each breach
+// that has not been alerted within the cooldown. A breach that clears is forgotten,
+// so its next occurrence alerts immediately.
+func (m *ParseSLAMonitor) Check(ctx context.Context, now time.Time) error {
+	var breaches []*port.Alert
+
+	stats, err := m.timingRepo.LatencyByModel(ctx, nil, now.Add(-m.cfg.Window))
+	if err != nil {
+		return fmt.Errorf("loading parse latency: %w", err)
+	}
+	for i := range stats {
+		st := &stats[i]
+		p95 := time.Duration(st.ParseP95Ms) * time.Millisecond
+		if st.Attempts-st.Failed < m.cfg.MinSamples || p95 <= m.cfg.MaxP95Parse {
+			continue
+		}
+		breaches = append(breaches, &port.Alert{
+			Key:     "parse_p95:" + st.ParserModel,
+			Subject: fmt.Sprintf("SATVOS parse latency: %s p95 is %s", st.ParserModel, p95.Round(time.Second)),
+			Message: fmt.Sprintf("p95 parse time for %s over the last %s is %s (threshold %s) across %d attempts, %d failed.",
+				st.ParserModel, m.cfg.Window, p95.Round(time.Second), m.cfg.MaxP95Parse, st.Attempts, st.Failed),
+		})
+	}
+
+	age, err := m.timingRepo.OldestWaiting(ctx)
+	if err != nil {
+		return fmt.Errorf("loading queue age: %w", err)
+	}
+	if age > m.cfg.MaxQueueAge {
+		breaches = append(breaches, &port.Alert{
+			Key:     "queue_age",
+			Subject: fmt.Sprintf("SATVOS parse queue: oldest document waiting %s", age.Round(time.Second)),
+			Message: fmt.Sprintf("The oldest pending or queued document has waited %s to be parsed (threshold %s).",
+				age.Round(time.Second), m.cfg.MaxQueueAge),
+		})
+	}
+
+	active := make(map[string]bool, len(breaches))
+	for _, a := range breaches {
+		active[a.Key] = true
+		if last, ok := m.lastSent[a.Key]; ok && now.Sub(last) < m.cfg.Cooldown {
+			continue
+		}
+		a.RaisedAt = now
+		log.Printf("parseSLAMonitor: %s", a.Subject)
+		if err := m.alerts.SendAlert(ctx, a); err != nil {
+			log.Printf("parseSLAMonitor: sending alert %s failed: %v", a.Key, err)
+			continue
+		}
+		m.lastSent[a.Key] = now
+	}
+	for key := range m.lastSent {
+		if !active[key] {
+			delete(m.lastSent, key)
+		}
+	}
+	return nil
+}
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -16,43 +20,121 @@ import (
 	"satvos/internal/port"
 )
 
+// RequestMeta identifies the client a request came from. Password reset links
+// are bound to it and the account security audit trail records it.
+type RequestMeta struct {
+	ClientIP  string
+	UserAgent string
+}
+
 // ForgotPasswordInput is the DTO for forgot-password requests.
 type ForgotPasswordInput struct {
-	TenantSlug string `json:"tenant_slug" binding:"required"`
-	Email      string `json:"email" binding:"required,email"`
+	TenantSlug string      `json:"tenant_slug" binding:"required"`
+	Email      string      `json:"email" binding:"required,email"`
+	Meta       RequestMeta `json:"-"`
 }
 
 // ResetPasswordInput is the DTO for reset-password requests.
 type ResetPasswordInput struct {
-	Token       string `json:"token" binding:"required"`
-	NewPassword string `json:"new_password" binding:"required,min=8"`
+	Token       string      `json:"token" binding:"required"`
+	NewPassword string      `json:"new_password" binding:"required,min=8"`
+	Meta        RequestMeta `json:"-"`
 }
 
-// PasswordResetService defines the password reset contract.
+// EmailChangeInput describes a change of a user's email address. User is the
+// user after the change.
+type EmailChangeInput struct {
+	User     *domain.User
+	OldEmail string
+	ActorID  uuid.UUID
+	Meta     RequestMeta
+}
+
+// UndoEmailChangeInput is the DTO for undo-email-change requests.
+type UndoEmailChangeInput struct {
+	Token string      `json:"token" binding:"required"`
+	Meta  RequestMeta `json:"-"`
+}
+
+// InviteInput describes a user an admin created, to be invited by email to
+// set a password.
+type InviteInput struct {
+	User       *domain.User
+	TenantName string
+	ActorID    uuid.UUID
+	Meta       RequestMeta
+}
+
+// PasswordResetService defines the password reset and account security contract.
 type PasswordResetService interface {
 	ForgotPassword(ctx context.Context, input ForgotPasswordInput) error
 	ResetPassword(ctx context.Context, input ResetPasswordInput) error
+	// RecordEmailChange audits a change of address and sends the old address a
+	// link to undo it.
+	RecordEmailChange(ctx context.Context, input EmailChangeInput) error
+	UndoEmailChange(ctx context.Context, input UndoEmailChangeInput) error
+	// Invite emails a new user a link to set their password, redeemed through
+	// ResetPassword. It returns an error if the email could not be sent.
+	Invite(ctx context.Context, input InviteInput) error
+	ListSecurityEvents(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.SecurityEvent, int, error)
+}
+
+const (
+	passwordResetAudience   = "password-reset"
+	emailChangeUndoAudience = "email-change-undo"
+	invitationAudience      = "invitation"
+)
+
+// accountTokenClaims are the claims of password reset and email change undo
+// tokens. Fingerprint binds a reset token to the client that asked for it.
+type accountTokenClaims struct {
+	Claims
+	Fingerprint string `json:"fpr,omitempty"`
 }
 
 type passwordResetService struct {
-	tenantRepo  port.TenantRepository
-	userRepo    port.UserRepository
-	emailSender port.EmailSender
-	jwtCfg      config.JWTConfig
+	tenantRepo   port.TenantRepository
+	userRepo     port.UserRepository
+	securityRepo port.AccountSecurityRepository
+	notifier     port.Notifier
+	jwtCfg       config.JWTConfig
+	resetTTL     time.Duration
+	bindTokens   bool
+	undoWindow   time.Duration
+	inviteTTL    time.Duration
 }
 
 // NewPasswordResetService creates a new PasswordResetService.
 func NewPasswordResetService(
 	tenantRepo port.TenantRepository,
 	userRepo port.UserRepository,
-	emailSender port.EmailSender,
+	securityRepo port.AccountSecurityRepository,
+	notifier port.Notifier,
 	jwtCfg config.JWTConfig,
+	securityCfg config.AccountSecurityConfig,
 ) PasswordResetService {
+	resetTTL := time.Duration(securityCfg.ResetTokenTTLMins) * time.Minute
+	if resetTTL <= 0 {
+		resetTTL = 15 * time.Minute
+	}
+	undoWindow := time.Duration(securityCfg.EmailChangeUndoHours) * time.Hour
+	if undoWindow <= 0 {
+		undoWindow = 72 * time.Hour
+	}
+	inviteTTL := time.Duration(securityCfg.InvitationTTLHours) * time.Hour
+	if inviteTTL <= 0 {
+		inviteTTL = 168 * time.Hour
+	}
 	return &passwordResetService{
-		tenantRepo:  tenantRepo,
-		userRepo:    userRepo,
-		emailSender: emailSender,
-		jwtCfg:      jwtCfg,
+		tenantRepo:   tenantRepo,
+		userRepo:     userRepo,
+		securityRepo: securityRepo,
+		notifier:     notifier,
+		jwtCfg:       jwtCfg,
+		resetTTL:     resetTTL,
+		bindTokens:   securityCfg.BindResetTokens,
+		undoWindow:   undoWindow,
+		inviteTTL:    inviteTTL,
 	}
 }
 
@@ -81,7 +163,8 @@ func (s *passwordResetService) ForgotPassword(ctx context.Context, input ForgotP
 		return nil
 	}
 
-	tokenString, jti, err := s.generateResetToken(user)
+	jti := uuid.New().String()
+	tokenString, err := s.signToken(user, passwordResetAudience, jti, time.Now().Add(s.resetTTL), s.fingerprint(input.Meta))
 	if err != nil {
 		log.Printf("WARNING: failed to generate password reset token for %s: %v", user.Email, err)
 		return nil
@@ -91,17 +174,40 @@ func (s *passwordResetService) ForgotPassword(ctx context.Context, input ForgotP
 		log.Printf("WARNING: failed to store password reset token for %s: %v", user.Email, err)
 		return nil
 	}
+	s.audit(ctx, user, domain.SecurityEventPasswordResetRequested, nil, input.Meta, "")
 
-	if err := s.emailSender.SendPasswordResetEmail(ctx, user.Email, user.FullName, tokenString); err != nil {
-		log.Printf("WARNING: failed to send password reset email to %s: %v", user.Email, err)
+	err = s.notifier.Notify(ctx, &port.Notification{
+		Kind:       domain.NotificationKindPasswordReset,
+		TenantID:   tenant.ID,
+		Recipients: []port.NotificationRecipient{{UserID: user.ID, Email: user.Email, Name: user.FullName}},
+		Subject:    "Password reset requested",
+		Body:       "A password reset link was emailed to " + user.Email + ".",
+		Token:      tokenString,
+	})
+	if err != nil {
+		log.Printf("WARNING: failed to send password reset to %s: %v", user.Email, err)
 	}
 
 	return nil
 }
 
 func (s *passwordResetService) ResetPassword(ctx context.Context, input ResetPasswordInput) error {
-	claims, err := s.parseResetToken(input.Token)
+	// An invitation link sets the first password the same way; it isn't bound
+	// to a client, since the admin's request is not the invitee's
+	invitation := false
+	claims, err := s.parseToken(input.Token, passwordResetAudience)
 	if err != nil {
+		if claims, err = s.parseToken(input.Token, invitationAudience); err != nil {
+			return domain.ErrPasswordResetTokenInvalid
+		}
+		invitation = true
+	}
+	user := &domain.User{ID: claims.UserID, TenantID: claims.TenantID}
+
+	if s.bindTokens && !invitation && !hmac.Equal([]byte(claims.Fingerprint), []byte(s.fingerprint(input.Meta))) {
+		// The link stays usable from the client that asked for it
+		s.audit(ctx, user, domain.SecurityEventPasswordResetRejected, nil, input.Meta,
+			"reset link used from a different IP address or browser")
 		return domain.ErrPasswordResetTokenInvalid
 	}
 
@@ -110,37 +216,167 @@ func (s *passwordResetService) ResetPassword(ctx context.Context, input ResetPas
 		return fmt.Errorf("hashing password: %w", err)
 	}
 
-	return s.userRepo.ResetPassword(ctx, claims.TenantID, claims.UserID, string(hash), claims.ID)
+	if err := s.userRepo.ResetPassword(ctx, claims.TenantID, claims.UserID, string(hash), claims.ID); err != nil {
+		return err
+	}
+	event := domain.SecurityEventPasswordResetCompleted
+	if invitation {
+		event = domain.SecurityEventInvitationAccepted
+	}
+	s.audit(ctx, user, event, nil, input.Meta, "")
+	return nil
+}
+
+func (s *passwordResetService) Invite(ctx context.Context, input InviteInput) error {
+	user := input.User
+	jti := uuid.New().String()
+	tokenString, err := s.signToken(user, invitationAudience, jti, time.Now().Add(s.inviteTTL), "")
+	if err != nil {
+		return fmt.Errorf("signing invitation token: %w", err)
+	}
+	if err := s.userRepo.SetPasswordResetToken(ctx, user.TenantID, user.ID, jti); err != nil {
+		return err
+	}
+	actorID := input.ActorID
+	s.audit(ctx, user, domain.SecurityEventInvited, &actorID, input.Meta, "")
+
+	return s.notifier.Notify(ctx, &port.Notification{
+		Kind:       domain.NotificationKindInvitation,
+		TenantID:   user.TenantID,
+		Recipients: []port.NotificationRecipient{{UserID: user.ID, Email: user.Email, Name: user.FullName}},
+		Subject:    "Invitation to " + input.TenantName,
+		Body:       "An invitation to set a password was emailed to " + user.Email + ".",
+		Token:      tokenString,
+		TenantName: input.TenantName,
+	})
 }
 
-func (s *passwordResetService) generateResetToken(user *domain.User) (tokenString, jti string, err error) {
+func (s *passwordResetService) RecordEmailChange(ctx context.Context, input EmailChangeInput) error {
+	user := input.User
+	if strings.EqualFold(user.Email, input.OldEmail) {
+		return nil
+	}
+
+	change := &domain.EmailChange{
+		TenantID:      user.TenantID,
+		UserID:        user.ID,
+		OldEmail:      input.OldEmail,
+		NewEmail:      user.Email,
+		ChangedBy:     input.ActorID,
+		UndoExpiresAt: time.Now().Add(s.undoWindow).UTC(),
+	}
+	if err := s.securityRepo.CreateEmailChange(ctx, change); err != nil {
+		return err
+	}
+	actorID := input.ActorID
+	s.audit(ctx, user, domain.SecurityEventEmailChanged, &actorID, input.Meta,
+		fmt.Sprintf("changed from %s to %s", change.OldEmail, change.NewEmail))
+
+	tokenString, err := s.signToken(user, emailChangeUndoAudience, change.ID.String(), change.UndoExpiresAt, "")
+	if err != nil {
+		return fmt.Errorf("signing undo token: %w", err)
+	}
+	err = s.notifier.Notify(ctx, &port.Notification{
+		Kind:       domain.NotificationKindEmailChanged,
+		TenantID:   user.TenantID,
+		Recipients: []port.NotificationRecipient{{UserID: user.ID, Email: change.OldEmail, Name: user.FullName}},
+		Subject:    "Email address changed",
+		Body:       fmt.Sprintf("The account's email address was changed from %s to %s.", change.OldEmail, change.NewEmail),
+		Token:      tokenString,
+		NewEmail:   change.NewEmail,
+	})
+	if err != nil {
+		log.Printf("WARNING: failed to send email change notice to %s: %v", change.OldEmail, err)
+	}
+	return nil
+}
+
+func (s *passwordResetService) UndoEmailChange(ctx context.Context, input UndoEmailChangeInput) error {
+	claims, err := s.parseToken(input.Token, emailChangeUndoAudience)
+	if err != nil {
+		return domain.ErrEmailChangeUndoInvalid
+	}
+	changeID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return domain.ErrEmailChangeUndoInvalid
+	}
+
+	change, err := s.securityRepo.GetEmailChange(ctx, claims.TenantID, changeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrEmailChangeUndoInvalid
+		}
+		return err
+	}
+	if change.UserID != claims.UserID || change.UndoneAt != nil || time.Now().After(change.UndoExpiresAt) {
+		return domain.ErrEmailChangeUndoInvalid
+	}
+
+	if err := s.securityRepo.UndoEmailChange(ctx, change); err != nil {
+		return err
+	}
+	s.audit(ctx, &domain.User{ID: change.UserID, TenantID: change.TenantID}, domain.SecurityEventEmailChangeUndone, nil, input.Meta,
+		fmt.Sprintf("restored %s, replacing %s", change.OldEmail, change.NewEmail))
+
+	log.Printf("passwordResetService.UndoEmailChange: user %s restored to %s", change.UserID, change.OldEmail)
+	return nil
+}
+
+func (s *passwordResetService) ListSecurityEvents(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.SecurityEvent, int, error) {
+	return s.securityRepo.ListEvents(ctx, tenantID, userID, offset, limit)
+}
+
+// audit records a security event. A failure is logged and never fails the
+// request it describes.
+func (s *passwordResetService) audit(ctx context.Context, user *domain.User, event domain.SecurityEventType, actorID *uuid.UUID, meta RequestMeta, detail string) {
+	err := s.securityRepo.RecordEvent(ctx, &domain.SecurityEvent{
+		TenantID:  user.TenantID,
+		UserID:    user.ID,
+		Event:     event,
+		ActorID:   actorID,
+		IPAddress: meta.ClientIP,
+		UserAgent: meta.UserAgent,
+		Detail:    detail,
+	})
+	if err != nil {
+		log.Printf("WARNING: failed to record %s for user %s: %v", event, user.ID, err)
+	}
+}
+
+// fingerprint is an HMAC of the client's IP address and user agent, so the
+// token doesn't reveal them.
+func (s *passwordResetService) fingerprint(meta RequestMeta) string {
+	mac := hmac.New(sha256.New, []byte(s.jwtCfg.Secret))
+	mac.Write([]byte(meta.ClientIP + "\n" + meta.UserAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *passwordResetService) signToken(user *domain.User, audience, jti string, expiresAt time.Time, fingerprint string) (string, error) {
 	now := time.Now()
-	jti = uuid.New().String()
-	claims := &Claims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   user.ID.String(),
-			Issuer:    s.jwtCfg.Issuer,
-			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(1 * time.Hour)),
-			ID:        jti,
-			Audience:  jwt.ClaimStrings{"password-reset"},
+	claims := &accountTokenClaims{
+		Claims: Claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   user.ID.String(),
+				Issuer:    s.jwtCfg.Issuer,
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+				ID:        jti,
+				Audience:  jwt.ClaimStrings{audience},
+			},
+			TenantID: user.TenantID,
+			UserID:   user.ID,
+			Email:    user.Email,
+			Role:     user.Role,
 		},
-		TenantID: user.TenantID,
-		UserID:   user.ID,
-		Email:    user.Email,
-		Role:     user.Role,
+		Fingerprint: fingerprint,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err = token.SignedString([]byte(s.jwtCfg.Secret))
-	if err != nil {
-		return "", "", err
-	}
-	return tokenString, jti, nil
+	return token.SignedString([]byte(s.jwtCfg.Secret))
 }
 
-func (s *passwordResetService) parseResetToken(tokenString string) (*Claims, error) {
-	claims := &Claims{}
+func (s *passwordResetService) parseToken(tokenString, audience string) (*accountTokenClaims, error) {
+	claims := &accountTokenClaims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -148,7 +384,7 @@ func (s *passwordResetService) parseResetToken(tokenString string) (*Claims, err
 		return []byte(s.jwtCfg.Secret), nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("parsing reset token: %w", err)
+		return nil, fmt.Errorf("parsing %s token: %w", audience, err)
 	}
 	if !token.Valid {
 		return nil, domain.ErrPasswordResetTokenInvalid
@@ -157,7 +393,7 @@ func (s *passwordResetService) parseResetToken(tokenString string) (*Claims, err
 	aud, _ := claims.GetAudience()
 	found := false
 	for _, a := range aud {
-		if a == "password-reset" {
+		if a == audience {
 			found = true
 			break
 		}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	maxQADisputedFields = 50
+	maxQANotesLength    = 2000
+)
+
+// SubmitQAVerdictInput is the DTO for a second reviewer's verdict on a QA sample.
+type SubmitQAVerdictInput struct {
+	TenantID uuid.UUID
+	ReviewID uuid.UUID
+	UserID   uuid.UUID
+	Role     domain.UserRole
+	// Decision is what the second reviewer would have decided: approved or rejected.
+	Decision domain.ReviewStatus
+	// DisputedFields are the structured-data field paths they would have changed.
+	DisputedFields []string
+	Notes          string
+}
+
+// QAReviewService runs second-opinion QA on sampled approvals: the blind queue,
+// verdicts, and the per-reviewer quality scores they add up to.
+type QAReviewService interface {
+	// ListQueue returns pending samples the caller may give a second opinion on:
+	// in any collection for managers and admins, in owned collections for others.
+	ListQueue(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.QASample, int, error)
+	// SubmitVerdict completes a sample. The second reviewer needs editor
+	// permission on the collection and must not have approved (or made) the
+	// original approval.
+	SubmitVerdict(ctx context.Context, input *SubmitQAVerdictInput) (*domain.QAReview, error)
+	// Scores reports each reviewer's QA record over the last window.
+	Scores(ctx context.Context, tenantID uuid.UUID, window time.Duration) ([]domain.QAReviewerScore, error)
+}
+
+type qaReviewService struct {
+	repo          port.QAReviewRepository
+	collectionSvc CollectionService
+	userRepo      port.UserRepository
+	auditRepo     port.DocumentAuditRepository
+}
+
+// NewQAReviewService creates a new QAReviewService.
+func NewQAReviewService(
+	repo port.QAReviewRepository,
+	collectionSvc CollectionService,
+	userRepo port.UserRepository,
+	auditRepo port.DocumentAuditRepository,
+) QAReviewService {
+	return &qaReviewService{
+		repo:          repo,
+		collectionSvc: collectionSvc,
+		userRepo:      userRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+func (s *qaReviewService) ListQueue(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.QASample, int, error) {
+	ownedOnly := role != domain.RoleAdmin && role != domain.RoleManager
+	return s.repo.ListPending(ctx, tenantID, userID, ownedOnly, offset, limit)
+}
+
+func (s *qaReviewService) SubmitVerdict(ctx context.Context, input *SubmitQAVerdictInput) (*domain.QAReview, error) {
+	disputed, err := normalizeDisputedFields(input.Decision, input.DisputedFields, input.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.repo.GetByID(ctx, input.TenantID, input.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status == domain.QAReviewStatusCompleted {
+		return nil, domain.ErrQAReviewCompleted
+	}
+	if review.ReviewerID == input.UserID || (review.MakerID != nil && *review.MakerID == input.UserID) {
+		return nil, domain.ErrQAOwnReview
+	}
+	perm := s.collectionSvc.EffectivePermission(ctx, review.CollectionID, input.UserID, input.Role)
+	if domain.CollectionPermLevel(perm) < domain.CollectionPermLevel(domain.CollectionPermEditor) {
+		return nil, domain.ErrCollectionPermDenied
+	}
+
+	raw, err := json.Marshal(disputed)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling disputed fields: %w", err)
+	}
+	decision := input.Decision
+	disagreed := decision == domain.ReviewStatusRejected || len(disputed) > 0
+	review.SecondReviewerID = &input.UserID
+	review.Decision = &decision
+	review.DisputedFields = raw
+	review.Disagreed = &disagreed
+	review.Notes = input.Notes
+	if err := s.repo.Complete(ctx, review); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, review)
+	log.Printf("qaReviewService.SubmitVerdict: QA review %s of document %s completed by %s (disagreed: %t)",
+		review.ID, review.DocumentID, input.UserID, disagreed)
+	return review, nil
+}
+
+func (s *qaReviewService) Scores(ctx context.Context, tenantID uuid.UUID, window time.Duration) ([]domain.QAReviewerScore, error) {
+	scores, err := s.repo.ScoresByReviewer(ctx, tenantID, time.Now().UTC().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) == 0 {
+		return scores, nil
+	}
+
+	ids := make([]uuid.UUID, len(scores))
+	for i := range scores {
+		ids[i] = scores[i].ReviewerID
+	}
+	users, err := s.userRepo.GetByIDs(ctx, tenantID, ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(users))
+	for i := range users {
+		names[users[i].ID] = users[i].FullName
+	}
+	for i := range scores {
+		scores[i].ReviewerName = names[scores[i].ReviewerID]
+		if scores[i].Completed > 0 {
+			rate := float64(scores[i].Completed-scores[i].Disagreements) / float64(scores[i].Completed)
+			scores[i].AgreementRate = &rate
+		}
+	}
+	return scores, nil
+}
+
+// audit records the verdict on the document once it is in; sampling itself is
+// not audited so the second reviewer can't see who approved the document.
+func (s *qaReviewService) audit(ctx context.Context, review *domain.QAReview) {
+	if s.auditRepo == nil {
+		return
+	}
+	changes, _ := json.Marshal(map[string]interface{}{
+		"qa_review_id":    review.ID.String(),
+		"decision":        string(*review.Decision),
+		"disputed_fields": review.DisputedFields,
+		"disagreed":       *review.Disagreed,
+	})
+	entry := &domain.DocumentAuditEntry{
+		ID:         uuid.New(),
+		TenantID:   review.TenantID,
+		DocumentID: review.DocumentID,
+		UserID:     review.SecondReviewerID,
+		Action:     string(domain.AuditDocumentQAReviewed),
+		Changes:    changes,
+	}
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("qaReviewService.audit: failed to write audit entry for %s: %v", review.DocumentID, err)
+	}
+}
+
+// normalizeDisputedFields validates a verdict and returns its disputed field
+// paths trimmed and de-duplicated.
+func normalizeDisputedFields(decision domain.ReviewStatus, fields []string, notes string) ([]string, error) {
+	if decision != domain.ReviewStatusApproved && decision != domain.ReviewStatusRejected {
+		return nil, fmt.Errorf("%w: decision must be approved or rejected", domain.ErrInvalidQAVerdict)
+	}
+	if len(fields) > maxQADisputedFields {
+		return nil, fmt.Errorf("%w: at most %d disputed fields", domain.ErrInvalidQAVerdict, maxQADisputedFields)
+	}
+	if len(notes) > maxQANotesLength {
+		return nil, fmt.Errorf("%w: notes must be at most %d characters", domain.ErrInvalidQAVerdict, maxQANotesLength)
+	}
+	seen := make(map[string]bool, len(fields))
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		if f == "" || len(f) > 200 {
+			return nil, fmt.Errorf("%w: disputed fields must be field paths of 1-200 characters", domain.ErrInvalidQAVerdict)
+		}
+		if !seen[f] {
+			seen[f] = true
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// qaSamplingDocumentRepo wraps a DocumentRepository and samples approvals for
+// second-opinion QA at their collection's qa_sample_percent. Every approval
+// path (review, checker confirmation, bulk review) writes through the
+// repository, so none escapes sampling.
+// Non-blocking: sampling failures are logged.
+type qaSamplingDocumentRepo struct {
+	port.DocumentRepository
+	reviews        port.QAReviewRepository
+	collectionRepo port.CollectionRepository
+	roll           func() int
+}
+
+// NewQASamplingDocumentRepo wraps repo so approvals are sampled into reviews.
+func NewQASamplingDocumentRepo(repo port.DocumentRepository, reviews port.QAReviewRepository, collectionRepo port.CollectionRepository) port.DocumentRepository {
+	return &qaSamplingDocumentRepo{
+		DocumentRepository: repo,
+		reviews:            reviews,
+		collectionRepo:     collectionRepo,
+		roll:               func() int { return rand.IntN(100) },
+	}
+}
+
+func (r *qaSamplingDocumentRepo) UpdateReviewStatus(ctx context.Context, doc *domain.Document) error {
+	if err := r.DocumentRepository.UpdateReviewStatus(ctx, doc); err != nil {
+		return err
+	}
+	if doc.ReviewStatus == domain.ReviewStatusApproved && doc.ReviewedBy != nil {
+		r.maybeSample(ctx, doc)
+	}
+	return nil
+}
+
+func (r *qaSamplingDocumentRepo) maybeSample(ctx context.Context, doc *domain.Document) {
+	collection, err := r.collectionRepo.GetByID(ctx, doc.TenantID, doc.CollectionID)
+	if err != nil {
+		log.Printf("qaSamplingDocumentRepo: loading collection %s: %v", doc.CollectionID, err)
+		return
+	}
+	if collection.QASamplePercent == nil || r.roll() >= *collection.QASamplePercent {
+		return
+	}
+
+	review := &domain.QAReview{
+		ID:           uuid.New(),
+		TenantID:     doc.TenantID,
+		DocumentID:   doc.ID,
+		CollectionID: doc.CollectionID,
+		ReviewerID:   *doc.ReviewedBy,
+		MakerID:      doc.MakerApprovedBy,
+	}
+	if _, err := r.reviews.Create(ctx, review); err != nil {
+		log.Printf("qaSamplingDocumentRepo: failed to sample document %s: %v", doc.ID, err)
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// queryCacheMaxEntries bounds the cache; when it is full of live entries it
+	// is emptied rather than grown.
+	queryCacheMaxEntries = 10000
+	// queryLoadTimeout bounds a coalesced query, which runs detached from the
+	// request that started it.
+	queryLoadTimeout = 60 * time.Second
+)
+
+type queryCacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// queryCache serves read-only query results from memory for ttl and coalesces
+// concurrent identical queries, so dashboards auto-refreshing together hit the
+// database once. It is per process. Cached values are shared between callers
+// and must not be modified. Errors are not cached.
+type queryCache struct {
+	ttl     time.Duration
+	group   singleflight.Group
+	mu      sync.Mutex
+	entries map[string]queryCacheEntry
+}
+
+// newQueryCache creates a cache. A ttl of 0 or less only coalesces queries.
+func newQueryCache(ttl time.Duration) *queryCache {
+	return &queryCache{ttl: ttl, entries: make(map[string]queryCacheEntry)}
+}
+
+// queryKey builds a cache key from the tenant, the query name and its
+// arguments. Keys start with the tenant so its entries can be invalidated.
+func queryKey(tenantID uuid.UUID, query string, args ...any) string {
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		// Every argument is plain data; fall back to a key that never repeats
+		encoded = []byte(uuid.NewString())
+	}
+	return tenantID.String() + "|" + query + "|" + string(encoded)
+}
+
+// cachedQuery returns key's cached value, or runs load once for all concurrent
+// callers asking for key. A caller whose context ends stops waiting without
+// cancelling the query for the others.
+func cachedQuery[T any](ctx context.Context, c *queryCache, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if v, ok := c.get(key); ok {
+		return v.(T), nil
+	}
+	ch := c.group.DoChan(key, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), queryLoadTimeout)
+		defer cancel()
+		v, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.put(key, v)
+		return v, nil
+	})
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case r := <-ch:
+		if r.Err != nil {
+			return zero, r.Err
+		}
+		return r.Val.(T), nil
+	}
+}
+
+func (c *queryCache) get(key string) (any, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *queryCache) put(key string, value any) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if len(c.entries) >= queryCacheMaxEntries {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= queryCacheMaxEntries {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = queryCacheEntry{value: value, expires: now.Add(c.ttl)}
+}
+
+// invalidateTenant drops the tenant's cached results.
+func (c *queryCache) invalidateTenant(tenantID uuid.UUID) {
+	prefix := tenantID.String() + "|"
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(c.entries, k)
+		}
+	}
+}
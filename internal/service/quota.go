@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// quotaPeriod matches the 30-day reset in CheckAndIncrementQuota.
+const quotaPeriod = 30 * 24 * time.Hour
+
+// quotaWarningLevels are the usage percentages that trigger a warning, highest
+// first. Each is sent at most once per quota period.
+var quotaWarningLevels = []int{95, 80}
+
+// QuotaService reports users' monthly document quota.
+type QuotaService interface {
+	// Usage returns the user's quota in the current period, or nil when the
+	// user has no limit.
+	Usage(ctx context.Context, tenantID, userID uuid.UUID) (*domain.QuotaUsage, error)
+}
+
+type quotaService struct {
+	userRepo port.UserRepository
+}
+
+// NewQuotaService creates a new QuotaService.
+func NewQuotaService(userRepo port.UserRepository) QuotaService {
+	return &quotaService{userRepo: userRepo}
+}
+
+func (s *quotaService) Usage(ctx context.Context, tenantID, userID uuid.UUID) (*domain.QuotaUsage, error) {
+	user, err := s.userRepo.GetByID(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return quotaUsage(user, time.Now()), nil
+}
+
+func quotaUsage(user *domain.User, now time.Time) *domain.QuotaUsage {
+	if user.MonthlyDocumentLimit == 0 {
+		return nil
+	}
+	usage := &domain.QuotaUsage{
+		Limit:       user.MonthlyDocumentLimit,
+		Used:        user.DocumentsUsedThisPeriod,
+		PeriodStart: user.CurrentPeriodStart,
+	}
+	if now.Sub(user.CurrentPeriodStart) > quotaPeriod {
+		// The period lapsed; it resets on the next document
+		usage.Used = 0
+		usage.PeriodStart = now
+	}
+	usage.Remaining = max(usage.Limit-usage.Used, 0)
+	usage.ResetsAt = usage.PeriodStart.Add(quotaPeriod)
+	return usage
+}
+
+// QuotaWarningUserRepo wraps a UserRepository so that every quota increment
+// that takes a user to 80% or 95% of their monthly limit notifies the user and
+// their tenant's admins, once per level per period, before uploads start being
+// rejected. Every caller of CheckAndIncrementQuota (uploads, file replacement,
+// parse previews) gets the warnings without changes.
+type QuotaWarningUserRepo struct {
+	port.UserRepository
+	notifier port.Notifier
+}
+
+// NewQuotaWarningUserRepo creates a UserRepository that sends quota warnings
+// through notifier.
+func NewQuotaWarningUserRepo(inner port.UserRepository, notifier port.Notifier) *QuotaWarningUserRepo {
+	return &QuotaWarningUserRepo{UserRepository: inner, notifier: notifier}
+}
+
+// CheckAndIncrementQuota counts the document, then warns if that crossed a
+// warning level. Warning failures are logged and never fail the upload.
+func (r *QuotaWarningUserRepo) CheckAndIncrementQuota(ctx context.Context, tenantID, userID uuid.UUID) error {
+	if err := r.UserRepository.CheckAndIncrementQuota(ctx, tenantID, userID); err != nil {
+		return err
+	}
+	r.warn(context.WithoutCancel(ctx), tenantID, userID)
+	return nil
+}
+
+func (r *QuotaWarningUserRepo) warn(ctx context.Context, tenantID, userID uuid.UUID) {
+	user, err := r.UserRepository.GetByID(ctx, tenantID, userID)
+	if err != nil {
+		log.Printf("QuotaWarningUserRepo: loading user %s failed: %v", userID, err)
+		return
+	}
+	usage := quotaUsage(user, time.Now())
+	if usage == nil {
+		return
+	}
+	level := 0
+	for _, l := range quotaWarningLevels {
+		if usage.Used*100 >= l*usage.Limit {
+			level = l
+			break
+		}
+	}
+	if level == 0 || level <= user.QuotaWarningLevel {
+		return
+	}
+	claimed, err := r.UserRepository.ClaimQuotaWarning(ctx, userID, level)
+	if err != nil {
+		log.Printf("QuotaWarningUserRepo: claiming %d%% warning for user %s failed: %v", level, userID, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	n := &port.Notification{
+		Kind:     domain.NotificationKindQuotaWarning,
+		TenantID: tenantID,
+		Recipients: []port.NotificationRecipient{
+			{UserID: user.ID, Email: user.Email, Name: user.FullName},
+		},
+		Subject: fmt.Sprintf("%s has used %d%% of their monthly document quota", user.FullName, level),
+		Body: fmt.Sprintf("%s (%s) has used %d of %d documents this period; %d remain until %s. "+
+			"Uploads are rejected once the quota is used up. Upgrade the plan or raise the user's limit to keep uploading.",
+			user.FullName, user.Email, usage.Used, usage.Limit, usage.Remaining, usage.ResetsAt.Format("2 Jan 2006")),
+	}
+	admins, err := tenantAdmins(ctx, r.UserRepository, tenantID)
+	if err != nil {
+		log.Printf("QuotaWarningUserRepo: listing admins of tenant %s failed: %v", tenantID, err)
+	}
+	for i := range admins {
+		if admins[i].ID != user.ID {
+			n.Recipients = append(n.Recipients, port.NotificationRecipient{
+				UserID: admins[i].ID, Email: admins[i].Email, Name: admins[i].FullName,
+			})
+		}
+	}
+	if err := r.notifier.Notify(ctx, n); err != nil {
+		log.Printf("QuotaWarningUserRepo: sending %d%% warning for user %s failed: %v", level, userID, err)
+	}
+}
@@ -33,7 +33,12 @@ type RegisterOutput struct {
 type RegistrationService interface {
 	Register(ctx context.Context, input RegisterInput) (*RegisterOutput, error)
 	VerifyEmail(ctx context.Context, token string) error
+	// ResendVerification returns ErrVerificationResendTooSoon within the resend cooldown.
 	ResendVerification(ctx context.Context, tenantID, userID uuid.UUID) error
+	// SendVerificationReminders sends the one automatic reminder to users still
+	// unverified VerificationReminderAfterHours after registering. It returns how
+	// many reminders were sent.
+	SendVerificationReminders(ctx context.Context, now time.Time) (int, error)
 }
 
 type registrationService struct {
@@ -42,7 +47,7 @@ type registrationService struct {
 	collRepo    port.CollectionRepository
 	permRepo    port.CollectionPermissionRepository
 	authSvc     AuthService
-	emailSender port.EmailSender
+	notifier    port.Notifier
 	jwtCfg      config.JWTConfig
 	freeTierCfg config.FreeTierConfig
 }
@@ -54,7 +59,7 @@ func NewRegistrationService(
 	collRepo port.CollectionRepository,
 	permRepo port.CollectionPermissionRepository,
 	authSvc AuthService,
-	emailSender port.EmailSender,
+	notifier port.Notifier,
 	jwtCfg config.JWTConfig,
 	freeTierCfg config.FreeTierConfig,
 ) RegistrationService {
@@ -64,7 +69,7 @@ func NewRegistrationService(
 		collRepo:    collRepo,
 		permRepo:    permRepo,
 		authSvc:     authSvc,
-		emailSender: emailSender,
+		notifier:    notifier,
 		jwtCfg:      jwtCfg,
 		freeTierCfg: freeTierCfg,
 	}
@@ -136,10 +141,10 @@ func (s *registrationService) Register(ctx context.Context, input RegisterInput)
 	}
 
 	// Send verification email (non-blocking — don't fail registration)
-	verifyToken, tokenErr := s.generateVerificationToken(user)
-	if tokenErr != nil {
-		log.Printf("WARNING: failed to generate verification token for %s: %v", user.Email, tokenErr)
-	} else if sendErr := s.emailSender.SendVerificationEmail(ctx, user.Email, user.FullName, verifyToken); sendErr != nil {
+	if _, claimErr := s.userRepo.ClaimVerificationSend(ctx, user.TenantID, user.ID, time.Now()); claimErr != nil {
+		log.Printf("WARNING: failed to record verification email for %s: %v", user.Email, claimErr)
+	}
+	if sendErr := s.sendVerification(ctx, user); sendErr != nil {
 		log.Printf("WARNING: failed to send verification email to %s: %v", user.Email, sendErr)
 	}
 
@@ -180,12 +185,58 @@ func (s *registrationService) ResendVerification(ctx context.Context, tenantID,
 		return nil
 	}
 
+	cooldown := time.Duration(s.freeTierCfg.VerificationResendCooldownSecs) * time.Second
+	allowed, err := s.userRepo.ClaimVerificationSend(ctx, tenantID, userID, time.Now().Add(-cooldown))
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return domain.ErrVerificationResendTooSoon
+	}
+
+	return s.sendVerification(ctx, user)
+}
+
+// reminderBatchSize caps the reminders claimed per SendVerificationReminders call.
+const reminderBatchSize = 100
+
+func (s *registrationService) SendVerificationReminders(ctx context.Context, now time.Time) (int, error) {
+	if s.freeTierCfg.VerificationReminderAfterHours <= 0 {
+		return 0, nil
+	}
+	registeredBefore := now.Add(-time.Duration(s.freeTierCfg.VerificationReminderAfterHours) * time.Hour)
+
+	// Users are marked reminded before the email goes out, so a failed send is
+	// logged and not retried: at most one reminder per user.
+	users, err := s.userRepo.ClaimVerificationReminders(ctx, registeredBefore, reminderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for i := range users {
+		if err := s.sendVerification(ctx, &users[i]); err != nil {
+			log.Printf("registrationService.SendVerificationReminders: user %s: %v", users[i].ID, err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (s *registrationService) sendVerification(ctx context.Context, user *domain.User) error {
 	verifyToken, err := s.generateVerificationToken(user)
 	if err != nil {
 		return fmt.Errorf("generating verification token: %w", err)
 	}
 
-	return s.emailSender.SendVerificationEmail(ctx, user.Email, user.FullName, verifyToken)
+	return s.notifier.Notify(ctx, &port.Notification{
+		Kind:       domain.NotificationKindEmailVerification,
+		TenantID:   user.TenantID,
+		Recipients: []port.NotificationRecipient{{UserID: user.ID, Email: user.Email, Name: user.FullName}},
+		Subject:    "Verify your email address",
+		Body:       "A verification link was emailed to " + user.Email + ".",
+		Token:      verifyToken,
+	})
 }
 
 func (s *registrationService) generateVerificationToken(user *domain.User) (string, error) {
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	maxRejectionReasonLabelLen = 100
+	maxRejectionReasonDescLen  = 500
+	maxCustomRejectionReasons  = 50
+	// customRejectionSortOrder places tenant-specific reasons after the defaults
+	// unless they set a sort_order of their own.
+	customRejectionSortOrder = 500
+	// unspecifiedRejectionLabel labels rejections recorded before reason codes were required.
+	unspecifiedRejectionLabel = "Unspecified"
+)
+
+var rejectionCodeRe = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+// SetRejectionReasonInput is the DTO for relabeling, retiring or adding a rejection reason.
+type SetRejectionReasonInput struct {
+	Label       string `json:"label" binding:"required"`
+	Description string `json:"description"`
+	// Active defaults to true; false retires the reason so new rejections can't use it.
+	Active *bool `json:"active"`
+	// SortOrder defaults to the built-in position, or after the defaults for new codes.
+	SortOrder *int `json:"sort_order"`
+}
+
+// RejectionReasonService manages each tenant's rejection-reason taxonomy and
+// reports how often each reason is used.
+type RejectionReasonService interface {
+	// List returns the tenant's effective taxonomy in display order. Retired
+	// reasons are only included with includeInactive.
+	List(ctx context.Context, tenantID uuid.UUID, includeInactive bool) ([]domain.RejectionReason, error)
+	Set(ctx context.Context, tenantID uuid.UUID, code string, input *SetRejectionReasonInput, updatedBy uuid.UUID) (*domain.RejectionReason, error)
+	Stats(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.RejectionReasonStat, error)
+}
+
+type rejectionReasonService struct {
+	repo port.RejectionReasonRepository
+}
+
+// NewRejectionReasonService creates a new RejectionReasonService.
+func NewRejectionReasonService(repo port.RejectionReasonRepository) RejectionReasonService {
+	return &rejectionReasonService{repo: repo}
+}
+
+func (s *rejectionReasonService) List(ctx context.Context, tenantID uuid.UUID, includeInactive bool) ([]domain.RejectionReason, error) {
+	reasons, err := tenantRejectionReasons(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if includeInactive {
+		return reasons, nil
+	}
+	active := reasons[:0]
+	for i := range reasons {
+		if reasons[i].Active {
+			active = append(active, reasons[i])
+		}
+	}
+	return active, nil
+}
+
+func (s *rejectionReasonService) Set(ctx context.Context, tenantID uuid.UUID, code string, input *SetRejectionReasonInput, updatedBy uuid.UUID) (*domain.RejectionReason, error) {
+	if !rejectionCodeRe.MatchString(code) {
+		return nil, fmt.Errorf("%w: code %q must be lowercase letters, digits and underscores", domain.ErrInvalidRejectionReason, code)
+	}
+	label := strings.TrimSpace(input.Label)
+	if label == "" || len(label) > maxRejectionReasonLabelLen {
+		return nil, fmt.Errorf("%w: label must be 1-%d characters", domain.ErrInvalidRejectionReason, maxRejectionReasonLabelLen)
+	}
+	description := strings.TrimSpace(input.Description)
+	if len(description) > maxRejectionReasonDescLen {
+		return nil, fmt.Errorf("%w: description must be at most %d characters", domain.ErrInvalidRejectionReason, maxRejectionReasonDescLen)
+	}
+
+	stored, err := s.repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	sortOrder := customRejectionSortOrder
+	if def := defaultRejectionReason(code); def != nil {
+		sortOrder = def.SortOrder
+	} else if !storedRejectionCode(stored, code) && countCustomRejectionReasons(stored) >= maxCustomRejectionReasons {
+		return nil, fmt.Errorf("%w: at most %d tenant-specific reasons", domain.ErrInvalidRejectionReason, maxCustomRejectionReasons)
+	}
+	if input.SortOrder != nil {
+		sortOrder = *input.SortOrder
+	}
+
+	reason := &domain.RejectionReason{
+		TenantID:    tenantID,
+		Code:        code,
+		Label:       label,
+		Description: description,
+		Active:      input.Active == nil || *input.Active,
+		SortOrder:   sortOrder,
+		UpdatedBy:   &updatedBy,
+	}
+	if err := s.repo.Upsert(ctx, reason); err != nil {
+		return nil, err
+	}
+	reason.Overridden = true
+	return reason, nil
+}
+
+func (s *rejectionReasonService) Stats(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.RejectionReasonStat, error) {
+	stats, err := s.repo.Stats(ctx, tenantID, filters)
+	if err != nil {
+		return nil, err
+	}
+	reasons, err := tenantRejectionReasons(ctx, s.repo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]string, len(reasons))
+	for i := range reasons {
+		labels[reasons[i].Code] = reasons[i].Label
+	}
+	for i := range stats {
+		switch label, ok := labels[stats[i].ReasonCode]; {
+		case stats[i].ReasonCode == "":
+			stats[i].Label = unspecifiedRejectionLabel
+		case ok:
+			stats[i].Label = label
+		default:
+			stats[i].Label = stats[i].ReasonCode
+		}
+	}
+	return stats, nil
+}
+
+// tenantRejectionReasons merges the tenant's stored reasons over the defaults and
+// returns them in display order.
+func tenantRejectionReasons(ctx context.Context, repo port.RejectionReasonRepository, tenantID uuid.UUID) ([]domain.RejectionReason, error) {
+	stored, err := repo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	explicit := make(map[string]domain.RejectionReason, len(stored))
+	for i := range stored {
+		stored[i].Overridden = true
+		explicit[stored[i].Code] = stored[i]
+	}
+
+	reasons := make([]domain.RejectionReason, 0, len(domain.DefaultRejectionReasons)+len(stored))
+	for _, def := range domain.DefaultRejectionReasons {
+		if r, ok := explicit[def.Code]; ok {
+			reasons = append(reasons, r)
+			delete(explicit, def.Code)
+			continue
+		}
+		def.TenantID = tenantID
+		def.Active = true
+		reasons = append(reasons, def)
+	}
+	for code := range explicit {
+		reasons = append(reasons, explicit[code])
+	}
+	sort.SliceStable(reasons, func(i, j int) bool {
+		if reasons[i].SortOrder != reasons[j].SortOrder {
+			return reasons[i].SortOrder < reasons[j].SortOrder
+		}
+		return reasons[i].Code < reasons[j].Code
+	})
+	return reasons, nil
+}
+
+// resolveRejectionReason checks that code is an active reason of the tenant's taxonomy.
+func resolveRejectionReason(ctx context.Context, repo port.RejectionReasonRepository, tenantID uuid.UUID, code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return "", domain.ErrRejectionReasonRequired
+	}
+	reasons, err := tenantRejectionReasons(ctx, repo, tenantID)
+	if err != nil {
+		return "", err
+	}
+	for i := range reasons {
+		if reasons[i].Code == code {
+			if !reasons[i].Active {
+				return "", fmt.Errorf("%w: %q is retired", domain.ErrInvalidRejectionReason, code)
+			}
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown code %q", domain.ErrInvalidRejectionReason, code)
+}
+
+func defaultRejectionReason(code string) *domain.RejectionReason {
+	for i := range domain.DefaultRejectionReasons {
+		if domain.DefaultRejectionReasons[i].Code == code {
+			return &domain.DefaultRejectionReasons[i]
+		}
+	}
+	return nil
+}
+
+func storedRejectionCode(stored []domain.RejectionReason, code string) bool {
+	for i := range stored {
+		if stored[i].Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func countCustomRejectionReasons(stored []domain.RejectionReason) int {
+	n := 0
+	for i := range stored {
+		if defaultRejectionReason(stored[i].Code) == nil {
+			n++
+		}
+	}
+	return n
+}
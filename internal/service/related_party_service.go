@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/validator/invoice"
+)
+
+const (
+	maxRelatedPartyNameLen = 255
+	maxRelatedParties      = 500
+	// RelatedPartyTagKey is the auto-tag of an invoice from a related party; its
+	// value is the relationship.
+	RelatedPartyTagKey = "related_party"
+)
+
+var relatedPartyGSTINRe = regexp.MustCompile(`^\d{2}[A-Z]{5}\d{4}[A-Z][1-9A-Z]Z[0-9A-Z]$`)
+
+// SetRelatedPartyInput is the DTO for registering or updating a related party.
+type SetRelatedPartyInput struct {
+	Name string `json:"name" binding:"required"`
+	// Relationship is own (another registration of the tenant) or group.
+	Relationship domain.RelatedPartyRelationship `json:"relationship" binding:"required"`
+}
+
+// RelatedPartyService manages the GSTINs a tenant registered as its own or its
+// group companies', and tags invoices from them.
+type RelatedPartyService interface {
+	List(ctx context.Context, tenantID uuid.UUID) ([]domain.RelatedParty, error)
+	Set(ctx context.Context, tenantID uuid.UUID, gstin string, input *SetRelatedPartyInput, updatedBy uuid.UUID) (*domain.RelatedParty, error)
+	Delete(ctx context.Context, tenantID uuid.UUID, gstin string) error
+	// TagDocument is a document.parsed and document.edited listener that keeps
+	// the document's related_party tag in line with its seller GSTIN.
+	TagDocument(ctx context.Context, e *DocumentEvent)
+}
+
+type relatedPartyService struct {
+	repo    port.RelatedPartyRepository
+	tagRepo port.DocumentTagRepository
+}
+
+// NewRelatedPartyService creates a new RelatedPartyService.
+func NewRelatedPartyService(repo port.RelatedPartyRepository, tagRepo port.DocumentTagRepository) RelatedPartyService {
+	return &relatedPartyService{repo: repo, tagRepo: tagRepo}
+}
+
+func (s *relatedPartyService) List(ctx context.Context, tenantID uuid.UUID) ([]domain.RelatedParty, error) {
+	return s.repo.ListByTenant(ctx, tenantID)
+}
+
+func (s *relatedPartyService) Set(ctx context.Context, tenantID uuid.UUID, gstin string, input *SetRelatedPartyInput, updatedBy uuid.UUID) (*domain.RelatedParty, error) {
+	gstin = strings.ToUpper(strings.TrimSpace(gstin))
+	if !relatedPartyGSTINRe.MatchString(gstin) {
+		return nil, fmt.Errorf("%w: %q is not a 15-character GSTIN", domain.ErrInvalidRelatedParty, gstin)
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxRelatedPartyNameLen {
+		return nil, fmt.Errorf("%w: name must be 1-%d characters", domain.ErrInvalidRelatedParty, maxRelatedPartyNameLen)
+	}
+	if input.Relationship != domain.RelatedPartyOwn && input.Relationship != domain.RelatedPartyGroup {
+		return nil, fmt.Errorf("%w: relationship must be own or group", domain.ErrInvalidRelatedParty)
+	}
+
+	if _, err := s.repo.FindRelatedParty(ctx, tenantID, gstin); errors.Is(err, domain.ErrNotFound) {
+		existing, listErr := s.repo.ListByTenant(ctx, tenantID)
+		if listErr != nil {
+			return nil, listErr
+		}
+		if len(existing) >= maxRelatedParties {
+			return nil, fmt.Errorf("%w: at most %d related parties", domain.ErrInvalidRelatedParty, maxRelatedParties)
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	party := &domain.RelatedParty{
+		TenantID:     tenantID,
+		GSTIN:        gstin,
+		Name:         name,
+		Relationship: input.Relationship,
+		UpdatedBy:    &updatedBy,
+	}
+	if err := s.repo.Upsert(ctx, party); err != nil {
+		return nil, err
+	}
+	log.Printf("relatedPartyService.Set: tenant %s registered %s as %s related party", tenantID, gstin, party.Relationship)
+	return party, nil
+}
+
+func (s *relatedPartyService) Delete(ctx context.Context, tenantID uuid.UUID, gstin string) error {
+	gstin = strings.ToUpper(strings.TrimSpace(gstin))
+	if err := s.repo.Delete(ctx, tenantID, gstin); err != nil {
+		return err
+	}
+	log.Printf("relatedPartyService.Delete: tenant %s removed related party %s", tenantID, gstin)
+	return nil
+}
+
+func (s *relatedPartyService) TagDocument(ctx context.Context, e *DocumentEvent) {
+	doc := e.Document
+	var inv invoice.GSTInvoice
+	if len(doc.StructuredData) > 0 {
+		if err := json.Unmarshal(doc.StructuredData, &inv); err != nil {
+			log.Printf("relatedPartyService.TagDocument: failed to unmarshal structured data for %s: %v", doc.ID, err)
+			return
+		}
+	}
+
+	var relationship domain.RelatedPartyRelationship
+	if gstin := strings.ToUpper(strings.TrimSpace(inv.Seller.GSTIN)); gstin != "" {
+		party, err := s.repo.FindRelatedParty(ctx, doc.TenantID, gstin)
+		switch {
+		case err == nil:
+			relationship = party.Relationship
+		case !errors.Is(err, domain.ErrNotFound):
+			log.Printf("relatedPartyService.TagDocument: looking up seller %s of document %s failed: %v", gstin, doc.ID, err)
+			return
+		}
+	}
+
+	// Drop a tag the document no longer earns, e.g. after the seller GSTIN was corrected
+	for _, r := range []domain.RelatedPartyRelationship{domain.RelatedPartyOwn, domain.RelatedPartyGroup} {
+		if r == relationship {
+			continue
+		}
+		if _, err := s.tagRepo.DeleteByKeyValue(ctx, doc.ID, RelatedPartyTagKey, string(r)); err != nil {
+			log.Printf("relatedPartyService.TagDocument: removing %s tag from document %s failed: %v", r, doc.ID, err)
+		}
+	}
+	if relationship == "" {
+		return
+	}
+	_, err := s.tagRepo.AddIfMissing(ctx, &domain.DocumentTag{
+		ID:         uuid.New(),
+		DocumentID: doc.ID,
+		TenantID:   doc.TenantID,
+		Key:        RelatedPartyTagKey,
+		Value:      string(relationship),
+		ValueType:  domain.TagValueString,
+		Source:     "auto",
+	})
+	if err != nil {
+		log.Printf("relatedPartyService.TagDocument: tagging document %s failed: %v", doc.ID, err)
+	}
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"math"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -14,6 +16,9 @@ type ReportService interface {
 	SellerSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.SellerSummaryRow, int, error)
 	BuyerSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.BuyerSummaryRow, int, error)
 	PartyLedger(ctx context.Context, tenantID uuid.UUID, gstin string, filters *domain.ReportFilters) ([]domain.PartyLedgerRow, int, error)
+	// SearchByAmount lists documents whose grand total is within tolerance of
+	// amount, closest first, optionally limited to a party (GSTIN or name).
+	SearchByAmount(ctx context.Context, tenantID uuid.UUID, amount float64, tolerance domain.AmountTolerance, party string, filters *domain.ReportFilters) ([]domain.AmountMatchRow, int, error)
 	FinancialSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.FinancialSummaryRow, error)
 	TaxSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.TaxSummaryRow, error)
 	HSNSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.HSNSummaryRow, int, error)
@@ -40,6 +45,23 @@ func (s *reportService) PartyLedger(ctx context.Context, tenantID uuid.UUID, gst
 	return s.reportRepo.PartyLedger(ctx, tenantID, gstin, filters)
 }
 
+func (s *reportService) SearchByAmount(ctx context.Context, tenantID uuid.UUID, amount float64, tolerance domain.AmountTolerance, party string, filters *domain.ReportFilters) ([]domain.AmountMatchRow, int, error) {
+	if !isFinite(amount) || !isFinite(tolerance.Value) {
+		return nil, 0, domain.ErrInvalidAmountSearch
+	}
+	if amount <= 0 || tolerance.Value < 0 || (tolerance.Percent && tolerance.Value > 100) {
+		return nil, 0, domain.ErrInvalidAmountSearch
+	}
+	delta := tolerance.Value
+	if tolerance.Percent {
+		delta = amount * tolerance.Value / 100
+	}
+	// Totals are stored to the paisa, so round the band outwards to match
+	minAmount := math.Floor((amount-delta)*100) / 100
+	maxAmount := math.Ceil((amount+delta)*100) / 100
+	return s.reportRepo.AmountMatches(ctx, tenantID, amount, minAmount, maxAmount, strings.TrimSpace(party), filters)
+}
+
 func (s *reportService) FinancialSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.FinancialSummaryRow, error) {
 	return s.reportRepo.FinancialSummary(ctx, tenantID, filters)
 }
@@ -55,3 +77,9 @@ func (s *reportService) HSNSummary(ctx context.Context, tenantID uuid.UUID, filt
 func (s *reportService) CollectionsOverview(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.CollectionOverviewRow, error) {
 	return s.reportRepo.CollectionsOverview(ctx, tenantID, filters)
 }
+
+// isFinite reports whether v is neither NaN nor infinite; both compare false
+// against any bound, so range checks alone let them through.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"satvos/internal/domain"
+)
+
+const (
+	maxReviewChecklistItems = 25
+	maxChecklistLabelLen    = 200
+)
+
+var (
+	checklistIDRe      = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+	checklistSlugStrip = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// normalizeChecklistItems trims labels, derives missing IDs from the label, and
+// rejects blank labels, malformed or duplicate IDs, and oversized checklists.
+func normalizeChecklistItems(items []domain.ReviewChecklistItem) ([]domain.ReviewChecklistItem, error) {
+	if len(items) > maxReviewChecklistItems {
+		return nil, fmt.Errorf("%w: at most %d items", domain.ErrInvalidReviewChecklist, maxReviewChecklistItems)
+	}
+	out := make([]domain.ReviewChecklistItem, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		label := strings.TrimSpace(item.Label)
+		if label == "" || len(label) > maxChecklistLabelLen {
+			return nil, fmt.Errorf("%w: label must be 1-%d characters", domain.ErrInvalidReviewChecklist, maxChecklistLabelLen)
+		}
+		id := strings.TrimSpace(item.ID)
+		if id == "" {
+			id = checklistSlug(label)
+		}
+		if !checklistIDRe.MatchString(id) {
+			return nil, fmt.Errorf("%w: id %q must be lowercase letters, digits and underscores", domain.ErrInvalidReviewChecklist, id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicate id %q", domain.ErrInvalidReviewChecklist, id)
+		}
+		seen[id] = true
+		out = append(out, domain.ReviewChecklistItem{ID: id, Label: label})
+	}
+	return out, nil
+}
+
+// checklistSlug derives an item ID from its label: "PO attached" → "po_attached".
+func checklistSlug(label string) string {
+	slug := strings.Trim(checklistSlugStrip.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	if len(slug) > 64 {
+		slug = strings.TrimRight(slug[:64], "_")
+	}
+	return slug
+}
+
+// decodeChecklistItems reads a collection's stored checklist; a missing or
+// unreadable value is treated as no checklist.
+func decodeChecklistItems(raw json.RawMessage) []domain.ReviewChecklistItem {
+	var items []domain.ReviewChecklistItem
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &items)
+	}
+	return items
+}
+
+// resolveReviewChecklist pairs a reviewer's answers with the collection's checklist
+// items, in checklist order. Answering an item the checklist doesn't have is an
+// error. Approval additionally requires every item to be answered true; a
+// rejection may leave items unanswered (recorded as unchecked).
+func resolveReviewChecklist(items []domain.ReviewChecklistItem, answers map[string]bool, status domain.ReviewStatus) ([]domain.ReviewChecklistAnswer, error) {
+	known := make(map[string]bool, len(items))
+	for _, item := range items {
+		known[item.ID] = true
+	}
+	for id := range answers {
+		if !known[id] {
+			return nil, fmt.Errorf("%w: unknown item %q", domain.ErrInvalidReviewChecklist, id)
+		}
+	}
+
+	resolved := make([]domain.ReviewChecklistAnswer, 0, len(items))
+	var missing []string
+	for _, item := range items {
+		checked := answers[item.ID]
+		if !checked {
+			missing = append(missing, item.ID)
+		}
+		resolved = append(resolved, domain.ReviewChecklistAnswer{ID: item.ID, Label: item.Label, Checked: checked})
+	}
+	if status == domain.ReviewStatusApproved && len(missing) > 0 {
+		return nil, fmt.Errorf("%w: unchecked %s", domain.ErrReviewChecklistIncomplete, strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// maxDelegationDays bounds how long a single out-of-office delegation may run.
+const maxDelegationDays = 180
+
+// SetDelegationInput is the DTO for setting a user's out-of-office delegation.
+type SetDelegationInput struct {
+	TenantID   uuid.UUID
+	UserID     uuid.UUID
+	DelegateID uuid.UUID
+	StartsOn   time.Time
+	EndsOn     time.Time
+	ShareQueue bool
+}
+
+// ReviewDelegationService manages users' out-of-office review delegations.
+type ReviewDelegationService interface {
+	Set(ctx context.Context, input *SetDelegationInput) (*domain.ReviewDelegation, error)
+	Get(ctx context.Context, tenantID, userID uuid.UUID) (*domain.ReviewDelegation, error)
+	Clear(ctx context.Context, tenantID, userID uuid.UUID) error
+}
+
+type reviewDelegationService struct {
+	repo     port.ReviewDelegationRepository
+	userRepo port.UserRepository
+}
+
+// NewReviewDelegationService creates a new ReviewDelegationService implementation.
+func NewReviewDelegationService(repo port.ReviewDelegationRepository, userRepo port.UserRepository) ReviewDelegationService {
+	return &reviewDelegationService{repo: repo, userRepo: userRepo}
+}
+
+// Set replaces the user's delegation. The delegate must be another active user
+// in the tenant who can review, and the range may not end in the past.
+func (s *reviewDelegationService) Set(ctx context.Context, input *SetDelegationInput) (*domain.ReviewDelegation, error) {
+	d := &domain.ReviewDelegation{
+		TenantID:   input.TenantID,
+		UserID:     input.UserID,
+		DelegateID: input.DelegateID,
+		StartsOn:   input.StartsOn.UTC().Truncate(24 * time.Hour),
+		EndsOn:     input.EndsOn.UTC().Truncate(24 * time.Hour),
+		ShareQueue: input.ShareQueue,
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	switch {
+	case d.DelegateID == d.UserID:
+		return nil, fmt.Errorf("%w: cannot delegate to yourself", domain.ErrInvalidDelegation)
+	case d.EndsOn.Before(d.StartsOn):
+		return nil, fmt.Errorf("%w: ends_on is before starts_on", domain.ErrInvalidDelegation)
+	case d.EndsOn.Before(today):
+		return nil, fmt.Errorf("%w: ends_on is in the past", domain.ErrInvalidDelegation)
+	case d.EndsOn.Sub(d.StartsOn) > maxDelegationDays*24*time.Hour:
+		return nil, fmt.Errorf("%w: range exceeds %d days", domain.ErrInvalidDelegation, maxDelegationDays)
+	}
+
+	delegate, err := s.userRepo.GetByID(ctx, input.TenantID, input.DelegateID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: delegate not found", domain.ErrInvalidDelegation)
+	}
+	if !delegate.IsActive || delegate.Role == domain.RoleViewer || delegate.Role == domain.RoleFree {
+		return nil, fmt.Errorf("%w: delegate cannot review documents", domain.ErrInvalidDelegation)
+	}
+
+	if err := s.repo.Upsert(ctx, d); err != nil {
+		return nil, err
+	}
+	log.Printf("reviewDelegationService.Set: user %s delegates to %s from %s to %s (share_queue=%t)",
+		d.UserID, d.DelegateID, d.StartsOn.Format("2006-01-02"), d.EndsOn.Format("2006-01-02"), d.ShareQueue)
+	return d, nil
+}
+
+func (s *reviewDelegationService) Get(ctx context.Context, tenantID, userID uuid.UUID) (*domain.ReviewDelegation, error) {
+	return s.repo.GetByUser(ctx, tenantID, userID)
+}
+
+func (s *reviewDelegationService) Clear(ctx context.Context, tenantID, userID uuid.UUID) error {
+	return s.repo.Delete(ctx, tenantID, userID)
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	maxEscalateAfterDays = 365
+	escalationClaimLimit = 100
+	// escalationOwnerScanLimit bounds the permissions read when the collection's
+	// creator can no longer take an escalated review.
+	escalationOwnerScanLimit = 100
+)
+
+// ReviewEscalationService escalates documents that have waited for review past
+// their collection's escalation policy and lists the escalated documents.
+type ReviewEscalationService interface {
+	// ListEscalated returns escalated documents still awaiting review, in any
+	// collection for managers and admins, in owned collections for others.
+	ListEscalated(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Document, int, error)
+	// RunDue escalates every overdue document across tenants.
+	RunDue(ctx context.Context) error
+}
+
+type reviewEscalationService struct {
+	repo           port.ReviewEscalationRepository
+	docRepo        port.DocumentRepository
+	collectionRepo port.CollectionRepository
+	permRepo       port.CollectionPermissionRepository
+	userRepo       port.UserRepository
+	auditRepo      port.DocumentAuditRepository
+	notifications  NotificationService
+}
+
+// NewReviewEscalationService creates a new ReviewEscalationService. Reassignments
+// go through docRepo, so its decorators see them; notifications may be nil.
+func NewReviewEscalationService(
+	repo port.ReviewEscalationRepository,
+	docRepo port.DocumentRepository,
+	collectionRepo port.CollectionRepository,
+	permRepo port.CollectionPermissionRepository,
+	userRepo port.UserRepository,
+	auditRepo port.DocumentAuditRepository,
+	notifications NotificationService,
+) ReviewEscalationService {
+	return &reviewEscalationService{
+		repo:           repo,
+		docRepo:        docRepo,
+		collectionRepo: collectionRepo,
+		permRepo:       permRepo,
+		userRepo:       userRepo,
+		auditRepo:      auditRepo,
+		notifications:  notifications,
+	}
+}
+
+func (s *reviewEscalationService) ListEscalated(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Document, int, error) {
+	ownedOnly := role != domain.RoleAdmin && role != domain.RoleManager
+	return s.repo.ListEscalated(ctx, tenantID, userID, ownedOnly, offset, limit)
+}
+
+func (s *reviewEscalationService) RunDue(ctx context.Context) error {
+	for {
+		now := time.Now().UTC()
+		docs, err := s.repo.ClaimDue(ctx, now, escalationClaimLimit)
+		if err != nil {
+			return err
+		}
+		collections := make(map[uuid.UUID]*domain.Collection)
+		for i := range docs {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.escalate(ctx, &docs[i], collections, now)
+		}
+		if len(docs) < escalationClaimLimit {
+			return nil
+		}
+	}
+}
+
+// escalate applies the collection's escalation action to a claimed document,
+// then audits and announces it. A reassignment that can't be made leaves the
+// document flagged.
+func (s *reviewEscalationService) escalate(ctx context.Context, doc *domain.Document, collections map[uuid.UUID]*domain.Collection, now time.Time) {
+	collection, ok := collections[doc.CollectionID]
+	if !ok {
+		c, err := s.collectionRepo.GetByID(ctx, doc.TenantID, doc.CollectionID)
+		if err != nil {
+			log.Printf("reviewEscalationService.escalate: loading collection %s: %v", doc.CollectionID, err)
+		}
+		collection = c
+		collections[doc.CollectionID] = c
+	}
+
+	changes := map[string]interface{}{"action": string(domain.EscalationActionFlag)}
+	if doc.ParsedAt != nil {
+		changes["waiting_hours"] = int(now.Sub(*doc.ParsedAt).Hours())
+	}
+	if collection != nil && collection.EscalateAfterDays != nil {
+		changes["after_days"] = *collection.EscalateAfterDays
+	}
+	if collection != nil && collection.EscalationAction == domain.EscalationActionReassign {
+		if owner := s.escalationOwner(ctx, collection); owner == nil {
+			log.Printf("reviewEscalationService.escalate: collection %s has no active owner, flagging document %s",
+				collection.ID, doc.ID)
+		} else if doc.AssignedTo == nil || *doc.AssignedTo != *owner {
+			previous := doc.AssignedTo
+			doc.AssignedTo = owner
+			doc.AssignedAt = &now
+			doc.AssignedBy = nil
+			if err := s.docRepo.UpdateAssignment(ctx, doc); err != nil {
+				log.Printf("reviewEscalationService.escalate: reassigning document %s: %v", doc.ID, err)
+				doc.AssignedTo = previous
+			} else {
+				changes["action"] = string(domain.EscalationActionReassign)
+				changes["assigned_to"] = owner.String()
+				if previous != nil {
+					changes["previous_assignee"] = previous.String()
+				}
+			}
+		}
+	}
+
+	s.audit(ctx, doc, changes)
+	if s.notifications != nil {
+		s.notifications.NotifyDocument(domain.NotificationEventReviewEscalated, doc)
+	}
+	log.Printf("reviewEscalationService.escalate: document %s in collection %s escalated (%s)",
+		doc.ID, doc.CollectionID, changes["action"])
+}
+
+// escalationOwner returns the collection's creator if they still own it and are
+// active, otherwise its first active owner, or nil if there is none.
+func (s *reviewEscalationService) escalationOwner(ctx context.Context, collection *domain.Collection) *uuid.UUID {
+	if perm, err := s.permRepo.GetByCollectionAndUser(ctx, collection.ID, collection.CreatedBy); err == nil &&
+		perm.Permission == domain.CollectionPermOwner && s.activeUser(ctx, collection.TenantID, collection.CreatedBy) {
+		return &collection.CreatedBy
+	}
+	perms, _, err := s.permRepo.ListByCollection(ctx, collection.ID, 0, escalationOwnerScanLimit)
+	if err != nil {
+		log.Printf("reviewEscalationService.escalationOwner: listing permissions of %s: %v", collection.ID, err)
+		return nil
+	}
+	for i := range perms {
+		if perms[i].Permission == domain.CollectionPermOwner && s.activeUser(ctx, collection.TenantID, perms[i].UserID) {
+			return &perms[i].UserID
+		}
+	}
+	return nil
+}
+
+func (s *reviewEscalationService) activeUser(ctx context.Context, tenantID, userID uuid.UUID) bool {
+	user, err := s.userRepo.GetByID(ctx, tenantID, userID)
+	return err == nil && user.IsActive
+}
+
+func (s *reviewEscalationService) audit(ctx context.Context, doc *domain.Document, changes map[string]interface{}) {
+	if s.auditRepo == nil {
+		return
+	}
+	data, _ := json.Marshal(changes)
+	entry := &domain.DocumentAuditEntry{
+		ID:         uuid.New(),
+		TenantID:   doc.TenantID,
+		DocumentID: doc.ID,
+		Action:     string(domain.AuditDocumentEscalated),
+		Changes:    data,
+	}
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("reviewEscalationService.audit: failed to write audit entry for %s: %v", doc.ID, err)
+	}
+}
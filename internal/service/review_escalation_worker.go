@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ReviewEscalationWorker periodically escalates documents whose review has waited
+// past their collection's escalation policy.
+type ReviewEscalationWorker struct {
+	svc      ReviewEscalationService
+	interval time.Duration
+}
+
+// NewReviewEscalationWorker creates a new ReviewEscalationWorker that checks every interval.
+func NewReviewEscalationWorker(svc ReviewEscalationService, interval time.Duration) *ReviewEscalationWorker {
+	return &ReviewEscalationWorker{svc: svc, interval: interval}
+}
+
+// Start runs the escalation loop until ctx is canceled.
+func (w *ReviewEscalationWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	log.Printf("reviewEscalationWorker: started (interval=%s)", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("reviewEscalationWorker: shutdown complete")
+			return
+		case <-ticker.C:
+			if err := w.svc.RunDue(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("reviewEscalationWorker: RunDue error: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+)
+
+// cachingStatsService wraps a StatsService so its aggregate queries are cached
+// for a short TTL and coalesced. Recount passes through and drops the tenant's
+// cached results.
+type cachingStatsService struct {
+	StatsService
+	cache *queryCache
+}
+
+// NewCachingStatsService wraps stats so identical queries within ttl are served
+// from memory and concurrent ones run once.
+func NewCachingStatsService(stats StatsService, ttl time.Duration) StatsService {
+	return &cachingStatsService{StatsService: stats, cache: newQueryCache(ttl)}
+}
+
+func (s *cachingStatsService) GetStats(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole) (*domain.Stats, error) {
+	return cachedQuery(ctx, s.cache, queryKey(tenantID, "stats", userID, role), func(ctx context.Context) (*domain.Stats, error) {
+		return s.StatsService.GetStats(ctx, tenantID, userID, role)
+	})
+}
+
+func (s *cachingStatsService) GetParseLatency(ctx context.Context, tenantID uuid.UUID, window time.Duration) ([]domain.ParseLatencyStats, error) {
+	return cachedQuery(ctx, s.cache, queryKey(tenantID, "parse_latency", window), func(ctx context.Context) ([]domain.ParseLatencyStats, error) {
+		return s.StatsService.GetParseLatency(ctx, tenantID, window)
+	})
+}
+
+func (s *cachingStatsService) GetConfidenceCalibration(ctx context.Context, tenantID uuid.UUID, documentType string, window time.Duration) ([]domain.ConfidenceCalibration, error) {
+	key := queryKey(tenantID, "confidence_calibration", documentType, window)
+	return cachedQuery(ctx, s.cache, key, func(ctx context.Context) ([]domain.ConfidenceCalibration, error) {
+		return s.StatsService.GetConfidenceCalibration(ctx, tenantID, documentType, window)
+	})
+}
+
+func (s *cachingStatsService) GetRuleNoise(ctx context.Context, tenantID uuid.UUID, documentType string, window time.Duration, sortBy RuleNoiseSort, limit int) ([]domain.RuleNoise, error) {
+	key := queryKey(tenantID, "rule_noise", documentType, window, sortBy, limit)
+	return cachedQuery(ctx, s.cache, key, func(ctx context.Context) ([]domain.RuleNoise, error) {
+		return s.StatsService.GetRuleNoise(ctx, tenantID, documentType, window, sortBy, limit)
+	})
+}
+
+func (s *cachingStatsService) Recount(ctx context.Context, tenantID uuid.UUID) (*domain.StatsRecount, error) {
+	result, err := s.StatsService.Recount(ctx, tenantID)
+	s.cache.invalidateTenant(tenantID)
+	return result, err
+}
+
+func (s *cachingStatsService) GetCollectionQuality(ctx context.Context, tenantID uuid.UUID, filter domain.CollectionQualityFilter) ([]domain.CollectionQuality, error) {
+	return cachedQuery(ctx, s.cache, queryKey(tenantID, "collection_quality", filter), func(ctx context.Context) ([]domain.CollectionQuality, error) {
+		return s.StatsService.GetCollectionQuality(ctx, tenantID, filter)
+	})
+}
+
+func (s *cachingStatsService) GetCollectionQualityHistory(ctx context.Context, tenantID, collectionID uuid.UUID, window time.Duration) ([]domain.CollectionQuality, error) {
+	key := queryKey(tenantID, "collection_quality_history", collectionID, window)
+	return cachedQuery(ctx, s.cache, key, func(ctx context.Context) ([]domain.CollectionQuality, error) {
+		return s.StatsService.GetCollectionQualityHistory(ctx, tenantID, collectionID, window)
+	})
+}
+
+// countedRows is a report page and its total, cached together.
+type countedRows[T any] struct {
+	rows  []T
+	total int
+}
+
+// cachingReportService wraps a ReportService so its rollups are cached for a
+// short TTL and coalesced. Filters carry the caller's user and role, so results
+// are only shared between identical views. SearchByAmount, an interactive
+// lookup rather than a rollup, passes through.
+type cachingReportService struct {
+	ReportService
+	cache *queryCache
+}
+
+// NewCachingReportService wraps reports so identical rollups within ttl are
+// served from memory and concurrent ones run once.
+func NewCachingReportService(reports ReportService, ttl time.Duration) ReportService {
+	return &cachingReportService{ReportService: reports, cache: newQueryCache(ttl)}
+}
+
+// countedQuery caches a query returning rows and a total.
+func countedQuery[T any](ctx context.Context, c *queryCache, key string, load func(ctx context.Context) ([]T, int, error)) ([]T, int, error) {
+	page, err := cachedQuery(ctx, c, key, func(ctx context.Context) (countedRows[T], error) {
+		rows, total, err := load(ctx)
+		return countedRows[T]{rows: rows, total: total}, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return page.rows, page.total, nil
+}
+
+func (s *cachingReportService) SellerSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.SellerSummaryRow, int, error) {
+	return countedQuery(ctx, s.cache, queryKey(tenantID, "seller_summary", filters), func(ctx context.Context) ([]domain.SellerSummaryRow, int, error) {
+		return s.ReportService.SellerSummary(ctx, tenantID, filters)
+	})
+}
+
+func (s *cachingReportService) BuyerSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.BuyerSummaryRow, int, error) {
+	return countedQuery(ctx, s.cache, queryKey(tenantID, "buyer_summary", filters), func(ctx context.Context) ([]domain.BuyerSummaryRow, int, error) {
+		return s.ReportService.BuyerSummary(ctx, tenantID, filters)
+	})
+}
+
+func (s *cachingReportService) PartyLedger(ctx context.Context, tenantID uuid.UUID, gstin string, filters *domain.ReportFilters) ([]domain.PartyLedgerRow, int, error) {
+	return countedQuery(ctx, s.cache, queryKey(tenantID, "party_ledger", gstin, filters), func(ctx context.Context) ([]domain.PartyLedgerRow, int, error) {
+		return s.ReportService.PartyLedger(ctx, tenantID, gstin, filters)
+	})
+}
+
+func (s *cachingReportService) FinancialSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.FinancialSummaryRow, error) {
+	return cachedQuery(ctx, s.cache, queryKey(tenantID, "financial_summary", filters), func(ctx context.Context) ([]domain.FinancialSummaryRow, error) {
+		return s.ReportService.FinancialSummary(ctx, tenantID, filters)
+	})
+}
+
+func (s *cachingReportService) TaxSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.TaxSummaryRow, error) {
+	return cachedQuery(ctx, s.cache, queryKey(tenantID, "tax_summary", filters), func(ctx context.Context) ([]domain.TaxSummaryRow, error) {
+		return s.ReportService.TaxSummary(ctx, tenantID, filters)
+	})
+}
+
+func (s *cachingReportService) HSNSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.HSNSummaryRow, int, error) {
+	return countedQuery(ctx, s.cache, queryKey(tenantID, "hsn_summary", filters), func(ctx context.Context) ([]domain.HSNSummaryRow, int, error) {
+		return s.ReportService.HSNSummary(ctx, tenantID, filters)
+	})
+}
+
+func (s *cachingReportService) CollectionsOverview(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.CollectionOverviewRow, error) {
+	return cachedQuery(ctx, s.cache, queryKey(tenantID, "collections_overview", filters), func(ctx context.Context) ([]domain.CollectionOverviewRow, error) {
+		return s.ReportService.CollectionsOverview(ctx, tenantID, filters)
+	})
+}
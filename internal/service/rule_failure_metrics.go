@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// ruleOutcomeTrackingDocumentRepo wraps a DocumentRepository and records the
+// result of every validation rule whenever a document's validation results are
+// written, and flags still-failing rules as overridden when the document is
+// approved. Every validation path (parse, edit, re-validate, validation runs)
+// writes through the repository, so none can skip the metrics.
+// Non-blocking: recording failures are logged.
+type ruleOutcomeTrackingDocumentRepo struct {
+	port.DocumentRepository
+	outcomes port.ValidationRuleOutcomeRepository
+}
+
+// NewRuleOutcomeTrackingDocumentRepo wraps repo so validation results and
+// approvals feed the rule failure metrics in outcomes.
+func NewRuleOutcomeTrackingDocumentRepo(repo port.DocumentRepository, outcomes port.ValidationRuleOutcomeRepository) port.DocumentRepository {
+	return &ruleOutcomeTrackingDocumentRepo{DocumentRepository: repo, outcomes: outcomes}
+}
+
+// ruleResult is the part of a stored validation result the metrics need.
+type ruleResult struct {
+	RuleID    uuid.UUID `json:"rule_id"`
+	Passed    bool      `json:"passed"`
+	FieldPath string    `json:"field_path"`
+}
+
+func (r *ruleOutcomeTrackingDocumentRepo) UpdateValidationResults(ctx context.Context, doc *domain.Document) error {
+	if err := r.DocumentRepository.UpdateValidationResults(ctx, doc); err != nil {
+		return err
+	}
+
+	var results []ruleResult
+	if err := json.Unmarshal(doc.ValidationResults, &results); err != nil {
+		return nil
+	}
+	// A rule reports one result per failing field (e.g. per line item); it fails
+	// on the document if any of them fails
+	byRule := make(map[uuid.UUID]int)
+	outcomes := make([]domain.ValidationRuleOutcome, 0, len(results))
+	for _, res := range results {
+		if i, ok := byRule[res.RuleID]; ok {
+			if !res.Passed && !outcomes[i].Failing {
+				outcomes[i].Failing = true
+				outcomes[i].FieldPath = res.FieldPath
+			}
+			continue
+		}
+		byRule[res.RuleID] = len(outcomes)
+		outcomes = append(outcomes, domain.ValidationRuleOutcome{
+			TenantID:     doc.TenantID,
+			DocumentID:   doc.ID,
+			RuleID:       res.RuleID,
+			DocumentType: doc.DocumentType,
+			FieldPath:    res.FieldPath,
+			Failing:      !res.Passed,
+		})
+	}
+	if err := r.outcomes.Record(ctx, outcomes); err != nil {
+		log.Printf("ruleOutcomeTrackingDocumentRepo: failed to record rule outcomes for %s: %v", doc.ID, err)
+	}
+	return nil
+}
+
+func (r *ruleOutcomeTrackingDocumentRepo) UpdateReviewStatus(ctx context.Context, doc *domain.Document) error {
+	if err := r.DocumentRepository.UpdateReviewStatus(ctx, doc); err != nil {
+		return err
+	}
+	if doc.ReviewStatus == domain.ReviewStatusApproved {
+		if err := r.outcomes.MarkOverridden(ctx, doc.TenantID, doc.ID); err != nil {
+			log.Printf("ruleOutcomeTrackingDocumentRepo: failed to mark overridden rules on %s: %v", doc.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/validator"
+)
+
+const (
+	// maxSimulationDocuments caps how many of the most recent documents a
+	// simulation reads, so it stays fast enough to run inside the request.
+	maxSimulationDocuments = 1000
+	// simulationPageSize is how many documents are read per repository call.
+	simulationPageSize = 100
+	// simulationSampleLimit is how many failing documents a simulation returns.
+	simulationSampleLimit = 10
+)
+
+// RuleSimulationInput is the DTO for a validation rule simulation.
+type RuleSimulationInput struct {
+	TenantID     uuid.UUID
+	UserID       uuid.UUID
+	Role         domain.UserRole
+	DocumentType string
+	// CollectionID, when set, limits the simulation to one collection.
+	CollectionID *uuid.UUID
+	Rule         validator.ProposedRule
+}
+
+// RuleSimulationResult is a simulation outcome plus how much of the tenant it covered.
+type RuleSimulationResult struct {
+	validator.SimulationResult
+	// Truncated is true when more documents matched than were evaluated; only
+	// the most recent ones are read.
+	Truncated bool `json:"truncated"`
+}
+
+// RuleSimulationService evaluates a proposed validation rule against existing
+// documents without storing anything.
+type RuleSimulationService interface {
+	Simulate(ctx context.Context, input *RuleSimulationInput) (*RuleSimulationResult, error)
+}
+
+type ruleSimulationService struct {
+	docRepo       port.DocumentRepository
+	validator     *validator.Engine
+	collectionSvc CollectionService
+}
+
+// NewRuleSimulationService creates a new RuleSimulationService.
+func NewRuleSimulationService(docRepo port.DocumentRepository, validationEngine *validator.Engine, collectionSvc CollectionService) RuleSimulationService {
+	return &ruleSimulationService{docRepo: docRepo, validator: validationEngine, collectionSvc: collectionSvc}
+}
+
+func (s *ruleSimulationService) Simulate(ctx context.Context, input *RuleSimulationInput) (*RuleSimulationResult, error) {
+	if s.validator == nil {
+		return nil, fmt.Errorf("validation engine not configured")
+	}
+	if input.CollectionID != nil {
+		if _, err := s.collectionSvc.GetByID(ctx, input.TenantID, *input.CollectionID, input.UserID, input.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	var docs []domain.Document
+	truncated := false
+	for offset := 0; ; offset += simulationPageSize {
+		if offset >= maxSimulationDocuments {
+			truncated = true
+			break
+		}
+		page, total, err := s.listPage(ctx, input, offset)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page {
+			if page[i].ParsingStatus == domain.ParsingStatusCompleted && page[i].DocumentType == input.DocumentType {
+				docs = append(docs, page[i])
+			}
+		}
+		if len(page) < simulationPageSize || offset+len(page) >= total {
+			break
+		}
+	}
+
+	sim, err := s.validator.Simulate(ctx, input.TenantID, input.DocumentType, &input.Rule, docs, simulationSampleLimit)
+	if err != nil {
+		return nil, err
+	}
+	return &RuleSimulationResult{SimulationResult: *sim, Truncated: truncated}, nil
+}
+
+// listPage reads one page of documents, newest first.
+func (s *ruleSimulationService) listPage(ctx context.Context, input *RuleSimulationInput, offset int) ([]domain.Document, int, error) {
+	if input.CollectionID != nil {
+		return s.docRepo.ListByCollection(ctx, input.TenantID, *input.CollectionID, nil, offset, simulationPageSize)
+	}
+	return s.docRepo.ListByTenant(ctx, input.TenantID, nil, offset, simulationPageSize)
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"satvos/internal/validator"
+)
+
+// SchemaService describes the structured data of each document type.
+type SchemaService interface {
+	Get(documentType string) (*validator.DocumentSchema, error)
+}
+
+type schemaService struct {
+	registry *validator.Registry
+}
+
+// NewSchemaService creates a new SchemaService. The registry must be fully
+// populated, since reconciliation-critical fields are read from its validators.
+func NewSchemaService(registry *validator.Registry) SchemaService {
+	return &schemaService{registry: registry}
+}
+
+func (s *schemaService) Get(documentType string) (*validator.DocumentSchema, error) {
+	return validator.BuildSchema(documentType, s.registry)
+}
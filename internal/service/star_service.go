@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// StarService manages per-user stars on documents and collections. Stars are
+// private to the user, unlike tags; starring needs viewer access.
+type StarService interface {
+	StarDocument(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole) error
+	UnstarDocument(ctx context.Context, tenantID, documentID, userID uuid.UUID) error
+	StarCollection(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) error
+	UnstarCollection(ctx context.Context, tenantID, collectionID, userID uuid.UUID) error
+	ListDocuments(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Document, int, error)
+	ListCollections(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Collection, int, error)
+}
+
+type starService struct {
+	starRepo       port.StarRepository
+	docRepo        port.DocumentRepository
+	collectionRepo port.CollectionRepository
+	collectionSvc  CollectionService
+}
+
+// NewStarService creates a new StarService implementation.
+func NewStarService(
+	starRepo port.StarRepository,
+	docRepo port.DocumentRepository,
+	collectionRepo port.CollectionRepository,
+	collectionSvc CollectionService,
+) StarService {
+	return &starService{starRepo: starRepo, docRepo: docRepo, collectionRepo: collectionRepo, collectionSvc: collectionSvc}
+}
+
+func (s *starService) requireViewer(ctx context.Context, collectionID, userID uuid.UUID, role domain.UserRole) error {
+	eff := s.collectionSvc.EffectivePermission(ctx, collectionID, userID, role)
+	if domain.CollectionPermLevel(eff) < domain.CollectionPermLevel(domain.CollectionPermViewer) {
+		return domain.ErrCollectionPermDenied
+	}
+	return nil
+}
+
+func (s *starService) StarDocument(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole) error {
+	doc, err := s.docRepo.GetByID(ctx, tenantID, documentID)
+	if err != nil {
+		return err
+	}
+	if err := s.requireViewer(ctx, doc.CollectionID, userID, role); err != nil {
+		return err
+	}
+	return s.starRepo.StarDocument(ctx, tenantID, userID, documentID)
+}
+
+func (s *starService) UnstarDocument(ctx context.Context, tenantID, documentID, userID uuid.UUID) error {
+	return s.starRepo.UnstarDocument(ctx, tenantID, userID, documentID)
+}
+
+func (s *starService) StarCollection(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) error {
+	if _, err := s.collectionRepo.GetByID(ctx, tenantID, collectionID); err != nil {
+		return err
+	}
+	if err := s.requireViewer(ctx, collectionID, userID, role); err != nil {
+		return err
+	}
+	return s.starRepo.StarCollection(ctx, tenantID, userID, collectionID)
+}
+
+func (s *starService) UnstarCollection(ctx context.Context, tenantID, collectionID, userID uuid.UUID) error {
+	return s.starRepo.UnstarCollection(ctx, tenantID, userID, collectionID)
+}
+
+// ListDocuments lists the user's starred documents they can still view. Roles
+// without implicit collection access only see stars in explicitly shared collections.
+func (s *starService) ListDocuments(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Document, int, error) {
+	explicitOnly := domain.ImplicitCollectionPerm(role) == ""
+	return s.starRepo.ListStarredDocuments(ctx, tenantID, userID, explicitOnly, offset, limit)
+}
+
+// ListCollections lists the user's starred collections they can still view.
+func (s *starService) ListCollections(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Collection, int, error) {
+	explicitOnly := domain.ImplicitCollectionPerm(role) == ""
+	return s.starRepo.ListStarredCollections(ctx, tenantID, userID, explicitOnly, offset, limit)
+}
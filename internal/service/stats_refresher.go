@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// StatsRefreshConfig holds the cadence of StatsRefresher.
+type StatsRefreshConfig struct {
+	Interval         time.Duration // how often dirty buckets are recounted
+	ReconcileHourUTC int           // hour after which the nightly full rebuild runs
+}
+
+// statsBucket identifies one document_daily_stats row.
+type statsBucket struct {
+	tenantID     uuid.UUID
+	collectionID uuid.UUID
+	day          time.Time // midnight UTC
+}
+
+// StatsRefresher keeps the materialized dashboard counters current. Document writes
+// mark their (tenant, collection, creation day) bucket dirty; each tick recounts the
+// dirty buckets, and once a day every tenant is rebuilt to repair anything missed.
+type StatsRefresher struct {
+	statsRepo  port.StatsRepository
+	tenantRepo port.TenantRepository
+	cfg        StatsRefreshConfig
+
+	mu             sync.Mutex
+	dirty          map[statsBucket]struct{}
+	lastReconciled time.Time
+}
+
+// NewStatsRefresher creates a new StatsRefresher. The first nightly rebuild runs on
+// the day after start; the migration that created the table seeds it.
+func NewStatsRefresher(statsRepo port.StatsRepository, tenantRepo port.TenantRepository, cfg StatsRefreshConfig) *StatsRefresher {
+	return &StatsRefresher{
+		statsRepo:      statsRepo,
+		tenantRepo:     tenantRepo,
+		cfg:            cfg,
+		dirty:          make(map[statsBucket]struct{}),
+		lastReconciled: utcDay(time.Now()),
+	}
+}
+
+// MarkDirty queues the bucket holding doc for the next refresh. It never touches the database.
+func (r *StatsRefresher) MarkDirty(doc *domain.Document) {
+	b := statsBucket{tenantID: doc.TenantID, collectionID: doc.CollectionID, day: utcDay(doc.CreatedAt)}
+	r.mu.Lock()
+	r.dirty[b] = struct{}{}
+	r.mu.Unlock()
+}
+
+// Flush recounts every dirty bucket. Buckets that fail stay dirty for the next tick.
+func (r *StatsRefresher) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.dirty
+	r.dirty = make(map[statsBucket]struct{})
+	r.mu.Unlock()
+
+	var errs []error
+	for b := range pending {
+		if err := r.statsRepo.RefreshDay(ctx, b.tenantID, b.collectionID, b.day); err != nil {
+			errs = append(errs, err)
+			r.mu.Lock()
+			r.dirty[b] = struct{}{}
+			r.mu.Unlock()
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("refreshing %d of %d stats buckets: %w", len(errs), len(pending), errors.Join(errs...))
+	}
+	return nil
+}
+
+// ReconcileAll rebuilds the counters of every tenant from the documents table and
+// records the day's collection quality snapshots.
+func (r *StatsRefresher) ReconcileAll(ctx context.Context) error {
+	today := utcDay(time.Now())
+	const pageSize = 100
+	reconciled, drifted := 0, 0
+	for offset := 0; ; offset += pageSize {
+		tenants, total, err := r.tenantRepo.List(ctx, offset, pageSize)
+		if err != nil {
+			return fmt.Errorf("listing tenants: %w", err)
+		}
+		for i := range tenants {
+			discrepancies, err := r.statsRepo.ReconcileTenant(ctx, tenants[i].ID)
+			if err != nil {
+				return fmt.Errorf("reconciling tenant %s: %w", tenants[i].ID, err)
+			}
+			for _, d := range discrepancies {
+				log.Printf("statsRefresher: tenant %s collection %s had %d documents counted, actual %d",
+					tenants[i].ID, d.CollectionID, d.Materialized, d.Actual)
+			}
+			// A missed snapshot only leaves a gap in the quality history
+			if err := r.statsRepo.SnapshotQuality(ctx, tenants[i].ID, today); err != nil {
+				log.Printf("statsRefresher: quality snapshot for tenant %s failed: %v", tenants[i].ID, err)
+			}
+			reconciled++
+			drifted += len(discrepancies)
+		}
+		if len(tenants) == 0 || offset+pageSize >= total {
+			break
+		}
+	}
+	log.Printf("statsRefresher: reconciled stats for %d tenants (%d drifted collections repaired)", reconciled, drifted)
+	return nil
+}
+
+// reconcileDue reports whether the nightly rebuild should run at now.
+func (r *StatsRefresher) reconcileDue(now time.Time) bool {
+	now = now.UTC()
+	return now.Hour() >= r.cfg.ReconcileHourUTC && utcDay(now).After(r.lastReconciled)
+}
+
+// Tick flushes dirty buckets and runs the nightly rebuild when it is due.
+func (r *StatsRefresher) Tick(ctx context.Context, now time.Time) error {
+	if err := r.Flush(ctx); err != nil {
+		return err
+	}
+	if !r.reconcileDue(now) {
+		return nil
+	}
+	if err := r.ReconcileAll(ctx); err != nil {
+		return err
+	}
+	r.lastReconciled = utcDay(now)
+	return nil
+}
+
+// Start runs the refresh loop until ctx is canceled.
+func (r *StatsRefresher) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	log.Printf("statsRefresher: started (interval=%s, reconcile after %02d:00 UTC)", r.cfg.Interval, r.cfg.ReconcileHourUTC)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("statsRefresher: shutdown complete")
+			return
+		case <-ticker.C:
+			if err := r.Tick(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				log.Printf("statsRefresher: Tick error: %v", err)
+			}
+		}
+	}
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// MarkDocumentDirty is a document event listener that marks the bucket of the
+// event's document. It covers the parse, edit and review changes the document
+// service makes.
+func (r *StatsRefresher) MarkDocumentDirty(_ context.Context, e *DocumentEvent) {
+	r.MarkDirty(e.Document)
+}
+
+// statsTrackingDocumentRepo wraps a DocumentRepository and marks the stats bucket
+// of the documents written outside the document service's events: queue claims,
+// validation runs and (bulk) deletes.
+type statsTrackingDocumentRepo struct {
+	port.DocumentRepository
+	refresher *StatsRefresher
+}
+
+// NewStatsTrackingDocumentRepo wraps repo so its writes feed refresher.
+func NewStatsTrackingDocumentRepo(repo port.DocumentRepository, refresher *StatsRefresher) port.DocumentRepository {
+	return &statsTrackingDocumentRepo{DocumentRepository: repo, refresher: refresher}
+}
+
+func (r *statsTrackingDocumentRepo) UpdateValidationResults(ctx context.Context, doc *domain.Document) error {
+	if err := r.DocumentRepository.UpdateValidationResults(ctx, doc); err != nil {
+		return err
+	}
+	r.refresher.MarkDirty(doc)
+	return nil
+}
+
+func (r *statsTrackingDocumentRepo) ClaimQueued(ctx context.Context, limit int) ([]domain.Document, error) {
+	docs, err := r.DocumentRepository.ClaimQueued(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range docs {
+		r.refresher.MarkDirty(&docs[i])
+	}
+	return docs, nil
+}
+
+func (r *statsTrackingDocumentRepo) Delete(ctx context.Context, tenantID, docID uuid.UUID) error {
+	// Load first: after the delete the bucket can no longer be derived
+	doc, getErr := r.DocumentRepository.GetByID(ctx, tenantID, docID)
+	if err := r.DocumentRepository.Delete(ctx, tenantID, docID); err != nil {
+		return err
+	}
+	if getErr == nil {
+		r.refresher.MarkDirty(doc)
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"math"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,15 +15,38 @@ import (
 // StatsService provides aggregate statistics.
 type StatsService interface {
 	GetStats(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole) (*domain.Stats, error)
+	// GetParseLatency returns per-parser-model queue and parse percentiles for the
+	// tenant's parse attempts within the trailing window.
+	GetParseLatency(ctx context.Context, tenantID uuid.UUID, window time.Duration) ([]domain.ParseLatencyStats, error)
+	// GetConfidenceCalibration returns a reliability curve per parser model: how often
+	// reviewers left fields unchanged at each tenth of predicted confidence.
+	GetConfidenceCalibration(ctx context.Context, tenantID uuid.UUID, documentType string, window time.Duration) ([]domain.ConfidenceCalibration, error)
+	// GetRuleNoise ranks the validation rules that failed on the tenant's documents
+	// first validated within the window in sortBy order (noise score by default),
+	// and returns at most limit of them; limit <= 0 returns every rule.
+	GetRuleNoise(ctx context.Context, tenantID uuid.UUID, documentType string, window time.Duration, sortBy RuleNoiseSort, limit int) ([]domain.RuleNoise, error)
+	// Recount rebuilds the tenant's materialized stats from the documents table and
+	// reports the collections whose document count had drifted.
+	Recount(ctx context.Context, tenantID uuid.UUID) (*domain.StatsRecount, error)
+	// GetCollectionQuality returns the live data quality score of each of the
+	// tenant's collections in filter. Filtering on a collection the tenant does not
+	// have returns ErrCollectionNotFound.
+	GetCollectionQuality(ctx context.Context, tenantID uuid.UUID, filter domain.CollectionQualityFilter) ([]domain.CollectionQuality, error)
+	// GetCollectionQualityHistory returns a collection's daily quality scores
+	// within the trailing window, ending with today's live score.
+	GetCollectionQualityHistory(ctx context.Context, tenantID, collectionID uuid.UUID, window time.Duration) ([]domain.CollectionQuality, error)
 }
 
 type statsService struct {
-	statsRepo port.StatsRepository
+	statsRepo       port.StatsRepository
+	timingRepo      port.ParseTimingRepository
+	observationRepo port.ConfidenceObservationRepository
+	outcomeRepo     port.ValidationRuleOutcomeRepository
 }
 
 // NewStatsService creates a new StatsService implementation.
-func NewStatsService(statsRepo port.StatsRepository) StatsService {
-	return &statsService{statsRepo: statsRepo}
+func NewStatsService(statsRepo port.StatsRepository, timingRepo port.ParseTimingRepository, observationRepo port.ConfidenceObservationRepository, outcomeRepo port.ValidationRuleOutcomeRepository) StatsService {
+	return &statsService{statsRepo: statsRepo, timingRepo: timingRepo, observationRepo: observationRepo, outcomeRepo: outcomeRepo}
 }
 
 func (s *statsService) GetStats(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole) (*domain.Stats, error) {
@@ -29,3 +55,220 @@ func (s *statsService) GetStats(ctx context.Context, tenantID, userID uuid.UUID,
 	}
 	return s.statsRepo.GetUserStats(ctx, tenantID, userID)
 }
+
+func (s *statsService) GetParseLatency(ctx context.Context, tenantID uuid.UUID, window time.Duration) ([]domain.ParseLatencyStats, error) {
+	return s.timingRepo.LatencyByModel(ctx, &tenantID, time.Now().UTC().Add(-window))
+}
+
+// calibrationBuckets is the number of equal-width confidence buckets per model.
+const calibrationBuckets = 10
+
+func (s *statsService) GetConfidenceCalibration(ctx context.Context, tenantID uuid.UUID, documentType string, window time.Duration) ([]domain.ConfidenceCalibration, error) {
+	rows, err := s.observationRepo.BucketsByModel(ctx, tenantID, documentType, time.Now().UTC().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows arrive ordered by model, so each model's buckets are contiguous
+	report := []domain.ConfidenceCalibration{}
+	for i := range rows {
+		row := &rows[i]
+		if len(report) == 0 || report[len(report)-1].ParserModel != row.ParserModel {
+			report = append(report, newCalibration(row.ParserModel))
+		}
+		cal := &report[len(report)-1]
+		if row.Bucket < 0 || row.Bucket >= calibrationBuckets {
+			continue
+		}
+		b := &cal.Buckets[row.Bucket]
+		b.Fields = row.Fields
+		b.Corrected = row.Corrected
+		b.MeanConfidence = row.MeanConfidence
+		b.Accuracy = accuracy(row.Fields, row.Corrected)
+		cal.Fields += row.Fields
+		cal.Corrected += row.Corrected
+	}
+	for i := range report {
+		if acc := accuracy(report[i].Fields, report[i].Corrected); acc != nil {
+			report[i].Accuracy = *acc
+		}
+	}
+	return report, nil
+}
+
+// newCalibration returns a report with all buckets present, so every model's
+// curve has the same x-axis even where it has no observations.
+func newCalibration(model string) domain.ConfidenceCalibration {
+	buckets := make([]domain.CalibrationBucket, calibrationBuckets)
+	for i := range buckets {
+		buckets[i].MinConfidence = float64(i) / calibrationBuckets
+		buckets[i].MaxConfidence = float64(i+1) / calibrationBuckets
+	}
+	return domain.ConfidenceCalibration{ParserModel: model, Buckets: buckets}
+}
+
+// accuracy is the share of uncorrected fields, or nil when there are none.
+func accuracy(fields, corrected int) *float64 {
+	if fields == 0 {
+		return nil
+	}
+	acc := float64(fields-corrected) / float64(fields)
+	return &acc
+}
+
+// RuleNoiseSort orders the noisy rules report.
+type RuleNoiseSort string
+
+// Noisy rules report orders.
+const (
+	// RuleNoiseByScore ranks rules reviewers most often approve despite (noise score).
+	RuleNoiseByScore RuleNoiseSort = "noise"
+	// RuleNoiseByFailures ranks rules by failure rate.
+	RuleNoiseByFailures RuleNoiseSort = "failures"
+	// RuleNoiseByCorrections ranks rules by how often their failures were corrected,
+	// pointing at parser fields that need prompt work.
+	RuleNoiseByCorrections RuleNoiseSort = "corrections"
+)
+
+func (s *statsService) GetRuleNoise(ctx context.Context, tenantID uuid.UUID, documentType string, window time.Duration, sortBy RuleNoiseSort, limit int) ([]domain.RuleNoise, error) {
+	var key func(r *domain.RuleNoise) float64
+	switch sortBy {
+	case RuleNoiseByFailures:
+		key = func(r *domain.RuleNoise) float64 { return r.FailureRate }
+	case RuleNoiseByCorrections:
+		key = func(r *domain.RuleNoise) float64 { return r.CorrectionRate }
+	default:
+		key = func(r *domain.RuleNoise) float64 { return r.NoiseScore }
+	}
+
+	rows, err := s.outcomeRepo.StatsByRule(ctx, tenantID, documentType, time.Now().UTC().Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	report := []domain.RuleNoise{}
+	for i := range rows {
+		row := rows[i]
+		if row.Failures == 0 || row.Evaluations == 0 {
+			continue
+		}
+		n := domain.RuleNoise{
+			RuleFailureStats: row,
+			FailureRate:      float64(row.Failures) / float64(row.Evaluations),
+			OverrideRate:     float64(row.Overrides) / float64(row.Failures),
+			CorrectionRate:   float64(row.Corrections) / float64(row.Failures),
+		}
+		n.NoiseScore = n.FailureRate * n.OverrideRate
+		report = append(report, n)
+	}
+	// Ties go to the rule with more failures: more evidence
+	sort.SliceStable(report, func(i, j int) bool {
+		if ki, kj := key(&report[i]), key(&report[j]); ki != kj {
+			return ki > kj
+		}
+		return report[i].Failures > report[j].Failures
+	})
+	if limit > 0 && len(report) > limit {
+		report = report[:limit]
+	}
+	return report, nil
+}
+
+func (s *statsService) Recount(ctx context.Context, tenantID uuid.UUID) (*domain.StatsRecount, error) {
+	discrepancies, err := s.statsRepo.ReconcileTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.StatsRecount{
+		TenantID:      tenantID,
+		Discrepancies: discrepancies,
+		RecountedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// Data quality score weights; they add up to 1. Validation weighs most because
+// failed rules are what a return gets rejected for.
+const (
+	qualityConfidenceWeight = 0.3
+	qualityValidationWeight = 0.4
+	qualityReviewWeight     = 0.3
+	// qualityWarningCredit is how much a document validated with warnings counts
+	// toward the validation pass rate.
+	qualityWarningCredit = 0.5
+)
+
+// collectionQuality scores counts. Every component is a share of all documents,
+// so unparsed, unvalidated and unreviewed documents pull the score down.
+func collectionQuality(counts *domain.CollectionQualityCounts) domain.CollectionQuality {
+	q := domain.CollectionQuality{
+		CollectionID:    counts.CollectionID,
+		CollectionName:  counts.CollectionName,
+		TotalDocuments:  counts.TotalDocuments,
+		ParsedDocuments: counts.ParsedDocuments,
+	}
+	if !counts.Day.IsZero() {
+		day := counts.Day
+		q.Day = &day
+	}
+	if counts.TotalDocuments == 0 {
+		return q
+	}
+	total := float64(counts.TotalDocuments)
+	confidence := counts.ConfidenceSum / total
+	validation := (float64(counts.ValidationValid) + qualityWarningCredit*float64(counts.ValidationWarning)) / total
+	review := float64(counts.ReviewApproved) / total
+	q.Confidence = roundTo(confidence, 4)
+	q.ValidationPassRate = roundTo(validation, 4)
+	q.ReviewCoverage = roundTo(review, 4)
+	score := roundTo(100*(qualityConfidenceWeight*confidence+qualityValidationWeight*validation+qualityReviewWeight*review), 1)
+	q.Score = &score
+	return q
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
+
+func (s *statsService) GetCollectionQuality(ctx context.Context, tenantID uuid.UUID, filter domain.CollectionQualityFilter) ([]domain.CollectionQuality, error) {
+	counts, err := s.statsRepo.CollectionQuality(ctx, tenantID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.CollectionID != nil && len(counts) == 0 {
+		return nil, domain.ErrCollectionNotFound
+	}
+	report := make([]domain.CollectionQuality, 0, len(counts))
+	for i := range counts {
+		report = append(report, collectionQuality(&counts[i]))
+	}
+	return report, nil
+}
+
+func (s *statsService) GetCollectionQualityHistory(ctx context.Context, tenantID, collectionID uuid.UUID, window time.Duration) ([]domain.CollectionQuality, error) {
+	live, err := s.statsRepo.CollectionQuality(ctx, tenantID, domain.CollectionQualityFilter{CollectionID: &collectionID})
+	if err != nil {
+		return nil, err
+	}
+	if len(live) == 0 {
+		return nil, domain.ErrCollectionNotFound
+	}
+	now := time.Now().UTC()
+	snapshots, err := s.statsRepo.QualityHistory(ctx, tenantID, collectionID, utcDay(now.Add(-window)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Today's snapshot, if the nightly run already took one, is superseded by the live score
+	today := utcDay(now)
+	history := make([]domain.CollectionQuality, 0, len(snapshots)+1)
+	for i := range snapshots {
+		if !snapshots[i].Day.Before(today) {
+			continue
+		}
+		snapshots[i].CollectionName = live[0].CollectionName
+		history = append(history, collectionQuality(&snapshots[i]))
+	}
+	live[0].Day = today
+	return append(history, collectionQuality(&live[0])), nil
+}
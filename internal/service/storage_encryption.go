@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// tenantEncryptingStorage wraps an ObjectStorage and writes every object under a
+// tenant's tenants/{tenant}/ prefix with the tenant's server-side encryption.
+// Every upload path (files, imports, drops, archives, relocation) builds its key
+// under that prefix, so none can skip the tenant's setting. Writes that already
+// name an encryption, and keys outside any tenant prefix, pass through.
+type tenantEncryptingStorage struct {
+	port.ObjectStorage
+	tenantRepo port.TenantRepository
+}
+
+// NewTenantEncryptingStorage wraps storage so writes of tenant objects use the
+// tenant's storage_sse and storage_kms_key_arn.
+func NewTenantEncryptingStorage(storage port.ObjectStorage, tenantRepo port.TenantRepository) port.ObjectStorage {
+	return &tenantEncryptingStorage{ObjectStorage: storage, tenantRepo: tenantRepo}
+}
+
+func (s *tenantEncryptingStorage) Upload(ctx context.Context, input port.UploadInput) (*port.UploadOutput, error) {
+	if input.Encryption == nil {
+		enc, err := s.encryptionFor(ctx, input.Key)
+		if err != nil {
+			return nil, err
+		}
+		input.Encryption = enc
+	}
+	return s.ObjectStorage.Upload(ctx, input)
+}
+
+func (s *tenantEncryptingStorage) Copy(ctx context.Context, bucket, srcKey, dstKey string, enc *port.Encryption) error {
+	if enc == nil {
+		var err error
+		if enc, err = s.encryptionFor(ctx, dstKey); err != nil {
+			return err
+		}
+	}
+	return s.ObjectStorage.Copy(ctx, bucket, srcKey, dstKey, enc)
+}
+
+// encryptionFor returns the encryption of the tenant owning key, or nil for the
+// bucket default. A failed lookup fails the write rather than storing the object
+// less protected than the tenant asked for.
+func (s *tenantEncryptingStorage) encryptionFor(ctx context.Context, key string) (*port.Encryption, error) {
+	tenantID, ok := keyTenant(key)
+	if !ok {
+		return nil, nil
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up storage encryption for tenant %s: %w", tenantID, err)
+	}
+	if tenant.StorageSSE == nil {
+		return nil, nil
+	}
+	enc := &port.Encryption{Algorithm: *tenant.StorageSSE}
+	if tenant.StorageKMSKeyARN != nil {
+		enc.KMSKeyARN = *tenant.StorageKMSKeyARN
+	}
+	return enc, nil
+}
+
+// keyTenant parses the tenant out of a tenants/{tenant}/... object key.
+func keyTenant(key string) (uuid.UUID, bool) {
+	rest, ok := strings.CutPrefix(key, "tenants/")
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, _, ok := strings.Cut(rest, "/")
+	if !ok {
+		return uuid.Nil, false
+	}
+	tenantID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const relocationBatchSize = 100
+
+// fileObjectKey is where a file's object lives. Files uploaded into a collection
+// go under tenants/{tenant}/collections/{collection}/; files without one keep the
+// flat tenants/{tenant}/files/ layout until the relocation job files them.
+func fileObjectKey(tenantID uuid.UUID, collectionID *uuid.UUID, fileID uuid.UUID, filename string) string {
+	if collectionID == nil {
+		return fmt.Sprintf("tenants/%s/files/%s/%s", tenantID, fileID, filename)
+	}
+	return fmt.Sprintf("tenants/%s/collections/%s/files/%s/%s", tenantID, *collectionID, fileID, filename)
+}
+
+// tenantLifecycleRuleID names the bucket lifecycle rule that belongs to a tenant.
+func tenantLifecycleRuleID(tenantID uuid.UUID) string {
+	return "satvos-tenant-" + tenantID.String() + "-ia"
+}
+
+// StorageLayoutService manages how a tenant's objects are laid out and aged in S3.
+type StorageLayoutService interface {
+	// ApplyLifecycle writes the tenant's STANDARD_IA transition rule to the bucket
+	// of its storage region, or removes the rule when the transition is disabled.
+	ApplyLifecycle(ctx context.Context, tenant *domain.Tenant) error
+	// RelocateTenant moves every collection file still stored outside its
+	// collection prefix, updating file_metadata as each object moves.
+	RelocateTenant(ctx context.Context, tenantID uuid.UUID) (*domain.StorageRelocation, error)
+}
+
+type storageLayoutService struct {
+	fileRepo port.FileMetaRepository
+	storage  port.ObjectStorage
+	cfg      *config.S3Config
+}
+
+// NewStorageLayoutService creates a new StorageLayoutService.
+func NewStorageLayoutService(fileRepo port.FileMetaRepository, storage port.ObjectStorage, cfg *config.S3Config) StorageLayoutService {
+	return &storageLayoutService{fileRepo: fileRepo, storage: storage, cfg: cfg}
+}
+
+func (s *storageLayoutService) ApplyLifecycle(ctx context.Context, tenant *domain.Tenant) error {
+	region := ""
+	if tenant.StorageRegion != nil {
+		region = *tenant.StorageRegion
+	}
+	bucket, ok := s.cfg.BucketForRegion(region)
+	if !ok {
+		return fmt.Errorf("%w: no bucket configured for region %s", domain.ErrDataResidencyViolation, region)
+	}
+
+	ruleID := tenantLifecycleRuleID(tenant.ID)
+	if tenant.StorageIAAfterDays == nil {
+		if err := s.storage.DeleteLifecycleRule(ctx, bucket, ruleID); err != nil {
+			return fmt.Errorf("removing lifecycle rule: %w", err)
+		}
+		return nil
+	}
+	err := s.storage.PutLifecycleRule(ctx, bucket, port.LifecycleRule{
+		ID:             ruleID,
+		Prefix:         fmt.Sprintf("tenants/%s/", tenant.ID),
+		TransitionDays: *tenant.StorageIAAfterDays,
+	})
+	if err != nil {
+		return fmt.Errorf("writing lifecycle rule: %w", err)
+	}
+	return nil
+}
+
+func (s *storageLayoutService) RelocateTenant(ctx context.Context, tenantID uuid.UUID) (*domain.StorageRelocation, error) {
+	result := &domain.StorageRelocation{TenantID: tenantID, Orphans: []string{}}
+
+	// Failed files stay behind the cursor, so a run visits each file at most once.
+	after := uuid.Nil
+	for {
+		batch, err := s.fileRepo.ListRelocations(ctx, tenantID, after, relocationBatchSize)
+		if err != nil {
+			return result, err
+		}
+		for i := range batch {
+			f := &batch[i]
+			after = f.FileID
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			orphaned, err := s.relocate(ctx, tenantID, f)
+			if err != nil {
+				log.Printf("storageLayoutService.RelocateTenant: file %s: %v", f.FileID, err)
+				result.Failed++
+				continue
+			}
+			result.Relocated++
+			if orphaned {
+				result.Orphans = append(result.Orphans, f.S3Key)
+			}
+		}
+		if len(batch) < relocationBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// relocate copies one object to its collection key, repoints the file at the
+// copy and only then deletes the original, so the row never names a missing
+// object. It reports whether the original had to be left behind.
+func (s *storageLayoutService) relocate(ctx context.Context, tenantID uuid.UUID, f *domain.FileRelocation) (bool, error) {
+	fileID := f.FileID
+	newKey := fileObjectKey(tenantID, &f.CollectionID, fileID, path.Base(f.S3Key))
+
+	if err := s.storage.Copy(ctx, f.S3Bucket, f.S3Key, newKey, nil); err != nil {
+		return false, fmt.Errorf("copying object: %w", err)
+	}
+	if err := s.fileRepo.UpdateS3Key(ctx, tenantID, fileID, f.S3Key, newKey); err != nil {
+		// The row changed under us or the update failed: drop the copy, keep the original.
+		if delErr := s.storage.Delete(ctx, f.S3Bucket, newKey); delErr != nil {
+			err = errors.Join(err, fmt.Errorf("removing copy %s: %w", newKey, delErr))
+		}
+		return false, fmt.Errorf("updating file key: %w", err)
+	}
+	if err := s.storage.Delete(ctx, f.S3Bucket, f.S3Key); err != nil {
+		log.Printf("storageLayoutService.relocate: file %s moved but old object %s was not deleted: %v",
+			fileID, f.S3Key, err)
+		return true, nil
+	}
+	return false, nil
+}
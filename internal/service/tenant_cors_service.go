@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// maxTenantCORSOrigins caps how many frontend origins one tenant may allow.
+const maxTenantCORSOrigins = 20
+
+// TenantCORSService manages the frontend origins CORS allows for a tenant, on
+// top of the server-wide allowed origins.
+type TenantCORSService interface {
+	Get(ctx context.Context, tenantID uuid.UUID) ([]string, error)
+	// Set replaces the tenant's origins and returns them normalized and sorted.
+	Set(ctx context.Context, tenantID uuid.UUID, origins []string) ([]string, error)
+}
+
+type tenantCORSService struct {
+	tenantRepo port.TenantRepository
+	originRepo port.TenantCORSOriginRepository
+}
+
+// NewTenantCORSService creates a new TenantCORSService.
+func NewTenantCORSService(tenantRepo port.TenantRepository, originRepo port.TenantCORSOriginRepository) TenantCORSService {
+	return &tenantCORSService{tenantRepo: tenantRepo, originRepo: originRepo}
+}
+
+func (s *tenantCORSService) Get(ctx context.Context, tenantID uuid.UUID) ([]string, error) {
+	if _, err := s.tenantRepo.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	return s.originRepo.ListByTenant(ctx, tenantID)
+}
+
+func (s *tenantCORSService) Set(ctx context.Context, tenantID uuid.UUID, origins []string) ([]string, error) {
+	normalized, err := normalizeCORSOrigins(origins)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.tenantRepo.GetByID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	if err := s.originRepo.Replace(ctx, tenantID, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// normalizeCORSOrigins validates origins and returns them deduplicated and
+// sorted in the form browsers send in the Origin header: a lowercase
+// scheme://host[:port] without a trailing slash.
+func normalizeCORSOrigins(origins []string) ([]string, error) {
+	seen := make(map[string]bool, len(origins))
+	normalized := make([]string, 0, len(origins))
+	for _, raw := range origins {
+		u, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+			return nil, fmt.Errorf("%w: %q is not an http(s) origin", domain.ErrInvalidCORSOrigins, raw)
+		}
+		origin := strings.ToLower(u.Scheme + "://" + u.Host)
+		if !seen[origin] {
+			seen[origin] = true
+			normalized = append(normalized, origin)
+		}
+	}
+	if len(normalized) > maxTenantCORSOrigins {
+		return nil, fmt.Errorf("%w: at most %d origins", domain.ErrInvalidCORSOrigins, maxTenantCORSOrigins)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
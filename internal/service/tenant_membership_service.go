@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// GrantMembershipInput is the DTO for giving a user of another tenant access to
+// the caller's tenant. The user is identified by their home tenant and email.
+type GrantMembershipInput struct {
+	Email      string          `json:"email" binding:"required,email"`
+	TenantSlug string          `json:"tenant_slug" binding:"required"`
+	Role       domain.UserRole `json:"role" binding:"required"`
+	// IsActive defaults to true; false suspends the membership without removing it.
+	IsActive *bool `json:"is_active"`
+}
+
+// TenantMembershipService manages users' access to tenants other than their home
+// tenant and issues tokens scoped to one of them.
+type TenantMembershipService interface {
+	// ListTenants returns the tenants the user can switch to, home tenant first.
+	ListTenants(ctx context.Context, userID uuid.UUID) ([]domain.TenantMembership, error)
+	// SwitchTenant issues a token pair for tenantID with the user's role there.
+	SwitchTenant(ctx context.Context, userID, tenantID uuid.UUID) (*TokenPair, error)
+	ListGuests(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMembership, int, error)
+	Grant(ctx context.Context, tenantID uuid.UUID, input *GrantMembershipInput, grantedBy uuid.UUID) (*domain.TenantMembership, error)
+	Revoke(ctx context.Context, tenantID, userID uuid.UUID) error
+}
+
+type tenantMembershipService struct {
+	repo       port.TenantMembershipRepository
+	userRepo   port.UserRepository
+	tenantRepo port.TenantRepository
+	authSvc    AuthService
+}
+
+// NewTenantMembershipService creates a new TenantMembershipService.
+func NewTenantMembershipService(
+	repo port.TenantMembershipRepository,
+	userRepo port.UserRepository,
+	tenantRepo port.TenantRepository,
+	authSvc AuthService,
+) TenantMembershipService {
+	return &tenantMembershipService{repo: repo, userRepo: userRepo, tenantRepo: tenantRepo, authSvc: authSvc}
+}
+
+func (s *tenantMembershipService) ListTenants(ctx context.Context, userID uuid.UUID) ([]domain.TenantMembership, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+func (s *tenantMembershipService) SwitchTenant(ctx context.Context, userID, tenantID uuid.UUID) (*TokenPair, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrTenantAccessDenied
+		}
+		return nil, err
+	}
+	if !tenant.IsActive {
+		return nil, domain.ErrTenantInactive
+	}
+
+	// GetByID resolves home users and active guests, with their role in this tenant.
+	user, err := s.userRepo.GetByID(ctx, tenantID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrTenantAccessDenied
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	log.Printf("tenantMembershipService.SwitchTenant: user %s switched to tenant %s as %s", userID, tenantID, user.Role)
+	return s.authSvc.GenerateTokenPairForUser(user)
+}
+
+func (s *tenantMembershipService) ListGuests(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMembership, int, error) {
+	return s.repo.ListByTenant(ctx, tenantID, offset, limit)
+}
+
+func (s *tenantMembershipService) Grant(ctx context.Context, tenantID uuid.UUID, input *GrantMembershipInput, grantedBy uuid.UUID) (*domain.TenantMembership, error) {
+	if input.Role == domain.RoleFree || !domain.ValidUserRoles[input.Role] {
+		return nil, fmt.Errorf("%w: role must be admin, manager, member or viewer", domain.ErrInvalidMembership)
+	}
+
+	home, err := s.tenantRepo.GetBySlug(ctx, strings.TrimSpace(input.TenantSlug))
+	if err != nil {
+		return nil, err
+	}
+	if home.ID == tenantID {
+		return nil, fmt.Errorf("%w: the user already belongs to this tenant", domain.ErrInvalidMembership)
+	}
+	user, err := s.userRepo.GetByEmail(ctx, home.ID, strings.TrimSpace(input.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	membership := &domain.TenantMembership{
+		TenantID:  tenantID,
+		UserID:    user.ID,
+		Role:      input.Role,
+		IsActive:  input.IsActive == nil || *input.IsActive,
+		CreatedBy: &grantedBy,
+	}
+	if err := s.repo.Upsert(ctx, membership); err != nil {
+		return nil, err
+	}
+	membership.Email = user.Email
+	membership.FullName = user.FullName
+
+	log.Printf("tenantMembershipService.Grant: user %s of tenant %s granted %s in tenant %s (active=%t, by user %s)",
+		user.ID, home.ID, input.Role, tenantID, membership.IsActive, grantedBy)
+	return membership, nil
+}
+
+func (s *tenantMembershipService) Revoke(ctx context.Context, tenantID, userID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, tenantID, userID); err != nil {
+		return err
+	}
+	log.Printf("tenantMembershipService.Revoke: user %s removed from tenant %s", userID, tenantID)
+	return nil
+}
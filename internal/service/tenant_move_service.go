@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+const (
+	// tenantMoveTimeout bounds the background processing of one tenant move.
+	tenantMoveTimeout = 6 * time.Hour
+	// tenantMoveProgressEvery is how many objects are copied between progress saves.
+	tenantMoveProgressEvery = 100
+)
+
+// StartTenantMoveInput is the DTO for moving a tenant to another database cluster.
+type StartTenantMoveInput struct {
+	// TargetCluster names a cluster configured in SATVOS_TENANT_MOVE_TARGETS.
+	TargetCluster string `json:"target_cluster" binding:"required"`
+	// StorageRegion also moves the tenant's files to that region's bucket; omit
+	// to leave files where they are.
+	StorageRegion *string `json:"storage_region"`
+}
+
+// TenantMoveService moves a tenant's rows, with their IDs, to another database
+// cluster and optionally its files to another storage region, verifies the copy
+// against per-table row counts and checksums, and points the tenant's routing
+// flag at the target. The source rows and objects are left in place.
+type TenantMoveService interface {
+	// Start validates the move and runs it in the background.
+	Start(ctx context.Context, tenantID uuid.UUID, input *StartTenantMoveInput, requestedBy *uuid.UUID) (*domain.TenantMove, error)
+	// Run validates and runs the move before returning; the returned move is
+	// failed when the error comes from the move itself.
+	Run(ctx context.Context, tenantID uuid.UUID, input *StartTenantMoveInput, requestedBy *uuid.UUID) (*domain.TenantMove, error)
+	Get(ctx context.Context, tenantID, moveID uuid.UUID) (*domain.TenantMove, error)
+	List(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMove, int, error)
+}
+
+type tenantMoveService struct {
+	moveRepo   port.TenantMoveRepository
+	tenantRepo port.TenantRepository
+	source     port.TenantDataStore
+	targets    map[string]port.TenantDataStore
+	storage    port.ObjectStorage
+	s3Cfg      *config.S3Config
+	cluster    string
+	settle     time.Duration
+}
+
+// NewTenantMoveService creates a new TenantMoveService implementation. source is
+// this server's cluster, named cluster; targets are the clusters tenants may move
+// to. settle is how long to wait after the routing flag flips before copying, so
+// requests admitted under cached tenant lookups finish first.
+func NewTenantMoveService(
+	moveRepo port.TenantMoveRepository,
+	tenantRepo port.TenantRepository,
+	source port.TenantDataStore,
+	targets map[string]port.TenantDataStore,
+	storage port.ObjectStorage,
+	s3Cfg *config.S3Config,
+	cluster string,
+	settle time.Duration,
+) TenantMoveService {
+	return &tenantMoveService{
+		moveRepo:   moveRepo,
+		tenantRepo: tenantRepo,
+		source:     source,
+		targets:    targets,
+		storage:    storage,
+		s3Cfg:      s3Cfg,
+		cluster:    cluster,
+		settle:     settle,
+	}
+}
+
+func (s *tenantMoveService) Start(ctx context.Context, tenantID uuid.UUID, input *StartTenantMoveInput, requestedBy *uuid.UUID) (*domain.TenantMove, error) {
+	move, tenant, target, err := s.prepare(ctx, tenantID, input, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	result := *move
+	go s.runInBackground(move, tenant, target)
+	return &result, nil
+}
+
+func (s *tenantMoveService) Run(ctx context.Context, tenantID uuid.UUID, input *StartTenantMoveInput, requestedBy *uuid.UUID) (*domain.TenantMove, error) {
+	move, tenant, target, err := s.prepare(ctx, tenantID, input, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	return move, s.execute(ctx, move, tenant, target)
+}
+
+func (s *tenantMoveService) Get(ctx context.Context, tenantID, moveID uuid.UUID) (*domain.TenantMove, error) {
+	return s.moveRepo.GetByID(ctx, tenantID, moveID)
+}
+
+func (s *tenantMoveService) List(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMove, int, error) {
+	return s.moveRepo.ListByTenant(ctx, tenantID, offset, limit)
+}
+
+// prepare checks the move can run and records it as pending.
+func (s *tenantMoveService) prepare(ctx context.Context, tenantID uuid.UUID, input *StartTenantMoveInput, requestedBy *uuid.UUID) (*domain.TenantMove, *domain.Tenant, port.TenantDataStore, error) {
+	target, ok := s.targets[input.TargetCluster]
+	if !ok || s.cluster == "" || input.TargetCluster == s.cluster {
+		return nil, nil, nil, domain.ErrInvalidMoveTarget
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if tenant.DBCluster != "" && tenant.DBCluster != s.cluster {
+		return nil, nil, nil, domain.ErrTenantMoved
+	}
+	if input.StorageRegion != nil {
+		if _, ok := s.s3Cfg.BucketForRegion(*input.StorageRegion); !ok {
+			return nil, nil, nil, domain.ErrInvalidStorageRegion
+		}
+	}
+	if err := s.source.CheckMovable(ctx, tenantID); err != nil {
+		return nil, nil, nil, err
+	}
+
+	move := &domain.TenantMove{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		SourceCluster: s.cluster,
+		TargetCluster: input.TargetCluster,
+		TargetRegion:  input.StorageRegion,
+		Status:        domain.TenantMoveStatusPending,
+		Tables:        json.RawMessage("[]"),
+		RequestedBy:   requestedBy,
+	}
+	if err := s.moveRepo.Create(ctx, move); err != nil {
+		return nil, nil, nil, err
+	}
+	log.Printf("tenantMoveService.prepare: move %s of tenant %s from cluster %q to %q",
+		move.ID, tenantID, s.cluster, input.TargetCluster)
+	return move, tenant, target, nil
+}
+
+func (s *tenantMoveService) runInBackground(move *domain.TenantMove, tenant *domain.Tenant, target port.TenantDataStore) {
+	ctx, cancel := context.WithTimeout(context.Background(), tenantMoveTimeout)
+	defer cancel()
+	_ = s.execute(ctx, move, tenant, target)
+}
+
+// execute runs a prepared move. The tenant stops being served here before its
+// rows are read, so nothing is written after the snapshot; on failure the
+// routing flag is put back.
+func (s *tenantMoveService) execute(ctx context.Context, move *domain.TenantMove, tenant *domain.Tenant, target port.TenantDataStore) error {
+	move.Status = domain.TenantMoveStatusCopying
+	s.saveProgress(ctx, move)
+
+	sourceVersion, err := s.source.SchemaVersion(ctx)
+	if err != nil {
+		return s.fail(ctx, move, fmt.Errorf("reading source schema version: %w", err))
+	}
+	targetVersion, err := target.SchemaVersion(ctx)
+	if err != nil {
+		return s.fail(ctx, move, fmt.Errorf("reading target schema version: %w", err))
+	}
+	if sourceVersion != targetVersion {
+		return s.fail(ctx, move, fmt.Errorf("schema versions differ: source at migration %d, target at %d", sourceVersion, targetVersion))
+	}
+
+	if err := s.source.SetCluster(ctx, tenant.ID, move.TargetCluster); err != nil {
+		return s.fail(ctx, move, fmt.Errorf("flipping routing flag: %w", err))
+	}
+	if err := s.copyTenant(ctx, move, target); err != nil {
+		// The move's context may be what expired; the tenant must be served again regardless
+		revertCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+		defer cancel()
+		if revertErr := s.source.SetCluster(revertCtx, tenant.ID, tenant.DBCluster); revertErr != nil {
+			log.Printf("tenantMoveService.execute: move %s: restoring routing flag of tenant %s: %v", move.ID, tenant.ID, revertErr)
+		}
+		return s.fail(ctx, move, err)
+	}
+
+	now := time.Now().UTC()
+	move.Status = domain.TenantMoveStatusCompleted
+	move.CompletedAt = &now
+	s.saveProgress(ctx, move)
+	log.Printf("tenantMoveService.execute: move %s completed; tenant %s is now served by cluster %q",
+		move.ID, tenant.ID, move.TargetCluster)
+	return nil
+}
+
+// copyTenant snapshots the source, copies the objects, then imports and verifies the rows.
+func (s *tenantMoveService) copyTenant(ctx context.Context, move *domain.TenantMove, target port.TenantDataStore) error {
+	if s.settle > 0 {
+		select {
+		case <-time.After(s.settle):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	tables, err := s.source.Snapshot(ctx, move.TenantID)
+	if err != nil {
+		return fmt.Errorf("snapshotting source rows: %w", err)
+	}
+	s.setTables(move, tables)
+	s.saveProgress(ctx, move)
+
+	buckets, err := s.copyObjects(ctx, move)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(tables))
+	for i := range tables {
+		names[i] = tables[i].Table
+	}
+	err = target.Import(ctx, &port.TenantImport{
+		TenantID:      move.TenantID,
+		Source:        s.source,
+		Tables:        names,
+		Verify:        func(imported []domain.TenantMoveTable) error { return verifyTables(tables, imported) },
+		StorageRegion: move.TargetRegion,
+		Buckets:       buckets,
+	})
+	s.setTables(move, tables)
+	if err != nil {
+		return fmt.Errorf("importing rows: %w", err)
+	}
+	return nil
+}
+
+// copyObjects copies the tenant's files into the target region's bucket, keeping
+// their keys, and returns the bucket mapping for the target's file metadata.
+func (s *tenantMoveService) copyObjects(ctx context.Context, move *domain.TenantMove) (map[string]string, error) {
+	if move.TargetRegion == nil {
+		return nil, nil
+	}
+	bucket, _ := s.s3Cfg.BucketForRegion(*move.TargetRegion)
+
+	objects, err := s.source.ListObjects(ctx, move.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing objects: %w", err)
+	}
+	move.ObjectsTotal = len(objects)
+	buckets := make(map[string]string)
+	for i, obj := range objects {
+		if obj.Bucket != bucket {
+			if err := s.copyObject(ctx, obj, bucket); err != nil {
+				return nil, fmt.Errorf("copying s3://%s/%s: %w", obj.Bucket, obj.Key, err)
+			}
+			buckets[obj.Bucket] = bucket
+		}
+		move.ObjectsCopied++
+		if (i+1)%tenantMoveProgressEvery == 0 {
+			s.saveProgress(ctx, move)
+		}
+	}
+	s.saveProgress(ctx, move)
+	return buckets, nil
+}
+
+func (s *tenantMoveService) copyObject(ctx context.Context, obj port.StoredObject, bucket string) error {
+	src, err := s.storage.Open(ctx, obj.Bucket, obj.Key)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Body.Close() }()
+	_, err = s.storage.Upload(ctx, port.UploadInput{
+		Bucket:      bucket,
+		Key:         obj.Key,
+		Body:        src.Body,
+		ContentType: src.ContentType,
+		Size:        src.Size,
+	})
+	return err
+}
+
+// verifyTables records the imported counts and checksums on source and reports
+// every table where they differ.
+func verifyTables(source, imported []domain.TenantMoveTable) error {
+	got := make(map[string]domain.TenantMoveTable, len(imported))
+	for _, t := range imported {
+		got[t.Table] = t
+	}
+	var mismatched []string
+	for i := range source {
+		t := got[source[i].Table]
+		source[i].TargetRows = t.Rows
+		source[i].TargetChecksum = t.Checksum
+		if t.Rows != source[i].Rows || t.Checksum != source[i].Checksum {
+			mismatched = append(mismatched, source[i].Table)
+		}
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("verification failed: target rows differ from the source in %s", strings.Join(mismatched, ", "))
+	}
+	return nil
+}
+
+func (s *tenantMoveService) setTables(move *domain.TenantMove, tables []domain.TenantMoveTable) {
+	if data, err := json.Marshal(tables); err == nil {
+		move.Tables = data
+	}
+}
+
+// saveProgress persists the move's current state. Errors are logged, not returned:
+// a lost progress update must not abort the move.
+func (s *tenantMoveService) saveProgress(ctx context.Context, move *domain.TenantMove) {
+	if err := s.moveRepo.UpdateProgress(ctx, move); err != nil {
+		log.Printf("tenantMoveService.saveProgress: failed to update move %s: %v", move.ID, err)
+	}
+}
+
+func (s *tenantMoveService) fail(ctx context.Context, move *domain.TenantMove, err error) error {
+	log.Printf("tenantMoveService.execute: move %s failed: %v", move.ID, err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = errors.New("tenant move timed out")
+	}
+	msg := err.Error()
+	now := time.Now().UTC()
+	move.Error = &msg
+	move.Status = domain.TenantMoveStatusFailed
+	move.CompletedAt = &now
+	// The move's context may have expired; the failure must still be recorded
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+	defer cancel()
+	s.saveProgress(saveCtx, move)
+	return err
+}
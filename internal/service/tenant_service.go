@@ -2,6 +2,11 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,15 +16,33 @@ import (
 
 // CreateTenantInput is the DTO for creating a tenant.
 type CreateTenantInput struct {
-	Name string `json:"name" binding:"required"`
-	Slug string `json:"slug" binding:"required"`
+	Name          string `json:"name" binding:"required"`
+	Slug          string `json:"slug" binding:"required"`
+	StorageRegion string `json:"storage_region"`
+	// StorageIAAfterDays moves objects to infrequent-access storage after N days; 0 disables.
+	StorageIAAfterDays int `json:"storage_ia_after_days"`
+	// StorageSSE is sse-s3 or sse-kms; "" uses the bucket's default encryption.
+	StorageSSE       string `json:"storage_sse"`
+	StorageKMSKeyARN string `json:"storage_kms_key_arn"`
 }
 
 // UpdateTenantInput is the DTO for updating a tenant.
 type UpdateTenantInput struct {
-	Name     *string `json:"name"`
-	Slug     *string `json:"slug"`
-	IsActive *bool   `json:"is_active"`
+	Name          *string `json:"name"`
+	Slug          *string `json:"slug"`
+	IsActive      *bool   `json:"is_active"`
+	StorageRegion *string `json:"storage_region"` // "" resets to the default region
+	// StorageIAAfterDays moves objects to infrequent-access storage after N days; 0 disables.
+	StorageIAAfterDays *int `json:"storage_ia_after_days"`
+	// StorageSSE and StorageKMSKeyARN are set together; "" resets to the bucket's
+	// default encryption. Objects already stored keep their encryption.
+	StorageSSE       *string `json:"storage_sse"`
+	StorageKMSKeyARN *string `json:"storage_kms_key_arn"`
+	// DefaultParseMode is single or dual for documents created without a parse
+	// mode in collections without their own default; "" resets it.
+	DefaultParseMode *domain.ParseMode `json:"default_parse_mode"`
+	// TimeZone is an IANA zone name such as Asia/Kolkata; "" resets it to UTC.
+	TimeZone *string `json:"time_zone"`
 }
 
 // TenantService defines the tenant management contract.
@@ -32,23 +55,116 @@ type TenantService interface {
 }
 
 type tenantService struct {
-	repo port.TenantRepository
+	repo      port.TenantRepository
+	fileRepo  port.FileMetaRepository
+	residency StorageResidency
+	layout    StorageLayoutService
 }
 
-// NewTenantService creates a new TenantService implementation.
-func NewTenantService(repo port.TenantRepository) TenantService {
-	return &tenantService{repo: repo}
+// NewTenantService creates a new TenantService implementation. layout may be nil,
+// in which case lifecycle settings are stored but never written to S3.
+func NewTenantService(repo port.TenantRepository, fileRepo port.FileMetaRepository, residency StorageResidency, layout StorageLayoutService) TenantService {
+	return &tenantService{repo: repo, fileRepo: fileRepo, residency: residency, layout: layout}
+}
+
+// validRegion reports whether region may be assigned; without residency
+// configured only the default region is allowed.
+func (s *tenantService) validRegion(region string) bool {
+	if s.residency == nil {
+		return region == ""
+	}
+	return s.residency.ValidRegion(region)
+}
+
+// regionPtr stores the default region as NULL.
+func regionPtr(region string) *string {
+	if region == "" {
+		return nil
+	}
+	return &region
+}
+
+// lifecycleDays validates a transition setting and stores "disabled" (0) as NULL.
+// S3 does not allow STANDARD_IA transitions earlier than 30 days.
+func lifecycleDays(days int) (*int, error) {
+	if days == 0 {
+		return nil, nil
+	}
+	if days < 30 {
+		return nil, domain.ErrInvalidStorageLifecycle
+	}
+	return &days, nil
+}
+
+// kmsKeyARNPattern matches a KMS key or alias ARN in any AWS partition.
+var kmsKeyARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:(key|alias)/[A-Za-z0-9/_-]+$`)
+
+// storageEncryption validates an encryption setting and stores the bucket
+// default ("") as NULL.
+func storageEncryption(sse, kmsKeyARN string) (*domain.StorageEncryption, *string, error) {
+	switch domain.StorageEncryption(sse) {
+	case "":
+		if kmsKeyARN != "" {
+			return nil, nil, domain.ErrInvalidStorageEncryption
+		}
+		return nil, nil, nil
+	case domain.StorageEncryptionS3:
+		if kmsKeyARN != "" {
+			return nil, nil, domain.ErrInvalidStorageEncryption
+		}
+		enc := domain.StorageEncryptionS3
+		return &enc, nil, nil
+	case domain.StorageEncryptionKMS:
+		if !kmsKeyARNPattern.MatchString(kmsKeyARN) {
+			return nil, nil, domain.ErrInvalidStorageEncryption
+		}
+		enc := domain.StorageEncryptionKMS
+		return &enc, &kmsKeyARN, nil
+	}
+	return nil, nil, domain.ErrInvalidStorageEncryption
+}
+
+// applyLifecycle pushes the tenant's lifecycle setting to S3. It runs before the
+// tenant row is saved so a failed S3 call leaves the stored setting unchanged.
+func (s *tenantService) applyLifecycle(ctx context.Context, tenant *domain.Tenant) error {
+	if s.layout == nil {
+		return nil
+	}
+	return s.layout.ApplyLifecycle(ctx, tenant)
 }
 
 func (s *tenantService) Create(ctx context.Context, input CreateTenantInput) (*domain.Tenant, error) {
+	if !s.validRegion(input.StorageRegion) {
+		return nil, domain.ErrInvalidStorageRegion
+	}
+	iaDays, err := lifecycleDays(input.StorageIAAfterDays)
+	if err != nil {
+		return nil, err
+	}
+	sse, kmsKeyARN, err := storageEncryption(input.StorageSSE, input.StorageKMSKeyARN)
+	if err != nil {
+		return nil, err
+	}
 	tenant := &domain.Tenant{
-		Name:     input.Name,
-		Slug:     input.Slug,
-		IsActive: true,
+		Name:               input.Name,
+		Slug:               input.Slug,
+		IsActive:           true,
+		StorageRegion:      regionPtr(input.StorageRegion),
+		StorageIAAfterDays: iaDays,
+		StorageSSE:         sse,
+		StorageKMSKeyARN:   kmsKeyARN,
 	}
 	if err := s.repo.Create(ctx, tenant); err != nil {
 		return nil, err
 	}
+	// The rule is keyed by tenant ID, which only exists once the row is created.
+	// Failing the request now would leave a tenant the client believes wasn't
+	// created, so the rule is left for cmd/storagelayout to sync instead.
+	if iaDays != nil {
+		if err := s.applyLifecycle(ctx, tenant); err != nil {
+			log.Printf("tenantService.Create: lifecycle rule for tenant %s not applied: %v", tenant.ID, err)
+		}
+	}
 	return tenant, nil
 }
 
@@ -75,6 +191,51 @@ func (s *tenantService) Update(ctx context.Context, id uuid.UUID, input UpdateTe
 	if input.IsActive != nil {
 		tenant.IsActive = *input.IsActive
 	}
+	lifecycleChanged := false
+	if input.StorageRegion != nil {
+		current := tenant.StorageRegion
+		if err := s.changeRegion(ctx, tenant, *input.StorageRegion); err != nil {
+			return nil, err
+		}
+		lifecycleChanged = tenant.StorageRegion != current
+	}
+	if input.StorageIAAfterDays != nil {
+		iaDays, err := lifecycleDays(*input.StorageIAAfterDays)
+		if err != nil {
+			return nil, err
+		}
+		if !sameDays(tenant.StorageIAAfterDays, iaDays) {
+			tenant.StorageIAAfterDays = iaDays
+			lifecycleChanged = true
+		}
+	}
+	if input.StorageSSE != nil || input.StorageKMSKeyARN != nil {
+		sse, kmsKeyARN := "", ""
+		if input.StorageSSE != nil {
+			sse = *input.StorageSSE
+		}
+		if input.StorageKMSKeyARN != nil {
+			kmsKeyARN = *input.StorageKMSKeyARN
+		}
+		if tenant.StorageSSE, tenant.StorageKMSKeyARN, err = storageEncryption(sse, kmsKeyARN); err != nil {
+			return nil, err
+		}
+	}
+	if input.DefaultParseMode != nil {
+		if tenant.DefaultParseMode, err = parseModeDefault(*input.DefaultParseMode); err != nil {
+			return nil, err
+		}
+	}
+	if input.TimeZone != nil {
+		if tenant.TimeZone, err = timeZoneName(*input.TimeZone); err != nil {
+			return nil, err
+		}
+	}
+	if lifecycleChanged {
+		if err := s.applyLifecycle(ctx, tenant); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.repo.Update(ctx, tenant); err != nil {
 		return nil, err
@@ -82,6 +243,56 @@ func (s *tenantService) Update(ctx context.Context, id uuid.UUID, input UpdateTe
 	return tenant, nil
 }
 
+// timeZoneName validates an IANA time zone name; "" means UTC. Abbreviations
+// and offsets are rejected even where the zone database knows them, since
+// they don't follow daylight saving.
+func timeZoneName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "UTC", nil
+	}
+	if name != "UTC" && !strings.Contains(name, "/") {
+		return "", fmt.Errorf("%w: %q", domain.ErrInvalidTimeZone, name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", fmt.Errorf("%w: %q", domain.ErrInvalidTimeZone, name)
+	}
+	return name, nil
+}
+
 func (s *tenantService) Delete(ctx context.Context, id uuid.UUID) error {
 	return s.repo.Delete(ctx, id)
 }
+
+// changeRegion moves a tenant to another storage region. Existing objects are
+// not migrated, so the region is locked once the tenant has any files.
+func (s *tenantService) changeRegion(ctx context.Context, tenant *domain.Tenant, region string) error {
+	current := ""
+	if tenant.StorageRegion != nil {
+		current = *tenant.StorageRegion
+	}
+	if region == current {
+		return nil
+	}
+	if !s.validRegion(region) {
+		return domain.ErrInvalidStorageRegion
+	}
+	if s.fileRepo != nil {
+		_, total, err := s.fileRepo.ListByTenant(ctx, tenant.ID, 0, 1)
+		if err != nil {
+			return fmt.Errorf("counting tenant files: %w", err)
+		}
+		if total > 0 {
+			return domain.ErrStorageRegionLocked
+		}
+	}
+	tenant.StorageRegion = regionPtr(region)
+	return nil
+}
+
+func sameDays(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// Invoice date layouts seen in parser output. Plain dates are calendar dates;
+// timestamps depend on the tenant's time zone.
+var (
+	invoiceDateLayouts      = []string{"2006-01-02", "02/01/2006", "02-01-2006", "01/02/2006", "January 2, 2006", "Jan 2, 2006", "2 January 2006"}
+	invoiceTimestampLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "02/01/2006 15:04:05", "02-01-2006 15:04:05"}
+)
+
+// collectionLocation returns the time zone dates in the collection are read in.
+// Lookup failures fall back to UTC, which is what every tenant used before
+// they had a zone.
+func collectionLocation(ctx context.Context, repo port.CollectionRepository, tenantID, collectionID uuid.UUID) *time.Location {
+	if repo == nil {
+		return time.UTC
+	}
+	tz, err := repo.TimeZone(ctx, tenantID, collectionID)
+	if err != nil {
+		log.Printf("collectionLocation: collection %s: %v", collectionID, err)
+		return time.UTC
+	}
+	return domain.LoadTimeZone(tz)
+}
+
+// hasTimeOfDay reports whether an invoice date from parser output is a
+// timestamp, so whether reading it needs the tenant's time zone.
+func hasTimeOfDay(s string) bool {
+	return strings.Contains(s, ":")
+}
+
+// parseInvoiceDate reads a date from parser output. Timestamps are converted
+// to loc before taking the date, so "2025-03-31T20:00:00Z" is 1 April for a
+// tenant in Asia/Kolkata; zoneless timestamps are taken to be in loc. The
+// result is midnight UTC of the date, matching the DATE columns it is stored in.
+func parseInvoiceDate(s string, loc *time.Location) *time.Time {
+	if s == "" {
+		return nil
+	}
+	for _, layout := range invoiceDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	for _, layout := range invoiceTimestampLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			y, m, d := t.In(loc).Date()
+			day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+			return &day
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/httpclient"
+)
+
+// maxSourceRedirects caps the redirects followed when fetching a source URL.
+const maxSourceRedirects = 3
+
+// genericContentTypes are accepted as declared by the source; the content is
+// then identified by its magic bytes alone. Presigned S3 URLs often serve these.
+var genericContentTypes = map[string]bool{
+	"":                         true,
+	"application/octet-stream": true,
+	"binary/octet-stream":      true,
+}
+
+// CreateFromURLInput is the DTO for creating a document from a file at a URL.
+type CreateFromURLInput struct {
+	TenantID     uuid.UUID
+	CollectionID uuid.UUID
+	URL          string
+	DocumentType string
+	ParseMode    domain.ParseMode
+	Name         string
+	Tags         map[string]string
+	CreatedBy    uuid.UUID
+	Role         domain.UserRole
+}
+
+// URLImportService downloads a file from a client-supplied URL into storage and
+// parses it like an uploaded file.
+type URLImportService interface {
+	CreateFromURL(ctx context.Context, input *CreateFromURLInput) (*domain.Document, error)
+}
+
+type urlImportService struct {
+	client        *http.Client
+	allowedHosts  []string
+	maxBytes      int64
+	fileSvc       FileService
+	collectionSvc CollectionService
+	docSvc        DocumentService
+}
+
+// NewURLImportService creates a new URLImportService. client should come from
+// httpclient.NewPublic so source hosts can't resolve to internal addresses.
+func NewURLImportService(client *http.Client, cfg config.URLImportConfig, fileSvc FileService, collectionSvc CollectionService, docSvc DocumentService) URLImportService {
+	s := &urlImportService{
+		allowedHosts:  cfg.HTTP.AllowedHosts,
+		maxBytes:      cfg.MaxSizeMB * 1024 * 1024,
+		fileSvc:       fileSvc,
+		collectionSvc: collectionSvc,
+		docSvc:        docSvc,
+	}
+	// Every redirect target must pass the same checks as the original URL.
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxSourceRedirects {
+			return fmt.Errorf("%w: too many redirects", domain.ErrSourceFetchFailed)
+		}
+		_, err := s.validateURL(req.URL.String())
+		return err
+	}
+	s.client = &c
+	return s
+}
+
+func (s *urlImportService) CreateFromURL(ctx context.Context, input *CreateFromURLInput) (*domain.Document, error) {
+	u, err := s.validateURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check access before making any outbound request on the caller's behalf.
+	if _, err := s.collectionSvc.GetByID(ctx, input.TenantID, input.CollectionID, input.CreatedBy, input.Role); err != nil {
+		return nil, err
+	}
+	perm := s.collectionSvc.EffectivePermission(ctx, input.CollectionID, input.CreatedBy, input.Role)
+	if domain.CollectionPermLevel(perm) < domain.CollectionPermLevel(domain.CollectionPermEditor) {
+		return nil, domain.ErrCollectionPermDenied
+	}
+
+	content, contentType, err := s.fetch(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	fileName, err := sourceFileName(u, content)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := s.fileSvc.Ingest(ctx, FileIngestInput{
+		TenantID:     input.TenantID,
+		UploadedBy:   input.CreatedBy,
+		FileName:     fileName,
+		Content:      content,
+		CollectionID: &input.CollectionID,
+		ContentType:  contentType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.collectionSvc.AddFileToCollection(ctx, input.TenantID, input.CollectionID, meta.ID, input.CreatedBy, input.Role); err != nil {
+		return nil, err
+	}
+
+	// The query string is left out of the log: presigned URLs carry credentials there.
+	log.Printf("urlImportService.CreateFromURL: fetched %s%s (%d bytes) as file %s for tenant %s",
+		u.Host, u.Path, len(content), meta.ID, input.TenantID)
+
+	return s.docSvc.CreateAndParse(ctx, &CreateDocumentInput{
+		TenantID:     input.TenantID,
+		CollectionID: input.CollectionID,
+		FileID:       meta.ID,
+		DocumentType: input.DocumentType,
+		ParseMode:    input.ParseMode,
+		Name:         input.Name,
+		Tags:         input.Tags,
+		CreatedBy:    input.CreatedBy,
+		Role:         input.Role,
+	})
+}
+
+// validateURL accepts https URLs with a host name. IP literals and localhost are
+// rejected outright; host names resolving to internal addresses are refused by
+// the public-only client at connect time.
+func (s *urlImportService) validateURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Scheme != "https" || u.User != nil {
+		return nil, domain.ErrInvalidSourceURL
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" || net.ParseIP(host) != nil || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return nil, domain.ErrInvalidSourceURL
+	}
+	if len(s.allowedHosts) > 0 && !httpclient.HostAllowed(host, s.allowedHosts) {
+		return nil, domain.ErrInvalidSourceURL
+	}
+	return u, nil
+}
+
+// fetch downloads the file, enforcing the size cap and the declared content type.
+// It returns the content and its declared media type.
+func (s *urlImportService) fetch(ctx context.Context, u *url.URL) (content []byte, contentType string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return nil, "", domain.ErrInvalidSourceURL
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) && errors.Is(urlErr.Err, domain.ErrInvalidSourceURL) {
+			return nil, "", domain.ErrInvalidSourceURL
+		}
+		if errors.Is(err, httpclient.ErrNonPublicAddress) || errors.Is(err, httpclient.ErrEgressDenied) {
+			return nil, "", domain.ErrInvalidSourceURL
+		}
+		return nil, "", fmt.Errorf("%w: %v", domain.ErrSourceFetchFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("%w: source returned %d", domain.ErrSourceFetchFailed, resp.StatusCode)
+	}
+	if resp.ContentLength > s.maxBytes {
+		return nil, "", domain.ErrFileTooLarge
+	}
+	contentType, _, _ = mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if _, known := domain.AllowedContentTypes[contentType]; !known && !genericContentTypes[contentType] {
+		return nil, "", domain.ErrUnsupportedFileType
+	}
+
+	content, err = io.ReadAll(io.LimitReader(resp.Body, s.maxBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: reading body: %v", domain.ErrSourceFetchFailed, err)
+	}
+	if int64(len(content)) > s.maxBytes {
+		return nil, "", domain.ErrFileTooLarge
+	}
+	return content, contentType, nil
+}
+
+// sourceFileName names the file after the last path segment of the URL, adding
+// the extension of the detected type when the segment has none that matches.
+func sourceFileName(u *url.URL, content []byte) (string, error) {
+	detected, ok := sniffFileType(content)
+	if !ok {
+		return "", domain.ErrUnsupportedFileType
+	}
+	name := path.Base(u.Path)
+	if name == "." || name == "/" {
+		name = "document"
+	}
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(name), "."))
+	if domain.AllowedExtensions[ext] != detected {
+		name += "." + string(detected)
+	}
+	return name, nil
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/mail"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// maxUserImportRows caps the data rows of one user import. Rows are created
+// and invited inline, so the cap also bounds the request's duration.
+const maxUserImportRows = 500
+
+// UserImportInput is the DTO for a bulk user import. CSV holds a header row
+// naming the name, email, role and (optional) collections columns, in any order.
+type UserImportInput struct {
+	TenantID uuid.UUID
+	ActorID  uuid.UUID
+	CSV      io.Reader
+	Meta     RequestMeta
+}
+
+// UserImportService onboards many users of a tenant from a CSV.
+type UserImportService interface {
+	// Import creates a user per CSV row, grants the row's collection
+	// permissions and emails an invitation to set a password. A bad row is
+	// reported and skipped; only an unreadable file fails the whole import.
+	Import(ctx context.Context, input *UserImportInput) (*domain.UserImportReport, error)
+}
+
+type userImportService struct {
+	tenantRepo      port.TenantRepository
+	userRepo        port.UserRepository
+	collectionRepo  port.CollectionRepository
+	permRepo        port.CollectionPermissionRepository
+	accountSecurity PasswordResetService
+}
+
+// NewUserImportService creates a new UserImportService implementation.
+func NewUserImportService(
+	tenantRepo port.TenantRepository,
+	userRepo port.UserRepository,
+	collectionRepo port.CollectionRepository,
+	permRepo port.CollectionPermissionRepository,
+	accountSecurity PasswordResetService,
+) UserImportService {
+	return &userImportService{
+		tenantRepo:      tenantRepo,
+		userRepo:        userRepo,
+		collectionRepo:  collectionRepo,
+		permRepo:        permRepo,
+		accountSecurity: accountSecurity,
+	}
+}
+
+// userImportRow is one parsed data row of the CSV.
+type userImportRow struct {
+	line        int
+	name        string
+	email       string
+	role        domain.UserRole
+	collections string
+}
+
+// collectionGrant is a collection permission a row asks for.
+type collectionGrant struct {
+	collectionID uuid.UUID
+	perm         domain.CollectionPermission
+}
+
+func (s *userImportService) Import(ctx context.Context, input *UserImportInput) (*domain.UserImportReport, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := readUserImportCSV(input.CSV)
+	if err != nil {
+		return nil, err
+	}
+
+	var collections map[string]uuid.UUID
+	if needsCollections(rows) {
+		if collections, err = s.collectionIndex(ctx, tenant.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &domain.UserImportReport{Total: len(rows), Rows: make([]domain.UserImportRow, 0, len(rows))}
+	seen := make(map[string]bool, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		result := domain.UserImportRow{Row: row.line, Email: row.email}
+		user, invited, err := s.importRow(ctx, tenant, row, collections, seen, input)
+		if user != nil {
+			result.UserID = &user.ID
+		}
+		if err != nil {
+			result.Status = domain.UserImportRowFailed
+			result.Error = userImportErrorMessage(err)
+			report.Failed++
+		} else {
+			result.Status = domain.UserImportRowCreated
+			result.Invited = invited
+			report.Created++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	log.Printf("userImportService.Import: tenant %s imported %d of %d users (by user %s)",
+		tenant.ID, report.Created, report.Total, input.ActorID)
+	return report, nil
+}
+
+// importRow validates and creates one user. The row is checked in full before
+// the user is created, so a failed row leaves nothing behind, except when a
+// grant fails: the user is then returned with the error, and left in place
+// without an invitation.
+func (s *userImportService) importRow(
+	ctx context.Context,
+	tenant *domain.Tenant,
+	row *userImportRow,
+	collections map[string]uuid.UUID,
+	seen map[string]bool,
+	input *UserImportInput,
+) (*domain.User, bool, error) {
+	if row.name == "" {
+		return nil, false, invalidUserImportRow("name is required")
+	}
+	if addr, err := mail.ParseAddress(row.email); err != nil || addr.Address != row.email {
+		return nil, false, invalidUserImportRow("email is not a valid address")
+	}
+	if !domain.ValidUserRoles[row.role] {
+		return nil, false, invalidUserImportRow("role must be one of admin, manager, member, viewer or free")
+	}
+	key := strings.ToLower(row.email)
+	if seen[key] {
+		return nil, false, invalidUserImportRow("email appears on an earlier row")
+	}
+	seen[key] = true
+	grants, err := parseCollectionGrants(row.collections, collections)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// No password until the invitation is accepted, so password login is refused
+	user := &domain.User{
+		TenantID:      tenant.ID,
+		Email:         row.email,
+		FullName:      row.name,
+		Role:          row.role,
+		IsActive:      true,
+		EmailVerified: true,
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, false, err
+	}
+
+	for _, g := range grants {
+		err := s.permRepo.Upsert(ctx, &domain.CollectionPermissionEntry{
+			CollectionID: g.collectionID,
+			TenantID:     tenant.ID,
+			UserID:       user.ID,
+			Permission:   g.perm,
+			GrantedBy:    input.ActorID,
+		})
+		if err != nil {
+			log.Printf("WARNING: failed to grant imported user %s collection %s: %v", user.Email, g.collectionID, err)
+			return user, false, invalidUserImportRow("user created, but granting its collection permissions failed; grant them from the collection")
+		}
+	}
+
+	err = s.accountSecurity.Invite(ctx, InviteInput{User: user, TenantName: tenant.Name, ActorID: input.ActorID, Meta: input.Meta})
+	if err != nil {
+		log.Printf("WARNING: failed to invite imported user %s: %v", user.Email, err)
+		return user, false, nil
+	}
+	return user, true, nil
+}
+
+// collectionIndex maps the tenant's collections by ID and by lowercased name.
+// A name shared by several collections maps to uuid.Nil, so it must be given by ID.
+func (s *userImportService) collectionIndex(ctx context.Context, tenantID uuid.UUID) (map[string]uuid.UUID, error) {
+	index := map[string]uuid.UUID{}
+	for offset := 0; ; offset += 100 {
+		page, total, err := s.collectionRepo.ListByTenant(ctx, tenantID, offset, 100)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page {
+			c := &page[i]
+			index[c.ID.String()] = c.ID
+			name := strings.ToLower(strings.TrimSpace(c.Name))
+			if _, dup := index[name]; dup {
+				index[name] = uuid.Nil
+			} else {
+				index[name] = c.ID
+			}
+		}
+		if len(page) == 0 || offset+len(page) >= total {
+			return index, nil
+		}
+	}
+}
+
+// parseCollectionGrants reads a row's collections cell: entries separated by
+// ";", each a collection name or ID with an optional ":owner", ":editor" or
+// ":viewer" suffix (viewer when omitted).
+func parseCollectionGrants(cell string, collections map[string]uuid.UUID) ([]collectionGrant, error) {
+	var grants []collectionGrant
+	for _, entry := range strings.Split(cell, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		ref, perm := entry, domain.CollectionPermViewer
+		if i := strings.LastIndex(entry, ":"); i >= 0 {
+			ref, perm = strings.TrimSpace(entry[:i]), domain.CollectionPermission(strings.ToLower(strings.TrimSpace(entry[i+1:])))
+			if !domain.ValidCollectionPermissions[perm] {
+				return nil, invalidUserImportRow(fmt.Sprintf("collection %q: permission must be owner, editor or viewer", ref))
+			}
+		}
+		id, ok := collections[strings.ToLower(ref)]
+		if !ok {
+			return nil, invalidUserImportRow(fmt.Sprintf("collection %q not found", ref))
+		}
+		if id == uuid.Nil {
+			return nil, invalidUserImportRow(fmt.Sprintf("collection name %q is ambiguous; use its ID", ref))
+		}
+		grants = append(grants, collectionGrant{collectionID: id, perm: perm})
+	}
+	return grants, nil
+}
+
+func needsCollections(rows []userImportRow) bool {
+	for i := range rows {
+		if strings.TrimSpace(rows[i].collections) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// readUserImportCSV parses the CSV into rows, matching header names
+// case-insensitively. Blank lines are skipped.
+func readUserImportCSV(r io.Reader) ([]userImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading header: %v", domain.ErrInvalidUserImport, err)
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, required := range []string{"name", "email", "role"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("%w: header has no %s column", domain.ErrInvalidUserImport, required)
+		}
+	}
+	field := func(record []string, col string) string {
+		i, ok := cols[col]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []userImportRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidUserImport, err)
+		}
+		if len(rows) == maxUserImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", domain.ErrInvalidUserImport, maxUserImportRows)
+		}
+		line, _ := cr.FieldPos(0)
+		rows = append(rows, userImportRow{
+			line:        line,
+			name:        field(record, "name"),
+			email:       field(record, "email"),
+			role:        domain.UserRole(strings.ToLower(field(record, "role"))),
+			collections: field(record, "collections"),
+		})
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows after the header", domain.ErrInvalidUserImport)
+	}
+	return rows, nil
+}
+
+// invalidUserImportRow is a problem with a row's data, reported as is.
+type invalidUserImportRow string
+
+func (e invalidUserImportRow) Error() string { return string(e) }
+
+// userImportErrorMessage is the message reported for a failed row. Other than
+// the row's own problems, errors are summarized so internal details don't
+// reach the report.
+func userImportErrorMessage(err error) string {
+	var invalid invalidUserImportRow
+	switch {
+	case errors.As(err, &invalid):
+		return invalid.Error()
+	case errors.Is(err, domain.ErrDuplicateEmail):
+		return "a user with this email already exists in the tenant"
+	default:
+		log.Printf("WARNING: user import row failed: %v", err)
+		return "internal error"
+	}
+}
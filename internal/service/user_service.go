@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -19,12 +21,17 @@ type CreateUserInput struct {
 	Role     domain.UserRole `json:"role" binding:"required"`
 }
 
-// UpdateUserInput is the DTO for updating a user.
+// UpdateUserInput is the DTO for updating a user. ActorID and Meta identify
+// who made the request, for the email change audit and notice.
 type UpdateUserInput struct {
 	Email    *string          `json:"email"`
 	FullName *string          `json:"full_name"`
 	Role     *domain.UserRole `json:"role"`
 	IsActive *bool            `json:"is_active"`
+	// MonthlyDocumentLimit sets the monthly document quota; 0 is unlimited.
+	MonthlyDocumentLimit *int        `json:"monthly_document_limit"`
+	ActorID              uuid.UUID   `json:"-"`
+	Meta                 RequestMeta `json:"-"`
 }
 
 // UserService defines the user management contract.
@@ -32,17 +39,21 @@ type UserService interface {
 	Create(ctx context.Context, tenantID uuid.UUID, input CreateUserInput) (*domain.User, error)
 	GetByID(ctx context.Context, tenantID, userID uuid.UUID) (*domain.User, error)
 	List(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.User, int, error)
+	// ListUnverified lists active users who have not verified their email.
+	ListUnverified(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.User, int, error)
 	Update(ctx context.Context, tenantID, userID uuid.UUID, input UpdateUserInput) (*domain.User, error)
 	Delete(ctx context.Context, tenantID, userID uuid.UUID) error
 }
 
 type userService struct {
-	repo port.UserRepository
+	repo            port.UserRepository
+	accountSecurity PasswordResetService
 }
 
-// NewUserService creates a new UserService implementation.
-func NewUserService(repo port.UserRepository) UserService {
-	return &userService{repo: repo}
+// NewUserService creates a new UserService implementation. accountSecurity
+// records email changes and notifies the old address; it may be nil.
+func NewUserService(repo port.UserRepository, accountSecurity PasswordResetService) UserService {
+	return &userService{repo: repo, accountSecurity: accountSecurity}
 }
 
 func (s *userService) Create(ctx context.Context, tenantID uuid.UUID, input CreateUserInput) (*domain.User, error) {
@@ -79,12 +90,21 @@ func (s *userService) List(ctx context.Context, tenantID uuid.UUID, offset, limi
 	return s.repo.ListByTenant(ctx, tenantID, offset, limit)
 }
 
+func (s *userService) ListUnverified(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.User, int, error) {
+	return s.repo.ListUnverified(ctx, tenantID, offset, limit)
+}
+
 func (s *userService) Update(ctx context.Context, tenantID, userID uuid.UUID, input UpdateUserInput) (*domain.User, error) {
 	user, err := s.repo.GetByID(ctx, tenantID, userID)
 	if err != nil {
 		return nil, err
 	}
+	if user.HomeTenantID != nil {
+		// Guests keep their profile in their home tenant; their role here is a membership.
+		return nil, fmt.Errorf("%w: guests are managed with /memberships", domain.ErrInvalidMembership)
+	}
 
+	oldEmail := user.Email
 	if input.Email != nil {
 		user.Email = *input.Email
 	}
@@ -100,13 +120,29 @@ func (s *userService) Update(ctx context.Context, tenantID, userID uuid.UUID, in
 	if input.IsActive != nil {
 		user.IsActive = *input.IsActive
 	}
+	if input.MonthlyDocumentLimit != nil {
+		if *input.MonthlyDocumentLimit < 0 {
+			return nil, domain.ErrInvalidDocumentLimit
+		}
+		user.MonthlyDocumentLimit = *input.MonthlyDocumentLimit
+	}
 
 	if err := s.repo.Update(ctx, user); err != nil {
 		return nil, err
 	}
+
+	if s.accountSecurity != nil && !strings.EqualFold(user.Email, oldEmail) {
+		// The address has already changed; a failed notice is logged, not returned
+		if err := s.accountSecurity.RecordEmailChange(ctx, EmailChangeInput{
+			User: user, OldEmail: oldEmail, ActorID: input.ActorID, Meta: input.Meta,
+		}); err != nil {
+			log.Printf("userService.Update: recording email change of user %s failed: %v", user.ID, err)
+		}
+	}
 	return user, nil
 }
 
 func (s *userService) Delete(ctx context.Context, tenantID, userID uuid.UUID) error {
+	// Only home users are deleted; a guest's row belongs to another tenant.
 	return s.repo.Delete(ctx, tenantID, userID)
 }
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/validator"
+)
+
+const (
+	// maxValidationRunDocuments caps how many documents one validation run may re-validate.
+	maxValidationRunDocuments = 10000
+	// validationRunTimeout bounds the background processing of one validation run.
+	validationRunTimeout = 30 * time.Minute
+	// validationRunProgressEvery is how many documents are processed between progress saves.
+	validationRunProgressEvery = 50
+)
+
+// ValidationRunService re-runs validation across every parsed document in a
+// collection. Runs are asynchronous: Start returns a pending run whose counters
+// and change list fill in as it runs.
+type ValidationRunService interface {
+	Start(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) (*domain.ValidationRun, error)
+	GetRun(ctx context.Context, tenantID, collectionID, runID, userID uuid.UUID, role domain.UserRole) (*domain.ValidationRun, error)
+	ListRuns(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ValidationRun, int, error)
+}
+
+type validationRunService struct {
+	runRepo       port.ValidationRunRepository
+	docRepo       port.DocumentRepository
+	validator     *validator.Engine
+	auditRepo     port.DocumentAuditRepository
+	summaryRepo   port.DocumentSummaryRepository
+	collectionSvc CollectionService
+}
+
+// NewValidationRunService creates a new ValidationRunService implementation.
+func NewValidationRunService(
+	runRepo port.ValidationRunRepository,
+	docRepo port.DocumentRepository,
+	validationEngine *validator.Engine,
+	auditRepo port.DocumentAuditRepository,
+	summaryRepo port.DocumentSummaryRepository,
+	collectionSvc CollectionService,
+) ValidationRunService {
+	return &validationRunService{
+		runRepo:       runRepo,
+		docRepo:       docRepo,
+		validator:     validationEngine,
+		auditRepo:     auditRepo,
+		summaryRepo:   summaryRepo,
+		collectionSvc: collectionSvc,
+	}
+}
+
+func (s *validationRunService) requirePerm(ctx context.Context, collectionID, userID uuid.UUID, role domain.UserRole, minLevel domain.CollectionPermission) error {
+	eff := s.collectionSvc.EffectivePermission(ctx, collectionID, userID, role)
+	if domain.CollectionPermLevel(eff) < domain.CollectionPermLevel(minLevel) {
+		return domain.ErrCollectionPermDenied
+	}
+	return nil
+}
+
+func (s *validationRunService) Start(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) (*domain.ValidationRun, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermEditor); err != nil {
+		return nil, err
+	}
+	if _, err := s.collectionSvc.GetByID(ctx, tenantID, collectionID, userID, role); err != nil {
+		return nil, err
+	}
+
+	run := &domain.ValidationRun{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		CreatedBy:    userID,
+		Status:       domain.ValidationRunStatusPending,
+		Changes:      json.RawMessage("[]"),
+	}
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		return nil, err
+	}
+
+	log.Printf("validationRunService.Start: run %s for collection %s (tenant %s, by user %s)",
+		run.ID, collectionID, tenantID, userID)
+	result := *run
+	go s.runInBackground(run)
+	return &result, nil
+}
+
+func (s *validationRunService) GetRun(ctx context.Context, tenantID, collectionID, runID, userID uuid.UUID, role domain.UserRole) (*domain.ValidationRun, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, err
+	}
+	run, err := s.runRepo.GetByID(ctx, tenantID, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.CollectionID != collectionID {
+		return nil, domain.ErrNotFound
+	}
+	return run, nil
+}
+
+func (s *validationRunService) ListRuns(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ValidationRun, int, error) {
+	if err := s.requirePerm(ctx, collectionID, userID, role, domain.CollectionPermViewer); err != nil {
+		return nil, 0, err
+	}
+	return s.runRepo.ListByCollection(ctx, tenantID, collectionID, offset, limit)
+}
+
+func (s *validationRunService) runInBackground(run *domain.ValidationRun) {
+	ctx, cancel := context.WithTimeout(context.Background(), validationRunTimeout)
+	defer cancel()
+	s.run(ctx, run)
+}
+
+func (s *validationRunService) run(ctx context.Context, run *domain.ValidationRun) {
+	run.Status = domain.ValidationRunStatusProcessing
+	s.saveProgress(ctx, run, nil)
+
+	targets, err := s.runRepo.ListTargets(ctx, run.TenantID, run.CollectionID, maxValidationRunDocuments+1)
+	if err == nil && len(targets) > maxValidationRunDocuments {
+		err = fmt.Errorf("collection has more than %d parsed documents", maxValidationRunDocuments)
+	}
+	if err == nil && s.validator == nil {
+		err = fmt.Errorf("validation engine not configured")
+	}
+	if err != nil {
+		log.Printf("validationRunService.run: run %s failed: %v", run.ID, err)
+		msg := err.Error()
+		run.Error = &msg
+		s.finish(ctx, run, domain.ValidationRunStatusFailed, nil)
+		return
+	}
+
+	run.TotalDocuments = len(targets)
+	changes := []domain.ValidationRunChange{}
+	for i := range targets {
+		if ctx.Err() != nil {
+			msg := "validation run timed out"
+			run.Error = &msg
+			s.finish(ctx, run, domain.ValidationRunStatusFailed, changes)
+			return
+		}
+
+		change, err := s.revalidate(ctx, run, &targets[i])
+		switch {
+		case err != nil:
+			log.Printf("validationRunService.run: run %s document %s: %v", run.ID, targets[i].DocumentID, err)
+			run.FailedCount++
+		case change != nil:
+			changes = append(changes, *change)
+			run.ChangedCount++
+		}
+		run.ProcessedCount++
+
+		if (i+1)%validationRunProgressEvery == 0 {
+			s.saveProgress(ctx, run, changes)
+		}
+	}
+
+	s.finish(ctx, run, domain.ValidationRunStatusCompleted, changes)
+	log.Printf("validationRunService.run: run %s completed (%d documents, %d changed, %d failed)",
+		run.ID, run.TotalDocuments, run.ChangedCount, run.FailedCount)
+}
+
+// revalidate validates one document and returns its status change, or nil when
+// neither status moved. Summary statuses are updated only for changed documents.
+func (s *validationRunService) revalidate(ctx context.Context, run *domain.ValidationRun, target *domain.ValidationRunTarget) (*domain.ValidationRunChange, error) {
+	if err := s.validator.ValidateDocument(ctx, run.TenantID, target.DocumentID); err != nil {
+		return nil, err
+	}
+	doc, err := s.docRepo.GetByID(ctx, run.TenantID, target.DocumentID)
+	if err != nil {
+		return nil, fmt.Errorf("re-fetching document: %w", err)
+	}
+
+	changes, _ := json.Marshal(map[string]string{
+		"validation_status":     string(doc.ValidationStatus),
+		"reconciliation_status": string(doc.ReconciliationStatus),
+		"trigger":               "validation_run",
+		"validation_run_id":     run.ID.String(),
+	})
+	entry := &domain.DocumentAuditEntry{
+		ID:         uuid.New(),
+		TenantID:   run.TenantID,
+		DocumentID: doc.ID,
+		UserID:     &run.CreatedBy,
+		Action:     string(domain.AuditDocumentValidationCompleted),
+		Changes:    changes,
+	}
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("validationRunService.revalidate: failed to write audit entry for %s: %v", doc.ID, err)
+	}
+
+	if doc.ValidationStatus == target.ValidationStatus && doc.ReconciliationStatus == target.ReconciliationStatus {
+		return nil, nil
+	}
+	if s.summaryRepo != nil {
+		if err := s.summaryRepo.UpdateStatuses(ctx, doc.ID, domain.SummaryStatusUpdate{
+			ParsingStatus:        doc.ParsingStatus,
+			ReviewStatus:         doc.ReviewStatus,
+			ValidationStatus:     doc.ValidationStatus,
+			ReconciliationStatus: doc.ReconciliationStatus,
+		}); err != nil {
+			log.Printf("validationRunService.revalidate: summary update failed for %s: %v", doc.ID, err)
+		}
+	}
+	return &domain.ValidationRunChange{
+		DocumentID:                   doc.ID,
+		DocumentName:                 target.Name,
+		PreviousValidationStatus:     target.ValidationStatus,
+		ValidationStatus:             doc.ValidationStatus,
+		PreviousReconciliationStatus: target.ReconciliationStatus,
+		ReconciliationStatus:         doc.ReconciliationStatus,
+	}, nil
+}
+
+// saveProgress persists the run's current state. Errors are logged, not returned:
+// a lost progress update must not abort the run. A nil changes keeps the stored list.
+func (s *validationRunService) saveProgress(ctx context.Context, run *domain.ValidationRun, changes []domain.ValidationRunChange) {
+	if changes != nil {
+		if data, err := json.Marshal(changes); err == nil {
+			run.Changes = data
+		}
+	}
+	if err := s.runRepo.UpdateProgress(ctx, run); err != nil {
+		log.Printf("validationRunService.saveProgress: failed to update run %s: %v", run.ID, err)
+	}
+}
+
+func (s *validationRunService) finish(ctx context.Context, run *domain.ValidationRun, status domain.ValidationRunStatus, changes []domain.ValidationRunChange) {
+	now := time.Now().UTC()
+	run.Status = status
+	run.CompletedAt = &now
+	s.saveProgress(ctx, run, changes)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// verificationReminderInterval is how often the worker looks for users due a reminder.
+const verificationReminderInterval = 10 * time.Minute
+
+// VerificationReminderWorker periodically sends the automatic verification
+// reminder to free-tier users who have not verified their email.
+type VerificationReminderWorker struct {
+	registrationSvc RegistrationService
+}
+
+// NewVerificationReminderWorker creates a new VerificationReminderWorker.
+func NewVerificationReminderWorker(registrationSvc RegistrationService) *VerificationReminderWorker {
+	return &VerificationReminderWorker{registrationSvc: registrationSvc}
+}
+
+// Start runs the reminder loop until ctx is canceled.
+func (w *VerificationReminderWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(verificationReminderInterval)
+	defer ticker.Stop()
+
+	log.Printf("verificationReminderWorker: started (interval=%s)", verificationReminderInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("verificationReminderWorker: shutdown complete")
+			return
+		case <-ticker.C:
+			w.Tick(ctx, time.Now().UTC())
+		}
+	}
+}
+
+// Tick sends every reminder that is due at now, one batch at a time.
+func (w *VerificationReminderWorker) Tick(ctx context.Context, now time.Time) {
+	for ctx.Err() == nil {
+		sent, err := w.registrationSvc.SendVerificationReminders(ctx, now)
+		if err != nil {
+			log.Printf("verificationReminderWorker: %v", err)
+			return
+		}
+		if sent > 0 {
+			log.Printf("verificationReminderWorker: sent %d reminders", sent)
+		}
+		if sent < reminderBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package shard routes tenant-scoped work to the Postgres cluster (shard) that
+// holds the tenant's data. Placement is tenants.db_cluster on the primary
+// cluster, the routing flag servers enforce and cmd/tenantmove sets. A tenant
+// that was never moved lives on the primary.
+package shard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// DefaultPrimary names the primary cluster when SATVOS_DB_CLUSTER is not set.
+const DefaultPrimary = "primary"
+
+// PrimaryName returns the shard name of the primary cluster: SATVOS_DB_CLUSTER,
+// or DefaultPrimary when that is not set.
+func PrimaryName(cluster string) string {
+	if cluster == "" {
+		return DefaultPrimary
+	}
+	return cluster
+}
+
+type cachedShard struct {
+	shard     string
+	expiresAt time.Time
+}
+
+// Resolver maps tenants to shard names. Placements are cached for ttl, so a
+// server picks up a move within that time.
+type Resolver struct {
+	primary    string
+	shards     map[string]bool
+	placements port.TenantShardRepository
+	ttl        time.Duration
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedShard
+}
+
+// NewResolver creates a Resolver for the primary cluster and the named shards.
+func NewResolver(primary string, shards []string, placements port.TenantShardRepository, ttl time.Duration) *Resolver {
+	known := map[string]bool{primary: true}
+	for _, s := range shards {
+		known[s] = true
+	}
+	return &Resolver{
+		primary:    primary,
+		shards:     known,
+		placements: placements,
+		ttl:        ttl,
+		cache:      make(map[uuid.UUID]cachedShard),
+	}
+}
+
+// Primary returns the name of the primary cluster.
+func (r *Resolver) Primary() string {
+	return r.primary
+}
+
+// Shards returns every shard name, the primary included, sorted.
+func (r *Resolver) Shards() []string {
+	names := make([]string, 0, len(r.shards))
+	for s := range r.shards {
+		names = append(names, s)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Shard returns the shard serving the tenant. A tenant placed on a shard this
+// server has no connection for gets domain.ErrShardUnavailable rather than the
+// primary, where its data is not kept.
+func (r *Resolver) Shard(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	r.mu.Lock()
+	cached, ok := r.cache[tenantID]
+	r.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.shard, nil
+	}
+
+	shard, err := r.placements.Resolve(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	if shard == "" {
+		shard = r.primary
+	}
+	if !r.shards[shard] {
+		return "", fmt.Errorf("shard %q: %w", shard, domain.ErrShardUnavailable)
+	}
+
+	r.mu.Lock()
+	r.cache[tenantID] = cachedShard{shard: shard, expiresAt: time.Now().Add(r.ttl)}
+	r.mu.Unlock()
+	return shard, nil
+}
+
+// Router holds one T per shard, such as its connection pool or a repository
+// built on it, and picks the one serving a tenant.
+type Router[T any] struct {
+	resolver *Resolver
+	targets  map[string]T
+}
+
+// NewRouter creates a Router over targets keyed by shard name. Every shard known
+// to the resolver needs a target.
+func NewRouter[T any](resolver *Resolver, targets map[string]T) (*Router[T], error) {
+	for _, s := range resolver.Shards() {
+		if _, ok := targets[s]; !ok {
+			return nil, fmt.Errorf("shard %q has no target", s)
+		}
+	}
+	return &Router[T]{resolver: resolver, targets: targets}, nil
+}
+
+// Map builds a Router whose targets are fn applied to r's, sharing r's resolver
+// and cache; for example a repository per shard from a pool per shard.
+func Map[T, U any](r *Router[T], fn func(T) U) *Router[U] {
+	targets := make(map[string]U, len(r.targets))
+	for s, t := range r.targets {
+		targets[s] = fn(t)
+	}
+	return &Router[U]{resolver: r.resolver, targets: targets}
+}
+
+// For returns the target of the shard serving the tenant.
+func (r *Router[T]) For(ctx context.Context, tenantID uuid.UUID) (T, error) {
+	shard, err := r.resolver.Shard(ctx, tenantID)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return r.targets[shard], nil
+}
+
+// Get returns the target of a shard by name.
+func (r *Router[T]) Get(shard string) (T, bool) {
+	t, ok := r.targets[shard]
+	return t, ok
+}
+
+// Each calls fn for every shard in name order, for work that spans tenants such
+// as background workers. It stops at the first error.
+func (r *Router[T]) Each(fn func(shard string, target T) error) error {
+	for _, s := range r.resolver.Shards() {
+		if err := fn(s, r.targets[s]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+
+	"satvos/internal/config"
+	"satvos/internal/httpclient"
+)
+
+// CheckBucketCompliance verifies that the default bucket and every regional
+// bucket have default server-side encryption and versioning enabled. It
+// returns every problem found, joined; nil means all buckets comply.
+func CheckBucketCompliance(ctx context.Context, cfg *config.S3Config) error {
+	var httpClient *http.Client
+	if !cfg.HTTP.IsZero() {
+		var err error
+		if httpClient, err = httpclient.New(cfg.HTTP); err != nil {
+			return fmt.Errorf("s3 http client: %w", err)
+		}
+	}
+
+	buckets := map[string]string{cfg.Region: cfg.Bucket}
+	for region, bucket := range cfg.RegionBuckets {
+		buckets[region] = bucket
+	}
+	regions := make([]string, 0, len(buckets))
+	for region := range buckets {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	var problems []error
+	for _, region := range regions {
+		c, err := newRegionClient(cfg, region, httpClient)
+		if err != nil {
+			return fmt.Errorf("region %s: %w", region, err)
+		}
+		problems = append(problems, c.checkCompliance(ctx, buckets[region])...)
+	}
+	return errors.Join(problems...)
+}
+
+func (c *s3Client) checkCompliance(ctx context.Context, bucket string) []error {
+	var problems []error
+
+	enc, err := c.client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: aws.String(bucket)})
+	var apiErr smithy.APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.ErrorCode() == "ServerSideEncryptionConfigurationNotFoundError":
+		problems = append(problems, fmt.Errorf("bucket %s: default encryption is not configured", bucket))
+	case err != nil:
+		problems = append(problems, fmt.Errorf("bucket %s: reading encryption: %w", bucket, err))
+	case enc.ServerSideEncryptionConfiguration == nil || len(enc.ServerSideEncryptionConfiguration.Rules) == 0:
+		problems = append(problems, fmt.Errorf("bucket %s: default encryption is not configured", bucket))
+	}
+
+	ver, err := c.client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: aws.String(bucket)})
+	switch {
+	case err != nil:
+		problems = append(problems, fmt.Errorf("bucket %s: reading versioning: %w", bucket, err))
+	case ver.Status != types.BucketVersioningStatusEnabled:
+		problems = append(problems, fmt.Errorf("bucket %s: versioning is not enabled", bucket))
+	}
+	return problems
+}
@@ -2,17 +2,25 @@ package s3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 
 	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/httpclient"
 	"satvos/internal/port"
 )
 
@@ -22,10 +30,43 @@ type s3Client struct {
 	uploader  *manager.Uploader
 }
 
-// NewS3Client creates a new S3-backed ObjectStorage implementation.
+// NewS3Client creates a new S3-backed ObjectStorage implementation. When
+// cfg.RegionBuckets is set, requests for each of those buckets go to a client
+// in the bucket's region; every other bucket uses cfg.Region.
 func NewS3Client(cfg *config.S3Config) (port.ObjectStorage, error) {
+	var httpClient *http.Client
+	if !cfg.HTTP.IsZero() {
+		var err error
+		if httpClient, err = httpclient.New(cfg.HTTP); err != nil {
+			return nil, fmt.Errorf("s3 http client: %w", err)
+		}
+	}
+
+	base, err := newRegionClient(cfg, cfg.Region, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.RegionBuckets) == 0 {
+		return base, nil
+	}
+
+	regional := &regionalClient{base: base, byBucket: map[string]*s3Client{}}
+	for region, bucket := range cfg.RegionBuckets {
+		client, err := newRegionClient(cfg, region, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("region %s: %w", region, err)
+		}
+		regional.byBucket[bucket] = client
+	}
+	return regional, nil
+}
+
+func newRegionClient(cfg *config.S3Config, region string, httpClient *http.Client) (*s3Client, error) {
 	var opts []func(*awsconfig.LoadOptions) error
-	opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	opts = append(opts, awsconfig.WithRegion(region))
+	if httpClient != nil {
+		opts = append(opts, awsconfig.WithHTTPClient(httpClient))
+	}
 
 	if cfg.AccessKey != "" && cfg.SecretKey != "" {
 		opts = append(opts, awsconfig.WithCredentialsProvider(
@@ -55,12 +96,14 @@ func NewS3Client(cfg *config.S3Config) (port.ObjectStorage, error) {
 }
 
 func (c *s3Client) Upload(ctx context.Context, input port.UploadInput) (*port.UploadOutput, error) {
-	result, err := c.uploader.Upload(ctx, &s3.PutObjectInput{
+	put := &s3.PutObjectInput{
 		Bucket:      aws.String(input.Bucket),
 		Key:         aws.String(input.Key),
 		Body:        input.Body,
 		ContentType: aws.String(input.ContentType),
-	})
+	}
+	put.ServerSideEncryption, put.SSEKMSKeyId = sseParams(input.Encryption)
+	result, err := c.uploader.Upload(ctx, put)
 	if err != nil {
 		return nil, fmt.Errorf("s3 upload: %w", err)
 	}
@@ -77,20 +120,44 @@ func (c *s3Client) Upload(ctx context.Context, input port.UploadInput) (*port.Up
 }
 
 func (c *s3Client) Download(ctx context.Context, bucket, key string) ([]byte, error) {
+	obj, err := c.Open(ctx, bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = obj.Body.Close() }()
+
+	data, err := io.ReadAll(obj.Body)
+	if err != nil {
+		// A body read that breaks mid-stream is a connection problem, never a permanent one.
+		return nil, fmt.Errorf("s3 download read: %w: %w", domain.ErrStorageUnavailable, err)
+	}
+	return data, nil
+}
+
+func (c *s3Client) Open(ctx context.Context, bucket, key string) (*port.ObjectReader, error) {
 	result, err := c.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
 	})
 	if err != nil {
+		if isTransient(err) {
+			return nil, fmt.Errorf("s3 download: %w: %w", domain.ErrStorageUnavailable, err)
+		}
 		return nil, fmt.Errorf("s3 download: %w", err)
 	}
-	defer func() { _ = result.Body.Close() }()
+	return &port.ObjectReader{
+		Body:        result.Body,
+		Size:        aws.ToInt64(result.ContentLength),
+		ContentType: aws.ToString(result.ContentType),
+	}, nil
+}
 
-	data, err := io.ReadAll(result.Body)
-	if err != nil {
-		return nil, fmt.Errorf("s3 download read: %w", err)
-	}
-	return data, nil
+// isTransient reports whether err is one the SDK's own retryer would retry:
+// connection errors, timeouts, throttling, and 5xx responses. Such errors reach us
+// only after the SDK's in-request retries are exhausted.
+func isTransient(err error) bool {
+	return retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary ||
+		retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary
 }
 
 func (c *s3Client) Delete(ctx context.Context, bucket, key string) error {
@@ -114,3 +181,175 @@ func (c *s3Client) GetPresignedURL(ctx context.Context, bucket, key string, expi
 	}
 	return result.URL, nil
 }
+
+func (c *s3Client) List(ctx context.Context, bucket, prefix string) ([]port.ObjectInfo, error) {
+	var objects []port.ObjectInfo
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("s3 list: %w", err)
+		}
+		for _, obj := range page.Contents {
+			objects = append(objects, port.ObjectInfo{
+				Key:  aws.ToString(obj.Key),
+				Size: aws.ToInt64(obj.Size),
+			})
+		}
+	}
+	return objects, nil
+}
+
+func (c *s3Client) Copy(ctx context.Context, bucket, srcKey, dstKey string, enc *port.Encryption) error {
+	in := &s3.CopyObjectInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(dstKey),
+		CopySource: aws.String(url.PathEscape(bucket + "/" + srcKey)),
+	}
+	in.ServerSideEncryption, in.SSEKMSKeyId = sseParams(enc)
+	if _, err := c.client.CopyObject(ctx, in); err != nil {
+		return fmt.Errorf("s3 copy: %w", err)
+	}
+	return nil
+}
+
+// sseParams returns the request's encryption headers; none for nil, which leaves
+// the object to the bucket's default encryption.
+func sseParams(enc *port.Encryption) (types.ServerSideEncryption, *string) {
+	if enc == nil {
+		return "", nil
+	}
+	switch enc.Algorithm {
+	case domain.StorageEncryptionS3:
+		return types.ServerSideEncryptionAes256, nil
+	case domain.StorageEncryptionKMS:
+		return types.ServerSideEncryptionAwsKms, aws.String(enc.KMSKeyARN)
+	}
+	return "", nil
+}
+
+// S3 has no per-rule lifecycle API: the bucket configuration is read, edited and
+// written back whole. Concurrent edits of the same bucket can lose a rule.
+
+func (c *s3Client) PutLifecycleRule(ctx context.Context, bucket string, rule port.LifecycleRule) error {
+	rules, err := c.lifecycleRules(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	rules = append(withoutRule(rules, rule.ID), types.LifecycleRule{
+		ID:     aws.String(rule.ID),
+		Status: types.ExpirationStatusEnabled,
+		Filter: &types.LifecycleRuleFilter{Prefix: aws.String(rule.Prefix)},
+		Transitions: []types.Transition{{
+			Days:         aws.Int32(int32(rule.TransitionDays)), //nolint:gosec // validated >= 30 by the tenant service
+			StorageClass: types.TransitionStorageClassStandardIa,
+		}},
+	})
+	return c.putLifecycleRules(ctx, bucket, rules)
+}
+
+func (c *s3Client) DeleteLifecycleRule(ctx context.Context, bucket, ruleID string) error {
+	rules, err := c.lifecycleRules(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	remaining := withoutRule(rules, ruleID)
+	if len(remaining) == len(rules) {
+		return nil
+	}
+	return c.putLifecycleRules(ctx, bucket, remaining)
+}
+
+func (c *s3Client) lifecycleRules(ctx context.Context, bucket string) ([]types.LifecycleRule, error) {
+	result, err := c.client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchLifecycleConfiguration" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("s3 get lifecycle: %w", err)
+	}
+	return result.Rules, nil
+}
+
+func (c *s3Client) putLifecycleRules(ctx context.Context, bucket string, rules []types.LifecycleRule) error {
+	// S3 rejects an empty configuration; removing the last rule deletes it instead.
+	if len(rules) == 0 {
+		if _, err := c.client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{Bucket: aws.String(bucket)}); err != nil {
+			return fmt.Errorf("s3 delete lifecycle: %w", err)
+		}
+		return nil
+	}
+	_, err := c.client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(bucket),
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{Rules: rules},
+	})
+	if err != nil {
+		return fmt.Errorf("s3 put lifecycle: %w", err)
+	}
+	return nil
+}
+
+func withoutRule(rules []types.LifecycleRule, id string) []types.LifecycleRule {
+	kept := make([]types.LifecycleRule, 0, len(rules))
+	for i := range rules {
+		if aws.ToString(rules[i].ID) != id {
+			kept = append(kept, rules[i])
+		}
+	}
+	return kept
+}
+
+// regionalClient routes each request to the client for the bucket's region.
+type regionalClient struct {
+	base     *s3Client
+	byBucket map[string]*s3Client
+}
+
+func (r *regionalClient) forBucket(bucket string) *s3Client {
+	if c, ok := r.byBucket[bucket]; ok {
+		return c
+	}
+	return r.base
+}
+
+func (r *regionalClient) Upload(ctx context.Context, input port.UploadInput) (*port.UploadOutput, error) {
+	return r.forBucket(input.Bucket).Upload(ctx, input)
+}
+
+func (r *regionalClient) Download(ctx context.Context, bucket, key string) ([]byte, error) {
+	return r.forBucket(bucket).Download(ctx, bucket, key)
+}
+
+func (r *regionalClient) Open(ctx context.Context, bucket, key string) (*port.ObjectReader, error) {
+	return r.forBucket(bucket).Open(ctx, bucket, key)
+}
+
+func (r *regionalClient) Delete(ctx context.Context, bucket, key string) error {
+	return r.forBucket(bucket).Delete(ctx, bucket, key)
+}
+
+func (r *regionalClient) GetPresignedURL(ctx context.Context, bucket, key string, expirySeconds int64) (string, error) {
+	return r.forBucket(bucket).GetPresignedURL(ctx, bucket, key, expirySeconds)
+}
+
+func (r *regionalClient) List(ctx context.Context, bucket, prefix string) ([]port.ObjectInfo, error) {
+	return r.forBucket(bucket).List(ctx, bucket, prefix)
+}
+
+func (r *regionalClient) Copy(ctx context.Context, bucket, srcKey, dstKey string, enc *port.Encryption) error {
+	return r.forBucket(bucket).Copy(ctx, bucket, srcKey, dstKey, enc)
+}
+
+func (r *regionalClient) PutLifecycleRule(ctx context.Context, bucket string, rule port.LifecycleRule) error {
+	return r.forBucket(bucket).PutLifecycleRule(ctx, bucket, rule)
+}
+
+func (r *regionalClient) DeleteLifecycleRule(ctx context.Context, bucket, ruleID string) error {
+	return r.forBucket(bucket).DeleteLifecycleRule(ctx, bucket, ruleID)
+}
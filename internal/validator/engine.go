@@ -53,26 +53,73 @@ func (e *Engine) ValidateDocument(ctx context.Context, tenantID, docID uuid.UUID
 		return fmt.Errorf("getting document: %w", err)
 	}
 
-	// Ensure built-in rules exist for this tenant/document type
-	if err := e.EnsureBuiltinRules(ctx, tenantID, doc.DocumentType, doc.CreatedBy); err != nil {
-		return fmt.Errorf("ensuring builtin rules: %w", err)
-	}
-
-	// Load all active rules
 	var collectionID *uuid.UUID
 	if doc.CollectionID != (uuid.UUID{}) {
 		collectionID = &doc.CollectionID
 	}
-	rules, err := e.ruleRepo.ListByDocumentType(ctx, tenantID, doc.DocumentType, collectionID)
+	ev, _, err := e.evaluate(ctx, tenantID, docID, doc.DocumentType, collectionID, doc.CreatedBy, doc.StructuredData)
+	if err != nil {
+		return err
+	}
+
+	// Marshal results to JSON
+	resultsJSON, err := json.Marshal(ev.results)
+	if err != nil {
+		return fmt.Errorf("marshaling validation results: %w", err)
+	}
+
+	doc.ValidationStatus = ev.status
+	doc.ValidationResults = resultsJSON
+	doc.ReconciliationStatus = ev.reconStatus
+	if err := e.docRepo.UpdateValidationResults(ctx, doc); err != nil {
+		return fmt.Errorf("updating validation results: %w", err)
+	}
+
+	log.Printf("validator.Engine: document %s validated — status=%s, reconciliation=%s, results=%d", docID, ev.status, ev.reconStatus, len(ev.results))
+	return nil
+}
+
+// Preview validates structured data that has no document behind it, using the
+// tenant's rules, and returns the results without storing them. DocumentID in
+// the response is the zero UUID.
+func (e *Engine) Preview(ctx context.Context, tenantID, userID uuid.UUID, documentType string, structuredData, confidenceScores json.RawMessage) (*ValidationResponse, error) {
+	ev, rules, err := e.evaluate(ctx, tenantID, uuid.Nil, documentType, nil, userID, structuredData)
+	if err != nil {
+		return nil, err
+	}
+	return buildValidationResponse(uuid.Nil, ev.status, ev.reconStatus, ev.results, rules, confidenceScores), nil
+}
+
+// evaluation is the outcome of running a tenant's rules against one invoice.
+type evaluation struct {
+	results     []ValidationResultEntry
+	status      domain.ValidationStatus
+	reconStatus domain.ReconciliationStatus
+}
+
+// evaluate runs the active rules for a tenant, document type and collection
+// against structured data. It returns the rules alongside the results so callers
+// can look up severities.
+func (e *Engine) evaluate(ctx context.Context, tenantID, docID uuid.UUID, documentType string, collectionID *uuid.UUID, createdBy uuid.UUID, structuredData json.RawMessage) (*evaluation, []domain.DocumentValidationRule, error) {
+	// Ensure built-in rules exist for this tenant/document type
+	if err := e.EnsureBuiltinRules(ctx, tenantID, documentType, createdBy); err != nil {
+		return nil, nil, fmt.Errorf("ensuring builtin rules: %w", err)
+	}
+
+	// Load all active rules
+	rules, err := e.ruleRepo.ListByDocumentType(ctx, tenantID, documentType, collectionID)
 	if err != nil {
-		return fmt.Errorf("loading rules: %w", err)
+		return nil, nil, fmt.Errorf("loading rules: %w", err)
 	}
 
 	// Parse structured data into typed struct
 	var inv invoice.GSTInvoice
-	if err := json.Unmarshal(doc.StructuredData, &inv); err != nil {
-		return fmt.Errorf("unmarshaling structured_data: %w", err)
+	if err := json.Unmarshal(structuredData, &inv); err != nil {
+		return nil, nil, fmt.Errorf("unmarshaling structured_data: %w", err)
 	}
+	// Map parsed state names/abbreviations to canonical GST state codes; the
+	// stored structured_data is not modified.
+	invoice.NormalizeStates(&inv)
 
 	ctx = invoice.WithValidationContext(ctx, tenantID, docID)
 
@@ -123,12 +170,6 @@ func (e *Engine) ValidateDocument(ctx context.Context, tenantID, docID uuid.UUID
 		// Custom (non-builtin) rules are skipped for now — extensible via CustomRuleExecutor.
 	}
 
-	// Marshal results to JSON
-	resultsJSON, err := json.Marshal(allResults)
-	if err != nil {
-		return fmt.Errorf("marshaling validation results: %w", err)
-	}
-
 	// Compute validation_status
 	var status domain.ValidationStatus
 	switch {
@@ -151,15 +192,7 @@ func (e *Engine) ValidateDocument(ctx context.Context, tenantID, docID uuid.UUID
 		reconStatus = domain.ReconciliationStatusValid
 	}
 
-	doc.ValidationStatus = status
-	doc.ValidationResults = resultsJSON
-	doc.ReconciliationStatus = reconStatus
-	if err := e.docRepo.UpdateValidationResults(ctx, doc); err != nil {
-		return fmt.Errorf("updating validation results: %w", err)
-	}
-
-	log.Printf("validator.Engine: document %s validated — status=%s, reconciliation=%s, results=%d", docID, status, reconStatus, len(allResults))
-	return nil
+	return &evaluation{results: allResults, status: status, reconStatus: reconStatus}, rules, nil
 }
 
 // EnsureBuiltinRules lazy-seeds all built-in rules for a tenant+document type combination.
@@ -225,13 +258,27 @@ func (e *Engine) GetValidation(ctx context.Context, tenantID, docID uuid.UUID) (
 	if err != nil {
 		return nil, fmt.Errorf("loading rules: %w", err)
 	}
+
+	return buildValidationResponse(docID, doc.ValidationStatus, doc.ReconciliationStatus, results, rulesList, doc.ConfidenceScores), nil
+}
+
+// buildValidationResponse summarizes validation results against the rules that
+// produced them.
+func buildValidationResponse(
+	docID uuid.UUID,
+	status domain.ValidationStatus,
+	reconStatus domain.ReconciliationStatus,
+	results []ValidationResultEntry,
+	rulesList []domain.DocumentValidationRule,
+	confidenceScores json.RawMessage,
+) *ValidationResponse {
 	rulesMap := make(map[string]*domain.DocumentValidationRule, len(rulesList))
 	for i := range rulesList {
 		rulesMap[rulesList[i].ID.String()] = &rulesList[i]
 	}
 
 	// Parse confidence scores
-	confidenceMap := flattenConfidenceScores(doc.ConfidenceScores)
+	confidenceMap := flattenConfidenceScores(confidenceScores)
 
 	// Compute field statuses
 	fieldStatuses := ComputeFieldStatuses(results, rulesMap, confidenceMap)
@@ -287,14 +334,14 @@ func (e *Engine) GetValidation(ctx context.Context, tenantID, docID uuid.UUID) (
 
 	return &ValidationResponse{
 		DocumentID:       docID,
-		ValidationStatus: doc.ValidationStatus,
+		ValidationStatus: status,
 		Summary: ValidationSummary{
 			Total:    len(results),
 			Passed:   passed,
 			Errors:   errorCount,
 			Warnings: warningCount,
 		},
-		ReconciliationStatus: doc.ReconciliationStatus,
+		ReconciliationStatus: reconStatus,
 		ReconciliationSummary: ReconciliationSummary{
 			Total:    reconPassed + reconErrors + reconWarnings,
 			Passed:   reconPassed,
@@ -303,7 +350,7 @@ func (e *Engine) GetValidation(ctx context.Context, tenantID, docID uuid.UUID) (
 		},
 		Results:       resultItems,
 		FieldStatuses: fieldStatuses,
-	}, nil
+	}
 }
 
 // ValidationResponse is the API response for GET /documents/:id/validation.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"satvos/internal/domain"
 )
@@ -63,6 +64,14 @@ func hsnExistsValidator(lookup *HSNLookup) func(context.Context, *GSTInvoice) []
 
 func hsnRateValidator(lookup *HSNLookup) func(context.Context, *GSTInvoice) []ValidationResult {
 	return func(_ context.Context, inv *GSTInvoice) []ValidationResult {
+		// Rates change over time, so check against the rate in force on the invoice
+		// date. Fall back to today when the date is missing or unparseable.
+		asOf := time.Now().UTC()
+		if d, err := parseDate(inv.Invoice.InvoiceDate); err == nil {
+			asOf = d
+		}
+		asOfStr := asOf.Format("2006-01-02")
+
 		results := make([]ValidationResult, 0, len(inv.LineItems))
 		for i := range inv.LineItems {
 			item := &inv.LineItems[i]
@@ -90,7 +99,14 @@ func hsnRateValidator(lookup *HSNLookup) func(context.Context, *GSTInvoice) []Va
 				effectiveRate = item.CGSTRate + item.SGSTRate
 			}
 
-			matched, validRates := lookup.RateMatches(item.HSNSACCode, effectiveRate)
+			matched, validRates := lookup.RateMatchesOn(item.HSNSACCode, effectiveRate, asOf)
+			if len(validRates) == 0 {
+				results = append(results, ValidationResult{
+					Passed: true, FieldPath: fp,
+					Message: fmt.Sprintf("Cross-field: HSN Code GST Rate Match: no rate for HSN %q in force on %s, skipping rate check", item.HSNSACCode, asOfStr),
+				})
+				continue
+			}
 
 			if matched {
 				results = append(results, ValidationResult{
@@ -98,7 +114,7 @@ func hsnRateValidator(lookup *HSNLookup) func(context.Context, *GSTInvoice) []Va
 					FieldPath:     fp,
 					ExpectedValue: formatExpectedRates(validRates),
 					ActualValue:   fmtf(effectiveRate) + "%",
-					Message:       fmt.Sprintf("Cross-field: HSN Code GST Rate Match: %s rate matches HSN %s", fp, item.HSNSACCode),
+					Message:       fmt.Sprintf("Cross-field: HSN Code GST Rate Match: %s rate matches HSN %s as of %s", fp, item.HSNSACCode, asOfStr),
 				})
 			} else {
 				results = append(results, ValidationResult{
@@ -106,7 +122,7 @@ func hsnRateValidator(lookup *HSNLookup) func(context.Context, *GSTInvoice) []Va
 					FieldPath:     fp,
 					ExpectedValue: formatExpectedRates(validRates),
 					ActualValue:   fmtf(effectiveRate) + "%",
-					Message:       fmt.Sprintf("Cross-field: HSN Code GST Rate Match: %s rate %s%% does not match expected rates for HSN %s", fp, fmtf(effectiveRate), item.HSNSACCode),
+					Message:       fmt.Sprintf("Cross-field: HSN Code GST Rate Match: %s rate %s%% does not match expected rates for HSN %s as of %s", fp, fmtf(effectiveRate), item.HSNSACCode, asOfStr),
 				})
 			}
 		}
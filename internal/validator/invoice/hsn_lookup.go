@@ -2,17 +2,31 @@ package invoice
 
 import (
 	"math"
+	"time"
 
 	"satvos/internal/port"
 )
 
-// HSNRateEntry holds a valid GST rate and optional condition for an HSN code.
+// HSNRateEntry holds a valid GST rate and optional condition for an HSN code,
+// along with the dates between which the rate applies.
 type HSNRateEntry struct {
 	Rate          float64
 	ConditionDesc string
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time // nil = still in force
+}
+
+// InForceOn reports whether the rate applied on the given date. Both bounds are inclusive.
+func (r *HSNRateEntry) InForceOn(date time.Time) bool {
+	day := truncateToDay(date)
+	if day.Before(truncateToDay(r.EffectiveFrom)) {
+		return false
+	}
+	return r.EffectiveTo == nil || !day.After(truncateToDay(*r.EffectiveTo))
 }
 
 // HSNLookup provides fast in-memory lookups for HSN code existence and rate validation.
+// It holds the full rate history so rates can be checked as of an invoice date.
 // It is immutable after construction and safe for concurrent access.
 type HSNLookup struct {
 	byCode map[string][]HSNRateEntry
@@ -26,6 +40,8 @@ func NewHSNLookup(entries []port.HSNEntry) *HSNLookup {
 		m[e.Code] = append(m[e.Code], HSNRateEntry{
 			Rate:          e.GSTRate,
 			ConditionDesc: e.ConditionDesc,
+			EffectiveFrom: e.EffectiveFrom,
+			EffectiveTo:   e.EffectiveTo,
 		})
 	}
 	return &HSNLookup{byCode: m}
@@ -34,31 +50,57 @@ func NewHSNLookup(entries []port.HSNEntry) *HSNLookup {
 // Exists returns true if the HSN code (or a prefix of it) is in the master list.
 // It checks exact match first, then falls back from 8→6→4 digit prefixes.
 func (h *HSNLookup) Exists(code string) bool {
-	if len(h.byCode) == 0 || code == "" {
-		return false
+	return h.history(code) != nil
+}
+
+// Rates returns the rate entries in force today for the given HSN code, with prefix fallback.
+func (h *HSNLookup) Rates(code string) []HSNRateEntry {
+	return h.RatesOn(code, time.Now().UTC())
+}
+
+// RatesOn returns the rate entries in force on date for the given HSN code, with prefix fallback.
+// The prefix is chosen by existence in the master, so a code whose rates all lapsed
+// before date returns nil rather than falling back to its parent.
+func (h *HSNLookup) RatesOn(code string, date time.Time) []HSNRateEntry {
+	var rates []HSNRateEntry
+	for _, r := range h.history(code) {
+		if r.InForceOn(date) {
+			rates = append(rates, r)
+		}
 	}
-	if _, ok := h.byCode[code]; ok {
-		return true
+	return rates
+}
+
+// RateMatches checks if the given GST rate matches any rate in force today for this HSN code.
+// Returns whether a match was found and the list of valid rates.
+func (h *HSNLookup) RateMatches(code string, gstRate float64) (matched bool, validRates []HSNRateEntry) {
+	return h.RateMatchesOn(code, gstRate, time.Now().UTC())
+}
+
+// RateMatchesOn checks if the given GST rate matches any rate in force on date for this HSN code.
+// Returns whether a match was found and the list of rates in force on that date.
+func (h *HSNLookup) RateMatchesOn(code string, gstRate float64, date time.Time) (matched bool, validRates []HSNRateEntry) {
+	validRates = h.RatesOn(code, date)
+	if len(validRates) == 0 {
+		return false, nil
 	}
-	// Hierarchical prefix fallback: try shorter prefixes
-	for _, prefixLen := range []int{6, 4} {
-		if len(code) > prefixLen {
-			if _, ok := h.byCode[code[:prefixLen]]; ok {
-				return true
-			}
+	for idx := range validRates {
+		if math.Abs(validRates[idx].Rate-gstRate) < 0.01 {
+			return true, validRates
 		}
 	}
-	return false
+	return false, validRates
 }
 
-// Rates returns valid rate entries for the given HSN code, with prefix fallback.
-func (h *HSNLookup) Rates(code string) []HSNRateEntry {
+// history returns every rate entry ever recorded for the code or its nearest prefix.
+func (h *HSNLookup) history(code string) []HSNRateEntry {
 	if len(h.byCode) == 0 || code == "" {
 		return nil
 	}
 	if rates, ok := h.byCode[code]; ok {
 		return rates
 	}
+	// Hierarchical prefix fallback: try shorter prefixes
 	for _, prefixLen := range []int{6, 4} {
 		if len(code) > prefixLen {
 			if rates, ok := h.byCode[code[:prefixLen]]; ok {
@@ -69,17 +111,6 @@ func (h *HSNLookup) Rates(code string) []HSNRateEntry {
 	return nil
 }
 
-// RateMatches checks if the given GST rate matches any valid rate for this HSN code.
-// Returns whether a match was found and the list of valid rates.
-func (h *HSNLookup) RateMatches(code string, gstRate float64) (matched bool, validRates []HSNRateEntry) {
-	validRates = h.Rates(code)
-	if len(validRates) == 0 {
-		return false, nil
-	}
-	for idx := range validRates {
-		if math.Abs(validRates[idx].Rate-gstRate) < 0.01 {
-			return true, validRates
-		}
-	}
-	return false, validRates
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 }
@@ -0,0 +1,128 @@
+package invoice
+
+import (
+	"math"
+	"time"
+)
+
+// RateProposalStatus says what ApplyHSNRates did with a line item's GST rate.
+type RateProposalStatus string
+
+const (
+	// RateApplied means the HSN code's one rate in force replaced the line's rate.
+	RateApplied RateProposalStatus = "applied"
+	// RateUnchanged means the line's rate was already one of the rates in force.
+	RateUnchanged RateProposalStatus = "unchanged"
+	// RateAmbiguous means several rates are in force (e.g. conditional rates), so
+	// the reviewer has to pick one of Rates.
+	RateAmbiguous RateProposalStatus = "ambiguous"
+	// RateTaxTypeUnknown means a party's state is missing and the line has no tax
+	// yet, so CGST+SGST and IGST can't be told apart.
+	RateTaxTypeUnknown RateProposalStatus = "tax_type_unknown"
+	// RateNotFound means the HSN code has no rate in force on the invoice date.
+	RateNotFound RateProposalStatus = "not_found"
+	// RateMissingCode means the line has no HSN/SAC code.
+	RateMissingCode RateProposalStatus = "missing_code"
+)
+
+// RateProposal is the outcome of ApplyHSNRates for one line item.
+type RateProposal struct {
+	Index      int                `json:"index"`
+	HSNSACCode string             `json:"hsn_sac_code"`
+	Status     RateProposalStatus `json:"status"`
+	// Rates are the GST rates in force for the code on the invoice date.
+	Rates []HSNRateOption `json:"rates"`
+}
+
+// HSNRateOption is a GST rate in force for an HSN code, with the condition it applies under.
+type HSNRateOption struct {
+	Rate          float64 `json:"rate"`
+	ConditionDesc string  `json:"condition_desc,omitempty"`
+}
+
+// ApplyHSNRates sets each line item's GST rate from the HSN master as of the
+// invoice date (today if missing or unparseable), then recomputes the tax
+// amounts and totals of every line item and the invoice totals from the
+// taxable amounts. Same-state supplies are split evenly into CGST and SGST,
+// others charged as IGST; when a party's state is unknown the line's current
+// tax type is kept. Lines whose rate can't be decided keep their rates.
+func ApplyHSNRates(inv *GSTInvoice, lookup *HSNLookup) []RateProposal {
+	asOf := time.Now().UTC()
+	if d, err := parseDate(inv.Invoice.InvoiceDate); err == nil {
+		asOf = d
+	}
+	// Compare normalised states, as the tax type validators do
+	seller, buyer := inv.Seller, inv.Buyer
+	normalizePartyState(&seller)
+	normalizePartyState(&buyer)
+	var intrastate *bool
+	if seller.StateCode != "" && buyer.StateCode != "" {
+		same := seller.StateCode == buyer.StateCode
+		intrastate = &same
+	}
+
+	proposals := make([]RateProposal, 0, len(inv.LineItems))
+	for i := range inv.LineItems {
+		item := &inv.LineItems[i]
+		p := RateProposal{Index: i, HSNSACCode: item.HSNSACCode, Rates: []HSNRateOption{}}
+		p.Status = applyLineRate(item, lookup, asOf, intrastate, &p)
+		proposals = append(proposals, p)
+
+		item.CGSTAmount = roundPaise(item.TaxableAmount * item.CGSTRate / 100)
+		item.SGSTAmount = roundPaise(item.TaxableAmount * item.SGSTRate / 100)
+		item.IGSTAmount = roundPaise(item.TaxableAmount * item.IGSTRate / 100)
+		item.Total = roundPaise(item.TaxableAmount + item.CGSTAmount + item.SGSTAmount + item.IGSTAmount)
+	}
+	recomputeTotals(inv)
+	return proposals
+}
+
+func applyLineRate(item *LineItem, lookup *HSNLookup, asOf time.Time, intrastate *bool, p *RateProposal) RateProposalStatus {
+	if item.HSNSACCode == "" {
+		return RateMissingCode
+	}
+	entries := lookup.RatesOn(item.HSNSACCode, asOf)
+	if len(entries) == 0 {
+		return RateNotFound
+	}
+	distinct := make(map[float64]bool, len(entries))
+	for idx := range entries {
+		p.Rates = append(p.Rates, HSNRateOption{Rate: entries[idx].Rate, ConditionDesc: entries[idx].ConditionDesc})
+		distinct[entries[idx].Rate] = true
+	}
+
+	current := item.CGSTRate + item.SGSTRate + item.IGSTRate
+	for idx := range entries {
+		if math.Abs(entries[idx].Rate-current) < 0.01 {
+			return RateUnchanged
+		}
+	}
+	if len(distinct) > 1 {
+		return RateAmbiguous
+	}
+
+	rate := entries[0].Rate
+	switch {
+	case intrastate != nil:
+		if *intrastate {
+			setIntrastateRate(item, rate)
+		} else {
+			setInterstateRate(item, rate)
+		}
+	case item.IGSTRate > 0:
+		setInterstateRate(item, rate)
+	case item.CGSTRate > 0 || item.SGSTRate > 0:
+		setIntrastateRate(item, rate)
+	default:
+		return RateTaxTypeUnknown
+	}
+	return RateApplied
+}
+
+func setIntrastateRate(item *LineItem, rate float64) {
+	item.CGSTRate, item.SGSTRate, item.IGSTRate = rate/2, rate/2, 0
+}
+
+func setInterstateRate(item *LineItem, rate float64) {
+	item.CGSTRate, item.SGSTRate, item.IGSTRate = 0, 0, rate
+}
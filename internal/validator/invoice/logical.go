@@ -220,5 +220,10 @@ func LogicalValidators() []*logicalValidator {
 				return results
 			},
 		},
+		{
+			ruleKey: "logic.invoice.script", ruleName: "Logical: Key Fields in Latin Script",
+			severity: domain.ValidationSeverityWarning,
+			validate: validateKeyFieldScript,
+		},
 	}
 }
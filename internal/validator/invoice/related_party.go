@@ -0,0 +1,80 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// RelatedPartyValidator returns a validator that flags invoices whose seller
+// GSTIN the tenant registered as its own or a group company's. Such supplies
+// are reported separately in GST returns and audits, so the warning marks them
+// rather than an error in the invoice.
+func RelatedPartyValidator(finder port.RelatedPartyFinder) *BuiltinValidator {
+	return &BuiltinValidator{
+		key:      "logic.seller.related_party",
+		name:     "Logical: Related Party Seller",
+		ruleType: domain.ValidationRuleCustom,
+		sev:      domain.ValidationSeverityWarning,
+		fn:       relatedPartyValidator(finder),
+	}
+}
+
+func relatedPartyValidator(finder port.RelatedPartyFinder) func(context.Context, *GSTInvoice) []ValidationResult {
+	return func(ctx context.Context, inv *GSTInvoice) []ValidationResult {
+		gstin := strings.ToUpper(strings.TrimSpace(inv.Seller.GSTIN))
+		if gstin == "" {
+			return []ValidationResult{{
+				Passed:    true,
+				FieldPath: "seller.gstin",
+				Message:   "Logical: Related Party Seller: seller GSTIN is empty, skipping related party check",
+			}}
+		}
+
+		tenantID, ok := TenantIDFromContext(ctx)
+		if !ok {
+			return []ValidationResult{{
+				Passed:    true,
+				FieldPath: "seller.gstin",
+				Message:   "Logical: Related Party Seller: validation context missing, skipping related party check",
+			}}
+		}
+
+		party, err := finder.FindRelatedParty(ctx, tenantID, gstin)
+		if errors.Is(err, domain.ErrNotFound) {
+			return []ValidationResult{{
+				Passed:        true,
+				FieldPath:     "seller.gstin",
+				ExpectedValue: "unrelated seller",
+				ActualValue:   gstin,
+				Message:       "Logical: Related Party Seller: seller is not a registered related party",
+			}}
+		}
+		if err != nil {
+			return []ValidationResult{{
+				Passed:    true,
+				FieldPath: "seller.gstin",
+				Message:   "Logical: Related Party Seller: related party check unavailable",
+			}}
+		}
+
+		what := "a group company"
+		if party.Relationship == domain.RelatedPartyOwn {
+			what = "another registration of your own"
+		}
+		return []ValidationResult{{
+			Passed:        false,
+			FieldPath:     "seller.gstin",
+			ExpectedValue: "unrelated seller",
+			ActualValue:   fmt.Sprintf("%s (%s)", gstin, party.Relationship),
+			Message: fmt.Sprintf(
+				"Logical: Related Party Seller: seller %s (%s) is %s; report this supply separately in GST returns",
+				gstin, party.Name, what,
+			),
+		}}
+	}
+}
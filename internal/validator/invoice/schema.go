@@ -0,0 +1,72 @@
+package invoice
+
+// FieldDescriptions documents every field of GSTInvoice by path, in the notation
+// of validation field paths ("seller.gstin"), with "[]" standing for any line
+// item index. Objects and arrays are described too. A unit test fails when a
+// field is added to GSTInvoice without a description here.
+var FieldDescriptions = map[string]string{
+	"invoice":                        "Invoice header",
+	"invoice.invoice_number":         "Invoice number as printed by the seller",
+	"invoice.invoice_date":           "Invoice date, DD-MM-YYYY",
+	"invoice.due_date":               "Payment due date, DD-MM-YYYY",
+	"invoice.invoice_type":           "Invoice type as printed, e.g. Tax Invoice or Bill of Supply",
+	"invoice.currency":               "ISO 4217 currency code, e.g. INR",
+	"invoice.place_of_supply":        "Place of supply (state name or code); decides intrastate vs interstate tax",
+	"invoice.reverse_charge":         "Whether tax is payable on reverse charge",
+	"invoice.irn":                    "e-Invoice Reference Number, a 64-character hex hash",
+	"invoice.acknowledgement_number": "e-Invoice acknowledgement number from the IRP",
+	"invoice.acknowledgement_date":   "e-Invoice acknowledgement date",
+	"invoice.qr_code_data":           "Raw payload of the e-invoice QR code",
+
+	"seller":            "Supplier issuing the invoice",
+	"seller.name":       "Seller's legal or trade name",
+	"seller.address":    "Seller's address",
+	"seller.gstin":      "Seller's 15-character GSTIN",
+	"seller.pan":        "Seller's 10-character PAN",
+	"seller.state":      "Seller's state name",
+	"seller.state_code": "Seller's 2-digit GST state code (01-38)",
+
+	"buyer":            "Recipient of the invoice",
+	"buyer.name":       "Buyer's legal or trade name",
+	"buyer.address":    "Buyer's address",
+	"buyer.gstin":      "Buyer's 15-character GSTIN; empty for unregistered buyers",
+	"buyer.pan":        "Buyer's 10-character PAN",
+	"buyer.state":      "Buyer's state name",
+	"buyer.state_code": "Buyer's 2-digit GST state code (01-38)",
+
+	"line_items":                  "Every line item on the invoice, across all pages and sections",
+	"line_items[].description":    "Item or service description",
+	"line_items[].hsn_sac_code":   "HSN code for goods or SAC code for services",
+	"line_items[].quantity":       "Quantity",
+	"line_items[].unit":           "Unit of measure, e.g. NOS or KGS",
+	"line_items[].unit_price":     "Price per unit before tax",
+	"line_items[].discount":       "Discount on the line",
+	"line_items[].taxable_amount": "Taxable value of the line after discount",
+	"line_items[].cgst_rate":      "CGST rate in percent (intrastate supplies)",
+	"line_items[].cgst_amount":    "CGST amount",
+	"line_items[].sgst_rate":      "SGST/UTGST rate in percent (intrastate supplies)",
+	"line_items[].sgst_amount":    "SGST/UTGST amount",
+	"line_items[].igst_rate":      "IGST rate in percent (interstate supplies)",
+	"line_items[].igst_amount":    "IGST amount",
+	"line_items[].total":          "Line total including tax",
+
+	"totals":                 "Invoice totals",
+	"totals.subtotal":        "Sum of line values before discount and tax",
+	"totals.total_discount":  "Total discount",
+	"totals.taxable_amount":  "Total taxable value (subtotal minus discount)",
+	"totals.cgst":            "Total CGST",
+	"totals.sgst":            "Total SGST/UTGST",
+	"totals.igst":            "Total IGST",
+	"totals.cess":            "Total cess",
+	"totals.round_off":       "Rounding adjustment",
+	"totals.total":           "Grand total payable",
+	"totals.amount_in_words": "Grand total in words, as printed",
+
+	"payment":                "Payment details",
+	"payment.bank_name":      "Seller's bank name",
+	"payment.account_number": "Seller's bank account number",
+	"payment.ifsc_code":      "11-character IFSC of the seller's bank branch",
+	"payment.payment_terms":  "Payment terms as printed",
+
+	"notes": "Free-text notes or terms printed on the invoice",
+}
@@ -0,0 +1,63 @@
+package invoice
+
+import (
+	"fmt"
+	"strings"
+
+	"satvos/internal/language"
+)
+
+// InvoiceText joins the free text of an invoice: party names and addresses,
+// line item descriptions, notes and the amount in words. It is what the
+// invoice's language is told from after parsing.
+func InvoiceText(d *GSTInvoice) string {
+	parts := []string{
+		d.Seller.Name, d.Seller.Address, d.Buyer.Name, d.Buyer.Address,
+		d.Totals.AmountInWords, d.Notes,
+	}
+	for i := range d.LineItems {
+		parts = append(parts, d.LineItems[i].Description)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// scriptKeyFields are the fields matched and reconciled on, which must be
+// extracted in Latin script whatever the invoice's language.
+func scriptKeyFields(d *GSTInvoice) []struct{ path, value string } {
+	return []struct{ path, value string }{
+		{"invoice.invoice_number", d.Invoice.InvoiceNumber},
+		{"invoice.invoice_date", d.Invoice.InvoiceDate},
+		{"seller.name", d.Seller.Name},
+		{"seller.gstin", d.Seller.GSTIN},
+		{"buyer.name", d.Buyer.Name},
+		{"buyer.gstin", d.Buyer.GSTIN},
+	}
+}
+
+// validateKeyFieldScript flags key fields left in an Indian script, and empty
+// key fields on an invoice in an Indian script, where the model may have
+// failed to read them.
+func validateKeyFieldScript(d *GSTInvoice) []ValidationResult {
+	const name = "Logical: Key Fields in Latin Script"
+	invoiceScript := language.FirstIndicScript(InvoiceText(d))
+	fields := scriptKeyFields(d)
+	results := make([]ValidationResult, 0, len(fields))
+	for _, f := range fields {
+		r := ValidationResult{Passed: true, FieldPath: f.path, ActualValue: f.value}
+		switch sc := language.FirstIndicScript(f.value); {
+		case sc != nil:
+			r.Passed = false
+			r.ExpectedValue = "Latin script"
+			r.Message = fmt.Sprintf("%s: %s is in %s script and was not transliterated", name, f.path, sc.Name)
+		case strings.TrimSpace(f.value) == "" && invoiceScript != nil:
+			r.Passed = false
+			r.ExpectedValue = "non-empty"
+			r.Message = fmt.Sprintf("%s: %s is empty on an invoice in %s script and may not have been extracted",
+				name, f.path, invoiceScript.Name)
+		default:
+			r.Message = fmt.Sprintf("%s: %s is in Latin script", name, f.path)
+		}
+		results = append(results, r)
+	}
+	return results
+}
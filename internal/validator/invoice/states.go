@@ -0,0 +1,169 @@
+package invoice
+
+import (
+	"regexp"
+	"strings"
+)
+
+// State is an entry in the GST state code master.
+type State struct {
+	Code    string   // 2-digit GST state code
+	Name    string   // canonical name
+	Aliases []string // abbreviations, former names and common misspellings
+}
+
+// States is the GST state code master (codes 01-38, matching fmt.*.state_code).
+var States = []State{
+	{Code: "01", Name: "Jammu and Kashmir", Aliases: []string{"JK", "J&K", "Jammu Kashmir"}},
+	{Code: "02", Name: "Himachal Pradesh", Aliases: []string{"HP"}},
+	{Code: "03", Name: "Punjab", Aliases: []string{"PB"}},
+	{Code: "04", Name: "Chandigarh", Aliases: []string{"CH"}},
+	{Code: "05", Name: "Uttarakhand", Aliases: []string{"UK", "Uttaranchal", "Uttrakhand"}},
+	{Code: "06", Name: "Haryana", Aliases: []string{"HR"}},
+	{Code: "07", Name: "Delhi", Aliases: []string{"DL", "New Delhi", "NCT of Delhi"}},
+	{Code: "08", Name: "Rajasthan", Aliases: []string{"RJ"}},
+	{Code: "09", Name: "Uttar Pradesh", Aliases: []string{"UP"}},
+	{Code: "10", Name: "Bihar", Aliases: []string{"BR"}},
+	{Code: "11", Name: "Sikkim", Aliases: []string{"SK"}},
+	{Code: "12", Name: "Arunachal Pradesh", Aliases: []string{"AR"}},
+	{Code: "13", Name: "Nagaland", Aliases: []string{"NL"}},
+	{Code: "14", Name: "Manipur", Aliases: []string{"MN"}},
+	{Code: "15", Name: "Mizoram", Aliases: []string{"MZ"}},
+	{Code: "16", Name: "Tripura", Aliases: []string{"TR"}},
+	{Code: "17", Name: "Meghalaya", Aliases: []string{"ML"}},
+	{Code: "18", Name: "Assam", Aliases: []string{"AS"}},
+	{Code: "19", Name: "West Bengal", Aliases: []string{"WB"}},
+	{Code: "20", Name: "Jharkhand", Aliases: []string{"JH"}},
+	{Code: "21", Name: "Odisha", Aliases: []string{"OD", "OR", "Orissa"}},
+	{Code: "22", Name: "Chhattisgarh", Aliases: []string{"CG", "CT", "Chattisgarh"}},
+	{Code: "23", Name: "Madhya Pradesh", Aliases: []string{"MP"}},
+	{Code: "24", Name: "Gujarat", Aliases: []string{"GJ", "Gujrat"}},
+	{Code: "25", Name: "Daman and Diu", Aliases: []string{"DD"}},
+	{Code: "26", Name: "Dadra and Nagar Haveli and Daman and Diu", Aliases: []string{"DN", "Dadra and Nagar Haveli", "DNHDD"}},
+	{Code: "27", Name: "Maharashtra", Aliases: []string{"MH", "Maharastra"}},
+	{Code: "28", Name: "Andhra Pradesh (Before Division)"},
+	{Code: "29", Name: "Karnataka", Aliases: []string{"KA", "Karnatka"}},
+	{Code: "30", Name: "Goa", Aliases: []string{"GA"}},
+	{Code: "31", Name: "Lakshadweep", Aliases: []string{"LD"}},
+	{Code: "32", Name: "Kerala", Aliases: []string{"KL"}},
+	{Code: "33", Name: "Tamil Nadu", Aliases: []string{"TN", "Tamilnadu"}},
+	{Code: "34", Name: "Puducherry", Aliases: []string{"PY", "Pondicherry"}},
+	{Code: "35", Name: "Andaman and Nicobar Islands", Aliases: []string{"AN", "Andaman and Nicobar"}},
+	{Code: "36", Name: "Telangana", Aliases: []string{"TS", "TG", "Telengana"}},
+	{Code: "37", Name: "Andhra Pradesh", Aliases: []string{"AD", "AP"}},
+	{Code: "38", Name: "Ladakh", Aliases: []string{"LA"}},
+}
+
+var (
+	statesByCode  = make(map[string]*State, len(States))
+	statesByAlias = make(map[string]*State, len(States)*3)
+	stateDigitsRe = regexp.MustCompile(`\d+`)
+	stateKeyRe    = regexp.MustCompile(`[^a-z]+`)
+)
+
+func init() {
+	for i := range States {
+		s := &States[i]
+		statesByCode[s.Code] = s
+		statesByAlias[stateKey(s.Name)] = s
+		for _, a := range s.Aliases {
+			statesByAlias[stateKey(a)] = s
+		}
+	}
+}
+
+// stateKey folds a state name for lookup: lowercase, "&" read as "and", and
+// everything but letters dropped, so "Jammu & Kashmir" and "JAMMU-AND-KASHMIR" match.
+func stateKey(s string) string {
+	s = strings.ReplaceAll(strings.ToLower(s), "&", "and")
+	return stateKeyRe.ReplaceAllString(s, "")
+}
+
+// LookupState resolves a state code or name as parsers emit it — "29", "9",
+// "29-Karnataka", "KA", "Karnatka" — to its master entry. A name with no exact
+// alias match is accepted if it is one edit away from exactly one state's name
+// or alias.
+func LookupState(s string) (*State, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, false
+	}
+	if digits := stateDigitsRe.FindAllString(s, -1); len(digits) == 1 && len(digits[0]) <= 2 {
+		code := digits[0]
+		if len(code) == 1 {
+			code = "0" + code
+		}
+		st, ok := statesByCode[code]
+		return st, ok
+	}
+
+	key := stateKey(s)
+	if key == "" {
+		return nil, false
+	}
+	if st, ok := statesByAlias[key]; ok {
+		return st, true
+	}
+	if len(key) < 5 {
+		return nil, false
+	}
+	var match *State
+	for alias, st := range statesByAlias {
+		if len(alias) < 5 || !withinOneEdit(key, alias) {
+			continue
+		}
+		if match != nil && match != st {
+			return nil, false
+		}
+		match = st
+	}
+	return match, match != nil
+}
+
+// NormalizeStates maps each party's parsed state code and name onto the state
+// master before validation, so spelling variants don't fail the GSTIN-state and
+// state code checks. The state code is taken from state_code when it resolves,
+// otherwise from the state name. Unresolvable values are left untouched.
+func NormalizeStates(inv *GSTInvoice) {
+	normalizePartyState(&inv.Seller)
+	normalizePartyState(&inv.Buyer)
+}
+
+func normalizePartyState(p *Party) {
+	if st, ok := LookupState(p.StateCode); ok {
+		p.StateCode = st.Code
+	} else if st, ok := LookupState(p.State); ok {
+		p.StateCode = st.Code
+	}
+	if st, ok := LookupState(p.State); ok && st.Code == p.StateCode {
+		p.State = st.Name
+	}
+}
+
+// withinOneEdit reports whether a and b differ by at most one insertion,
+// deletion or substitution.
+func withinOneEdit(a, b string) bool {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(a)-len(b) > 1 {
+		return false
+	}
+	i, j, edits := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] == b[j] {
+			i++
+			j++
+			continue
+		}
+		edits++
+		if edits > 1 {
+			return false
+		}
+		if len(a) == len(b) {
+			j++
+		}
+		i++
+	}
+	return edits+(len(a)-i) <= 1
+}
@@ -0,0 +1,85 @@
+package invoice
+
+import "math"
+
+// TotalsDelta is one invoice total whose stored value differs from the value
+// recomputed from the line items.
+type TotalsDelta struct {
+	FieldPath  string  `json:"field_path"`
+	Stored     float64 `json:"stored"`
+	Recomputed float64 `json:"recomputed"`
+	Delta      float64 `json:"delta"`
+}
+
+// RecomputeTotals returns the invoice totals recalculated from the line items
+// the way the math validators check them: subtotal, taxable amount, the CGST,
+// SGST and IGST splits and the grand total. An invoice whose stored total is
+// rounded (a round-off is set or the total is whole rupees) gets its grand total
+// rounded to the nearest rupee with the difference as round-off; otherwise the
+// round-off is zero. Discount, cess and amount in words are kept.
+func RecomputeTotals(inv *GSTInvoice) Totals {
+	t := inv.Totals
+	sumLineItems(inv, &t)
+	exact := roundPaise(t.TaxableAmount + t.CGST + t.SGST + t.IGST + t.Cess)
+	t.Total, t.RoundOff = exact, 0
+	if inv.Totals.RoundOff != 0 || (inv.Totals.Total != 0 && inv.Totals.Total == math.Round(inv.Totals.Total)) {
+		t.Total = math.Round(exact)
+		t.RoundOff = roundPaise(t.Total - exact)
+	}
+	return t
+}
+
+// DiffTotals lists the totals that differ between stored and recomputed by at
+// least a paisa, in the order they appear on an invoice.
+func DiffTotals(stored, recomputed *Totals) []TotalsDelta {
+	fields := []struct {
+		path               string
+		stored, recomputed float64
+	}{
+		{"totals.subtotal", stored.Subtotal, recomputed.Subtotal},
+		{"totals.taxable_amount", stored.TaxableAmount, recomputed.TaxableAmount},
+		{"totals.cgst", stored.CGST, recomputed.CGST},
+		{"totals.sgst", stored.SGST, recomputed.SGST},
+		{"totals.igst", stored.IGST, recomputed.IGST},
+		{"totals.round_off", stored.RoundOff, recomputed.RoundOff},
+		{"totals.total", stored.Total, recomputed.Total},
+	}
+	deltas := []TotalsDelta{}
+	for _, f := range fields {
+		delta := roundPaise(f.recomputed - f.stored)
+		if math.Abs(delta) >= 0.01 {
+			deltas = append(deltas, TotalsDelta{FieldPath: f.path, Stored: f.stored, Recomputed: f.recomputed, Delta: delta})
+		}
+	}
+	return deltas
+}
+
+// recomputeTotals sums the line items into the invoice totals, keeping the
+// round-off as entered.
+func recomputeTotals(inv *GSTInvoice) {
+	t := &inv.Totals
+	sumLineItems(inv, t)
+	t.Total = roundPaise(t.TaxableAmount + t.CGST + t.SGST + t.IGST + t.Cess + t.RoundOff)
+}
+
+// sumLineItems sets the subtotal, taxable amount and tax totals of t from the
+// line items, net of t's discount.
+func sumLineItems(inv *GSTInvoice, t *Totals) {
+	var taxable, cgst, sgst, igst float64
+	for i := range inv.LineItems {
+		item := &inv.LineItems[i]
+		taxable += item.TaxableAmount
+		cgst += item.CGSTAmount
+		sgst += item.SGSTAmount
+		igst += item.IGSTAmount
+	}
+	t.Subtotal = roundPaise(taxable)
+	t.TaxableAmount = roundPaise(taxable - t.TotalDiscount)
+	t.CGST = roundPaise(cgst)
+	t.SGST = roundPaise(sgst)
+	t.IGST = roundPaise(igst)
+}
+
+func roundPaise(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,173 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+// schemaTypes maps document types to the Go type their structured data decodes
+// into. Every document type is parsed as a GST invoice today.
+var schemaTypes = map[string]reflect.Type{
+	"invoice": reflect.TypeOf(invoice.GSTInvoice{}),
+}
+
+// lineIndexRe matches array indexes in result field paths ("line_items[3]").
+var lineIndexRe = regexp.MustCompile(`\[\d+\]`)
+
+// DocumentSchema describes the structured data of a document type: a JSON Schema,
+// a flat field list with descriptions, and the reconciliation-critical rules.
+type DocumentSchema struct {
+	DocumentType                string                 `json:"document_type"`
+	JSONSchema                  map[string]interface{} `json:"json_schema"`
+	Fields                      []SchemaField          `json:"fields"`
+	ReconciliationCriticalRules []SchemaRule           `json:"reconciliation_critical_rules"`
+}
+
+// SchemaField is one field of the structured data. Path uses validation field
+// path notation, with "[]" for any array index.
+type SchemaField struct {
+	Path                   string `json:"path"`
+	Type                   string `json:"type"`
+	Description            string `json:"description"`
+	ReconciliationCritical bool   `json:"reconciliation_critical"`
+}
+
+// SchemaRule is a reconciliation-critical built-in rule and the field paths its
+// results are reported under (the keys of field_statuses).
+type SchemaRule struct {
+	RuleKey    string                    `json:"rule_key"`
+	RuleName   string                    `json:"rule_name"`
+	Severity   domain.ValidationSeverity `json:"severity"`
+	FieldPaths []string                  `json:"field_paths"`
+}
+
+// BuildSchema derives the schema for a document type from its Go type and the
+// registered validators, so it follows code changes without upkeep. It returns
+// domain.ErrNotFound for unknown document types.
+func BuildSchema(documentType string, registry *Registry) (*DocumentSchema, error) {
+	t, ok := schemaTypes[documentType]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	rules := reconciliationCriticalRules(registry)
+	critical := make(map[string]bool)
+	for _, r := range rules {
+		for _, p := range r.FieldPaths {
+			critical[p] = true
+		}
+	}
+
+	var fields []SchemaField
+	root := jsonSchemaOf(t, "", critical, &fields)
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["title"] = documentType
+
+	return &DocumentSchema{
+		DocumentType:                documentType,
+		JSONSchema:                  root,
+		Fields:                      fields,
+		ReconciliationCriticalRules: rules,
+	}, nil
+}
+
+// jsonSchemaOf builds the JSON Schema for t and appends a SchemaField for every
+// field below path.
+func jsonSchemaOf(t reflect.Type, path string, critical map[string]bool, fields *[]SchemaField) map[string]interface{} {
+	node := map[string]interface{}{"type": jsonType(t)}
+	if path != "" {
+		if desc := invoice.FieldDescriptions[path]; desc != "" {
+			node["description"] = desc
+		}
+		*fields = append(*fields, SchemaField{
+			Path:                   path,
+			Type:                   jsonType(t),
+			Description:            invoice.FieldDescriptions[path],
+			ReconciliationCritical: critical[path],
+		})
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		props := make(map[string]interface{}, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			childPath := name
+			if path != "" {
+				childPath = path + "." + name
+			}
+			props[name] = jsonSchemaOf(f.Type, childPath, critical, fields)
+		}
+		node["properties"] = props
+	case reflect.Slice, reflect.Array:
+		var itemFields []SchemaField
+		items := jsonSchemaOf(t.Elem(), path+"[]", critical, &itemFields)
+		// The element itself is described by the array; keep only its fields
+		if len(itemFields) > 0 && itemFields[0].Path == path+"[]" {
+			itemFields = itemFields[1:]
+		}
+		delete(items, "description")
+		node["items"] = items
+		*fields = append(*fields, itemFields...)
+	}
+	return node
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// reconciliationCriticalRules lists the registered reconciliation-critical rules.
+// Validators report every result under a field path, so running each one against
+// a blank invoice with a single line item reveals the paths it covers.
+func reconciliationCriticalRules(registry *Registry) []SchemaRule {
+	blank := &invoice.GSTInvoice{LineItems: []invoice.LineItem{{}}}
+	var rules []SchemaRule
+	for _, v := range registry.All() {
+		if !v.ReconciliationCritical() {
+			continue
+		}
+		seen := make(map[string]bool)
+		paths := []string{}
+		for _, r := range v.Validate(context.Background(), blank) {
+			p := lineIndexRe.ReplaceAllString(r.FieldPath, "[]")
+			if p != "" && !seen[p] {
+				seen[p] = true
+				paths = append(paths, p)
+			}
+		}
+		sort.Strings(paths)
+		rules = append(rules, SchemaRule{
+			RuleKey:    v.RuleKey(),
+			RuleName:   v.RuleName(),
+			Severity:   v.Severity(),
+			FieldPaths: paths,
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].RuleKey < rules[j].RuleKey })
+	return rules
+}
@@ -0,0 +1,258 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+// ProposedRule is a rule to try out against existing documents before enabling
+// it. It is either a registered built-in (BuiltinRuleKey) or a config-driven
+// check: rule_type "required_field" with {"field_path"}, or "regex" with
+// {"field_path", "pattern"}. Field paths use schema notation ("seller.pan",
+// "line_items[].hsn_sac_code") and must name a string field.
+type ProposedRule struct {
+	BuiltinRuleKey string                    `json:"builtin_rule_key,omitempty"`
+	RuleName       string                    `json:"rule_name,omitempty"`
+	RuleType       domain.ValidationRuleType `json:"rule_type,omitempty"`
+	RuleConfig     json.RawMessage           `json:"rule_config,omitempty" swaggertype:"object"`
+	// Severity defaults to the built-in's severity, or error for config-driven rules.
+	Severity domain.ValidationSeverity `json:"severity,omitempty"`
+}
+
+// SimulationResult reports how a proposed rule would fare against a set of documents.
+type SimulationResult struct {
+	DocumentsEvaluated int `json:"documents_evaluated"`
+	WouldFail          int `json:"would_fail"`
+	// WouldChangeStatus counts failing documents whose validation_status would get
+	// worse (e.g. valid to invalid) with the rule enabled.
+	WouldChangeStatus int                `json:"would_change_status"`
+	Samples           []SimulationSample `json:"samples"`
+}
+
+// SimulationSample is a document the proposed rule fails, with its failed checks.
+type SimulationSample struct {
+	DocumentID       uuid.UUID               `json:"document_id"`
+	DocumentName     string                  `json:"document_name"`
+	CollectionID     uuid.UUID               `json:"collection_id"`
+	ValidationStatus domain.ValidationStatus `json:"validation_status"`
+	Failures         []SimulationFailure     `json:"failures"`
+}
+
+// SimulationFailure is one failed check of a proposed rule.
+type SimulationFailure struct {
+	FieldPath   string `json:"field_path"`
+	ActualValue string `json:"actual_value"`
+	Message     string `json:"message"`
+}
+
+// ruleConfig is the rule_config of a config-driven proposed rule.
+type ruleConfig struct {
+	FieldPath string `json:"field_path"`
+	Pattern   string `json:"pattern"`
+}
+
+// Simulate evaluates a proposed rule against docs without storing anything. docs
+// must be parsed documents of documentType. At most sampleLimit failing
+// documents are returned as samples. It returns domain.ErrInvalidProposedRule
+// when the rule can't be compiled.
+func (e *Engine) Simulate(ctx context.Context, tenantID uuid.UUID, documentType string, rule *ProposedRule, docs []domain.Document, sampleLimit int) (*SimulationResult, error) {
+	v, err := e.compileRule(documentType, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SimulationResult{Samples: []SimulationSample{}}
+	for i := range docs {
+		doc := &docs[i]
+		var inv invoice.GSTInvoice
+		if err := json.Unmarshal(doc.StructuredData, &inv); err != nil {
+			continue
+		}
+		invoice.NormalizeStates(&inv)
+		result.DocumentsEvaluated++
+
+		var failures []SimulationFailure
+		for _, r := range v.Validate(invoice.WithValidationContext(ctx, tenantID, doc.ID), &inv) {
+			if !r.Passed {
+				failures = append(failures, SimulationFailure{FieldPath: r.FieldPath, ActualValue: r.ActualValue, Message: r.Message})
+			}
+		}
+		if len(failures) == 0 {
+			continue
+		}
+
+		result.WouldFail++
+		if worsens(doc.ValidationStatus, v.Severity()) {
+			result.WouldChangeStatus++
+		}
+		if len(result.Samples) < sampleLimit {
+			result.Samples = append(result.Samples, SimulationSample{
+				DocumentID:       doc.ID,
+				DocumentName:     doc.Name,
+				CollectionID:     doc.CollectionID,
+				ValidationStatus: doc.ValidationStatus,
+				Failures:         failures,
+			})
+		}
+	}
+	return result, nil
+}
+
+// worsens reports whether a failure of the given severity would move a
+// document's validation status to a worse one.
+func worsens(current domain.ValidationStatus, severity domain.ValidationSeverity) bool {
+	if severity == domain.ValidationSeverityError {
+		return current != domain.ValidationStatusInvalid
+	}
+	return current == domain.ValidationStatusValid
+}
+
+func (e *Engine) compileRule(documentType string, rule *ProposedRule) (Validator, error) {
+	if rule.BuiltinRuleKey != "" {
+		v := e.registry.Get(rule.BuiltinRuleKey)
+		if v == nil {
+			return nil, fmt.Errorf("%w: unknown builtin_rule_key %q", domain.ErrInvalidProposedRule, rule.BuiltinRuleKey)
+		}
+		if rule.Severity == "" {
+			return v, nil
+		}
+		return &severityOverride{Validator: v, severity: rule.Severity}, nil
+	}
+
+	var cfg ruleConfig
+	if len(rule.RuleConfig) > 0 {
+		if err := json.Unmarshal(rule.RuleConfig, &cfg); err != nil {
+			return nil, fmt.Errorf("%w: rule_config must be an object", domain.ErrInvalidProposedRule)
+		}
+	}
+	schema, err := BuildSchema(documentType, e.registry)
+	if err != nil {
+		return nil, err
+	}
+	if !isStringField(schema, cfg.FieldPath) {
+		return nil, fmt.Errorf("%w: rule_config.field_path must name a string field of %s", domain.ErrInvalidProposedRule, documentType)
+	}
+
+	severity := rule.Severity
+	if severity == "" {
+		severity = domain.ValidationSeverityError
+	}
+	fr := &fieldRule{name: rule.RuleName, ruleType: rule.RuleType, fieldPath: cfg.FieldPath, severity: severity}
+	if fr.name == "" {
+		fr.name = "Proposed rule"
+	}
+	switch rule.RuleType {
+	case domain.ValidationRuleRequired:
+	case domain.ValidationRuleRegex:
+		if fr.pattern, err = regexp.Compile(cfg.Pattern); err != nil || cfg.Pattern == "" {
+			return nil, fmt.Errorf("%w: rule_config.pattern must be a valid regular expression", domain.ErrInvalidProposedRule)
+		}
+	default:
+		return nil, fmt.Errorf("%w: rule_type must be required_field or regex", domain.ErrInvalidProposedRule)
+	}
+	if severity != domain.ValidationSeverityError && severity != domain.ValidationSeverityWarning {
+		return nil, fmt.Errorf("%w: severity must be error or warning", domain.ErrInvalidProposedRule)
+	}
+	return fr, nil
+}
+
+func isStringField(schema *DocumentSchema, path string) bool {
+	for _, f := range schema.Fields {
+		if f.Path == path {
+			return f.Type == "string"
+		}
+	}
+	return false
+}
+
+// severityOverride runs a built-in validator with a different severity.
+type severityOverride struct {
+	Validator
+	severity domain.ValidationSeverity
+}
+
+func (s *severityOverride) Severity() domain.ValidationSeverity { return s.severity }
+
+// fieldRule is a config-driven required_field or regex check on one field path.
+type fieldRule struct {
+	name      string
+	ruleType  domain.ValidationRuleType
+	fieldPath string
+	pattern   *regexp.Regexp
+	severity  domain.ValidationSeverity
+}
+
+func (r *fieldRule) RuleKey() string                     { return "" }
+func (r *fieldRule) RuleName() string                    { return r.name }
+func (r *fieldRule) RuleType() domain.ValidationRuleType { return r.ruleType }
+func (r *fieldRule) Severity() domain.ValidationSeverity { return r.severity }
+func (r *fieldRule) ReconciliationCritical() bool        { return false }
+
+func (r *fieldRule) Validate(_ context.Context, data *invoice.GSTInvoice) []invoice.ValidationResult {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	var root interface{}
+	if err := json.Unmarshal(raw, &root); err != nil {
+		return nil
+	}
+
+	var results []invoice.ValidationResult
+	for path, value := range resolvePath(root, "", strings.Split(r.fieldPath, ".")) {
+		s, _ := value.(string)
+		res := invoice.ValidationResult{FieldPath: path, ActualValue: s}
+		if r.pattern == nil {
+			res.Passed = s != ""
+			res.ExpectedValue = "non-empty value"
+		} else {
+			// Like the built-in format checks, an empty field is not a format failure
+			res.Passed = s == "" || r.pattern.MatchString(s)
+			res.ExpectedValue = r.pattern.String()
+		}
+		if res.Passed {
+			res.Message = fmt.Sprintf("%s: %s passed", r.name, path)
+		} else {
+			res.Message = fmt.Sprintf("%s: %s failed", r.name, path)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// resolvePath returns the values at a schema path in decoded JSON, keyed by
+// concrete field path: "line_items[]" expands to every index.
+func resolvePath(node interface{}, prefix string, segments []string) map[string]interface{} {
+	out := make(map[string]interface{})
+	if len(segments) == 0 {
+		out[prefix] = node
+		return out
+	}
+	seg := segments[0]
+	join := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "." + name
+	}
+
+	obj, _ := node.(map[string]interface{})
+	if name, isArray := strings.CutSuffix(seg, "[]"); isArray {
+		items, _ := obj[name].([]interface{})
+		for i, item := range items {
+			for k, v := range resolvePath(item, fmt.Sprintf("%s[%d]", join(name), i), segments[1:]) {
+				out[k] = v
+			}
+		}
+		return out
+	}
+	return resolvePath(obj[seg], join(seg), segments[1:])
+}
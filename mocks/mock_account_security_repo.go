@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockAccountSecurityRepo is a mock implementation of port.AccountSecurityRepository.
+type MockAccountSecurityRepo struct {
+	mock.Mock
+}
+
+func (m *MockAccountSecurityRepo) RecordEvent(ctx context.Context, event *domain.SecurityEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAccountSecurityRepo) ListEvents(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.SecurityEvent, int, error) {
+	args := m.Called(ctx, tenantID, userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.SecurityEvent), args.Int(1), args.Error(2)
+}
+
+func (m *MockAccountSecurityRepo) CreateEmailChange(ctx context.Context, change *domain.EmailChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockAccountSecurityRepo) GetEmailChange(ctx context.Context, tenantID, changeID uuid.UUID) (*domain.EmailChange, error) {
+	args := m.Called(ctx, tenantID, changeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmailChange), args.Error(1)
+}
+
+func (m *MockAccountSecurityRepo) UndoEmailChange(ctx context.Context, change *domain.EmailChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockAnalyticsExportRepo is a mock implementation of port.AnalyticsExportRepository.
+type MockAnalyticsExportRepo struct {
+	mock.Mock
+}
+
+func (m *MockAnalyticsExportRepo) Get(ctx context.Context, tenantID uuid.UUID) (*domain.AnalyticsExport, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnalyticsExport), args.Error(1)
+}
+
+func (m *MockAnalyticsExportRepo) Upsert(ctx context.Context, export *domain.AnalyticsExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsExportRepo) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	args := m.Called(ctx, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsExportRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.AnalyticsExport, error) {
+	args := m.Called(ctx, now, lease, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AnalyticsExport), args.Error(1)
+}
+
+func (m *MockAnalyticsExportRepo) CompleteRun(ctx context.Context, export *domain.AnalyticsExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsExportRepo) ListDocumentsUpdated(ctx context.Context, tenantID uuid.UUID, afterAt time.Time, afterID uuid.UUID, through time.Time, limit int) ([]domain.Document, error) {
+	args := m.Called(ctx, tenantID, afterAt, afterID, through, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Document), args.Error(1)
+}
+
+func (m *MockAnalyticsExportRepo) ListValidationFacts(ctx context.Context, tenantID uuid.UUID, documentIDs []uuid.UUID) ([]domain.ValidationFact, error) {
+	args := m.Called(ctx, tenantID, documentIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ValidationFact), args.Error(1)
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockAnalyticsExportService is a mock implementation of service.AnalyticsExportService.
+type MockAnalyticsExportService struct {
+	mock.Mock
+}
+
+func (m *MockAnalyticsExportService) Get(ctx context.Context, tenantID uuid.UUID) (*domain.AnalyticsExport, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnalyticsExport), args.Error(1)
+}
+
+func (m *MockAnalyticsExportService) Configure(ctx context.Context, tenantID uuid.UUID, input *service.ConfigureAnalyticsExportInput) (*domain.AnalyticsExport, error) {
+	args := m.Called(ctx, tenantID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnalyticsExport), args.Error(1)
+}
+
+func (m *MockAnalyticsExportService) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	args := m.Called(ctx, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsExportService) RunNow(ctx context.Context, tenantID uuid.UUID) (*domain.AnalyticsExport, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AnalyticsExport), args.Error(1)
+}
+
+func (m *MockAnalyticsExportService) RunDue(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
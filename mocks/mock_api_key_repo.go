@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockAPIKeyRepo is a mock implementation of port.APIKeyRepository.
+type MockAPIKeyRepo struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepo) Create(ctx context.Context, key *domain.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepo) ListByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.APIKey, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) Delete(ctx context.Context, tenantID, userID, keyID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID, keyID)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepo) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepo) TouchLastUsed(ctx context.Context, keyID uuid.UUID) error {
+	args := m.Called(ctx, keyID)
+	return args.Error(0)
+}
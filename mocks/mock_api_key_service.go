@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockAPIKeyService is a mock implementation of service.APIKeyService.
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyService) Create(ctx context.Context, tenantID, userID uuid.UUID, input service.CreateAPIKeyInput) (*service.CreatedAPIKey, error) {
+	args := m.Called(ctx, tenantID, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.CreatedAPIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) List(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.APIKey, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) Delete(ctx context.Context, tenantID, userID, keyID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID, keyID)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyService) Authenticate(ctx context.Context, key string) (*service.Claims, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.Claims), args.Error(1)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockAuthzDenialRepo is a mock implementation of port.AuthzDenialRepository.
+type MockAuthzDenialRepo struct {
+	mock.Mock
+}
+
+func (m *MockAuthzDenialRepo) Create(ctx context.Context, denial *domain.AuthzDenial) error {
+	args := m.Called(ctx, denial)
+	return args.Error(0)
+}
+
+func (m *MockAuthzDenialRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, filters *domain.AuthzDenialFilters) ([]domain.AuthzDenial, int, error) {
+	args := m.Called(ctx, tenantID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.AuthzDenial), args.Int(1), args.Error(2)
+}
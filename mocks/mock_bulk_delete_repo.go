@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockBulkDeleteRepo is a mock implementation of port.BulkDeleteRepository.
+type MockBulkDeleteRepo struct {
+	mock.Mock
+}
+
+func (m *MockBulkDeleteRepo) Create(ctx context.Context, rec *domain.BulkDelete) error {
+	args := m.Called(ctx, rec)
+	return args.Error(0)
+}
+
+func (m *MockBulkDeleteRepo) Finish(ctx context.Context, rec *domain.BulkDelete) error {
+	args := m.Called(ctx, rec)
+	return args.Error(0)
+}
+
+func (m *MockBulkDeleteRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, createdBy *uuid.UUID, offset, limit int) ([]domain.BulkDelete, int, error) {
+	args := m.Called(ctx, tenantID, createdBy, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.BulkDelete), args.Int(1), args.Error(2)
+}
+
+func (m *MockBulkDeleteRepo) MatchDocuments(ctx context.Context, tenantID uuid.UUID, filter *domain.BulkTagFilter, limit int) ([]domain.BulkDeleteTarget, error) {
+	args := m.Called(ctx, tenantID, filter, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BulkDeleteTarget), args.Error(1)
+}
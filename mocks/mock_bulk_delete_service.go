@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockBulkDeleteService is a mock implementation of service.BulkDeleteService.
+type MockBulkDeleteService struct {
+	mock.Mock
+}
+
+func (m *MockBulkDeleteService) Preview(ctx context.Context, input *service.BulkDeleteInput) (*domain.BulkDeletePreview, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BulkDeletePreview), args.Error(1)
+}
+
+func (m *MockBulkDeleteService) Delete(ctx context.Context, input *service.BulkDeleteInput) (*domain.BulkDelete, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BulkDelete), args.Error(1)
+}
+
+func (m *MockBulkDeleteService) ListRuns(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.BulkDelete, int, error) {
+	args := m.Called(ctx, tenantID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.BulkDelete), args.Int(1), args.Error(2)
+}
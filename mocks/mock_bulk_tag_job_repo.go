@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockBulkTagJobRepo is a mock implementation of port.BulkTagJobRepository.
+type MockBulkTagJobRepo struct {
+	mock.Mock
+}
+
+func (m *MockBulkTagJobRepo) Create(ctx context.Context, job *domain.BulkTagJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockBulkTagJobRepo) GetByID(ctx context.Context, tenantID, jobID uuid.UUID) (*domain.BulkTagJob, error) {
+	args := m.Called(ctx, tenantID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BulkTagJob), args.Error(1)
+}
+
+func (m *MockBulkTagJobRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, createdBy *uuid.UUID, offset, limit int) ([]domain.BulkTagJob, int, error) {
+	args := m.Called(ctx, tenantID, createdBy, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.BulkTagJob), args.Int(1), args.Error(2)
+}
+
+func (m *MockBulkTagJobRepo) UpdateProgress(ctx context.Context, job *domain.BulkTagJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockBulkTagJobRepo) MatchDocuments(ctx context.Context, tenantID uuid.UUID, filter *domain.BulkTagFilter, limit int) ([]domain.BulkTagTarget, error) {
+	args := m.Called(ctx, tenantID, filter, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BulkTagTarget), args.Error(1)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockCapabilityService is a mock implementation of service.CapabilityService.
+type MockCapabilityService struct {
+	mock.Mock
+}
+
+func (m *MockCapabilityService) Get(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole) (*domain.Capabilities, error) {
+	args := m.Called(ctx, tenantID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Capabilities), args.Error(1)
+}
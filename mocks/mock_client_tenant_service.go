@@ -0,0 +1,85 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockClientTenantService is a mock implementation of service.ClientTenantService.
+type MockClientTenantService struct {
+	mock.Mock
+}
+
+func (m *MockClientTenantService) Create(ctx context.Context, firmID uuid.UUID, input *service.CreateClientInput, createdBy uuid.UUID) (*domain.Tenant, error) {
+	args := m.Called(ctx, firmID, input, createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockClientTenantService) List(ctx context.Context, firmID uuid.UUID, offset, limit int) ([]domain.Tenant, int, error) {
+	args := m.Called(ctx, firmID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Tenant), args.Int(1), args.Error(2)
+}
+
+func (m *MockClientTenantService) Get(ctx context.Context, firmID, clientID uuid.UUID) (*domain.Tenant, error) {
+	args := m.Called(ctx, firmID, clientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockClientTenantService) Update(ctx context.Context, firmID, clientID uuid.UUID, input *service.UpdateClientInput) (*domain.Tenant, error) {
+	args := m.Called(ctx, firmID, clientID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tenant), args.Error(1)
+}
+
+func (m *MockClientTenantService) Dashboard(ctx context.Context, firmID uuid.UUID) (*domain.ClientDashboard, error) {
+	args := m.Called(ctx, firmID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ClientDashboard), args.Error(1)
+}
+
+func (m *MockClientTenantService) ListStaff(ctx context.Context, firmID, clientID uuid.UUID, offset, limit int) ([]domain.TenantMembership, int, error) {
+	args := m.Called(ctx, firmID, clientID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.TenantMembership), args.Int(1), args.Error(2)
+}
+
+func (m *MockClientTenantService) AssignStaff(ctx context.Context, firmID, clientID uuid.UUID, input *service.AssignStaffInput, assignedBy uuid.UUID) (*domain.TenantMembership, error) {
+	args := m.Called(ctx, firmID, clientID, input, assignedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TenantMembership), args.Error(1)
+}
+
+func (m *MockClientTenantService) RemoveStaff(ctx context.Context, firmID, clientID, userID uuid.UUID) error {
+	args := m.Called(ctx, firmID, clientID, userID)
+	return args.Error(0)
+}
+
+func (m *MockClientTenantService) MoveStaff(ctx context.Context, firmID, clientID, userID uuid.UUID, input *service.MoveStaffInput, movedBy uuid.UUID) (*domain.TenantMembership, error) {
+	args := m.Called(ctx, firmID, clientID, userID, input, movedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TenantMembership), args.Error(1)
+}
@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// MockCloudDriveProvider is a mock implementation of port.CloudDriveProvider.
+type MockCloudDriveProvider struct {
+	mock.Mock
+	Name domain.CloudProvider
+}
+
+func (m *MockCloudDriveProvider) Provider() domain.CloudProvider {
+	return m.Name
+}
+
+func (m *MockCloudDriveProvider) AuthCodeURL(state string) string {
+	return "https://consent.example.com/auth?state=" + state
+}
+
+func (m *MockCloudDriveProvider) Exchange(ctx context.Context, code string) (*port.CloudToken, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*port.CloudToken), args.Error(1)
+}
+
+func (m *MockCloudDriveProvider) Refresh(ctx context.Context, refreshToken string) (*port.CloudToken, error) {
+	args := m.Called(ctx, refreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*port.CloudToken), args.Error(1)
+}
+
+func (m *MockCloudDriveProvider) ListFolder(ctx context.Context, accessToken, folderID string) ([]port.CloudFile, error) {
+	args := m.Called(ctx, accessToken, folderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]port.CloudFile), args.Error(1)
+}
+
+func (m *MockCloudDriveProvider) Download(ctx context.Context, accessToken, fileID string, maxBytes int64) ([]byte, error) {
+	args := m.Called(ctx, accessToken, fileID, maxBytes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
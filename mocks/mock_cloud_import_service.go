@@ -0,0 +1,107 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+)
+
+// MockCloudImportService is a mock implementation of service.CloudImportService.
+type MockCloudImportService struct {
+	mock.Mock
+}
+
+func (m *MockCloudImportService) AuthorizeURL(ctx context.Context, tenantID, userID uuid.UUID, provider domain.CloudProvider) (string, error) {
+	args := m.Called(ctx, tenantID, userID, provider)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCloudImportService) Connect(ctx context.Context, tenantID, userID uuid.UUID, provider domain.CloudProvider, code, state string) (*domain.CloudConnection, error) {
+	args := m.Called(ctx, tenantID, userID, provider, code, state)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CloudConnection), args.Error(1)
+}
+
+func (m *MockCloudImportService) ListConnections(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.CloudConnection, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CloudConnection), args.Error(1)
+}
+
+func (m *MockCloudImportService) DeleteConnection(ctx context.Context, tenantID, userID, connectionID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID, connectionID)
+	return args.Error(0)
+}
+
+func (m *MockCloudImportService) ListFolder(ctx context.Context, tenantID, userID, connectionID uuid.UUID, folderID string) ([]port.CloudFile, error) {
+	args := m.Called(ctx, tenantID, userID, connectionID, folderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]port.CloudFile), args.Error(1)
+}
+
+func (m *MockCloudImportService) CreateSync(ctx context.Context, input *service.CreateCloudSyncInput) (*domain.CloudSync, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CloudSync), args.Error(1)
+}
+
+func (m *MockCloudImportService) ListSyncs(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) ([]domain.CloudSync, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CloudSync), args.Error(1)
+}
+
+func (m *MockCloudImportService) GetSync(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole) (*domain.CloudSync, error) {
+	args := m.Called(ctx, tenantID, collectionID, syncID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CloudSync), args.Error(1)
+}
+
+func (m *MockCloudImportService) SetPolling(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole, enabled bool) (*domain.CloudSync, error) {
+	args := m.Called(ctx, tenantID, collectionID, syncID, userID, role, enabled)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CloudSync), args.Error(1)
+}
+
+func (m *MockCloudImportService) DeleteSync(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole) error {
+	args := m.Called(ctx, tenantID, collectionID, syncID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockCloudImportService) TriggerSync(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole) error {
+	args := m.Called(ctx, tenantID, collectionID, syncID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockCloudImportService) ListSyncFiles(ctx context.Context, tenantID, collectionID, syncID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.CloudSyncFile, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, syncID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.CloudSyncFile), args.Int(1), args.Error(2)
+}
+
+func (m *MockCloudImportService) PollDue(ctx context.Context, interval time.Duration) error {
+	args := m.Called(ctx, interval)
+	return args.Error(0)
+}
@@ -0,0 +1,117 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockCloudConnectionRepo is a mock implementation of port.CloudConnectionRepository.
+type MockCloudConnectionRepo struct {
+	mock.Mock
+}
+
+func (m *MockCloudConnectionRepo) Upsert(ctx context.Context, conn *domain.CloudConnection) error {
+	args := m.Called(ctx, conn)
+	return args.Error(0)
+}
+
+func (m *MockCloudConnectionRepo) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.CloudConnection, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CloudConnection), args.Error(1)
+}
+
+func (m *MockCloudConnectionRepo) ListByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.CloudConnection, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CloudConnection), args.Error(1)
+}
+
+func (m *MockCloudConnectionRepo) UpdateTokens(ctx context.Context, conn *domain.CloudConnection) error {
+	args := m.Called(ctx, conn)
+	return args.Error(0)
+}
+
+func (m *MockCloudConnectionRepo) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+// MockCloudSyncRepo is a mock implementation of port.CloudSyncRepository.
+type MockCloudSyncRepo struct {
+	mock.Mock
+}
+
+func (m *MockCloudSyncRepo) Create(ctx context.Context, sync *domain.CloudSync) error {
+	args := m.Called(ctx, sync)
+	return args.Error(0)
+}
+
+func (m *MockCloudSyncRepo) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.CloudSync, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CloudSync), args.Error(1)
+}
+
+func (m *MockCloudSyncRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID) ([]domain.CloudSync, error) {
+	args := m.Called(ctx, tenantID, collectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CloudSync), args.Error(1)
+}
+
+func (m *MockCloudSyncRepo) ClaimDueForPoll(ctx context.Context, cutoff time.Time, limit int) ([]domain.CloudSync, error) {
+	args := m.Called(ctx, cutoff, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CloudSync), args.Error(1)
+}
+
+func (m *MockCloudSyncRepo) Update(ctx context.Context, sync *domain.CloudSync) error {
+	args := m.Called(ctx, sync)
+	return args.Error(0)
+}
+
+func (m *MockCloudSyncRepo) Delete(ctx context.Context, tenantID, id uuid.UUID) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
+
+func (m *MockCloudSyncRepo) RecordFile(ctx context.Context, file *domain.CloudSyncFile) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+func (m *MockCloudSyncRepo) ListFiles(ctx context.Context, tenantID, syncID uuid.UUID, offset, limit int) ([]domain.CloudSyncFile, int, error) {
+	args := m.Called(ctx, tenantID, syncID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.CloudSyncFile), args.Int(1), args.Error(2)
+}
+
+func (m *MockCloudSyncRepo) SeenExternalIDs(ctx context.Context, syncID uuid.UUID) (map[string]bool, error) {
+	args := m.Called(ctx, syncID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]bool), args.Error(1)
+}
+
+func (m *MockCloudSyncRepo) HashImported(ctx context.Context, collectionID uuid.UUID, contentHash string) (bool, error) {
+	args := m.Called(ctx, collectionID, contentHash)
+	return args.Bool(0), args.Error(1)
+}
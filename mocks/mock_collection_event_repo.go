@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockCollectionEventRepo is a mock implementation of port.CollectionEventRepository.
+type MockCollectionEventRepo struct {
+	mock.Mock
+}
+
+func (m *MockCollectionEventRepo) Create(ctx context.Context, event *domain.CollectionEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockCollectionEventRepo) ListActivity(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.CollectionActivityEvent, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.CollectionActivityEvent), args.Int(1), args.Error(2)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -48,7 +49,89 @@ func (m *MockCollectionRepo) Update(ctx context.Context, collection *domain.Coll
 	return args.Error(0)
 }
 
+func (m *MockCollectionRepo) UpdateReviewChecklist(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepo) UpdateEscalationPolicy(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepo) UpdateKPITargets(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepo) UpdateQASampling(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepo) UpdateDefaultParseMode(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepo) UpdateNamingTemplate(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepo) UpdateFileDownloadPerm(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepo) FileDownloadPerms(ctx context.Context, tenantID, fileID uuid.UUID) (map[uuid.UUID]domain.CollectionPermission, error) {
+	args := m.Called(ctx, tenantID, fileID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.CollectionPermission), args.Error(1)
+}
+
+func (m *MockCollectionRepo) ListFileDownloadPerms(ctx context.Context, tenantID uuid.UUID) (map[uuid.UUID]domain.CollectionPermission, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.CollectionPermission), args.Error(1)
+}
+
+func (m *MockCollectionRepo) DefaultParseMode(ctx context.Context, tenantID, collectionID uuid.UUID) (domain.ParseMode, error) {
+	args := m.Called(ctx, tenantID, collectionID)
+	return args.Get(0).(domain.ParseMode), args.Error(1)
+}
+
+func (m *MockCollectionRepo) TimeZone(ctx context.Context, tenantID, collectionID uuid.UUID) (string, error) {
+	args := m.Called(ctx, tenantID, collectionID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCollectionRepo) ProgressCounts(ctx context.Context, tenantID, collectionID uuid.UUID, since time.Time) (*domain.CollectionProgressCounts, error) {
+	args := m.Called(ctx, tenantID, collectionID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CollectionProgressCounts), args.Error(1)
+}
+
+func (m *MockCollectionRepo) UpdateCheckerThreshold(ctx context.Context, collection *domain.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
 func (m *MockCollectionRepo) Delete(ctx context.Context, tenantID, collectionID uuid.UUID) error {
 	args := m.Called(ctx, tenantID, collectionID)
 	return args.Error(0)
 }
+
+func (m *MockCollectionRepo) ListDemo(ctx context.Context, tenantID uuid.UUID) ([]domain.Collection, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Collection), args.Error(1)
+}
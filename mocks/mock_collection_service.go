@@ -47,6 +47,83 @@ func (m *MockCollectionService) Update(ctx context.Context, input *service.Updat
 	return args.Get(0).(*domain.Collection), args.Error(1)
 }
 
+func (m *MockCollectionService) SetReviewChecklist(ctx context.Context, input *service.SetReviewChecklistInput) (*domain.Collection, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) SetEscalationPolicy(ctx context.Context, input *service.SetEscalationPolicyInput) (*domain.Collection, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) SetKPITargets(ctx context.Context, input *service.SetKPITargetsInput) (*domain.Collection, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) SetQASampling(ctx context.Context, input *service.SetQASamplingInput) (*domain.Collection, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) SetDefaultParseMode(ctx context.Context, input *service.SetDefaultParseModeInput) (*domain.Collection, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) SetNamingTemplate(ctx context.Context, input *service.SetNamingTemplateInput) (*domain.Collection, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) SetFileDownloadPerm(ctx context.Context, input *service.SetFileDownloadPermInput) (*domain.Collection, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
+func (m *MockCollectionService) CheckFileDownload(ctx context.Context, tenantID, fileID, userID uuid.UUID, role domain.UserRole) error {
+	args := m.Called(ctx, tenantID, fileID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockCollectionService) GetProgress(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) (*service.CollectionProgress, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.CollectionProgress), args.Error(1)
+}
+
+func (m *MockCollectionService) SetCheckerThreshold(ctx context.Context, input *service.SetCheckerThresholdInput) (*domain.Collection, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Collection), args.Error(1)
+}
+
 func (m *MockCollectionService) Delete(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) error {
 	args := m.Called(ctx, tenantID, collectionID, userID, role)
 	return args.Error(0)
@@ -108,3 +185,11 @@ func (m *MockCollectionService) EffectivePermissions(ctx context.Context, collec
 	}
 	return args.Get(0).(map[uuid.UUID]domain.CollectionPermission), args.Error(1)
 }
+
+func (m *MockCollectionService) ListActivity(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.CollectionActivityEvent, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.CollectionActivityEvent), args.Int(1), args.Error(2)
+}
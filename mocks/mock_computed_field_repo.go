@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockComputedFieldRepo is a mock implementation of port.ComputedFieldRepository.
+type MockComputedFieldRepo struct {
+	mock.Mock
+}
+
+func (m *MockComputedFieldRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.ComputedField, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ComputedField), args.Error(1)
+}
+
+func (m *MockComputedFieldRepo) Upsert(ctx context.Context, field *domain.ComputedField) error {
+	args := m.Called(ctx, field)
+	return args.Error(0)
+}
+
+func (m *MockComputedFieldRepo) Delete(ctx context.Context, tenantID uuid.UUID, name string) error {
+	args := m.Called(ctx, tenantID, name)
+	return args.Error(0)
+}
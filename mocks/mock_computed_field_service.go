@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockComputedFieldService is a mock implementation of service.ComputedFieldService.
+type MockComputedFieldService struct {
+	mock.Mock
+}
+
+func (m *MockComputedFieldService) List(ctx context.Context, tenantID uuid.UUID) ([]domain.ComputedField, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ComputedField), args.Error(1)
+}
+
+func (m *MockComputedFieldService) Set(ctx context.Context, tenantID uuid.UUID, name string, input *service.SetComputedFieldInput, updatedBy uuid.UUID) (*domain.ComputedField, error) {
+	args := m.Called(ctx, tenantID, name, input, updatedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ComputedField), args.Error(1)
+}
+
+func (m *MockComputedFieldService) Delete(ctx context.Context, tenantID uuid.UUID, name string) error {
+	args := m.Called(ctx, tenantID, name)
+	return args.Error(0)
+}
+
+func (m *MockComputedFieldService) Resolve(ctx context.Context, tenantID uuid.UUID, docs []domain.Document) error {
+	args := m.Called(ctx, tenantID, docs)
+	return args.Error(0)
+}
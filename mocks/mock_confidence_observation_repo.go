@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockConfidenceObservationRepo is a mock implementation of port.ConfidenceObservationRepository.
+type MockConfidenceObservationRepo struct {
+	mock.Mock
+}
+
+func (m *MockConfidenceObservationRepo) Record(ctx context.Context, observations []domain.ConfidenceObservation) error {
+	args := m.Called(ctx, observations)
+	return args.Error(0)
+}
+
+func (m *MockConfidenceObservationRepo) MarkCorrected(ctx context.Context, tenantID, documentID uuid.UUID, fieldPaths []string) error {
+	args := m.Called(ctx, tenantID, documentID, fieldPaths)
+	return args.Error(0)
+}
+
+func (m *MockConfidenceObservationRepo) BucketsByModel(ctx context.Context, tenantID uuid.UUID, documentType string, since time.Time) ([]domain.CalibrationBucketStats, error) {
+	args := m.Called(ctx, tenantID, documentType, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CalibrationBucketStats), args.Error(1)
+}
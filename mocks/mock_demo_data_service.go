@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockDemoDataService is a mock implementation of service.DemoDataService.
+type MockDemoDataService struct {
+	mock.Mock
+}
+
+func (m *MockDemoDataService) Seed(ctx context.Context, tenantID, ownerID uuid.UUID) (*domain.DemoData, error) {
+	args := m.Called(ctx, tenantID, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DemoData), args.Error(1)
+}
+
+func (m *MockDemoDataService) Cleanup(ctx context.Context, tenantID uuid.UUID) (*domain.DemoCleanup, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DemoCleanup), args.Error(1)
+}
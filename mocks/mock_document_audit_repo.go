@@ -26,3 +26,11 @@ func (m *MockDocumentAuditRepo) ListByDocument(ctx context.Context, tenantID, do
 	}
 	return args.Get(0).([]domain.DocumentAuditEntry), args.Int(1), args.Error(2)
 }
+
+func (m *MockDocumentAuditRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, filters *domain.AuditFilters) ([]domain.DocumentAuditEntry, int, error) {
+	args := m.Called(ctx, tenantID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.DocumentAuditEntry), args.Int(1), args.Error(2)
+}
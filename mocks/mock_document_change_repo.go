@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockDocumentChangeRepo is a mock implementation of port.DocumentChangeRepository.
+type MockDocumentChangeRepo struct {
+	mock.Mock
+}
+
+func (m *MockDocumentChangeRepo) ListSince(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID,
+	afterAt time.Time, afterID uuid.UUID, settle time.Duration, limit int) ([]domain.DocumentChange, error) {
+	args := m.Called(ctx, tenantID, userID, explicitOnly, collectionID, afterAt, afterID, settle, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DocumentChange), args.Error(1)
+}
+
+func (m *MockDocumentChangeRepo) ListDocuments(ctx context.Context, tenantID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]domain.Document, error) {
+	args := m.Called(ctx, tenantID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.Document), args.Error(1)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockDocumentFieldOverrideRepo is a mock implementation of port.DocumentFieldOverrideRepository.
+type MockDocumentFieldOverrideRepo struct {
+	mock.Mock
+}
+
+func (m *MockDocumentFieldOverrideRepo) Upsert(ctx context.Context, override *domain.DocumentFieldOverride) error {
+	args := m.Called(ctx, override)
+	return args.Error(0)
+}
+
+func (m *MockDocumentFieldOverrideRepo) ListByDocument(ctx context.Context, tenantID, documentID uuid.UUID) ([]domain.DocumentFieldOverride, error) {
+	args := m.Called(ctx, tenantID, documentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DocumentFieldOverride), args.Error(1)
+}
+
+func (m *MockDocumentFieldOverrideRepo) DeleteByPath(ctx context.Context, tenantID, documentID uuid.UUID, fieldPath string) error {
+	args := m.Called(ctx, tenantID, documentID, fieldPath)
+	return args.Error(0)
+}
+
+func (m *MockDocumentFieldOverrideRepo) DeleteByDocument(ctx context.Context, tenantID, documentID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, documentID)
+	return args.Error(0)
+}
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockDocumentLockRepo is a mock implementation of port.DocumentLockRepository.
+type MockDocumentLockRepo struct {
+	mock.Mock
+}
+
+func (m *MockDocumentLockRepo) Acquire(ctx context.Context, lock *domain.DocumentLock) (*domain.DocumentLock, error) {
+	args := m.Called(ctx, lock)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DocumentLock), args.Error(1)
+}
+
+func (m *MockDocumentLockRepo) GetActive(ctx context.Context, tenantID, documentID uuid.UUID) (*domain.DocumentLock, error) {
+	args := m.Called(ctx, tenantID, documentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DocumentLock), args.Error(1)
+}
+
+func (m *MockDocumentLockRepo) Release(ctx context.Context, tenantID, documentID uuid.UUID, userID *uuid.UUID) error {
+	args := m.Called(ctx, tenantID, documentID, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockDocumentLockService is a mock implementation of service.DocumentLockService.
+type MockDocumentLockService struct {
+	mock.Mock
+}
+
+func (m *MockDocumentLockService) Acquire(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole, ttl time.Duration) (*domain.DocumentLock, error) {
+	args := m.Called(ctx, tenantID, documentID, userID, role, ttl)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DocumentLock), args.Error(1)
+}
+
+func (m *MockDocumentLockService) Get(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole) (*domain.DocumentLock, error) {
+	args := m.Called(ctx, tenantID, documentID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DocumentLock), args.Error(1)
+}
+
+func (m *MockDocumentLockService) Release(ctx context.Context, tenantID, documentID, userID uuid.UUID, role domain.UserRole) error {
+	args := m.Called(ctx, tenantID, documentID, userID, role)
+	return args.Error(0)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -64,6 +65,11 @@ func (m *MockDocumentRepo) UpdateStructuredData(ctx context.Context, doc *domain
 	return args.Error(0)
 }
 
+func (m *MockDocumentRepo) UpdateStructuredDataIfUnmodified(ctx context.Context, doc *domain.Document, lastUpdatedAt time.Time) error {
+	args := m.Called(ctx, doc, lastUpdatedAt)
+	return args.Error(0)
+}
+
 func (m *MockDocumentRepo) UpdateReviewStatus(ctx context.Context, doc *domain.Document) error {
 	args := m.Called(ctx, doc)
 	return args.Error(0)
@@ -79,8 +85,32 @@ func (m *MockDocumentRepo) UpdateValidationResults(ctx context.Context, doc *dom
 	return args.Error(0)
 }
 
-func (m *MockDocumentRepo) ListReviewQueue(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.Document, int, error) {
-	args := m.Called(ctx, tenantID, userID, offset, limit)
+func (m *MockDocumentRepo) ListReviewQueue(ctx context.Context, tenantID uuid.UUID, assignees []uuid.UUID, offset, limit int) ([]domain.Document, int, error) {
+	args := m.Called(ctx, tenantID, assignees, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Document), args.Int(1), args.Error(2)
+}
+
+func (m *MockDocumentRepo) ReviewQueueNeighbors(ctx context.Context, tenantID uuid.UUID, assignees []uuid.UUID, assignedAt time.Time, docID uuid.UUID) (*domain.DocumentNeighbors, error) {
+	args := m.Called(ctx, tenantID, assignees, assignedAt, docID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DocumentNeighbors), args.Error(1)
+}
+
+func (m *MockDocumentRepo) CollectionNeighbors(ctx context.Context, tenantID, collectionID uuid.UUID, assignedTo *uuid.UUID, createdAt time.Time, docID uuid.UUID) (*domain.DocumentNeighbors, error) {
+	args := m.Called(ctx, tenantID, collectionID, assignedTo, createdAt, docID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DocumentNeighbors), args.Error(1)
+}
+
+func (m *MockDocumentRepo) ListCheckerQueue(ctx context.Context, tenantID, userID uuid.UUID, ownedOnly bool, offset, limit int) ([]domain.Document, int, error) {
+	args := m.Called(ctx, tenantID, userID, ownedOnly, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Int(1), args.Error(2)
 	}
@@ -99,3 +129,45 @@ func (m *MockDocumentRepo) Delete(ctx context.Context, tenantID, docID uuid.UUID
 	args := m.Called(ctx, tenantID, docID)
 	return args.Error(0)
 }
+
+func (m *MockDocumentRepo) SaveVersion(ctx context.Context, doc *domain.Document, prev *domain.DocumentVersion) error {
+	args := m.Called(ctx, doc, prev)
+	return args.Error(0)
+}
+
+func (m *MockDocumentRepo) ListVersions(ctx context.Context, tenantID, docID uuid.UUID) ([]domain.DocumentVersion, error) {
+	args := m.Called(ctx, tenantID, docID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DocumentVersion), args.Error(1)
+}
+
+func (m *MockDocumentRepo) ListApprovals(ctx context.Context, tenantID, docID uuid.UUID) ([]domain.DocumentApproval, error) {
+	args := m.Called(ctx, tenantID, docID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DocumentApproval), args.Error(1)
+}
+
+func (m *MockDocumentRepo) UpdateName(ctx context.Context, doc *domain.Document) error {
+	args := m.Called(ctx, doc)
+	return args.Error(0)
+}
+
+func (m *MockDocumentRepo) ListNamesWithPrefix(ctx context.Context, tenantID, collectionID uuid.UUID, prefix string, excludeID uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, tenantID, collectionID, prefix, excludeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockDocumentRepo) ListValidationFailures(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.ValidationFailure, error) {
+	args := m.Called(ctx, tenantID, collectionID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ValidationFailure), args.Error(1)
+}
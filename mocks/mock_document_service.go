@@ -24,6 +24,14 @@ func (m *MockDocumentService) CreateAndParse(ctx context.Context, input *service
 	return args.Get(0).(*domain.Document), args.Error(1)
 }
 
+func (m *MockDocumentService) CreateParsed(ctx context.Context, input *service.CreateParsedDocumentInput) (*domain.Document, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Document), args.Error(1)
+}
+
 func (m *MockDocumentService) GetByID(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*domain.Document, error) {
 	args := m.Called(ctx, tenantID, docID, userID, role)
 	if args.Get(0) == nil {
@@ -80,6 +88,22 @@ func (m *MockDocumentService) ListReviewQueue(ctx context.Context, tenantID, use
 	return args.Get(0).([]domain.Document), args.Int(1), args.Error(2)
 }
 
+func (m *MockDocumentService) ListCheckerQueue(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Document, int, error) {
+	args := m.Called(ctx, tenantID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Document), args.Int(1), args.Error(2)
+}
+
+func (m *MockDocumentService) GetNeighbors(ctx context.Context, input *service.NeighborsInput) (*domain.DocumentNeighbors, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DocumentNeighbors), args.Error(1)
+}
+
 func (m *MockDocumentService) EditStructuredData(ctx context.Context, input *service.EditStructuredDataInput) (*domain.Document, error) {
 	args := m.Called(ctx, input)
 	if args.Get(0) == nil {
@@ -109,6 +133,22 @@ func (m *MockDocumentService) GetValidation(ctx context.Context, tenantID, docID
 	return args.Get(0).(*validator.ValidationResponse), args.Error(1)
 }
 
+func (m *MockDocumentService) RecomputeTotals(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*service.TotalsRecomputation, error) {
+	args := m.Called(ctx, tenantID, docID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.TotalsRecomputation), args.Error(1)
+}
+
+func (m *MockDocumentService) PatchLineItems(ctx context.Context, input *service.PatchLineItemsInput) (*domain.Document, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Document), args.Error(1)
+}
+
 func (m *MockDocumentService) Delete(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) error {
 	args := m.Called(ctx, tenantID, docID, userID, role)
 	return args.Error(0)
@@ -122,8 +162,8 @@ func (m *MockDocumentService) ListTags(ctx context.Context, tenantID, docID, use
 	return args.Get(0).([]domain.DocumentTag), args.Error(1)
 }
 
-func (m *MockDocumentService) AddTags(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, tags map[string]string) ([]domain.DocumentTag, error) {
-	args := m.Called(ctx, tenantID, docID, userID, role, tags)
+func (m *MockDocumentService) AddTags(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, tags map[string]string, types map[string]domain.TagValueType) ([]domain.DocumentTag, error) {
+	args := m.Called(ctx, tenantID, docID, userID, role, tags, types)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -139,10 +179,92 @@ func (m *MockDocumentService) ParseDocument(ctx context.Context, doc *domain.Doc
 	m.Called(ctx, doc, maxAttempts)
 }
 
-func (m *MockDocumentService) SearchByTag(ctx context.Context, tenantID uuid.UUID, key, value string, offset, limit int) ([]domain.Document, int, error) {
-	args := m.Called(ctx, tenantID, key, value, offset, limit)
+func (m *MockDocumentService) Events() *service.DocumentEvents {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*service.DocumentEvents)
+}
+
+func (m *MockDocumentService) SearchByTag(ctx context.Context, tenantID uuid.UUID, input *service.TagSearchInput, offset, limit int) ([]domain.Document, int, error) {
+	args := m.Called(ctx, tenantID, input, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Int(1), args.Error(2)
 	}
 	return args.Get(0).([]domain.Document), args.Int(1), args.Error(2)
 }
+
+func (m *MockDocumentService) EnrichDocuments(ctx context.Context, tenantID uuid.UUID, docs []domain.Document) error {
+	args := m.Called(ctx, tenantID, docs)
+	return args.Error(0)
+}
+
+func (m *MockDocumentService) ListOverrides(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentFieldOverride, error) {
+	args := m.Called(ctx, tenantID, docID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DocumentFieldOverride), args.Error(1)
+}
+
+func (m *MockDocumentService) GetTimeline(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) (*domain.DocumentTimeline, error) {
+	args := m.Called(ctx, tenantID, docID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DocumentTimeline), args.Error(1)
+}
+
+func (m *MockDocumentService) ListParseAttempts(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.ParseTiming, error) {
+	args := m.Called(ctx, tenantID, docID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ParseTiming), args.Error(1)
+}
+
+func (m *MockDocumentService) ClearOverrides(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole, fieldPath string) error {
+	args := m.Called(ctx, tenantID, docID, userID, role, fieldPath)
+	return args.Error(0)
+}
+
+func (m *MockDocumentService) ReplaceFile(ctx context.Context, input *service.ReplaceFileInput) (*domain.Document, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Document), args.Error(1)
+}
+
+func (m *MockDocumentService) Reclassify(ctx context.Context, input *service.ReclassifyInput) (*domain.Document, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Document), args.Error(1)
+}
+
+func (m *MockDocumentService) ListVersions(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentVersion, error) {
+	args := m.Called(ctx, tenantID, docID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DocumentVersion), args.Error(1)
+}
+
+func (m *MockDocumentService) ListApprovals(ctx context.Context, tenantID, docID, userID uuid.UUID, role domain.UserRole) ([]domain.DocumentApproval, error) {
+	args := m.Called(ctx, tenantID, docID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DocumentApproval), args.Error(1)
+}
+
+func (m *MockDocumentService) ListValidationFailures(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ValidationFailure, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ValidationFailure), args.Error(1)
+}
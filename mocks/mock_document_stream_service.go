@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/service"
+)
+
+// MockDocumentStreamService is a mock implementation of service.DocumentStreamService.
+// Records set with Records are emitted before the mocked error is returned.
+type MockDocumentStreamService struct {
+	mock.Mock
+	Records []service.DocumentStreamRecord
+}
+
+func (m *MockDocumentStreamService) Stream(ctx context.Context, input *service.StreamDocumentsInput, emit func(*service.DocumentStreamRecord) error) error {
+	args := m.Called(ctx, input)
+	for i := range m.Records {
+		if err := emit(&m.Records[i]); err != nil {
+			return err
+		}
+	}
+	return args.Error(0)
+}
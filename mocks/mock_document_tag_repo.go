@@ -27,8 +27,16 @@ func (m *MockDocumentTagRepo) ListByDocument(ctx context.Context, documentID uui
 	return args.Get(0).([]domain.DocumentTag), args.Error(1)
 }
 
-func (m *MockDocumentTagRepo) SearchByTag(ctx context.Context, tenantID uuid.UUID, key, value string, offset, limit int) ([]domain.Document, int, error) {
-	args := m.Called(ctx, tenantID, key, value, offset, limit)
+func (m *MockDocumentTagRepo) ListByDocuments(ctx context.Context, tenantID uuid.UUID, documentIDs []uuid.UUID) (map[uuid.UUID][]domain.DocumentTag, error) {
+	args := m.Called(ctx, tenantID, documentIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]domain.DocumentTag), args.Error(1)
+}
+
+func (m *MockDocumentTagRepo) SearchByTag(ctx context.Context, tenantID uuid.UUID, q *domain.TagQuery, offset, limit int) ([]domain.Document, int, error) {
+	args := m.Called(ctx, tenantID, q, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Int(1), args.Error(2)
 	}
@@ -49,3 +57,13 @@ func (m *MockDocumentTagRepo) DeleteByDocumentAndSource(ctx context.Context, doc
 	args := m.Called(ctx, documentID, source)
 	return args.Error(0)
 }
+
+func (m *MockDocumentTagRepo) AddIfMissing(ctx context.Context, tag *domain.DocumentTag) (bool, error) {
+	args := m.Called(ctx, tag)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDocumentTagRepo) DeleteByKeyValue(ctx context.Context, documentID uuid.UUID, key, value string) (int, error) {
+	args := m.Called(ctx, documentID, key, value)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// MockDuplicateFinder is a mock implementation of port.DuplicateInvoiceFinder.
+type MockDuplicateFinder struct {
+	mock.Mock
+}
+
+func (m *MockDuplicateFinder) FindDuplicates(ctx context.Context, tenantID, excludeDocID uuid.UUID, sellerGSTIN, invoiceNumber string) ([]port.DuplicateMatch, error) {
+	args := m.Called(ctx, tenantID, excludeDocID, sellerGSTIN, invoiceNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]port.DuplicateMatch), args.Error(1)
+}
+
+func (m *MockDuplicateFinder) FindCandidates(ctx context.Context, tenantID, excludeDocID uuid.UUID, sellerGSTIN string, userID uuid.UUID, role domain.UserRole, limit int) ([]port.DuplicateCandidate, error) {
+	args := m.Called(ctx, tenantID, excludeDocID, sellerGSTIN, userID, role, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]port.DuplicateCandidate), args.Error(1)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/port"
 )
 
 // MockEmailSender is a mock implementation of port.EmailSender.
@@ -20,3 +22,23 @@ func (m *MockEmailSender) SendPasswordResetEmail(ctx context.Context, toEmail, t
 	args := m.Called(ctx, toEmail, toName, resetToken)
 	return args.Error(0)
 }
+
+func (m *MockEmailSender) SendIngestionReport(ctx context.Context, toEmail, toName string, report *port.IngestionReport) error {
+	args := m.Called(ctx, toEmail, toName, report)
+	return args.Error(0)
+}
+
+func (m *MockEmailSender) SendEmailChangeNotice(ctx context.Context, toEmail, toName, newEmail, undoToken string) error {
+	args := m.Called(ctx, toEmail, toName, newEmail, undoToken)
+	return args.Error(0)
+}
+
+func (m *MockEmailSender) SendInvitationEmail(ctx context.Context, toEmail, toName, tenantName, inviteToken string) error {
+	args := m.Called(ctx, toEmail, toName, tenantName, inviteToken)
+	return args.Error(0)
+}
+
+func (m *MockEmailSender) SendAlertEmail(ctx context.Context, toEmail, subject, body string) error {
+	args := m.Called(ctx, toEmail, subject, body)
+	return args.Error(0)
+}
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockEmailSuppressionRepo is a mock implementation of port.EmailSuppressionRepository.
+type MockEmailSuppressionRepo struct {
+	mock.Mock
+}
+
+func (m *MockEmailSuppressionRepo) Suppress(ctx context.Context, s *domain.EmailSuppression) ([]domain.User, error) {
+	args := m.Called(ctx, s)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockEmailSuppressionRepo) Get(ctx context.Context, email string) (*domain.EmailSuppression, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmailSuppression), args.Error(1)
+}
+
+func (m *MockEmailSuppressionRepo) Delete(ctx context.Context, tenantID uuid.UUID, email string) error {
+	args := m.Called(ctx, tenantID, email)
+	return args.Error(0)
+}
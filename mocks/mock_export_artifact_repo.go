@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockExportArtifactRepo is a mock implementation of port.ExportArtifactRepository.
+type MockExportArtifactRepo struct {
+	mock.Mock
+}
+
+func (m *MockExportArtifactRepo) Record(ctx context.Context, artifact *domain.ExportArtifact, userID uuid.UUID) error {
+	args := m.Called(ctx, artifact, userID)
+	return args.Error(0)
+}
+
+func (m *MockExportArtifactRepo) GetByID(ctx context.Context, tenantID, artifactID uuid.UUID) (*domain.ExportArtifact, error) {
+	args := m.Called(ctx, tenantID, artifactID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExportArtifact), args.Error(1)
+}
+
+func (m *MockExportArtifactRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, checksum string, offset, limit int) ([]domain.ExportArtifact, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, checksum, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.ExportArtifact), args.Int(1), args.Error(2)
+}
+
+func (m *MockExportArtifactRepo) ListDownloads(ctx context.Context, tenantID, artifactID uuid.UUID, offset, limit int) ([]domain.ExportDownload, int, error) {
+	args := m.Called(ctx, tenantID, artifactID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.ExportDownload), args.Int(1), args.Error(2)
+}
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockExportAuditService is a mock implementation of service.ExportAuditService.
+type MockExportAuditService struct {
+	mock.Mock
+}
+
+func (m *MockExportAuditService) Record(ctx context.Context, input *service.RecordExportInput) (*domain.ExportArtifact, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExportArtifact), args.Error(1)
+}
+
+func (m *MockExportAuditService) ListByCollection(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, checksum string, offset, limit int) ([]domain.ExportArtifact, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role, checksum, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.ExportArtifact), args.Int(1), args.Error(2)
+}
+
+func (m *MockExportAuditService) ListDownloads(ctx context.Context, tenantID, collectionID, artifactID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ExportDownload, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, artifactID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.ExportDownload), args.Int(1), args.Error(2)
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockFeatureFlagRepo is a mock implementation of port.FeatureFlagRepository.
+type MockFeatureFlagRepo struct {
+	mock.Mock
+}
+
+func (m *MockFeatureFlagRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantFeatureFlag, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TenantFeatureFlag), args.Error(1)
+}
+
+func (m *MockFeatureFlagRepo) Upsert(ctx context.Context, flag *domain.TenantFeatureFlag) error {
+	args := m.Called(ctx, flag)
+	return args.Error(0)
+}
+
+func (m *MockFeatureFlagRepo) Delete(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) error {
+	args := m.Called(ctx, tenantID, flag)
+	return args.Error(0)
+}
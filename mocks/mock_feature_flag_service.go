@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockFeatureFlagService is a mock implementation of service.FeatureFlagService.
+// It also satisfies port.Flags.
+type MockFeatureFlagService struct {
+	mock.Mock
+}
+
+func (m *MockFeatureFlagService) IsEnabled(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) bool {
+	args := m.Called(ctx, tenantID, flag)
+	return args.Bool(0)
+}
+
+func (m *MockFeatureFlagService) List(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantFeatureFlag, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TenantFeatureFlag), args.Error(1)
+}
+
+func (m *MockFeatureFlagService) Set(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag, enabled bool, updatedBy uuid.UUID) (*domain.TenantFeatureFlag, error) {
+	args := m.Called(ctx, tenantID, flag, enabled, updatedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TenantFeatureFlag), args.Error(1)
+}
+
+func (m *MockFeatureFlagService) Reset(ctx context.Context, tenantID uuid.UUID, flag domain.FeatureFlag) error {
+	args := m.Called(ctx, tenantID, flag)
+	return args.Error(0)
+}
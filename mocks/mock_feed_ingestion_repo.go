@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockFeedIngestionRepo is a mock implementation of port.FeedIngestionRepository.
+type MockFeedIngestionRepo struct {
+	mock.Mock
+}
+
+func (m *MockFeedIngestionRepo) Claim(ctx context.Context, rec *domain.FeedIngestion) (bool, error) {
+	args := m.Called(ctx, rec)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFeedIngestionRepo) Complete(ctx context.Context, rec *domain.FeedIngestion) error {
+	args := m.Called(ctx, rec)
+	return args.Error(0)
+}
+
+func (m *MockFeedIngestionRepo) FailStale(ctx context.Context, cutoff time.Time) (int, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockFeedIngestionRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.FeedIngestion, int, error) {
+	args := m.Called(ctx, tenantID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.FeedIngestion), args.Int(1), args.Error(2)
+}
+
+func (m *MockFeedIngestionRepo) ListCompletedBetween(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]domain.FeedIngestion, error) {
+	args := m.Called(ctx, tenantID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.FeedIngestion), args.Error(1)
+}
+
+func (m *MockFeedIngestionRepo) ClaimReport(ctx context.Context, tenantID uuid.UUID, date time.Time) (bool, error) {
+	args := m.Called(ctx, tenantID, date)
+	return args.Bool(0), args.Error(1)
+}
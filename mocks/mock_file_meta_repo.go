@@ -52,3 +52,16 @@ func (m *MockFileMetaRepo) Delete(ctx context.Context, tenantID, fileID uuid.UUI
 	args := m.Called(ctx, tenantID, fileID)
 	return args.Error(0)
 }
+
+func (m *MockFileMetaRepo) ListRelocations(ctx context.Context, tenantID, afterID uuid.UUID, limit int) ([]domain.FileRelocation, error) {
+	args := m.Called(ctx, tenantID, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.FileRelocation), args.Error(1)
+}
+
+func (m *MockFileMetaRepo) UpdateS3Key(ctx context.Context, tenantID, fileID uuid.UUID, oldKey, newKey string) error {
+	args := m.Called(ctx, tenantID, fileID, oldKey, newKey)
+	return args.Error(0)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockFilePreflightService is a mock implementation of service.FilePreflightService.
+type MockFilePreflightService struct {
+	mock.Mock
+}
+
+func (m *MockFilePreflightService) Preflight(ctx context.Context, input *service.FilePreflightInput) (*domain.FilePreflight, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FilePreflight), args.Error(1)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"satvos/internal/domain"
+	"satvos/internal/port"
 	"satvos/internal/service"
 )
 
@@ -23,6 +24,14 @@ func (m *MockFileService) Upload(ctx context.Context, input service.FileUploadIn
 	return args.Get(0).(*domain.FileMeta), args.Error(1)
 }
 
+func (m *MockFileService) Ingest(ctx context.Context, input service.FileIngestInput) (*domain.FileMeta, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FileMeta), args.Error(1)
+}
+
 func (m *MockFileService) GetByID(ctx context.Context, tenantID, fileID uuid.UUID) (*domain.FileMeta, error) {
 	args := m.Called(ctx, tenantID, fileID)
 	if args.Get(0) == nil {
@@ -52,6 +61,14 @@ func (m *MockFileService) GetDownloadURL(ctx context.Context, tenantID, fileID u
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockFileService) OpenContent(ctx context.Context, tenantID, fileID uuid.UUID) (*domain.FileMeta, *port.ObjectReader, error) {
+	args := m.Called(ctx, tenantID, fileID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.FileMeta), args.Get(1).(*port.ObjectReader), args.Error(2)
+}
+
 func (m *MockFileService) Delete(ctx context.Context, tenantID, fileID uuid.UUID) error {
 	args := m.Called(ctx, tenantID, fileID)
 	return args.Error(0)
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockImportJobRepo is a mock implementation of port.ImportJobRepository.
+type MockImportJobRepo struct {
+	mock.Mock
+}
+
+func (m *MockImportJobRepo) Create(ctx context.Context, job *domain.ImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockImportJobRepo) GetByID(ctx context.Context, tenantID, jobID uuid.UUID) (*domain.ImportJob, error) {
+	args := m.Called(ctx, tenantID, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.ImportJob, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.ImportJob), args.Int(1), args.Error(2)
+}
+
+func (m *MockImportJobRepo) UpdateProgress(ctx context.Context, job *domain.ImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockImportService is a mock implementation of service.ImportService.
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) ImportZip(ctx context.Context, input *service.ZipImportInput) (*domain.ImportJob, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) ImportS3Prefix(ctx context.Context, input *service.S3ImportInput) (*domain.ImportJob, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) GetJob(ctx context.Context, tenantID, collectionID, jobID, userID uuid.UUID, role domain.UserRole) (*domain.ImportJob, error) {
+	args := m.Called(ctx, tenantID, collectionID, jobID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) ListJobs(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ImportJob, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.ImportJob), args.Int(1), args.Error(2)
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockInAppNotificationRepo is a mock implementation of port.InAppNotificationRepository.
+type MockInAppNotificationRepo struct {
+	mock.Mock
+}
+
+func (m *MockInAppNotificationRepo) Create(ctx context.Context, n *domain.InAppNotification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
+
+func (m *MockInAppNotificationRepo) ListByUser(ctx context.Context, tenantID, userID uuid.UUID, unreadOnly bool, offset, limit int) ([]domain.InAppNotification, int, error) {
+	args := m.Called(ctx, tenantID, userID, unreadOnly, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.InAppNotification), args.Int(1), args.Error(2)
+}
+
+func (m *MockInAppNotificationRepo) MarkRead(ctx context.Context, tenantID, userID, notificationID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID, notificationID)
+	return args.Error(0)
+}
@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockIntegrationRepo is a mock implementation of port.IntegrationRepository.
+type MockIntegrationRepo struct {
+	mock.Mock
+}
+
+func (m *MockIntegrationRepo) CreateHook(ctx context.Context, hook *domain.IntegrationHook) error {
+	args := m.Called(ctx, hook)
+	return args.Error(0)
+}
+
+func (m *MockIntegrationRepo) GetHook(ctx context.Context, tenantID, hookID uuid.UUID) (*domain.IntegrationHook, error) {
+	args := m.Called(ctx, tenantID, hookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IntegrationHook), args.Error(1)
+}
+
+func (m *MockIntegrationRepo) UpdateHookTemplate(ctx context.Context, tenantID, hookID uuid.UUID, payloadTemplate *string) error {
+	args := m.Called(ctx, tenantID, hookID, payloadTemplate)
+	return args.Error(0)
+}
+
+func (m *MockIntegrationRepo) DeleteHook(ctx context.Context, tenantID, hookID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, hookID)
+	return args.Error(0)
+}
+
+func (m *MockIntegrationRepo) ListHooksByUser(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.IntegrationHook, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.IntegrationHook), args.Error(1)
+}
+
+func (m *MockIntegrationRepo) ListHooksForEvent(ctx context.Context, tenantID uuid.UUID, event domain.IntegrationEvent) ([]domain.IntegrationHook, error) {
+	args := m.Called(ctx, tenantID, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.IntegrationHook), args.Error(1)
+}
+
+func (m *MockIntegrationRepo) ListApprovedSince(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID, afterAt time.Time, afterID uuid.UUID, limit int) ([]domain.Document, error) {
+	args := m.Called(ctx, tenantID, userID, explicitOnly, collectionID, afterAt, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Document), args.Error(1)
+}
+
+func (m *MockIntegrationRepo) ListRecentlyApproved(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, collectionID *uuid.UUID, limit int) ([]domain.Document, error) {
+	args := m.Called(ctx, tenantID, userID, explicitOnly, collectionID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Document), args.Error(1)
+}
@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockIntegrationService is a mock implementation of service.IntegrationService.
+type MockIntegrationService struct {
+	mock.Mock
+}
+
+func (m *MockIntegrationService) ListApproved(ctx context.Context, input *service.ListApprovedInput) (*service.ApprovedDocumentsPage, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ApprovedDocumentsPage), args.Error(1)
+}
+
+func (m *MockIntegrationService) Subscribe(ctx context.Context, input *service.SubscribeHookInput) (*domain.IntegrationHook, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IntegrationHook), args.Error(1)
+}
+
+func (m *MockIntegrationService) ListHooks(ctx context.Context, tenantID, userID uuid.UUID) ([]domain.IntegrationHook, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.IntegrationHook), args.Error(1)
+}
+
+func (m *MockIntegrationService) SetHookTemplate(
+	ctx context.Context, tenantID, hookID, userID uuid.UUID, role domain.UserRole, input *service.SetHookTemplateInput,
+) (*domain.IntegrationHook, error) {
+	args := m.Called(ctx, tenantID, hookID, userID, role, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IntegrationHook), args.Error(1)
+}
+
+func (m *MockIntegrationService) Unsubscribe(ctx context.Context, tenantID, hookID, userID uuid.UUID, role domain.UserRole) error {
+	args := m.Called(ctx, tenantID, hookID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockIntegrationService) NotifyApproved(doc *domain.Document) {
+	m.Called(doc)
+}
+
+func (m *MockIntegrationService) DeliverApproved(ctx context.Context, doc *domain.Document) error {
+	args := m.Called(ctx, doc)
+	return args.Error(0)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLeaderLock is a mock implementation of port.LeaderLock.
+type MockLeaderLock struct {
+	mock.Mock
+}
+
+func (m *MockLeaderLock) TryAcquire(ctx context.Context) (bool, error) {
+	args := m.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLeaderLock) Check(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockLeaderLock) Release(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
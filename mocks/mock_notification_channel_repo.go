@@ -0,0 +1,94 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockNotificationChannelRepo is a mock implementation of port.NotificationChannelRepository.
+type MockNotificationChannelRepo struct {
+	mock.Mock
+}
+
+func (m *MockNotificationChannelRepo) Create(ctx context.Context, channel *domain.NotificationChannel) error {
+	args := m.Called(ctx, channel)
+	return args.Error(0)
+}
+
+func (m *MockNotificationChannelRepo) GetByID(ctx context.Context, tenantID, channelID uuid.UUID) (*domain.NotificationChannel, error) {
+	args := m.Called(ctx, tenantID, channelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationChannelRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID) ([]domain.NotificationChannel, error) {
+	args := m.Called(ctx, tenantID, collectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationChannelRepo) Update(ctx context.Context, channel *domain.NotificationChannel) error {
+	args := m.Called(ctx, channel)
+	return args.Error(0)
+}
+
+func (m *MockNotificationChannelRepo) Delete(ctx context.Context, tenantID, channelID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, channelID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationChannelRepo) ListForEvent(ctx context.Context, tenantID, collectionID uuid.UUID, event domain.NotificationEvent) ([]domain.NotificationChannel, error) {
+	args := m.Called(ctx, tenantID, collectionID, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationChannelRepo) ListScheduled(ctx context.Context) ([]domain.NotificationChannel, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationChannelRepo) AdvanceSLACheck(ctx context.Context, channelID uuid.UUID, from, to time.Time) (bool, error) {
+	args := m.Called(ctx, channelID, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationChannelRepo) AdvanceSummary(ctx context.Context, channelID uuid.UUID, from, to time.Time) (bool, error) {
+	args := m.Called(ctx, channelID, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockNotificationChannelRepo) RecordDelivery(ctx context.Context, channelID uuid.UUID, at time.Time, lastError string) error {
+	args := m.Called(ctx, channelID, at, lastError)
+	return args.Error(0)
+}
+
+func (m *MockNotificationChannelRepo) ListOverdueReviews(ctx context.Context, tenantID, collectionID uuid.UUID, after, through time.Time, limit int) ([]domain.Document, error) {
+	args := m.Called(ctx, tenantID, collectionID, after, through, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Document), args.Error(1)
+}
+
+func (m *MockNotificationChannelRepo) CollectionActivity(ctx context.Context, tenantID, collectionID uuid.UUID, from, to time.Time) (*domain.CollectionActivity, error) {
+	args := m.Called(ctx, tenantID, collectionID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CollectionActivity), args.Error(1)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockNotificationRouteRepo is a mock implementation of port.NotificationRouteRepository.
+type MockNotificationRouteRepo struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRouteRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.NotificationRoute, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NotificationRoute), args.Error(1)
+}
+
+func (m *MockNotificationRouteRepo) Replace(ctx context.Context, tenantID uuid.UUID, routes []domain.NotificationRoute) error {
+	args := m.Called(ctx, tenantID, routes)
+	return args.Error(0)
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockNotificationService is a mock implementation of service.NotificationService.
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) ListChannels(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) ([]domain.NotificationChannel, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationService) CreateChannel(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, input *service.NotificationChannelInput) (*domain.NotificationChannel, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationService) UpdateChannel(ctx context.Context, tenantID, collectionID, channelID, userID uuid.UUID, role domain.UserRole, input *service.NotificationChannelInput) (*domain.NotificationChannel, error) {
+	args := m.Called(ctx, tenantID, collectionID, channelID, userID, role, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NotificationChannel), args.Error(1)
+}
+
+func (m *MockNotificationService) DeleteChannel(ctx context.Context, tenantID, collectionID, channelID, userID uuid.UUID, role domain.UserRole) error {
+	args := m.Called(ctx, tenantID, collectionID, channelID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) TestChannel(ctx context.Context, tenantID, collectionID, channelID, userID uuid.UUID, role domain.UserRole, event domain.NotificationEvent) error {
+	args := m.Called(ctx, tenantID, collectionID, channelID, userID, role, event)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) NotifyDocument(event domain.NotificationEvent, doc *domain.Document) {
+	m.Called(event, doc)
+}
+
+func (m *MockNotificationService) RunScheduled(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
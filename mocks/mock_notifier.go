@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/port"
+)
+
+// MockNotifier is a mock implementation of port.Notifier.
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, n *port.Notification) error {
+	args := m.Called(ctx, n)
+	return args.Error(0)
+}
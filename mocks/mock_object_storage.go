@@ -29,6 +29,14 @@ func (m *MockObjectStorage) Download(ctx context.Context, bucket, key string) ([
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (m *MockObjectStorage) Open(ctx context.Context, bucket, key string) (*port.ObjectReader, error) {
+	args := m.Called(ctx, bucket, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*port.ObjectReader), args.Error(1)
+}
+
 func (m *MockObjectStorage) Delete(ctx context.Context, bucket, key string) error {
 	args := m.Called(ctx, bucket, key)
 	return args.Error(0)
@@ -38,3 +46,26 @@ func (m *MockObjectStorage) GetPresignedURL(ctx context.Context, bucket, key str
 	args := m.Called(ctx, bucket, key, expirySeconds)
 	return args.String(0), args.Error(1)
 }
+
+func (m *MockObjectStorage) List(ctx context.Context, bucket, prefix string) ([]port.ObjectInfo, error) {
+	args := m.Called(ctx, bucket, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]port.ObjectInfo), args.Error(1)
+}
+
+func (m *MockObjectStorage) Copy(ctx context.Context, bucket, srcKey, dstKey string, enc *port.Encryption) error {
+	args := m.Called(ctx, bucket, srcKey, dstKey, enc)
+	return args.Error(0)
+}
+
+func (m *MockObjectStorage) PutLifecycleRule(ctx context.Context, bucket string, rule port.LifecycleRule) error {
+	args := m.Called(ctx, bucket, rule)
+	return args.Error(0)
+}
+
+func (m *MockObjectStorage) DeleteLifecycleRule(ctx context.Context, bucket, ruleID string) error {
+	args := m.Called(ctx, bucket, ruleID)
+	return args.Error(0)
+}
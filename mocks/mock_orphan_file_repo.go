@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockOrphanFileRepo is a mock implementation of port.OrphanFileRepository.
+type MockOrphanFileRepo struct {
+	mock.Mock
+}
+
+func (m *MockOrphanFileRepo) Summarize(ctx context.Context, tenantID uuid.UUID, olderThan time.Time) ([]domain.OrphanFileSummary, error) {
+	args := m.Called(ctx, tenantID, olderThan)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrphanFileSummary), args.Error(1)
+}
+
+func (m *MockOrphanFileRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, olderThan time.Time, offset, limit int) ([]domain.FileMeta, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, olderThan, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.FileMeta), args.Int(1), args.Error(2)
+}
+
+func (m *MockOrphanFileRepo) ClaimNudge(ctx context.Context, tenantID, collectionID uuid.UUID, fileCount int, notSince time.Time) (bool, error) {
+	args := m.Called(ctx, tenantID, collectionID, fileCount, notSince)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockOrphanFileService is a mock implementation of service.OrphanFileService.
+type MockOrphanFileService struct {
+	mock.Mock
+}
+
+func (m *MockOrphanFileService) Summary(ctx context.Context, tenantID uuid.UUID, olderThanDays int) ([]domain.OrphanFileSummary, error) {
+	args := m.Called(ctx, tenantID, olderThanDays)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OrphanFileSummary), args.Error(1)
+}
+
+func (m *MockOrphanFileService) List(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, olderThanDays, offset, limit int) ([]domain.FileMeta, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role, olderThanDays, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.FileMeta), args.Int(1), args.Error(2)
+}
+
+func (m *MockOrphanFileService) CreateDocuments(ctx context.Context, input *service.OrphanDocumentsInput) (*domain.OrphanDocumentRun, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrphanDocumentRun), args.Error(1)
+}
+
+func (m *MockOrphanFileService) RunNudges(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
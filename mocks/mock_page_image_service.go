@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/service"
+)
+
+// MockPageImageService is a mock implementation of service.PageImageService.
+type MockPageImageService struct {
+	mock.Mock
+}
+
+func (m *MockPageImageService) GetPageImage(ctx context.Context, input *service.PageImageInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPageRenderer is a mock implementation of port.PageRenderer.
+type MockPageRenderer struct {
+	mock.Mock
+}
+
+func (m *MockPageRenderer) RenderPage(ctx context.Context, pdf []byte, page, dpi int) ([]byte, error) {
+	args := m.Called(ctx, pdf, page, dpi)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockParseBudgetRepo is a mock implementation of port.ParseBudgetRepository.
+type MockParseBudgetRepo struct {
+	mock.Mock
+}
+
+func (m *MockParseBudgetRepo) Reserve(ctx context.Context, tenantID, userID uuid.UUID, day time.Time, limit int) error {
+	args := m.Called(ctx, tenantID, userID, day, limit)
+	return args.Error(0)
+}
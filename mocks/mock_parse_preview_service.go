@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/service"
+)
+
+// MockParsePreviewService is a mock implementation of service.ParsePreviewService.
+type MockParsePreviewService struct {
+	mock.Mock
+}
+
+func (m *MockParsePreviewService) Preview(ctx context.Context, input *service.ParsePreviewInput) (*service.ParsePreviewResult, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ParsePreviewResult), args.Error(1)
+}
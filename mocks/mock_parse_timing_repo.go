@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockParseTimingRepo is a mock implementation of port.ParseTimingRepository.
+type MockParseTimingRepo struct {
+	mock.Mock
+}
+
+func (m *MockParseTimingRepo) Record(ctx context.Context, timing *domain.ParseTiming) error {
+	args := m.Called(ctx, timing)
+	return args.Error(0)
+}
+
+func (m *MockParseTimingRepo) ListByDocument(ctx context.Context, tenantID, documentID uuid.UUID) ([]domain.ParseTiming, error) {
+	args := m.Called(ctx, tenantID, documentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ParseTiming), args.Error(1)
+}
+
+func (m *MockParseTimingRepo) LatencyByModel(ctx context.Context, tenantID *uuid.UUID, since time.Time) ([]domain.ParseLatencyStats, error) {
+	args := m.Called(ctx, tenantID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ParseLatencyStats), args.Error(1)
+}
+
+func (m *MockParseTimingRepo) OldestWaiting(ctx context.Context) (time.Duration, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
@@ -3,8 +3,10 @@ package mocks
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 
+	"satvos/internal/domain"
 	"satvos/internal/service"
 )
 
@@ -22,3 +24,26 @@ func (m *MockPasswordResetService) ResetPassword(ctx context.Context, input serv
 	args := m.Called(ctx, input)
 	return args.Error(0)
 }
+
+func (m *MockPasswordResetService) RecordEmailChange(ctx context.Context, input service.EmailChangeInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetService) UndoEmailChange(ctx context.Context, input service.UndoEmailChangeInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetService) Invite(ctx context.Context, input service.InviteInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetService) ListSecurityEvents(ctx context.Context, tenantID, userID uuid.UUID, offset, limit int) ([]domain.SecurityEvent, int, error) {
+	args := m.Called(ctx, tenantID, userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.SecurityEvent), args.Int(1), args.Error(2)
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockQAReviewRepo is a mock implementation of port.QAReviewRepository.
+type MockQAReviewRepo struct {
+	mock.Mock
+}
+
+func (m *MockQAReviewRepo) Create(ctx context.Context, review *domain.QAReview) (bool, error) {
+	args := m.Called(ctx, review)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockQAReviewRepo) GetByID(ctx context.Context, tenantID, reviewID uuid.UUID) (*domain.QAReview, error) {
+	args := m.Called(ctx, tenantID, reviewID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QAReview), args.Error(1)
+}
+
+func (m *MockQAReviewRepo) ListPending(ctx context.Context, tenantID, userID uuid.UUID, ownedOnly bool, offset, limit int) ([]domain.QASample, int, error) {
+	args := m.Called(ctx, tenantID, userID, ownedOnly, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.QASample), args.Int(1), args.Error(2)
+}
+
+func (m *MockQAReviewRepo) Complete(ctx context.Context, review *domain.QAReview) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockQAReviewRepo) ScoresByReviewer(ctx context.Context, tenantID uuid.UUID, since time.Time) ([]domain.QAReviewerScore, error) {
+	args := m.Called(ctx, tenantID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.QAReviewerScore), args.Error(1)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockQAReviewService is a mock implementation of service.QAReviewService.
+type MockQAReviewService struct {
+	mock.Mock
+}
+
+func (m *MockQAReviewService) ListQueue(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.QASample, int, error) {
+	args := m.Called(ctx, tenantID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.QASample), args.Int(1), args.Error(2)
+}
+
+func (m *MockQAReviewService) SubmitVerdict(ctx context.Context, input *service.SubmitQAVerdictInput) (*domain.QAReview, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QAReview), args.Error(1)
+}
+
+func (m *MockQAReviewService) Scores(ctx context.Context, tenantID uuid.UUID, window time.Duration) ([]domain.QAReviewerScore, error) {
+	args := m.Called(ctx, tenantID, window)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.QAReviewerScore), args.Error(1)
+}
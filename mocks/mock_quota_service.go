@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockQuotaService is a mock implementation of service.QuotaService.
+type MockQuotaService struct {
+	mock.Mock
+}
+
+func (m *MockQuotaService) Usage(ctx context.Context, tenantID, userID uuid.UUID) (*domain.QuotaUsage, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QuotaUsage), args.Error(1)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -31,3 +32,8 @@ func (m *MockRegistrationService) ResendVerification(ctx context.Context, tenant
 	args := m.Called(ctx, tenantID, userID)
 	return args.Error(0)
 }
+
+func (m *MockRegistrationService) SendVerificationReminders(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockRejectionReasonRepo is a mock implementation of port.RejectionReasonRepository.
+type MockRejectionReasonRepo struct {
+	mock.Mock
+}
+
+func (m *MockRejectionReasonRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.RejectionReason, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RejectionReason), args.Error(1)
+}
+
+func (m *MockRejectionReasonRepo) Upsert(ctx context.Context, reason *domain.RejectionReason) error {
+	args := m.Called(ctx, reason)
+	return args.Error(0)
+}
+
+func (m *MockRejectionReasonRepo) Stats(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.RejectionReasonStat, error) {
+	args := m.Called(ctx, tenantID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RejectionReasonStat), args.Error(1)
+}
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockRejectionReasonService is a mock implementation of service.RejectionReasonService.
+type MockRejectionReasonService struct {
+	mock.Mock
+}
+
+func (m *MockRejectionReasonService) List(ctx context.Context, tenantID uuid.UUID, includeInactive bool) ([]domain.RejectionReason, error) {
+	args := m.Called(ctx, tenantID, includeInactive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RejectionReason), args.Error(1)
+}
+
+func (m *MockRejectionReasonService) Set(ctx context.Context, tenantID uuid.UUID, code string, input *service.SetRejectionReasonInput, updatedBy uuid.UUID) (*domain.RejectionReason, error) {
+	args := m.Called(ctx, tenantID, code, input, updatedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RejectionReason), args.Error(1)
+}
+
+func (m *MockRejectionReasonService) Stats(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.RejectionReasonStat, error) {
+	args := m.Called(ctx, tenantID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RejectionReasonStat), args.Error(1)
+}
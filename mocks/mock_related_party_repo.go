@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockRelatedPartyRepo is a mock implementation of port.RelatedPartyRepository.
+type MockRelatedPartyRepo struct {
+	mock.Mock
+}
+
+func (m *MockRelatedPartyRepo) FindRelatedParty(ctx context.Context, tenantID uuid.UUID, gstin string) (*domain.RelatedParty, error) {
+	args := m.Called(ctx, tenantID, gstin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RelatedParty), args.Error(1)
+}
+
+func (m *MockRelatedPartyRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.RelatedParty, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RelatedParty), args.Error(1)
+}
+
+func (m *MockRelatedPartyRepo) Upsert(ctx context.Context, party *domain.RelatedParty) error {
+	args := m.Called(ctx, party)
+	return args.Error(0)
+}
+
+func (m *MockRelatedPartyRepo) Delete(ctx context.Context, tenantID uuid.UUID, gstin string) error {
+	args := m.Called(ctx, tenantID, gstin)
+	return args.Error(0)
+}
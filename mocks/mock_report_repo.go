@@ -28,6 +28,14 @@ func (m *MockReportRepo) PartyLedger(ctx context.Context, tenantID uuid.UUID, gs
 	return args.Get(0).([]domain.PartyLedgerRow), args.Int(1), args.Error(2)
 }
 
+func (m *MockReportRepo) AmountMatches(ctx context.Context, tenantID uuid.UUID, target, minAmount, maxAmount float64, party string, filters *domain.ReportFilters) ([]domain.AmountMatchRow, int, error) {
+	args := m.Called(ctx, tenantID, target, minAmount, maxAmount, party, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.AmountMatchRow), args.Int(1), args.Error(2)
+}
+
 func (m *MockReportRepo) FinancialSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.FinancialSummaryRow, error) {
 	args := m.Called(ctx, tenantID, filters)
 	return args.Get(0).([]domain.FinancialSummaryRow), args.Error(1)
@@ -28,6 +28,14 @@ func (m *MockReportService) PartyLedger(ctx context.Context, tenantID uuid.UUID,
 	return args.Get(0).([]domain.PartyLedgerRow), args.Int(1), args.Error(2)
 }
 
+func (m *MockReportService) SearchByAmount(ctx context.Context, tenantID uuid.UUID, amount float64, tolerance domain.AmountTolerance, party string, filters *domain.ReportFilters) ([]domain.AmountMatchRow, int, error) {
+	args := m.Called(ctx, tenantID, amount, tolerance, party, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.AmountMatchRow), args.Int(1), args.Error(2)
+}
+
 func (m *MockReportService) FinancialSummary(ctx context.Context, tenantID uuid.UUID, filters *domain.ReportFilters) ([]domain.FinancialSummaryRow, error) {
 	args := m.Called(ctx, tenantID, filters)
 	return args.Get(0).([]domain.FinancialSummaryRow), args.Error(1)
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockReviewDelegationRepo is a mock implementation of port.ReviewDelegationRepository.
+type MockReviewDelegationRepo struct {
+	mock.Mock
+}
+
+func (m *MockReviewDelegationRepo) Upsert(ctx context.Context, d *domain.ReviewDelegation) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockReviewDelegationRepo) GetByUser(ctx context.Context, tenantID, userID uuid.UUID) (*domain.ReviewDelegation, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewDelegation), args.Error(1)
+}
+
+func (m *MockReviewDelegationRepo) Delete(ctx context.Context, tenantID, userID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID)
+	return args.Error(0)
+}
+
+func (m *MockReviewDelegationRepo) ListActiveForDelegate(ctx context.Context, tenantID, delegateID uuid.UUID, at time.Time) ([]domain.ReviewDelegation, error) {
+	args := m.Called(ctx, tenantID, delegateID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewDelegation), args.Error(1)
+}
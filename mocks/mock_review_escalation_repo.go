@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockReviewEscalationRepo is a mock implementation of port.ReviewEscalationRepository.
+type MockReviewEscalationRepo struct {
+	mock.Mock
+}
+
+func (m *MockReviewEscalationRepo) ClaimDue(ctx context.Context, now time.Time, limit int) ([]domain.Document, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Document), args.Error(1)
+}
+
+func (m *MockReviewEscalationRepo) ListEscalated(ctx context.Context, tenantID, userID uuid.UUID, ownedOnly bool, offset, limit int) ([]domain.Document, int, error) {
+	args := m.Called(ctx, tenantID, userID, ownedOnly, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Document), args.Int(1), args.Error(2)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockReviewEscalationService is a mock implementation of service.ReviewEscalationService.
+type MockReviewEscalationService struct {
+	mock.Mock
+}
+
+func (m *MockReviewEscalationService) ListEscalated(ctx context.Context, tenantID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.Document, int, error) {
+	args := m.Called(ctx, tenantID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Document), args.Int(1), args.Error(2)
+}
+
+func (m *MockReviewEscalationService) RunDue(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/service"
+)
+
+// MockRuleSimulationService is a mock implementation of service.RuleSimulationService.
+type MockRuleSimulationService struct {
+	mock.Mock
+}
+
+func (m *MockRuleSimulationService) Simulate(ctx context.Context, input *service.RuleSimulationInput) (*service.RuleSimulationResult, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RuleSimulationResult), args.Error(1)
+}
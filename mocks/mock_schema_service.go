@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/validator"
+)
+
+// MockSchemaService is a mock implementation of service.SchemaService.
+type MockSchemaService struct {
+	mock.Mock
+}
+
+func (m *MockSchemaService) Get(documentType string) (*validator.DocumentSchema, error) {
+	args := m.Called(documentType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*validator.DocumentSchema), args.Error(1)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/port"
+)
+
+// MockSNSVerifier is a mock implementation of port.SNSVerifier.
+type MockSNSVerifier struct {
+	mock.Mock
+}
+
+func (m *MockSNSVerifier) Verify(ctx context.Context, body []byte) (*port.SNSMessage, error) {
+	args := m.Called(ctx, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*port.SNSMessage), args.Error(1)
+}
+
+func (m *MockSNSVerifier) ConfirmSubscription(ctx context.Context, msg *port.SNSMessage) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
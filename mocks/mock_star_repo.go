@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockStarRepo is a mock implementation of port.StarRepository.
+type MockStarRepo struct {
+	mock.Mock
+}
+
+func (m *MockStarRepo) StarDocument(ctx context.Context, tenantID, userID, documentID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID, documentID)
+	return args.Error(0)
+}
+
+func (m *MockStarRepo) UnstarDocument(ctx context.Context, tenantID, userID, documentID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID, documentID)
+	return args.Error(0)
+}
+
+func (m *MockStarRepo) StarCollection(ctx context.Context, tenantID, userID, collectionID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID, collectionID)
+	return args.Error(0)
+}
+
+func (m *MockStarRepo) UnstarCollection(ctx context.Context, tenantID, userID, collectionID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID, collectionID)
+	return args.Error(0)
+}
+
+func (m *MockStarRepo) ListStarredDocuments(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, offset, limit int) ([]domain.Document, int, error) {
+	args := m.Called(ctx, tenantID, userID, explicitOnly, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Document), args.Int(1), args.Error(2)
+}
+
+func (m *MockStarRepo) ListStarredCollections(ctx context.Context, tenantID, userID uuid.UUID, explicitOnly bool, offset, limit int) ([]domain.Collection, int, error) {
+	args := m.Called(ctx, tenantID, userID, explicitOnly, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Collection), args.Int(1), args.Error(2)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -29,3 +30,37 @@ func (m *MockStatsRepo) GetUserStats(ctx context.Context, tenantID, userID uuid.
 	}
 	return args.Get(0).(*domain.Stats), args.Error(1)
 }
+
+func (m *MockStatsRepo) RefreshDay(ctx context.Context, tenantID, collectionID uuid.UUID, day time.Time) error {
+	args := m.Called(ctx, tenantID, collectionID, day)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepo) ReconcileTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.StatsDiscrepancy, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.StatsDiscrepancy), args.Error(1)
+}
+
+func (m *MockStatsRepo) CollectionQuality(ctx context.Context, tenantID uuid.UUID, filter domain.CollectionQualityFilter) ([]domain.CollectionQualityCounts, error) {
+	args := m.Called(ctx, tenantID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CollectionQualityCounts), args.Error(1)
+}
+
+func (m *MockStatsRepo) SnapshotQuality(ctx context.Context, tenantID uuid.UUID, day time.Time) error {
+	args := m.Called(ctx, tenantID, day)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepo) QualityHistory(ctx context.Context, tenantID, collectionID uuid.UUID, since time.Time) ([]domain.CollectionQualityCounts, error) {
+	args := m.Called(ctx, tenantID, collectionID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CollectionQualityCounts), args.Error(1)
+}
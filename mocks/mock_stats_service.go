@@ -2,11 +2,13 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 
 	"satvos/internal/domain"
+	"satvos/internal/service"
 )
 
 // MockStatsService is a mock implementation of service.StatsService.
@@ -21,3 +23,51 @@ func (m *MockStatsService) GetStats(ctx context.Context, tenantID, userID uuid.U
 	}
 	return args.Get(0).(*domain.Stats), args.Error(1)
 }
+
+func (m *MockStatsService) GetParseLatency(ctx context.Context, tenantID uuid.UUID, window time.Duration) ([]domain.ParseLatencyStats, error) {
+	args := m.Called(ctx, tenantID, window)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ParseLatencyStats), args.Error(1)
+}
+
+func (m *MockStatsService) GetConfidenceCalibration(ctx context.Context, tenantID uuid.UUID, documentType string, window time.Duration) ([]domain.ConfidenceCalibration, error) {
+	args := m.Called(ctx, tenantID, documentType, window)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ConfidenceCalibration), args.Error(1)
+}
+
+func (m *MockStatsService) GetRuleNoise(ctx context.Context, tenantID uuid.UUID, documentType string, window time.Duration, sortBy service.RuleNoiseSort, limit int) ([]domain.RuleNoise, error) {
+	args := m.Called(ctx, tenantID, documentType, window, sortBy, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RuleNoise), args.Error(1)
+}
+
+func (m *MockStatsService) Recount(ctx context.Context, tenantID uuid.UUID) (*domain.StatsRecount, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.StatsRecount), args.Error(1)
+}
+
+func (m *MockStatsService) GetCollectionQuality(ctx context.Context, tenantID uuid.UUID, filter domain.CollectionQualityFilter) ([]domain.CollectionQuality, error) {
+	args := m.Called(ctx, tenantID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CollectionQuality), args.Error(1)
+}
+
+func (m *MockStatsService) GetCollectionQualityHistory(ctx context.Context, tenantID, collectionID uuid.UUID, window time.Duration) ([]domain.CollectionQuality, error) {
+	args := m.Called(ctx, tenantID, collectionID, window)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CollectionQuality), args.Error(1)
+}
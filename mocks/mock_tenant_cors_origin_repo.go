@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTenantCORSOriginRepo is a mock implementation of port.TenantCORSOriginRepository.
+type MockTenantCORSOriginRepo struct {
+	mock.Mock
+}
+
+func (m *MockTenantCORSOriginRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTenantCORSOriginRepo) Replace(ctx context.Context, tenantID uuid.UUID, origins []string) error {
+	args := m.Called(ctx, tenantID, origins)
+	return args.Error(0)
+}
+
+func (m *MockTenantCORSOriginRepo) TenantsByOrigin(ctx context.Context, origin string) ([]uuid.UUID, error) {
+	args := m.Called(ctx, origin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+)
+
+// MockTenantDataStore is a mock implementation of port.TenantDataStore.
+type MockTenantDataStore struct {
+	mock.Mock
+}
+
+func (m *MockTenantDataStore) SchemaVersion(ctx context.Context) (uint, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockTenantDataStore) CheckMovable(ctx context.Context, tenantID uuid.UUID) error {
+	args := m.Called(ctx, tenantID)
+	return args.Error(0)
+}
+
+func (m *MockTenantDataStore) SetCluster(ctx context.Context, tenantID uuid.UUID, cluster string) error {
+	args := m.Called(ctx, tenantID, cluster)
+	return args.Error(0)
+}
+
+func (m *MockTenantDataStore) Snapshot(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantMoveTable, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TenantMoveTable), args.Error(1)
+}
+
+func (m *MockTenantDataStore) ExportTable(ctx context.Context, tenantID uuid.UUID, table string, fn func(rows json.RawMessage) error) error {
+	args := m.Called(ctx, tenantID, table, fn)
+	return args.Error(0)
+}
+
+func (m *MockTenantDataStore) ListObjects(ctx context.Context, tenantID uuid.UUID) ([]port.StoredObject, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]port.StoredObject), args.Error(1)
+}
+
+// Import returns the configured error, or calls a configured
+// func(context.Context, *port.TenantImport) error, e.g. to exercise in.Verify.
+func (m *MockTenantDataStore) Import(ctx context.Context, in *port.TenantImport) error {
+	args := m.Called(ctx, in)
+	if fn, ok := args.Get(0).(func(context.Context, *port.TenantImport) error); ok {
+		return fn(ctx, in)
+	}
+	return args.Error(0)
+}
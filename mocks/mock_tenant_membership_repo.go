@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockTenantMembershipRepo is a mock implementation of port.TenantMembershipRepository.
+type MockTenantMembershipRepo struct {
+	mock.Mock
+}
+
+func (m *MockTenantMembershipRepo) Upsert(ctx context.Context, membership *domain.TenantMembership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockTenantMembershipRepo) Get(ctx context.Context, tenantID, userID uuid.UUID) (*domain.TenantMembership, error) {
+	args := m.Called(ctx, tenantID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TenantMembership), args.Error(1)
+}
+
+func (m *MockTenantMembershipRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.TenantMembership, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TenantMembership), args.Error(1)
+}
+
+func (m *MockTenantMembershipRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMembership, int, error) {
+	args := m.Called(ctx, tenantID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.TenantMembership), args.Int(1), args.Error(2)
+}
+
+func (m *MockTenantMembershipRepo) Delete(ctx context.Context, tenantID, userID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockTenantMembershipService is a mock implementation of service.TenantMembershipService.
+type MockTenantMembershipService struct {
+	mock.Mock
+}
+
+func (m *MockTenantMembershipService) ListTenants(ctx context.Context, userID uuid.UUID) ([]domain.TenantMembership, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TenantMembership), args.Error(1)
+}
+
+func (m *MockTenantMembershipService) SwitchTenant(ctx context.Context, userID, tenantID uuid.UUID) (*service.TokenPair, error) {
+	args := m.Called(ctx, userID, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.TokenPair), args.Error(1)
+}
+
+func (m *MockTenantMembershipService) ListGuests(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMembership, int, error) {
+	args := m.Called(ctx, tenantID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.TenantMembership), args.Int(1), args.Error(2)
+}
+
+func (m *MockTenantMembershipService) Grant(ctx context.Context, tenantID uuid.UUID, input *service.GrantMembershipInput, grantedBy uuid.UUID) (*domain.TenantMembership, error) {
+	args := m.Called(ctx, tenantID, input, grantedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TenantMembership), args.Error(1)
+}
+
+func (m *MockTenantMembershipService) Revoke(ctx context.Context, tenantID, userID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockTenantMoveRepo is a mock implementation of port.TenantMoveRepository.
+type MockTenantMoveRepo struct {
+	mock.Mock
+}
+
+func (m *MockTenantMoveRepo) Create(ctx context.Context, move *domain.TenantMove) error {
+	args := m.Called(ctx, move)
+	return args.Error(0)
+}
+
+func (m *MockTenantMoveRepo) GetByID(ctx context.Context, tenantID, moveID uuid.UUID) (*domain.TenantMove, error) {
+	args := m.Called(ctx, tenantID, moveID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TenantMove), args.Error(1)
+}
+
+func (m *MockTenantMoveRepo) ListByTenant(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMove, int, error) {
+	args := m.Called(ctx, tenantID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.TenantMove), args.Int(1), args.Error(2)
+}
+
+func (m *MockTenantMoveRepo) UpdateProgress(ctx context.Context, move *domain.TenantMove) error {
+	args := m.Called(ctx, move)
+	return args.Error(0)
+}
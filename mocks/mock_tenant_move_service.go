@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockTenantMoveService is a mock implementation of service.TenantMoveService.
+type MockTenantMoveService struct {
+	mock.Mock
+}
+
+func (m *MockTenantMoveService) Start(ctx context.Context, tenantID uuid.UUID, input *service.StartTenantMoveInput, requestedBy *uuid.UUID) (*domain.TenantMove, error) {
+	args := m.Called(ctx, tenantID, input, requestedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TenantMove), args.Error(1)
+}
+
+func (m *MockTenantMoveService) Run(ctx context.Context, tenantID uuid.UUID, input *service.StartTenantMoveInput, requestedBy *uuid.UUID) (*domain.TenantMove, error) {
+	args := m.Called(ctx, tenantID, input, requestedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TenantMove), args.Error(1)
+}
+
+func (m *MockTenantMoveService) Get(ctx context.Context, tenantID, moveID uuid.UUID) (*domain.TenantMove, error) {
+	args := m.Called(ctx, tenantID, moveID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TenantMove), args.Error(1)
+}
+
+func (m *MockTenantMoveService) List(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.TenantMove, int, error) {
+	args := m.Called(ctx, tenantID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.TenantMove), args.Int(1), args.Error(2)
+}
@@ -43,6 +43,14 @@ func (m *MockTenantRepo) List(ctx context.Context, offset, limit int) ([]domain.
 	return args.Get(0).([]domain.Tenant), args.Int(1), args.Error(2)
 }
 
+func (m *MockTenantRepo) ListClients(ctx context.Context, parentID uuid.UUID, offset, limit int) ([]domain.Tenant, int, error) {
+	args := m.Called(ctx, parentID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Tenant), args.Int(1), args.Error(2)
+}
+
 func (m *MockTenantRepo) Update(ctx context.Context, tenant *domain.Tenant) error {
 	args := m.Called(ctx, tenant)
 	return args.Error(0)
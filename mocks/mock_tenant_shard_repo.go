@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTenantShardRepo is a mock implementation of port.TenantShardRepository.
+type MockTenantShardRepo struct {
+	mock.Mock
+}
+
+func (m *MockTenantShardRepo) Resolve(ctx context.Context, tenantID uuid.UUID) (string, error) {
+	args := m.Called(ctx, tenantID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTenantShardRepo) CountByShard(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockURLImportService is a mock implementation of service.URLImportService.
+type MockURLImportService struct {
+	mock.Mock
+}
+
+func (m *MockURLImportService) CreateFromURL(ctx context.Context, input *service.CreateFromURLInput) (*domain.Document, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Document), args.Error(1)
+}
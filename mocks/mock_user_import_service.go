@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+// MockUserImportService is a mock implementation of service.UserImportService.
+type MockUserImportService struct {
+	mock.Mock
+}
+
+func (m *MockUserImportService) Import(ctx context.Context, input *service.UserImportInput) (*domain.UserImportReport, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserImportReport), args.Error(1)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -27,6 +28,14 @@ func (m *MockUserRepo) GetByID(ctx context.Context, tenantID, userID uuid.UUID)
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepo) GetByIDs(ctx context.Context, tenantID uuid.UUID, userIDs []uuid.UUID) ([]domain.User, error) {
+	args := m.Called(ctx, tenantID, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
 func (m *MockUserRepo) GetByEmail(ctx context.Context, tenantID uuid.UUID, email string) (*domain.User, error) {
 	args := m.Called(ctx, tenantID, email)
 	if args.Get(0) == nil {
@@ -58,6 +67,11 @@ func (m *MockUserRepo) CheckAndIncrementQuota(ctx context.Context, tenantID, use
 	return args.Error(0)
 }
 
+func (m *MockUserRepo) ClaimQuotaWarning(ctx context.Context, userID uuid.UUID, level int) (bool, error) {
+	args := m.Called(ctx, userID, level)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepo) SetEmailVerified(ctx context.Context, tenantID, userID uuid.UUID) error {
 	args := m.Called(ctx, tenantID, userID)
 	return args.Error(0)
@@ -85,3 +99,24 @@ func (m *MockUserRepo) LinkProvider(ctx context.Context, tenantID, userID uuid.U
 	args := m.Called(ctx, tenantID, userID, provider, providerUserID)
 	return args.Error(0)
 }
+
+func (m *MockUserRepo) ListUnverified(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.User, int, error) {
+	args := m.Called(ctx, tenantID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserRepo) ClaimVerificationSend(ctx context.Context, tenantID, userID uuid.UUID, notSentSince time.Time) (bool, error) {
+	args := m.Called(ctx, tenantID, userID, notSentSince)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepo) ClaimVerificationReminders(ctx context.Context, registeredBefore time.Time, limit int) ([]domain.User, error) {
+	args := m.Called(ctx, registeredBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
@@ -39,6 +39,14 @@ func (m *MockUserService) List(ctx context.Context, tenantID uuid.UUID, offset,
 	return args.Get(0).([]domain.User), args.Int(1), args.Error(2)
 }
 
+func (m *MockUserService) ListUnverified(ctx context.Context, tenantID uuid.UUID, offset, limit int) ([]domain.User, int, error) {
+	args := m.Called(ctx, tenantID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.User), args.Int(1), args.Error(2)
+}
+
 func (m *MockUserService) Update(ctx context.Context, tenantID, userID uuid.UUID, input service.UpdateUserInput) (*domain.User, error) {
 	args := m.Called(ctx, tenantID, userID, input)
 	if args.Get(0) == nil {
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockValidationRuleOutcomeRepo is a mock implementation of port.ValidationRuleOutcomeRepository.
+type MockValidationRuleOutcomeRepo struct {
+	mock.Mock
+}
+
+func (m *MockValidationRuleOutcomeRepo) Record(ctx context.Context, outcomes []domain.ValidationRuleOutcome) error {
+	args := m.Called(ctx, outcomes)
+	return args.Error(0)
+}
+
+func (m *MockValidationRuleOutcomeRepo) MarkOverridden(ctx context.Context, tenantID, documentID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, documentID)
+	return args.Error(0)
+}
+
+func (m *MockValidationRuleOutcomeRepo) StatsByRule(ctx context.Context, tenantID uuid.UUID, documentType string, since time.Time) ([]domain.RuleFailureStats, error) {
+	args := m.Called(ctx, tenantID, documentType, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.RuleFailureStats), args.Error(1)
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockValidationRunRepo is a mock implementation of port.ValidationRunRepository.
+type MockValidationRunRepo struct {
+	mock.Mock
+}
+
+func (m *MockValidationRunRepo) Create(ctx context.Context, run *domain.ValidationRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockValidationRunRepo) GetByID(ctx context.Context, tenantID, runID uuid.UUID) (*domain.ValidationRun, error) {
+	args := m.Called(ctx, tenantID, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ValidationRun), args.Error(1)
+}
+
+func (m *MockValidationRunRepo) ListByCollection(ctx context.Context, tenantID, collectionID uuid.UUID, offset, limit int) ([]domain.ValidationRun, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.ValidationRun), args.Int(1), args.Error(2)
+}
+
+func (m *MockValidationRunRepo) UpdateProgress(ctx context.Context, run *domain.ValidationRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockValidationRunRepo) ListTargets(ctx context.Context, tenantID, collectionID uuid.UUID, limit int) ([]domain.ValidationRunTarget, error) {
+	args := m.Called(ctx, tenantID, collectionID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ValidationRunTarget), args.Error(1)
+}
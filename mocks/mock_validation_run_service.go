@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+)
+
+// MockValidationRunService is a mock implementation of service.ValidationRunService.
+type MockValidationRunService struct {
+	mock.Mock
+}
+
+func (m *MockValidationRunService) Start(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole) (*domain.ValidationRun, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ValidationRun), args.Error(1)
+}
+
+func (m *MockValidationRunService) GetRun(ctx context.Context, tenantID, collectionID, runID, userID uuid.UUID, role domain.UserRole) (*domain.ValidationRun, error) {
+	args := m.Called(ctx, tenantID, collectionID, runID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ValidationRun), args.Error(1)
+}
+
+func (m *MockValidationRunService) ListRuns(ctx context.Context, tenantID, collectionID, userID uuid.UUID, role domain.UserRole, offset, limit int) ([]domain.ValidationRun, int, error) {
+	args := m.Called(ctx, tenantID, collectionID, userID, role, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.ValidationRun), args.Int(1), args.Error(2)
+}
@@ -0,0 +1,141 @@
+package apierror_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/apierror"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func respond(accept string, status int, code, msg string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/123", http.NoBody)
+	if accept != "" {
+		c.Request.Header.Set("Accept", accept)
+	}
+	c.Set("request_id", "req-1")
+	apierror.Respond(c, status, code, msg)
+	return w
+}
+
+func TestRespond_EnvelopeByDefault(t *testing.T) {
+	w := respond("application/json", http.StatusServiceUnavailable, "PARSER_UNAVAILABLE", "the document parser is busy; try again shortly")
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	assert.JSONEq(t, `{"success":false,"error":{"code":"PARSER_UNAVAILABLE","message":"the document parser is busy; try again shortly","retryable":true,"docs_url":"/api/v1/errors/PARSER_UNAVAILABLE"}}`, w.Body.String())
+}
+
+func TestRespond_ProblemJSONWhenAccepted(t *testing.T) {
+	w := respond("application/problem+json, application/json;q=0.5", http.StatusNotFound, "DOCUMENT_NOT_FOUND", "document not found")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, apierror.ProblemContentType, w.Header().Get("Content-Type"))
+	var p apierror.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	assert.Equal(t, apierror.Problem{
+		Type: "/api/v1/errors/DOCUMENT_NOT_FOUND", Title: "document not found", Status: 404,
+		Detail: "document not found", Instance: "/api/v1/documents/123", Code: "DOCUMENT_NOT_FOUND",
+		RequestID: "req-1",
+	}, p)
+}
+
+func TestRespondFields_IncludesErrorsArray(t *testing.T) {
+	fields := []apierror.FieldError{{Field: "file_id", Code: "invalid_uuid", Message: "file_id must be a valid UUID"}}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/documents", http.NoBody)
+	apierror.RespondFields(c, http.StatusBadRequest, "INVALID_REQUEST", "file_id must be a valid UUID", fields)
+
+	assert.JSONEq(t, `{"success":false,"error":{"code":"INVALID_REQUEST","message":"file_id must be a valid UUID","retryable":false,"docs_url":"/api/v1/errors/INVALID_REQUEST","errors":[{"field":"file_id","code":"invalid_uuid","message":"file_id must be a valid UUID"}]}}`, w.Body.String())
+}
+
+func TestRespond_UnknownCodeFallsBackToStatus(t *testing.T) {
+	w := respond(apierror.ProblemContentType, http.StatusTooManyRequests, "SOMETHING_NEW", "slow down")
+
+	var p apierror.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	assert.Equal(t, "Too Many Requests", p.Title)
+	assert.True(t, p.Retryable)
+}
+
+func TestAbort_StopsChain(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", http.NoBody)
+
+	apierror.Abort(c, http.StatusForbidden, "FORBIDDEN", "insufficient permissions")
+
+	assert.True(t, c.IsAborted())
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestLookup(t *testing.T) {
+	entry, ok := apierror.Lookup("RATE_LIMITED")
+	require.True(t, ok)
+	assert.Equal(t, http.StatusTooManyRequests, entry.Status)
+	assert.True(t, entry.Retryable)
+	assert.Equal(t, "/api/v1/errors/RATE_LIMITED", entry.DocsURL)
+
+	entry, _ = apierror.Lookup("QUOTA_EXCEEDED")
+	assert.False(t, entry.Retryable, "a monthly quota does not reset by retrying")
+
+	_, ok = apierror.Lookup("NOPE")
+	assert.False(t, ok)
+}
+
+var docRow = regexp.MustCompile("(?m)^\\| `([A-Z_]+)` \\| ([0-9]+) \\|")
+
+// ERROR_CODES.md and the catalogue must list the same codes with the same statuses.
+func TestCatalogue_MatchesErrorCodesDoc(t *testing.T) {
+	doc, err := os.ReadFile(filepath.Join("..", "..", "..", "ERROR_CODES.md"))
+	require.NoError(t, err)
+
+	documented := make(map[string]bool)
+	for _, m := range docRow.FindAllStringSubmatch(string(doc), -1) {
+		documented[m[1]] = true
+		entry, ok := apierror.Lookup(m[1])
+		if assert.True(t, ok, "%s is documented but not in the catalogue", m[1]) {
+			status, _ := strconv.Atoi(m[2])
+			assert.Equal(t, status, entry.Status, "status of %s", m[1])
+		}
+	}
+	for _, entry := range apierror.Catalogue() {
+		assert.True(t, documented[entry.Code], "%s is in the catalogue but not in ERROR_CODES.md", entry.Code)
+	}
+}
+
+var codeLiteral = regexp.MustCompile(`(?:http\.Status\w+|bind(?:Optional)?JSON\(c, &\w+),\s*"([A-Z][A-Z0-9_]+)"[,)]`)
+
+// Every code the handlers and middleware return must be in the catalogue.
+func TestCatalogue_CoversEveryReturnedCode(t *testing.T) {
+	for _, dir := range []string{"handler", "middleware"} {
+		files, err := filepath.Glob(filepath.Join("..", "..", "..", "internal", dir, "*.go"))
+		require.NoError(t, err)
+		require.NotEmpty(t, files)
+		for _, f := range files {
+			src, err := os.ReadFile(f)
+			require.NoError(t, err)
+			for _, m := range codeLiteral.FindAllStringSubmatch(string(src), -1) {
+				_, ok := apierror.Lookup(m[1])
+				assert.True(t, ok, "%s returns %s, which is not in the catalogue", filepath.Base(f), m[1])
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"satvos/internal/config"
+)
+
+func TestS3Config_BucketForRegion(t *testing.T) {
+	cfg := config.S3Config{
+		Region:        "us-east-1",
+		Bucket:        "satvos-uploads",
+		RegionBuckets: map[string]string{"ap-south-1": "satvos-in"},
+	}
+
+	bucket, ok := cfg.BucketForRegion("")
+	assert.True(t, ok)
+	assert.Equal(t, "satvos-uploads", bucket)
+
+	bucket, ok = cfg.BucketForRegion("us-east-1")
+	assert.True(t, ok)
+	assert.Equal(t, "satvos-uploads", bucket)
+
+	bucket, ok = cfg.BucketForRegion("ap-south-1")
+	assert.True(t, ok)
+	assert.Equal(t, "satvos-in", bucket)
+
+	_, ok = cfg.BucketForRegion("eu-west-1")
+	assert.False(t, ok)
+}
+
+func TestS3Config_RegionForBucket(t *testing.T) {
+	cfg := config.S3Config{
+		Region:        "us-east-1",
+		Bucket:        "satvos-uploads",
+		RegionBuckets: map[string]string{"ap-south-1": "satvos-in"},
+	}
+
+	region, ok := cfg.RegionForBucket("satvos-in")
+	assert.True(t, ok)
+	assert.Equal(t, "ap-south-1", region)
+
+	_, ok = cfg.RegionForBucket("someone-elses-bucket")
+	assert.False(t, ok)
+}
@@ -0,0 +1,145 @@
+package expr_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/expr"
+)
+
+var fields = map[string]string{
+	"invoice.invoice_number":    "string",
+	"invoice.reverse_charge":    "boolean",
+	"seller.state_code":         "string",
+	"buyer.state_code":          "string",
+	"totals.total":              "number",
+	"totals.taxable_amount":     "number",
+	"totals.cess":               "number",
+	"line_items":                "array",
+	"line_items[].quantity":     "number",
+	"line_items[].hsn_sac_code": "string",
+	"line_items[].total":        "number",
+	"seller":                    "object",
+}
+
+const doc = `{
+	"invoice": {"invoice_number": "INV-7", "reverse_charge": false},
+	"seller": {"state_code": "29"},
+	"buyer": {"state_code": "27"},
+	"totals": {"total": 1180, "taxable_amount": 1000},
+	"line_items": [
+		{"quantity": 2, "hsn_sac_code": "8471", "total": 590.5},
+		{"quantity": 3, "hsn_sac_code": "8473", "total": 589.5},
+		{"hsn_sac_code": "9983"}
+	]
+}`
+
+func eval(t *testing.T, src string) interface{} {
+	t.Helper()
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(doc), &data))
+	e, err := expr.Compile(src, fields)
+	require.NoError(t, err, src)
+	v, err := e.Eval(data)
+	require.NoError(t, err, src)
+	return v
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		src  string
+		want interface{}
+	}{
+		{"totals.total - totals.taxable_amount", 180.0},
+		{"1 + 2 * 3", 7.0},
+		{"(1 + 2) * 3", 9.0},
+		{"-totals.total / 4", -295.0},
+		{"round(10 / 3, 2)", 3.33},
+		{"round(2.5)", 3.0},
+		{"abs(-4)", 4.0},
+		{"sum(line_items[].quantity)", 5.0},
+		{"count(line_items[].hsn_sac_code)", 3.0},
+		{"count(line_items[].quantity)", 2.0},
+		{"avg(line_items[].total)", 590.0},
+		{"min(line_items[].total)", 589.5},
+		{"max(line_items[].total)", 590.5},
+		{"seller.state_code != buyer.state_code", true},
+		{"totals.total >= 1000 && !invoice.reverse_charge", true},
+		{"totals.cess > 0 || false", false},
+		{`if(seller.state_code == buyer.state_code, "intra", "inter")`, "inter"},
+		{"concat(invoice.invoice_number, '/', seller.state_code)", "INV-7/29"},
+		{`lower(invoice.invoice_number) + "-x"`, "inv-7-x"},
+		{"coalesce(totals.cess, 0)", 0.0},
+		{"totals.cess", nil},
+		{"totals.cess * 2", nil},
+		{"totals.cess == null", true},
+		{`'it\'s'`, "it's"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			assert.Equal(t, tt.want, eval(t, tt.src))
+		})
+	}
+}
+
+func TestCompile_Rejects(t *testing.T) {
+	tests := []string{
+		"",
+		"totals.grand_total",
+		"seller",
+		"line_items",
+		"line_items[].quantity * 2",
+		"sum(totals.total)",
+		"sum(line_items[].quantity, 1)",
+		"nope(1)",
+		"round()",
+		"if(true, 1)",
+		"1 +",
+		"(1 + 2",
+		"1 2",
+		"'open",
+		"1 # 2",
+	}
+	for _, src := range tests {
+		t.Run(src, func(t *testing.T) {
+			_, err := expr.Compile(src, fields)
+			assert.ErrorIs(t, err, expr.ErrSyntax)
+		})
+	}
+}
+
+func TestCompile_RejectsDeepNesting(t *testing.T) {
+	src := ""
+	for i := 0; i < 40; i++ {
+		src += "("
+	}
+	src += "1"
+	for i := 0; i < 40; i++ {
+		src += ")"
+	}
+	_, err := expr.Compile(src, fields)
+	assert.ErrorIs(t, err, expr.ErrSyntax)
+}
+
+func TestEval_Errors(t *testing.T) {
+	for _, src := range []string{"totals.total / 0", "invoice.invoice_number - 1", "upper(totals.total)", "sum(line_items[].hsn_sac_code)"} {
+		t.Run(src, func(t *testing.T) {
+			var data map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(doc), &data))
+			e, err := expr.Compile(src, fields)
+			require.NoError(t, err)
+			_, err = e.Eval(data)
+			assert.ErrorIs(t, err, expr.ErrEval)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "", expr.Format(nil))
+	assert.Equal(t, "1180.5", expr.Format(1180.5))
+	assert.Equal(t, "12", expr.Format(12.0))
+	assert.Equal(t, "true", expr.Format(true))
+}
@@ -0,0 +1,167 @@
+package faults_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/faults"
+	"satvos/internal/parser"
+	"satvos/internal/port"
+	"satvos/mocks"
+)
+
+// fixedRoll returns an injector whose every roll is r.
+func fixedRoll(r int) *faults.Injector {
+	return faults.NewInjectorWithRoll(func() int { return r })
+}
+
+func TestInjector_SetValidates(t *testing.T) {
+	inj := faults.NewInjector()
+
+	for _, s := range []faults.Scenario{
+		{Target: "queue"},
+		{Target: faults.TargetStorage, LatencyMS: -1},
+		{Target: faults.TargetStorage, LatencyMS: int(faults.MaxLatency.Milliseconds()) + 1},
+		{Target: faults.TargetStorage, ErrorPercent: 60, PartialPercent: 50},
+		{Target: faults.TargetStorage, ErrorPercent: -5},
+	} {
+		assert.ErrorIs(t, inj.Set(s), faults.ErrInvalidScenario, "%+v", s)
+	}
+	assert.Empty(t, inj.Scenarios())
+
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetStorage, ErrorPercent: 50}))
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetParser, PartialPercent: 10}))
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetStorage, ErrorPercent: 20}))
+
+	scenarios := inj.Scenarios()
+	require.Len(t, scenarios, 2)
+	assert.Equal(t, faults.TargetParser, scenarios[0].Target)
+	assert.Equal(t, 20, scenarios[1].ErrorPercent)
+
+	inj.Clear(faults.TargetParser)
+	assert.Len(t, inj.Scenarios(), 1)
+}
+
+func TestStorage_FailSkipsBackend(t *testing.T) {
+	inj := fixedRoll(10)
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetStorage, ErrorPercent: 20}))
+	backend := new(mocks.MockObjectStorage)
+
+	_, err := faults.NewStorage(backend, inj).Download(context.Background(), "b", "k")
+
+	assert.ErrorIs(t, err, faults.ErrInjected)
+	backend.AssertNotCalled(t, "Download", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStorage_PartialDownloadIsTruncated(t *testing.T) {
+	inj := fixedRoll(30)
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetStorage, ErrorPercent: 20, PartialPercent: 20}))
+	backend := new(mocks.MockObjectStorage)
+	backend.On("Download", mock.Anything, "b", "k").Return([]byte("abcdefgh"), nil)
+
+	data, err := faults.NewStorage(backend, inj).Download(context.Background(), "b", "k")
+
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.ErrorIs(t, err, faults.ErrInjected)
+	assert.Equal(t, []byte("abcd"), data)
+}
+
+func TestStorage_PartialOpenFailsMidStream(t *testing.T) {
+	inj := fixedRoll(0)
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetStorage, PartialPercent: 100}))
+	backend := new(mocks.MockObjectStorage)
+	backend.On("Open", mock.Anything, "b", "k").Return(&port.ObjectReader{
+		Body: io.NopCloser(bytes.NewReader([]byte("abcdefgh"))), Size: 8,
+	}, nil)
+
+	obj, err := faults.NewStorage(backend, inj).Open(context.Background(), "b", "k")
+	require.NoError(t, err)
+	data, err := io.ReadAll(obj.Body)
+
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, []byte("abcd"), data)
+}
+
+func TestStorage_PartialUploadLandsThenFails(t *testing.T) {
+	inj := fixedRoll(0)
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetStorage, PartialPercent: 100}))
+	backend := new(mocks.MockObjectStorage)
+	backend.On("Upload", mock.Anything, mock.Anything).Return(&port.UploadOutput{Location: "s3://b/k"}, nil)
+
+	out, err := faults.NewStorage(backend, inj).Upload(context.Background(), port.UploadInput{Bucket: "b", Key: "k"})
+
+	assert.ErrorIs(t, err, faults.ErrInjected)
+	assert.Nil(t, out)
+	backend.AssertCalled(t, "Upload", mock.Anything, mock.Anything)
+}
+
+func TestStorage_OperationsFilter(t *testing.T) {
+	inj := fixedRoll(0)
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetStorage, Operations: []string{"Upload"}, ErrorPercent: 100}))
+	backend := new(mocks.MockObjectStorage)
+	backend.On("Download", mock.Anything, "b", "k").Return([]byte("ok"), nil)
+
+	data, err := faults.NewStorage(backend, inj).Download(context.Background(), "b", "k")
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ok"), data)
+}
+
+func TestStorage_LatencyHonoursContext(t *testing.T) {
+	inj := fixedRoll(99)
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetStorage, LatencyMS: 60000}))
+	backend := new(mocks.MockObjectStorage)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := faults.NewStorage(backend, inj).Download(ctx, "b", "k")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	backend.AssertNotCalled(t, "Download", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParser_FailuresAreTransient(t *testing.T) {
+	inj := fixedRoll(0)
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetParser, ErrorPercent: 100}))
+	backend := new(mocks.MockDocumentParser)
+
+	_, err := faults.NewParser(backend, inj).Parse(context.Background(), port.ParseInput{})
+
+	var transient *parser.TransientError
+	assert.True(t, errors.As(err, &transient))
+	assert.ErrorIs(t, err, faults.ErrInjected)
+	backend.AssertNotCalled(t, "Parse", mock.Anything, mock.Anything)
+}
+
+func TestParser_NoScenarioPassesThrough(t *testing.T) {
+	backend := new(mocks.MockDocumentParser)
+	backend.On("Parse", mock.Anything, mock.Anything).Return(&port.ParseOutput{ModelUsed: "m"}, nil)
+
+	out, err := faults.NewParser(backend, faults.NewInjector()).Parse(context.Background(), port.ParseInput{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "m", out.ModelUsed)
+}
+
+func TestDocumentRepo_PartialClaimCommitsThenFails(t *testing.T) {
+	inj := fixedRoll(0)
+	require.NoError(t, inj.Set(faults.Scenario{Target: faults.TargetRepository, PartialPercent: 100}))
+	backend := new(mocks.MockDocumentRepo)
+	backend.On("ClaimQueued", mock.Anything, 5).Return([]domain.Document{{ID: uuid.New()}}, nil)
+
+	docs, err := faults.NewDocumentRepo(backend, inj).ClaimQueued(context.Background(), 5)
+
+	assert.ErrorIs(t, err, faults.ErrInjected)
+	assert.Nil(t, docs)
+	backend.AssertCalled(t, "ClaimQueued", mock.Anything, 5)
+}
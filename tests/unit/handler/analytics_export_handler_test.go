@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func newAnalyticsExportHandler() (*handler.AnalyticsExportHandler, *mocks.MockAnalyticsExportService) {
+	mockSvc := new(mocks.MockAnalyticsExportService)
+	return handler.NewAnalyticsExportHandler(mockSvc), mockSvc
+}
+
+func analyticsExportContext(method, tenantID, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(method, "/api/v1/admin/tenants/"+tenantID+"/analytics-export", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: tenantID}}
+	return c, w
+}
+
+func TestAnalyticsExportHandler_Configure(t *testing.T) {
+	h, mockSvc := newAnalyticsExportHandler()
+	tenantID := uuid.New()
+	mockSvc.On("Configure", mock.Anything, tenantID, mock.MatchedBy(func(in *service.ConfigureAnalyticsExportInput) bool {
+		return in.Destination == "s3://acme-warehouse/satvos" && in.IntervalHours == 6
+	})).Return(&domain.AnalyticsExport{TenantID: tenantID, S3Bucket: "acme-warehouse", S3Prefix: "satvos", IntervalHours: 6}, nil)
+
+	c, w := analyticsExportContext(http.MethodPut, tenantID.String(),
+		`{"destination":"s3://acme-warehouse/satvos","interval_hours":6}`)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Configure(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data domain.AnalyticsExport `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "acme-warehouse", resp.Data.S3Bucket)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestAnalyticsExportHandler_Configure_MissingDestination(t *testing.T) {
+	h, mockSvc := newAnalyticsExportHandler()
+
+	c, w := analyticsExportContext(http.MethodPut, uuid.New().String(), `{"interval_hours":6}`)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Configure(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Configure", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAnalyticsExportHandler_Configure_InvalidDestination(t *testing.T) {
+	h, mockSvc := newAnalyticsExportHandler()
+	tenantID := uuid.New()
+	mockSvc.On("Configure", mock.Anything, tenantID, mock.Anything).Return(nil, domain.ErrInvalidExportDestination)
+
+	c, w := analyticsExportContext(http.MethodPut, tenantID.String(), `{"destination":"acme-warehouse"}`)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Configure(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_EXPORT_DESTINATION")
+}
+
+func TestAnalyticsExportHandler_Get_NotConfigured(t *testing.T) {
+	h, mockSvc := newAnalyticsExportHandler()
+	tenantID := uuid.New()
+	mockSvc.On("Get", mock.Anything, tenantID).Return(nil, domain.ErrNotFound)
+
+	c, w := analyticsExportContext(http.MethodGet, tenantID.String(), "")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAnalyticsExportHandler_Run(t *testing.T) {
+	h, mockSvc := newAnalyticsExportHandler()
+	tenantID := uuid.New()
+	mockSvc.On("RunNow", mock.Anything, tenantID).Return(&domain.AnalyticsExport{TenantID: tenantID}, nil)
+
+	c, w := analyticsExportContext(http.MethodPost, tenantID.String(), "")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Run(c)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	mockSvc.AssertExpectations(t)
+}
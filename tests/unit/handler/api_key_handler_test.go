@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func newAPIKeyHandler() (*handler.APIKeyHandler, *mocks.MockAPIKeyService) {
+	mockSvc := new(mocks.MockAPIKeyService)
+	return handler.NewAPIKeyHandler(mockSvc), mockSvc
+}
+
+func TestAPIKeyHandler_Create_ReturnsKey(t *testing.T) {
+	h, mockSvc := newAPIKeyHandler()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	mockSvc.On("Create", mock.Anything, tenantID, userID, service.CreateAPIKeyInput{Name: "ops"}).
+		Return(&service.CreatedAPIKey{APIKey: domain.APIKey{ID: uuid.New(), Name: "ops", KeyHash: "secret-hash"}, Key: "satvos_abc"}, nil)
+
+	body, _ := json.Marshal(map[string]string{"name": "ops"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/users/me/api-keys", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, tenantID, userID, "admin")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"key":"satvos_abc"`)
+	assert.NotContains(t, w.Body.String(), "secret-hash")
+}
+
+func TestAPIKeyHandler_Create_MissingName(t *testing.T) {
+	h, _ := newAPIKeyHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/users/me/api-keys", bytes.NewReader([]byte(`{}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAPIKeyHandler_Delete_NotFound(t *testing.T) {
+	h, mockSvc := newAPIKeyHandler()
+	tenantID, userID, keyID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("Delete", mock.Anything, tenantID, userID, keyID).Return(domain.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/users/me/api-keys/"+keyID.String(), http.NoBody)
+	c.Params = gin.Params{{Key: "keyId", Value: keyID.String()}}
+	setAuthContext(c, tenantID, userID, "admin")
+
+	h.Delete(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -384,3 +384,55 @@ func TestAuthHandler_ForgotPassword_Disabled(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
+
+func TestAuthHandler_ResetPassword_PassesClientMeta(t *testing.T) {
+	mockPwReset := new(mocks.MockPasswordResetService)
+	h := handler.NewAuthHandler(new(mocks.MockAuthService), nil, mockPwReset, nil)
+
+	mockPwReset.On("ResetPassword", mock.Anything, mock.MatchedBy(func(in service.ResetPasswordInput) bool {
+		return in.Meta.ClientIP == "203.0.113.7" && in.Meta.UserAgent == "Firefox"
+	})).Return(nil)
+
+	body, _ := json.Marshal(map[string]string{"token": "t", "new_password": "newpassword123"})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/reset-password", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("User-Agent", "Firefox")
+	c.Request.RemoteAddr = "203.0.113.7:4321"
+
+	h.ResetPassword(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockPwReset.AssertExpectations(t)
+}
+
+func TestAuthHandler_UndoEmailChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"restored", nil, http.StatusOK},
+		{"invalid token", domain.ErrEmailChangeUndoInvalid, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPwReset := new(mocks.MockPasswordResetService)
+			h := handler.NewAuthHandler(new(mocks.MockAuthService), nil, mockPwReset, nil)
+			mockPwReset.On("UndoEmailChange", mock.Anything, mock.MatchedBy(func(in service.UndoEmailChangeInput) bool {
+				return in.Token == "undo-token"
+			})).Return(tt.err)
+
+			body, _ := json.Marshal(map[string]string{"token": "undo-token"})
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/undo-email-change", bytes.NewReader(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.UndoEmailChange(c)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
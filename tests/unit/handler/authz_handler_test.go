@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/mocks"
+)
+
+func TestAuthzHandler_Matrix(t *testing.T) {
+	rules := []domain.RouteRule{
+		{Method: http.MethodGet, Path: "/api/v1/documents/:id", Roles: []domain.UserRole{domain.RoleAdmin}, CollectionPerm: domain.CollectionPermViewer},
+	}
+	h := handler.NewAuthzHandler(rules, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/authz-matrix", http.NoBody)
+
+	h.Matrix(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []domain.RouteRule `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, rules, resp.Data)
+}
+
+func TestAuthzHandler_Denials(t *testing.T) {
+	repo := new(mocks.MockAuthzDenialRepo)
+	h := handler.NewAuthzHandler(nil, repo)
+	tenantID, adminID, deniedUser := uuid.New(), uuid.New(), uuid.New()
+
+	denials := []domain.AuthzDenial{{ID: uuid.New(), TenantID: tenantID, UserID: deniedUser, Code: "FORBIDDEN"}}
+	repo.On("ListByTenant", mock.Anything, tenantID, mock.MatchedBy(func(f *domain.AuthzDenialFilters) bool {
+		return f.UserID != nil && *f.UserID == deniedUser && f.Code == "FORBIDDEN" &&
+			f.From != nil && f.From.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) &&
+			f.To == nil && f.Limit == 20
+	})).Return(denials, 1, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/audit/denials?user_id="+deniedUser.String()+"&code=FORBIDDEN&from=2026-10-01", http.NoBody)
+	setAuthContext(c, tenantID, adminID, "admin")
+
+	h.Denials(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []domain.AuthzDenial `json:"data"`
+		Meta handler.PagMeta      `json:"meta"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, 1)
+	assert.Equal(t, 1, resp.Meta.Total)
+	repo.AssertExpectations(t)
+}
+
+func TestAuthzHandler_Denials_InvalidUserID(t *testing.T) {
+	repo := new(mocks.MockAuthzDenialRepo)
+	h := handler.NewAuthzHandler(nil, repo)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/audit/denials?user_id=nope", http.NoBody)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Denials(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	repo.AssertNotCalled(t, "ListByTenant", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,136 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/apierror"
+	"satvos/internal/handler"
+	"satvos/mocks"
+)
+
+func postJSON(t *testing.T, path, body string, setup func(c *gin.Context), call func(c *gin.Context)) (*httptest.ResponseRecorder, handler.APIResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if setup != nil {
+		setup(c)
+	}
+	call(c)
+
+	var resp handler.APIResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w, resp
+}
+
+func TestBinding_MissingFieldsListedIndividually(t *testing.T) {
+	h, _ := newDocumentHandler()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	w, resp := postJSON(t, "/api/v1/documents", `{"file_id":"`+uuid.New().String()+`"}`,
+		func(c *gin.Context) { setAuthContext(c, tenantID, userID, "member") }, h.Create)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "INVALID_REQUEST", resp.Error.Code)
+	assert.Equal(t, []apierror.FieldError{
+		{Field: "collection_id", Code: "required", Message: "collection_id is required"},
+		{Field: "document_type", Code: "required", Message: "document_type is required"},
+	}, resp.Error.Errors)
+	assert.Equal(t, "collection_id is required; document_type is required", resp.Error.Message)
+}
+
+func TestBinding_BadUUIDNamesField(t *testing.T) {
+	h, _ := newDocumentHandler()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	body := `{"file_id":"not-a-uuid","collection_id":"` + uuid.New().String() + `","document_type":"invoice"}`
+	w, resp := postJSON(t, "/api/v1/documents", body,
+		func(c *gin.Context) { setAuthContext(c, tenantID, userID, "member") }, h.Create)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, []apierror.FieldError{
+		{Field: "file_id", Code: "invalid_uuid", Message: "file_id must be a valid UUID"},
+	}, resp.Error.Errors)
+}
+
+func TestBinding_WrongTypeNamesField(t *testing.T) {
+	h, _ := newDocumentHandler()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	body := `{"file_id":"` + uuid.New().String() + `","collection_id":"` + uuid.New().String() + `","document_type":42}`
+	w, resp := postJSON(t, "/api/v1/documents", body,
+		func(c *gin.Context) { setAuthContext(c, tenantID, userID, "member") }, h.Create)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, []apierror.FieldError{
+		{Field: "document_type", Code: "invalid_type", Message: "document_type must be a string"},
+	}, resp.Error.Errors)
+}
+
+func TestBinding_OutOfRangeAndFormat(t *testing.T) {
+	h := handler.NewAuthHandler(new(mocks.MockAuthService), nil, nil, nil)
+
+	w, resp := postJSON(t, "/api/v1/auth/login", `{"tenant_slug":"acme","email":"not-an-email","password":"short"}`, nil, h.Login)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
+	assert.Equal(t, []apierror.FieldError{
+		{Field: "email", Code: "invalid_format", Message: "email must be a valid email address"},
+		{Field: "password", Code: "out_of_range", Message: "password must be at least 8 characters"},
+	}, resp.Error.Errors)
+}
+
+func TestBinding_MalformedJSONHasNoFieldErrors(t *testing.T) {
+	h := handler.NewAuthHandler(new(mocks.MockAuthService), nil, nil, nil)
+
+	w, resp := postJSON(t, "/api/v1/auth/login", `{"email":`, nil, h.Login)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "request body is not valid JSON", resp.Error.Message)
+	assert.Empty(t, resp.Error.Errors)
+}
+
+func TestBinding_EmptyBody(t *testing.T) {
+	h := handler.NewAuthHandler(new(mocks.MockAuthService), nil, nil, nil)
+
+	w, resp := postJSON(t, "/api/v1/auth/login", "", nil, h.Login)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "request body is required", resp.Error.Message)
+}
+
+func TestBinding_ProblemDetailsCarryFieldErrors(t *testing.T) {
+	h := handler.NewAuthHandler(new(mocks.MockAuthService), nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/refresh", bytes.NewBufferString(`{}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("Accept", apierror.ProblemContentType)
+
+	h.RefreshToken(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, apierror.ProblemContentType, w.Header().Get("Content-Type"))
+	var problem apierror.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, []apierror.FieldError{
+		{Field: "refresh_token", Code: "required", Message: "refresh_token is required"},
+	}, problem.Errors)
+}
@@ -0,0 +1,66 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func runBulkDelete(h *handler.BulkDeleteHandler, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/documents/bulk-delete", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+	h.Run(c)
+	return w
+}
+
+func TestBulkDeleteHandler_Run_DefaultsToDryRun(t *testing.T) {
+	svc := new(mocks.MockBulkDeleteService)
+	h := handler.NewBulkDeleteHandler(svc)
+	svc.On("Preview", mock.Anything, mock.MatchedBy(func(in *service.BulkDeleteInput) bool {
+		return in.Filter.SellerGSTIN == "29ABCDE1234F1Z5" && in.DeleteFiles
+	})).Return(&domain.BulkDeletePreview{MatchedCount: 2, ConfirmationToken: "abc"}, nil)
+
+	w := runBulkDelete(h, `{"filter":{"seller_gstin":"29ABCDE1234F1Z5"},"delete_files":true}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"confirmation_token":"abc"`)
+	svc.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestBulkDeleteHandler_Run_Confirmed(t *testing.T) {
+	svc := new(mocks.MockBulkDeleteService)
+	h := handler.NewBulkDeleteHandler(svc)
+	svc.On("Delete", mock.Anything, mock.MatchedBy(func(in *service.BulkDeleteInput) bool {
+		return in.ConfirmationToken == "abc"
+	})).Return(&domain.BulkDelete{ID: uuid.New(), DeletedCount: 2}, nil)
+
+	w := runBulkDelete(h, `{"filter":{"review_status":"rejected"},"dry_run":false,"confirmation_token":"abc"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"deleted_count":2`)
+}
+
+func TestBulkDeleteHandler_Run_StaleToken(t *testing.T) {
+	svc := new(mocks.MockBulkDeleteService)
+	h := handler.NewBulkDeleteHandler(svc)
+	svc.On("Delete", mock.Anything, mock.Anything).Return(nil, domain.ErrBulkDeleteUnconfirmed)
+
+	w := runBulkDelete(h, `{"filter":{"review_status":"rejected"},"dry_run":false,"confirmation_token":"old"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "BULK_DELETE_UNCONFIRMED")
+}
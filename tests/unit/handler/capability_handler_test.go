@@ -0,0 +1,51 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/mocks"
+)
+
+func TestCapabilityHandler_Get_Success(t *testing.T) {
+	mockSvc := new(mocks.MockCapabilityService)
+	h := handler.NewCapabilityHandler(mockSvc)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	mockSvc.On("Get", mock.Anything, tenantID, userID, domain.RoleViewer).Return(&domain.Capabilities{
+		Role:       domain.RoleViewer,
+		Operations: []domain.Operation{{Method: http.MethodGet, Path: "/api/v1/documents/:id", CollectionPerm: domain.CollectionPermViewer}},
+		Features:   map[domain.FeatureFlag]bool{domain.FlagDualParse: true},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/me/capabilities", http.NoBody)
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"path":"/api/v1/documents/:id"`)
+	assert.Contains(t, w.Body.String(), `"dual_parse":true`)
+}
+
+func TestCapabilityHandler_Get_Unauthenticated(t *testing.T) {
+	h := handler.NewCapabilityHandler(new(mocks.MockCapabilityService))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/me/capabilities", http.NoBody)
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
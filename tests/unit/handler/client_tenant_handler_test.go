@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestClientTenantHandler_Create(t *testing.T) {
+	mockSvc := new(mocks.MockClientTenantService)
+	h := handler.NewClientTenantHandler(mockSvc)
+	firmID, userID := uuid.New(), uuid.New()
+	mockSvc.On("Create", mock.Anything, firmID, mock.MatchedBy(func(in *service.CreateClientInput) bool {
+		return in.Name == "Client A" && in.Slug == "client-a"
+	}), userID).Return(&domain.Tenant{ID: uuid.New(), Name: "Client A", Slug: "client-a", ParentTenantID: &firmID}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/clients",
+		strings.NewReader(`{"name":"Client A","slug":"client-a"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, firmID, userID, "admin")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"parent_tenant_id":"`+firmID.String()+`"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestClientTenantHandler_Create_NestedClient(t *testing.T) {
+	mockSvc := new(mocks.MockClientTenantService)
+	h := handler.NewClientTenantHandler(mockSvc)
+	mockSvc.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidClientTenant)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/clients",
+		strings.NewReader(`{"name":"Nested","slug":"nested"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_CLIENT_TENANT")
+}
+
+func TestClientTenantHandler_Dashboard(t *testing.T) {
+	mockSvc := new(mocks.MockClientTenantService)
+	h := handler.NewClientTenantHandler(mockSvc)
+	firmID := uuid.New()
+	mockSvc.On("Dashboard", mock.Anything, firmID).Return(&domain.ClientDashboard{
+		Totals:  domain.Stats{TotalDocuments: 8},
+		Clients: []domain.ClientStats{{TenantID: uuid.New(), Name: "A", Stats: domain.Stats{TotalDocuments: 8}}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/clients/dashboard", http.NoBody)
+	setAuthContext(c, firmID, uuid.New(), "admin")
+
+	h.Dashboard(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"totals":{"total_documents":8`)
+}
+
+func TestClientTenantHandler_MoveStaff(t *testing.T) {
+	mockSvc := new(mocks.MockClientTenantService)
+	h := handler.NewClientTenantHandler(mockSvc)
+	firmID, adminID, fromID, toID, staffID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("MoveStaff", mock.Anything, firmID, fromID, staffID, mock.MatchedBy(func(in *service.MoveStaffInput) bool {
+		return in.ToClientID == toID && in.Role == ""
+	}), adminID).Return(&domain.TenantMembership{TenantID: toID, UserID: staffID, Role: domain.RoleMember}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/clients/"+fromID.String()+"/staff/"+staffID.String()+"/move",
+		strings.NewReader(`{"to_client_id":"`+toID.String()+`"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: fromID.String()}, {Key: "userId", Value: staffID.String()}}
+	setAuthContext(c, firmID, adminID, "admin")
+
+	h.MoveStaff(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestCloudImportHandler_Authorize_NotConfigured(t *testing.T) {
+	mockSvc := new(mocks.MockCloudImportService)
+	h := handler.NewCloudImportHandler(mockSvc)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	mockSvc.On("AuthorizeURL", mock.Anything, tenantID, userID, domain.CloudProviderDropbox).
+		Return("", domain.ErrCloudProviderNotConfigured)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/integrations/dropbox/authorize", http.NoBody)
+	c.Params = gin.Params{{Key: "provider", Value: "dropbox"}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Authorize(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "CLOUD_PROVIDER_NOT_CONFIGURED")
+}
+
+func TestCloudImportHandler_Connect_InvalidState(t *testing.T) {
+	mockSvc := new(mocks.MockCloudImportService)
+	h := handler.NewCloudImportHandler(mockSvc)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	mockSvc.On("Connect", mock.Anything, tenantID, userID, domain.CloudProviderGoogleDrive, "code-1", "bad-state").
+		Return(nil, domain.ErrInvalidOAuthState)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/integrations/google_drive/connect",
+		bytes.NewBufferString(`{"code":"code-1","state":"bad-state"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "provider", Value: "google_drive"}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Connect(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_OAUTH_STATE")
+}
+
+func TestCloudImportHandler_CreateSync_Accepted(t *testing.T) {
+	mockSvc := new(mocks.MockCloudImportService)
+	h := handler.NewCloudImportHandler(mockSvc)
+	tenantID, userID, collectionID, connectionID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("CreateSync", mock.Anything, mock.MatchedBy(func(in *service.CreateCloudSyncInput) bool {
+		return in.CollectionID == collectionID && in.ConnectionID == connectionID &&
+			in.FolderID == "folder-1" && in.PollEnabled && in.ParseMode == domain.ParseModeSingle
+	})).Return(&domain.CloudSync{ID: uuid.New(), CollectionID: collectionID, PollEnabled: true}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/collections/"+collectionID.String()+"/cloud-syncs",
+		bytes.NewBufferString(`{"connection_id":"`+connectionID.String()+`","folder_id":"folder-1","document_type":"invoice","poll_enabled":true}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.CreateSync(c)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"poll_enabled":true`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestCloudImportHandler_UpdateSync_MissingPollEnabled(t *testing.T) {
+	mockSvc := new(mocks.MockCloudImportService)
+	h := handler.NewCloudImportHandler(mockSvc)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/", bytes.NewBufferString(`{}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: uuid.New().String()}, {Key: "syncId", Value: uuid.New().String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.UpdateSync(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "SetPolling")
+}
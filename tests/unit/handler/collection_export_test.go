@@ -1,8 +1,12 @@
 package handler_test
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -14,10 +18,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
 
 	"satvos/internal/csvexport"
 	"satvos/internal/domain"
 	"satvos/internal/handler"
+	"satvos/internal/service"
 	"satvos/internal/validator/invoice"
 	"satvos/mocks"
 )
@@ -25,7 +31,7 @@ import (
 func newExportHandler() (*handler.CollectionHandler, *mocks.MockCollectionService, *mocks.MockDocumentService) {
 	collSvc := new(mocks.MockCollectionService)
 	docSvc := new(mocks.MockDocumentService)
-	h := handler.NewCollectionHandler(collSvc, docSvc)
+	h := handler.NewCollectionHandler(collSvc, docSvc, nil, nil)
 	return h, collSvc, docSvc
 }
 
@@ -105,6 +111,43 @@ func TestExportCSV_Success(t *testing.T) {
 	docSvc.AssertExpectations(t)
 }
 
+func TestExportCSV_WithExtensions(t *testing.T) {
+	collSvc := new(mocks.MockCollectionService)
+	docSvc := new(mocks.MockDocumentService)
+	fieldSvc := new(mocks.MockComputedFieldService)
+	h := handler.NewCollectionHandler(collSvc, docSvc, nil, fieldSvc)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	collectionID := uuid.New()
+
+	collSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member")).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID, Name: "Q3"}, nil)
+	docSvc.On("ListByCollection", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member"), (*uuid.UUID)(nil), 0, 200).
+		Return([]domain.Document{{ID: uuid.New(), Name: "Invoice 1", CreatedAt: time.Now()}}, 1, nil)
+	fieldSvc.On("List", mock.Anything, tenantID).Return([]domain.ComputedField{{Name: "net_tax"}, {Name: "region"}}, nil)
+	fieldSvc.On("Resolve", mock.Anything, tenantID, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).([]domain.Document)[0].Extensions = map[string]interface{}{"net_tax": 180.5, "region": nil}
+	}).Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/export/csv?extensions=true", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ExportCSV(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	records, err := csv.NewReader(strings.NewReader(w.Body.String()[3:])).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Len(t, records[0], 35)
+	assert.Equal(t, []string{"net_tax", "region"}, records[0][33:])
+	assert.Equal(t, []string{"180.5", ""}, records[1][33:])
+	fieldSvc.AssertExpectations(t)
+}
+
 func TestExportCSV_CollectionNotFound(t *testing.T) {
 	h, collSvc, _ := newExportHandler()
 
@@ -211,3 +254,234 @@ func TestExportCSV_InvalidID(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+func exportFailuresRequest(t *testing.T, format string) (*httptest.ResponseRecorder, *mocks.MockCollectionService, *mocks.MockDocumentService) {
+	t.Helper()
+	h, collSvc, docSvc := newExportHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	collectionID := uuid.New()
+
+	collSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member")).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID, Name: "Q3 Purchase Invoices"}, nil).Maybe()
+	docSvc.On("ListValidationFailures", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member"), 0, 500).
+		Return([]domain.ValidationFailure{
+			{
+				DocumentID: uuid.New(), DocumentName: "Invoice 1", InvoiceNumber: "INV-001",
+				ReviewStatus: domain.ReviewStatusPending, RuleName: "Math: Total", RuleType: "sum_check",
+				Severity: domain.ValidationSeverityError, FieldPath: "totals.total",
+				ExpectedValue: "1180.00", ActualValue: "1100.00", Message: "total does not match",
+			},
+		}, nil).Maybe()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	url := "/api/v1/collections/" + collectionID.String() + "/export/validation-failures"
+	if format != "" {
+		url += "?format=" + format
+	}
+	c.Request, _ = http.NewRequest(http.MethodGet, url, http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ExportValidationFailures(c)
+	return w, collSvc, docSvc
+}
+
+func TestExportValidationFailures_CSV(t *testing.T) {
+	w, _, docSvc := exportFailuresRequest(t, "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "Q3_Purchase_Invoices_validation_failures_")
+
+	body := w.Body.Bytes()
+	require.True(t, bytes.HasPrefix(body, csvexport.BOM))
+	records, err := csv.NewReader(bytes.NewReader(body[3:])).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "Document Name", records[0][0])
+	assert.Equal(t, []string{"INV-001", "Math: Total", "error", "totals.total", "1180.00", "1100.00"},
+		[]string{records[1][1], records[1][4], records[1][6], records[1][7], records[1][8], records[1][9]})
+	docSvc.AssertExpectations(t)
+}
+
+func TestExportValidationFailures_XLSX(t *testing.T) {
+	w, _, _ := exportFailuresRequest(t, "xlsx")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".xlsx")
+
+	f, err := excelize.OpenReader(bytes.NewReader(w.Body.Bytes()))
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := f.GetRows("Validation Failures")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Severity", rows[0][6])
+	assert.Equal(t, "Invoice 1", rows[1][0])
+	assert.Equal(t, "1100.00", rows[1][9])
+}
+
+func TestExportValidationFailures_InvalidFormat(t *testing.T) {
+	w, collSvc, _ := exportFailuresRequest(t, "pdf")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	collSvc.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportCSV_RecordsArtifact(t *testing.T) {
+	collSvc := new(mocks.MockCollectionService)
+	docSvc := new(mocks.MockDocumentService)
+	exportSvc := new(mocks.MockExportAuditService)
+	h := handler.NewCollectionHandler(collSvc, docSvc, exportSvc, nil)
+
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	collSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member")).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID, Name: "Q3"}, nil)
+	docSvc.On("ListByCollection", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member"), (*uuid.UUID)(nil), 0, 200).
+		Return([]domain.Document{{ID: uuid.New(), Name: "A"}, {ID: uuid.New(), Name: "B"}}, 2, nil)
+
+	var recorded *service.RecordExportInput
+	exportSvc.On("Record", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { recorded = args.Get(1).(*service.RecordExportInput) }).
+		Return(&domain.ExportArtifact{}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/export/csv", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ExportCSV(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, recorded)
+	sum := sha256.Sum256(w.Body.Bytes())
+	assert.Equal(t, hex.EncodeToString(sum[:]), recorded.ChecksumSHA256)
+	assert.Equal(t, int64(w.Body.Len()), recorded.SizeBytes)
+	assert.Equal(t, 2, recorded.RowCount)
+	assert.Equal(t, domain.ExportKindDocumentsCSV, recorded.Kind)
+	assert.Equal(t, csvexport.FormatCSV, recorded.Format)
+	assert.Equal(t, collectionID, recorded.CollectionID)
+	assert.Equal(t, userID, recorded.UserID)
+	assert.Empty(t, recorded.Filters)
+}
+
+func TestExportCSV_FetchFailure_NotRecorded(t *testing.T) {
+	collSvc := new(mocks.MockCollectionService)
+	docSvc := new(mocks.MockDocumentService)
+	exportSvc := new(mocks.MockExportAuditService)
+	h := handler.NewCollectionHandler(collSvc, docSvc, exportSvc, nil)
+
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	collSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member")).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID, Name: "Q3"}, nil)
+	docSvc.On("ListByCollection", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member"), (*uuid.UUID)(nil), 0, 200).
+		Return(nil, 0, errors.New("db down"))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/export/csv", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ExportCSV(c)
+
+	exportSvc.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestExportValidationFailures_RecordsArtifact(t *testing.T) {
+	collSvc := new(mocks.MockCollectionService)
+	docSvc := new(mocks.MockDocumentService)
+	exportSvc := new(mocks.MockExportAuditService)
+	h := handler.NewCollectionHandler(collSvc, docSvc, exportSvc, nil)
+
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	collSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member")).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID, Name: "Q3"}, nil)
+	docSvc.On("ListValidationFailures", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member"), 0, 500).
+		Return([]domain.ValidationFailure{{DocumentID: uuid.New(), DocumentName: "Invoice 1"}}, nil)
+
+	var recorded *service.RecordExportInput
+	exportSvc.On("Record", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { recorded = args.Get(1).(*service.RecordExportInput) }).
+		Return(&domain.ExportArtifact{}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/api/v1/collections/"+collectionID.String()+"/export/validation-failures?format=xlsx&period=2025-09", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ExportValidationFailures(c)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, recorded)
+	sum := sha256.Sum256(w.Body.Bytes())
+	assert.Equal(t, hex.EncodeToString(sum[:]), recorded.ChecksumSHA256)
+	assert.Equal(t, 1, recorded.RowCount)
+	assert.Equal(t, domain.ExportKindValidationFailures, recorded.Kind)
+	assert.Equal(t, csvexport.FormatXLSX, recorded.Format)
+	assert.Equal(t, map[string]string{"period": "2025-09"}, recorded.Filters)
+}
+
+func TestListExports_ByChecksum(t *testing.T) {
+	exportSvc := new(mocks.MockExportAuditService)
+	h := handler.NewCollectionHandler(nil, nil, exportSvc, nil)
+
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	checksum := strings.Repeat("ab", 32)
+	exportSvc.On("ListByCollection", mock.Anything, tenantID, collectionID, userID, domain.UserRole("viewer"), checksum, 0, 20).
+		Return([]domain.ExportArtifact{{CollectionID: collectionID, ChecksumSHA256: checksum, DownloadCount: 2}}, 1, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/api/v1/collections/"+collectionID.String()+"/exports?checksum="+strings.ToUpper(checksum), http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.ListExports(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"download_count":2`)
+	exportSvc.AssertExpectations(t)
+}
+
+func TestListExportDownloads_NotFound(t *testing.T) {
+	exportSvc := new(mocks.MockExportAuditService)
+	h := handler.NewCollectionHandler(nil, nil, exportSvc, nil)
+
+	tenantID, userID, collectionID, artifactID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	exportSvc.On("ListDownloads", mock.Anything, tenantID, collectionID, artifactID, userID, domain.UserRole("viewer"), 0, 20).
+		Return(nil, 0, domain.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/api/v1/collections/"+collectionID.String()+"/exports/"+artifactID.String()+"/downloads", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}, {Key: "exportId", Value: artifactID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.ListExportDownloads(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListExportDownloads_InvalidExportID(t *testing.T) {
+	h := handler.NewCollectionHandler(nil, nil, new(mocks.MockExportAuditService), nil)
+
+	collectionID := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/exports/bad/downloads", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}, {Key: "exportId", Value: "bad"}}
+	setAuthContext(c, uuid.New(), uuid.New(), "viewer")
+
+	h.ListExportDownloads(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
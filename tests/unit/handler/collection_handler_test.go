@@ -3,6 +3,7 @@ package handler_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
@@ -15,12 +16,13 @@ import (
 
 	"satvos/internal/domain"
 	"satvos/internal/handler"
+	"satvos/internal/service"
 	"satvos/mocks"
 )
 
 func newCollectionHandler() (*handler.CollectionHandler, *mocks.MockCollectionService) {
 	mockSvc := new(mocks.MockCollectionService)
-	h := handler.NewCollectionHandler(mockSvc, nil)
+	h := handler.NewCollectionHandler(mockSvc, nil, nil, nil)
 	return h, mockSvc
 }
 
@@ -299,6 +301,162 @@ func TestCollectionHandler_Update_MissingName(t *testing.T) {
 
 // --- Delete ---
 
+func TestCollectionHandler_SetReviewChecklist_InvalidChecklist(t *testing.T) {
+	h, mockSvc := newCollectionHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	collectionID := uuid.New()
+
+	mockSvc.On("SetReviewChecklist", mock.Anything, mock.MatchedBy(func(in *service.SetReviewChecklistInput) bool {
+		return in.CollectionID == collectionID && len(in.Items) == 1 && in.Items[0].Label == ""
+	})).Return(nil, fmt.Errorf("%w: label must be 1-200 characters", domain.ErrInvalidReviewChecklist))
+
+	body := []byte(`{"items":[{"id":"po_attached","label":""}]}`)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/collections/"+collectionID.String()+"/review-checklist", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.SetReviewChecklist(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_REVIEW_CHECKLIST")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestCollectionHandler_SetApprovalPolicy_NegativeThreshold(t *testing.T) {
+	h, mockSvc := newCollectionHandler()
+	collectionID := uuid.New()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/collections/"+collectionID.String()+"/approval-policy",
+		bytes.NewReader([]byte(`{"checker_threshold":-1}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.SetApprovalPolicy(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "SetCheckerThreshold", mock.Anything, mock.Anything)
+}
+
+func TestCollectionHandler_SetEscalationPolicy(t *testing.T) {
+	h, mockSvc := newCollectionHandler()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	days := 3
+	mockSvc.On("SetEscalationPolicy", mock.Anything, mock.MatchedBy(func(in *service.SetEscalationPolicyInput) bool {
+		return in.CollectionID == collectionID && in.AfterDays != nil && *in.AfterDays == 3 &&
+			in.Action == domain.EscalationActionReassign
+	})).Return(&domain.Collection{ID: collectionID, EscalateAfterDays: &days, EscalationAction: domain.EscalationActionReassign}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/collections/"+collectionID.String()+"/escalation-policy",
+		bytes.NewReader([]byte(`{"after_days":3,"action":"reassign"}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.SetEscalationPolicy(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"escalate_after_days":3`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestCollectionHandler_SetEscalationPolicy_Invalid(t *testing.T) {
+	h, mockSvc := newCollectionHandler()
+	collectionID := uuid.New()
+	mockSvc.On("SetEscalationPolicy", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidEscalationPolicy)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/collections/"+collectionID.String()+"/escalation-policy",
+		bytes.NewReader([]byte(`{"after_days":0}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.SetEscalationPolicy(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_ESCALATION_POLICY")
+}
+
+func TestCollectionHandler_SetKPITargets(t *testing.T) {
+	h, mockSvc := newCollectionHandler()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("SetKPITargets", mock.Anything, mock.MatchedBy(func(in *service.SetKPITargetsInput) bool {
+		return in.CollectionID == collectionID && len(in.Targets) == 1 &&
+			in.Targets[0].Metric == domain.KPIMetricReviewed && in.Targets[0].DueDate == "2026-11-10"
+	})).Return(&domain.Collection{ID: collectionID}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/collections/"+collectionID.String()+"/kpi-targets",
+		bytes.NewReader([]byte(`{"targets":[{"metric":"reviewed","target_pct":100,"due_date":"2026-11-10"}]}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.SetKPITargets(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestCollectionHandler_SetKPITargets_Invalid(t *testing.T) {
+	h, mockSvc := newCollectionHandler()
+	collectionID := uuid.New()
+	mockSvc.On("SetKPITargets", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidKPITargets)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/collections/"+collectionID.String()+"/kpi-targets",
+		bytes.NewReader([]byte(`{"targets":[{"metric":"exported","due_date":"2026-11-10"}]}`)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.SetKPITargets(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_KPI_TARGETS")
+}
+
+func TestCollectionHandler_GetProgress(t *testing.T) {
+	h, mockSvc := newCollectionHandler()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("GetProgress", mock.Anything, tenantID, collectionID, userID, domain.UserRole("viewer")).
+		Return(&service.CollectionProgress{
+			CollectionID: collectionID, TotalDocuments: 10, Reviewed: 4, PercentReviewed: 40, AtRisk: true,
+			Targets: []service.KPITargetProgress{{
+				KPITarget: domain.KPITarget{Metric: domain.KPIMetricReviewed, TargetPct: 100, DueDate: "2026-11-10"},
+				Remaining: 6, Status: domain.KPITargetAtRisk,
+			}},
+		}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/progress", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.GetProgress(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"metric":"reviewed"`)
+	assert.Contains(t, w.Body.String(), `"status":"at_risk"`)
+	assert.Contains(t, w.Body.String(), `"at_risk":true`)
+	mockSvc.AssertExpectations(t)
+}
+
 func TestCollectionHandler_Delete_Success(t *testing.T) {
 	h, mockSvc := newCollectionHandler()
 
@@ -652,3 +810,45 @@ func TestFileHandler_Upload_WithCollectionID_CollectionFails(t *testing.T) {
 func multipartWriter(body *bytes.Buffer) *multipart.Writer {
 	return multipart.NewWriter(body)
 }
+
+// --- ListActivity ---
+
+func TestCollectionHandler_ListActivity_Success(t *testing.T) {
+	h, mockSvc := newCollectionHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	collectionID := uuid.New()
+
+	mockSvc.On("ListActivity", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member"), 0, 20).
+		Return([]domain.CollectionActivityEvent{{Type: domain.ActivityFileUploaded}}, 1, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/activity", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ListActivity(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp handler.APIResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Success)
+	assert.NotNil(t, resp.Meta)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestCollectionHandler_ListActivity_InvalidID(t *testing.T) {
+	h, _ := newCollectionHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/bad/activity", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: "bad"}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.ListActivity(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
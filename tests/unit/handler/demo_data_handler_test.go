@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/mocks"
+)
+
+func newDemoDataHandler() (*handler.DemoDataHandler, *mocks.MockDemoDataService) {
+	mockSvc := new(mocks.MockDemoDataService)
+	return handler.NewDemoDataHandler(mockSvc), mockSvc
+}
+
+func demoDataContext(method, tenantID, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(method, "/api/v1/admin/tenants/"+tenantID+"/demo-data", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: tenantID}}
+	return c, w
+}
+
+func TestDemoDataHandler_Seed_DefaultsOwnerToCaller(t *testing.T) {
+	h, mockSvc := newDemoDataHandler()
+	tenantID, callerID := uuid.New(), uuid.New()
+	mockSvc.On("Seed", mock.Anything, tenantID, callerID).
+		Return(&domain.DemoData{TenantID: tenantID, CollectionID: uuid.New(), Documents: 6}, nil)
+
+	c, w := demoDataContext(http.MethodPost, tenantID.String(), "")
+	setAuthContext(c, tenantID, callerID, "admin")
+
+	h.Seed(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp struct {
+		Data domain.DemoData `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 6, resp.Data.Documents)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDemoDataHandler_Seed_ExplicitOwner(t *testing.T) {
+	h, mockSvc := newDemoDataHandler()
+	tenantID, ownerID := uuid.New(), uuid.New()
+	mockSvc.On("Seed", mock.Anything, tenantID, ownerID).Return(&domain.DemoData{TenantID: tenantID}, nil)
+
+	c, w := demoDataContext(http.MethodPost, tenantID.String(), `{"owner_id":"`+ownerID.String()+`"}`)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Seed(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDemoDataHandler_Seed_AlreadySeeded(t *testing.T) {
+	h, mockSvc := newDemoDataHandler()
+	tenantID := uuid.New()
+	mockSvc.On("Seed", mock.Anything, tenantID, mock.Anything).Return(nil, domain.ErrDemoDataExists)
+
+	c, w := demoDataContext(http.MethodPost, tenantID.String(), "")
+	setAuthContext(c, tenantID, uuid.New(), "admin")
+
+	h.Seed(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "DEMO_DATA_EXISTS")
+}
+
+func TestDemoDataHandler_Cleanup(t *testing.T) {
+	h, mockSvc := newDemoDataHandler()
+	tenantID := uuid.New()
+	mockSvc.On("Cleanup", mock.Anything, tenantID).
+		Return(&domain.DemoCleanup{TenantID: tenantID, Collections: 1, Documents: 6, Files: 6}, nil)
+
+	c, w := demoDataContext(http.MethodDelete, tenantID.String(), "")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Cleanup(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDemoDataHandler_Cleanup_InvalidID(t *testing.T) {
+	h, mockSvc := newDemoDataHandler()
+
+	c, w := demoDataContext(http.MethodDelete, "nope", "")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Cleanup(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Cleanup", mock.Anything, mock.Anything)
+}
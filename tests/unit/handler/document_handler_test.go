@@ -6,23 +6,26 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"satvos/internal/domain"
 	"satvos/internal/handler"
 	"satvos/internal/service"
 	"satvos/internal/validator"
+	"satvos/internal/validator/invoice"
 	"satvos/mocks"
 )
 
 func newDocumentHandler() (*handler.DocumentHandler, *mocks.MockDocumentService) {
 	mockSvc := new(mocks.MockDocumentService)
 	auditRepo := new(mocks.MockDocumentAuditRepo)
-	h := handler.NewDocumentHandler(mockSvc, auditRepo)
+	h := handler.NewDocumentHandler(mockSvc, auditRepo, nil, nil)
 	return h, mockSvc
 }
 
@@ -79,6 +82,85 @@ func TestDocumentHandler_Create_Success(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func TestDocumentHandler_Create_QuotaHeaders(t *testing.T) {
+	mockSvc := new(mocks.MockDocumentService)
+	quota := new(mocks.MockQuotaService)
+	h := handler.NewDocumentHandler(mockSvc, new(mocks.MockDocumentAuditRepo), nil, quota)
+
+	tenantID, userID := uuid.New(), uuid.New()
+	mockSvc.On("CreateAndParse", mock.Anything, mock.Anything).Return(&domain.Document{ID: uuid.New()}, nil)
+	quota.On("Usage", mock.Anything, tenantID, userID).Return(&domain.QuotaUsage{Limit: 50, Used: 41, Remaining: 9}, nil)
+
+	body, _ := json.Marshal(map[string]string{
+		"file_id":       uuid.New().String(),
+		"collection_id": uuid.New().String(),
+		"document_type": "invoice",
+	})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/documents", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, tenantID, userID, "free")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "50", w.Header().Get("X-Quota-Limit"))
+	assert.Equal(t, "9", w.Header().Get("X-Quota-Remaining"))
+}
+
+func TestDocumentHandler_Create_QuotaExceededHeaders(t *testing.T) {
+	mockSvc := new(mocks.MockDocumentService)
+	quota := new(mocks.MockQuotaService)
+	h := handler.NewDocumentHandler(mockSvc, new(mocks.MockDocumentAuditRepo), nil, quota)
+
+	tenantID, userID := uuid.New(), uuid.New()
+	mockSvc.On("CreateAndParse", mock.Anything, mock.Anything).Return(nil, domain.ErrQuotaExceeded)
+	quota.On("Usage", mock.Anything, tenantID, userID).Return(&domain.QuotaUsage{Limit: 50, Used: 50, Remaining: 0}, nil)
+
+	body, _ := json.Marshal(map[string]string{
+		"file_id":       uuid.New().String(),
+		"collection_id": uuid.New().String(),
+		"document_type": "invoice",
+	})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/documents", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, tenantID, userID, "free")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-Quota-Remaining"))
+}
+
+func TestDocumentHandler_Create_UnlimitedUserNoQuotaHeaders(t *testing.T) {
+	mockSvc := new(mocks.MockDocumentService)
+	quota := new(mocks.MockQuotaService)
+	h := handler.NewDocumentHandler(mockSvc, new(mocks.MockDocumentAuditRepo), nil, quota)
+
+	tenantID, userID := uuid.New(), uuid.New()
+	mockSvc.On("CreateAndParse", mock.Anything, mock.Anything).Return(&domain.Document{ID: uuid.New()}, nil)
+	quota.On("Usage", mock.Anything, tenantID, userID).Return(nil, nil)
+
+	body, _ := json.Marshal(map[string]string{
+		"file_id":       uuid.New().String(),
+		"collection_id": uuid.New().String(),
+		"document_type": "invoice",
+	})
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/documents", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("X-Quota-Remaining"))
+}
+
 func TestDocumentHandler_Create_MissingFields(t *testing.T) {
 	h, _ := newDocumentHandler()
 
@@ -224,7 +306,7 @@ func TestDocumentHandler_Create_DefaultParseMode(t *testing.T) {
 	}
 
 	mockSvc.On("CreateAndParse", mock.Anything, mock.MatchedBy(func(input *service.CreateDocumentInput) bool {
-		return input.ParseMode == domain.ParseModeSingle
+		return input.ParseMode == ""
 	})).Return(expected, nil)
 
 	body, _ := json.Marshal(map[string]string{
@@ -308,6 +390,63 @@ func TestDocumentHandler_GetByID_Success(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func TestDocumentHandler_GetByID_WithExtensions(t *testing.T) {
+	mockSvc := new(mocks.MockDocumentService)
+	fieldSvc := new(mocks.MockComputedFieldService)
+	h := handler.NewDocumentHandler(mockSvc, new(mocks.MockDocumentAuditRepo), fieldSvc, nil)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	docID := uuid.New()
+
+	mockSvc.On("GetByID", mock.Anything, tenantID, docID, userID, domain.UserRole("member")).
+		Return(&domain.Document{ID: docID, TenantID: tenantID}, nil)
+	fieldSvc.On("Resolve", mock.Anything, tenantID, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).([]domain.Document)[0].Extensions = map[string]interface{}{"net_tax": 180.0}
+	}).Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"?extensions=true", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.GetByID(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data domain.Document `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, map[string]interface{}{"net_tax": 180.0}, resp.Data.Extensions)
+	fieldSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_GetByID_ExtensionsNotRequested(t *testing.T) {
+	mockSvc := new(mocks.MockDocumentService)
+	fieldSvc := new(mocks.MockComputedFieldService)
+	h := handler.NewDocumentHandler(mockSvc, new(mocks.MockDocumentAuditRepo), fieldSvc, nil)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	docID := uuid.New()
+
+	mockSvc.On("GetByID", mock.Anything, tenantID, docID, userID, domain.UserRole("member")).
+		Return(&domain.Document{ID: docID, TenantID: tenantID}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String(), http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.GetByID(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "extensions")
+	fieldSvc.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestDocumentHandler_GetByID_NotFound(t *testing.T) {
 	h, mockSvc := newDocumentHandler()
 
@@ -345,6 +484,55 @@ func TestDocumentHandler_GetByID_InvalidID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+// --- ListApprovals ---
+
+func TestDocumentHandler_ListApprovals(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	docID := uuid.New()
+	approvals := []domain.DocumentApproval{{ID: uuid.New(), DocumentID: docID, Version: 2, ApprovedBy: userID}}
+
+	mockSvc.On("ListApprovals", mock.Anything, tenantID, docID, userID, domain.UserRole("viewer")).Return(approvals, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"/approvals", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.ListApprovals(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []domain.DocumentApproval `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, 2, resp.Data[0].Version)
+}
+
+func TestDocumentHandler_ListApprovals_NotFound(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	docID := uuid.New()
+
+	mockSvc.On("ListApprovals", mock.Anything, tenantID, docID, userID, domain.UserRole("viewer")).Return(nil, domain.ErrDocumentNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"/approvals", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.ListApprovals(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 // --- List ---
 
 func TestDocumentHandler_List_ByTenant(t *testing.T) {
@@ -357,6 +545,7 @@ func TestDocumentHandler_List_ByTenant(t *testing.T) {
 		{ID: uuid.New(), TenantID: tenantID, ParsingStatus: domain.ParsingStatusCompleted},
 	}
 
+	mockSvc.On("EnrichDocuments", mock.Anything, tenantID, mock.Anything).Return(nil)
 	mockSvc.On("ListByTenant", mock.Anything, tenantID, userID, domain.UserRole("member"), (*uuid.UUID)(nil), 0, 20).Return(docs, 1, nil)
 
 	w := httptest.NewRecorder()
@@ -387,6 +576,7 @@ func TestDocumentHandler_List_ByCollection(t *testing.T) {
 		{ID: uuid.New(), TenantID: tenantID, CollectionID: collectionID},
 	}
 
+	mockSvc.On("EnrichDocuments", mock.Anything, tenantID, mock.Anything).Return(nil)
 	mockSvc.On("ListByCollection", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member"), (*uuid.UUID)(nil), 0, 20).
 		Return(docs, 1, nil)
 
@@ -870,7 +1060,7 @@ func TestDocumentHandler_AddTags_Success(t *testing.T) {
 	}
 
 	mockSvc.On("AddTags", mock.Anything, tenantID, docID, userID, domain.UserRole("member"),
-		map[string]string{"vendor": "Acme"}).Return(resultTags, nil)
+		map[string]string{"vendor": "Acme"}, map[string]domain.TagValueType(nil)).Return(resultTags, nil)
 
 	body, _ := json.Marshal(map[string]interface{}{
 		"tags": map[string]string{"vendor": "Acme"},
@@ -970,7 +1160,8 @@ func TestDocumentHandler_SearchByTag_Success(t *testing.T) {
 		{ID: uuid.New(), TenantID: tenantID},
 	}
 
-	mockSvc.On("SearchByTag", mock.Anything, tenantID, "vendor", "Acme", 0, 20).
+	mockSvc.On("EnrichDocuments", mock.Anything, tenantID, mock.Anything).Return(nil)
+	mockSvc.On("SearchByTag", mock.Anything, tenantID, &service.TagSearchInput{Key: "vendor", Value: "Acme"}, 0, 20).
 		Return(docs, 1, nil)
 
 	w := httptest.NewRecorder()
@@ -991,12 +1182,38 @@ func TestDocumentHandler_SearchByTag_Success(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func TestDocumentHandler_SearchByTag_Range(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	mockSvc.On("EnrichDocuments", mock.Anything, tenantID, mock.Anything).Return(nil)
+	mockSvc.On("SearchByTag", mock.Anything, tenantID,
+		&service.TagSearchInput{Key: "total_amount", Min: "1000", Max: "5000", Type: domain.TagValueNumber}, 0, 20).
+		Return([]domain.Document{}, 0, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/api/v1/documents/search/tags?key=total_amount&min=1000&max=5000&type=number", http.NoBody)
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.SearchByTag(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
 func TestDocumentHandler_SearchByTag_MissingParams(t *testing.T) {
-	h, _ := newDocumentHandler()
+	h, mockSvc := newDocumentHandler()
 
 	tenantID := uuid.New()
 	userID := uuid.New()
 
+	mockSvc.On("SearchByTag", mock.Anything, tenantID, &service.TagSearchInput{Key: "vendor"}, 0, 20).
+		Return(nil, 0, domain.ErrInvalidTagSearch)
+
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/search/tags?key=vendor", http.NoBody)
@@ -1539,7 +1756,7 @@ func TestDocumentHandler_GetValidation_PermDenied(t *testing.T) {
 func TestDocumentHandler_ListAudit_Success(t *testing.T) {
 	mockSvc := new(mocks.MockDocumentService)
 	auditRepo := new(mocks.MockDocumentAuditRepo)
-	h := handler.NewDocumentHandler(mockSvc, auditRepo)
+	h := handler.NewDocumentHandler(mockSvc, auditRepo, nil, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -1764,6 +1981,7 @@ func TestDocumentHandler_ReviewQueue_Success(t *testing.T) {
 		{ID: uuid.New(), TenantID: tenantID, ParsingStatus: domain.ParsingStatusCompleted, AssignedTo: &userID},
 	}
 
+	mockSvc.On("EnrichDocuments", mock.Anything, tenantID, mock.Anything).Return(nil)
 	mockSvc.On("ListReviewQueue", mock.Anything, tenantID, userID, 0, 20).Return(docs, 1, nil)
 
 	w := httptest.NewRecorder()
@@ -1789,6 +2007,7 @@ func TestDocumentHandler_ReviewQueue_Empty(t *testing.T) {
 	tenantID := uuid.New()
 	userID := uuid.New()
 
+	mockSvc.On("EnrichDocuments", mock.Anything, tenantID, mock.Anything).Return(nil)
 	mockSvc.On("ListReviewQueue", mock.Anything, tenantID, userID, 0, 20).Return([]domain.Document{}, 0, nil)
 
 	w := httptest.NewRecorder()
@@ -1818,3 +2037,505 @@ func TestDocumentHandler_ReviewQueue_NoAuth(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
+
+// --- Overrides ---
+
+func TestDocumentHandler_ListOverrides_Success(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	docID := uuid.New()
+
+	overrides := []domain.DocumentFieldOverride{
+		{ID: uuid.New(), DocumentID: docID, FieldPath: "invoice.invoice_date", Value: json.RawMessage(`"2025-01-16"`)},
+	}
+	mockSvc.On("ListOverrides", mock.Anything, tenantID, docID, userID, domain.UserRole("member")).Return(overrides, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"/overrides", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ListOverrides(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "invoice.invoice_date")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_ClearOverrides_SinglePath(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	docID := uuid.New()
+
+	mockSvc.On("ClearOverrides", mock.Anything, tenantID, docID, userID, domain.UserRole("member"), "seller.gstin").Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/documents/"+docID.String()+"/overrides?field_path=seller.gstin", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ClearOverrides(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_ClearOverrides_InvalidID(t *testing.T) {
+	h, _ := newDocumentHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/documents/bad/overrides", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: "not-a-uuid"}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.ClearOverrides(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// --- SearchAudit ---
+
+func TestDocumentHandler_SearchAudit_WithFilters(t *testing.T) {
+	mockSvc := new(mocks.MockDocumentService)
+	auditRepo := new(mocks.MockDocumentAuditRepo)
+	h := handler.NewDocumentHandler(mockSvc, auditRepo, nil, nil)
+
+	tenantID := uuid.New()
+	actorID := uuid.New()
+	collectionID := uuid.New()
+
+	auditRepo.On("ListByTenant", mock.Anything, tenantID, mock.MatchedBy(func(f *domain.AuditFilters) bool {
+		return len(f.Actions) == 2 && f.Actions[0] == "document.review" &&
+			f.UserID != nil && *f.UserID == actorID &&
+			f.CollectionID != nil && *f.CollectionID == collectionID &&
+			f.DocumentID == nil &&
+			f.From != nil && f.From.Format("2006-01-02T15:04:05") == "2025-01-01T00:00:00" &&
+			f.To != nil && f.To.Format("2006-01-02T15:04:05") == "2025-01-31T23:59:59" &&
+			f.Offset == 0 && f.Limit == 50
+	})).Return([]domain.DocumentAuditEntry{{ID: uuid.New(), TenantID: tenantID, Action: "document.review"}}, 1, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/api/v1/audit?action=document.review,document.edit_structured_data&user_id="+actorID.String()+
+			"&collection_id="+collectionID.String()+"&from=2025-01-01&to=2025-01-31&limit=50", http.NoBody)
+	setAuthContext(c, tenantID, uuid.New(), "admin")
+
+	h.SearchAudit(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestDocumentHandler_SearchAudit_InvalidUserID(t *testing.T) {
+	h, _ := newDocumentHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/audit?user_id=nope", http.NoBody)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.SearchAudit(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDocumentHandler_SearchAudit_InvalidDate(t *testing.T) {
+	h, _ := newDocumentHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/audit?from=01/02/2025", http.NoBody)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.SearchAudit(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDocumentHandler_Timeline_Success(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	docID := uuid.New()
+
+	timeline := &domain.DocumentTimeline{
+		DocumentID:    docID,
+		ParsingStatus: domain.ParsingStatusCompleted,
+		ParseAttempts: 1,
+		Events:        []domain.TimelineEvent{{Type: domain.TimelineParseAttempt}},
+	}
+	mockSvc.On("GetTimeline", mock.Anything, tenantID, docID, userID, domain.UserRole("viewer")).Return(timeline, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"/timeline", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.Timeline(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"type":"parse_attempt"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_Timeline_PermissionDenied(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	docID := uuid.New()
+
+	mockSvc.On("GetTimeline", mock.Anything, tenantID, docID, userID, domain.UserRole("member")).
+		Return(nil, domain.ErrCollectionPermDenied)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"/timeline", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Timeline(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDocumentHandler_Attempts_Success(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	docID := uuid.New()
+
+	attempts := []domain.ParseTiming{
+		{DocumentID: docID, Attempt: 1, ParserModel: "claude", Outcome: domain.ParsingStatusFailed,
+			FailureCategory: domain.ParseFailureError, ErrorMessage: "parsing document: bad JSON"},
+	}
+	mockSvc.On("ListParseAttempts", mock.Anything, tenantID, docID, userID, domain.UserRole("viewer")).Return(attempts, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"/attempts", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.Attempts(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"error_message":"parsing document: bad JSON"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_RecomputeTotals_Success(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID, userID, docID := uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("RecomputeTotals", mock.Anything, tenantID, docID, userID, domain.UserRole("viewer")).Return(&service.TotalsRecomputation{
+		DocumentID: docID,
+		Deltas:     []invoice.TotalsDelta{{FieldPath: "totals.total", Stored: 112, Recomputed: 118, Delta: 6}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/documents/"+docID.String()+"/recompute", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.RecomputeTotals(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"field_path":"totals.total"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_RecomputeTotals_NotParsed(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+
+	tenantID, userID, docID := uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("RecomputeTotals", mock.Anything, tenantID, docID, userID, domain.UserRole("member")).
+		Return(nil, domain.ErrDocumentNotParsed)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/documents/"+docID.String()+"/recompute", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.RecomputeTotals(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDocumentHandler_EditStructuredData_JSONPatch(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	tenantID, userID, docID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("EditStructuredData", mock.Anything, mock.MatchedBy(func(input *service.EditStructuredDataInput) bool {
+		return input.DocumentID == docID && input.StructuredData == nil && len(input.Patch) == 2 &&
+			input.Patch[0].Op == "test" && input.Patch[1].Path == "/invoice/invoice_number" &&
+			string(input.Patch[1].Value) == `"INV-2"`
+	})).Return(&domain.Document{ID: docID}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/documents/"+docID.String()+"/structured-data", bytes.NewBufferString(
+		`[{"op":"test","path":"/invoice/invoice_number","value":"INV-1"},{"op":"replace","path":"/invoice/invoice_number","value":"INV-2"}]`))
+	c.Request.Header.Set("Content-Type", "application/json-patch+json")
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.EditStructuredData(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_EditStructuredData_JSONPatchInvalid(t *testing.T) {
+	for name, body := range map[string]string{
+		"not an array": `{"structured_data":{}}`,
+		"empty":        `[]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			h, mockSvc := newDocumentHandler()
+			docID := uuid.New()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/documents/"+docID.String()+"/structured-data", bytes.NewBufferString(body))
+			c.Request.Header.Set("Content-Type", "application/json-patch+json")
+			c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+			setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+			h.EditStructuredData(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), "INVALID_JSON_PATCH")
+			mockSvc.AssertNotCalled(t, "EditStructuredData", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDocumentHandler_EditStructuredData_JSONPatchConflict(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	docID := uuid.New()
+	mockSvc.On("EditStructuredData", mock.Anything, mock.Anything).Return(nil, domain.ErrJSONPatchConflict)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/documents/"+docID.String()+"/structured-data",
+		bytes.NewBufferString(`[{"op":"remove","path":"/line_items/9"}]`))
+	c.Request.Header.Set("Content-Type", "application/json-patch+json")
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.EditStructuredData(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "JSON_PATCH_CONFLICT")
+}
+
+func TestDocumentHandler_EditStructuredData_JSONPatchIfUpdatedAt(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	docID := uuid.New()
+	want := time.Date(2026, 10, 15, 9, 30, 0, 123456000, time.UTC)
+	mockSvc.On("EditStructuredData", mock.Anything, mock.MatchedBy(func(in *service.EditStructuredDataInput) bool {
+		return in.IfUpdatedAt != nil && in.IfUpdatedAt.Equal(want)
+	})).Return(nil, domain.ErrDocumentModified)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut,
+		"/api/v1/documents/"+docID.String()+"/structured-data?if_updated_at=2026-10-15T09:30:00.123456Z",
+		bytes.NewBufferString(`[{"op":"remove","path":"/line_items/0"}]`))
+	c.Request.Header.Set("Content-Type", "application/json-patch+json")
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.EditStructuredData(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "DOCUMENT_MODIFIED")
+	mockSvc.AssertExpectations(t)
+}
+
+// --- PatchLineItems ---
+
+func TestDocumentHandler_PatchLineItems_Success(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	tenantID, userID, docID := uuid.New(), uuid.New(), uuid.New()
+	updatedAt := time.Date(2026, 10, 15, 9, 30, 0, 123456000, time.UTC)
+
+	mockSvc.On("PatchLineItems", mock.Anything, mock.MatchedBy(func(input *service.PatchLineItemsInput) bool {
+		return input.TenantID == tenantID && input.DocumentID == docID && input.UserID == userID &&
+			input.IfUpdatedAt != nil && input.IfUpdatedAt.Equal(updatedAt) &&
+			len(input.Operations) == 2 &&
+			input.Operations[0].Op == domain.LineItemOpUpdate && *input.Operations[0].Index == 1 &&
+			string(input.Operations[0].Fields["quantity"]) == "3" &&
+			input.Operations[1].Op == domain.LineItemOpDelete
+	})).Return(&domain.Document{ID: docID}, nil)
+
+	w, resp := postJSON(t, "/api/v1/documents/"+docID.String()+"/line-items",
+		`{"if_updated_at":"2026-10-15T09:30:00.123456Z","operations":[{"op":"update","index":1,"fields":{"quantity":3}},{"op":"delete","index":0}]}`,
+		func(c *gin.Context) {
+			c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+			setAuthContext(c, tenantID, userID, "member")
+		}, h.PatchLineItems)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, resp.Success)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_PatchLineItems_MissingOperations(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	docID := uuid.New()
+
+	w, _ := postJSON(t, "/api/v1/documents/"+docID.String()+"/line-items", `{}`,
+		func(c *gin.Context) {
+			c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+			setAuthContext(c, uuid.New(), uuid.New(), "member")
+		}, h.PatchLineItems)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "PatchLineItems", mock.Anything, mock.Anything)
+}
+
+func TestDocumentHandler_PatchLineItems_Errors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+		body string
+	}{
+		{domain.ErrDocumentModified, http.StatusConflict, "DOCUMENT_MODIFIED"},
+		{domain.ErrInvalidLineItemPatch, http.StatusBadRequest, "INVALID_LINE_ITEM_PATCH"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			h, mockSvc := newDocumentHandler()
+			docID := uuid.New()
+			mockSvc.On("PatchLineItems", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			w, _ := postJSON(t, "/api/v1/documents/"+docID.String()+"/line-items", `{"operations":[{"op":"delete","index":0}]}`,
+				func(c *gin.Context) {
+					c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+					setAuthContext(c, uuid.New(), uuid.New(), "member")
+				}, h.PatchLineItems)
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.Contains(t, w.Body.String(), tt.body)
+		})
+	}
+}
+
+// --- ReplaceFile ---
+
+func TestDocumentHandler_ReplaceFile_Success(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	tenantID, userID, docID, fileID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("ReplaceFile", mock.Anything, &service.ReplaceFileInput{
+		TenantID: tenantID, DocumentID: docID, FileID: fileID, UserID: userID, Role: domain.UserRole("member"),
+	}).Return(&domain.Document{ID: docID, FileID: fileID, Version: 2}, nil)
+
+	w, resp := postJSON(t, "/api/v1/documents/"+docID.String()+"/replace-file", `{"file_id":"`+fileID.String()+`"}`,
+		func(c *gin.Context) {
+			c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+			setAuthContext(c, tenantID, userID, "member")
+		}, h.ReplaceFile)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, resp.Success)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_ReplaceFile_ParseInProgress(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	tenantID, userID, docID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("ReplaceFile", mock.Anything, mock.Anything).Return(nil, domain.ErrDocumentParseInProgress)
+
+	w, resp := postJSON(t, "/api/v1/documents/"+docID.String()+"/replace-file", `{"file_id":"`+uuid.New().String()+`"}`,
+		func(c *gin.Context) {
+			c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+			setAuthContext(c, tenantID, userID, "member")
+		}, h.ReplaceFile)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "DOCUMENT_PARSE_IN_PROGRESS", resp.Error.Code)
+}
+
+func TestDocumentHandler_ReplaceFile_MissingFileID(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	docID := uuid.New()
+
+	w, _ := postJSON(t, "/api/v1/documents/"+docID.String()+"/replace-file", `{}`,
+		func(c *gin.Context) {
+			c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+			setAuthContext(c, uuid.New(), uuid.New(), "member")
+		}, h.ReplaceFile)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "ReplaceFile", mock.Anything, mock.Anything)
+}
+
+// --- Reclassify ---
+
+func TestDocumentHandler_Reclassify_Success(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	tenantID, userID, docID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("Reclassify", mock.Anything, &service.ReclassifyInput{
+		TenantID: tenantID, DocumentID: docID, DocumentType: "credit_note", UserID: userID, Role: domain.UserRole("member"),
+	}).Return(&domain.Document{ID: docID, DocumentType: "credit_note", Version: 2}, nil)
+
+	w, resp := postJSON(t, "/api/v1/documents/"+docID.String()+"/reclassify", `{"document_type":"credit_note"}`,
+		func(c *gin.Context) {
+			c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+			setAuthContext(c, tenantID, userID, "member")
+		}, h.Reclassify)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, resp.Success)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentHandler_Reclassify_SameType(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	docID := uuid.New()
+
+	mockSvc.On("Reclassify", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidDocumentType)
+
+	w, resp := postJSON(t, "/api/v1/documents/"+docID.String()+"/reclassify", `{"document_type":"invoice"}`,
+		func(c *gin.Context) {
+			c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+			setAuthContext(c, uuid.New(), uuid.New(), "member")
+		}, h.Reclassify)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, "INVALID_DOCUMENT_TYPE", resp.Error.Code)
+}
+
+func TestDocumentHandler_Reclassify_MissingType(t *testing.T) {
+	h, mockSvc := newDocumentHandler()
+	docID := uuid.New()
+
+	w, _ := postJSON(t, "/api/v1/documents/"+docID.String()+"/reclassify", `{}`,
+		func(c *gin.Context) {
+			c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+			setAuthContext(c, uuid.New(), uuid.New(), "member")
+		}, h.Reclassify)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Reclassify", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,62 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/mocks"
+)
+
+func TestDocumentLockHandler_Acquire_TTL(t *testing.T) {
+	lockSvc := new(mocks.MockDocumentLockService)
+	h := handler.NewDocumentLockHandler(lockSvc)
+	tenantID, userID, docID := uuid.New(), uuid.New(), uuid.New()
+	lockSvc.On("Acquire", mock.Anything, tenantID, docID, userID, domain.RoleMember, 60*time.Second).
+		Return(&domain.DocumentLock{DocumentID: docID, UserID: userID, HolderName: "Ravi"}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/documents/"+docID.String()+"/lock",
+		bytes.NewBufferString(`{"ttl_seconds":60}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Acquire(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"holder_name":"Ravi"`)
+	lockSvc.AssertExpectations(t)
+}
+
+func TestDocumentLockHandler_Acquire_HeldByOther(t *testing.T) {
+	lockSvc := new(mocks.MockDocumentLockService)
+	h := handler.NewDocumentLockHandler(lockSvc)
+	docID := uuid.New()
+	lockSvc.On("Acquire", mock.Anything, mock.Anything, docID, mock.Anything, mock.Anything, time.Duration(0)).
+		Return(&domain.DocumentLock{DocumentID: docID, UserID: uuid.New(), HolderName: "Ravi", ExpiresAt: time.Now().Add(time.Minute)},
+			domain.ErrDocumentLocked)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/documents/"+docID.String()+"/lock", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: docID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.Acquire(c)
+
+	assert.Equal(t, http.StatusLocked, w.Code)
+	assert.Contains(t, w.Body.String(), "DOCUMENT_LOCKED")
+	assert.Contains(t, w.Body.String(), "being reviewed by Ravi")
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+}
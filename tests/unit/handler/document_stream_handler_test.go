@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestDocumentStreamHandler_Stream(t *testing.T) {
+	mockSvc := new(mocks.MockDocumentStreamService)
+	h := handler.NewDocumentStreamHandler(mockSvc)
+	tenantID, userID, docID, deletedID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mockSvc.Records = []service.DocumentStreamRecord{
+		{Type: service.DocumentStreamDocument, Cursor: "c1", ID: &docID, Document: &domain.Document{ID: docID, Name: "inv.pdf"}},
+		{Type: service.DocumentStreamTombstone, Cursor: "c2", ID: &deletedID},
+		{Type: service.DocumentStreamEnd, Cursor: "c2"},
+	}
+	mockSvc.On("Stream", mock.Anything, mock.MatchedBy(func(in *service.StreamDocumentsInput) bool {
+		return in.TenantID == tenantID && in.Since == "c0" && in.Limit == 50
+	})).Return(nil)
+
+	c, w := integrationContext(http.MethodGet, "/api/v1/documents/stream?since=c0&limit=50", "")
+	setAuthContext(c, tenantID, userID, "admin")
+
+	h.Stream(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	var lines []service.DocumentStreamRecord
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var rec service.DocumentStreamRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &rec))
+		lines = append(lines, rec)
+	}
+	require.Len(t, lines, 3)
+	assert.Equal(t, "inv.pdf", lines[0].Document.Name)
+	assert.Equal(t, service.DocumentStreamTombstone, lines[1].Type)
+	assert.Equal(t, deletedID, *lines[1].ID)
+	assert.Equal(t, service.DocumentStreamEnd, lines[2].Type)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDocumentStreamHandler_Stream_InvalidCursor(t *testing.T) {
+	mockSvc := new(mocks.MockDocumentStreamService)
+	h := handler.NewDocumentStreamHandler(mockSvc)
+	mockSvc.On("Stream", mock.Anything, mock.Anything).Return(domain.ErrInvalidCursor)
+
+	c, w := integrationContext(http.MethodGet, "/api/v1/documents/stream?since=garbage", "")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Stream(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_CURSOR")
+}
@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/apierror"
+	"satvos/internal/handler"
+)
+
+func TestErrorCatalogueHandler_List(t *testing.T) {
+	h := handler.NewErrorCatalogueHandler()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/errors", http.NoBody)
+
+	h.List(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []apierror.Entry `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, len(apierror.Catalogue()))
+}
+
+func TestErrorCatalogueHandler_Get(t *testing.T) {
+	h := handler.NewErrorCatalogueHandler()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/errors/maintenance", http.NoBody)
+	c.Params = gin.Params{{Key: "code", Value: "maintenance"}}
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"MAINTENANCE"`)
+	assert.Contains(t, w.Body.String(), `"retryable":true`)
+}
+
+func TestErrorCatalogueHandler_Get_Unknown(t *testing.T) {
+	h := handler.NewErrorCatalogueHandler()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/errors/NOPE", http.NoBody)
+	c.Params = gin.Params{{Key: "code", Value: "NOPE"}}
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
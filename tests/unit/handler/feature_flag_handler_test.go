@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/mocks"
+)
+
+func TestFeatureFlagHandler_List_Success(t *testing.T) {
+	mockSvc := new(mocks.MockFeatureFlagService)
+	h := handler.NewFeatureFlagHandler(mockSvc)
+	tenantID := uuid.New()
+
+	mockSvc.On("List", mock.Anything, tenantID).Return([]domain.TenantFeatureFlag{
+		{TenantID: tenantID, Flag: domain.FlagDualParse, Enabled: true},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/tenants/"+tenantID.String()+"/flags", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: tenantID.String()}}
+
+	h.List(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "dual_parse")
+}
+
+func TestFeatureFlagHandler_Set_Success(t *testing.T) {
+	mockSvc := new(mocks.MockFeatureFlagService)
+	h := handler.NewFeatureFlagHandler(mockSvc)
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	mockSvc.On("Set", mock.Anything, tenantID, domain.FlagAutoApproval, false, userID).
+		Return(&domain.TenantFeatureFlag{TenantID: tenantID, Flag: domain.FlagAutoApproval, Overridden: true}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/admin/tenants/"+tenantID.String()+"/flags/auto_approval",
+		bytes.NewBufferString(`{"enabled":false}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: tenantID.String()}, {Key: "flag", Value: "auto_approval"}}
+	setAuthContext(c, uuid.New(), userID, "admin")
+
+	h.Set(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestFeatureFlagHandler_Set_MissingEnabled(t *testing.T) {
+	h := handler.NewFeatureFlagHandler(new(mocks.MockFeatureFlagService))
+	tenantID := uuid.New()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/admin/tenants/"+tenantID.String()+"/flags/dual_parse",
+		bytes.NewBufferString(`{}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: tenantID.String()}, {Key: "flag", Value: "dual_parse"}}
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Set(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFeatureFlagHandler_Reset_UnknownFlag(t *testing.T) {
+	mockSvc := new(mocks.MockFeatureFlagService)
+	h := handler.NewFeatureFlagHandler(mockSvc)
+	tenantID := uuid.New()
+
+	mockSvc.On("Reset", mock.Anything, tenantID, domain.FeatureFlag("bogus")).Return(domain.ErrUnknownFeatureFlag)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/admin/tenants/"+tenantID.String()+"/flags/bogus", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: tenantID.String()}, {Key: "flag", Value: "bogus"}}
+
+	h.Reset(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "UNKNOWN_FEATURE_FLAG")
+}
@@ -3,6 +3,7 @@ package handler_test
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
@@ -16,6 +17,7 @@ import (
 	"satvos/internal/domain"
 	"satvos/internal/handler"
 	"satvos/internal/middleware"
+	"satvos/internal/port"
 	"satvos/mocks"
 )
 
@@ -133,7 +135,7 @@ func TestFileHandler_List_Success(t *testing.T) {
 
 func TestFileHandler_GetByID_Success(t *testing.T) {
 	mockFileSvc := new(mocks.MockFileService)
-	h := handler.NewFileHandler(mockFileSvc, nil)
+	h := handler.NewFileHandler(mockFileSvc, openDownloads())
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -202,6 +204,125 @@ func TestFileHandler_GetByID_InvalidID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestFileHandler_Download_StreamsContent(t *testing.T) {
+	mockFileSvc := new(mocks.MockFileService)
+	h := handler.NewFileHandler(mockFileSvc, openDownloads())
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	fileID := uuid.New()
+
+	meta := &domain.FileMeta{
+		ID:           fileID,
+		TenantID:     tenantID,
+		UploadedBy:   userID,
+		OriginalName: "invoice march.pdf",
+		ContentType:  "application/pdf",
+	}
+	content := []byte("%PDF-1.4 streamed")
+
+	mockFileSvc.On("GetByID", mock.Anything, tenantID, fileID).Return(meta, nil)
+	mockFileSvc.On("OpenContent", mock.Anything, tenantID, fileID).Return(meta, &port.ObjectReader{
+		Body: io.NopCloser(bytes.NewReader(content)),
+		Size: int64(len(content)),
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/files/"+fileID.String()+"/download", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: fileID.String()}}
+	setAuthContext(c, tenantID, userID, "free")
+
+	h.Download(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, content, w.Body.Bytes())
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, "17", w.Header().Get("Content-Length"))
+	assert.Equal(t, `attachment; filename="invoice march.pdf"`, w.Header().Get("Content-Disposition"))
+	mockFileSvc.AssertExpectations(t)
+}
+
+func TestFileHandler_Download_FreeUserOtherFile(t *testing.T) {
+	mockFileSvc := new(mocks.MockFileService)
+	h := handler.NewFileHandler(mockFileSvc, nil)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	fileID := uuid.New()
+
+	meta := &domain.FileMeta{ID: fileID, TenantID: tenantID, UploadedBy: uuid.New()}
+	mockFileSvc.On("GetByID", mock.Anything, tenantID, fileID).Return(meta, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/files/"+fileID.String()+"/download", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: fileID.String()}}
+	setAuthContext(c, tenantID, userID, "free")
+
+	h.Download(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockFileSvc.AssertNotCalled(t, "OpenContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFileHandler_GetByID_DownloadRestricted(t *testing.T) {
+	mockFileSvc := new(mocks.MockFileService)
+	mockCollSvc := new(mocks.MockCollectionService)
+	h := handler.NewFileHandler(mockFileSvc, mockCollSvc)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	fileID := uuid.New()
+
+	mockFileSvc.On("GetByID", mock.Anything, tenantID, fileID).Return(&domain.FileMeta{ID: fileID, TenantID: tenantID}, nil)
+	mockCollSvc.On("CheckFileDownload", mock.Anything, tenantID, fileID, userID, domain.RoleViewer).
+		Return(domain.ErrFileDownloadRestricted)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/files/"+fileID.String(), http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: fileID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.GetByID(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, true, resp.Data["download_restricted"])
+	assert.NotContains(t, resp.Data, "download_url")
+	mockFileSvc.AssertNotCalled(t, "GetDownloadURL", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFileHandler_Download_Restricted(t *testing.T) {
+	mockFileSvc := new(mocks.MockFileService)
+	mockCollSvc := new(mocks.MockCollectionService)
+	h := handler.NewFileHandler(mockFileSvc, mockCollSvc)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	fileID := uuid.New()
+
+	mockFileSvc.On("GetByID", mock.Anything, tenantID, fileID).Return(&domain.FileMeta{ID: fileID, TenantID: tenantID}, nil)
+	mockCollSvc.On("CheckFileDownload", mock.Anything, tenantID, fileID, userID, domain.RoleMember).
+		Return(domain.ErrFileDownloadRestricted)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/files/"+fileID.String()+"/download", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: fileID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Download(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "FILE_DOWNLOAD_RESTRICTED")
+	mockFileSvc.AssertNotCalled(t, "OpenContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestFileHandler_Delete_Success(t *testing.T) {
 	mockFileSvc := new(mocks.MockFileService)
 	h := handler.NewFileHandler(mockFileSvc, nil)
@@ -0,0 +1,68 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func newPreflightContext(fileID string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/files/"+fileID+"/preflight", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: fileID}}
+	setAuthContext(c, uuid.New(), uuid.New(), "free")
+	return c, w
+}
+
+func TestFilePreflightHandler_Preflight(t *testing.T) {
+	mockSvc := new(mocks.MockFilePreflightService)
+	h := handler.NewFilePreflightHandler(mockSvc)
+	fileID := uuid.New()
+	mockSvc.On("Preflight", mock.Anything, mock.MatchedBy(func(in *service.FilePreflightInput) bool {
+		return in.FileID == fileID && in.Role == domain.RoleFree
+	})).Return(&domain.FilePreflight{
+		FileID:     fileID,
+		Difficulty: service.PreflightDifficultyHard,
+		Warnings:   []string{service.PreflightWarningBlurry},
+	}, nil)
+
+	c, w := newPreflightContext(fileID.String())
+	h.Preflight(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"warnings":["blurry"]`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestFilePreflightHandler_InvalidID(t *testing.T) {
+	mockSvc := new(mocks.MockFilePreflightService)
+	h := handler.NewFilePreflightHandler(mockSvc)
+
+	c, w := newPreflightContext("not-a-uuid")
+	h.Preflight(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Preflight", mock.Anything, mock.Anything)
+}
+
+func TestFilePreflightHandler_NotFound(t *testing.T) {
+	mockSvc := new(mocks.MockFilePreflightService)
+	h := handler.NewFilePreflightHandler(mockSvc)
+	mockSvc.On("Preflight", mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound)
+
+	c, w := newPreflightContext(uuid.New().String())
+	h.Preflight(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
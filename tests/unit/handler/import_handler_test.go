@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestImportHandler_ImportZip_Accepted(t *testing.T) {
+	mockSvc := new(mocks.MockImportService)
+	h := handler.NewImportHandler(mockSvc)
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("ImportZip", mock.Anything, mock.MatchedBy(func(in *service.ZipImportInput) bool {
+		return in.CollectionID == collectionID && in.DocumentType == "invoice" &&
+			in.ParseMode == domain.ParseModeSingle && in.Header.Filename == "batch.zip"
+	})).Return(&domain.ImportJob{ID: uuid.New(), Status: domain.ImportStatusPending}, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipartWriter(body)
+	part, _ := writer.CreateFormFile("file", "batch.zip")
+	_, _ = part.Write([]byte("PK\x03\x04"))
+	_ = writer.WriteField("document_type", "invoice")
+	_ = writer.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/collections/"+collectionID.String()+"/imports", body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ImportZip(c)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestImportHandler_ImportZip_MissingDocumentType(t *testing.T) {
+	mockSvc := new(mocks.MockImportService)
+	h := handler.NewImportHandler(mockSvc)
+	collectionID := uuid.New()
+
+	body := &bytes.Buffer{}
+	writer := multipartWriter(body)
+	part, _ := writer.CreateFormFile("file", "batch.zip")
+	_, _ = part.Write([]byte("PK\x03\x04"))
+	_ = writer.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/collections/"+collectionID.String()+"/imports", body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.ImportZip(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "ImportZip", mock.Anything, mock.Anything)
+}
+
+func TestImportHandler_ImportS3Prefix_InvalidPrefix(t *testing.T) {
+	mockSvc := new(mocks.MockImportService)
+	h := handler.NewImportHandler(mockSvc)
+	collectionID := uuid.New()
+
+	mockSvc.On("ImportS3Prefix", mock.Anything, mock.AnythingOfType("*service.S3ImportInput")).
+		Return(nil, domain.ErrInvalidImportPrefix)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/collections/"+collectionID.String()+"/imports/s3",
+		bytes.NewBufferString(`{"prefix":"../other","document_type":"invoice"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.ImportS3Prefix(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_IMPORT_PREFIX")
+}
+
+func TestImportHandler_Get_NotFound(t *testing.T) {
+	mockSvc := new(mocks.MockImportService)
+	h := handler.NewImportHandler(mockSvc)
+	tenantID, userID, collectionID, jobID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("GetJob", mock.Anything, tenantID, collectionID, jobID, userID, domain.RoleViewer).
+		Return(nil, domain.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}, {Key: "importId", Value: jobID.String()}}
+	setAuthContext(c, tenantID, userID, "viewer")
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
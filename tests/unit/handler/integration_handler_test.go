@@ -0,0 +1,153 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func newIntegrationHandler() (*handler.IntegrationHandler, *mocks.MockIntegrationService) {
+	mockSvc := new(mocks.MockIntegrationService)
+	return handler.NewIntegrationHandler(mockSvc), mockSvc
+}
+
+func integrationContext(method, target, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(method, target, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func TestIntegrationHandler_ListApproved(t *testing.T) {
+	h, mockSvc := newIntegrationHandler()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	docID := uuid.New()
+	mockSvc.On("ListApproved", mock.Anything, mock.MatchedBy(func(in *service.ListApprovedInput) bool {
+		return in.TenantID == tenantID && in.Cursor == "abc" && in.Limit == 20 &&
+			in.CollectionID != nil && *in.CollectionID == collectionID
+	})).Return(&service.ApprovedDocumentsPage{Items: []service.FlatDocument{{ID: docID, InvoiceNumber: "INV-7"}}, NextCursor: "def"}, nil)
+
+	c, w := integrationContext(http.MethodGet,
+		"/api/v1/integrations/documents/approved?cursor=abc&limit=20&collection_id="+collectionID.String(), "")
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ListApproved(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data service.ApprovedDocumentsPage `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "def", resp.Data.NextCursor)
+	assert.Equal(t, "INV-7", resp.Data.Items[0].InvoiceNumber)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestIntegrationHandler_ListApproved_InvalidCursor(t *testing.T) {
+	h, mockSvc := newIntegrationHandler()
+	mockSvc.On("ListApproved", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidCursor)
+
+	c, w := integrationContext(http.MethodGet, "/api/v1/integrations/documents/approved?cursor=bad", "")
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.ListApproved(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_CURSOR")
+}
+
+func TestIntegrationHandler_Subscribe(t *testing.T) {
+	h, mockSvc := newIntegrationHandler()
+	tenantID, userID := uuid.New(), uuid.New()
+	mockSvc.On("Subscribe", mock.Anything, mock.MatchedBy(func(in *service.SubscribeHookInput) bool {
+		return in.TenantID == tenantID && in.UserID == userID && in.TargetURL == "https://hooks.zapier.com/x"
+	})).Return(&domain.IntegrationHook{ID: uuid.New(), TenantID: tenantID, UserID: userID}, nil)
+
+	c, w := integrationContext(http.MethodPost, "/api/v1/integrations/hooks",
+		`{"target_url":"https://hooks.zapier.com/x","event":"document.approved"}`)
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Subscribe(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestIntegrationHandler_Subscribe_InvalidTarget(t *testing.T) {
+	h, mockSvc := newIntegrationHandler()
+	mockSvc.On("Subscribe", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidHookTarget)
+
+	c, w := integrationContext(http.MethodPost, "/api/v1/integrations/hooks",
+		`{"target_url":"http://10.0.0.1/x","event":"document.approved"}`)
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.Subscribe(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_HOOK_TARGET")
+}
+
+func TestIntegrationHandler_Unsubscribe_NotFound(t *testing.T) {
+	h, mockSvc := newIntegrationHandler()
+	tenantID, userID, hookID := uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("Unsubscribe", mock.Anything, tenantID, hookID, userID, domain.UserRole("member")).Return(domain.ErrNotFound)
+
+	c, w := integrationContext(http.MethodDelete, "/api/v1/integrations/hooks/"+hookID.String(), "")
+	c.Params = gin.Params{{Key: "id", Value: hookID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Unsubscribe(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestIntegrationHandler_SetHookTemplate(t *testing.T) {
+	h, mockSvc := newIntegrationHandler()
+	tenantID, userID, hookID := uuid.New(), uuid.New(), uuid.New()
+	tmpl := `{"bill_no": {{json .InvoiceNumber}}}`
+	mockSvc.On("SetHookTemplate", mock.Anything, tenantID, hookID, userID, domain.UserRole("member"),
+		mock.MatchedBy(func(in *service.SetHookTemplateInput) bool {
+			return in.PayloadTemplate != nil && *in.PayloadTemplate == tmpl
+		})).Return(&domain.IntegrationHook{ID: hookID, PayloadTemplate: &tmpl}, nil)
+
+	body, _ := json.Marshal(map[string]string{"payload_template": tmpl})
+	c, w := integrationContext(http.MethodPut, "/api/v1/integrations/hooks/"+hookID.String()+"/template", string(body))
+	c.Params = gin.Params{{Key: "id", Value: hookID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.SetHookTemplate(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"payload_template"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestIntegrationHandler_SetHookTemplate_Invalid(t *testing.T) {
+	h, mockSvc := newIntegrationHandler()
+	hookID := uuid.New()
+	mockSvc.On("SetHookTemplate", mock.Anything, mock.Anything, hookID, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, domain.ErrInvalidHookTemplate)
+
+	c, w := integrationContext(http.MethodPut, "/api/v1/integrations/hooks/"+hookID.String()+"/template", `{"payload_template": "x={{.Total}}"}`)
+	c.Params = gin.Params{{Key: "id", Value: hookID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.SetHookTemplate(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_HOOK_TEMPLATE")
+}
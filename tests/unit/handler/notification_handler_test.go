@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func newNotificationHandler() (*handler.NotificationHandler, *mocks.MockNotificationService) {
+	mockSvc := new(mocks.MockNotificationService)
+	return handler.NewNotificationHandler(mockSvc), mockSvc
+}
+
+func notificationContext(method, collectionID, channelID, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(method, "/api/v1/collections/"+collectionID+"/notification-channels", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID}}
+	if channelID != "" {
+		c.Params = append(c.Params, gin.Param{Key: "channelId", Value: channelID})
+	}
+	return c, w
+}
+
+func TestNotificationHandler_Create(t *testing.T) {
+	h, mockSvc := newNotificationHandler()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("CreateChannel", mock.Anything, tenantID, collectionID, userID, domain.UserRole("member"),
+		mock.MatchedBy(func(in *service.NotificationChannelInput) bool {
+			return in.Provider == domain.NotificationProviderSlack && len(in.Events) == 1 &&
+				in.Templates[domain.NotificationEventParseFailed] == "Failed: {{.Document.Name}}"
+		})).Return(&domain.NotificationChannel{ID: uuid.New(), WebhookHost: "hooks.slack.com"}, nil)
+
+	c, w := notificationContext(http.MethodPost, collectionID.String(), "",
+		`{"provider":"slack","webhook_url":"https://hooks.slack.com/services/x","events":["parse_failed"],
+		  "templates":{"parse_failed":"Failed: {{.Document.Name}}"}}`)
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NotContains(t, w.Body.String(), "services/x")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestNotificationHandler_Create_InvalidTemplate(t *testing.T) {
+	h, mockSvc := newNotificationHandler()
+	mockSvc.On("CreateChannel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, domain.ErrInvalidNotificationTemplate)
+
+	c, w := notificationContext(http.MethodPost, uuid.New().String(), "",
+		`{"provider":"slack","webhook_url":"https://hooks.slack.com/services/x","events":["parse_failed"]}`)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Create(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_NOTIFICATION_TEMPLATE")
+}
+
+func TestNotificationHandler_Test_EmptyBody(t *testing.T) {
+	h, mockSvc := newNotificationHandler()
+	tenantID, userID, collectionID, channelID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("TestChannel", mock.Anything, tenantID, collectionID, channelID, userID, domain.UserRole("admin"),
+		domain.NotificationEvent("")).Return(nil)
+
+	c, w := notificationContext(http.MethodPost, collectionID.String(), channelID.String(), "")
+	setAuthContext(c, tenantID, userID, "admin")
+
+	h.Test(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestNotificationHandler_Test_DeliveryFailed(t *testing.T) {
+	h, mockSvc := newNotificationHandler()
+	mockSvc.On("TestChannel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		domain.NotificationEventWeeklySummary).Return(domain.ErrNotificationDeliveryFailed)
+
+	c, w := notificationContext(http.MethodPost, uuid.New().String(), uuid.New().String(), `{"event":"weekly_summary"}`)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Test(c)
+
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Contains(t, w.Body.String(), "NOTIFICATION_DELIVERY_FAILED")
+}
+
+func TestNotificationHandler_Delete_InvalidChannelID(t *testing.T) {
+	h, mockSvc := newNotificationHandler()
+
+	c, w := notificationContext(http.MethodDelete, uuid.New().String(), "not-a-uuid", "")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Delete(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "DeleteChannel")
+}
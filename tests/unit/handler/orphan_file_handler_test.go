@@ -0,0 +1,125 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestOrphanFileHandler_Summary_DefaultsToSevenDays(t *testing.T) {
+	mockSvc := new(mocks.MockOrphanFileService)
+	h := handler.NewOrphanFileHandler(mockSvc)
+	tenantID := uuid.New()
+
+	mockSvc.On("Summary", mock.Anything, tenantID, 7).
+		Return([]domain.OrphanFileSummary{{CollectionID: uuid.New(), CollectionName: "Invoices", FileCount: 3}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/reports/orphan-files", http.NoBody)
+	setAuthContext(c, tenantID, uuid.New(), "manager")
+
+	h.Summary(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"file_count":3`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestOrphanFileHandler_List_RejectsBadDays(t *testing.T) {
+	for _, days := range []string{"-1", "366", "week"} {
+		t.Run(days, func(t *testing.T) {
+			mockSvc := new(mocks.MockOrphanFileService)
+			h := handler.NewOrphanFileHandler(mockSvc)
+			collectionID := uuid.New()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/orphan-files?days="+days, http.NoBody)
+			c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+			setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+			h.List(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockSvc.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrphanFileHandler_List_Paginates(t *testing.T) {
+	mockSvc := new(mocks.MockOrphanFileService)
+	h := handler.NewOrphanFileHandler(mockSvc)
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("List", mock.Anything, tenantID, collectionID, userID, domain.RoleMember, 30, 0, 20).
+		Return([]domain.FileMeta{{ID: uuid.New()}}, 1, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/orphan-files?days=30", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.List(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestOrphanFileHandler_CreateDocuments(t *testing.T) {
+	mockSvc := new(mocks.MockOrphanFileService)
+	h := handler.NewOrphanFileHandler(mockSvc)
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	mockSvc.On("CreateDocuments", mock.Anything, mock.MatchedBy(func(in *service.OrphanDocumentsInput) bool {
+		return in.CollectionID == collectionID && in.DocumentType == "invoice" &&
+			in.ParseMode == domain.ParseModeSingle && in.OlderThanDays == 0
+	})).Return(&domain.OrphanDocumentRun{Matched: 2, Created: 2, Entries: []domain.ImportEntry{}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/collections/"+collectionID.String()+"/orphan-files/documents",
+		bytes.NewBufferString(`{"document_type":"invoice","days":0}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.CreateDocuments(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"created":2`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestOrphanFileHandler_CreateDocuments_PermissionDenied(t *testing.T) {
+	mockSvc := new(mocks.MockOrphanFileService)
+	h := handler.NewOrphanFileHandler(mockSvc)
+	collectionID := uuid.New()
+
+	mockSvc.On("CreateDocuments", mock.Anything, mock.Anything).Return(nil, domain.ErrCollectionPermDenied)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/collections/"+collectionID.String()+"/orphan-files/documents",
+		bytes.NewBufferString(`{"document_type":"invoice"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "viewer")
+
+	h.CreateDocuments(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func newPageImageContext(fileID, page, query string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/files/"+fileID+"/pages/"+page+"/image"+query, http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: fileID}, {Key: "n", Value: page}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+	return c, w
+}
+
+// openDownloads is a collection service under which every file may be downloaded.
+func openDownloads() *mocks.MockCollectionService {
+	collSvc := new(mocks.MockCollectionService)
+	collSvc.On("CheckFileDownload", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return collSvc
+}
+
+func TestPageImageHandler_GetPageImage(t *testing.T) {
+	mockSvc := new(mocks.MockPageImageService)
+	h := handler.NewPageImageHandler(mockSvc, openDownloads())
+	fileID := uuid.New()
+	mockSvc.On("GetPageImage", mock.Anything, mock.MatchedBy(func(in *service.PageImageInput) bool {
+		return in.FileID == fileID && in.Page == 3 && in.DPI == 200 && in.Role == domain.RoleMember
+	})).Return([]byte("png-bytes"), nil)
+
+	c, w := newPageImageContext(fileID.String(), "3", "?dpi=200")
+	h.GetPageImage(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, "png-bytes", w.Body.String())
+	mockSvc.AssertExpectations(t)
+}
+
+func TestPageImageHandler_InvalidPage(t *testing.T) {
+	mockSvc := new(mocks.MockPageImageService)
+	h := handler.NewPageImageHandler(mockSvc, openDownloads())
+
+	for _, page := range []string{"0", "abc"} {
+		c, w := newPageImageContext(uuid.New().String(), page, "")
+		h.GetPageImage(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code, page)
+		assert.Contains(t, w.Body.String(), "INVALID_PAGE")
+	}
+	mockSvc.AssertNotCalled(t, "GetPageImage", mock.Anything, mock.Anything)
+}
+
+func TestPageImageHandler_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{domain.ErrPageNotFound, http.StatusNotFound, "PAGE_NOT_FOUND"},
+		{domain.ErrInvalidDPI, http.StatusBadRequest, "INVALID_DPI"},
+		{domain.ErrPageRenderUnavailable, http.StatusServiceUnavailable, "PAGE_RENDER_UNAVAILABLE"},
+	}
+	for _, tt := range tests {
+		mockSvc := new(mocks.MockPageImageService)
+		h := handler.NewPageImageHandler(mockSvc, openDownloads())
+		mockSvc.On("GetPageImage", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+		c, w := newPageImageContext(uuid.New().String(), "1", "")
+		h.GetPageImage(c)
+
+		assert.Equal(t, tt.status, w.Code, tt.code)
+		assert.Contains(t, w.Body.String(), tt.code)
+	}
+}
+
+func TestPageImageHandler_DownloadRestricted(t *testing.T) {
+	mockSvc := new(mocks.MockPageImageService)
+	collSvc := new(mocks.MockCollectionService)
+	h := handler.NewPageImageHandler(mockSvc, collSvc)
+	fileID := uuid.New()
+	collSvc.On("CheckFileDownload", mock.Anything, mock.Anything, fileID, mock.Anything, domain.RoleMember).
+		Return(domain.ErrFileDownloadRestricted)
+
+	c, w := newPageImageContext(fileID.String(), "1", "")
+	h.GetPageImage(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "FILE_DOWNLOAD_RESTRICTED")
+	mockSvc.AssertNotCalled(t, "GetPageImage", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,98 @@
+package handler_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func newPreviewRequest(t *testing.T, fields map[string]string, fileName string, content []byte) (*httptest.ResponseRecorder, *gin.Context) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for k, v := range fields {
+		_ = mw.WriteField(k, v)
+	}
+	if fileName != "" {
+		part, _ := mw.CreateFormFile("file", fileName)
+		_, _ = part.Write(content)
+	}
+	_ = mw.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/parse/preview", &body)
+	c.Request.Header.Set("Content-Type", mw.FormDataContentType())
+	return w, c
+}
+
+func TestParsePreviewHandler_Preview(t *testing.T) {
+	mockSvc := new(mocks.MockParsePreviewService)
+	h := handler.NewParsePreviewHandler(mockSvc)
+	tenantID, userID := uuid.New(), uuid.New()
+	mockSvc.On("Preview", mock.Anything, mock.MatchedBy(func(in *service.ParsePreviewInput) bool {
+		return in.TenantID == tenantID && in.UserID == userID && in.FileName == "inv.pdf" &&
+			in.DocumentType == "invoice" && string(in.Content) == "%PDF-1.4"
+	})).Return(&service.ParsePreviewResult{DocumentType: "invoice", ParserModel: "m"}, nil)
+
+	w, c := newPreviewRequest(t, nil, "inv.pdf", []byte("%PDF-1.4"))
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Preview(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"parser_model":"m"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestParsePreviewHandler_Preview_MissingFile(t *testing.T) {
+	h := handler.NewParsePreviewHandler(new(mocks.MockParsePreviewService))
+
+	w, c := newPreviewRequest(t, map[string]string{"document_type": "invoice"}, "", nil)
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.Preview(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "MISSING_FILE")
+}
+
+func TestParsePreviewHandler_Preview_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"quota", domain.ErrQuotaExceeded, http.StatusTooManyRequests, "QUOTA_EXCEEDED"},
+		{"unparseable", domain.ErrParseFailed, http.StatusUnprocessableEntity, "PARSE_FAILED"},
+		{"parser busy", domain.ErrParserUnavailable, http.StatusServiceUnavailable, "PARSER_UNAVAILABLE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.MockParsePreviewService)
+			h := handler.NewParsePreviewHandler(mockSvc)
+			mockSvc.On("Preview", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			w, c := newPreviewRequest(t, nil, "inv.png", []byte{0x89})
+			setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+			h.Preview(c)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestQAReviewHandler_SubmitVerdict(t *testing.T) {
+	qaSvc := new(mocks.MockQAReviewService)
+	h := handler.NewQAReviewHandler(qaSvc)
+	tenantID, userID, reviewID := uuid.New(), uuid.New(), uuid.New()
+	disagreed := true
+	qaSvc.On("SubmitVerdict", mock.Anything, mock.MatchedBy(func(in *service.SubmitQAVerdictInput) bool {
+		return in.ReviewID == reviewID && in.UserID == userID && in.Decision == domain.ReviewStatusRejected &&
+			len(in.DisputedFields) == 1 && in.DisputedFields[0] == "seller.gstin"
+	})).Return(&domain.QAReview{ID: reviewID, Status: domain.QAReviewStatusCompleted, Disagreed: &disagreed}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/qa-reviews/"+reviewID.String()+"/verdict",
+		bytes.NewBufferString(`{"decision":"rejected","disputed_fields":["seller.gstin"]}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: reviewID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.SubmitVerdict(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"disagreed":true`)
+	qaSvc.AssertExpectations(t)
+}
+
+func TestQAReviewHandler_SubmitVerdict_OwnApproval(t *testing.T) {
+	qaSvc := new(mocks.MockQAReviewService)
+	h := handler.NewQAReviewHandler(qaSvc)
+	reviewID := uuid.New()
+	qaSvc.On("SubmitVerdict", mock.Anything, mock.Anything).Return(nil, domain.ErrQAOwnReview)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/qa-reviews/"+reviewID.String()+"/verdict",
+		bytes.NewBufferString(`{"decision":"approved"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: reviewID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "manager")
+
+	h.SubmitVerdict(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "QA_OWN_REVIEW")
+}
+
+func TestQAReviewHandler_Scores_Window(t *testing.T) {
+	qaSvc := new(mocks.MockQAReviewService)
+	h := handler.NewQAReviewHandler(qaSvc)
+	tenantID := uuid.New()
+	qaSvc.On("Scores", mock.Anything, tenantID, 30*24*time.Hour).Return([]domain.QAReviewerScore{}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/qa-reviews/scores?window_days=30", http.NoBody)
+	setAuthContext(c, tenantID, uuid.New(), "manager")
+	h.Scores(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/qa-reviews/scores?window_days=0", http.NoBody)
+	setAuthContext(c, tenantID, uuid.New(), "manager")
+	h.Scores(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	qaSvc.AssertNumberOfCalls(t, "Scores", 1)
+}
@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestRejectionReasonHandler_List_IncludeInactive(t *testing.T) {
+	mockSvc := new(mocks.MockRejectionReasonService)
+	h := handler.NewRejectionReasonHandler(mockSvc)
+	tenantID, userID := uuid.New(), uuid.New()
+	mockSvc.On("List", mock.Anything, tenantID, true).
+		Return([]domain.RejectionReason{{Code: "illegible", Label: "Illegible"}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/rejection-reasons?include_inactive=true", http.NoBody)
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.List(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"illegible"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestRejectionReasonHandler_Set(t *testing.T) {
+	mockSvc := new(mocks.MockRejectionReasonService)
+	h := handler.NewRejectionReasonHandler(mockSvc)
+	tenantID, userID := uuid.New(), uuid.New()
+	mockSvc.On("Set", mock.Anything, tenantID, "po_mismatch",
+		mock.MatchedBy(func(in *service.SetRejectionReasonInput) bool {
+			return in.Label == "PO mismatch" && in.Active != nil && !*in.Active
+		}), userID).Return(&domain.RejectionReason{Code: "po_mismatch", Label: "PO mismatch"}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/rejection-reasons/po_mismatch",
+		strings.NewReader(`{"label":"PO mismatch","active":false}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "code", Value: "po_mismatch"}}
+	setAuthContext(c, tenantID, userID, "admin")
+
+	h.Set(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestRejectionReasonHandler_Set_InvalidReason(t *testing.T) {
+	mockSvc := new(mocks.MockRejectionReasonService)
+	h := handler.NewRejectionReasonHandler(mockSvc)
+	mockSvc.On("Set", mock.Anything, mock.Anything, "Bad Code", mock.Anything, mock.Anything).
+		Return(nil, domain.ErrInvalidRejectionReason)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/rejection-reasons/Bad%20Code", strings.NewReader(`{"label":"x"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "code", Value: "Bad Code"}}
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Set(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_REJECTION_REASON")
+}
+
+func TestRejectionReasonHandler_Stats_InvalidDate(t *testing.T) {
+	mockSvc := new(mocks.MockRejectionReasonService)
+	h := handler.NewRejectionReasonHandler(mockSvc)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/reports/rejection-reasons?from=01-01-2025", http.NoBody)
+	setAuthContext(c, uuid.New(), uuid.New(), "manager")
+
+	h.Stats(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Stats", mock.Anything, mock.Anything, mock.Anything)
+}
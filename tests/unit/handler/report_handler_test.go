@@ -341,3 +341,60 @@ func TestReportHandler_Sellers_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	mockSvc.AssertExpectations(t)
 }
+
+func TestReportHandler_SearchByAmount_Success(t *testing.T) {
+	h, mockSvc := newReportHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	expected := []domain.AmountMatchRow{{DocumentID: uuid.New(), TotalAmount: 11750, Difference: -50}}
+	mockSvc.On("SearchByAmount", mock.Anything, tenantID, 11800.0, domain.AmountTolerance{Value: 1, Percent: true}, "Acme",
+		mock.MatchedBy(func(f *domain.ReportFilters) bool { return f.From != nil && f.To != nil })).Return(expected, 1, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/search/amount?value=11800&tolerance=1%25&party=Acme&from=2026-01-01&to=2026-03-31", http.NoBody)
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.SearchByAmount(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestReportHandler_SearchByAmount_UnescapedPercent(t *testing.T) {
+	h, mockSvc := newReportHandler()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	mockSvc.On("SearchByAmount", mock.Anything, tenantID, 11800.0, domain.AmountTolerance{Value: 1, Percent: true}, "",
+		mock.AnythingOfType("*domain.ReportFilters")).Return([]domain.AmountMatchRow{}, 0, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/search/amount?value=11800&tolerance=1%", http.NoBody)
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.SearchByAmount(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestReportHandler_SearchByAmount_InvalidParams(t *testing.T) {
+	for _, query := range []string{"", "value=abc", "value=100&tolerance=x%25"} {
+		h, _ := newReportHandler()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/search/amount?"+query, http.NoBody)
+		setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+		h.SearchByAmount(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		assert.Contains(t, w.Body.String(), "INVALID_AMOUNT_SEARCH", query)
+	}
+}
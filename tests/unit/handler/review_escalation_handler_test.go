@@ -0,0 +1,38 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/mocks"
+)
+
+func TestReviewEscalationHandler_List(t *testing.T) {
+	escalationSvc := new(mocks.MockReviewEscalationService)
+	docSvc := new(mocks.MockDocumentService)
+	h := handler.NewReviewEscalationHandler(escalationSvc, docSvc)
+	tenantID, userID := uuid.New(), uuid.New()
+	docs := []domain.Document{{ID: uuid.New(), TenantID: tenantID, Name: "stale.pdf"}}
+	escalationSvc.On("ListEscalated", mock.Anything, tenantID, userID, domain.RoleMember, 0, 20).Return(docs, 1, nil)
+	docSvc.On("EnrichDocuments", mock.Anything, tenantID, mock.Anything).Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/documents/escalations", http.NoBody)
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.List(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"stale.pdf"`)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+	escalationSvc.AssertExpectations(t)
+}
@@ -0,0 +1,69 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/internal/validator"
+	"satvos/mocks"
+)
+
+func newSimulateContext(body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/validation-rules/simulate", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+	return c, w
+}
+
+func TestRuleSimulationHandler_Simulate(t *testing.T) {
+	mockSvc := new(mocks.MockRuleSimulationService)
+	h := handler.NewRuleSimulationHandler(mockSvc)
+	mockSvc.On("Simulate", mock.Anything, mock.MatchedBy(func(in *service.RuleSimulationInput) bool {
+		return in.DocumentType == "invoice" && in.Rule.RuleType == domain.ValidationRuleRegex && in.CollectionID == nil
+	})).Return(&service.RuleSimulationResult{
+		SimulationResult: validator.SimulationResult{DocumentsEvaluated: 5, WouldFail: 2, Samples: []validator.SimulationSample{}},
+	}, nil)
+
+	c, w := newSimulateContext(`{"document_type":"invoice","rule":{"rule_type":"regex","rule_config":{"field_path":"seller.pan","pattern":"^[A-Z]"}}}`)
+	h.Simulate(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"would_fail":2`)
+	assert.Contains(t, w.Body.String(), `"truncated":false`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestRuleSimulationHandler_Simulate_InvalidRule(t *testing.T) {
+	mockSvc := new(mocks.MockRuleSimulationService)
+	h := handler.NewRuleSimulationHandler(mockSvc)
+	mockSvc.On("Simulate", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidProposedRule)
+
+	c, w := newSimulateContext(`{"document_type":"invoice","rule":{"rule_type":"custom"}}`)
+	h.Simulate(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_PROPOSED_RULE")
+}
+
+func TestRuleSimulationHandler_Simulate_MissingDocumentType(t *testing.T) {
+	mockSvc := new(mocks.MockRuleSimulationService)
+	h := handler.NewRuleSimulationHandler(mockSvc)
+
+	c, w := newSimulateContext(`{"rule":{}}`)
+	h.Simulate(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Simulate", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,50 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/validator"
+	"satvos/mocks"
+)
+
+func TestSchemaHandler_Get(t *testing.T) {
+	mockSvc := new(mocks.MockSchemaService)
+	h := handler.NewSchemaHandler(mockSvc)
+	mockSvc.On("Get", "invoice").Return(&validator.DocumentSchema{
+		DocumentType: "invoice",
+		Fields:       []validator.SchemaField{{Path: "seller.gstin", Type: "string", ReconciliationCritical: true}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/schemas/invoice", http.NoBody)
+	c.Params = gin.Params{{Key: "documentType", Value: "invoice"}}
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reconciliation_critical":true`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestSchemaHandler_Get_Unknown(t *testing.T) {
+	mockSvc := new(mocks.MockSchemaService)
+	h := handler.NewSchemaHandler(mockSvc)
+	mockSvc.On("Get", "receipt").Return(nil, domain.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/schemas/receipt", http.NoBody)
+	c.Params = gin.Params{{Key: "documentType", Value: "receipt"}}
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
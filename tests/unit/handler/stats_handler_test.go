@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -14,6 +15,7 @@ import (
 
 	"satvos/internal/domain"
 	"satvos/internal/handler"
+	"satvos/internal/service"
 	"satvos/mocks"
 )
 
@@ -125,3 +127,215 @@ func TestStatsHandler_GetStats_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	mockSvc.AssertExpectations(t)
 }
+
+func TestStatsHandler_GetParseLatency_Success(t *testing.T) {
+	h, mockSvc := newStatsHandler()
+
+	tenantID := uuid.New()
+	mockSvc.On("GetParseLatency", mock.Anything, tenantID, 6*time.Hour).Return([]domain.ParseLatencyStats{
+		{ParserModel: "test-model", Attempts: 10, ParseP95Ms: 4200},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/stats/parse-latency?window_hours=6", http.NoBody)
+	setAuthContext(c, tenantID, uuid.New(), "manager")
+
+	h.GetParseLatency(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestStatsHandler_GetParseLatency_InvalidWindow(t *testing.T) {
+	h, mockSvc := newStatsHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/stats/parse-latency?window_hours=1000", http.NoBody)
+	setAuthContext(c, uuid.New(), uuid.New(), "manager")
+
+	h.GetParseLatency(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "GetParseLatency", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStatsHandler_GetConfidenceCalibration_Success(t *testing.T) {
+	h, mockSvc := newStatsHandler()
+
+	tenantID := uuid.New()
+	mockSvc.On("GetConfidenceCalibration", mock.Anything, tenantID, "invoice", 30*24*time.Hour).
+		Return([]domain.ConfidenceCalibration{{ParserModel: "test-model", Fields: 12, Accuracy: 0.75}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/stats/confidence-calibration?window_days=30&document_type=invoice", http.NoBody)
+	setAuthContext(c, tenantID, uuid.New(), "manager")
+
+	h.GetConfidenceCalibration(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"accuracy":0.75`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestStatsHandler_GetConfidenceCalibration_InvalidWindow(t *testing.T) {
+	h, mockSvc := newStatsHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/stats/confidence-calibration?window_days=0", http.NoBody)
+	setAuthContext(c, uuid.New(), uuid.New(), "manager")
+
+	h.GetConfidenceCalibration(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "GetConfidenceCalibration", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStatsHandler_GetRuleNoise_Success(t *testing.T) {
+	h, mockSvc := newStatsHandler()
+
+	tenantID := uuid.New()
+	mockSvc.On("GetRuleNoise", mock.Anything, tenantID, "", 90*24*time.Hour, service.RuleNoiseByCorrections, 5).
+		Return([]domain.RuleNoise{{RuleFailureStats: domain.RuleFailureStats{RuleName: "HSN format", Failures: 4}, CorrectionRate: 0.5}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/stats/rule-noise?sort=corrections&limit=5", http.NoBody)
+	setAuthContext(c, tenantID, uuid.New(), "admin")
+
+	h.GetRuleNoise(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"correction_rate":0.5`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestStatsHandler_GetRuleNoise_InvalidQuery(t *testing.T) {
+	for _, query := range []string{"sort=loudest", "limit=0", "window_days=731"} {
+		h, mockSvc := newStatsHandler()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/stats/rule-noise?"+query, http.NoBody)
+		setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+		h.GetRuleNoise(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		mockSvc.AssertNotCalled(t, "GetRuleNoise", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func TestStatsHandler_GetCollectionQuality_Period(t *testing.T) {
+	h, mockSvc := newStatsHandler()
+
+	tenantID, collectionID := uuid.New(), uuid.New()
+	from := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)
+	score := 82.5
+	mockSvc.On("GetCollectionQuality", mock.Anything, tenantID,
+		domain.CollectionQualityFilter{CollectionID: &collectionID, From: &from, To: &to}).
+		Return([]domain.CollectionQuality{{CollectionID: collectionID, Score: &score, TotalDocuments: 40}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/api/v1/stats/quality?collection_id="+collectionID.String()+"&from=2026-04-01&to=2026-04-30", http.NoBody)
+	setAuthContext(c, tenantID, uuid.New(), "manager")
+
+	h.GetCollectionQuality(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"score":82.5`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestStatsHandler_GetCollectionQuality_InvalidQuery(t *testing.T) {
+	for _, query := range []string{"collection_id=nope", "from=2026-13-01", "to=April", "from=2026-05-01&to=2026-04-01"} {
+		h, mockSvc := newStatsHandler()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/stats/quality?"+query, http.NoBody)
+		setAuthContext(c, uuid.New(), uuid.New(), "manager")
+
+		h.GetCollectionQuality(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		mockSvc.AssertNotCalled(t, "GetCollectionQuality", mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func TestStatsHandler_GetCollectionQualityHistory(t *testing.T) {
+	h, mockSvc := newStatsHandler()
+
+	tenantID, collectionID := uuid.New(), uuid.New()
+	mockSvc.On("GetCollectionQualityHistory", mock.Anything, tenantID, collectionID, 30*24*time.Hour).
+		Return(nil, domain.ErrCollectionNotFound)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet,
+		"/api/v1/stats/quality/history?collection_id="+collectionID.String()+"&window_days=30", http.NoBody)
+	setAuthContext(c, tenantID, uuid.New(), "manager")
+
+	h.GetCollectionQualityHistory(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockSvc.AssertExpectations(t)
+
+	for _, query := range []string{"", "collection_id=" + collectionID.String() + "&window_days=0"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/stats/quality/history?"+query, http.NoBody)
+		setAuthContext(c, tenantID, uuid.New(), "manager")
+
+		h.GetCollectionQualityHistory(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestStatsHandler_Recount_Success(t *testing.T) {
+	h, mockSvc := newStatsHandler()
+
+	tenantID := uuid.New()
+	mockSvc.On("Recount", mock.Anything, tenantID).Return(&domain.StatsRecount{
+		TenantID:      tenantID,
+		Discrepancies: []domain.StatsDiscrepancy{{CollectionID: uuid.New(), Materialized: 4, Actual: 5}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/tenants/"+tenantID.String()+"/stats/recount", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: tenantID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Recount(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data domain.StatsRecount `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data.Discrepancies, 1)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestStatsHandler_Recount_InvalidID(t *testing.T) {
+	h, mockSvc := newStatsHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/tenants/nope/stats/recount", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: "nope"}}
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Recount(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Recount", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestTenantMembershipHandler_SwitchTenant(t *testing.T) {
+	mockSvc := new(mocks.MockTenantMembershipService)
+	h := handler.NewTenantMembershipHandler(mockSvc)
+	tenantID, userID, targetID := uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("SwitchTenant", mock.Anything, userID, targetID).
+		Return(&service.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/switch-tenant",
+		strings.NewReader(`{"tenant_id":"`+targetID.String()+`"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.SwitchTenant(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"access_token":"access"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestTenantMembershipHandler_SwitchTenant_AccessDenied(t *testing.T) {
+	mockSvc := new(mocks.MockTenantMembershipService)
+	h := handler.NewTenantMembershipHandler(mockSvc)
+	targetID := uuid.New()
+	mockSvc.On("SwitchTenant", mock.Anything, mock.Anything, targetID).Return(nil, domain.ErrTenantAccessDenied)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/switch-tenant",
+		strings.NewReader(`{"tenant_id":"`+targetID.String()+`"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.SwitchTenant(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "TENANT_ACCESS_DENIED")
+}
+
+func TestTenantMembershipHandler_SwitchTenant_InvalidID(t *testing.T) {
+	mockSvc := new(mocks.MockTenantMembershipService)
+	h := handler.NewTenantMembershipHandler(mockSvc)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/switch-tenant",
+		strings.NewReader(`{"tenant_id":"nope"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.SwitchTenant(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "SwitchTenant", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTenantMembershipHandler_Grant(t *testing.T) {
+	mockSvc := new(mocks.MockTenantMembershipService)
+	h := handler.NewTenantMembershipHandler(mockSvc)
+	tenantID, userID := uuid.New(), uuid.New()
+	mockSvc.On("Grant", mock.Anything, tenantID, mock.MatchedBy(func(in *service.GrantMembershipInput) bool {
+		return in.Email == "ca@firm.com" && in.TenantSlug == "ca-firm" && in.Role == domain.RoleViewer
+	}), userID).Return(&domain.TenantMembership{TenantID: tenantID, Role: domain.RoleViewer, IsActive: true}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/memberships",
+		strings.NewReader(`{"email":"ca@firm.com","tenant_slug":"ca-firm","role":"viewer"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	setAuthContext(c, tenantID, userID, "admin")
+
+	h.Grant(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestTenantMembershipHandler_Revoke(t *testing.T) {
+	mockSvc := new(mocks.MockTenantMembershipService)
+	h := handler.NewTenantMembershipHandler(mockSvc)
+	tenantID, guestID := uuid.New(), uuid.New()
+	mockSvc.On("Revoke", mock.Anything, tenantID, guestID).Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/api/v1/memberships/"+guestID.String(), http.NoBody)
+	c.Params = gin.Params{{Key: "userId", Value: guestID.String()}}
+	setAuthContext(c, tenantID, uuid.New(), "admin")
+
+	h.Revoke(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func tenantMoveContext(method, tenantID, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(method, "/api/v1/admin/tenants/"+tenantID+"/moves", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: tenantID}}
+	return c, w
+}
+
+func TestTenantMoveHandler_Start(t *testing.T) {
+	mockSvc := new(mocks.MockTenantMoveService)
+	h := handler.NewTenantMoveHandler(mockSvc)
+	tenantID, userID := uuid.New(), uuid.New()
+	mockSvc.On("Start", mock.Anything, tenantID, mock.MatchedBy(func(in *service.StartTenantMoveInput) bool {
+		return in.TargetCluster == "green" && in.StorageRegion != nil && *in.StorageRegion == "eu-west-1"
+	}), &userID).Return(&domain.TenantMove{ID: uuid.New(), TenantID: tenantID, TargetCluster: "green", Status: domain.TenantMoveStatusPending}, nil)
+
+	c, w := tenantMoveContext(http.MethodPost, tenantID.String(), `{"target_cluster":"green","storage_region":"eu-west-1"}`)
+	setAuthContext(c, uuid.New(), userID, "admin")
+
+	h.Start(c)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp struct {
+		Data domain.TenantMove `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, domain.TenantMoveStatusPending, resp.Data.Status)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestTenantMoveHandler_Start_MissingTarget(t *testing.T) {
+	mockSvc := new(mocks.MockTenantMoveService)
+	h := handler.NewTenantMoveHandler(mockSvc)
+
+	c, w := tenantMoveContext(http.MethodPost, uuid.New().String(), `{}`)
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Start(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Start", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTenantMoveHandler_Start_Errors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+		body string
+	}{
+		{domain.ErrInvalidMoveTarget, http.StatusBadRequest, "INVALID_MOVE_TARGET"},
+		{domain.ErrTenantMoveInProgress, http.StatusConflict, "TENANT_MOVE_IN_PROGRESS"},
+		{domain.ErrTenantNotMovable, http.StatusConflict, "TENANT_NOT_MOVABLE"},
+		{domain.ErrTenantMoved, http.StatusMisdirectedRequest, "TENANT_MOVED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			mockSvc := new(mocks.MockTenantMoveService)
+			h := handler.NewTenantMoveHandler(mockSvc)
+			mockSvc.On("Start", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			c, w := tenantMoveContext(http.MethodPost, uuid.New().String(), `{"target_cluster":"green"}`)
+			setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+			h.Start(c)
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.Contains(t, w.Body.String(), tt.body)
+		})
+	}
+}
+
+func TestTenantMoveHandler_Get_InvalidMoveID(t *testing.T) {
+	mockSvc := new(mocks.MockTenantMoveService)
+	h := handler.NewTenantMoveHandler(mockSvc)
+
+	c, w := tenantMoveContext(http.MethodGet, uuid.New().String(), "")
+	c.Params = append(c.Params, gin.Param{Key: "moveId", Value: "not-a-uuid"})
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+}
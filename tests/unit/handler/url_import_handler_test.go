@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func newURLImportRequest(t *testing.T, body string) (*httptest.ResponseRecorder, *gin.Context) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/documents/from-url", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return w, c
+}
+
+func TestURLImportHandler_CreateFromURL(t *testing.T) {
+	mockSvc := new(mocks.MockURLImportService)
+	h := handler.NewURLImportHandler(mockSvc, nil)
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("CreateFromURL", mock.Anything, mock.MatchedBy(func(in *service.CreateFromURLInput) bool {
+		return in.TenantID == tenantID && in.CollectionID == collectionID && in.CreatedBy == userID &&
+			in.URL == "https://files.example.com/inv.pdf" && in.ParseMode == ""
+	})).Return(&domain.Document{ID: uuid.New(), CollectionID: collectionID}, nil)
+
+	w, c := newURLImportRequest(t, `{"url":"https://files.example.com/inv.pdf","collection_id":"`+collectionID.String()+`","document_type":"invoice"}`)
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.CreateFromURL(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestURLImportHandler_CreateFromURL_MissingURL(t *testing.T) {
+	h := handler.NewURLImportHandler(new(mocks.MockURLImportService), nil)
+
+	w, c := newURLImportRequest(t, `{"collection_id":"`+uuid.New().String()+`","document_type":"invoice"}`)
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.CreateFromURL(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_REQUEST")
+}
+
+func TestURLImportHandler_CreateFromURL_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"blocked url", domain.ErrInvalidSourceURL, http.StatusBadRequest, "INVALID_SOURCE_URL"},
+		{"source unreachable", domain.ErrSourceFetchFailed, http.StatusBadGateway, "SOURCE_FETCH_FAILED"},
+		{"too large", domain.ErrFileTooLarge, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(mocks.MockURLImportService)
+			h := handler.NewURLImportHandler(mockSvc, nil)
+			mockSvc.On("CreateFromURL", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			w, c := newURLImportRequest(t, `{"url":"https://files.example.com/inv.pdf","collection_id":"`+uuid.New().String()+`","document_type":"invoice"}`)
+			setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+			h.CreateFromURL(c)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+		})
+	}
+}
@@ -20,7 +20,7 @@ import (
 
 func newUserHandler() (*handler.UserHandler, *mocks.MockUserService) {
 	mockSvc := new(mocks.MockUserService)
-	h := handler.NewUserHandler(mockSvc)
+	h := handler.NewUserHandler(mockSvc, nil, nil)
 	return h, mockSvc
 }
 
@@ -172,6 +172,27 @@ func TestUserHandler_List_Success(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func TestUserHandler_List_Unverified(t *testing.T) {
+	h, mockSvc := newUserHandler()
+
+	tenantID := uuid.New()
+	adminID := uuid.New()
+
+	users := []domain.User{{ID: uuid.New(), TenantID: tenantID, Email: "new@acme.com", IsActive: true}}
+	mockSvc.On("ListUnverified", mock.Anything, tenantID, 0, 20).Return(users, 1, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/users?email_verified=false", http.NoBody)
+	setAuthContext(c, tenantID, adminID, "admin")
+
+	h.List(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+	mockSvc.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestUserHandler_List_NoAuth(t *testing.T) {
 	h, _ := newUserHandler()
 
@@ -386,6 +407,29 @@ func TestUserHandler_Update_AdminCanChangeRole(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func TestUserHandler_Update_ManagerCannotChangeQuota(t *testing.T) {
+	h, mockSvc := newUserHandler()
+
+	tenantID := uuid.New()
+	managerID := uuid.New()
+	targetUserID := uuid.New()
+
+	limit := 100
+	body, _ := json.Marshal(service.UpdateUserInput{MonthlyDocumentLimit: &limit})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPut, "/api/v1/users/"+targetUserID.String(), bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "id", Value: targetUserID.String()}}
+	setAuthContext(c, tenantID, managerID, "manager")
+
+	h.Update(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockSvc.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestUserHandler_Update_MemberCannotUpdateOther(t *testing.T) {
 	h, _ := newUserHandler()
 
@@ -498,3 +542,42 @@ func TestUserHandler_Delete_InvalidID(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+// --- Security events ---
+
+func TestUserHandler_ListSecurityEvents_Self(t *testing.T) {
+	mockPwReset := new(mocks.MockPasswordResetService)
+	h := handler.NewUserHandler(new(mocks.MockUserService), nil, mockPwReset)
+	tenantID, userID := uuid.New(), uuid.New()
+	mockPwReset.On("ListSecurityEvents", mock.Anything, tenantID, userID, 0, 20).Return([]domain.SecurityEvent{
+		{UserID: userID, Event: domain.SecurityEventEmailChanged},
+	}, 1, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/users/"+userID.String()+"/security-events", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: userID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.ListSecurityEvents(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "email_changed")
+}
+
+func TestUserHandler_ListSecurityEvents_OtherUserForbidden(t *testing.T) {
+	mockPwReset := new(mocks.MockPasswordResetService)
+	h := handler.NewUserHandler(new(mocks.MockUserService), nil, mockPwReset)
+	otherID := uuid.New()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/users/"+otherID.String()+"/security-events", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: otherID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "manager")
+
+	h.ListSecurityEvents(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockPwReset.AssertNotCalled(t, "ListSecurityEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,91 @@
+package handler_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func userImportRequest(c *gin.Context, tenantID string, csv string) {
+	body := &bytes.Buffer{}
+	writer := multipartWriter(body)
+	if csv != "" {
+		part, _ := writer.CreateFormFile("file", "users.csv")
+		_, _ = part.Write([]byte(csv))
+	}
+	_ = writer.Close()
+
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/tenants/"+tenantID+"/users/import", body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	c.Params = gin.Params{{Key: "id", Value: tenantID}}
+}
+
+func TestUserImportHandler_Import_ReturnsReport(t *testing.T) {
+	mockSvc := new(mocks.MockUserImportService)
+	h := handler.NewUserImportHandler(mockSvc)
+	targetTenant, userID := uuid.New(), uuid.New()
+	csv := "name,email,role\nAsha,asha@acme.in,member\n"
+
+	mockSvc.On("Import", mock.Anything, mock.MatchedBy(func(in *service.UserImportInput) bool {
+		data, _ := io.ReadAll(in.CSV)
+		return in.TenantID == targetTenant && in.ActorID == userID && string(data) == csv
+	})).Return(&domain.UserImportReport{Total: 1, Created: 1, Rows: []domain.UserImportRow{
+		{Row: 2, Email: "asha@acme.in", Status: domain.UserImportRowCreated, Invited: true},
+	}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	userImportRequest(c, targetTenant.String(), csv)
+	setAuthContext(c, uuid.New(), userID, "admin")
+
+	h.Import(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"created":1`)
+	assert.Contains(t, w.Body.String(), `"status":"created"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserImportHandler_Import_MissingFile(t *testing.T) {
+	mockSvc := new(mocks.MockUserImportService)
+	h := handler.NewUserImportHandler(mockSvc)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	userImportRequest(c, uuid.New().String(), "")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Import(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "MISSING_FILE")
+	mockSvc.AssertNotCalled(t, "Import", mock.Anything, mock.Anything)
+}
+
+func TestUserImportHandler_Import_InvalidCSV(t *testing.T) {
+	mockSvc := new(mocks.MockUserImportService)
+	h := handler.NewUserImportHandler(mockSvc)
+	mockSvc.On("Import", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidUserImport)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	userImportRequest(c, uuid.New().String(), "name,email\n")
+	setAuthContext(c, uuid.New(), uuid.New(), "admin")
+
+	h.Import(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "INVALID_USER_IMPORT")
+}
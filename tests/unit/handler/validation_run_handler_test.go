@@ -0,0 +1,72 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/handler"
+	"satvos/mocks"
+)
+
+func TestValidationRunHandler_Start(t *testing.T) {
+	mockSvc := new(mocks.MockValidationRunService)
+	h := handler.NewValidationRunHandler(mockSvc)
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	mockSvc.On("Start", mock.Anything, tenantID, collectionID, userID, domain.RoleMember).
+		Return(&domain.ValidationRun{ID: uuid.New(), CollectionID: collectionID, Status: domain.ValidationRunStatusPending}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/collections/"+collectionID.String()+"/validate", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, tenantID, userID, "member")
+
+	h.Start(c)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestValidationRunHandler_Start_InProgress(t *testing.T) {
+	mockSvc := new(mocks.MockValidationRunService)
+	h := handler.NewValidationRunHandler(mockSvc)
+	collectionID := uuid.New()
+	mockSvc.On("Start", mock.Anything, mock.Anything, collectionID, mock.Anything, mock.Anything).
+		Return(nil, domain.ErrValidationRunInProgress)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodPost, "/api/v1/collections/"+collectionID.String()+"/validate", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.Start(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "VALIDATION_RUN_IN_PROGRESS")
+}
+
+func TestValidationRunHandler_Get_InvalidRunID(t *testing.T) {
+	mockSvc := new(mocks.MockValidationRunService)
+	h := handler.NewValidationRunHandler(mockSvc)
+	collectionID := uuid.New()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/validation-runs/bad", http.NoBody)
+	c.Params = gin.Params{{Key: "id", Value: collectionID.String()}, {Key: "runId", Value: "bad"}}
+	setAuthContext(c, uuid.New(), uuid.New(), "member")
+
+	h.Get(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertNotCalled(t, "GetRun", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,90 @@
+package httpclient_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/httpclient"
+)
+
+func TestHostAllowed(t *testing.T) {
+	allowed := []string{"api.anthropic.com", "*.googleapis.com"}
+
+	assert.True(t, httpclient.HostAllowed("api.anthropic.com", allowed))
+	assert.True(t, httpclient.HostAllowed("API.Anthropic.com", allowed))
+	assert.True(t, httpclient.HostAllowed("generativelanguage.googleapis.com", allowed))
+	assert.False(t, httpclient.HostAllowed("googleapis.com", allowed))
+	assert.False(t, httpclient.HostAllowed("evil-googleapis.com", allowed))
+	assert.False(t, httpclient.HostAllowed("api.openai.com", allowed))
+}
+
+func TestNew_AllowlistBlocksOtherHosts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client, err := httpclient.New(config.HTTPClientConfig{AllowedHosts: []string{"api.anthropic.com"}})
+	require.NoError(t, err)
+
+	_, err = client.Get(srv.URL)
+	assert.ErrorIs(t, err, httpclient.ErrEgressDenied)
+}
+
+func TestNew_AllowlistPermitsListedHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client, err := httpclient.New(config.HTTPClientConfig{AllowedHosts: []string{"127.0.0.1"}, ConnectTimeoutSecs: 5})
+	require.NoError(t, err)
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNew_InvalidProxyURL(t *testing.T) {
+	_, err := httpclient.New(config.HTTPClientConfig{ProxyURL: "not a url"})
+	assert.Error(t, err)
+}
+
+func TestNew_CABundleWithoutCertificates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0o600))
+
+	_, err := httpclient.New(config.HTTPClientConfig{CABundle: path})
+	assert.Error(t, err)
+}
+
+func TestNewPublic_RefusesLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client, err := httpclient.NewPublic(config.HTTPClientConfig{})
+	require.NoError(t, err)
+
+	_, err = client.Get(srv.URL)
+	assert.ErrorIs(t, err, httpclient.ErrNonPublicAddress)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "::1", "fd00::1", "0.0.0.0"} {
+		assert.False(t, httpclient.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"8.8.8.8", "52.95.110.1", "2606:4700::1111"} {
+		assert.True(t, httpclient.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
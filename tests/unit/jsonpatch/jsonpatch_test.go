@@ -0,0 +1,84 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/jsonpatch"
+)
+
+func ops(t *testing.T, raw string) []jsonpatch.Operation {
+	t.Helper()
+	var out []jsonpatch.Operation
+	require.NoError(t, json.Unmarshal([]byte(raw), &out))
+	return out
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"add member", `{"a":1}`, `[{"op":"add","path":"/b","value":"x"}]`, `{"a":1,"b":"x"}`},
+		{"add replaces member", `{"a":1}`, `[{"op":"add","path":"/a","value":2}]`, `{"a":2}`},
+		{"add inserts into array", `{"a":[1,3]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2,3]}`},
+		{"add appends with dash", `{"a":[1]}`, `[{"op":"add","path":"/a/-","value":{"b":null}}]`, `{"a":[1,{"b":null}]}`},
+		{"remove member", `{"a":1,"b":2}`, `[{"op":"remove","path":"/a"}]`, `{"b":2}`},
+		{"remove array element", `{"a":[1,2,3]}`, `[{"op":"remove","path":"/a/0"}]`, `{"a":[2,3]}`},
+		{"replace nested", `{"a":{"b":[{"c":1}]}}`, `[{"op":"replace","path":"/a/b/0/c","value":5}]`, `{"a":{"b":[{"c":5}]}}`},
+		{"replace root", `{"a":1}`, `[{"op":"replace","path":"","value":{"b":2}}]`, `{"b":2}`},
+		{"move", `{"a":{"b":1},"c":{}}`, `[{"op":"move","from":"/a/b","path":"/c/d"}]`, `{"a":{},"c":{"d":1}}`},
+		{"move within array", `{"a":[1,2,3]}`, `[{"op":"move","from":"/a/0","path":"/a/-"}]`, `{"a":[2,3,1]}`},
+		{"copy is independent", `{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`, `{"a":{"b":1},"c":{"b":2}}`},
+		{"test passes with equal numbers", `{"a":1}`, `[{"op":"test","path":"/a","value":1.0},{"op":"add","path":"/b","value":true}]`, `{"a":1,"b":true}`},
+		{"escaped pointer", `{"a/b":1,"m~n":2}`, `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, `{"a/b":3}`},
+		{"numbers kept verbatim", `{"a":1250.50,"b":1}`, `[{"op":"replace","path":"/b","value":2}]`, `{"a":1250.50,"b":2}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonpatch.Apply(json.RawMessage(tt.doc), ops(t, tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  error
+	}{
+		{"unknown op", `[{"op":"merge","path":"/a"}]`, jsonpatch.ErrInvalid},
+		{"missing value", `[{"op":"add","path":"/b"}]`, jsonpatch.ErrInvalid},
+		{"path without slash", `[{"op":"remove","path":"a"}]`, jsonpatch.ErrInvalid},
+		{"move into own child", `[{"op":"move","from":"/o","path":"/o/x"}]`, jsonpatch.ErrInvalid},
+		{"remove missing member", `[{"op":"remove","path":"/missing"}]`, jsonpatch.ErrConflict},
+		{"replace missing member", `[{"op":"replace","path":"/missing","value":1}]`, jsonpatch.ErrConflict},
+		{"add under missing parent", `[{"op":"add","path":"/missing/x","value":1}]`, jsonpatch.ErrConflict},
+		{"array index out of range", `[{"op":"add","path":"/l/5","value":1}]`, jsonpatch.ErrConflict},
+		{"array index with leading zero", `[{"op":"remove","path":"/l/01"}]`, jsonpatch.ErrConflict},
+		{"test fails", `[{"op":"test","path":"/a","value":2}]`, jsonpatch.ErrConflict},
+	}
+	doc := json.RawMessage(`{"a":1,"l":[1,2],"o":{}}`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jsonpatch.Apply(doc, ops(t, tt.patch))
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestApply_FailedPatchLeavesDocumentUnchanged(t *testing.T) {
+	doc := json.RawMessage(`{"a":1}`)
+
+	_, err := jsonpatch.Apply(doc, ops(t, `[{"op":"replace","path":"/a","value":2},{"op":"test","path":"/a","value":3}]`))
+
+	assert.ErrorIs(t, err, jsonpatch.ErrConflict)
+	assert.JSONEq(t, `{"a":1}`, string(doc))
+}
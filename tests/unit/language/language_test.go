@@ -0,0 +1,94 @@
+package language_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/language"
+)
+
+func TestDetectText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "Tax Invoice for cotton fabric", language.English},
+		{"hindi", "कर बीजक सूती कपड़ा", "hi"},
+		{"tamil", "வரி விலைப்பட்டியல்", "ta"},
+		{"bilingual counts as indic", "Tax Invoice / कर बीजक for cotton fabric", "hi"},
+		{"a stray indic word is english", "Tax Invoice for cotton fabric and yarn supplied under the contract श्री", language.English},
+		{"too little text", "ab 12345", ""},
+		{"digits only", "1234567890", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, language.DetectText(tt.text))
+		})
+	}
+}
+
+func TestScriptLookups(t *testing.T) {
+	sc := language.ScriptOf('க')
+	require.NotNil(t, sc)
+	assert.Equal(t, "ta", sc.Language)
+	assert.Nil(t, language.ScriptOf('k'))
+
+	assert.Equal(t, "Gujarati", language.ForLanguage("gu").Name)
+	assert.Nil(t, language.ForLanguage(language.English))
+	assert.Nil(t, language.ForLanguage("xx"))
+
+	assert.Equal(t, "Kannada", language.FirstIndicScript("Invoice ಕನ್ನಡ").Name)
+	assert.Nil(t, language.FirstIndicScript("Invoice"))
+}
+
+// pdfWithCMap builds a minimal PDF holding one ToUnicode CMap stream.
+func pdfWithCMap(t *testing.T, cmap string, compress bool) []byte {
+	t.Helper()
+	body, dict := []byte(cmap), "<< /Length %d >>"
+	if compress {
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		_, err := w.Write(body)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		body, dict = buf.Bytes(), "<< /Length %d /Filter /FlateDecode >>"
+	}
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.7\n1 0 obj\n<< /Type /Page >>\nendobj\n5 0 obj\n")
+	fmt.Fprintf(&pdf, dict, len(body))
+	pdf.WriteString("\nstream\n")
+	pdf.Write(body)
+	pdf.WriteString("\nendstream\nendobj\n%%EOF\n")
+	return pdf.Bytes()
+}
+
+func TestDetectPDF(t *testing.T) {
+	// Glyphs 01-03 map to Devanagari क, र, ब; a range of 20 glyphs to A-T.
+	hindi := "/CIDInit /ProcSet findresource begin\n3 beginbfchar\n<01> <0915>\n<02> <0930>\n<03> <092C>\nendbfchar\n" +
+		"1 beginbfrange\n<10> <13> <0928>\nendbfrange\nend"
+	latin := "1 beginbfrange\n<0020> <0033> <0041>\nendbfrange\n1 beginbfchar\n<0040> <0915>\nendbfchar"
+
+	t.Run("flate compressed hindi", func(t *testing.T) {
+		assert.Equal(t, "hi", language.DetectPDF(pdfWithCMap(t, hindi, true)))
+	})
+	t.Run("uncompressed", func(t *testing.T) {
+		assert.Equal(t, "hi", language.DetectPDF(pdfWithCMap(t, hindi, false)))
+	})
+	t.Run("latin fonts are english", func(t *testing.T) {
+		assert.Equal(t, language.English, language.DetectPDF(pdfWithCMap(t, latin, true)))
+	})
+	t.Run("no text layer", func(t *testing.T) {
+		assert.Equal(t, "", language.DetectPDF([]byte("%PDF-1.7\n1 0 obj\n<< /Type /Page >>\nendobj\n%%EOF\n")))
+	})
+	t.Run("corrupt stream", func(t *testing.T) {
+		pdf := pdfWithCMap(t, hindi, true)
+		pdf = bytes.Replace(pdf, []byte("/FlateDecode >>\nstream\n"), []byte("/FlateDecode >>\nstream\nXX"), 1)
+		assert.Equal(t, "", language.DetectPDF(pdf))
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"satvos/internal/domain"
 	"satvos/internal/middleware"
@@ -162,3 +163,51 @@ func TestRequireRole_NoRole(t *testing.T) {
 
 	assert.Equal(t, http.StatusForbidden, w.Code)
 }
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	mockAuth := new(mocks.MockAuthService)
+	mockKeys := new(mocks.MockAPIKeyService)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	mockKeys.On("Authenticate", mock.Anything, "satvos_abc").Return(&service.Claims{
+		TenantID: tenantID, UserID: userID, Role: domain.RoleAdmin,
+	}, nil)
+
+	r := gin.New()
+	r.Use(middleware.AuthMiddleware(service.NewAPIKeyAuthService(mockAuth, mockKeys)))
+	r.GET("/test", func(c *gin.Context) {
+		uid, _ := middleware.GetUserID(c)
+		c.JSON(http.StatusOK, gin.H{"user_id": uid, "role": middleware.GetRole(c)})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Set("Authorization", "Bearer satvos_abc")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, userID.String(), resp["user_id"])
+	assert.Equal(t, "admin", resp["role"])
+	mockAuth.AssertNotCalled(t, "ValidateToken", mock.Anything)
+}
+
+func TestAuthMiddleware_APIKeyRejected(t *testing.T) {
+	mockKeys := new(mocks.MockAPIKeyService)
+	mockKeys.On("Authenticate", mock.Anything, "satvos_revoked").Return(nil, domain.ErrUnauthorized)
+
+	r := gin.New()
+	r.Use(middleware.AuthMiddleware(service.NewAPIKeyAuthService(new(mocks.MockAuthService), mockKeys)))
+	r.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Set("Authorization", "Bearer satvos_revoked")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,76 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"satvos/internal/domain"
+	"satvos/internal/middleware"
+)
+
+func newMatrixRouter(role string) *gin.Engine {
+	rules := []domain.RouteRule{
+		{Method: http.MethodGet, Path: "/items/:id", Roles: []domain.UserRole{domain.RoleAdmin, domain.RoleMember}},
+		{Method: http.MethodDelete, Path: "/items/:id", Roles: []domain.UserRole{domain.RoleAdmin}},
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if role != "" {
+			c.Set(middleware.ContextKeyRole, role)
+		}
+		c.Next()
+	})
+	r.Use(middleware.EnforceRouteMatrix(rules))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/items/:id", ok)
+	r.DELETE("/items/:id", ok)
+	r.POST("/items", ok)
+	return r
+}
+
+func TestEnforceRouteMatrix_AllowedRole(t *testing.T) {
+	r := newMatrixRouter("member")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/items/123", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestEnforceRouteMatrix_RoleNotAllowed(t *testing.T) {
+	r := newMatrixRouter("member")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/items/123", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "insufficient permissions")
+}
+
+func TestEnforceRouteMatrix_UndeclaredRouteDenied(t *testing.T) {
+	r := newMatrixRouter("admin")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "route not authorized")
+}
+
+func TestEnforceRouteMatrix_MissingRole(t *testing.T) {
+	r := newMatrixRouter("")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/items/123", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
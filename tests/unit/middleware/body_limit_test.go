@@ -0,0 +1,71 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"satvos/internal/middleware"
+)
+
+func newBodyLimitRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.BodyLimit(16, map[string]int64{"/upload": 64}))
+	read := func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+	r.POST("/items", read)
+	r.POST("/upload", read)
+	return r
+}
+
+func TestBodyLimit_WithinDefault(t *testing.T) {
+	r := newBodyLimitRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/items", strings.NewReader("small"))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestBodyLimit_RejectsDeclaredLength(t *testing.T) {
+	r := newBodyLimitRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/items", strings.NewReader(strings.Repeat("x", 32)))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "PAYLOAD_TOO_LARGE")
+}
+
+func TestBodyLimit_CutsOffChunkedBody(t *testing.T) {
+	r := newBodyLimitRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/items", io.NopCloser(strings.NewReader(strings.Repeat("x", 32))))
+	req.ContentLength = -1
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestBodyLimit_RouteOverride(t *testing.T) {
+	r := newBodyLimitRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("x", 32)))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
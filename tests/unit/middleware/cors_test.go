@@ -1,14 +1,20 @@
 package middleware_test
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"satvos/internal/middleware"
+	"satvos/internal/service"
+	"satvos/mocks"
 )
 
 func TestCORS_AllowedOrigin(t *testing.T) {
@@ -113,3 +119,120 @@ func TestCORS_EmptyOriginsList(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
 }
+
+const whiteLabelOrigin = "https://invoices.acme.example"
+
+// tenantCORSRouter allows whiteLabelOrigin for tenantID and serves /test.
+func tenantCORSRouter(tenantID uuid.UUID) (*gin.Engine, *mocks.MockAuthService, *mocks.MockTenantCORSOriginRepo) {
+	authSvc := new(mocks.MockAuthService)
+	origins := new(mocks.MockTenantCORSOriginRepo)
+	origins.On("TenantsByOrigin", mock.Anything, whiteLabelOrigin).Return([]uuid.UUID{tenantID}, nil)
+	origins.On("TenantsByOrigin", mock.Anything, mock.Anything).Return([]uuid.UUID{}, nil)
+
+	r := gin.New()
+	r.Use(middleware.TenantCORS([]string{"https://app.example.com"}, authSvc, origins, time.Minute))
+	r.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.PUT("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r, authSvc, origins
+}
+
+func corsRequest(r *gin.Engine, method, origin, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, "/test", http.NoBody)
+	req.Header.Set("Origin", origin)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestTenantCORS_TokenOfOwningTenant(t *testing.T) {
+	tenantID := uuid.New()
+	r, authSvc, _ := tenantCORSRouter(tenantID)
+	authSvc.On("ValidateToken", "acme").Return(&service.Claims{TenantID: tenantID}, nil)
+	authSvc.On("ValidateToken", "other").Return(&service.Claims{TenantID: uuid.New()}, nil)
+
+	w := corsRequest(r, http.MethodGet, whiteLabelOrigin, "acme")
+	assert.Equal(t, whiteLabelOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+
+	w = corsRequest(r, http.MethodGet, whiteLabelOrigin, "other")
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	// Server-wide origins stay allowed for every tenant
+	w = corsRequest(r, http.MethodGet, "https://app.example.com", "other")
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestTenantCORS_WithoutCredentialsOnlyServerWideOrigins(t *testing.T) {
+	r, authSvc, _ := tenantCORSRouter(uuid.New())
+	authSvc.On("ValidateToken", "expired").Return(nil, errors.New("token expired"))
+
+	// A preflight carries no credentials, so any tenant's origin may send the real request
+	w := corsRequest(r, http.MethodOptions, whiteLabelOrigin, "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, whiteLabelOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = corsRequest(r, http.MethodPut, whiteLabelOrigin, "")
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = corsRequest(r, http.MethodGet, whiteLabelOrigin, "expired")
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = corsRequest(r, http.MethodGet, "https://app.example.com", "")
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = corsRequest(r, http.MethodOptions, "https://evil.example", "")
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestTenantCORS_APIKeyOfOwningTenant(t *testing.T) {
+	tenantID := uuid.New()
+	authSvc, keys := new(mocks.MockAuthService), new(mocks.MockAPIKeyService)
+	keys.On("Authenticate", mock.Anything, service.APIKeyPrefix+"acme").Return(&service.Claims{TenantID: tenantID}, nil)
+	keys.On("Authenticate", mock.Anything, service.APIKeyPrefix+"other").Return(&service.Claims{TenantID: uuid.New()}, nil)
+	origins := new(mocks.MockTenantCORSOriginRepo)
+	origins.On("TenantsByOrigin", mock.Anything, whiteLabelOrigin).Return([]uuid.UUID{tenantID}, nil)
+	r := gin.New()
+	r.Use(middleware.TenantCORS(nil, service.NewAPIKeyAuthService(authSvc, keys), origins, time.Minute))
+	r.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := corsRequest(r, http.MethodGet, whiteLabelOrigin, service.APIKeyPrefix+"acme")
+	assert.Equal(t, whiteLabelOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = corsRequest(r, http.MethodGet, whiteLabelOrigin, service.APIKeyPrefix+"other")
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	authSvc.AssertNotCalled(t, "ValidateToken", mock.Anything)
+}
+
+func TestTenantCORS_CachesLookups(t *testing.T) {
+	r, _, origins := tenantCORSRouter(uuid.New())
+
+	corsRequest(r, http.MethodOptions, whiteLabelOrigin, "")
+	corsRequest(r, http.MethodOptions, whiteLabelOrigin, "")
+
+	origins.AssertNumberOfCalls(t, "TenantsByOrigin", 1)
+}
+
+func TestTenantCORS_DoesNotCacheUnregisteredOrigins(t *testing.T) {
+	r, _, origins := tenantCORSRouter(uuid.New())
+
+	corsRequest(r, http.MethodOptions, "https://random-1.example", "")
+	corsRequest(r, http.MethodOptions, "https://random-1.example", "")
+
+	origins.AssertNumberOfCalls(t, "TenantsByOrigin", 2)
+}
+
+func TestTenantCORS_LookupErrorDenies(t *testing.T) {
+	origins := new(mocks.MockTenantCORSOriginRepo)
+	origins.On("TenantsByOrigin", mock.Anything, whiteLabelOrigin).Return(nil, errors.New("db down"))
+	r := gin.New()
+	r.Use(middleware.TenantCORS(nil, nil, origins, time.Minute))
+	r.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := corsRequest(r, http.MethodGet, whiteLabelOrigin, "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
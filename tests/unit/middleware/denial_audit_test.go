@@ -0,0 +1,93 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/apierror"
+	"satvos/internal/domain"
+	"satvos/internal/middleware"
+	"satvos/internal/port"
+	"satvos/mocks"
+)
+
+func newDenialAuditRouter(repo port.AuthzDenialRepository, tenantID, userID uuid.UUID) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("request_id", "req-1")
+		c.Set(middleware.ContextKeyTenantID, tenantID)
+		c.Set(middleware.ContextKeyUserID, userID)
+		c.Set(middleware.ContextKeyRole, "viewer")
+		c.Next()
+	})
+	r.Use(middleware.AuditDenials(repo))
+	r.GET("/collections/:id", func(c *gin.Context) {
+		apierror.Respond(c, http.StatusForbidden, "FORBIDDEN", "insufficient collection permission")
+	})
+	r.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/missing", func(c *gin.Context) {
+		apierror.Respond(c, http.StatusNotFound, "NOT_FOUND", "resource not found")
+	})
+	return r
+}
+
+func TestAuditDenials_RecordsForbidden(t *testing.T) {
+	repo := new(mocks.MockAuthzDenialRepo)
+	tenantID, userID := uuid.New(), uuid.New()
+	repo.On("Create", mock.Anything, &domain.AuthzDenial{
+		TenantID: tenantID, UserID: userID, Role: "viewer",
+		Method: http.MethodGet, Path: "/collections/abc", Route: "/collections/:id",
+		Code: "FORBIDDEN", Reason: "insufficient collection permission", RequestID: "req-1",
+	}).Return(nil)
+	r := newDenialAuditRouter(repo, tenantID, userID)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/collections/abc", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	repo.AssertExpectations(t)
+}
+
+func TestAuditDenials_IgnoresOtherStatuses(t *testing.T) {
+	repo := new(mocks.MockAuthzDenialRepo)
+	r := newDenialAuditRouter(repo, uuid.New(), uuid.New())
+
+	for _, path := range []string{"/ok", "/missing"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, http.NoBody)
+		r.ServeHTTP(w, req)
+	}
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuditDenials_WriteFailureKeepsResponse(t *testing.T) {
+	repo := new(mocks.MockAuthzDenialRepo)
+	repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+	r := newDenialAuditRouter(repo, uuid.New(), uuid.New())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/collections/abc", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "FORBIDDEN")
+}
+
+func TestAuditDenials_NilRepoDisabled(t *testing.T) {
+	r := newDenialAuditRouter(nil, uuid.New(), uuid.New())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/collections/abc", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
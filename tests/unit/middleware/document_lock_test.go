@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/middleware"
+	"satvos/mocks"
+)
+
+func lockedRouter(locks *mocks.MockDocumentLockRepo, users *mocks.MockUserRepo, tenantID, userID uuid.UUID) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextKeyTenantID, tenantID)
+		c.Set(middleware.ContextKeyUserID, userID)
+		c.Next()
+	})
+	r.PUT("/documents/:id", middleware.RequireDocumentUnlocked(locks, users), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func TestRequireDocumentUnlocked_OtherHolderIsRejected(t *testing.T) {
+	locks, users := new(mocks.MockDocumentLockRepo), new(mocks.MockUserRepo)
+	tenantID, userID, holderID, docID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	locks.On("GetActive", mock.Anything, tenantID, docID).Return(&domain.DocumentLock{
+		DocumentID: docID, TenantID: tenantID, UserID: holderID, ExpiresAt: time.Now().Add(90 * time.Second),
+	}, nil)
+	users.On("GetByID", mock.Anything, tenantID, holderID).Return(&domain.User{ID: holderID, FullName: "Asha"}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/documents/"+docID.String(), http.NoBody)
+	lockedRouter(locks, users, tenantID, userID).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusLocked, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	var resp map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	errObj := resp["error"].(map[string]interface{})
+	assert.Equal(t, "DOCUMENT_LOCKED", errObj["code"])
+	assert.Contains(t, errObj["message"], "reviewed by Asha")
+}
+
+func TestRequireDocumentUnlocked_HolderAndFreeDocumentPass(t *testing.T) {
+	locks, users := new(mocks.MockDocumentLockRepo), new(mocks.MockUserRepo)
+	tenantID, userID, ownDoc, freeDoc := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	locks.On("GetActive", mock.Anything, tenantID, ownDoc).Return(&domain.DocumentLock{
+		DocumentID: ownDoc, TenantID: tenantID, UserID: userID, ExpiresAt: time.Now().Add(time.Minute),
+	}, nil)
+	locks.On("GetActive", mock.Anything, tenantID, freeDoc).Return(nil, nil)
+	r := lockedRouter(locks, users, tenantID, userID)
+
+	for _, docID := range []uuid.UUID{ownDoc, freeDoc} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/documents/"+docID.String(), http.NoBody)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
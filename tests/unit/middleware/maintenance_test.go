@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"satvos/internal/middleware"
+)
+
+func newMaintenanceRouter(mode *middleware.MaintenanceMode) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.Maintenance(mode, "/auth/login"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/items", ok)
+	r.POST("/items", ok)
+	r.POST("/auth/login", ok)
+	return r
+}
+
+func TestMaintenance_DisabledAllowsWrites(t *testing.T) {
+	r := newMaintenanceRouter(middleware.NewMaintenanceMode(false, 120))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMaintenance_EnabledRejectsWrites(t *testing.T) {
+	r := newMaintenanceRouter(middleware.NewMaintenanceMode(true, 120))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "120", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "MAINTENANCE")
+}
+
+func TestMaintenance_EnabledAllowsReads(t *testing.T) {
+	r := newMaintenanceRouter(middleware.NewMaintenanceMode(true, 120))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMaintenance_ExemptPathAllowed(t *testing.T) {
+	r := newMaintenanceRouter(middleware.NewMaintenanceMode(true, 120))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/auth/login", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMaintenance_ToggleAtRuntime(t *testing.T) {
+	mode := middleware.NewMaintenanceMode(false, 60)
+	r := newMaintenanceRouter(mode)
+
+	mode.Set(true)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	mode.Set(false)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,74 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"satvos/internal/middleware"
+)
+
+func newRateLimitRouter(limit int, window time.Duration) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if id, err := uuid.Parse(c.GetHeader("X-User")); err == nil {
+			c.Set(middleware.ContextKeyUserID, id)
+		}
+		c.Next()
+	})
+	r.Use(middleware.RateLimitPerUser(limit, window))
+	r.POST("/preview", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func postAs(r *gin.Engine, userID uuid.UUID) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/preview", http.NoBody)
+	req.Header.Set("X-User", userID.String())
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitPerUser_BlocksOverLimit(t *testing.T) {
+	r := newRateLimitRouter(2, time.Minute)
+	userID := uuid.New()
+
+	assert.Equal(t, http.StatusOK, postAs(r, userID).Code)
+	assert.Equal(t, http.StatusOK, postAs(r, userID).Code)
+	w := postAs(r, userID)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), "RATE_LIMITED")
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+}
+
+func TestRateLimitPerUser_CountsUsersSeparately(t *testing.T) {
+	r := newRateLimitRouter(1, time.Minute)
+
+	assert.Equal(t, http.StatusOK, postAs(r, uuid.New()).Code)
+	assert.Equal(t, http.StatusOK, postAs(r, uuid.New()).Code)
+}
+
+func TestRateLimitPerUser_WindowResets(t *testing.T) {
+	r := newRateLimitRouter(1, 20*time.Millisecond)
+	userID := uuid.New()
+
+	assert.Equal(t, http.StatusOK, postAs(r, userID).Code)
+	assert.Equal(t, http.StatusTooManyRequests, postAs(r, userID).Code)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, http.StatusOK, postAs(r, userID).Code)
+}
+
+func TestRateLimitPerUser_ZeroDisables(t *testing.T) {
+	r := newRateLimitRouter(0, time.Minute)
+	userID := uuid.New()
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, postAs(r, userID).Code)
+	}
+}
@@ -0,0 +1,173 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/middleware"
+	"satvos/mocks"
+)
+
+func newActiveTenantRouter(tenantRepo *mocks.MockTenantRepo, tenantID uuid.UUID) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextKeyTenantID, tenantID)
+		c.Next()
+	})
+	r.Use(middleware.RequireActiveTenant(tenantRepo, "blue", time.Minute))
+	r.GET("/items", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func TestRequireActiveTenant_Active(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, IsActive: true}, nil).Once()
+	r := newActiveTenantRouter(tenantRepo, tenantID)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	// Second request served from cache
+	tenantRepo.AssertNumberOfCalls(t, "GetByID", 1)
+}
+
+func TestRequireActiveTenant_Suspended(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, IsActive: false}, nil)
+	r := newActiveTenantRouter(tenantRepo, tenantID)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "TENANT_INACTIVE")
+}
+
+func TestRequireActiveTenant_MovedToOtherCluster(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).
+		Return(&domain.Tenant{ID: tenantID, IsActive: true, DBCluster: "green"}, nil)
+	r := newActiveTenantRouter(tenantRepo, tenantID)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMisdirectedRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "TENANT_MOVED")
+}
+
+func TestRequireActiveTenant_ServedByThisCluster(t *testing.T) {
+	for _, cluster := range []string{"", "blue"} {
+		tenantRepo := new(mocks.MockTenantRepo)
+		tenantID := uuid.New()
+		tenantRepo.On("GetByID", mock.Anything, tenantID).
+			Return(&domain.Tenant{ID: tenantID, IsActive: true, DBCluster: cluster}, nil)
+		r := newActiveTenantRouter(tenantRepo, tenantID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, "db_cluster %q", cluster)
+	}
+}
+
+func TestRequireActiveTenant_DeletedTenant(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(nil, domain.ErrNotFound)
+	r := newActiveTenantRouter(tenantRepo, tenantID)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestRequireActiveTenant_LookupErrorFailsOpen(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(nil, errors.New("db down"))
+	r := newActiveTenantRouter(tenantRepo, tenantID)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func newActiveMembershipRouter(userRepo *mocks.MockUserRepo, tenantID, userID uuid.UUID, homeTenantID *uuid.UUID) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextKeyTenantID, tenantID)
+		c.Set(middleware.ContextKeyUserID, userID)
+		if homeTenantID != nil {
+			c.Set(middleware.ContextKeyHomeTenantID, *homeTenantID)
+		}
+		c.Next()
+	})
+	r.Use(middleware.RequireActiveMembership(userRepo, time.Minute))
+	r.GET("/items", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func TestRequireActiveMembership_HomeTokenSkipsLookup(t *testing.T) {
+	userRepo := new(mocks.MockUserRepo)
+	r := newActiveMembershipRouter(userRepo, uuid.New(), uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRequireActiveMembership_ActiveGuest(t *testing.T) {
+	userRepo := new(mocks.MockUserRepo)
+	tenantID, userID, homeID := uuid.New(), uuid.New(), uuid.New()
+	userRepo.On("GetByID", mock.Anything, tenantID, userID).
+		Return(&domain.User{ID: userID, TenantID: tenantID, HomeTenantID: &homeID}, nil).Once()
+	r := newActiveMembershipRouter(userRepo, tenantID, userID, &homeID)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	userRepo.AssertNumberOfCalls(t, "GetByID", 1)
+}
+
+func TestRequireActiveMembership_Revoked(t *testing.T) {
+	userRepo := new(mocks.MockUserRepo)
+	tenantID, userID, homeID := uuid.New(), uuid.New(), uuid.New()
+	userRepo.On("GetByID", mock.Anything, tenantID, userID).Return(nil, domain.ErrNotFound)
+	r := newActiveMembershipRouter(userRepo, tenantID, userID, &homeID)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/items", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "TENANT_ACCESS_DENIED")
+}
@@ -0,0 +1,221 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/notify"
+	"satvos/internal/port"
+	"satvos/mocks"
+)
+
+func resetNotification(tenantID uuid.UUID) *port.Notification {
+	return &port.Notification{
+		Kind:       domain.NotificationKindPasswordReset,
+		TenantID:   tenantID,
+		Recipients: []port.NotificationRecipient{{UserID: uuid.New(), Email: "a@acme.com", Name: "A"}},
+		Subject:    "Password reset requested",
+		Token:      "secret-token",
+	}
+}
+
+func TestRouter_UsesDefaultsWithoutOverride(t *testing.T) {
+	email, inApp := new(mocks.MockNotifier), new(mocks.MockNotifier)
+	routes := new(mocks.MockNotificationRouteRepo)
+	tenantID := uuid.New()
+	r := notify.NewRouter(
+		map[domain.NotifierChannel]port.Notifier{domain.NotifierChannelEmail: email, domain.NotifierChannelInApp: inApp},
+		map[domain.NotificationKind][]domain.NotifierChannel{
+			domain.NotificationKindPasswordReset: {domain.NotifierChannelEmail, domain.NotifierChannelInApp},
+		},
+		routes)
+	routes.On("ListByTenant", mock.Anything, tenantID).Return([]domain.NotificationRoute{}, nil)
+
+	var emailed, stored *port.Notification
+	email.On("Notify", mock.Anything, mock.Anything).Run(func(args mock.Arguments) { emailed = args.Get(1).(*port.Notification) }).Return(nil)
+	inApp.On("Notify", mock.Anything, mock.Anything).Run(func(args mock.Arguments) { stored = args.Get(1).(*port.Notification) }).Return(nil)
+
+	require.NoError(t, r.Notify(context.Background(), resetNotification(tenantID)))
+	assert.Equal(t, "secret-token", emailed.Token)
+	assert.Empty(t, stored.Token, "only email may carry the token")
+	assert.Equal(t, "Password reset requested", stored.Subject)
+}
+
+func TestRouter_QuotaWarningDefaultsToEmailAndInApp(t *testing.T) {
+	r := notify.NewRouter(map[domain.NotifierChannel]port.Notifier{}, nil, nil)
+
+	assert.Equal(t, []domain.NotifierChannel{domain.NotifierChannelEmail, domain.NotifierChannelInApp},
+		r.Default(domain.NotificationKindQuotaWarning))
+	assert.Equal(t, []domain.NotifierChannel{domain.NotifierChannelEmail}, r.Default(domain.NotificationKindIngestionReport))
+
+	configured := notify.NewRouter(map[domain.NotifierChannel]port.Notifier{},
+		map[domain.NotificationKind][]domain.NotifierChannel{domain.NotificationKindQuotaWarning: {domain.NotifierChannelSlack}}, nil)
+	assert.Equal(t, []domain.NotifierChannel{domain.NotifierChannelSlack}, configured.Default(domain.NotificationKindQuotaWarning))
+}
+
+func TestRouter_TenantOverride(t *testing.T) {
+	email, slack := new(mocks.MockNotifier), new(mocks.MockNotifier)
+	routes := new(mocks.MockNotificationRouteRepo)
+	tenantID := uuid.New()
+	r := notify.NewRouter(
+		map[domain.NotifierChannel]port.Notifier{domain.NotifierChannelEmail: email, domain.NotifierChannelSlack: slack},
+		nil, routes)
+	routes.On("ListByTenant", mock.Anything, tenantID).Return([]domain.NotificationRoute{
+		{TenantID: tenantID, Kind: domain.NotificationKindIngestionReport, Channels: pq.StringArray{"slack"}},
+	}, nil)
+	slack.On("Notify", mock.Anything, mock.Anything).Return(nil)
+
+	err := r.Notify(context.Background(), &port.Notification{Kind: domain.NotificationKindIngestionReport, TenantID: tenantID})
+
+	require.NoError(t, err)
+	slack.AssertNumberOfCalls(t, "Notify", 1)
+	email.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func TestRouter_SkipsUnconfiguredAndJoinsErrors(t *testing.T) {
+	email := new(mocks.MockNotifier)
+	r := notify.NewRouter(
+		map[domain.NotifierChannel]port.Notifier{domain.NotifierChannelEmail: email},
+		map[domain.NotificationKind][]domain.NotifierChannel{
+			domain.NotificationKindIngestionReport: {domain.NotifierChannelWebhook, domain.NotifierChannelEmail},
+		},
+		nil)
+	email.On("Notify", mock.Anything, mock.Anything).Return(errors.New("ses down"))
+
+	err := r.Notify(context.Background(), &port.Notification{Kind: domain.NotificationKindIngestionReport, TenantID: uuid.New()})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "email: ses down")
+}
+
+func TestRouter_RouteLoadFailureFallsBackToDefault(t *testing.T) {
+	email := new(mocks.MockNotifier)
+	routes := new(mocks.MockNotificationRouteRepo)
+	tenantID := uuid.New()
+	r := notify.NewRouter(map[domain.NotifierChannel]port.Notifier{domain.NotifierChannelEmail: email}, nil, routes)
+	routes.On("ListByTenant", mock.Anything, tenantID).Return(nil, errors.New("db down"))
+	email.On("Notify", mock.Anything, mock.Anything).Return(nil)
+
+	require.NoError(t, r.Notify(context.Background(), resetNotification(tenantID)))
+	email.AssertNumberOfCalls(t, "Notify", 1)
+}
+
+func TestValidateRoute(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     domain.NotificationKind
+		channels []domain.NotifierChannel
+		wantErr  bool
+	}{
+		{"report on slack", domain.NotificationKindIngestionReport, []domain.NotifierChannel{"slack"}, false},
+		{"reset with email", domain.NotificationKindPasswordReset, []domain.NotifierChannel{"email", "in_app"}, false},
+		{"reset without email", domain.NotificationKindPasswordReset, []domain.NotifierChannel{"in_app"}, true},
+		{"unknown kind", "digest", []domain.NotifierChannel{"email"}, true},
+		{"unknown channel", domain.NotificationKindIngestionReport, []domain.NotifierChannel{"sms"}, true},
+		{"duplicate channel", domain.NotificationKindIngestionReport, []domain.NotifierChannel{"email", "email"}, true},
+		{"no channels", domain.NotificationKindIngestionReport, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := notify.ValidateRoute(tt.kind, tt.channels)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidNotificationRoutes)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseRoutes(t *testing.T) {
+	routes, err := notify.ParseRoutes(map[string]string{"ingestion_report": "email + slack"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.NotifierChannel{"email", "slack"}, routes[domain.NotificationKindIngestionReport])
+
+	_, err = notify.ParseRoutes(map[string]string{"email_verification": "slack"})
+	assert.ErrorIs(t, err, domain.ErrInvalidNotificationRoutes)
+}
+
+func TestEmailNotifier_SendsByKind(t *testing.T) {
+	sender := new(mocks.MockEmailSender)
+	n := notify.NewEmailNotifier(sender)
+	ctx := context.Background()
+	report := &port.IngestionReport{Imported: 2}
+	sender.On("SendPasswordResetEmail", ctx, "a@acme.com", "A", "secret-token").Return(nil)
+	sender.On("SendIngestionReport", ctx, "b@acme.com", "B", report).Return(nil)
+
+	require.NoError(t, n.Notify(ctx, resetNotification(uuid.New())))
+	require.NoError(t, n.Notify(ctx, &port.Notification{
+		Kind:            domain.NotificationKindIngestionReport,
+		Recipients:      []port.NotificationRecipient{{Email: "b@acme.com", Name: "B"}},
+		IngestionReport: report,
+	}))
+	sender.AssertExpectations(t)
+}
+
+func TestSlackAndWebhookNotifiers_PostOnce(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n := &port.Notification{
+		Kind:     domain.NotificationKindIngestionReport,
+		TenantID: uuid.New(),
+		Recipients: []port.NotificationRecipient{
+			{Email: "a@acme.com", Name: "A"}, {Email: "b@acme.com", Name: "B"},
+		},
+		Subject: "Batch feed report",
+		Body:    "2 imported",
+	}
+	require.NoError(t, notify.NewSlackNotifier(srv.URL, srv.Client()).Notify(context.Background(), n))
+	require.NoError(t, notify.NewWebhookNotifier(srv.URL, srv.Client()).Notify(context.Background(), n))
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, "*Batch feed report*\n2 imported", bodies[0]["text"])
+	assert.Equal(t, "ingestion_report", bodies[1]["kind"])
+	assert.Len(t, bodies[1]["recipients"], 2)
+}
+
+func TestWebhookNotifier_RejectedStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := notify.NewWebhookNotifier(srv.URL, srv.Client()).Notify(context.Background(), resetNotification(uuid.New()))
+	assert.Error(t, err)
+}
+
+func TestInAppNotifier_StoresPerUser(t *testing.T) {
+	repo := new(mocks.MockInAppNotificationRepo)
+	tenantID, userID := uuid.New(), uuid.New()
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.InAppNotification) bool {
+		return n.TenantID == tenantID && n.UserID == userID && n.Subject == "Hello"
+	})).Return(nil)
+
+	err := notify.NewInAppNotifier(repo).Notify(context.Background(), &port.Notification{
+		Kind:       domain.NotificationKindIngestionReport,
+		TenantID:   tenantID,
+		Recipients: []port.NotificationRecipient{{UserID: userID}, {Email: "no-user@acme.com"}},
+		Subject:    "Hello",
+	})
+
+	require.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "Create", 1)
+}
@@ -0,0 +1,34 @@
+package pagerender_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"satvos/internal/domain"
+	"satvos/internal/pagerender/pdftoppm"
+)
+
+func TestRenderer_MissingBinary(t *testing.T) {
+	r := pdftoppm.NewRenderer("satvos-no-such-pdftoppm")
+
+	_, err := r.RenderPage(context.Background(), []byte("%PDF-1.4"), 1, 150)
+
+	assert.ErrorIs(t, err, domain.ErrPageRenderUnavailable)
+}
+
+func TestRenderer_PageOutOfRange(t *testing.T) {
+	if _, err := exec.LookPath("pdftoppm"); err != nil {
+		t.Skip("pdftoppm not installed")
+	}
+	r := pdftoppm.NewRenderer("pdftoppm")
+	pdf := []byte("%PDF-1.4\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n" +
+		"2 0 obj << /Type /Pages /Kids [3 0 R] /Count 1 >> endobj\n" +
+		"3 0 obj << /Type /Page /Parent 2 0 R /MediaBox [0 0 100 100] >> endobj\ntrailer << /Root 1 0 R >>\n%%EOF")
+
+	_, err := r.RenderPage(context.Background(), pdf, 5, 72)
+
+	assert.ErrorIs(t, err, domain.ErrPageNotFound)
+}
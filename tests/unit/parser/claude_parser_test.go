@@ -354,6 +354,33 @@ func TestClaudeParser_Parse_Truncated(t *testing.T) {
 	assert.Contains(t, err.Error(), "stop_reason: max_tokens")
 }
 
+func TestClaudeParser_Parse_ReportsTokenUsage(t *testing.T) {
+	responseBody := map[string]interface{}{
+		"content": []map[string]interface{}{
+			{"type": "text", "text": `{"data":{},"confidence_scores":{}}`},
+		},
+		"usage": map[string]interface{}{"input_tokens": 2450, "output_tokens": 812},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(responseBody)
+	}))
+	defer server.Close()
+
+	p := newTestParser(server.URL)
+
+	result, err := p.Parse(context.Background(), port.ParseInput{
+		FileBytes:    []byte("%PDF-1.4 test"),
+		ContentType:  "application/pdf",
+		DocumentType: "invoice",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2450, result.InputTokens)
+	assert.Equal(t, 812, result.OutputTokens)
+}
+
 func TestClaudeParser_Parse_ConnectionRefused(t *testing.T) {
 	p := newTestParser("http://localhost:1")
 
@@ -367,3 +394,27 @@ func TestClaudeParser_Parse_ConnectionRefused(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "calling anthropic API")
 }
+
+func TestClaudeParser_Parse_DeadlineReturnsTimeoutError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	p := newTestParser(server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := p.Parse(ctx, port.ParseInput{
+		FileBytes:    []byte("%PDF-1.4 test"),
+		ContentType:  "application/pdf",
+		DocumentType: "invoice",
+	})
+
+	var toErr *parser.TimeoutError
+	require.True(t, errors.As(err, &toErr))
+	assert.Equal(t, "claude", toErr.Provider)
+}
@@ -58,3 +58,20 @@ func TestParseRetryAfterHeader(t *testing.T) {
 	assert.Equal(t, 0, parser.ParseRetryAfterHeader("invalid"))
 	assert.Equal(t, 120, parser.ParseRetryAfterHeader("120"))
 }
+
+func TestTransientError_ErrorsAs(t *testing.T) {
+	wrapped := fmt.Errorf("all parsers failed: %w", parser.NewTransientError("gemini", fmt.Errorf("connection reset")))
+
+	var target *parser.TransientError
+	assert.True(t, errors.As(wrapped, &target))
+	assert.Equal(t, "gemini", target.Provider)
+	assert.Contains(t, wrapped.Error(), "connection reset")
+}
+
+func TestIsTransientStatus(t *testing.T) {
+	assert.True(t, parser.IsTransientStatus(500))
+	assert.True(t, parser.IsTransientStatus(503))
+	assert.True(t, parser.IsTransientStatus(529))
+	assert.False(t, parser.IsTransientStatus(400))
+	assert.False(t, parser.IsTransientStatus(429))
+}
@@ -0,0 +1,108 @@
+package parser_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/parser"
+	"satvos/internal/parser/fake"
+	"satvos/internal/port"
+	"satvos/internal/validator/invoice"
+)
+
+func fakeInput(content string) port.ParseInput {
+	return port.ParseInput{FileBytes: []byte(content), ContentType: "application/pdf", DocumentType: "invoice"}
+}
+
+func TestFakeParser_Deterministic(t *testing.T) {
+	p := fake.NewParser(config.FakeParserConfig{})
+
+	first, err := p.Parse(context.Background(), fakeInput("invoice-a"))
+	require.NoError(t, err)
+	second, err := p.Parse(context.Background(), fakeInput("invoice-a"))
+	require.NoError(t, err)
+	other, err := p.Parse(context.Background(), fakeInput("invoice-b"))
+	require.NoError(t, err)
+
+	assert.Equal(t, fake.Model, first.ModelUsed)
+	assert.NotEmpty(t, first.PromptUsed)
+	assert.JSONEq(t, string(first.StructuredData), string(second.StructuredData))
+	assert.JSONEq(t, string(first.ConfidenceScores), string(second.ConfidenceScores))
+	assert.NotEqual(t, string(first.StructuredData), string(other.StructuredData))
+}
+
+func TestFakeParser_PassesBuiltinValidators(t *testing.T) {
+	p := fake.NewParser(config.FakeParserConfig{})
+
+	for i := 0; i < 25; i++ {
+		out, err := p.Parse(context.Background(), fakeInput(fmt.Sprintf("file-%d", i)))
+		require.NoError(t, err)
+
+		var inv invoice.GSTInvoice
+		require.NoError(t, json.Unmarshal(out.StructuredData, &inv))
+		var scores invoice.ConfidenceScores
+		require.NoError(t, json.Unmarshal(out.ConfidenceScores, &scores))
+		assert.Len(t, scores.LineItems, len(inv.LineItems))
+
+		for _, v := range invoice.AllBuiltinValidators() {
+			for _, r := range v.Validate(context.Background(), &inv) {
+				assert.True(t, r.Passed, "file-%d: %s %s: %s", i, v.RuleKey(), r.FieldPath, r.Message)
+			}
+		}
+	}
+}
+
+func TestFakeParser_FailMarker(t *testing.T) {
+	p := fake.NewParser(config.FakeParserConfig{})
+
+	for i := 0; i < 2; i++ {
+		out, err := p.Parse(context.Background(), fakeInput("%PDF FAKE_PARSER_FAIL"))
+		assert.Nil(t, out)
+		require.Error(t, err)
+		var rlErr *parser.RateLimitError
+		assert.False(t, errors.As(err, &rlErr))
+	}
+}
+
+func TestFakeParser_RateLimitedOnce(t *testing.T) {
+	p := fake.NewParser(config.FakeParserConfig{RetryAfterSecs: 3})
+
+	_, err := p.Parse(context.Background(), fakeInput("%PDF FAKE_PARSER_RATE_LIMIT"))
+	var rlErr *parser.RateLimitError
+	require.True(t, errors.As(err, &rlErr))
+	assert.Equal(t, "fake", rlErr.Provider)
+	assert.Equal(t, "3s", rlErr.RetryAfter.String())
+
+	out, err := p.Parse(context.Background(), fakeInput("%PDF FAKE_PARSER_RATE_LIMIT"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, out.StructuredData)
+}
+
+func TestFakeParser_Percentages(t *testing.T) {
+	failing := fake.NewParser(config.FakeParserConfig{FailPercent: 100})
+	_, err := failing.Parse(context.Background(), fakeInput("anything"))
+	assert.Error(t, err)
+
+	limited := fake.NewParser(config.FakeParserConfig{RateLimitPercent: 100})
+	_, err = limited.Parse(context.Background(), fakeInput("anything"))
+	var rlErr *parser.RateLimitError
+	assert.True(t, errors.As(err, &rlErr))
+	_, err = limited.Parse(context.Background(), fakeInput("anything"))
+	assert.NoError(t, err)
+}
+
+func TestFakeParser_CancelledContext(t *testing.T) {
+	p := fake.NewParser(config.FakeParserConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := p.Parse(ctx, fakeInput("invoice-a"))
+	assert.ErrorIs(t, err, context.Canceled)
+}
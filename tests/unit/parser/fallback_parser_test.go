@@ -157,6 +157,56 @@ func TestFallbackParser_AllFail_NonRateLimit(t *testing.T) {
 	assert.False(t, errors.As(err, &rlErr))
 }
 
+func TestFallbackParser_LastResort_UsedWhenAllFail(t *testing.T) {
+	p1 := new(mocks.MockDocumentParser)
+	ocr := new(mocks.MockDocumentParser)
+
+	input := port.ParseInput{FileBytes: []byte("test"), ContentType: "application/pdf", DocumentType: "invoice"}
+	p1.On("Parse", mock.Anything, input).Return(nil, parser.NewRateLimitError("claude", errors.New("429"), 60))
+	ocr.On("Parse", mock.Anything, input).Return(fallbackOutput("tesseract-ocr"), nil)
+
+	fp := parser.NewFallbackParser([]port.DocumentParser{p1}, []string{"claude"}).WithLastResort(ocr, "tesseract")
+
+	result, err := fp.Parse(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Equal(t, "tesseract-ocr", result.ModelUsed)
+}
+
+func TestFallbackParser_LastResort_NotTriedOnSuccess(t *testing.T) {
+	p1 := new(mocks.MockDocumentParser)
+	ocr := new(mocks.MockDocumentParser)
+
+	input := port.ParseInput{FileBytes: []byte("test"), ContentType: "application/pdf", DocumentType: "invoice"}
+	p1.On("Parse", mock.Anything, input).Return(fallbackOutput("claude"), nil)
+
+	fp := parser.NewFallbackParser([]port.DocumentParser{p1}, []string{"claude"}).WithLastResort(ocr, "tesseract")
+
+	result, err := fp.Parse(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Equal(t, "claude", result.ModelUsed)
+	ocr.AssertNotCalled(t, "Parse", mock.Anything, mock.Anything)
+}
+
+func TestFallbackParser_LastResort_FailureKeepsRateLimit(t *testing.T) {
+	p1 := new(mocks.MockDocumentParser)
+	ocr := new(mocks.MockDocumentParser)
+
+	input := port.ParseInput{FileBytes: []byte("test"), ContentType: "application/pdf", DocumentType: "invoice"}
+	p1.On("Parse", mock.Anything, input).Return(nil, parser.NewRateLimitError("claude", errors.New("429"), 60))
+	ocr.On("Parse", mock.Anything, input).Return(nil, errors.New("tesseract read no text"))
+
+	fp := parser.NewFallbackParser([]port.DocumentParser{p1}, []string{"claude"}).WithLastResort(ocr, "tesseract")
+
+	_, err := fp.Parse(context.Background(), input)
+
+	// The document is still queued for retry, not failed by the OCR error
+	var rlErr *parser.RateLimitError
+	require.True(t, errors.As(err, &rlErr))
+	assert.Equal(t, "all", rlErr.Provider)
+}
+
 func TestFallbackParser_CircuitAutoCloses(t *testing.T) {
 	p1 := new(mocks.MockDocumentParser)
 	p2 := new(mocks.MockDocumentParser)
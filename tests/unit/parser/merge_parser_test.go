@@ -65,6 +65,29 @@ func TestMergeParser_BothSucceed_Agreement(t *testing.T) {
 	assert.Greater(t, mergedConf.Invoice.InvoiceNumber, 0.8)
 }
 
+func TestMergeParser_BothSucceed_SumsTokens(t *testing.T) {
+	primary := new(mocks.MockDocumentParser)
+	secondary := new(mocks.MockDocumentParser)
+	mp := parser.NewMergeParser(primary, secondary)
+
+	inv := invoice.GSTInvoice{Invoice: invoice.InvoiceHeader{InvoiceNumber: "INV-001"}}
+	conf := invoice.ConfidenceScores{}
+	input := port.ParseInput{FileBytes: []byte("test"), ContentType: "application/pdf", DocumentType: "invoice"}
+
+	pOut := makeParseOutput(&inv, &conf, "claude")
+	pOut.InputTokens, pOut.OutputTokens = 2000, 700
+	sOut := makeParseOutput(&inv, &conf, "gemini")
+	sOut.InputTokens, sOut.OutputTokens = 1800, 650
+	primary.On("Parse", mock.Anything, input).Return(pOut, nil)
+	secondary.On("Parse", mock.Anything, input).Return(sOut, nil)
+
+	result, err := mp.Parse(context.Background(), input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3800, result.InputTokens)
+	assert.Equal(t, 1350, result.OutputTokens)
+}
+
 func TestMergeParser_BothSucceed_Disagreement(t *testing.T) {
 	primary := new(mocks.MockDocumentParser)
 	secondary := new(mocks.MockDocumentParser)
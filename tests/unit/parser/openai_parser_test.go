@@ -228,6 +228,10 @@ func TestOpenAIParser_Parse_ServerError(t *testing.T) {
 
 	var rlErr *parser.RateLimitError
 	assert.False(t, errors.As(err, &rlErr))
+
+	var trErr *parser.TransientError
+	require.True(t, errors.As(err, &trErr))
+	assert.Equal(t, "openai", trErr.Provider)
 }
 
 func TestOpenAIParser_Parse_EmptyResponse(t *testing.T) {
@@ -382,3 +386,75 @@ func TestOpenAIParser_Parse_Truncated(t *testing.T) {
 	assert.Contains(t, err.Error(), "output truncated")
 	assert.Contains(t, err.Error(), "finish_reason: length")
 }
+
+func TestLocalParser_Parse_Success(t *testing.T) {
+	llmJSON := `{"data":{"invoice":{"invoice_number":"INV-001"}},"confidence_scores":{"invoice":{"invoice_number":0.9}}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		var reqBody map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+		assert.Equal(t, "qwen2.5-vl-7b", reqBody["model"])
+		assert.Equal(t, float64(16384), reqBody["max_tokens"])
+		assert.NotContains(t, reqBody, "max_completion_tokens")
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(openaiSuccessResponse(llmJSON))
+	}))
+	defer server.Close()
+
+	p, err := openai.NewLocalParser(&config.ParserProviderConfig{
+		Provider:     "local",
+		BaseURL:      server.URL + "/v1/",
+		DefaultModel: "qwen2.5-vl-7b",
+		TimeoutSecs:  30,
+	})
+	require.NoError(t, err)
+
+	result, err := p.Parse(context.Background(), port.ParseInput{
+		FileBytes:    []byte{0xFF, 0xD8, 0xFF},
+		ContentType:  "image/jpeg",
+		DocumentType: "invoice",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "qwen2.5-vl-7b", result.ModelUsed)
+	assert.JSONEq(t, `{"invoice":{"invoice_number":"INV-001"}}`, string(result.StructuredData))
+}
+
+func TestLocalParser_Parse_ServerErrorLabelledLocal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`model loading`))
+	}))
+	defer server.Close()
+
+	p, err := openai.NewLocalParser(&config.ParserProviderConfig{
+		Provider:     "local",
+		BaseURL:      server.URL,
+		DefaultModel: "llava",
+		TimeoutSecs:  30,
+	})
+	require.NoError(t, err)
+
+	_, err = p.Parse(context.Background(), port.ParseInput{
+		FileBytes:    []byte{0x89, 0x50, 0x4E, 0x47},
+		ContentType:  "image/png",
+		DocumentType: "invoice",
+	})
+
+	var trErr *parser.TransientError
+	require.True(t, errors.As(err, &trErr))
+	assert.Equal(t, "local", trErr.Provider)
+	assert.Contains(t, err.Error(), "local API error (status 503)")
+}
+
+func TestNewLocalParser_RequiresBaseURLAndModel(t *testing.T) {
+	_, err := openai.NewLocalParser(&config.ParserProviderConfig{Provider: "local", DefaultModel: "llava"})
+	assert.ErrorContains(t, err, "base_url")
+
+	_, err = openai.NewLocalParser(&config.ParserProviderConfig{Provider: "local", BaseURL: "http://ollama:11434/v1"})
+	assert.ErrorContains(t, err, "default_model")
+}
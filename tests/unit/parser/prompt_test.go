@@ -0,0 +1,41 @@
+package parser_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/language"
+	"satvos/internal/parser"
+	"satvos/internal/parser/fake"
+)
+
+func TestBuildGSTInvoicePromptForLanguage(t *testing.T) {
+	plain := parser.BuildGSTInvoicePrompt("invoice")
+
+	assert.Equal(t, plain, parser.BuildGSTInvoicePromptForLanguage("invoice", ""))
+	assert.Equal(t, plain, parser.BuildGSTInvoicePromptForLanguage("invoice", language.English))
+	assert.Equal(t, plain, parser.BuildGSTInvoicePromptForLanguage("invoice", "xx"))
+
+	tamil := parser.BuildGSTInvoicePromptForLanguage("invoice", "ta")
+	assert.True(t, len(tamil) > len(plain))
+	assert.Contains(t, tamil, plain)
+	assert.Contains(t, tamil, "LANGUAGE:")
+	assert.Contains(t, tamil, "Tamil (Tamil script)")
+
+	hindi := parser.BuildGSTInvoicePromptForLanguage("invoice", "hi")
+	assert.Contains(t, hindi, "Hindi (Devanagari script)")
+}
+
+func TestFakeParser_UsesLanguagePrompt(t *testing.T) {
+	p := fake.NewParser(config.FakeParserConfig{})
+	input := fakeInput("invoice-a")
+	input.Language = "gu"
+
+	out, err := p.Parse(context.Background(), input)
+	require.NoError(t, err)
+	assert.Contains(t, out.PromptUsed, "Gujarati (Gujarati script)")
+}
@@ -0,0 +1,101 @@
+package parser_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/parser"
+	"satvos/internal/port"
+)
+
+func TestRateLimitTracker_Allow(t *testing.T) {
+	future := time.Now().Add(time.Minute)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		headroom *parser.RateLimitHeadroom
+		reserve  int
+		want     int
+	}{
+		{"nothing known", nil, 10, 5},
+		{"plenty of requests", &parser.RateLimitHeadroom{RequestsLimit: 100, RequestsRemaining: 50, RequestsReset: future}, 10, 5},
+		{"requests near reserve", &parser.RateLimitHeadroom{RequestsLimit: 100, RequestsRemaining: 12, RequestsReset: future}, 10, 2},
+		{"requests within reserve", &parser.RateLimitHeadroom{RequestsLimit: 100, RequestsRemaining: 4, RequestsReset: future}, 10, 0},
+		{"tokens within reserve", &parser.RateLimitHeadroom{RequestsLimit: 100, RequestsRemaining: 90, RequestsReset: future,
+			TokensLimit: 80000, TokensRemaining: 5000, TokensReset: future}, 10, 0},
+		{"limit already reset", &parser.RateLimitHeadroom{RequestsLimit: 100, RequestsRemaining: 0, RequestsReset: past}, 10, 5},
+		{"pacing off", &parser.RateLimitHeadroom{RequestsLimit: 100, RequestsRemaining: 0, RequestsReset: future}, 0, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := parser.NewRateLimitTracker()
+			if tt.headroom != nil {
+				tt.headroom.Provider = "claude"
+				tracker.Observe(*tt.headroom)
+			}
+			assert.Equal(t, tt.want, tracker.Allow("claude", 5, tt.reserve))
+		})
+	}
+}
+
+func TestRateLimitTracker_IgnoresResponsesWithoutHeaders(t *testing.T) {
+	tracker := parser.NewRateLimitTracker()
+	tracker.Observe(parser.RateLimitHeadroom{Provider: "openai", RequestsLimit: 500, RequestsRemaining: 499})
+	tracker.Observe(parser.OpenAIHeadroom("openai", http.Header{}, time.Now()))
+
+	headroom := tracker.Headroom()
+	require.Len(t, headroom, 1)
+	assert.Equal(t, 499, headroom[0].RequestsRemaining)
+}
+
+func TestOpenAIHeadroom_ParsesResetDurations(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	header := http.Header{}
+	header.Set("x-ratelimit-limit-requests", "500")
+	header.Set("x-ratelimit-remaining-requests", "42")
+	header.Set("x-ratelimit-reset-requests", "6m0s")
+	header.Set("x-ratelimit-limit-tokens", "30000")
+	header.Set("x-ratelimit-remaining-tokens", "1200")
+	header.Set("x-ratelimit-reset-tokens", "1.5s")
+
+	h := parser.OpenAIHeadroom("openai", header, now)
+
+	assert.Equal(t, 500, h.RequestsLimit)
+	assert.Equal(t, 42, h.RequestsRemaining)
+	assert.Equal(t, now.Add(6*time.Minute), h.RequestsReset)
+	assert.Equal(t, 1200, h.TokensRemaining)
+	assert.Equal(t, now.Add(1500*time.Millisecond), h.TokensReset)
+}
+
+func TestClaudeParser_Parse_RecordsRateLimitHeaders(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second).UTC().Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("anthropic-ratelimit-requests-limit", "50")
+		w.Header().Set("anthropic-ratelimit-requests-remaining", "3")
+		w.Header().Set("anthropic-ratelimit-requests-reset", reset.Format(time.RFC3339))
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":"rate limited"}`))
+	}))
+	defer server.Close()
+
+	tracker := parser.NewRateLimitTracker()
+	p := newTestParser(server.URL)
+	p.TrackRateLimits(tracker)
+
+	_, err := p.Parse(context.Background(), port.ParseInput{FileBytes: []byte("%PDF"), ContentType: "application/pdf"})
+	require.Error(t, err)
+
+	headroom := tracker.Headroom()
+	require.Len(t, headroom, 1)
+	assert.Equal(t, "claude", headroom[0].Provider)
+	assert.Equal(t, 3, headroom[0].RequestsRemaining)
+	assert.True(t, reset.Equal(headroom[0].RequestsReset))
+	assert.Equal(t, 0, tracker.Allow("claude", 5, 10))
+}
@@ -0,0 +1,145 @@
+package parser_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	tesseractparser "satvos/internal/parser/tesseract"
+	"satvos/internal/port"
+	"satvos/internal/validator/invoice"
+	"satvos/mocks"
+)
+
+// fakeRecognizer returns one text per call, in order.
+type fakeRecognizer struct {
+	texts  []string
+	err    error
+	images [][]byte
+}
+
+func (f *fakeRecognizer) Recognize(_ context.Context, image []byte) (string, error) {
+	f.images = append(f.images, image)
+	if f.err != nil {
+		return "", f.err
+	}
+	text := f.texts[0]
+	f.texts = f.texts[1:]
+	return text, nil
+}
+
+func ocrTestConfig() config.OCRParserConfig {
+	return config.OCRParserConfig{DPI: 300, MaxPages: 5, TimeoutSecs: 30, Confidence: 0.3}
+}
+
+const sampleOCRText = `TAX INVOICE
+Acme Traders Pvt Ltd
+GSTIN: 29ABCDE1234F1Z5
+Invoice No: INV-42              Invoice Date: 15/01/2024
+Due Date: 14/02/2024
+Bill To: Globex Ltd
+GSTIN 27PQRSX5678K1Z2
+Place of Supply: Karnataka
+1  Steel rods  7214  10  10,000.00  1,00,000.00
+Total Qty 10
+Taxable Value            1,00,000.00
+CGST @ 9%                   9,000.00
+SGST @ 9%                   9,000.00
+Grand Total          Rs. 1,18,000.00
+Amount in words: One Lakh Eighteen Thousand Only
+Bank: HDFC Bank  IFSC: HDFC0001234`
+
+func TestTesseractParser_Parse_ExtractsFields(t *testing.T) {
+	ocr := &fakeRecognizer{texts: []string{sampleOCRText}}
+	p := tesseractparser.NewParserWithRecognizer(ocrTestConfig(), ocr, nil)
+
+	out, err := p.Parse(context.Background(), port.ParseInput{FileBytes: []byte("png"), ContentType: "image/png"})
+	require.NoError(t, err)
+	assert.Equal(t, tesseractparser.Model, out.ModelUsed)
+	assert.Equal(t, [][]byte{[]byte("png")}, ocr.images)
+
+	var inv invoice.GSTInvoice
+	require.NoError(t, json.Unmarshal(out.StructuredData, &inv))
+	assert.Equal(t, "INV-42", inv.Invoice.InvoiceNumber)
+	assert.Equal(t, "15-01-2024", inv.Invoice.InvoiceDate)
+	assert.Equal(t, "Karnataka", inv.Invoice.PlaceOfSupply)
+	assert.Equal(t, "INR", inv.Invoice.Currency)
+	assert.Equal(t, "29ABCDE1234F1Z5", inv.Seller.GSTIN)
+	assert.Equal(t, "29", inv.Seller.StateCode)
+	assert.Equal(t, "27PQRSX5678K1Z2", inv.Buyer.GSTIN)
+	assert.Equal(t, "27", inv.Buyer.StateCode)
+	assert.Equal(t, 100000.0, inv.Totals.TaxableAmount)
+	assert.Equal(t, 100000.0, inv.Totals.Subtotal)
+	assert.Equal(t, 9000.0, inv.Totals.CGST)
+	assert.Equal(t, 9000.0, inv.Totals.SGST)
+	assert.Equal(t, 118000.0, inv.Totals.Total)
+	assert.Equal(t, "HDFC0001234", inv.Payment.IFSCCode)
+	assert.Empty(t, inv.LineItems)
+
+	// Every extracted field is low confidence so the document goes to review
+	var scores invoice.ConfidenceScores
+	require.NoError(t, json.Unmarshal(out.ConfidenceScores, &scores))
+	assert.InDelta(t, 0.3, scores.Invoice.InvoiceNumber, 0.001)
+	assert.InDelta(t, 0.3, scores.Totals.Total, 0.001)
+	assert.InDelta(t, 0.3, scores.Seller.GSTIN, 0.001)
+	assert.Zero(t, scores.Totals.IGST)
+}
+
+func TestTesseractParser_Parse_RendersPDFPages(t *testing.T) {
+	renderer := new(mocks.MockPageRenderer)
+	renderer.On("RenderPage", mock.Anything, []byte("pdf"), 1, 300).Return([]byte("page1"), nil)
+	renderer.On("RenderPage", mock.Anything, []byte("pdf"), 2, 300).Return([]byte("page2"), nil)
+	ocr := &fakeRecognizer{texts: []string{"Invoice No: INV-7", "Grand Total 590.00"}}
+	p := tesseractparser.NewParserWithRecognizer(ocrTestConfig(), ocr, renderer)
+
+	out, err := p.Parse(context.Background(), port.ParseInput{FileBytes: []byte("pdf"), ContentType: "application/pdf", PageCount: 2})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("page1"), []byte("page2")}, ocr.images)
+
+	var inv invoice.GSTInvoice
+	require.NoError(t, json.Unmarshal(out.StructuredData, &inv))
+	assert.Equal(t, "INV-7", inv.Invoice.InvoiceNumber)
+	assert.Equal(t, 590.0, inv.Totals.Total)
+}
+
+func TestTesseractParser_Parse_UnknownPageCountStopsAtLastPage(t *testing.T) {
+	renderer := new(mocks.MockPageRenderer)
+	renderer.On("RenderPage", mock.Anything, mock.Anything, 1, 300).Return([]byte("page1"), nil)
+	renderer.On("RenderPage", mock.Anything, mock.Anything, 2, 300).Return(nil, domain.ErrPageNotFound)
+	ocr := &fakeRecognizer{texts: []string{"Invoice No: INV-7"}}
+	p := tesseractparser.NewParserWithRecognizer(ocrTestConfig(), ocr, renderer)
+
+	_, err := p.Parse(context.Background(), port.ParseInput{FileBytes: []byte("pdf"), ContentType: "application/pdf"})
+	require.NoError(t, err)
+	assert.Len(t, ocr.images, 1)
+}
+
+func TestTesseractParser_Parse_Failures(t *testing.T) {
+	tests := []struct {
+		name  string
+		ocr   *fakeRecognizer
+		input port.ParseInput
+		want  string
+	}{
+		{"content type", &fakeRecognizer{}, port.ParseInput{ContentType: "text/csv"}, "unsupported content type"},
+		{"too many pages", &fakeRecognizer{}, port.ParseInput{ContentType: "application/pdf", PageCount: 9}, "up to 5 pages"},
+		{"ocr error", &fakeRecognizer{err: errors.New("tesseract is not installed")}, port.ParseInput{ContentType: "image/png"}, "not installed"},
+		{"no text", &fakeRecognizer{texts: []string{"  \n"}}, port.ParseInput{ContentType: "image/png"}, "no text"},
+		{"not an invoice", &fakeRecognizer{texts: []string{"Dear customer, thank you"}}, port.ParseInput{ContentType: "image/jpeg"}, "neither an invoice number nor a total"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tesseractparser.NewParserWithRecognizer(ocrTestConfig(), tt.ocr, nil)
+			_, err := p.Parse(context.Background(), tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package parser_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/textract"
+	"github.com/aws/aws-sdk-go-v2/service/textract/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/parser"
+	textractparser "satvos/internal/parser/textract"
+	"satvos/internal/port"
+	"satvos/internal/validator/invoice"
+)
+
+type fakeExpenseAnalyzer struct {
+	out   *textract.AnalyzeExpenseOutput
+	err   error
+	calls int
+	input *textract.AnalyzeExpenseInput
+}
+
+func (f *fakeExpenseAnalyzer) AnalyzeExpense(_ context.Context, params *textract.AnalyzeExpenseInput, _ ...func(*textract.Options)) (*textract.AnalyzeExpenseOutput, error) {
+	f.calls++
+	f.input = params
+	return f.out, f.err
+}
+
+func newTextractTestParser(client *fakeExpenseAnalyzer) *textractparser.Parser {
+	return textractparser.NewParserWithClient(&config.ParserProviderConfig{Provider: "textract", TimeoutSecs: 30}, client)
+}
+
+func expenseField(typ, label, value string, confidence float32) types.ExpenseField {
+	f := types.ExpenseField{
+		Type:           &types.ExpenseType{Text: aws.String(typ), Confidence: aws.Float32(99)},
+		ValueDetection: &types.ExpenseDetection{Text: aws.String(value), Confidence: aws.Float32(confidence)},
+	}
+	if label != "" {
+		f.LabelDetection = &types.ExpenseDetection{Text: aws.String(label)}
+	}
+	return f
+}
+
+func sampleExpenseOutput() *textract.AnalyzeExpenseOutput {
+	total := expenseField("TOTAL", "Grand Total", "Rs. 1,18,000.00", 97)
+	total.Currency = &types.ExpenseCurrency{Code: aws.String("inr"), Confidence: aws.Float32(90)}
+	return &textract.AnalyzeExpenseOutput{
+		ExpenseDocuments: []types.ExpenseDocument{{
+			SummaryFields: []types.ExpenseField{
+				expenseField("INVOICE_RECEIPT_ID", "Invoice No", "INV-42", 95),
+				expenseField("INVOICE_RECEIPT_DATE", "Date", "15/01/2024", 92),
+				expenseField("VENDOR_NAME", "", "Acme Traders", 88),
+				expenseField("VENDOR_GST_NUMBER", "GSTIN", "29abcde1234f1z5", 90),
+				expenseField("RECEIVER_NAME", "Bill To", "Globex Ltd", 85),
+				expenseField("SUBTOTAL", "Sub Total", "1,00,000.00", 96),
+				expenseField("TAX", "CGST @ 9%", "9,000.00", 94),
+				expenseField("TAX", "SGST @ 9%", "9,000.00", 93),
+				// A second, less confident read of the CGST amount is ignored
+				expenseField("TAX", "CGST", "900.00", 40),
+				total,
+				expenseField("OTHER", "Place of Supply", "Karnataka", 80),
+				expenseField("OTHER", "IFSC Code", "hdfc0001234", 86),
+			},
+			LineItemGroups: []types.LineItemGroup{{
+				LineItems: []types.LineItemFields{
+					{LineItemExpenseFields: []types.ExpenseField{
+						expenseField("ITEM", "Description", "Steel rods", 91),
+						expenseField("PRODUCT_CODE", "HSN", "7214", 89),
+						expenseField("QUANTITY", "Qty", "10", 95),
+						expenseField("UNIT_PRICE", "Rate", "10,000.00", 94),
+						expenseField("PRICE", "Amount", "1,00,000.00", 96),
+					}},
+					// A header row Textract kept as an item
+					{LineItemExpenseFields: []types.ExpenseField{
+						expenseField("QUANTITY", "Qty", "Qty", 50),
+					}},
+				},
+			}},
+		}},
+	}
+}
+
+func TestTextractParser_Parse_MapsExpenseFields(t *testing.T) {
+	client := &fakeExpenseAnalyzer{out: sampleExpenseOutput()}
+	p := newTextractTestParser(client)
+
+	out, err := p.Parse(context.Background(), port.ParseInput{
+		FileBytes:    []byte("%PDF-1.4 test"),
+		ContentType:  "application/pdf",
+		DocumentType: "invoice",
+		PageCount:    1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, textractparser.Model, out.ModelUsed)
+	assert.Equal(t, []byte("%PDF-1.4 test"), client.input.Document.Bytes)
+
+	var inv invoice.GSTInvoice
+	require.NoError(t, json.Unmarshal(out.StructuredData, &inv))
+	assert.Equal(t, "INV-42", inv.Invoice.InvoiceNumber)
+	assert.Equal(t, "15-01-2024", inv.Invoice.InvoiceDate)
+	assert.Equal(t, "INR", inv.Invoice.Currency)
+	assert.Equal(t, "Karnataka", inv.Invoice.PlaceOfSupply)
+	assert.Equal(t, "Acme Traders", inv.Seller.Name)
+	assert.Equal(t, "29ABCDE1234F1Z5", inv.Seller.GSTIN)
+	assert.Equal(t, "29", inv.Seller.StateCode)
+	assert.Equal(t, "Globex Ltd", inv.Buyer.Name)
+	assert.Equal(t, 100000.0, inv.Totals.Subtotal)
+	assert.Equal(t, 100000.0, inv.Totals.TaxableAmount)
+	assert.Equal(t, 9000.0, inv.Totals.CGST)
+	assert.Equal(t, 9000.0, inv.Totals.SGST)
+	assert.Equal(t, 118000.0, inv.Totals.Total)
+	assert.Equal(t, "HDFC0001234", inv.Payment.IFSCCode)
+
+	require.Len(t, inv.LineItems, 1)
+	item := inv.LineItems[0]
+	assert.Equal(t, "Steel rods", item.Description)
+	assert.Equal(t, "7214", item.HSNSACCode)
+	assert.Equal(t, 10.0, item.Quantity)
+	assert.Equal(t, 10000.0, item.UnitPrice)
+	assert.Equal(t, 100000.0, item.Total)
+	assert.Equal(t, 100000.0, item.TaxableAmount)
+
+	var scores invoice.ConfidenceScores
+	require.NoError(t, json.Unmarshal(out.ConfidenceScores, &scores))
+	assert.InDelta(t, 0.95, scores.Invoice.InvoiceNumber, 0.001)
+	assert.InDelta(t, 0.94, scores.Totals.CGST, 0.001)
+	assert.InDelta(t, 0.90, scores.Seller.StateCode, 0.001)
+	assert.Zero(t, scores.Buyer.GSTIN)
+	require.Len(t, scores.LineItems, 1)
+	assert.InDelta(t, 0.91, scores.LineItems[0].Description, 0.001)
+}
+
+func TestTextractParser_Parse_FallsBackToAmountDue(t *testing.T) {
+	client := &fakeExpenseAnalyzer{out: &textract.AnalyzeExpenseOutput{
+		ExpenseDocuments: []types.ExpenseDocument{{
+			SummaryFields: []types.ExpenseField{
+				expenseField("AMOUNT_DUE", "Balance Due", "₹ 5,900", 90),
+				expenseField("INVOICE_RECEIPT_DATE", "Date", "3 Mar 2024", 90),
+			},
+		}},
+	}}
+	p := newTextractTestParser(client)
+
+	out, err := p.Parse(context.Background(), port.ParseInput{FileBytes: []byte("img"), ContentType: "image/png"})
+	require.NoError(t, err)
+
+	var inv invoice.GSTInvoice
+	require.NoError(t, json.Unmarshal(out.StructuredData, &inv))
+	assert.Equal(t, 5900.0, inv.Totals.Total)
+	assert.Equal(t, "03-03-2024", inv.Invoice.InvoiceDate)
+	assert.Empty(t, inv.LineItems)
+}
+
+func TestTextractParser_Parse_RejectsUnreadableInputs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input port.ParseInput
+		want  string
+	}{
+		{"content type", port.ParseInput{FileBytes: []byte("x"), ContentType: "image/tiff"}, "unsupported content type"},
+		{"multi-page", port.ParseInput{FileBytes: []byte("x"), ContentType: "application/pdf", PageCount: 3}, "3 pages"},
+		{"indic script", port.ParseInput{FileBytes: []byte("x"), ContentType: "application/pdf", Language: "hi"}, "Devanagari"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeExpenseAnalyzer{}
+			_, err := newTextractTestParser(client).Parse(context.Background(), tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Zero(t, client.calls)
+		})
+	}
+}
+
+func TestTextractParser_Parse_ClassifiesErrors(t *testing.T) {
+	input := port.ParseInput{FileBytes: []byte("x"), ContentType: "application/pdf"}
+
+	_, err := newTextractTestParser(&fakeExpenseAnalyzer{err: &types.ThrottlingException{Message: aws.String("slow down")}}).Parse(context.Background(), input)
+	var rlErr *parser.RateLimitError
+	require.True(t, errors.As(err, &rlErr))
+	assert.Equal(t, "textract", rlErr.Provider)
+
+	_, err = newTextractTestParser(&fakeExpenseAnalyzer{err: &types.InternalServerError{Message: aws.String("oops")}}).Parse(context.Background(), input)
+	var trErr *parser.TransientError
+	require.True(t, errors.As(err, &trErr))
+
+	_, err = newTextractTestParser(&fakeExpenseAnalyzer{err: &types.UnsupportedDocumentException{Message: aws.String("bad file")}}).Parse(context.Background(), input)
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &rlErr))
+	assert.False(t, errors.As(err, &trErr))
+	assert.Contains(t, err.Error(), "textract API error")
+}
+
+func TestTextractParser_Parse_NoExpenseDocuments(t *testing.T) {
+	client := &fakeExpenseAnalyzer{out: &textract.AnalyzeExpenseOutput{}}
+	_, err := newTextractTestParser(client).Parse(context.Background(), port.ParseInput{FileBytes: []byte("x"), ContentType: "image/jpeg"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no invoice")
+}
@@ -0,0 +1,41 @@
+package parser_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"satvos/internal/config"
+	"satvos/internal/parser"
+	"satvos/internal/port"
+)
+
+func TestTimeoutPolicy_ScalesWithPagesAndSize(t *testing.T) {
+	policy := parser.NewTimeoutPolicy(&config.ParserProviderConfig{
+		TimeoutSecs: 30, TimeoutPerPageSecs: 10, TimeoutPerMBSecs: 4, MaxTimeoutSecs: 600,
+	})
+
+	small := policy.For(port.ParseInput{FileBytes: make([]byte, 1024), PageCount: 1})
+	large := policy.For(port.ParseInput{FileBytes: make([]byte, 2<<20), PageCount: 20})
+
+	assert.InDelta(t, float64(40*time.Second), float64(small), float64(100*time.Millisecond))
+	assert.Equal(t, 30*time.Second+200*time.Second+8*time.Second, large)
+}
+
+func TestTimeoutPolicy_CappedAtMax(t *testing.T) {
+	policy := parser.NewTimeoutPolicy(&config.ParserProviderConfig{
+		TimeoutSecs: 60, TimeoutPerPageSecs: 10, MaxTimeoutSecs: 300,
+	})
+
+	assert.Equal(t, 300*time.Second, policy.For(port.ParseInput{PageCount: 80}))
+}
+
+func TestTimeoutPolicy_Defaults(t *testing.T) {
+	policy := parser.NewTimeoutPolicy(&config.ParserProviderConfig{})
+
+	assert.Equal(t, 60*time.Second, policy.Base)
+	assert.Equal(t, 10*time.Minute, policy.Max)
+	// Unknown page count counts as one page.
+	assert.Equal(t, 60*time.Second, policy.For(port.ParseInput{}))
+}
@@ -0,0 +1,124 @@
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/middleware"
+	"satvos/internal/router"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// publicPrefixes are routes served without authentication and therefore outside the matrix.
+var publicPrefixes = []string{"/healthz", "/readyz", "/swagger/", "/api/v1/auth/login", "/api/v1/auth/refresh",
+	"/api/v1/auth/register", "/api/v1/auth/verify-email", "/api/v1/auth/forgot-password",
+	"/api/v1/auth/reset-password", "/api/v1/auth/undo-email-change", "/api/v1/auth/social-login", "/api/v1/errors",
+	"/api/v1/webhooks/ses"}
+
+func isPublic(path string) bool {
+	for _, p := range publicPrefixes {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRouteMatrix_CoversEveryProtectedRoute(t *testing.T) {
+	r := router.Setup(&router.Deps{Maintenance: middleware.NewMaintenanceMode(false, 0)})
+
+	declared := make(map[string]bool)
+	for _, rule := range router.RouteMatrix() {
+		key := rule.Method + " " + rule.Path
+		assert.False(t, declared[key], "duplicate matrix entry %s", key)
+		declared[key] = true
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range r.Routes() {
+		if isPublic(route.Path) {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		assert.True(t, declared[key], "route %s has no authorization matrix entry", key)
+	}
+
+	for key := range declared {
+		assert.True(t, registered[key], "matrix entry %s does not match a registered route", key)
+	}
+}
+
+func TestRouteMatrix_AdminRoutesAdminOnly(t *testing.T) {
+	for _, rule := range router.RouteMatrix() {
+		if !strings.HasPrefix(rule.Path, "/api/v1/admin/") {
+			continue
+		}
+		assert.Equal(t, []domain.UserRole{domain.RoleAdmin}, rule.Roles, rule.Path)
+	}
+}
+
+func TestRouteMatrix_FreeRoleOnlyOnSelfServiceRoutes(t *testing.T) {
+	for _, rule := range router.RouteMatrix() {
+		if rule.Path == "/api/v1/files/upload" {
+			assert.Contains(t, rule.Roles, domain.RoleFree)
+			assert.NotContains(t, rule.Roles, domain.RoleViewer)
+		}
+		if rule.Path == "/api/v1/users" {
+			assert.NotContains(t, rule.Roles, domain.RoleFree)
+		}
+	}
+}
+
+func TestDocumentRoutes_RefuseWritesWhileAnotherUserHoldsTheLock(t *testing.T) {
+	tenantID, userID, holderID, docID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	authSvc, tenants, users, locks := new(mocks.MockAuthService), new(mocks.MockTenantRepo), new(mocks.MockUserRepo), new(mocks.MockDocumentLockRepo)
+	authSvc.On("ValidateToken", "token").Return(&service.Claims{TenantID: tenantID, UserID: userID, Role: domain.RoleAdmin}, nil)
+	tenants.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, IsActive: true}, nil)
+	users.On("GetByID", mock.Anything, tenantID, holderID).Return(&domain.User{ID: holderID, FullName: "Asha"}, nil)
+	locks.On("GetActive", mock.Anything, tenantID, docID).Return(&domain.DocumentLock{
+		DocumentID: docID, TenantID: tenantID, UserID: holderID, ExpiresAt: time.Now().Add(time.Minute),
+	}, nil)
+	r := router.Setup(&router.Deps{
+		AuthSvc: authSvc, TenantRepo: tenants, UserRepo: users, DocLockRepo: locks,
+		Maintenance: middleware.NewMaintenanceMode(false, 0),
+	})
+
+	base := "/api/v1/documents/" + docID.String()
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPut, base},
+		{http.MethodPut, base + "/review"},
+		{http.MethodPut, base + "/assign"},
+		{http.MethodPut, base + "/structured-data"},
+		{http.MethodPost, base + "/validate"},
+		{http.MethodPost, base + "/recompute"},
+		{http.MethodPatch, base + "/line-items"},
+		{http.MethodPost, base + "/tags"},
+		{http.MethodDelete, base + "/tags/" + uuid.New().String()},
+		{http.MethodDelete, base + "/overrides"},
+		{http.MethodDelete, base},
+	} {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(route.method, route.path, http.NoBody)
+			req.Header.Set("Authorization", "Bearer token")
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusLocked, w.Code)
+		})
+	}
+}
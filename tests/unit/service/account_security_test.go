@@ -0,0 +1,234 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type accountSecurityDeps struct {
+	svc          service.PasswordResetService
+	tenantRepo   *mocks.MockTenantRepo
+	userRepo     *mocks.MockUserRepo
+	securityRepo *mocks.MockAccountSecurityRepo
+	notifier     *mocks.MockNotifier
+	user         *domain.User
+}
+
+func setupAccountSecurity() *accountSecurityDeps {
+	d := &accountSecurityDeps{
+		tenantRepo:   new(mocks.MockTenantRepo),
+		userRepo:     new(mocks.MockUserRepo),
+		securityRepo: new(mocks.MockAccountSecurityRepo),
+		notifier:     new(mocks.MockNotifier),
+	}
+	d.svc = service.NewPasswordResetService(d.tenantRepo, d.userRepo, d.securityRepo, d.notifier, testJWTCfg, testSecurityCfg)
+	tenantID := uuid.New()
+	d.user = &domain.User{
+		ID: uuid.New(), TenantID: tenantID, Email: "new@test.com", FullName: "Test User",
+		Role: domain.RoleMember, IsActive: true,
+	}
+	return d
+}
+
+// requestReset runs ForgotPassword from meta and returns the emailed token and its ID.
+func (d *accountSecurityDeps) requestReset(t *testing.T, meta service.RequestMeta) (token, tokenID string) {
+	t.Helper()
+	ctx := context.Background()
+	d.tenantRepo.On("GetBySlug", ctx, "acme").Return(&domain.Tenant{ID: d.user.TenantID, IsActive: true}, nil)
+	d.userRepo.On("GetByEmail", ctx, d.user.TenantID, d.user.Email).Return(d.user, nil)
+	d.userRepo.On("SetPasswordResetToken", ctx, d.user.TenantID, d.user.ID, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { tokenID = args.Get(3).(string) }).Return(nil)
+	d.notifier.On("Notify", ctx, notificationTo(domain.NotificationKindPasswordReset, d.user.Email)).
+		Run(func(args mock.Arguments) { token = args.Get(1).(*port.Notification).Token }).Return(nil)
+	d.securityRepo.On("RecordEvent", ctx, mock.MatchedBy(func(e *domain.SecurityEvent) bool {
+		return e.Event == domain.SecurityEventPasswordResetRequested && e.IPAddress == meta.ClientIP
+	})).Return(nil).Once()
+
+	require.NoError(t, d.svc.ForgotPassword(ctx, service.ForgotPasswordInput{TenantSlug: "acme", Email: d.user.Email, Meta: meta}))
+	require.NotEmpty(t, token)
+	return token, tokenID
+}
+
+func TestResetPassword_TokenIsShortLived(t *testing.T) {
+	d := setupAccountSecurity()
+	token, _ := d.requestReset(t, service.RequestMeta{ClientIP: "203.0.113.7", UserAgent: "Firefox"})
+
+	claims := jwt.MapClaims{}
+	_, _, err := jwt.NewParser().ParseUnverified(token, claims)
+	require.NoError(t, err)
+	exp, err := claims.GetExpirationTime()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), exp.Time, 5*time.Second)
+}
+
+func TestResetPassword_BoundToRequestingClient(t *testing.T) {
+	d := setupAccountSecurity()
+	ctx := context.Background()
+	meta := service.RequestMeta{ClientIP: "203.0.113.7", UserAgent: "Firefox"}
+	token, tokenID := d.requestReset(t, meta)
+
+	d.securityRepo.On("RecordEvent", ctx, mock.MatchedBy(func(e *domain.SecurityEvent) bool {
+		return e.Event == domain.SecurityEventPasswordResetRejected && e.IPAddress == "198.51.100.9"
+	})).Return(nil).Once()
+
+	err := d.svc.ResetPassword(ctx, service.ResetPasswordInput{
+		Token: token, NewPassword: "newpassword123",
+		Meta: service.RequestMeta{ClientIP: "198.51.100.9", UserAgent: "Firefox"},
+	})
+	assert.ErrorIs(t, err, domain.ErrPasswordResetTokenInvalid)
+	d.userRepo.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The link still works from the client that asked for it
+	d.userRepo.On("ResetPassword", ctx, d.user.TenantID, d.user.ID, mock.AnythingOfType("string"), tokenID).Return(nil)
+	d.securityRepo.On("RecordEvent", ctx, mock.MatchedBy(func(e *domain.SecurityEvent) bool {
+		return e.Event == domain.SecurityEventPasswordResetCompleted
+	})).Return(nil).Once()
+
+	err = d.svc.ResetPassword(ctx, service.ResetPasswordInput{Token: token, NewPassword: "newpassword123", Meta: meta})
+	require.NoError(t, err)
+	d.securityRepo.AssertExpectations(t)
+}
+
+func TestRecordEmailChange_NotifiesOldAddress(t *testing.T) {
+	d := setupAccountSecurity()
+	ctx := context.Background()
+	actorID := uuid.New()
+
+	var change *domain.EmailChange
+	d.securityRepo.On("CreateEmailChange", ctx, mock.AnythingOfType("*domain.EmailChange")).
+		Run(func(args mock.Arguments) {
+			change = args.Get(1).(*domain.EmailChange)
+			change.ID = uuid.New()
+		}).Return(nil)
+	d.securityRepo.On("RecordEvent", ctx, mock.MatchedBy(func(e *domain.SecurityEvent) bool {
+		return e.Event == domain.SecurityEventEmailChanged && e.ActorID != nil && *e.ActorID == actorID
+	})).Return(nil)
+	var n *port.Notification
+	d.notifier.On("Notify", ctx, notificationTo(domain.NotificationKindEmailChanged, "old@test.com")).
+		Run(func(args mock.Arguments) { n = args.Get(1).(*port.Notification) }).Return(nil)
+
+	err := d.svc.RecordEmailChange(ctx, service.EmailChangeInput{
+		User: d.user, OldEmail: "old@test.com", ActorID: actorID,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, change)
+	assert.Equal(t, "old@test.com", change.OldEmail)
+	assert.Equal(t, "new@test.com", change.NewEmail)
+	assert.WithinDuration(t, time.Now().Add(72*time.Hour), change.UndoExpiresAt, 5*time.Second)
+	require.NotNil(t, n)
+	assert.Equal(t, "new@test.com", n.NewEmail)
+	assert.NotEmpty(t, n.Token)
+}
+
+func TestRecordEmailChange_SameAddressIsNoop(t *testing.T) {
+	d := setupAccountSecurity()
+
+	err := d.svc.RecordEmailChange(context.Background(), service.EmailChangeInput{User: d.user, OldEmail: "NEW@test.com"})
+
+	require.NoError(t, err)
+	d.securityRepo.AssertNotCalled(t, "CreateEmailChange", mock.Anything, mock.Anything)
+}
+
+// recordChange runs RecordEmailChange and returns the change and its undo token.
+func (d *accountSecurityDeps) recordChange(t *testing.T) (*domain.EmailChange, string) {
+	t.Helper()
+	ctx := context.Background()
+	var change *domain.EmailChange
+	var token string
+	d.securityRepo.On("CreateEmailChange", ctx, mock.AnythingOfType("*domain.EmailChange")).
+		Run(func(args mock.Arguments) {
+			change = args.Get(1).(*domain.EmailChange)
+			change.ID = uuid.New()
+		}).Return(nil)
+	d.securityRepo.On("RecordEvent", ctx, mock.Anything).Return(nil)
+	d.notifier.On("Notify", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { token = args.Get(1).(*port.Notification).Token }).Return(nil)
+	require.NoError(t, d.svc.RecordEmailChange(ctx, service.EmailChangeInput{User: d.user, OldEmail: "old@test.com"}))
+	return change, token
+}
+
+func TestUndoEmailChange_Success(t *testing.T) {
+	d := setupAccountSecurity()
+	ctx := context.Background()
+	change, token := d.recordChange(t)
+	d.securityRepo.On("GetEmailChange", ctx, d.user.TenantID, change.ID).Return(change, nil)
+	d.securityRepo.On("UndoEmailChange", ctx, change).Return(nil)
+
+	err := d.svc.UndoEmailChange(ctx, service.UndoEmailChangeInput{Token: token})
+
+	require.NoError(t, err)
+	d.securityRepo.AssertCalled(t, "RecordEvent", ctx, mock.MatchedBy(func(e *domain.SecurityEvent) bool {
+		return e.Event == domain.SecurityEventEmailChangeUndone
+	}))
+}
+
+func TestUndoEmailChange_AlreadyUndone(t *testing.T) {
+	d := setupAccountSecurity()
+	ctx := context.Background()
+	change, token := d.recordChange(t)
+	undone := time.Now()
+	change.UndoneAt = &undone
+	d.securityRepo.On("GetEmailChange", ctx, d.user.TenantID, change.ID).Return(change, nil)
+
+	err := d.svc.UndoEmailChange(ctx, service.UndoEmailChangeInput{Token: token})
+
+	assert.ErrorIs(t, err, domain.ErrEmailChangeUndoInvalid)
+	d.securityRepo.AssertNotCalled(t, "UndoEmailChange", mock.Anything, mock.Anything)
+}
+
+func TestUndoEmailChange_RejectsResetToken(t *testing.T) {
+	d := setupAccountSecurity()
+	token, _ := d.requestReset(t, service.RequestMeta{})
+
+	err := d.svc.UndoEmailChange(context.Background(), service.UndoEmailChangeInput{Token: token})
+
+	assert.ErrorIs(t, err, domain.ErrEmailChangeUndoInvalid)
+}
+
+func TestUserService_Update_EmailChangeRecorded(t *testing.T) {
+	repo := new(mocks.MockUserRepo)
+	security := new(mocks.MockPasswordResetService)
+	svc := service.NewUserService(repo, security)
+	ctx := context.Background()
+	tenantID, userID, actorID := uuid.New(), uuid.New(), uuid.New()
+	repo.On("GetByID", ctx, tenantID, userID).Return(&domain.User{ID: userID, TenantID: tenantID, Email: "old@test.com"}, nil)
+	repo.On("Update", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+	security.On("RecordEmailChange", ctx, mock.MatchedBy(func(in service.EmailChangeInput) bool {
+		return in.OldEmail == "old@test.com" && in.User.Email == "new@test.com" && in.ActorID == actorID
+	})).Return(nil)
+
+	newEmail := "new@test.com"
+	_, err := svc.Update(ctx, tenantID, userID, service.UpdateUserInput{Email: &newEmail, ActorID: actorID})
+
+	require.NoError(t, err)
+	security.AssertExpectations(t)
+}
+
+func TestUserService_Update_NameOnlySkipsEmailChange(t *testing.T) {
+	repo := new(mocks.MockUserRepo)
+	security := new(mocks.MockPasswordResetService)
+	svc := service.NewUserService(repo, security)
+	ctx := context.Background()
+	tenantID, userID := uuid.New(), uuid.New()
+	repo.On("GetByID", ctx, tenantID, userID).Return(&domain.User{ID: userID, TenantID: tenantID, Email: "a@test.com"}, nil)
+	repo.On("Update", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+
+	name := "Renamed"
+	_, err := svc.Update(ctx, tenantID, userID, service.UpdateUserInput{FullName: &name})
+
+	require.NoError(t, err)
+	security.AssertNotCalled(t, "RecordEmailChange", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,227 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/internal/validator/invoice"
+	"satvos/mocks"
+)
+
+func setupAnalyticsExportService() (*mocks.MockAnalyticsExportRepo, *mocks.MockObjectStorage, service.AnalyticsExportService) {
+	repo := new(mocks.MockAnalyticsExportRepo)
+	storage := new(mocks.MockObjectStorage)
+	cfg := testS3Config()
+	return repo, storage, service.NewAnalyticsExportService(repo, storage, &cfg)
+}
+
+func TestAnalyticsExportService_Configure_New(t *testing.T) {
+	repo, _, svc := setupAnalyticsExportService()
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	repo.On("Get", ctx, tenantID).Return(nil, domain.ErrNotFound)
+	repo.On("Upsert", ctx, mock.AnythingOfType("*domain.AnalyticsExport")).Return(nil)
+
+	export, err := svc.Configure(ctx, tenantID, &service.ConfigureAnalyticsExportInput{
+		Destination: "s3://acme-warehouse/satvos/",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "acme-warehouse", export.S3Bucket)
+	assert.Equal(t, "satvos", export.S3Prefix)
+	assert.Equal(t, 24, export.IntervalHours)
+	assert.True(t, export.Enabled)
+	assert.Nil(t, export.ExportedThrough)
+	assert.WithinDuration(t, time.Now(), export.NextRunAt, time.Minute)
+}
+
+func TestAnalyticsExportService_Configure_ChangedDestinationResetsWatermark(t *testing.T) {
+	repo, _, svc := setupAnalyticsExportService()
+	ctx := context.Background()
+	tenantID := uuid.New()
+	through := time.Now().Add(-time.Hour)
+	existing := &domain.AnalyticsExport{
+		TenantID: tenantID, S3Bucket: "old-bucket", S3Prefix: "exports", IntervalHours: 24,
+		Enabled: true, ExportedThrough: &through, NextRunAt: time.Now().Add(20 * time.Hour),
+	}
+	disabled := false
+
+	repo.On("Get", ctx, tenantID).Return(existing, nil)
+	repo.On("Upsert", ctx, existing).Return(nil)
+
+	export, err := svc.Configure(ctx, tenantID, &service.ConfigureAnalyticsExportInput{
+		Destination: "s3://new-bucket/exports", IntervalHours: 6, Enabled: &disabled,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, export.ExportedThrough)
+	assert.WithinDuration(t, time.Now(), export.NextRunAt, time.Minute)
+	assert.Equal(t, 6, export.IntervalHours)
+	assert.False(t, export.Enabled)
+}
+
+func TestAnalyticsExportService_Configure_Invalid(t *testing.T) {
+	tenantID := uuid.New()
+	tests := []struct {
+		name        string
+		destination string
+		hours       int
+		wantErr     error
+	}{
+		{"not s3", "https://acme-warehouse/satvos", 0, domain.ErrInvalidExportDestination},
+		{"bad bucket", "s3://Acme_Warehouse/satvos", 0, domain.ErrInvalidExportDestination},
+		{"dot segment", "s3://acme-warehouse/a/../b", 0, domain.ErrInvalidExportDestination},
+		{"deployment bucket outside tenant", "s3://test-bucket/tenants/" + uuid.New().String() + "/exports", 0, domain.ErrInvalidExportDestination},
+		{"deployment bucket root", "s3://test-bucket", 0, domain.ErrInvalidExportDestination},
+		{"interval too long", "s3://acme-warehouse/satvos", 169, domain.ErrInvalidExportInterval},
+		{"negative interval", "s3://acme-warehouse/satvos", -1, domain.ErrInvalidExportInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, _, svc := setupAnalyticsExportService()
+
+			_, err := svc.Configure(context.Background(), tenantID, &service.ConfigureAnalyticsExportInput{
+				Destination: tt.destination, IntervalHours: tt.hours,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAnalyticsExportService_Configure_DeploymentBucketUnderTenantPrefix(t *testing.T) {
+	repo, _, svc := setupAnalyticsExportService()
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	repo.On("Get", ctx, tenantID).Return(nil, domain.ErrNotFound)
+	repo.On("Upsert", ctx, mock.AnythingOfType("*domain.AnalyticsExport")).Return(nil)
+
+	export, err := svc.Configure(ctx, tenantID, &service.ConfigureAnalyticsExportInput{
+		Destination: "s3://test-bucket/tenants/" + tenantID.String() + "/analytics",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "tenants/"+tenantID.String()+"/analytics", export.S3Prefix)
+}
+
+func TestAnalyticsExportService_RunDue_UploadsTablesAndAdvancesWatermark(t *testing.T) {
+	repo, storage, svc := setupAnalyticsExportService()
+	ctx := context.Background()
+	tenantID := uuid.New()
+	through := time.Now().Add(-24 * time.Hour).UTC()
+	export := domain.AnalyticsExport{
+		TenantID: tenantID, S3Bucket: "acme-warehouse", S3Prefix: "satvos", IntervalHours: 24,
+		Enabled: true, ExportedThrough: &through,
+	}
+	data, _ := json.Marshal(invoice.GSTInvoice{
+		Invoice:   invoice.InvoiceHeader{InvoiceNumber: "INV-1"},
+		LineItems: []invoice.LineItem{{Description: "Widget", Total: 118}},
+		Totals:    invoice.Totals{Total: 118},
+	})
+	doc := domain.Document{
+		ID: uuid.New(), TenantID: tenantID, ParsingStatus: domain.ParsingStatusCompleted,
+		StructuredData: data, UpdatedAt: time.Now().Add(-time.Hour),
+	}
+
+	repo.On("ClaimDue", ctx, mock.Anything, time.Hour, 5).Return([]domain.AnalyticsExport{export}, nil)
+	repo.On("ListDocumentsUpdated", ctx, tenantID, through, uuid.Max, mock.Anything, 1000).
+		Return([]domain.Document{doc}, nil)
+	repo.On("ListValidationFacts", ctx, tenantID, []uuid.UUID{doc.ID}).Return([]domain.ValidationFact{
+		{DocumentID: doc.ID, TenantID: tenantID, RuleID: uuid.New(), Passed: true},
+	}, nil)
+
+	var keys []string
+	storage.On("Upload", ctx, mock.AnythingOfType("port.UploadInput")).
+		Run(func(args mock.Arguments) {
+			in := args.Get(1).(port.UploadInput)
+			assert.Equal(t, "acme-warehouse", in.Bucket)
+			assert.Equal(t, "application/vnd.apache.parquet", in.ContentType)
+			body, err := io.ReadAll(in.Body)
+			require.NoError(t, err)
+			assert.Equal(t, in.Size, int64(len(body)))
+			assert.Equal(t, "PAR1", string(body[:4]))
+			keys = append(keys, in.Key)
+		}).
+		Return(&port.UploadOutput{}, nil)
+
+	var completed *domain.AnalyticsExport
+	repo.On("CompleteRun", ctx, mock.AnythingOfType("*domain.AnalyticsExport")).
+		Run(func(args mock.Arguments) { completed = args.Get(1).(*domain.AnalyticsExport) }).
+		Return(nil)
+
+	require.NoError(t, svc.RunDue(ctx))
+
+	require.Len(t, keys, 3)
+	for _, table := range []string{"documents/", "line_items/", "validations/"} {
+		assert.True(t, containsPrefix(keys, "satvos/"+table+"export_date="), "missing %s in %v", table, keys)
+	}
+	require.NotNil(t, completed)
+	require.NotNil(t, completed.ExportedThrough)
+	assert.True(t, completed.ExportedThrough.After(through))
+	assert.Empty(t, completed.LastError)
+	assert.Equal(t, 1, completed.LastRunDocuments)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), completed.NextRunAt, time.Minute)
+}
+
+func TestAnalyticsExportService_RunDue_NothingChanged(t *testing.T) {
+	repo, storage, svc := setupAnalyticsExportService()
+	ctx := context.Background()
+	export := domain.AnalyticsExport{TenantID: uuid.New(), S3Bucket: "acme-warehouse", IntervalHours: 12, Enabled: true}
+
+	repo.On("ClaimDue", ctx, mock.Anything, time.Hour, 5).Return([]domain.AnalyticsExport{export}, nil)
+	repo.On("ListDocumentsUpdated", ctx, export.TenantID, time.Time{}, uuid.Nil, mock.Anything, 1000).
+		Return([]domain.Document{}, nil)
+	repo.On("CompleteRun", ctx, mock.MatchedBy(func(e *domain.AnalyticsExport) bool {
+		return e.ExportedThrough != nil && e.LastRunDocuments == 0 && e.LastError == ""
+	})).Return(nil)
+
+	require.NoError(t, svc.RunDue(ctx))
+
+	storage.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+}
+
+func TestAnalyticsExportService_RunDue_UploadFailureKeepsWatermark(t *testing.T) {
+	repo, storage, svc := setupAnalyticsExportService()
+	ctx := context.Background()
+	export := domain.AnalyticsExport{TenantID: uuid.New(), S3Bucket: "acme-warehouse", IntervalHours: 24, Enabled: true}
+	doc := domain.Document{ID: uuid.New(), TenantID: export.TenantID, ParsingStatus: domain.ParsingStatusPending}
+
+	repo.On("ClaimDue", ctx, mock.Anything, time.Hour, 5).Return([]domain.AnalyticsExport{export}, nil)
+	repo.On("ListDocumentsUpdated", ctx, export.TenantID, time.Time{}, uuid.Nil, mock.Anything, 1000).
+		Return([]domain.Document{doc}, nil)
+	repo.On("ListValidationFacts", ctx, export.TenantID, []uuid.UUID{doc.ID}).Return([]domain.ValidationFact{}, nil)
+	storage.On("Upload", ctx, mock.AnythingOfType("port.UploadInput")).Return(nil, assert.AnError)
+	repo.On("CompleteRun", ctx, mock.MatchedBy(func(e *domain.AnalyticsExport) bool {
+		return e.ExportedThrough == nil && strings.Contains(e.LastError, "uploading documents") &&
+			e.NextRunAt.After(time.Now().Add(50*time.Minute))
+	})).Return(nil)
+
+	require.NoError(t, svc.RunDue(ctx))
+
+	repo.AssertExpectations(t)
+}
+
+func containsPrefix(keys []string, prefix string) bool {
+	for _, k := range keys {
+		if strings.HasPrefix(k, prefix) && strings.HasSuffix(k, ".parquet") {
+			return true
+		}
+	}
+	return false
+}
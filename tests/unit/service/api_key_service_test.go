@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAPIKeyService_Create_StoresOnlyHash(t *testing.T) {
+	repo := new(mocks.MockAPIKeyRepo)
+	svc := service.NewAPIKeyService(repo, new(mocks.MockUserRepo))
+	tenantID, userID := uuid.New(), uuid.New()
+
+	var stored *domain.APIKey
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.APIKey")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.APIKey) }).Return(nil)
+
+	created, err := svc.Create(context.Background(), tenantID, userID, service.CreateAPIKeyInput{Name: " ops laptop "})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, service.APIKeyPrefix))
+	assert.Equal(t, "ops laptop", stored.Name)
+	assert.Equal(t, sha256Hex(created.Key), stored.KeyHash)
+	assert.True(t, strings.HasPrefix(created.Key, stored.KeyPrefix))
+	assert.Less(t, len(stored.KeyPrefix), len(created.Key))
+	assert.Equal(t, userID, stored.UserID)
+}
+
+func TestAPIKeyService_Authenticate_UsesCurrentRole(t *testing.T) {
+	repo := new(mocks.MockAPIKeyRepo)
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewAPIKeyService(repo, userRepo)
+	key := &domain.APIKey{ID: uuid.New(), TenantID: uuid.New(), UserID: uuid.New()}
+
+	repo.On("GetByHash", mock.Anything, sha256Hex("satvos_secret")).Return(key, nil)
+	userRepo.On("GetByID", mock.Anything, key.TenantID, key.UserID).Return(&domain.User{
+		ID: key.UserID, TenantID: key.TenantID, Email: "ops@acme.com", Role: domain.RoleManager, IsActive: true,
+	}, nil)
+	repo.On("TouchLastUsed", mock.Anything, key.ID).Return(nil)
+
+	claims, err := svc.Authenticate(context.Background(), "satvos_secret")
+
+	require.NoError(t, err)
+	assert.Equal(t, key.TenantID, claims.TenantID)
+	assert.Equal(t, key.UserID, claims.UserID)
+	assert.Equal(t, domain.RoleManager, claims.Role)
+	repo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_Authenticate_RecentlyUsedSkipsTouch(t *testing.T) {
+	repo := new(mocks.MockAPIKeyRepo)
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewAPIKeyService(repo, userRepo)
+	justNow := time.Now()
+	key := &domain.APIKey{ID: uuid.New(), TenantID: uuid.New(), UserID: uuid.New(), LastUsedAt: &justNow}
+
+	repo.On("GetByHash", mock.Anything, mock.Anything).Return(key, nil)
+	userRepo.On("GetByID", mock.Anything, key.TenantID, key.UserID).Return(&domain.User{ID: key.UserID, IsActive: true}, nil)
+
+	_, err := svc.Authenticate(context.Background(), "satvos_secret")
+
+	require.NoError(t, err)
+	repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyService_Authenticate_UnknownKey(t *testing.T) {
+	repo := new(mocks.MockAPIKeyRepo)
+	svc := service.NewAPIKeyService(repo, new(mocks.MockUserRepo))
+	repo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound)
+
+	_, err := svc.Authenticate(context.Background(), "satvos_nope")
+
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestAPIKeyService_Authenticate_InactiveUser(t *testing.T) {
+	repo := new(mocks.MockAPIKeyRepo)
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewAPIKeyService(repo, userRepo)
+	key := &domain.APIKey{ID: uuid.New(), TenantID: uuid.New(), UserID: uuid.New()}
+
+	repo.On("GetByHash", mock.Anything, mock.Anything).Return(key, nil)
+	userRepo.On("GetByID", mock.Anything, key.TenantID, key.UserID).Return(&domain.User{ID: key.UserID, IsActive: false}, nil)
+
+	_, err := svc.Authenticate(context.Background(), "satvos_secret")
+
+	assert.ErrorIs(t, err, domain.ErrUserInactive)
+}
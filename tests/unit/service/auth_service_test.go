@@ -288,6 +288,7 @@ func TestAuthService_RefreshToken_Success(t *testing.T) {
 	tenantRepo.On("GetBySlug", mock.Anything, "test-tenant").Return(tenant, nil)
 	userRepo.On("GetByEmail", mock.Anything, tenantID, "user@test.com").Return(user, nil)
 	userRepo.On("GetByID", mock.Anything, tenantID, userID).Return(user, nil)
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(tenant, nil)
 
 	tokenPair, err := svc.Login(context.Background(), service.LoginInput{
 		TenantSlug: "test-tenant",
@@ -302,3 +303,25 @@ func TestAuthService_RefreshToken_Success(t *testing.T) {
 	assert.NotEmpty(t, newTokenPair.RefreshToken)
 	assert.NotEqual(t, tokenPair.AccessToken, newTokenPair.AccessToken)
 }
+
+func TestAuthService_RefreshToken_TenantSuspended(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewAuthService(userRepo, tenantRepo, testJWTConfig())
+
+	tenantID := uuid.New()
+	user := &domain.User{
+		ID: uuid.New(), TenantID: tenantID, Email: "user@test.com",
+		Role: domain.RoleMember, IsActive: true,
+	}
+
+	tokenPair, err := svc.GenerateTokenPairForUser(user)
+	assert.NoError(t, err)
+
+	userRepo.On("GetByID", mock.Anything, tenantID, user.ID).Return(user, nil)
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, IsActive: false}, nil)
+
+	result, err := svc.RefreshToken(context.Background(), tokenPair.RefreshToken)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrTenantInactive)
+}
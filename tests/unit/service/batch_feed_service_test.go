@@ -0,0 +1,225 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type feedMocks struct {
+	tenantRepo     *mocks.MockTenantRepo
+	collectionRepo *mocks.MockCollectionRepo
+	userRepo       *mocks.MockUserRepo
+	feedRepo       *mocks.MockFeedIngestionRepo
+	flags          *mocks.MockFeatureFlagService
+	fileSvc        *mocks.MockFileService
+	collectionSvc  *mocks.MockCollectionService
+	docSvc         *mocks.MockDocumentService
+	storage        *mocks.MockObjectStorage
+	notifier       *mocks.MockNotifier
+}
+
+func setupBatchFeedService() (service.BatchFeedService, *feedMocks) {
+	m := &feedMocks{
+		tenantRepo:     new(mocks.MockTenantRepo),
+		collectionRepo: new(mocks.MockCollectionRepo),
+		userRepo:       new(mocks.MockUserRepo),
+		feedRepo:       new(mocks.MockFeedIngestionRepo),
+		flags:          new(mocks.MockFeatureFlagService),
+		fileSvc:        new(mocks.MockFileService),
+		collectionSvc:  new(mocks.MockCollectionService),
+		docSvc:         new(mocks.MockDocumentService),
+		storage:        new(mocks.MockObjectStorage),
+		notifier:       new(mocks.MockNotifier),
+	}
+	s3Cfg := testS3Config()
+	svc := service.NewBatchFeedService(m.tenantRepo, m.collectionRepo, m.userRepo, m.feedRepo, m.flags,
+		m.fileSvc, m.collectionSvc, m.docSvc, m.storage, m.notifier, &s3Cfg, 18)
+	return svc, m
+}
+
+// expectEnabledTenant makes tenant the only tenant, with the batch_feed flag on.
+func expectEnabledTenant(m *feedMocks, tenant *domain.Tenant) {
+	m.tenantRepo.On("List", mock.Anything, 0, mock.Anything).Return([]domain.Tenant{*tenant}, 1, nil)
+	m.flags.On("IsEnabled", mock.Anything, tenant.ID, domain.FlagBatchFeed).Return(true)
+}
+
+func TestBatchFeedService_ScanAll_RoutesByFolder(t *testing.T) {
+	svc, m := setupBatchFeedService()
+	tenant := &domain.Tenant{ID: uuid.New(), Name: "Acme", IsActive: true}
+	ownerID := uuid.New()
+	col := domain.Collection{ID: uuid.New(), TenantID: tenant.ID, Name: "AP Invoices", CreatedBy: ownerID}
+	prefix := fmt.Sprintf("tenants/%s/drop/", tenant.ID)
+
+	m.feedRepo.On("FailStale", mock.Anything, mock.AnythingOfType("time.Time")).Return(0, nil)
+	expectEnabledTenant(m, tenant)
+	m.storage.On("List", mock.Anything, "test-bucket", prefix).Return([]port.ObjectInfo{
+		{Key: prefix + "ap invoices/", Size: 0},
+		{Key: prefix + "ap invoices/inv-1.pdf", Size: 100},
+		{Key: prefix + "Unknown/inv-2.pdf", Size: 100},
+		{Key: prefix + "loose.pdf", Size: 100},
+	}, nil)
+	m.collectionRepo.On("ListByTenant", mock.Anything, tenant.ID, 0, mock.Anything).
+		Return([]domain.Collection{col}, 1, nil)
+	m.userRepo.On("GetByID", mock.Anything, tenant.ID, ownerID).
+		Return(&domain.User{ID: ownerID, TenantID: tenant.ID, Role: domain.RoleManager, IsActive: true}, nil)
+	m.feedRepo.On("Claim", mock.Anything, mock.AnythingOfType("*domain.FeedIngestion")).Return(true, nil)
+	m.storage.On("Download", mock.Anything, "test-bucket", mock.AnythingOfType("string")).Return(pdfContent(), nil)
+	m.storage.On("Upload", mock.Anything, mock.AnythingOfType("port.UploadInput")).Return(&port.UploadOutput{}, nil)
+	m.storage.On("Delete", mock.Anything, "test-bucket", mock.AnythingOfType("string")).Return(nil)
+	expectImportPipeline(&importMocks{fileSvc: m.fileSvc, collectionSvc: m.collectionSvc, docSvc: m.docSvc}, tenant.ID, col.ID)
+
+	outcomes := map[string]*domain.FeedIngestion{}
+	m.feedRepo.On("Complete", mock.Anything, mock.AnythingOfType("*domain.FeedIngestion")).
+		Run(func(args mock.Arguments) {
+			rec := args.Get(1).(*domain.FeedIngestion)
+			outcomes[rec.ObjectKey] = rec
+		}).Return(nil)
+
+	err := svc.ScanAll(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, outcomes, 3)
+	imported := outcomes[prefix+"ap invoices/inv-1.pdf"]
+	assert.Equal(t, domain.FeedIngestionImported, imported.Status)
+	assert.Equal(t, &col.ID, imported.CollectionID)
+	assert.NotNil(t, imported.DocumentID)
+	assert.Equal(t, domain.FeedIngestionFailed, outcomes[prefix+"Unknown/inv-2.pdf"].Status)
+	assert.Contains(t, outcomes[prefix+"Unknown/inv-2.pdf"].Reason, "no collection matches")
+	assert.Equal(t, domain.FeedIngestionFailed, outcomes[prefix+"loose.pdf"].Status)
+
+	m.docSvc.AssertNumberOfCalls(t, "CreateAndParse", 1)
+	m.storage.AssertNumberOfCalls(t, "Delete", 3)
+	m.storage.AssertCalled(t, "Upload", mock.Anything, mock.MatchedBy(func(in port.UploadInput) bool {
+		return in.Key == fmt.Sprintf("tenants/%s/drop-processed/%s/ap invoices/inv-1.pdf",
+			tenant.ID, time.Now().UTC().Format("2006-01-02"))
+	}))
+}
+
+func TestBatchFeedService_ScanAll_SkipsObjectClaimedElsewhere(t *testing.T) {
+	svc, m := setupBatchFeedService()
+	tenant := &domain.Tenant{ID: uuid.New(), IsActive: true}
+	prefix := fmt.Sprintf("tenants/%s/drop/", tenant.ID)
+
+	m.feedRepo.On("FailStale", mock.Anything, mock.AnythingOfType("time.Time")).Return(0, nil)
+	expectEnabledTenant(m, tenant)
+	m.storage.On("List", mock.Anything, "test-bucket", prefix).
+		Return([]port.ObjectInfo{{Key: prefix + "ap/inv-1.pdf", Size: 100}}, nil)
+	m.feedRepo.On("Claim", mock.Anything, mock.AnythingOfType("*domain.FeedIngestion")).Return(false, nil)
+
+	err := svc.ScanAll(context.Background())
+
+	require.NoError(t, err)
+	m.storage.AssertNotCalled(t, "Download", mock.Anything, mock.Anything, mock.Anything)
+	m.feedRepo.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+}
+
+func TestBatchFeedService_ScanAll_FlagDisabled(t *testing.T) {
+	svc, m := setupBatchFeedService()
+	tenant := domain.Tenant{ID: uuid.New(), IsActive: true}
+
+	m.feedRepo.On("FailStale", mock.Anything, mock.AnythingOfType("time.Time")).Return(0, nil)
+	m.tenantRepo.On("List", mock.Anything, 0, mock.Anything).Return([]domain.Tenant{tenant}, 1, nil)
+	m.flags.On("IsEnabled", mock.Anything, tenant.ID, domain.FlagBatchFeed).Return(false)
+
+	err := svc.ScanAll(context.Background())
+
+	require.NoError(t, err)
+	m.storage.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBatchFeedService_SendDueReports_EmailsAdmins(t *testing.T) {
+	svc, m := setupBatchFeedService()
+	tenant := &domain.Tenant{ID: uuid.New(), Name: "Acme", IsActive: true}
+	now := time.Date(2026, 3, 10, 19, 0, 0, 0, time.UTC)
+	reportDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	prefix := fmt.Sprintf("tenants/%s/drop/", tenant.ID)
+
+	expectEnabledTenant(m, tenant)
+	m.feedRepo.On("ClaimReport", mock.Anything, tenant.ID, reportDate).Return(true, nil)
+	m.feedRepo.On("ListCompletedBetween", mock.Anything, tenant.ID,
+		time.Date(2026, 3, 9, 18, 0, 0, 0, time.UTC), time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)).
+		Return([]domain.FeedIngestion{
+			{ObjectKey: prefix + "ap/inv-1.pdf", Status: domain.FeedIngestionImported},
+			{ObjectKey: prefix + "ap/notes.txt", Status: domain.FeedIngestionSkipped, Reason: "unsupported"},
+			{ObjectKey: prefix + "x/inv-2.pdf", Status: domain.FeedIngestionFailed, Reason: "no collection"},
+		}, nil)
+	m.userRepo.On("ListByTenant", mock.Anything, tenant.ID, 0, mock.Anything).Return([]domain.User{
+		{Email: "admin@acme.com", FullName: "Admin", Role: domain.RoleAdmin, IsActive: true},
+		{Email: "member@acme.com", FullName: "Member", Role: domain.RoleMember, IsActive: true},
+		{Email: "old@acme.com", FullName: "Old", Role: domain.RoleAdmin, IsActive: false},
+	}, 3, nil)
+	var n *port.Notification
+	m.notifier.On("Notify", mock.Anything, notificationTo(domain.NotificationKindIngestionReport, "admin@acme.com")).
+		Run(func(args mock.Arguments) { n = args.Get(1).(*port.Notification) }).Return(nil)
+
+	err := svc.SendDueReports(context.Background(), now)
+
+	require.NoError(t, err)
+	m.notifier.AssertNumberOfCalls(t, "Notify", 1)
+	require.NotNil(t, n)
+	assert.Equal(t, tenant.ID, n.TenantID)
+	assert.Len(t, n.Recipients, 1)
+	assert.Contains(t, n.Body, "x/inv-2.pdf")
+	sent := n.IngestionReport
+	require.NotNil(t, sent)
+	assert.Equal(t, 1, sent.Imported)
+	assert.Equal(t, 1, sent.Skipped)
+	assert.Equal(t, 1, sent.Failed)
+	assert.Equal(t, "ap/inv-1.pdf", sent.Entries[0].Path)
+}
+
+func TestBatchFeedService_SendDueReports_NotYetDue(t *testing.T) {
+	svc, m := setupBatchFeedService()
+	tenant := &domain.Tenant{ID: uuid.New(), IsActive: true}
+
+	expectEnabledTenant(m, tenant)
+
+	err := svc.SendDueReports(context.Background(), time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	m.feedRepo.AssertNotCalled(t, "ClaimReport", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBatchFeedService_SendDueReports_TenantTimeZone(t *testing.T) {
+	svc, m := setupBatchFeedService()
+	tenant := &domain.Tenant{ID: uuid.New(), IsActive: true, TimeZone: "Asia/Kolkata"}
+	ist := time.FixedZone("IST", 5*3600+1800)
+
+	expectEnabledTenant(m, tenant)
+	// 18:00 IST on 10 March is 12:30 UTC, and the window covers the IST day
+	m.feedRepo.On("ClaimReport", mock.Anything, tenant.ID, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)).Return(true, nil)
+	m.feedRepo.On("ListCompletedBetween", mock.Anything, tenant.ID,
+		time.Date(2026, 3, 9, 18, 0, 0, 0, ist).UTC(), time.Date(2026, 3, 10, 18, 0, 0, 0, ist).UTC()).
+		Return([]domain.FeedIngestion{}, nil)
+
+	err := svc.SendDueReports(context.Background(), time.Date(2026, 3, 10, 12, 45, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	m.feedRepo.AssertExpectations(t)
+}
+
+func TestBatchFeedService_SendDueReports_AlreadySent(t *testing.T) {
+	svc, m := setupBatchFeedService()
+	tenant := &domain.Tenant{ID: uuid.New(), IsActive: true}
+
+	expectEnabledTenant(m, tenant)
+	m.feedRepo.On("ClaimReport", mock.Anything, tenant.ID, mock.AnythingOfType("time.Time")).Return(false, nil)
+
+	err := svc.SendDueReports(context.Background(), time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	m.feedRepo.AssertNotCalled(t, "ListCompletedBetween", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
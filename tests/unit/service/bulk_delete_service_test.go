@@ -0,0 +1,180 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type bulkDeleteMocks struct {
+	repo          *mocks.MockBulkDeleteRepo
+	docRepo       *mocks.MockDocumentRepo
+	fileSvc       *mocks.MockFileService
+	auditRepo     *mocks.MockDocumentAuditRepo
+	collectionSvc *mocks.MockCollectionService
+}
+
+func setupBulkDeleteService() (service.BulkDeleteService, *bulkDeleteMocks) {
+	m := &bulkDeleteMocks{
+		repo:          new(mocks.MockBulkDeleteRepo),
+		docRepo:       new(mocks.MockDocumentRepo),
+		fileSvc:       new(mocks.MockFileService),
+		auditRepo:     new(mocks.MockDocumentAuditRepo),
+		collectionSvc: new(mocks.MockCollectionService),
+	}
+	return service.NewBulkDeleteService(m.repo, m.docRepo, m.fileSvc, m.auditRepo, m.collectionSvc), m
+}
+
+// bulkDeleteFixture matches one document in an owned collection and one in a
+// collection the member can only edit.
+type bulkDeleteFixture struct {
+	tenantID, userID uuid.UUID
+	owned, edited    domain.BulkDeleteTarget
+	filter           domain.BulkTagFilter
+}
+
+func newBulkDeleteFixture(m *bulkDeleteMocks) *bulkDeleteFixture {
+	f := &bulkDeleteFixture{
+		tenantID: uuid.New(),
+		userID:   uuid.New(),
+		owned:    domain.BulkDeleteTarget{DocumentID: uuid.New(), CollectionID: uuid.New(), FileID: uuid.New(), Name: "a.pdf"},
+		edited:   domain.BulkDeleteTarget{DocumentID: uuid.New(), CollectionID: uuid.New(), FileID: uuid.New(), Name: "b.pdf"},
+		filter:   domain.BulkTagFilter{ReviewStatus: domain.ReviewStatusRejected},
+	}
+	m.repo.On("MatchDocuments", mock.Anything, f.tenantID, &f.filter, 1001).
+		Return([]domain.BulkDeleteTarget{f.owned, f.edited}, nil)
+	m.collectionSvc.On("EffectivePermissions", mock.Anything, []uuid.UUID{f.owned.CollectionID, f.edited.CollectionID}, f.userID, domain.RoleMember).
+		Return(map[uuid.UUID]domain.CollectionPermission{
+			f.owned.CollectionID:  domain.CollectionPermOwner,
+			f.edited.CollectionID: domain.CollectionPermEditor,
+		}, nil)
+	return f
+}
+
+func (f *bulkDeleteFixture) input(token string, deleteFiles bool) *service.BulkDeleteInput {
+	return &service.BulkDeleteInput{
+		TenantID: f.tenantID, UserID: f.userID, Role: domain.RoleMember,
+		Filter: f.filter, DeleteFiles: deleteFiles, ConfirmationToken: token,
+	}
+}
+
+func TestBulkDeleteService_Preview_OnlyOwnedCollections(t *testing.T) {
+	svc, m := setupBulkDeleteService()
+	f := newBulkDeleteFixture(m)
+
+	preview, err := svc.Preview(context.Background(), f.input("", false))
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.BulkDeleteTarget{f.owned}, preview.Documents)
+	assert.Equal(t, 1, preview.MatchedCount)
+	assert.Equal(t, 1, preview.SkippedCount)
+	assert.NotEmpty(t, preview.ConfirmationToken)
+	m.docRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBulkDeleteService_Delete_WithPreviewToken(t *testing.T) {
+	svc, m := setupBulkDeleteService()
+	f := newBulkDeleteFixture(m)
+	preview, err := svc.Preview(context.Background(), f.input("", false))
+	require.NoError(t, err)
+
+	var rec *domain.BulkDelete
+	m.repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.BulkDelete")).
+		Run(func(args mock.Arguments) { rec = args.Get(1).(*domain.BulkDelete) }).Return(nil)
+	m.repo.On("Finish", mock.Anything, mock.AnythingOfType("*domain.BulkDelete")).Return(nil)
+	m.docRepo.On("Delete", mock.Anything, f.tenantID, f.owned.DocumentID).Return(nil)
+	m.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.DocumentAuditEntry) bool {
+		return e.DocumentID == f.owned.DocumentID && e.Action == string(domain.AuditDocumentDeleted)
+	})).Return(nil)
+
+	result, err := svc.Delete(context.Background(), f.input(preview.ConfirmationToken, false))
+
+	require.NoError(t, err)
+	assert.Same(t, rec, result)
+	assert.JSONEq(t, `{"review_status":"rejected"}`, string(result.Filter))
+	assert.Equal(t, 1, result.DeletedCount)
+	assert.NotNil(t, result.CompletedAt)
+	m.docRepo.AssertNotCalled(t, "Delete", mock.Anything, f.tenantID, f.edited.DocumentID)
+	m.auditRepo.AssertExpectations(t)
+}
+
+func TestBulkDeleteService_Delete_FilesCascade(t *testing.T) {
+	svc, m := setupBulkDeleteService()
+	f := newBulkDeleteFixture(m)
+	preview, err := svc.Preview(context.Background(), f.input("", true))
+	require.NoError(t, err)
+
+	m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("Finish", mock.Anything, mock.Anything).Return(nil)
+	m.fileSvc.On("Delete", mock.Anything, f.tenantID, f.owned.FileID).Return(errors.New("s3 down"))
+
+	result, err := svc.Delete(context.Background(), f.input(preview.ConfirmationToken, true))
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.DeletedCount)
+	assert.Equal(t, 1, result.FailedCount)
+	m.docRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	m.auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestBulkDeleteService_Delete_RejectsTokenOfOtherRun(t *testing.T) {
+	svc, m := setupBulkDeleteService()
+	f := newBulkDeleteFixture(m)
+	// A token previewed without files doesn't confirm deleting the files
+	preview, err := svc.Preview(context.Background(), f.input("", false))
+	require.NoError(t, err)
+
+	_, err = svc.Delete(context.Background(), f.input(preview.ConfirmationToken, true))
+
+	assert.ErrorIs(t, err, domain.ErrBulkDeleteUnconfirmed)
+	m.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestBulkDeleteService_Delete_RequiresToken(t *testing.T) {
+	svc, _ := setupBulkDeleteService()
+
+	_, err := svc.Delete(context.Background(), &service.BulkDeleteInput{
+		Filter: domain.BulkTagFilter{ReviewStatus: domain.ReviewStatusRejected},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidBulkDelete)
+}
+
+func TestBulkDeleteService_Preview_Validation(t *testing.T) {
+	svc, m := setupBulkDeleteService()
+	collectionID, userID := uuid.New(), uuid.New()
+
+	_, err := svc.Preview(context.Background(), &service.BulkDeleteInput{})
+	assert.ErrorIs(t, err, domain.ErrInvalidBulkDelete)
+
+	_, err = svc.Preview(context.Background(), &service.BulkDeleteInput{Filter: domain.BulkTagFilter{From: "01/03/2026"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidBulkDelete)
+
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleMember).Return(domain.CollectionPermEditor)
+	_, err = svc.Preview(context.Background(), &service.BulkDeleteInput{
+		UserID: userID, Role: domain.RoleMember, Filter: domain.BulkTagFilter{CollectionID: &collectionID},
+	})
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	m.repo.AssertNotCalled(t, "MatchDocuments", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBulkDeleteService_Preview_TooManyMatches(t *testing.T) {
+	svc, m := setupBulkDeleteService()
+	tenantID := uuid.New()
+	filter := domain.BulkTagFilter{SellerGSTIN: "29ABCDE1234F1Z5"}
+	m.repo.On("MatchDocuments", mock.Anything, tenantID, &filter, 1001).
+		Return(make([]domain.BulkDeleteTarget, 1001), nil)
+
+	_, err := svc.Preview(context.Background(), &service.BulkDeleteInput{TenantID: tenantID, Filter: filter})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidBulkDelete)
+}
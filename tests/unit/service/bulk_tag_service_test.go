@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type bulkTagMocks struct {
+	jobRepo       *mocks.MockBulkTagJobRepo
+	tagRepo       *mocks.MockDocumentTagRepo
+	auditRepo     *mocks.MockDocumentAuditRepo
+	collectionSvc *mocks.MockCollectionService
+}
+
+func setupBulkTagService() (service.BulkTagService, *bulkTagMocks) {
+	m := &bulkTagMocks{
+		jobRepo:       new(mocks.MockBulkTagJobRepo),
+		tagRepo:       new(mocks.MockDocumentTagRepo),
+		auditRepo:     new(mocks.MockDocumentAuditRepo),
+		collectionSvc: new(mocks.MockCollectionService),
+	}
+	return service.NewBulkTagService(m.jobRepo, m.tagRepo, m.auditRepo, m.collectionSvc), m
+}
+
+// expectBulkTagRun records the job passed to Create and closes done once the job finishes.
+func expectBulkTagRun(m *bulkTagMocks, done chan struct{}) **domain.BulkTagJob {
+	var job *domain.BulkTagJob
+	m.jobRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.BulkTagJob")).
+		Run(func(args mock.Arguments) { job = args.Get(1).(*domain.BulkTagJob) }).Return(nil)
+	m.jobRepo.On("UpdateProgress", mock.Anything, mock.AnythingOfType("*domain.BulkTagJob")).
+		Run(func(args mock.Arguments) {
+			if args.Get(1).(*domain.BulkTagJob).CompletedAt != nil {
+				close(done)
+			}
+		}).Return(nil)
+	return &job
+}
+
+func TestBulkTagService_Start_AddsTagToMatchingDocuments(t *testing.T) {
+	svc, m := setupBulkTagService()
+	tenantID, userID := uuid.New(), uuid.New()
+	editable, readOnly := uuid.New(), uuid.New()
+	fresh, tagged, foreign := uuid.New(), uuid.New(), uuid.New()
+	filter := domain.BulkTagFilter{SellerGSTIN: "29ABCDE1234F1Z5", From: "2026-03-01", To: "2026-03-31"}
+
+	done := make(chan struct{})
+	job := expectBulkTagRun(m, done)
+	m.jobRepo.On("MatchDocuments", mock.Anything, tenantID, &filter, 10001).Return([]domain.BulkTagTarget{
+		{DocumentID: fresh, CollectionID: editable},
+		{DocumentID: tagged, CollectionID: editable},
+		{DocumentID: foreign, CollectionID: readOnly},
+	}, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, editable, userID, domain.RoleMember).Return(domain.CollectionPermEditor)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, readOnly, userID, domain.RoleMember).Return(domain.CollectionPermViewer)
+	m.tagRepo.On("AddIfMissing", mock.Anything, mock.MatchedBy(func(tag *domain.DocumentTag) bool {
+		return tag.DocumentID == fresh && tag.Key == "audit" && tag.Value == "sample"
+	})).Return(true, nil)
+	m.tagRepo.On("AddIfMissing", mock.Anything, mock.MatchedBy(func(tag *domain.DocumentTag) bool {
+		return tag.DocumentID == tagged
+	})).Return(false, nil)
+	m.auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil)
+
+	result, err := svc.Start(context.Background(), &service.BulkTagInput{
+		TenantID: tenantID, UserID: userID, Role: domain.RoleMember,
+		Action: domain.BulkTagAdd, Key: " audit ", Value: "sample", Filter: filter,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.BulkTagStatusPending, result.Status)
+	waitFor(t, done)
+
+	assert.Equal(t, domain.BulkTagStatusCompleted, (*job).Status)
+	assert.Equal(t, 3, (*job).MatchedCount)
+	assert.Equal(t, 1, (*job).UpdatedCount)
+	assert.Equal(t, 2, (*job).SkippedCount)
+	m.auditRepo.AssertNumberOfCalls(t, "Create", 1)
+	m.collectionSvc.AssertNumberOfCalls(t, "EffectivePermission", 2)
+}
+
+func TestBulkTagService_Start_RemovesTag(t *testing.T) {
+	svc, m := setupBulkTagService()
+	tenantID, userID, collectionID, docID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	filter := domain.BulkTagFilter{TagKey: "audit"}
+
+	done := make(chan struct{})
+	job := expectBulkTagRun(m, done)
+	m.jobRepo.On("MatchDocuments", mock.Anything, tenantID, &filter, 10001).
+		Return([]domain.BulkTagTarget{{DocumentID: docID, CollectionID: collectionID}}, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleManager).Return(domain.CollectionPermEditor)
+	m.tagRepo.On("DeleteByKeyValue", mock.Anything, docID, "audit", "sample").Return(0, errors.New("db down"))
+
+	_, err := svc.Start(context.Background(), &service.BulkTagInput{
+		TenantID: tenantID, UserID: userID, Role: domain.RoleManager,
+		Action: domain.BulkTagRemove, Key: "audit", Value: "sample", Filter: filter,
+	})
+
+	require.NoError(t, err)
+	waitFor(t, done)
+	assert.Equal(t, domain.BulkTagStatusCompleted, (*job).Status)
+	assert.Equal(t, 1, (*job).FailedCount)
+	m.auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestBulkTagService_Start_TooManyMatchesFails(t *testing.T) {
+	svc, m := setupBulkTagService()
+	tenantID := uuid.New()
+	filter := domain.BulkTagFilter{ReviewStatus: domain.ReviewStatusPending}
+
+	done := make(chan struct{})
+	job := expectBulkTagRun(m, done)
+	m.jobRepo.On("MatchDocuments", mock.Anything, tenantID, &filter, 10001).
+		Return(make([]domain.BulkTagTarget, 10001), nil)
+
+	_, err := svc.Start(context.Background(), &service.BulkTagInput{
+		TenantID: tenantID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		Action: domain.BulkTagAdd, Key: "audit", Filter: filter,
+	})
+
+	require.NoError(t, err)
+	waitFor(t, done)
+	assert.Equal(t, domain.BulkTagStatusFailed, (*job).Status)
+	require.NotNil(t, (*job).Error)
+	assert.Contains(t, *(*job).Error, "more than 10000")
+	m.tagRepo.AssertNotCalled(t, "AddIfMissing", mock.Anything, mock.Anything)
+}
+
+func TestBulkTagService_Start_Invalid(t *testing.T) {
+	cases := map[string]service.BulkTagInput{
+		"empty filter":   {Action: domain.BulkTagAdd, Key: "audit"},
+		"unknown action": {Action: "toggle", Key: "audit", Filter: domain.BulkTagFilter{TagKey: "x"}},
+		"blank key":      {Action: domain.BulkTagAdd, Key: "  ", Filter: domain.BulkTagFilter{TagKey: "x"}},
+		"bad date":       {Action: domain.BulkTagAdd, Key: "audit", Filter: domain.BulkTagFilter{From: "03/01/2026"}},
+	}
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			svc, m := setupBulkTagService()
+			input := input
+
+			_, err := svc.Start(context.Background(), &input)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidBulkTag)
+			m.jobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBulkTagService_GetJob_OtherUsersJobHidden(t *testing.T) {
+	svc, m := setupBulkTagService()
+	tenantID, jobID := uuid.New(), uuid.New()
+	m.jobRepo.On("GetByID", mock.Anything, tenantID, jobID).
+		Return(&domain.BulkTagJob{ID: jobID, TenantID: tenantID, CreatedBy: uuid.New()}, nil)
+
+	_, err := svc.GetJob(context.Background(), tenantID, jobID, uuid.New(), domain.RoleManager)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	job, err := svc.GetJob(context.Background(), tenantID, jobID, uuid.New(), domain.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, jobID, job.ID)
+}
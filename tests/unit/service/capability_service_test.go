@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/router"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type capabilityFixture struct {
+	userRepo *mocks.MockUserRepo
+	permRepo *mocks.MockCollectionPermissionRepo
+	collRepo *mocks.MockCollectionRepo
+	flags    *mocks.MockFeatureFlagService
+	svc      service.CapabilityService
+	tenantID uuid.UUID
+	user     *domain.User
+}
+
+func newCapabilityFixture(role domain.UserRole, verified bool) *capabilityFixture {
+	return newRestrictedCapabilityFixture(role, verified, map[uuid.UUID]domain.CollectionPermission{})
+}
+
+// newRestrictedCapabilityFixture is a fixture whose tenant has collections
+// restricting original file downloads.
+func newRestrictedCapabilityFixture(role domain.UserRole, verified bool, restricted map[uuid.UUID]domain.CollectionPermission) *capabilityFixture {
+	f := &capabilityFixture{
+		userRepo: new(mocks.MockUserRepo),
+		permRepo: new(mocks.MockCollectionPermissionRepo),
+		collRepo: new(mocks.MockCollectionRepo),
+		flags:    new(mocks.MockFeatureFlagService),
+		tenantID: uuid.New(),
+	}
+	f.user = &domain.User{ID: uuid.New(), TenantID: f.tenantID, Role: role, EmailVerified: verified, IsActive: true}
+	f.svc = service.NewCapabilityService(router.RouteMatrix(), f.userRepo, f.permRepo, f.collRepo, f.flags)
+	f.userRepo.On("GetByID", mock.Anything, f.tenantID, f.user.ID).Return(f.user, nil)
+	f.collRepo.On("ListFileDownloadPerms", mock.Anything, f.tenantID).Return(restricted, nil)
+	f.flags.On("IsEnabled", mock.Anything, f.tenantID, mock.Anything).Return(false)
+	return f
+}
+
+func hasOperation(caps *domain.Capabilities, method, path string) bool {
+	for _, op := range caps.Operations {
+		if op.Method == method && op.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCapabilityService_Get_OperationsFollowRouteMatrix(t *testing.T) {
+	f := newCapabilityFixture(domain.RoleMember, true)
+	f.permRepo.On("ListByUser", mock.Anything, f.tenantID, f.user.ID).Return(map[uuid.UUID]domain.CollectionPermission{}, nil)
+
+	caps, err := f.svc.Get(context.Background(), f.tenantID, f.user.ID, domain.RoleMember)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleMember, caps.Role)
+	assert.True(t, hasOperation(caps, "POST", "/api/v1/collections"))
+	assert.True(t, hasOperation(caps, "POST", "/api/v1/documents/bulk-delete"))
+	assert.False(t, hasOperation(caps, "POST", "/api/v1/users"))
+	assert.False(t, hasOperation(caps, "GET", "/api/v1/admin/authz-matrix"))
+	for _, op := range caps.Operations {
+		if op.Method == "PUT" && op.Path == "/api/v1/documents/:id/review" {
+			assert.Equal(t, domain.CollectionPermEditor, op.CollectionPerm)
+		}
+	}
+}
+
+func TestCapabilityService_Get_UnverifiedFreeUserCannotUpload(t *testing.T) {
+	f := newCapabilityFixture(domain.RoleFree, false)
+	f.permRepo.On("ListByUser", mock.Anything, f.tenantID, f.user.ID).Return(map[uuid.UUID]domain.CollectionPermission{}, nil)
+
+	caps, err := f.svc.Get(context.Background(), f.tenantID, f.user.ID, domain.RoleFree)
+
+	require.NoError(t, err)
+	assert.False(t, caps.EmailVerified)
+	assert.False(t, hasOperation(caps, "POST", "/api/v1/documents"))
+	assert.False(t, hasOperation(caps, "POST", "/api/v1/files/upload"))
+	assert.True(t, hasOperation(caps, "GET", "/api/v1/documents"))
+}
+
+func TestCapabilityService_Get_VerifiedFreeUserCanUpload(t *testing.T) {
+	f := newCapabilityFixture(domain.RoleFree, true)
+	f.permRepo.On("ListByUser", mock.Anything, f.tenantID, f.user.ID).Return(map[uuid.UUID]domain.CollectionPermission{}, nil)
+
+	caps, err := f.svc.Get(context.Background(), f.tenantID, f.user.ID, domain.RoleFree)
+
+	require.NoError(t, err)
+	assert.True(t, hasOperation(caps, "POST", "/api/v1/documents"))
+}
+
+func TestCapabilityService_Get_CollectionGrantsAboveRoleDefault(t *testing.T) {
+	f := newCapabilityFixture(domain.RoleManager, true)
+	owned, viewed := uuid.New(), uuid.New()
+	f.permRepo.On("ListByUser", mock.Anything, f.tenantID, f.user.ID).Return(map[uuid.UUID]domain.CollectionPermission{
+		owned:  domain.CollectionPermOwner,
+		viewed: domain.CollectionPermViewer,
+	}, nil)
+
+	caps, err := f.svc.Get(context.Background(), f.tenantID, f.user.ID, domain.RoleManager)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.CollectionPermEditor, caps.CollectionPerm)
+	assert.Equal(t, map[uuid.UUID]domain.CollectionPermission{owned: domain.CollectionPermOwner}, caps.Collections)
+}
+
+func TestCapabilityService_Get_AdminSkipsGrantLookup(t *testing.T) {
+	f := newCapabilityFixture(domain.RoleAdmin, true)
+
+	caps, err := f.svc.Get(context.Background(), f.tenantID, f.user.ID, domain.RoleAdmin)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.CollectionPermOwner, caps.CollectionPerm)
+	assert.Empty(t, caps.Collections)
+	assert.True(t, hasOperation(caps, "GET", "/api/v1/admin/authz-matrix"))
+	assert.Empty(t, caps.FileDownloadDenied)
+	f.permRepo.AssertNotCalled(t, "ListByUser", mock.Anything, mock.Anything, mock.Anything)
+	f.collRepo.AssertNotCalled(t, "ListFileDownloadPerms", mock.Anything, mock.Anything)
+}
+
+func TestCapabilityService_Get_FileDownloadDenied(t *testing.T) {
+	ownerOnly, editorOnly, granted := uuid.New(), uuid.New(), uuid.New()
+	f := newRestrictedCapabilityFixture(domain.RoleManager, true, map[uuid.UUID]domain.CollectionPermission{
+		ownerOnly:  domain.CollectionPermOwner,
+		editorOnly: domain.CollectionPermEditor,
+		granted:    domain.CollectionPermOwner,
+	})
+	f.permRepo.On("ListByUser", mock.Anything, f.tenantID, f.user.ID).Return(map[uuid.UUID]domain.CollectionPermission{
+		granted: domain.CollectionPermOwner,
+	}, nil)
+
+	caps, err := f.svc.Get(context.Background(), f.tenantID, f.user.ID, domain.RoleManager)
+
+	require.NoError(t, err)
+	// A manager is an implicit editor, and owns the granted collection
+	assert.Equal(t, []uuid.UUID{ownerOnly}, caps.FileDownloadDenied)
+}
+
+func TestCapabilityService_Get_ReportsFeatureFlags(t *testing.T) {
+	f := &capabilityFixture{
+		userRepo: new(mocks.MockUserRepo),
+		permRepo: new(mocks.MockCollectionPermissionRepo),
+		flags:    new(mocks.MockFeatureFlagService),
+		tenantID: uuid.New(),
+	}
+	f.user = &domain.User{ID: uuid.New(), TenantID: f.tenantID, Role: domain.RoleAdmin, IsActive: true}
+	f.svc = service.NewCapabilityService(router.RouteMatrix(), f.userRepo, f.permRepo, new(mocks.MockCollectionRepo), f.flags)
+	f.userRepo.On("GetByID", mock.Anything, f.tenantID, f.user.ID).Return(f.user, nil)
+	f.flags.On("IsEnabled", mock.Anything, f.tenantID, domain.FlagDualParse).Return(true)
+	f.flags.On("IsEnabled", mock.Anything, f.tenantID, mock.Anything).Return(false)
+
+	caps, err := f.svc.Get(context.Background(), f.tenantID, f.user.ID, domain.RoleAdmin)
+
+	require.NoError(t, err)
+	assert.Len(t, caps.Features, len(domain.FeatureFlagDefaults))
+	assert.True(t, caps.Features[domain.FlagDualParse])
+	assert.False(t, caps.Features[domain.FlagBatchFeed])
+}
+
+func TestCapabilityService_Get_UserNotFound(t *testing.T) {
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewCapabilityService(router.RouteMatrix(), userRepo, new(mocks.MockCollectionPermissionRepo), new(mocks.MockCollectionRepo), new(mocks.MockFeatureFlagService))
+	tenantID, userID := uuid.New(), uuid.New()
+	userRepo.On("GetByID", mock.Anything, tenantID, userID).Return(nil, domain.ErrNotFound)
+
+	_, err := svc.Get(context.Background(), tenantID, userID, domain.RoleMember)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
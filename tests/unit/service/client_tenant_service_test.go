@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type clientTenantMocks struct {
+	tenantRepo     *mocks.MockTenantRepo
+	membershipRepo *mocks.MockTenantMembershipRepo
+	userRepo       *mocks.MockUserRepo
+	statsRepo      *mocks.MockStatsRepo
+}
+
+func setupClientTenantService() (service.ClientTenantService, *clientTenantMocks) {
+	m := &clientTenantMocks{
+		tenantRepo:     new(mocks.MockTenantRepo),
+		membershipRepo: new(mocks.MockTenantMembershipRepo),
+		userRepo:       new(mocks.MockUserRepo),
+		statsRepo:      new(mocks.MockStatsRepo),
+	}
+	return service.NewClientTenantService(m.tenantRepo, m.membershipRepo, m.userRepo, m.statsRepo), m
+}
+
+func TestClientTenantService_Create(t *testing.T) {
+	svc, m := setupClientTenantService()
+	firmID, adminID := uuid.New(), uuid.New()
+	region := "ap-south-1"
+
+	m.tenantRepo.On("GetByID", mock.Anything, firmID).Return(&domain.Tenant{ID: firmID, StorageRegion: &region, IsActive: true}, nil)
+	m.tenantRepo.On("Create", mock.Anything, mock.MatchedBy(func(tn *domain.Tenant) bool {
+		return tn.Slug == "client-a" && tn.IsActive && tn.ParentTenantID != nil && *tn.ParentTenantID == firmID &&
+			tn.StorageRegion != nil && *tn.StorageRegion == region
+	})).Run(func(args mock.Arguments) { args.Get(1).(*domain.Tenant).ID = uuid.New() }).Return(nil)
+	m.membershipRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(ms *domain.TenantMembership) bool {
+		return ms.UserID == adminID && ms.Role == domain.RoleAdmin && ms.IsActive
+	})).Return(nil)
+
+	client, err := svc.Create(context.Background(), firmID, &service.CreateClientInput{Name: " Client A ", Slug: "client-a"}, adminID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Client A", client.Name)
+	m.tenantRepo.AssertExpectations(t)
+	m.membershipRepo.AssertExpectations(t)
+}
+
+func TestClientTenantService_Create_FromClientRejected(t *testing.T) {
+	svc, m := setupClientTenantService()
+	clientID, firmID := uuid.New(), uuid.New()
+	m.tenantRepo.On("GetByID", mock.Anything, clientID).Return(&domain.Tenant{ID: clientID, ParentTenantID: &firmID}, nil)
+
+	_, err := svc.Create(context.Background(), clientID, &service.CreateClientInput{Name: "Nested", Slug: "nested"}, uuid.New())
+
+	assert.ErrorIs(t, err, domain.ErrInvalidClientTenant)
+	m.tenantRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestClientTenantService_Get_OtherFirmsClient(t *testing.T) {
+	svc, m := setupClientTenantService()
+	clientID, otherFirm := uuid.New(), uuid.New()
+	m.tenantRepo.On("GetByID", mock.Anything, clientID).Return(&domain.Tenant{ID: clientID, ParentTenantID: &otherFirm}, nil)
+
+	_, err := svc.Get(context.Background(), uuid.New(), clientID)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestClientTenantService_AssignStaff_RejectsNonFirmUsers(t *testing.T) {
+	svc, m := setupClientTenantService()
+	firmID, clientID, guestID, otherHome := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	m.tenantRepo.On("GetByID", mock.Anything, clientID).Return(&domain.Tenant{ID: clientID, ParentTenantID: &firmID}, nil)
+	m.userRepo.On("GetByID", mock.Anything, firmID, guestID).
+		Return(&domain.User{ID: guestID, TenantID: firmID, HomeTenantID: &otherHome}, nil)
+
+	_, err := svc.AssignStaff(context.Background(), firmID, clientID,
+		&service.AssignStaffInput{UserID: guestID, Role: domain.RoleMember}, uuid.New())
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMembership)
+	m.membershipRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
+
+func TestClientTenantService_MoveStaff(t *testing.T) {
+	svc, m := setupClientTenantService()
+	firmID, fromID, toID, staffID, adminID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	m.tenantRepo.On("GetByID", mock.Anything, fromID).Return(&domain.Tenant{ID: fromID, ParentTenantID: &firmID}, nil)
+	m.tenantRepo.On("GetByID", mock.Anything, toID).Return(&domain.Tenant{ID: toID, ParentTenantID: &firmID}, nil)
+	m.membershipRepo.On("Get", mock.Anything, fromID, staffID).
+		Return(&domain.TenantMembership{TenantID: fromID, UserID: staffID, Role: domain.RoleManager, IsActive: true}, nil)
+	m.userRepo.On("GetByID", mock.Anything, firmID, staffID).Return(&domain.User{ID: staffID, TenantID: firmID}, nil)
+	m.membershipRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(ms *domain.TenantMembership) bool {
+		return ms.TenantID == toID && ms.UserID == staffID && ms.Role == domain.RoleManager
+	})).Return(nil)
+	m.membershipRepo.On("Delete", mock.Anything, fromID, staffID).Return(nil)
+
+	membership, err := svc.MoveStaff(context.Background(), firmID, fromID, staffID, &service.MoveStaffInput{ToClientID: toID}, adminID)
+
+	require.NoError(t, err)
+	assert.Equal(t, toID, membership.TenantID)
+	m.membershipRepo.AssertExpectations(t)
+}
+
+func TestClientTenantService_Dashboard(t *testing.T) {
+	svc, m := setupClientTenantService()
+	firmID := uuid.New()
+	a, b := domain.Tenant{ID: uuid.New(), Name: "A", IsActive: true}, domain.Tenant{ID: uuid.New(), Name: "B"}
+
+	m.tenantRepo.On("ListClients", mock.Anything, firmID, 0, 100).Return([]domain.Tenant{a, b}, 2, nil)
+	m.statsRepo.On("GetTenantStats", mock.Anything, a.ID).Return(&domain.Stats{TotalDocuments: 5, ReviewPending: 2}, nil)
+	m.statsRepo.On("GetTenantStats", mock.Anything, b.ID).Return(&domain.Stats{TotalDocuments: 3, ReviewPending: 1}, nil)
+
+	dashboard, err := svc.Dashboard(context.Background(), firmID)
+
+	require.NoError(t, err)
+	require.Len(t, dashboard.Clients, 2)
+	assert.Equal(t, 5, dashboard.Clients[0].Stats.TotalDocuments)
+	assert.Equal(t, 8, dashboard.Totals.TotalDocuments)
+	assert.Equal(t, 3, dashboard.Totals.ReviewPending)
+}
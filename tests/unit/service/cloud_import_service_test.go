@@ -0,0 +1,233 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/cloud"
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type cloudMocks struct {
+	provider      *mocks.MockCloudDriveProvider
+	connRepo      *mocks.MockCloudConnectionRepo
+	syncRepo      *mocks.MockCloudSyncRepo
+	userRepo      *mocks.MockUserRepo
+	fileSvc       *mocks.MockFileService
+	collectionSvc *mocks.MockCollectionService
+	docSvc        *mocks.MockDocumentService
+	sealer        *cloud.TokenSealer
+}
+
+func setupCloudImportService(t *testing.T) (service.CloudImportService, *cloudMocks) {
+	t.Helper()
+	sealer, err := cloud.NewTokenSealer("test-token-key")
+	require.NoError(t, err)
+	m := &cloudMocks{
+		provider:      &mocks.MockCloudDriveProvider{Name: domain.CloudProviderGoogleDrive},
+		connRepo:      new(mocks.MockCloudConnectionRepo),
+		syncRepo:      new(mocks.MockCloudSyncRepo),
+		userRepo:      new(mocks.MockUserRepo),
+		fileSvc:       new(mocks.MockFileService),
+		collectionSvc: new(mocks.MockCollectionService),
+		docSvc:        new(mocks.MockDocumentService),
+		sealer:        sealer,
+	}
+	s3Cfg := testS3Config()
+	svc := service.NewCloudImportService(
+		[]port.CloudDriveProvider{m.provider}, m.connRepo, m.syncRepo, m.userRepo,
+		m.fileSvc, m.collectionSvc, m.docSvc, sealer,
+		config.JWTConfig{Secret: "test-secret", Issuer: "satvos"}, &s3Cfg,
+	)
+	return svc, m
+}
+
+// stateFromURL extracts the OAuth state parameter from a consent URL.
+func stateFromURL(t *testing.T, consentURL string) string {
+	t.Helper()
+	u, err := url.Parse(consentURL)
+	require.NoError(t, err)
+	return u.Query().Get("state")
+}
+
+func TestCloudImportService_Connect_Success(t *testing.T) {
+	svc, m := setupCloudImportService(t)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	consentURL, err := svc.AuthorizeURL(context.Background(), tenantID, userID, domain.CloudProviderGoogleDrive)
+	require.NoError(t, err)
+
+	m.provider.On("Exchange", mock.Anything, "auth-code").Return(&port.CloudToken{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		Expiry:       time.Now().Add(time.Hour),
+		AccountID:    "ops@acme.com",
+	}, nil)
+	var saved *domain.CloudConnection
+	m.connRepo.On("Upsert", mock.Anything, mock.AnythingOfType("*domain.CloudConnection")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*domain.CloudConnection) }).Return(nil)
+
+	conn, err := svc.Connect(context.Background(), tenantID, userID, domain.CloudProviderGoogleDrive,
+		"auth-code", stateFromURL(t, consentURL))
+
+	require.NoError(t, err)
+	assert.Equal(t, "ops@acme.com", conn.AccountID)
+	require.NotNil(t, saved)
+	assert.NotEqual(t, "access-1", saved.AccessToken, "tokens must be stored encrypted")
+	access, err := m.sealer.Open(saved.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", access)
+}
+
+func TestCloudImportService_Connect_StateFromOtherUser(t *testing.T) {
+	svc, m := setupCloudImportService(t)
+	tenantID := uuid.New()
+
+	consentURL, err := svc.AuthorizeURL(context.Background(), tenantID, uuid.New(), domain.CloudProviderGoogleDrive)
+	require.NoError(t, err)
+
+	_, err = svc.Connect(context.Background(), tenantID, uuid.New(), domain.CloudProviderGoogleDrive,
+		"auth-code", stateFromURL(t, consentURL))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidOAuthState)
+	m.provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything)
+}
+
+func TestCloudImportService_AuthorizeURL_ProviderNotConfigured(t *testing.T) {
+	svc, _ := setupCloudImportService(t)
+
+	_, err := svc.AuthorizeURL(context.Background(), uuid.New(), uuid.New(), domain.CloudProviderDropbox)
+
+	assert.ErrorIs(t, err, domain.ErrCloudProviderNotConfigured)
+}
+
+func TestCloudImportService_CreateSync_ViewerDenied(t *testing.T) {
+	svc, m := setupCloudImportService(t)
+	collectionID, userID := uuid.New(), uuid.New()
+
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleViewer).
+		Return(domain.CollectionPermViewer)
+
+	_, err := svc.CreateSync(context.Background(), &service.CreateCloudSyncInput{
+		TenantID:     uuid.New(),
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         domain.RoleViewer,
+		ConnectionID: uuid.New(),
+		FolderID:     "folder-1",
+		DocumentType: "invoice",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	m.syncRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCloudImportService_PollDue_ImportsNewFiles(t *testing.T) {
+	svc, m := setupCloudImportService(t)
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	staleAccess, _ := m.sealer.Seal("stale-access")
+	refresh, _ := m.sealer.Seal("refresh-1")
+	conn := &domain.CloudConnection{
+		ID: uuid.New(), TenantID: tenantID, UserID: userID, Provider: domain.CloudProviderGoogleDrive,
+		AccessToken: staleAccess, RefreshToken: refresh, TokenExpiry: time.Now().Add(-time.Minute),
+	}
+	cs := domain.CloudSync{
+		ID: uuid.New(), TenantID: tenantID, CollectionID: collectionID, ConnectionID: conn.ID,
+		Provider: domain.CloudProviderGoogleDrive, FolderID: "folder-1", DocumentType: "invoice",
+		ParseMode: domain.ParseModeSingle, PollEnabled: true, CreatedBy: userID,
+	}
+	dupContent := []byte("%PDF-1.4 duplicate")
+
+	m.syncRepo.On("ClaimDueForPoll", mock.Anything, mock.AnythingOfType("time.Time"), mock.Anything).
+		Return([]domain.CloudSync{cs}, nil)
+	m.connRepo.On("GetByID", mock.Anything, tenantID, conn.ID).Return(conn, nil)
+	m.userRepo.On("GetByID", mock.Anything, tenantID, userID).
+		Return(&domain.User{ID: userID, TenantID: tenantID, Role: domain.RoleMember, IsActive: true}, nil)
+	m.provider.On("Refresh", mock.Anything, "refresh-1").
+		Return(&port.CloudToken{AccessToken: "fresh-access", Expiry: time.Now().Add(time.Hour)}, nil)
+	m.connRepo.On("UpdateTokens", mock.Anything, conn).Return(nil)
+	m.provider.On("ListFolder", mock.Anything, "fresh-access", "folder-1").Return([]port.CloudFile{
+		{ID: "sub", Name: "Archive", IsFolder: true},
+		{ID: "seen", Name: "old.pdf", Size: 10},
+		{ID: "doc", Name: "notes.docx", Size: 10},
+		{ID: "dup", Name: "copy.pdf", Size: 10},
+		{ID: "new", Name: "inv-1.pdf", Size: 10},
+	}, nil)
+	m.syncRepo.On("SeenExternalIDs", mock.Anything, cs.ID).Return(map[string]bool{"seen": true}, nil)
+	m.provider.On("Download", mock.Anything, "fresh-access", "dup", mock.Anything).Return(dupContent, nil)
+	m.provider.On("Download", mock.Anything, "fresh-access", "new", mock.Anything).Return(pdfContent(), nil)
+	dupHash := sha256.Sum256(dupContent)
+	m.syncRepo.On("HashImported", mock.Anything, collectionID, hex.EncodeToString(dupHash[:])).Return(true, nil)
+	m.syncRepo.On("HashImported", mock.Anything, collectionID, mock.AnythingOfType("string")).Return(false, nil)
+	expectImportPipeline(&importMocks{fileSvc: m.fileSvc, collectionSvc: m.collectionSvc, docSvc: m.docSvc}, tenantID, collectionID)
+
+	recorded := map[string]domain.CloudSyncFileStatus{}
+	m.syncRepo.On("RecordFile", mock.Anything, mock.AnythingOfType("*domain.CloudSyncFile")).
+		Run(func(args mock.Arguments) {
+			f := args.Get(1).(*domain.CloudSyncFile)
+			recorded[f.ExternalID] = f.Status
+		}).Return(nil)
+	var updated *domain.CloudSync
+	m.syncRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.CloudSync")).
+		Run(func(args mock.Arguments) { updated = args.Get(1).(*domain.CloudSync) }).Return(nil)
+
+	err := svc.PollDue(context.Background(), 15*time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.CloudSyncFileStatus{
+		"doc": domain.CloudSyncFileSkipped,
+		"dup": domain.CloudSyncFileDuplicate,
+		"new": domain.CloudSyncFileImported,
+	}, recorded)
+	require.NotNil(t, updated)
+	assert.NotNil(t, updated.LastSyncedAt)
+	assert.Nil(t, updated.LastError)
+	m.docSvc.AssertNumberOfCalls(t, "CreateAndParse", 1)
+}
+
+func TestCloudImportService_PollDue_AuthFailureDisablesPolling(t *testing.T) {
+	svc, m := setupCloudImportService(t)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	access, _ := m.sealer.Seal("access-1")
+	conn := &domain.CloudConnection{
+		ID: uuid.New(), TenantID: tenantID, UserID: userID, Provider: domain.CloudProviderGoogleDrive,
+		AccessToken: access, TokenExpiry: time.Now().Add(time.Hour),
+	}
+	cs := domain.CloudSync{
+		ID: uuid.New(), TenantID: tenantID, CollectionID: uuid.New(), ConnectionID: conn.ID,
+		Provider: domain.CloudProviderGoogleDrive, FolderID: "folder-1", PollEnabled: true, CreatedBy: userID,
+	}
+
+	m.syncRepo.On("ClaimDueForPoll", mock.Anything, mock.AnythingOfType("time.Time"), mock.Anything).
+		Return([]domain.CloudSync{cs}, nil)
+	m.connRepo.On("GetByID", mock.Anything, tenantID, conn.ID).Return(conn, nil)
+	m.userRepo.On("GetByID", mock.Anything, tenantID, userID).
+		Return(&domain.User{ID: userID, Role: domain.RoleMember, IsActive: true}, nil)
+	m.provider.On("ListFolder", mock.Anything, "access-1", "folder-1").Return(nil, domain.ErrCloudAuthFailed)
+	var updated *domain.CloudSync
+	m.syncRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.CloudSync")).
+		Run(func(args mock.Arguments) { updated = args.Get(1).(*domain.CloudSync) }).Return(nil)
+
+	err := svc.PollDue(context.Background(), 15*time.Minute)
+
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.False(t, updated.PollEnabled)
+	require.NotNil(t, updated.LastError)
+	assert.Contains(t, *updated.LastError, "reconnect")
+}
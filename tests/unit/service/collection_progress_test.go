@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+func TestCollectionService_SetKPITargets(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID}, nil)
+	collRepo.On("UpdateKPITargets", mock.Anything, mock.AnythingOfType("*domain.Collection")).Return(nil)
+
+	result, err := svc.SetKPITargets(context.Background(), &service.SetKPITargetsInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleMember,
+		Targets: []domain.KPITarget{{Metric: domain.KPIMetricReviewed, DueDate: "2026-11-10"}},
+	})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"metric":"reviewed","target_pct":100,"due_date":"2026-11-10"}]`, string(result.KPITargets))
+}
+
+func TestCollectionService_SetKPITargets_Invalid(t *testing.T) {
+	target := domain.KPITarget{Metric: domain.KPIMetricParsed, TargetPct: 90, DueDate: "2026-11-10"}
+	tests := []struct {
+		name    string
+		targets []domain.KPITarget
+	}{
+		{"unknown metric", []domain.KPITarget{{Metric: "exported", DueDate: "2026-11-10"}}},
+		{"over 100 percent", []domain.KPITarget{{Metric: domain.KPIMetricParsed, TargetPct: 120, DueDate: "2026-11-10"}}},
+		{"negative percent", []domain.KPITarget{{Metric: domain.KPIMetricParsed, TargetPct: -5, DueDate: "2026-11-10"}}},
+		{"bad due date", []domain.KPITarget{{Metric: domain.KPIMetricParsed, DueDate: "10/11/2026"}}},
+		{"duplicate", []domain.KPITarget{target, target}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, collRepo, _, _, _ := setupCollectionService()
+
+			_, err := svc.SetKPITargets(context.Background(), &service.SetKPITargetsInput{Targets: tt.targets})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidKPITargets)
+			collRepo.AssertNotCalled(t, "UpdateKPITargets", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCollectionService_GetProgress(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	day := func(days int) string { return time.Now().UTC().AddDate(0, 0, days).Format("2006-01-02") }
+
+	targets, _ := json.Marshal([]domain.KPITarget{
+		{Metric: domain.KPIMetricParsed, TargetPct: 90, DueDate: day(5)},     // 90 of 100 needed, 95 parsed
+		{Metric: domain.KPIMetricReviewed, TargetPct: 100, DueDate: day(10)}, // 40 left at 7/day: ~6 days
+		{Metric: domain.KPIMetricApproved, TargetPct: 100, DueDate: day(2)},  // 50 left at 1/day
+		{Metric: domain.KPIMetricReviewed, TargetPct: 100, DueDate: day(-1)}, // past due
+	})
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID, KPITargets: targets}, nil)
+	collRepo.On("TimeZone", mock.Anything, tenantID, collectionID).Return("UTC", nil)
+	collRepo.On("ProgressCounts", mock.Anything, tenantID, collectionID, mock.AnythingOfType("time.Time")).
+		Return(&domain.CollectionProgressCounts{
+			Total: 100, Parsed: 95, Reviewed: 60, Approved: 50,
+			ParsedRecent: 30, ReviewedRecent: 49, ApprovedRecent: 7,
+		}, nil)
+
+	progress, err := svc.GetProgress(context.Background(), tenantID, collectionID, userID, domain.RoleViewer)
+
+	require.NoError(t, err)
+	assert.Equal(t, 95.0, progress.PercentParsed)
+	assert.Equal(t, 60.0, progress.PercentReviewed)
+	assert.Equal(t, 50.0, progress.PercentApproved)
+	assert.True(t, progress.AtRisk)
+	require.Len(t, progress.Targets, 4)
+
+	assert.Equal(t, domain.KPITargetMet, progress.Targets[0].Status)
+	assert.Zero(t, progress.Targets[0].Remaining)
+	assert.Nil(t, progress.Targets[0].ProjectedCompletion)
+
+	assert.Equal(t, domain.KPITargetOnTrack, progress.Targets[1].Status)
+	assert.Equal(t, 40, progress.Targets[1].Remaining)
+	assert.Equal(t, 7.0, progress.Targets[1].VelocityPerDay)
+	require.NotNil(t, progress.Targets[1].ProjectedCompletion)
+	assert.WithinDuration(t, time.Now().Add(40*24*time.Hour/7), *progress.Targets[1].ProjectedCompletion, time.Minute)
+
+	assert.Equal(t, domain.KPITargetAtRisk, progress.Targets[2].Status)
+	assert.Equal(t, domain.KPITargetMissed, progress.Targets[3].Status)
+}
+
+func TestCollectionService_GetProgress_NoVelocity(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	targets, _ := json.Marshal([]domain.KPITarget{{Metric: domain.KPIMetricReviewed, TargetPct: 100, DueDate: "2999-01-01"}})
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID, KPITargets: targets}, nil)
+	collRepo.On("TimeZone", mock.Anything, tenantID, collectionID).Return("UTC", nil)
+	collRepo.On("ProgressCounts", mock.Anything, tenantID, collectionID, mock.Anything).
+		Return(&domain.CollectionProgressCounts{Total: 3, Reviewed: 1}, nil)
+
+	progress, err := svc.GetProgress(context.Background(), tenantID, collectionID, userID, domain.RoleViewer)
+
+	require.NoError(t, err)
+	assert.Equal(t, 33.33, progress.PercentReviewed)
+	assert.Equal(t, domain.KPITargetAtRisk, progress.Targets[0].Status)
+	assert.Equal(t, 2, progress.Targets[0].Remaining)
+	assert.Nil(t, progress.Targets[0].ProjectedCompletion)
+}
+
+func TestCollectionService_GetProgress_PermissionDenied(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	collectionID, userID := uuid.New(), uuid.New()
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).Return(nil, domain.ErrNotFound)
+
+	_, err := svc.GetProgress(context.Background(), uuid.New(), collectionID, userID, domain.RoleViewer)
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	collRepo.AssertNotCalled(t, "ProgressCounts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
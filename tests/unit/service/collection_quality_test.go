@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestStatsService_GetCollectionQuality_Scores(t *testing.T) {
+	repo := new(mocks.MockStatsRepo)
+	svc := service.NewStatsService(repo, nil, nil, nil)
+	tenantID := uuid.New()
+	full, partial, empty := uuid.New(), uuid.New(), uuid.New()
+
+	repo.On("CollectionQuality", mock.Anything, tenantID, domain.CollectionQualityFilter{}).Return([]domain.CollectionQualityCounts{
+		{CollectionID: full, CollectionName: "April", TotalDocuments: 4, ParsedDocuments: 4, ConfidenceSum: 4,
+			ValidationValid: 4, ReviewApproved: 4},
+		// 10 documents, 8 parsed at 0.9 mean confidence, 5 valid + 2 warnings, 3 approved
+		{CollectionID: partial, CollectionName: "May", TotalDocuments: 10, ParsedDocuments: 8, ConfidenceSum: 7.2,
+			ValidationValid: 5, ValidationWarning: 2, ReviewApproved: 3},
+		{CollectionID: empty, CollectionName: "June"},
+	}, nil)
+
+	report, err := svc.GetCollectionQuality(context.Background(), tenantID, domain.CollectionQualityFilter{})
+	require.NoError(t, err)
+	require.Len(t, report, 3)
+
+	require.NotNil(t, report[0].Score)
+	assert.Equal(t, 100.0, *report[0].Score)
+
+	require.NotNil(t, report[1].Score)
+	assert.Equal(t, 0.72, report[1].Confidence)
+	assert.Equal(t, 0.6, report[1].ValidationPassRate)
+	assert.Equal(t, 0.3, report[1].ReviewCoverage)
+	// 100 * (0.3*0.72 + 0.4*0.6 + 0.3*0.3)
+	assert.Equal(t, 54.6, *report[1].Score)
+	assert.Equal(t, "May", report[1].CollectionName)
+	assert.Nil(t, report[1].Day)
+
+	assert.Nil(t, report[2].Score)
+	assert.Zero(t, report[2].TotalDocuments)
+}
+
+func TestStatsService_GetCollectionQuality_UnknownCollection(t *testing.T) {
+	repo := new(mocks.MockStatsRepo)
+	svc := service.NewStatsService(repo, nil, nil, nil)
+	tenantID, collectionID := uuid.New(), uuid.New()
+	filter := domain.CollectionQualityFilter{CollectionID: &collectionID}
+
+	repo.On("CollectionQuality", mock.Anything, tenantID, filter).Return([]domain.CollectionQualityCounts{}, nil)
+
+	_, err := svc.GetCollectionQuality(context.Background(), tenantID, filter)
+	assert.ErrorIs(t, err, domain.ErrCollectionNotFound)
+}
+
+func TestStatsService_GetCollectionQualityHistory_EndsWithLiveScore(t *testing.T) {
+	repo := new(mocks.MockStatsRepo)
+	svc := service.NewStatsService(repo, nil, nil, nil)
+	tenantID, collectionID := uuid.New(), uuid.New()
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	repo.On("CollectionQuality", mock.Anything, tenantID, domain.CollectionQualityFilter{CollectionID: &collectionID}).
+		Return([]domain.CollectionQualityCounts{{CollectionID: collectionID, CollectionName: "April",
+			TotalDocuments: 2, ParsedDocuments: 2, ConfidenceSum: 2, ValidationValid: 2, ReviewApproved: 2}}, nil)
+	repo.On("QualityHistory", mock.Anything, tenantID, collectionID, today.AddDate(0, 0, -30)).
+		Return([]domain.CollectionQualityCounts{
+			{CollectionID: collectionID, Day: today.AddDate(0, 0, -2), TotalDocuments: 2, ParsedDocuments: 1, ConfidenceSum: 0.8},
+			{CollectionID: collectionID, Day: today.AddDate(0, 0, -1), TotalDocuments: 2, ParsedDocuments: 2, ConfidenceSum: 1.8, ValidationValid: 2},
+			// Taken by tonight's run before this request: replaced by the live score
+			{CollectionID: collectionID, Day: today, TotalDocuments: 2},
+		}, nil)
+
+	history, err := svc.GetCollectionQualityHistory(context.Background(), tenantID, collectionID, 30*24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	assert.Equal(t, today.AddDate(0, 0, -2), *history[0].Day)
+	assert.Equal(t, 12.0, *history[0].Score)
+	assert.Equal(t, "April", history[0].CollectionName)
+	assert.Equal(t, 67.0, *history[1].Score)
+	assert.Equal(t, today, *history[2].Day)
+	assert.Equal(t, 100.0, *history[2].Score)
+}
+
+func TestStatsService_GetCollectionQualityHistory_Errors(t *testing.T) {
+	t.Run("unknown collection", func(t *testing.T) {
+		repo := new(mocks.MockStatsRepo)
+		svc := service.NewStatsService(repo, nil, nil, nil)
+		repo.On("CollectionQuality", mock.Anything, mock.Anything, mock.Anything).Return([]domain.CollectionQualityCounts{}, nil)
+
+		_, err := svc.GetCollectionQualityHistory(context.Background(), uuid.New(), uuid.New(), 24*time.Hour)
+		assert.ErrorIs(t, err, domain.ErrCollectionNotFound)
+		repo.AssertNotCalled(t, "QualityHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("history query fails", func(t *testing.T) {
+		repo := new(mocks.MockStatsRepo)
+		svc := service.NewStatsService(repo, nil, nil, nil)
+		repo.On("CollectionQuality", mock.Anything, mock.Anything, mock.Anything).
+			Return([]domain.CollectionQualityCounts{{TotalDocuments: 1}}, nil)
+		repo.On("QualityHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+		_, err := svc.GetCollectionQualityHistory(context.Background(), uuid.New(), uuid.New(), 24*time.Hour)
+		assert.Error(t, err)
+	})
+}
@@ -3,11 +3,13 @@ package service_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"satvos/internal/domain"
 	"satvos/internal/service"
@@ -27,7 +29,7 @@ func setupCollectionService() (
 	fileSvc := new(mocks.MockFileService)
 	userRepo := new(mocks.MockUserRepo)
 	userRepo.On("GetByID", mock.Anything, mock.Anything, mock.Anything).Return(&domain.User{}, nil).Maybe()
-	svc := service.NewCollectionService(collRepo, permRepo, fileRepo, fileSvc, userRepo)
+	svc := service.NewCollectionService(collRepo, permRepo, fileRepo, fileSvc, userRepo, nil)
 	return svc, collRepo, permRepo, fileRepo, userRepo
 }
 
@@ -332,6 +334,335 @@ func TestCollectionService_Update_NotOwner(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
 }
 
+func TestCollectionService_SetReviewChecklist_Success(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	collectionID := uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID}, nil)
+	collRepo.On("UpdateReviewChecklist", mock.Anything, mock.AnythingOfType("*domain.Collection")).Return(nil)
+
+	result, err := svc.SetReviewChecklist(context.Background(), &service.SetReviewChecklistInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         domain.RoleMember,
+		Items: []domain.ReviewChecklistItem{
+			{Label: "  PO attached "},
+			{ID: "gstin_ok", Label: "GSTIN verified"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"po_attached","label":"PO attached"},{"id":"gstin_ok","label":"GSTIN verified"}]`,
+		string(result.ReviewChecklist))
+}
+
+func TestCollectionService_SetReviewChecklist_Invalid(t *testing.T) {
+	cases := map[string][]domain.ReviewChecklistItem{
+		"blank_label":  {{ID: "a", Label: " "}},
+		"duplicate_id": {{Label: "PO attached"}, {ID: "po_attached", Label: "PO present"}},
+		"malformed_id": {{ID: "PO Attached", Label: "PO attached"}},
+	}
+	for name, items := range cases {
+		t.Run(name, func(t *testing.T) {
+			svc, collRepo, permRepo, _, _ := setupCollectionService()
+			collectionID, userID := uuid.New(), uuid.New()
+			permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+				Return(ownerPerm(collectionID, userID), nil)
+
+			_, err := svc.SetReviewChecklist(context.Background(), &service.SetReviewChecklistInput{
+				TenantID: uuid.New(), CollectionID: collectionID, UserID: userID, Role: domain.RoleMember, Items: items,
+			})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidReviewChecklist)
+			collRepo.AssertNotCalled(t, "UpdateReviewChecklist", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCollectionService_SetReviewChecklist_EditorDenied(t *testing.T) {
+	svc, _, permRepo, _, _ := setupCollectionService()
+	collectionID, userID := uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(editorPerm(collectionID, userID), nil)
+
+	_, err := svc.SetReviewChecklist(context.Background(), &service.SetReviewChecklistInput{
+		TenantID: uuid.New(), CollectionID: collectionID, UserID: userID, Role: domain.RoleMember,
+		Items: []domain.ReviewChecklistItem{{Label: "PO attached"}},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+}
+
+func TestCollectionService_SetCheckerThreshold_Success(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	threshold := 100000.0
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID}, nil)
+	collRepo.On("UpdateCheckerThreshold", mock.Anything, mock.AnythingOfType("*domain.Collection")).Return(nil)
+
+	result, err := svc.SetCheckerThreshold(context.Background(), &service.SetCheckerThresholdInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleMember, Threshold: &threshold,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.CheckerThreshold)
+	assert.Equal(t, 100000.0, *result.CheckerThreshold)
+}
+
+func TestCollectionService_SetCheckerThreshold_EditorDenied(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	collectionID, userID := uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(editorPerm(collectionID, userID), nil)
+
+	_, err := svc.SetCheckerThreshold(context.Background(), &service.SetCheckerThresholdInput{
+		TenantID: uuid.New(), CollectionID: collectionID, UserID: userID, Role: domain.RoleMember,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	collRepo.AssertNotCalled(t, "UpdateCheckerThreshold", mock.Anything, mock.Anything)
+}
+
+func TestCollectionService_SetEscalationPolicy_DefaultsToFlag(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	days := 5
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID}, nil)
+	collRepo.On("UpdateEscalationPolicy", mock.Anything, mock.AnythingOfType("*domain.Collection")).Return(nil)
+
+	result, err := svc.SetEscalationPolicy(context.Background(), &service.SetEscalationPolicyInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleMember, AfterDays: &days,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.EscalateAfterDays)
+	assert.Equal(t, 5, *result.EscalateAfterDays)
+	assert.Equal(t, domain.EscalationActionFlag, result.EscalationAction)
+}
+
+func TestCollectionService_SetEscalationPolicy_Invalid(t *testing.T) {
+	zero, tooMany := 0, 366
+	tests := []struct {
+		name  string
+		input service.SetEscalationPolicyInput
+	}{
+		{"zero days", service.SetEscalationPolicyInput{AfterDays: &zero}},
+		{"over a year", service.SetEscalationPolicyInput{AfterDays: &tooMany}},
+		{"unknown action", service.SetEscalationPolicyInput{Action: "email"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, collRepo, _, _, _ := setupCollectionService()
+
+			_, err := svc.SetEscalationPolicy(context.Background(), &tt.input)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidEscalationPolicy)
+			collRepo.AssertNotCalled(t, "UpdateEscalationPolicy", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCollectionService_SetDefaultParseMode(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID}, nil)
+	collRepo.On("UpdateDefaultParseMode", mock.Anything, mock.AnythingOfType("*domain.Collection")).Return(nil)
+
+	result, err := svc.SetDefaultParseMode(context.Background(), &service.SetDefaultParseModeInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleMember, ParseMode: domain.ParseModeDual,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.DefaultParseMode)
+	assert.Equal(t, domain.ParseModeDual, *result.DefaultParseMode)
+}
+
+func TestCollectionService_SetDefaultParseMode_ClearFallsBackToTenant(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	dual := domain.ParseModeDual
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID, DefaultParseMode: &dual}, nil)
+	collRepo.On("UpdateDefaultParseMode", mock.Anything, mock.AnythingOfType("*domain.Collection")).Return(nil)
+
+	result, err := svc.SetDefaultParseMode(context.Background(), &service.SetDefaultParseModeInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleMember,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, result.DefaultParseMode)
+}
+
+func TestCollectionService_SetDefaultParseMode_Invalid(t *testing.T) {
+	svc, collRepo, _, _, _ := setupCollectionService()
+
+	_, err := svc.SetDefaultParseMode(context.Background(), &service.SetDefaultParseModeInput{ParseMode: "triple"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidParseMode)
+	collRepo.AssertNotCalled(t, "UpdateDefaultParseMode", mock.Anything, mock.Anything)
+}
+
+func TestCollectionService_SetDefaultParseMode_RequiresOwner(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(editorPerm(collectionID, userID), nil)
+
+	_, err := svc.SetDefaultParseMode(context.Background(), &service.SetDefaultParseModeInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleMember, ParseMode: domain.ParseModeDual,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	collRepo.AssertNotCalled(t, "UpdateDefaultParseMode", mock.Anything, mock.Anything)
+}
+
+func TestCollectionService_SetNamingTemplate(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID}, nil)
+	collRepo.On("UpdateNamingTemplate", mock.Anything, mock.AnythingOfType("*domain.Collection")).Return(nil)
+
+	result, err := svc.SetNamingTemplate(context.Background(), &service.SetNamingTemplateInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleMember,
+		Template: " {seller_name}-{invoice_number}-{invoice_date} ",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.NamingTemplate)
+	assert.Equal(t, "{seller_name}-{invoice_number}-{invoice_date}", *result.NamingTemplate)
+}
+
+func TestCollectionService_SetNamingTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"no placeholder", "invoice"},
+		{"unknown placeholder", "{seller}-{invoice_number}"},
+		{"unclosed placeholder", "{seller_name}-{invoice_number"},
+		{"too long", "{seller_name}" + strings.Repeat("x", 200)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, collRepo, _, _, _ := setupCollectionService()
+
+			_, err := svc.SetNamingTemplate(context.Background(), &service.SetNamingTemplateInput{Template: tt.template})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidNamingTemplate)
+			collRepo.AssertNotCalled(t, "UpdateNamingTemplate", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCollectionService_SetFileDownloadPerm(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(ownerPerm(collectionID, userID), nil)
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID}, nil)
+	collRepo.On("UpdateFileDownloadPerm", mock.Anything, mock.AnythingOfType("*domain.Collection")).Return(nil)
+
+	result, err := svc.SetFileDownloadPerm(context.Background(), &service.SetFileDownloadPermInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleMember,
+		Permission: domain.CollectionPermOwner,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.FileDownloadPerm)
+	assert.Equal(t, domain.CollectionPermOwner, *result.FileDownloadPerm)
+}
+
+func TestCollectionService_SetFileDownloadPerm_Invalid(t *testing.T) {
+	svc, collRepo, _, _, _ := setupCollectionService()
+
+	_, err := svc.SetFileDownloadPerm(context.Background(), &service.SetFileDownloadPermInput{Permission: "admin"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidPermission)
+	collRepo.AssertNotCalled(t, "UpdateFileDownloadPerm", mock.Anything, mock.Anything)
+}
+
+func TestCollectionService_SetFileDownloadPerm_EditorDenied(t *testing.T) {
+	svc, collRepo, permRepo, _, _ := setupCollectionService()
+	collectionID, userID := uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).Return(nil, domain.ErrCollectionPermDenied)
+
+	_, err := svc.SetFileDownloadPerm(context.Background(), &service.SetFileDownloadPermInput{
+		TenantID: uuid.New(), CollectionID: collectionID, UserID: userID, Role: domain.RoleManager,
+		Permission: domain.CollectionPermOwner,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	collRepo.AssertNotCalled(t, "UpdateFileDownloadPerm", mock.Anything, mock.Anything)
+}
+
+func TestCollectionService_CheckFileDownload(t *testing.T) {
+	tenantID, fileID, userID := uuid.New(), uuid.New(), uuid.New()
+	editorOnly, ownerOnly := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		role       domain.UserRole
+		restricted map[uuid.UUID]domain.CollectionPermission
+		grants     map[uuid.UUID]domain.CollectionPermission
+		want       error
+	}{
+		{"unrestricted", domain.RoleViewer, map[uuid.UUID]domain.CollectionPermission{}, nil, nil},
+		{"member below editor", domain.RoleMember, map[uuid.UUID]domain.CollectionPermission{editorOnly: domain.CollectionPermEditor}, map[uuid.UUID]domain.CollectionPermission{}, domain.ErrFileDownloadRestricted},
+		{"member granted editor", domain.RoleMember, map[uuid.UUID]domain.CollectionPermission{editorOnly: domain.CollectionPermEditor}, map[uuid.UUID]domain.CollectionPermission{editorOnly: domain.CollectionPermEditor}, nil},
+		{"strictest collection wins", domain.RoleManager, map[uuid.UUID]domain.CollectionPermission{editorOnly: domain.CollectionPermEditor, ownerOnly: domain.CollectionPermOwner}, map[uuid.UUID]domain.CollectionPermission{}, domain.ErrFileDownloadRestricted},
+		{"admin", domain.RoleAdmin, map[uuid.UUID]domain.CollectionPermission{ownerOnly: domain.CollectionPermOwner}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, collRepo, permRepo, _, _ := setupCollectionService()
+			collRepo.On("FileDownloadPerms", mock.Anything, tenantID, fileID).Return(tt.restricted, nil)
+			if tt.grants != nil {
+				permRepo.On("GetByUserForCollections", mock.Anything, userID, mock.Anything).Return(tt.grants, nil)
+			}
+
+			err := svc.CheckFileDownload(context.Background(), tenantID, fileID, userID, tt.role)
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
 func TestCollectionService_Update_ManagerCanEdit(t *testing.T) {
 	svc, collRepo, permRepo, _, _ := setupCollectionService()
 
@@ -853,3 +1184,85 @@ func TestCollectionService_EffectivePermissions_ManagerBatchQuery(t *testing.T)
 	assert.Equal(t, domain.CollectionPermEditor, result[id2])
 	permRepo.AssertExpectations(t)
 }
+
+// --- Activity ---
+
+func setupCollectionServiceWithEvents() (service.CollectionService, *mocks.MockCollectionPermissionRepo, *mocks.MockCollectionEventRepo) {
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	eventRepo := new(mocks.MockCollectionEventRepo)
+	userRepo := new(mocks.MockUserRepo)
+	userRepo.On("GetByID", mock.Anything, mock.Anything, mock.Anything).Return(&domain.User{}, nil).Maybe()
+	svc := service.NewCollectionService(new(mocks.MockCollectionRepo), permRepo, new(mocks.MockCollectionFileRepo), new(mocks.MockFileService), userRepo, eventRepo)
+	return svc, permRepo, eventRepo
+}
+
+func TestCollectionService_SetPermission_RecordsEvent(t *testing.T) {
+	svc, permRepo, eventRepo := setupCollectionServiceWithEvents()
+	tenantID, collectionID, ownerID, targetID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, ownerID).Return(ownerPerm(collectionID, ownerID), nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, targetID).Return(viewerPerm(collectionID, targetID), nil)
+	permRepo.On("Upsert", mock.Anything, mock.Anything).Return(nil)
+	var event *domain.CollectionEvent
+	eventRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.CollectionEvent")).
+		Run(func(args mock.Arguments) { event = args.Get(1).(*domain.CollectionEvent) }).
+		Return(nil)
+
+	err := svc.SetPermission(context.Background(), &service.SetPermissionInput{
+		TenantID: tenantID, CollectionID: collectionID, GrantedBy: ownerID, CallerRole: domain.RoleMember,
+		UserID: targetID, Permission: domain.CollectionPermEditor,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, domain.CollectionEventPermissionSet, event.Action)
+	assert.Equal(t, targetID, *event.TargetUserID)
+	assert.Equal(t, ownerID, *event.UserID)
+	assert.JSONEq(t, `{"permission":"editor","previous_permission":"viewer"}`, string(event.Changes))
+}
+
+func TestCollectionService_RemovePermission_EventFailureDoesNotFail(t *testing.T) {
+	svc, permRepo, eventRepo := setupCollectionServiceWithEvents()
+	collectionID, ownerID, targetID := uuid.New(), uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, ownerID).Return(ownerPerm(collectionID, ownerID), nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, targetID).Return(editorPerm(collectionID, targetID), nil)
+	permRepo.On("Delete", mock.Anything, collectionID, targetID).Return(nil)
+	eventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.CollectionEvent) bool {
+		return e.Action == domain.CollectionEventPermissionRemoved
+	})).Return(errors.New("db down"))
+
+	err := svc.RemovePermission(context.Background(), uuid.New(), collectionID, targetID, ownerID, domain.RoleMember)
+
+	assert.NoError(t, err)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestCollectionService_ListActivity(t *testing.T) {
+	svc, permRepo, eventRepo := setupCollectionServiceWithEvents()
+	tenantID, collectionID, viewerID := uuid.New(), uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, viewerID).Return(viewerPerm(collectionID, viewerID), nil)
+	eventRepo.On("ListActivity", mock.Anything, tenantID, collectionID, 0, 20).Return([]domain.CollectionActivityEvent{
+		{Type: string(domain.AuditDocumentParseCompleted)},
+		{Type: domain.ActivityFileUploaded},
+	}, 2, nil)
+
+	activity, total, err := svc.ListActivity(context.Background(), tenantID, collectionID, viewerID, domain.RoleViewer, 0, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, activity, 2)
+}
+
+func TestCollectionService_ListActivity_NoAccess(t *testing.T) {
+	svc, permRepo, eventRepo := setupCollectionServiceWithEvents()
+	collectionID, userID := uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).Return(nil, errors.New("not found"))
+
+	_, _, err := svc.ListActivity(context.Background(), uuid.New(), collectionID, userID, domain.RoleFree, 0, 20)
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	eventRepo.AssertNotCalled(t, "ListActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/internal/validator"
+	"satvos/mocks"
+)
+
+func newComputedFieldService() (service.ComputedFieldService, *mocks.MockComputedFieldRepo) {
+	repo := new(mocks.MockComputedFieldRepo)
+	return service.NewComputedFieldService(repo, service.NewSchemaService(validator.NewRegistry())), repo
+}
+
+func TestComputedFieldService_Set_SavesCompiledExpression(t *testing.T) {
+	svc, repo := newComputedFieldService()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.ComputedField{}, nil)
+	repo.On("Upsert", mock.Anything, mock.MatchedBy(func(f *domain.ComputedField) bool {
+		return f.TenantID == tenantID && f.Name == "tax_total" && f.DocumentType == "invoice" &&
+			f.Expression == "totals.cgst + totals.sgst + totals.igst" && *f.UpdatedBy == userID
+	})).Return(nil)
+
+	field, err := svc.Set(context.Background(), tenantID, "tax_total", &service.SetComputedFieldInput{
+		Expression: "  totals.cgst + totals.sgst + totals.igst ",
+	}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "tax_total", field.Name)
+	repo.AssertExpectations(t)
+}
+
+func TestComputedFieldService_Set_Rejects(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldName string
+		input     service.SetComputedFieldInput
+	}{
+		{"bad name", "Tax-Total", service.SetComputedFieldInput{Expression: "1"}},
+		{"name starts with digit", "1st", service.SetComputedFieldInput{Expression: "1"}},
+		{"unknown document type", "x", service.SetComputedFieldInput{Expression: "1", DocumentType: "receipt"}},
+		{"unknown field", "x", service.SetComputedFieldInput{Expression: "totals.grand_total"}},
+		{"syntax error", "x", service.SetComputedFieldInput{Expression: "totals.total +"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newComputedFieldService()
+			_, err := svc.Set(context.Background(), uuid.New(), tt.fieldName, &tt.input, uuid.New())
+			assert.ErrorIs(t, err, domain.ErrInvalidComputedField)
+			repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestComputedFieldService_Set_LimitsFieldCount(t *testing.T) {
+	svc, repo := newComputedFieldService()
+	tenantID := uuid.New()
+	existing := make([]domain.ComputedField, 50)
+	for i := range existing {
+		existing[i].Name = "f" + string(rune('a'+i%26)) + string(rune('a'+i/26))
+	}
+	repo.On("ListByTenant", mock.Anything, tenantID).Return(existing, nil)
+	repo.On("Upsert", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := svc.Set(context.Background(), tenantID, "one_more", &service.SetComputedFieldInput{Expression: "1"}, uuid.New())
+	assert.ErrorIs(t, err, domain.ErrInvalidComputedField)
+
+	// Replacing an existing field is still allowed
+	_, err = svc.Set(context.Background(), tenantID, existing[0].Name, &service.SetComputedFieldInput{Expression: "2"}, uuid.New())
+	assert.NoError(t, err)
+}
+
+func TestComputedFieldService_Resolve(t *testing.T) {
+	svc, repo := newComputedFieldService()
+	tenantID := uuid.New()
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.ComputedField{
+		{Name: "net_tax", DocumentType: "invoice", Expression: "totals.total - totals.taxable_amount"},
+		{Name: "qty", DocumentType: "invoice", Expression: "sum(line_items[].quantity)"},
+		{Name: "per_unit", DocumentType: "invoice", Expression: "totals.total / sum(line_items[].quantity)"},
+		{Name: "stale", DocumentType: "invoice", Expression: "totals.removed_field"},
+		{Name: "other_type", DocumentType: "receipt", Expression: "1"},
+	}, nil)
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"totals":     map[string]interface{}{"total": 1180, "taxable_amount": 1000},
+		"line_items": []map[string]interface{}{{"quantity": 2}, {"quantity": 2}},
+	})
+	docs := []domain.Document{
+		{DocumentType: "invoice", StructuredData: data},
+		{DocumentType: "invoice", StructuredData: json.RawMessage(`{}`)},
+		{DocumentType: "invoice"},
+	}
+
+	require.NoError(t, svc.Resolve(context.Background(), tenantID, docs))
+
+	assert.Equal(t, map[string]interface{}{"net_tax": 180.0, "qty": 4.0, "per_unit": 295.0, "stale": nil}, docs[0].Extensions)
+	// Division by zero and missing values resolve to null, not an error
+	assert.Equal(t, map[string]interface{}{"net_tax": nil, "qty": 0.0, "per_unit": nil, "stale": nil}, docs[1].Extensions)
+	assert.Equal(t, map[string]interface{}{"net_tax": nil, "qty": nil, "per_unit": nil, "stale": nil}, docs[2].Extensions)
+}
@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func setupObservedDocument(t *testing.T, provenance string) (service.DocumentService, *mocks.MockDocumentRepo, *mocks.MockConfidenceObservationRepo, *domain.Document) {
+	t.Helper()
+	docRepo := new(mocks.MockDocumentRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	auditRepo := new(mocks.MockDocumentAuditRepo)
+	observations := new(mocks.MockConfidenceObservationRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:      docRepo,
+		FileRepo:     new(mocks.MockFileMetaRepo),
+		UserRepo:     new(mocks.MockUserRepo),
+		PermRepo:     permRepo,
+		Parser:       new(mocks.MockDocumentParser),
+		Storage:      new(mocks.MockObjectStorage),
+		AuditRepo:    auditRepo,
+		Observations: observations,
+	})
+
+	doc := &domain.Document{
+		ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(),
+		DocumentType: "invoice", ParserModel: "gemini-2.0-flash",
+		ParsingStatus: domain.ParsingStatusCompleted, ReviewStatus: domain.ReviewStatusPending,
+		StructuredData:   json.RawMessage(`{"invoice":{"invoice_number":"INV-1"},"seller":{"gstin":"29ABCDE1234F1Z5"},"line_items":[{"hsn_sac_code":"8471"}]}`),
+		ConfidenceScores: json.RawMessage(`{"invoice":{"invoice_number":0.95},"seller":{"gstin":1},"line_items":[{"hsn_sac_code":0.4}]}`),
+		FieldProvenance:  json.RawMessage(provenance),
+	}
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, doc.TenantID, doc.ID).Return(doc, nil)
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.Anything).Return(nil).Maybe()
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.Anything).Return(nil).Maybe()
+	auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return svc, docRepo, observations, doc
+}
+
+func observedFields(observations []domain.ConfidenceObservation) map[string]bool {
+	out := make(map[string]bool, len(observations))
+	for _, o := range observations {
+		out[o.FieldPath] = o.Corrected
+	}
+	return out
+}
+
+func TestDocumentService_EditStructuredData_RecordsConfidenceObservations(t *testing.T) {
+	svc, _, observations, doc := setupObservedDocument(t, `{"seller.gstin":"manual_override"}`)
+	var recorded []domain.ConfidenceObservation
+	observations.On("Record", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { recorded = args.Get(1).([]domain.ConfidenceObservation) }).Return(nil)
+
+	_, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		StructuredData: json.RawMessage(`{"invoice":{"invoice_number":"INV-1"},"seller":{"gstin":"29ABCDE1234F1Z5"},"line_items":[{"hsn_sac_code":"847130"}]}`),
+	})
+
+	require.NoError(t, err)
+	// seller.gstin is a reviewer override, so its score is not the parser's
+	assert.Equal(t, map[string]bool{"invoice.invoice_number": false, "line_items[0].hsn_sac_code": true}, observedFields(recorded))
+	assert.Equal(t, "gemini-2.0-flash", recorded[0].ParserModel)
+	assert.Equal(t, 0.95, recorded[0].Confidence)
+}
+
+func TestDocumentService_EditStructuredData_ArrayResizeCorrectsItems(t *testing.T) {
+	svc, _, observations, doc := setupObservedDocument(t, `{}`)
+	var recorded []domain.ConfidenceObservation
+	observations.On("Record", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { recorded = args.Get(1).([]domain.ConfidenceObservation) }).Return(nil)
+
+	_, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		StructuredData: json.RawMessage(`{"invoice":{"invoice_number":"INV-1"},"seller":{"gstin":"29ABCDE1234F1Z5"},"line_items":[{"hsn_sac_code":"8471"},{"hsn_sac_code":"9983"}]}`),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, observedFields(recorded)["line_items[0].hsn_sac_code"])
+	assert.False(t, observedFields(recorded)["seller.gstin"])
+}
+
+func TestDocumentService_EditStructuredData_AfterManualEditMarksCorrected(t *testing.T) {
+	svc, _, observations, doc := setupObservedDocument(t, `{"source":"manual_edit"}`)
+	observations.On("MarkCorrected", mock.Anything, doc.TenantID, doc.ID, []string{"invoice.invoice_number"}).Return(nil)
+
+	_, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		StructuredData: json.RawMessage(`{"invoice":{"invoice_number":"INV-2"},"seller":{"gstin":"29ABCDE1234F1Z5"},"line_items":[{"hsn_sac_code":"8471"}]}`),
+	})
+
+	require.NoError(t, err)
+	observations.AssertExpectations(t)
+	observations.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_UpdateReview_ApprovalRecordsUncorrected(t *testing.T) {
+	svc, _, observations, doc := setupObservedDocument(t, `{}`)
+	var recorded []domain.ConfidenceObservation
+	observations.On("Record", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { recorded = args.Get(1).([]domain.ConfidenceObservation) }).Return(errors.New("db down"))
+
+	_, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+		Status: domain.ReviewStatusApproved,
+	})
+
+	require.NoError(t, err, "observation failures must not fail the review")
+	assert.Equal(t, map[string]bool{
+		"invoice.invoice_number": false, "seller.gstin": false, "line_items[0].hsn_sac_code": false,
+	}, observedFields(recorded))
+}
+
+func TestDocumentService_UpdateReview_RejectionRecordsNothing(t *testing.T) {
+	svc, _, observations, doc := setupObservedDocument(t, `{}`)
+
+	_, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+		Status: domain.ReviewStatusRejected,
+	})
+
+	require.NoError(t, err)
+	observations.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func residencyS3Config() config.S3Config {
+	return config.S3Config{
+		Region:        "us-east-1",
+		Bucket:        "satvos-uploads",
+		MaxFileSizeMB: 50,
+		RegionBuckets: map[string]string{"ap-south-1": "satvos-in"},
+	}
+}
+
+func pinnedTenant(tenantID uuid.UUID, region string) *domain.Tenant {
+	return &domain.Tenant{ID: tenantID, IsActive: true, StorageRegion: &region}
+}
+
+func TestStorageResidency_Bucket(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	cfg := residencyS3Config()
+	residency := service.NewStorageResidency(tenantRepo, &cfg)
+
+	pinned, unpinned := uuid.New(), uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, pinned).Return(pinnedTenant(pinned, "ap-south-1"), nil)
+	tenantRepo.On("GetByID", mock.Anything, unpinned).Return(&domain.Tenant{ID: unpinned}, nil)
+
+	bucket, err := residency.Bucket(context.Background(), pinned)
+	require.NoError(t, err)
+	assert.Equal(t, "satvos-in", bucket)
+
+	bucket, err = residency.Bucket(context.Background(), unpinned)
+	require.NoError(t, err)
+	assert.Equal(t, "satvos-uploads", bucket)
+}
+
+func TestStorageResidency_UnconfiguredRegionNeverFallsBack(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	cfg := residencyS3Config()
+	residency := service.NewStorageResidency(tenantRepo, &cfg)
+
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(pinnedTenant(tenantID, "eu-west-1"), nil)
+
+	_, err := residency.Bucket(context.Background(), tenantID)
+
+	assert.ErrorIs(t, err, domain.ErrDataResidencyViolation)
+}
+
+func TestStorageResidency_Check(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	cfg := residencyS3Config()
+	residency := service.NewStorageResidency(tenantRepo, &cfg)
+
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(pinnedTenant(tenantID, "ap-south-1"), nil)
+
+	assert.NoError(t, residency.Check(context.Background(), tenantID, "satvos-in"))
+	assert.ErrorIs(t, residency.Check(context.Background(), tenantID, "satvos-uploads"), domain.ErrDataResidencyViolation)
+}
+
+func TestFileService_Upload_UsesResidencyBucket(t *testing.T) {
+	fileRepo := new(mocks.MockFileMetaRepo)
+	storage := new(mocks.MockObjectStorage)
+	tenantRepo := new(mocks.MockTenantRepo)
+	cfg := residencyS3Config()
+	svc := service.NewFileService(fileRepo, storage, &cfg, service.NewStorageResidency(tenantRepo, &cfg))
+
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(pinnedTenant(tenantID, "ap-south-1"), nil)
+	fileRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *domain.FileMeta) bool {
+		return m.S3Bucket == "satvos-in"
+	})).Return(nil)
+	storage.On("Upload", mock.Anything, mock.MatchedBy(func(in port.UploadInput) bool {
+		return in.Bucket == "satvos-in"
+	})).Return(&port.UploadOutput{}, nil)
+	fileRepo.On("UpdateStatus", mock.Anything, tenantID, mock.AnythingOfType("uuid.UUID"), domain.FileStatusUploaded).Return(nil)
+
+	file, header := createMultipartFile("document.pdf", pdfContent(), "application/pdf")
+	defer func() { _ = file.Close() }()
+
+	_, err := svc.Upload(context.Background(), service.FileUploadInput{
+		TenantID:   tenantID,
+		UploadedBy: uuid.New(),
+		File:       file,
+		Header:     header,
+	})
+
+	require.NoError(t, err)
+	storage.AssertExpectations(t)
+}
+
+func TestFileService_GetDownloadURL_OutsideRegion(t *testing.T) {
+	fileRepo := new(mocks.MockFileMetaRepo)
+	storage := new(mocks.MockObjectStorage)
+	tenantRepo := new(mocks.MockTenantRepo)
+	cfg := residencyS3Config()
+	svc := service.NewFileService(fileRepo, storage, &cfg, service.NewStorageResidency(tenantRepo, &cfg))
+
+	tenantID, fileID := uuid.New(), uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(pinnedTenant(tenantID, "ap-south-1"), nil)
+	fileRepo.On("GetByID", mock.Anything, tenantID, fileID).
+		Return(&domain.FileMeta{ID: fileID, TenantID: tenantID, S3Bucket: "satvos-uploads", S3Key: "k"}, nil)
+
+	_, err := svc.GetDownloadURL(context.Background(), tenantID, fileID)
+
+	assert.ErrorIs(t, err, domain.ErrDataResidencyViolation)
+	storage.AssertNotCalled(t, "GetPresignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTenantService_Update_StorageRegion(t *testing.T) {
+	repo := new(mocks.MockTenantRepo)
+	fileRepo := new(mocks.MockFileMetaRepo)
+	cfg := residencyS3Config()
+	svc := service.NewTenantService(repo, fileRepo, service.NewStorageResidency(repo, &cfg), nil)
+
+	tenantID := uuid.New()
+	repo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID}, nil)
+	fileRepo.On("ListByTenant", mock.Anything, tenantID, 0, 1).Return([]domain.FileMeta{}, 0, nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Tenant")).Return(nil)
+
+	region := "ap-south-1"
+	tenant, err := svc.Update(context.Background(), tenantID, service.UpdateTenantInput{StorageRegion: &region})
+
+	require.NoError(t, err)
+	require.NotNil(t, tenant.StorageRegion)
+	assert.Equal(t, "ap-south-1", *tenant.StorageRegion)
+}
+
+func TestTenantService_Update_StorageRegionLockedWithFiles(t *testing.T) {
+	repo := new(mocks.MockTenantRepo)
+	fileRepo := new(mocks.MockFileMetaRepo)
+	cfg := residencyS3Config()
+	svc := service.NewTenantService(repo, fileRepo, service.NewStorageResidency(repo, &cfg), nil)
+
+	tenantID := uuid.New()
+	repo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID}, nil)
+	fileRepo.On("ListByTenant", mock.Anything, tenantID, 0, 1).Return([]domain.FileMeta{{ID: uuid.New()}}, 3, nil)
+
+	region := "ap-south-1"
+	_, err := svc.Update(context.Background(), tenantID, service.UpdateTenantInput{StorageRegion: &region})
+
+	assert.ErrorIs(t, err, domain.ErrStorageRegionLocked)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTenantService_Create_UnknownStorageRegion(t *testing.T) {
+	repo := new(mocks.MockTenantRepo)
+	cfg := residencyS3Config()
+	svc := service.NewTenantService(repo, nil, service.NewStorageResidency(repo, &cfg), nil)
+
+	_, err := svc.Create(context.Background(), service.CreateTenantInput{
+		Name: "Acme", Slug: "acme", StorageRegion: "eu-west-1",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidStorageRegion)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
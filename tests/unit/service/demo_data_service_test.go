@@ -0,0 +1,154 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/internal/validator/invoice"
+	"satvos/mocks"
+)
+
+type demoDataDeps struct {
+	userRepo           *mocks.MockUserRepo
+	collectionRepo     *mocks.MockCollectionRepo
+	permRepo           *mocks.MockCollectionPermissionRepo
+	collectionFileRepo *mocks.MockCollectionFileRepo
+	fileRepo           *mocks.MockFileMetaRepo
+	storage            *mocks.MockObjectStorage
+	fileSvc            *mocks.MockFileService
+	docSvc             *mocks.MockDocumentService
+}
+
+// setupDemoDataService stores files through a real FileService so seeded PDFs
+// go through upload inspection; cleanup uses the FileService mock.
+func setupDemoDataService(realFiles bool) (*demoDataDeps, service.DemoDataService) {
+	d := &demoDataDeps{
+		userRepo:           new(mocks.MockUserRepo),
+		collectionRepo:     new(mocks.MockCollectionRepo),
+		permRepo:           new(mocks.MockCollectionPermissionRepo),
+		collectionFileRepo: new(mocks.MockCollectionFileRepo),
+		fileRepo:           new(mocks.MockFileMetaRepo),
+		storage:            new(mocks.MockObjectStorage),
+		fileSvc:            new(mocks.MockFileService),
+		docSvc:             new(mocks.MockDocumentService),
+	}
+	var fileSvc service.FileService = d.fileSvc
+	if realFiles {
+		cfg := testS3Config()
+		fileSvc = service.NewFileService(d.fileRepo, d.storage, &cfg, nil)
+	}
+	return d, service.NewDemoDataService(d.userRepo, d.collectionRepo, d.permRepo, d.collectionFileRepo, fileSvc, d.docSvc)
+}
+
+func TestDemoDataService_Seed(t *testing.T) {
+	d, svc := setupDemoDataService(true)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	owner := &domain.User{ID: uuid.New(), TenantID: tenantID, Role: domain.RoleAdmin, IsActive: true}
+
+	d.collectionRepo.On("ListDemo", ctx, tenantID).Return([]domain.Collection{}, nil)
+	d.userRepo.On("GetByID", ctx, tenantID, owner.ID).Return(owner, nil)
+	d.collectionRepo.On("Create", ctx, mock.MatchedBy(func(c *domain.Collection) bool {
+		return c.IsDemo && c.TenantID == tenantID && c.CreatedBy == owner.ID
+	})).Return(nil)
+	d.permRepo.On("Upsert", ctx, mock.MatchedBy(func(p *domain.CollectionPermissionEntry) bool {
+		return p.UserID == owner.ID && p.Permission == domain.CollectionPermOwner
+	})).Return(nil)
+	d.fileRepo.On("Create", ctx, mock.AnythingOfType("*domain.FileMeta")).Return(nil)
+	d.storage.On("Upload", ctx, mock.AnythingOfType("port.UploadInput")).Return(&port.UploadOutput{}, nil)
+	d.fileRepo.On("UpdateStatus", ctx, tenantID, mock.AnythingOfType("uuid.UUID"), domain.FileStatusUploaded).Return(nil)
+	d.collectionFileRepo.On("Add", ctx, mock.AnythingOfType("*domain.CollectionFile")).Return(nil)
+
+	var invoices []invoice.GSTInvoice
+	d.docSvc.On("CreateParsed", ctx, mock.AnythingOfType("*service.CreateParsedDocumentInput")).
+		Run(func(args mock.Arguments) {
+			in := args.Get(1).(*service.CreateParsedDocumentInput)
+			var inv invoice.GSTInvoice
+			require.NoError(t, json.Unmarshal(in.StructuredData, &inv))
+			invoices = append(invoices, inv)
+			assert.Equal(t, "demo", in.ParserModel)
+			assert.Equal(t, owner.ID, in.CreatedBy)
+		}).
+		Return(&domain.Document{ID: uuid.New(), TenantID: tenantID}, nil)
+	d.docSvc.On("UpdateReview", ctx, mock.AnythingOfType("*service.UpdateReviewInput")).
+		Return(&domain.Document{}, nil)
+
+	result, err := svc.Seed(ctx, tenantID, owner.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 6, result.Documents)
+	assert.Len(t, invoices, 6)
+	d.storage.AssertNumberOfCalls(t, "Upload", 6)
+	d.docSvc.AssertNumberOfCalls(t, "UpdateReview", 3)
+	d.docSvc.AssertCalled(t, "UpdateReview", ctx, mock.MatchedBy(func(in *service.UpdateReviewInput) bool {
+		return in.Status == domain.ReviewStatusRejected && in.Notes != ""
+	}))
+}
+
+func TestDemoDataService_Seed_AlreadySeeded(t *testing.T) {
+	d, svc := setupDemoDataService(false)
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	d.collectionRepo.On("ListDemo", ctx, tenantID).Return([]domain.Collection{{ID: uuid.New(), IsDemo: true}}, nil)
+
+	_, err := svc.Seed(ctx, tenantID, uuid.New())
+
+	assert.ErrorIs(t, err, domain.ErrDemoDataExists)
+	d.collectionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestDemoDataService_Seed_OwnerNotInTenant(t *testing.T) {
+	d, svc := setupDemoDataService(false)
+	ctx := context.Background()
+	tenantID, ownerID := uuid.New(), uuid.New()
+
+	d.collectionRepo.On("ListDemo", ctx, tenantID).Return([]domain.Collection{}, nil)
+	d.userRepo.On("GetByID", ctx, tenantID, ownerID).Return(nil, domain.ErrNotFound)
+
+	_, err := svc.Seed(ctx, tenantID, ownerID)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidDemoOwner)
+}
+
+func TestDemoDataService_Seed_InactiveOwner(t *testing.T) {
+	d, svc := setupDemoDataService(false)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	owner := &domain.User{ID: uuid.New(), TenantID: tenantID, Role: domain.RoleAdmin}
+
+	d.collectionRepo.On("ListDemo", ctx, tenantID).Return([]domain.Collection{}, nil)
+	d.userRepo.On("GetByID", ctx, tenantID, owner.ID).Return(owner, nil)
+
+	_, err := svc.Seed(ctx, tenantID, owner.ID)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidDemoOwner)
+}
+
+func TestDemoDataService_Cleanup(t *testing.T) {
+	d, svc := setupDemoDataService(false)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	demo := domain.Collection{ID: uuid.New(), TenantID: tenantID, IsDemo: true, DocumentCount: 2}
+	files := []domain.FileMeta{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	d.collectionRepo.On("ListDemo", ctx, tenantID).Return([]domain.Collection{demo}, nil)
+	d.collectionFileRepo.On("ListByCollection", ctx, tenantID, demo.ID, 0, 100).Return(files, 2, nil)
+	d.collectionRepo.On("Delete", ctx, tenantID, demo.ID).Return(nil)
+	d.fileSvc.On("Delete", ctx, tenantID, files[0].ID).Return(nil)
+	d.fileSvc.On("Delete", ctx, tenantID, files[1].ID).Return(assert.AnError)
+
+	result, err := svc.Cleanup(ctx, tenantID)
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.DemoCleanup{TenantID: tenantID, Collections: 1, Documents: 2, Files: 1}, result)
+}
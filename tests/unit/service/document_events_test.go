@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+)
+
+func TestDocumentEvents_PublishRunsListenersOfKindInOrder(t *testing.T) {
+	events := service.NewDocumentEvents()
+	var calls []string
+	events.Subscribe("first", func(_ context.Context, _ *service.DocumentEvent) {
+		calls = append(calls, "first")
+	}, service.DocumentEventParsed, service.DocumentEventEdited)
+	events.Subscribe("reviews", func(_ context.Context, _ *service.DocumentEvent) {
+		calls = append(calls, "reviews")
+	}, service.DocumentEventReviewed)
+	events.Subscribe("second", func(_ context.Context, _ *service.DocumentEvent) {
+		calls = append(calls, "second")
+	}, service.DocumentEventParsed)
+
+	events.Publish(context.Background(), &service.DocumentEvent{Kind: service.DocumentEventParsed, Document: &domain.Document{ID: uuid.New()}})
+
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestDocumentEvents_PanickingListenerDoesNotStopOthers(t *testing.T) {
+	events := service.NewDocumentEvents()
+	ran := false
+	events.Subscribe("broken", func(_ context.Context, _ *service.DocumentEvent) {
+		panic("boom")
+	}, service.DocumentEventEdited)
+	events.Subscribe("after", func(_ context.Context, _ *service.DocumentEvent) {
+		ran = true
+	}, service.DocumentEventEdited)
+
+	assert.NotPanics(t, func() {
+		events.Publish(context.Background(), &service.DocumentEvent{Kind: service.DocumentEventEdited, Document: &domain.Document{ID: uuid.New()}})
+	})
+	assert.True(t, ran)
+}
+
+func TestDocumentService_UpdateReview_PublishesReviewedEvent(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+	reviewerID := uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{
+		ID: docID, TenantID: tenantID, ParsingStatus: domain.ParsingStatusCompleted, ReviewStatus: domain.ReviewStatusPending,
+	}, nil)
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+
+	var got *service.DocumentEvent
+	svc.Events().Subscribe("test", func(_ context.Context, e *service.DocumentEvent) {
+		got = e
+	}, service.DocumentEventReviewed)
+
+	_, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID:   tenantID,
+		DocumentID: docID,
+		ReviewerID: reviewerID,
+		Role:       domain.RoleAdmin,
+		Status:     domain.ReviewStatusRejected,
+		Notes:      "wrong vendor",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, docID, got.Document.ID)
+	assert.Equal(t, domain.ReviewStatusRejected, got.Document.ReviewStatus)
+	require.NotNil(t, got.ActorID)
+	assert.Equal(t, reviewerID, *got.ActorID)
+}
+
+func TestDocumentService_ParseDocument_PublishesParseFailedEvent(t *testing.T) {
+	svc, _, doc := setupRetryParse(t, 1, errors.New("s3 download: NoSuchKey"))
+
+	var kinds []service.DocumentEventKind
+	svc.Events().Subscribe("test", func(_ context.Context, e *service.DocumentEvent) {
+		kinds = append(kinds, e.Kind)
+	}, service.DocumentEventParseFailed, service.DocumentEventParseStatusChanged, service.DocumentEventParsed)
+
+	svc.ParseDocument(context.Background(), doc, 5)
+
+	assert.Equal(t, []service.DocumentEventKind{service.DocumentEventParseFailed}, kinds)
+}
+
+func TestDocumentService_ParseDocument_RetryPublishesParseStatusChanged(t *testing.T) {
+	svc, _, doc := setupRetryParse(t, 1, fmt.Errorf("s3 download: %w", domain.ErrStorageUnavailable))
+
+	var got *service.DocumentEvent
+	svc.Events().Subscribe("test", func(_ context.Context, e *service.DocumentEvent) {
+		got = e
+	}, service.DocumentEventParseStatusChanged)
+
+	svc.ParseDocument(context.Background(), doc, 5)
+
+	require.NotNil(t, got)
+	assert.Equal(t, domain.ParsingStatusQueued, got.Document.ParsingStatus)
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type docLockMocks struct {
+	repo          *mocks.MockDocumentLockRepo
+	docRepo       *mocks.MockDocumentRepo
+	collectionSvc *mocks.MockCollectionService
+	userRepo      *mocks.MockUserRepo
+	doc           *domain.Document
+}
+
+func setupDocumentLockService(perm domain.CollectionPermission) (service.DocumentLockService, *docLockMocks) {
+	m := &docLockMocks{
+		repo:          new(mocks.MockDocumentLockRepo),
+		docRepo:       new(mocks.MockDocumentRepo),
+		collectionSvc: new(mocks.MockCollectionService),
+		userRepo:      new(mocks.MockUserRepo),
+		doc:           &domain.Document{ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New()},
+	}
+	m.docRepo.On("GetByID", mock.Anything, m.doc.TenantID, m.doc.ID).Return(m.doc, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, m.doc.CollectionID, mock.Anything, mock.Anything).Return(perm)
+	m.userRepo.On("GetByID", mock.Anything, m.doc.TenantID, mock.Anything).Return(&domain.User{FullName: "Asha"}, nil)
+	return service.NewDocumentLockService(m.repo, m.docRepo, m.collectionSvc, m.userRepo), m
+}
+
+func TestDocumentLockService_Acquire_DefaultTTL(t *testing.T) {
+	svc, m := setupDocumentLockService(domain.CollectionPermEditor)
+	userID := uuid.New()
+	m.repo.On("Acquire", mock.Anything, mock.MatchedBy(func(l *domain.DocumentLock) bool {
+		return l.UserID == userID && l.ExpiresAt.Sub(l.AcquiredAt) == service.DefaultDocumentLockTTL
+	})).Return(&domain.DocumentLock{DocumentID: m.doc.ID, TenantID: m.doc.TenantID, UserID: userID}, nil)
+
+	lock, err := svc.Acquire(context.Background(), m.doc.TenantID, m.doc.ID, userID, domain.RoleMember, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Asha", lock.HolderName)
+}
+
+func TestDocumentLockService_Acquire_HeldByOther(t *testing.T) {
+	svc, m := setupDocumentLockService(domain.CollectionPermEditor)
+	holder := &domain.DocumentLock{DocumentID: m.doc.ID, TenantID: m.doc.TenantID, UserID: uuid.New(), ExpiresAt: time.Now().Add(time.Minute)}
+	m.repo.On("Acquire", mock.Anything, mock.Anything).Return(holder, nil)
+
+	lock, err := svc.Acquire(context.Background(), m.doc.TenantID, m.doc.ID, uuid.New(), domain.RoleMember, time.Minute)
+
+	assert.ErrorIs(t, err, domain.ErrDocumentLocked)
+	assert.Equal(t, holder.UserID, lock.UserID)
+	assert.Equal(t, "Asha", lock.HolderName)
+}
+
+func TestDocumentLockService_Acquire_Validation(t *testing.T) {
+	svc, m := setupDocumentLockService(domain.CollectionPermViewer)
+
+	_, err := svc.Acquire(context.Background(), m.doc.TenantID, m.doc.ID, uuid.New(), domain.RoleViewer, time.Hour)
+	assert.ErrorIs(t, err, domain.ErrInvalidLockTTL)
+
+	_, err = svc.Acquire(context.Background(), m.doc.TenantID, m.doc.ID, uuid.New(), domain.RoleViewer, 0)
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	m.repo.AssertNotCalled(t, "Acquire", mock.Anything, mock.Anything)
+}
+
+func TestDocumentLockService_Release(t *testing.T) {
+	holderID := uuid.New()
+
+	t.Run("holder", func(t *testing.T) {
+		svc, m := setupDocumentLockService(domain.CollectionPermEditor)
+		m.repo.On("GetActive", mock.Anything, m.doc.TenantID, m.doc.ID).Return(&domain.DocumentLock{UserID: holderID}, nil)
+		m.repo.On("Release", mock.Anything, m.doc.TenantID, m.doc.ID, &holderID).Return(nil)
+		require.NoError(t, svc.Release(context.Background(), m.doc.TenantID, m.doc.ID, holderID, domain.RoleMember))
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("other editor", func(t *testing.T) {
+		svc, m := setupDocumentLockService(domain.CollectionPermEditor)
+		m.repo.On("GetActive", mock.Anything, m.doc.TenantID, m.doc.ID).Return(&domain.DocumentLock{UserID: holderID}, nil)
+		err := svc.Release(context.Background(), m.doc.TenantID, m.doc.ID, uuid.New(), domain.RoleMember)
+		assert.ErrorIs(t, err, domain.ErrDocumentLocked)
+		m.repo.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("manager breaks it", func(t *testing.T) {
+		svc, m := setupDocumentLockService(domain.CollectionPermEditor)
+		m.repo.On("GetActive", mock.Anything, m.doc.TenantID, m.doc.ID).Return(&domain.DocumentLock{UserID: holderID}, nil)
+		m.repo.On("Release", mock.Anything, m.doc.TenantID, m.doc.ID, (*uuid.UUID)(nil)).Return(nil)
+		require.NoError(t, svc.Release(context.Background(), m.doc.TenantID, m.doc.ID, uuid.New(), domain.RoleManager))
+		m.repo.AssertExpectations(t)
+	})
+}
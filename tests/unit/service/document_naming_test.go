@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func setupNamingDocumentService(template string) (service.DocumentService, *mocks.MockDocumentRepo, uuid.UUID, uuid.UUID) {
+	docRepo := new(mocks.MockDocumentRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	collRepo := new(mocks.MockCollectionRepo)
+	tenantID, collectionID := uuid.New(), uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	collRepo.On("GetByID", mock.Anything, tenantID, collectionID).
+		Return(&domain.Collection{ID: collectionID, TenantID: tenantID, NamingTemplate: &template}, nil)
+	collRepo.On("TimeZone", mock.Anything, mock.Anything, mock.Anything).Return("UTC", nil).Maybe()
+	collRepo.On("DefaultParseMode", mock.Anything, mock.Anything, mock.Anything).Return(domain.ParseMode(""), nil).Maybe()
+	docRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:        docRepo,
+		FileRepo:       new(mocks.MockFileMetaRepo),
+		UserRepo:       new(mocks.MockUserRepo),
+		PermRepo:       permRepo,
+		Parser:         new(mocks.MockDocumentParser),
+		Storage:        new(mocks.MockObjectStorage),
+		CollectionRepo: collRepo,
+	})
+	return svc, docRepo, tenantID, collectionID
+}
+
+func createParsedNamed(t *testing.T, svc service.DocumentService, tenantID, collectionID uuid.UUID, name string, data string) *domain.Document {
+	t.Helper()
+	doc, err := svc.CreateParsed(context.Background(), &service.CreateParsedDocumentInput{
+		TenantID:       tenantID,
+		CollectionID:   collectionID,
+		FileID:         uuid.New(),
+		Name:           name,
+		DocumentType:   "invoice",
+		StructuredData: json.RawMessage(data),
+		ParserModel:    "demo",
+		CreatedBy:      uuid.New(),
+		Role:           domain.RoleAdmin,
+	})
+	require.NoError(t, err)
+	return doc
+}
+
+const namingInvoice = `{"seller":{"name":"Acme Traders Pvt. Ltd."},"invoice":{"invoice_number":"INV/24/001","invoice_date":"2024-01-15"},"totals":{"total":118}}`
+
+func TestDocumentNaming_RenamesAfterParseAndKeepsOriginal(t *testing.T) {
+	svc, docRepo, tenantID, collectionID := setupNamingDocumentService("{seller_name}-{invoice_number}-{invoice_date}")
+	docRepo.On("ListNamesWithPrefix", mock.Anything, tenantID, collectionID, "Acme Traders Pvt. Ltd.-INV-24-001-2024-01-15", mock.Anything).
+		Return([]string{}, nil)
+	docRepo.On("UpdateName", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+
+	doc := createParsedNamed(t, svc, tenantID, collectionID, "scan_0042.pdf", namingInvoice)
+
+	assert.Equal(t, "Acme Traders Pvt. Ltd.-INV-24-001-2024-01-15.pdf", doc.Name)
+	require.NotNil(t, doc.OriginalName)
+	assert.Equal(t, "scan_0042.pdf", *doc.OriginalName)
+}
+
+func TestDocumentNaming_SuffixesConflicts(t *testing.T) {
+	svc, docRepo, tenantID, collectionID := setupNamingDocumentService("{invoice_number}")
+	docRepo.On("ListNamesWithPrefix", mock.Anything, tenantID, collectionID, "INV-24-001", mock.Anything).
+		Return([]string{"INV-24-001.pdf", "INV-24-001 (2).pdf", "INV-24-001-copy.pdf"}, nil)
+	docRepo.On("UpdateName", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+
+	doc := createParsedNamed(t, svc, tenantID, collectionID, "upload.pdf", namingInvoice)
+
+	assert.Equal(t, "INV-24-001 (3).pdf", doc.Name)
+}
+
+func TestDocumentNaming_MissingFieldKeepsName(t *testing.T) {
+	svc, docRepo, tenantID, collectionID := setupNamingDocumentService("{buyer_gstin}-{invoice_number}")
+
+	doc := createParsedNamed(t, svc, tenantID, collectionID, "upload.pdf", namingInvoice)
+
+	assert.Equal(t, "upload.pdf", doc.Name)
+	assert.Nil(t, doc.OriginalName)
+	docRepo.AssertNotCalled(t, "UpdateName", mock.Anything, mock.Anything)
+}
@@ -11,8 +11,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"satvos/internal/config"
 	"satvos/internal/domain"
+	"satvos/internal/jsonpatch"
 	"satvos/internal/parser"
 	"satvos/internal/port"
 	"satvos/internal/service"
@@ -40,7 +43,16 @@ func setupDocumentService() ( //nolint:gocritic // test helper benefits from mul
 	storage := new(mocks.MockObjectStorage)
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil).Maybe()
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, auditRepo, nil)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:   docRepo,
+		FileRepo:  fileRepo,
+		UserRepo:  userRepo,
+		PermRepo:  permRepo,
+		TagRepo:   tagRepo,
+		Parser:    p,
+		Storage:   storage,
+		AuditRepo: auditRepo,
+	})
 	return svc, docRepo, fileRepo, permRepo, p, storage, tagRepo, userRepo, auditRepo
 }
 
@@ -115,6 +127,39 @@ func TestDocumentService_CreateAndParse_Success(t *testing.T) {
 	fileRepo.AssertExpectations(t)
 }
 
+func TestDocumentService_CreateParsed_StoresCompletedDocumentWithoutParsing(t *testing.T) {
+	svc, docRepo, _, permRepo, p, _, tagRepo, userRepo, _ := setupDocumentService()
+
+	tenantID, userID := uuid.New(), uuid.New()
+	data := json.RawMessage(`{"invoice":{"invoice_number":"DEMO-1"},"totals":{"total":118}}`)
+
+	docRepo.On("Create", mock.Anything, mock.MatchedBy(func(d *domain.Document) bool {
+		return d.ParsingStatus == domain.ParsingStatusCompleted && d.ParsedAt != nil && d.ParserModel == "demo"
+	})).Return(nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	tagRepo.On("DeleteByDocumentAndSource", mock.Anything, mock.Anything, "auto").Return(nil)
+	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := svc.CreateParsed(context.Background(), &service.CreateParsedDocumentInput{
+		TenantID:       tenantID,
+		CollectionID:   uuid.New(),
+		FileID:         uuid.New(),
+		DocumentType:   "invoice",
+		StructuredData: data,
+		ParserModel:    "demo",
+		CreatedBy:      userID,
+		Role:           domain.RoleAdmin,
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(data), string(result.StructuredData))
+	docRepo.AssertExpectations(t)
+	tagRepo.AssertExpectations(t)
+	p.AssertNotCalled(t, "Parse", mock.Anything, mock.Anything)
+	userRepo.AssertNotCalled(t, "CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestDocumentService_CreateAndParse_FileNotFound(t *testing.T) {
 	svc, _, fileRepo, permRepo, _, _, _, _, _ := setupDocumentService()
 
@@ -402,6 +447,219 @@ func TestDocumentService_UpdateReview_Approved(t *testing.T) {
 	assert.Equal(t, "Looks good", result.ReviewerNotes)
 }
 
+// setupChecklistReview returns a document service whose document sits in a
+// collection with a two-item review checklist.
+func setupChecklistReview(t *testing.T) (service.DocumentService, *mocks.MockDocumentRepo, *mocks.MockDocumentAuditRepo, *domain.Document) {
+	t.Helper()
+	docRepo := new(mocks.MockDocumentRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	auditRepo := new(mocks.MockDocumentAuditRepo)
+	collRepo := new(mocks.MockCollectionRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:        docRepo,
+		FileRepo:       new(mocks.MockFileMetaRepo),
+		UserRepo:       new(mocks.MockUserRepo),
+		PermRepo:       permRepo,
+		Parser:         new(mocks.MockDocumentParser),
+		Storage:        new(mocks.MockObjectStorage),
+		AuditRepo:      auditRepo,
+		CollectionRepo: collRepo,
+	})
+
+	doc := &domain.Document{
+		ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(),
+		ParsingStatus: domain.ParsingStatusCompleted, ReviewStatus: domain.ReviewStatusPending,
+	}
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, doc.TenantID, doc.ID).Return(doc, nil)
+	collRepo.On("GetByID", mock.Anything, doc.TenantID, doc.CollectionID).Return(&domain.Collection{
+		ID:              doc.CollectionID,
+		ReviewChecklist: json.RawMessage(`[{"id":"po_attached","label":"PO attached"},{"id":"gstin_verified","label":"GSTIN verified"}]`),
+	}, nil)
+	return svc, docRepo, auditRepo, doc
+}
+
+func TestDocumentService_UpdateReview_ChecklistRequiredForApproval(t *testing.T) {
+	svc, docRepo, _, doc := setupChecklistReview(t)
+
+	_, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+		Status:    domain.ReviewStatusApproved,
+		Checklist: map[string]bool{"po_attached": true, "gstin_verified": false},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrReviewChecklistIncomplete)
+	assert.Contains(t, err.Error(), "gstin_verified")
+	docRepo.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_UpdateReview_ChecklistStoredAndAudited(t *testing.T) {
+	svc, docRepo, auditRepo, doc := setupChecklistReview(t)
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	var entry *domain.DocumentAuditEntry
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).
+		Run(func(args mock.Arguments) { entry = args.Get(1).(*domain.DocumentAuditEntry) }).Return(nil)
+
+	result, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+		Status:    domain.ReviewStatusApproved,
+		Checklist: map[string]bool{"po_attached": true, "gstin_verified": true},
+	})
+
+	require.NoError(t, err)
+	want := `[{"id":"po_attached","label":"PO attached","checked":true},{"id":"gstin_verified","label":"GSTIN verified","checked":true}]`
+	assert.JSONEq(t, want, string(result.ReviewChecklist))
+	require.NotNil(t, entry)
+	var changes map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(entry.Changes, &changes))
+	assert.JSONEq(t, want, string(changes["checklist"]))
+}
+
+func TestDocumentService_UpdateReview_ChecklistOptionalForRejection(t *testing.T) {
+	svc, docRepo, auditRepo, doc := setupChecklistReview(t)
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil)
+
+	result, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+		Status:    domain.ReviewStatusRejected,
+		Checklist: map[string]bool{"po_attached": true},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, string(result.ReviewChecklist), `"id":"gstin_verified","label":"GSTIN verified","checked":false`)
+}
+
+func TestDocumentService_UpdateReview_ChecklistUnknownItem(t *testing.T) {
+	svc, _, _, doc := setupChecklistReview(t)
+
+	_, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+		Status:    domain.ReviewStatusRejected,
+		Checklist: map[string]bool{"signed": true},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidReviewChecklist)
+}
+
+func setupMakerChecker(t *testing.T, total float64) (service.DocumentService, *mocks.MockDocumentRepo, *mocks.MockCollectionPermissionRepo, *domain.Document) {
+	t.Helper()
+	docRepo := new(mocks.MockDocumentRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	auditRepo := new(mocks.MockDocumentAuditRepo)
+	collRepo := new(mocks.MockCollectionRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:        docRepo,
+		FileRepo:       new(mocks.MockFileMetaRepo),
+		UserRepo:       new(mocks.MockUserRepo),
+		PermRepo:       permRepo,
+		Parser:         new(mocks.MockDocumentParser),
+		Storage:        new(mocks.MockObjectStorage),
+		AuditRepo:      auditRepo,
+		CollectionRepo: collRepo,
+	})
+
+	doc := &domain.Document{
+		ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(),
+		ParsingStatus: domain.ParsingStatusCompleted, ReviewStatus: domain.ReviewStatusPending,
+		StructuredData: json.RawMessage(fmt.Sprintf(`{"totals":{"total":%v}}`, total)),
+	}
+	threshold := 100000.0
+	docRepo.On("GetByID", mock.Anything, doc.TenantID, doc.ID).Return(doc, nil)
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil).Maybe()
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil).Maybe()
+	collRepo.On("GetByID", mock.Anything, doc.TenantID, doc.CollectionID).Return(&domain.Collection{
+		ID: doc.CollectionID, CheckerThreshold: &threshold,
+	}, nil)
+	return svc, docRepo, permRepo, doc
+}
+
+func TestDocumentService_UpdateReview_MakerApprovalAwaitsChecker(t *testing.T) {
+	svc, _, permRepo, doc := setupMakerChecker(t, 150000)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	makerID := uuid.New()
+
+	result, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: makerID, Role: domain.RoleAdmin,
+		Status: domain.ReviewStatusApproved,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusAwaitingChecker, result.ReviewStatus)
+	assert.Equal(t, &makerID, result.MakerApprovedBy)
+	assert.NotNil(t, result.MakerApprovedAt)
+}
+
+func TestDocumentService_UpdateReview_BelowThresholdApprovesDirectly(t *testing.T) {
+	svc, _, permRepo, doc := setupMakerChecker(t, 99999.99)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+
+	result, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+		Status: domain.ReviewStatusApproved,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusApproved, result.ReviewStatus)
+	assert.Nil(t, result.MakerApprovedBy)
+}
+
+func TestDocumentService_UpdateReview_CheckerConfirms(t *testing.T) {
+	svc, _, permRepo, doc := setupMakerChecker(t, 150000)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	makerID := uuid.New()
+	doc.ReviewStatus = domain.ReviewStatusAwaitingChecker
+	doc.MakerApprovedBy = &makerID
+
+	result, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleManager,
+		Status: domain.ReviewStatusApproved,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusApproved, result.ReviewStatus)
+	assert.Equal(t, &makerID, result.MakerApprovedBy)
+}
+
+func TestDocumentService_UpdateReview_CheckerSameAsMaker(t *testing.T) {
+	svc, docRepo, permRepo, doc := setupMakerChecker(t, 150000)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	makerID := uuid.New()
+	doc.ReviewStatus = domain.ReviewStatusAwaitingChecker
+	doc.MakerApprovedBy = &makerID
+
+	_, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: makerID, Role: domain.RoleAdmin,
+		Status: domain.ReviewStatusApproved,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCheckerSameAsMaker)
+	docRepo.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_UpdateReview_CheckerNeedsOwnerOrManager(t *testing.T) {
+	svc, docRepo, permRepo, doc := setupMakerChecker(t, 150000)
+	makerID, editorID := uuid.New(), uuid.New()
+	doc.ReviewStatus = domain.ReviewStatusAwaitingChecker
+	doc.MakerApprovedBy = &makerID
+	permRepo.On("GetByCollectionAndUser", mock.Anything, doc.CollectionID, editorID).Return(&domain.CollectionPermissionEntry{
+		CollectionID: doc.CollectionID, UserID: editorID, Permission: domain.CollectionPermEditor,
+	}, nil)
+
+	_, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: editorID, Role: domain.RoleMember,
+		Status: domain.ReviewStatusRejected,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCheckerNotAllowed)
+	docRepo.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything)
+}
+
 func TestDocumentService_UpdateReview_Rejected(t *testing.T) {
 	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
 
@@ -727,7 +985,15 @@ func TestDocumentService_BackgroundParsing_Success(t *testing.T) {
 	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
 	userRepo := new(mocks.MockUserRepo)
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, nil, nil)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:  docRepo,
+		FileRepo: fileRepo,
+		UserRepo: userRepo,
+		PermRepo: permRepo,
+		TagRepo:  tagRepo,
+		Parser:   p,
+		Storage:  storage,
+	})
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -807,7 +1073,15 @@ func TestDocumentService_BackgroundParsing_DownloadFailure(t *testing.T) {
 	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
 	userRepo := new(mocks.MockUserRepo)
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, nil, nil)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:  docRepo,
+		FileRepo: fileRepo,
+		UserRepo: userRepo,
+		PermRepo: permRepo,
+		TagRepo:  tagRepo,
+		Parser:   p,
+		Storage:  storage,
+	})
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -872,7 +1146,15 @@ func TestDocumentService_BackgroundParsing_ParserFailure(t *testing.T) {
 	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
 	userRepo := new(mocks.MockUserRepo)
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, nil, nil)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:  docRepo,
+		FileRepo: fileRepo,
+		UserRepo: userRepo,
+		PermRepo: permRepo,
+		TagRepo:  tagRepo,
+		Parser:   p,
+		Storage:  storage,
+	})
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -1091,12 +1373,61 @@ func TestDocumentService_AddTags_Success(t *testing.T) {
 	})).Return(nil)
 
 	tags, err := svc.AddTags(context.Background(), tenantID, docID, userID, domain.RoleAdmin,
-		map[string]string{"vendor": "Acme", "year": "2025"})
+		map[string]string{"vendor": "Acme", "year": "2025"}, nil)
 
 	assert.NoError(t, err)
 	assert.Len(t, tags, 2)
 }
 
+func TestDocumentService_AddTags_Typed(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, tagRepo, _, _ := setupDocumentService()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{ID: docID, TenantID: tenantID}, nil)
+	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+	tags, err := svc.AddTags(context.Background(), tenantID, docID, uuid.New(), domain.RoleAdmin,
+		map[string]string{"amount": "1250.50", "due": "2025-03-31"},
+		map[string]domain.TagValueType{"amount": domain.TagValueNumber, "due": domain.TagValueDate})
+
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	for _, tag := range tags {
+		switch tag.Key {
+		case "amount":
+			assert.Equal(t, domain.TagValueNumber, tag.ValueType)
+			require.NotNil(t, tag.NumberValue)
+			assert.Equal(t, 1250.50, *tag.NumberValue)
+		case "due":
+			assert.Equal(t, domain.TagValueDate, tag.ValueType)
+			require.NotNil(t, tag.DateValue)
+			assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), *tag.DateValue)
+		}
+	}
+}
+
+func TestDocumentService_AddTags_InvalidTypedValue(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, tagRepo, _, _ := setupDocumentService()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{ID: docID, TenantID: tenantID}, nil)
+
+	_, err := svc.AddTags(context.Background(), tenantID, docID, uuid.New(), domain.RoleAdmin,
+		map[string]string{"due": "31/03/2025"},
+		map[string]domain.TagValueType{"due": domain.TagValueDate})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTagValue)
+	tagRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+}
+
 // --- SearchByTag ---
 
 func TestDocumentService_SearchByTag_Success(t *testing.T) {
@@ -1105,16 +1436,149 @@ func TestDocumentService_SearchByTag_Success(t *testing.T) {
 	tenantID := uuid.New()
 
 	expected := []domain.Document{{ID: uuid.New(), TenantID: tenantID}}
-	tagRepo.On("SearchByTag", mock.Anything, tenantID, "vendor", "Acme", 0, 20).
+	tagRepo.On("SearchByTag", mock.Anything, tenantID, &domain.TagQuery{Key: "vendor", Value: "Acme"}, 0, 20).
 		Return(expected, 1, nil)
 
-	docs, total, err := svc.SearchByTag(context.Background(), tenantID, "vendor", "Acme", 0, 20)
+	docs, total, err := svc.SearchByTag(context.Background(), tenantID,
+		&service.TagSearchInput{Key: "vendor", Value: "Acme"}, 0, 20)
 
 	assert.NoError(t, err)
 	assert.Len(t, docs, 1)
 	assert.Equal(t, 1, total)
 }
 
+func TestDocumentService_SearchByTag_NumberRange(t *testing.T) {
+	svc, _, _, _, _, _, tagRepo, _, _ := setupDocumentService()
+
+	tenantID := uuid.New()
+	tagRepo.On("SearchByTag", mock.Anything, tenantID, mock.MatchedBy(func(q *domain.TagQuery) bool {
+		return q.Type == domain.TagValueNumber && *q.MinNumber == 1000 && q.MaxNumber == nil
+	}), 0, 20).Return([]domain.Document{}, 0, nil)
+
+	_, _, err := svc.SearchByTag(context.Background(), tenantID,
+		&service.TagSearchInput{Key: "total_amount", Min: "1000"}, 0, 20)
+
+	assert.NoError(t, err)
+	tagRepo.AssertExpectations(t)
+}
+
+func TestDocumentService_SearchByTag_InfersDateRange(t *testing.T) {
+	svc, _, _, _, _, _, tagRepo, _, _ := setupDocumentService()
+
+	tenantID := uuid.New()
+	tagRepo.On("SearchByTag", mock.Anything, tenantID, mock.MatchedBy(func(q *domain.TagQuery) bool {
+		return q.Type == domain.TagValueDate &&
+			q.MinDate.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+			q.MaxDate.Equal(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC))
+	}), 0, 20).Return([]domain.Document{}, 0, nil)
+
+	_, _, err := svc.SearchByTag(context.Background(), tenantID,
+		&service.TagSearchInput{Key: "invoice_date", Min: "2025-01-01", Max: "2025-03-31"}, 0, 20)
+
+	assert.NoError(t, err)
+	tagRepo.AssertExpectations(t)
+}
+
+func TestDocumentService_SearchByTag_Prefix(t *testing.T) {
+	svc, _, _, _, _, _, tagRepo, _, _ := setupDocumentService()
+
+	tenantID := uuid.New()
+	tagRepo.On("SearchByTag", mock.Anything, tenantID, &domain.TagQuery{Key: "invoice_number", Prefix: "INV/24"}, 0, 20).
+		Return([]domain.Document{}, 0, nil)
+
+	_, _, err := svc.SearchByTag(context.Background(), tenantID,
+		&service.TagSearchInput{Key: "invoice_number", Prefix: "INV/24"}, 0, 20)
+
+	assert.NoError(t, err)
+	tagRepo.AssertExpectations(t)
+}
+
+func TestDocumentService_SearchByTag_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input service.TagSearchInput
+	}{
+		{"missing key", service.TagSearchInput{Value: "Acme"}},
+		{"no mode", service.TagSearchInput{Key: "vendor"}},
+		{"value and prefix", service.TagSearchInput{Key: "vendor", Value: "Acme", Prefix: "Ac"}},
+		{"value and range", service.TagSearchInput{Key: "total_amount", Value: "10", Min: "5"}},
+		{"bad number", service.TagSearchInput{Key: "total_amount", Min: "ten", Type: domain.TagValueNumber}},
+		{"bad date", service.TagSearchInput{Key: "due", Min: "2025-13-01", Type: domain.TagValueDate}},
+		{"min above max", service.TagSearchInput{Key: "total_amount", Min: "500", Max: "100"}},
+		{"range on string", service.TagSearchInput{Key: "vendor", Min: "a", Type: domain.TagValueString}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _, _, _, _, tagRepo, _, _ := setupDocumentService()
+
+			_, _, err := svc.SearchByTag(context.Background(), uuid.New(), &tt.input, 0, 20)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidTagSearch)
+			tagRepo.AssertNotCalled(t, "SearchByTag", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// --- EnrichDocuments ---
+
+func TestDocumentService_EnrichDocuments_BatchLoadsTagsAndAssignees(t *testing.T) {
+	svc, _, _, _, _, _, tagRepo, userRepo, _ := setupDocumentService()
+
+	tenantID := uuid.New()
+	alice := uuid.New()
+	gone := uuid.New()
+	docs := []domain.Document{
+		{ID: uuid.New(), TenantID: tenantID, AssignedTo: &alice},
+		{ID: uuid.New(), TenantID: tenantID, AssignedTo: &alice},
+		{ID: uuid.New(), TenantID: tenantID, AssignedTo: &gone},
+		{ID: uuid.New(), TenantID: tenantID},
+	}
+	docIDs := []uuid.UUID{docs[0].ID, docs[1].ID, docs[2].ID, docs[3].ID}
+	tag := domain.DocumentTag{DocumentID: docs[1].ID, Key: "vendor", Value: "Acme"}
+
+	tagRepo.On("ListByDocuments", mock.Anything, tenantID, docIDs).
+		Return(map[uuid.UUID][]domain.DocumentTag{docs[1].ID: {tag}}, nil).Once()
+	// Assignees are deduplicated into a single lookup
+	userRepo.On("GetByIDs", mock.Anything, tenantID, []uuid.UUID{alice, gone}).
+		Return([]domain.User{{ID: alice, FullName: "Alice"}}, nil).Once()
+
+	err := svc.EnrichDocuments(context.Background(), tenantID, docs)
+
+	require.NoError(t, err)
+	require.NotNil(t, docs[0].AssigneeName)
+	assert.Equal(t, "Alice", *docs[0].AssigneeName)
+	assert.Equal(t, "Alice", *docs[1].AssigneeName)
+	assert.Nil(t, docs[2].AssigneeName)
+	assert.Nil(t, docs[3].AssigneeName)
+	assert.Empty(t, docs[0].Tags)
+	assert.Equal(t, []domain.DocumentTag{tag}, docs[1].Tags)
+	tagRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+}
+
+func TestDocumentService_EnrichDocuments_NoAssigneesSkipsUserLookup(t *testing.T) {
+	svc, _, _, _, _, _, tagRepo, userRepo, _ := setupDocumentService()
+
+	tenantID := uuid.New()
+	docs := []domain.Document{{ID: uuid.New(), TenantID: tenantID}}
+	tagRepo.On("ListByDocuments", mock.Anything, tenantID, []uuid.UUID{docs[0].ID}).
+		Return(map[uuid.UUID][]domain.DocumentTag{}, nil)
+
+	err := svc.EnrichDocuments(context.Background(), tenantID, docs)
+
+	require.NoError(t, err)
+	userRepo.AssertNotCalled(t, "GetByIDs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_EnrichDocuments_EmptyPage(t *testing.T) {
+	svc, _, _, _, _, _, tagRepo, _, _ := setupDocumentService()
+
+	err := svc.EnrichDocuments(context.Background(), uuid.New(), nil)
+
+	require.NoError(t, err)
+	tagRepo.AssertNotCalled(t, "ListByDocuments", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // --- RetryParse deletes auto-tags ---
 
 func TestDocumentService_RetryParse_DeletesAutoTags(t *testing.T) {
@@ -1401,10 +1865,14 @@ func TestDocumentService_EditStructuredData_ReextractsAutoTags(t *testing.T) {
 		ConfidenceScores: json.RawMessage(`{}`),
 	}
 
+	structuredData := json.RawMessage(`{"invoice":{"invoice_number":"INV-999","invoice_date":"2025-06-01"},"seller":{"name":"Seller Corp","gstin":"29AABCU9603R1ZM"},"buyer":{"name":"Buyer Inc"},"line_items":[],"totals":{"total":5000},"payment":{}}`)
+
+	// Auto-tags are extracted from the saved document, as re-fetched after validation
 	updated := &domain.Document{
-		ID:            docID,
-		TenantID:      tenantID,
-		ParsingStatus: domain.ParsingStatusCompleted,
+		ID:             docID,
+		TenantID:       tenantID,
+		ParsingStatus:  domain.ParsingStatusCompleted,
+		StructuredData: structuredData,
 	}
 
 	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
@@ -1425,8 +1893,6 @@ func TestDocumentService_EditStructuredData_ReextractsAutoTags(t *testing.T) {
 	})).Return(nil).Maybe()
 	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(updated, nil).Maybe()
 
-	structuredData := json.RawMessage(`{"invoice":{"invoice_number":"INV-999","invoice_date":"2025-06-01"},"seller":{"name":"Seller Corp","gstin":"29AABCU9603R1ZM"},"buyer":{"name":"Buyer Inc"},"line_items":[],"totals":{"total":5000},"payment":{}}`)
-
 	result, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
 		TenantID:       tenantID,
 		DocumentID:     docID,
@@ -1454,7 +1920,15 @@ func TestDocumentService_BackgroundParsing_RateLimitQueuesDocument(t *testing.T)
 	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
 	userRepo := new(mocks.MockUserRepo)
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, nil, nil)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:  docRepo,
+		FileRepo: fileRepo,
+		UserRepo: userRepo,
+		PermRepo: permRepo,
+		TagRepo:  tagRepo,
+		Parser:   p,
+		Storage:  storage,
+	})
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -1539,7 +2013,15 @@ func TestDocumentService_BackgroundParsing_RateLimitExceedsMaxAttempts(t *testin
 	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
 	userRepo := new(mocks.MockUserRepo)
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, nil, nil)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:  docRepo,
+		FileRepo: fileRepo,
+		UserRepo: userRepo,
+		PermRepo: permRepo,
+		TagRepo:  tagRepo,
+		Parser:   p,
+		Storage:  storage,
+	})
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -1617,7 +2099,15 @@ func TestDocumentService_BackgroundParsing_NonRateLimitErrorStillFails(t *testin
 	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
 	userRepo := new(mocks.MockUserRepo)
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, nil, nil)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:  docRepo,
+		FileRepo: fileRepo,
+		UserRepo: userRepo,
+		PermRepo: permRepo,
+		TagRepo:  tagRepo,
+		Parser:   p,
+		Storage:  storage,
+	})
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -1783,6 +2273,47 @@ func TestDocumentService_EditStructuredData_WritesAuditEntry(t *testing.T) {
 	}))
 }
 
+func TestDocumentService_EditStructuredData_AuditIncludesSnapshots(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, tagRepo, _, auditRepo := setupDocumentService()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+
+	existing := &domain.Document{
+		ID: docID, TenantID: tenantID, ParsingStatus: domain.ParsingStatusCompleted,
+		StructuredData: json.RawMessage(`{"invoice":{"invoice_number":"OLD"}}`), ConfidenceScores: json.RawMessage("{}"),
+	}
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(existing, nil)
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	tagRepo.On("DeleteByDocumentAndSource", mock.Anything, docID, "auto").Return(nil)
+	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	_, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
+		TenantID: tenantID, DocumentID: docID, UserID: uuid.New(),
+		Role: domain.RoleAdmin, StructuredData: json.RawMessage(`{"invoice":{"invoice_number":"NEW"}}`),
+	})
+	assert.NoError(t, err)
+
+	auditRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(entry *domain.DocumentAuditEntry) bool {
+		if entry.Action != string(domain.AuditDocumentEditStructured) {
+			return false
+		}
+		var changes struct {
+			Before json.RawMessage `json:"before"`
+			After  json.RawMessage `json:"after"`
+		}
+		if err := json.Unmarshal(entry.Changes, &changes); err != nil {
+			return false
+		}
+		return string(changes.Before) == `{"invoice":{"invoice_number":"OLD"}}` &&
+			string(changes.After) == `{"invoice":{"invoice_number":"NEW"}}`
+	}))
+}
+
 func TestDocumentService_AuditFailureDoesNotBlockOperation(t *testing.T) {
 	docRepo := new(mocks.MockDocumentRepo)
 	fileRepo := new(mocks.MockFileMetaRepo)
@@ -1797,7 +2328,16 @@ func TestDocumentService_AuditFailureDoesNotBlockOperation(t *testing.T) {
 	// Audit repo always fails
 	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(errors.New("db down")).Maybe()
 
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, auditRepo, nil)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:   docRepo,
+		FileRepo:  fileRepo,
+		UserRepo:  userRepo,
+		PermRepo:  permRepo,
+		TagRepo:   tagRepo,
+		Parser:    p,
+		Storage:   storage,
+		AuditRepo: auditRepo,
+	})
 
 	tenantID := uuid.New()
 	docID := uuid.New()
@@ -2007,7 +2547,7 @@ func TestDocumentService_ListReviewQueue_Success(t *testing.T) {
 		{ID: uuid.New(), TenantID: tenantID, AssignedTo: &userID, ParsingStatus: domain.ParsingStatusCompleted},
 	}
 
-	docRepo.On("ListReviewQueue", mock.Anything, tenantID, userID, 0, 20).Return(expected, 1, nil)
+	docRepo.On("ListReviewQueue", mock.Anything, tenantID, []uuid.UUID{userID}, 0, 20).Return(expected, 1, nil)
 
 	docs, total, err := svc.ListReviewQueue(context.Background(), tenantID, userID, 0, 20)
 
@@ -2022,7 +2562,7 @@ func TestDocumentService_ListReviewQueue_Empty(t *testing.T) {
 	tenantID := uuid.New()
 	userID := uuid.New()
 
-	docRepo.On("ListReviewQueue", mock.Anything, tenantID, userID, 0, 20).Return([]domain.Document{}, 0, nil)
+	docRepo.On("ListReviewQueue", mock.Anything, tenantID, []uuid.UUID{userID}, 0, 20).Return([]domain.Document{}, 0, nil)
 
 	docs, total, err := svc.ListReviewQueue(context.Background(), tenantID, userID, 0, 20)
 
@@ -2031,6 +2571,71 @@ func TestDocumentService_ListReviewQueue_Empty(t *testing.T) {
 	assert.Equal(t, 0, total)
 }
 
+// --- GetNeighbors ---
+
+func TestDocumentService_GetNeighbors_ReviewQueue(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+
+	tenantID, userID, docID, collectionID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	assignedAt := time.Now().UTC()
+	prevID, nextID := uuid.New(), uuid.New()
+
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).
+		Return(&domain.Document{ID: docID, TenantID: tenantID, CollectionID: collectionID, AssignedTo: &userID, AssignedAt: &assignedAt}, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("ReviewQueueNeighbors", mock.Anything, tenantID, []uuid.UUID{userID}, assignedAt, docID).
+		Return(&domain.DocumentNeighbors{PreviousID: &prevID, NextID: &nextID}, nil)
+
+	neighbors, err := svc.GetNeighbors(context.Background(), &service.NeighborsInput{
+		TenantID: tenantID, DocumentID: docID, UserID: userID, Role: domain.RoleMember,
+		Context: domain.NeighborContextReviewQueue,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &prevID, neighbors.PreviousID)
+	assert.Equal(t, &nextID, neighbors.NextID)
+}
+
+func TestDocumentService_GetNeighbors_CollectionWithFilter(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+
+	tenantID, userID, docID, collectionID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	createdAt := time.Now().UTC()
+	assignee := uuid.New()
+
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).
+		Return(&domain.Document{ID: docID, TenantID: tenantID, CollectionID: collectionID, CreatedAt: createdAt}, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("CollectionNeighbors", mock.Anything, tenantID, collectionID, &assignee, createdAt, docID).
+		Return(&domain.DocumentNeighbors{}, nil)
+
+	neighbors, err := svc.GetNeighbors(context.Background(), &service.NeighborsInput{
+		TenantID: tenantID, DocumentID: docID, UserID: userID, Role: domain.RoleManager,
+		Context: domain.NeighborContextCollection, AssignedTo: &assignee,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, neighbors.PreviousID)
+	assert.Nil(t, neighbors.NextID)
+}
+
+func TestDocumentService_GetNeighbors_InvalidContext(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+
+	tenantID, userID, docID, collectionID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).
+		Return(&domain.Document{ID: docID, TenantID: tenantID, CollectionID: collectionID}, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).Return(nil, errors.New("not found")).Maybe()
+
+	_, err := svc.GetNeighbors(context.Background(), &service.NeighborsInput{
+		TenantID: tenantID, DocumentID: docID, UserID: userID, Role: domain.RoleAdmin,
+		Context: "inbox",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidNeighborContext)
+}
+
 // --- RetryParse clears assignment ---
 
 func TestDocumentService_RetryParse_ClearsAssignment(t *testing.T) {
@@ -2103,7 +2708,17 @@ func TestDocumentService_ParseDocument_UpsertsSummary(t *testing.T) {
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil).Maybe()
 
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, auditRepo, summaryRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:     docRepo,
+		FileRepo:    fileRepo,
+		UserRepo:    userRepo,
+		PermRepo:    permRepo,
+		TagRepo:     tagRepo,
+		Parser:      p,
+		Storage:     storage,
+		AuditRepo:   auditRepo,
+		SummaryRepo: summaryRepo,
+	})
 
 	tenantID := uuid.New()
 	docID := uuid.New()
@@ -2187,7 +2802,17 @@ func TestDocumentService_UpdateReview_UpdatesSummaryStatuses(t *testing.T) {
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil).Maybe()
 
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, auditRepo, summaryRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:     docRepo,
+		FileRepo:    fileRepo,
+		UserRepo:    userRepo,
+		PermRepo:    permRepo,
+		TagRepo:     tagRepo,
+		Parser:      p,
+		Storage:     storage,
+		AuditRepo:   auditRepo,
+		SummaryRepo: summaryRepo,
+	})
 
 	tenantID := uuid.New()
 	docID := uuid.New()
@@ -2239,7 +2864,17 @@ func TestDocumentService_SummaryUpsertFailure_DoesNotFailParse(t *testing.T) {
 	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil).Maybe()
 
-	svc := service.NewDocumentService(docRepo, fileRepo, userRepo, permRepo, tagRepo, p, storage, nil, auditRepo, summaryRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:     docRepo,
+		FileRepo:    fileRepo,
+		UserRepo:    userRepo,
+		PermRepo:    permRepo,
+		TagRepo:     tagRepo,
+		Parser:      p,
+		Storage:     storage,
+		AuditRepo:   auditRepo,
+		SummaryRepo: summaryRepo,
+	})
 
 	tenantID := uuid.New()
 	docID := uuid.New()
@@ -2359,3 +2994,1057 @@ func TestDocumentService_NilSummaryRepo_NoPanic(t *testing.T) {
 
 	docRepo.AssertCalled(t, "UpdateStructuredData", mock.Anything, mock.AnythingOfType("*domain.Document"))
 }
+
+// --- Field overrides ---
+
+func setupDocumentServiceWithOverrides() ( //nolint:gocritic // test helper benefits from multiple named returns
+	service.DocumentService,
+	*mocks.MockDocumentRepo,
+	*mocks.MockFileMetaRepo,
+	*mocks.MockDocumentParser,
+	*mocks.MockObjectStorage,
+	*mocks.MockDocumentFieldOverrideRepo,
+	*mocks.MockDocumentAuditRepo,
+) {
+	docRepo := new(mocks.MockDocumentRepo)
+	fileRepo := new(mocks.MockFileMetaRepo)
+	userRepo := new(mocks.MockUserRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	tagRepo := new(mocks.MockDocumentTagRepo)
+	auditRepo := new(mocks.MockDocumentAuditRepo)
+	overrideRepo := new(mocks.MockDocumentFieldOverrideRepo)
+	p := new(mocks.MockDocumentParser)
+	storage := new(mocks.MockObjectStorage)
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	tagRepo.On("DeleteByDocumentAndSource", mock.Anything, mock.Anything, "auto").Return(nil).Maybe()
+	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil).Maybe()
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:      docRepo,
+		FileRepo:     fileRepo,
+		UserRepo:     userRepo,
+		PermRepo:     permRepo,
+		TagRepo:      tagRepo,
+		Parser:       p,
+		Storage:      storage,
+		AuditRepo:    auditRepo,
+		OverrideRepo: overrideRepo,
+	})
+	return svc, docRepo, fileRepo, p, storage, overrideRepo, auditRepo
+}
+
+func TestDocumentService_EditStructuredData_RecordsOverrides(t *testing.T) {
+	svc, docRepo, _, _, _, overrideRepo, auditRepo := setupDocumentServiceWithOverrides()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+	userID := uuid.New()
+
+	existing := &domain.Document{
+		ID: docID, TenantID: tenantID, ParsingStatus: domain.ParsingStatusCompleted,
+		StructuredData:   json.RawMessage(`{"invoice":{"invoice_date":"2025-01-15"},"seller":{"gstin":"29ABCDE1234F1Z5"},"line_items":[{"hsn_sac_code":"1234"}]}`),
+		ConfidenceScores: json.RawMessage(`{}`),
+	}
+	edited := json.RawMessage(`{"invoice":{"invoice_date":"2025-01-16"},"seller":{"gstin":"29ABCDE1234F1Z5"},"line_items":[{"hsn_sac_code":"9983"}]}`)
+
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(existing, nil)
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+
+	var saved []string
+	overrideRepo.On("Upsert", mock.Anything, mock.AnythingOfType("*domain.DocumentFieldOverride")).
+		Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(*domain.DocumentFieldOverride).FieldPath)
+		}).Return(nil)
+
+	_, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
+		TenantID: tenantID, DocumentID: docID, UserID: userID,
+		Role: domain.RoleAdmin, StructuredData: edited,
+	})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"invoice.invoice_date", "line_items[0].hsn_sac_code"}, saved)
+	auditRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(entry *domain.DocumentAuditEntry) bool {
+		var changes map[string]interface{}
+		if entry.Action != string(domain.AuditDocumentEditStructured) || json.Unmarshal(entry.Changes, &changes) != nil {
+			return false
+		}
+		fields, _ := changes["overridden_fields"].([]interface{})
+		return len(fields) == 2
+	}))
+}
+
+func TestDocumentService_ParseDocument_ReappliesOverrides(t *testing.T) {
+	svc, docRepo, fileRepo, p, storage, overrideRepo, _ := setupDocumentServiceWithOverrides()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+	fileID := uuid.New()
+
+	doc := &domain.Document{
+		ID: docID, TenantID: tenantID, FileID: fileID, DocumentType: "invoice",
+		ParseMode: domain.ParseModeSingle, ParsingStatus: domain.ParsingStatusProcessing,
+	}
+
+	fileRepo.On("GetByID", mock.Anything, tenantID, fileID).Return(&domain.FileMeta{
+		ID: fileID, S3Bucket: "bucket", S3Key: "key", ContentType: "application/pdf",
+	}, nil)
+	storage.On("Download", mock.Anything, "bucket", "key").Return([]byte("%PDF"), nil)
+	p.On("Parse", mock.Anything, mock.Anything).Return(&port.ParseOutput{
+		StructuredData:   json.RawMessage(`{"invoice":{"invoice_date":"2025-01-15","invoice_number":"INV-1"}}`),
+		ConfidenceScores: json.RawMessage(`{"invoice":{"invoice_date":0.4,"invoice_number":0.9}}`),
+		FieldProvenance:  map[string]string{"invoice.invoice_number": "consensus"},
+	}, nil)
+	overrideRepo.On("ListByDocument", mock.Anything, tenantID, docID).Return([]domain.DocumentFieldOverride{
+		{DocumentID: docID, FieldPath: "invoice.invoice_date", Value: json.RawMessage(`"2025-01-16"`)},
+	}, nil)
+
+	var persisted *domain.Document
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.AnythingOfType("*domain.Document")).
+		Run(func(args mock.Arguments) { persisted = args.Get(1).(*domain.Document) }).Return(nil)
+
+	svc.ParseDocument(context.Background(), doc, 3)
+
+	assert.NotNil(t, persisted)
+	assert.JSONEq(t, `{"invoice":{"invoice_date":"2025-01-16","invoice_number":"INV-1"}}`, string(persisted.StructuredData))
+	assert.JSONEq(t, `{"invoice":{"invoice_date":1,"invoice_number":0.9}}`, string(persisted.ConfidenceScores))
+
+	var provenance map[string]string
+	assert.NoError(t, json.Unmarshal(persisted.FieldProvenance, &provenance))
+	assert.Equal(t, "manual_override", provenance["invoice.invoice_date"])
+	assert.Equal(t, "consensus", provenance["invoice.invoice_number"])
+}
+
+func TestDocumentService_EditStructuredData_RecordsRemovedField(t *testing.T) {
+	svc, docRepo, _, _, _, overrideRepo, _ := setupDocumentServiceWithOverrides()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+
+	existing := &domain.Document{
+		ID: docID, TenantID: tenantID, ParsingStatus: domain.ParsingStatusCompleted,
+		StructuredData:   json.RawMessage(`{"invoice":{"invoice_date":"2025-01-15","po_number":"PO-9"}}`),
+		ConfidenceScores: json.RawMessage(`{}`),
+	}
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(existing, nil)
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+
+	saved := map[string]string{}
+	overrideRepo.On("Upsert", mock.Anything, mock.AnythingOfType("*domain.DocumentFieldOverride")).
+		Run(func(args mock.Arguments) {
+			o := args.Get(1).(*domain.DocumentFieldOverride)
+			saved[o.FieldPath] = string(o.Value)
+		}).Return(nil)
+
+	_, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
+		TenantID: tenantID, DocumentID: docID, UserID: uuid.New(),
+		Role: domain.RoleAdmin, StructuredData: json.RawMessage(`{"invoice":{"invoice_date":"2025-01-15"}}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"invoice.po_number": "null"}, saved)
+}
+
+func TestDocumentService_ParseDocument_ReappliesRemovedField(t *testing.T) {
+	svc, docRepo, fileRepo, p, storage, overrideRepo, _ := setupDocumentServiceWithOverrides()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+	fileID := uuid.New()
+
+	doc := &domain.Document{
+		ID: docID, TenantID: tenantID, FileID: fileID, DocumentType: "invoice",
+		ParseMode: domain.ParseModeSingle, ParsingStatus: domain.ParsingStatusProcessing,
+	}
+
+	fileRepo.On("GetByID", mock.Anything, tenantID, fileID).Return(&domain.FileMeta{
+		ID: fileID, S3Bucket: "bucket", S3Key: "key", ContentType: "application/pdf",
+	}, nil)
+	storage.On("Download", mock.Anything, "bucket", "key").Return([]byte("%PDF"), nil)
+	p.On("Parse", mock.Anything, mock.Anything).Return(&port.ParseOutput{
+		StructuredData:   json.RawMessage(`{"invoice":{"invoice_number":"INV-1","po_number":"PO-9"}}`),
+		ConfidenceScores: json.RawMessage(`{"invoice":{"invoice_number":0.9,"po_number":0.3}}`),
+	}, nil)
+	overrideRepo.On("ListByDocument", mock.Anything, tenantID, docID).Return([]domain.DocumentFieldOverride{
+		{DocumentID: docID, FieldPath: "invoice.po_number", Value: json.RawMessage(`null`)},
+	}, nil)
+
+	var persisted *domain.Document
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.AnythingOfType("*domain.Document")).
+		Run(func(args mock.Arguments) { persisted = args.Get(1).(*domain.Document) }).Return(nil)
+
+	svc.ParseDocument(context.Background(), doc, 3)
+
+	assert.NotNil(t, persisted)
+	assert.JSONEq(t, `{"invoice":{"invoice_number":"INV-1"}}`, string(persisted.StructuredData))
+	assert.JSONEq(t, `{"invoice":{"invoice_number":0.9}}`, string(persisted.ConfidenceScores))
+}
+
+func TestDocumentService_ParseDocument_OverrideLoadFailureKeepsParserOutput(t *testing.T) {
+	svc, docRepo, fileRepo, p, storage, overrideRepo, _ := setupDocumentServiceWithOverrides()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+	fileID := uuid.New()
+
+	doc := &domain.Document{ID: docID, TenantID: tenantID, FileID: fileID, DocumentType: "invoice"}
+
+	fileRepo.On("GetByID", mock.Anything, tenantID, fileID).Return(&domain.FileMeta{S3Bucket: "bucket", S3Key: "key"}, nil)
+	storage.On("Download", mock.Anything, "bucket", "key").Return([]byte("%PDF"), nil)
+	p.On("Parse", mock.Anything, mock.Anything).Return(&port.ParseOutput{
+		StructuredData:   json.RawMessage(`{"invoice":{"invoice_date":"2025-01-15"}}`),
+		ConfidenceScores: json.RawMessage(`{}`),
+	}, nil)
+	overrideRepo.On("ListByDocument", mock.Anything, tenantID, docID).Return(nil, errors.New("db down"))
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+
+	svc.ParseDocument(context.Background(), doc, 3)
+
+	assert.Equal(t, domain.ParsingStatusCompleted, doc.ParsingStatus)
+	assert.JSONEq(t, `{"invoice":{"invoice_date":"2025-01-15"}}`, string(doc.StructuredData))
+}
+
+func TestDocumentService_ListOverrides_Success(t *testing.T) {
+	svc, docRepo, _, _, _, overrideRepo, _ := setupDocumentServiceWithOverrides()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{ID: docID, TenantID: tenantID}, nil)
+	overrideRepo.On("ListByDocument", mock.Anything, tenantID, docID).Return([]domain.DocumentFieldOverride{
+		{FieldPath: "seller.gstin"},
+	}, nil)
+
+	overrides, err := svc.ListOverrides(context.Background(), tenantID, docID, uuid.New(), domain.RoleAdmin)
+
+	assert.NoError(t, err)
+	assert.Len(t, overrides, 1)
+	assert.Equal(t, "seller.gstin", overrides[0].FieldPath)
+}
+
+func TestDocumentService_ClearOverrides_SinglePath(t *testing.T) {
+	svc, docRepo, _, _, _, overrideRepo, auditRepo := setupDocumentServiceWithOverrides()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{ID: docID, TenantID: tenantID}, nil)
+	overrideRepo.On("DeleteByPath", mock.Anything, tenantID, docID, "seller.gstin").Return(nil)
+
+	err := svc.ClearOverrides(context.Background(), tenantID, docID, uuid.New(), domain.RoleAdmin, "seller.gstin")
+
+	assert.NoError(t, err)
+	overrideRepo.AssertNotCalled(t, "DeleteByDocument", mock.Anything, mock.Anything, mock.Anything)
+	auditRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(entry *domain.DocumentAuditEntry) bool {
+		return entry.Action == string(domain.AuditDocumentOverridesCleared)
+	}))
+}
+
+func TestDocumentService_ClearOverrides_All(t *testing.T) {
+	svc, docRepo, _, _, _, overrideRepo, _ := setupDocumentServiceWithOverrides()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{ID: docID, TenantID: tenantID}, nil)
+	overrideRepo.On("DeleteByDocument", mock.Anything, tenantID, docID).Return(nil)
+
+	err := svc.ClearOverrides(context.Background(), tenantID, docID, uuid.New(), domain.RoleAdmin, "")
+
+	assert.NoError(t, err)
+	overrideRepo.AssertExpectations(t)
+}
+
+func TestDocumentService_ClearOverrides_NotFound(t *testing.T) {
+	svc, docRepo, _, _, _, overrideRepo, _ := setupDocumentServiceWithOverrides()
+
+	tenantID := uuid.New()
+	docID := uuid.New()
+
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{ID: docID, TenantID: tenantID}, nil)
+	overrideRepo.On("DeleteByPath", mock.Anything, tenantID, docID, "seller.gstin").Return(domain.ErrNotFound)
+
+	err := svc.ClearOverrides(context.Background(), tenantID, docID, uuid.New(), domain.RoleAdmin, "seller.gstin")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+// --- Feature flags ---
+
+func TestDocumentService_CreateAndParse_DualParseDisabledForTenant(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	fileRepo := new(mocks.MockFileMetaRepo)
+	userRepo := new(mocks.MockUserRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	flags := new(mocks.MockFeatureFlagService)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:  docRepo,
+		FileRepo: fileRepo,
+		UserRepo: userRepo,
+		PermRepo: permRepo,
+		Flags:    flags,
+	})
+
+	tenantID := uuid.New()
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	flags.On("IsEnabled", mock.Anything, tenantID, domain.FlagDualParse).Return(false)
+
+	doc, err := svc.CreateAndParse(context.Background(), &service.CreateDocumentInput{
+		TenantID:     tenantID,
+		CollectionID: uuid.New(),
+		FileID:       uuid.New(),
+		DocumentType: "invoice",
+		ParseMode:    domain.ParseModeDual,
+		CreatedBy:    uuid.New(),
+		Role:         domain.RoleAdmin,
+	})
+
+	assert.Nil(t, doc)
+	assert.ErrorIs(t, err, domain.ErrFeatureDisabled)
+	userRepo.AssertNotCalled(t, "CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// createWithParseModeDefault runs CreateAndParse with a collection/tenant default
+// of def and returns the parse mode of the document it tried to create.
+func createWithParseModeDefault(t *testing.T, requested, def domain.ParseMode, dualEnabled bool) domain.ParseMode {
+	t.Helper()
+	docRepo := new(mocks.MockDocumentRepo)
+	fileRepo := new(mocks.MockFileMetaRepo)
+	userRepo := new(mocks.MockUserRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	collectionRepo := new(mocks.MockCollectionRepo)
+	flags := new(mocks.MockFeatureFlagService)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:        docRepo,
+		FileRepo:       fileRepo,
+		UserRepo:       userRepo,
+		PermRepo:       permRepo,
+		Parser:         new(mocks.MockDocumentParser),
+		MergeParser:    new(mocks.MockDocumentParser),
+		Flags:          flags,
+		CollectionRepo: collectionRepo,
+	})
+
+	tenantID, collectionID, fileID, userID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	flags.On("IsEnabled", mock.Anything, tenantID, domain.FlagDualParse).Return(dualEnabled)
+	collectionRepo.On("DefaultParseMode", mock.Anything, tenantID, collectionID).Return(def, nil).Maybe()
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, tenantID, userID).Return(nil)
+	fileRepo.On("GetByID", mock.Anything, tenantID, fileID).Return(&domain.FileMeta{ID: fileID, TenantID: tenantID, OriginalName: "inv.pdf"}, nil)
+	var mode domain.ParseMode
+	docRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Document")).
+		Run(func(args mock.Arguments) { mode = args.Get(1).(*domain.Document).ParseMode }).
+		Return(errors.New("stop before parsing"))
+
+	_, err := svc.CreateAndParse(context.Background(), &service.CreateDocumentInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		FileID:       fileID,
+		DocumentType: "invoice",
+		ParseMode:    requested,
+		CreatedBy:    userID,
+		Role:         domain.RoleAdmin,
+	})
+	require.EqualError(t, err, "creating document: stop before parsing")
+	return mode
+}
+
+func TestDocumentService_CreateAndParse_UsesDefaultParseMode(t *testing.T) {
+	mode := createWithParseModeDefault(t, "", domain.ParseModeDual, true)
+
+	assert.Equal(t, domain.ParseModeDual, mode)
+}
+
+func TestDocumentService_CreateAndParse_RequestParseModeOverridesDefault(t *testing.T) {
+	mode := createWithParseModeDefault(t, domain.ParseModeSingle, domain.ParseModeDual, true)
+
+	assert.Equal(t, domain.ParseModeSingle, mode)
+}
+
+func TestDocumentService_CreateAndParse_NoDefaultParsesSingle(t *testing.T) {
+	mode := createWithParseModeDefault(t, "", "", true)
+
+	assert.Equal(t, domain.ParseModeSingle, mode)
+}
+
+func TestDocumentService_CreateAndParse_DualDefaultWithFlagOffParsesSingle(t *testing.T) {
+	mode := createWithParseModeDefault(t, "", domain.ParseModeDual, false)
+
+	assert.Equal(t, domain.ParseModeSingle, mode)
+}
+
+func TestDocumentService_CreateAndParse_ParseBudgetExhausted(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	fileRepo := new(mocks.MockFileMetaRepo)
+	userRepo := new(mocks.MockUserRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	tenantRepo := new(mocks.MockTenantRepo)
+	budgetRepo := new(mocks.MockParseBudgetRepo)
+	budget := service.NewParseBudget(tenantRepo, budgetRepo, "satvos", config.ParseBudgetConfig{FreeDailyCalls: 5}, nil)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:  docRepo,
+		FileRepo: fileRepo,
+		UserRepo: userRepo,
+		PermRepo: permRepo,
+		Budget:   budget,
+	})
+
+	tenantID, userID := uuid.New(), uuid.New()
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, tenantID, userID).Return(nil)
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, Slug: "satvos"}, nil)
+	budgetRepo.On("Reserve", mock.Anything, tenantID, userID, mock.Anything, 5).Return(domain.ErrParseBudgetExhausted)
+
+	doc, err := svc.CreateAndParse(context.Background(), &service.CreateDocumentInput{
+		TenantID:     tenantID,
+		CollectionID: uuid.New(),
+		FileID:       uuid.New(),
+		DocumentType: "invoice",
+		CreatedBy:    userID,
+		Role:         domain.RoleAdmin,
+	})
+
+	assert.Nil(t, doc)
+	assert.ErrorIs(t, err, domain.ErrParseBudgetExhausted)
+	fileRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+	docRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func setupTimedParse(t *testing.T) (service.DocumentService, *mocks.MockDocumentParser, *mocks.MockParseTimingRepo, *domain.Document) {
+	t.Helper()
+	docRepo := new(mocks.MockDocumentRepo)
+	fileRepo := new(mocks.MockFileMetaRepo)
+	p := new(mocks.MockDocumentParser)
+	storage := new(mocks.MockObjectStorage)
+	timingRepo := new(mocks.MockParseTimingRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:    docRepo,
+		FileRepo:   fileRepo,
+		UserRepo:   new(mocks.MockUserRepo),
+		PermRepo:   new(mocks.MockCollectionPermissionRepo),
+		Parser:     p,
+		Storage:    storage,
+		TimingRepo: timingRepo,
+	})
+
+	doc := &domain.Document{
+		ID:               uuid.New(),
+		TenantID:         uuid.New(),
+		FileID:           uuid.New(),
+		DocumentType:     "invoice",
+		ParseMode:        domain.ParseModeSingle,
+		ParsingStatus:    domain.ParsingStatusProcessing,
+		ParseAttempts:    1,
+		QueuedAt:         time.Now().Add(-2 * time.Minute),
+		StructuredData:   json.RawMessage("{}"),
+		ConfidenceScores: json.RawMessage("{}"),
+	}
+	fileRepo.On("GetByID", mock.Anything, doc.TenantID, doc.FileID).Return(&domain.FileMeta{
+		ID: doc.FileID, TenantID: doc.TenantID, S3Bucket: "test-bucket", S3Key: "test-key", ContentType: "application/pdf",
+	}, nil)
+	storage.On("Download", mock.Anything, "test-bucket", "test-key").Return([]byte("%PDF-1.4 test"), nil)
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	return svc, p, timingRepo, doc
+}
+
+func TestDocumentService_ParseDocument_RecordsTiming(t *testing.T) {
+	svc, p, timingRepo, doc := setupTimedParse(t)
+
+	p.On("Parse", mock.Anything, mock.Anything).Return(&port.ParseOutput{
+		StructuredData:   json.RawMessage(`{}`),
+		ConfidenceScores: json.RawMessage(`{}`),
+		ModelUsed:        "test-model",
+		InputTokens:      1200,
+		OutputTokens:     340,
+	}, nil)
+	var recorded *domain.ParseTiming
+	timingRepo.On("Record", mock.Anything, mock.AnythingOfType("*domain.ParseTiming")).
+		Run(func(args mock.Arguments) { recorded = args.Get(1).(*domain.ParseTiming) }).Return(nil)
+
+	svc.ParseDocument(context.Background(), doc, 3)
+
+	require.NotNil(t, recorded)
+	assert.Equal(t, doc.ID, recorded.DocumentID)
+	assert.Equal(t, doc.TenantID, recorded.TenantID)
+	assert.Equal(t, "test-model", recorded.ParserModel)
+	assert.Equal(t, 1, recorded.Attempt)
+	assert.Equal(t, domain.ParsingStatusCompleted, recorded.Outcome)
+	assert.Empty(t, recorded.ErrorMessage)
+	assert.Equal(t, 1200, recorded.InputTokens)
+	assert.Equal(t, 340, recorded.OutputTokens)
+	assert.GreaterOrEqual(t, recorded.QueueMs, int64(2*time.Minute/time.Millisecond))
+}
+
+func TestDocumentService_ParseDocument_RecordsLanguageFromExtractedText(t *testing.T) {
+	svc, p, timingRepo, doc := setupTimedParse(t)
+
+	// The test PDF has no fonts, so the parser gets no language and the
+	// document's language comes from the extracted text.
+	p.On("Parse", mock.Anything, mock.MatchedBy(func(in port.ParseInput) bool { return in.Language == "" })).
+		Return(&port.ParseOutput{
+			StructuredData:   json.RawMessage(`{"seller":{"name":"Shree Traders","address":"१२३ मुख्य मार्ग, मुंबई"}}`),
+			ConfidenceScores: json.RawMessage(`{}`),
+		}, nil)
+	timingRepo.On("Record", mock.Anything, mock.Anything).Return(nil)
+
+	svc.ParseDocument(context.Background(), doc, 3)
+
+	assert.Equal(t, domain.ParsingStatusCompleted, doc.ParsingStatus)
+	assert.Equal(t, "hi", doc.Language)
+}
+
+func TestDocumentService_ParseDocument_RateLimitRecordsQueuedTiming(t *testing.T) {
+	svc, p, timingRepo, doc := setupTimedParse(t)
+
+	p.On("Parse", mock.Anything, mock.Anything).
+		Return(nil, parser.NewRateLimitError("claude", fmt.Errorf("429 Too Many Requests"), 30))
+	var recorded *domain.ParseTiming
+	timingRepo.On("Record", mock.Anything, mock.AnythingOfType("*domain.ParseTiming")).
+		Run(func(args mock.Arguments) { recorded = args.Get(1).(*domain.ParseTiming) }).Return(nil)
+
+	svc.ParseDocument(context.Background(), doc, 3)
+
+	require.NotNil(t, recorded)
+	assert.Equal(t, "claude", recorded.ParserModel)
+	assert.Equal(t, domain.ParsingStatusQueued, recorded.Outcome)
+	assert.Equal(t, domain.ParseFailureRateLimited, recorded.FailureCategory)
+	assert.Contains(t, recorded.ErrorMessage, "rate limited by claude")
+	require.NotNil(t, doc.RetryAfter)
+	assert.Equal(t, *doc.RetryAfter, doc.QueuedAt, "queue wait restarts when the backoff elapses")
+}
+
+func setupRetryParse(t *testing.T, attempts int, downloadErr error) (service.DocumentService, *mocks.MockDocumentParser, *domain.Document) {
+	t.Helper()
+	docRepo := new(mocks.MockDocumentRepo)
+	fileRepo := new(mocks.MockFileMetaRepo)
+	p := new(mocks.MockDocumentParser)
+	storage := new(mocks.MockObjectStorage)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:  docRepo,
+		FileRepo: fileRepo,
+		UserRepo: new(mocks.MockUserRepo),
+		PermRepo: new(mocks.MockCollectionPermissionRepo),
+		Parser:   p,
+		Storage:  storage,
+	})
+
+	doc := &domain.Document{
+		ID:               uuid.New(),
+		TenantID:         uuid.New(),
+		FileID:           uuid.New(),
+		DocumentType:     "invoice",
+		ParseMode:        domain.ParseModeSingle,
+		ParsingStatus:    domain.ParsingStatusProcessing,
+		ParseAttempts:    attempts,
+		StructuredData:   json.RawMessage("{}"),
+		ConfidenceScores: json.RawMessage("{}"),
+	}
+	fileRepo.On("GetByID", mock.Anything, doc.TenantID, doc.FileID).Return(&domain.FileMeta{
+		ID: doc.FileID, TenantID: doc.TenantID, S3Bucket: "test-bucket", S3Key: "test-key", ContentType: "application/pdf",
+	}, nil)
+	if downloadErr != nil {
+		storage.On("Download", mock.Anything, "test-bucket", "test-key").Return(nil, downloadErr)
+	} else {
+		storage.On("Download", mock.Anything, "test-bucket", "test-key").Return([]byte("%PDF-1.4 test"), nil)
+	}
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	return svc, p, doc
+}
+
+func TestDocumentService_ParseDocument_TransientDownloadErrorQueuesWithBackoff(t *testing.T) {
+	svc, p, doc := setupRetryParse(t, 3, fmt.Errorf("s3 download: %w: %w", domain.ErrStorageUnavailable, errors.New("503 SlowDown")))
+
+	before := time.Now()
+	svc.ParseDocument(context.Background(), doc, 5)
+
+	assert.Equal(t, domain.ParsingStatusQueued, doc.ParsingStatus)
+	assert.Contains(t, doc.ParsingError, "downloading file")
+	require.NotNil(t, doc.RetryAfter)
+	// Third attempt: 30s doubled twice.
+	assert.WithinDuration(t, before.Add(2*time.Minute), *doc.RetryAfter, 5*time.Second)
+	p.AssertNotCalled(t, "Parse", mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_ParseDocument_PermanentDownloadErrorFails(t *testing.T) {
+	svc, _, doc := setupRetryParse(t, 1, errors.New("s3 download: NoSuchKey"))
+
+	svc.ParseDocument(context.Background(), doc, 5)
+
+	assert.Equal(t, domain.ParsingStatusFailed, doc.ParsingStatus)
+	assert.Contains(t, doc.ParsingError, "NoSuchKey")
+	assert.Nil(t, doc.RetryAfter)
+}
+
+func TestDocumentService_ParseDocument_TransientParserErrorBackoffCapped(t *testing.T) {
+	svc, p, doc := setupRetryParse(t, 9, nil)
+	p.On("Parse", mock.Anything, mock.Anything).
+		Return(nil, parser.NewTransientError("claude", errors.New("anthropic API error (status 529): overloaded")))
+
+	before := time.Now()
+	svc.ParseDocument(context.Background(), doc, 10)
+
+	assert.Equal(t, domain.ParsingStatusQueued, doc.ParsingStatus)
+	require.NotNil(t, doc.RetryAfter)
+	assert.WithinDuration(t, before.Add(15*time.Minute), *doc.RetryAfter, 5*time.Second)
+}
+
+func TestDocumentService_ParseDocument_TransientErrorAtMaxAttemptsFails(t *testing.T) {
+	svc, p, doc := setupRetryParse(t, 5, nil)
+	p.On("Parse", mock.Anything, mock.Anything).
+		Return(nil, parser.NewTransientError("claude", errors.New("calling anthropic API: connection reset")))
+
+	svc.ParseDocument(context.Background(), doc, 5)
+
+	assert.Equal(t, domain.ParsingStatusFailed, doc.ParsingStatus)
+	assert.Contains(t, doc.ParsingError, "parsing document")
+	assert.Nil(t, doc.RetryAfter)
+}
+
+func TestDocumentService_ParseDocument_TimeoutQueuedWithCategory(t *testing.T) {
+	svc, p, doc := setupRetryParse(t, 1, nil)
+	p.On("Parse", mock.Anything, mock.Anything).
+		Return(nil, parser.NewTimeoutError("claude", time.Minute, context.DeadlineExceeded))
+
+	svc.ParseDocument(context.Background(), doc, 5)
+
+	assert.Equal(t, domain.ParsingStatusQueued, doc.ParsingStatus)
+	assert.Equal(t, domain.ParseFailureTimeout, doc.ParseFailureCategory)
+	assert.Contains(t, doc.ParsingError, "timed out")
+}
+
+func TestDocumentService_ParseDocument_TimeoutAtMaxAttemptsFailsAsTimeout(t *testing.T) {
+	svc, p, doc := setupRetryParse(t, 5, nil)
+	p.On("Parse", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("all parsers failed: %w", parser.NewTimeoutError("gemini", time.Minute, context.DeadlineExceeded)))
+
+	svc.ParseDocument(context.Background(), doc, 5)
+
+	assert.Equal(t, domain.ParsingStatusFailed, doc.ParsingStatus)
+	assert.Equal(t, domain.ParseFailureTimeout, doc.ParseFailureCategory)
+}
+
+func TestDocumentService_ParseDocument_PermanentErrorCategory(t *testing.T) {
+	svc, p, doc := setupRetryParse(t, 1, nil)
+	p.On("Parse", mock.Anything, mock.Anything).
+		Return(nil, errors.New("anthropic API error (status 400): invalid image"))
+
+	svc.ParseDocument(context.Background(), doc, 5)
+
+	assert.Equal(t, domain.ParsingStatusFailed, doc.ParsingStatus)
+	assert.Equal(t, domain.ParseFailureError, doc.ParseFailureCategory)
+}
+
+func TestDocumentService_ParseDocument_SuccessClearsFailureCategory(t *testing.T) {
+	svc, p, doc := setupRetryParse(t, 2, nil)
+	doc.ParseFailureCategory = domain.ParseFailureTimeout
+	p.On("Parse", mock.Anything, mock.Anything).
+		Return(&port.ParseOutput{StructuredData: json.RawMessage(`{}`), ConfidenceScores: json.RawMessage(`{}`)}, nil)
+
+	svc.ParseDocument(context.Background(), doc, 5)
+
+	assert.Equal(t, domain.ParsingStatusCompleted, doc.ParsingStatus)
+	assert.Empty(t, doc.ParseFailureCategory)
+}
+
+func TestDocumentService_GetTimeline_MergesAuditAndAttempts(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	auditRepo := new(mocks.MockDocumentAuditRepo)
+	timingRepo := new(mocks.MockParseTimingRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:    docRepo,
+		FileRepo:   new(mocks.MockFileMetaRepo),
+		UserRepo:   new(mocks.MockUserRepo),
+		PermRepo:   permRepo,
+		Parser:     new(mocks.MockDocumentParser),
+		Storage:    new(mocks.MockObjectStorage),
+		AuditRepo:  auditRepo,
+		TimingRepo: timingRepo,
+	})
+
+	tenantID, docID := uuid.New(), uuid.New()
+	created := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{
+		ID: docID, TenantID: tenantID, CollectionID: uuid.New(), CreatedAt: created,
+		ParsingStatus: domain.ParsingStatusCompleted, ReviewStatus: domain.ReviewStatusPending,
+	}, nil)
+	// Audit entries come back newest first.
+	auditRepo.On("ListByDocument", mock.Anything, tenantID, docID, 0, mock.Anything).Return([]domain.DocumentAuditEntry{
+		{Action: string(domain.AuditDocumentParseCompleted), CreatedAt: created.Add(3*time.Hour + time.Minute)},
+		{Action: string(domain.AuditDocumentParseQueued), CreatedAt: created.Add(2 * time.Minute)},
+		{Action: string(domain.AuditDocumentCreated), CreatedAt: created},
+	}, 3, nil)
+	timingRepo.On("ListByDocument", mock.Anything, tenantID, docID).Return([]domain.ParseTiming{
+		{ParserModel: "claude", Outcome: domain.ParsingStatusQueued, ParseMs: 60_000, CreatedAt: created.Add(2 * time.Minute)},
+		{ParserModel: "claude", Outcome: domain.ParsingStatusCompleted, QueueMs: 3 * 3600_000, ParseMs: 60_000, CreatedAt: created.Add(3*time.Hour + time.Minute)},
+	}, nil)
+
+	tl, err := svc.GetTimeline(context.Background(), tenantID, docID, uuid.New(), domain.RoleAdmin)
+
+	require.NoError(t, err)
+	require.Len(t, tl.Events, 5)
+	types := make([]string, len(tl.Events))
+	for i, ev := range tl.Events {
+		types[i] = ev.Type
+	}
+	assert.Equal(t, []string{
+		string(domain.AuditDocumentCreated), domain.TimelineParseAttempt, string(domain.AuditDocumentParseQueued),
+		domain.TimelineParseAttempt, string(domain.AuditDocumentParseCompleted),
+	}, types)
+	assert.Equal(t, 2, tl.ParseAttempts)
+	assert.Equal(t, int64(3*3600_000), tl.TotalQueueMs)
+	assert.Equal(t, int64(120_000), tl.TotalParseMs)
+	assert.Equal(t, int64((3*time.Hour+time.Minute)/time.Millisecond), tl.ElapsedMs)
+	// The second attempt started 3h after the first was queued.
+	assert.Equal(t, int64((3*time.Hour-2*time.Minute)/time.Millisecond), tl.Events[3].SincePreviousMs)
+}
+
+func TestDocumentService_ListParseAttempts(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	timingRepo := new(mocks.MockParseTimingRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:    docRepo,
+		FileRepo:   new(mocks.MockFileMetaRepo),
+		UserRepo:   new(mocks.MockUserRepo),
+		PermRepo:   permRepo,
+		Parser:     new(mocks.MockDocumentParser),
+		Storage:    new(mocks.MockObjectStorage),
+		TimingRepo: timingRepo,
+	})
+
+	tenantID, docID, userID, collectionID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{
+		ID: docID, TenantID: tenantID, CollectionID: collectionID,
+	}, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, collectionID, userID).
+		Return(&domain.CollectionPermissionEntry{Permission: domain.CollectionPermViewer}, nil)
+	attempts := []domain.ParseTiming{
+		{Attempt: 1, ParserModel: "claude", Outcome: domain.ParsingStatusQueued, FailureCategory: domain.ParseFailureTimeout},
+		{Attempt: 2, ParserModel: "gemini", Outcome: domain.ParsingStatusCompleted, InputTokens: 1500, OutputTokens: 400},
+	}
+	timingRepo.On("ListByDocument", mock.Anything, tenantID, docID).Return(attempts, nil)
+
+	got, err := svc.ListParseAttempts(context.Background(), tenantID, docID, userID, domain.RoleViewer)
+
+	require.NoError(t, err)
+	assert.Equal(t, attempts, got)
+}
+
+func TestDocumentService_ListParseAttempts_NoPermission(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	timingRepo := new(mocks.MockParseTimingRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:    docRepo,
+		FileRepo:   new(mocks.MockFileMetaRepo),
+		UserRepo:   new(mocks.MockUserRepo),
+		PermRepo:   permRepo,
+		Parser:     new(mocks.MockDocumentParser),
+		Storage:    new(mocks.MockObjectStorage),
+		TimingRepo: timingRepo,
+	})
+
+	tenantID, docID := uuid.New(), uuid.New()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{
+		ID: docID, TenantID: tenantID, CollectionID: uuid.New(),
+	}, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound)
+
+	_, err := svc.ListParseAttempts(context.Background(), tenantID, docID, uuid.New(), domain.RoleViewer)
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	timingRepo.AssertNotCalled(t, "ListByDocument", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- RecomputeTotals ---
+
+func TestDocumentService_RecomputeTotals(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Maybe()
+
+	tenantID, docID := uuid.New(), uuid.New()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{
+		ID: docID, TenantID: tenantID, CollectionID: uuid.New(), ParsingStatus: domain.ParsingStatusCompleted,
+		StructuredData: json.RawMessage(`{
+			"line_items": [{"taxable_amount": 100, "igst_amount": 18, "total": 118}],
+			"totals": {"subtotal": 100, "taxable_amount": 100, "igst": 12, "total": 112}
+		}`),
+	}, nil)
+
+	result, err := svc.RecomputeTotals(context.Background(), tenantID, docID, uuid.New(), domain.RoleAdmin)
+
+	require.NoError(t, err)
+	assert.False(t, result.Balanced)
+	assert.Equal(t, 12.0, result.Stored.IGST)
+	assert.Equal(t, 18.0, result.Recomputed.IGST)
+	assert.Equal(t, 118.0, result.Recomputed.Total)
+	require.Len(t, result.Deltas, 2)
+	assert.Equal(t, "totals.igst", result.Deltas[0].FieldPath)
+	assert.Equal(t, 6.0, result.Deltas[0].Delta)
+	docRepo.AssertNotCalled(t, "UpdateStructuredData", mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_RecomputeTotals_NotParsed(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Maybe()
+
+	tenantID, docID := uuid.New(), uuid.New()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{
+		ID: docID, TenantID: tenantID, CollectionID: uuid.New(), ParsingStatus: domain.ParsingStatusProcessing,
+	}, nil)
+
+	_, err := svc.RecomputeTotals(context.Background(), tenantID, docID, uuid.New(), domain.RoleAdmin)
+
+	assert.ErrorIs(t, err, domain.ErrDocumentNotParsed)
+}
+
+func setupPatchLineItemsDoc(t *testing.T, docRepo *mocks.MockDocumentRepo, permRepo *mocks.MockCollectionPermissionRepo, tagRepo *mocks.MockDocumentTagRepo) (tenantID, docID uuid.UUID, updatedAt time.Time, saved *domain.Document) {
+	t.Helper()
+	tenantID, docID = uuid.New(), uuid.New()
+	updatedAt = time.Date(2026, 10, 15, 9, 30, 0, 123456000, time.UTC)
+	saved = &domain.Document{}
+
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{
+		ID: docID, TenantID: tenantID, CollectionID: uuid.New(), ParsingStatus: domain.ParsingStatusCompleted,
+		UpdatedAt: updatedAt, ConfidenceScores: json.RawMessage(`{}`),
+		StructuredData: json.RawMessage(`{
+			"invoice": {"invoice_number": "INV-1"},
+			"line_items": [
+				{"description": "Bolts", "quantity": 10, "unit_price": 10, "taxable_amount": 100, "igst_rate": 18, "igst_amount": 18, "total": 118, "note": "kept"},
+				{"description": "Nuts", "quantity": 1, "unit_price": 50, "taxable_amount": 50, "igst_rate": 18, "igst_amount": 9, "total": 59}
+			],
+			"totals": {"subtotal": 150, "taxable_amount": 150, "igst": 27, "total": 177, "amount_in_words": "x"}
+		}`),
+	}, nil)
+	docRepo.On("UpdateStructuredDataIfUnmodified", mock.Anything, mock.AnythingOfType("*domain.Document"), updatedAt).
+		Run(func(args mock.Arguments) { *saved = *args.Get(1).(*domain.Document) }).Return(nil).Maybe()
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.Anything).Return(nil).Maybe()
+	tagRepo.On("DeleteByDocumentAndSource", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	tagRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return tenantID, docID, updatedAt, saved
+}
+
+func TestDocumentService_PatchLineItems(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, tagRepo, _, _ := setupDocumentService()
+	tenantID, docID, updatedAt, saved := setupPatchLineItemsDoc(t, docRepo, permRepo, tagRepo)
+
+	zero, one := 0, 1
+	_, err := svc.PatchLineItems(context.Background(), &service.PatchLineItemsInput{
+		TenantID: tenantID, DocumentID: docID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		IfUpdatedAt: &updatedAt,
+		Operations: []service.LineItemOperation{
+			{Op: domain.LineItemOpUpdate, Index: &zero, Fields: map[string]json.RawMessage{"quantity": json.RawMessage(`20`)}},
+			{Op: domain.LineItemOpDelete, Index: &one},
+			{Op: domain.LineItemOpAdd, Fields: map[string]json.RawMessage{
+				"description": json.RawMessage(`"Washers"`), "quantity": json.RawMessage(`5`),
+				"unit_price": json.RawMessage(`20`), "igst_rate": json.RawMessage(`5`),
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	var data struct {
+		LineItems []map[string]interface{} `json:"line_items"`
+		Totals    map[string]interface{}   `json:"totals"`
+	}
+	require.NoError(t, json.Unmarshal(saved.StructuredData, &data))
+	require.Len(t, data.LineItems, 2)
+	assert.Equal(t, "Bolts", data.LineItems[0]["description"])
+	assert.Equal(t, 200.0, data.LineItems[0]["taxable_amount"])
+	assert.Equal(t, 36.0, data.LineItems[0]["igst_amount"])
+	assert.Equal(t, 236.0, data.LineItems[0]["total"])
+	assert.Equal(t, "kept", data.LineItems[0]["note"])
+	assert.Equal(t, "Washers", data.LineItems[1]["description"])
+	assert.Equal(t, 100.0, data.LineItems[1]["taxable_amount"])
+	assert.Equal(t, 5.0, data.LineItems[1]["igst_amount"])
+	assert.Equal(t, 105.0, data.LineItems[1]["total"])
+	assert.Equal(t, 300.0, data.Totals["taxable_amount"])
+	assert.Equal(t, 41.0, data.Totals["igst"])
+	assert.Equal(t, 341.0, data.Totals["total"])
+	assert.Equal(t, "x", data.Totals["amount_in_words"])
+}
+
+func TestDocumentService_PatchLineItems_ExplicitAmountsKept(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, tagRepo, _, _ := setupDocumentService()
+	tenantID, docID, _, saved := setupPatchLineItemsDoc(t, docRepo, permRepo, tagRepo)
+
+	one := 1
+	_, err := svc.PatchLineItems(context.Background(), &service.PatchLineItemsInput{
+		TenantID: tenantID, DocumentID: docID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		Operations: []service.LineItemOperation{
+			{Op: domain.LineItemOpUpdate, Index: &one, Fields: map[string]json.RawMessage{
+				"unit_price": json.RawMessage(`60`), "taxable_amount": json.RawMessage(`55`),
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	var data struct {
+		LineItems []map[string]interface{} `json:"line_items"`
+	}
+	require.NoError(t, json.Unmarshal(saved.StructuredData, &data))
+	assert.Equal(t, 55.0, data.LineItems[1]["taxable_amount"])
+	assert.Equal(t, 9.9, data.LineItems[1]["igst_amount"])
+	assert.Equal(t, 64.9, data.LineItems[1]["total"])
+}
+
+func TestDocumentService_PatchLineItems_Modified(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, tagRepo, _, _ := setupDocumentService()
+	tenantID, docID, updatedAt, _ := setupPatchLineItemsDoc(t, docRepo, permRepo, tagRepo)
+
+	stale := updatedAt.Add(-time.Second)
+	_, err := svc.PatchLineItems(context.Background(), &service.PatchLineItemsInput{
+		TenantID: tenantID, DocumentID: docID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		IfUpdatedAt: &stale,
+		Operations:  []service.LineItemOperation{{Op: domain.LineItemOpAdd, Fields: map[string]json.RawMessage{"quantity": json.RawMessage(`1`)}}},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrDocumentModified)
+	docRepo.AssertNotCalled(t, "UpdateStructuredData", mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_PatchLineItems_ConcurrentSaveWins(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, tagRepo, _, _ := setupDocumentService()
+	tenantID, docID := uuid.New(), uuid.New()
+	updatedAt := time.Date(2026, 10, 15, 9, 30, 0, 123456000, time.UTC)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{
+		ID: docID, TenantID: tenantID, CollectionID: uuid.New(), ParsingStatus: domain.ParsingStatusCompleted,
+		UpdatedAt: updatedAt, StructuredData: json.RawMessage(`{"line_items": []}`),
+	}, nil)
+	// Another edit was saved after the document was read
+	docRepo.On("UpdateStructuredDataIfUnmodified", mock.Anything, mock.Anything, updatedAt).Return(domain.ErrDocumentModified)
+
+	_, err := svc.PatchLineItems(context.Background(), &service.PatchLineItemsInput{
+		TenantID: tenantID, DocumentID: docID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		Operations: []service.LineItemOperation{{Op: domain.LineItemOpAdd, Fields: map[string]json.RawMessage{"quantity": json.RawMessage(`1`)}}},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrDocumentModified)
+	docRepo.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything)
+	tagRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_PatchLineItems_Invalid(t *testing.T) {
+	zero, five := 0, 5
+	tests := []struct {
+		name string
+		ops  []service.LineItemOperation
+	}{
+		{"no operations", nil},
+		{"unknown op", []service.LineItemOperation{{Op: "move", Index: &zero}}},
+		{"index out of range", []service.LineItemOperation{{Op: domain.LineItemOpDelete, Index: &five}}},
+		{"update without index", []service.LineItemOperation{{Op: domain.LineItemOpUpdate, Fields: map[string]json.RawMessage{"quantity": json.RawMessage(`1`)}}}},
+		{"add with index", []service.LineItemOperation{{Op: domain.LineItemOpAdd, Index: &zero}}},
+		{"deleted twice", []service.LineItemOperation{{Op: domain.LineItemOpDelete, Index: &zero}, {Op: domain.LineItemOpDelete, Index: &zero}}},
+		{"unknown field", []service.LineItemOperation{{Op: domain.LineItemOpUpdate, Index: &zero, Fields: map[string]json.RawMessage{"colour": json.RawMessage(`"red"`)}}}},
+		{"wrong type", []service.LineItemOperation{{Op: domain.LineItemOpUpdate, Index: &zero, Fields: map[string]json.RawMessage{"quantity": json.RawMessage(`"ten"`)}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, docRepo, _, permRepo, _, _, tagRepo, _, _ := setupDocumentService()
+			tenantID, docID, _, _ := setupPatchLineItemsDoc(t, docRepo, permRepo, tagRepo)
+
+			_, err := svc.PatchLineItems(context.Background(), &service.PatchLineItemsInput{
+				TenantID: tenantID, DocumentID: docID, UserID: uuid.New(), Role: domain.RoleAdmin,
+				Operations: tt.ops,
+			})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidLineItemPatch)
+			docRepo.AssertNotCalled(t, "UpdateStructuredData", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDocumentService_EditStructuredData_JSONPatch(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, tagRepo, _, auditRepo := setupDocumentService()
+	tenantID, docID, _, saved := setupPatchLineItemsDoc(t, docRepo, permRepo, tagRepo)
+	_, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
+		TenantID: tenantID, DocumentID: docID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		Patch: []jsonpatch.Operation{
+			{Op: "test", Path: "/invoice/invoice_number", Value: json.RawMessage(`"INV-1"`)},
+			{Op: "replace", Path: "/invoice/invoice_number", Value: json.RawMessage(`"INV-2"`)},
+			{Op: "remove", Path: "/line_items/1"},
+		},
+	})
+	require.NoError(t, err)
+
+	var data struct {
+		Invoice   map[string]interface{}   `json:"invoice"`
+		LineItems []map[string]interface{} `json:"line_items"`
+	}
+	require.NoError(t, json.Unmarshal(saved.StructuredData, &data))
+	assert.Equal(t, "INV-2", data.Invoice["invoice_number"])
+	require.Len(t, data.LineItems, 1)
+	assert.Equal(t, "kept", data.LineItems[0]["note"])
+
+	var audited *domain.DocumentAuditEntry
+	for _, call := range auditRepo.Calls {
+		if entry := call.Arguments.Get(1).(*domain.DocumentAuditEntry); entry.Action == string(domain.AuditDocumentEditStructured) {
+			audited = entry
+		}
+	}
+	require.NotNil(t, audited)
+	var changes map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(audited.Changes, &changes))
+	assert.Contains(t, string(changes["patch"]), `"/line_items/1"`)
+}
+
+func TestDocumentService_EditStructuredData_JSONPatchConcurrentSaveWins(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, tagRepo, _, _ := setupDocumentService()
+	tenantID, docID := uuid.New(), uuid.New()
+	updatedAt := time.Date(2026, 10, 15, 9, 30, 0, 123456000, time.UTC)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{
+		ID: docID, TenantID: tenantID, CollectionID: uuid.New(), ParsingStatus: domain.ParsingStatusCompleted,
+		UpdatedAt: updatedAt, StructuredData: json.RawMessage(`{"invoice": {"invoice_number": "INV-1"}}`),
+	}, nil)
+	// Another edit was saved after the document was read
+	docRepo.On("UpdateStructuredDataIfUnmodified", mock.Anything, mock.Anything, updatedAt).Return(domain.ErrDocumentModified)
+
+	_, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
+		TenantID: tenantID, DocumentID: docID, UserID: uuid.New(), Role: domain.RoleAdmin,
+		Patch: []jsonpatch.Operation{{Op: "replace", Path: "/invoice/invoice_number", Value: json.RawMessage(`"INV-2"`)}},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrDocumentModified)
+	docRepo.AssertNotCalled(t, "UpdateStructuredData", mock.Anything, mock.Anything)
+	docRepo.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything)
+	tagRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_EditStructuredData_JSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch []jsonpatch.Operation
+		want  error
+	}{
+		{"test fails", []jsonpatch.Operation{{Op: "test", Path: "/invoice/invoice_number", Value: json.RawMessage(`"INV-9"`)}}, domain.ErrJSONPatchConflict},
+		{"missing path", []jsonpatch.Operation{{Op: "remove", Path: "/line_items/7"}}, domain.ErrJSONPatchConflict},
+		{"unknown op", []jsonpatch.Operation{{Op: "merge", Path: "/invoice"}}, domain.ErrInvalidJSONPatch},
+		{"result not an invoice", []jsonpatch.Operation{{Op: "replace", Path: "/line_items", Value: json.RawMessage(`"none"`)}}, domain.ErrInvalidStructuredData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, docRepo, _, permRepo, _, _, tagRepo, _, _ := setupDocumentService()
+			tenantID, docID, _, _ := setupPatchLineItemsDoc(t, docRepo, permRepo, tagRepo)
+
+			_, err := svc.EditStructuredData(context.Background(), &service.EditStructuredDataInput{
+				TenantID: tenantID, DocumentID: docID, UserID: uuid.New(), Role: domain.RoleAdmin,
+				Patch: tt.patch,
+			})
+
+			assert.ErrorIs(t, err, tt.want)
+			docRepo.AssertNotCalled(t, "UpdateStructuredData", mock.Anything, mock.Anything)
+		})
+	}
+}
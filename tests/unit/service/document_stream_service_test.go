@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func collectStream(t *testing.T, svc service.DocumentStreamService, input *service.StreamDocumentsInput) ([]service.DocumentStreamRecord, error) {
+	t.Helper()
+	var recs []service.DocumentStreamRecord
+	err := svc.Stream(context.Background(), input, func(rec *service.DocumentStreamRecord) error {
+		recs = append(recs, *rec)
+		return nil
+	})
+	return recs, err
+}
+
+func TestDocumentStreamService_Stream(t *testing.T) {
+	repo := new(mocks.MockDocumentChangeRepo)
+	svc := service.NewDocumentStreamService(repo, new(mocks.MockCollectionService))
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	updatedID, deletedID, goneID := uuid.New(), uuid.New(), uuid.New()
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	repo.On("ListSince", mock.Anything, tenantID, userID, false, (*uuid.UUID)(nil), time.Time{}, uuid.Nil, mock.Anything, 500).
+		Return([]domain.DocumentChange{
+			{DocumentID: updatedID, TenantID: tenantID, CollectionID: collectionID, ChangedAt: at},
+			{DocumentID: deletedID, TenantID: tenantID, CollectionID: collectionID, ChangedAt: at.Add(time.Second), Deleted: true},
+			{DocumentID: goneID, TenantID: tenantID, CollectionID: collectionID, ChangedAt: at.Add(2 * time.Second)},
+		}, nil)
+	repo.On("ListDocuments", mock.Anything, tenantID, []uuid.UUID{updatedID, goneID}).
+		Return(map[uuid.UUID]domain.Document{updatedID: {ID: updatedID, Name: "inv.pdf"}}, nil)
+
+	recs, err := collectStream(t, svc, &service.StreamDocumentsInput{TenantID: tenantID, UserID: userID, Role: domain.RoleAdmin})
+	require.NoError(t, err)
+	require.Len(t, recs, 3)
+	assert.Equal(t, service.DocumentStreamDocument, recs[0].Type)
+	assert.Equal(t, "inv.pdf", recs[0].Document.Name)
+	assert.Equal(t, service.DocumentStreamTombstone, recs[1].Type)
+	assert.Equal(t, deletedID, *recs[1].ID)
+	assert.Nil(t, recs[1].Document)
+	// A document deleted after it was listed is skipped, but the cursor moves past it
+	assert.Equal(t, service.DocumentStreamEnd, recs[2].Type)
+	assert.False(t, recs[2].HasMore)
+	assert.NotEqual(t, recs[1].Cursor, recs[2].Cursor)
+
+	// Resuming from the end cursor continues after the last change
+	repo.On("ListSince", mock.Anything, tenantID, userID, false, (*uuid.UUID)(nil), at.Add(2*time.Second), goneID, mock.Anything, 500).
+		Return([]domain.DocumentChange{}, nil)
+	repo.On("ListDocuments", mock.Anything, tenantID, []uuid.UUID{}).Return(map[uuid.UUID]domain.Document{}, nil)
+	recs, err = collectStream(t, svc, &service.StreamDocumentsInput{TenantID: tenantID, UserID: userID, Role: domain.RoleAdmin, Since: recs[2].Cursor})
+	require.NoError(t, err)
+	require.Len(t, recs, 1)
+	assert.Equal(t, service.DocumentStreamEnd, recs[0].Type)
+	repo.AssertExpectations(t)
+}
+
+func TestDocumentStreamService_Stream_LimitReached(t *testing.T) {
+	repo := new(mocks.MockDocumentChangeRepo)
+	svc := service.NewDocumentStreamService(repo, new(mocks.MockCollectionService))
+	tenantID, userID := uuid.New(), uuid.New()
+	first, second := uuid.New(), uuid.New()
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// Viewers only stream collections they have a permission on
+	repo.On("ListSince", mock.Anything, tenantID, userID, true, (*uuid.UUID)(nil), time.Time{}, uuid.Nil, mock.Anything, 2).
+		Return([]domain.DocumentChange{
+			{DocumentID: first, ChangedAt: at, Deleted: true},
+			{DocumentID: second, ChangedAt: at, Deleted: true},
+		}, nil)
+	repo.On("ListDocuments", mock.Anything, tenantID, []uuid.UUID{}).Return(map[uuid.UUID]domain.Document{}, nil)
+
+	recs, err := collectStream(t, svc, &service.StreamDocumentsInput{TenantID: tenantID, UserID: userID, Role: domain.RoleViewer, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, recs, 3)
+	assert.True(t, recs[2].HasMore)
+	assert.Equal(t, recs[1].Cursor, recs[2].Cursor)
+	repo.AssertNumberOfCalls(t, "ListSince", 1)
+}
+
+func TestDocumentStreamService_Stream_InvalidCursor(t *testing.T) {
+	repo := new(mocks.MockDocumentChangeRepo)
+	svc := service.NewDocumentStreamService(repo, new(mocks.MockCollectionService))
+
+	recs, err := collectStream(t, svc, &service.StreamDocumentsInput{TenantID: uuid.New(), Since: "not-a-cursor!"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	assert.Empty(t, recs)
+	repo.AssertNotCalled(t, "ListSince")
+}
+
+func TestDocumentStreamService_Stream_CollectionForbidden(t *testing.T) {
+	repo := new(mocks.MockDocumentChangeRepo)
+	collectionSvc := new(mocks.MockCollectionService)
+	svc := service.NewDocumentStreamService(repo, collectionSvc)
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	collectionSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.RoleViewer).Return(nil, domain.ErrForbidden)
+
+	recs, err := collectStream(t, svc, &service.StreamDocumentsInput{
+		TenantID: tenantID, UserID: userID, Role: domain.RoleViewer, CollectionID: &collectionID,
+	})
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	assert.Empty(t, recs)
+}
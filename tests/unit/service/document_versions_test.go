@@ -0,0 +1,304 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func parsedDocument(tenantID uuid.UUID) *domain.Document {
+	reviewer := uuid.New()
+	parsedAt := time.Now().Add(-time.Hour)
+	return &domain.Document{
+		ID:                uuid.New(),
+		TenantID:          tenantID,
+		CollectionID:      uuid.New(),
+		FileID:            uuid.New(),
+		DocumentType:      "invoice",
+		Version:           1,
+		ParserModel:       "claude",
+		StructuredData:    json.RawMessage(`{"invoice":{"invoice_number":"INV-1"}}`),
+		ConfidenceScores:  json.RawMessage(`{"invoice":{"invoice_number":0.9}}`),
+		ParsingStatus:     domain.ParsingStatusCompleted,
+		ParsedAt:          &parsedAt,
+		ReviewStatus:      domain.ReviewStatusApproved,
+		ReviewedBy:        &reviewer,
+		ValidationStatus:  domain.ValidationStatusValid,
+		ValidationResults: json.RawMessage(`[]`),
+	}
+}
+
+// allowBackgroundParse lets the re-parse goroutine run against the mocks without failing the test.
+func allowBackgroundParse(docRepo *mocks.MockDocumentRepo, fileRepo *mocks.MockFileMetaRepo, storage *mocks.MockObjectStorage) {
+	fileRepo.On("GetByID", mock.Anything, mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound).Maybe()
+	docRepo.On("UpdateStructuredData", mock.Anything, mock.Anything).Return(nil).Maybe()
+	storage.On("Download", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("no storage")).Maybe()
+}
+
+func TestDocumentService_ReplaceFile_VersionsPreviousFile(t *testing.T) {
+	svc, docRepo, fileRepo, permRepo, _, storage, tagRepo, userRepo, auditRepo := setupDocumentService()
+	tenantID, userID, newFileID := uuid.New(), uuid.New(), uuid.New()
+	doc := parsedDocument(tenantID)
+	oldFileID := doc.FileID
+
+	docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	fileRepo.On("GetByID", mock.Anything, tenantID, newFileID).Return(&domain.FileMeta{ID: newFileID, TenantID: tenantID}, nil).Once()
+	var prev *domain.DocumentVersion
+	docRepo.On("SaveVersion", mock.Anything, mock.AnythingOfType("*domain.Document"), mock.AnythingOfType("*domain.DocumentVersion")).
+		Run(func(args mock.Arguments) { prev = args.Get(2).(*domain.DocumentVersion) }).
+		Return(nil)
+	tagRepo.On("DeleteByDocumentAndSource", mock.Anything, doc.ID, "auto").Return(nil)
+	allowBackgroundParse(docRepo, fileRepo, storage)
+	var event *service.DocumentEvent
+	svc.Events().Subscribe("test", func(_ context.Context, e *service.DocumentEvent) {
+		event = e
+	}, service.DocumentEventVersioned)
+
+	result, err := svc.ReplaceFile(context.Background(), &service.ReplaceFileInput{
+		TenantID: tenantID, DocumentID: doc.ID, FileID: newFileID, UserID: userID, Role: domain.RoleAdmin,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, newFileID, result.FileID)
+	assert.Equal(t, 2, result.Version)
+	assert.Equal(t, domain.ParsingStatusPending, result.ParsingStatus)
+	assert.Equal(t, domain.ReviewStatusPending, result.ReviewStatus)
+	assert.Nil(t, result.ReviewedBy)
+	assert.JSONEq(t, `{}`, string(result.StructuredData))
+
+	require.NotNil(t, prev)
+	assert.Equal(t, 1, prev.Version)
+	require.NotNil(t, prev.FileID)
+	assert.Equal(t, oldFileID, *prev.FileID)
+	assert.JSONEq(t, `{"invoice":{"invoice_number":"INV-1"}}`, string(prev.StructuredData))
+	assert.Equal(t, domain.ReviewStatusApproved, prev.ReviewStatus)
+	assert.Equal(t, "invoice", prev.DocumentType)
+	assert.Equal(t, domain.DocumentVersionFileReplaced, prev.Reason)
+	assert.Equal(t, userID, prev.ReplacedBy)
+
+	require.NotNil(t, event)
+	assert.Equal(t, newFileID, event.Document.FileID)
+	assert.Equal(t, &userID, event.ActorID)
+	tagRepo.AssertCalled(t, "DeleteByDocumentAndSource", mock.Anything, doc.ID, "auto")
+	userRepo.AssertCalled(t, "CheckAndIncrementQuota", mock.Anything, tenantID, userID)
+	auditRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(e *domain.DocumentAuditEntry) bool {
+		return e.Action == string(domain.AuditDocumentFileReplaced)
+	}))
+}
+
+func TestDocumentService_ReplaceFile_RejectsWhileParsing(t *testing.T) {
+	svc, docRepo, fileRepo, permRepo, _, _, _, _, _ := setupDocumentService()
+	tenantID := uuid.New()
+	doc := parsedDocument(tenantID)
+	doc.ParsingStatus = domain.ParsingStatusProcessing
+
+	docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+
+	_, err := svc.ReplaceFile(context.Background(), &service.ReplaceFileInput{
+		TenantID: tenantID, DocumentID: doc.ID, FileID: uuid.New(), UserID: uuid.New(), Role: domain.RoleAdmin,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrDocumentParseInProgress)
+	fileRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+	docRepo.AssertNotCalled(t, "SaveVersion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_ReplaceFile_SameFile(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, userRepo, _ := setupDocumentService()
+	tenantID := uuid.New()
+	doc := parsedDocument(tenantID)
+
+	docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+
+	_, err := svc.ReplaceFile(context.Background(), &service.ReplaceFileInput{
+		TenantID: tenantID, DocumentID: doc.ID, FileID: doc.FileID, UserID: uuid.New(), Role: domain.RoleAdmin,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrDocumentAlreadyExists)
+	userRepo.AssertNotCalled(t, "CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_ReplaceFile_ViewerForbidden(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+	tenantID, userID := uuid.New(), uuid.New()
+	doc := parsedDocument(tenantID)
+
+	docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, doc.CollectionID, userID).
+		Return(&domain.CollectionPermissionEntry{Permission: domain.CollectionPermViewer}, nil)
+
+	_, err := svc.ReplaceFile(context.Background(), &service.ReplaceFileInput{
+		TenantID: tenantID, DocumentID: doc.ID, FileID: uuid.New(), UserID: userID, Role: domain.RoleMember,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	docRepo.AssertNotCalled(t, "SaveVersion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_Reclassify_VersionsPreviousParse(t *testing.T) {
+	svc, docRepo, fileRepo, permRepo, _, storage, tagRepo, userRepo, auditRepo := setupDocumentService()
+	tenantID, userID := uuid.New(), uuid.New()
+	doc := parsedDocument(tenantID)
+	fileID := doc.FileID
+
+	docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	fileRepo.On("GetByID", mock.Anything, tenantID, fileID).Return(&domain.FileMeta{ID: fileID, TenantID: tenantID}, nil).Once()
+	var prev *domain.DocumentVersion
+	docRepo.On("SaveVersion", mock.Anything, mock.AnythingOfType("*domain.Document"), mock.AnythingOfType("*domain.DocumentVersion")).
+		Run(func(args mock.Arguments) { prev = args.Get(2).(*domain.DocumentVersion) }).
+		Return(nil)
+	tagRepo.On("DeleteByDocumentAndSource", mock.Anything, doc.ID, "auto").Return(nil)
+	allowBackgroundParse(docRepo, fileRepo, storage)
+	var event *service.DocumentEvent
+	svc.Events().Subscribe("test", func(_ context.Context, e *service.DocumentEvent) {
+		event = e
+	}, service.DocumentEventVersioned)
+
+	result, err := svc.Reclassify(context.Background(), &service.ReclassifyInput{
+		TenantID: tenantID, DocumentID: doc.ID, DocumentType: " credit_note ", UserID: userID, Role: domain.RoleAdmin,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "credit_note", result.DocumentType)
+	assert.Equal(t, fileID, result.FileID)
+	assert.Equal(t, 2, result.Version)
+	assert.Equal(t, domain.ParsingStatusPending, result.ParsingStatus)
+	assert.Equal(t, domain.ReviewStatusPending, result.ReviewStatus)
+	assert.JSONEq(t, `{}`, string(result.StructuredData))
+
+	require.NotNil(t, prev)
+	assert.Equal(t, 1, prev.Version)
+	assert.Equal(t, "invoice", prev.DocumentType)
+	assert.Equal(t, domain.DocumentVersionReclassified, prev.Reason)
+	require.NotNil(t, prev.FileID)
+	assert.Equal(t, fileID, *prev.FileID)
+	assert.JSONEq(t, `{"invoice":{"invoice_number":"INV-1"}}`, string(prev.StructuredData))
+	assert.Equal(t, userID, prev.ReplacedBy)
+
+	require.NotNil(t, event)
+	assert.Equal(t, "credit_note", event.Document.DocumentType)
+	tagRepo.AssertCalled(t, "DeleteByDocumentAndSource", mock.Anything, doc.ID, "auto")
+	userRepo.AssertNotCalled(t, "CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything)
+	auditRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(e *domain.DocumentAuditEntry) bool {
+		return e.Action == string(domain.AuditDocumentReclassified)
+	}))
+}
+
+func TestDocumentService_Reclassify_InvalidType(t *testing.T) {
+	tests := []struct {
+		name    string
+		docType string
+	}{
+		{"empty", "  "},
+		{"too long", strings.Repeat("x", 51)},
+		{"unchanged", "invoice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+			tenantID := uuid.New()
+			doc := parsedDocument(tenantID)
+
+			docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil).Maybe()
+			permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, errors.New("not found")).Maybe()
+
+			_, err := svc.Reclassify(context.Background(), &service.ReclassifyInput{
+				TenantID: tenantID, DocumentID: doc.ID, DocumentType: tt.docType, UserID: uuid.New(), Role: domain.RoleAdmin,
+			})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidDocumentType)
+			docRepo.AssertNotCalled(t, "SaveVersion", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDocumentService_Reclassify_RejectsWhileParsing(t *testing.T) {
+	svc, docRepo, fileRepo, permRepo, _, _, _, _, _ := setupDocumentService()
+	tenantID := uuid.New()
+	doc := parsedDocument(tenantID)
+	doc.ParsingStatus = domain.ParsingStatusQueued
+
+	docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+
+	_, err := svc.Reclassify(context.Background(), &service.ReclassifyInput{
+		TenantID: tenantID, DocumentID: doc.ID, DocumentType: "credit_note", UserID: uuid.New(), Role: domain.RoleAdmin,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrDocumentParseInProgress)
+	fileRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+	docRepo.AssertNotCalled(t, "SaveVersion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDocumentService_ListVersions(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+	tenantID := uuid.New()
+	doc := parsedDocument(tenantID)
+
+	docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("ListVersions", mock.Anything, tenantID, doc.ID).Return(nil, nil)
+
+	versions, err := svc.ListVersions(context.Background(), tenantID, doc.ID, uuid.New(), domain.RoleAdmin)
+
+	require.NoError(t, err)
+	assert.NotNil(t, versions)
+	assert.Empty(t, versions)
+}
+
+func TestDocumentService_ListApprovals(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+	tenantID, userID := uuid.New(), uuid.New()
+	doc := parsedDocument(tenantID)
+	approvals := []domain.DocumentApproval{{
+		ID: uuid.New(), TenantID: tenantID, DocumentID: doc.ID, Version: 1,
+		StructuredData: doc.StructuredData, ApprovedBy: *doc.ReviewedBy, ApprovedAt: time.Now(),
+	}}
+
+	docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, doc.CollectionID, userID).
+		Return(&domain.CollectionPermissionEntry{CollectionID: doc.CollectionID, UserID: userID, Permission: domain.CollectionPermViewer}, nil)
+	docRepo.On("ListApprovals", mock.Anything, tenantID, doc.ID).Return(approvals, nil)
+
+	got, err := svc.ListApprovals(context.Background(), tenantID, doc.ID, userID, domain.RoleViewer)
+
+	require.NoError(t, err)
+	assert.Equal(t, approvals, got)
+}
+
+func TestDocumentService_ListApprovals_PermissionDenied(t *testing.T) {
+	svc, docRepo, _, permRepo, _, _, _, _, _ := setupDocumentService()
+	tenantID, userID := uuid.New(), uuid.New()
+	doc := parsedDocument(tenantID)
+
+	docRepo.On("GetByID", mock.Anything, tenantID, doc.ID).Return(doc, nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, doc.CollectionID, userID).Return(nil, domain.ErrNotFound)
+
+	_, err := svc.ListApprovals(context.Background(), tenantID, doc.ID, userID, domain.RoleViewer)
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	docRepo.AssertNotCalled(t, "ListApprovals", mock.Anything, mock.Anything, mock.Anything)
+}
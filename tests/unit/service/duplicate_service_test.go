@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/internal/validator/invoice"
+	"satvos/mocks"
+)
+
+func parsedInvoiceDoc(t *testing.T, tenantID uuid.UUID, inv *invoice.GSTInvoice) *domain.Document {
+	t.Helper()
+	data, err := json.Marshal(inv)
+	require.NoError(t, err)
+	return &domain.Document{ID: uuid.New(), TenantID: tenantID, ParsingStatus: domain.ParsingStatusCompleted, StructuredData: data}
+}
+
+func day(s string) *time.Time {
+	d, _ := time.Parse("2006-01-02", s)
+	return &d
+}
+
+func TestDuplicateService_FindForDocument_RanksFuzzyMatches(t *testing.T) {
+	docSvc := new(mocks.MockDocumentService)
+	finder := new(mocks.MockDuplicateFinder)
+	svc := service.NewDuplicateService(docSvc, finder, nil)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	doc := parsedInvoiceDoc(t, tenantID, &invoice.GSTInvoice{
+		Invoice: invoice.InvoiceHeader{InvoiceNumber: "INV/2024/0012", InvoiceDate: "2024-06-10"},
+		Seller:  invoice.Party{GSTIN: "29ABCDE1234F1Z5"},
+		Totals:  invoice.Totals{Total: 11800},
+	})
+	exact := port.DuplicateCandidate{DocumentID: uuid.New(), InvoiceNumber: "inv-2024-0012", InvoiceDate: day("2024-06-10"), TotalAmount: 11800}
+	renumbered := port.DuplicateCandidate{DocumentID: uuid.New(), InvoiceNumber: "RS-77", InvoiceDate: day("2024-06-13"), TotalAmount: 11800.4}
+	reprefixed := port.DuplicateCandidate{DocumentID: uuid.New(), InvoiceNumber: "12", InvoiceDate: day("2024-05-01"), TotalAmount: 11500}
+	unrelated := port.DuplicateCandidate{DocumentID: uuid.New(), InvoiceNumber: "INV/2024/0345", InvoiceDate: day("2024-02-01"), TotalAmount: 900}
+
+	docSvc.On("GetByID", mock.Anything, tenantID, doc.ID, userID, domain.RoleViewer).Return(doc, nil)
+	finder.On("FindCandidates", mock.Anything, tenantID, doc.ID, "29ABCDE1234F1Z5", userID, domain.RoleViewer, 500).
+		Return([]port.DuplicateCandidate{unrelated, reprefixed, renumbered, exact}, nil)
+
+	got, err := svc.FindForDocument(context.Background(), tenantID, doc.ID, userID, domain.RoleViewer, service.DuplicateQuery{})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, exact.DocumentID, got[0].DocumentID)
+	assert.Equal(t, 1.0, got[0].Score)
+	assert.Equal(t, []string{"same_invoice_number", "same_amount", "same_date"}, got[0].Reasons)
+
+	// A resubmission under a new number is still caught by amount and date
+	assert.Equal(t, renumbered.DocumentID, got[1].DocumentID)
+	assert.Equal(t, []string{"same_amount", "close_date"}, got[1].Reasons)
+	assert.InDelta(t, 0.58, got[1].Score, 0.03)
+
+	assert.Equal(t, reprefixed.DocumentID, got[2].DocumentID)
+	assert.Equal(t, 0.9, got[2].Signals.InvoiceNumber)
+	assert.Contains(t, got[2].Reasons, "similar_invoice_number")
+}
+
+func TestDuplicateService_FindForDocument_MinScoreAndLimit(t *testing.T) {
+	docSvc := new(mocks.MockDocumentService)
+	finder := new(mocks.MockDuplicateFinder)
+	svc := service.NewDuplicateService(docSvc, finder, nil)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	doc := parsedInvoiceDoc(t, tenantID, &invoice.GSTInvoice{
+		Invoice: invoice.InvoiceHeader{InvoiceNumber: "A-1", InvoiceDate: "2024-06-10"},
+		Seller:  invoice.Party{GSTIN: "29ABCDE1234F1Z5"},
+		Totals:  invoice.Totals{Total: 500},
+	})
+	newer := port.DuplicateCandidate{DocumentID: uuid.New(), InvoiceNumber: "A-1", TotalAmount: 500}
+	older := port.DuplicateCandidate{DocumentID: uuid.New(), InvoiceNumber: "A-1", TotalAmount: 500}
+	docSvc.On("GetByID", mock.Anything, tenantID, doc.ID, userID, domain.RoleAdmin).Return(doc, nil)
+	finder.On("FindCandidates", mock.Anything, tenantID, doc.ID, "29ABCDE1234F1Z5", userID, domain.RoleAdmin, 500).
+		Return([]port.DuplicateCandidate{newer, older}, nil)
+
+	got, err := svc.FindForDocument(context.Background(), tenantID, doc.ID, userID, domain.RoleAdmin, service.DuplicateQuery{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, newer.DocumentID, got[0].DocumentID, "ties keep the newest first")
+	assert.Equal(t, 0.8, got[0].Score, "no invoice date on the candidate")
+
+	got, err = svc.FindForDocument(context.Background(), tenantID, doc.ID, userID, domain.RoleAdmin, service.DuplicateQuery{MinScore: 0.9})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestDuplicateService_FindForDocument_RequiresParsedDocument(t *testing.T) {
+	docSvc := new(mocks.MockDocumentService)
+	finder := new(mocks.MockDuplicateFinder)
+	svc := service.NewDuplicateService(docSvc, finder, nil)
+	tenantID, userID, docID := uuid.New(), uuid.New(), uuid.New()
+
+	docSvc.On("GetByID", mock.Anything, tenantID, docID, userID, domain.RoleMember).
+		Return(&domain.Document{ID: docID, ParsingStatus: domain.ParsingStatusProcessing}, nil)
+
+	_, err := svc.FindForDocument(context.Background(), tenantID, docID, userID, domain.RoleMember, service.DuplicateQuery{})
+	assert.ErrorIs(t, err, domain.ErrDocumentNotParsed)
+	finder.AssertNotCalled(t, "FindCandidates", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDuplicateService_FindForDocument_NoSellerGSTIN(t *testing.T) {
+	docSvc := new(mocks.MockDocumentService)
+	finder := new(mocks.MockDuplicateFinder)
+	svc := service.NewDuplicateService(docSvc, finder, nil)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	doc := parsedInvoiceDoc(t, tenantID, &invoice.GSTInvoice{Invoice: invoice.InvoiceHeader{InvoiceNumber: "A-1"}})
+	docSvc.On("GetByID", mock.Anything, tenantID, doc.ID, userID, domain.RoleMember).Return(doc, nil)
+
+	got, err := svc.FindForDocument(context.Background(), tenantID, doc.ID, userID, domain.RoleMember, service.DuplicateQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestDuplicateService_FindForDocument_TimestampInTenantZone(t *testing.T) {
+	docSvc := new(mocks.MockDocumentService)
+	finder := new(mocks.MockDuplicateFinder)
+	collRepo := new(mocks.MockCollectionRepo)
+	svc := service.NewDuplicateService(docSvc, finder, collRepo)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	// 20:00 UTC on 31 March is 1 April in India
+	doc := parsedInvoiceDoc(t, tenantID, &invoice.GSTInvoice{
+		Invoice: invoice.InvoiceHeader{InvoiceNumber: "INV-9", InvoiceDate: "2025-03-31T20:00:00Z"},
+		Seller:  invoice.Party{GSTIN: "29ABCDE1234F1Z5"},
+		Totals:  invoice.Totals{Total: 1000},
+	})
+	doc.CollectionID = uuid.New()
+	candidate := port.DuplicateCandidate{DocumentID: uuid.New(), InvoiceNumber: "INV-9", InvoiceDate: day("2025-04-01"), TotalAmount: 1000}
+
+	docSvc.On("GetByID", mock.Anything, tenantID, doc.ID, userID, domain.RoleViewer).Return(doc, nil)
+	finder.On("FindCandidates", mock.Anything, tenantID, doc.ID, "29ABCDE1234F1Z5", userID, domain.RoleViewer, 500).
+		Return([]port.DuplicateCandidate{candidate}, nil)
+	collRepo.On("TimeZone", mock.Anything, tenantID, doc.CollectionID).Return("Asia/Kolkata", nil)
+
+	got, err := svc.FindForDocument(context.Background(), tenantID, doc.ID, userID, domain.RoleViewer, service.DuplicateQuery{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, 1.0, got[0].Signals.Date)
+	assert.Contains(t, got[0].Reasons, "same_date")
+}
@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+const sesTopic = "arn:aws:sns:ap-south-1:111122223333:satvos-ses-feedback"
+
+type emailFeedbackDeps struct {
+	verifier     *mocks.MockSNSVerifier
+	suppressions *mocks.MockEmailSuppressionRepo
+	userRepo     *mocks.MockUserRepo
+	notifier     *mocks.MockNotifier
+}
+
+func newEmailFeedbackService() (service.EmailFeedbackService, *emailFeedbackDeps) {
+	d := &emailFeedbackDeps{
+		verifier:     new(mocks.MockSNSVerifier),
+		suppressions: new(mocks.MockEmailSuppressionRepo),
+		userRepo:     new(mocks.MockUserRepo),
+		notifier:     new(mocks.MockNotifier),
+	}
+	return service.NewEmailFeedbackService(d.verifier, d.suppressions, d.userRepo, d.notifier, sesTopic), d
+}
+
+func TestEmailFeedbackService_RejectsOtherTopic(t *testing.T) {
+	svc, d := newEmailFeedbackService()
+	d.verifier.On("Verify", mock.Anything, mock.Anything).Return(&port.SNSMessage{
+		Type: "Notification", TopicARN: "arn:aws:sns:ap-south-1:999999999999:other",
+	}, nil)
+
+	err := svc.HandleSNS(context.Background(), []byte("{}"))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidSNSMessage)
+	d.suppressions.AssertNotCalled(t, "Suppress", mock.Anything, mock.Anything)
+}
+
+func TestEmailFeedbackService_ConfirmsSubscription(t *testing.T) {
+	svc, d := newEmailFeedbackService()
+	msg := &port.SNSMessage{Type: "SubscriptionConfirmation", TopicARN: sesTopic, SubscribeURL: "https://sns.ap-south-1.amazonaws.com/?Action=ConfirmSubscription"}
+	d.verifier.On("Verify", mock.Anything, mock.Anything).Return(msg, nil)
+	d.verifier.On("ConfirmSubscription", mock.Anything, msg).Return(nil)
+
+	require.NoError(t, svc.HandleSNS(context.Background(), []byte("{}")))
+	d.verifier.AssertExpectations(t)
+}
+
+func TestEmailFeedbackService_PermanentBounceSuppressesAndAlertsAdmins(t *testing.T) {
+	svc, d := newEmailFeedbackService()
+	tenantID := uuid.New()
+	bounced := domain.User{ID: uuid.New(), TenantID: tenantID, Email: "jane@example.com", FullName: "Jane"}
+	admin := domain.User{ID: uuid.New(), TenantID: tenantID, Email: "admin@example.com", Role: domain.RoleAdmin, IsActive: true}
+	d.verifier.On("Verify", mock.Anything, mock.Anything).Return(&port.SNSMessage{
+		Type: "Notification", TopicARN: sesTopic,
+		Message: `{"notificationType":"Bounce","bounce":{"bounceType":"Permanent","bounceSubType":"General","feedbackId":"fb-1",` +
+			`"bouncedRecipients":[{"emailAddress":"Jane <jane@example.com>","diagnosticCode":"550 no such user"}]}}`,
+	}, nil)
+	d.suppressions.On("Suppress", mock.Anything, mock.MatchedBy(func(s *domain.EmailSuppression) bool {
+		return s.Email == "jane@example.com" && s.Reason == domain.EmailSuppressionBounce &&
+			s.Detail == "General: 550 no such user" && s.FeedbackID == "fb-1"
+	})).Return([]domain.User{bounced}, nil)
+	d.userRepo.On("ListByTenant", mock.Anything, tenantID, 0, mock.Anything).Return([]domain.User{bounced, admin}, 2, nil)
+	d.notifier.On("Notify", mock.Anything, mock.MatchedBy(func(n *port.Notification) bool {
+		return n.Kind == domain.NotificationKindEmailUndeliverable && n.TenantID == tenantID &&
+			len(n.Recipients) == 1 && n.Recipients[0].UserID == admin.ID
+	})).Return(nil)
+
+	require.NoError(t, svc.HandleSNS(context.Background(), []byte("{}")))
+	d.suppressions.AssertExpectations(t)
+	d.notifier.AssertExpectations(t)
+}
+
+func TestEmailFeedbackService_TransientBounceIgnored(t *testing.T) {
+	svc, d := newEmailFeedbackService()
+	d.verifier.On("Verify", mock.Anything, mock.Anything).Return(&port.SNSMessage{
+		Type: "Notification", TopicARN: sesTopic,
+		Message: `{"notificationType":"Bounce","bounce":{"bounceType":"Transient","bouncedRecipients":[{"emailAddress":"jane@example.com"}]}}`,
+	}, nil)
+
+	require.NoError(t, svc.HandleSNS(context.Background(), []byte("{}")))
+	d.suppressions.AssertNotCalled(t, "Suppress", mock.Anything, mock.Anything)
+}
+
+func TestEmailFeedbackService_ClearSuppression(t *testing.T) {
+	svc, d := newEmailFeedbackService()
+	tenantID, userID := uuid.New(), uuid.New()
+	d.userRepo.On("GetByID", mock.Anything, tenantID, userID).Return(&domain.User{ID: userID, Email: "Jane@Example.com"}, nil)
+	d.suppressions.On("Delete", mock.Anything, tenantID, "Jane@Example.com").Return(nil)
+
+	require.NoError(t, svc.ClearSuppression(context.Background(), tenantID, userID))
+	d.suppressions.AssertExpectations(t)
+}
+
+func TestEmailFeedbackService_ClearSuppression_SharedWithOtherTenant(t *testing.T) {
+	svc, d := newEmailFeedbackService()
+	tenantID, userID := uuid.New(), uuid.New()
+	d.userRepo.On("GetByID", mock.Anything, tenantID, userID).Return(&domain.User{ID: userID, Email: "jane@example.com"}, nil)
+	d.suppressions.On("Delete", mock.Anything, tenantID, "jane@example.com").Return(domain.ErrEmailSuppressionShared)
+
+	err := svc.ClearSuppression(context.Background(), tenantID, userID)
+	assert.ErrorIs(t, err, domain.ErrEmailSuppressionShared)
+}
+
+func TestSuppressingEmailSender_RefusesSuppressedAddress(t *testing.T) {
+	next := new(mocks.MockEmailSender)
+	suppressions := new(mocks.MockEmailSuppressionRepo)
+	suppressions.On("Get", mock.Anything, "jane@example.com").Return(&domain.EmailSuppression{Reason: domain.EmailSuppressionComplaint}, nil)
+	suppressions.On("Get", mock.Anything, "bob@example.com").Return(nil, domain.ErrNotFound)
+	next.On("SendPasswordResetEmail", mock.Anything, "bob@example.com", "Bob", "tok").Return(nil)
+	sender := service.NewSuppressingEmailSender(next, suppressions)
+
+	err := sender.SendVerificationEmail(context.Background(), "jane@example.com", "Jane", "tok")
+	assert.ErrorIs(t, err, domain.ErrEmailUndeliverable)
+	next.AssertNotCalled(t, "SendVerificationEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	require.NoError(t, sender.SendPasswordResetEmail(context.Background(), "bob@example.com", "Bob", "tok"))
+	next.AssertExpectations(t)
+}
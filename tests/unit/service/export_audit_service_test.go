@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type exportAuditMocks struct {
+	artifactRepo   *mocks.MockExportArtifactRepo
+	collectionRepo *mocks.MockCollectionRepo
+	collectionSvc  *mocks.MockCollectionService
+}
+
+func setupExportAuditService() (service.ExportAuditService, *exportAuditMocks) {
+	m := &exportAuditMocks{
+		artifactRepo:   new(mocks.MockExportArtifactRepo),
+		collectionRepo: new(mocks.MockCollectionRepo),
+		collectionSvc:  new(mocks.MockCollectionService),
+	}
+	return service.NewExportAuditService(m.artifactRepo, m.collectionRepo, m.collectionSvc), m
+}
+
+func TestExportAuditService_Record(t *testing.T) {
+	svc, m := setupExportAuditService()
+	tenantID, collID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	var stored *domain.ExportArtifact
+	m.artifactRepo.On("Record", mock.Anything, mock.AnythingOfType("*domain.ExportArtifact"), userID).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.ExportArtifact) }).
+		Return(nil)
+
+	artifact, err := svc.Record(context.Background(), &service.RecordExportInput{
+		TenantID: tenantID, CollectionID: collID, UserID: userID,
+		Kind: domain.ExportKindDocumentsCSV, Format: "csv",
+		RowCount: 12, SizeBytes: 4096, ChecksumSHA256: "abc123",
+	})
+
+	require.NoError(t, err)
+	assert.Same(t, stored, artifact)
+	assert.Equal(t, userID, artifact.GeneratedBy)
+	assert.Equal(t, 12, artifact.RowCount)
+	assert.Equal(t, int64(4096), artifact.SizeBytes)
+	assert.Equal(t, "abc123", artifact.ChecksumSHA256)
+	assert.JSONEq(t, `{}`, string(artifact.Filters))
+}
+
+func TestExportAuditService_Record_KeepsFilters(t *testing.T) {
+	svc, m := setupExportAuditService()
+	userID := uuid.New()
+	m.artifactRepo.On("Record", mock.Anything, mock.Anything, userID).Return(nil)
+
+	artifact, err := svc.Record(context.Background(), &service.RecordExportInput{
+		TenantID: uuid.New(), CollectionID: uuid.New(), UserID: userID,
+		Kind: domain.ExportKindValidationFailures, Format: "xlsx",
+		Filters: map[string]string{"period": "2025-09"},
+	})
+
+	require.NoError(t, err)
+	var filters map[string]string
+	require.NoError(t, json.Unmarshal(artifact.Filters, &filters))
+	assert.Equal(t, map[string]string{"period": "2025-09"}, filters)
+}
+
+func TestExportAuditService_ListByCollection(t *testing.T) {
+	svc, m := setupExportAuditService()
+	tenantID, collID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	m.collectionRepo.On("GetByID", mock.Anything, tenantID, collID).Return(&domain.Collection{ID: collID}, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collID, userID, domain.RoleViewer).Return(domain.CollectionPermViewer)
+	m.artifactRepo.On("ListByCollection", mock.Anything, tenantID, collID, "abc123", 0, 20).
+		Return([]domain.ExportArtifact{{ChecksumSHA256: "abc123"}}, 1, nil)
+
+	artifacts, total, err := svc.ListByCollection(context.Background(), tenantID, collID, userID, domain.RoleViewer, "abc123", 0, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, artifacts, 1)
+}
+
+func TestExportAuditService_ListByCollection_NoAccess(t *testing.T) {
+	svc, m := setupExportAuditService()
+	tenantID, collID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	m.collectionRepo.On("GetByID", mock.Anything, tenantID, collID).Return(&domain.Collection{ID: collID}, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collID, userID, domain.RoleFree).Return(domain.CollectionPermission(""))
+
+	_, _, err := svc.ListByCollection(context.Background(), tenantID, collID, userID, domain.RoleFree, "", 0, 20)
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	m.artifactRepo.AssertNotCalled(t, "ListByCollection", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportAuditService_ListDownloads(t *testing.T) {
+	svc, m := setupExportAuditService()
+	tenantID, collID, userID, artifactID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	m.collectionRepo.On("GetByID", mock.Anything, tenantID, collID).Return(&domain.Collection{ID: collID}, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collID, userID, domain.RoleViewer).Return(domain.CollectionPermViewer)
+	m.artifactRepo.On("GetByID", mock.Anything, tenantID, artifactID).
+		Return(&domain.ExportArtifact{ID: artifactID, CollectionID: collID}, nil)
+	m.artifactRepo.On("ListDownloads", mock.Anything, tenantID, artifactID, 0, 20).
+		Return([]domain.ExportDownload{{ArtifactID: artifactID, UserID: userID}}, 1, nil)
+
+	downloads, total, err := svc.ListDownloads(context.Background(), tenantID, collID, artifactID, userID, domain.RoleViewer, 0, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, userID, downloads[0].UserID)
+}
+
+func TestExportAuditService_ListDownloads_OtherCollection(t *testing.T) {
+	svc, m := setupExportAuditService()
+	tenantID, collID, userID, artifactID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	m.collectionRepo.On("GetByID", mock.Anything, tenantID, collID).Return(&domain.Collection{ID: collID}, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collID, userID, domain.RoleViewer).Return(domain.CollectionPermViewer)
+	m.artifactRepo.On("GetByID", mock.Anything, tenantID, artifactID).
+		Return(&domain.ExportArtifact{ID: artifactID, CollectionID: uuid.New()}, nil)
+
+	_, _, err := svc.ListDownloads(context.Background(), tenantID, collID, artifactID, userID, domain.RoleViewer, 0, 20)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	m.artifactRepo.AssertNotCalled(t, "ListDownloads", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
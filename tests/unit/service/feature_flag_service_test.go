@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestFeatureFlagService_IsEnabled_DefaultsWhenUnset(t *testing.T) {
+	repo := new(mocks.MockFeatureFlagRepo)
+	svc := service.NewFeatureFlagService(repo, time.Minute)
+	tenantID := uuid.New()
+
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.TenantFeatureFlag{}, nil)
+
+	assert.True(t, svc.IsEnabled(context.Background(), tenantID, domain.FlagDualParse))
+	assert.False(t, svc.IsEnabled(context.Background(), tenantID, domain.FlagAutoApproval))
+}
+
+func TestFeatureFlagService_IsEnabled_ExplicitOverridesDefault(t *testing.T) {
+	repo := new(mocks.MockFeatureFlagRepo)
+	svc := service.NewFeatureFlagService(repo, time.Minute)
+	tenantID := uuid.New()
+
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.TenantFeatureFlag{
+		{TenantID: tenantID, Flag: domain.FlagDualParse, Enabled: false},
+		{TenantID: tenantID, Flag: domain.FlagAutoApproval, Enabled: true},
+	}, nil)
+
+	assert.False(t, svc.IsEnabled(context.Background(), tenantID, domain.FlagDualParse))
+	assert.True(t, svc.IsEnabled(context.Background(), tenantID, domain.FlagAutoApproval))
+}
+
+func TestFeatureFlagService_IsEnabled_CachesPerTenant(t *testing.T) {
+	repo := new(mocks.MockFeatureFlagRepo)
+	svc := service.NewFeatureFlagService(repo, time.Minute)
+	tenantID := uuid.New()
+
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.TenantFeatureFlag{}, nil).Once()
+
+	svc.IsEnabled(context.Background(), tenantID, domain.FlagDualParse)
+	svc.IsEnabled(context.Background(), tenantID, domain.FlagAutoApproval)
+
+	repo.AssertNumberOfCalls(t, "ListByTenant", 1)
+}
+
+func TestFeatureFlagService_IsEnabled_RepoErrorFallsBackToDefault(t *testing.T) {
+	repo := new(mocks.MockFeatureFlagRepo)
+	svc := service.NewFeatureFlagService(repo, time.Minute)
+	tenantID := uuid.New()
+
+	repo.On("ListByTenant", mock.Anything, tenantID).Return(nil, errors.New("db down"))
+
+	assert.True(t, svc.IsEnabled(context.Background(), tenantID, domain.FlagDualParse))
+	assert.False(t, svc.IsEnabled(context.Background(), tenantID, domain.FlagWhatsAppIngestion))
+}
+
+func TestFeatureFlagService_Set_InvalidatesCache(t *testing.T) {
+	repo := new(mocks.MockFeatureFlagRepo)
+	svc := service.NewFeatureFlagService(repo, time.Minute)
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.TenantFeatureFlag{}, nil).Once()
+	assert.False(t, svc.IsEnabled(context.Background(), tenantID, domain.FlagAnomalyDetection))
+
+	repo.On("Upsert", mock.Anything, mock.MatchedBy(func(f *domain.TenantFeatureFlag) bool {
+		return f.Flag == domain.FlagAnomalyDetection && f.Enabled && *f.UpdatedBy == userID
+	})).Return(nil)
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.TenantFeatureFlag{
+		{TenantID: tenantID, Flag: domain.FlagAnomalyDetection, Enabled: true},
+	}, nil).Once()
+
+	flag, err := svc.Set(context.Background(), tenantID, domain.FlagAnomalyDetection, true, userID)
+	assert.NoError(t, err)
+	assert.True(t, flag.Overridden)
+	assert.True(t, svc.IsEnabled(context.Background(), tenantID, domain.FlagAnomalyDetection))
+}
+
+func TestFeatureFlagService_Set_UnknownFlag(t *testing.T) {
+	repo := new(mocks.MockFeatureFlagRepo)
+	svc := service.NewFeatureFlagService(repo, time.Minute)
+
+	_, err := svc.Set(context.Background(), uuid.New(), domain.FeatureFlag("nope"), true, uuid.New())
+
+	assert.ErrorIs(t, err, domain.ErrUnknownFeatureFlag)
+	repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
+
+func TestFeatureFlagService_Reset_UnknownFlag(t *testing.T) {
+	repo := new(mocks.MockFeatureFlagRepo)
+	svc := service.NewFeatureFlagService(repo, time.Minute)
+
+	err := svc.Reset(context.Background(), uuid.New(), domain.FeatureFlag("nope"))
+
+	assert.ErrorIs(t, err, domain.ErrUnknownFeatureFlag)
+}
+
+func TestFeatureFlagService_List_MergesDefaults(t *testing.T) {
+	repo := new(mocks.MockFeatureFlagRepo)
+	svc := service.NewFeatureFlagService(repo, time.Minute)
+	tenantID := uuid.New()
+
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.TenantFeatureFlag{
+		{TenantID: tenantID, Flag: domain.FlagAutoApproval, Enabled: true},
+	}, nil)
+
+	flags, err := svc.List(context.Background(), tenantID)
+
+	assert.NoError(t, err)
+	assert.Len(t, flags, len(domain.FeatureFlagDefaults))
+	for _, f := range flags {
+		if f.Flag == domain.FlagAutoApproval {
+			assert.True(t, f.Enabled)
+			assert.True(t, f.Overridden)
+		} else {
+			assert.Equal(t, domain.FeatureFlagDefaults[f.Flag], f.Enabled)
+			assert.False(t, f.Overridden)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package service_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+// checkerboard is a sharp test scan: 8-pixel black and white squares.
+func checkerboard(w, h int) image.Image {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if (x/8+y/8)%2 == 0 {
+				img.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+	return img
+}
+
+// flatGray is a featureless test scan, as blurry as it gets.
+func flatGray(w, h int) image.Image {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 128
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}))
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// textPDF builds a born-digital A4 PDF with pages pages showing text in a
+// compressed content stream.
+func textPDF(t *testing.T, pages int, text string) []byte {
+	var content bytes.Buffer
+	zw := zlib.NewWriter(&content)
+	_, err := fmt.Fprintf(zw, "BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n")
+	b.WriteString("2 0 obj << /Type /Pages /Count 1 >> endobj\n")
+	b.WriteString("4 0 obj << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> endobj\n")
+	fmt.Fprintf(&b, "5 0 obj << /Length %d /Filter /FlateDecode >> stream\n", content.Len())
+	b.Write(content.Bytes())
+	b.WriteString("\nendstream endobj\n")
+	for i := 0; i < pages; i++ {
+		b.WriteString("3 0 obj << /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >> endobj\n")
+	}
+	b.WriteString("trailer << /Root 1 0 R >>\n%%EOF")
+	return b.Bytes()
+}
+
+// scannedPDF builds an A4 PDF whose only page is a JPEG scan.
+func scannedPDF(scan []byte, w, h int) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n")
+	b.WriteString("2 0 obj << /Type /Pages /Count 1 >> endobj\n")
+	b.WriteString("3 0 obj << /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /XObject << /Im1 4 0 R >> >> >> endobj\n")
+	fmt.Fprintf(&b, "4 0 obj << /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /DCTDecode /Length %d >> stream\n", w, h, len(scan))
+	b.Write(scan)
+	b.WriteString("\nendstream endobj\ntrailer << /Root 1 0 R >>\n%%EOF")
+	return b.Bytes()
+}
+
+func runPreflight(t *testing.T, fileType domain.FileType, data []byte) *domain.FilePreflight {
+	fileRepo, storage := new(mocks.MockFileMetaRepo), new(mocks.MockObjectStorage)
+	svc := service.NewFilePreflightService(fileRepo, storage, nil)
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, fileType)
+	fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+	storage.On("Download", mock.Anything, "test-bucket", meta.S3Key).Return(data, nil)
+
+	report, err := svc.Preflight(context.Background(), &service.FilePreflightInput{
+		TenantID: tenantID, Role: domain.RoleMember, FileID: meta.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, meta.ID, report.FileID)
+	return report
+}
+
+func TestFilePreflight_TextPDFIsEasy(t *testing.T) {
+	report := runPreflight(t, domain.FileTypePDF, textPDF(t, 1, "Tax Invoice for goods supplied to Acme Traders"))
+
+	assert.True(t, report.SignatureValid)
+	assert.Equal(t, 1, report.PageCount)
+	assert.True(t, report.HasTextLayer)
+	assert.Equal(t, "en", report.Language)
+	assert.Equal(t, service.PreflightDifficultyEasy, report.Difficulty)
+	assert.True(t, report.ParseReady)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestFilePreflight_ManyPagesRaisesDifficulty(t *testing.T) {
+	report := runPreflight(t, domain.FileTypePDF, textPDF(t, 12, "Tax Invoice for goods supplied to Acme Traders"))
+
+	assert.Equal(t, 12, report.PageCount)
+	assert.Equal(t, service.PreflightDifficultyModerate, report.Difficulty)
+	assert.Equal(t, []string{service.PreflightWarningManyPages}, report.Warnings)
+}
+
+func TestFilePreflight_LowResolutionScanIsHard(t *testing.T) {
+	scan := encodeJPEG(t, checkerboard(300, 420))
+	report := runPreflight(t, domain.FileTypePDF, scannedPDF(scan, 300, 420))
+
+	assert.False(t, report.HasTextLayer)
+	assert.Equal(t, "unknown", report.Language)
+	assert.Equal(t, 36, report.EstimatedDPI) // 420 px over 842/72 in
+	require.NotNil(t, report.Sharpness)
+	assert.Equal(t, service.PreflightDifficultyHard, report.Difficulty)
+	assert.Contains(t, report.Warnings, service.PreflightWarningLowDPI)
+	assert.True(t, report.ParseReady)
+}
+
+func TestFilePreflight_BlurryPhotoIsHard(t *testing.T) {
+	report := runPreflight(t, domain.FileTypeJPG, encodeJPEG(t, flatGray(1800, 2400)))
+
+	assert.Equal(t, 1, report.PageCount)
+	assert.Equal(t, 218, report.EstimatedDPI) // 1800 px over an A4 short side
+	require.NotNil(t, report.Sharpness)
+	assert.Less(t, *report.Sharpness, 100.0)
+	assert.Equal(t, service.PreflightDifficultyHard, report.Difficulty)
+	assert.Equal(t, []string{service.PreflightWarningBlurry}, report.Warnings)
+}
+
+func TestFilePreflight_SharpScanIsModerate(t *testing.T) {
+	report := runPreflight(t, domain.FileTypePNG, encodePNG(t, checkerboard(1800, 2400)))
+
+	require.NotNil(t, report.Sharpness)
+	assert.Greater(t, *report.Sharpness, 100.0)
+	assert.Equal(t, service.PreflightDifficultyModerate, report.Difficulty)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestFilePreflight_EncryptedPDFIsUnparseable(t *testing.T) {
+	data := []byte(strings.Replace(string(pdfContent()), "trailer <<", "trailer << /Encrypt 9 0 R", 1))
+	report := runPreflight(t, domain.FileTypePDF, data)
+
+	assert.True(t, report.Encrypted)
+	assert.Equal(t, service.PreflightDifficultyUnparseable, report.Difficulty)
+	assert.False(t, report.ParseReady)
+	assert.Equal(t, []string{service.PreflightWarningEncrypted}, report.Warnings)
+}
+
+func TestFilePreflight_SignatureMismatchIsUnparseable(t *testing.T) {
+	report := runPreflight(t, domain.FileTypePDF, encodePNG(t, checkerboard(16, 16)))
+
+	assert.False(t, report.SignatureValid)
+	assert.False(t, report.ParseReady)
+	assert.Equal(t, []string{service.PreflightWarningSignatureMismatch}, report.Warnings)
+}
+
+func TestFilePreflight_FreeUserCannotCheckOthersFile(t *testing.T) {
+	fileRepo := new(mocks.MockFileMetaRepo)
+	svc := service.NewFilePreflightService(fileRepo, new(mocks.MockObjectStorage), nil)
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, domain.FileTypePDF)
+	fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+
+	_, err := svc.Preflight(context.Background(), &service.FilePreflightInput{
+		TenantID: tenantID, UserID: uuid.New(), Role: domain.RoleFree, FileID: meta.ID,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
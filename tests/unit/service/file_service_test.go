@@ -47,9 +47,11 @@ func createMultipartFile(filename string, content []byte, contentType string) (m
 	return file, form.File["file"][0]
 }
 
-// pdfContent returns minimal valid PDF bytes.
+// pdfContent returns minimal valid PDF bytes with a single page object.
 func pdfContent() []byte {
-	return []byte("%PDF-1.4 test content that is at least a few bytes long for detection purposes")
+	return []byte("%PDF-1.4\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n" +
+		"2 0 obj << /Type /Pages /Kids [3 0 R] /Count 1 >> endobj\n" +
+		"3 0 obj << /Type /Page /Parent 2 0 R >> endobj\ntrailer << /Root 1 0 R >>\n%%EOF")
 }
 
 // pngContent returns minimal valid PNG bytes (magic bytes).
@@ -63,7 +65,7 @@ func TestFileService_Upload_Success_PDF(t *testing.T) {
 	fileRepo := new(mocks.MockFileMetaRepo)
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -96,7 +98,7 @@ func TestFileService_Upload_Success_PNG(t *testing.T) {
 	fileRepo := new(mocks.MockFileMetaRepo)
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -124,7 +126,7 @@ func TestFileService_Upload_UnsupportedExtension(t *testing.T) {
 	fileRepo := new(mocks.MockFileMetaRepo)
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	file, header := createMultipartFile("malware.exe", []byte("MZ fake exe content"), "application/octet-stream")
 	defer func() { _ = file.Close() }()
@@ -145,7 +147,7 @@ func TestFileService_Upload_FileTooLarge(t *testing.T) {
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
 	cfg.MaxFileSizeMB = 1 // 1MB limit
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	// Create a file header with size exceeding limit
 	file, header := createMultipartFile("large.pdf", pdfContent(), "application/pdf")
@@ -163,11 +165,79 @@ func TestFileService_Upload_FileTooLarge(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrFileTooLarge)
 }
 
+func TestFileService_Upload_Success_TIFF(t *testing.T) {
+	fileRepo := new(mocks.MockFileMetaRepo)
+	storage := new(mocks.MockObjectStorage)
+	cfg := testS3Config()
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
+
+	tenantID := uuid.New()
+	content := append([]byte{'I', 'I', 0x2A, 0x00}, make([]byte, 100)...)
+	file, header := createMultipartFile("scan.tif", content, "image/tiff")
+	defer func() { _ = file.Close() }()
+
+	fileRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.FileMeta")).Return(nil)
+	storage.On("Upload", mock.Anything, mock.MatchedBy(func(in port.UploadInput) bool {
+		return in.ContentType == "image/tiff"
+	})).Return(&port.UploadOutput{Location: "https://s3/test", ETag: "abc"}, nil)
+	fileRepo.On("UpdateStatus", mock.Anything, tenantID, mock.AnythingOfType("uuid.UUID"), domain.FileStatusUploaded).Return(nil)
+
+	result, err := svc.Upload(context.Background(), service.FileUploadInput{
+		TenantID:   tenantID,
+		UploadedBy: uuid.New(),
+		File:       file,
+		Header:     header,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FileTypeTIFF, result.FileType)
+}
+
+func TestFileService_Upload_Rejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		content     []byte
+		contentType string
+		wantErr     error
+	}{
+		{"png content with pdf extension", "invoice.pdf", pngContent(), "application/pdf", domain.ErrFileContentMismatch},
+		{"declared type disagrees with content", "image.png", pngContent(), "image/jpeg", domain.ErrFileContentMismatch},
+		{"unknown magic bytes", "invoice.pdf", []byte("not a pdf at all"), "application/pdf", domain.ErrUnsupportedFileType},
+		{"encrypted pdf", "locked.pdf", append(pdfContent(), []byte("\ntrailer << /Root 1 0 R /Encrypt 4 0 R >>")...), "application/pdf", domain.ErrPDFEncrypted},
+		{"zero-page pdf", "empty.pdf", []byte("%PDF-1.4\n1 0 obj << /Type /Pages /Kids [] /Count 0 >> endobj\n%%EOF"), "application/pdf", domain.ErrPDFNoPages},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileRepo := new(mocks.MockFileMetaRepo)
+			storage := new(mocks.MockObjectStorage)
+			cfg := testS3Config()
+			svc := service.NewFileService(fileRepo, storage, &cfg, nil)
+
+			file, header := createMultipartFile(tt.filename, tt.content, tt.contentType)
+			defer func() { _ = file.Close() }()
+
+			result, err := svc.Upload(context.Background(), service.FileUploadInput{
+				TenantID:   uuid.New(),
+				UploadedBy: uuid.New(),
+				File:       file,
+				Header:     header,
+			})
+
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, tt.wantErr)
+			fileRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			storage.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestFileService_Upload_StorageFailure(t *testing.T) {
 	fileRepo := new(mocks.MockFileMetaRepo)
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	tenantID := uuid.New()
 	file, header := createMultipartFile("document.pdf", pdfContent(), "application/pdf")
@@ -196,7 +266,7 @@ func TestFileService_GetByID_Success(t *testing.T) {
 	fileRepo := new(mocks.MockFileMetaRepo)
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -218,7 +288,7 @@ func TestFileService_GetByID_NotFound(t *testing.T) {
 	fileRepo := new(mocks.MockFileMetaRepo)
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -235,7 +305,7 @@ func TestFileService_Delete_Success(t *testing.T) {
 	fileRepo := new(mocks.MockFileMetaRepo)
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -258,11 +328,41 @@ func TestFileService_Delete_Success(t *testing.T) {
 	storage.AssertExpectations(t)
 }
 
+func TestFileService_Delete_RemovesPageImages(t *testing.T) {
+	fileRepo := new(mocks.MockFileMetaRepo)
+	storage := new(mocks.MockObjectStorage)
+	cfg := testS3Config()
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
+
+	tenantID := uuid.New()
+	fileID := uuid.New()
+	meta := &domain.FileMeta{
+		ID:       fileID,
+		TenantID: tenantID,
+		FileType: domain.FileTypePDF,
+		S3Bucket: "test-bucket",
+		S3Key:    "tenants/test/files/f1/test.pdf",
+		Status:   domain.FileStatusUploaded,
+	}
+
+	fileRepo.On("GetByID", mock.Anything, tenantID, fileID).Return(meta, nil)
+	storage.On("Delete", mock.Anything, "test-bucket", "tenants/test/files/f1/test.pdf").Return(nil)
+	storage.On("List", mock.Anything, "test-bucket", "tenants/test/files/f1/pages/").
+		Return([]port.ObjectInfo{{Key: "tenants/test/files/f1/pages/1@150.png"}}, nil)
+	storage.On("Delete", mock.Anything, "test-bucket", "tenants/test/files/f1/pages/1@150.png").Return(nil)
+	fileRepo.On("Delete", mock.Anything, tenantID, fileID).Return(nil)
+
+	err := svc.Delete(context.Background(), tenantID, fileID)
+
+	assert.NoError(t, err)
+	storage.AssertExpectations(t)
+}
+
 func TestFileService_List_Success(t *testing.T) {
 	fileRepo := new(mocks.MockFileMetaRepo)
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	tenantID := uuid.New()
 	expected := []domain.FileMeta{
@@ -283,7 +383,7 @@ func TestFileService_GetDownloadURL_Success(t *testing.T) {
 	fileRepo := new(mocks.MockFileMetaRepo)
 	storage := new(mocks.MockObjectStorage)
 	cfg := testS3Config()
-	svc := service.NewFileService(fileRepo, storage, &cfg)
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
 
 	tenantID := uuid.New()
 	fileID := uuid.New()
@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/internal/validator/invoice"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testHSNCodes() []domain.HSNCode {
+	return []domain.HSNCode{
+		{Code: "0101", Description: "Live horses", GSTRates: []float64{0, 12}},
+		{Code: "010121", Description: "Pure-bred breeding animals", ParentCode: strPtr("0101"), GSTRates: []float64{12}},
+		{Code: "01012100", Description: "Pure-bred breeding animals", ParentCode: strPtr("0101"), GSTRates: []float64{12}},
+		{Code: "01019010", Description: "Horses for polo", ParentCode: strPtr("0101"), GSTRates: []float64{0}},
+		{Code: "9954", Description: "Construction services", GSTRates: []float64{1, 5}},
+	}
+}
+
+func TestHSNService_Tree_ChaptersAndHeadings(t *testing.T) {
+	svc := service.NewHSNService(testHSNCodes(), nil)
+
+	tree := svc.Tree(context.Background(), 2)
+
+	require.Len(t, tree, 2)
+	assert.Equal(t, "01", tree[0].Code)
+	assert.Equal(t, "chapter", tree[0].Level)
+	assert.True(t, tree[0].HasChildren)
+	require.Len(t, tree[0].Children, 1)
+	heading := tree[0].Children[0]
+	assert.Equal(t, "0101", heading.Code)
+	assert.Equal(t, []float64{0, 12}, heading.GSTRates)
+	assert.True(t, heading.HasChildren)
+	assert.Nil(t, heading.Children, "depth 2 stops at headings")
+	assert.Equal(t, "99", tree[1].Code)
+}
+
+func TestHSNService_Children_NearestExistingAncestor(t *testing.T) {
+	svc := service.NewHSNService(testHSNCodes(), nil)
+
+	heading, err := svc.Children(context.Background(), "0101")
+	require.NoError(t, err)
+	codes := make([]string, len(heading))
+	for i, n := range heading {
+		codes[i] = n.Code
+	}
+	// 01012100 sits under its subheading; 01019010 has none, so it hangs off the heading.
+	assert.Equal(t, []string{"010121", "01019010"}, codes)
+
+	sub, err := svc.Children(context.Background(), "010121")
+	require.NoError(t, err)
+	require.Len(t, sub, 1)
+	assert.Equal(t, "tariff_item", sub[0].Level)
+	assert.False(t, sub[0].HasChildren)
+}
+
+func TestHSNService_Children_UnknownCode(t *testing.T) {
+	svc := service.NewHSNService(testHSNCodes(), nil)
+
+	_, err := svc.Children(context.Background(), "0202")
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestHSNService_ProposeRates(t *testing.T) {
+	lookup := invoice.NewHSNLookup([]port.HSNEntry{{Code: "9954", GSTRate: 18}})
+	svc := service.NewHSNService(testHSNCodes(), lookup)
+
+	proposal, err := svc.ProposeRates(context.Background(), json.RawMessage(`{
+		"seller": {"state_code": "27"}, "buyer": {"state_code": "27"},
+		"line_items": [{"hsn_sac_code": "9954", "taxable_amount": 100}]
+	}`))
+
+	require.NoError(t, err)
+	require.Len(t, proposal.LineItems, 1)
+	assert.Equal(t, 9.0, proposal.LineItems[0].CGSTRate)
+	assert.Equal(t, 118.0, proposal.Totals.Total)
+	assert.Equal(t, invoice.RateApplied, proposal.Proposals[0].Status)
+}
+
+func TestHSNService_ProposeRates_InvalidStructuredData(t *testing.T) {
+	svc := service.NewHSNService(testHSNCodes(), nil)
+
+	_, err := svc.ProposeRates(context.Background(), json.RawMessage(`{"line_items": "x"}`))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidStructuredData)
+}
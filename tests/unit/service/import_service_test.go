@@ -0,0 +1,288 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type importMocks struct {
+	jobRepo       *mocks.MockImportJobRepo
+	fileSvc       *mocks.MockFileService
+	collectionSvc *mocks.MockCollectionService
+	docSvc        *mocks.MockDocumentService
+	storage       *mocks.MockObjectStorage
+}
+
+func setupImportService() (service.ImportService, *importMocks) {
+	m := &importMocks{
+		jobRepo:       new(mocks.MockImportJobRepo),
+		fileSvc:       new(mocks.MockFileService),
+		collectionSvc: new(mocks.MockCollectionService),
+		docSvc:        new(mocks.MockDocumentService),
+		storage:       new(mocks.MockObjectStorage),
+	}
+	cfg := testS3Config()
+	svc := service.NewImportService(m.jobRepo, m.fileSvc, m.collectionSvc, m.docSvc, m.storage, &cfg, nil)
+	return svc, m
+}
+
+// buildZip returns a ZIP archive containing the given name → content entries.
+func buildZip(t *testing.T, entries map[string][]byte) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// expectImportPipeline wires the per-file mocks for a successful import of one file.
+func expectImportPipeline(m *importMocks, tenantID, collectionID uuid.UUID) {
+	m.fileSvc.On("Ingest", mock.Anything, mock.AnythingOfType("service.FileIngestInput")).
+		Return(&domain.FileMeta{ID: uuid.New(), TenantID: tenantID}, nil)
+	m.collectionSvc.On("AddFileToCollection", mock.Anything, tenantID, collectionID, mock.AnythingOfType("uuid.UUID"), mock.Anything, mock.Anything).
+		Return(nil)
+	m.docSvc.On("CreateAndParse", mock.Anything, mock.AnythingOfType("*service.CreateDocumentInput")).
+		Return(&domain.Document{ID: uuid.New()}, nil)
+}
+
+func waitFor(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("import did not finish")
+	}
+}
+
+func TestImportService_ImportZip_Success(t *testing.T) {
+	svc, m := setupImportService()
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	archive := buildZip(t, map[string][]byte{
+		"invoices/inv-1.pdf":      pdfContent(),
+		"invoices/notes.txt":      []byte("not an invoice"),
+		"__MACOSX/._inv-1.pdf":    []byte("resource fork"),
+		"invoices/.DS_Store":      []byte("finder"),
+		"invoices/empty-file.pdf": []byte("%PDF-1.4\n%%EOF"),
+	})
+	file, header := createMultipartFile("batch.zip", archive, "application/zip")
+	defer func() { _ = file.Close() }()
+
+	var job *domain.ImportJob
+	done := make(chan struct{})
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleMember).
+		Return(domain.CollectionPermEditor)
+	m.storage.On("Upload", mock.Anything, mock.AnythingOfType("port.UploadInput")).
+		Return(&port.UploadOutput{}, nil)
+	m.jobRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.ImportJob")).
+		Run(func(args mock.Arguments) { job = args.Get(1).(*domain.ImportJob) }).Return(nil)
+	m.jobRepo.On("UpdateProgress", mock.Anything, mock.AnythingOfType("*domain.ImportJob")).Return(nil)
+	m.storage.On("Download", mock.Anything, "test-bucket", mock.AnythingOfType("string")).Return(archive, nil)
+	m.fileSvc.On("Ingest", mock.Anything, mock.MatchedBy(func(in service.FileIngestInput) bool {
+		return in.FileName == "empty-file.pdf"
+	})).Return(nil, domain.ErrPDFNoPages)
+	expectImportPipeline(m, tenantID, collectionID)
+	m.storage.On("Delete", mock.Anything, "test-bucket", mock.AnythingOfType("string")).
+		Run(func(mock.Arguments) { close(done) }).Return(nil)
+
+	result, err := svc.ImportZip(context.Background(), &service.ZipImportInput{
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		UserID:       userID,
+		Role:         domain.RoleMember,
+		DocumentType: "invoice",
+		File:         file,
+		Header:       header,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ImportStatusPending, result.Status)
+	assert.Equal(t, domain.ParseModeSingle, result.ParseMode)
+	waitFor(t, done)
+
+	assert.Equal(t, domain.ImportStatusCompleted, job.Status)
+	assert.Equal(t, 3, job.TotalEntries)
+	assert.Equal(t, 1, job.ImportedCount)
+	assert.Equal(t, 2, job.SkippedCount)
+	assert.Equal(t, 0, job.FailedCount)
+	assert.NotNil(t, job.CompletedAt)
+
+	var entries []domain.ImportEntry
+	require.NoError(t, json.Unmarshal(job.Entries, &entries))
+	byName := make(map[string]domain.ImportEntry, len(entries))
+	for _, e := range entries {
+		byName[e.Name] = e
+	}
+	assert.Equal(t, domain.ImportEntryImported, byName["invoices/inv-1.pdf"].Status)
+	assert.NotNil(t, byName["invoices/inv-1.pdf"].DocumentID)
+	assert.Equal(t, domain.ImportEntrySkipped, byName["invoices/notes.txt"].Status)
+	assert.Equal(t, domain.ImportEntrySkipped, byName["invoices/empty-file.pdf"].Status)
+	assert.NotContains(t, byName, "__MACOSX/._inv-1.pdf")
+}
+
+func TestImportService_ImportZip_DocumentCreateFails(t *testing.T) {
+	svc, m := setupImportService()
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	archive := buildZip(t, map[string][]byte{"inv.pdf": pdfContent()})
+	file, header := createMultipartFile("batch.zip", archive, "application/zip")
+	defer func() { _ = file.Close() }()
+
+	var job *domain.ImportJob
+	done := make(chan struct{})
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleAdmin).
+		Return(domain.CollectionPermOwner)
+	m.storage.On("Upload", mock.Anything, mock.Anything).Return(&port.UploadOutput{}, nil)
+	m.jobRepo.On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { job = args.Get(1).(*domain.ImportJob) }).Return(nil)
+	m.jobRepo.On("UpdateProgress", mock.Anything, mock.Anything).Return(nil)
+	m.storage.On("Download", mock.Anything, mock.Anything, mock.Anything).Return(archive, nil)
+	m.fileSvc.On("Ingest", mock.Anything, mock.Anything).Return(&domain.FileMeta{ID: uuid.New()}, nil)
+	m.collectionSvc.On("AddFileToCollection", mock.Anything, tenantID, collectionID, mock.Anything, userID, domain.RoleAdmin).Return(nil)
+	m.docSvc.On("CreateAndParse", mock.Anything, mock.Anything).Return(nil, domain.ErrQuotaExceeded)
+	m.storage.On("Delete", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { close(done) }).Return(nil)
+
+	_, err := svc.ImportZip(context.Background(), &service.ZipImportInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleAdmin,
+		DocumentType: "invoice", File: file, Header: header,
+	})
+	require.NoError(t, err)
+	waitFor(t, done)
+
+	assert.Equal(t, domain.ImportStatusCompleted, job.Status)
+	assert.Equal(t, 1, job.FailedCount)
+	assert.Contains(t, string(job.Entries), "quota")
+}
+
+func TestImportService_ImportZip_NotAnArchive(t *testing.T) {
+	svc, m := setupImportService()
+	collectionID, userID := uuid.New(), uuid.New()
+
+	file, header := createMultipartFile("batch.zip", pdfContent(), "application/zip")
+	defer func() { _ = file.Close() }()
+
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleAdmin).
+		Return(domain.CollectionPermOwner)
+
+	result, err := svc.ImportZip(context.Background(), &service.ZipImportInput{
+		TenantID: uuid.New(), CollectionID: collectionID, UserID: userID, Role: domain.RoleAdmin,
+		DocumentType: "invoice", File: file, Header: header,
+	})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidArchive)
+	m.storage.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything)
+}
+
+func TestImportService_ImportZip_PermissionDenied(t *testing.T) {
+	svc, m := setupImportService()
+	collectionID, userID := uuid.New(), uuid.New()
+
+	file, header := createMultipartFile("batch.zip", buildZip(t, nil), "application/zip")
+	defer func() { _ = file.Close() }()
+
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleMember).
+		Return(domain.CollectionPermViewer)
+
+	result, err := svc.ImportZip(context.Background(), &service.ZipImportInput{
+		TenantID: uuid.New(), CollectionID: collectionID, UserID: userID, Role: domain.RoleMember,
+		DocumentType: "invoice", File: file, Header: header,
+	})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+}
+
+func TestImportService_ImportS3Prefix_Success(t *testing.T) {
+	svc, m := setupImportService()
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+	inbox := "tenants/" + tenantID.String() + "/inbox/"
+
+	var job *domain.ImportJob
+	done := make(chan struct{})
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleManager).
+		Return(domain.CollectionPermEditor)
+	m.jobRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.ImportJob")).
+		Run(func(args mock.Arguments) { job = args.Get(1).(*domain.ImportJob) }).Return(nil)
+	m.jobRepo.On("UpdateProgress", mock.Anything, mock.AnythingOfType("*domain.ImportJob")).
+		Run(func(args mock.Arguments) {
+			if args.Get(1).(*domain.ImportJob).Status == domain.ImportStatusCompleted {
+				close(done)
+			}
+		}).Return(nil)
+	m.storage.On("List", mock.Anything, "test-bucket", inbox+"2024-10/").Return([]port.ObjectInfo{
+		{Key: inbox + "2024-10/", Size: 0},
+		{Key: inbox + "2024-10/a.pdf", Size: 200},
+		{Key: inbox + "2024-10/b.docx", Size: 200},
+	}, nil)
+	m.storage.On("Download", mock.Anything, "test-bucket", inbox+"2024-10/a.pdf").Return(pdfContent(), nil)
+	expectImportPipeline(m, tenantID, collectionID)
+
+	result, err := svc.ImportS3Prefix(context.Background(), &service.S3ImportInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleManager,
+		DocumentType: "invoice", Prefix: "/2024-10/",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, inbox+"2024-10/", result.SourceRef)
+	waitFor(t, done)
+
+	assert.Equal(t, 2, job.TotalEntries)
+	assert.Equal(t, 1, job.ImportedCount)
+	assert.Equal(t, 1, job.SkippedCount)
+	assert.Contains(t, string(job.Entries), "2024-10/b.docx")
+	m.storage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportService_ImportS3Prefix_RejectsTraversal(t *testing.T) {
+	svc, m := setupImportService()
+	collectionID, userID := uuid.New(), uuid.New()
+
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleAdmin).
+		Return(domain.CollectionPermOwner)
+
+	result, err := svc.ImportS3Prefix(context.Background(), &service.S3ImportInput{
+		TenantID: uuid.New(), CollectionID: collectionID, UserID: userID, Role: domain.RoleAdmin,
+		DocumentType: "invoice", Prefix: "../" + uuid.New().String() + "/inbox/",
+	})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrInvalidImportPrefix)
+	m.jobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestImportService_GetJob_OtherCollection(t *testing.T) {
+	svc, m := setupImportService()
+	tenantID, collectionID, userID, jobID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleViewer).
+		Return(domain.CollectionPermViewer)
+	m.jobRepo.On("GetByID", mock.Anything, tenantID, jobID).
+		Return(&domain.ImportJob{ID: jobID, TenantID: tenantID, CollectionID: uuid.New()}, nil)
+
+	result, err := svc.GetJob(context.Background(), tenantID, collectionID, jobID, userID, domain.RoleViewer)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,442 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type integrationMocks struct {
+	repo           *mocks.MockIntegrationRepo
+	userRepo       *mocks.MockUserRepo
+	permRepo       *mocks.MockCollectionPermissionRepo
+	collectionRepo *mocks.MockCollectionRepo
+}
+
+func setupIntegrationService(client *http.Client, allowedHosts []string) (*integrationMocks, service.IntegrationService) {
+	m := &integrationMocks{
+		repo:           new(mocks.MockIntegrationRepo),
+		userRepo:       new(mocks.MockUserRepo),
+		permRepo:       new(mocks.MockCollectionPermissionRepo),
+		collectionRepo: new(mocks.MockCollectionRepo),
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return m, service.NewIntegrationService(m.repo, m.userRepo, m.permRepo, m.collectionRepo, client, allowedHosts)
+}
+
+func approvedDoc(tenantID, collectionID uuid.UUID, reviewedAt time.Time) domain.Document {
+	return domain.Document{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		CollectionID: collectionID,
+		Name:         "invoice.pdf",
+		ReviewStatus: domain.ReviewStatusApproved,
+		ReviewedAt:   &reviewedAt,
+		StructuredData: json.RawMessage(`{
+			"invoice": {"invoice_number": "INV-7", "invoice_date": "2026-01-05"},
+			"seller": {"name": "Acme Traders", "gstin": "29ABCDE1234F1Z5"},
+			"buyer": {"name": "Globex"},
+			"line_items": [{"description": "Widget", "quantity": 2, "total": 118}],
+			"totals": {"taxable_amount": 100, "cgst": 9, "sgst": 9, "total": 118}
+		}`),
+	}
+}
+
+func TestFlattenDocument(t *testing.T) {
+	doc := approvedDoc(uuid.New(), uuid.New(), time.Now())
+
+	flat := service.FlattenDocument(&doc)
+
+	assert.Equal(t, doc.ID, flat.ID)
+	assert.Equal(t, "approved", flat.ReviewStatus)
+	assert.Equal(t, "INV-7", flat.InvoiceNumber)
+	assert.Equal(t, "Acme Traders", flat.SellerName)
+	assert.Equal(t, "29ABCDE1234F1Z5", flat.SellerGSTIN)
+	assert.Equal(t, "Globex", flat.BuyerName)
+	assert.InDelta(t, 118.0, flat.Total, 0.001)
+	require.Len(t, flat.LineItems, 1)
+	assert.Equal(t, 1, flat.LineItems[0].LineNumber)
+	assert.Equal(t, "Widget", flat.LineItems[0].Description)
+	assert.Equal(t, 1, flat.LineItemCount)
+}
+
+func TestFlattenDocument_NoStructuredData(t *testing.T) {
+	doc := domain.Document{ID: uuid.New(), Name: "scan.pdf"}
+
+	flat := service.FlattenDocument(&doc)
+
+	assert.Empty(t, flat.InvoiceNumber)
+	assert.NotNil(t, flat.LineItems)
+	assert.Empty(t, flat.LineItems)
+}
+
+func TestIntegrationService_ListApproved_NoCursorReturnsNewestFirst(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+	ctx := context.Background()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now().UTC()
+	newest := approvedDoc(tenantID, collectionID, now)
+	older := approvedDoc(tenantID, collectionID, now.Add(-time.Hour))
+
+	m.repo.On("ListRecentlyApproved", ctx, tenantID, userID, false, (*uuid.UUID)(nil), 50).
+		Return([]domain.Document{newest, older}, nil)
+
+	page, err := svc.ListApproved(ctx, &service.ListApprovedInput{TenantID: tenantID, UserID: userID, Role: domain.RoleMember})
+
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, newest.ID, page.Items[0].ID)
+
+	// The cursor continues after the newest document
+	m.repo.On("ListApprovedSince", ctx, tenantID, userID, false, (*uuid.UUID)(nil),
+		mock.MatchedBy(func(at time.Time) bool { return at.Equal(now) }), newest.ID, 50).
+		Return([]domain.Document{}, nil)
+
+	next, err := svc.ListApproved(ctx, &service.ListApprovedInput{
+		TenantID: tenantID, UserID: userID, Role: domain.RoleMember, Cursor: page.NextCursor,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, next.Items)
+	assert.Equal(t, page.NextCursor, next.NextCursor)
+	m.repo.AssertExpectations(t)
+}
+
+func TestIntegrationService_ListApproved_CursorAdvancesToLastItem(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+	ctx := context.Background()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now().UTC()
+	first := approvedDoc(tenantID, collectionID, now.Add(-time.Minute))
+	last := approvedDoc(tenantID, collectionID, now)
+	cursor := base64.RawURLEncoding.EncodeToString([]byte(now.Add(-time.Hour).Format(time.RFC3339Nano) + "|" + uuid.New().String()))
+
+	m.repo.On("ListApprovedSince", ctx, tenantID, userID, true, (*uuid.UUID)(nil),
+		mock.Anything, mock.Anything, 10).Return([]domain.Document{first, last}, nil)
+
+	page, err := svc.ListApproved(ctx, &service.ListApprovedInput{
+		TenantID: tenantID, UserID: userID, Role: domain.RoleViewer, Cursor: cursor, Limit: 10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	raw, err := base64.RawURLEncoding.DecodeString(page.NextCursor)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), last.ID.String())
+	m.repo.AssertExpectations(t)
+}
+
+func TestIntegrationService_ListApproved_InvalidCursor(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+
+	_, err := svc.ListApproved(context.Background(), &service.ListApprovedInput{
+		TenantID: uuid.New(), UserID: uuid.New(), Role: domain.RoleAdmin, Cursor: "not-a-cursor",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	m.repo.AssertNotCalled(t, "ListApprovedSince")
+}
+
+func TestIntegrationService_ListApproved_CollectionWithoutAccess(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+	ctx := context.Background()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	m.collectionRepo.On("GetByID", ctx, tenantID, collectionID).Return(&domain.Collection{ID: collectionID}, nil)
+	m.permRepo.On("GetByCollectionAndUser", ctx, collectionID, userID).Return(nil, domain.ErrNotFound)
+
+	_, err := svc.ListApproved(ctx, &service.ListApprovedInput{
+		TenantID: tenantID, UserID: userID, Role: domain.RoleViewer, CollectionID: &collectionID,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+}
+
+func TestIntegrationService_Subscribe(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+	ctx := context.Background()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	m.repo.On("CreateHook", ctx, mock.AnythingOfType("*domain.IntegrationHook")).Return(nil)
+
+	hook, err := svc.Subscribe(ctx, &service.SubscribeHookInput{
+		TenantID: tenantID, UserID: userID, Role: domain.RoleMember,
+		TargetURL: "https://hooks.zapier.com/hooks/standard/123", Event: domain.IntegrationEventDocumentApproved,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, tenantID, hook.TenantID)
+	assert.Equal(t, userID, hook.UserID)
+	assert.NotEqual(t, uuid.Nil, hook.ID)
+	m.repo.AssertExpectations(t)
+}
+
+func TestIntegrationService_Subscribe_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		event   domain.IntegrationEvent
+		allowed []string
+		wantErr error
+	}{
+		{"unknown event", "https://hooks.zapier.com/x", "document.deleted", nil, domain.ErrInvalidHookEvent},
+		{"plain http", "http://hooks.zapier.com/x", domain.IntegrationEventDocumentApproved, nil, domain.ErrInvalidHookTarget},
+		{"ip literal", "https://10.0.0.5/x", domain.IntegrationEventDocumentApproved, nil, domain.ErrInvalidHookTarget},
+		{"localhost", "https://localhost:8443/x", domain.IntegrationEventDocumentApproved, nil, domain.ErrInvalidHookTarget},
+		{"credentials", "https://user:pw@hooks.zapier.com/x", domain.IntegrationEventDocumentApproved, nil, domain.ErrInvalidHookTarget},
+		{"not allowlisted", "https://example.com/x", domain.IntegrationEventDocumentApproved, []string{"hooks.zapier.com"}, domain.ErrInvalidHookTarget},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := setupIntegrationService(nil, tt.allowed)
+
+			_, err := svc.Subscribe(context.Background(), &service.SubscribeHookInput{
+				TenantID: uuid.New(), UserID: uuid.New(), Role: domain.RoleAdmin, TargetURL: tt.target, Event: tt.event,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			m.repo.AssertNotCalled(t, "CreateHook", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestIntegrationService_Subscribe_PayloadTemplate(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+	ctx := context.Background()
+	tmpl := `{"vendor": {{json .SellerName}}, "amount": {{.Total}}}`
+
+	m.repo.On("CreateHook", ctx, mock.AnythingOfType("*domain.IntegrationHook")).Return(nil)
+
+	hook, err := svc.Subscribe(ctx, &service.SubscribeHookInput{
+		TenantID: uuid.New(), UserID: uuid.New(), Role: domain.RoleMember,
+		TargetURL: "https://erp.example.com/invoices", Event: domain.IntegrationEventDocumentApproved,
+		PayloadTemplate: &tmpl,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, hook.PayloadTemplate)
+	assert.Equal(t, tmpl, *hook.PayloadTemplate)
+}
+
+func TestIntegrationService_Subscribe_InvalidPayloadTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+	}{
+		{"does not parse", `{"vendor": {{.SellerName}`},
+		{"unknown field", `{"vendor": {{json .Vendor}}}`},
+		{"not json", `vendor={{.SellerName}}`},
+		{"unquoted string", `{"vendor": {{.SellerName}}}`},
+		{"blank", "   "},
+		{"too long", `{"notes": "` + strings.Repeat("x", 8000) + `"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := setupIntegrationService(nil, nil)
+
+			_, err := svc.Subscribe(context.Background(), &service.SubscribeHookInput{
+				TenantID: uuid.New(), UserID: uuid.New(), Role: domain.RoleMember,
+				TargetURL: "https://erp.example.com/invoices", Event: domain.IntegrationEventDocumentApproved,
+				PayloadTemplate: &tt.tmpl,
+			})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidHookTemplate)
+			m.repo.AssertNotCalled(t, "CreateHook", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestIntegrationService_SetHookTemplate(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+	ctx := context.Background()
+	tenantID, hookID, userID := uuid.New(), uuid.New(), uuid.New()
+	tmpl := `{"bill_no": {{json .InvoiceNumber}}}`
+
+	m.repo.On("GetHook", ctx, tenantID, hookID).Return(&domain.IntegrationHook{ID: hookID, TenantID: tenantID, UserID: userID}, nil)
+	m.repo.On("UpdateHookTemplate", ctx, tenantID, hookID, &tmpl).Return(nil)
+
+	hook, err := svc.SetHookTemplate(ctx, tenantID, hookID, userID, domain.RoleMember, &service.SetHookTemplateInput{PayloadTemplate: &tmpl})
+
+	require.NoError(t, err)
+	assert.Equal(t, &tmpl, hook.PayloadTemplate)
+	m.repo.AssertExpectations(t)
+}
+
+func TestIntegrationService_SetHookTemplate_EmptyClears(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+	ctx := context.Background()
+	tenantID, hookID, userID := uuid.New(), uuid.New(), uuid.New()
+	old, empty := `{"a": 1}`, ""
+
+	m.repo.On("GetHook", ctx, tenantID, hookID).Return(&domain.IntegrationHook{ID: hookID, TenantID: tenantID, UserID: userID, PayloadTemplate: &old}, nil)
+	m.repo.On("UpdateHookTemplate", ctx, tenantID, hookID, (*string)(nil)).Return(nil)
+
+	hook, err := svc.SetHookTemplate(ctx, tenantID, hookID, userID, domain.RoleMember, &service.SetHookTemplateInput{PayloadTemplate: &empty})
+
+	require.NoError(t, err)
+	assert.Nil(t, hook.PayloadTemplate)
+}
+
+func TestIntegrationService_SetHookTemplate_OtherUsersHook(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+	ctx := context.Background()
+	tenantID, hookID := uuid.New(), uuid.New()
+	tmpl := `{"a": 1}`
+
+	m.repo.On("GetHook", ctx, tenantID, hookID).Return(&domain.IntegrationHook{ID: hookID, TenantID: tenantID, UserID: uuid.New()}, nil)
+
+	_, err := svc.SetHookTemplate(ctx, tenantID, hookID, uuid.New(), domain.RoleManager, &service.SetHookTemplateInput{PayloadTemplate: &tmpl})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	m.repo.AssertNotCalled(t, "UpdateHookTemplate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIntegrationService_Unsubscribe_OtherUsersHook(t *testing.T) {
+	m, svc := setupIntegrationService(nil, nil)
+	ctx := context.Background()
+	tenantID, hookID := uuid.New(), uuid.New()
+
+	m.repo.On("GetHook", ctx, tenantID, hookID).Return(&domain.IntegrationHook{ID: hookID, TenantID: tenantID, UserID: uuid.New()}, nil)
+
+	err := svc.Unsubscribe(ctx, tenantID, hookID, uuid.New(), domain.RoleManager)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	m.repo.AssertNotCalled(t, "DeleteHook", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIntegrationService_DeliverApproved(t *testing.T) {
+	var received atomic.Int32
+	var gotBody service.FlatDocument
+	var gotEvent string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		gotEvent = r.Header.Get("X-Satvos-Event")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	m, svc := setupIntegrationService(ts.Client(), nil)
+	ctx := context.Background()
+	tenantID, collectionID := uuid.New(), uuid.New()
+	doc := approvedDoc(tenantID, collectionID, time.Now())
+	owner := &domain.User{ID: uuid.New(), TenantID: tenantID, Role: domain.RoleMember, IsActive: true}
+	viewer := &domain.User{ID: uuid.New(), TenantID: tenantID, Role: domain.RoleViewer, IsActive: true}
+	otherCollection := uuid.New()
+
+	m.repo.On("ListHooksForEvent", ctx, tenantID, domain.IntegrationEventDocumentApproved).Return([]domain.IntegrationHook{
+		{ID: uuid.New(), TenantID: tenantID, UserID: owner.ID, Event: domain.IntegrationEventDocumentApproved, TargetURL: ts.URL},
+		{ID: uuid.New(), TenantID: tenantID, UserID: owner.ID, Event: domain.IntegrationEventDocumentApproved, TargetURL: ts.URL, CollectionID: &otherCollection},
+		{ID: uuid.New(), TenantID: tenantID, UserID: viewer.ID, Event: domain.IntegrationEventDocumentApproved, TargetURL: ts.URL},
+	}, nil)
+	m.userRepo.On("GetByID", ctx, tenantID, owner.ID).Return(owner, nil)
+	m.userRepo.On("GetByID", ctx, tenantID, viewer.ID).Return(viewer, nil)
+	m.permRepo.On("GetByCollectionAndUser", ctx, collectionID, viewer.ID).Return(nil, domain.ErrNotFound)
+
+	err := svc.DeliverApproved(ctx, &doc)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), received.Load())
+	assert.Equal(t, "document.approved", gotEvent)
+	assert.Equal(t, doc.ID, gotBody.ID)
+	assert.Equal(t, "INV-7", gotBody.InvoiceNumber)
+}
+
+func TestIntegrationService_DeliverApproved_GoneDeletesHook(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer ts.Close()
+
+	m, svc := setupIntegrationService(ts.Client(), nil)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	doc := approvedDoc(tenantID, uuid.New(), time.Now())
+	owner := &domain.User{ID: uuid.New(), TenantID: tenantID, Role: domain.RoleAdmin, IsActive: true}
+	hookID := uuid.New()
+
+	m.repo.On("ListHooksForEvent", ctx, tenantID, domain.IntegrationEventDocumentApproved).Return([]domain.IntegrationHook{
+		{ID: hookID, TenantID: tenantID, UserID: owner.ID, Event: domain.IntegrationEventDocumentApproved, TargetURL: ts.URL},
+	}, nil)
+	m.userRepo.On("GetByID", ctx, tenantID, owner.ID).Return(owner, nil)
+	m.repo.On("DeleteHook", ctx, tenantID, hookID).Return(nil)
+
+	err := svc.DeliverApproved(ctx, &doc)
+
+	require.NoError(t, err)
+	m.repo.AssertExpectations(t)
+}
+
+func TestIntegrationService_DeliverApproved_PayloadTemplate(t *testing.T) {
+	var received atomic.Int32
+	var gotBody map[string]any
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	m, svc := setupIntegrationService(ts.Client(), nil)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	doc := approvedDoc(tenantID, uuid.New(), time.Now())
+	doc.StructuredData = json.RawMessage(`{"invoice": {"invoice_number": "INV-7"}, "seller": {"name": "Acme Traders"}, "totals": {"total": 118}}`)
+	owner := &domain.User{ID: uuid.New(), TenantID: tenantID, Role: domain.RoleAdmin, IsActive: true}
+	tmpl := `{"vendor": {{json .SellerName}}, "bill_no": {{json .InvoiceNumber}}, "amount": {{.Total}},
+		"lines": [{{range $i, $li := .LineItems}}{{if $i}},{{end}}{"item": {{json $li.Description}}}{{end}}]}`
+	// Valid against the one-line sample, but fails on an invoice without lines
+	failing := `{"item": {{json (index .LineItems 0).Description}}}`
+
+	m.repo.On("ListHooksForEvent", ctx, tenantID, domain.IntegrationEventDocumentApproved).Return([]domain.IntegrationHook{
+		{ID: uuid.New(), TenantID: tenantID, UserID: owner.ID, Event: domain.IntegrationEventDocumentApproved, TargetURL: ts.URL, PayloadTemplate: &tmpl},
+		{ID: uuid.New(), TenantID: tenantID, UserID: owner.ID, Event: domain.IntegrationEventDocumentApproved, TargetURL: ts.URL, PayloadTemplate: &failing},
+	}, nil)
+	m.userRepo.On("GetByID", ctx, tenantID, owner.ID).Return(owner, nil)
+
+	err := svc.DeliverApproved(ctx, &doc)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rendering payload template")
+	assert.Equal(t, int32(1), received.Load())
+	assert.Equal(t, map[string]any{
+		"vendor":  "Acme Traders",
+		"bill_no": "INV-7",
+		"amount":  float64(118),
+		"lines":   []any{},
+	}, gotBody)
+}
+
+func TestNotifyApprovedListener_NotifiesOnApproval(t *testing.T) {
+	integrations := new(mocks.MockIntegrationService)
+	events := service.NewDocumentEvents()
+	events.Subscribe("rest_hooks", service.NotifyApprovedListener(integrations), service.DocumentEventReviewed)
+	ctx := context.Background()
+
+	approved := &domain.Document{ID: uuid.New(), ReviewStatus: domain.ReviewStatusApproved}
+	rejected := &domain.Document{ID: uuid.New(), ReviewStatus: domain.ReviewStatusRejected}
+	integrations.On("NotifyApproved", approved).Return()
+
+	events.Publish(ctx, &service.DocumentEvent{Kind: service.DocumentEventReviewed, Document: approved})
+	events.Publish(ctx, &service.DocumentEvent{Kind: service.DocumentEventReviewed, Document: rejected})
+
+	integrations.AssertExpectations(t)
+	integrations.AssertNumberOfCalls(t, "NotifyApproved", 1)
+}
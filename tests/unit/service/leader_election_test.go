@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+// runElection starts the election, lets it run for d and then shuts it down.
+func runElection(election *service.LeaderElection, d time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		election.Start(ctx)
+		close(done)
+	}()
+	time.Sleep(d)
+	cancel()
+	<-done
+}
+
+func TestLeaderElection_StandbyNeverRunsWork(t *testing.T) {
+	lock := new(mocks.MockLeaderLock)
+	lock.On("TryAcquire", mock.Anything).Return(false, nil)
+
+	var runs atomic.Int32
+	election := service.NewLeaderElection("test", lock, 20*time.Millisecond, func(context.Context) { runs.Add(1) })
+	runElection(election, 100*time.Millisecond)
+
+	assert.Zero(t, runs.Load())
+	assert.Greater(t, len(lock.Calls), 1, "standby should keep retrying")
+	lock.AssertNotCalled(t, "Release", mock.Anything)
+}
+
+func TestLeaderElection_LeaderRunsWorkUntilShutdown(t *testing.T) {
+	lock := new(mocks.MockLeaderLock)
+	lock.On("TryAcquire", mock.Anything).Return(true, nil).Once()
+	lock.On("Check", mock.Anything).Return(nil)
+	lock.On("Release", mock.Anything).Return(nil).Once()
+
+	var runs, stopped atomic.Int32
+	election := service.NewLeaderElection("test", lock, 20*time.Millisecond, func(ctx context.Context) {
+		runs.Add(1)
+		<-ctx.Done()
+		stopped.Add(1)
+	})
+	runElection(election, 100*time.Millisecond)
+
+	assert.Equal(t, int32(1), runs.Load())
+	assert.Equal(t, int32(1), stopped.Load(), "work must have returned before Start does")
+	lock.AssertExpectations(t)
+}
+
+func TestLeaderElection_LostLockStopsWorkAndStandsBy(t *testing.T) {
+	lock := new(mocks.MockLeaderLock)
+	lock.On("TryAcquire", mock.Anything).Return(true, nil).Once()
+	lock.On("TryAcquire", mock.Anything).Return(false, nil)
+	lock.On("Check", mock.Anything).Return(errors.New("connection reset")).Once()
+	lock.On("Release", mock.Anything).Return(nil).Once()
+
+	var runs atomic.Int32
+	election := service.NewLeaderElection("test", lock, 20*time.Millisecond, func(ctx context.Context) {
+		runs.Add(1)
+		<-ctx.Done()
+	})
+	runElection(election, 150*time.Millisecond)
+
+	assert.Equal(t, int32(1), runs.Load())
+	lock.AssertExpectations(t)
+	lock.AssertNumberOfCalls(t, "Check", 1)
+}
+
+func TestLeaderElection_WorkReturningReleasesLock(t *testing.T) {
+	lock := new(mocks.MockLeaderLock)
+	lock.On("TryAcquire", mock.Anything).Return(true, nil)
+	lock.On("Check", mock.Anything).Return(nil).Maybe()
+	lock.On("Release", mock.Anything).Return(nil)
+
+	var runs atomic.Int32
+	election := service.NewLeaderElection("test", lock, 20*time.Millisecond, func(context.Context) { runs.Add(1) })
+	runElection(election, 100*time.Millisecond)
+
+	// Each term ends when work returns, so the lock is released and taken again
+	assert.Greater(t, runs.Load(), int32(1))
+	lock.AssertNumberOfCalls(t, "Release", int(runs.Load()))
+}
+
+func TestLeaderElection_AcquireErrorKeepsRetrying(t *testing.T) {
+	lock := new(mocks.MockLeaderLock)
+	lock.On("TryAcquire", mock.Anything).Return(false, errors.New("database down"))
+
+	var runs atomic.Int32
+	election := service.NewLeaderElection("test", lock, 20*time.Millisecond, func(context.Context) { runs.Add(1) })
+	runElection(election, 100*time.Millisecond)
+
+	assert.Zero(t, runs.Load())
+	assert.Greater(t, len(lock.Calls), 1)
+}
+
+func TestLeaderElection_NonPositiveIntervalUsesDefault(t *testing.T) {
+	lock := new(mocks.MockLeaderLock)
+	lock.On("TryAcquire", mock.Anything).Return(false, nil)
+
+	election := service.NewLeaderElection("test", lock, 0, func(context.Context) {})
+	assert.NotPanics(t, func() { runElection(election, 20*time.Millisecond) })
+	lock.AssertNumberOfCalls(t, "TryAcquire", 1)
+}
@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/notify"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func setupNotificationRouteService() (service.NotificationRouteService, *mocks.MockTenantRepo, *mocks.MockNotificationRouteRepo) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	routeRepo := new(mocks.MockNotificationRouteRepo)
+	router := notify.NewRouter(
+		map[domain.NotifierChannel]port.Notifier{
+			domain.NotifierChannelEmail: new(mocks.MockNotifier),
+			domain.NotifierChannelSlack: new(mocks.MockNotifier),
+		},
+		map[domain.NotificationKind][]domain.NotifierChannel{
+			domain.NotificationKindIngestionReport: {domain.NotifierChannelEmail, domain.NotifierChannelSlack},
+		},
+		routeRepo)
+	return service.NewNotificationRouteService(tenantRepo, routeRepo, router), tenantRepo, routeRepo
+}
+
+func TestNotificationRouteService_Get_MergesDefaultsAndOverrides(t *testing.T) {
+	svc, tenantRepo, routeRepo := setupNotificationRouteService()
+	ctx := context.Background()
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", ctx, tenantID).Return(&domain.Tenant{ID: tenantID}, nil)
+	routeRepo.On("ListByTenant", ctx, tenantID).Return([]domain.NotificationRoute{
+		{TenantID: tenantID, Kind: domain.NotificationKindPasswordReset, Channels: pq.StringArray{"email", "slack"}},
+	}, nil)
+
+	routes, err := svc.Get(ctx, tenantID)
+
+	require.NoError(t, err)
+	require.Len(t, routes, len(domain.NotificationKinds))
+	byKind := map[domain.NotificationKind]service.NotificationKindRoute{}
+	for _, r := range routes {
+		byKind[r.Kind] = r
+	}
+	assert.Equal(t, []domain.NotifierChannel{"email"}, byKind[domain.NotificationKindEmailVerification].Channels)
+	assert.False(t, byKind[domain.NotificationKindEmailVerification].Overridden)
+	assert.Equal(t, []domain.NotifierChannel{"email", "slack"}, byKind[domain.NotificationKindPasswordReset].Channels)
+	assert.True(t, byKind[domain.NotificationKindPasswordReset].Overridden)
+	assert.Equal(t, []domain.NotifierChannel{"email", "slack"}, byKind[domain.NotificationKindIngestionReport].Channels)
+}
+
+func TestNotificationRouteService_Set_Replaces(t *testing.T) {
+	svc, tenantRepo, routeRepo := setupNotificationRouteService()
+	ctx := context.Background()
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", ctx, tenantID).Return(&domain.Tenant{ID: tenantID}, nil)
+	routeRepo.On("Replace", ctx, tenantID, []domain.NotificationRoute{
+		{TenantID: tenantID, Kind: domain.NotificationKindIngestionReport, Channels: pq.StringArray{"slack"}},
+	}).Return(nil)
+	routeRepo.On("ListByTenant", ctx, tenantID).Return([]domain.NotificationRoute{}, nil)
+
+	_, err := svc.Set(ctx, tenantID, map[domain.NotificationKind][]domain.NotifierChannel{
+		domain.NotificationKindIngestionReport: {domain.NotifierChannelSlack},
+	})
+
+	require.NoError(t, err)
+	routeRepo.AssertExpectations(t)
+}
+
+func TestNotificationRouteService_Set_RejectsUnconfiguredChannel(t *testing.T) {
+	svc, _, routeRepo := setupNotificationRouteService()
+
+	_, err := svc.Set(context.Background(), uuid.New(), map[domain.NotificationKind][]domain.NotifierChannel{
+		domain.NotificationKindIngestionReport: {domain.NotifierChannelWebhook},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidNotificationRoutes)
+	routeRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNotificationRouteService_Set_TokenKindNeedsEmail(t *testing.T) {
+	svc, _, _ := setupNotificationRouteService()
+
+	_, err := svc.Set(context.Background(), uuid.New(), map[domain.NotificationKind][]domain.NotifierChannel{
+		domain.NotificationKindEmailVerification: {domain.NotifierChannelSlack},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidNotificationRoutes)
+}
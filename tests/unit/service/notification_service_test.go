@@ -0,0 +1,337 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/cloud"
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+const testSlackWebhook = "https://hooks.slack.com/services/T000/B000/secret"
+
+// webhookRecorder is an http.RoundTripper that records posted messages instead
+// of calling Slack / Teams.
+type webhookRecorder struct {
+	mu     sync.Mutex
+	status int
+	urls   []string
+	bodies []map[string]string
+}
+
+func (r *webhookRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	var payload map[string]string
+	_ = json.Unmarshal(body, &payload)
+	r.mu.Lock()
+	r.urls = append(r.urls, req.URL.String())
+	r.bodies = append(r.bodies, payload)
+	r.mu.Unlock()
+	status := r.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("ok")), Header: http.Header{}}, nil
+}
+
+type notificationMocks struct {
+	repo           *mocks.MockNotificationChannelRepo
+	collectionRepo *mocks.MockCollectionRepo
+	permRepo       *mocks.MockCollectionPermissionRepo
+	userRepo       *mocks.MockUserRepo
+	sealer         *cloud.TokenSealer
+	webhooks       *webhookRecorder
+}
+
+func setupNotificationService(t *testing.T) (*notificationMocks, service.NotificationService) {
+	sealer, err := cloud.NewTokenSealer("test-token-key")
+	require.NoError(t, err)
+	m := &notificationMocks{
+		repo:           new(mocks.MockNotificationChannelRepo),
+		collectionRepo: new(mocks.MockCollectionRepo),
+		permRepo:       new(mocks.MockCollectionPermissionRepo),
+		userRepo:       new(mocks.MockUserRepo),
+		sealer:         sealer,
+		webhooks:       &webhookRecorder{},
+	}
+	svc := service.NewNotificationService(m.repo, m.collectionRepo, m.permRepo, m.userRepo, sealer,
+		&http.Client{Transport: m.webhooks})
+	return m, svc
+}
+
+func (m *notificationMocks) channel(t *testing.T, provider domain.NotificationProvider, webhook string, events ...domain.NotificationEvent) *domain.NotificationChannel {
+	sealed, err := m.sealer.Seal(webhook)
+	require.NoError(t, err)
+	ch := &domain.NotificationChannel{
+		ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(), Provider: provider,
+		WebhookURL: sealed, WebhookHost: "hooks.slack.com", Templates: json.RawMessage("{}"),
+		ReviewSLAHours: 48, Enabled: true,
+	}
+	for _, e := range events {
+		ch.Events = append(ch.Events, string(e))
+	}
+	return ch
+}
+
+func TestNotificationService_CreateChannel(t *testing.T) {
+	m, svc := setupNotificationService(t)
+	ctx := context.Background()
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	m.collectionRepo.On("GetByID", ctx, tenantID, collectionID).Return(&domain.Collection{ID: collectionID, Name: "Payables"}, nil)
+	m.repo.On("Create", ctx, mock.AnythingOfType("*domain.NotificationChannel")).Return(nil)
+	m.collectionRepo.On("TimeZone", ctx, tenantID, collectionID).Return("UTC", nil)
+
+	ch, err := svc.CreateChannel(ctx, tenantID, collectionID, userID, domain.RoleAdmin, &service.NotificationChannelInput{
+		Provider:   domain.NotificationProviderSlack,
+		WebhookURL: testSlackWebhook,
+		Events:     []domain.NotificationEvent{domain.NotificationEventParseFailed, domain.NotificationEventWeeklySummary, domain.NotificationEventParseFailed},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "hooks.slack.com", ch.WebhookHost)
+	assert.NotEqual(t, testSlackWebhook, ch.WebhookURL, "webhook URL must be sealed")
+	opened, err := m.sealer.Open(ch.WebhookURL)
+	require.NoError(t, err)
+	assert.Equal(t, testSlackWebhook, opened)
+	assert.Equal(t, []string{"parse_failed", "weekly_summary"}, []string(ch.Events))
+	assert.Equal(t, 48, ch.ReviewSLAHours)
+	assert.True(t, ch.Enabled)
+	assert.Equal(t, time.Monday, ch.NextSummaryAt.Weekday())
+	assert.Equal(t, 9, ch.NextSummaryAt.Hour())
+	assert.True(t, ch.NextSummaryAt.After(time.Now()))
+	assert.WithinDuration(t, time.Now().Add(-48*time.Hour), ch.SLACheckedThrough, time.Minute)
+	m.repo.AssertExpectations(t)
+}
+
+func TestNotificationService_CreateChannel_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   service.NotificationChannelInput
+		wantErr error
+	}{
+		{"slack url for teams", service.NotificationChannelInput{Provider: domain.NotificationProviderTeams, WebhookURL: testSlackWebhook,
+			Events: []domain.NotificationEvent{domain.NotificationEventParseFailed}}, domain.ErrInvalidNotificationChannel},
+		{"other host", service.NotificationChannelInput{Provider: domain.NotificationProviderSlack, WebhookURL: "https://example.com/hook",
+			Events: []domain.NotificationEvent{domain.NotificationEventParseFailed}}, domain.ErrInvalidNotificationChannel},
+		{"plain http", service.NotificationChannelInput{Provider: domain.NotificationProviderSlack, WebhookURL: "http://hooks.slack.com/services/x",
+			Events: []domain.NotificationEvent{domain.NotificationEventParseFailed}}, domain.ErrInvalidNotificationChannel},
+		{"unknown event", service.NotificationChannelInput{Provider: domain.NotificationProviderSlack, WebhookURL: testSlackWebhook,
+			Events: []domain.NotificationEvent{"document_deleted"}}, domain.ErrInvalidNotificationChannel},
+		{"sla too long", service.NotificationChannelInput{Provider: domain.NotificationProviderSlack, WebhookURL: testSlackWebhook,
+			Events: []domain.NotificationEvent{domain.NotificationEventReviewSLABreached}, ReviewSLAHours: 1000}, domain.ErrInvalidNotificationChannel},
+		{"template syntax", service.NotificationChannelInput{Provider: domain.NotificationProviderSlack, WebhookURL: testSlackWebhook,
+			Events:    []domain.NotificationEvent{domain.NotificationEventParseFailed},
+			Templates: map[domain.NotificationEvent]string{domain.NotificationEventParseFailed: "{{.Document.Name"}}, domain.ErrInvalidNotificationTemplate},
+		{"template reads another event's fields", service.NotificationChannelInput{Provider: domain.NotificationProviderSlack, WebhookURL: testSlackWebhook,
+			Events:    []domain.NotificationEvent{domain.NotificationEventParseFailed},
+			Templates: map[domain.NotificationEvent]string{domain.NotificationEventParseFailed: "{{.Summary.Uploaded}}"}}, domain.ErrInvalidNotificationTemplate},
+		{"template unknown field", service.NotificationChannelInput{Provider: domain.NotificationProviderSlack, WebhookURL: testSlackWebhook,
+			Events:    []domain.NotificationEvent{domain.NotificationEventParseFailed},
+			Templates: map[domain.NotificationEvent]string{domain.NotificationEventParseFailed: "{{.Document.Invoice}}"}}, domain.ErrInvalidNotificationTemplate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := setupNotificationService(t)
+			ctx := context.Background()
+			tenantID, collectionID := uuid.New(), uuid.New()
+			m.collectionRepo.On("GetByID", ctx, tenantID, collectionID).Return(&domain.Collection{ID: collectionID}, nil)
+
+			_, err := svc.CreateChannel(ctx, tenantID, collectionID, uuid.New(), domain.RoleAdmin, &tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			m.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestNotificationService_CreateChannel_RequiresOwner(t *testing.T) {
+	m, svc := setupNotificationService(t)
+	ctx := context.Background()
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	m.collectionRepo.On("GetByID", ctx, tenantID, collectionID).Return(&domain.Collection{ID: collectionID}, nil)
+	m.permRepo.On("GetByCollectionAndUser", ctx, collectionID, userID).
+		Return(&domain.CollectionPermissionEntry{Permission: domain.CollectionPermEditor}, nil)
+
+	_, err := svc.CreateChannel(ctx, tenantID, collectionID, userID, domain.RoleManager, &service.NotificationChannelInput{
+		Provider: domain.NotificationProviderSlack, WebhookURL: testSlackWebhook,
+		Events: []domain.NotificationEvent{domain.NotificationEventParseFailed},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+}
+
+func TestNotificationService_TestChannel_Slack(t *testing.T) {
+	m, svc := setupNotificationService(t)
+	ctx := context.Background()
+	ch := m.channel(t, domain.NotificationProviderSlack, testSlackWebhook, domain.NotificationEventParseFailed)
+
+	m.collectionRepo.On("GetByID", ctx, ch.TenantID, ch.CollectionID).Return(&domain.Collection{ID: ch.CollectionID, Name: "Payables"}, nil)
+	m.repo.On("GetByID", ctx, ch.TenantID, ch.ID).Return(ch, nil)
+	m.repo.On("RecordDelivery", ctx, ch.ID, mock.Anything, "").Return(nil)
+
+	err := svc.TestChannel(ctx, ch.TenantID, ch.CollectionID, ch.ID, uuid.New(), domain.RoleAdmin, "")
+
+	require.NoError(t, err)
+	require.Len(t, m.webhooks.bodies, 1)
+	assert.Equal(t, testSlackWebhook, m.webhooks.urls[0])
+	assert.Contains(t, m.webhooks.bodies[0]["text"], "Payables")
+	m.repo.AssertExpectations(t)
+}
+
+func TestNotificationService_TestChannel_TeamsTemplatePreview(t *testing.T) {
+	m, svc := setupNotificationService(t)
+	ctx := context.Background()
+	ch := m.channel(t, domain.NotificationProviderTeams, "https://acme.webhook.office.com/webhookb2/abc", domain.NotificationEventDocumentAssigned)
+	ch.Templates = json.RawMessage(`{"document_assigned": "Review {{.Document.Name}} please, {{.Document.Assignee}}"}`)
+
+	m.collectionRepo.On("GetByID", ctx, ch.TenantID, ch.CollectionID).Return(&domain.Collection{ID: ch.CollectionID, Name: "Payables"}, nil)
+	m.repo.On("GetByID", ctx, ch.TenantID, ch.ID).Return(ch, nil)
+	m.repo.On("RecordDelivery", ctx, ch.ID, mock.Anything, "").Return(nil)
+
+	err := svc.TestChannel(ctx, ch.TenantID, ch.CollectionID, ch.ID, uuid.New(), domain.RoleAdmin, domain.NotificationEventDocumentAssigned)
+
+	require.NoError(t, err)
+	require.Len(t, m.webhooks.bodies, 1)
+	assert.Equal(t, "MessageCard", m.webhooks.bodies[0]["@type"])
+	assert.Equal(t, "Review sample-invoice.pdf please, Sample Reviewer", m.webhooks.bodies[0]["text"])
+}
+
+func TestNotificationService_TestChannel_DeliveryFailed(t *testing.T) {
+	m, svc := setupNotificationService(t)
+	m.webhooks.status = http.StatusNotFound
+	ctx := context.Background()
+	ch := m.channel(t, domain.NotificationProviderSlack, testSlackWebhook, domain.NotificationEventParseFailed)
+
+	m.collectionRepo.On("GetByID", ctx, ch.TenantID, ch.CollectionID).Return(&domain.Collection{ID: ch.CollectionID}, nil)
+	m.repo.On("GetByID", ctx, ch.TenantID, ch.ID).Return(ch, nil)
+	m.repo.On("RecordDelivery", ctx, ch.ID, mock.Anything, mock.MatchedBy(func(e string) bool { return e != "" })).Return(nil)
+
+	err := svc.TestChannel(ctx, ch.TenantID, ch.CollectionID, ch.ID, uuid.New(), domain.RoleAdmin, "")
+
+	assert.ErrorIs(t, err, domain.ErrNotificationDeliveryFailed)
+	assert.NotContains(t, err.Error(), "secret", "webhook URL must not leak into errors")
+	m.repo.AssertExpectations(t)
+}
+
+func TestNotificationService_RunScheduled_ReviewSLABreached(t *testing.T) {
+	m, svc := setupNotificationService(t)
+	ctx := context.Background()
+	ch := m.channel(t, domain.NotificationProviderSlack, testSlackWebhook, domain.NotificationEventReviewSLABreached)
+	ch.SLACheckedThrough = time.Now().UTC().Add(-50 * time.Hour).Truncate(time.Microsecond)
+	parsed := time.Now().UTC().Add(-49 * time.Hour)
+	docs := []domain.Document{
+		{ID: uuid.New(), Name: "a.pdf", ParsedAt: &parsed},
+		{ID: uuid.New(), Name: "b.pdf", ParsedAt: &parsed},
+	}
+
+	m.repo.On("ListScheduled", ctx).Return([]domain.NotificationChannel{*ch}, nil)
+	m.repo.On("ListOverdueReviews", ctx, ch.TenantID, ch.CollectionID, ch.SLACheckedThrough, mock.Anything, 20).Return(docs, nil)
+	m.repo.On("AdvanceSLACheck", ctx, ch.ID, ch.SLACheckedThrough, mock.MatchedBy(func(to time.Time) bool {
+		return to.After(ch.SLACheckedThrough) && to.Before(time.Now().Add(-47*time.Hour))
+	})).Return(true, nil)
+	m.collectionRepo.On("GetByID", ctx, ch.TenantID, ch.CollectionID).Return(&domain.Collection{ID: ch.CollectionID, Name: "Payables"}, nil)
+	m.repo.On("RecordDelivery", ctx, ch.ID, mock.Anything, "").Return(nil)
+
+	require.NoError(t, svc.RunScheduled(ctx))
+
+	require.Len(t, m.webhooks.bodies, 1)
+	text := m.webhooks.bodies[0]["text"]
+	assert.Contains(t, text, "2 document(s) in Payables have waited more than 48h")
+	assert.Contains(t, text, "- a.pdf (49h)")
+	m.repo.AssertExpectations(t)
+}
+
+func TestNotificationService_RunScheduled_SLAWindowClaimedElsewhere(t *testing.T) {
+	m, svc := setupNotificationService(t)
+	ctx := context.Background()
+	ch := m.channel(t, domain.NotificationProviderSlack, testSlackWebhook, domain.NotificationEventReviewSLABreached)
+	ch.SLACheckedThrough = time.Now().UTC().Add(-50 * time.Hour).Truncate(time.Microsecond)
+	parsed := time.Now().UTC().Add(-49 * time.Hour)
+
+	m.repo.On("ListScheduled", ctx).Return([]domain.NotificationChannel{*ch}, nil)
+	m.repo.On("ListOverdueReviews", ctx, ch.TenantID, ch.CollectionID, ch.SLACheckedThrough, mock.Anything, 20).
+		Return([]domain.Document{{ID: uuid.New(), Name: "a.pdf", ParsedAt: &parsed}}, nil)
+	m.repo.On("AdvanceSLACheck", ctx, ch.ID, ch.SLACheckedThrough, mock.Anything).Return(false, nil)
+
+	require.NoError(t, svc.RunScheduled(ctx))
+
+	assert.Empty(t, m.webhooks.bodies)
+}
+
+func TestNotificationService_RunScheduled_WeeklySummary(t *testing.T) {
+	m, svc := setupNotificationService(t)
+	ctx := context.Background()
+	ch := m.channel(t, domain.NotificationProviderSlack, testSlackWebhook, domain.NotificationEventWeeklySummary)
+	ch.NextSummaryAt = time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+
+	m.repo.On("ListScheduled", ctx).Return([]domain.NotificationChannel{*ch}, nil)
+	m.collectionRepo.On("TimeZone", ctx, ch.TenantID, ch.CollectionID).Return("Asia/Kolkata", nil)
+	var next time.Time
+	m.repo.On("AdvanceSummary", ctx, ch.ID, ch.NextSummaryAt, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { next = args.Get(3).(time.Time) }).Return(true, nil)
+	m.repo.On("CollectionActivity", ctx, ch.TenantID, ch.CollectionID, mock.Anything, mock.Anything).
+		Return(&domain.CollectionActivity{Uploaded: 12, Approved: 8, Rejected: 1, ParseFailed: 2, PendingReview: 3}, nil)
+	m.collectionRepo.On("GetByID", ctx, ch.TenantID, ch.CollectionID).Return(&domain.Collection{ID: ch.CollectionID, Name: "Payables"}, nil)
+	m.repo.On("RecordDelivery", ctx, ch.ID, mock.Anything, "").Return(nil)
+
+	require.NoError(t, svc.RunScheduled(ctx))
+
+	require.Len(t, m.webhooks.bodies, 1)
+	assert.Contains(t, m.webhooks.bodies[0]["text"], "12 uploaded, 8 approved, 1 rejected, 2 failed to parse. 3 awaiting review.")
+	// The next summary is the coming Monday 09:00 in the tenant's time zone
+	ist := next.In(time.FixedZone("IST", 5*3600+1800))
+	assert.Equal(t, time.Monday, ist.Weekday())
+	assert.Equal(t, 9, ist.Hour())
+	assert.Zero(t, ist.Minute())
+	assert.True(t, next.After(time.Now()))
+	m.repo.AssertExpectations(t)
+}
+
+func TestChannelNotifyingDocumentRepo(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	notifications := new(mocks.MockNotificationService)
+	repo := service.NewChannelNotifyingDocumentRepo(docRepo, notifications)
+	ctx := context.Background()
+	assignee := uuid.New()
+
+	assigned := &domain.Document{ID: uuid.New(), AssignedTo: &assignee}
+	unassigned := &domain.Document{ID: uuid.New()}
+	docRepo.On("UpdateAssignment", ctx, mock.Anything).Return(nil)
+	notifications.On("NotifyDocument", domain.NotificationEventDocumentAssigned, assigned).Return()
+
+	require.NoError(t, repo.UpdateAssignment(ctx, assigned))
+	require.NoError(t, repo.UpdateAssignment(ctx, unassigned))
+
+	notifications.AssertExpectations(t)
+	notifications.AssertNumberOfCalls(t, "NotifyDocument", 1)
+}
+
+func TestNotifyParseFailedListener(t *testing.T) {
+	notifications := new(mocks.MockNotificationService)
+	events := service.NewDocumentEvents()
+	events.Subscribe("channel_notifications", service.NotifyParseFailedListener(notifications), service.DocumentEventParseFailed)
+
+	failed := &domain.Document{ID: uuid.New(), ParsingStatus: domain.ParsingStatusFailed}
+	notifications.On("NotifyDocument", domain.NotificationEventParseFailed, failed).Return()
+
+	events.Publish(context.Background(), &service.DocumentEvent{Kind: service.DocumentEventParseFailed, Document: failed})
+	events.Publish(context.Background(), &service.DocumentEvent{Kind: service.DocumentEventParsed, Document: &domain.Document{ID: uuid.New()}})
+
+	notifications.AssertExpectations(t)
+	notifications.AssertNumberOfCalls(t, "NotifyDocument", 1)
+}
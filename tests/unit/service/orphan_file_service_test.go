@@ -0,0 +1,192 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type orphanFileMocks struct {
+	orphanRepo    *mocks.MockOrphanFileRepo
+	tenantRepo    *mocks.MockTenantRepo
+	userRepo      *mocks.MockUserRepo
+	collectionSvc *mocks.MockCollectionService
+	docSvc        *mocks.MockDocumentService
+	flags         *mocks.MockFeatureFlagService
+	notifier      *mocks.MockNotifier
+}
+
+func setupOrphanFileService() (service.OrphanFileService, *orphanFileMocks) {
+	m := &orphanFileMocks{
+		orphanRepo:    new(mocks.MockOrphanFileRepo),
+		tenantRepo:    new(mocks.MockTenantRepo),
+		userRepo:      new(mocks.MockUserRepo),
+		collectionSvc: new(mocks.MockCollectionService),
+		docSvc:        new(mocks.MockDocumentService),
+		flags:         new(mocks.MockFeatureFlagService),
+		notifier:      new(mocks.MockNotifier),
+	}
+	svc := service.NewOrphanFileService(m.orphanRepo, m.tenantRepo, m.userRepo, m.collectionSvc, m.docSvc, m.flags, m.notifier, 7)
+	return svc, m
+}
+
+// cutoffAbout matches an upload cutoff days days before now.
+func cutoffAbout(days int) interface{} {
+	want := time.Now().UTC().AddDate(0, 0, -days)
+	return mock.MatchedBy(func(t time.Time) bool { return t.Sub(want).Abs() < time.Minute })
+}
+
+func TestOrphanFileService_List_RequiresViewer(t *testing.T) {
+	svc, m := setupOrphanFileService()
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleMember).
+		Return(domain.CollectionPermission(""))
+
+	_, _, err := svc.List(context.Background(), tenantID, collectionID, userID, domain.RoleMember, 7, 0, 20)
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	m.orphanRepo.AssertNotCalled(t, "ListByCollection", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrphanFileService_List_UsesCutoff(t *testing.T) {
+	svc, m := setupOrphanFileService()
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleMember).
+		Return(domain.CollectionPermViewer)
+	files := []domain.FileMeta{{ID: uuid.New(), OriginalName: "a.pdf"}}
+	m.orphanRepo.On("ListByCollection", mock.Anything, tenantID, collectionID, cutoffAbout(30), 0, 20).Return(files, 1, nil)
+
+	got, total, err := svc.List(context.Background(), tenantID, collectionID, userID, domain.RoleMember, 30, 0, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, files, got)
+}
+
+func TestOrphanFileService_CreateDocuments_ReportsEachFile(t *testing.T) {
+	svc, m := setupOrphanFileService()
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+	ok, bad := domain.FileMeta{ID: uuid.New(), OriginalName: "ok.pdf"}, domain.FileMeta{ID: uuid.New(), OriginalName: "bad.pdf"}
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleMember).
+		Return(domain.CollectionPermEditor)
+	m.collectionSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.RoleMember).
+		Return(&domain.Collection{ID: collectionID}, nil)
+	m.orphanRepo.On("ListByCollection", mock.Anything, tenantID, collectionID, cutoffAbout(7), 0, 500).
+		Return([]domain.FileMeta{ok, bad}, 502, nil)
+	docID := uuid.New()
+	m.docSvc.On("CreateAndParse", mock.Anything, mock.MatchedBy(func(in *service.CreateDocumentInput) bool {
+		return in.FileID == ok.ID && in.DocumentType == "invoice" && in.ParseMode == domain.ParseModeSingle && in.CreatedBy == userID
+	})).Return(&domain.Document{ID: docID}, nil)
+	m.docSvc.On("CreateAndParse", mock.Anything, mock.MatchedBy(func(in *service.CreateDocumentInput) bool {
+		return in.FileID == bad.ID
+	})).Return(nil, domain.ErrDocumentAlreadyExists)
+
+	run, err := svc.CreateDocuments(context.Background(), &service.OrphanDocumentsInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleMember,
+		DocumentType: "invoice", ParseMode: domain.ParseModeSingle, OlderThanDays: 7,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, run.Matched)
+	assert.Equal(t, 1, run.Created)
+	assert.Equal(t, 1, run.Failed)
+	assert.Equal(t, 500, run.Remaining)
+	require.Len(t, run.Entries, 2)
+	assert.Equal(t, "ok.pdf", run.Entries[0].Name)
+	assert.Equal(t, domain.ImportEntryImported, run.Entries[0].Status)
+	assert.Equal(t, &docID, run.Entries[0].DocumentID)
+	assert.Equal(t, domain.ImportEntryFailed, run.Entries[1].Status)
+	assert.Equal(t, &bad.ID, run.Entries[1].FileID)
+}
+
+func TestOrphanFileService_CreateDocuments_StopsWhenQuotaRunsOut(t *testing.T) {
+	svc, m := setupOrphanFileService()
+	tenantID, collectionID, userID := uuid.New(), uuid.New(), uuid.New()
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleFree).
+		Return(domain.CollectionPermOwner)
+	m.collectionSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.RoleFree).
+		Return(&domain.Collection{ID: collectionID}, nil)
+	files := []domain.FileMeta{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	m.orphanRepo.On("ListByCollection", mock.Anything, tenantID, collectionID, mock.Anything, 0, 500).Return(files, 3, nil)
+	m.docSvc.On("CreateAndParse", mock.Anything, mock.Anything).Return(nil, domain.ErrQuotaExceeded).Once()
+
+	run, err := svc.CreateDocuments(context.Background(), &service.OrphanDocumentsInput{
+		TenantID: tenantID, CollectionID: collectionID, UserID: userID, Role: domain.RoleFree, DocumentType: "invoice",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, run.Failed)
+	assert.Zero(t, run.Created)
+	for _, e := range run.Entries {
+		assert.Contains(t, e.Reason, "quota")
+	}
+	m.docSvc.AssertNumberOfCalls(t, "CreateAndParse", 1)
+}
+
+func TestOrphanFileService_CreateDocuments_RequiresEditor(t *testing.T) {
+	svc, m := setupOrphanFileService()
+	collectionID, userID := uuid.New(), uuid.New()
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleViewer).
+		Return(domain.CollectionPermViewer)
+
+	_, err := svc.CreateDocuments(context.Background(), &service.OrphanDocumentsInput{
+		TenantID: uuid.New(), CollectionID: collectionID, UserID: userID, Role: domain.RoleViewer, DocumentType: "invoice",
+	})
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	m.docSvc.AssertNotCalled(t, "CreateAndParse", mock.Anything, mock.Anything)
+}
+
+// expectOrphanNudgeTenant makes tenant the only tenant, with orphan file nudges on.
+func expectOrphanNudgeTenant(m *orphanFileMocks, tenant *domain.Tenant) {
+	m.tenantRepo.On("List", mock.Anything, 0, 100).Return([]domain.Tenant{*tenant}, 1, nil)
+	m.flags.On("IsEnabled", mock.Anything, tenant.ID, domain.FlagOrphanFileNudges).Return(true)
+}
+
+func TestOrphanFileService_RunNudges_NotifiesCollectionCreator(t *testing.T) {
+	svc, m := setupOrphanFileService()
+	tenant := &domain.Tenant{ID: uuid.New(), IsActive: true}
+	expectOrphanNudgeTenant(m, tenant)
+	owner := &domain.User{ID: uuid.New(), Email: "owner@acme.in", FullName: "Owner", IsActive: true}
+	inactive := &domain.User{ID: uuid.New(), IsActive: false}
+	nudged := domain.OrphanFileSummary{CollectionID: uuid.New(), CollectionName: "Invoices", CreatedBy: owner.ID, FileCount: 4,
+		OldestUploadedAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)}
+	recent := domain.OrphanFileSummary{CollectionID: uuid.New(), CreatedBy: owner.ID, FileCount: 2}
+	orphaned := domain.OrphanFileSummary{CollectionID: uuid.New(), CreatedBy: inactive.ID, FileCount: 1}
+	m.orphanRepo.On("Summarize", mock.Anything, tenant.ID, cutoffAbout(7)).
+		Return([]domain.OrphanFileSummary{nudged, recent, orphaned}, nil)
+	m.userRepo.On("GetByID", mock.Anything, tenant.ID, owner.ID).Return(owner, nil)
+	m.userRepo.On("GetByID", mock.Anything, tenant.ID, inactive.ID).Return(inactive, nil)
+	m.orphanRepo.On("ClaimNudge", mock.Anything, tenant.ID, nudged.CollectionID, 4, cutoffAbout(7)).Return(true, nil)
+	m.orphanRepo.On("ClaimNudge", mock.Anything, tenant.ID, recent.CollectionID, 2, mock.Anything).Return(false, nil)
+	m.notifier.On("Notify", mock.Anything, mock.MatchedBy(func(n *port.Notification) bool {
+		return n.Kind == domain.NotificationKindOrphanFiles && n.TenantID == tenant.ID &&
+			len(n.Recipients) == 1 && n.Recipients[0].UserID == owner.ID
+	})).Return(nil)
+
+	require.NoError(t, svc.RunNudges(context.Background()))
+
+	m.notifier.AssertNumberOfCalls(t, "Notify", 1)
+	n := m.notifier.Calls[0].Arguments.Get(1).(*port.Notification)
+	assert.Contains(t, n.Subject, "4 files in Invoices")
+	assert.Contains(t, n.Body, "1 Sep 2026")
+	m.orphanRepo.AssertNotCalled(t, "ClaimNudge", mock.Anything, tenant.ID, orphaned.CollectionID, mock.Anything, mock.Anything)
+}
+
+func TestOrphanFileService_RunNudges_SkipsTenantsWithoutFlag(t *testing.T) {
+	svc, m := setupOrphanFileService()
+	off := domain.Tenant{ID: uuid.New(), IsActive: true}
+	suspended := domain.Tenant{ID: uuid.New(), IsActive: false}
+	m.tenantRepo.On("List", mock.Anything, 0, 100).Return([]domain.Tenant{off, suspended}, 2, nil)
+	m.flags.On("IsEnabled", mock.Anything, off.ID, domain.FlagOrphanFileNudges).Return(false)
+
+	require.NoError(t, svc.RunNudges(context.Background()))
+	m.orphanRepo.AssertNotCalled(t, "Summarize", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,203 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type pageImageMocks struct {
+	fileRepo *mocks.MockFileMetaRepo
+	storage  *mocks.MockObjectStorage
+	renderer *mocks.MockPageRenderer
+}
+
+func newPageImageService() (service.PageImageService, *pageImageMocks) {
+	m := &pageImageMocks{
+		fileRepo: new(mocks.MockFileMetaRepo),
+		storage:  new(mocks.MockObjectStorage),
+		renderer: new(mocks.MockPageRenderer),
+	}
+	cfg := config.PageImageConfig{DefaultDPI: 150, MaxDPI: 300, TimeoutSecs: 30}
+	return service.NewPageImageService(m.fileRepo, m.storage, m.renderer, nil, cfg), m
+}
+
+func pageImageFile(tenantID uuid.UUID, fileType domain.FileType) *domain.FileMeta {
+	return &domain.FileMeta{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		UploadedBy: uuid.New(),
+		FileType:   fileType,
+		S3Bucket:   "test-bucket",
+		S3Key:      "tenants/t/files/f/invoice." + string(fileType),
+	}
+}
+
+func TestPageImageService_RendersAndCachesPDFPage(t *testing.T) {
+	svc, m := newPageImageService()
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, domain.FileTypePDF)
+	rendered := []byte("png-bytes")
+
+	m.fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+	m.storage.On("Download", mock.Anything, "test-bucket", "tenants/t/files/f/pages/2@150.png").
+		Return(nil, errors.New("NoSuchKey"))
+	m.storage.On("Download", mock.Anything, "test-bucket", meta.S3Key).Return(pdfContent(), nil)
+	m.renderer.On("RenderPage", mock.Anything, pdfContent(), 2, 150).Return(rendered, nil)
+	m.storage.On("Upload", mock.Anything, mock.MatchedBy(func(in port.UploadInput) bool {
+		return in.Key == "tenants/t/files/f/pages/2@150.png" && in.ContentType == "image/png"
+	})).Return(&port.UploadOutput{}, nil)
+
+	img, err := svc.GetPageImage(context.Background(), &service.PageImageInput{
+		TenantID: tenantID, Role: domain.RoleMember, FileID: meta.ID, Page: 2,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, rendered, img)
+	m.storage.AssertExpectations(t)
+	m.renderer.AssertExpectations(t)
+}
+
+func TestPageImageService_ServesCachedPage(t *testing.T) {
+	svc, m := newPageImageService()
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, domain.FileTypePDF)
+
+	m.fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+	m.storage.On("Download", mock.Anything, "test-bucket", "tenants/t/files/f/pages/1@72.png").
+		Return([]byte("cached"), nil)
+
+	img, err := svc.GetPageImage(context.Background(), &service.PageImageInput{
+		TenantID: tenantID, Role: domain.RoleMember, FileID: meta.ID, Page: 1, DPI: 72,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("cached"), img)
+	m.renderer.AssertNotCalled(t, "RenderPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPageImageService_CacheWriteFailureStillReturnsImage(t *testing.T) {
+	svc, m := newPageImageService()
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, domain.FileTypePDF)
+
+	m.fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+	m.storage.On("Download", mock.Anything, "test-bucket", "tenants/t/files/f/pages/1@150.png").
+		Return(nil, errors.New("NoSuchKey"))
+	m.storage.On("Download", mock.Anything, "test-bucket", meta.S3Key).Return(pdfContent(), nil)
+	m.renderer.On("RenderPage", mock.Anything, mock.Anything, 1, 150).Return([]byte("png"), nil)
+	m.storage.On("Upload", mock.Anything, mock.Anything).Return(nil, errors.New("s3 down"))
+
+	img, err := svc.GetPageImage(context.Background(), &service.PageImageInput{
+		TenantID: tenantID, Role: domain.RoleMember, FileID: meta.ID, Page: 1,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), img)
+}
+
+func TestPageImageService_PageOutOfRange(t *testing.T) {
+	svc, m := newPageImageService()
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, domain.FileTypePDF)
+
+	m.fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+	m.storage.On("Download", mock.Anything, "test-bucket", "tenants/t/files/f/pages/9@150.png").
+		Return(nil, errors.New("NoSuchKey"))
+	m.storage.On("Download", mock.Anything, "test-bucket", meta.S3Key).Return(pdfContent(), nil)
+	m.renderer.On("RenderPage", mock.Anything, mock.Anything, 9, 150).Return(nil, domain.ErrPageNotFound)
+
+	_, err := svc.GetPageImage(context.Background(), &service.PageImageInput{
+		TenantID: tenantID, Role: domain.RoleMember, FileID: meta.ID, Page: 9,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrPageNotFound)
+	m.storage.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything)
+}
+
+func TestPageImageService_InvalidDPI(t *testing.T) {
+	for _, dpi := range []int{10, 301} {
+		svc, m := newPageImageService()
+		_, err := svc.GetPageImage(context.Background(), &service.PageImageInput{
+			TenantID: uuid.New(), FileID: uuid.New(), Page: 1, DPI: dpi,
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidDPI, "dpi %d", dpi)
+		m.fileRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func TestPageImageService_FreeUserCannotSeeOthersFiles(t *testing.T) {
+	svc, m := newPageImageService()
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, domain.FileTypePDF)
+
+	m.fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+
+	_, err := svc.GetPageImage(context.Background(), &service.PageImageInput{
+		TenantID: tenantID, UserID: uuid.New(), Role: domain.RoleFree, FileID: meta.ID, Page: 1,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	m.storage.AssertNotCalled(t, "Download", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPageImageService_ConvertsJPEG(t *testing.T) {
+	svc, m := newPageImageService()
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, domain.FileTypeJPG)
+
+	var jpg bytes.Buffer
+	require.NoError(t, jpeg.Encode(&jpg, image.NewRGBA(image.Rect(0, 0, 4, 3)), nil))
+	m.fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+	m.storage.On("Download", mock.Anything, "test-bucket", meta.S3Key).Return(jpg.Bytes(), nil)
+
+	img, err := svc.GetPageImage(context.Background(), &service.PageImageInput{
+		TenantID: tenantID, Role: domain.RoleMember, FileID: meta.ID, Page: 1,
+	})
+
+	require.NoError(t, err)
+	decoded, err := png.Decode(bytes.NewReader(img))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 4, 3), decoded.Bounds())
+}
+
+func TestPageImageService_ImageHasOnePage(t *testing.T) {
+	svc, m := newPageImageService()
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, domain.FileTypePNG)
+	m.fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+
+	_, err := svc.GetPageImage(context.Background(), &service.PageImageInput{
+		TenantID: tenantID, Role: domain.RoleMember, FileID: meta.ID, Page: 2,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrPageNotFound)
+}
+
+func TestPageImageService_TIFFUnsupported(t *testing.T) {
+	svc, m := newPageImageService()
+	tenantID := uuid.New()
+	meta := pageImageFile(tenantID, domain.FileTypeTIFF)
+	m.fileRepo.On("GetByID", mock.Anything, tenantID, meta.ID).Return(meta, nil)
+
+	_, err := svc.GetPageImage(context.Background(), &service.PageImageInput{
+		TenantID: tenantID, Role: domain.RoleMember, FileID: meta.ID, Page: 1,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedFileType)
+}
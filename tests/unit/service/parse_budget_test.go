@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+var parseBudgetCfg = config.ParseBudgetConfig{DailyCalls: 100, FreeDailyCalls: 5}
+
+func setupParseBudget(slug string, freeParser port.DocumentParser) (service.ParseBudget, *mocks.MockTenantRepo, *mocks.MockParseBudgetRepo, uuid.UUID) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	repo := new(mocks.MockParseBudgetRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, Slug: slug}, nil)
+	return service.NewParseBudget(tenantRepo, repo, "satvos", parseBudgetCfg, freeParser), tenantRepo, repo, tenantID
+}
+
+// utcDay matches a reservation day: midnight UTC.
+var utcDay = mock.MatchedBy(func(day time.Time) bool {
+	return day.Location() == time.UTC && day.Equal(day.Truncate(24*time.Hour))
+})
+
+func TestParseBudget_FreeTierCountsPerUser(t *testing.T) {
+	budget, _, repo, tenantID := setupParseBudget("satvos", nil)
+	userID := uuid.New()
+	repo.On("Reserve", mock.Anything, tenantID, userID, utcDay, 5).Return(nil)
+
+	require.NoError(t, budget.Reserve(context.Background(), tenantID, userID))
+	repo.AssertExpectations(t)
+}
+
+func TestParseBudget_StandardTierCountsPerTenant(t *testing.T) {
+	budget, _, repo, tenantID := setupParseBudget("acme", nil)
+	repo.On("Reserve", mock.Anything, tenantID, uuid.Nil, utcDay, 100).Return(nil)
+
+	require.NoError(t, budget.Reserve(context.Background(), tenantID, uuid.New()))
+	repo.AssertExpectations(t)
+}
+
+func TestParseBudget_Exhausted(t *testing.T) {
+	budget, _, repo, tenantID := setupParseBudget("satvos", nil)
+	repo.On("Reserve", mock.Anything, tenantID, mock.Anything, mock.Anything, 5).Return(domain.ErrParseBudgetExhausted)
+
+	err := budget.Reserve(context.Background(), tenantID, uuid.New())
+
+	assert.ErrorIs(t, err, domain.ErrParseBudgetExhausted)
+}
+
+func TestParseBudget_UnlimitedSkipsCounter(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	repo := new(mocks.MockParseBudgetRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, Slug: "acme"}, nil)
+	budget := service.NewParseBudget(tenantRepo, repo, "satvos", config.ParseBudgetConfig{FreeDailyCalls: 5}, nil)
+
+	require.NoError(t, budget.Reserve(context.Background(), tenantID, uuid.New()))
+	repo.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParseBudget_TenantLookupError(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(nil, domain.ErrNotFound)
+	budget := service.NewParseBudget(tenantRepo, new(mocks.MockParseBudgetRepo), "satvos", parseBudgetCfg, nil)
+
+	err := budget.Reserve(context.Background(), tenantID, uuid.New())
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestParseBudget_ParserRoutesFreeTier(t *testing.T) {
+	freeParser := new(mocks.MockDocumentParser)
+	defParser := new(mocks.MockDocumentParser)
+
+	free, _, _, freeTenant := setupParseBudget("satvos", freeParser)
+	standard, _, _, standardTenant := setupParseBudget("acme", freeParser)
+
+	assert.Same(t, freeParser, free.Parser(context.Background(), freeTenant, defParser))
+	assert.Same(t, defParser, standard.Parser(context.Background(), standardTenant, defParser))
+}
+
+func TestParseBudget_ParserWithoutFreeProviderUsesDefault(t *testing.T) {
+	defParser := new(mocks.MockDocumentParser)
+	budget, tenantRepo, _, tenantID := setupParseBudget("satvos", nil)
+
+	assert.Same(t, defParser, budget.Parser(context.Background(), tenantID, defParser))
+	tenantRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/parser"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/internal/validator"
+	"satvos/internal/validator/invoice"
+	"satvos/mocks"
+)
+
+func setupParsePreviewService(engine *validator.Engine) (service.ParsePreviewService, *mocks.MockDocumentParser, *mocks.MockUserRepo) {
+	docParser := new(mocks.MockDocumentParser)
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewParsePreviewService(docParser, engine, userRepo, nil, config.ParsePreviewConfig{MaxSizeMB: 1})
+	return svc, docParser, userRepo
+}
+
+func previewInput(fileName string, content []byte) *service.ParsePreviewInput {
+	return &service.ParsePreviewInput{
+		TenantID:     uuid.New(),
+		UserID:       uuid.New(),
+		FileName:     fileName,
+		Content:      content,
+		DocumentType: "invoice",
+	}
+}
+
+func TestParsePreviewService_Preview_Success(t *testing.T) {
+	ruleRepo := new(mocks.MockDocumentValidationRuleRepo)
+	docRepo := new(mocks.MockDocumentRepo)
+	registry := validator.NewRegistry()
+	for _, v := range invoice.AllBuiltinValidators() {
+		registry.Register(v)
+	}
+	svc, docParser, userRepo := setupParsePreviewService(validator.NewEngine(registry, ruleRepo, docRepo))
+	input := previewInput("inv.pdf", pdfContent())
+
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, input.TenantID, input.UserID).Return(nil)
+	docParser.On("Parse", mock.Anything, mock.MatchedBy(func(in port.ParseInput) bool {
+		return in.ContentType == "application/pdf" && in.DocumentType == "invoice" && in.PageCount == 1
+	})).Return(&port.ParseOutput{
+		StructuredData:   json.RawMessage(`{"invoice":{"invoice_number":"INV-1"}}`),
+		ConfidenceScores: json.RawMessage(`{"invoice":{"invoice_number":0.9}}`),
+		ModelUsed:        "claude-sonnet-4",
+	}, nil)
+	ruleRepo.On("ListBuiltinKeys", mock.Anything, input.TenantID, "invoice").Return([]string{}, nil)
+	ruleRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentValidationRule")).Return(nil)
+	ruleRepo.On("ListByDocumentType", mock.Anything, input.TenantID, "invoice", (*uuid.UUID)(nil)).
+		Return([]domain.DocumentValidationRule{}, nil)
+
+	result, err := svc.Preview(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Equal(t, "claude-sonnet-4", result.ParserModel)
+	assert.JSONEq(t, `{"invoice":{"invoice_number":"INV-1"}}`, string(result.StructuredData))
+	require.NotNil(t, result.Validation)
+	assert.Equal(t, domain.ValidationStatusValid, result.Validation.ValidationStatus)
+	docRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	docRepo.AssertNotCalled(t, "UpdateValidationResults", mock.Anything, mock.Anything)
+}
+
+func TestParsePreviewService_Preview_RejectsBeforeQuota(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  []byte
+		want     error
+	}{
+		{"unsupported extension", "inv.docx", pdfContent(), domain.ErrUnsupportedFileType},
+		{"unknown content", "inv.pdf", []byte("plain text"), domain.ErrUnsupportedFileType},
+		{"extension mismatch", "inv.pdf", pngContent(), domain.ErrFileContentMismatch},
+		{"too large", "inv.png", append(pngContent(), make([]byte, 1024*1024)...), domain.ErrFileTooLarge},
+		{"encrypted pdf", "inv.pdf", []byte("%PDF-1.4\n/Type /Page\ntrailer << /Encrypt 5 0 R >>"), domain.ErrPDFEncrypted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, docParser, userRepo := setupParsePreviewService(nil)
+
+			_, err := svc.Preview(context.Background(), previewInput(tt.fileName, tt.content))
+
+			assert.ErrorIs(t, err, tt.want)
+			userRepo.AssertNotCalled(t, "CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything)
+			docParser.AssertNotCalled(t, "Parse", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestParsePreviewService_Preview_QuotaExceeded(t *testing.T) {
+	svc, docParser, userRepo := setupParsePreviewService(nil)
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrQuotaExceeded)
+
+	_, err := svc.Preview(context.Background(), previewInput("inv.png", pngContent()))
+
+	assert.ErrorIs(t, err, domain.ErrQuotaExceeded)
+	docParser.AssertNotCalled(t, "Parse", mock.Anything, mock.Anything)
+}
+
+func TestParsePreviewService_Preview_ParserErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"rate limited", parser.NewRateLimitError("claude", errors.New("429"), 30), domain.ErrParserUnavailable},
+		{"transient", parser.NewTransientError("claude", errors.New("503")), domain.ErrParserUnavailable},
+		{"unreadable", errors.New("no JSON in model output"), domain.ErrParseFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, docParser, userRepo := setupParsePreviewService(nil)
+			userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			docParser.On("Parse", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			_, err := svc.Preview(context.Background(), previewInput("inv.png", pngContent()))
+
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestParsePreviewService_Preview_WithoutValidator(t *testing.T) {
+	svc, docParser, userRepo := setupParsePreviewService(nil)
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	docParser.On("Parse", mock.Anything, mock.Anything).Return(&port.ParseOutput{
+		StructuredData: json.RawMessage(`{}`), ModelUsed: "m",
+	}, nil)
+
+	result, err := svc.Preview(context.Background(), previewInput("scan.png", pngContent()))
+
+	require.NoError(t, err)
+	assert.Nil(t, result.Validation)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"satvos/internal/domain"
+	"satvos/internal/parser"
 	"satvos/internal/service"
 	"satvos/mocks"
 )
@@ -200,3 +201,43 @@ func TestParseQueueWorker_ClaimQueuedError(t *testing.T) {
 	// ParseDocument should never have been called
 	docSvc.AssertNotCalled(t, "ParseDocument", mock.Anything, mock.Anything, mock.Anything)
 }
+
+func TestParseQueueWorker_SlowsDispatchNearRateLimit(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	docSvc := new(mocks.MockDocumentService)
+
+	limits := parser.NewRateLimitTracker()
+	limits.Observe(parser.RateLimitHeadroom{
+		Provider: "claude", RequestsLimit: 100, RequestsRemaining: 12, RequestsReset: time.Now().Add(time.Minute),
+	})
+
+	// 12 remaining with 10 in reserve leaves room for 2 of the 5 free slots
+	docRepo.On("ClaimQueued", mock.Anything, 2).Return([]domain.Document{}, nil)
+
+	worker := service.NewParseQueueWorker(docRepo, docSvc, service.ParseQueueConfig{
+		PollInterval:            20 * time.Millisecond,
+		MaxRetries:              5,
+		Concurrency:             5,
+		RateLimits:              limits,
+		Provider:                "claude",
+		RateLimitReservePercent: 10,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		worker.Start(ctx)
+		close(done)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	status := worker.Status()
+	assert.True(t, status.Throttled)
+	assert.Equal(t, 5, status.Concurrency)
+	assert.Len(t, status.Headroom, 1)
+
+	cancel()
+	<-done
+	docRepo.AssertCalled(t, "ClaimQueued", mock.Anything, 2)
+	docRepo.AssertNotCalled(t, "ClaimQueued", mock.Anything, 5)
+}
@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+// recordingAlerts captures alerts sent by the monitor.
+type recordingAlerts struct {
+	sent []port.Alert
+}
+
+func (r *recordingAlerts) SendAlert(_ context.Context, alert *port.Alert) error {
+	r.sent = append(r.sent, *alert)
+	return nil
+}
+
+func testSLAConfig() service.ParseSLAConfig {
+	return service.ParseSLAConfig{
+		CheckInterval: time.Minute,
+		Window:        15 * time.Minute,
+		MinSamples:    5,
+		MaxP95Parse:   2 * time.Minute,
+		MaxQueueAge:   15 * time.Minute,
+		Cooldown:      time.Hour,
+	}
+}
+
+func TestParseSLAMonitor_Check_AlertsOnSlowModelOnce(t *testing.T) {
+	timingRepo := new(mocks.MockParseTimingRepo)
+	alerts := &recordingAlerts{}
+	monitor := service.NewParseSLAMonitor(timingRepo, alerts, testSLAConfig())
+
+	timingRepo.On("LatencyByModel", mock.Anything, (*uuid.UUID)(nil), mock.AnythingOfType("time.Time")).Return([]domain.ParseLatencyStats{
+		{ParserModel: "slow-model", Attempts: 20, ParseP95Ms: 180000},
+		{ParserModel: "fast-model", Attempts: 20, ParseP95Ms: 20000},
+		{ParserModel: "rare-model", Attempts: 2, ParseP95Ms: 600000},
+	}, nil)
+	timingRepo.On("OldestWaiting", mock.Anything).Return(time.Minute, nil)
+
+	now := time.Now().UTC()
+	require.NoError(t, monitor.Check(context.Background(), now))
+	require.NoError(t, monitor.Check(context.Background(), now.Add(10*time.Minute)))
+
+	require.Len(t, alerts.sent, 1, "a continuing breach is not re-alerted within the cooldown")
+	assert.Equal(t, "parse_p95:slow-model", alerts.sent[0].Key)
+	assert.Contains(t, alerts.sent[0].Message, "slow-model")
+
+	require.NoError(t, monitor.Check(context.Background(), now.Add(61*time.Minute)))
+	assert.Len(t, alerts.sent, 2, "re-alerted once the cooldown elapses")
+}
+
+func TestParseSLAMonitor_Check_AlertsOnQueueAge(t *testing.T) {
+	timingRepo := new(mocks.MockParseTimingRepo)
+	alerts := &recordingAlerts{}
+	monitor := service.NewParseSLAMonitor(timingRepo, alerts, testSLAConfig())
+
+	timingRepo.On("LatencyByModel", mock.Anything, (*uuid.UUID)(nil), mock.AnythingOfType("time.Time")).
+		Return([]domain.ParseLatencyStats{}, nil)
+	timingRepo.On("OldestWaiting", mock.Anything).Return(40*time.Minute, nil)
+
+	require.NoError(t, monitor.Check(context.Background(), time.Now().UTC()))
+
+	require.Len(t, alerts.sent, 1)
+	assert.Equal(t, "queue_age", alerts.sent[0].Key)
+}
+
+func TestParseSLAMonitor_Check_ClearedBreachAlertsAgainImmediately(t *testing.T) {
+	timingRepo := new(mocks.MockParseTimingRepo)
+	alerts := &recordingAlerts{}
+	monitor := service.NewParseSLAMonitor(timingRepo, alerts, testSLAConfig())
+
+	timingRepo.On("LatencyByModel", mock.Anything, (*uuid.UUID)(nil), mock.AnythingOfType("time.Time")).
+		Return([]domain.ParseLatencyStats{}, nil)
+	timingRepo.On("OldestWaiting", mock.Anything).Return(40*time.Minute, nil).Once()
+	timingRepo.On("OldestWaiting", mock.Anything).Return(time.Minute, nil).Once()
+	timingRepo.On("OldestWaiting", mock.Anything).Return(40*time.Minute, nil).Once()
+
+	now := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, monitor.Check(context.Background(), now.Add(time.Duration(i)*time.Minute)))
+	}
+
+	assert.Len(t, alerts.sent, 2)
+}
@@ -13,6 +13,7 @@ import (
 
 	"satvos/internal/config"
 	"satvos/internal/domain"
+	"satvos/internal/port"
 	"satvos/internal/service"
 	"satvos/mocks"
 )
@@ -24,23 +25,36 @@ var testJWTCfg = config.JWTConfig{
 	Issuer:             "satvos-test",
 }
 
+var testSecurityCfg = config.AccountSecurityConfig{
+	ResetTokenTTLMins:    15,
+	BindResetTokens:      true,
+	EmailChangeUndoHours: 72,
+}
+
+// auditedSecurityRepo accepts any security event.
+func auditedSecurityRepo() *mocks.MockAccountSecurityRepo {
+	repo := new(mocks.MockAccountSecurityRepo)
+	repo.On("RecordEvent", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return repo
+}
+
 func setupPasswordResetService() (
 	service.PasswordResetService,
 	*mocks.MockTenantRepo,
 	*mocks.MockUserRepo,
-	*mocks.MockEmailSender,
+	*mocks.MockNotifier,
 ) {
 	tenantRepo := new(mocks.MockTenantRepo)
 	userRepo := new(mocks.MockUserRepo)
-	emailSender := new(mocks.MockEmailSender)
+	notifier := new(mocks.MockNotifier)
 
-	svc := service.NewPasswordResetService(tenantRepo, userRepo, emailSender, testJWTCfg)
+	svc := service.NewPasswordResetService(tenantRepo, userRepo, auditedSecurityRepo(), notifier, testJWTCfg, testSecurityCfg)
 
-	return svc, tenantRepo, userRepo, emailSender
+	return svc, tenantRepo, userRepo, notifier
 }
 
 func TestForgotPassword_Success(t *testing.T) {
-	svc, tenantRepo, userRepo, emailSender := setupPasswordResetService()
+	svc, tenantRepo, userRepo, notifier := setupPasswordResetService()
 	ctx := context.Background()
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -58,7 +72,7 @@ func TestForgotPassword_Success(t *testing.T) {
 	tenantRepo.On("GetBySlug", ctx, "test-tenant").Return(tenant, nil)
 	userRepo.On("GetByEmail", ctx, tenantID, "user@test.com").Return(user, nil)
 	userRepo.On("SetPasswordResetToken", ctx, tenantID, userID, mock.AnythingOfType("string")).Return(nil)
-	emailSender.On("SendPasswordResetEmail", ctx, "user@test.com", "Test User", mock.AnythingOfType("string")).Return(nil)
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindPasswordReset, "user@test.com")).Return(nil)
 
 	err := svc.ForgotPassword(ctx, service.ForgotPasswordInput{
 		TenantSlug: "test-tenant",
@@ -68,11 +82,11 @@ func TestForgotPassword_Success(t *testing.T) {
 	assert.NoError(t, err)
 	tenantRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
-	emailSender.AssertExpectations(t)
+	notifier.AssertExpectations(t)
 }
 
 func TestForgotPassword_UserNotFound(t *testing.T) {
-	svc, tenantRepo, userRepo, emailSender := setupPasswordResetService()
+	svc, tenantRepo, userRepo, notifier := setupPasswordResetService()
 	ctx := context.Background()
 	tenantID := uuid.New()
 
@@ -87,11 +101,11 @@ func TestForgotPassword_UserNotFound(t *testing.T) {
 	})
 
 	assert.NoError(t, err) // Returns nil — no leak
-	emailSender.AssertNotCalled(t, "SendPasswordResetEmail")
+	notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
 }
 
 func TestForgotPassword_TenantNotFound(t *testing.T) {
-	svc, tenantRepo, _, emailSender := setupPasswordResetService()
+	svc, tenantRepo, _, notifier := setupPasswordResetService()
 	ctx := context.Background()
 
 	tenantRepo.On("GetBySlug", ctx, "nonexistent").Return(nil, domain.ErrNotFound)
@@ -102,11 +116,11 @@ func TestForgotPassword_TenantNotFound(t *testing.T) {
 	})
 
 	assert.NoError(t, err) // Returns nil — no leak
-	emailSender.AssertNotCalled(t, "SendPasswordResetEmail")
+	notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
 }
 
 func TestForgotPassword_InactiveUser(t *testing.T) {
-	svc, tenantRepo, userRepo, emailSender := setupPasswordResetService()
+	svc, tenantRepo, userRepo, notifier := setupPasswordResetService()
 	ctx := context.Background()
 	tenantID := uuid.New()
 
@@ -128,11 +142,11 @@ func TestForgotPassword_InactiveUser(t *testing.T) {
 	})
 
 	assert.NoError(t, err) // Returns nil — no leak
-	emailSender.AssertNotCalled(t, "SendPasswordResetEmail")
+	notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
 }
 
 func TestForgotPassword_InactiveTenant(t *testing.T) {
-	svc, tenantRepo, _, emailSender := setupPasswordResetService()
+	svc, tenantRepo, _, notifier := setupPasswordResetService()
 	ctx := context.Background()
 	tenantID := uuid.New()
 
@@ -146,11 +160,11 @@ func TestForgotPassword_InactiveTenant(t *testing.T) {
 	})
 
 	assert.NoError(t, err) // Returns nil — no leak
-	emailSender.AssertNotCalled(t, "SendPasswordResetEmail")
+	notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
 }
 
 func TestForgotPassword_EmailSendFailure(t *testing.T) {
-	svc, tenantRepo, userRepo, emailSender := setupPasswordResetService()
+	svc, tenantRepo, userRepo, notifier := setupPasswordResetService()
 	ctx := context.Background()
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -168,7 +182,7 @@ func TestForgotPassword_EmailSendFailure(t *testing.T) {
 	tenantRepo.On("GetBySlug", ctx, "test-tenant").Return(tenant, nil)
 	userRepo.On("GetByEmail", ctx, tenantID, "user@test.com").Return(user, nil)
 	userRepo.On("SetPasswordResetToken", ctx, tenantID, userID, mock.AnythingOfType("string")).Return(nil)
-	emailSender.On("SendPasswordResetEmail", ctx, "user@test.com", "Test User", mock.AnythingOfType("string")).
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindPasswordReset, "user@test.com")).
 		Return(assert.AnError)
 
 	err := svc.ForgotPassword(ctx, service.ForgotPasswordInput{
@@ -177,18 +191,18 @@ func TestForgotPassword_EmailSendFailure(t *testing.T) {
 	})
 
 	assert.NoError(t, err) // Logs warning but still returns nil
-	emailSender.AssertExpectations(t)
+	notifier.AssertExpectations(t)
 }
 
 func TestResetPassword_Success(t *testing.T) {
 	tenantRepo := new(mocks.MockTenantRepo)
 	userRepo := new(mocks.MockUserRepo)
-	emailSender := new(mocks.MockEmailSender)
+	notifier := new(mocks.MockNotifier)
 	ctx := context.Background()
 	tenantID := uuid.New()
 	userID := uuid.New()
 
-	svc := service.NewPasswordResetService(tenantRepo, userRepo, emailSender, testJWTCfg)
+	svc := service.NewPasswordResetService(tenantRepo, userRepo, auditedSecurityRepo(), notifier, testJWTCfg, testSecurityCfg)
 
 	tenant := &domain.Tenant{ID: tenantID, Slug: "test-tenant", IsActive: true}
 	user := &domain.User{
@@ -210,9 +224,9 @@ func TestResetPassword_Success(t *testing.T) {
 		Run(func(args mock.Arguments) {
 			capturedTokenID = args.Get(3).(string)
 		}).Return(nil)
-	emailSender.On("SendPasswordResetEmail", ctx, "user@test.com", "Test User", mock.AnythingOfType("string")).
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindPasswordReset, "user@test.com")).
 		Run(func(args mock.Arguments) {
-			capturedToken = args.Get(3).(string)
+			capturedToken = args.Get(1).(*port.Notification).Token
 		}).Return(nil)
 
 	// Trigger forgot password to get a valid token
@@ -313,12 +327,12 @@ func TestResetPassword_WrongAudience(t *testing.T) {
 func TestResetPassword_TokenAlreadyUsed(t *testing.T) {
 	tenantRepo := new(mocks.MockTenantRepo)
 	userRepo := new(mocks.MockUserRepo)
-	emailSender := new(mocks.MockEmailSender)
+	notifier := new(mocks.MockNotifier)
 	ctx := context.Background()
 	tenantID := uuid.New()
 	userID := uuid.New()
 
-	svc := service.NewPasswordResetService(tenantRepo, userRepo, emailSender, testJWTCfg)
+	svc := service.NewPasswordResetService(tenantRepo, userRepo, auditedSecurityRepo(), notifier, testJWTCfg, testSecurityCfg)
 
 	tenant := &domain.Tenant{ID: tenantID, Slug: "test-tenant", IsActive: true}
 	user := &domain.User{
@@ -339,9 +353,9 @@ func TestResetPassword_TokenAlreadyUsed(t *testing.T) {
 		Run(func(args mock.Arguments) {
 			capturedTokenID = args.Get(3).(string)
 		}).Return(nil)
-	emailSender.On("SendPasswordResetEmail", ctx, "user@test.com", "Test User", mock.AnythingOfType("string")).
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindPasswordReset, "user@test.com")).
 		Run(func(args mock.Arguments) {
-			capturedToken = args.Get(3).(string)
+			capturedToken = args.Get(1).(*port.Notification).Token
 		}).Return(nil)
 
 	_ = svc.ForgotPassword(ctx, service.ForgotPasswordInput{
@@ -373,12 +387,12 @@ func TestResetPassword_TokenAlreadyUsed(t *testing.T) {
 func TestResetPassword_NewTokenInvalidatesOld(t *testing.T) {
 	tenantRepo := new(mocks.MockTenantRepo)
 	userRepo := new(mocks.MockUserRepo)
-	emailSender := new(mocks.MockEmailSender)
+	notifier := new(mocks.MockNotifier)
 	ctx := context.Background()
 	tenantID := uuid.New()
 	userID := uuid.New()
 
-	svc := service.NewPasswordResetService(tenantRepo, userRepo, emailSender, testJWTCfg)
+	svc := service.NewPasswordResetService(tenantRepo, userRepo, auditedSecurityRepo(), notifier, testJWTCfg, testSecurityCfg)
 
 	tenant := &domain.Tenant{ID: tenantID, Slug: "test-tenant", IsActive: true}
 	user := &domain.User{
@@ -408,13 +422,13 @@ func TestResetPassword_NewTokenInvalidatesOld(t *testing.T) {
 		}).Return(nil)
 
 	emailCallCount := 0
-	emailSender.On("SendPasswordResetEmail", ctx, "user@test.com", "Test User", mock.AnythingOfType("string")).
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindPasswordReset, "user@test.com")).
 		Run(func(args mock.Arguments) {
 			emailCallCount++
 			if emailCallCount == 1 {
-				firstToken = args.Get(3).(string)
+				firstToken = args.Get(1).(*port.Notification).Token
 			} else {
-				secondToken = args.Get(3).(string)
+				secondToken = args.Get(1).(*port.Notification).Token
 			}
 		}).Return(nil)
 
@@ -452,12 +466,12 @@ func TestResetPassword_NewTokenInvalidatesOld(t *testing.T) {
 func TestResetPassword_VerifyBcryptHash(t *testing.T) {
 	tenantRepo := new(mocks.MockTenantRepo)
 	userRepo := new(mocks.MockUserRepo)
-	emailSender := new(mocks.MockEmailSender)
+	notifier := new(mocks.MockNotifier)
 	ctx := context.Background()
 	tenantID := uuid.New()
 	userID := uuid.New()
 
-	svc := service.NewPasswordResetService(tenantRepo, userRepo, emailSender, testJWTCfg)
+	svc := service.NewPasswordResetService(tenantRepo, userRepo, auditedSecurityRepo(), notifier, testJWTCfg, testSecurityCfg)
 
 	tenant := &domain.Tenant{ID: tenantID, Slug: "test-tenant", IsActive: true}
 	user := &domain.User{
@@ -478,9 +492,9 @@ func TestResetPassword_VerifyBcryptHash(t *testing.T) {
 		Run(func(args mock.Arguments) {
 			capturedTokenID = args.Get(3).(string)
 		}).Return(nil)
-	emailSender.On("SendPasswordResetEmail", ctx, "user@test.com", "Test User", mock.AnythingOfType("string")).
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindPasswordReset, "user@test.com")).
 		Run(func(args mock.Arguments) {
-			capturedToken = args.Get(3).(string)
+			capturedToken = args.Get(1).(*port.Notification).Token
 		}).Return(nil)
 
 	_ = svc.ForgotPassword(ctx, service.ForgotPasswordInput{
@@ -507,3 +521,45 @@ func TestResetPassword_VerifyBcryptHash(t *testing.T) {
 	err = bcrypt.CompareHashAndPassword([]byte(capturedHash), []byte(newPassword))
 	assert.NoError(t, err)
 }
+
+func TestInvite_TokenSetsPasswordFromAnyClient(t *testing.T) {
+	svc, _, userRepo, notifier := setupPasswordResetService()
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), TenantID: uuid.New(), Email: "new@test.com", FullName: "New User", Role: domain.RoleMember}
+
+	var tokenID string
+	var sent *port.Notification
+	userRepo.On("SetPasswordResetToken", ctx, user.TenantID, user.ID, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { tokenID = args.Get(3).(string) }).Return(nil)
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindInvitation, "new@test.com")).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*port.Notification) }).Return(nil)
+
+	err := svc.Invite(ctx, service.InviteInput{
+		User: user, TenantName: "Acme", ActorID: uuid.New(),
+		Meta: service.RequestMeta{ClientIP: "10.0.0.1", UserAgent: "admin-browser"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme", sent.TenantName)
+
+	// Token binding is on, but the invitee redeems from their own browser
+	userRepo.On("ResetPassword", ctx, user.TenantID, user.ID, mock.AnythingOfType("string"), tokenID).Return(nil)
+	err = svc.ResetPassword(ctx, service.ResetPasswordInput{
+		Token:       sent.Token,
+		NewPassword: "newpassword123",
+		Meta:        service.RequestMeta{ClientIP: "192.168.1.9", UserAgent: "invitee-browser"},
+	})
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+}
+
+func TestInvite_ReturnsSendFailure(t *testing.T) {
+	svc, _, userRepo, notifier := setupPasswordResetService()
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), TenantID: uuid.New(), Email: "new@test.com"}
+
+	userRepo.On("SetPasswordResetToken", ctx, user.TenantID, user.ID, mock.AnythingOfType("string")).Return(nil)
+	notifier.On("Notify", ctx, mock.Anything).Return(assert.AnError)
+
+	err := svc.Invite(ctx, service.InviteInput{User: user, TenantName: "Acme", ActorID: uuid.New()})
+	assert.ErrorIs(t, err, assert.AnError)
+}
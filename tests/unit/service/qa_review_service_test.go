@@ -0,0 +1,194 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type qaMocks struct {
+	repo          *mocks.MockQAReviewRepo
+	collectionSvc *mocks.MockCollectionService
+	userRepo      *mocks.MockUserRepo
+	auditRepo     *mocks.MockDocumentAuditRepo
+}
+
+func setupQAReviewService() (service.QAReviewService, *qaMocks) {
+	m := &qaMocks{
+		repo:          new(mocks.MockQAReviewRepo),
+		collectionSvc: new(mocks.MockCollectionService),
+		userRepo:      new(mocks.MockUserRepo),
+		auditRepo:     new(mocks.MockDocumentAuditRepo),
+	}
+	return service.NewQAReviewService(m.repo, m.collectionSvc, m.userRepo, m.auditRepo), m
+}
+
+func pendingQAReview() *domain.QAReview {
+	maker := uuid.New()
+	return &domain.QAReview{
+		ID: uuid.New(), TenantID: uuid.New(), DocumentID: uuid.New(), CollectionID: uuid.New(),
+		ReviewerID: uuid.New(), MakerID: &maker, Status: domain.QAReviewStatusPending,
+	}
+}
+
+func TestQAReviewService_SubmitVerdict_DisputedFieldsDisagree(t *testing.T) {
+	svc, m := setupQAReviewService()
+	review := pendingQAReview()
+	second := uuid.New()
+
+	m.repo.On("GetByID", mock.Anything, review.TenantID, review.ID).Return(review, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, review.CollectionID, second, domain.RoleMember).
+		Return(domain.CollectionPermEditor)
+	m.repo.On("Complete", mock.Anything, review).Return(nil)
+	m.auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.DocumentAuditEntry) bool {
+		return e.Action == string(domain.AuditDocumentQAReviewed) && e.DocumentID == review.DocumentID
+	})).Return(nil)
+
+	got, err := svc.SubmitVerdict(context.Background(), &service.SubmitQAVerdictInput{
+		TenantID: review.TenantID, ReviewID: review.ID, UserID: second, Role: domain.RoleMember,
+		Decision: domain.ReviewStatusApproved, DisputedFields: []string{" seller.gstin ", "seller.gstin", "invoice.total_amount"},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, *got.Disagreed)
+	assert.Equal(t, second, *got.SecondReviewerID)
+	assert.JSONEq(t, `["seller.gstin","invoice.total_amount"]`, string(got.DisputedFields))
+	m.auditRepo.AssertExpectations(t)
+}
+
+func TestQAReviewService_SubmitVerdict_CleanApprovalAgrees(t *testing.T) {
+	svc, m := setupQAReviewService()
+	review := pendingQAReview()
+	second := uuid.New()
+
+	m.repo.On("GetByID", mock.Anything, review.TenantID, review.ID).Return(review, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, review.CollectionID, second, domain.RoleManager).
+		Return(domain.CollectionPermOwner)
+	m.repo.On("Complete", mock.Anything, review).Return(nil)
+	m.auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	got, err := svc.SubmitVerdict(context.Background(), &service.SubmitQAVerdictInput{
+		TenantID: review.TenantID, ReviewID: review.ID, UserID: second, Role: domain.RoleManager,
+		Decision: domain.ReviewStatusApproved,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, *got.Disagreed)
+	assert.JSONEq(t, `[]`, string(got.DisputedFields))
+}
+
+func TestQAReviewService_SubmitVerdict_RejectsOwnApproval(t *testing.T) {
+	svc, m := setupQAReviewService()
+	review := pendingQAReview()
+	m.repo.On("GetByID", mock.Anything, review.TenantID, review.ID).Return(review, nil)
+
+	for _, user := range []uuid.UUID{review.ReviewerID, *review.MakerID} {
+		_, err := svc.SubmitVerdict(context.Background(), &service.SubmitQAVerdictInput{
+			TenantID: review.TenantID, ReviewID: review.ID, UserID: user, Role: domain.RoleAdmin,
+			Decision: domain.ReviewStatusRejected,
+		})
+		assert.ErrorIs(t, err, domain.ErrQAOwnReview)
+	}
+	m.repo.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything)
+}
+
+func TestQAReviewService_SubmitVerdict_NeedsEditor(t *testing.T) {
+	svc, m := setupQAReviewService()
+	review := pendingQAReview()
+	second := uuid.New()
+	m.repo.On("GetByID", mock.Anything, review.TenantID, review.ID).Return(review, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, review.CollectionID, second, domain.RoleViewer).
+		Return(domain.CollectionPermViewer)
+
+	_, err := svc.SubmitVerdict(context.Background(), &service.SubmitQAVerdictInput{
+		TenantID: review.TenantID, ReviewID: review.ID, UserID: second, Role: domain.RoleViewer,
+		Decision: domain.ReviewStatusApproved,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+}
+
+func TestQAReviewService_SubmitVerdict_InvalidDecision(t *testing.T) {
+	svc, m := setupQAReviewService()
+
+	_, err := svc.SubmitVerdict(context.Background(), &service.SubmitQAVerdictInput{
+		TenantID: uuid.New(), ReviewID: uuid.New(), UserID: uuid.New(), Role: domain.RoleAdmin,
+		Decision: domain.ReviewStatusPending,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidQAVerdict)
+	m.repo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestQAReviewService_Scores(t *testing.T) {
+	svc, m := setupQAReviewService()
+	tenantID, alice, bob := uuid.New(), uuid.New(), uuid.New()
+	m.repo.On("ScoresByReviewer", mock.Anything, tenantID, mock.AnythingOfType("time.Time")).Return([]domain.QAReviewerScore{
+		{ReviewerID: alice, Sampled: 10, Completed: 8, Disagreements: 2},
+		{ReviewerID: bob, Sampled: 3},
+	}, nil)
+	m.userRepo.On("GetByIDs", mock.Anything, tenantID, []uuid.UUID{alice, bob}).
+		Return([]domain.User{{ID: alice, FullName: "Alice"}}, nil)
+
+	scores, err := svc.Scores(context.Background(), tenantID, 30*24*time.Hour)
+
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+	assert.Equal(t, "Alice", scores[0].ReviewerName)
+	assert.InDelta(t, 0.75, *scores[0].AgreementRate, 1e-9)
+	assert.Nil(t, scores[1].AgreementRate)
+}
+
+func TestQASamplingDocumentRepo_SamplesApprovals(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	reviews := new(mocks.MockQAReviewRepo)
+	collRepo := new(mocks.MockCollectionRepo)
+	repo := service.NewQASamplingDocumentRepo(docRepo, reviews, collRepo)
+
+	percent := 100
+	collection := &domain.Collection{ID: uuid.New(), TenantID: uuid.New(), QASamplePercent: &percent}
+	reviewer, maker := uuid.New(), uuid.New()
+	doc := &domain.Document{
+		ID: uuid.New(), TenantID: collection.TenantID, CollectionID: collection.ID,
+		ReviewStatus: domain.ReviewStatusApproved, ReviewedBy: &reviewer, MakerApprovedBy: &maker,
+	}
+	docRepo.On("UpdateReviewStatus", mock.Anything, doc).Return(nil)
+	collRepo.On("GetByID", mock.Anything, collection.TenantID, collection.ID).Return(collection, nil)
+	reviews.On("Create", mock.Anything, mock.MatchedBy(func(q *domain.QAReview) bool {
+		return q.DocumentID == doc.ID && q.ReviewerID == reviewer && *q.MakerID == maker
+	})).Return(true, nil)
+
+	require.NoError(t, repo.UpdateReviewStatus(context.Background(), doc))
+	reviews.AssertExpectations(t)
+}
+
+func TestQASamplingDocumentRepo_SkipsWhenOff(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	reviews := new(mocks.MockQAReviewRepo)
+	collRepo := new(mocks.MockCollectionRepo)
+	repo := service.NewQASamplingDocumentRepo(docRepo, reviews, collRepo)
+
+	collection := &domain.Collection{ID: uuid.New(), TenantID: uuid.New()}
+	reviewer := uuid.New()
+	approved := &domain.Document{
+		ID: uuid.New(), TenantID: collection.TenantID, CollectionID: collection.ID,
+		ReviewStatus: domain.ReviewStatusApproved, ReviewedBy: &reviewer,
+	}
+	rejected := &domain.Document{ID: uuid.New(), ReviewStatus: domain.ReviewStatusRejected, ReviewedBy: &reviewer}
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.Anything).Return(nil)
+	collRepo.On("GetByID", mock.Anything, collection.TenantID, collection.ID).Return(collection, nil)
+
+	require.NoError(t, repo.UpdateReviewStatus(context.Background(), approved))
+	require.NoError(t, repo.UpdateReviewStatus(context.Background(), rejected))
+	reviews.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	collRepo.AssertNumberOfCalls(t, "GetByID", 1)
+}
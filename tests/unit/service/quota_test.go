@@ -0,0 +1,188 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func quotaUser(tenantID uuid.UUID, used, limit, warned int) *domain.User {
+	return &domain.User{
+		ID: uuid.New(), TenantID: tenantID, Email: "jane@example.com", FullName: "Jane", Role: domain.RoleFree, IsActive: true,
+		MonthlyDocumentLimit: limit, DocumentsUsedThisPeriod: used, QuotaWarningLevel: warned,
+		CurrentPeriodStart: time.Now().Add(-10 * 24 * time.Hour),
+	}
+}
+
+func TestQuotaWarningUserRepo_WarnsUserAndAdminsAt80Percent(t *testing.T) {
+	userRepo, notifier := new(mocks.MockUserRepo), new(mocks.MockNotifier)
+	repo := service.NewQuotaWarningUserRepo(userRepo, notifier)
+	tenantID := uuid.New()
+	user := quotaUser(tenantID, 8, 10, 0)
+	admin := domain.User{ID: uuid.New(), TenantID: tenantID, Email: "admin@example.com", Role: domain.RoleAdmin, IsActive: true}
+
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, tenantID, user.ID).Return(nil)
+	userRepo.On("GetByID", mock.Anything, tenantID, user.ID).Return(user, nil)
+	userRepo.On("ClaimQuotaWarning", mock.Anything, user.ID, 80).Return(true, nil)
+	userRepo.On("ListByTenant", mock.Anything, tenantID, 0, mock.Anything).Return([]domain.User{*user, admin}, 2, nil)
+	var sent *port.Notification
+	notifier.On("Notify", mock.Anything, mock.Anything).Run(func(args mock.Arguments) { sent = args.Get(1).(*port.Notification) }).Return(nil)
+
+	require.NoError(t, repo.CheckAndIncrementQuota(context.Background(), tenantID, user.ID))
+
+	require.NotNil(t, sent)
+	assert.Equal(t, domain.NotificationKindQuotaWarning, sent.Kind)
+	assert.Equal(t, tenantID, sent.TenantID)
+	require.Len(t, sent.Recipients, 2)
+	assert.Equal(t, user.ID, sent.Recipients[0].UserID)
+	assert.Equal(t, admin.ID, sent.Recipients[1].UserID)
+	assert.Contains(t, sent.Subject, "80%")
+	assert.Contains(t, sent.Body, "8 of 10 documents")
+}
+
+func TestQuotaWarningUserRepo_JumpsStraightTo95(t *testing.T) {
+	userRepo, notifier := new(mocks.MockUserRepo), new(mocks.MockNotifier)
+	repo := service.NewQuotaWarningUserRepo(userRepo, notifier)
+	tenantID := uuid.New()
+	user := quotaUser(tenantID, 19, 20, 0)
+
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, tenantID, user.ID).Return(nil)
+	userRepo.On("GetByID", mock.Anything, tenantID, user.ID).Return(user, nil)
+	userRepo.On("ClaimQuotaWarning", mock.Anything, user.ID, 95).Return(true, nil)
+	userRepo.On("ListByTenant", mock.Anything, tenantID, 0, mock.Anything).Return([]domain.User{*user}, 1, nil)
+	notifier.On("Notify", mock.Anything, mock.MatchedBy(func(n *port.Notification) bool {
+		return len(n.Recipients) == 1 && n.Recipients[0].UserID == user.ID
+	})).Return(nil)
+
+	require.NoError(t, repo.CheckAndIncrementQuota(context.Background(), tenantID, user.ID))
+	notifier.AssertExpectations(t)
+}
+
+func TestQuotaWarningUserRepo_WarnsOncePerLevel(t *testing.T) {
+	userRepo, notifier := new(mocks.MockUserRepo), new(mocks.MockNotifier)
+	repo := service.NewQuotaWarningUserRepo(userRepo, notifier)
+	tenantID := uuid.New()
+	user := quotaUser(tenantID, 9, 10, 80)
+
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, tenantID, user.ID).Return(nil)
+	userRepo.On("GetByID", mock.Anything, tenantID, user.ID).Return(user, nil)
+
+	require.NoError(t, repo.CheckAndIncrementQuota(context.Background(), tenantID, user.ID))
+	userRepo.AssertNotCalled(t, "ClaimQuotaWarning", mock.Anything, mock.Anything, mock.Anything)
+	notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func TestQuotaWarningUserRepo_ConcurrentClaimSendsNothing(t *testing.T) {
+	userRepo, notifier := new(mocks.MockUserRepo), new(mocks.MockNotifier)
+	repo := service.NewQuotaWarningUserRepo(userRepo, notifier)
+	tenantID := uuid.New()
+	user := quotaUser(tenantID, 8, 10, 0)
+
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, tenantID, user.ID).Return(nil)
+	userRepo.On("GetByID", mock.Anything, tenantID, user.ID).Return(user, nil)
+	userRepo.On("ClaimQuotaWarning", mock.Anything, user.ID, 80).Return(false, nil)
+
+	require.NoError(t, repo.CheckAndIncrementQuota(context.Background(), tenantID, user.ID))
+	notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func TestQuotaWarningUserRepo_BelowThresholdOrUnlimited(t *testing.T) {
+	for name, user := range map[string]*domain.User{
+		"below 80%": quotaUser(uuid.New(), 7, 10, 0),
+		"unlimited": quotaUser(uuid.New(), 500, 0, 0),
+	} {
+		t.Run(name, func(t *testing.T) {
+			userRepo, notifier := new(mocks.MockUserRepo), new(mocks.MockNotifier)
+			repo := service.NewQuotaWarningUserRepo(userRepo, notifier)
+			userRepo.On("CheckAndIncrementQuota", mock.Anything, user.TenantID, user.ID).Return(nil)
+			userRepo.On("GetByID", mock.Anything, user.TenantID, user.ID).Return(user, nil)
+
+			require.NoError(t, repo.CheckAndIncrementQuota(context.Background(), user.TenantID, user.ID))
+			userRepo.AssertNotCalled(t, "ClaimQuotaWarning", mock.Anything, mock.Anything, mock.Anything)
+			notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestQuotaWarningUserRepo_ExceededPassesThrough(t *testing.T) {
+	userRepo, notifier := new(mocks.MockUserRepo), new(mocks.MockNotifier)
+	repo := service.NewQuotaWarningUserRepo(userRepo, notifier)
+	tenantID, userID := uuid.New(), uuid.New()
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, tenantID, userID).Return(domain.ErrQuotaExceeded)
+
+	err := repo.CheckAndIncrementQuota(context.Background(), tenantID, userID)
+
+	assert.ErrorIs(t, err, domain.ErrQuotaExceeded)
+	userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestQuotaWarningUserRepo_NotifyFailureDoesNotFailUpload(t *testing.T) {
+	userRepo, notifier := new(mocks.MockUserRepo), new(mocks.MockNotifier)
+	repo := service.NewQuotaWarningUserRepo(userRepo, notifier)
+	tenantID := uuid.New()
+	user := quotaUser(tenantID, 10, 10, 80)
+
+	userRepo.On("CheckAndIncrementQuota", mock.Anything, tenantID, user.ID).Return(nil)
+	userRepo.On("GetByID", mock.Anything, tenantID, user.ID).Return(user, nil)
+	userRepo.On("ClaimQuotaWarning", mock.Anything, user.ID, 95).Return(true, nil)
+	userRepo.On("ListByTenant", mock.Anything, tenantID, 0, mock.Anything).Return(nil, 0, errors.New("db down"))
+	notifier.On("Notify", mock.Anything, mock.Anything).Return(errors.New("ses down"))
+
+	assert.NoError(t, repo.CheckAndIncrementQuota(context.Background(), tenantID, user.ID))
+}
+
+func TestQuotaService_Usage(t *testing.T) {
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewQuotaService(userRepo)
+	tenantID := uuid.New()
+	user := quotaUser(tenantID, 4, 5, 0)
+	userRepo.On("GetByID", mock.Anything, tenantID, user.ID).Return(user, nil)
+
+	usage, err := svc.Usage(context.Background(), tenantID, user.ID)
+
+	require.NoError(t, err)
+	require.NotNil(t, usage)
+	assert.Equal(t, 5, usage.Limit)
+	assert.Equal(t, 4, usage.Used)
+	assert.Equal(t, 1, usage.Remaining)
+	assert.Equal(t, user.CurrentPeriodStart.Add(30*24*time.Hour), usage.ResetsAt)
+}
+
+func TestQuotaService_Usage_LapsedPeriodIsFull(t *testing.T) {
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewQuotaService(userRepo)
+	tenantID := uuid.New()
+	user := quotaUser(tenantID, 5, 5, 95)
+	user.CurrentPeriodStart = time.Now().Add(-40 * 24 * time.Hour)
+	userRepo.On("GetByID", mock.Anything, tenantID, user.ID).Return(user, nil)
+
+	usage, err := svc.Usage(context.Background(), tenantID, user.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, usage.Used)
+	assert.Equal(t, 5, usage.Remaining)
+}
+
+func TestQuotaService_Usage_Unlimited(t *testing.T) {
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewQuotaService(userRepo)
+	tenantID := uuid.New()
+	user := quotaUser(tenantID, 40, 0, 0)
+	userRepo.On("GetByID", mock.Anything, tenantID, user.ID).Return(user, nil)
+
+	usage, err := svc.Usage(context.Background(), tenantID, user.ID)
+
+	require.NoError(t, err)
+	assert.Nil(t, usage)
+}
@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -11,18 +12,19 @@ import (
 
 	"satvos/internal/config"
 	"satvos/internal/domain"
+	"satvos/internal/port"
 	"satvos/internal/service"
 	"satvos/mocks"
 )
 
 type registrationTestDeps struct {
-	svc         service.RegistrationService
-	tenantRepo  *mocks.MockTenantRepo
-	userRepo    *mocks.MockUserRepo
-	collRepo    *mocks.MockCollectionRepo
-	permRepo    *mocks.MockCollectionPermissionRepo
-	authSvc     *mocks.MockAuthService
-	emailSender *mocks.MockEmailSender
+	svc        service.RegistrationService
+	tenantRepo *mocks.MockTenantRepo
+	userRepo   *mocks.MockUserRepo
+	collRepo   *mocks.MockCollectionRepo
+	permRepo   *mocks.MockCollectionPermissionRepo
+	authSvc    *mocks.MockAuthService
+	notifier   *mocks.MockNotifier
 }
 
 func setupRegistrationService() registrationTestDeps {
@@ -31,7 +33,7 @@ func setupRegistrationService() registrationTestDeps {
 	collRepo := new(mocks.MockCollectionRepo)
 	permRepo := new(mocks.MockCollectionPermissionRepo)
 	authSvc := new(mocks.MockAuthService)
-	emailSender := new(mocks.MockEmailSender)
+	notifier := new(mocks.MockNotifier)
 
 	jwtCfg := config.JWTConfig{
 		Secret:             "test-secret-key-for-testing-only",
@@ -46,10 +48,17 @@ func setupRegistrationService() registrationTestDeps {
 
 	svc := service.NewRegistrationService(
 		tenantRepo, userRepo, collRepo, permRepo,
-		authSvc, emailSender, jwtCfg, freeTierCfg,
+		authSvc, notifier, jwtCfg, freeTierCfg,
 	)
 
-	return registrationTestDeps{svc, tenantRepo, userRepo, collRepo, permRepo, authSvc, emailSender}
+	return registrationTestDeps{svc, tenantRepo, userRepo, collRepo, permRepo, authSvc, notifier}
+}
+
+// notificationTo matches a notification of kind whose first recipient is email.
+func notificationTo(kind domain.NotificationKind, email string) interface{} {
+	return mock.MatchedBy(func(n *port.Notification) bool {
+		return n.Kind == kind && len(n.Recipients) > 0 && n.Recipients[0].Email == email
+	})
 }
 
 func TestRegistrationService_Register_Success(t *testing.T) {
@@ -79,7 +88,8 @@ func TestRegistrationService_Register_Success(t *testing.T) {
 		ExpiresAt:    time.Now().Add(15 * time.Minute),
 	}
 	d.authSvc.On("Login", ctx, mock.AnythingOfType("service.LoginInput")).Return(tokens, nil)
-	d.emailSender.On("SendVerificationEmail", ctx, "test@example.com", "Test User", mock.AnythingOfType("string")).Return(nil)
+	d.userRepo.On("ClaimVerificationSend", ctx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	d.notifier.On("Notify", ctx, notificationTo(domain.NotificationKindEmailVerification, "test@example.com")).Return(nil)
 
 	output, err := d.svc.Register(ctx, service.RegisterInput{
 		Email:    "test@example.com",
@@ -99,7 +109,7 @@ func TestRegistrationService_Register_Success(t *testing.T) {
 	d.collRepo.AssertExpectations(t)
 	d.permRepo.AssertExpectations(t)
 	d.authSvc.AssertExpectations(t)
-	d.emailSender.AssertExpectations(t)
+	d.notifier.AssertExpectations(t)
 }
 
 func TestRegistrationService_Register_EmailSendFailure_DoesNotFailRegistration(t *testing.T) {
@@ -129,7 +139,8 @@ func TestRegistrationService_Register_EmailSendFailure_DoesNotFailRegistration(t
 		ExpiresAt:    time.Now().Add(15 * time.Minute),
 	}
 	d.authSvc.On("Login", ctx, mock.AnythingOfType("service.LoginInput")).Return(tokens, nil)
-	d.emailSender.On("SendVerificationEmail", ctx, "test@example.com", "Test User", mock.AnythingOfType("string")).
+	d.userRepo.On("ClaimVerificationSend", ctx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	d.notifier.On("Notify", ctx, notificationTo(domain.NotificationKindEmailVerification, "test@example.com")).
 		Return(assert.AnError)
 
 	output, err := d.svc.Register(ctx, service.RegisterInput{
@@ -142,7 +153,7 @@ func TestRegistrationService_Register_EmailSendFailure_DoesNotFailRegistration(t
 	assert.NoError(t, err)
 	assert.NotNil(t, output)
 	assert.False(t, output.User.EmailVerified)
-	d.emailSender.AssertExpectations(t)
+	d.notifier.AssertExpectations(t)
 }
 
 func TestRegistrationService_Register_DuplicateEmail(t *testing.T) {
@@ -188,10 +199,11 @@ func TestRegistrationService_VerifyEmail_Success(t *testing.T) {
 	// We need to generate a valid token. The simplest approach is to use ResendVerification
 	// to get a token via the email sender mock, then call VerifyEmail.
 	var capturedToken string
-	emailSender := new(mocks.MockEmailSender)
-	emailSender.On("SendVerificationEmail", ctx, "test@example.com", "Test User", mock.AnythingOfType("string")).
+	notifier := new(mocks.MockNotifier)
+	d.userRepo.On("ClaimVerificationSend", ctx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindEmailVerification, "test@example.com")).
 		Run(func(args mock.Arguments) {
-			capturedToken = args.Get(3).(string)
+			capturedToken = args.Get(1).(*port.Notification).Token
 		}).
 		Return(nil)
 
@@ -210,7 +222,7 @@ func TestRegistrationService_VerifyEmail_Success(t *testing.T) {
 
 	svc := service.NewRegistrationService(
 		tenantRepo, d.userRepo, collRepo, permRepo,
-		authSvc, emailSender, jwtCfg, freeTierCfg,
+		authSvc, notifier, jwtCfg, freeTierCfg,
 	)
 
 	// Resend to capture the token
@@ -250,9 +262,10 @@ func TestRegistrationService_VerifyEmail_AlreadyVerified(t *testing.T) {
 	// Capture token via resend
 	var capturedToken string
 	d.userRepo.On("GetByID", ctx, tenantID, userID).Return(user, nil).Once()
-	d.emailSender.On("SendVerificationEmail", ctx, "test@example.com", "Test User", mock.AnythingOfType("string")).
+	d.userRepo.On("ClaimVerificationSend", ctx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	d.notifier.On("Notify", ctx, notificationTo(domain.NotificationKindEmailVerification, "test@example.com")).
 		Run(func(args mock.Arguments) {
-			capturedToken = args.Get(3).(string)
+			capturedToken = args.Get(1).(*port.Notification).Token
 		}).
 		Return(nil)
 
@@ -270,7 +283,7 @@ func TestRegistrationService_VerifyEmail_AlreadyVerified(t *testing.T) {
 
 	svc := service.NewRegistrationService(
 		tenantRepo, d.userRepo, collRepo, permRepo,
-		authSvc, d.emailSender, jwtCfg, freeTierCfg,
+		authSvc, d.notifier, jwtCfg, freeTierCfg,
 	)
 
 	err := svc.ResendVerification(ctx, tenantID, userID)
@@ -308,11 +321,12 @@ func TestRegistrationService_ResendVerification_Success(t *testing.T) {
 		EmailVerified: false,
 	}
 	d.userRepo.On("GetByID", ctx, tenantID, userID).Return(user, nil)
-	d.emailSender.On("SendVerificationEmail", ctx, "test@example.com", "Test User", mock.AnythingOfType("string")).Return(nil)
+	d.userRepo.On("ClaimVerificationSend", ctx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	d.notifier.On("Notify", ctx, notificationTo(domain.NotificationKindEmailVerification, "test@example.com")).Return(nil)
 
 	err := d.svc.ResendVerification(ctx, tenantID, userID)
 	assert.NoError(t, err)
-	d.emailSender.AssertExpectations(t)
+	d.notifier.AssertExpectations(t)
 }
 
 func TestRegistrationService_ResendVerification_AlreadyVerified(t *testing.T) {
@@ -335,3 +349,47 @@ func TestRegistrationService_ResendVerification_AlreadyVerified(t *testing.T) {
 	assert.NoError(t, err)
 	// Email sender should NOT be called
 }
+
+func TestRegistrationService_ResendVerification_TooSoon(t *testing.T) {
+	d := setupRegistrationService()
+	ctx := context.Background()
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	user := &domain.User{ID: userID, TenantID: tenantID, Email: "test@example.com", FullName: "Test User"}
+	d.userRepo.On("GetByID", ctx, tenantID, userID).Return(user, nil)
+	d.userRepo.On("ClaimVerificationSend", ctx, tenantID, userID, mock.AnythingOfType("time.Time")).Return(false, nil)
+
+	err := d.svc.ResendVerification(ctx, tenantID, userID)
+	assert.ErrorIs(t, err, domain.ErrVerificationResendTooSoon)
+	d.notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
+
+func TestRegistrationService_SendVerificationReminders(t *testing.T) {
+	userRepo := new(mocks.MockUserRepo)
+	notifier := new(mocks.MockNotifier)
+	svc := service.NewRegistrationService(
+		new(mocks.MockTenantRepo), userRepo, new(mocks.MockCollectionRepo), new(mocks.MockCollectionPermissionRepo),
+		new(mocks.MockAuthService), notifier,
+		config.JWTConfig{Secret: "test-secret-key-for-testing-only", Issuer: "satvos-test"},
+		config.FreeTierConfig{TenantSlug: "satvos", VerificationReminderAfterHours: 24},
+	)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	users := []domain.User{
+		{ID: uuid.New(), TenantID: uuid.New(), Email: "a@example.com", FullName: "A"},
+		{ID: uuid.New(), TenantID: uuid.New(), Email: "b@example.com", FullName: "B"},
+	}
+	userRepo.On("ClaimVerificationReminders", ctx, now.Add(-24*time.Hour), 100).Return(users, nil)
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindEmailVerification, "a@example.com")).Return(nil)
+	notifier.On("Notify", ctx, notificationTo(domain.NotificationKindEmailVerification, "b@example.com")).
+		Return(errors.New("ses throttled"))
+
+	sent, err := svc.SendVerificationReminders(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	userRepo.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
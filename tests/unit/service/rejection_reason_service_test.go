@@ -0,0 +1,207 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestRejectionReasonService_List_MergesOverridesAndCustomReasons(t *testing.T) {
+	repo := new(mocks.MockRejectionReasonRepo)
+	svc := service.NewRejectionReasonService(repo)
+	tenantID := uuid.New()
+
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.RejectionReason{
+		{TenantID: tenantID, Code: "illegible", Label: "Blurry scan", Active: true, SortOrder: 20},
+		{TenantID: tenantID, Code: "duplicate", Label: "Duplicate", Active: false, SortOrder: 40},
+		{TenantID: tenantID, Code: "po_mismatch", Label: "PO mismatch", Active: true, SortOrder: 15},
+	}, nil)
+
+	active, err := svc.List(context.Background(), tenantID, false)
+	require.NoError(t, err)
+	codes := make([]string, 0, len(active))
+	for _, r := range active {
+		codes = append(codes, r.Code)
+	}
+	assert.Equal(t, []string{"wrong_amount", "po_mismatch", "illegible", "not_our_invoice", "wrong_party_details", "missing_fields", "other"}, codes)
+	assert.Equal(t, "Blurry scan", active[2].Label)
+	assert.True(t, active[2].Overridden)
+	assert.False(t, active[0].Overridden)
+
+	all, err := svc.List(context.Background(), tenantID, true)
+	require.NoError(t, err)
+	assert.Len(t, all, len(domain.DefaultRejectionReasons)+1)
+}
+
+func TestRejectionReasonService_Set(t *testing.T) {
+	repo := new(mocks.MockRejectionReasonRepo)
+	svc := service.NewRejectionReasonService(repo)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.RejectionReason{}, nil)
+	repo.On("Upsert", mock.Anything, mock.AnythingOfType("*domain.RejectionReason")).Return(nil)
+
+	inactive := false
+	reason, err := svc.Set(context.Background(), tenantID, "duplicate",
+		&service.SetRejectionReasonInput{Label: "  Duplicate submission ", Active: &inactive}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "Duplicate submission", reason.Label)
+	assert.False(t, reason.Active)
+	assert.Equal(t, 40, reason.SortOrder, "built-in codes keep their position")
+	assert.True(t, reason.Overridden)
+
+	reason, err = svc.Set(context.Background(), tenantID, "po_mismatch",
+		&service.SetRejectionReasonInput{Label: "PO mismatch"}, userID)
+	require.NoError(t, err)
+	assert.True(t, reason.Active)
+	assert.Equal(t, 500, reason.SortOrder, "new codes sort after the defaults")
+}
+
+func TestRejectionReasonService_Set_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		code  string
+		input service.SetRejectionReasonInput
+	}{
+		{"uppercase code", "Wrong", service.SetRejectionReasonInput{Label: "Wrong"}},
+		{"blank label", "blank", service.SetRejectionReasonInput{Label: "   "}},
+		{"long label", "long", service.SetRejectionReasonInput{Label: string(make([]byte, 101))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockRejectionReasonRepo)
+			svc := service.NewRejectionReasonService(repo)
+
+			_, err := svc.Set(context.Background(), uuid.New(), tt.code, &tt.input, uuid.New())
+
+			assert.ErrorIs(t, err, domain.ErrInvalidRejectionReason)
+			repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRejectionReasonService_Stats_Labels(t *testing.T) {
+	repo := new(mocks.MockRejectionReasonRepo)
+	svc := service.NewRejectionReasonService(repo)
+	tenantID := uuid.New()
+	filters := &domain.ReportFilters{}
+
+	repo.On("Stats", mock.Anything, tenantID, filters).Return([]domain.RejectionReasonStat{
+		{ReasonCode: "illegible", ParserModel: "gemini-2.0-flash", RejectedCount: 6, SharePct: 60},
+		{ReasonCode: "", ParserModel: "gemini-2.0-flash", RejectedCount: 3, SharePct: 30},
+		{ReasonCode: "po_mismatch", ParserModel: "claude-sonnet-4", RejectedCount: 1, SharePct: 10},
+	}, nil)
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.RejectionReason{
+		{TenantID: tenantID, Code: "po_mismatch", Label: "PO mismatch", Active: false, SortOrder: 500},
+	}, nil)
+
+	stats, err := svc.Stats(context.Background(), tenantID, filters)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Illegible", stats[0].Label)
+	assert.Equal(t, "Unspecified", stats[1].Label)
+	assert.Equal(t, "PO mismatch", stats[2].Label, "retired reasons keep their label")
+}
+
+// setupRejectionReview returns a document service with a rejection-reason
+// repository whose tenant has retired "duplicate".
+func setupRejectionReview(t *testing.T) (service.DocumentService, *mocks.MockDocumentRepo, *mocks.MockDocumentAuditRepo, *domain.Document) {
+	t.Helper()
+	docRepo := new(mocks.MockDocumentRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	auditRepo := new(mocks.MockDocumentAuditRepo)
+	reasonRepo := new(mocks.MockRejectionReasonRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:    docRepo,
+		FileRepo:   new(mocks.MockFileMetaRepo),
+		UserRepo:   new(mocks.MockUserRepo),
+		PermRepo:   permRepo,
+		Parser:     new(mocks.MockDocumentParser),
+		Storage:    new(mocks.MockObjectStorage),
+		AuditRepo:  auditRepo,
+		ReasonRepo: reasonRepo,
+	})
+
+	doc := &domain.Document{
+		ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(),
+		ParsingStatus: domain.ParsingStatusCompleted, ReviewStatus: domain.ReviewStatusPending,
+	}
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	docRepo.On("GetByID", mock.Anything, doc.TenantID, doc.ID).Return(doc, nil)
+	reasonRepo.On("ListByTenant", mock.Anything, doc.TenantID).Return([]domain.RejectionReason{
+		{TenantID: doc.TenantID, Code: "duplicate", Label: "Duplicate", Active: false, SortOrder: 40},
+	}, nil).Maybe()
+	return svc, docRepo, auditRepo, doc
+}
+
+func TestDocumentService_UpdateReview_RejectionReasonValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{"missing", "", domain.ErrRejectionReasonRequired},
+		{"unknown", "bad_vibes", domain.ErrInvalidRejectionReason},
+		{"retired", "duplicate", domain.ErrInvalidRejectionReason},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, docRepo, _, doc := setupRejectionReview(t)
+
+			_, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+				TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+				Status: domain.ReviewStatusRejected, ReasonCode: tt.code,
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			docRepo.AssertNotCalled(t, "UpdateReviewStatus", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDocumentService_UpdateReview_RejectionReasonStoredAndAudited(t *testing.T) {
+	svc, docRepo, auditRepo, doc := setupRejectionReview(t)
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	var entry *domain.DocumentAuditEntry
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).
+		Run(func(args mock.Arguments) { entry = args.Get(1).(*domain.DocumentAuditEntry) }).Return(nil)
+
+	result, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+		Status: domain.ReviewStatusRejected, ReasonCode: "wrong_amount", Notes: "CGST doubled",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "wrong_amount", result.RejectionReason)
+	require.NotNil(t, entry)
+	var changes map[string]interface{}
+	require.NoError(t, json.Unmarshal(entry.Changes, &changes))
+	assert.Equal(t, "wrong_amount", changes["reason_code"])
+}
+
+func TestDocumentService_UpdateReview_ApprovalClearsRejectionReason(t *testing.T) {
+	svc, docRepo, auditRepo, doc := setupRejectionReview(t)
+	doc.ReviewStatus = domain.ReviewStatusRejected
+	doc.RejectionReason = "illegible"
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil)
+
+	result, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: uuid.New(), Role: domain.RoleAdmin,
+		Status: domain.ReviewStatusApproved, ReasonCode: "illegible",
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.RejectionReason)
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestRelatedPartyService_Set(t *testing.T) {
+	repo := new(mocks.MockRelatedPartyRepo)
+	svc := service.NewRelatedPartyService(repo, new(mocks.MockDocumentTagRepo))
+	tenantID, userID := uuid.New(), uuid.New()
+
+	repo.On("FindRelatedParty", mock.Anything, tenantID, "29ABCDE1234F1Z5").Return(nil, domain.ErrNotFound)
+	repo.On("ListByTenant", mock.Anything, tenantID).Return([]domain.RelatedParty{}, nil)
+	repo.On("Upsert", mock.Anything, mock.MatchedBy(func(p *domain.RelatedParty) bool {
+		return p.GSTIN == "29ABCDE1234F1Z5" && p.Name == "Acme Components" && p.Relationship == domain.RelatedPartyGroup
+	})).Return(nil)
+
+	party, err := svc.Set(context.Background(), tenantID, " 29abcde1234f1z5 ", &service.SetRelatedPartyInput{
+		Name: " Acme Components ", Relationship: domain.RelatedPartyGroup,
+	}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "29ABCDE1234F1Z5", party.GSTIN)
+	assert.Equal(t, &userID, party.UpdatedBy)
+	repo.AssertExpectations(t)
+}
+
+func TestRelatedPartyService_Set_Invalid(t *testing.T) {
+	tenantID := uuid.New()
+	tests := []struct {
+		name  string
+		gstin string
+		input service.SetRelatedPartyInput
+	}{
+		{"malformed GSTIN", "29ABCDE1234", service.SetRelatedPartyInput{Name: "Acme", Relationship: domain.RelatedPartyGroup}},
+		{"blank name", "29ABCDE1234F1Z5", service.SetRelatedPartyInput{Name: "  ", Relationship: domain.RelatedPartyGroup}},
+		{"unknown relationship", "29ABCDE1234F1Z5", service.SetRelatedPartyInput{Name: "Acme", Relationship: "vendor"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockRelatedPartyRepo)
+			svc := service.NewRelatedPartyService(repo, new(mocks.MockDocumentTagRepo))
+			_, err := svc.Set(context.Background(), tenantID, tt.gstin, &tt.input, uuid.New())
+			assert.True(t, errors.Is(err, domain.ErrInvalidRelatedParty))
+			repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func relatedPartyEvent(t *testing.T, sellerGSTIN string) *service.DocumentEvent {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"seller": map[string]string{"gstin": sellerGSTIN}})
+	require.NoError(t, err)
+	return &service.DocumentEvent{
+		Kind:     service.DocumentEventParsed,
+		Document: &domain.Document{ID: uuid.New(), TenantID: uuid.New(), StructuredData: data},
+	}
+}
+
+func TestRelatedPartyService_TagDocument_TagsRelatedSeller(t *testing.T) {
+	repo := new(mocks.MockRelatedPartyRepo)
+	tagRepo := new(mocks.MockDocumentTagRepo)
+	svc := service.NewRelatedPartyService(repo, tagRepo)
+	e := relatedPartyEvent(t, "29ABCDE1234F1Z5")
+
+	repo.On("FindRelatedParty", mock.Anything, e.Document.TenantID, "29ABCDE1234F1Z5").
+		Return(&domain.RelatedParty{GSTIN: "29ABCDE1234F1Z5", Relationship: domain.RelatedPartyOwn}, nil)
+	tagRepo.On("DeleteByKeyValue", mock.Anything, e.Document.ID, service.RelatedPartyTagKey, "group").Return(0, nil)
+	tagRepo.On("AddIfMissing", mock.Anything, mock.MatchedBy(func(tag *domain.DocumentTag) bool {
+		return tag.DocumentID == e.Document.ID && tag.Key == service.RelatedPartyTagKey && tag.Value == "own" && tag.Source == "auto"
+	})).Return(true, nil)
+
+	svc.TagDocument(context.Background(), e)
+	tagRepo.AssertExpectations(t)
+}
+
+func TestRelatedPartyService_TagDocument_RemovesStaleTag(t *testing.T) {
+	repo := new(mocks.MockRelatedPartyRepo)
+	tagRepo := new(mocks.MockDocumentTagRepo)
+	svc := service.NewRelatedPartyService(repo, tagRepo)
+	e := relatedPartyEvent(t, "29FGHIJ5678K1Z2")
+
+	repo.On("FindRelatedParty", mock.Anything, e.Document.TenantID, "29FGHIJ5678K1Z2").Return(nil, domain.ErrNotFound)
+	tagRepo.On("DeleteByKeyValue", mock.Anything, e.Document.ID, service.RelatedPartyTagKey, "own").Return(0, nil)
+	tagRepo.On("DeleteByKeyValue", mock.Anything, e.Document.ID, service.RelatedPartyTagKey, "group").Return(1, nil)
+
+	svc.TagDocument(context.Background(), e)
+	tagRepo.AssertExpectations(t)
+	tagRepo.AssertNotCalled(t, "AddIfMissing", mock.Anything, mock.Anything)
+}
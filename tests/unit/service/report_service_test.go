@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestReportService_SearchByAmount_PercentTolerance(t *testing.T) {
+	repo := new(mocks.MockReportRepo)
+	svc := service.NewReportService(repo)
+	tenantID := uuid.New()
+	filters := &domain.ReportFilters{Limit: 20}
+	expected := []domain.AmountMatchRow{{DocumentID: uuid.New(), TotalAmount: 11800, Difference: 0}}
+	repo.On("AmountMatches", mock.Anything, tenantID, 11800.0, 11682.0, 11918.0, "", filters).Return(expected, 1, nil)
+
+	rows, total, err := svc.SearchByAmount(context.Background(), tenantID, 11800, domain.AmountTolerance{Value: 1, Percent: true}, "", filters)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, rows)
+	assert.Equal(t, 1, total)
+	repo.AssertExpectations(t)
+}
+
+func TestReportService_SearchByAmount_AbsoluteToleranceAndParty(t *testing.T) {
+	repo := new(mocks.MockReportRepo)
+	svc := service.NewReportService(repo)
+	tenantID := uuid.New()
+	filters := &domain.ReportFilters{Limit: 20}
+	repo.On("AmountMatches", mock.Anything, tenantID, 5000.5, 4950.5, 5050.5, "29AABCT1332L1ZP", filters).Return([]domain.AmountMatchRow{}, 0, nil)
+
+	_, _, err := svc.SearchByAmount(context.Background(), tenantID, 5000.5, domain.AmountTolerance{Value: 50}, "  29AABCT1332L1ZP ", filters)
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestReportService_SearchByAmount_ExactMatch(t *testing.T) {
+	repo := new(mocks.MockReportRepo)
+	svc := service.NewReportService(repo)
+	tenantID := uuid.New()
+	filters := &domain.ReportFilters{Limit: 20}
+	repo.On("AmountMatches", mock.Anything, tenantID, 118.0, 118.0, 118.0, "", filters).Return([]domain.AmountMatchRow{}, 0, nil)
+
+	_, _, err := svc.SearchByAmount(context.Background(), tenantID, 118, domain.AmountTolerance{}, "", filters)
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestReportService_SearchByAmount_Invalid(t *testing.T) {
+	cases := map[string]struct {
+		amount    float64
+		tolerance domain.AmountTolerance
+	}{
+		"zero amount":        {amount: 0},
+		"negative amount":    {amount: -10},
+		"negative tolerance": {amount: 100, tolerance: domain.AmountTolerance{Value: -1}},
+		"percent over 100":   {amount: 100, tolerance: domain.AmountTolerance{Value: 150, Percent: true}},
+		"NaN amount":         {amount: math.NaN()},
+		"infinite amount":    {amount: math.Inf(1)},
+		"NaN tolerance":      {amount: 100, tolerance: domain.AmountTolerance{Value: math.NaN()}},
+		"infinite tolerance": {amount: 100, tolerance: domain.AmountTolerance{Value: math.Inf(1)}},
+		"infinite percent":   {amount: 100, tolerance: domain.AmountTolerance{Value: math.Inf(-1), Percent: true}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			repo := new(mocks.MockReportRepo)
+			svc := service.NewReportService(repo)
+
+			_, _, err := svc.SearchByAmount(context.Background(), uuid.New(), tc.amount, tc.tolerance, "", &domain.ReportFilters{})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidAmountSearch)
+			repo.AssertNotCalled(t, "AmountMatches", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
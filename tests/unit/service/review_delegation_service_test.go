@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestReviewDelegationService_Set_Success(t *testing.T) {
+	repo := new(mocks.MockReviewDelegationRepo)
+	userRepo := new(mocks.MockUserRepo)
+	svc := service.NewReviewDelegationService(repo, userRepo)
+	tenantID, userID, delegateID := uuid.New(), uuid.New(), uuid.New()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	userRepo.On("GetByID", mock.Anything, tenantID, delegateID).
+		Return(&domain.User{ID: delegateID, Role: domain.RoleMember, IsActive: true}, nil)
+	repo.On("Upsert", mock.Anything, mock.AnythingOfType("*domain.ReviewDelegation")).Return(nil)
+
+	d, err := svc.Set(context.Background(), &service.SetDelegationInput{
+		TenantID: tenantID, UserID: userID, DelegateID: delegateID,
+		StartsOn: today, EndsOn: today.AddDate(0, 0, 7), ShareQueue: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, delegateID, d.DelegateID)
+	assert.True(t, d.ActiveOn(time.Now()))
+	assert.False(t, d.ActiveOn(today.AddDate(0, 0, 8)))
+}
+
+func TestReviewDelegationService_Set_Invalid(t *testing.T) {
+	tenantID, userID, delegateID := uuid.New(), uuid.New(), uuid.New()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	cases := map[string]struct {
+		delegateID   uuid.UUID
+		starts, ends time.Time
+		delegate     *domain.User
+	}{
+		"self":           {userID, today, today, nil},
+		"reversed range": {delegateID, today.AddDate(0, 0, 2), today, nil},
+		"ended":          {delegateID, today.AddDate(0, 0, -5), today.AddDate(0, 0, -1), nil},
+		"viewer delegate": {delegateID, today, today.AddDate(0, 0, 1),
+			&domain.User{ID: delegateID, Role: domain.RoleViewer, IsActive: true}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			repo := new(mocks.MockReviewDelegationRepo)
+			userRepo := new(mocks.MockUserRepo)
+			svc := service.NewReviewDelegationService(repo, userRepo)
+			if tc.delegate != nil {
+				userRepo.On("GetByID", mock.Anything, tenantID, tc.delegateID).Return(tc.delegate, nil)
+			}
+
+			_, err := svc.Set(context.Background(), &service.SetDelegationInput{
+				TenantID: tenantID, UserID: userID, DelegateID: tc.delegateID, StartsOn: tc.starts, EndsOn: tc.ends,
+			})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidDelegation)
+			repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// activeDelegationTo returns a delegation from userID to delegateID covering today.
+func activeDelegationTo(tenantID, userID, delegateID uuid.UUID, shareQueue bool) *domain.ReviewDelegation {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return &domain.ReviewDelegation{
+		TenantID: tenantID, UserID: userID, DelegateID: delegateID,
+		StartsOn: today.AddDate(0, 0, -1), EndsOn: today.AddDate(0, 0, 1), ShareQueue: shareQueue,
+	}
+}
+
+func TestDocumentService_AssignDocument_RoutesToDelegate(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	userRepo := new(mocks.MockUserRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	auditRepo := new(mocks.MockDocumentAuditRepo)
+	delegationRepo := new(mocks.MockReviewDelegationRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:        docRepo,
+		FileRepo:       new(mocks.MockFileMetaRepo),
+		UserRepo:       userRepo,
+		PermRepo:       permRepo,
+		Parser:         new(mocks.MockDocumentParser),
+		Storage:        new(mocks.MockObjectStorage),
+		AuditRepo:      auditRepo,
+		DelegationRepo: delegationRepo,
+	})
+
+	doc := &domain.Document{ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(), ParsingStatus: domain.ParsingStatusCompleted}
+	callerID, assigneeID, delegateID := uuid.New(), uuid.New(), uuid.New()
+
+	docRepo.On("GetByID", mock.Anything, doc.TenantID, doc.ID).Return(doc, nil)
+	docRepo.On("UpdateAssignment", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found"))
+	userRepo.On("GetByID", mock.Anything, doc.TenantID, assigneeID).Return(&domain.User{ID: assigneeID, Role: domain.RoleManager}, nil)
+	userRepo.On("GetByID", mock.Anything, doc.TenantID, delegateID).Return(&domain.User{ID: delegateID, Role: domain.RoleManager}, nil)
+	delegationRepo.On("GetByUser", mock.Anything, doc.TenantID, assigneeID).
+		Return(activeDelegationTo(doc.TenantID, assigneeID, delegateID, false), nil)
+	var entry *domain.DocumentAuditEntry
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).
+		Run(func(args mock.Arguments) { entry = args.Get(1).(*domain.DocumentAuditEntry) }).Return(nil)
+
+	result, err := svc.AssignDocument(context.Background(), &service.AssignDocumentInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, CallerID: callerID, CallerRole: domain.RoleAdmin, AssigneeID: &assigneeID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &delegateID, result.AssignedTo)
+	require.NotNil(t, entry)
+	var changes map[string]interface{}
+	require.NoError(t, json.Unmarshal(entry.Changes, &changes))
+	assert.Equal(t, assigneeID.String(), changes["delegated_from"])
+}
+
+func TestDocumentService_ListReviewQueue_IncludesSharedDelegatorQueues(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	delegationRepo := new(mocks.MockReviewDelegationRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:        docRepo,
+		FileRepo:       new(mocks.MockFileMetaRepo),
+		UserRepo:       new(mocks.MockUserRepo),
+		PermRepo:       new(mocks.MockCollectionPermissionRepo),
+		Parser:         new(mocks.MockDocumentParser),
+		Storage:        new(mocks.MockObjectStorage),
+		DelegationRepo: delegationRepo,
+	})
+	tenantID, userID, sharedID, privateID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	delegationRepo.On("ListActiveForDelegate", mock.Anything, tenantID, userID, mock.AnythingOfType("time.Time")).
+		Return([]domain.ReviewDelegation{
+			*activeDelegationTo(tenantID, sharedID, userID, true),
+			*activeDelegationTo(tenantID, privateID, userID, false),
+		}, nil)
+	docRepo.On("ListReviewQueue", mock.Anything, tenantID, []uuid.UUID{userID, sharedID}, 0, 20).
+		Return([]domain.Document{}, 0, nil)
+
+	_, _, err := svc.ListReviewQueue(context.Background(), tenantID, userID, 0, 20)
+
+	require.NoError(t, err)
+	docRepo.AssertExpectations(t)
+}
+
+func TestDocumentService_UpdateReview_AuditsDelegateOnBehalfOfAssignee(t *testing.T) {
+	docRepo := new(mocks.MockDocumentRepo)
+	permRepo := new(mocks.MockCollectionPermissionRepo)
+	auditRepo := new(mocks.MockDocumentAuditRepo)
+	delegationRepo := new(mocks.MockReviewDelegationRepo)
+	svc := service.NewDocumentService(&service.DocumentServiceDeps{
+		DocRepo:        docRepo,
+		FileRepo:       new(mocks.MockFileMetaRepo),
+		UserRepo:       new(mocks.MockUserRepo),
+		PermRepo:       permRepo,
+		Parser:         new(mocks.MockDocumentParser),
+		Storage:        new(mocks.MockObjectStorage),
+		AuditRepo:      auditRepo,
+		DelegationRepo: delegationRepo,
+	})
+	assigneeID, delegateID := uuid.New(), uuid.New()
+	doc := &domain.Document{
+		ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(), AssignedTo: &assigneeID,
+		ParsingStatus: domain.ParsingStatusCompleted, ReviewStatus: domain.ReviewStatusPending,
+	}
+
+	docRepo.On("GetByID", mock.Anything, doc.TenantID, doc.ID).Return(doc, nil)
+	docRepo.On("UpdateReviewStatus", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	permRepo.On("GetByCollectionAndUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not found")).Maybe()
+	delegationRepo.On("GetByUser", mock.Anything, doc.TenantID, assigneeID).
+		Return(activeDelegationTo(doc.TenantID, assigneeID, delegateID, true), nil)
+	var entry *domain.DocumentAuditEntry
+	auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).
+		Run(func(args mock.Arguments) { entry = args.Get(1).(*domain.DocumentAuditEntry) }).Return(nil)
+
+	_, err := svc.UpdateReview(context.Background(), &service.UpdateReviewInput{
+		TenantID: doc.TenantID, DocumentID: doc.ID, ReviewerID: delegateID, Role: domain.RoleManager,
+		Status: domain.ReviewStatusApproved,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, &delegateID, entry.UserID)
+	var changes map[string]interface{}
+	require.NoError(t, json.Unmarshal(entry.Changes, &changes))
+	assert.Equal(t, assigneeID.String(), changes["on_behalf_of"])
+}
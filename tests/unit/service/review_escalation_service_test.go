@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type escalationMocks struct {
+	repo          *mocks.MockReviewEscalationRepo
+	docRepo       *mocks.MockDocumentRepo
+	collRepo      *mocks.MockCollectionRepo
+	permRepo      *mocks.MockCollectionPermissionRepo
+	userRepo      *mocks.MockUserRepo
+	auditRepo     *mocks.MockDocumentAuditRepo
+	notifications *mocks.MockNotificationService
+}
+
+func setupReviewEscalationService() (service.ReviewEscalationService, *escalationMocks) {
+	m := &escalationMocks{
+		repo:          new(mocks.MockReviewEscalationRepo),
+		docRepo:       new(mocks.MockDocumentRepo),
+		collRepo:      new(mocks.MockCollectionRepo),
+		permRepo:      new(mocks.MockCollectionPermissionRepo),
+		userRepo:      new(mocks.MockUserRepo),
+		auditRepo:     new(mocks.MockDocumentAuditRepo),
+		notifications: new(mocks.MockNotificationService),
+	}
+	svc := service.NewReviewEscalationService(m.repo, m.docRepo, m.collRepo, m.permRepo, m.userRepo, m.auditRepo, m.notifications)
+	return svc, m
+}
+
+// escalationFixture returns a collection with the given action and one of its
+// documents parsed four days ago.
+func escalationFixture(action domain.EscalationAction) (*domain.Collection, domain.Document) {
+	days := 3
+	collection := &domain.Collection{
+		ID: uuid.New(), TenantID: uuid.New(), CreatedBy: uuid.New(),
+		EscalateAfterDays: &days, EscalationAction: action,
+	}
+	parsedAt := time.Now().UTC().Add(-96 * time.Hour)
+	doc := domain.Document{
+		ID: uuid.New(), TenantID: collection.TenantID, CollectionID: collection.ID, Name: "stale.pdf",
+		ParsingStatus: domain.ParsingStatusCompleted, ReviewStatus: domain.ReviewStatusPending, ParsedAt: &parsedAt,
+	}
+	return collection, doc
+}
+
+func captureEscalationAudit(m *escalationMocks) map[string]interface{} {
+	changes := map[string]interface{}{}
+	m.auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).
+		Run(func(args mock.Arguments) {
+			entry := args.Get(1).(*domain.DocumentAuditEntry)
+			if entry.Action == string(domain.AuditDocumentEscalated) {
+				_ = json.Unmarshal(entry.Changes, &changes)
+			}
+		}).Return(nil)
+	return changes
+}
+
+func TestReviewEscalationService_RunDue_Flag(t *testing.T) {
+	svc, m := setupReviewEscalationService()
+	collection, doc := escalationFixture(domain.EscalationActionFlag)
+
+	m.repo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Document{doc}, nil)
+	m.collRepo.On("GetByID", mock.Anything, collection.TenantID, collection.ID).Return(collection, nil)
+	changes := captureEscalationAudit(m)
+	m.notifications.On("NotifyDocument", domain.NotificationEventReviewEscalated,
+		mock.MatchedBy(func(d *domain.Document) bool { return d.ID == doc.ID })).Return()
+
+	require.NoError(t, svc.RunDue(context.Background()))
+
+	assert.Equal(t, "flag", changes["action"])
+	assert.EqualValues(t, 3, changes["after_days"])
+	assert.EqualValues(t, 96, changes["waiting_hours"])
+	m.docRepo.AssertNotCalled(t, "UpdateAssignment", mock.Anything, mock.Anything)
+	m.notifications.AssertExpectations(t)
+}
+
+func TestReviewEscalationService_RunDue_ReassignsToCreator(t *testing.T) {
+	svc, m := setupReviewEscalationService()
+	collection, doc := escalationFixture(domain.EscalationActionReassign)
+	previous := uuid.New()
+	doc.AssignedTo = &previous
+
+	m.repo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Document{doc}, nil)
+	m.collRepo.On("GetByID", mock.Anything, collection.TenantID, collection.ID).Return(collection, nil)
+	m.permRepo.On("GetByCollectionAndUser", mock.Anything, collection.ID, collection.CreatedBy).
+		Return(ownerPerm(collection.ID, collection.CreatedBy), nil)
+	m.userRepo.On("GetByID", mock.Anything, collection.TenantID, collection.CreatedBy).
+		Return(&domain.User{ID: collection.CreatedBy, IsActive: true}, nil)
+	m.docRepo.On("UpdateAssignment", mock.Anything, mock.MatchedBy(func(d *domain.Document) bool {
+		return d.AssignedTo != nil && *d.AssignedTo == collection.CreatedBy && d.AssignedBy == nil
+	})).Return(nil)
+	changes := captureEscalationAudit(m)
+	m.notifications.On("NotifyDocument", domain.NotificationEventReviewEscalated, mock.Anything).Return()
+
+	require.NoError(t, svc.RunDue(context.Background()))
+
+	assert.Equal(t, "reassign", changes["action"])
+	assert.Equal(t, collection.CreatedBy.String(), changes["assigned_to"])
+	assert.Equal(t, previous.String(), changes["previous_assignee"])
+	m.docRepo.AssertExpectations(t)
+}
+
+func TestReviewEscalationService_RunDue_NoActiveOwnerFallsBackToFlag(t *testing.T) {
+	svc, m := setupReviewEscalationService()
+	collection, doc := escalationFixture(domain.EscalationActionReassign)
+	otherOwner := uuid.New()
+
+	m.repo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Document{doc}, nil)
+	m.collRepo.On("GetByID", mock.Anything, collection.TenantID, collection.ID).Return(collection, nil)
+	m.permRepo.On("GetByCollectionAndUser", mock.Anything, collection.ID, collection.CreatedBy).
+		Return(nil, errors.New("not found"))
+	m.permRepo.On("ListByCollection", mock.Anything, collection.ID, 0, mock.Anything).
+		Return([]domain.CollectionPermissionEntry{*ownerPerm(collection.ID, otherOwner)}, 1, nil)
+	m.userRepo.On("GetByID", mock.Anything, collection.TenantID, otherOwner).
+		Return(&domain.User{ID: otherOwner, IsActive: false}, nil)
+	changes := captureEscalationAudit(m)
+	m.notifications.On("NotifyDocument", domain.NotificationEventReviewEscalated, mock.Anything).Return()
+
+	require.NoError(t, svc.RunDue(context.Background()))
+
+	assert.Equal(t, "flag", changes["action"])
+	m.docRepo.AssertNotCalled(t, "UpdateAssignment", mock.Anything, mock.Anything)
+}
+
+func TestReviewEscalationService_ListEscalated_OwnedOnlyForMembers(t *testing.T) {
+	svc, m := setupReviewEscalationService()
+	tenantID, userID := uuid.New(), uuid.New()
+	m.repo.On("ListEscalated", mock.Anything, tenantID, userID, true, 0, 20).Return([]domain.Document{}, 0, nil)
+	m.repo.On("ListEscalated", mock.Anything, tenantID, userID, false, 0, 20).Return([]domain.Document{}, 0, nil)
+
+	_, _, err := svc.ListEscalated(context.Background(), tenantID, userID, domain.RoleMember, 0, 20)
+	require.NoError(t, err)
+	_, _, err = svc.ListEscalated(context.Background(), tenantID, userID, domain.RoleManager, 0, 20)
+	require.NoError(t, err)
+
+	m.repo.AssertExpectations(t)
+}
@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestCachingStatsService_ServesRepeatsFromCache(t *testing.T) {
+	inner := new(mocks.MockStatsService)
+	svc := service.NewCachingStatsService(inner, time.Minute)
+	ctx := context.Background()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	inner.On("GetStats", mock.Anything, tenantID, userID, domain.RoleAdmin).Return(&domain.Stats{TotalDocuments: 7}, nil).Once()
+
+	for i := 0; i < 3; i++ {
+		stats, err := svc.GetStats(ctx, tenantID, userID, domain.RoleAdmin)
+		require.NoError(t, err)
+		assert.Equal(t, 7, stats.TotalDocuments)
+	}
+	inner.AssertExpectations(t)
+}
+
+func TestCachingStatsService_KeysOnArguments(t *testing.T) {
+	inner := new(mocks.MockStatsService)
+	svc := service.NewCachingStatsService(inner, time.Minute)
+	ctx := context.Background()
+	tenantID, otherTenant, userID := uuid.New(), uuid.New(), uuid.New()
+
+	inner.On("GetStats", mock.Anything, tenantID, userID, domain.RoleAdmin).Return(&domain.Stats{TotalDocuments: 1}, nil).Once()
+	inner.On("GetStats", mock.Anything, tenantID, userID, domain.RoleViewer).Return(&domain.Stats{TotalDocuments: 2}, nil).Once()
+	inner.On("GetStats", mock.Anything, otherTenant, userID, domain.RoleAdmin).Return(&domain.Stats{TotalDocuments: 3}, nil).Once()
+
+	a, _ := svc.GetStats(ctx, tenantID, userID, domain.RoleAdmin)
+	b, _ := svc.GetStats(ctx, tenantID, userID, domain.RoleViewer)
+	c, _ := svc.GetStats(ctx, otherTenant, userID, domain.RoleAdmin)
+
+	assert.Equal(t, []int{1, 2, 3}, []int{a.TotalDocuments, b.TotalDocuments, c.TotalDocuments})
+	inner.AssertExpectations(t)
+}
+
+func TestCachingStatsService_CoalescesConcurrentQueries(t *testing.T) {
+	inner := new(mocks.MockStatsService)
+	// A zero TTL caches nothing, so only coalescing can share the result
+	svc := service.NewCachingStatsService(inner, 0)
+	tenantID := uuid.New()
+	release := make(chan struct{})
+
+	inner.On("GetParseLatency", mock.Anything, tenantID, time.Hour).
+		Run(func(mock.Arguments) { <-release }).
+		Return([]domain.ParseLatencyStats{{ParserModel: "gemini"}}, nil).Once()
+
+	const callers = 8
+	var wg sync.WaitGroup
+	results := make([][]domain.ParseLatencyStats, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = svc.GetParseLatency(context.Background(), tenantID, time.Hour)
+		}(i)
+	}
+	// Let every caller join the in-flight query before it returns
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for _, r := range results {
+		require.Len(t, r, 1)
+		assert.Equal(t, "gemini", r[0].ParserModel)
+	}
+	inner.AssertExpectations(t)
+}
+
+func TestCachingStatsService_DoesNotCacheErrors(t *testing.T) {
+	inner := new(mocks.MockStatsService)
+	svc := service.NewCachingStatsService(inner, time.Minute)
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	inner.On("GetParseLatency", mock.Anything, tenantID, time.Hour).Return(nil, errors.New("db down")).Once()
+	inner.On("GetParseLatency", mock.Anything, tenantID, time.Hour).Return([]domain.ParseLatencyStats{}, nil).Once()
+
+	_, err := svc.GetParseLatency(ctx, tenantID, time.Hour)
+	require.Error(t, err)
+	_, err = svc.GetParseLatency(ctx, tenantID, time.Hour)
+	require.NoError(t, err)
+	inner.AssertExpectations(t)
+}
+
+func TestCachingStatsService_CancelledCallerDoesNotFailOthers(t *testing.T) {
+	inner := new(mocks.MockStatsService)
+	svc := service.NewCachingStatsService(inner, time.Minute)
+	tenantID, userID := uuid.New(), uuid.New()
+	release := make(chan struct{})
+
+	inner.On("GetStats", mock.Anything, tenantID, userID, domain.RoleAdmin).
+		Run(func(args mock.Arguments) {
+			<-release
+			assert.NoError(t, args.Get(0).(context.Context).Err())
+		}).
+		Return(&domain.Stats{TotalDocuments: 4}, nil).Once()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := svc.GetStats(cancelled, tenantID, userID, domain.RoleAdmin)
+		firstErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	done := make(chan *domain.Stats, 1)
+	go func() {
+		stats, _ := svc.GetStats(context.Background(), tenantID, userID, domain.RoleAdmin)
+		done <- stats
+	}()
+	close(release)
+	stats := <-done
+	require.NotNil(t, stats)
+	assert.Equal(t, 4, stats.TotalDocuments)
+	inner.AssertExpectations(t)
+}
+
+func TestCachingStatsService_RecountInvalidatesTenant(t *testing.T) {
+	inner := new(mocks.MockStatsService)
+	svc := service.NewCachingStatsService(inner, time.Minute)
+	ctx := context.Background()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	inner.On("GetStats", mock.Anything, tenantID, userID, domain.RoleAdmin).Return(&domain.Stats{TotalDocuments: 1}, nil).Once()
+	inner.On("Recount", ctx, tenantID).Return(&domain.StatsRecount{TenantID: tenantID}, nil)
+	inner.On("GetStats", mock.Anything, tenantID, userID, domain.RoleAdmin).Return(&domain.Stats{TotalDocuments: 2}, nil).Once()
+
+	before, _ := svc.GetStats(ctx, tenantID, userID, domain.RoleAdmin)
+	_, err := svc.Recount(ctx, tenantID)
+	require.NoError(t, err)
+	after, _ := svc.GetStats(ctx, tenantID, userID, domain.RoleAdmin)
+
+	assert.Equal(t, 1, before.TotalDocuments)
+	assert.Equal(t, 2, after.TotalDocuments)
+	inner.AssertExpectations(t)
+}
+
+func TestCachingReportService_CachesRowsAndTotal(t *testing.T) {
+	inner := new(mocks.MockReportService)
+	svc := service.NewCachingReportService(inner, time.Minute)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	filters := &domain.ReportFilters{UserID: uuid.New(), UserRole: domain.RoleManager, Limit: 20}
+
+	inner.On("SellerSummary", mock.Anything, tenantID, filters).
+		Return([]domain.SellerSummaryRow{{SellerGSTIN: "29ABCDE1234F1Z5"}}, 41, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		// An equal filter from another request shares the cached result
+		same := *filters
+		rows, total, err := svc.SellerSummary(ctx, tenantID, &same)
+		require.NoError(t, err)
+		assert.Equal(t, 41, total)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "29ABCDE1234F1Z5", rows[0].SellerGSTIN)
+	}
+	inner.AssertExpectations(t)
+}
+
+func TestCachingReportService_SearchByAmountPassesThrough(t *testing.T) {
+	inner := new(mocks.MockReportService)
+	svc := service.NewCachingReportService(inner, time.Minute)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	filters := &domain.ReportFilters{}
+	tolerance := domain.AmountTolerance{}
+
+	inner.On("SearchByAmount", ctx, tenantID, 1180.0, tolerance, "", filters).Return([]domain.AmountMatchRow{}, 0, nil).Twice()
+
+	for i := 0; i < 2; i++ {
+		_, _, err := svc.SearchByAmount(ctx, tenantID, 1180, tolerance, "", filters)
+		require.NoError(t, err)
+	}
+	inner.AssertExpectations(t)
+}
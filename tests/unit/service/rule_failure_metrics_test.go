@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestRuleOutcomeTrackingDocumentRepo_RecordsOneOutcomePerRule(t *testing.T) {
+	inner := new(mocks.MockDocumentRepo)
+	outcomes := new(mocks.MockValidationRuleOutcomeRepo)
+	repo := service.NewRuleOutcomeTrackingDocumentRepo(inner, outcomes)
+
+	lineItems, gstin := uuid.New(), uuid.New()
+	results, _ := json.Marshal([]map[string]interface{}{
+		{"rule_id": lineItems, "passed": true, "field_path": "line_items[0].hsn_sac_code"},
+		{"rule_id": lineItems, "passed": false, "field_path": "line_items[1].hsn_sac_code"},
+		{"rule_id": gstin, "passed": true, "field_path": "seller.gstin"},
+	})
+	doc := &domain.Document{ID: uuid.New(), TenantID: uuid.New(), DocumentType: "invoice", ValidationResults: results}
+	inner.On("UpdateValidationResults", mock.Anything, doc).Return(nil)
+	outcomes.On("Record", mock.Anything, []domain.ValidationRuleOutcome{
+		{TenantID: doc.TenantID, DocumentID: doc.ID, RuleID: lineItems, DocumentType: "invoice", FieldPath: "line_items[1].hsn_sac_code", Failing: true},
+		{TenantID: doc.TenantID, DocumentID: doc.ID, RuleID: gstin, DocumentType: "invoice", FieldPath: "seller.gstin"},
+	}).Return(nil)
+
+	require.NoError(t, repo.UpdateValidationResults(context.Background(), doc))
+	outcomes.AssertExpectations(t)
+}
+
+func TestRuleOutcomeTrackingDocumentRepo_RecordFailureDoesNotFailWrite(t *testing.T) {
+	inner := new(mocks.MockDocumentRepo)
+	outcomes := new(mocks.MockValidationRuleOutcomeRepo)
+	repo := service.NewRuleOutcomeTrackingDocumentRepo(inner, outcomes)
+
+	doc := &domain.Document{ID: uuid.New(), ValidationResults: json.RawMessage(`[{"rule_id":"` + uuid.NewString() + `","passed":false}]`)}
+	inner.On("UpdateValidationResults", mock.Anything, doc).Return(nil)
+	outcomes.On("Record", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	assert.NoError(t, repo.UpdateValidationResults(context.Background(), doc))
+}
+
+func TestRuleOutcomeTrackingDocumentRepo_ApprovalMarksOverridden(t *testing.T) {
+	inner := new(mocks.MockDocumentRepo)
+	outcomes := new(mocks.MockValidationRuleOutcomeRepo)
+	repo := service.NewRuleOutcomeTrackingDocumentRepo(inner, outcomes)
+
+	approved := &domain.Document{ID: uuid.New(), TenantID: uuid.New(), ReviewStatus: domain.ReviewStatusApproved}
+	rejected := &domain.Document{ID: uuid.New(), TenantID: uuid.New(), ReviewStatus: domain.ReviewStatusRejected}
+	inner.On("UpdateReviewStatus", mock.Anything, mock.Anything).Return(nil)
+	outcomes.On("MarkOverridden", mock.Anything, approved.TenantID, approved.ID).Return(nil).Once()
+
+	require.NoError(t, repo.UpdateReviewStatus(context.Background(), approved))
+	require.NoError(t, repo.UpdateReviewStatus(context.Background(), rejected))
+	outcomes.AssertExpectations(t)
+}
+
+func TestStatsService_GetRuleNoise_RanksAndLimits(t *testing.T) {
+	outcomeRepo := new(mocks.MockValidationRuleOutcomeRepo)
+	svc := service.NewStatsService(new(mocks.MockStatsRepo), nil, nil, outcomeRepo)
+
+	tenantID := uuid.New()
+	quiet, noisy, misparsed, clean := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	outcomeRepo.On("StatsByRule", mock.Anything, tenantID, "invoice", mock.AnythingOfType("time.Time")).
+		Return([]domain.RuleFailureStats{
+			{RuleID: quiet, Evaluations: 100, Failures: 5, Overrides: 1},
+			{RuleID: noisy, Evaluations: 100, Failures: 40, Overrides: 36},
+			{RuleID: misparsed, Evaluations: 100, Failures: 20, Overrides: 2, Corrections: 18},
+			{RuleID: clean, Evaluations: 100},
+		}, nil)
+
+	report, err := svc.GetRuleNoise(context.Background(), tenantID, "invoice", 90*24*time.Hour, service.RuleNoiseByScore, 10)
+	require.NoError(t, err)
+	require.Len(t, report, 3, "rules that never failed are left out")
+	assert.Equal(t, noisy, report[0].RuleID)
+	assert.InDelta(t, 0.4, report[0].FailureRate, 1e-9)
+	assert.InDelta(t, 0.9, report[0].OverrideRate, 1e-9)
+	assert.InDelta(t, 0.36, report[0].NoiseScore, 1e-9)
+
+	report, err = svc.GetRuleNoise(context.Background(), tenantID, "invoice", 90*24*time.Hour, service.RuleNoiseByCorrections, 1)
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	assert.Equal(t, misparsed, report[0].RuleID)
+	assert.InDelta(t, 0.9, report[0].CorrectionRate, 1e-9)
+}
@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/internal/validator"
+	"satvos/mocks"
+)
+
+func setupRuleSimulationService() (service.RuleSimulationService, *mocks.MockDocumentRepo, *mocks.MockCollectionService) {
+	docRepo := new(mocks.MockDocumentRepo)
+	collectionSvc := new(mocks.MockCollectionService)
+	engine := validator.NewEngine(validator.NewRegistry(), new(mocks.MockDocumentValidationRuleRepo), docRepo)
+	return service.NewRuleSimulationService(docRepo, engine, collectionSvc), docRepo, collectionSvc
+}
+
+func requiredBuyerPANRule() validator.ProposedRule {
+	return validator.ProposedRule{
+		RuleType:   domain.ValidationRuleRequired,
+		RuleConfig: json.RawMessage(`{"field_path":"buyer.pan"}`),
+	}
+}
+
+func TestRuleSimulationService_Simulate_SkipsUnparsedAndOtherTypes(t *testing.T) {
+	svc, docRepo, _ := setupRuleSimulationService()
+	tenantID := uuid.New()
+	parsed := domain.Document{ID: uuid.New(), DocumentType: "invoice", ParsingStatus: domain.ParsingStatusCompleted,
+		ValidationStatus: domain.ValidationStatusValid, StructuredData: json.RawMessage(`{}`)}
+	pending := domain.Document{ID: uuid.New(), DocumentType: "invoice", ParsingStatus: domain.ParsingStatusPending}
+	receipt := domain.Document{ID: uuid.New(), DocumentType: "receipt", ParsingStatus: domain.ParsingStatusCompleted,
+		StructuredData: json.RawMessage(`{}`)}
+	docRepo.On("ListByTenant", mock.Anything, tenantID, (*uuid.UUID)(nil), 0, 100).
+		Return([]domain.Document{parsed, pending, receipt}, 3, nil)
+
+	result, err := svc.Simulate(context.Background(), &service.RuleSimulationInput{
+		TenantID: tenantID, Role: domain.RoleAdmin, DocumentType: "invoice", Rule: requiredBuyerPANRule(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.DocumentsEvaluated)
+	assert.Equal(t, 1, result.WouldFail)
+	assert.False(t, result.Truncated)
+	docRepo.AssertNotCalled(t, "UpdateValidationResults", mock.Anything, mock.Anything)
+}
+
+func TestRuleSimulationService_Simulate_CapsDocuments(t *testing.T) {
+	svc, docRepo, _ := setupRuleSimulationService()
+	tenantID := uuid.New()
+	page := make([]domain.Document, 100)
+	docRepo.On("ListByTenant", mock.Anything, tenantID, (*uuid.UUID)(nil), mock.AnythingOfType("int"), 100).
+		Return(page, 5000, nil)
+
+	result, err := svc.Simulate(context.Background(), &service.RuleSimulationInput{
+		TenantID: tenantID, Role: domain.RoleAdmin, DocumentType: "invoice", Rule: requiredBuyerPANRule(),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Truncated)
+	docRepo.AssertNumberOfCalls(t, "ListByTenant", 10)
+}
+
+func TestRuleSimulationService_Simulate_Collection(t *testing.T) {
+	svc, docRepo, collectionSvc := setupRuleSimulationService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	collectionSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.RoleAdmin).
+		Return(nil, domain.ErrCollectionNotFound)
+
+	_, err := svc.Simulate(context.Background(), &service.RuleSimulationInput{
+		TenantID: tenantID, UserID: userID, Role: domain.RoleAdmin, DocumentType: "invoice",
+		CollectionID: &collectionID, Rule: requiredBuyerPANRule(),
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCollectionNotFound)
+	docRepo.AssertNotCalled(t, "ListByCollection", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type starMocks struct {
+	starRepo       *mocks.MockStarRepo
+	docRepo        *mocks.MockDocumentRepo
+	collectionRepo *mocks.MockCollectionRepo
+	collectionSvc  *mocks.MockCollectionService
+}
+
+func setupStarService() (service.StarService, *starMocks) {
+	m := &starMocks{
+		starRepo:       new(mocks.MockStarRepo),
+		docRepo:        new(mocks.MockDocumentRepo),
+		collectionRepo: new(mocks.MockCollectionRepo),
+		collectionSvc:  new(mocks.MockCollectionService),
+	}
+	return service.NewStarService(m.starRepo, m.docRepo, m.collectionRepo, m.collectionSvc), m
+}
+
+func TestStarService_StarDocument_Success(t *testing.T) {
+	svc, m := setupStarService()
+	tenantID, userID, docID, collID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	m.docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{ID: docID, CollectionID: collID}, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collID, userID, domain.RoleViewer).Return(domain.CollectionPermViewer)
+	m.starRepo.On("StarDocument", mock.Anything, tenantID, userID, docID).Return(nil)
+
+	err := svc.StarDocument(context.Background(), tenantID, docID, userID, domain.RoleViewer)
+
+	require.NoError(t, err)
+	m.starRepo.AssertExpectations(t)
+}
+
+func TestStarService_StarDocument_NoAccess(t *testing.T) {
+	svc, m := setupStarService()
+	tenantID, userID, docID, collID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	m.docRepo.On("GetByID", mock.Anything, tenantID, docID).Return(&domain.Document{ID: docID, CollectionID: collID}, nil)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collID, userID, domain.RoleFree).Return(domain.CollectionPermission(""))
+
+	err := svc.StarDocument(context.Background(), tenantID, docID, userID, domain.RoleFree)
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	m.starRepo.AssertNotCalled(t, "StarDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStarService_StarCollection_NotFound(t *testing.T) {
+	svc, m := setupStarService()
+	tenantID, userID, collID := uuid.New(), uuid.New(), uuid.New()
+
+	m.collectionRepo.On("GetByID", mock.Anything, tenantID, collID).Return(nil, domain.ErrCollectionNotFound)
+
+	err := svc.StarCollection(context.Background(), tenantID, collID, userID, domain.RoleAdmin)
+
+	assert.ErrorIs(t, err, domain.ErrCollectionNotFound)
+	m.starRepo.AssertNotCalled(t, "StarCollection", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStarService_ListDocuments_ExplicitOnlyForViewers(t *testing.T) {
+	svc, m := setupStarService()
+	tenantID, userID := uuid.New(), uuid.New()
+	docs := []domain.Document{{ID: uuid.New()}}
+
+	m.starRepo.On("ListStarredDocuments", mock.Anything, tenantID, userID, true, 0, 20).Return(docs, 1, nil)
+	m.starRepo.On("ListStarredDocuments", mock.Anything, tenantID, userID, false, 0, 20).Return(docs, 1, nil)
+
+	_, total, err := svc.ListDocuments(context.Background(), tenantID, userID, domain.RoleViewer, 0, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	_, _, err = svc.ListDocuments(context.Background(), tenantID, userID, domain.RoleMember, 0, 20)
+	require.NoError(t, err)
+
+	m.starRepo.AssertExpectations(t)
+}
@@ -0,0 +1,141 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func newTestStatsRefresher() (*service.StatsRefresher, *mocks.MockStatsRepo, *mocks.MockTenantRepo) {
+	statsRepo := new(mocks.MockStatsRepo)
+	tenantRepo := new(mocks.MockTenantRepo)
+	r := service.NewStatsRefresher(statsRepo, tenantRepo, service.StatsRefreshConfig{
+		Interval:         time.Second,
+		ReconcileHourUTC: 2,
+	})
+	return r, statsRepo, tenantRepo
+}
+
+func TestStatsRefresher_Flush_RecountsEachDirtyBucketOnce(t *testing.T) {
+	r, statsRepo, _ := newTestStatsRefresher()
+
+	tenantID, collectionID := uuid.New(), uuid.New()
+	// Two documents created on the same UTC day share a bucket
+	r.MarkDirty(&domain.Document{TenantID: tenantID, CollectionID: collectionID,
+		CreatedAt: time.Date(2026, 3, 4, 1, 0, 0, 0, time.UTC)})
+	r.MarkDirty(&domain.Document{TenantID: tenantID, CollectionID: collectionID,
+		CreatedAt: time.Date(2026, 3, 4, 23, 59, 0, 0, time.UTC)})
+	r.MarkDirty(&domain.Document{TenantID: tenantID, CollectionID: collectionID,
+		CreatedAt: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)})
+
+	statsRepo.On("RefreshDay", mock.Anything, tenantID, collectionID, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)).
+		Return(nil).Once()
+	statsRepo.On("RefreshDay", mock.Anything, tenantID, collectionID, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)).
+		Return(nil).Once()
+
+	require.NoError(t, r.Flush(context.Background()))
+	require.NoError(t, r.Flush(context.Background())) // nothing left dirty
+
+	statsRepo.AssertExpectations(t)
+}
+
+func TestStatsRefresher_Flush_FailedBucketStaysDirty(t *testing.T) {
+	r, statsRepo, _ := newTestStatsRefresher()
+
+	doc := &domain.Document{TenantID: uuid.New(), CollectionID: uuid.New(), CreatedAt: time.Now()}
+	r.MarkDirty(doc)
+
+	statsRepo.On("RefreshDay", mock.Anything, doc.TenantID, doc.CollectionID, mock.Anything).
+		Return(errors.New("db down")).Once()
+	statsRepo.On("RefreshDay", mock.Anything, doc.TenantID, doc.CollectionID, mock.Anything).
+		Return(nil).Once()
+
+	assert.Error(t, r.Flush(context.Background()))
+	assert.NoError(t, r.Flush(context.Background()))
+
+	statsRepo.AssertNumberOfCalls(t, "RefreshDay", 2)
+}
+
+func TestStatsRefresher_Tick_ReconcilesOncePerDayAfterHour(t *testing.T) {
+	r, statsRepo, tenantRepo := newTestStatsRefresher()
+
+	tenantA, tenantB := uuid.New(), uuid.New()
+	tenantRepo.On("List", mock.Anything, 0, 100).
+		Return([]domain.Tenant{{ID: tenantA}, {ID: tenantB}}, 2, nil)
+	statsRepo.On("ReconcileTenant", mock.Anything, tenantA).Return([]domain.StatsDiscrepancy{}, nil)
+	statsRepo.On("ReconcileTenant", mock.Anything, tenantB).Return([]domain.StatsDiscrepancy{{CollectionID: uuid.New(), Materialized: 3, Actual: 2}}, nil)
+	statsRepo.On("SnapshotQuality", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	today := time.Now().UTC()
+	tomorrow := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	// Start-up day is considered reconciled; before the hour nothing runs either
+	require.NoError(t, r.Tick(context.Background(), today))
+	require.NoError(t, r.Tick(context.Background(), tomorrow.Add(1*time.Hour)))
+	statsRepo.AssertNotCalled(t, "ReconcileTenant", mock.Anything, mock.Anything)
+
+	require.NoError(t, r.Tick(context.Background(), tomorrow.Add(2*time.Hour)))
+	require.NoError(t, r.Tick(context.Background(), tomorrow.Add(5*time.Hour)))
+
+	statsRepo.AssertNumberOfCalls(t, "ReconcileTenant", 2)
+	statsRepo.AssertNumberOfCalls(t, "SnapshotQuality", 2)
+}
+
+func TestStatsTrackingDocumentRepo_MarksWrittenDocuments(t *testing.T) {
+	r, statsRepo, _ := newTestStatsRefresher()
+	inner := new(mocks.MockDocumentRepo)
+	repo := service.NewStatsTrackingDocumentRepo(inner, r)
+
+	validated := &domain.Document{ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(), CreatedAt: time.Now()}
+	deleted := &domain.Document{ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(), CreatedAt: time.Now()}
+
+	inner.On("UpdateValidationResults", mock.Anything, validated).Return(nil)
+	inner.On("GetByID", mock.Anything, deleted.TenantID, deleted.ID).Return(deleted, nil)
+	inner.On("Delete", mock.Anything, deleted.TenantID, deleted.ID).Return(nil)
+	statsRepo.On("RefreshDay", mock.Anything, validated.TenantID, validated.CollectionID, mock.Anything).Return(nil).Once()
+	statsRepo.On("RefreshDay", mock.Anything, deleted.TenantID, deleted.CollectionID, mock.Anything).Return(nil).Once()
+
+	require.NoError(t, repo.UpdateValidationResults(context.Background(), validated))
+	require.NoError(t, repo.Delete(context.Background(), deleted.TenantID, deleted.ID))
+	require.NoError(t, r.Flush(context.Background()))
+
+	statsRepo.AssertExpectations(t)
+}
+
+func TestStatsTrackingDocumentRepo_FailedWriteNotMarked(t *testing.T) {
+	r, statsRepo, _ := newTestStatsRefresher()
+	inner := new(mocks.MockDocumentRepo)
+	repo := service.NewStatsTrackingDocumentRepo(inner, r)
+
+	doc := &domain.Document{ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New()}
+	inner.On("UpdateValidationResults", mock.Anything, doc).Return(domain.ErrDocumentNotFound)
+
+	assert.ErrorIs(t, repo.UpdateValidationResults(context.Background(), doc), domain.ErrDocumentNotFound)
+	require.NoError(t, r.Flush(context.Background()))
+
+	statsRepo.AssertNotCalled(t, "RefreshDay", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStatsRefresher_MarkDocumentDirty(t *testing.T) {
+	r, statsRepo, _ := newTestStatsRefresher()
+	events := service.NewDocumentEvents()
+	events.Subscribe("stats", r.MarkDocumentDirty, service.DocumentEventReviewed)
+
+	doc := &domain.Document{ID: uuid.New(), TenantID: uuid.New(), CollectionID: uuid.New(), CreatedAt: time.Now()}
+	statsRepo.On("RefreshDay", mock.Anything, doc.TenantID, doc.CollectionID, mock.Anything).Return(nil).Once()
+
+	events.Publish(context.Background(), &service.DocumentEvent{Kind: service.DocumentEventReviewed, Document: doc})
+	require.NoError(t, r.Flush(context.Background()))
+
+	statsRepo.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -16,7 +17,7 @@ import (
 
 func TestStatsService_GetStats_AdminCallsTenantStats(t *testing.T) {
 	mockRepo := new(mocks.MockStatsRepo)
-	svc := service.NewStatsService(mockRepo)
+	svc := service.NewStatsService(mockRepo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -32,7 +33,7 @@ func TestStatsService_GetStats_AdminCallsTenantStats(t *testing.T) {
 
 func TestStatsService_GetStats_ManagerCallsTenantStats(t *testing.T) {
 	mockRepo := new(mocks.MockStatsRepo)
-	svc := service.NewStatsService(mockRepo)
+	svc := service.NewStatsService(mockRepo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -48,7 +49,7 @@ func TestStatsService_GetStats_ManagerCallsTenantStats(t *testing.T) {
 
 func TestStatsService_GetStats_MemberCallsTenantStats(t *testing.T) {
 	mockRepo := new(mocks.MockStatsRepo)
-	svc := service.NewStatsService(mockRepo)
+	svc := service.NewStatsService(mockRepo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -64,7 +65,7 @@ func TestStatsService_GetStats_MemberCallsTenantStats(t *testing.T) {
 
 func TestStatsService_GetStats_ViewerCallsUserStats(t *testing.T) {
 	mockRepo := new(mocks.MockStatsRepo)
-	svc := service.NewStatsService(mockRepo)
+	svc := service.NewStatsService(mockRepo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -80,7 +81,7 @@ func TestStatsService_GetStats_ViewerCallsUserStats(t *testing.T) {
 
 func TestStatsService_GetStats_RepoError(t *testing.T) {
 	mockRepo := new(mocks.MockStatsRepo)
-	svc := service.NewStatsService(mockRepo)
+	svc := service.NewStatsService(mockRepo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -95,7 +96,7 @@ func TestStatsService_GetStats_RepoError(t *testing.T) {
 
 func TestStatsService_GetStats_ViewerRepoError(t *testing.T) {
 	mockRepo := new(mocks.MockStatsRepo)
-	svc := service.NewStatsService(mockRepo)
+	svc := service.NewStatsService(mockRepo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -107,3 +108,72 @@ func TestStatsService_GetStats_ViewerRepoError(t *testing.T) {
 	assert.Nil(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestStatsService_Recount_ReportsDiscrepancies(t *testing.T) {
+	mockRepo := new(mocks.MockStatsRepo)
+	svc := service.NewStatsService(mockRepo, nil, nil, nil)
+
+	tenantID := uuid.New()
+	drift := []domain.StatsDiscrepancy{{CollectionID: uuid.New(), Materialized: 12, Actual: 9}}
+	mockRepo.On("ReconcileTenant", mock.Anything, tenantID).Return(drift, nil)
+
+	result, err := svc.Recount(context.Background(), tenantID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, tenantID, result.TenantID)
+	assert.Equal(t, drift, result.Discrepancies)
+	assert.False(t, result.RecountedAt.IsZero())
+}
+
+func TestStatsService_Recount_RepoError(t *testing.T) {
+	mockRepo := new(mocks.MockStatsRepo)
+	svc := service.NewStatsService(mockRepo, nil, nil, nil)
+
+	tenantID := uuid.New()
+	mockRepo.On("ReconcileTenant", mock.Anything, tenantID).Return(nil, errors.New("db error"))
+
+	result, err := svc.Recount(context.Background(), tenantID)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestStatsService_GetConfidenceCalibration_BuildsCurvePerModel(t *testing.T) {
+	observationRepo := new(mocks.MockConfidenceObservationRepo)
+	svc := service.NewStatsService(new(mocks.MockStatsRepo), nil, observationRepo, nil)
+
+	tenantID := uuid.New()
+	observationRepo.On("BucketsByModel", mock.Anything, tenantID, "invoice", mock.AnythingOfType("time.Time")).
+		Return([]domain.CalibrationBucketStats{
+			{ParserModel: "claude-sonnet-4", Bucket: 9, Fields: 100, Corrected: 2, MeanConfidence: 0.97},
+			{ParserModel: "gemini-2.0-flash", Bucket: 3, Fields: 10, Corrected: 5, MeanConfidence: 0.35},
+			{ParserModel: "gemini-2.0-flash", Bucket: 8, Fields: 40, Corrected: 4, MeanConfidence: 0.84},
+		}, nil)
+
+	report, err := svc.GetConfidenceCalibration(context.Background(), tenantID, "invoice", 90*24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Len(t, report, 2)
+	gemini := report[1]
+	assert.Equal(t, "gemini-2.0-flash", gemini.ParserModel)
+	assert.Equal(t, 50, gemini.Fields)
+	assert.InDelta(t, 0.82, gemini.Accuracy, 1e-9)
+	assert.Len(t, gemini.Buckets, 10)
+	assert.Equal(t, 0.8, gemini.Buckets[8].MinConfidence)
+	assert.InDelta(t, 0.9, *gemini.Buckets[8].Accuracy, 1e-9)
+	assert.Nil(t, gemini.Buckets[0].Accuracy, "empty buckets have no accuracy")
+	assert.InDelta(t, 0.98, *report[0].Buckets[9].Accuracy, 1e-9)
+}
+
+func TestStatsService_GetConfidenceCalibration_Empty(t *testing.T) {
+	observationRepo := new(mocks.MockConfidenceObservationRepo)
+	svc := service.NewStatsService(new(mocks.MockStatsRepo), nil, observationRepo, nil)
+	observationRepo.On("BucketsByModel", mock.Anything, mock.Anything, "", mock.Anything).
+		Return([]domain.CalibrationBucketStats{}, nil)
+
+	report, err := svc.GetConfidenceCalibration(context.Background(), uuid.New(), "", time.Hour)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, report)
+	assert.Empty(t, report)
+}
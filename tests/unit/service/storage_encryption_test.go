@@ -0,0 +1,61 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestTenantEncryptingStorage_UploadUsesTenantKMSKey(t *testing.T) {
+	storage := new(mocks.MockObjectStorage)
+	tenantRepo := new(mocks.MockTenantRepo)
+	tenantID := uuid.New()
+	sse := domain.StorageEncryptionKMS
+	arn := "arn:aws:kms:ap-south-1:111122223333:alias/acme"
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, StorageSSE: &sse, StorageKMSKeyARN: &arn}, nil)
+	storage.On("Upload", mock.Anything, mock.MatchedBy(func(in port.UploadInput) bool {
+		return in.Encryption != nil && in.Encryption.Algorithm == domain.StorageEncryptionKMS && in.Encryption.KMSKeyARN == arn
+	})).Return(&port.UploadOutput{}, nil)
+
+	_, err := service.NewTenantEncryptingStorage(storage, tenantRepo).Upload(context.Background(), port.UploadInput{
+		Bucket: "b", Key: "tenants/" + tenantID.String() + "/files/f/a.pdf",
+	})
+
+	require.NoError(t, err)
+	storage.AssertExpectations(t)
+}
+
+func TestTenantEncryptingStorage_NonTenantKeyUsesBucketDefault(t *testing.T) {
+	storage := new(mocks.MockObjectStorage)
+	tenantRepo := new(mocks.MockTenantRepo)
+	storage.On("Copy", mock.Anything, "b", "exports/a.csv", "exports/b.csv", (*port.Encryption)(nil)).Return(nil)
+
+	err := service.NewTenantEncryptingStorage(storage, tenantRepo).Copy(context.Background(), "b", "exports/a.csv", "exports/b.csv", nil)
+
+	require.NoError(t, err)
+	tenantRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestTenantEncryptingStorage_LookupFailureFailsWrite(t *testing.T) {
+	storage := new(mocks.MockObjectStorage)
+	tenantRepo := new(mocks.MockTenantRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(nil, errors.New("db down"))
+
+	_, err := service.NewTenantEncryptingStorage(storage, tenantRepo).Upload(context.Background(), port.UploadInput{
+		Bucket: "b", Key: "tenants/" + tenantID.String() + "/inbox/a.pdf",
+	})
+
+	assert.Error(t, err)
+	storage.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestFileService_Upload_CollectionKeyLayout(t *testing.T) {
+	fileRepo := new(mocks.MockFileMetaRepo)
+	storage := new(mocks.MockObjectStorage)
+	cfg := testS3Config()
+	svc := service.NewFileService(fileRepo, storage, &cfg, nil)
+
+	tenantID := uuid.New()
+	collectionID := uuid.New()
+
+	file, header := createMultipartFile("invoice.pdf", pdfContent(), "application/pdf")
+	defer func() { _ = file.Close() }()
+
+	fileRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.FileMeta")).Return(nil)
+	storage.On("Upload", mock.Anything, mock.AnythingOfType("port.UploadInput")).Return(&port.UploadOutput{}, nil)
+	fileRepo.On("UpdateStatus", mock.Anything, tenantID, mock.AnythingOfType("uuid.UUID"), domain.FileStatusUploaded).Return(nil)
+
+	result, err := svc.Upload(context.Background(), service.FileUploadInput{
+		TenantID:     tenantID,
+		UploadedBy:   uuid.New(),
+		File:         file,
+		Header:       header,
+		CollectionID: &collectionID,
+	})
+
+	require.NoError(t, err)
+	want := fmt.Sprintf("tenants/%s/collections/%s/files/%s/invoice.pdf", tenantID, collectionID, result.ID)
+	assert.Equal(t, want, result.S3Key)
+	storage.AssertCalled(t, "Upload", mock.Anything, mock.MatchedBy(func(in port.UploadInput) bool {
+		return in.Key == want
+	}))
+}
+
+func TestStorageLayoutService_RelocateTenant_MovesObjectThenDeletesOld(t *testing.T) {
+	fileRepo := new(mocks.MockFileMetaRepo)
+	storage := new(mocks.MockObjectStorage)
+	cfg := testS3Config()
+	svc := service.NewStorageLayoutService(fileRepo, storage, &cfg)
+
+	tenantID, fileID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	oldKey := fmt.Sprintf("tenants/%s/files/%s/invoice.pdf", tenantID, fileID)
+	newKey := fmt.Sprintf("tenants/%s/collections/%s/files/%s/invoice.pdf", tenantID, collectionID, fileID)
+
+	fileRepo.On("ListRelocations", mock.Anything, tenantID, uuid.Nil, 100).Return([]domain.FileRelocation{
+		{FileID: fileID, CollectionID: collectionID, S3Bucket: "test-bucket", S3Key: oldKey},
+	}, nil)
+	storage.On("Copy", mock.Anything, "test-bucket", oldKey, newKey, mock.Anything).Return(nil)
+	fileRepo.On("UpdateS3Key", mock.Anything, tenantID, fileID, oldKey, newKey).Return(nil)
+	storage.On("Delete", mock.Anything, "test-bucket", oldKey).Return(nil)
+
+	result, err := svc.RelocateTenant(context.Background(), tenantID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Relocated)
+	assert.Equal(t, 0, result.Failed)
+	assert.Empty(t, result.Orphans)
+	fileRepo.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
+
+func TestStorageLayoutService_RelocateTenant_KeyUpdateFailureRemovesCopy(t *testing.T) {
+	fileRepo := new(mocks.MockFileMetaRepo)
+	storage := new(mocks.MockObjectStorage)
+	cfg := testS3Config()
+	svc := service.NewStorageLayoutService(fileRepo, storage, &cfg)
+
+	tenantID, fileID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	oldKey := fmt.Sprintf("tenants/%s/files/%s/invoice.pdf", tenantID, fileID)
+	newKey := fmt.Sprintf("tenants/%s/collections/%s/files/%s/invoice.pdf", tenantID, collectionID, fileID)
+
+	fileRepo.On("ListRelocations", mock.Anything, tenantID, uuid.Nil, 100).Return([]domain.FileRelocation{
+		{FileID: fileID, CollectionID: collectionID, S3Bucket: "test-bucket", S3Key: oldKey},
+	}, nil)
+	storage.On("Copy", mock.Anything, "test-bucket", oldKey, newKey, mock.Anything).Return(nil)
+	fileRepo.On("UpdateS3Key", mock.Anything, tenantID, fileID, oldKey, newKey).Return(domain.ErrNotFound)
+	storage.On("Delete", mock.Anything, "test-bucket", newKey).Return(nil)
+
+	result, err := svc.RelocateTenant(context.Background(), tenantID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Relocated)
+	assert.Equal(t, 1, result.Failed)
+	storage.AssertNotCalled(t, "Delete", mock.Anything, "test-bucket", oldKey)
+	storage.AssertExpectations(t)
+}
+
+func TestStorageLayoutService_ApplyLifecycle(t *testing.T) {
+	storage := new(mocks.MockObjectStorage)
+	cfg := testS3Config()
+	svc := service.NewStorageLayoutService(nil, storage, &cfg)
+
+	tenantID := uuid.New()
+	ruleID := "satvos-tenant-" + tenantID.String() + "-ia"
+	days := 90
+
+	storage.On("PutLifecycleRule", mock.Anything, "test-bucket", port.LifecycleRule{
+		ID:             ruleID,
+		Prefix:         "tenants/" + tenantID.String() + "/",
+		TransitionDays: 90,
+	}).Return(nil)
+	storage.On("DeleteLifecycleRule", mock.Anything, "test-bucket", ruleID).Return(nil)
+
+	require.NoError(t, svc.ApplyLifecycle(context.Background(), &domain.Tenant{ID: tenantID, StorageIAAfterDays: &days}))
+	require.NoError(t, svc.ApplyLifecycle(context.Background(), &domain.Tenant{ID: tenantID}))
+	storage.AssertExpectations(t)
+}
+
+func TestTenantService_Update_StorageLifecycle(t *testing.T) {
+	repo := new(mocks.MockTenantRepo)
+	storage := new(mocks.MockObjectStorage)
+	cfg := testS3Config()
+	svc := service.NewTenantService(repo, nil, nil, service.NewStorageLayoutService(nil, storage, &cfg))
+
+	tenantID := uuid.New()
+	for i := 0; i < 3; i++ {
+		repo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID}, nil).Once()
+	}
+
+	_, err := svc.Update(context.Background(), tenantID, service.UpdateTenantInput{StorageIAAfterDays: intPtr(7)})
+	assert.ErrorIs(t, err, domain.ErrInvalidStorageLifecycle)
+
+	// A failed S3 write must not save the setting.
+	storage.On("PutLifecycleRule", mock.Anything, "test-bucket", mock.Anything).Return(errors.New("access denied")).Once()
+	_, err = svc.Update(context.Background(), tenantID, service.UpdateTenantInput{StorageIAAfterDays: intPtr(60)})
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	storage.On("PutLifecycleRule", mock.Anything, "test-bucket", mock.Anything).Return(nil).Once()
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Tenant")).Return(nil)
+	tenant, err := svc.Update(context.Background(), tenantID, service.UpdateTenantInput{StorageIAAfterDays: intPtr(60)})
+	require.NoError(t, err)
+	assert.Equal(t, 60, *tenant.StorageIAAfterDays)
+}
+
+func intPtr(n int) *int { return &n }
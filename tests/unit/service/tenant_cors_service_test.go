@@ -0,0 +1,67 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func TestTenantCORSService_Set_Normalizes(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	originRepo := new(mocks.MockTenantCORSOriginRepo)
+	svc := service.NewTenantCORSService(tenantRepo, originRepo)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	want := []string{"http://localhost:5173", "https://invoices.acme.example"}
+	tenantRepo.On("GetByID", ctx, tenantID).Return(&domain.Tenant{ID: tenantID}, nil)
+	originRepo.On("Replace", ctx, tenantID, want).Return(nil)
+
+	origins, err := svc.Set(ctx, tenantID, []string{
+		" https://Invoices.Acme.example/ ", "http://localhost:5173", "https://invoices.acme.example",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, want, origins)
+}
+
+func TestTenantCORSService_Set_RejectsInvalidOrigins(t *testing.T) {
+	originRepo := new(mocks.MockTenantCORSOriginRepo)
+	svc := service.NewTenantCORSService(new(mocks.MockTenantRepo), originRepo)
+
+	tooMany := make([]string, 21)
+	for i := range tooMany {
+		tooMany[i] = "https://" + uuid.NewString() + ".example"
+	}
+	for _, origins := range [][]string{
+		{"*"},
+		{"invoices.acme.example"},
+		{"ftp://acme.example"},
+		{"https://acme.example/app"},
+		{"https://user:pw@acme.example"},
+		{"https://acme.example?x=1"},
+		tooMany,
+	} {
+		_, err := svc.Set(context.Background(), uuid.New(), origins)
+		assert.ErrorIs(t, err, domain.ErrInvalidCORSOrigins, "%v", origins)
+	}
+	originRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTenantCORSService_Get_UnknownTenant(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	svc := service.NewTenantCORSService(tenantRepo, new(mocks.MockTenantCORSOriginRepo))
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(nil, domain.ErrNotFound)
+
+	_, err := svc.Get(context.Background(), tenantID)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+func setupTenantMembershipService() (service.TenantMembershipService, *mocks.MockTenantMembershipRepo, *mocks.MockUserRepo, *mocks.MockTenantRepo) {
+	repo := new(mocks.MockTenantMembershipRepo)
+	userRepo := new(mocks.MockUserRepo)
+	tenantRepo := new(mocks.MockTenantRepo)
+	authSvc := service.NewAuthService(userRepo, tenantRepo, testJWTConfig())
+	return service.NewTenantMembershipService(repo, userRepo, tenantRepo, authSvc), repo, userRepo, tenantRepo
+}
+
+func TestTenantMembershipService_SwitchTenant_Guest(t *testing.T) {
+	svc, _, userRepo, tenantRepo := setupTenantMembershipService()
+	homeID, guestTenantID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	tenantRepo.On("GetByID", mock.Anything, guestTenantID).Return(&domain.Tenant{ID: guestTenantID, IsActive: true}, nil)
+	userRepo.On("GetByID", mock.Anything, guestTenantID, userID).Return(&domain.User{
+		ID: userID, TenantID: guestTenantID, HomeTenantID: &homeID, Email: "ca@firm.com",
+		Role: domain.RoleViewer, IsActive: true,
+	}, nil)
+
+	pair, err := svc.SwitchTenant(context.Background(), userID, guestTenantID)
+	require.NoError(t, err)
+
+	authSvc := service.NewAuthService(userRepo, tenantRepo, testJWTConfig())
+	claims, err := authSvc.ValidateToken(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, guestTenantID, claims.TenantID)
+	assert.Equal(t, domain.RoleViewer, claims.Role)
+	require.NotNil(t, claims.HomeTenantID)
+	assert.Equal(t, homeID, *claims.HomeTenantID)
+}
+
+func TestTenantMembershipService_SwitchTenant_NoAccess(t *testing.T) {
+	svc, _, userRepo, tenantRepo := setupTenantMembershipService()
+	tenantID, userID := uuid.New(), uuid.New()
+
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, IsActive: true}, nil)
+	userRepo.On("GetByID", mock.Anything, tenantID, userID).Return(nil, domain.ErrNotFound)
+
+	pair, err := svc.SwitchTenant(context.Background(), userID, tenantID)
+	assert.Nil(t, pair)
+	assert.ErrorIs(t, err, domain.ErrTenantAccessDenied)
+}
+
+func TestTenantMembershipService_SwitchTenant_InactiveTenant(t *testing.T) {
+	svc, _, userRepo, tenantRepo := setupTenantMembershipService()
+	tenantID := uuid.New()
+
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, IsActive: false}, nil)
+
+	_, err := svc.SwitchTenant(context.Background(), uuid.New(), tenantID)
+	assert.ErrorIs(t, err, domain.ErrTenantInactive)
+	userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTenantMembershipService_Grant(t *testing.T) {
+	svc, repo, userRepo, tenantRepo := setupTenantMembershipService()
+	tenantID, homeID, adminID := uuid.New(), uuid.New(), uuid.New()
+	guest := &domain.User{ID: uuid.New(), TenantID: homeID, Email: "ca@firm.com", FullName: "CA Firm"}
+
+	tenantRepo.On("GetBySlug", mock.Anything, "ca-firm").Return(&domain.Tenant{ID: homeID, Slug: "ca-firm"}, nil)
+	userRepo.On("GetByEmail", mock.Anything, homeID, "ca@firm.com").Return(guest, nil)
+	repo.On("Upsert", mock.Anything, mock.MatchedBy(func(m *domain.TenantMembership) bool {
+		return m.TenantID == tenantID && m.UserID == guest.ID && m.Role == domain.RoleManager && m.IsActive
+	})).Return(nil)
+
+	membership, err := svc.Grant(context.Background(), tenantID, &service.GrantMembershipInput{
+		Email: " ca@firm.com ", TenantSlug: "ca-firm", Role: domain.RoleManager,
+	}, adminID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "CA Firm", membership.FullName)
+	assert.Equal(t, adminID, *membership.CreatedBy)
+	repo.AssertExpectations(t)
+}
+
+func TestTenantMembershipService_Grant_Invalid(t *testing.T) {
+	tenantID := uuid.New()
+	tests := []struct {
+		name  string
+		input service.GrantMembershipInput
+	}{
+		{"free role", service.GrantMembershipInput{Email: "a@b.com", TenantSlug: "other", Role: domain.RoleFree}},
+		{"unknown role", service.GrantMembershipInput{Email: "a@b.com", TenantSlug: "other", Role: "owner"}},
+		{"home tenant", service.GrantMembershipInput{Email: "a@b.com", TenantSlug: "self", Role: domain.RoleMember}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _, tenantRepo := setupTenantMembershipService()
+			tenantRepo.On("GetBySlug", mock.Anything, "self").Return(&domain.Tenant{ID: tenantID}, nil).Maybe()
+
+			_, err := svc.Grant(context.Background(), tenantID, &tt.input, uuid.New())
+
+			assert.ErrorIs(t, err, domain.ErrInvalidMembership)
+			repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
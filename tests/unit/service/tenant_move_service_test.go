@@ -0,0 +1,210 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/config"
+	"satvos/internal/domain"
+	"satvos/internal/port"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type tenantMoveMocks struct {
+	moveRepo   *mocks.MockTenantMoveRepo
+	tenantRepo *mocks.MockTenantRepo
+	source     *mocks.MockTenantDataStore
+	target     *mocks.MockTenantDataStore
+	storage    *mocks.MockObjectStorage
+}
+
+func setupTenantMoveService() (service.TenantMoveService, *tenantMoveMocks) {
+	m := &tenantMoveMocks{
+		moveRepo:   new(mocks.MockTenantMoveRepo),
+		tenantRepo: new(mocks.MockTenantRepo),
+		source:     new(mocks.MockTenantDataStore),
+		target:     new(mocks.MockTenantDataStore),
+		storage:    new(mocks.MockObjectStorage),
+	}
+	s3Cfg := &config.S3Config{
+		Region:        "ap-south-1",
+		Bucket:        "satvos-in",
+		RegionBuckets: map[string]string{"eu-west-1": "satvos-eu"},
+	}
+	targets := map[string]port.TenantDataStore{"green": m.target}
+	svc := service.NewTenantMoveService(m.moveRepo, m.tenantRepo, m.source, targets, m.storage, s3Cfg, "blue", 0)
+	return svc, m
+}
+
+// expectMovableTenant sets up a tenant served by this cluster that can be moved.
+func expectMovableTenant(m *tenantMoveMocks, tenantID uuid.UUID) {
+	m.tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, IsActive: true}, nil)
+	m.source.On("CheckMovable", mock.Anything, tenantID).Return(nil)
+	m.moveRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TenantMove")).Return(nil)
+	m.moveRepo.On("UpdateProgress", mock.Anything, mock.AnythingOfType("*domain.TenantMove")).Return(nil)
+}
+
+var movedTables = []domain.TenantMoveTable{
+	{Table: "tenants", Rows: 1, Checksum: "aaa"},
+	{Table: "users", Rows: 3, Checksum: "bbb"},
+}
+
+func TestTenantMoveService_Run_CopiesVerifiesAndFlipsRouting(t *testing.T) {
+	svc, m := setupTenantMoveService()
+	tenantID := uuid.New()
+	expectMovableTenant(m, tenantID)
+	m.source.On("SchemaVersion", mock.Anything).Return(uint(53), nil)
+	m.target.On("SchemaVersion", mock.Anything).Return(uint(53), nil)
+	m.source.On("SetCluster", mock.Anything, tenantID, "green").Return(nil).Once()
+	m.source.On("Snapshot", mock.Anything, tenantID).Return(append([]domain.TenantMoveTable(nil), movedTables...), nil)
+	m.source.On("ListObjects", mock.Anything, tenantID).Return([]port.StoredObject{
+		{Bucket: "satvos-in", Key: "tenants/a/file.pdf"},
+		{Bucket: "satvos-eu", Key: "tenants/a/already-there.pdf"},
+	}, nil)
+	m.storage.On("Open", mock.Anything, "satvos-in", "tenants/a/file.pdf").Return(&port.ObjectReader{
+		Body: io.NopCloser(strings.NewReader("%PDF")), Size: 4, ContentType: "application/pdf",
+	}, nil)
+	m.storage.On("Upload", mock.Anything, mock.MatchedBy(func(in port.UploadInput) bool {
+		return in.Bucket == "satvos-eu" && in.Key == "tenants/a/file.pdf" && in.ContentType == "application/pdf"
+	})).Return(&port.UploadOutput{}, nil)
+
+	var imported *port.TenantImport
+	m.target.On("Import", mock.Anything, mock.AnythingOfType("*port.TenantImport")).
+		Run(func(args mock.Arguments) { imported = args.Get(1).(*port.TenantImport) }).
+		Return(func(_ context.Context, in *port.TenantImport) error { return in.Verify(movedTables) })
+
+	region := "eu-west-1"
+	move, err := svc.Run(context.Background(), tenantID, &service.StartTenantMoveInput{TargetCluster: "green", StorageRegion: &region}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TenantMoveStatusCompleted, move.Status)
+	assert.Equal(t, "blue", move.SourceCluster)
+	assert.Equal(t, 2, move.ObjectsTotal)
+	assert.Equal(t, 2, move.ObjectsCopied)
+	assert.NotNil(t, move.CompletedAt)
+
+	require.NotNil(t, imported)
+	assert.Equal(t, []string{"tenants", "users"}, imported.Tables)
+	assert.Equal(t, map[string]string{"satvos-in": "satvos-eu"}, imported.Buckets)
+	assert.Equal(t, &region, imported.StorageRegion)
+
+	var tables []domain.TenantMoveTable
+	require.NoError(t, json.Unmarshal(move.Tables, &tables))
+	require.Len(t, tables, 2)
+	assert.Equal(t, int64(3), tables[1].TargetRows)
+	assert.Equal(t, "bbb", tables[1].TargetChecksum)
+	m.source.AssertNumberOfCalls(t, "SetCluster", 1)
+}
+
+func TestTenantMoveService_Run_VerificationMismatchRestoresRouting(t *testing.T) {
+	svc, m := setupTenantMoveService()
+	tenantID := uuid.New()
+	expectMovableTenant(m, tenantID)
+	m.source.On("SchemaVersion", mock.Anything).Return(uint(53), nil)
+	m.target.On("SchemaVersion", mock.Anything).Return(uint(53), nil)
+	m.source.On("SetCluster", mock.Anything, tenantID, "green").Return(nil).Once()
+	m.source.On("SetCluster", mock.Anything, tenantID, "").Return(nil).Once()
+	m.source.On("Snapshot", mock.Anything, tenantID).Return(append([]domain.TenantMoveTable(nil), movedTables...), nil)
+	m.target.On("Import", mock.Anything, mock.AnythingOfType("*port.TenantImport")).
+		Return(func(_ context.Context, in *port.TenantImport) error {
+			return in.Verify([]domain.TenantMoveTable{
+				{Table: "tenants", Rows: 1, Checksum: "aaa"},
+				{Table: "users", Rows: 2, Checksum: "ccc"},
+			})
+		})
+
+	move, err := svc.Run(context.Background(), tenantID, &service.StartTenantMoveInput{TargetCluster: "green"}, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "users")
+	assert.Equal(t, domain.TenantMoveStatusFailed, move.Status)
+	require.NotNil(t, move.Error)
+	assert.Contains(t, *move.Error, "verification failed")
+	m.source.AssertCalled(t, "SetCluster", mock.Anything, tenantID, "")
+	m.source.AssertNotCalled(t, "ListObjects", mock.Anything, mock.Anything)
+}
+
+func TestTenantMoveService_Run_SchemaMismatchLeavesTenantInPlace(t *testing.T) {
+	svc, m := setupTenantMoveService()
+	tenantID := uuid.New()
+	expectMovableTenant(m, tenantID)
+	m.source.On("SchemaVersion", mock.Anything).Return(uint(53), nil)
+	m.target.On("SchemaVersion", mock.Anything).Return(uint(52), nil)
+
+	move, err := svc.Run(context.Background(), tenantID, &service.StartTenantMoveInput{TargetCluster: "green"}, nil)
+
+	require.Error(t, err)
+	assert.Equal(t, domain.TenantMoveStatusFailed, move.Status)
+	m.source.AssertNotCalled(t, "SetCluster", mock.Anything, mock.Anything, mock.Anything)
+	m.target.AssertNotCalled(t, "Import", mock.Anything, mock.Anything)
+}
+
+func TestTenantMoveService_Start_RejectsInvalidMoves(t *testing.T) {
+	eu, mars := "eu-west-1", "mars-1"
+	tests := []struct {
+		name    string
+		input   service.StartTenantMoveInput
+		tenant  *domain.Tenant
+		movable error
+		wantErr error
+	}{
+		{name: "unknown target", input: service.StartTenantMoveInput{TargetCluster: "red"}, wantErr: domain.ErrInvalidMoveTarget},
+		{name: "own cluster", input: service.StartTenantMoveInput{TargetCluster: "blue"}, wantErr: domain.ErrInvalidMoveTarget},
+		{
+			name:    "served elsewhere",
+			input:   service.StartTenantMoveInput{TargetCluster: "green"},
+			tenant:  &domain.Tenant{DBCluster: "green"},
+			wantErr: domain.ErrTenantMoved,
+		},
+		{
+			name:    "unknown region",
+			input:   service.StartTenantMoveInput{TargetCluster: "green", StorageRegion: &mars},
+			tenant:  &domain.Tenant{},
+			wantErr: domain.ErrInvalidStorageRegion,
+		},
+		{
+			name:    "cross-tenant links",
+			input:   service.StartTenantMoveInput{TargetCluster: "green", StorageRegion: &eu},
+			tenant:  &domain.Tenant{},
+			movable: domain.ErrTenantNotMovable,
+			wantErr: domain.ErrTenantNotMovable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := setupTenantMoveService()
+			tenantID := uuid.New()
+			if tt.tenant != nil {
+				tt.tenant.ID = tenantID
+				m.tenantRepo.On("GetByID", mock.Anything, tenantID).Return(tt.tenant, nil)
+			}
+			m.source.On("CheckMovable", mock.Anything, tenantID).Return(tt.movable)
+
+			_, err := svc.Start(context.Background(), tenantID, &tt.input, nil)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			m.moveRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTenantMoveService_Start_MoveInProgress(t *testing.T) {
+	svc, m := setupTenantMoveService()
+	tenantID := uuid.New()
+	m.tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID}, nil)
+	m.source.On("CheckMovable", mock.Anything, tenantID).Return(nil)
+	m.moveRepo.On("Create", mock.Anything, mock.Anything).Return(domain.ErrTenantMoveInProgress)
+
+	_, err := svc.Start(context.Background(), tenantID, &service.StartTenantMoveInput{TargetCluster: "green"}, nil)
+
+	assert.ErrorIs(t, err, domain.ErrTenantMoveInProgress)
+}
@@ -15,7 +15,7 @@ import (
 
 func TestTenantService_Create_Success(t *testing.T) {
 	repo := new(mocks.MockTenantRepo)
-	svc := service.NewTenantService(repo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
 
 	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Tenant")).Return(nil)
 
@@ -33,7 +33,7 @@ func TestTenantService_Create_Success(t *testing.T) {
 
 func TestTenantService_Create_DuplicateSlug(t *testing.T) {
 	repo := new(mocks.MockTenantRepo)
-	svc := service.NewTenantService(repo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
 
 	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Tenant")).Return(domain.ErrDuplicateTenantSlug)
 
@@ -48,7 +48,7 @@ func TestTenantService_Create_DuplicateSlug(t *testing.T) {
 
 func TestTenantService_GetByID_Success(t *testing.T) {
 	repo := new(mocks.MockTenantRepo)
-	svc := service.NewTenantService(repo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	expected := &domain.Tenant{ID: tenantID, Name: "Acme Corp", Slug: "acme-corp", IsActive: true}
@@ -62,7 +62,7 @@ func TestTenantService_GetByID_Success(t *testing.T) {
 
 func TestTenantService_GetByID_NotFound(t *testing.T) {
 	repo := new(mocks.MockTenantRepo)
-	svc := service.NewTenantService(repo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	repo.On("GetByID", mock.Anything, tenantID).Return(nil, domain.ErrNotFound)
@@ -75,7 +75,7 @@ func TestTenantService_GetByID_NotFound(t *testing.T) {
 
 func TestTenantService_List_Success(t *testing.T) {
 	repo := new(mocks.MockTenantRepo)
-	svc := service.NewTenantService(repo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
 
 	expected := []domain.Tenant{
 		{ID: uuid.New(), Name: "Tenant A"},
@@ -92,7 +92,7 @@ func TestTenantService_List_Success(t *testing.T) {
 
 func TestTenantService_Update_Success(t *testing.T) {
 	repo := new(mocks.MockTenantRepo)
-	svc := service.NewTenantService(repo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	existing := &domain.Tenant{ID: tenantID, Name: "Old Name", Slug: "old-slug", IsActive: true}
@@ -112,7 +112,7 @@ func TestTenantService_Update_Success(t *testing.T) {
 
 func TestTenantService_Delete_Success(t *testing.T) {
 	repo := new(mocks.MockTenantRepo)
-	svc := service.NewTenantService(repo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	repo.On("Delete", mock.Anything, tenantID).Return(nil)
@@ -125,7 +125,7 @@ func TestTenantService_Delete_Success(t *testing.T) {
 
 func TestTenantService_Delete_NotFound(t *testing.T) {
 	repo := new(mocks.MockTenantRepo)
-	svc := service.NewTenantService(repo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
 
 	tenantID := uuid.New()
 	repo.On("Delete", mock.Anything, tenantID).Return(domain.ErrNotFound)
@@ -134,3 +134,129 @@ func TestTenantService_Delete_NotFound(t *testing.T) {
 
 	assert.ErrorIs(t, err, domain.ErrNotFound)
 }
+
+func TestTenantService_Create_StorageEncryption(t *testing.T) {
+	repo := new(mocks.MockTenantRepo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Tenant")).Return(nil)
+
+	arn := "arn:aws:kms:ap-south-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	tenant, err := svc.Create(context.Background(), service.CreateTenantInput{
+		Name: "Acme Corp", Slug: "acme-corp", StorageSSE: "sse-kms", StorageKMSKeyARN: arn,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StorageEncryptionKMS, *tenant.StorageSSE)
+	assert.Equal(t, arn, *tenant.StorageKMSKeyARN)
+}
+
+func TestTenantService_Create_InvalidStorageEncryption(t *testing.T) {
+	repo := new(mocks.MockTenantRepo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
+
+	for _, tc := range []struct{ sse, arn string }{
+		{"sse-c", ""},
+		{"sse-kms", ""},
+		{"sse-kms", "not-an-arn"},
+		{"sse-s3", "arn:aws:kms:ap-south-1:111122223333:alias/satvos"},
+		{"", "arn:aws:kms:ap-south-1:111122223333:alias/satvos"},
+	} {
+		_, err := svc.Create(context.Background(), service.CreateTenantInput{
+			Name: "Acme Corp", Slug: "acme-corp", StorageSSE: tc.sse, StorageKMSKeyARN: tc.arn,
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidStorageEncryption, "%+v", tc)
+	}
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTenantService_Update_ResetStorageEncryption(t *testing.T) {
+	repo := new(mocks.MockTenantRepo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
+
+	tenantID := uuid.New()
+	sse := domain.StorageEncryptionS3
+	existing := &domain.Tenant{ID: tenantID, Name: "Acme", Slug: "acme", StorageSSE: &sse}
+	repo.On("GetByID", mock.Anything, tenantID).Return(existing, nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Tenant")).Return(nil)
+
+	reset := ""
+	tenant, err := svc.Update(context.Background(), tenantID, service.UpdateTenantInput{StorageSSE: &reset})
+
+	assert.NoError(t, err)
+	assert.Nil(t, tenant.StorageSSE)
+	assert.Nil(t, tenant.StorageKMSKeyARN)
+}
+
+func TestTenantService_Update_DefaultParseMode(t *testing.T) {
+	repo := new(mocks.MockTenantRepo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
+
+	tenantID := uuid.New()
+	repo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, Name: "Acme", Slug: "acme"}, nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Tenant")).Return(nil)
+
+	dual := domain.ParseModeDual
+	tenant, err := svc.Update(context.Background(), tenantID, service.UpdateTenantInput{DefaultParseMode: &dual})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, tenant.DefaultParseMode) {
+		assert.Equal(t, domain.ParseModeDual, *tenant.DefaultParseMode)
+	}
+}
+
+func TestTenantService_Update_InvalidDefaultParseMode(t *testing.T) {
+	repo := new(mocks.MockTenantRepo)
+	svc := service.NewTenantService(repo, nil, nil, nil)
+
+	tenantID := uuid.New()
+	repo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, Name: "Acme", Slug: "acme"}, nil)
+
+	bad := domain.ParseMode("triple")
+	_, err := svc.Update(context.Background(), tenantID, service.UpdateTenantInput{DefaultParseMode: &bad})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidParseMode)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTenantService_Update_TimeZone(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"iana zone", "Asia/Kolkata", "Asia/Kolkata"},
+		{"trimmed", " Asia/Kolkata ", "Asia/Kolkata"},
+		{"utc", "UTC", "UTC"},
+		{"empty resets to utc", "", "UTC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockTenantRepo)
+			svc := service.NewTenantService(repo, nil, nil, nil)
+			tenantID := uuid.New()
+			repo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, Name: "Acme", Slug: "acme", TimeZone: "Europe/London"}, nil)
+			repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Tenant")).Return(nil)
+
+			tenant, err := svc.Update(context.Background(), tenantID, service.UpdateTenantInput{TimeZone: &tt.input})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tenant.TimeZone)
+		})
+	}
+}
+
+func TestTenantService_Update_InvalidTimeZone(t *testing.T) {
+	for _, tz := range []string{"IST", "Asia/Nowhere", "Local"} {
+		t.Run(tz, func(t *testing.T) {
+			repo := new(mocks.MockTenantRepo)
+			svc := service.NewTenantService(repo, nil, nil, nil)
+			tenantID := uuid.New()
+			repo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, Name: "Acme", Slug: "acme"}, nil)
+
+			_, err := svc.Update(context.Background(), tenantID, service.UpdateTenantInput{TimeZone: &tz})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidTimeZone)
+			repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/mocks"
+)
+
+type userImportDeps struct {
+	tenantRepo     *mocks.MockTenantRepo
+	userRepo       *mocks.MockUserRepo
+	collectionRepo *mocks.MockCollectionRepo
+	permRepo       *mocks.MockCollectionPermissionRepo
+	accountSec     *mocks.MockPasswordResetService
+	tenant         *domain.Tenant
+}
+
+func setupUserImportService() (service.UserImportService, *userImportDeps) {
+	d := &userImportDeps{
+		tenantRepo:     new(mocks.MockTenantRepo),
+		userRepo:       new(mocks.MockUserRepo),
+		collectionRepo: new(mocks.MockCollectionRepo),
+		permRepo:       new(mocks.MockCollectionPermissionRepo),
+		accountSec:     new(mocks.MockPasswordResetService),
+		tenant:         &domain.Tenant{ID: uuid.New(), Name: "Acme"},
+	}
+	d.tenantRepo.On("GetByID", mock.Anything, d.tenant.ID).Return(d.tenant, nil)
+	svc := service.NewUserImportService(d.tenantRepo, d.userRepo, d.collectionRepo, d.permRepo, d.accountSec)
+	return svc, d
+}
+
+func (d *userImportDeps) createsUsers() {
+	d.userRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.User).ID = uuid.New() }).Return(nil)
+}
+
+func (d *userImportDeps) importCSV(svc service.UserImportService, csv string) (*domain.UserImportReport, error) {
+	return svc.Import(context.Background(), &service.UserImportInput{
+		TenantID: d.tenant.ID,
+		ActorID:  uuid.New(),
+		CSV:      strings.NewReader(csv),
+	})
+}
+
+func TestUserImportService_Import_CreatesGrantsAndInvites(t *testing.T) {
+	svc, d := setupUserImportService()
+	invoices := domain.Collection{ID: uuid.New(), Name: "Invoices 2024"}
+	vendors := domain.Collection{ID: uuid.New(), Name: "Vendors"}
+	d.collectionRepo.On("ListByTenant", mock.Anything, d.tenant.ID, 0, 100).
+		Return([]domain.Collection{invoices, vendors}, 2, nil)
+	d.createsUsers()
+	d.permRepo.On("Upsert", mock.Anything, mock.AnythingOfType("*domain.CollectionPermissionEntry")).Return(nil)
+	d.accountSec.On("Invite", mock.Anything, mock.MatchedBy(func(in service.InviteInput) bool {
+		return in.TenantName == "Acme"
+	})).Return(nil)
+
+	report, err := d.importCSV(svc, "Email,Name,Role,Collections\n"+
+		"asha@acme.in,Asha Rao,Manager,invoices 2024:editor; "+vendors.ID.String()+"\n"+
+		"ravi@acme.in,Ravi K,member,\n")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 2, report.Created)
+	assert.Zero(t, report.Failed)
+	require.Len(t, report.Rows, 2)
+	assert.Equal(t, 2, report.Rows[0].Row)
+	assert.Equal(t, domain.UserImportRowCreated, report.Rows[0].Status)
+	assert.NotNil(t, report.Rows[0].UserID)
+	assert.True(t, report.Rows[0].Invited)
+
+	d.userRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Email == "asha@acme.in" && u.Role == domain.RoleManager && u.PasswordHash == "" && u.TenantID == d.tenant.ID
+	}))
+	d.permRepo.AssertCalled(t, "Upsert", mock.Anything, mock.MatchedBy(func(p *domain.CollectionPermissionEntry) bool {
+		return p.CollectionID == invoices.ID && p.Permission == domain.CollectionPermEditor
+	}))
+	d.permRepo.AssertCalled(t, "Upsert", mock.Anything, mock.MatchedBy(func(p *domain.CollectionPermissionEntry) bool {
+		return p.CollectionID == vendors.ID && p.Permission == domain.CollectionPermViewer
+	}))
+	d.accountSec.AssertNumberOfCalls(t, "Invite", 2)
+}
+
+func TestUserImportService_Import_ReportsBadRows(t *testing.T) {
+	svc, d := setupUserImportService()
+	d.collectionRepo.On("ListByTenant", mock.Anything, d.tenant.ID, 0, 100).
+		Return([]domain.Collection{{ID: uuid.New(), Name: "Ops"}, {ID: uuid.New(), Name: "ops"}}, 2, nil)
+	d.userRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool { return u.Email == "taken@acme.in" })).
+		Return(domain.ErrDuplicateEmail)
+	d.userRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool { return u.Email == "ok@acme.in" })).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.User).ID = uuid.New() }).Return(nil)
+	// The invitation fails, but the user is still created
+	d.accountSec.On("Invite", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	report, err := d.importCSV(svc, "name,email,role,collections\n"+
+		",noname@acme.in,member,\n"+
+		"Bad Email,not-an-email,member,\n"+
+		"Bad Role,role@acme.in,owner,\n"+
+		"Taken,taken@acme.in,member,\n"+
+		"Missing Coll,coll@acme.in,member,Archive\n"+
+		"Ambiguous,amb@acme.in,member,Ops\n"+
+		"Bad Perm,perm@acme.in,member,Ops:admin\n"+
+		"OK,ok@acme.in,viewer,\n"+
+		"Again,OK@acme.in,viewer,\n")
+	require.NoError(t, err)
+
+	assert.Equal(t, 9, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 8, report.Failed)
+	errs := make([]string, len(report.Rows))
+	for i, row := range report.Rows {
+		errs[i] = row.Error
+	}
+	assert.Contains(t, errs[0], "name is required")
+	assert.Contains(t, errs[1], "valid address")
+	assert.Contains(t, errs[2], "role must be")
+	assert.Contains(t, errs[3], "already exists")
+	assert.Contains(t, errs[4], `"Archive" not found`)
+	assert.Contains(t, errs[5], "ambiguous")
+	assert.Contains(t, errs[6], "permission must be")
+	assert.Empty(t, errs[7])
+	assert.False(t, report.Rows[7].Invited)
+	assert.Contains(t, errs[8], "earlier row")
+	d.permRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
+
+func TestUserImportService_Import_RejectsUnreadableCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{"empty", ""},
+		{"missing column", "name,email\nAsha,asha@acme.in\n"},
+		{"no rows", "name,email,role\n"},
+		{"too many rows", "name,email,role\n" + strings.Repeat("A,a@acme.in,member\n", 501)},
+		{"malformed", "name,email,role\n\"Asha,asha@acme.in,member\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, d := setupUserImportService()
+			_, err := d.importCSV(svc, tt.csv)
+			assert.ErrorIs(t, err, domain.ErrInvalidUserImport)
+			d.userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUserImportService_Import_UnknownTenant(t *testing.T) {
+	tenantRepo := new(mocks.MockTenantRepo)
+	tenantID := uuid.New()
+	tenantRepo.On("GetByID", mock.Anything, tenantID).Return(nil, domain.ErrNotFound)
+	svc := service.NewUserImportService(tenantRepo, nil, nil, nil, nil)
+
+	_, err := svc.Import(context.Background(), &service.UserImportInput{TenantID: tenantID, CSV: strings.NewReader("name,email,role\n")})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -15,7 +15,7 @@ import (
 
 func TestUserService_Create_Success(t *testing.T) {
 	repo := new(mocks.MockUserRepo)
-	svc := service.NewUserService(repo)
+	svc := service.NewUserService(repo, nil)
 
 	tenantID := uuid.New()
 
@@ -40,7 +40,7 @@ func TestUserService_Create_Success(t *testing.T) {
 
 func TestUserService_Create_DuplicateEmail(t *testing.T) {
 	repo := new(mocks.MockUserRepo)
-	svc := service.NewUserService(repo)
+	svc := service.NewUserService(repo, nil)
 
 	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(domain.ErrDuplicateEmail)
 
@@ -57,7 +57,7 @@ func TestUserService_Create_DuplicateEmail(t *testing.T) {
 
 func TestUserService_GetByID_Success(t *testing.T) {
 	repo := new(mocks.MockUserRepo)
-	svc := service.NewUserService(repo)
+	svc := service.NewUserService(repo, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -73,7 +73,7 @@ func TestUserService_GetByID_Success(t *testing.T) {
 
 func TestUserService_GetByID_NotFound(t *testing.T) {
 	repo := new(mocks.MockUserRepo)
-	svc := service.NewUserService(repo)
+	svc := service.NewUserService(repo, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -88,7 +88,7 @@ func TestUserService_GetByID_NotFound(t *testing.T) {
 
 func TestUserService_List_Success(t *testing.T) {
 	repo := new(mocks.MockUserRepo)
-	svc := service.NewUserService(repo)
+	svc := service.NewUserService(repo, nil)
 
 	tenantID := uuid.New()
 	expected := []domain.User{
@@ -107,7 +107,7 @@ func TestUserService_List_Success(t *testing.T) {
 
 func TestUserService_Update_Success(t *testing.T) {
 	repo := new(mocks.MockUserRepo)
-	svc := service.NewUserService(repo)
+	svc := service.NewUserService(repo, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -136,9 +136,44 @@ func TestUserService_Update_Success(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestUserService_Update_MonthlyDocumentLimit(t *testing.T) {
+	repo := new(mocks.MockUserRepo)
+	svc := service.NewUserService(repo, nil)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	repo.On("GetByID", mock.Anything, tenantID, userID).Return(&domain.User{
+		ID: userID, TenantID: tenantID, Role: domain.RoleMember, IsActive: true, MonthlyDocumentLimit: 5,
+	}, nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+
+	limit := 0
+	user, err := svc.Update(context.Background(), tenantID, userID, service.UpdateUserInput{MonthlyDocumentLimit: &limit})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, user.MonthlyDocumentLimit)
+}
+
+func TestUserService_Update_NegativeDocumentLimit(t *testing.T) {
+	repo := new(mocks.MockUserRepo)
+	svc := service.NewUserService(repo, nil)
+
+	tenantID := uuid.New()
+	userID := uuid.New()
+	repo.On("GetByID", mock.Anything, tenantID, userID).Return(&domain.User{
+		ID: userID, TenantID: tenantID, Role: domain.RoleMember, IsActive: true,
+	}, nil)
+
+	limit := -1
+	_, err := svc.Update(context.Background(), tenantID, userID, service.UpdateUserInput{MonthlyDocumentLimit: &limit})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidDocumentLimit)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
 func TestUserService_Delete_Success(t *testing.T) {
 	repo := new(mocks.MockUserRepo)
-	svc := service.NewUserService(repo)
+	svc := service.NewUserService(repo, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -153,7 +188,7 @@ func TestUserService_Delete_Success(t *testing.T) {
 
 func TestUserService_Delete_NotFound(t *testing.T) {
 	repo := new(mocks.MockUserRepo)
-	svc := service.NewUserService(repo)
+	svc := service.NewUserService(repo, nil)
 
 	tenantID := uuid.New()
 	userID := uuid.New()
@@ -0,0 +1,176 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/service"
+	"satvos/internal/validator"
+	"satvos/mocks"
+)
+
+type validationRunMocks struct {
+	runRepo       *mocks.MockValidationRunRepo
+	docRepo       *mocks.MockDocumentRepo
+	ruleRepo      *mocks.MockDocumentValidationRuleRepo
+	auditRepo     *mocks.MockDocumentAuditRepo
+	summaryRepo   *mocks.MockDocumentSummaryRepo
+	collectionSvc *mocks.MockCollectionService
+}
+
+// setupValidationRunService wires a real engine with no registered validators,
+// so every document validates as valid/valid.
+func setupValidationRunService() (service.ValidationRunService, *validationRunMocks) {
+	m := &validationRunMocks{
+		runRepo:       new(mocks.MockValidationRunRepo),
+		docRepo:       new(mocks.MockDocumentRepo),
+		ruleRepo:      new(mocks.MockDocumentValidationRuleRepo),
+		auditRepo:     new(mocks.MockDocumentAuditRepo),
+		summaryRepo:   new(mocks.MockDocumentSummaryRepo),
+		collectionSvc: new(mocks.MockCollectionService),
+	}
+	engine := validator.NewEngine(validator.NewRegistry(), m.ruleRepo, m.docRepo)
+	svc := service.NewValidationRunService(m.runRepo, m.docRepo, engine, m.auditRepo, m.summaryRepo, m.collectionSvc)
+	return svc, m
+}
+
+// expectValidationRun records the run passed to Create and closes done once it finishes.
+func expectValidationRun(m *validationRunMocks, done chan struct{}) **domain.ValidationRun {
+	var run *domain.ValidationRun
+	m.runRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.ValidationRun")).
+		Run(func(args mock.Arguments) { run = args.Get(1).(*domain.ValidationRun) }).Return(nil)
+	m.runRepo.On("UpdateProgress", mock.Anything, mock.AnythingOfType("*domain.ValidationRun")).
+		Run(func(args mock.Arguments) {
+			if args.Get(1).(*domain.ValidationRun).CompletedAt != nil {
+				close(done)
+			}
+		}).Return(nil)
+	return &run
+}
+
+func validationRunDoc(tenantID, collectionID uuid.UUID, status domain.ValidationStatus) *domain.Document {
+	return &domain.Document{
+		ID:                   uuid.New(),
+		TenantID:             tenantID,
+		CollectionID:         collectionID,
+		DocumentType:         "invoice",
+		ParsingStatus:        domain.ParsingStatusCompleted,
+		StructuredData:       json.RawMessage(`{}`),
+		ValidationStatus:     status,
+		ReconciliationStatus: domain.ReconciliationStatus(status),
+	}
+}
+
+func TestValidationRunService_Start_ReportsChangedDocuments(t *testing.T) {
+	svc, m := setupValidationRunService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	changed := validationRunDoc(tenantID, collectionID, domain.ValidationStatusInvalid)
+	unchanged := validationRunDoc(tenantID, collectionID, domain.ValidationStatusValid)
+	missing := uuid.New()
+
+	done := make(chan struct{})
+	run := expectValidationRun(m, done)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleMember).Return(domain.CollectionPermEditor)
+	m.collectionSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.RoleMember).
+		Return(&domain.Collection{ID: collectionID}, nil)
+	m.runRepo.On("ListTargets", mock.Anything, tenantID, collectionID, 10001).Return([]domain.ValidationRunTarget{
+		{DocumentID: changed.ID, Name: "a.pdf", ValidationStatus: changed.ValidationStatus, ReconciliationStatus: changed.ReconciliationStatus},
+		{DocumentID: unchanged.ID, Name: "b.pdf", ValidationStatus: unchanged.ValidationStatus, ReconciliationStatus: unchanged.ReconciliationStatus},
+		{DocumentID: missing, Name: "c.pdf", ValidationStatus: domain.ValidationStatusValid, ReconciliationStatus: domain.ReconciliationStatusValid},
+	}, nil)
+	m.docRepo.On("GetByID", mock.Anything, tenantID, changed.ID).Return(changed, nil)
+	m.docRepo.On("GetByID", mock.Anything, tenantID, unchanged.ID).Return(unchanged, nil)
+	m.docRepo.On("GetByID", mock.Anything, tenantID, missing).Return(nil, domain.ErrDocumentNotFound)
+	m.docRepo.On("UpdateValidationResults", mock.Anything, mock.AnythingOfType("*domain.Document")).Return(nil)
+	m.ruleRepo.On("ListBuiltinKeys", mock.Anything, tenantID, "invoice").Return([]string{}, nil)
+	m.ruleRepo.On("ListByDocumentType", mock.Anything, tenantID, "invoice", &collectionID).
+		Return([]domain.DocumentValidationRule{}, nil)
+	m.auditRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.DocumentAuditEntry")).Return(nil)
+	m.summaryRepo.On("UpdateStatuses", mock.Anything, changed.ID, mock.Anything).Return(nil)
+
+	result, err := svc.Start(context.Background(), tenantID, collectionID, userID, domain.RoleMember)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.ValidationRunStatusPending, result.Status)
+	waitFor(t, done)
+
+	assert.Equal(t, domain.ValidationRunStatusCompleted, (*run).Status)
+	assert.Equal(t, 3, (*run).TotalDocuments)
+	assert.Equal(t, 3, (*run).ProcessedCount)
+	assert.Equal(t, 1, (*run).ChangedCount)
+	assert.Equal(t, 1, (*run).FailedCount)
+
+	var changes []domain.ValidationRunChange
+	require.NoError(t, json.Unmarshal((*run).Changes, &changes))
+	require.Len(t, changes, 1)
+	assert.Equal(t, changed.ID, changes[0].DocumentID)
+	assert.Equal(t, "a.pdf", changes[0].DocumentName)
+	assert.Equal(t, domain.ValidationStatusInvalid, changes[0].PreviousValidationStatus)
+	assert.Equal(t, domain.ValidationStatusValid, changes[0].ValidationStatus)
+	m.auditRepo.AssertNumberOfCalls(t, "Create", 2)
+	m.summaryRepo.AssertNumberOfCalls(t, "UpdateStatuses", 1)
+}
+
+func TestValidationRunService_Start_RequiresEditor(t *testing.T) {
+	svc, m := setupValidationRunService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleViewer).Return(domain.CollectionPermViewer)
+
+	_, err := svc.Start(context.Background(), tenantID, collectionID, userID, domain.RoleViewer)
+
+	assert.ErrorIs(t, err, domain.ErrCollectionPermDenied)
+	m.runRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestValidationRunService_Start_RejectsConcurrentRun(t *testing.T) {
+	svc, m := setupValidationRunService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleAdmin).Return(domain.CollectionPermOwner)
+	m.collectionSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.RoleAdmin).
+		Return(&domain.Collection{ID: collectionID}, nil)
+	m.runRepo.On("Create", mock.Anything, mock.Anything).Return(domain.ErrValidationRunInProgress)
+
+	_, err := svc.Start(context.Background(), tenantID, collectionID, userID, domain.RoleAdmin)
+
+	assert.ErrorIs(t, err, domain.ErrValidationRunInProgress)
+	m.runRepo.AssertNotCalled(t, "ListTargets", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestValidationRunService_Start_ListFailureFailsRun(t *testing.T) {
+	svc, m := setupValidationRunService()
+	tenantID, userID, collectionID := uuid.New(), uuid.New(), uuid.New()
+
+	done := make(chan struct{})
+	run := expectValidationRun(m, done)
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleAdmin).Return(domain.CollectionPermOwner)
+	m.collectionSvc.On("GetByID", mock.Anything, tenantID, collectionID, userID, domain.RoleAdmin).
+		Return(&domain.Collection{ID: collectionID}, nil)
+	m.runRepo.On("ListTargets", mock.Anything, tenantID, collectionID, 10001).Return(nil, errors.New("db down"))
+
+	_, err := svc.Start(context.Background(), tenantID, collectionID, userID, domain.RoleAdmin)
+
+	require.NoError(t, err)
+	waitFor(t, done)
+	assert.Equal(t, domain.ValidationRunStatusFailed, (*run).Status)
+	require.NotNil(t, (*run).Error)
+}
+
+func TestValidationRunService_GetRun_OtherCollection(t *testing.T) {
+	svc, m := setupValidationRunService()
+	tenantID, userID, collectionID, runID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	m.collectionSvc.On("EffectivePermission", mock.Anything, collectionID, userID, domain.RoleMember).Return(domain.CollectionPermViewer)
+	m.runRepo.On("GetByID", mock.Anything, tenantID, runID).
+		Return(&domain.ValidationRun{ID: runID, CollectionID: uuid.New()}, nil)
+
+	_, err := svc.GetRun(context.Background(), tenantID, collectionID, runID, userID, domain.RoleMember)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
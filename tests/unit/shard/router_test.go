@@ -0,0 +1,130 @@
+package shard_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/shard"
+	"satvos/mocks"
+)
+
+func newRouter(t *testing.T, repo *mocks.MockTenantShardRepo) *shard.Router[string] {
+	t.Helper()
+	resolver := shard.NewResolver("blue", []string{"green"}, repo, time.Minute)
+	r, err := shard.NewRouter(resolver, map[string]string{"blue": "blue-pool", "green": "green-pool"})
+	require.NoError(t, err)
+	return r
+}
+
+func TestRouter_For_UnassignedTenantUsesPrimary(t *testing.T) {
+	repo := new(mocks.MockTenantShardRepo)
+	tenantID := uuid.New()
+	repo.On("Resolve", mock.Anything, tenantID).Return("", nil).Once()
+	r := newRouter(t, repo)
+
+	for i := 0; i < 2; i++ {
+		target, err := r.For(context.Background(), tenantID)
+		require.NoError(t, err)
+		assert.Equal(t, "blue-pool", target)
+	}
+	// Second lookup served from cache
+	repo.AssertNumberOfCalls(t, "Resolve", 1)
+}
+
+func TestRouter_For_AssignedTenant(t *testing.T) {
+	repo := new(mocks.MockTenantShardRepo)
+	tenantID := uuid.New()
+	repo.On("Resolve", mock.Anything, tenantID).Return("green", nil)
+	r := newRouter(t, repo)
+
+	target, err := r.For(context.Background(), tenantID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "green-pool", target)
+}
+
+func TestRouter_For_ShardNotConfiguredHere(t *testing.T) {
+	repo := new(mocks.MockTenantShardRepo)
+	tenantID := uuid.New()
+	repo.On("Resolve", mock.Anything, tenantID).Return("red", nil)
+	r := newRouter(t, repo)
+
+	_, err := r.For(context.Background(), tenantID)
+
+	assert.ErrorIs(t, err, domain.ErrShardUnavailable)
+}
+
+func TestRouter_For_ResolveError(t *testing.T) {
+	repo := new(mocks.MockTenantShardRepo)
+	tenantID := uuid.New()
+	repo.On("Resolve", mock.Anything, tenantID).Return("", domain.ErrNotFound)
+	r := newRouter(t, repo)
+
+	_, err := r.For(context.Background(), tenantID)
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestNewRouter_MissingTarget(t *testing.T) {
+	resolver := shard.NewResolver("blue", []string{"green"}, new(mocks.MockTenantShardRepo), time.Minute)
+
+	_, err := shard.NewRouter(resolver, map[string]string{"blue": "blue-pool"})
+
+	assert.Error(t, err)
+}
+
+func TestMap_SharesResolver(t *testing.T) {
+	repo := new(mocks.MockTenantShardRepo)
+	tenantID := uuid.New()
+	repo.On("Resolve", mock.Anything, tenantID).Return("green", nil).Once()
+	pools := newRouter(t, repo)
+	repos := shard.Map(pools, func(pool string) string { return "repo on " + pool })
+
+	_, err := pools.For(context.Background(), tenantID)
+	require.NoError(t, err)
+	target, err := repos.For(context.Background(), tenantID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "repo on green-pool", target)
+	repo.AssertNumberOfCalls(t, "Resolve", 1)
+}
+
+func TestRouter_Each_VisitsShardsInOrder(t *testing.T) {
+	r := newRouter(t, new(mocks.MockTenantShardRepo))
+
+	var visited []string
+	err := r.Each(func(name, target string) error {
+		visited = append(visited, name+"="+target)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blue=blue-pool", "green=green-pool"}, visited)
+}
+
+func TestRouter_Each_StopsAtError(t *testing.T) {
+	r := newRouter(t, new(mocks.MockTenantShardRepo))
+	boom := errors.New("boom")
+
+	calls := 0
+	err := r.Each(func(string, string) error {
+		calls++
+		return boom
+	})
+
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 1, calls)
+}
+
+func TestPrimaryName(t *testing.T) {
+	assert.Equal(t, shard.DefaultPrimary, shard.PrimaryName(""))
+	assert.Equal(t, "blue", shard.PrimaryName("blue"))
+}
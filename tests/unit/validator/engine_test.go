@@ -846,3 +846,40 @@ func TestEngine_GetValidation_WithConfidenceScores(t *testing.T) {
 	assert.Equal(t, domain.FieldStatusUnsure, resp.FieldStatuses["seller.gstin"].Status)
 	assert.Equal(t, domain.FieldStatusValid, resp.FieldStatuses["seller.name"].Status)
 }
+
+// --- Preview ---
+
+func TestEngine_Preview_DoesNotPersist(t *testing.T) {
+	engine, docRepo, ruleRepo := setupEngine()
+	ctx := context.Background()
+	tenantID := uuid.New()
+	userID := uuid.New()
+
+	ruleKey := "req.invoice.number"
+	rules := []domain.DocumentValidationRule{makeRule(uuid.New(), ruleKey, domain.ValidationSeverityError)}
+	ruleRepo.On("ListBuiltinKeys", ctx, tenantID, "invoice").Return(allBuiltinKeys(), nil)
+	ruleRepo.On("ListByDocumentType", ctx, tenantID, "invoice", (*uuid.UUID)(nil)).Return(rules, nil)
+
+	resp, err := engine.Preview(ctx, tenantID, userID, "invoice", validInvoiceJSON(), json.RawMessage("{}"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, resp.DocumentID)
+	assert.Equal(t, domain.ValidationStatusValid, resp.ValidationStatus)
+	assert.Equal(t, 1, resp.Summary.Total)
+	assert.Equal(t, "Test: "+ruleKey, resp.Results[0].RuleName)
+	docRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+	docRepo.AssertNotCalled(t, "UpdateValidationResults", mock.Anything, mock.Anything)
+}
+
+func TestEngine_Preview_InvalidStructuredData(t *testing.T) {
+	engine, _, ruleRepo := setupEngine()
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	ruleRepo.On("ListBuiltinKeys", ctx, tenantID, "invoice").Return(allBuiltinKeys(), nil)
+	ruleRepo.On("ListByDocumentType", ctx, tenantID, "invoice", (*uuid.UUID)(nil)).Return([]domain.DocumentValidationRule{}, nil)
+
+	_, err := engine.Preview(ctx, tenantID, uuid.New(), "invoice", json.RawMessage("not json"), nil)
+
+	assert.Error(t, err)
+}
@@ -25,6 +25,10 @@ func (m *mockDuplicateFinder) FindDuplicates(_ context.Context, _, _ uuid.UUID,
 	return m.matches, m.err
 }
 
+func (m *mockDuplicateFinder) FindCandidates(_ context.Context, _, _ uuid.UUID, _ string, _ uuid.UUID, _ domain.UserRole, _ int) ([]port.DuplicateCandidate, error) {
+	return nil, m.err
+}
+
 // --- Context helper tests ---
 
 func TestValidationContext_RoundTrip(t *testing.T) {
@@ -168,9 +172,9 @@ func TestDuplicateInvoiceValidator_Metadata(t *testing.T) {
 
 // --- Integration checks ---
 
-func TestAllBuiltinValidators_StillReturns56(t *testing.T) {
+func TestAllBuiltinValidators_StillReturns57(t *testing.T) {
 	all := invoice.AllBuiltinValidators()
-	assert.Len(t, all, 56, "Duplicate validator should NOT be in AllBuiltinValidators()")
+	assert.Len(t, all, 57, "Duplicate validator should NOT be in AllBuiltinValidators()")
 }
 
 func TestDuplicateValidator_NoKeyConflict(t *testing.T) {
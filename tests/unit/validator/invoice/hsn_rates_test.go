@@ -0,0 +1,138 @@
+package invoice_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/port"
+	"satvos/internal/validator/invoice"
+)
+
+func rateInvoice(sellerState, buyerState string, items ...invoice.LineItem) *invoice.GSTInvoice {
+	return &invoice.GSTInvoice{
+		Invoice:   invoice.InvoiceHeader{InvoiceDate: "2024-06-15"},
+		Seller:    invoice.Party{StateCode: sellerState},
+		Buyer:     invoice.Party{StateCode: buyerState},
+		LineItems: items,
+	}
+}
+
+func TestApplyHSNRates_IntrastateSplitsCGSTAndSGST(t *testing.T) {
+	inv := rateInvoice("27", "27", invoice.LineItem{HSNSACCode: "1006", TaxableAmount: 1000, CGSTRate: 9, SGSTRate: 9})
+
+	proposals := invoice.ApplyHSNRates(inv, testHSNLookup())
+
+	require.Len(t, proposals, 1)
+	assert.Equal(t, invoice.RateApplied, proposals[0].Status)
+	item := inv.LineItems[0]
+	assert.Equal(t, 2.5, item.CGSTRate)
+	assert.Equal(t, 2.5, item.SGSTRate)
+	assert.Zero(t, item.IGSTRate)
+	assert.Equal(t, 25.0, item.CGSTAmount)
+	assert.Equal(t, 25.0, item.SGSTAmount)
+	assert.Equal(t, 1050.0, item.Total)
+	assert.Equal(t, 1050.0, inv.Totals.Total)
+}
+
+func TestApplyHSNRates_InterstateUsesIGST(t *testing.T) {
+	inv := rateInvoice("27", "Karnataka", invoice.LineItem{HSNSACCode: "100630", TaxableAmount: 200, CGSTRate: 6, SGSTRate: 6})
+
+	proposals := invoice.ApplyHSNRates(inv, testHSNLookup())
+
+	assert.Equal(t, invoice.RateApplied, proposals[0].Status)
+	item := inv.LineItems[0]
+	assert.Zero(t, item.CGSTRate)
+	assert.Zero(t, item.SGSTRate)
+	assert.Equal(t, 5.0, item.IGSTRate)
+	assert.Equal(t, 10.0, item.IGSTAmount)
+	assert.Equal(t, 10.0, inv.Totals.IGST)
+	assert.Zero(t, inv.Totals.CGST)
+}
+
+func TestApplyHSNRates_AmbiguousKeepsRateButRecomputesAmounts(t *testing.T) {
+	// 8471 has 18% and a conditional 12%
+	inv := rateInvoice("27", "27", invoice.LineItem{HSNSACCode: "8471", TaxableAmount: 100, IGSTRate: 5, IGSTAmount: 1})
+
+	proposals := invoice.ApplyHSNRates(inv, testHSNLookup())
+
+	assert.Equal(t, invoice.RateAmbiguous, proposals[0].Status)
+	assert.ElementsMatch(t, []invoice.HSNRateOption{{Rate: 18}, {Rate: 12, ConditionDesc: "used/refurbished"}}, proposals[0].Rates)
+	assert.Equal(t, 5.0, inv.LineItems[0].IGSTRate)
+	assert.Equal(t, 5.0, inv.LineItems[0].IGSTAmount)
+	assert.Equal(t, 105.0, inv.LineItems[0].Total)
+}
+
+func TestApplyHSNRates_MatchingRateUnchanged(t *testing.T) {
+	inv := rateInvoice("27", "27", invoice.LineItem{HSNSACCode: "8471", TaxableAmount: 100, CGSTRate: 6, SGSTRate: 6})
+
+	proposals := invoice.ApplyHSNRates(inv, testHSNLookup())
+
+	assert.Equal(t, invoice.RateUnchanged, proposals[0].Status)
+	assert.Equal(t, 6.0, inv.LineItems[0].CGSTRate)
+	assert.Equal(t, 6.0, inv.LineItems[0].CGSTAmount)
+}
+
+func TestApplyHSNRates_StatesMissing(t *testing.T) {
+	inv := rateInvoice("", "",
+		invoice.LineItem{HSNSACCode: "1006", TaxableAmount: 100, IGSTRate: 12},
+		invoice.LineItem{HSNSACCode: "1006", TaxableAmount: 100},
+	)
+
+	proposals := invoice.ApplyHSNRates(inv, testHSNLookup())
+
+	// The line's current tax type is kept
+	assert.Equal(t, invoice.RateApplied, proposals[0].Status)
+	assert.Equal(t, 5.0, inv.LineItems[0].IGSTRate)
+	assert.Equal(t, invoice.RateTaxTypeUnknown, proposals[1].Status)
+	assert.Zero(t, inv.LineItems[1].IGSTRate)
+	assert.Equal(t, []invoice.HSNRateOption{{Rate: 5}}, proposals[1].Rates)
+}
+
+func TestApplyHSNRates_UnknownAndMissingCodes(t *testing.T) {
+	inv := rateInvoice("27", "27",
+		invoice.LineItem{HSNSACCode: "9999", TaxableAmount: 100, CGSTRate: 9, SGSTRate: 9},
+		invoice.LineItem{TaxableAmount: 50},
+	)
+
+	proposals := invoice.ApplyHSNRates(inv, testHSNLookup())
+
+	assert.Equal(t, invoice.RateNotFound, proposals[0].Status)
+	assert.Empty(t, proposals[0].Rates)
+	assert.Equal(t, invoice.RateMissingCode, proposals[1].Status)
+	assert.Equal(t, 118.0, inv.LineItems[0].Total)
+	assert.Equal(t, 150.0, inv.Totals.Subtotal)
+	assert.Equal(t, 168.0, inv.Totals.Total)
+}
+
+func TestApplyHSNRates_UsesRateInForceOnInvoiceDate(t *testing.T) {
+	changed := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	lapsed := changed.AddDate(0, 0, -1)
+	lookup := invoice.NewHSNLookup([]port.HSNEntry{
+		{Code: "3401", GSTRate: 18, EffectiveTo: &lapsed},
+		{Code: "3401", GSTRate: 5, EffectiveFrom: changed},
+	})
+	before := rateInvoice("27", "29", invoice.LineItem{HSNSACCode: "3401", TaxableAmount: 100})
+	after := rateInvoice("27", "29", invoice.LineItem{HSNSACCode: "3401", TaxableAmount: 100})
+	after.Invoice.InvoiceDate = "2025-10-01"
+
+	invoice.ApplyHSNRates(before, lookup)
+	invoice.ApplyHSNRates(after, lookup)
+
+	assert.Equal(t, 18.0, before.LineItems[0].IGSTRate)
+	assert.Equal(t, 5.0, after.LineItems[0].IGSTRate)
+}
+
+func TestApplyHSNRates_TotalsKeepDiscountCessAndRoundOff(t *testing.T) {
+	inv := rateInvoice("27", "27", invoice.LineItem{HSNSACCode: "1006", TaxableAmount: 1000, CGSTRate: 2.5, SGSTRate: 2.5})
+	inv.Totals = invoice.Totals{TotalDiscount: 100, Cess: 4, RoundOff: 0.5}
+
+	invoice.ApplyHSNRates(inv, testHSNLookup())
+
+	assert.Equal(t, 1000.0, inv.Totals.Subtotal)
+	assert.Equal(t, 900.0, inv.Totals.TaxableAmount)
+	assert.Equal(t, 25.0, inv.Totals.CGST)
+	assert.Equal(t, 954.5, inv.Totals.Total)
+}
@@ -3,6 +3,7 @@ package invoice_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -134,6 +135,41 @@ func TestHSNLookup_RateMatches(t *testing.T) {
 	})
 }
 
+// rateChangeLookup returns a lookup where "6802" moved from 28% to 18% on 2024-10-01.
+func rateChangeLookup() *invoice.HSNLookup {
+	cutover := time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)
+	return invoice.NewHSNLookup([]port.HSNEntry{
+		{Code: "6802", GSTRate: 28, EffectiveFrom: time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC), EffectiveTo: &cutover},
+		{Code: "6802", GSTRate: 18, EffectiveFrom: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)},
+	})
+}
+
+func TestHSNLookup_RatesOn(t *testing.T) {
+	lookup := rateChangeLookup()
+
+	t.Run("before_change", func(t *testing.T) {
+		rates := lookup.RatesOn("6802", time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC))
+		require.Len(t, rates, 1)
+		assert.InDelta(t, 28.0, rates[0].Rate, 0.01)
+	})
+
+	t.Run("after_change", func(t *testing.T) {
+		rates := lookup.RatesOn("6802", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC))
+		require.Len(t, rates, 1)
+		assert.InDelta(t, 18.0, rates[0].Rate, 0.01)
+	})
+
+	t.Run("before_first_rate", func(t *testing.T) {
+		assert.Nil(t, lookup.RatesOn("6802", time.Date(2017, 6, 30, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, lookup.Exists("6802"))
+	})
+
+	t.Run("today_uses_current_rate", func(t *testing.T) {
+		matched, _ := lookup.RateMatches("6802", 18)
+		assert.True(t, matched)
+	})
+}
+
 // --- HSN Exists validator tests ---
 
 func TestHSN_ExistsValidator_Count(t *testing.T) {
@@ -297,12 +333,61 @@ func TestHSN_Rate(t *testing.T) {
 	})
 }
 
+func TestHSN_Rate_UsesRateInForceOnInvoiceDate(t *testing.T) {
+	v := findHSNValidator("xf.line_item.hsn_rate", rateChangeLookup())
+	require.NotNil(t, v)
+	ctx := context.Background()
+
+	preChange := func() *invoice.GSTInvoice {
+		inv := validInvoice()
+		inv.Invoice.InvoiceDate = "15-09-2024"
+		inv.LineItems[0].HSNSACCode = "6802"
+		inv.LineItems[0].CGSTRate = 14
+		inv.LineItems[0].SGSTRate = 14
+		return inv
+	}
+
+	t.Run("pass_old_rate_before_change", func(t *testing.T) {
+		results := v.Validate(ctx, preChange())
+		require.Len(t, results, 1)
+		assert.True(t, results[0].Passed)
+		assert.Equal(t, "28.00%", results[0].ExpectedValue)
+	})
+
+	t.Run("fail_old_rate_after_change", func(t *testing.T) {
+		inv := preChange()
+		inv.Invoice.InvoiceDate = "2024-10-05"
+		results := v.Validate(ctx, inv)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Passed)
+		assert.Equal(t, "18.00%", results[0].ExpectedValue)
+		assert.Contains(t, results[0].Message, "as of 2024-10-05")
+	})
+
+	t.Run("skip_when_no_rate_in_force", func(t *testing.T) {
+		inv := preChange()
+		inv.Invoice.InvoiceDate = "2017-01-10"
+		results := v.Validate(ctx, inv)
+		require.Len(t, results, 1)
+		assert.True(t, results[0].Passed)
+		assert.Contains(t, results[0].Message, "no rate")
+	})
+
+	t.Run("unparseable_date_uses_today", func(t *testing.T) {
+		inv := preChange()
+		inv.Invoice.InvoiceDate = "not-a-date"
+		results := v.Validate(ctx, inv)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Passed)
+	})
+}
+
 // --- Verify HSN validators don't affect existing counts ---
 
 func TestHSNValidators_SeparateFromBuiltin(t *testing.T) {
-	// AllBuiltinValidators() should still return exactly 56
+	// AllBuiltinValidators() should still return exactly 57
 	all := invoice.AllBuiltinValidators()
-	assert.Len(t, all, 56, "HSN validators should not be included in AllBuiltinValidators()")
+	assert.Len(t, all, 57, "HSN validators should not be included in AllBuiltinValidators()")
 }
 
 func TestHSNValidators_UniqueKeys(t *testing.T) {
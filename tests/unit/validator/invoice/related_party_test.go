@@ -0,0 +1,97 @@
+package invoice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator/invoice"
+)
+
+// mockRelatedPartyFinder is a hand-written mock for port.RelatedPartyFinder.
+type mockRelatedPartyFinder struct {
+	parties map[string]domain.RelatedParty
+	err     error
+}
+
+func (m *mockRelatedPartyFinder) FindRelatedParty(_ context.Context, _ uuid.UUID, gstin string) (*domain.RelatedParty, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	p, ok := m.parties[gstin]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &p, nil
+}
+
+func TestRelatedPartyValidator_UnrelatedSeller(t *testing.T) {
+	v := invoice.RelatedPartyValidator(&mockRelatedPartyFinder{})
+	ctx := invoice.WithValidationContext(context.Background(), uuid.New(), uuid.New())
+
+	results := v.Validate(ctx, validInvoice())
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Passed)
+	assert.Equal(t, domain.ValidationSeverityWarning, v.Severity())
+}
+
+func TestRelatedPartyValidator_GroupCompany(t *testing.T) {
+	inv := validInvoice()
+	finder := &mockRelatedPartyFinder{parties: map[string]domain.RelatedParty{
+		inv.Seller.GSTIN: {GSTIN: inv.Seller.GSTIN, Name: "Acme Components", Relationship: domain.RelatedPartyGroup},
+	}}
+	v := invoice.RelatedPartyValidator(finder)
+	ctx := invoice.WithValidationContext(context.Background(), uuid.New(), uuid.New())
+
+	results := v.Validate(ctx, inv)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Passed)
+	assert.Equal(t, "seller.gstin", results[0].FieldPath)
+	assert.Contains(t, results[0].Message, "Acme Components")
+	assert.Contains(t, results[0].Message, "group company")
+}
+
+func TestRelatedPartyValidator_OwnRegistration(t *testing.T) {
+	inv := validInvoice()
+	finder := &mockRelatedPartyFinder{parties: map[string]domain.RelatedParty{
+		inv.Seller.GSTIN: {GSTIN: inv.Seller.GSTIN, Name: "Own branch", Relationship: domain.RelatedPartyOwn},
+	}}
+	v := invoice.RelatedPartyValidator(finder)
+	ctx := invoice.WithValidationContext(context.Background(), uuid.New(), uuid.New())
+
+	results := v.Validate(ctx, inv)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Passed)
+	assert.Contains(t, results[0].Message, "registration of your own")
+}
+
+func TestRelatedPartyValidator_SkipsWithoutGSTINOrContext(t *testing.T) {
+	v := invoice.RelatedPartyValidator(&mockRelatedPartyFinder{err: errors.New("must not be called")})
+
+	inv := validInvoice()
+	results := v.Validate(context.Background(), inv)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Passed)
+	assert.Contains(t, results[0].Message, "context missing")
+
+	inv.Seller.GSTIN = ""
+	results = v.Validate(invoice.WithValidationContext(context.Background(), uuid.New(), uuid.New()), inv)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Passed)
+	assert.Contains(t, results[0].Message, "empty")
+}
+
+func TestRelatedPartyValidator_LookupError(t *testing.T) {
+	v := invoice.RelatedPartyValidator(&mockRelatedPartyFinder{err: errors.New("db down")})
+	ctx := invoice.WithValidationContext(context.Background(), uuid.New(), uuid.New())
+
+	results := v.Validate(ctx, validInvoice())
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Passed)
+	assert.Contains(t, results[0].Message, "unavailable")
+}
@@ -0,0 +1,76 @@
+package invoice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/validator/invoice"
+)
+
+func failedFields(results []invoice.ValidationResult) []string {
+	var fields []string
+	for _, r := range results {
+		if !r.Passed {
+			fields = append(fields, r.FieldPath)
+		}
+	}
+	return fields
+}
+
+func TestLogic_KeyFieldScript(t *testing.T) {
+	v := findLogicalValidator("logic.invoice.script")
+	require.NotNil(t, v)
+	ctx := context.Background()
+
+	t.Run("pass_latin_invoice", func(t *testing.T) {
+		results := v.Validate(ctx, validInvoice())
+		require.Len(t, results, 6)
+		assert.Empty(t, failedFields(results))
+	})
+
+	t.Run("pass_indic_text_with_latin_key_fields", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Seller.Address = "१२३ मुख्य मार्ग, मुंबई"
+		inv.LineItems[0].Description = "सूती कपड़ा"
+		assert.Empty(t, failedFields(v.Validate(ctx, inv)))
+	})
+
+	t.Run("fail_untransliterated_name", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Seller.Name = "ஸ்ரீ டிரேடர்ஸ்"
+		results := v.Validate(ctx, inv)
+		assert.Equal(t, []string{"seller.name"}, failedFields(results))
+		for _, r := range results {
+			if r.FieldPath == "seller.name" {
+				assert.Contains(t, r.Message, "Tamil script")
+				assert.Equal(t, "Latin script", r.ExpectedValue)
+			}
+		}
+	})
+
+	t.Run("fail_empty_field_on_indic_invoice", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Notes = "ধন্যবাদ"
+		inv.Invoice.InvoiceNumber = ""
+		assert.Equal(t, []string{"invoice.invoice_number"}, failedFields(v.Validate(ctx, inv)))
+	})
+
+	t.Run("pass_empty_field_on_latin_invoice", func(t *testing.T) {
+		inv := validInvoice()
+		inv.Buyer.GSTIN = ""
+		assert.Empty(t, failedFields(v.Validate(ctx, inv)))
+	})
+}
+
+func TestInvoiceText(t *testing.T) {
+	inv := validInvoice()
+	inv.Notes = "thank you"
+	text := invoice.InvoiceText(inv)
+	assert.Contains(t, text, inv.Seller.Name)
+	assert.Contains(t, text, inv.Buyer.Address)
+	assert.Contains(t, text, inv.LineItems[0].Description)
+	assert.Contains(t, text, "thank you")
+}
@@ -0,0 +1,78 @@
+package invoice_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/validator/invoice"
+)
+
+func TestLookupState(t *testing.T) {
+	cases := map[string]string{
+		"29":              "29",
+		"9":               "09",
+		"29-Karnataka":    "29",
+		"Karnataka (29)":  "29",
+		"KA":              "29",
+		"karnataka":       "29",
+		"Karnatka":        "29",
+		"Karnatakaa":      "29",
+		"Jammu & Kashmir": "01",
+		"Orissa":          "21",
+		"Andhra Pradesh":  "37",
+		"NEW DELHI":       "07",
+	}
+	for in, want := range cases {
+		st, ok := invoice.LookupState(in)
+		require.True(t, ok, in)
+		assert.Equal(t, want, st.Code, in)
+	}
+
+	for _, in := range []string{"", "99", "290", "XX", "Atlantis", "Goa Beach"} {
+		_, ok := invoice.LookupState(in)
+		assert.False(t, ok, in)
+	}
+}
+
+func TestNormalizeStates(t *testing.T) {
+	t.Run("code_from_abbreviation", func(t *testing.T) {
+		inv := &invoice.GSTInvoice{Seller: invoice.Party{State: "Karnatka", StateCode: "KA"}}
+		invoice.NormalizeStates(inv)
+		assert.Equal(t, "29", inv.Seller.StateCode)
+		assert.Equal(t, "Karnataka", inv.Seller.State)
+	})
+
+	t.Run("code_from_name_when_missing", func(t *testing.T) {
+		inv := &invoice.GSTInvoice{Buyer: invoice.Party{State: "Maharastra"}}
+		invoice.NormalizeStates(inv)
+		assert.Equal(t, "27", inv.Buyer.StateCode)
+	})
+
+	t.Run("conflicting_name_left_alone", func(t *testing.T) {
+		inv := &invoice.GSTInvoice{Seller: invoice.Party{State: "Kerala", StateCode: "29"}}
+		invoice.NormalizeStates(inv)
+		assert.Equal(t, "29", inv.Seller.StateCode)
+		assert.Equal(t, "Kerala", inv.Seller.State)
+	})
+
+	t.Run("unresolvable_untouched", func(t *testing.T) {
+		inv := &invoice.GSTInvoice{Seller: invoice.Party{State: "Unknown", StateCode: "ZZ"}}
+		invoice.NormalizeStates(inv)
+		assert.Equal(t, "ZZ", inv.Seller.StateCode)
+	})
+}
+
+func TestNormalizeStates_FixesGSTINStateCheck(t *testing.T) {
+	v := findCrossFieldValidator("xf.seller.gstin_state")
+	require.NotNil(t, v)
+	inv := validInvoice()
+	inv.Seller.StateCode = "Karnatka"
+
+	invoice.NormalizeStates(inv)
+	results := v.Validate(context.Background(), inv)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Passed)
+}
@@ -0,0 +1,65 @@
+package invoice_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"satvos/internal/validator/invoice"
+)
+
+func totalsInvoice(totals invoice.Totals) *invoice.GSTInvoice {
+	return &invoice.GSTInvoice{
+		LineItems: []invoice.LineItem{
+			{TaxableAmount: 1000, CGSTAmount: 90, SGSTAmount: 90, Total: 1180},
+			{TaxableAmount: 250.5, IGSTAmount: 45.09, Total: 295.59},
+		},
+		Totals: totals,
+	}
+}
+
+func TestRecomputeTotals_FromLineItems(t *testing.T) {
+	inv := totalsInvoice(invoice.Totals{TotalDiscount: 50, Cess: 10, AmountInWords: "kept"})
+
+	got := invoice.RecomputeTotals(inv)
+
+	assert.Equal(t, 1250.5, got.Subtotal)
+	assert.Equal(t, 1200.5, got.TaxableAmount)
+	assert.Equal(t, 90.0, got.CGST)
+	assert.Equal(t, 90.0, got.SGST)
+	assert.Equal(t, 45.09, got.IGST)
+	assert.Equal(t, 1435.59, got.Total, "unrounded invoice stays unrounded")
+	assert.Zero(t, got.RoundOff)
+	assert.Equal(t, 50.0, got.TotalDiscount)
+	assert.Equal(t, "kept", got.AmountInWords)
+}
+
+func TestRecomputeTotals_RoundedInvoiceRoundsToRupee(t *testing.T) {
+	inv := totalsInvoice(invoice.Totals{Total: 1476})
+
+	got := invoice.RecomputeTotals(inv)
+
+	assert.Equal(t, 1476.0, got.Total)
+	assert.Equal(t, 0.41, got.RoundOff)
+}
+
+func TestDiffTotals(t *testing.T) {
+	stored := invoice.Totals{Subtotal: 1250.5, TaxableAmount: 1250.5, CGST: 90, SGST: 90, IGST: 54, Total: 1485}
+	inv := totalsInvoice(stored)
+	recomputed := invoice.RecomputeTotals(inv)
+
+	deltas := invoice.DiffTotals(&stored, &recomputed)
+
+	assert.Equal(t, []invoice.TotalsDelta{
+		{FieldPath: "totals.igst", Stored: 54, Recomputed: 45.09, Delta: -8.91},
+		{FieldPath: "totals.round_off", Stored: 0, Recomputed: 0.41, Delta: 0.41},
+		{FieldPath: "totals.total", Stored: 1485, Recomputed: 1476, Delta: -9},
+	}, deltas)
+}
+
+func TestDiffTotals_Balanced(t *testing.T) {
+	inv := totalsInvoice(invoice.Totals{})
+	recomputed := invoice.RecomputeTotals(inv)
+
+	assert.Empty(t, invoice.DiffTotals(&recomputed, &recomputed))
+}
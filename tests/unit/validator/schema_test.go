@@ -0,0 +1,116 @@
+package validator_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator"
+	"satvos/internal/validator/invoice"
+)
+
+func builtinRegistry() *validator.Registry {
+	registry := validator.NewRegistry()
+	for _, v := range invoice.AllBuiltinValidators() {
+		registry.Register(v)
+	}
+	return registry
+}
+
+func findField(fields []validator.SchemaField, path string) *validator.SchemaField {
+	for i := range fields {
+		if fields[i].Path == path {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func TestBuildSchema_Invoice(t *testing.T) {
+	schema, err := validator.BuildSchema("invoice", builtinRegistry())
+	require.NoError(t, err)
+
+	assert.Equal(t, "invoice", schema.DocumentType)
+	assert.Equal(t, "object", schema.JSONSchema["type"])
+	props := schema.JSONSchema["properties"].(map[string]interface{})
+	seller := props["seller"].(map[string]interface{})
+	gstin := seller["properties"].(map[string]interface{})["gstin"].(map[string]interface{})
+	assert.Equal(t, "string", gstin["type"])
+	assert.Equal(t, invoice.FieldDescriptions["seller.gstin"], gstin["description"])
+	lineItems := props["line_items"].(map[string]interface{})
+	assert.Equal(t, "array", lineItems["type"])
+	item := lineItems["items"].(map[string]interface{})
+	assert.Equal(t, "number", item["properties"].(map[string]interface{})["cgst_rate"].(map[string]interface{})["type"])
+
+	f := findField(schema.Fields, "line_items[].cgst_rate")
+	require.NotNil(t, f)
+	assert.Equal(t, "number", f.Type)
+	f = findField(schema.Fields, "invoice.reverse_charge")
+	require.NotNil(t, f)
+	assert.Equal(t, "boolean", f.Type)
+	assert.Nil(t, findField(schema.Fields, "line_items[]"))
+}
+
+func TestBuildSchema_ReconciliationCritical(t *testing.T) {
+	schema, err := validator.BuildSchema("invoice", builtinRegistry())
+	require.NoError(t, err)
+
+	for _, path := range []string{"seller.gstin", "buyer.gstin", "invoice.invoice_number", "totals.total", "line_items"} {
+		f := findField(schema.Fields, path)
+		require.NotNil(t, f, path)
+		assert.True(t, f.ReconciliationCritical, path)
+	}
+	for _, path := range []string{"buyer.name", "payment.ifsc_code", "line_items[].hsn_sac_code"} {
+		f := findField(schema.Fields, path)
+		require.NotNil(t, f, path)
+		assert.False(t, f.ReconciliationCritical, path)
+	}
+
+	var criticalCount int
+	for _, v := range invoice.AllBuiltinValidators() {
+		if v.ReconciliationCritical() {
+			criticalCount++
+		}
+	}
+	assert.Len(t, schema.ReconciliationCriticalRules, criticalCount)
+	for _, r := range schema.ReconciliationCriticalRules {
+		assert.NotEmpty(t, r.FieldPaths, r.RuleKey)
+		if r.RuleKey == "math.totals.grand_total" {
+			assert.Equal(t, []string{"totals.total"}, r.FieldPaths)
+		}
+	}
+}
+
+// Adding a field to GSTInvoice without describing it should fail here.
+func TestBuildSchema_EveryFieldDescribed(t *testing.T) {
+	schema, err := validator.BuildSchema("invoice", builtinRegistry())
+	require.NoError(t, err)
+
+	paths := make(map[string]bool, len(schema.Fields))
+	for _, f := range schema.Fields {
+		assert.NotEmpty(t, f.Description, "missing description for %s", f.Path)
+		paths[f.Path] = true
+	}
+	for path := range invoice.FieldDescriptions {
+		assert.True(t, paths[path], "description for unknown field %s", path)
+	}
+	// Field count follows the struct: spot-check against reflection of one type
+	partyFields := reflect.TypeOf(invoice.Party{}).NumField()
+	var sellerFields int
+	for _, f := range schema.Fields {
+		if strings.HasPrefix(f.Path, "seller.") {
+			sellerFields++
+		}
+	}
+	assert.Equal(t, partyFields, sellerFields)
+}
+
+func TestBuildSchema_UnknownDocumentType(t *testing.T) {
+	_, err := validator.BuildSchema("purchase_order", builtinRegistry())
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
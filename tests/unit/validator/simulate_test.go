@@ -0,0 +1,107 @@
+package validator_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"satvos/internal/domain"
+	"satvos/internal/validator"
+)
+
+func simulationDoc(name string, status domain.ValidationStatus, data string) domain.Document {
+	return domain.Document{
+		ID:               uuid.New(),
+		Name:             name,
+		CollectionID:     uuid.New(),
+		ValidationStatus: status,
+		StructuredData:   json.RawMessage(data),
+	}
+}
+
+func TestEngine_Simulate_RegexRuleOnLineItems(t *testing.T) {
+	engine, _, _ := setupEngine()
+	docs := []domain.Document{
+		simulationDoc("ok.pdf", domain.ValidationStatusValid, `{"line_items":[{"hsn_sac_code":"998314"}]}`),
+		simulationDoc("bad.pdf", domain.ValidationStatusValid, `{"line_items":[{"hsn_sac_code":"9983"},{"hsn_sac_code":"AB"}]}`),
+		simulationDoc("empty.pdf", domain.ValidationStatusInvalid, `{"line_items":[{"hsn_sac_code":""}]}`),
+	}
+	rule := &validator.ProposedRule{
+		RuleName:   "Six-digit HSN",
+		RuleType:   domain.ValidationRuleRegex,
+		RuleConfig: json.RawMessage(`{"field_path":"line_items[].hsn_sac_code","pattern":"^\\d{6,8}$"}`),
+		Severity:   domain.ValidationSeverityWarning,
+	}
+
+	result, err := engine.Simulate(context.Background(), uuid.New(), "invoice", rule, docs, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.DocumentsEvaluated)
+	assert.Equal(t, 1, result.WouldFail)
+	assert.Equal(t, 1, result.WouldChangeStatus)
+	require.Len(t, result.Samples, 1)
+	assert.Equal(t, "bad.pdf", result.Samples[0].DocumentName)
+	assert.Len(t, result.Samples[0].Failures, 2)
+}
+
+func TestEngine_Simulate_RequiredFieldRule(t *testing.T) {
+	engine, _, _ := setupEngine()
+	docs := []domain.Document{
+		simulationDoc("a.pdf", domain.ValidationStatusInvalid, `{"buyer":{"pan":""}}`),
+		simulationDoc("b.pdf", domain.ValidationStatusValid, `{}`),
+		simulationDoc("c.pdf", domain.ValidationStatusValid, `{"buyer":{"pan":"ABCDE1234F"}}`),
+	}
+	rule := &validator.ProposedRule{
+		RuleType:   domain.ValidationRuleRequired,
+		RuleConfig: json.RawMessage(`{"field_path":"buyer.pan"}`),
+	}
+
+	result, err := engine.Simulate(context.Background(), uuid.New(), "invoice", rule, docs, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.WouldFail)
+	// a.pdf is already invalid; only b.pdf would get worse
+	assert.Equal(t, 1, result.WouldChangeStatus)
+	assert.Len(t, result.Samples, 1, "samples are capped")
+	assert.Equal(t, "buyer.pan", result.Samples[0].Failures[0].FieldPath)
+}
+
+func TestEngine_Simulate_BuiltinRule(t *testing.T) {
+	engine, _, _ := setupEngine()
+	docs := []domain.Document{
+		simulationDoc("a.pdf", domain.ValidationStatusValid, string(validInvoiceJSON())),
+		simulationDoc("b.pdf", domain.ValidationStatusValid, `{"invoice":{"invoice_number":""}}`),
+	}
+
+	result, err := engine.Simulate(context.Background(), uuid.New(), "invoice",
+		&validator.ProposedRule{BuiltinRuleKey: "req.invoice.number"}, docs, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.WouldFail)
+	assert.Equal(t, "b.pdf", result.Samples[0].DocumentName)
+}
+
+func TestEngine_Simulate_InvalidRules(t *testing.T) {
+	engine, _, _ := setupEngine()
+	tests := []struct {
+		name string
+		rule validator.ProposedRule
+	}{
+		{"unknown builtin", validator.ProposedRule{BuiltinRuleKey: "nope"}},
+		{"unknown field", validator.ProposedRule{RuleType: domain.ValidationRuleRequired, RuleConfig: json.RawMessage(`{"field_path":"seller.nope"}`)}},
+		{"non-string field", validator.ProposedRule{RuleType: domain.ValidationRuleRequired, RuleConfig: json.RawMessage(`{"field_path":"totals.total"}`)}},
+		{"bad pattern", validator.ProposedRule{RuleType: domain.ValidationRuleRegex, RuleConfig: json.RawMessage(`{"field_path":"seller.pan","pattern":"("}`)}},
+		{"unsupported type", validator.ProposedRule{RuleType: domain.ValidationRuleSumCheck, RuleConfig: json.RawMessage(`{"field_path":"seller.pan"}`)}},
+		{"bad severity", validator.ProposedRule{RuleType: domain.ValidationRuleRequired, RuleConfig: json.RawMessage(`{"field_path":"seller.pan"}`), Severity: "fatal"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := engine.Simulate(context.Background(), uuid.New(), "invoice", &tt.rule, nil, 10)
+			assert.ErrorIs(t, err, domain.ErrInvalidProposedRule)
+		})
+	}
+}